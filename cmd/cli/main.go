@@ -0,0 +1,23 @@
+// Package main provides goforms-cli, kept for scripts written against it. It
+// runs the goforms command line with the same commands; see package command.
+// Without a command it prints help instead of starting the server.
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/goformx/goforms/internal/command"
+)
+
+func main() {
+	args := os.Args[1:]
+	if len(args) == 0 {
+		args = []string{"--help"}
+	}
+
+	if err := command.Execute(args, os.Stdout, os.Stderr); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,205 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"go/ast"
+	"go/token"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+
+	"golang.org/x/tools/go/callgraph/rta"
+	"golang.org/x/tools/go/packages"
+	"golang.org/x/tools/go/ssa"
+	"golang.org/x/tools/go/ssa/ssautil"
+)
+
+// Options selects the roots and the reported functions
+type Options struct {
+	// Tests adds the test executables as roots and loads test files
+	Tests bool
+	// Generated reports functions declared in generated files
+	Generated bool
+	// Filter matches the import paths of reported packages; nil means the main module
+	Filter *regexp.Regexp
+}
+
+// Finding is a function no root reaches, or a config key or field nothing uses
+type Finding struct {
+	// Rule identifies the kind of finding
+	Rule string `json:"rule"`
+	// Key names the finding independently of its position, for baselines
+	Key     string `json:"key"`
+	Message string `json:"message"`
+	Package string `json:"package"`
+	// Function is the unreachable function, relative to its package
+	Function string `json:"function,omitempty"`
+	// File is relative to the module root
+	File   string `json:"file"`
+	Line   int    `json:"line"`
+	Column int    `json:"column"`
+}
+
+// Result is the outcome of an analysis
+type Result struct {
+	// Root is the main module's directory
+	Root     string
+	Findings []Finding
+}
+
+// Analyze loads the packages matching patterns from dir with their
+// dependencies, computes what the main packages reach and returns the
+// unreachable functions sorted by file and line
+func Analyze(dir string, patterns []string, opts Options) (*Result, error) {
+	cfg := &packages.Config{
+		Mode:  packages.LoadAllSyntax | packages.NeedModule,
+		Dir:   dir,
+		Tests: opts.Tests,
+	}
+
+	initial, err := packages.Load(cfg, patterns...)
+	if err != nil {
+		return nil, fmt.Errorf("load packages: %w", err)
+	}
+
+	if packages.PrintErrors(initial) > 0 {
+		return nil, errors.New("packages contain errors")
+	}
+
+	root, filter, err := mainModule(initial, opts.Filter)
+	if err != nil {
+		return nil, err
+	}
+
+	prog, pkgs := ssautil.AllPackages(initial, ssa.InstantiateGenerics)
+	prog.Build()
+
+	var roots []*ssa.Function
+
+	for _, main := range ssautil.MainPackages(pkgs) {
+		roots = append(roots, main.Func("init"), main.Func("main"))
+	}
+
+	if len(roots) == 0 {
+		return nil, errors.New("no main packages to start from")
+	}
+
+	// With tests a package is loaded once more for its test executable, so
+	// functions are matched by position: reaching either copy counts
+	reachable := make(map[token.Position]bool)
+
+	for fn := range rta.Analyze(roots, false).Reachable {
+		if fn.Origin() != nil {
+			fn = fn.Origin()
+		}
+
+		reachable[prog.Fset.Position(fn.Pos())] = true
+	}
+
+	generated := generatedFiles(initial)
+	seen := make(map[string]bool)
+
+	var findings []Finding
+
+	for fn := range ssautil.AllFunctions(prog) {
+		if !reportable(fn) || !filter.MatchString(fn.Pkg.Pkg.Path()) {
+			continue
+		}
+
+		pos := prog.Fset.Position(fn.Pos())
+		if reachable[pos] || (!opts.Generated && generated[pos.Filename]) {
+			continue
+		}
+
+		file, relErr := filepath.Rel(root, pos.Filename)
+		if relErr != nil {
+			file = pos.Filename
+		}
+
+		name := fn.RelString(fn.Pkg.Pkg)
+		key := fn.Pkg.Pkg.Path() + "." + name
+
+		if seen[key] {
+			continue
+		}
+
+		seen[key] = true
+
+		findings = append(findings, Finding{
+			Rule:     ruleUnreachable,
+			Key:      key,
+			Message:  "unreachable func: " + name,
+			Package:  fn.Pkg.Pkg.Path(),
+			Function: name,
+			File:     filepath.ToSlash(file),
+			Line:     pos.Line,
+			Column:   pos.Column,
+		})
+	}
+
+	sortFindings(findings)
+
+	return &Result{Root: root, Findings: findings}, nil
+}
+
+// sortFindings sorts findings by file and position
+func sortFindings(findings []Finding) {
+	slices.SortFunc(findings, func(a, b Finding) int {
+		if c := strings.Compare(a.File, b.File); c != 0 {
+			return c
+		}
+
+		if a.Line != b.Line {
+			return a.Line - b.Line
+		}
+
+		return a.Column - b.Column
+	})
+}
+
+// mainModule returns the main module's directory and the filter of reported
+// packages, which defaults to the module's import path
+func mainModule(initial []*packages.Package, filter *regexp.Regexp) (string, *regexp.Regexp, error) {
+	for _, pkg := range initial {
+		if pkg.Module == nil || !pkg.Module.Main {
+			continue
+		}
+
+		if filter == nil {
+			filter = regexp.MustCompile("^" + regexp.QuoteMeta(pkg.Module.Path) + "(/|$)")
+		}
+
+		return pkg.Module.Dir, filter, nil
+	}
+
+	return "", nil, errors.New("no packages of the main module matched")
+}
+
+// reportable reports whether fn is a function declared in source, other than
+// closures, package initializers and instantiations of generic functions
+func reportable(fn *ssa.Function) bool {
+	return fn.Pkg != nil &&
+		fn.Synthetic == "" &&
+		fn.Parent() == nil &&
+		fn.Origin() == nil &&
+		fn.Pos().IsValid() &&
+		!strings.HasPrefix(fn.Name(), "init#") &&
+		fn.Name() != "init"
+}
+
+// generatedFiles returns the names of the loaded files marked as generated
+func generatedFiles(initial []*packages.Package) map[string]bool {
+	generated := make(map[string]bool)
+
+	for _, pkg := range initial {
+		for _, file := range pkg.Syntax {
+			if ast.IsGenerated(file) {
+				generated[pkg.Fset.Position(file.Pos()).Filename] = true
+			}
+		}
+	}
+
+	return generated
+}
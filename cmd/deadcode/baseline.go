@@ -0,0 +1,99 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"slices"
+	"strings"
+)
+
+// baselineHeader starts every written baseline file
+const baselineHeader = `# Known findings, suppressed by deadcode -baseline.
+# Remove entries as the code is deleted or put to use; regenerate with
+# deadcode -write-baseline and the flags the file is checked with.
+`
+
+// Baseline is the set of suppressed finding keys
+type Baseline map[string]bool
+
+// ReadBaseline reads a baseline file: one key per line, with blank lines and
+// # comments ignored. A missing file is an empty baseline.
+func ReadBaseline(path string) (Baseline, error) {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return Baseline{}, nil
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("read baseline: %w", err)
+	}
+
+	baseline := Baseline{}
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+
+	for scanner.Scan() {
+		key := strings.TrimSpace(scanner.Text())
+		if key != "" && !strings.HasPrefix(key, "#") {
+			baseline[key] = true
+		}
+	}
+
+	if err = scanner.Err(); err != nil {
+		return nil, fmt.Errorf("read baseline: %w", err)
+	}
+
+	return baseline, nil
+}
+
+// WriteBaseline writes the keys of findings to path, sorted
+func WriteBaseline(path string, findings []Finding) error {
+	keys := make([]string, 0, len(findings))
+	for _, finding := range findings {
+		keys = append(keys, finding.Key)
+	}
+
+	slices.Sort(keys)
+
+	var out strings.Builder
+
+	out.WriteString(baselineHeader)
+
+	for _, key := range slices.Compact(keys) {
+		out.WriteString(key)
+		out.WriteByte('\n')
+	}
+
+	if err := os.WriteFile(path, []byte(out.String()), 0o600); err != nil {
+		return fmt.Errorf("write baseline: %w", err)
+	}
+
+	return nil
+}
+
+// Apply returns the findings not in the baseline, and the baseline keys that
+// matched no finding, sorted
+func (b Baseline) Apply(findings []Finding) (fresh []Finding, stale []string) {
+	found := make(map[string]bool, len(findings))
+
+	for _, finding := range findings {
+		found[finding.Key] = true
+
+		if !b[finding.Key] {
+			fresh = append(fresh, finding)
+		}
+	}
+
+	for key := range b {
+		if !found[key] {
+			stale = append(stale, key)
+		}
+	}
+
+	slices.Sort(stale)
+
+	return fresh, stale
+}
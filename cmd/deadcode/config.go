@@ -0,0 +1,643 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"go/ast"
+	"go/constant"
+	"go/token"
+	"go/types"
+	"maps"
+	"path/filepath"
+	"reflect"
+	"regexp"
+	"slices"
+	"strings"
+
+	"golang.org/x/tools/go/packages"
+)
+
+// viperPackage is the name of the package whose calls define and read keys
+const viperPackage = "viper"
+
+// Viper methods and functions that define a key, by name
+var definingCalls = map[string]bool{
+	"SetDefault": true,
+	"BindEnv":    true,
+}
+
+// Viper methods and functions that read a key or the section under it, by
+// name: every Get variant, IsSet, InConfig, Sub and UnmarshalKey
+func readingCall(name string) bool {
+	switch name {
+	case "IsSet", "InConfig", "Sub", "UnmarshalKey":
+		return true
+	}
+
+	return strings.HasPrefix(name, "Get")
+}
+
+// wildcard stands for the non-constant parts of a computed key
+const wildcard = "*"
+
+// keyUse is a key passed to a Viper call. A key built by concatenating
+// constants and variables, such as "security.events." + name + ".window", is
+// kept as a pattern with wildcards for the variables.
+type keyUse struct {
+	key string
+	pos token.Position
+	// pattern matches the keys a computed key may be, and keys under them
+	pattern *regexp.Regexp
+}
+
+// AnalyzeConfig cross-references the Viper keys and the configuration structs
+// of the package configPath with their uses in the packages matching patterns.
+// It reports keys that have a default or environment binding but are never
+// read, keys read without either, and exported fields of the package's structs
+// that no other package reads. A key counts as read when it, or a section
+// containing it, is read.
+func AnalyzeConfig(dir string, patterns []string, configPath string, opts Options) (*Result, error) {
+	cfg := &packages.Config{
+		Mode: packages.NeedName | packages.NeedFiles | packages.NeedSyntax |
+			packages.NeedImports | packages.NeedDeps | packages.NeedTypes | packages.NeedTypesInfo | packages.NeedModule,
+		Dir:   dir,
+		Tests: opts.Tests,
+	}
+
+	initial, err := packages.Load(cfg, append(patterns, configPath)...)
+	if err != nil {
+		return nil, fmt.Errorf("load packages: %w", err)
+	}
+
+	if packages.PrintErrors(initial) > 0 {
+		return nil, errors.New("packages contain errors")
+	}
+
+	root, _, err := mainModule(initial, opts.Filter)
+	if err != nil {
+		return nil, err
+	}
+
+	configPkg := findPackage(initial, configPath)
+	if configPkg == nil {
+		return nil, fmt.Errorf("config package %s not loaded", configPath)
+	}
+
+	generated := generatedFiles(initial)
+	keys := collectKeys(initial)
+	reads := fieldReads(initial, configPkg.Types)
+
+	relative := func(pos token.Position) (string, int, int) {
+		file, relErr := filepath.Rel(root, pos.Filename)
+		if relErr != nil {
+			file = pos.Filename
+		}
+
+		return filepath.ToSlash(file), pos.Line, pos.Column
+	}
+
+	var findings []Finding
+
+	addKeyFindings := func(uses, against map[string]keyUse, rule, message string) {
+		for key, use := range uses {
+			if keyMatches(use, against) {
+				continue
+			}
+
+			file, line, column := relative(use.pos)
+			findings = append(findings, Finding{
+				Rule:    rule,
+				Key:     "config:" + rule + ":" + key,
+				Message: fmt.Sprintf(message, key),
+				Package: configPkg.PkgPath,
+				File:    file,
+				Line:    line,
+				Column:  column,
+			})
+		}
+	}
+
+	read := maps.Clone(keys.read)
+	maps.Copy(read, keys.decoded)
+
+	addKeyFindings(keys.defined, read, ruleUnusedDefault, "config key %s has a default but is never read")
+	addKeyFindings(keys.read, keys.defined, ruleUndefinedKey, "config key %s is read but has no default")
+
+	for _, field := range unreadFields(configFields(configPkg.Types), reads) {
+		pos := configPkg.Fset.Position(field.Pos())
+		if !opts.Generated && generated[pos.Filename] {
+			continue
+		}
+
+		file, line, column := relative(pos)
+		findings = append(findings, Finding{
+			Rule:    ruleUnreadField,
+			Key:     "config:" + ruleUnreadField + ":" + field.name,
+			Message: "config field " + field.name + " is never read outside " + configPkg.Name,
+			Package: configPkg.PkgPath,
+			File:    file,
+			Line:    line,
+			Column:  column,
+		})
+	}
+
+	sortFindings(findings)
+
+	return &Result{Root: root, Findings: findings}, nil
+}
+
+// findPackage returns the loaded package with the import path or directory
+// path, which may be relative to the module root, such as ./internal/config
+func findPackage(initial []*packages.Package, path string) *packages.Package {
+	for _, pkg := range initial {
+		// Skip the variants compiled for tests
+		if pkg.ID != pkg.PkgPath {
+			continue
+		}
+
+		if pkg.PkgPath == path {
+			return pkg
+		}
+
+		if pkg.Module != nil && pkg.Module.Main &&
+			pkg.PkgPath == pkg.Module.Path+"/"+strings.TrimPrefix(filepath.ToSlash(filepath.Clean(path)), "./") {
+			return pkg
+		}
+	}
+
+	return nil
+}
+
+// viperKeys are the keys defined and read through Viper, with the first use of each
+type viperKeys struct {
+	defined map[string]keyUse
+	read    map[string]keyUse
+	// decoded are the keys Unmarshal decodes into the fields of its target;
+	// they are read, but a field without a default simply keeps its zero value
+	decoded map[string]keyUse
+}
+
+// collectKeys finds the constant keys passed to Viper calls. A key read from a
+// struct field, as in a loop over a table of bindings, stands for the constants
+// the field is set to in composite literals. Keys are compared lower-cased, as
+// Viper does.
+func collectKeys(initial []*packages.Package) viperKeys {
+	keys := viperKeys{
+		defined: make(map[string]keyUse),
+		read:    make(map[string]keyUse),
+		decoded: make(map[string]keyUse),
+	}
+
+	// keyFields maps the struct fields passed as keys to the calls' names
+	keyFields := make(map[*types.Var]string)
+
+	record := func(name string, use keyUse) {
+		var into map[string]keyUse
+
+		switch {
+		case definingCalls[name]:
+			into = keys.defined
+		case readingCall(name):
+			into = keys.read
+		default:
+			return
+		}
+
+		if _, ok := into[use.key]; !ok {
+			into[use.key] = use
+		}
+	}
+
+	for _, pkg := range initial {
+		for _, file := range pkg.Syntax {
+			ast.Inspect(file, func(n ast.Node) bool {
+				call, ok := n.(*ast.CallExpr)
+				if !ok || len(call.Args) == 0 {
+					return true
+				}
+
+				name, ok := viperCall(pkg.TypesInfo, call)
+				if !ok {
+					return true
+				}
+
+				if name == "Unmarshal" {
+					pos := pkg.Fset.Position(call.Pos())
+
+					for _, key := range structKeys(pkg.TypesInfo.TypeOf(call.Args[0]), "") {
+						if _, ok := keys.decoded[key]; !ok {
+							keys.decoded[key] = keyUse{key: key, pos: pos}
+						}
+					}
+
+					return true
+				}
+
+				if field := keyField(pkg.TypesInfo, keyArg(call)); field != nil {
+					keyFields[field] = name
+
+					return true
+				}
+
+				parts, ok := keyParts(pkg.TypesInfo, keyArg(call))
+				if !ok {
+					return true
+				}
+
+				record(name, newKeyUse(parts, pkg.Fset.Position(call.Pos())))
+
+				return true
+			})
+		}
+	}
+
+	if len(keyFields) == 0 {
+		return keys
+	}
+
+	for _, pkg := range initial {
+		for _, file := range pkg.Syntax {
+			ast.Inspect(file, func(n ast.Node) bool {
+				pair, ok := n.(*ast.KeyValueExpr)
+				if !ok {
+					return true
+				}
+
+				ident, ok := pair.Key.(*ast.Ident)
+				if !ok {
+					return true
+				}
+
+				field, ok := pkg.TypesInfo.Uses[ident].(*types.Var)
+				if !ok || keyFields[field] == "" {
+					return true
+				}
+
+				if parts, ok := keyParts(pkg.TypesInfo, pair.Value); ok {
+					record(keyFields[field], newKeyUse(parts, pkg.Fset.Position(pair.Pos())))
+				}
+
+				return true
+			})
+		}
+	}
+
+	return keys
+}
+
+// keyArg returns the key argument of a Viper call. For a call spreading
+// append([]string{key, ...}, more...)..., as variadic calls such as BindEnv
+// may, it is the literal's first element.
+func keyArg(call *ast.CallExpr) ast.Expr {
+	arg := call.Args[0]
+	if !call.Ellipsis.IsValid() {
+		return arg
+	}
+
+	inner, ok := ast.Unparen(arg).(*ast.CallExpr)
+	if !ok || len(inner.Args) == 0 {
+		return arg
+	}
+
+	if fn, ok := ast.Unparen(inner.Fun).(*ast.Ident); !ok || fn.Name != "append" {
+		return arg
+	}
+
+	literal, ok := ast.Unparen(inner.Args[0]).(*ast.CompositeLit)
+	if !ok || len(literal.Elts) == 0 {
+		return arg
+	}
+
+	return literal.Elts[0]
+}
+
+// structKeys returns the keys Unmarshal decodes into a value of typ: the
+// fields' mapstructure tags, or lower-cased names, joined by dots. Squashed
+// fields add their fields to the parent's; lists and maps are sections.
+func structKeys(typ types.Type, prefix string) []string {
+	if pointer, ok := typ.(*types.Pointer); ok {
+		typ = pointer.Elem()
+	}
+
+	st, ok := typ.Underlying().(*types.Struct)
+	if !ok {
+		return nil
+	}
+
+	var keys []string
+
+	for i := range st.NumFields() {
+		field := st.Field(i)
+		if !field.Exported() {
+			continue
+		}
+
+		name, options, _ := strings.Cut(reflect.StructTag(st.Tag(i)).Get("mapstructure"), ",")
+
+		switch {
+		case name == "-":
+			continue
+		case options == "squash":
+			keys = append(keys, structKeys(field.Type(), prefix)...)
+
+			continue
+		case name == "":
+			name = strings.ToLower(field.Name())
+		}
+
+		if _, nested := field.Type().Underlying().(*types.Struct); nested {
+			keys = append(keys, structKeys(field.Type(), prefix+name+".")...)
+		} else {
+			keys = append(keys, prefix+name)
+		}
+	}
+
+	return keys
+}
+
+// keyField returns the struct field a key expression reads, or nil
+func keyField(info *types.Info, expr ast.Expr) *types.Var {
+	selector, ok := ast.Unparen(expr).(*ast.SelectorExpr)
+	if !ok {
+		return nil
+	}
+
+	field, ok := info.Uses[selector.Sel].(*types.Var)
+	if !ok || !field.IsField() {
+		return nil
+	}
+
+	return field
+}
+
+// viperCall returns the name of the Viper method or function a call invokes
+func viperCall(info *types.Info, call *ast.CallExpr) (string, bool) {
+	selector, ok := call.Fun.(*ast.SelectorExpr)
+	if !ok {
+		return "", false
+	}
+
+	fn, ok := info.Uses[selector.Sel].(*types.Func)
+	if !ok || fn.Pkg() == nil || fn.Pkg().Name() != viperPackage {
+		return "", false
+	}
+
+	return fn.Name(), true
+}
+
+// keyParts returns the parts of a key expression: constant strings, and
+// wildcards for the operands of a concatenation that are not constant. It
+// fails for keys without any constant part.
+func keyParts(info *types.Info, expr ast.Expr) ([]string, bool) {
+	if value := info.Types[expr].Value; value != nil {
+		if value.Kind() != constant.String {
+			return nil, false
+		}
+
+		return []string{strings.ToLower(constant.StringVal(value))}, true
+	}
+
+	binary, ok := ast.Unparen(expr).(*ast.BinaryExpr)
+	if !ok || binary.Op != token.ADD {
+		return []string{wildcard}, false
+	}
+
+	left, leftOK := keyParts(info, binary.X)
+	right, rightOK := keyParts(info, binary.Y)
+
+	return append(left, right...), leftOK || rightOK
+}
+
+// newKeyUse creates the use of a key made of parts
+func newKeyUse(parts []string, pos token.Position) keyUse {
+	use := keyUse{key: strings.Join(parts, ""), pos: pos}
+
+	if !slices.Contains(parts, wildcard) {
+		return use
+	}
+
+	var pattern strings.Builder
+
+	pattern.WriteString("^")
+
+	for _, part := range parts {
+		if part == wildcard {
+			pattern.WriteString(".+")
+		} else {
+			pattern.WriteString(regexp.QuoteMeta(part))
+		}
+	}
+
+	pattern.WriteString(`(\..+)?$`)
+	use.pattern = regexp.MustCompile(pattern.String())
+
+	return use
+}
+
+// prefix returns the constant start of a key
+func (u keyUse) prefix() string {
+	prefix, _, _ := strings.Cut(u.key, wildcard)
+
+	return prefix
+}
+
+// related reports whether two keys are the same, or one is a section containing the other
+func (u keyUse) related(other keyUse) bool {
+	switch {
+	case u.pattern == nil && other.pattern == nil:
+		return u.key == other.key ||
+			strings.HasPrefix(u.key, other.key+".") || strings.HasPrefix(other.key, u.key+".")
+	case u.pattern != nil && other.pattern != nil:
+		return strings.HasPrefix(u.prefix(), other.prefix()) || strings.HasPrefix(other.prefix(), u.prefix())
+	case u.pattern != nil:
+		return u.pattern.MatchString(other.key) || strings.HasPrefix(u.prefix(), other.key+".")
+	default:
+		return other.related(u)
+	}
+}
+
+// keyMatches reports whether a key related to use is in keys
+func keyMatches(use keyUse, keys map[string]keyUse) bool {
+	if _, ok := keys[use.key]; ok {
+		return true
+	}
+
+	for _, other := range keys {
+		if use.related(other) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// rootConfigType names the struct whose fields, recursively, are checked
+const rootConfigType = "Config"
+
+// configField is an exported field of a configuration struct
+type configField struct {
+	*types.Var
+	// name is the struct type and field, such as AppConfig.Port
+	name string
+	// owner is the struct declaring the field
+	owner *types.Named
+	// section is the field's struct type when it holds a nested section
+	section *types.Named
+}
+
+// configFields returns the exported fields of the package's Config struct and
+// of the structs it nests, or of all its named structs when it has no Config
+func configFields(pkg *types.Package) []configField {
+	var fields []configField
+
+	seen := make(map[*types.Named]bool)
+
+	var visit func(named *types.Named)
+
+	visit = func(named *types.Named) {
+		structType, ok := named.Underlying().(*types.Struct)
+		if !ok || seen[named] || named.Obj().Pkg() != pkg {
+			return
+		}
+
+		seen[named] = true
+
+		for i := range structType.NumFields() {
+			field := structType.Field(i)
+			if !field.Exported() || field.Embedded() {
+				continue
+			}
+
+			section := sectionType(field.Type())
+			fields = append(fields, configField{
+				Var:     field,
+				name:    named.Obj().Name() + "." + field.Name(),
+				owner:   named,
+				section: section,
+			})
+
+			if section != nil {
+				visit(section)
+			}
+		}
+	}
+
+	if root, ok := pkg.Scope().Lookup(rootConfigType).(*types.TypeName); ok {
+		if named, isNamed := root.Type().(*types.Named); isNamed {
+			visit(named)
+
+			return fields
+		}
+	}
+
+	for _, name := range pkg.Scope().Names() {
+		if typeName, ok := pkg.Scope().Lookup(name).(*types.TypeName); ok && typeName.Exported() && !typeName.IsAlias() {
+			if named, isNamed := typeName.Type().(*types.Named); isNamed {
+				visit(named)
+			}
+		}
+	}
+
+	return fields
+}
+
+// sectionType returns the named struct a field holds directly, by pointer or
+// as the elements of a slice or map
+func sectionType(typ types.Type) *types.Named {
+	for {
+		switch t := typ.(type) {
+		case *types.Pointer:
+			typ = t.Elem()
+		case *types.Slice:
+			typ = t.Elem()
+		case *types.Map:
+			typ = t.Elem()
+		case *types.Named:
+			if _, ok := t.Underlying().(*types.Struct); ok {
+				return t
+			}
+
+			return nil
+		default:
+			return nil
+		}
+	}
+}
+
+// unreadFields returns the fields neither read by other packages nor holding
+// a section with a read field
+func unreadFields(fields []configField, reads map[*types.Var]bool) []configField {
+	used := make(map[*types.Named]bool)
+
+	// A section is used when any of its fields is read; repeat until nested
+	// sections settle
+	for changed := true; changed; {
+		changed = false
+
+		for _, field := range fields {
+			if field.section != nil && used[field.section] {
+				reads[field.Var] = true
+			}
+
+			if !reads[field.Var] {
+				continue
+			}
+
+			if !used[field.owner] {
+				used[field.owner] = true
+				changed = true
+			}
+		}
+	}
+
+	var unread []configField
+
+	for _, field := range fields {
+		if !reads[field.Var] {
+			unread = append(unread, field)
+		}
+	}
+
+	return unread
+}
+
+// fieldReads returns the fields of configPkg's structs read by other
+// packages. Selecting a field counts as a read unless it is the target of an
+// assignment.
+func fieldReads(initial []*packages.Package, configPkg *types.Package) map[*types.Var]bool {
+	reads := make(map[*types.Var]bool)
+
+	for _, pkg := range initial {
+		if pkg.Types == configPkg || strings.TrimSuffix(pkg.PkgPath, "_test") == configPkg.Path() {
+			continue
+		}
+
+		for _, file := range pkg.Syntax {
+			written := make(map[*ast.SelectorExpr]bool)
+
+			ast.Inspect(file, func(n ast.Node) bool {
+				switch node := n.(type) {
+				case *ast.AssignStmt:
+					for _, lhs := range node.Lhs {
+						if selector, ok := ast.Unparen(lhs).(*ast.SelectorExpr); ok {
+							written[selector] = true
+						}
+					}
+				case *ast.IncDecStmt:
+					if selector, ok := ast.Unparen(node.X).(*ast.SelectorExpr); ok {
+						written[selector] = true
+					}
+				case *ast.SelectorExpr:
+					field, ok := pkg.TypesInfo.Uses[node.Sel].(*types.Var)
+					if ok && field.IsField() && field.Pkg() == configPkg && !written[node] {
+						reads[field] = true
+					}
+				}
+
+				return true
+			})
+		}
+	}
+
+	return reads
+}
@@ -0,0 +1,284 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fixture is a module without imports, so only its own packages are loaded
+var fixture = map[string]string{
+	"go.mod": "module example.com/fixture\n\ngo 1.25\n",
+	"main.go": `package main
+
+import "example.com/fixture/lib"
+
+type greeter interface{ Greet() string }
+
+func main() {
+	var g greeter = lib.English{}
+	_ = g.Greet()
+
+	register(lib.Provide)
+	_ = lib.Max(1, 2)
+}
+
+func register(fn func() int) { _ = fn() }
+
+func unusedHelper() {}
+`,
+	"lib/lib.go": `package lib
+
+type English struct{}
+
+func (English) Greet() string { return "hello" }
+
+type French struct{}
+
+func (French) Greet() string { return "bonjour" }
+
+func Provide() int { return 1 }
+
+func Max[T int | float64](a, b T) T {
+	if a > b {
+		return a
+	}
+
+	return b
+}
+
+func Dead() { deadHelper() }
+
+func deadHelper() {}
+`,
+	"lib/zz_generated.go": `// Code generated by fixturegen. DO NOT EDIT.
+
+package lib
+
+func GeneratedDead() {}
+`,
+}
+
+// writeFixture writes the fixture module and returns its directory
+func writeFixture(t *testing.T) string {
+	t.Helper()
+
+	dir := t.TempDir()
+
+	for name, content := range fixture {
+		path := filepath.Join(dir, name)
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o755))
+		require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+	}
+
+	return dir
+}
+
+// keys returns the keys of findings
+func keys(findings []Finding) []string {
+	out := make([]string, 0, len(findings))
+	for _, f := range findings {
+		out = append(out, f.Key)
+	}
+
+	return out
+}
+
+func TestAnalyze(t *testing.T) {
+	dir := writeFixture(t)
+
+	result, err := Analyze(dir, []string{"./..."}, Options{})
+	require.NoError(t, err)
+
+	assert.ElementsMatch(t, []string{
+		"example.com/fixture/lib.(French).Greet",
+		"example.com/fixture/lib.Dead",
+		"example.com/fixture/lib.deadHelper",
+		"example.com/fixture.unusedHelper",
+	}, keys(result.Findings))
+
+	for _, f := range result.Findings {
+		if f.Key == "example.com/fixture/lib.Dead" {
+			assert.Equal(t, "lib/lib.go", f.File)
+			assert.Equal(t, 21, f.Line)
+		}
+	}
+
+	result, err = Analyze(dir, []string{"./..."}, Options{Generated: true})
+	require.NoError(t, err)
+	assert.Contains(t, keys(result.Findings), "example.com/fixture/lib.GeneratedDead")
+}
+
+func TestBaseline(t *testing.T) {
+	path := filepath.Join(t.TempDir(), ".deadcode-baseline")
+	findings := []Finding{{Key: "example.com/a.B"}, {Key: "example.com/a.A"}}
+
+	require.NoError(t, WriteBaseline(path, findings))
+
+	baseline, err := ReadBaseline(path)
+	require.NoError(t, err)
+	assert.Equal(t, Baseline{"example.com/a.A": true, "example.com/a.B": true}, baseline)
+
+	fresh, stale := baseline.Apply([]Finding{{Key: "example.com/a.A"}, {Key: "example.com/a.C"}})
+	assert.Equal(t, []string{"example.com/a.C"}, keys(fresh))
+	assert.Equal(t, []string{"example.com/a.B"}, stale)
+
+	missing, err := ReadBaseline(filepath.Join(t.TempDir(), "missing"))
+	require.NoError(t, err)
+	assert.Empty(t, missing)
+}
+
+func TestRunWithBaselineAndSARIF(t *testing.T) {
+	dir := writeFixture(t)
+	baselinePath := filepath.Join(dir, ".deadcode-baseline")
+
+	t.Chdir(dir)
+
+	var stdout, stderr bytes.Buffer
+
+	require.ErrorIs(t, run(nil, &stdout, &stderr), errFindings)
+	assert.Contains(t, stdout.String(), "lib/lib.go:21:6: unreachable func: Dead\n")
+
+	require.NoError(t, run([]string{"-baseline", baselinePath, "-write-baseline"}, &stdout, &stderr))
+
+	stdout.Reset()
+	require.NoError(t, run([]string{"-baseline", baselinePath}, &stdout, &stderr))
+	assert.Empty(t, stdout.String(), "baselined findings are not reported")
+
+	stdout.Reset()
+	require.NoError(t, run([]string{"-baseline", baselinePath, "-format", "sarif"}, &stdout, &stderr))
+
+	var log sarifLog
+	require.NoError(t, json.Unmarshal(stdout.Bytes(), &log))
+	require.Len(t, log.Runs, 1)
+	require.Len(t, log.Runs[0].Results, 4)
+
+	for _, result := range log.Runs[0].Results {
+		assert.Equal(t, ruleUnreachable, result.RuleID)
+		assert.NotEmpty(t, result.PartialFingerprints[fingerprintKey])
+		assert.Len(t, result.Suppressions, 1, "baselined findings are suppressed")
+	}
+}
+
+// configFixture is a module with a stand-in viper package
+var configFixture = map[string]string{
+	"go.mod": "module example.com/fixture\n\ngo 1.25\n",
+	"viper/viper.go": `package viper
+
+type Viper struct{}
+
+func (*Viper) SetDefault(key string, value any)          {}
+func (*Viper) BindEnv(input ...string) error             { return nil }
+func (*Viper) GetString(key string) string               { return "" }
+func (*Viper) GetInt(key string) int                     { return 0 }
+func (*Viper) UnmarshalKey(key string, raw any) error    { return nil }
+func (*Viper) Unmarshal(raw any) error                   { return nil }
+`,
+	"config/config.go": `package config
+
+import "example.com/fixture/viper"
+
+type Config struct {
+	App   AppConfig
+	Hooks []HookConfig
+}
+
+type AppConfig struct {
+	Name string
+	Port int
+}
+
+type HookConfig struct {
+	URL string
+}
+
+type ValidationError struct {
+	Field string
+}
+
+type binding struct {
+	Key string
+	Env []string
+}
+
+var bindings = []binding{{Key: "app.name", Env: []string{"NAME"}}, {Key: "app.timeout"}}
+
+func Load(v *viper.Viper) Config {
+	v.SetDefault("app.name", "GoForms")
+	v.SetDefault("app.port", 8090)
+	v.SetDefault("app.legacy", true)
+	v.SetDefault("events.login.threshold", 5)
+	_ = v.BindEnv("app.port", "PORT")
+
+	for _, b := range bindings {
+		_ = v.BindEnv(append([]string{b.Key}, b.Env...)...)
+	}
+
+	var decoded struct {
+		Log struct {
+			Level  int    "mapstructure:\"level\""
+			Format string "mapstructure:\"format\""
+		} "mapstructure:\"log\""
+	}
+
+	v.SetDefault("log.level", 1)
+	v.SetDefault("log.color", true)
+	_ = v.Unmarshal(&decoded)
+
+	cfg := Config{App: AppConfig{Name: v.GetString("app.name"), Port: v.GetInt("APP.PORT")}}
+	_ = v.UnmarshalKey("hooks", &cfg.Hooks)
+	_ = v.GetString("app.secret")
+
+	for _, rule := range []string{"login"} {
+		_ = v.GetInt("events." + rule + ".threshold")
+	}
+
+	return cfg
+}
+`,
+	"server/server.go": `package server
+
+import "example.com/fixture/config"
+
+func Addr(cfg config.Config) int {
+	for _, hook := range cfg.Hooks {
+		_ = hook.URL
+	}
+
+	cfg.App.Name = "overridden"
+
+	return cfg.App.Port
+}
+`,
+}
+
+func TestAnalyzeConfig(t *testing.T) {
+	dir := t.TempDir()
+
+	for name, content := range configFixture {
+		path := filepath.Join(dir, name)
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o755))
+		require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+	}
+
+	result, err := AnalyzeConfig(dir, []string{"./..."}, "./config", Options{})
+	require.NoError(t, err)
+
+	assert.ElementsMatch(t, []string{
+		"config:config-unused-default:app.legacy",
+		"config:config-unused-default:app.timeout",
+		"config:config-unused-default:log.color",
+		"config:config-undefined-key:hooks",
+		"config:config-undefined-key:app.secret",
+		"config:config-unread-field:AppConfig.Name",
+	}, keys(result.Findings))
+
+	for _, f := range result.Findings {
+		assert.Equal(t, "config/config.go", f.File)
+	}
+}
@@ -0,0 +1,145 @@
+// Package main provides deadcode, which reports the functions of the module
+// that no program can call. It builds the SSA form of the whole module and its
+// dependencies and runs Rapid Type Analysis from the main packages (and, with
+// -test, the test executables), so calls through interfaces, function values
+// and fx providers count. Functions of the module missing from the resulting
+// call graph are reported.
+//
+// Usage:
+//
+//	deadcode [-test] [-generated] [-filter <regexp>] [-baseline <file> [-write-baseline]] [-format text|json|sarif] [packages]
+//	deadcode -config <package> [-test] [-baseline <file> [-write-baseline]] [-format text|json|sarif] [packages]
+//
+// Packages default to ./... . Findings are reported for the packages of the
+// main module, or those whose import path matches -filter.
+//
+// With -config, deadcode checks the configuration package instead: keys given
+// a Viper default or environment binding that nothing reads, keys read without
+// either, and exported fields of the package's structs that no other package
+// reads. Reading a section, as UnmarshalKey does, reads every key under it;
+// Unmarshal reads the keys named by its target's mapstructure tags.
+//
+// A baseline file lists known findings, one key per line, which are then
+// suppressed: text and JSON output leave them out and SARIF marks them as
+// suppressed. Keys name the function, config key or field, not its line, so
+// edits elsewhere in a file keep them valid. -write-baseline rewrites the file with the current
+// findings.
+//
+// SARIF results carry the key as a partial fingerprint, so code scanning tracks
+// each finding across commits. The exit status is 1 when a finding is not in
+// the baseline.
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"regexp"
+)
+
+// errFindings is returned when findings are not in the baseline
+var errFindings = errors.New("unreachable functions found")
+
+func main() {
+	if err := run(os.Args[1:], os.Stdout, os.Stderr); err != nil {
+		if !errors.Is(err, errFindings) {
+			fmt.Fprintln(os.Stderr, "deadcode:", err)
+		}
+
+		os.Exit(1)
+	}
+}
+
+// run parses the command line and reports the findings
+func run(args []string, stdout, stderr io.Writer) error {
+	flags := flag.NewFlagSet("deadcode", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+
+	tests := flags.Bool("test", false, "include test executables as roots and report test code")
+	generated := flags.Bool("generated", false, "report functions in generated files")
+	filter := flags.String("filter", "", "report packages whose import path matches this regexp (default: the main module)")
+	baselinePath := flags.String("baseline", "", "file of known findings to suppress")
+	writeBaseline := flags.Bool("write-baseline", false, "write the current findings to the baseline file")
+	format := flags.String("format", "text", "output format: text, json or sarif")
+	configPath := flags.String("config", "", "check the Viper keys and config structs of this package instead of functions")
+
+	if err := flags.Parse(args); err != nil {
+		return fmt.Errorf("parse flags: %w", err)
+	}
+
+	if *writeBaseline && *baselinePath == "" {
+		return errors.New("-write-baseline requires -baseline")
+	}
+
+	opts := Options{Tests: *tests, Generated: *generated}
+
+	if *filter != "" {
+		pattern, err := regexp.Compile(*filter)
+		if err != nil {
+			return fmt.Errorf("parse filter: %w", err)
+		}
+
+		opts.Filter = pattern
+	}
+
+	patterns := flags.Args()
+	if len(patterns) == 0 {
+		patterns = []string{"./..."}
+	}
+
+	var result *Result
+
+	var err error
+
+	if *configPath != "" {
+		result, err = AnalyzeConfig(".", patterns, *configPath, opts)
+	} else {
+		result, err = Analyze(".", patterns, opts)
+	}
+
+	if err != nil {
+		return err
+	}
+
+	if *writeBaseline {
+		return WriteBaseline(*baselinePath, result.Findings)
+	}
+
+	baseline := Baseline{}
+
+	if *baselinePath != "" {
+		if baseline, err = ReadBaseline(*baselinePath); err != nil {
+			return err
+		}
+	}
+
+	fresh, stale := baseline.Apply(result.Findings)
+
+	if len(stale) > 0 {
+		fmt.Fprintf(stderr, "deadcode: %d baseline entries are no longer found; rewrite %s with -write-baseline\n",
+			len(stale), *baselinePath)
+	}
+
+	switch *format {
+	case "text":
+		err = writeText(stdout, fresh)
+	case "json":
+		err = writeJSON(stdout, fresh)
+	case "sarif":
+		err = writeSARIF(stdout, result.Findings, baseline)
+	default:
+		return fmt.Errorf("unknown format %q", *format)
+	}
+
+	if err != nil {
+		return err
+	}
+
+	if len(fresh) > 0 {
+		return errFindings
+	}
+
+	return nil
+}
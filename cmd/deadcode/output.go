@@ -0,0 +1,189 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+)
+
+// fingerprintKey names the SARIF partial fingerprint holding a finding's key
+const fingerprintKey = "deadcodeKey/v1"
+
+// Rules identify the kinds of findings
+const (
+	ruleUnreachable   = "unreachable-func"
+	ruleUnusedDefault = "config-unused-default"
+	ruleUndefinedKey  = "config-undefined-key"
+	ruleUnreadField   = "config-unread-field"
+)
+
+// rules describes each rule in SARIF
+var rules = []sarifRule{
+	{
+		ID:               ruleUnreachable,
+		ShortDescription: sarifMessage{Text: "Unreachable function"},
+		FullDescription: sarifMessage{
+			Text: "No call graph path from a main package's init or main function reaches this function.",
+		},
+	},
+	{
+		ID:               ruleUnusedDefault,
+		ShortDescription: sarifMessage{Text: "Config key defined but never read"},
+		FullDescription: sarifMessage{
+			Text: "The key has a Viper default or environment binding, but nothing reads it or a section containing it.",
+		},
+	},
+	{
+		ID:               ruleUndefinedKey,
+		ShortDescription: sarifMessage{Text: "Config key read but never defined"},
+		FullDescription: sarifMessage{
+			Text: "The key is read from Viper, but has no default or environment binding, so it is empty unless a config file sets it.",
+		},
+	},
+	{
+		ID:               ruleUnreadField,
+		ShortDescription: sarifMessage{Text: "Config field never read"},
+		FullDescription: sarifMessage{
+			Text: "No package outside the config package reads this configuration struct field.",
+		},
+	},
+}
+
+// writeText writes one line per finding, in the style of compiler diagnostics
+func writeText(w io.Writer, findings []Finding) error {
+	for _, f := range findings {
+		if _, err := fmt.Fprintf(w, "%s:%d:%d: %s\n", f.File, f.Line, f.Column, f.Message); err != nil {
+			return fmt.Errorf("write findings: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// writeJSON writes the findings as an indented JSON array
+func writeJSON(w io.Writer, findings []Finding) error {
+	if findings == nil {
+		findings = []Finding{}
+	}
+
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+
+	if err := encoder.Encode(findings); err != nil {
+		return fmt.Errorf("write findings: %w", err)
+	}
+
+	return nil
+}
+
+// sarifLog is the subset of SARIF 2.1.0 that deadcode writes
+type sarifLog struct {
+	Schema  string     `json:"$schema"`
+	Version string     `json:"version"`
+	Runs    []sarifRun `json:"runs"`
+}
+
+type sarifRun struct {
+	Tool    sarifTool     `json:"tool"`
+	Results []sarifResult `json:"results"`
+}
+
+type sarifTool struct {
+	Driver sarifDriver `json:"driver"`
+}
+
+type sarifDriver struct {
+	Name           string      `json:"name"`
+	InformationURI string      `json:"informationUri"`
+	Rules          []sarifRule `json:"rules"`
+}
+
+type sarifRule struct {
+	ID               string       `json:"id"`
+	ShortDescription sarifMessage `json:"shortDescription"`
+	FullDescription  sarifMessage `json:"fullDescription"`
+}
+
+type sarifMessage struct {
+	Text string `json:"text"`
+}
+
+type sarifResult struct {
+	RuleID              string             `json:"ruleId"`
+	Level               string             `json:"level"`
+	Message             sarifMessage       `json:"message"`
+	Locations           []sarifLocation    `json:"locations"`
+	PartialFingerprints map[string]string  `json:"partialFingerprints"`
+	Suppressions        []sarifSuppression `json:"suppressions,omitempty"`
+}
+
+type sarifLocation struct {
+	PhysicalLocation sarifPhysicalLocation `json:"physicalLocation"`
+}
+
+type sarifPhysicalLocation struct {
+	ArtifactLocation sarifArtifactLocation `json:"artifactLocation"`
+	Region           sarifRegion           `json:"region"`
+}
+
+type sarifArtifactLocation struct {
+	URI       string `json:"uri"`
+	URIBaseID string `json:"uriBaseId"`
+}
+
+type sarifRegion struct {
+	StartLine   int `json:"startLine"`
+	StartColumn int `json:"startColumn"`
+}
+
+type sarifSuppression struct {
+	Kind          string `json:"kind"`
+	Justification string `json:"justification"`
+}
+
+// writeSARIF writes every finding as a SARIF log; baselined findings are
+// marked as suppressed so code scanning closes their alerts
+func writeSARIF(w io.Writer, findings []Finding, baseline Baseline) error {
+	results := make([]sarifResult, 0, len(findings))
+
+	for _, f := range findings {
+		result := sarifResult{
+			RuleID:  f.Rule,
+			Level:   "warning",
+			Message: sarifMessage{Text: f.Message},
+			Locations: []sarifLocation{{PhysicalLocation: sarifPhysicalLocation{
+				ArtifactLocation: sarifArtifactLocation{URI: f.File, URIBaseID: "%SRCROOT%"},
+				Region:           sarifRegion{StartLine: f.Line, StartColumn: f.Column},
+			}}},
+			PartialFingerprints: map[string]string{fingerprintKey: f.Key},
+		}
+
+		if baseline[f.Key] {
+			result.Suppressions = []sarifSuppression{{Kind: "external", Justification: "listed in the deadcode baseline"}}
+		}
+
+		results = append(results, result)
+	}
+
+	log := sarifLog{
+		Schema:  "https://json.schemastore.org/sarif-2.1.0.json",
+		Version: "2.1.0",
+		Runs: []sarifRun{{
+			Tool: sarifTool{Driver: sarifDriver{
+				Name:           "deadcode",
+				InformationURI: "https://github.com/goformx/goforms/tree/main/cmd/deadcode",
+				Rules:          rules,
+			}},
+			Results: results,
+		}},
+	}
+
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+
+	if err := encoder.Encode(log); err != nil {
+		return fmt.Errorf("write sarif: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,222 @@
+// Package accessibility audits form schemas for problems that keep the hosted
+// form from being usable with assistive technology: fields and buttons without
+// an accessible name, options without labels, images without alternative
+// text, duplicate keys (which give duplicate element IDs and break label
+// associations), positive tab indexes and input formats nobody is told about.
+// Each violation names the WCAG 2.1 success criterion it fails.
+package accessibility
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/goformx/goforms/internal/domain/form/model"
+)
+
+// Severities of a violation
+const (
+	// SeverityError makes the form unusable for some visitors
+	SeverityError = "error"
+	// SeverityWarning makes the form harder to use
+	SeverityWarning = "warning"
+)
+
+// Rules checked by Audit
+const (
+	RuleLabelMissing       = "label-missing"
+	RuleLabelHidden        = "label-hidden"
+	RuleOptionLabelMissing = "option-label-missing"
+	RuleButtonLabelMissing = "button-label-missing"
+	RuleImageAltMissing    = "image-alt-missing"
+	RuleDuplicateKey       = "duplicate-key"
+	RulePositiveTabIndex   = "positive-tabindex"
+	RuleFormatUndescribed  = "format-undescribed"
+)
+
+var (
+	imgTagPattern  = regexp.MustCompile(`(?i)<img\b[^>]*>`)
+	altAttrPattern = regexp.MustCompile(`(?i)\balt\s*=`)
+)
+
+// Violation is one accessibility problem of a component
+type Violation struct {
+	Rule     string `json:"rule"`
+	Severity string `json:"severity"`
+	// WCAG is the success criterion, e.g. "1.3.1"
+	WCAG string `json:"wcag"`
+	// Key is the component key; empty for components without one
+	Key     string `json:"key,omitempty"`
+	Type    string `json:"type,omitempty"`
+	Message string `json:"message"`
+}
+
+// Report is the result of auditing a schema
+type Report struct {
+	// Checked is the number of components audited
+	Checked    int         `json:"checked"`
+	Errors     int         `json:"errors"`
+	Warnings   int         `json:"warnings"`
+	Violations []Violation `json:"violations"`
+}
+
+// Passed reports whether the schema has no errors; warnings do not fail it
+func (r *Report) Passed() bool {
+	return r.Errors == 0
+}
+
+// add records a violation of a component
+func (r *Report) add(component map[string]any, rule, severity, wcag, message string) {
+	key, _ := component["key"].(string)
+	componentType, _ := component["type"].(string)
+
+	r.Violations = append(r.Violations, Violation{
+		Rule: rule, Severity: severity, WCAG: wcag, Key: key, Type: componentType, Message: message,
+	})
+
+	if severity == SeverityError {
+		r.Errors++
+	} else {
+		r.Warnings++
+	}
+}
+
+// Audit checks every component of a form.io schema
+func Audit(schema model.JSON) *Report {
+	report := &Report{Violations: []Violation{}}
+	seen := map[string]bool{}
+
+	_ = model.WalkSchema(schema, func(component map[string]any) error {
+		if model.IsLayoutComponent(component) {
+			return nil
+		}
+
+		report.Checked++
+
+		if key, _ := component["key"].(string); key != "" {
+			if seen[key] {
+				report.add(component, RuleDuplicateKey, SeverityError, "4.1.1",
+					"Another component uses the key "+strconv.Quote(key)+"; their element IDs clash and labels may point at the wrong field")
+			}
+
+			seen[key] = true
+		}
+
+		auditComponent(report, component)
+
+		return nil
+	})
+
+	return report
+}
+
+// auditComponent applies the rules of one component
+func auditComponent(report *Report, component map[string]any) {
+	componentType, _ := component["type"].(string)
+
+	switch componentType {
+	case "button":
+		if text(component, "label") == "" {
+			report.add(component, RuleButtonLabelMissing, SeverityError, "4.1.2", "The button has no label, so screen readers announce it without a name")
+		}
+	case "content":
+		auditImages(report, component, text(component, "html"))
+	case "htmlelement":
+		auditHTMLElement(report, component)
+	case "hidden":
+		return
+	default:
+		if input, _ := component["input"].(bool); input {
+			auditInput(report, component)
+		}
+	}
+
+	if tabIndex, err := strconv.Atoi(text(component, "tabindex")); err == nil && tabIndex > 0 {
+		report.add(component, RulePositiveTabIndex, SeverityWarning, "2.4.3",
+			"A positive tab index moves the field out of the reading order for keyboard users")
+	}
+}
+
+// auditInput checks the name, options and instructions of an input
+func auditInput(report *Report, component map[string]any) {
+	switch {
+	case text(component, "label") == "" && text(component, "placeholder") != "":
+		report.add(component, RuleLabelMissing, SeverityError, "3.3.2",
+			"The field has only a placeholder, which disappears while typing and is not a label")
+	case text(component, "label") == "":
+		report.add(component, RuleLabelMissing, SeverityError, "1.3.1", "The field has no label, so it has no accessible name")
+	case component["hideLabel"] == true:
+		report.add(component, RuleLabelHidden, SeverityWarning, "3.3.2",
+			"The label is hidden; sighted visitors only see the placeholder or surrounding text")
+	}
+
+	for _, option := range options(component) {
+		if text(option, "label") == "" {
+			report.add(component, RuleOptionLabelMissing, SeverityError, "4.1.2",
+				"An option has no label, so screen readers announce only its value or nothing")
+
+			break
+		}
+	}
+
+	validate, _ := component["validate"].(map[string]any)
+	if validate != nil && text(validate, "pattern") != "" &&
+		text(component, "description") == "" && text(component, "tooltip") == "" && text(validate, "customMessage") == "" {
+		report.add(component, RuleFormatUndescribed, SeverityWarning, "3.3.2",
+			"The field requires a format that neither its description nor its error message explains")
+	}
+}
+
+// auditHTMLElement checks an htmlelement component, which may be an image
+func auditHTMLElement(report *Report, component map[string]any) {
+	if !strings.EqualFold(text(component, "tag"), "img") {
+		auditImages(report, component, text(component, "content"))
+
+		return
+	}
+
+	attrs, _ := component["attrs"].([]any)
+	for _, attr := range attrs {
+		if attrMap, ok := attr.(map[string]any); ok && strings.EqualFold(text(attrMap, "attr"), "alt") {
+			return
+		}
+	}
+
+	report.add(component, RuleImageAltMissing, SeverityError, "1.1.1", "The image has no alt attribute")
+}
+
+// auditImages reports the <img> tags of html without an alt attribute
+func auditImages(report *Report, component map[string]any, html string) {
+	for _, tag := range imgTagPattern.FindAllString(html, -1) {
+		if !altAttrPattern.MatchString(tag) {
+			report.add(component, RuleImageAltMissing, SeverityError, "1.1.1", "An image in the content has no alt attribute")
+
+			return
+		}
+	}
+}
+
+// options returns the choices of a radio, selectboxes or select component
+func options(component map[string]any) []map[string]any {
+	values, _ := component["values"].([]any)
+	if data, ok := component["data"].(map[string]any); ok && len(values) == 0 {
+		values, _ = data["values"].([]any)
+	}
+
+	choices := make([]map[string]any, 0, len(values))
+
+	for _, value := range values {
+		if choice, ok := value.(map[string]any); ok {
+			choices = append(choices, choice)
+		}
+	}
+
+	return choices
+}
+
+// text returns the trimmed string property name of m
+func text(m map[string]any, name string) string {
+	value, _ := m[name].(string)
+
+	return strings.TrimSpace(value)
+}
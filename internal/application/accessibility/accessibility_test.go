@@ -0,0 +1,94 @@
+package accessibility_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/goformx/goforms/internal/application/accessibility"
+	"github.com/goformx/goforms/internal/domain/form/model"
+)
+
+func schemaOf(components ...map[string]any) model.JSON {
+	list := make([]any, 0, len(components))
+	for _, component := range components {
+		list = append(list, component)
+	}
+
+	return model.JSON{"components": list}
+}
+
+func rulesOf(report *accessibility.Report) []string {
+	rules := make([]string, 0, len(report.Violations))
+	for _, violation := range report.Violations {
+		rules = append(rules, violation.Rule)
+	}
+
+	return rules
+}
+
+func TestAudit_AccessibleFormPasses(t *testing.T) {
+	report := accessibility.Audit(schemaOf(
+		map[string]any{"type": "textfield", "key": "name", "label": "Name", "input": true},
+		map[string]any{"type": "radio", "key": "size", "label": "Size", "input": true,
+			"values": []any{map[string]any{"label": "Small", "value": "s"}}},
+		map[string]any{"type": "htmlelement", "key": "logo", "tag": "img",
+			"attrs": []any{map[string]any{"attr": "alt", "value": "Logo"}}},
+		map[string]any{"type": "button", "key": "submit", "label": "Submit", "input": true},
+	))
+
+	assert.True(t, report.Passed())
+	assert.Empty(t, report.Violations)
+	assert.Equal(t, 4, report.Checked)
+}
+
+func TestAudit_Rules(t *testing.T) {
+	tests := map[string]map[string]any{
+		accessibility.RuleLabelMissing:       {"type": "textfield", "key": "a", "input": true, "placeholder": "Name"},
+		accessibility.RuleLabelHidden:        {"type": "textfield", "key": "a", "label": "Name", "input": true, "hideLabel": true},
+		accessibility.RuleButtonLabelMissing: {"type": "button", "key": "a", "input": true},
+		accessibility.RuleImageAltMissing:    {"type": "content", "key": "a", "html": `<p>Hi</p><img src="x.png">`},
+		accessibility.RulePositiveTabIndex:   {"type": "textfield", "key": "a", "label": "Name", "input": true, "tabindex": "3"},
+		accessibility.RuleOptionLabelMissing: {"type": "select", "key": "a", "label": "Pick", "input": true,
+			"data": map[string]any{"values": []any{map[string]any{"label": "", "value": "x"}}}},
+		accessibility.RuleFormatUndescribed: {"type": "textfield", "key": "a", "label": "Code", "input": true,
+			"validate": map[string]any{"pattern": "[A-Z]{3}"}},
+	}
+
+	for rule, component := range tests {
+		report := accessibility.Audit(schemaOf(component))
+		assert.Equal(t, []string{rule}, rulesOf(report), rule)
+		assert.Equal(t, "a", report.Violations[0].Key, rule)
+		assert.NotEmpty(t, report.Violations[0].WCAG, rule)
+	}
+}
+
+func TestAudit_DuplicateKeysFail(t *testing.T) {
+	report := accessibility.Audit(schemaOf(
+		map[string]any{"type": "textfield", "key": "email", "label": "Email", "input": true},
+		map[string]any{"type": "email", "key": "email", "label": "Email again", "input": true},
+	))
+
+	assert.False(t, report.Passed())
+	assert.Equal(t, []string{accessibility.RuleDuplicateKey}, rulesOf(report))
+}
+
+func TestAudit_WarningsDoNotFail(t *testing.T) {
+	report := accessibility.Audit(schemaOf(
+		map[string]any{"type": "textfield", "key": "a", "label": "Name", "input": true, "hideLabel": true},
+	))
+
+	assert.True(t, report.Passed())
+	assert.Equal(t, 1, report.Warnings)
+	assert.Equal(t, accessibility.SeverityWarning, report.Violations[0].Severity)
+}
+
+func TestAudit_SkipsLayoutAndHiddenComponents(t *testing.T) {
+	report := accessibility.Audit(schemaOf(
+		map[string]any{"type": "panel", "key": "panel", "components": []any{
+			map[string]any{"type": "hidden", "key": "token", "input": true},
+		}},
+	))
+
+	assert.Empty(t, report.Violations)
+}
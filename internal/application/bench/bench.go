@@ -0,0 +1,226 @@
+// Package bench generates synthetic form traffic against a running instance to
+// validate performance changes. It posts schema-conformant fake submissions at a
+// fixed rate and reports end-to-end latency percentiles.
+//
+// Requests are sent open loop: one is started every 1/RPS seconds whether or not
+// earlier ones have finished, so a slow server shows up as growing latency rather
+// than a lower send rate. A tick is dropped, and counted, when every worker is busy.
+package bench
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"slices"
+	"sync"
+	"time"
+)
+
+// defaultConcurrency caps in-flight requests when Options.Concurrency is unset
+const defaultConcurrency = 64
+
+// ErrNoFields is returned when the target form has no fields to fill in
+var ErrNoFields = errors.New("form has no input fields")
+
+// Options configures a run
+type Options struct {
+	// FormID is the form to submit to
+	FormID string
+	// RPS is the target request rate
+	RPS int
+	// Duration is how long to send requests for
+	Duration time.Duration
+	// Concurrency caps in-flight requests
+	Concurrency int
+	// Seed makes the generated submissions repeatable
+	Seed uint64
+}
+
+// Latency summarizes request latencies in milliseconds
+type Latency struct {
+	Min  float64 `json:"min"`
+	Mean float64 `json:"mean"`
+	P50  float64 `json:"p50"`
+	P90  float64 `json:"p90"`
+	P95  float64 `json:"p95"`
+	P99  float64 `json:"p99"`
+	Max  float64 `json:"max"`
+}
+
+// Report is the outcome of a run
+type Report struct {
+	FormID      string      `json:"form_id"`
+	TargetRPS   int         `json:"target_rps"`
+	AchievedRPS float64     `json:"achieved_rps"`
+	Duration    float64     `json:"duration_seconds"`
+	Sent        int         `json:"sent"`
+	Succeeded   int         `json:"succeeded"`
+	Failed      int         `json:"failed"`
+	Dropped     int         `json:"dropped"`
+	StatusCodes map[int]int `json:"status_codes"`
+	Errors      []string    `json:"errors,omitempty"`
+	Latency     Latency     `json:"latency_ms"`
+}
+
+// maxReportedErrors caps the distinct transport errors kept in a report
+const maxReportedErrors = 20
+
+// recorder collects request outcomes from the workers
+type recorder struct {
+	mu        sync.Mutex
+	latencies []time.Duration
+	report    *Report
+}
+
+// record adds one request outcome
+func (r *recorder) record(latency time.Duration, status int, err error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.report.Sent++
+	r.latencies = append(r.latencies, latency)
+
+	switch {
+	case err != nil:
+		r.report.Failed++
+		if message := err.Error(); len(r.report.Errors) < maxReportedErrors && !slices.Contains(r.report.Errors, message) {
+			r.report.Errors = append(r.report.Errors, message)
+		}
+	case status >= 200 && status < 300:
+		r.report.Succeeded++
+		r.report.StatusCodes[status]++
+	default:
+		r.report.Failed++
+		r.report.StatusCodes[status]++
+	}
+}
+
+// Run loads the form's fields and submits fake submissions at options.RPS until
+// options.Duration has passed or ctx is cancelled
+func Run(ctx context.Context, client *Client, options Options) (*Report, error) {
+	if options.RPS <= 0 {
+		return nil, errors.New("rps must be positive")
+	}
+
+	if options.Duration <= 0 {
+		return nil, errors.New("duration must be positive")
+	}
+
+	if options.Concurrency <= 0 {
+		options.Concurrency = defaultConcurrency
+	}
+
+	fields, err := client.Fields(ctx, options.FormID)
+	if err != nil {
+		return nil, fmt.Errorf("load form fields: %w", err)
+	}
+
+	if len(fields) == 0 {
+		return nil, ErrNoFields
+	}
+
+	generator := NewGenerator(fields, options.Seed)
+	rec := &recorder{
+		latencies: make([]time.Duration, 0, options.RPS*int(math.Ceil(options.Duration.Seconds()))),
+		report: &Report{
+			FormID:      options.FormID,
+			TargetRPS:   options.RPS,
+			StatusCodes: make(map[int]int),
+		},
+	}
+
+	jobs := make(chan struct{})
+
+	var wg sync.WaitGroup
+
+	for range options.Concurrency {
+		wg.Go(func() {
+			for range jobs {
+				data := generator.Submission()
+				start := time.Now()
+				status, submitErr := client.Submit(ctx, options.FormID, data)
+				rec.record(time.Since(start), status, submitErr)
+			}
+		})
+	}
+
+	start := time.Now()
+	dropped := send(ctx, jobs, options)
+
+	close(jobs)
+	wg.Wait()
+
+	elapsed := time.Since(start)
+
+	report := rec.report
+	report.Dropped = dropped
+	report.Duration = elapsed.Seconds()
+	report.AchievedRPS = float64(report.Sent) / elapsed.Seconds()
+	report.Latency = summarize(rec.latencies)
+
+	return report, nil
+}
+
+// send paces jobs at the target rate and returns the number of ticks dropped
+// because every worker was busy
+func send(ctx context.Context, jobs chan<- struct{}, options Options) int {
+	ticker := time.NewTicker(time.Second / time.Duration(options.RPS))
+	defer ticker.Stop()
+
+	deadline := time.NewTimer(options.Duration)
+	defer deadline.Stop()
+
+	dropped := 0
+
+	for {
+		select {
+		case <-ctx.Done():
+			return dropped
+		case <-deadline.C:
+			return dropped
+		case <-ticker.C:
+			select {
+			case jobs <- struct{}{}:
+			default:
+				dropped++
+			}
+		}
+	}
+}
+
+// summarize computes latency statistics in milliseconds
+func summarize(latencies []time.Duration) Latency {
+	if len(latencies) == 0 {
+		return Latency{}
+	}
+
+	slices.Sort(latencies)
+
+	var total time.Duration
+	for _, latency := range latencies {
+		total += latency
+	}
+
+	return Latency{
+		Min:  milliseconds(latencies[0]),
+		Mean: milliseconds(total / time.Duration(len(latencies))),
+		P50:  milliseconds(percentile(latencies, 50)),
+		P90:  milliseconds(percentile(latencies, 90)),
+		P95:  milliseconds(percentile(latencies, 95)),
+		P99:  milliseconds(percentile(latencies, 99)),
+		Max:  milliseconds(latencies[len(latencies)-1]),
+	}
+}
+
+// percentile returns the nearest-rank percentile of sorted latencies
+func percentile(sorted []time.Duration, p float64) time.Duration {
+	rank := int(math.Ceil(p / 100 * float64(len(sorted))))
+
+	return sorted[max(rank, 1)-1]
+}
+
+// milliseconds converts a duration to fractional milliseconds
+func milliseconds(d time.Duration) float64 {
+	return float64(d.Microseconds()) / 1000
+}
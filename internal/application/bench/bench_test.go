@@ -0,0 +1,143 @@
+package bench_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/goformx/goforms/internal/application/bench"
+	"github.com/goformx/goforms/internal/application/validation"
+	"github.com/goformx/goforms/internal/domain/form/model"
+)
+
+var benchSchema = model.JSON{
+	"components": []any{
+		map[string]any{
+			"type": "textfield", "key": "name", "label": "Name",
+			"validate": map[string]any{"required": true, "minLength": float64(3), "maxLength": float64(10)},
+		},
+		map[string]any{"type": "email", "key": "email", "label": "Email", "validate": map[string]any{"required": true}},
+		map[string]any{
+			"type": "number", "key": "age", "label": "Age",
+			"validate": map[string]any{"required": true, "min": float64(18), "max": float64(99)},
+		},
+		map[string]any{
+			"type": "select", "key": "size", "label": "Size", "validate": map[string]any{"required": true},
+			"data": map[string]any{"values": []any{
+				map[string]any{"label": "Small", "value": "s"},
+				map[string]any{"label": "Large", "value": "l"},
+			}},
+		},
+		map[string]any{"type": "checkbox", "key": "terms", "label": "Terms", "validate": map[string]any{"required": true}},
+		map[string]any{"type": "phoneNumber", "key": "phone", "label": "Phone"},
+		map[string]any{"type": "textarea", "key": "notes", "label": "Notes"},
+		map[string]any{"type": "button", "key": "submit", "label": "Submit"},
+	},
+}
+
+// newInstance serves the public field and submit endpoints for one form,
+// rejecting submissions that fail schema validation
+func newInstance(t *testing.T, rejected *atomic.Int32) *httptest.Server {
+	t.Helper()
+
+	fields, ok := validation.NewSchemaParser().ExtractFields(benchSchema)
+	require.True(t, ok)
+
+	validator := validation.NewComprehensiveValidator()
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /forms/form-1/fields", func(w http.ResponseWriter, _ *http.Request) {
+		_ = json.NewEncoder(w).Encode(map[string]any{
+			"success": true,
+			"data":    map[string]any{"form_id": "form-1", "fields": fields},
+		})
+	})
+	mux.HandleFunc("POST /forms/form-1/submit", func(w http.ResponseWriter, r *http.Request) {
+		var data model.JSON
+		if err := json.NewDecoder(r.Body).Decode(&data); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+
+			return
+		}
+
+		if result := validator.ValidateForm(benchSchema, data); !result.IsValid {
+			rejected.Add(1)
+			t.Logf("rejected submission %v: %v", data, result.Errors)
+			w.WriteHeader(http.StatusBadRequest)
+
+			return
+		}
+
+		w.WriteHeader(http.StatusOK)
+	})
+
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+
+	return server
+}
+
+func TestGenerator_SubmissionsPassValidation(t *testing.T) {
+	fields, ok := validation.NewSchemaParser().ExtractFields(benchSchema)
+	require.True(t, ok)
+
+	generator := bench.NewGenerator(fields, 42)
+	validator := validation.NewComprehensiveValidator()
+
+	for range 500 {
+		data := generator.Submission()
+
+		result := validator.ValidateForm(benchSchema, data)
+		require.True(t, result.IsValid, "submission %v: %v", data, result.Errors)
+		assert.NotContains(t, data, "submit")
+	}
+}
+
+func TestGenerator_IsRepeatable(t *testing.T) {
+	fields, ok := validation.NewSchemaParser().ExtractFields(benchSchema)
+	require.True(t, ok)
+
+	first := bench.NewGenerator(fields, 7).Submission()
+	second := bench.NewGenerator(fields, 7).Submission()
+
+	assert.Equal(t, first, second)
+}
+
+func TestRun(t *testing.T) {
+	var rejected atomic.Int32
+
+	server := newInstance(t, &rejected)
+	client := bench.NewClient(bench.ClientOptions{BaseURL: server.URL + "/", Timeout: time.Second})
+
+	report, err := bench.Run(t.Context(), client, bench.Options{
+		FormID:      "form-1",
+		RPS:         200,
+		Duration:    250 * time.Millisecond,
+		Concurrency: 8,
+	})
+	require.NoError(t, err)
+
+	assert.Zero(t, rejected.Load())
+	assert.Positive(t, report.Sent)
+	assert.Equal(t, report.Sent, report.Succeeded)
+	assert.Equal(t, report.Sent, report.StatusCodes[http.StatusOK])
+	assert.LessOrEqual(t, report.Latency.Min, report.Latency.P50)
+	assert.LessOrEqual(t, report.Latency.P50, report.Latency.P99)
+	assert.LessOrEqual(t, report.Latency.P99, report.Latency.Max)
+}
+
+func TestRun_UnknownForm(t *testing.T) {
+	var rejected atomic.Int32
+
+	server := newInstance(t, &rejected)
+	client := bench.NewClient(bench.ClientOptions{BaseURL: server.URL, Timeout: time.Second})
+
+	_, err := bench.Run(t.Context(), client, bench.Options{FormID: "missing", RPS: 1, Duration: time.Second})
+	require.Error(t, err)
+}
@@ -0,0 +1,125 @@
+package bench
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/goformx/goforms/internal/application/validation"
+)
+
+// ClientOptions configures a Client
+type ClientOptions struct {
+	// BaseURL is the instance to target, e.g. https://staging.example.com
+	BaseURL string
+	// Timeout bounds each request
+	Timeout time.Duration
+	// Headers are sent with every request, e.g. an API key
+	Headers map[string]string
+	// MaxConns caps open connections to the instance
+	MaxConns int
+}
+
+// Client talks to the public form endpoints of a running instance
+type Client struct {
+	baseURL string
+	headers map[string]string
+	http    *http.Client
+}
+
+// NewClient creates a client for the instance at options.BaseURL
+func NewClient(options ClientOptions) *Client {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	if options.MaxConns > 0 {
+		transport.MaxIdleConnsPerHost = options.MaxConns
+		transport.MaxConnsPerHost = options.MaxConns
+	}
+
+	return &Client{
+		baseURL: strings.TrimRight(options.BaseURL, "/"),
+		headers: options.Headers,
+		http:    &http.Client{Timeout: options.Timeout, Transport: transport},
+	}
+}
+
+// Fields loads a form's fields from GET /forms/:id/fields
+func (c *Client) Fields(ctx context.Context, formID string) ([]validation.Field, error) {
+	req, err := c.newRequest(ctx, http.MethodGet, formID, "fields", http.NoBody)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("get form fields: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("get form fields: unexpected status %d", resp.StatusCode)
+	}
+
+	var body struct {
+		Data struct {
+			Fields []validation.Field `json:"fields"`
+		} `json:"data"`
+	}
+
+	if decodeErr := json.NewDecoder(resp.Body).Decode(&body); decodeErr != nil {
+		return nil, fmt.Errorf("decode form fields: %w", decodeErr)
+	}
+
+	return body.Data.Fields, nil
+}
+
+// Submit posts one submission to POST /forms/:id/submit and returns the response status
+func (c *Client) Submit(ctx context.Context, formID string, data map[string]any) (int, error) {
+	payload, err := json.Marshal(data)
+	if err != nil {
+		return 0, fmt.Errorf("encode submission: %w", err)
+	}
+
+	req, err := c.newRequest(ctx, http.MethodPost, formID, "submit", bytes.NewReader(payload))
+	if err != nil {
+		return 0, err
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return 0, fmt.Errorf("submit form: %w", err)
+	}
+	defer resp.Body.Close()
+
+	// Drain the body so the connection is reused
+	_, _ = io.Copy(io.Discard, resp.Body)
+
+	return resp.StatusCode, nil
+}
+
+// newRequest builds a request for /forms/:id/<action>
+func (c *Client) newRequest(
+	ctx context.Context,
+	method, formID, action string,
+	body io.Reader,
+) (*http.Request, error) {
+	target := c.baseURL + "/forms/" + url.PathEscape(formID) + "/" + action
+
+	req, err := http.NewRequestWithContext(ctx, method, target, body)
+	if err != nil {
+		return nil, fmt.Errorf("build request: %w", err)
+	}
+
+	for name, value := range c.headers {
+		req.Header.Set(name, value)
+	}
+
+	return req, nil
+}
@@ -0,0 +1,178 @@
+package bench
+
+import (
+	"fmt"
+	"math/rand/v2"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/goformx/goforms/internal/application/validation"
+)
+
+const (
+	// defaultNumberMax is the upper bound for number fields without a max
+	defaultNumberMax = 100
+	// defaultTextLength is the length of text values for fields without length limits
+	defaultTextLength = 24
+)
+
+// words are drawn from to build text values
+var words = []string{
+	"alpha", "bravo", "charlie", "delta", "echo", "foxtrot", "golf", "hotel",
+	"india", "juliet", "kilo", "lima", "mike", "november", "oscar", "papa",
+}
+
+// Generator builds fake submissions that satisfy a form's fields. Values respect
+// field types, options and length and range limits. File fields are left out, as
+// are optional fields with a pattern; required fields with a pattern get plain text
+// and may be rejected.
+type Generator struct {
+	fields []validation.Field
+
+	mu   sync.Mutex
+	rand *rand.Rand
+	seq  int
+}
+
+// NewGenerator creates a generator for fields; the same seed yields the same submissions
+func NewGenerator(fields []validation.Field, seed uint64) *Generator {
+	return &Generator{
+		fields: fields,
+		rand:   rand.New(rand.NewPCG(seed, seed)), //nolint:gosec // fake data, not security sensitive
+	}
+}
+
+// Submission returns the data for one fake submission
+func (g *Generator) Submission() map[string]any {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	g.seq++
+
+	data := make(map[string]any, len(g.fields))
+
+	for i := range g.fields {
+		field := &g.fields[i]
+
+		if field.Type == "file" || (field.Constraints != nil && field.Constraints.Pattern != "" && !field.Required) {
+			continue
+		}
+
+		value := g.value(field)
+		if field.Multiple && field.Type != "selectboxes" {
+			value = []any{value}
+		}
+
+		data[field.Key] = value
+	}
+
+	return data
+}
+
+// value generates a single value for a field
+func (g *Generator) value(field *validation.Field) any {
+	if len(field.Options) > 0 && field.Type != "selectboxes" {
+		return field.Options[g.rand.IntN(len(field.Options))].Value
+	}
+
+	switch field.Type {
+	case "email":
+		return fmt.Sprintf("bench.user%d@example.com", g.seq)
+	case "url":
+		return fmt.Sprintf("https://example.com/bench/%d", g.seq)
+	case "phoneNumber":
+		return fmt.Sprintf("+1555%07d", g.rand.IntN(10_000_000))
+	case "date", "day":
+		return g.date().Format(time.DateOnly)
+	case "datetime":
+		return g.date().Format(time.RFC3339)
+	case "number", "integer", "currency":
+		return g.number(field.Constraints)
+	case "checkbox":
+		return field.Required || g.rand.IntN(2) == 0
+	case "selectboxes":
+		return g.selectBoxes(field.Options)
+	default:
+		return g.text(field.Constraints)
+	}
+}
+
+// date returns a day within the past year
+func (g *Generator) date() time.Time {
+	return time.Now().UTC().AddDate(0, 0, -g.rand.IntN(365))
+}
+
+// number returns a whole number within the field's range
+func (g *Generator) number(constraints *validation.Constraints) float64 {
+	low, high := 0.0, float64(defaultNumberMax)
+
+	if constraints != nil {
+		if constraints.Min != nil {
+			low = *constraints.Min
+		}
+
+		if constraints.Max != nil {
+			high = *constraints.Max
+		} else if constraints.Min != nil {
+			high = low + defaultNumberMax
+		}
+	}
+
+	if high <= low {
+		return low
+	}
+
+	return low + float64(g.rand.IntN(int(high-low)+1))
+}
+
+// selectBoxes checks one option of a selectboxes field
+func (g *Generator) selectBoxes(options []validation.Option) map[string]any {
+	checked := make(map[string]any, len(options))
+	if len(options) == 0 {
+		return checked
+	}
+
+	pick := g.rand.IntN(len(options))
+	for i, option := range options {
+		checked[fmt.Sprint(option.Value)] = i == pick
+	}
+
+	return checked
+}
+
+// text returns words trimmed or padded to the field's length limits
+func (g *Generator) text(constraints *validation.Constraints) string {
+	minLength, maxLength := 1, defaultTextLength
+
+	if constraints != nil {
+		if constraints.MinLength != nil {
+			minLength = max(*constraints.MinLength, minLength)
+		}
+
+		if constraints.MaxLength != nil && *constraints.MaxLength > 0 {
+			maxLength = *constraints.MaxLength
+		}
+	}
+
+	maxLength = max(maxLength, minLength)
+	length := minLength + g.rand.IntN(maxLength-minLength+1)
+
+	var b strings.Builder
+	for b.Len() < length {
+		if b.Len() > 0 {
+			b.WriteByte(' ')
+		}
+
+		b.WriteString(words[g.rand.IntN(len(words))])
+	}
+
+	text := []byte(b.String()[:length])
+
+	// A value ending in a space would fall short of the minimum once trimmed
+	if text[length-1] == ' ' {
+		text[length-1] = 'x'
+	}
+
+	return string(text)
+}
@@ -66,10 +66,35 @@ const (
 	PathAPIMetrics          = "/api/v1/metrics"
 	PathAPIForms            = "/api/v1/forms"
 	PathAPIFormsLaravel     = "/api/forms"
-	PathFormsPublic         = "/forms" // Public embed routes: /forms/:id/embed, schema, submit
+	PathFormsPublic         = "/forms"       // Public embed routes: /forms/:id/embed, schema, submit
+	PathEmbedFrame          = "/embed/frame" // Framed form pages talking to their parent: /embed/frame/:id
 	PathAPIAdmin            = "/api/v1/admin"
 	PathAPIAdminUsers       = "/api/v1/admin/users"
 	PathAPIAdminForms       = "/api/v1/admin/forms"
+	PathAPIAdminEmail       = "/api/v1/admin/email"
+	PathAPIAdminAudit       = "/api/v1/admin/audit"
+	PathAPIAdminSecurity    = "/api/v1/admin/security"
+	PathAPIAdminBackups     = "/api/v1/admin/backups"
+	PathAPIAdminRoutes      = "/api/v1/admin/routes"
+	PathAPIAdminFlags       = "/api/v1/admin/flags"
+	PathAPIAdminUpdates     = "/api/v1/admin/updates"
+	PathAPIAdminEvents      = "/api/v1/admin/events"
+	PathAPIAdminSubmissions = "/api/v1/admin/submissions"
+	PathAPIAdminDeadLetters = "/api/v1/admin/dead-letters"
+	PathAPIAdminEncryption  = "/api/v1/admin/encryption"
+	PathAPIAdminDebug       = "/api/v1/admin/debug"
+	PathAPIImpersonation    = "/api/v1/impersonation"
+	PathAPIUsage            = "/api/v1/usage"    // Account usage report: auth via API key or assertion headers
+	PathAPIDatasets         = "/api/v1/datasets" // Managed datasets: auth via assertion headers
+	PathAPIAccount          = "/api/v1/account"  // The requesting user's account: auth via assertion headers
+	PathAPIWebhooks         = "/api/v1/webhooks" // Provider callbacks: auth via the provider's signature
+	PathAPIWebhooksEmail    = "/api/v1/webhooks/email"
+	PathAPIWebhooksStripe   = "/api/v1/webhooks/stripe"
+	PathFiles               = "/files" // Signed file downloads: auth via the link's HMAC token
+
+	// Long-lived routes under PathAPIFormsLaravel, which the request timeout skips
+	RouteFormSubmissionStream = "/:id/submissions/stream" // SSE stream of a form's new submissions
+	RouteFormCollab           = "/:id/collab"             // Websocket of the builder's collaborators
 
 	// Static asset paths
 	PathStatic    = "/static"
@@ -80,6 +105,7 @@ const (
 	PathFonts     = "/fonts"
 	PathFavicon   = "/favicon.ico"
 	PathRobotsTxt = "/robots.txt"
+	PathThemeCSS  = "/css/theme.css" // Theme variables for the dashboard and form builder
 
 	PathLoginPost  = "/login"
 	PathSignupPost = "/signup"
@@ -113,6 +139,22 @@ const (
 	HeaderXRealIP        = "X-Real-IP"
 )
 
+// Form access tokens, issued by POST /forms/:id/unlock once a form's passphrase is entered
+const (
+	// HeaderFormAccessToken carries a form access token
+	HeaderFormAccessToken = "X-Form-Access-Token"
+	// QueryParamFormAccessToken is the query parameter alternative used by the hosted page
+	QueryParamFormAccessToken = "access_token"
+)
+
+// Headers set on every response to an impersonation session, so the UI can show a banner
+const (
+	// HeaderXImpersonatedBy is the ID of the admin acting as the user
+	HeaderXImpersonatedBy = "X-Impersonated-By"
+	// HeaderXImpersonationExpires is when the impersonation session ends, in RFC 3339
+	HeaderXImpersonationExpires = "X-Impersonation-Expires"
+)
+
 // Content Types
 const (
 	ContentTypeJSON = "application/json"
@@ -12,6 +12,9 @@ type PathManager struct {
 	StaticPaths        []string
 	AdminPaths         []string
 	APIValidationPaths []string
+	// RouteAuthPaths are authenticated by middleware on their route groups,
+	// with signed user assertions or API keys, instead of a session
+	RouteAuthPaths []string
 }
 
 // NewPathManager creates a new path manager with all path constants
@@ -28,6 +31,14 @@ func NewPathManager() *PathManager {
 			PathAPIHealth,
 			PathAPIValidation,
 			PathAPIFormsLaravel, // Laravel assertion API: auth via X-User-Id/X-Signature on route group
+			PathAPIWebhooks,     // Provider webhooks: auth via the provider's request signature
+			PathFiles,           // Signed file downloads: auth via the link's token
+			PathThemeCSS,        // Theme stylesheet linked by the dashboard
+		},
+		RouteAuthPaths: []string{
+			PathAPIUsage,    // Usage report: auth via API key or assertion headers on route group
+			PathAPIDatasets, // Managed datasets: auth via assertion headers on route group
+			PathAPIAccount,  // Account deletion and health report: auth via assertion headers on route group
 		},
 		StaticPaths: []string{
 			PathStatic,
@@ -43,6 +54,7 @@ func NewPathManager() *PathManager {
 			PathAdmin,
 			PathAdminUsers,
 			PathAdminForms,
+			PathAPIAdmin,
 		},
 		APIValidationPaths: []string{
 			PathAPIValidation,
@@ -62,6 +74,11 @@ func (pm *PathManager) IsStaticPath(path string) bool {
 	return pm.containsPath(pm.StaticPaths, path)
 }
 
+// IsRouteAuthPath checks if a path is authenticated by its route group
+func (pm *PathManager) IsRouteAuthPath(path string) bool {
+	return pm.containsPath(pm.RouteAuthPaths, path)
+}
+
 // IsAdminPath checks if a path requires admin access
 func (pm *PathManager) IsAdminPath(path string) bool {
 	return pm.containsPath(pm.AdminPaths, path)
@@ -89,6 +106,10 @@ func (pm *PathManager) GetRequiredAccess(path string) string {
 		return "public"
 	}
 
+	if pm.IsRouteAuthPath(path) {
+		return "route"
+	}
+
 	if pm.IsAdminPath(path) {
 		return "admin"
 	}
@@ -0,0 +1,287 @@
+// Package deploy renders Kubernetes deployment files from the application
+// configuration: a Secret holding the secret settings, a Deployment running
+// the image with the rest of the settings as environment variables, a Service
+// and, when a host is given, an Ingress. The same settings can be rendered as
+// a Helm values file instead.
+package deploy
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"strconv"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/goformx/goforms/internal/application/constants"
+	"github.com/goformx/goforms/internal/infrastructure/config"
+)
+
+// servicePort is the port the Service exposes inside the cluster
+const servicePort = 80
+
+// ErrImageRequired is returned when no image is given
+var ErrImageRequired = errors.New("image is required")
+
+// Options are the deployment settings that are not part of the application configuration
+type Options struct {
+	// Name names every object and labels its pods; empty uses "goforms"
+	Name      string
+	Namespace string
+	Image     string
+	// Replicas is the Deployment's replica count; zero uses one
+	Replicas int
+	// Host is the Ingress host; no Ingress is rendered without it
+	Host         string
+	IngressClass string
+	// TLSSecret names the Secret holding the Ingress certificate for Host
+	TLSSecret string
+}
+
+// withDefaults fills the unset options
+func (o Options) withDefaults() (Options, error) {
+	if o.Image == "" {
+		return o, ErrImageRequired
+	}
+
+	if o.Name == "" {
+		o.Name = "goforms"
+	}
+
+	o.Replicas = max(o.Replicas, 1)
+
+	return o, nil
+}
+
+// Environment splits the configuration into the environment variables the
+// container runs with: plain settings and the secret ones kept in the Secret.
+// Unset secrets are left out.
+func Environment(cfg *config.Config) (plain, secret map[string]string) {
+	plain = map[string]string{
+		"APP_NAME":    cfg.App.Name,
+		"APP_ENV":     cfg.App.Environment,
+		"APP_SCHEME":  cfg.App.Scheme,
+		"APP_HOST":    "0.0.0.0",
+		"APP_PORT":    strconv.Itoa(cfg.App.Port),
+		"DB_DRIVER":   cfg.Database.Driver,
+		"DB_HOST":     cfg.Database.Host,
+		"DB_PORT":     strconv.Itoa(cfg.Database.Port),
+		"DB_NAME":     cfg.Database.Name,
+		"DB_USERNAME": cfg.Database.Username,
+	}
+
+	secret = make(map[string]string)
+
+	for name, value := range map[string]string{
+		"DB_PASSWORD":                   cfg.Database.Password,
+		"SESSION_SECRET":                cfg.Session.Secret,
+		"SECURITY_CSRF_SECRET":          cfg.Security.CSRF.Secret,
+		"EMAIL_PASSWORD":                cfg.Email.Password,
+		"BILLING_STRIPE_SECRET_KEY":     cfg.Billing.Stripe.SecretKey,
+		"BILLING_STRIPE_WEBHOOK_SECRET": cfg.Billing.Stripe.WebhookSecret,
+	} {
+		if value != "" {
+			secret[name] = value
+		}
+	}
+
+	return plain, secret
+}
+
+// RenderManifests returns the Secret, Deployment, Service and Ingress as a
+// multi-document YAML stream
+func RenderManifests(cfg *config.Config, opts Options) ([]byte, error) {
+	opts, err := opts.withDefaults()
+	if err != nil {
+		return nil, err
+	}
+
+	plain, secret := Environment(cfg)
+	labels := map[string]any{"app.kubernetes.io/name": opts.Name}
+
+	metadata := func(name string) map[string]any {
+		meta := map[string]any{"name": name, "labels": labels}
+		if opts.Namespace != "" {
+			meta["namespace"] = opts.Namespace
+		}
+
+		return meta
+	}
+
+	env := make([]map[string]any, 0, len(plain))
+	for _, name := range slices.Sorted(maps.Keys(plain)) {
+		env = append(env, map[string]any{"name": name, "value": plain[name]})
+	}
+
+	probe := map[string]any{
+		"httpGet": map[string]any{"path": constants.PathHealth, "port": "http"},
+	}
+
+	documents := []map[string]any{
+		{
+			"apiVersion": "v1",
+			"kind":       "Secret",
+			"metadata":   metadata(opts.Name),
+			"type":       "Opaque",
+			"stringData": secret,
+		},
+		{
+			"apiVersion": "apps/v1",
+			"kind":       "Deployment",
+			"metadata":   metadata(opts.Name),
+			"spec": map[string]any{
+				"replicas": opts.Replicas,
+				"selector": map[string]any{"matchLabels": labels},
+				"template": map[string]any{
+					"metadata": map[string]any{"labels": labels},
+					"spec": map[string]any{
+						"containers": []map[string]any{{
+							"name":           opts.Name,
+							"image":          opts.Image,
+							"ports":          []map[string]any{{"name": "http", "containerPort": cfg.App.Port}},
+							"env":            env,
+							"envFrom":        []map[string]any{{"secretRef": map[string]any{"name": opts.Name}}},
+							"readinessProbe": probe,
+							"livenessProbe":  probe,
+						}},
+					},
+				},
+			},
+		},
+		{
+			"apiVersion": "v1",
+			"kind":       "Service",
+			"metadata":   metadata(opts.Name),
+			"spec": map[string]any{
+				"selector": labels,
+				"ports":    []map[string]any{{"name": "http", "port": servicePort, "targetPort": "http"}},
+			},
+		},
+	}
+
+	if opts.Host != "" {
+		documents = append(documents, ingress(opts, metadata(opts.Name)))
+	}
+
+	var out bytes.Buffer
+
+	encoder := yaml.NewEncoder(&out)
+	encoder.SetIndent(2)
+
+	for _, document := range documents {
+		if encodeErr := encoder.Encode(document); encodeErr != nil {
+			return nil, fmt.Errorf("encode %s: %w", document["kind"], encodeErr)
+		}
+	}
+
+	if err = encoder.Close(); err != nil {
+		return nil, fmt.Errorf("encode manifests: %w", err)
+	}
+
+	return out.Bytes(), nil
+}
+
+// ingress returns the Ingress routing opts.Host to the Service
+func ingress(opts Options, metadata map[string]any) map[string]any {
+	spec := map[string]any{
+		"rules": []map[string]any{{
+			"host": opts.Host,
+			"http": map[string]any{
+				"paths": []map[string]any{{
+					"path":     "/",
+					"pathType": "Prefix",
+					"backend": map[string]any{
+						"service": map[string]any{"name": opts.Name, "port": map[string]any{"name": "http"}},
+					},
+				}},
+			},
+		}},
+	}
+
+	if opts.IngressClass != "" {
+		spec["ingressClassName"] = opts.IngressClass
+	}
+
+	if opts.TLSSecret != "" {
+		spec["tls"] = []map[string]any{{"hosts": []string{opts.Host}, "secretName": opts.TLSSecret}}
+	}
+
+	return map[string]any{
+		"apiVersion": "networking.k8s.io/v1",
+		"kind":       "Ingress",
+		"metadata":   metadata,
+		"spec":       spec,
+	}
+}
+
+// RenderHelmValues returns the settings as a Helm values file in the layout of
+// charts created by "helm create", with env and secretEnv for the container
+func RenderHelmValues(cfg *config.Config, opts Options) ([]byte, error) {
+	opts, err := opts.withDefaults()
+	if err != nil {
+		return nil, err
+	}
+
+	plain, secret := Environment(cfg)
+
+	repository, tag := splitImage(opts.Image)
+
+	values := map[string]any{
+		"nameOverride": opts.Name,
+		"replicaCount": opts.Replicas,
+		"image":        map[string]any{"repository": repository, "tag": tag},
+		"service":      map[string]any{"type": "ClusterIP", "port": servicePort, "targetPort": cfg.App.Port},
+		"env":          plain,
+		"secretEnv":    secret,
+		"ingress":      map[string]any{"enabled": false},
+	}
+
+	if opts.Host != "" {
+		ingressValues := map[string]any{
+			"enabled":   true,
+			"className": opts.IngressClass,
+			"hosts": []map[string]any{{
+				"host":  opts.Host,
+				"paths": []map[string]any{{"path": "/", "pathType": "Prefix"}},
+			}},
+		}
+
+		if opts.TLSSecret != "" {
+			ingressValues["tls"] = []map[string]any{{"hosts": []string{opts.Host}, "secretName": opts.TLSSecret}}
+		}
+
+		values["ingress"] = ingressValues
+	}
+
+	var out bytes.Buffer
+
+	encoder := yaml.NewEncoder(&out)
+	encoder.SetIndent(2)
+
+	if err = encoder.Encode(values); err != nil {
+		return nil, fmt.Errorf("encode helm values: %w", err)
+	}
+
+	if err = encoder.Close(); err != nil {
+		return nil, fmt.Errorf("encode helm values: %w", err)
+	}
+
+	return out.Bytes(), nil
+}
+
+// splitImage splits an image reference into repository and tag. A reference
+// without a tag, or pinned by digest, keeps its whole reference as repository.
+func splitImage(image string) (repository, tag string) {
+	for i := len(image) - 1; i >= 0; i-- {
+		switch image[i] {
+		case ':':
+			return image[:i], image[i+1:]
+		case '/', '@':
+			return image, ""
+		}
+	}
+
+	return image, ""
+}
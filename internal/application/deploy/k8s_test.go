@@ -0,0 +1,115 @@
+package deploy_test
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+
+	"github.com/goformx/goforms/internal/application/deploy"
+	"github.com/goformx/goforms/internal/infrastructure/config"
+)
+
+func testConfig() *config.Config {
+	cfg := &config.Config{}
+	cfg.App.Name = "GoForms"
+	cfg.App.Environment = "production"
+	cfg.App.Port = 8090
+	cfg.Database.Driver = "postgres"
+	cfg.Database.Host = "db"
+	cfg.Database.Port = 5432
+	cfg.Database.Password = "db-secret"
+	cfg.Session.Secret = "session-secret"
+
+	return cfg
+}
+
+// decodeAll decodes every document of a YAML stream
+func decodeAll(t *testing.T, data []byte) []map[string]any {
+	t.Helper()
+
+	var documents []map[string]any
+
+	decoder := yaml.NewDecoder(bytes.NewReader(data))
+
+	for {
+		var document map[string]any
+
+		err := decoder.Decode(&document)
+		if errors.Is(err, io.EOF) {
+			return documents
+		}
+
+		require.NoError(t, err)
+
+		documents = append(documents, document)
+	}
+}
+
+func TestRenderManifests(t *testing.T) {
+	out, err := deploy.RenderManifests(testConfig(), deploy.Options{
+		Namespace: "forms",
+		Image:     "ghcr.io/goformx/goforms:1.2.0",
+		Replicas:  2,
+		Host:      "forms.example.com",
+		TLSSecret: "forms-tls",
+	})
+	require.NoError(t, err)
+
+	documents := decodeAll(t, out)
+	require.Len(t, documents, 4)
+
+	kinds := make([]string, 0, len(documents))
+	for _, document := range documents {
+		kinds = append(kinds, document["kind"].(string))
+		assert.Equal(t, "forms", document["metadata"].(map[string]any)["namespace"])
+	}
+
+	assert.Equal(t, []string{"Secret", "Deployment", "Service", "Ingress"}, kinds)
+
+	// Secrets stay out of the Deployment; unset ones are not rendered
+	assert.Equal(t, map[string]any{
+		"DB_PASSWORD":    "db-secret",
+		"SESSION_SECRET": "session-secret",
+	}, documents[0]["stringData"])
+	assert.NotContains(t, string(out), "value: db-secret")
+
+	spec := documents[1]["spec"].(map[string]any)
+	assert.Equal(t, 2, spec["replicas"])
+
+	container := spec["template"].(map[string]any)["spec"].(map[string]any)["containers"].([]any)[0].(map[string]any)
+	assert.Equal(t, "ghcr.io/goformx/goforms:1.2.0", container["image"])
+	assert.Contains(t, container["env"], map[string]any{"name": "APP_PORT", "value": "8090"})
+}
+
+func TestRenderManifestsWithoutHost(t *testing.T) {
+	out, err := deploy.RenderManifests(testConfig(), deploy.Options{Image: "goforms:latest"})
+	require.NoError(t, err)
+
+	documents := decodeAll(t, out)
+	require.Len(t, documents, 3)
+	assert.Equal(t, "goforms", documents[0]["metadata"].(map[string]any)["name"])
+
+	_, err = deploy.RenderManifests(testConfig(), deploy.Options{})
+	require.ErrorIs(t, err, deploy.ErrImageRequired)
+}
+
+func TestRenderHelmValues(t *testing.T) {
+	out, err := deploy.RenderHelmValues(testConfig(), deploy.Options{
+		Image: "registry.local:5000/goforms:1.2.0",
+		Host:  "forms.example.com",
+	})
+	require.NoError(t, err)
+
+	var values map[string]any
+	require.NoError(t, yaml.Unmarshal(out, &values))
+
+	assert.Equal(t, map[string]any{"repository": "registry.local:5000/goforms", "tag": "1.2.0"}, values["image"])
+	assert.Equal(t, "db-secret", values["secretEnv"].(map[string]any)["DB_PASSWORD"])
+	assert.Equal(t, "db", values["env"].(map[string]any)["DB_HOST"])
+	assert.Equal(t, true, values["ingress"].(map[string]any)["enabled"])
+}
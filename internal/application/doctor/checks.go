@@ -0,0 +1,294 @@
+package doctor
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/goformx/goforms/internal/infrastructure/config"
+)
+
+// errFreeSpaceUnsupported is returned where free space cannot be measured
+var errFreeSpaceUnsupported = errors.New("free space is not measured on this platform")
+
+// checkDocker checks the Docker engine is reachable and recent enough
+func checkDocker(ctx context.Context, opts Options) Check {
+	return checkVersion(ctx, opts, "docker", MinDockerVersion,
+		"docker", "version", "--format", "{{.Server.Version}}")
+}
+
+// checkCompose checks the Compose plugin is installed and recent enough
+func checkCompose(ctx context.Context, opts Options) Check {
+	return checkVersion(ctx, opts, "compose", MinComposeVersion,
+		"docker", "compose", "version", "--short")
+}
+
+// checkVersion runs a version command and compares its output with minimum
+func checkVersion(ctx context.Context, opts Options, name string, minimum []int, command string, args ...string) Check {
+	ctx, cancel := context.WithTimeout(ctx, opts.Timeout)
+	defer cancel()
+
+	out, err := opts.Run(ctx, command, args...)
+	if err != nil {
+		return Check{Name: name, Status: StatusFail, Detail: fmt.Sprintf("%s %s: %v", command, strings.Join(args, " "), err)}
+	}
+
+	version := strings.TrimPrefix(out, "v")
+	data := map[string]any{"version": version, "minimum": joinVersion(minimum)}
+
+	parsed, ok := parseVersion(version)
+	if !ok {
+		return Check{Name: name, Status: StatusWarn, Detail: "unrecognized version " + out, Data: data}
+	}
+
+	if compareVersions(parsed, minimum) < 0 {
+		return Check{
+			Name:   name,
+			Status: StatusFail,
+			Detail: fmt.Sprintf("version %s is older than %s", version, joinVersion(minimum)),
+			Data:   data,
+		}
+	}
+
+	return Check{Name: name, Status: StatusOK, Detail: "version " + version, Data: data}
+}
+
+// parseVersion reads the leading major.minor[.patch] numbers of a version
+func parseVersion(version string) ([]int, bool) {
+	version, _, _ = strings.Cut(version, "-")
+	version, _, _ = strings.Cut(version, "+")
+
+	parts := strings.Split(version, ".")
+	parsed := make([]int, 0, len(parts))
+
+	for _, part := range parts {
+		n, err := strconv.Atoi(part)
+		if err != nil {
+			break
+		}
+
+		parsed = append(parsed, n)
+	}
+
+	// At least major.minor
+	return parsed, len(parsed) >= 2
+}
+
+// compareVersions compares versions number by number, missing numbers being zero
+func compareVersions(a, b []int) int {
+	for i := range max(len(a), len(b)) {
+		var x, y int
+		if i < len(a) {
+			x = a[i]
+		}
+
+		if i < len(b) {
+			y = b[i]
+		}
+
+		if x != y {
+			if x < y {
+				return -1
+			}
+
+			return 1
+		}
+	}
+
+	return 0
+}
+
+// joinVersion formats version numbers as a dotted string
+func joinVersion(version []int) string {
+	parts := make([]string, len(version))
+	for i, n := range version {
+		parts[i] = strconv.Itoa(n)
+	}
+
+	return strings.Join(parts, ".")
+}
+
+// checkConfig runs the validation the server runs at startup
+func checkConfig(cfg *config.Config) Check {
+	if err := cfg.Validate(); err != nil {
+		return Check{Name: "config", Status: StatusFail, Detail: err.Error()}
+	}
+
+	return Check{Name: "config", Status: StatusOK, Detail: "configuration loaded and valid"}
+}
+
+// checkSchema validates every section against the configuration schema and
+// names the environment variable that sets each invalid field. The server does
+// not refuse these settings at startup, so mismatches are warnings.
+func checkSchema(cfg *config.Config) Check {
+	result := config.ValidateConfig(cfg)
+	if result.IsValid {
+		return Check{Name: "env", Status: StatusOK, Detail: "every setting matches the configuration schema"}
+	}
+
+	fields := make([]map[string]any, 0, len(result.Errors))
+	for _, fieldErr := range result.Errors {
+		fields = append(fields, map[string]any{
+			"field":   fieldErr.Field,
+			"env":     envName(fieldErr.Field),
+			"message": fieldErr.Message,
+		})
+	}
+
+	return Check{
+		Name:   "env",
+		Status: StatusWarn,
+		Detail: fmt.Sprintf("%d settings do not match the configuration schema", len(result.Errors)),
+		Data:   map[string]any{"errors": fields},
+	}
+}
+
+// envName returns the environment variable viper reads a config key from
+func envName(field string) string {
+	field, _, _ = strings.Cut(field, "[")
+
+	return strings.ToUpper(strings.ReplaceAll(field, ".", "_"))
+}
+
+// checkPort checks nothing else listens on the server's port
+func checkPort(cfg *config.Config) Check {
+	address := net.JoinHostPort(cfg.App.Host, strconv.Itoa(cfg.App.Port))
+	data := map[string]any{"address": address}
+
+	listener, err := net.Listen("tcp", address)
+	if err != nil {
+		return Check{Name: "port", Status: StatusFail, Detail: fmt.Sprintf("cannot listen on %s: %v", address, err), Data: data}
+	}
+
+	_ = listener.Close()
+
+	return Check{Name: "port", Status: StatusOK, Detail: address + " is free", Data: data}
+}
+
+// checkDatabase connects to the configured database
+func checkDatabase(ctx context.Context, cfg *config.Config, opts Options) Check {
+	ctx, cancel := context.WithTimeout(ctx, opts.Timeout)
+	defer cancel()
+
+	data := map[string]any{
+		"driver": cfg.Database.Driver,
+		"host":   cfg.Database.Host,
+		"port":   cfg.Database.Port,
+		"name":   cfg.Database.Name,
+	}
+
+	// Opening a connection is not bounded by ctx, so the wait for it is
+	done := make(chan error, 1)
+
+	go func() { done <- opts.Ping(ctx, cfg) }()
+
+	select {
+	case err := <-done:
+		if err != nil {
+			return Check{Name: "database", Status: StatusFail, Detail: err.Error(), Data: data}
+		}
+	case <-ctx.Done():
+		return Check{Name: "database", Status: StatusFail, Detail: "connection timed out", Data: data}
+	}
+
+	return Check{Name: "database", Status: StatusOK, Detail: "connected", Data: data}
+}
+
+// checkUploadsDisk checks the free space of the local storage volume
+func checkUploadsDisk(cfg *config.Config, opts Options) Check {
+	if cfg.Storage.Type != "" && cfg.Storage.Type != config.StorageTypeLocal {
+		return Check{Name: "uploads_disk", Status: StatusSkip, Detail: "uploads are stored in " + cfg.Storage.Type}
+	}
+
+	path := cfg.Storage.Local.Path
+	if path == "" {
+		path = "."
+	}
+
+	// The directory is created on the first upload; measure the volume it will be on
+	existing := filepath.Clean(path)
+	for {
+		if _, err := os.Stat(existing); err == nil || filepath.Dir(existing) == existing {
+			break
+		}
+
+		existing = filepath.Dir(existing)
+	}
+
+	free, err := freeBytes(existing)
+	if errors.Is(err, errFreeSpaceUnsupported) {
+		return Check{Name: "uploads_disk", Status: StatusSkip, Detail: err.Error()}
+	}
+
+	if err != nil {
+		return Check{Name: "uploads_disk", Status: StatusFail, Detail: err.Error()}
+	}
+
+	data := map[string]any{"path": path, "free_bytes": free, "min_free_bytes": opts.MinFreeBytes}
+
+	if free < opts.MinFreeBytes {
+		return Check{
+			Name:   "uploads_disk",
+			Status: StatusFail,
+			Detail: fmt.Sprintf("%d bytes free, need %d", free, opts.MinFreeBytes),
+			Data:   data,
+		}
+	}
+
+	return Check{Name: "uploads_disk", Status: StatusOK, Detail: fmt.Sprintf("%d bytes free", free), Data: data}
+}
+
+// checkTLS checks the configured certificate loads with its key and is not
+// expired or about to expire
+func checkTLS(cfg *config.Config, opts Options) Check {
+	settings := cfg.Security.TLS
+
+	switch {
+	case !settings.Enabled:
+		return Check{Name: "tls", Status: StatusSkip, Detail: "TLS is disabled"}
+	case settings.AutoCert:
+		return Check{Name: "tls", Status: StatusSkip, Detail: "certificates are issued automatically"}
+	}
+
+	pair, err := tls.LoadX509KeyPair(settings.CertFile, settings.KeyFile)
+	if err != nil {
+		return Check{Name: "tls", Status: StatusFail, Detail: fmt.Sprintf("load certificate: %v", err)}
+	}
+
+	leaf, err := x509.ParseCertificate(pair.Certificate[0])
+	if err != nil {
+		return Check{Name: "tls", Status: StatusFail, Detail: fmt.Sprintf("parse certificate: %v", err)}
+	}
+
+	now := opts.Now()
+	remaining := leaf.NotAfter.Sub(now)
+	data := map[string]any{
+		"subject":   leaf.Subject.String(),
+		"dns_names": leaf.DNSNames,
+		"not_after": leaf.NotAfter.UTC().Format(time.RFC3339),
+	}
+
+	switch {
+	case now.Before(leaf.NotBefore):
+		return Check{Name: "tls", Status: StatusFail, Detail: "certificate is not valid yet", Data: data}
+	case remaining <= 0:
+		return Check{Name: "tls", Status: StatusFail, Detail: "certificate expired", Data: data}
+	case remaining < opts.CertWarning:
+		return Check{
+			Name:   "tls",
+			Status: StatusWarn,
+			Detail: fmt.Sprintf("certificate expires in %s", remaining.Truncate(time.Hour)),
+			Data:   data,
+		}
+	}
+
+	return Check{Name: "tls", Status: StatusOK, Detail: "certificate valid until " + data["not_after"].(string), Data: data}
+}
@@ -0,0 +1,18 @@
+//go:build linux || darwin
+
+package doctor
+
+import (
+	"fmt"
+	"syscall"
+)
+
+// freeBytes returns the space available to unprivileged users on path's volume
+func freeBytes(path string) (uint64, error) {
+	var stat syscall.Statfs_t
+	if err := syscall.Statfs(path, &stat); err != nil {
+		return 0, fmt.Errorf("statfs %s: %w", path, err)
+	}
+
+	return stat.Bavail * uint64(stat.Bsize), nil
+}
@@ -0,0 +1,166 @@
+// Package doctor diagnoses the environment an instance is about to run in:
+// the configuration and the environment variables behind it, Docker and
+// Compose, the listen port, the database, disk space for uploads and the TLS
+// certificate. Each check reports ok, warn, fail or skip.
+package doctor
+
+import (
+	"context"
+	"os/exec"
+	"strings"
+	"time"
+
+	"github.com/goformx/goforms/internal/infrastructure/config"
+)
+
+// Status is the outcome of a check
+type Status string
+
+// Check outcomes, from best to worst
+const (
+	StatusOK   Status = "ok"
+	StatusSkip Status = "skip"
+	StatusWarn Status = "warn"
+	StatusFail Status = "fail"
+)
+
+// rank orders statuses so the worst decides the report status
+func (s Status) rank() int {
+	switch s {
+	case StatusOK:
+		return 0
+	case StatusSkip:
+		return 1
+	case StatusWarn:
+		return 2
+	default:
+		return 3
+	}
+}
+
+const (
+	// DefaultTimeout bounds each check that reaches another process or the network
+	DefaultTimeout = 5 * time.Second
+	// DefaultMinFreeBytes is the free space below which the uploads check fails
+	DefaultMinFreeBytes = 1 << 30
+	// DefaultCertWarning is how long before expiry the TLS check warns
+	DefaultCertWarning = 30 * 24 * time.Hour
+)
+
+// Minimum supported versions of the container tooling
+var (
+	MinDockerVersion  = []int{20, 10}
+	MinComposeVersion = []int{2, 0}
+)
+
+// Check is the result of one diagnostic
+type Check struct {
+	Name   string         `json:"name"`
+	Status Status         `json:"status"`
+	Detail string         `json:"detail"`
+	Data   map[string]any `json:"data,omitempty"`
+}
+
+// Report is the result of every diagnostic. Its status is the worst check status.
+type Report struct {
+	Status Status  `json:"status"`
+	Checks []Check `json:"checks"`
+}
+
+// add appends a check and lowers the report status to it when it is worse
+func (r *Report) add(check Check) {
+	r.Checks = append(r.Checks, check)
+
+	if check.Status.rank() > r.Status.rank() {
+		r.Status = check.Status
+	}
+}
+
+// Failed reports whether any check failed
+func (r *Report) Failed() bool {
+	return r.Status == StatusFail
+}
+
+// Runner runs a command and returns its trimmed standard output
+type Runner func(ctx context.Context, name string, args ...string) (string, error)
+
+// execRunner runs commands with os/exec
+func execRunner(ctx context.Context, name string, args ...string) (string, error) {
+	out, err := exec.CommandContext(ctx, name, args...).Output()
+
+	return strings.TrimSpace(string(out)), err
+}
+
+// Options tune the diagnostics; zero values use the defaults
+type Options struct {
+	Timeout      time.Duration
+	MinFreeBytes uint64
+	CertWarning  time.Duration
+	// SkipDocker leaves out the Docker and Compose checks, for binary installs
+	SkipDocker bool
+	// SkipDatabase leaves out the connection check
+	SkipDatabase bool
+	// Run runs external commands; nil uses os/exec
+	Run Runner
+	// Ping checks the database connection; nil skips the database check
+	Ping func(ctx context.Context, cfg *config.Config) error
+	// Now is the current time; nil uses time.Now
+	Now func() time.Time
+}
+
+// withDefaults fills the unset options
+func (o Options) withDefaults() Options {
+	if o.Timeout <= 0 {
+		o.Timeout = DefaultTimeout
+	}
+
+	if o.MinFreeBytes == 0 {
+		o.MinFreeBytes = DefaultMinFreeBytes
+	}
+
+	if o.CertWarning <= 0 {
+		o.CertWarning = DefaultCertWarning
+	}
+
+	if o.Run == nil {
+		o.Run = execRunner
+	}
+
+	if o.Now == nil {
+		o.Now = time.Now
+	}
+
+	return o
+}
+
+// Run loads the configuration with load and runs every diagnostic. Checks that
+// need the configuration are skipped when it cannot be loaded.
+func Run(ctx context.Context, load func() (*config.Config, error), opts Options) *Report {
+	opts = opts.withDefaults()
+	report := &Report{Status: StatusOK}
+
+	if !opts.SkipDocker {
+		report.add(checkDocker(ctx, opts))
+		report.add(checkCompose(ctx, opts))
+	}
+
+	cfg, err := load()
+	if err != nil {
+		report.add(Check{Name: "config", Status: StatusFail, Detail: err.Error()})
+
+		return report
+	}
+
+	report.add(checkConfig(cfg))
+	report.add(checkSchema(cfg))
+	report.add(checkPort(cfg))
+
+	if !opts.SkipDatabase && opts.Ping != nil {
+		report.add(checkDatabase(ctx, cfg, opts))
+	}
+
+	report.add(checkUploadsDisk(cfg, opts))
+	report.add(checkTLS(cfg, opts))
+
+	return report
+}
@@ -0,0 +1,158 @@
+package doctor_test
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"errors"
+	"math/big"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/goformx/goforms/internal/application/doctor"
+	"github.com/goformx/goforms/internal/infrastructure/config"
+)
+
+// fakeRunner answers docker version commands with fixed versions
+func fakeRunner(dockerVersion, composeVersion string) doctor.Runner {
+	return func(_ context.Context, _ string, args ...string) (string, error) {
+		if args[0] == "compose" {
+			return composeVersion, nil
+		}
+
+		return dockerVersion, nil
+	}
+}
+
+// freePort returns a port nothing listens on
+func freePort(t *testing.T) int {
+	t.Helper()
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer listener.Close()
+
+	return listener.Addr().(*net.TCPAddr).Port
+}
+
+// writeCertificate writes a self-signed certificate valid until notAfter and its key
+func writeCertificate(t *testing.T, notAfter time.Time) (certFile, keyFile string) {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "forms.example.com"},
+		DNSNames:     []string{"forms.example.com"},
+		NotBefore:    notAfter.Add(-90 * 24 * time.Hour),
+		NotAfter:     notAfter,
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	require.NoError(t, err)
+
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	require.NoError(t, err)
+
+	dir := t.TempDir()
+	certFile = filepath.Join(dir, "cert.pem")
+	keyFile = filepath.Join(dir, "key.pem")
+
+	require.NoError(t, os.WriteFile(certFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0o600))
+	require.NoError(t, os.WriteFile(keyFile, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}), 0o600))
+
+	return certFile, keyFile
+}
+
+// statuses maps check names to their status
+func statuses(report *doctor.Report) map[string]doctor.Status {
+	byName := make(map[string]doctor.Status, len(report.Checks))
+	for _, check := range report.Checks {
+		byName[check.Name] = check.Status
+	}
+
+	return byName
+}
+
+func testConfig(t *testing.T) *config.Config {
+	t.Helper()
+
+	cfg := &config.Config{}
+	cfg.App.Host = "127.0.0.1"
+	cfg.App.Port = freePort(t)
+	cfg.Storage.Type = config.StorageTypeLocal
+	cfg.Storage.Local.Path = filepath.Join(t.TempDir(), "uploads", "not-created-yet")
+
+	return cfg
+}
+
+func TestRunChecks(t *testing.T) {
+	cfg := testConfig(t)
+	cfg.Security.TLS.Enabled = true
+	cfg.Security.TLS.CertFile, cfg.Security.TLS.KeyFile = writeCertificate(t, time.Now().Add(10*24*time.Hour))
+
+	occupied, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer occupied.Close()
+
+	cfg.App.Port = occupied.Addr().(*net.TCPAddr).Port
+
+	report := doctor.Run(context.Background(), func() (*config.Config, error) { return cfg, nil }, doctor.Options{
+		MinFreeBytes: 1,
+		Run:          fakeRunner("27.3.1", "v2.29.7"),
+		Ping: func(context.Context, *config.Config) error {
+			return errors.New("connection refused")
+		},
+	})
+
+	got := statuses(report)
+	assert.Equal(t, doctor.StatusOK, got["docker"])
+	assert.Equal(t, doctor.StatusOK, got["compose"])
+	assert.Equal(t, doctor.StatusFail, got["port"])
+	assert.Equal(t, doctor.StatusFail, got["database"])
+	assert.Equal(t, doctor.StatusOK, got["uploads_disk"])
+	assert.Equal(t, doctor.StatusWarn, got["tls"])
+	assert.Equal(t, doctor.StatusWarn, got["env"])
+	assert.Equal(t, doctor.StatusFail, report.Status)
+	assert.True(t, report.Failed())
+}
+
+func TestRunOldToolingAndExpiredCertificate(t *testing.T) {
+	cfg := testConfig(t)
+	cfg.Security.TLS.Enabled = true
+	cfg.Security.TLS.CertFile, cfg.Security.TLS.KeyFile = writeCertificate(t, time.Now().Add(-time.Hour))
+
+	report := doctor.Run(context.Background(), func() (*config.Config, error) { return cfg, nil }, doctor.Options{
+		MinFreeBytes: 1,
+		Run:          fakeRunner("19.03.8", "1.29.2"),
+	})
+
+	got := statuses(report)
+	assert.Equal(t, doctor.StatusFail, got["docker"])
+	assert.Equal(t, doctor.StatusFail, got["compose"])
+	assert.Equal(t, doctor.StatusOK, got["port"])
+	assert.Equal(t, doctor.StatusFail, got["tls"])
+	assert.NotContains(t, got, "database", "without a Ping the database is not checked")
+}
+
+func TestRunConfigLoadFailure(t *testing.T) {
+	report := doctor.Run(context.Background(), func() (*config.Config, error) {
+		return nil, errors.New("failed to read config file")
+	}, doctor.Options{SkipDocker: true})
+
+	require.Len(t, report.Checks, 1)
+	assert.Equal(t, "config", report.Checks[0].Name)
+	assert.Equal(t, doctor.StatusFail, report.Status)
+}
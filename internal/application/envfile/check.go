@@ -0,0 +1,109 @@
+package envfile
+
+import (
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/goformx/goforms/internal/infrastructure/config"
+)
+
+// Required is a key every deployment must set to its own value
+type Required struct {
+	Key string
+	// Default is the built-in value the server falls back to without the key
+	Default string
+}
+
+// RequiredKeys are the secrets that must not be missing or left at a shared value
+var RequiredKeys = []Required{
+	{Key: "SESSION_SECRET", Default: "session-secret"},
+	{Key: "SECURITY_CSRF_SECRET", Default: "csrf-secret"},
+	{Key: "DB_PASSWORD", Default: "goforms"},
+	{Key: "GOFORMS_SHARED_SECRET"},
+}
+
+// Warnings lists the required keys that are missing, empty, left at the
+// built-in default, or copied unchanged from example (which may be nil)
+func Warnings(file, example *File) []string {
+	var warnings []string
+
+	for _, required := range RequiredKeys {
+		value, ok := file.Get(required.Key)
+
+		switch {
+		case !ok || value == "":
+			warnings = append(warnings, required.Key+" is not set")
+		case value == required.Default:
+			warnings = append(warnings, required.Key+" is the built-in default")
+		case example != nil && example.path != file.path:
+			if exampleValue, found := example.Get(required.Key); found && exampleValue == value {
+				warnings = append(warnings, fmt.Sprintf("%s is copied from %s", required.Key, example.path))
+			}
+		}
+	}
+
+	return warnings
+}
+
+// Problems loads the configuration the server would load with values set in
+// its environment and returns every validation error: the startup checks and
+// the configuration schema. The process environment is restored afterwards.
+func Problems(values map[string]string) ([]string, error) {
+	restore := make(map[string]*string, len(values))
+
+	for key, value := range values {
+		if previous, ok := os.LookupEnv(key); ok {
+			restore[key] = &previous
+		} else {
+			restore[key] = nil
+		}
+
+		if err := os.Setenv(key, value); err != nil {
+			return nil, fmt.Errorf("set %s: %w", key, err)
+		}
+	}
+
+	defer func() {
+		for key, previous := range restore {
+			if previous == nil {
+				_ = os.Unsetenv(key)
+			} else {
+				_ = os.Setenv(key, *previous)
+			}
+		}
+	}()
+
+	cfg, err := config.NewViperConfig().LoadUnvalidated()
+	if err != nil {
+		return []string{err.Error()}, nil
+	}
+
+	var problems []string
+
+	if validateErr := cfg.Validate(); validateErr != nil {
+		problems = append(problems, strings.Split(strings.TrimPrefix(validateErr.Error(), "validation errors: "), "; ")...)
+	}
+
+	for _, fieldErr := range config.ValidateConfig(cfg).Errors {
+		problems = append(problems, fieldErr.Field+": "+fieldErr.Message)
+	}
+
+	slices.Sort(problems)
+
+	return slices.Compact(problems), nil
+}
+
+// Introduced returns the problems in after that are not in before
+func Introduced(before, after []string) []string {
+	var introduced []string
+
+	for _, problem := range after {
+		if !slices.Contains(before, problem) {
+			introduced = append(introduced, problem)
+		}
+	}
+
+	return introduced
+}
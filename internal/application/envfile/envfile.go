@@ -0,0 +1,266 @@
+// Package envfile reads and edits Docker Compose style .env files. Edits keep
+// the file's comments, blank lines and order; only the lines of changed keys
+// are rewritten. Files encrypted with sops are decrypted and re-encrypted
+// through the sops CLI.
+package envfile
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// fileMode is the mode of files created by Save; .env files hold secrets
+const fileMode = 0o600
+
+// keyPattern matches the names accepted as keys
+var keyPattern = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+// plainValue matches values written without quotes
+var plainValue = regexp.MustCompile(`^[A-Za-z0-9_./:@,+=%-]*$`)
+
+// ErrInvalidKey is returned for keys that are not valid variable names
+var ErrInvalidKey = errors.New("invalid variable name")
+
+// line is one line of a file; key is empty for blank and comment lines
+type line struct {
+	raw    string
+	key    string
+	value  string
+	export bool
+}
+
+// File is a parsed .env file
+type File struct {
+	path  string
+	lines []line
+}
+
+// Load reads and parses the file at path. A missing file loads as empty and is
+// created by Save.
+func Load(path string) (*File, error) {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return &File{path: path}, nil
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("read env file: %w", err)
+	}
+
+	return Parse(path, data)
+}
+
+// Parse parses file contents; path is used in errors and by Save. Blank lines
+// and # comments are kept, an "export " prefix is allowed and quoted values are
+// unquoted. Unquoted values end at an inline " #" comment.
+func Parse(path string, data []byte) (*File, error) {
+	file := &File{path: path}
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+
+	for number := 1; scanner.Scan(); number++ {
+		raw := scanner.Text()
+		text := strings.TrimSpace(raw)
+
+		if text == "" || strings.HasPrefix(text, "#") {
+			file.lines = append(file.lines, line{raw: raw})
+
+			continue
+		}
+
+		export := strings.HasPrefix(text, "export ")
+
+		key, value, ok := strings.Cut(strings.TrimPrefix(text, "export "), "=")
+		key = strings.TrimSpace(key)
+
+		if !ok || !keyPattern.MatchString(key) {
+			return nil, fmt.Errorf("%s:%d: expected KEY=VALUE", path, number)
+		}
+
+		value, err := unquote(strings.TrimSpace(value))
+		if err != nil {
+			return nil, fmt.Errorf("%s:%d: %w", path, number, err)
+		}
+
+		file.lines = append(file.lines, line{raw: raw, key: key, value: value, export: export})
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("read env file: %w", err)
+	}
+
+	return file, nil
+}
+
+// unquote returns the value a raw assignment value stands for
+func unquote(value string) (string, error) {
+	switch {
+	case strings.HasPrefix(value, `"`):
+		unquoted, err := strconv.Unquote(value)
+		if err != nil {
+			return "", fmt.Errorf("invalid quoted value: %w", err)
+		}
+
+		return unquoted, nil
+	case len(value) >= 2 && strings.HasPrefix(value, "'") && strings.HasSuffix(value, "'"):
+		return value[1 : len(value)-1], nil
+	}
+
+	if i := strings.Index(value, " #"); i >= 0 {
+		value = strings.TrimSpace(value[:i])
+	}
+
+	return value, nil
+}
+
+// quote returns value as written in the file
+func quote(value string) string {
+	if plainValue.MatchString(value) {
+		return value
+	}
+
+	return strconv.Quote(value)
+}
+
+// Path returns the file's path
+func (f *File) Path() string {
+	return f.path
+}
+
+// Get returns a key's value. When a key is assigned more than once, the last
+// assignment wins, as in Compose.
+func (f *File) Get(key string) (string, bool) {
+	for i := len(f.lines) - 1; i >= 0; i-- {
+		if f.lines[i].key == key {
+			return f.lines[i].value, true
+		}
+	}
+
+	return "", false
+}
+
+// Set assigns a key, rewriting its last assignment or appending one
+func (f *File) Set(key, value string) error {
+	if !keyPattern.MatchString(key) {
+		return fmt.Errorf("%q: %w", key, ErrInvalidKey)
+	}
+
+	for i := len(f.lines) - 1; i >= 0; i-- {
+		if f.lines[i].key != key {
+			continue
+		}
+
+		prefix := ""
+		if f.lines[i].export {
+			prefix = "export "
+		}
+
+		f.lines[i].value = value
+		f.lines[i].raw = prefix + key + "=" + quote(value)
+
+		return nil
+	}
+
+	f.lines = append(f.lines, line{raw: key + "=" + quote(value), key: key, value: value})
+
+	return nil
+}
+
+// Keys returns the assigned keys in file order, each once
+func (f *File) Keys() []string {
+	seen := make(map[string]bool)
+
+	var keys []string
+
+	for _, l := range f.lines {
+		if l.key != "" && !seen[l.key] {
+			seen[l.key] = true
+			keys = append(keys, l.key)
+		}
+	}
+
+	return keys
+}
+
+// Values returns every key with its value
+func (f *File) Values() map[string]string {
+	values := make(map[string]string)
+
+	for _, key := range f.Keys() {
+		values[key], _ = f.Get(key)
+	}
+
+	return values
+}
+
+// Bytes returns the file contents
+func (f *File) Bytes() []byte {
+	var out bytes.Buffer
+
+	for _, l := range f.lines {
+		out.WriteString(l.raw)
+		out.WriteByte('\n')
+	}
+
+	return out.Bytes()
+}
+
+// Apply sets every key in the process environment, overriding variables already set
+func (f *File) Apply() error {
+	for key, value := range f.Values() {
+		if err := os.Setenv(key, value); err != nil {
+			return fmt.Errorf("set %s: %w", key, err)
+		}
+	}
+
+	return nil
+}
+
+// Save writes the file, replacing it atomically
+func (f *File) Save() error {
+	return writeAtomic(f.path, f.Bytes())
+}
+
+// writeAtomic writes data to a temporary file beside path and renames it over
+// path, keeping the mode of an existing file
+func writeAtomic(path string, data []byte) error {
+	mode := fs.FileMode(fileMode)
+	if info, err := os.Stat(path); err == nil {
+		mode = info.Mode().Perm()
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(path), "."+filepath.Base(path)+".*")
+	if err != nil {
+		return fmt.Errorf("write env file: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err = tmp.Write(data); err != nil {
+		tmp.Close()
+
+		return fmt.Errorf("write env file: %w", err)
+	}
+
+	if err = tmp.Chmod(mode); err != nil {
+		tmp.Close()
+
+		return fmt.Errorf("write env file: %w", err)
+	}
+
+	if err = tmp.Close(); err != nil {
+		return fmt.Errorf("write env file: %w", err)
+	}
+
+	if err = os.Rename(tmp.Name(), path); err != nil {
+		return fmt.Errorf("write env file: %w", err)
+	}
+
+	return nil
+}
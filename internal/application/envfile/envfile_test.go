@@ -0,0 +1,126 @@
+package envfile_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/goformx/goforms/internal/application/envfile"
+)
+
+const sample = `# database
+DB_HOST=db # the compose service
+export DB_PASSWORD="p@ss word"
+
+APP_NAME='Go Forms'
+APP_NAME=GoFormX
+`
+
+func TestParseAndEdit(t *testing.T) {
+	path := filepath.Join(t.TempDir(), ".env")
+	require.NoError(t, os.WriteFile(path, []byte(sample), 0o640))
+
+	file, err := envfile.Load(path)
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"DB_HOST", "DB_PASSWORD", "APP_NAME"}, file.Keys())
+	assert.Equal(t, map[string]string{
+		"DB_HOST":     "db",
+		"DB_PASSWORD": "p@ss word",
+		"APP_NAME":    "GoFormX",
+	}, file.Values())
+
+	require.NoError(t, file.Set("DB_PASSWORD", `new "secret"`))
+	require.NoError(t, file.Set("APP_NAME", "Forms"))
+	require.NoError(t, file.Set("SESSION_SECRET", "0123456789abcdef"))
+	require.ErrorIs(t, file.Set("NOT-A-KEY", "x"), envfile.ErrInvalidKey)
+	require.NoError(t, file.Save())
+
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, `# database
+DB_HOST=db # the compose service
+export DB_PASSWORD="new \"secret\""
+
+APP_NAME='Go Forms'
+APP_NAME=Forms
+SESSION_SECRET=0123456789abcdef
+`, string(data))
+
+	info, err := os.Stat(path)
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0o640), info.Mode().Perm(), "saving keeps the file mode")
+
+	reloaded, err := envfile.Load(path)
+	require.NoError(t, err)
+	assert.Equal(t, file.Values(), reloaded.Values())
+}
+
+func TestParseRejectsMalformedLines(t *testing.T) {
+	_, err := envfile.Parse(".env", []byte("NOT A VARIABLE\n"))
+	require.Error(t, err)
+
+	_, err = envfile.Parse(".env", []byte("KEY=\"unterminated\n"))
+	require.Error(t, err)
+}
+
+func TestLoadMissingFileIsEmpty(t *testing.T) {
+	path := filepath.Join(t.TempDir(), ".env")
+
+	file, err := envfile.Load(path)
+	require.NoError(t, err)
+	assert.Empty(t, file.Keys())
+
+	require.NoError(t, file.Set("APP_ENV", "production"))
+	require.NoError(t, file.Save())
+
+	info, err := os.Stat(path)
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0o600), info.Mode().Perm())
+}
+
+func TestEncryptedMetadata(t *testing.T) {
+	file, err := envfile.Parse(".env", []byte(`APP_ENV=ENC[AES256_GCM,data:abc,type:str]
+sops_age__list_0__map_recipient=age1first
+sops_age__list_1__map_recipient=age1second
+sops_version=3.9.0
+`))
+	require.NoError(t, err)
+
+	assert.True(t, file.Encrypted())
+	assert.Equal(t, []string{"age1first", "age1second"}, file.AgeRecipients())
+}
+
+func TestWarnings(t *testing.T) {
+	file, err := envfile.Parse(".env", []byte(`SESSION_SECRET=session-secret
+SECURITY_CSRF_SECRET=from-the-example
+DB_PASSWORD=a-real-password
+`))
+	require.NoError(t, err)
+
+	example, err := envfile.Parse(".env.example", []byte("SECURITY_CSRF_SECRET=from-the-example\n"))
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{
+		"SESSION_SECRET is the built-in default",
+		"SECURITY_CSRF_SECRET is copied from .env.example",
+		"GOFORMS_SHARED_SECRET is not set",
+	}, envfile.Warnings(file, example))
+}
+
+func TestProblemsIntroduced(t *testing.T) {
+	t.Setenv("APP_PORT", "8090")
+
+	before, err := envfile.Problems(map[string]string{"APP_PORT": "8090"})
+	require.NoError(t, err)
+
+	after, err := envfile.Problems(map[string]string{"APP_PORT": "0"})
+	require.NoError(t, err)
+
+	introduced := envfile.Introduced(before, after)
+	assert.Contains(t, introduced, "app.port: must be at least 1")
+	assert.Equal(t, "8090", os.Getenv("APP_PORT"), "the environment is restored")
+}
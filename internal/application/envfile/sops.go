@@ -0,0 +1,97 @@
+package envfile
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os/exec"
+	"strings"
+)
+
+// sopsCommand is the sops executable
+var sopsCommand = "sops"
+
+// sopsPrefix starts the keys sops stores its metadata under in dotenv files
+const sopsPrefix = "sops_"
+
+// Encrypted reports whether the file holds sops metadata
+func (f *File) Encrypted() bool {
+	for _, key := range f.Keys() {
+		if strings.HasPrefix(key, sopsPrefix) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// AgeRecipients returns the age recipients of an encrypted file
+func (f *File) AgeRecipients() []string {
+	var recipients []string
+
+	for _, key := range f.Keys() {
+		if strings.HasPrefix(key, sopsPrefix+"age__") && strings.HasSuffix(key, "__map_recipient") {
+			value, _ := f.Get(key)
+			recipients = append(recipients, value)
+		}
+	}
+
+	return recipients
+}
+
+// Open loads the file at path. An encrypted file is decrypted with sops; the
+// returned recipients are its age recipients, for SaveEncrypted.
+func Open(ctx context.Context, path string) (file *File, encrypted bool, recipients []string, err error) {
+	file, err = Load(path)
+	if err != nil || !file.Encrypted() {
+		return file, false, nil, err
+	}
+
+	recipients = file.AgeRecipients()
+
+	plain, err := runSops(ctx, nil, "--decrypt", "--input-type", "dotenv", "--output-type", "dotenv", path)
+	if err != nil {
+		return nil, true, nil, err
+	}
+
+	file, err = Parse(path, plain)
+
+	return file, true, recipients, err
+}
+
+// SaveEncrypted encrypts the file with sops and writes it over its path.
+// recipients are age public keys; without any, sops applies the creation
+// rules of .sops.yaml for the file's path.
+func (f *File) SaveEncrypted(ctx context.Context, recipients []string) error {
+	args := []string{
+		"--encrypt", "--input-type", "dotenv", "--output-type", "dotenv",
+		"--filename-override", f.path,
+	}
+
+	if len(recipients) > 0 {
+		args = append(args, "--age", strings.Join(recipients, ","))
+	}
+
+	encrypted, err := runSops(ctx, f.Bytes(), append(args, "/dev/stdin")...)
+	if err != nil {
+		return err
+	}
+
+	return writeAtomic(f.path, encrypted)
+}
+
+// runSops runs sops with stdin and returns its output
+func runSops(ctx context.Context, stdin []byte, args ...string) ([]byte, error) {
+	cmd := exec.CommandContext(ctx, sopsCommand, args...)
+	cmd.Stdin = bytes.NewReader(stdin)
+
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+
+	out, err := cmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf("sops %s: %w: %s", args[0], err, strings.TrimSpace(stderr.String()))
+	}
+
+	return out, nil
+}
@@ -0,0 +1,139 @@
+package web
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+
+	"github.com/goformx/goforms/internal/application/constants"
+	"github.com/goformx/goforms/internal/application/middleware/assertion"
+	mwcontext "github.com/goformx/goforms/internal/application/middleware/context"
+	"github.com/goformx/goforms/internal/application/response"
+	"github.com/goformx/goforms/internal/domain/entities"
+	"github.com/goformx/goforms/internal/domain/user"
+	"github.com/goformx/goforms/internal/infrastructure/repository/common"
+)
+
+// AccountDeletionHandler lets the requesting user delete their own account at
+// /api/v1/account/deletion. The deletion is carried out once the grace period
+// ends, unless it is cancelled first. Requests authenticate with a signed
+// user assertion; there is no session.
+type AccountDeletionHandler struct {
+	*BaseHandler
+	Deletions           user.DeletionService
+	AssertionMiddleware *assertion.Middleware
+
+	routes routeRecorder
+}
+
+// NewAccountDeletionHandler creates a new AccountDeletionHandler
+func NewAccountDeletionHandler(base *BaseHandler, deletions user.DeletionService) *AccountDeletionHandler {
+	return &AccountDeletionHandler{
+		BaseHandler:         base,
+		Deletions:           deletions,
+		AssertionMiddleware: assertion.NewMiddleware(base.Config, base.Logger),
+	}
+}
+
+// RegisterRoutes registers the account deletion routes
+func (h *AccountDeletionHandler) RegisterRoutes(e *echo.Echo) {
+	h.routes.reset()
+
+	deletion := h.routes.group(e, constants.PathAPIAccount+"/deletion", "assertion",
+		named("assertion", h.AssertionMiddleware.Verify()))
+	deletion.GET("", h.handleStatus)
+	deletion.POST("", h.handleRequest)
+	deletion.DELETE("", h.handleCancel)
+}
+
+// DescribeRoutes describes how the account routes authenticate
+func (h *AccountDeletionHandler) DescribeRoutes() []RouteGroup {
+	return h.routes.described()
+}
+
+// Register satisfies the Handler interface; routes are registered by RegisterHandlers
+func (h *AccountDeletionHandler) Register(_ *echo.Echo) {}
+
+// Start initializes the handler
+func (h *AccountDeletionHandler) Start(_ context.Context) error {
+	return nil
+}
+
+// Stop cleans up the handler
+func (h *AccountDeletionHandler) Stop(_ context.Context) error {
+	return nil
+}
+
+// actor returns the user the assertion middleware verified the request for
+func (h *AccountDeletionHandler) actor(c echo.Context) (user.Actor, bool) {
+	id, ok := mwcontext.GetUserID(c)
+
+	return user.Actor{ID: id, IPAddress: c.RealIP()}, ok
+}
+
+// GET /api/v1/account/deletion - whether a deletion of the account is pending and when it is carried out
+func (h *AccountDeletionHandler) handleStatus(c echo.Context) error {
+	actor, ok := h.actor(c)
+	if !ok {
+		return response.ErrorResponse(c, http.StatusUnauthorized, "Authentication required")
+	}
+
+	u, err := h.UserService.GetUserByID(c.Request().Context(), actor.ID)
+	if err != nil {
+		return h.deletionError(c, err, "Failed to get account deletion")
+	}
+
+	return response.Success(c, deletionStatus(u))
+}
+
+// POST /api/v1/account/deletion - schedules the deletion of the account for the end of the grace period
+func (h *AccountDeletionHandler) handleRequest(c echo.Context) error {
+	actor, ok := h.actor(c)
+	if !ok {
+		return response.ErrorResponse(c, http.StatusUnauthorized, "Authentication required")
+	}
+
+	u, err := h.Deletions.RequestDeletion(c.Request().Context(), actor)
+	if err != nil {
+		return h.deletionError(c, err, "Failed to request account deletion")
+	}
+
+	return response.Success(c, deletionStatus(u))
+}
+
+// DELETE /api/v1/account/deletion - cancels a pending deletion of the account
+func (h *AccountDeletionHandler) handleCancel(c echo.Context) error {
+	actor, ok := h.actor(c)
+	if !ok {
+		return response.ErrorResponse(c, http.StatusUnauthorized, "Authentication required")
+	}
+
+	u, err := h.Deletions.CancelDeletion(c.Request().Context(), actor)
+	if err != nil {
+		return h.deletionError(c, err, "Failed to cancel account deletion")
+	}
+
+	return response.Success(c, deletionStatus(u))
+}
+
+// deletionError maps account deletion errors to responses
+func (h *AccountDeletionHandler) deletionError(c echo.Context, err error, message string) error {
+	if errors.Is(err, common.ErrNotFound) {
+		return response.ErrorResponse(c, http.StatusNotFound, "Account not found")
+	}
+
+	h.Logger.Error("account deletion request failed", "path", c.Path(), "error", err)
+
+	return response.ErrorResponse(c, http.StatusInternalServerError, message)
+}
+
+// deletionStatus describes the pending deletion of an account
+func deletionStatus(u *entities.User) map[string]any {
+	return map[string]any{
+		"pending":      u.DeletionPending(),
+		"requested_at": u.DeletionRequestedAt,
+		"scheduled_at": u.DeletionScheduledAt,
+	}
+}
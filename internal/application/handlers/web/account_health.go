@@ -0,0 +1,84 @@
+package web
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/labstack/echo/v4"
+
+	"github.com/goformx/goforms/internal/application/constants"
+	"github.com/goformx/goforms/internal/application/middleware/assertion"
+	mwcontext "github.com/goformx/goforms/internal/application/middleware/context"
+	"github.com/goformx/goforms/internal/application/response"
+	formdomain "github.com/goformx/goforms/internal/domain/form"
+)
+
+// AccountHealthHandler previews the weekly account health report at
+// /api/v1/account/health-report. Users opt in to receiving it by email through
+// their dashboard preferences. Requests authenticate with a signed user
+// assertion; there is no session.
+type AccountHealthHandler struct {
+	*BaseHandler
+	Reporter            *formdomain.HealthReporter
+	AssertionMiddleware *assertion.Middleware
+
+	routes routeRecorder
+}
+
+// NewAccountHealthHandler creates a new AccountHealthHandler
+func NewAccountHealthHandler(base *BaseHandler, reporter *formdomain.HealthReporter) *AccountHealthHandler {
+	return &AccountHealthHandler{
+		BaseHandler:         base,
+		Reporter:            reporter,
+		AssertionMiddleware: assertion.NewMiddleware(base.Config, base.Logger),
+	}
+}
+
+// RegisterRoutes registers the account health report route
+func (h *AccountHealthHandler) RegisterRoutes(e *echo.Echo) {
+	h.routes.reset()
+
+	account := h.routes.group(e, constants.PathAPIAccount+"/health-report", "assertion",
+		named("assertion", h.AssertionMiddleware.Verify()))
+	account.GET("", h.handlePreview)
+}
+
+// DescribeRoutes describes how the account health report route authenticates
+func (h *AccountHealthHandler) DescribeRoutes() []RouteGroup {
+	return h.routes.described()
+}
+
+// Register satisfies the Handler interface; routes are registered by RegisterHandlers
+func (h *AccountHealthHandler) Register(_ *echo.Echo) {}
+
+// Start initializes the handler
+func (h *AccountHealthHandler) Start(_ context.Context) error {
+	return nil
+}
+
+// Stop cleans up the handler
+func (h *AccountHealthHandler) Stop(_ context.Context) error {
+	return nil
+}
+
+// GET /api/v1/account/health-report - the account health report of the past
+// week, as the weekly email would carry it
+func (h *AccountHealthHandler) handlePreview(c echo.Context) error {
+	userID, ok := mwcontext.GetUserID(c)
+	if !ok {
+		return response.ErrorResponse(c, http.StatusUnauthorized, "Authentication required")
+	}
+
+	now := time.Now().UTC()
+
+	report, err := h.Reporter.Build(c.Request().Context(), userID, now.Add(-formdomain.HealthReportPeriod), now)
+	if err != nil {
+		h.Logger.Error("failed to build account health report", "user_id", h.Logger.SanitizeField("user_id", userID),
+			"error", err)
+
+		return response.ErrorResponse(c, http.StatusInternalServerError, "Failed to build health report")
+	}
+
+	return response.Success(c, map[string]any{"report": report})
+}
@@ -0,0 +1,68 @@
+package web
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+
+	"github.com/goformx/goforms/internal/application/constants"
+	"github.com/goformx/goforms/internal/application/response"
+	"github.com/goformx/goforms/internal/domain/audit"
+)
+
+// AdminAuditHandler serves the audit log under /api/v1/admin/audit. Admin
+// access is enforced by the access middleware.
+type AdminAuditHandler struct {
+	*BaseHandler
+	Audit audit.Service
+}
+
+// NewAdminAuditHandler creates a new AdminAuditHandler
+func NewAdminAuditHandler(base *BaseHandler, auditService audit.Service) *AdminAuditHandler {
+	return &AdminAuditHandler{BaseHandler: base, Audit: auditService}
+}
+
+// RegisterRoutes registers the audit log routes
+func (h *AdminAuditHandler) RegisterRoutes(e *echo.Echo) {
+	e.GET(constants.PathAPIAdminAudit, h.handleListEntries)
+}
+
+// Register satisfies the Handler interface; routes are registered by RegisterHandlers
+func (h *AdminAuditHandler) Register(_ *echo.Echo) {}
+
+// Start initializes the handler
+func (h *AdminAuditHandler) Start(_ context.Context) error {
+	return nil
+}
+
+// Stop cleans up the handler
+func (h *AdminAuditHandler) Stop(_ context.Context) error {
+	return nil
+}
+
+// GET /api/v1/admin/audit?actor_id=&target_id=&action=&offset=&limit= - lists audit entries, newest first
+func (h *AdminAuditHandler) handleListEntries(c echo.Context) error {
+	offset, limit, err := parsePage(c)
+	if err != nil {
+		return response.ErrorResponse(c, http.StatusBadRequest, err.Error())
+	}
+
+	filter := audit.Filter{
+		ActorID:  c.QueryParam("actor_id"),
+		TargetID: c.QueryParam("target_id"),
+		Action:   c.QueryParam("action"),
+	}
+
+	entries, total, err := h.Audit.List(c.Request().Context(), filter, offset, limit)
+	if err != nil {
+		return h.HandleError(c, err, "Failed to list audit entries")
+	}
+
+	return response.Success(c, map[string]any{
+		"entries": entries,
+		"total":   total,
+		"offset":  offset,
+		"limit":   limit,
+	})
+}
@@ -0,0 +1,145 @@
+package web
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+
+	"github.com/goformx/goforms/internal/application/constants"
+	mwcontext "github.com/goformx/goforms/internal/application/middleware/context"
+	"github.com/goformx/goforms/internal/application/response"
+	"github.com/goformx/goforms/internal/domain/audit"
+	"github.com/goformx/goforms/internal/domain/backup"
+)
+
+// AdminBackupHandler lists, takes, verifies and restores application backups
+// under /api/v1/admin/backups. Admin access is enforced by the access middleware.
+type AdminBackupHandler struct {
+	*BaseHandler
+	// Backups is nil when storage.backup.enabled is unset
+	Backups backup.Service
+	Audit   audit.Service
+}
+
+// NewAdminBackupHandler creates a new AdminBackupHandler
+func NewAdminBackupHandler(base *BaseHandler, backups backup.Service, auditService audit.Service) *AdminBackupHandler {
+	return &AdminBackupHandler{BaseHandler: base, Backups: backups, Audit: auditService}
+}
+
+// RegisterRoutes registers the backup routes
+func (h *AdminBackupHandler) RegisterRoutes(e *echo.Echo) {
+	backups := e.Group(constants.PathAPIAdminBackups)
+	backups.Use(h.requireBackups)
+	backups.GET("", h.handleList)
+	backups.POST("", h.handleCreate)
+	backups.POST("/:id/verify", h.handleVerify)
+	backups.POST("/:id/restore", h.handleRestore)
+}
+
+// DescribeRoutes describes the group middleware RegisterRoutes applies
+func (h *AdminBackupHandler) DescribeRoutes() []RouteGroup {
+	return []RouteGroup{{Prefix: constants.PathAPIAdminBackups, Middleware: []string{"require_backups"}}}
+}
+
+// Register satisfies the Handler interface; routes are registered by RegisterHandlers
+func (h *AdminBackupHandler) Register(_ *echo.Echo) {}
+
+// Start initializes the handler
+func (h *AdminBackupHandler) Start(_ context.Context) error {
+	return nil
+}
+
+// Stop cleans up the handler
+func (h *AdminBackupHandler) Stop(_ context.Context) error {
+	return nil
+}
+
+// requireBackups answers 409 while backups are disabled
+func (h *AdminBackupHandler) requireBackups(next echo.HandlerFunc) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		if h.Backups == nil {
+			return response.ErrorResponse(c, http.StatusConflict, "Backups are disabled")
+		}
+
+		return next(c)
+	}
+}
+
+// GET /api/v1/admin/backups - lists the stored backups, newest first
+func (h *AdminBackupHandler) handleList(c echo.Context) error {
+	backups, err := h.Backups.List(c.Request().Context())
+	if err != nil {
+		return h.HandleError(c, err, "Failed to list backups")
+	}
+
+	return response.Success(c, map[string]any{"backups": backups})
+}
+
+// POST /api/v1/admin/backups - takes a backup now
+func (h *AdminBackupHandler) handleCreate(c echo.Context) error {
+	b, err := h.Backups.Create(c.Request().Context(), backup.TriggerManual)
+	if err != nil {
+		return h.HandleError(c, err, "Failed to create backup")
+	}
+
+	h.record(c, audit.ActionBackupCreated, b)
+
+	return c.JSON(http.StatusCreated, response.APIResponse{Success: true, Data: b})
+}
+
+// POST /api/v1/admin/backups/:id/verify - checks a backup's checksums and that it decrypts
+func (h *AdminBackupHandler) handleVerify(c echo.Context) error {
+	b, err := h.Backups.Verify(c.Request().Context(), c.Param("id"))
+	if err != nil {
+		return h.handleBackupError(c, err, "Failed to verify backup")
+	}
+
+	return response.Success(c, map[string]any{"backup": b, "valid": true})
+}
+
+// POST /api/v1/admin/backups/:id/restore - restores a backup's forms and submissions.
+// Rows with the same IDs are replaced; rows created after the backup are kept.
+func (h *AdminBackupHandler) handleRestore(c echo.Context) error {
+	b, err := h.Backups.Restore(c.Request().Context(), c.Param("id"))
+	if err != nil {
+		return h.handleBackupError(c, err, "Failed to restore backup")
+	}
+
+	h.record(c, audit.ActionBackupRestored, b)
+
+	return response.Success(c, map[string]any{"backup": b})
+}
+
+// handleBackupError maps backup errors to responses
+func (h *AdminBackupHandler) handleBackupError(c echo.Context, err error, message string) error {
+	switch {
+	case errors.Is(err, backup.ErrBackupNotFound):
+		return response.ErrorResponse(c, http.StatusNotFound, "Backup not found")
+	case errors.Is(err, backup.ErrChecksumMismatch), errors.Is(err, backup.ErrDecrypt),
+		errors.Is(err, backup.ErrUnsupportedVersion):
+		h.Logger.Error("backup failed verification", "backup_id", c.Param("id"), "error", err)
+
+		return response.ErrorResponse(c, http.StatusUnprocessableEntity, err.Error())
+	default:
+		return h.HandleError(c, err, message)
+	}
+}
+
+// record writes an audit entry for a backup action; a failed write is logged
+func (h *AdminBackupHandler) record(c echo.Context, action string, b *backup.Backup) {
+	actorID, _ := mwcontext.GetUserID(c)
+
+	err := h.Audit.Record(c.Request().Context(), &audit.Entry{
+		ActorID:    actorID,
+		Action:     action,
+		TargetType: audit.TargetBackup,
+		TargetID:   b.ID,
+		Details:    map[string]any{"forms": b.Forms, "submissions": b.Submissions},
+		IPAddress:  c.RealIP(),
+	})
+	if err != nil {
+		h.Logger.Error("failed to record backup audit entry", "backup_id", b.ID, "action", action, "error", err)
+	}
+}
@@ -0,0 +1,243 @@
+package web
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+
+	"github.com/goformx/goforms/internal/application/constants"
+	mwcontext "github.com/goformx/goforms/internal/application/middleware/context"
+	"github.com/goformx/goforms/internal/application/response"
+	"github.com/goformx/goforms/internal/domain/audit"
+	"github.com/goformx/goforms/internal/domain/deadletter"
+)
+
+// AdminDeadLetterHandler inspects, retries and purges dead-lettered background
+// work under /api/v1/admin/dead-letters. Admin access is enforced by the access middleware.
+type AdminDeadLetterHandler struct {
+	*BaseHandler
+	// DeadLetters is nil when dead_letter.enabled is unset
+	DeadLetters deadletter.Service
+	Audit       audit.Service
+}
+
+// NewAdminDeadLetterHandler creates a new AdminDeadLetterHandler
+func NewAdminDeadLetterHandler(
+	base *BaseHandler, deadLetters deadletter.Service, auditService audit.Service,
+) *AdminDeadLetterHandler {
+	return &AdminDeadLetterHandler{
+		BaseHandler: base,
+		DeadLetters: deadLetters,
+		Audit:       auditService,
+	}
+}
+
+// DeadLetterSelection selects dead letters to retry or purge; empty fields match everything
+type DeadLetterSelection struct {
+	// IDs selects the listed entries
+	IDs  []string `json:"ids"`
+	Kind string   `json:"kind"`
+	// Status is pending or resolved; retries only ever select pending entries
+	Status string `json:"status"`
+}
+
+// filter converts the selection to a dead-letter filter
+func (s DeadLetterSelection) filter() deadletter.Filter {
+	return deadletter.Filter{Kind: s.Kind, Status: s.Status, IDs: s.IDs}
+}
+
+// RegisterRoutes registers the dead-letter routes
+func (h *AdminDeadLetterHandler) RegisterRoutes(e *echo.Echo) {
+	letters := e.Group(constants.PathAPIAdminDeadLetters)
+	letters.Use(h.requireDeadLetters)
+	letters.GET("", h.handleList)
+	letters.POST("/retry", h.handleRetryAll)
+	letters.POST("/purge", h.handlePurge)
+	letters.GET("/:id", h.handleGet)
+	letters.POST("/:id/retry", h.handleRetry)
+	letters.DELETE("/:id", h.handleDelete)
+}
+
+// DescribeRoutes describes the group middleware RegisterRoutes applies
+func (h *AdminDeadLetterHandler) DescribeRoutes() []RouteGroup {
+	return []RouteGroup{{Prefix: constants.PathAPIAdminDeadLetters, Middleware: []string{"require_dead_letters"}}}
+}
+
+// Register satisfies the Handler interface; routes are registered by RegisterHandlers
+func (h *AdminDeadLetterHandler) Register(_ *echo.Echo) {}
+
+// Start initializes the handler
+func (h *AdminDeadLetterHandler) Start(_ context.Context) error {
+	return nil
+}
+
+// Stop cleans up the handler
+func (h *AdminDeadLetterHandler) Stop(_ context.Context) error {
+	return nil
+}
+
+// requireDeadLetters answers 409 while the dead-letter queue is disabled
+func (h *AdminDeadLetterHandler) requireDeadLetters(next echo.HandlerFunc) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		if h.DeadLetters == nil {
+			return response.ErrorResponse(c, http.StatusConflict, "The dead-letter queue is disabled")
+		}
+
+		return next(c)
+	}
+}
+
+// GET /api/v1/admin/dead-letters?kind=&status=&offset=&limit= - lists dead letters, newest first
+func (h *AdminDeadLetterHandler) handleList(c echo.Context) error {
+	offset, limit, err := parsePage(c)
+	if err != nil {
+		return response.ErrorResponse(c, http.StatusBadRequest, err.Error())
+	}
+
+	filter := deadletter.Filter{Kind: c.QueryParam("kind"), Status: c.QueryParam("status")}
+
+	entries, total, err := h.DeadLetters.List(c.Request().Context(), filter, offset, limit)
+	if errors.Is(err, deadletter.ErrInvalidStatus) {
+		return response.ErrorResponse(c, http.StatusBadRequest, err.Error())
+	}
+
+	if err != nil {
+		return h.HandleError(c, err, "Failed to list dead letters")
+	}
+
+	return response.Success(c, map[string]any{
+		"dead_letters": entries,
+		"total":        total,
+		"offset":       offset,
+		"limit":        limit,
+	})
+}
+
+// GET /api/v1/admin/dead-letters/:id - returns a dead letter with its payload and error history
+func (h *AdminDeadLetterHandler) handleGet(c echo.Context) error {
+	entry, err := h.DeadLetters.Get(c.Request().Context(), c.Param("id"))
+	if errors.Is(err, deadletter.ErrEntryNotFound) {
+		return response.ErrorResponse(c, http.StatusNotFound, "Dead letter not found")
+	}
+
+	if err != nil {
+		return h.HandleError(c, err, "Failed to get dead letter")
+	}
+
+	return response.Success(c, map[string]any{"dead_letter": entry})
+}
+
+// POST /api/v1/admin/dead-letters/:id/retry - runs a pending dead letter's work
+// again. A retry that fails again is reported with the updated entry.
+func (h *AdminDeadLetterHandler) handleRetry(c echo.Context) error {
+	id := c.Param("id")
+
+	entry, err := h.DeadLetters.Retry(c.Request().Context(), id)
+
+	switch {
+	case errors.Is(err, deadletter.ErrEntryNotFound):
+		return response.ErrorResponse(c, http.StatusNotFound, "Dead letter not found")
+	case errors.Is(err, deadletter.ErrAlreadyResolved), errors.Is(err, deadletter.ErrNoRetryHandler):
+		return response.ErrorResponse(c, http.StatusConflict, err.Error())
+	case err != nil && !errors.Is(err, deadletter.ErrRetryFailed):
+		return h.HandleError(c, err, "Failed to retry dead letter")
+	}
+
+	h.record(c, audit.ActionDeadLettersRetried, id, map[string]any{
+		"kind":    entry.Kind,
+		"retried": err == nil,
+	})
+
+	result := map[string]any{"dead_letter": entry, "retried": err == nil}
+	if err != nil {
+		result["error"] = err.Error()
+	}
+
+	return response.Success(c, result)
+}
+
+// POST /api/v1/admin/dead-letters/retry - retries the selected pending dead
+// letters, oldest first, and reports each failure
+func (h *AdminDeadLetterHandler) handleRetryAll(c echo.Context) error {
+	var req DeadLetterSelection
+	if err := c.Bind(&req); err != nil {
+		return response.ErrorResponse(c, http.StatusBadRequest, "Invalid request body")
+	}
+
+	report, err := h.DeadLetters.RetryAll(c.Request().Context(), req.filter())
+	if err != nil {
+		return h.HandleError(c, err, "Failed to retry dead letters")
+	}
+
+	h.record(c, audit.ActionDeadLettersRetried, "", map[string]any{
+		"kind":    req.Kind,
+		"ids":     req.IDs,
+		"retried": report.Retried,
+		"failed":  len(report.Failed),
+	})
+
+	return response.Success(c, map[string]any{"retry": report})
+}
+
+// POST /api/v1/admin/dead-letters/purge - deletes the selected dead letters
+func (h *AdminDeadLetterHandler) handlePurge(c echo.Context) error {
+	var req DeadLetterSelection
+	if err := c.Bind(&req); err != nil {
+		return response.ErrorResponse(c, http.StatusBadRequest, "Invalid request body")
+	}
+
+	deleted, err := h.DeadLetters.Purge(c.Request().Context(), req.filter())
+	if errors.Is(err, deadletter.ErrInvalidStatus) {
+		return response.ErrorResponse(c, http.StatusBadRequest, err.Error())
+	}
+
+	if err != nil {
+		return h.HandleError(c, err, "Failed to purge dead letters")
+	}
+
+	h.record(c, audit.ActionDeadLettersPurged, "", map[string]any{
+		"kind":    req.Kind,
+		"status":  req.Status,
+		"ids":     req.IDs,
+		"deleted": deleted,
+	})
+
+	return response.Success(c, map[string]any{"deleted": deleted})
+}
+
+// DELETE /api/v1/admin/dead-letters/:id - deletes a dead letter
+func (h *AdminDeadLetterHandler) handleDelete(c echo.Context) error {
+	id := c.Param("id")
+
+	deleted, err := h.DeadLetters.Purge(c.Request().Context(), deadletter.Filter{IDs: []string{id}})
+	if err != nil {
+		return h.HandleError(c, err, "Failed to delete dead letter")
+	}
+
+	if deleted == 0 {
+		return response.ErrorResponse(c, http.StatusNotFound, "Dead letter not found")
+	}
+
+	h.record(c, audit.ActionDeadLettersPurged, id, map[string]any{"deleted": deleted})
+
+	return c.NoContent(http.StatusNoContent)
+}
+
+// record writes an audit entry for an admin action; a failed write is logged
+func (h *AdminDeadLetterHandler) record(c echo.Context, action, targetID string, details map[string]any) {
+	actorID, _ := mwcontext.GetUserID(c)
+
+	err := h.Audit.Record(c.Request().Context(), &audit.Entry{
+		ActorID:    actorID,
+		Action:     action,
+		TargetType: audit.TargetDeadLetter,
+		TargetID:   targetID,
+		Details:    details,
+		IPAddress:  c.RealIP(),
+	})
+	if err != nil {
+		h.Logger.Error("failed to record dead letter audit entry", "action", action, "target_id", targetID, "error", err)
+	}
+}
@@ -0,0 +1,125 @@
+package web_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/goformx/goforms/internal/application/constants"
+	"github.com/goformx/goforms/internal/application/handlers/web"
+	"github.com/goformx/goforms/internal/domain/audit"
+	"github.com/goformx/goforms/internal/domain/deadletter"
+	memorystore "github.com/goformx/goforms/internal/infrastructure/repository/memory"
+	mocklogging "github.com/goformx/goforms/test/mocks/logging"
+)
+
+func TestAdminDeadLetterHandler(t *testing.T) {
+	ctrl := gomock.NewController(t)
+
+	logger := mocklogging.NewMockLogger(ctrl)
+	logger.EXPECT().Info(gomock.Any(), gomock.Any()).AnyTimes()
+	logger.EXPECT().Warn(gomock.Any(), gomock.Any()).AnyTimes()
+
+	store := memorystore.NewStore(logger)
+	auditService := audit.NewService(store.Audit(), logger)
+	letters := deadletter.NewService(memorystore.NewDeadLetterStore(), time.Hour, logger)
+
+	failing := true
+
+	letters.Handle(deadletter.KindEmail, func(context.Context, *deadletter.Entry) error {
+		if failing {
+			return errors.New("connection refused")
+		}
+
+		return nil
+	})
+
+	ctx := context.Background()
+	require.NoError(t, letters.Record(ctx, deadletter.KindEmail, "welcome", map[string]string{"subject": "Hi"}, errors.New("timeout")))
+	require.NoError(t, letters.Record(ctx, deadletter.KindWebhook, "hook", map[string]string{}, errors.New("502")))
+
+	e := echo.New()
+	e.Use(func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			c.Set("user_id", "admin-1")
+			c.Set("role", "admin")
+
+			return next(c)
+		}
+	})
+	web.NewAdminDeadLetterHandler(&web.BaseHandler{Logger: logger}, letters, auditService).RegisterRoutes(e)
+
+	call := func(method, path, body string) (int, map[string]any) {
+		req := httptest.NewRequest(method, path, strings.NewReader(body))
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, req)
+
+		var payload struct {
+			Data map[string]any `json:"data"`
+		}
+		if rec.Body.Len() > 0 {
+			require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &payload))
+		}
+
+		return rec.Code, payload.Data
+	}
+
+	code, data := call(http.MethodGet, constants.PathAPIAdminDeadLetters+"?kind=email", "")
+	require.Equal(t, http.StatusOK, code)
+	require.Len(t, data["dead_letters"], 1)
+
+	entry, ok := data["dead_letters"].([]any)[0].(map[string]any)
+	require.True(t, ok)
+	id, _ := entry["id"].(string)
+	assert.Equal(t, map[string]any{"subject": "Hi"}, entry["payload"])
+
+	code, _ = call(http.MethodGet, constants.PathAPIAdminDeadLetters+"?status=lost", "")
+	assert.Equal(t, http.StatusBadRequest, code)
+
+	// A retry that fails again is reported with the entry's history
+	code, data = call(http.MethodPost, constants.PathAPIAdminDeadLetters+"/"+id+"/retry", "")
+	require.Equal(t, http.StatusOK, code)
+	assert.Equal(t, false, data["retried"])
+	assert.Len(t, data["dead_letter"].(map[string]any)["history"], 2)
+
+	failing = false
+
+	code, data = call(http.MethodPost, constants.PathAPIAdminDeadLetters+"/retry", `{"kind":"email"}`)
+	require.Equal(t, http.StatusOK, code)
+	assert.InDelta(t, 1, data["retry"].(map[string]any)["retried"], 0)
+
+	code, _ = call(http.MethodPost, constants.PathAPIAdminDeadLetters+"/"+id+"/retry", "")
+	assert.Equal(t, http.StatusConflict, code)
+
+	code, data = call(http.MethodPost, constants.PathAPIAdminDeadLetters+"/purge", `{"status":"resolved"}`)
+	require.Equal(t, http.StatusOK, code)
+	assert.InDelta(t, 1, data["deleted"], 0)
+
+	code, _ = call(http.MethodDelete, constants.PathAPIAdminDeadLetters+"/"+id, "")
+	assert.Equal(t, http.StatusNotFound, code)
+
+	entries, _, err := auditService.List(ctx, audit.Filter{ActorID: "admin-1"}, 0, 10)
+	require.NoError(t, err)
+	assert.Len(t, entries, 3)
+}
+
+func TestAdminDeadLetterHandler_Disabled(t *testing.T) {
+	e := echo.New()
+	web.NewAdminDeadLetterHandler(&web.BaseHandler{}, nil, nil).RegisterRoutes(e)
+
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, constants.PathAPIAdminDeadLetters, nil))
+	assert.Equal(t, http.StatusConflict, rec.Code)
+}
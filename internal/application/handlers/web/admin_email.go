@@ -0,0 +1,123 @@
+package web
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/mail"
+	"time"
+
+	"github.com/labstack/echo/v4"
+
+	"github.com/goformx/goforms/internal/application/constants"
+	"github.com/goformx/goforms/internal/application/response"
+	"github.com/goformx/goforms/internal/domain/emaildelivery"
+	"github.com/goformx/goforms/internal/domain/emailtemplate"
+	"github.com/goformx/goforms/internal/infrastructure/email"
+)
+
+// testEmailTimeout bounds a test send, including retries
+const testEmailTimeout = time.Minute
+
+// AdminEmailHandler serves the admin email endpoints under /api/v1/admin/email.
+// Admin access is enforced by the access middleware.
+type AdminEmailHandler struct {
+	*BaseHandler
+	Sender     email.Sender
+	Templates  emailtemplate.Service
+	Deliveries emaildelivery.Service
+}
+
+// NewAdminEmailHandler creates a new AdminEmailHandler
+func NewAdminEmailHandler(
+	base *BaseHandler,
+	sender email.Sender,
+	templates emailtemplate.Service,
+	deliveries emaildelivery.Service,
+) *AdminEmailHandler {
+	return &AdminEmailHandler{BaseHandler: base, Sender: sender, Templates: templates, Deliveries: deliveries}
+}
+
+// TestEmailRequest is the body of POST /api/v1/admin/email/test
+type TestEmailRequest struct {
+	To string `json:"to"`
+}
+
+// RegisterRoutes registers the admin email routes
+func (h *AdminEmailHandler) RegisterRoutes(e *echo.Echo) {
+	adminEmail := e.Group(constants.PathAPIAdminEmail)
+	adminEmail.POST("/test", h.handleTestEmail)
+
+	templates := adminEmail.Group("/templates")
+	templates.GET("", h.handleListTemplates)
+	templates.GET("/:kind", h.handleGetTemplate)
+	templates.PUT("/:kind", h.handleSaveTemplate)
+	templates.DELETE("/:kind", h.handleDeleteTemplate)
+	templates.POST("/:kind/preview", h.handlePreviewTemplate)
+
+	suppressions := adminEmail.Group("/suppressions")
+	suppressions.GET("", h.handleListSuppressions)
+	suppressions.DELETE("/:address", h.handleDeleteSuppression)
+}
+
+// Register satisfies the Handler interface; routes are registered by RegisterHandlers
+func (h *AdminEmailHandler) Register(_ *echo.Echo) {}
+
+// Start initializes the handler
+func (h *AdminEmailHandler) Start(_ context.Context) error {
+	return nil
+}
+
+// Stop cleans up the handler
+func (h *AdminEmailHandler) Stop(_ context.Context) error {
+	return nil
+}
+
+// POST /api/v1/admin/email/test - sends a test message through the configured provider
+func (h *AdminEmailHandler) handleTestEmail(c echo.Context) error {
+	var req TestEmailRequest
+	if err := c.Bind(&req); err != nil {
+		return response.ErrorResponse(c, http.StatusBadRequest, "Invalid request body")
+	}
+
+	recipient, err := mail.ParseAddress(req.To)
+	if err != nil {
+		return response.ErrorResponse(c, http.StatusBadRequest, "A valid recipient address is required")
+	}
+
+	provider := email.Provider(h.Config.Email)
+	if provider == "" {
+		return response.ErrorResponse(c, http.StatusConflict,
+			"Email delivery is not configured; set email.provider or email.host")
+	}
+
+	ctx, cancel := context.WithTimeout(c.Request().Context(), testEmailTimeout)
+	defer cancel()
+
+	sendErr := h.Sender.Send(ctx, &email.Message{
+		To:      []string{recipient.Address},
+		Subject: "GoFormX test email",
+		Body: fmt.Sprintf("This is a test email from %s, sent through the %s provider.\n",
+			h.Config.App.Name, provider),
+	})
+
+	if errors.Is(sendErr, email.ErrRecipientsSuppressed) {
+		return response.ErrorResponse(c, http.StatusUnprocessableEntity,
+			"The recipient is on the suppression list")
+	}
+
+	if sendErr != nil {
+		h.Logger.Warn("test email failed", "provider", provider, "error", sendErr)
+
+		// The provider's reason is what an admin needs to fix the configuration
+		return response.ErrorResponse(c, http.StatusBadGateway, "Test email failed: "+sendErr.Error())
+	}
+
+	h.Logger.Info("test email sent", "provider", provider)
+
+	return response.Success(c, map[string]any{
+		"provider":  provider,
+		"recipient": recipient.Address,
+	})
+}
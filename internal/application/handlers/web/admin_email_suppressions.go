@@ -0,0 +1,50 @@
+package web
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+
+	"github.com/goformx/goforms/internal/application/response"
+	domainerrors "github.com/goformx/goforms/internal/domain/common/errors"
+	"github.com/goformx/goforms/internal/domain/emaildelivery"
+)
+
+// GET /api/v1/admin/email/suppressions?offset=&limit= - lists suppressed addresses, newest first
+func (h *AdminEmailHandler) handleListSuppressions(c echo.Context) error {
+	offset, limit, err := parsePage(c)
+	if err != nil {
+		return response.ErrorResponse(c, http.StatusBadRequest, err.Error())
+	}
+
+	suppressions, total, err := h.Deliveries.ListSuppressions(c.Request().Context(), offset, limit)
+	if err != nil {
+		return h.HandleError(c, err, "Failed to list email suppressions")
+	}
+
+	return response.Success(c, map[string]any{
+		"suppressions": suppressions,
+		"total":        total,
+		"offset":       offset,
+		"limit":        limit,
+	})
+}
+
+// DELETE /api/v1/admin/email/suppressions/:address - lets an address receive email again
+func (h *AdminEmailHandler) handleDeleteSuppression(c echo.Context) error {
+	err := h.Deliveries.Unsuppress(c.Request().Context(), c.Param("address"))
+
+	switch {
+	case err == nil:
+		return c.NoContent(http.StatusNoContent)
+	case errors.Is(err, emaildelivery.ErrSuppressionNotFound):
+		return response.ErrorResponse(c, http.StatusNotFound, "Email address is not suppressed")
+	}
+
+	if domainErr := domainerrors.GetDomainError(err); domainErr != nil && domainErr.Code == domainerrors.ErrCodeValidation {
+		return response.ErrorResponse(c, http.StatusBadRequest, domainErr.Message)
+	}
+
+	return h.HandleError(c, err, "Failed to delete email suppression")
+}
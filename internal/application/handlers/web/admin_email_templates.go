@@ -0,0 +1,129 @@
+package web
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+
+	"github.com/goformx/goforms/internal/application/response"
+	domainerrors "github.com/goformx/goforms/internal/domain/common/errors"
+	"github.com/goformx/goforms/internal/domain/emailtemplate"
+)
+
+// EmailTemplateRequest is the body of PUT /api/v1/admin/email/templates/:kind
+type EmailTemplateRequest struct {
+	OwnerID string `json:"owner_id"`
+	Subject string `json:"subject"`
+	HTML    string `json:"html"`
+	Text    string `json:"text"`
+}
+
+// EmailTemplatePreviewRequest is the body of POST /api/v1/admin/email/templates/:kind/preview.
+// Without a subject or body, the owner's current template is previewed.
+type EmailTemplatePreviewRequest struct {
+	EmailTemplateRequest
+
+	// Variables override the sample variables
+	Variables map[string]any `json:"variables"`
+}
+
+// GET /api/v1/admin/email/templates?owner_id= - lists the effective template of every kind
+func (h *AdminEmailHandler) handleListTemplates(c echo.Context) error {
+	templates, err := h.Templates.List(c.Request().Context(), c.QueryParam("owner_id"))
+	if err != nil {
+		return h.handleTemplateError(c, err, "Failed to list email templates")
+	}
+
+	return response.Success(c, map[string]any{"templates": templates})
+}
+
+// GET /api/v1/admin/email/templates/:kind?owner_id= - returns the owner's override or the default
+func (h *AdminEmailHandler) handleGetTemplate(c echo.Context) error {
+	template, err := h.Templates.Get(c.Request().Context(), c.QueryParam("owner_id"), emailtemplate.Kind(c.Param("kind")))
+	if err != nil {
+		return h.handleTemplateError(c, err, "Failed to get email template")
+	}
+
+	return response.Success(c, template)
+}
+
+// PUT /api/v1/admin/email/templates/:kind - creates or replaces an owner's override
+func (h *AdminEmailHandler) handleSaveTemplate(c echo.Context) error {
+	var req EmailTemplateRequest
+	if err := c.Bind(&req); err != nil {
+		return response.ErrorResponse(c, http.StatusBadRequest, "Invalid request body")
+	}
+
+	template := req.template(c.Param("kind"))
+
+	if err := h.Templates.Save(c.Request().Context(), template); err != nil {
+		return h.handleTemplateError(c, err, "Failed to save email template")
+	}
+
+	return response.Success(c, template)
+}
+
+// DELETE /api/v1/admin/email/templates/:kind?owner_id= - removes an override so the default applies
+func (h *AdminEmailHandler) handleDeleteTemplate(c echo.Context) error {
+	ownerID := c.QueryParam("owner_id")
+	if ownerID == "" {
+		return response.ErrorResponse(c, http.StatusBadRequest, emailtemplate.ErrOwnerRequired.Error())
+	}
+
+	if err := h.Templates.Delete(c.Request().Context(), ownerID, emailtemplate.Kind(c.Param("kind"))); err != nil {
+		return h.handleTemplateError(c, err, "Failed to delete email template")
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
+
+// POST /api/v1/admin/email/templates/:kind/preview - renders a template with sample variables
+func (h *AdminEmailHandler) handlePreviewTemplate(c echo.Context) error {
+	var req EmailTemplatePreviewRequest
+	if err := c.Bind(&req); err != nil {
+		return response.ErrorResponse(c, http.StatusBadRequest, "Invalid request body")
+	}
+
+	template := req.template(c.Param("kind"))
+
+	if req.Subject == "" && req.HTML == "" && req.Text == "" {
+		current, err := h.Templates.Get(c.Request().Context(), req.OwnerID, template.Kind)
+		if err != nil {
+			return h.handleTemplateError(c, err, "Failed to get email template")
+		}
+
+		template = current
+	}
+
+	rendered, err := h.Templates.Preview(template, req.Variables)
+	if err != nil {
+		return h.handleTemplateError(c, err, "Failed to preview email template")
+	}
+
+	return response.Success(c, rendered)
+}
+
+// template builds the template a request describes
+func (r *EmailTemplateRequest) template(kind string) *emailtemplate.Template {
+	return &emailtemplate.Template{
+		OwnerID: r.OwnerID,
+		Kind:    emailtemplate.Kind(kind),
+		Subject: r.Subject,
+		HTML:    r.HTML,
+		Text:    r.Text,
+	}
+}
+
+// handleTemplateError maps email template errors to HTTP responses
+func (h *AdminEmailHandler) handleTemplateError(c echo.Context, err error, message string) error {
+	if errors.Is(err, emailtemplate.ErrTemplateNotFound) {
+		return response.ErrorResponse(c, http.StatusNotFound, "Email template override not found")
+	}
+
+	if domainErr := domainerrors.GetDomainError(err); domainErr != nil && domainErr.Code == domainerrors.ErrCodeValidation {
+		return response.ErrorResponse(c, http.StatusBadRequest, domainErr.Message)
+	}
+
+	return h.HandleError(c, err, message)
+}
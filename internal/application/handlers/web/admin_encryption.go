@@ -0,0 +1,107 @@
+package web
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+
+	"github.com/goformx/goforms/internal/application/constants"
+	mwcontext "github.com/goformx/goforms/internal/application/middleware/context"
+	"github.com/goformx/goforms/internal/application/response"
+	"github.com/goformx/goforms/internal/domain/audit"
+	"github.com/goformx/goforms/internal/domain/encryption"
+)
+
+// AdminEncryptionHandler reports the submission encryption keys and runs key
+// rotation under /api/v1/admin/encryption. Admin access is enforced by the access middleware.
+type AdminEncryptionHandler struct {
+	*BaseHandler
+	// Encryption is nil when form.encryption.enabled is unset
+	Encryption encryption.Service
+	Audit      audit.Service
+}
+
+// NewAdminEncryptionHandler creates a new AdminEncryptionHandler
+func NewAdminEncryptionHandler(
+	base *BaseHandler, encryptionService encryption.Service, auditService audit.Service,
+) *AdminEncryptionHandler {
+	return &AdminEncryptionHandler{BaseHandler: base, Encryption: encryptionService, Audit: auditService}
+}
+
+// RegisterRoutes registers the encryption routes
+func (h *AdminEncryptionHandler) RegisterRoutes(e *echo.Echo) {
+	keys := e.Group(constants.PathAPIAdminEncryption)
+	keys.Use(h.requireEncryption)
+	keys.GET("", h.handleStatus)
+	keys.POST("/rotate", h.handleRotate)
+}
+
+// DescribeRoutes describes the group middleware RegisterRoutes applies
+func (h *AdminEncryptionHandler) DescribeRoutes() []RouteGroup {
+	return []RouteGroup{{Prefix: constants.PathAPIAdminEncryption, Middleware: []string{"require_encryption"}}}
+}
+
+// Register satisfies the Handler interface; routes are registered by RegisterHandlers
+func (h *AdminEncryptionHandler) Register(_ *echo.Echo) {}
+
+// Start initializes the handler
+func (h *AdminEncryptionHandler) Start(_ context.Context) error {
+	return nil
+}
+
+// Stop cleans up the handler
+func (h *AdminEncryptionHandler) Stop(_ context.Context) error {
+	return nil
+}
+
+// requireEncryption answers 409 while submission encryption is disabled
+func (h *AdminEncryptionHandler) requireEncryption(next echo.HandlerFunc) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		if h.Encryption == nil {
+			return response.ErrorResponse(c, http.StatusConflict, "Submission encryption is disabled")
+		}
+
+		return next(c)
+	}
+}
+
+// GET /api/v1/admin/encryption - the active and retired key IDs and the last rotation
+func (h *AdminEncryptionHandler) handleStatus(c echo.Context) error {
+	return response.Success(c, map[string]any{"encryption": h.Encryption.Status()})
+}
+
+// POST /api/v1/admin/encryption/rotate - moves stored submissions onto the
+// active key and in line with their accounts' settings now
+func (h *AdminEncryptionHandler) handleRotate(c echo.Context) error {
+	report, err := h.Encryption.Rotate(c.Request().Context())
+	if errors.Is(err, encryption.ErrRotationRunning) {
+		return response.ErrorResponse(c, http.StatusConflict, err.Error())
+	}
+
+	if err != nil {
+		return h.HandleError(c, err, "Failed to rotate encryption keys")
+	}
+
+	actorID, _ := mwcontext.GetUserID(c)
+
+	if recordErr := h.Audit.Record(c.Request().Context(), &audit.Entry{
+		ActorID:    actorID,
+		Action:     audit.ActionEncryptionRotated,
+		TargetType: audit.TargetEncryption,
+		TargetID:   h.Encryption.Status().ActiveKey,
+		Details: map[string]any{
+			"scanned":   report.Scanned,
+			"encrypted": report.Encrypted,
+			"decrypted": report.Decrypted,
+			"rekeyed":   report.Rekeyed,
+			"failed":    report.Failed,
+		},
+		IPAddress: c.RealIP(),
+	}); recordErr != nil {
+		h.Logger.Error("failed to record encryption audit entry", "error", recordErr)
+	}
+
+	return response.Success(c, map[string]any{"rotation": report})
+}
@@ -0,0 +1,91 @@
+package web_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/goformx/goforms/internal/application/constants"
+	"github.com/goformx/goforms/internal/application/handlers/web"
+	"github.com/goformx/goforms/internal/domain/audit"
+	"github.com/goformx/goforms/internal/domain/encryption"
+	memorystore "github.com/goformx/goforms/internal/infrastructure/repository/memory"
+	mocklogging "github.com/goformx/goforms/test/mocks/logging"
+)
+
+func TestAdminEncryptionHandler(t *testing.T) {
+	ctrl := gomock.NewController(t)
+
+	logger := mocklogging.NewMockLogger(ctrl)
+	logger.EXPECT().Info(gomock.Any(), gomock.Any()).AnyTimes()
+
+	store := memorystore.NewStore(logger)
+	auditService := audit.NewService(store.Audit(), logger)
+
+	keyring, err := encryption.NewKeyring([]string{
+		"2025-06:0123456789abcdef0123456789abcdef",
+		"2024-01:fedcba9876543210fedcba9876543210",
+	})
+	require.NoError(t, err)
+
+	service := encryption.NewService(memorystore.NewEncryptionStore(), keyring, encryption.Options{
+		SettingCacheTTL:   time.Minute,
+		RotationBatchSize: 100,
+	}, logger)
+
+	newServer := func(service encryption.Service) *echo.Echo {
+		e := echo.New()
+		e.Use(func(next echo.HandlerFunc) echo.HandlerFunc {
+			return func(c echo.Context) error {
+				c.Set("user_id", "admin-1")
+				c.Set("role", "admin")
+
+				return next(c)
+			}
+		})
+		web.NewAdminEncryptionHandler(&web.BaseHandler{Logger: logger}, service, auditService).RegisterRoutes(e)
+
+		return e
+	}
+
+	call := func(e *echo.Echo, method, path string) (int, map[string]any) {
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, httptest.NewRequest(method, path, http.NoBody))
+
+		var payload struct {
+			Data map[string]any `json:"data"`
+		}
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &payload))
+
+		return rec.Code, payload.Data
+	}
+
+	e := newServer(service)
+
+	code, data := call(e, http.MethodGet, constants.PathAPIAdminEncryption)
+	require.Equal(t, http.StatusOK, code)
+
+	status := data["encryption"].(map[string]any)
+	assert.Equal(t, "2025-06", status["active_key"])
+	assert.Equal(t, []any{"2025-06", "2024-01"}, status["keys"])
+	assert.Nil(t, status["last_rotation"])
+
+	code, data = call(e, http.MethodPost, constants.PathAPIAdminEncryption+"/rotate")
+	require.Equal(t, http.StatusOK, code)
+	assert.InDelta(t, 0, data["rotation"].(map[string]any)["failed"], 0)
+
+	entries, _, err := auditService.List(t.Context(), audit.Filter{Action: audit.ActionEncryptionRotated}, 0, 10)
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+	assert.Equal(t, "admin-1", entries[0].ActorID)
+
+	code, _ = call(newServer(nil), http.MethodGet, constants.PathAPIAdminEncryption)
+	assert.Equal(t, http.StatusConflict, code)
+}
@@ -0,0 +1,289 @@
+package web
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/labstack/echo/v4"
+
+	"github.com/goformx/goforms/internal/application/constants"
+	mwcontext "github.com/goformx/goforms/internal/application/middleware/context"
+	"github.com/goformx/goforms/internal/application/response"
+	"github.com/goformx/goforms/internal/domain/audit"
+	"github.com/goformx/goforms/internal/domain/eventlog"
+	"github.com/goformx/goforms/internal/domain/extension"
+	"github.com/goformx/goforms/internal/domain/form"
+	"github.com/goformx/goforms/internal/domain/form/model"
+)
+
+// AdminEventHandler lists and replays stored domain events under
+// /api/v1/admin/events, and re-runs a submission's failed post-submit hooks
+// under /api/v1/admin/submissions. Admin access is enforced by the access middleware.
+type AdminEventHandler struct {
+	*BaseHandler
+	// Events is nil when events.store.enabled is unset
+	Events     eventlog.Service
+	Forms      form.Service
+	Activities form.ActivityService
+	Extensions *extension.Runner
+	Audit      audit.Service
+	now        func() time.Time
+}
+
+// NewAdminEventHandler creates a new AdminEventHandler
+func NewAdminEventHandler(
+	base *BaseHandler,
+	events eventlog.Service,
+	forms form.Service,
+	activities form.ActivityService,
+	extensions *extension.Runner,
+	auditService audit.Service,
+) *AdminEventHandler {
+	return &AdminEventHandler{
+		BaseHandler: base,
+		Events:      events,
+		Forms:       forms,
+		Activities:  activities,
+		Extensions:  extensions,
+		Audit:       auditService,
+		now:         time.Now,
+	}
+}
+
+// ReplayEventsRequest selects the stored events to replay
+type ReplayEventsRequest struct {
+	// Type is the event name, such as form.submitted; empty replays every event
+	Type string `json:"type"`
+	// Since is a duration before now, such as 24h, or an RFC 3339 time
+	Since string `json:"since"`
+}
+
+// RedeliverRequest selects the post-submit hooks to re-run
+type RedeliverRequest struct {
+	// Extension re-runs one hook whatever its last outcome; empty re-runs
+	// every hook whose last delivery failed
+	Extension string `json:"extension"`
+}
+
+// Redelivery is the outcome of re-running one post-submit hook
+type Redelivery struct {
+	Extension string `json:"extension"`
+	Success   bool   `json:"success"`
+	Error     string `json:"error,omitempty"`
+}
+
+// RegisterRoutes registers the stored event and redelivery routes
+func (h *AdminEventHandler) RegisterRoutes(e *echo.Echo) {
+	stored := e.Group(constants.PathAPIAdminEvents)
+	stored.Use(h.requireEventStore)
+	stored.GET("", h.handleList)
+	stored.GET("/:id", h.handleGet)
+	stored.POST("/replay", h.handleReplay)
+
+	e.POST(constants.PathAPIAdminSubmissions+"/:id/redeliver", h.handleRedeliver)
+}
+
+// DescribeRoutes describes the group middleware RegisterRoutes applies
+func (h *AdminEventHandler) DescribeRoutes() []RouteGroup {
+	return []RouteGroup{{Prefix: constants.PathAPIAdminEvents, Middleware: []string{"require_event_store"}}}
+}
+
+// Register satisfies the Handler interface; routes are registered by RegisterHandlers
+func (h *AdminEventHandler) Register(_ *echo.Echo) {}
+
+// Start initializes the handler
+func (h *AdminEventHandler) Start(_ context.Context) error {
+	return nil
+}
+
+// Stop cleans up the handler
+func (h *AdminEventHandler) Stop(_ context.Context) error {
+	return nil
+}
+
+// requireEventStore answers 409 while the event store is disabled
+func (h *AdminEventHandler) requireEventStore(next echo.HandlerFunc) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		if h.Events == nil {
+			return response.ErrorResponse(c, http.StatusConflict, "The event store is disabled")
+		}
+
+		return next(c)
+	}
+}
+
+// GET /api/v1/admin/events?type=&since=&offset=&limit= - lists stored events, oldest first
+func (h *AdminEventHandler) handleList(c echo.Context) error {
+	offset, limit, err := parsePage(c)
+	if err != nil {
+		return response.ErrorResponse(c, http.StatusBadRequest, err.Error())
+	}
+
+	since, err := eventlog.ParseSince(c.QueryParam("since"), h.now())
+	if err != nil {
+		return response.ErrorResponse(c, http.StatusBadRequest, err.Error())
+	}
+
+	records, total, err := h.Events.List(c.Request().Context(), eventlog.Filter{Name: c.QueryParam("type"), Since: since}, offset, limit)
+	if err != nil {
+		return h.HandleError(c, err, "Failed to list stored events")
+	}
+
+	return response.Success(c, map[string]any{
+		"events": records,
+		"total":  total,
+		"offset": offset,
+		"limit":  limit,
+	})
+}
+
+// GET /api/v1/admin/events/:id - returns a stored event
+func (h *AdminEventHandler) handleGet(c echo.Context) error {
+	record, err := h.Events.Get(c.Request().Context(), c.Param("id"))
+	if errors.Is(err, eventlog.ErrRecordNotFound) {
+		return response.ErrorResponse(c, http.StatusNotFound, "Stored event not found")
+	}
+
+	if err != nil {
+		return h.HandleError(c, err, "Failed to get stored event")
+	}
+
+	return response.Success(c, map[string]any{"event": record})
+}
+
+// POST /api/v1/admin/events/replay - republishes the matching stored events
+// to this server's subscribers, oldest first
+func (h *AdminEventHandler) handleReplay(c echo.Context) error {
+	var req ReplayEventsRequest
+	if err := c.Bind(&req); err != nil {
+		return response.ErrorResponse(c, http.StatusBadRequest, "Invalid request body")
+	}
+
+	since, err := eventlog.ParseSince(req.Since, h.now())
+	if err != nil {
+		return response.ErrorResponse(c, http.StatusBadRequest, err.Error())
+	}
+
+	report, err := h.Events.Replay(c.Request().Context(), eventlog.Filter{Name: req.Type, Since: since})
+	if err != nil {
+		return h.HandleError(c, err, "Failed to replay stored events")
+	}
+
+	h.record(c, audit.ActionEventsReplayed, audit.TargetEvent, req.Type, map[string]any{
+		"since":    req.Since,
+		"replayed": report.Replayed,
+		"failed":   len(report.Failed),
+	})
+
+	return response.Success(c, map[string]any{"replay": report})
+}
+
+// POST /api/v1/admin/submissions/:id/redeliver - re-runs the submission's
+// failed post-submit hooks, or the named one, and reports each outcome
+func (h *AdminEventHandler) handleRedeliver(c echo.Context) error {
+	var req RedeliverRequest
+	if err := c.Bind(&req); err != nil {
+		return response.ErrorResponse(c, http.StatusBadRequest, "Invalid request body")
+	}
+
+	ctx := c.Request().Context()
+
+	submission, err := h.Forms.GetFormSubmission(ctx, c.Param("id"))
+	if err != nil || submission == nil {
+		return h.HandleNotFound(c, "Submission not found")
+	}
+
+	target, err := h.Forms.GetForm(ctx, submission.FormID)
+	if err != nil || target == nil {
+		return h.HandleNotFound(c, "Form not found")
+	}
+
+	names := []string{req.Extension}
+	if req.Extension == "" {
+		activity, listErr := h.Activities.ListActivity(ctx, submission.ID)
+		if listErr != nil {
+			return h.HandleError(c, listErr, "Failed to list submission activity")
+		}
+
+		names = failedDeliveries(activity)
+	}
+
+	redeliveries := make([]Redelivery, 0, len(names))
+
+	for _, name := range names {
+		redelivery := Redelivery{Extension: name, Success: true}
+
+		if redeliverErr := h.Extensions.Redeliver(ctx, target, submission, name); redeliverErr != nil {
+			if errors.Is(redeliverErr, extension.ErrNotPostSubmit) {
+				return response.ErrorResponse(c, http.StatusBadRequest, redeliverErr.Error())
+			}
+
+			redelivery.Success = false
+			redelivery.Error = redeliverErr.Error()
+		}
+
+		redeliveries = append(redeliveries, redelivery)
+	}
+
+	h.record(c, audit.ActionSubmissionRedelivered, audit.TargetSubmission, submission.ID, map[string]any{
+		"form_id":    submission.FormID,
+		"extensions": names,
+	})
+
+	return response.Success(c, map[string]any{"redeliveries": redeliveries})
+}
+
+// failedDeliveries returns the extensions whose latest delivery in a
+// submission's activity, oldest first, failed
+func failedDeliveries(activity []*model.SubmissionActivity) []string {
+	var names []string
+
+	latest := make(map[string]bool)
+
+	for _, entry := range activity {
+		if entry.Kind != model.ActivityWebhookDelivery {
+			continue
+		}
+
+		name, _ := entry.Details["extension"].(string)
+		if name == "" {
+			continue
+		}
+
+		if _, seen := latest[name]; !seen {
+			names = append(names, name)
+		}
+
+		success, _ := entry.Details["success"].(bool)
+		latest[name] = success
+	}
+
+	failed := make([]string, 0, len(names))
+
+	for _, name := range names {
+		if !latest[name] {
+			failed = append(failed, name)
+		}
+	}
+
+	return failed
+}
+
+// record writes an audit entry for an admin action; a failed write is logged
+func (h *AdminEventHandler) record(c echo.Context, action, targetType, targetID string, details map[string]any) {
+	actorID, _ := mwcontext.GetUserID(c)
+
+	err := h.Audit.Record(c.Request().Context(), &audit.Entry{
+		ActorID:    actorID,
+		Action:     action,
+		TargetType: targetType,
+		TargetID:   targetID,
+		Details:    details,
+		IPAddress:  c.RealIP(),
+	})
+	if err != nil {
+		h.Logger.Error("failed to record event audit entry", "action", action, "target_id", targetID, "error", err)
+	}
+}
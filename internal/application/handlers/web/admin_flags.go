@@ -0,0 +1,193 @@
+package web
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+
+	"github.com/goformx/goforms/internal/application/constants"
+	mwcontext "github.com/goformx/goforms/internal/application/middleware/context"
+	"github.com/goformx/goforms/internal/application/response"
+	"github.com/goformx/goforms/internal/domain/audit"
+	domainerrors "github.com/goformx/goforms/internal/domain/common/errors"
+	"github.com/goformx/goforms/internal/domain/flags"
+)
+
+// SetFlagRequest is the body of PUT /api/v1/admin/flags/:key. Omitted fields
+// keep the flag's current value, so {"enabled": false} toggles a flag off.
+type SetFlagRequest struct {
+	Description *string `json:"description"`
+	Enabled     *bool   `json:"enabled"`
+	// Percentage is the share of accounts, 0 to 100, the flag is on for
+	Percentage *int `json:"percentage"`
+	// Accounts always get the flag while it is enabled
+	Accounts *[]string `json:"accounts"`
+}
+
+// AdminFlagHandler lists and toggles feature flags under /api/v1/admin/flags.
+// Admin access is enforced by the access middleware.
+type AdminFlagHandler struct {
+	*BaseHandler
+	Flags flags.Service
+	Audit audit.Service
+}
+
+// NewAdminFlagHandler creates a new AdminFlagHandler
+func NewAdminFlagHandler(base *BaseHandler, flagService flags.Service, auditService audit.Service) *AdminFlagHandler {
+	return &AdminFlagHandler{BaseHandler: base, Flags: flagService, Audit: auditService}
+}
+
+// RegisterRoutes registers the feature flag routes
+func (h *AdminFlagHandler) RegisterRoutes(e *echo.Echo) {
+	e.GET(constants.PathAPIAdminFlags, h.handleList)
+	e.GET(constants.PathAPIAdminFlags+"/:key", h.handleGet)
+	e.PUT(constants.PathAPIAdminFlags+"/:key", h.handleSet)
+	e.DELETE(constants.PathAPIAdminFlags+"/:key", h.handleDelete)
+}
+
+// Register satisfies the Handler interface; routes are registered by RegisterHandlers
+func (h *AdminFlagHandler) Register(_ *echo.Echo) {}
+
+// Start initializes the handler
+func (h *AdminFlagHandler) Start(_ context.Context) error {
+	return nil
+}
+
+// Stop cleans up the handler
+func (h *AdminFlagHandler) Stop(_ context.Context) error {
+	return nil
+}
+
+// GET /api/v1/admin/flags - lists every flag with its source
+func (h *AdminFlagHandler) handleList(c echo.Context) error {
+	list, err := h.Flags.List(c.Request().Context())
+	if err != nil {
+		return h.HandleError(c, err, "Failed to list feature flags")
+	}
+
+	return response.Success(c, map[string]any{"flags": list})
+}
+
+// GET /api/v1/admin/flags/:key?account_id= - returns a flag; with account_id it
+// also reports whether the flag is on for that account
+func (h *AdminFlagHandler) handleGet(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	flag, err := h.Flags.Get(ctx, c.Param("key"))
+	if err != nil {
+		return h.handleFlagError(c, err, "Failed to get feature flag")
+	}
+
+	data := map[string]any{"flag": flag}
+
+	if accountID := c.QueryParam("account_id"); accountID != "" {
+		data["account_id"] = accountID
+		data["enabled_for_account"] = flag.EnabledFor(accountID)
+		data["bucket"] = flags.Bucket(flag.Key, accountID)
+	}
+
+	return response.Success(c, data)
+}
+
+// PUT /api/v1/admin/flags/:key - stores a flag, overriding a config flag with the same key
+func (h *AdminFlagHandler) handleSet(c echo.Context) error {
+	var req SetFlagRequest
+	if err := c.Bind(&req); err != nil {
+		return response.ErrorResponse(c, http.StatusBadRequest, "Invalid request body")
+	}
+
+	ctx := c.Request().Context()
+	key := c.Param("key")
+
+	flag := &flags.Flag{Key: key, Percentage: 100}
+
+	current, err := h.Flags.Get(ctx, key)
+
+	switch {
+	case err == nil:
+		copied := *current
+		flag = &copied
+	case !errors.Is(err, flags.ErrFlagNotFound):
+		return h.HandleError(c, err, "Failed to get feature flag")
+	}
+
+	req.apply(flag)
+
+	saved, err := h.Flags.Set(ctx, flag)
+	if err != nil {
+		return h.handleFlagError(c, err, "Failed to save feature flag")
+	}
+
+	h.record(c, audit.ActionFlagUpdated, key, map[string]any{
+		"enabled":    saved.Enabled,
+		"percentage": saved.Percentage,
+		"accounts":   len(saved.Accounts),
+	})
+
+	return response.Success(c, map[string]any{"flag": saved})
+}
+
+// DELETE /api/v1/admin/flags/:key - removes a stored flag; a config flag with
+// the same key applies again
+func (h *AdminFlagHandler) handleDelete(c echo.Context) error {
+	key := c.Param("key")
+
+	if err := h.Flags.Delete(c.Request().Context(), key); err != nil {
+		return h.handleFlagError(c, err, "Failed to delete feature flag")
+	}
+
+	h.record(c, audit.ActionFlagDeleted, key, nil)
+
+	return c.NoContent(http.StatusNoContent)
+}
+
+// apply overlays the fields set in the request onto a flag
+func (r *SetFlagRequest) apply(flag *flags.Flag) {
+	if r.Description != nil {
+		flag.Description = *r.Description
+	}
+
+	if r.Enabled != nil {
+		flag.Enabled = *r.Enabled
+	}
+
+	if r.Percentage != nil {
+		flag.Percentage = *r.Percentage
+	}
+
+	if r.Accounts != nil {
+		flag.Accounts = *r.Accounts
+	}
+}
+
+// handleFlagError maps feature flag errors to HTTP responses
+func (h *AdminFlagHandler) handleFlagError(c echo.Context, err error, message string) error {
+	if errors.Is(err, flags.ErrFlagNotFound) {
+		return response.ErrorResponse(c, http.StatusNotFound, "Feature flag not found")
+	}
+
+	if domainErr := domainerrors.GetDomainError(err); domainErr != nil && domainErr.Code == domainerrors.ErrCodeValidation {
+		return response.ErrorResponse(c, http.StatusBadRequest, domainErr.Message)
+	}
+
+	return h.HandleError(c, err, message)
+}
+
+// record writes an audit entry for a flag change; a failed write is logged
+func (h *AdminFlagHandler) record(c echo.Context, action, key string, details map[string]any) {
+	actorID, _ := mwcontext.GetUserID(c)
+
+	err := h.Audit.Record(c.Request().Context(), &audit.Entry{
+		ActorID:    actorID,
+		Action:     action,
+		TargetType: audit.TargetFlag,
+		TargetID:   key,
+		Details:    details,
+		IPAddress:  c.RealIP(),
+	})
+	if err != nil {
+		h.Logger.Error("failed to record feature flag audit entry", "flag", key, "action", action, "error", err)
+	}
+}
@@ -0,0 +1,99 @@
+package web_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/goformx/goforms/internal/application/constants"
+	"github.com/goformx/goforms/internal/application/handlers/web"
+	"github.com/goformx/goforms/internal/domain/audit"
+	"github.com/goformx/goforms/internal/domain/flags"
+	memorystore "github.com/goformx/goforms/internal/infrastructure/repository/memory"
+	mocklogging "github.com/goformx/goforms/test/mocks/logging"
+)
+
+func TestAdminFlagHandler(t *testing.T) {
+	ctrl := gomock.NewController(t)
+
+	logger := mocklogging.NewMockLogger(ctrl)
+	logger.EXPECT().Info(gomock.Any(), gomock.Any()).AnyTimes()
+
+	store := memorystore.NewStore(logger)
+	auditService := audit.NewService(store.Audit(), logger)
+	flagService := flags.NewService(flags.NewStaticProvider([]*flags.Flag{
+		{Key: "new-builder", Description: "New form builder", Percentage: 100},
+	}), store.Flags(), logger)
+
+	base := &web.BaseHandler{Logger: logger, Flags: flagService}
+
+	e := echo.New()
+	e.Use(func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			c.Set("user_id", "admin-1")
+			c.Set("role", "admin")
+
+			return next(c)
+		}
+	})
+	web.NewAdminFlagHandler(base, flagService, auditService).RegisterRoutes(e)
+
+	call := func(method, path, body string) (int, map[string]any) {
+		req := httptest.NewRequest(method, path, strings.NewReader(body))
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, req)
+
+		var payload struct {
+			Data map[string]any `json:"data"`
+		}
+		if rec.Body.Len() > 0 {
+			require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &payload))
+		}
+
+		return rec.Code, payload.Data
+	}
+
+	code, data := call(http.MethodGet, constants.PathAPIAdminFlags, "")
+	require.Equal(t, http.StatusOK, code)
+	require.Len(t, data["flags"], 1)
+
+	// Toggling a config flag stores it and keeps the config description
+	code, data = call(http.MethodPut, constants.PathAPIAdminFlags+"/new-builder", `{"enabled":true,"percentage":0,"accounts":["acct-1"]}`)
+	require.Equal(t, http.StatusOK, code)
+
+	flag, ok := data["flag"].(map[string]any)
+	require.True(t, ok)
+	assert.Equal(t, "New form builder", flag["description"])
+	assert.Equal(t, flags.SourceStored, flag["source"])
+
+	assert.True(t, flagService.Enabled(context.Background(), "new-builder", "acct-1"))
+	assert.False(t, flagService.Enabled(context.Background(), "new-builder", "acct-2"))
+
+	code, data = call(http.MethodGet, constants.PathAPIAdminFlags+"/new-builder?account_id=acct-1", "")
+	require.Equal(t, http.StatusOK, code)
+	assert.Equal(t, true, data["enabled_for_account"])
+
+	code, _ = call(http.MethodPut, constants.PathAPIAdminFlags+"/new-builder", `{"percentage":150}`)
+	assert.Equal(t, http.StatusBadRequest, code)
+
+	code, _ = call(http.MethodDelete, constants.PathAPIAdminFlags+"/new-builder", "")
+	require.Equal(t, http.StatusNoContent, code)
+	assert.False(t, flagService.Enabled(context.Background(), "new-builder", "acct-1"))
+
+	code, _ = call(http.MethodDelete, constants.PathAPIAdminFlags+"/new-builder", "")
+	assert.Equal(t, http.StatusNotFound, code)
+
+	entries, _, err := auditService.List(context.Background(), audit.Filter{TargetID: "new-builder"}, 0, 10)
+	require.NoError(t, err)
+	assert.Len(t, entries, 2)
+}
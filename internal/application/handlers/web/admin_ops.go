@@ -0,0 +1,52 @@
+package web
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+
+	"github.com/goformx/goforms/internal/application/constants"
+	"github.com/goformx/goforms/internal/infrastructure/metrics"
+	"github.com/goformx/goforms/internal/infrastructure/ops"
+)
+
+// AdminOpsHandler serves the runtime diagnostics (pprof, expvar and runtime
+// stats) under /api/v1/admin/debug. Admin access is enforced by the access
+// middleware. Nothing is registered while the diagnostics are disabled or
+// served on ops.address instead.
+type AdminOpsHandler struct {
+	*BaseHandler
+	// Metrics is reported by expvar under "goforms"; optional
+	Metrics *metrics.Registry
+}
+
+// NewAdminOpsHandler creates a new AdminOpsHandler
+func NewAdminOpsHandler(base *BaseHandler, registry *metrics.Registry) *AdminOpsHandler {
+	return &AdminOpsHandler{BaseHandler: base, Metrics: registry}
+}
+
+// RegisterRoutes registers the diagnostics routes
+func (h *AdminOpsHandler) RegisterRoutes(e *echo.Echo) {
+	if !h.Config.DiagnosticsEnabled() || h.Config.Ops.Address != "" {
+		return
+	}
+
+	// The ops handler serves /debug/...; strip the admin API prefix in front of it
+	diagnostics := echo.WrapHandler(http.StripPrefix(constants.PathAPIAdmin, ops.Handler(h.Metrics)))
+
+	e.Any(constants.PathAPIAdminDebug+"/*", diagnostics)
+}
+
+// Register satisfies the Handler interface; routes are registered by RegisterHandlers
+func (h *AdminOpsHandler) Register(_ *echo.Echo) {}
+
+// Start initializes the handler
+func (h *AdminOpsHandler) Start(_ context.Context) error {
+	return nil
+}
+
+// Stop cleans up the handler
+func (h *AdminOpsHandler) Stop(_ context.Context) error {
+	return nil
+}
@@ -0,0 +1,41 @@
+package web_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/goformx/goforms/internal/application/constants"
+	"github.com/goformx/goforms/internal/application/handlers/web"
+	"github.com/goformx/goforms/internal/infrastructure/config"
+	"github.com/goformx/goforms/internal/infrastructure/metrics"
+)
+
+func TestAdminOpsHandler(t *testing.T) {
+	status := func(cfg *config.Config, path string) int {
+		e := echo.New()
+		web.NewAdminOpsHandler(&web.BaseHandler{Config: cfg}, metrics.NewRegistry()).RegisterRoutes(e)
+
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, path, http.NoBody))
+
+		return rec.Code
+	}
+
+	development := &config.Config{App: config.AppConfig{Environment: "development"}}
+	assert.Equal(t, http.StatusOK, status(development, constants.PathAPIAdminDebug+"/runtime"))
+	assert.Equal(t, http.StatusOK, status(development, constants.PathAPIAdminDebug+"/vars"))
+	assert.Equal(t, http.StatusOK, status(development, constants.PathAPIAdminDebug+"/pprof/"))
+
+	production := &config.Config{App: config.AppConfig{Environment: "production"}}
+	assert.Equal(t, http.StatusNotFound, status(production, constants.PathAPIAdminDebug+"/runtime"), "off in production by default")
+
+	production.Ops.Pprof.Enabled = true
+	assert.Equal(t, http.StatusOK, status(production, constants.PathAPIAdminDebug+"/runtime"))
+
+	production.Ops.Address = "127.0.0.1:6060"
+	assert.Equal(t, http.StatusNotFound, status(production, constants.PathAPIAdminDebug+"/runtime"), "served on ops.address instead")
+}
@@ -0,0 +1,44 @@
+package web
+
+import (
+	"errors"
+	"strconv"
+
+	"github.com/labstack/echo/v4"
+)
+
+// Page sizes of the admin list endpoints
+const (
+	defaultAdminPageSize = 50
+	maxAdminPageSize     = 200
+)
+
+var (
+	errInvalidOffset = errors.New("offset must be a non-negative integer")
+	errInvalidLimit  = errors.New("limit must be between 1 and " + strconv.Itoa(maxAdminPageSize))
+)
+
+// parsePage reads the offset and limit query parameters of an admin list endpoint
+func parsePage(c echo.Context) (offset, limit int, err error) {
+	offset, err = queryInt(c, "offset", 0)
+	if err != nil || offset < 0 {
+		return 0, 0, errInvalidOffset
+	}
+
+	limit, err = queryInt(c, "limit", defaultAdminPageSize)
+	if err != nil || limit < 1 || limit > maxAdminPageSize {
+		return 0, 0, errInvalidLimit
+	}
+
+	return offset, limit, nil
+}
+
+// queryInt parses an integer query parameter, returning fallback when it is absent
+func queryInt(c echo.Context, name string, fallback int) (int, error) {
+	value := c.QueryParam(name)
+	if value == "" {
+		return fallback, nil
+	}
+
+	return strconv.Atoi(value)
+}
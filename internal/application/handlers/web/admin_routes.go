@@ -0,0 +1,241 @@
+package web
+
+import (
+	"context"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+
+	"github.com/goformx/goforms/internal/application/constants"
+	"github.com/goformx/goforms/internal/application/middleware"
+	"github.com/goformx/goforms/internal/application/middleware/access"
+	"github.com/goformx/goforms/internal/application/middleware/security"
+	"github.com/goformx/goforms/internal/application/response"
+	appconfig "github.com/goformx/goforms/internal/infrastructure/config"
+)
+
+// RouteGroup describes the middleware a handler applies to some of its routes.
+// Echo's route table does not record group or route middleware, so handlers
+// that use them describe it here.
+type RouteGroup struct {
+	// Prefix matches the routes at or below it. With a Method it matches that one route.
+	Prefix string
+	Method string
+	// Auth names how the routes authenticate when the access level does not say,
+	// such as "assertion" for the Laravel API
+	Auth       string
+	Middleware []string
+	// RateLimit replaces the rate limit tier budgets on the group's routes;
+	// security.rate_limit.routes overrides it
+	RateLimit *appconfig.RateLimitTier
+}
+
+// RouteRateLimits returns the rate limits handlers declare in their route groups
+func RouteRateLimits(handlers []Handler) []security.RouteLimit {
+	var limits []security.RouteLimit
+
+	for _, handler := range handlers {
+		describer, ok := handler.(RouteDescriber)
+		if !ok {
+			continue
+		}
+
+		for _, group := range describer.DescribeRoutes() {
+			if group.RateLimit != nil {
+				limits = append(limits, security.RouteLimit{Path: group.Prefix, Method: group.Method, Limit: *group.RateLimit})
+			}
+		}
+	}
+
+	return limits
+}
+
+// RouteDescriber is implemented by handlers that apply group or route middleware
+type RouteDescriber interface {
+	DescribeRoutes() []RouteGroup
+}
+
+// matches reports whether the group applies to a route
+func (g RouteGroup) matches(method, path string) bool {
+	if g.Method != "" {
+		return g.Method == method && g.Prefix == path
+	}
+
+	return path == g.Prefix || strings.HasPrefix(path, g.Prefix+"/")
+}
+
+// RouteInfo describes one registered route
+type RouteInfo struct {
+	Method  string `json:"method"`
+	Path    string `json:"path"`
+	Handler string `json:"handler"`
+	// Access is the level the access middleware requires
+	Access string `json:"access"`
+	// Auth is how the route authenticates: a RouteGroup's Auth or else the access level
+	Auth string `json:"auth"`
+	// Middleware is the full chain, global middleware first
+	Middleware []string `json:"middleware"`
+	// RateLimit is the route's own limit, such as "10/1m0s", when it has one
+	RateLimit string `json:"rate_limit,omitempty"`
+}
+
+// AdminRouteHandler lists the registered routes under /api/v1/admin/routes.
+// Admin access is enforced by the access middleware.
+type AdminRouteHandler struct {
+	*BaseHandler
+	AccessManager     *access.Manager
+	MiddlewareManager *middleware.Manager
+
+	echo       *echo.Echo
+	describers []RouteDescriber
+}
+
+// NewAdminRouteHandler creates a new AdminRouteHandler
+func NewAdminRouteHandler(
+	base *BaseHandler,
+	accessManager *access.Manager,
+	middlewareManager *middleware.Manager,
+) *AdminRouteHandler {
+	return &AdminRouteHandler{BaseHandler: base, AccessManager: accessManager, MiddlewareManager: middlewareManager}
+}
+
+// RegisterRoutes registers the route listing. The route metadata of handlers is
+// read when routes are listed, since handlers registered after this one have
+// recorded nothing yet.
+func (h *AdminRouteHandler) RegisterRoutes(e *echo.Echo, handlers []Handler) {
+	h.echo = e
+	h.describers = nil
+
+	for _, handler := range handlers {
+		if describer, ok := handler.(RouteDescriber); ok {
+			h.describers = append(h.describers, describer)
+		}
+	}
+
+	e.GET(constants.PathAPIAdminRoutes, h.handleListRoutes)
+}
+
+// Register satisfies the Handler interface; routes are registered by RegisterHandlers
+func (h *AdminRouteHandler) Register(_ *echo.Echo) {}
+
+// Start initializes the handler
+func (h *AdminRouteHandler) Start(_ context.Context) error {
+	return nil
+}
+
+// Stop cleans up the handler
+func (h *AdminRouteHandler) Stop(_ context.Context) error {
+	return nil
+}
+
+// Routes returns the registered routes sorted by path and method
+func (h *AdminRouteHandler) Routes() []RouteInfo {
+	global := h.globalMiddleware()
+	routes := []RouteInfo{}
+
+	var groups []RouteGroup
+	for _, describer := range h.describers {
+		groups = append(groups, describer.DescribeRoutes()...)
+	}
+
+	for _, route := range h.echo.Routes() {
+		if route.Method == echo.RouteNotFound {
+			continue
+		}
+
+		info := RouteInfo{
+			Method:     route.Method,
+			Path:       route.Path,
+			Handler:    handlerName(route.Name),
+			Access:     access.Authenticated.String(),
+			Middleware: append([]string{}, global...),
+		}
+
+		if h.AccessManager != nil {
+			info.Access = h.AccessManager.GetRequiredAccess(route.Path, route.Method).String()
+		}
+
+		info.Auth = info.Access
+
+		for _, group := range groups {
+			if !group.matches(route.Method, route.Path) {
+				continue
+			}
+
+			if group.Auth != "" {
+				info.Auth = group.Auth
+			}
+
+			info.Middleware = append(info.Middleware, group.Middleware...)
+
+			if group.RateLimit != nil {
+				info.RateLimit = formatRateLimit(*group.RateLimit)
+			}
+		}
+
+		if h.MiddlewareManager != nil {
+			if limit, ok := h.MiddlewareManager.RouteRateLimit(route.Method, route.Path); ok {
+				info.RateLimit = formatRateLimit(limit.Limit)
+			}
+		}
+
+		routes = append(routes, info)
+	}
+
+	sort.Slice(routes, func(i, j int) bool {
+		if routes[i].Path != routes[j].Path {
+			return routes[i].Path < routes[j].Path
+		}
+
+		return routes[i].Method < routes[j].Method
+	})
+
+	return routes
+}
+
+// formatRateLimit formats a budget as requests/window
+func formatRateLimit(limit appconfig.RateLimitTier) string {
+	return strconv.Itoa(limit.Requests) + "/" + limit.Window.String()
+}
+
+// handlerName shortens Echo's route name, the handler's full function name,
+// to package.(*Type).method
+func handlerName(name string) string {
+	if i := strings.LastIndex(name, "/"); i >= 0 {
+		name = name[i+1:]
+	}
+
+	return strings.TrimSuffix(name, "-fm")
+}
+
+// GET /api/v1/admin/routes?path=&method= - lists registered routes with their
+// middleware chain and auth requirement; path filters by prefix
+func (h *AdminRouteHandler) handleListRoutes(c echo.Context) error {
+	prefix := c.QueryParam("path")
+	method := strings.ToUpper(c.QueryParam("method"))
+
+	routes := []RouteInfo{}
+
+	for _, route := range h.Routes() {
+		if strings.HasPrefix(route.Path, prefix) && (method == "" || route.Method == method) {
+			routes = append(routes, route)
+		}
+	}
+
+	return response.Success(c, map[string]any{
+		"routes":            routes,
+		"total":             len(routes),
+		"global_middleware": h.globalMiddleware(),
+	})
+}
+
+// globalMiddleware returns the names of the middleware applied to every route
+func (h *AdminRouteHandler) globalMiddleware() []string {
+	if h.MiddlewareManager == nil {
+		return []string{}
+	}
+
+	return h.MiddlewareManager.GlobalMiddleware()
+}
@@ -0,0 +1,66 @@
+package web_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/goformx/goforms/internal/application/constants"
+	"github.com/goformx/goforms/internal/application/handlers/web"
+	"github.com/goformx/goforms/internal/application/middleware/access"
+	mocklogging "github.com/goformx/goforms/test/mocks/logging"
+)
+
+func TestAdminRouteHandler(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	logger := mocklogging.NewMockLogger(ctrl)
+
+	base := &web.BaseHandler{Logger: logger}
+	paths := constants.NewPathManager()
+	accessManager := access.NewManager(&access.Config{
+		DefaultAccess: access.Authenticated,
+		PublicPaths:   paths.PublicPaths,
+		AdminPaths:    paths.AdminPaths,
+	}, nil)
+
+	e := echo.New()
+	backups := web.NewAdminBackupHandler(base, nil, nil)
+	backups.RegisterRoutes(e)
+
+	routes := web.NewAdminRouteHandler(base, accessManager, nil)
+	routes.RegisterRoutes(e, []web.Handler{backups, routes})
+
+	req := httptest.NewRequest(http.MethodGet, constants.PathAPIAdminRoutes+"?path=/api/v1/admin/backups&method=post", nil)
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+	require.Equal(t, http.StatusOK, rec.Code)
+
+	var payload struct {
+		Data struct {
+			Routes []web.RouteInfo `json:"routes"`
+			Total  int             `json:"total"`
+		} `json:"data"`
+	}
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &payload))
+
+	require.Equal(t, 3, payload.Data.Total)
+
+	route := payload.Data.Routes[0]
+	assert.Equal(t, http.MethodPost, route.Method)
+	assert.Equal(t, constants.PathAPIAdminBackups, route.Path)
+	assert.Equal(t, "web.(*AdminBackupHandler).handleCreate", route.Handler)
+	assert.Equal(t, "admin", route.Access)
+	assert.Equal(t, "admin", route.Auth)
+	assert.Equal(t, []string{"require_backups"}, route.Middleware)
+
+	all := routes.Routes()
+	for _, info := range all {
+		assert.NotEqual(t, echo.RouteNotFound, info.Method)
+	}
+}
@@ -0,0 +1,114 @@
+package web
+
+import (
+	"context"
+	"net/http"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/labstack/echo/v4"
+
+	"github.com/goformx/goforms/internal/application/constants"
+	"github.com/goformx/goforms/internal/application/middleware/access"
+	"github.com/goformx/goforms/internal/application/response"
+	"github.com/goformx/goforms/internal/domain/securityevent"
+)
+
+// AdminSecurityHandler serves detected security events and the IP blocks they
+// applied under /api/v1/admin/security. Admin access is enforced by the access middleware.
+type AdminSecurityHandler struct {
+	*BaseHandler
+	Events securityevent.Service
+	Access *access.Manager
+}
+
+// NewAdminSecurityHandler creates a new AdminSecurityHandler
+func NewAdminSecurityHandler(
+	base *BaseHandler,
+	events securityevent.Service,
+	accessManager *access.Manager,
+) *AdminSecurityHandler {
+	return &AdminSecurityHandler{BaseHandler: base, Events: events, Access: accessManager}
+}
+
+// BlockedIP is an active IP block
+type BlockedIP struct {
+	IPAddress    string    `json:"ip_address"`
+	BlockedUntil time.Time `json:"blocked_until"`
+}
+
+// RegisterRoutes registers the security event routes
+func (h *AdminSecurityHandler) RegisterRoutes(e *echo.Echo) {
+	security := e.Group(constants.PathAPIAdminSecurity)
+	security.GET("/events", h.handleListEvents)
+	security.GET("/blocks", h.handleListBlocks)
+	security.DELETE("/blocks/:ip", h.handleUnblock)
+}
+
+// Register satisfies the Handler interface; routes are registered by RegisterHandlers
+func (h *AdminSecurityHandler) Register(_ *echo.Echo) {}
+
+// Start initializes the handler
+func (h *AdminSecurityHandler) Start(_ context.Context) error {
+	return nil
+}
+
+// Stop cleans up the handler
+func (h *AdminSecurityHandler) Stop(_ context.Context) error {
+	return nil
+}
+
+// GET /api/v1/admin/security/events?kind=&ip=&offset=&limit= - lists security events, newest first
+func (h *AdminSecurityHandler) handleListEvents(c echo.Context) error {
+	offset, limit, err := parsePage(c)
+	if err != nil {
+		return response.ErrorResponse(c, http.StatusBadRequest, err.Error())
+	}
+
+	filter := securityevent.Filter{Kind: c.QueryParam("kind"), IPAddress: c.QueryParam("ip")}
+
+	events, total, err := h.Events.List(c.Request().Context(), filter, offset, limit)
+	if err != nil {
+		return h.HandleError(c, err, "Failed to list security events")
+	}
+
+	return response.Success(c, map[string]any{
+		"events": events,
+		"total":  total,
+		"offset": offset,
+		"limit":  limit,
+	})
+}
+
+// GET /api/v1/admin/security/blocks - lists the active IP blocks, ending soonest first
+func (h *AdminSecurityHandler) handleListBlocks(c echo.Context) error {
+	blocked := h.Access.BlockedIPs()
+
+	blocks := make([]BlockedIP, 0, len(blocked))
+	for ip, until := range blocked {
+		blocks = append(blocks, BlockedIP{IPAddress: ip, BlockedUntil: until})
+	}
+
+	slices.SortFunc(blocks, func(a, b BlockedIP) int {
+		if n := a.BlockedUntil.Compare(b.BlockedUntil); n != 0 {
+			return n
+		}
+
+		return strings.Compare(a.IPAddress, b.IPAddress)
+	})
+
+	return response.Success(c, map[string]any{"blocks": blocks})
+}
+
+// DELETE /api/v1/admin/security/blocks/:ip - lifts an IP block
+func (h *AdminSecurityHandler) handleUnblock(c echo.Context) error {
+	ip := c.Param("ip")
+	if !h.Access.UnblockIP(ip) {
+		return response.ErrorResponse(c, http.StatusNotFound, "IP address is not blocked")
+	}
+
+	h.Logger.Info("ip block lifted", "ip_address", ip)
+
+	return c.NoContent(http.StatusNoContent)
+}
@@ -0,0 +1,63 @@
+package web
+
+import (
+	"context"
+
+	"github.com/labstack/echo/v4"
+
+	"github.com/goformx/goforms/internal/application/constants"
+	"github.com/goformx/goforms/internal/application/response"
+	"github.com/goformx/goforms/internal/infrastructure/updates"
+)
+
+// AdminUpdateHandler reports new GoForms releases under /api/v1/admin/updates
+// for the admin dashboard's upgrade notice. Admin access is enforced by the
+// access middleware.
+type AdminUpdateHandler struct {
+	*BaseHandler
+	// Updates is nil when update checks are disabled
+	Updates *updates.Checker
+}
+
+// NewAdminUpdateHandler creates a new AdminUpdateHandler
+func NewAdminUpdateHandler(base *BaseHandler, checker *updates.Checker) *AdminUpdateHandler {
+	return &AdminUpdateHandler{BaseHandler: base, Updates: checker}
+}
+
+// RegisterRoutes registers the update status route
+func (h *AdminUpdateHandler) RegisterRoutes(e *echo.Echo) {
+	e.GET(constants.PathAPIAdminUpdates, h.handleStatus)
+}
+
+// Register satisfies the Handler interface; routes are registered by RegisterHandlers
+func (h *AdminUpdateHandler) Register(_ *echo.Echo) {}
+
+// Start initializes the handler
+func (h *AdminUpdateHandler) Start(_ context.Context) error {
+	return nil
+}
+
+// Stop cleans up the handler
+func (h *AdminUpdateHandler) Stop(_ context.Context) error {
+	return nil
+}
+
+// GET /api/v1/admin/updates?refresh=true - returns the running version and the
+// latest release; refresh checks GitHub now instead of returning the last check
+func (h *AdminUpdateHandler) handleStatus(c echo.Context) error {
+	status := h.Updates.Status()
+
+	if c.QueryParam("refresh") == "true" {
+		// A failed check is reported in the status's error
+		status, _ = h.Updates.Check(c.Request().Context())
+	}
+
+	return response.Success(c, map[string]any{
+		"enabled":          status.Enabled,
+		"current":          status.Current,
+		"latest":           status.Latest,
+		"update_available": status.UpdateAvailable,
+		"checked_at":       status.CheckedAt,
+		"error":            status.Error,
+	})
+}
@@ -0,0 +1,76 @@
+package web_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/goformx/goforms/internal/application/constants"
+	"github.com/goformx/goforms/internal/application/handlers/web"
+	"github.com/goformx/goforms/internal/infrastructure/config"
+	"github.com/goformx/goforms/internal/infrastructure/updates"
+	"github.com/goformx/goforms/internal/infrastructure/version"
+	mocklogging "github.com/goformx/goforms/test/mocks/logging"
+)
+
+func TestAdminUpdateHandler(t *testing.T) {
+	previous := version.Version
+	version.Version = "v1.0.0"
+
+	t.Cleanup(func() { version.Version = previous })
+
+	releases := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte(`{"tag_name": "v1.1.0", "body": "- Update notices"}`))
+	}))
+	defer releases.Close()
+
+	ctrl := gomock.NewController(t)
+
+	logger := mocklogging.NewMockLogger(ctrl)
+	logger.EXPECT().Info(gomock.Any(), gomock.Any()).AnyTimes()
+
+	checker := updates.New(config.UpdatesConfig{
+		Enabled:    true,
+		Repository: "goformx/goforms",
+		APIURL:     releases.URL,
+	}, nil, logger)
+
+	call := func(handler *web.AdminUpdateHandler, path string) map[string]any {
+		e := echo.New()
+		handler.RegisterRoutes(e)
+
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, path, http.NoBody))
+		require.Equal(t, http.StatusOK, rec.Code)
+
+		var payload struct {
+			Data map[string]any `json:"data"`
+		}
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &payload))
+
+		return payload.Data
+	}
+
+	base := &web.BaseHandler{Logger: logger}
+	handler := web.NewAdminUpdateHandler(base, checker)
+
+	data := call(handler, constants.PathAPIAdminUpdates)
+	assert.Equal(t, true, data["enabled"])
+	assert.Equal(t, false, data["update_available"])
+	assert.Nil(t, data["latest"], "nothing is fetched until a check runs")
+
+	data = call(handler, constants.PathAPIAdminUpdates+"?refresh=true")
+	assert.Equal(t, true, data["update_available"])
+	assert.Equal(t, "v1.1.0", data["latest"].(map[string]any)["version"])
+	assert.Equal(t, []any{"Update notices"}, data["latest"].(map[string]any)["highlights"])
+
+	data = call(web.NewAdminUpdateHandler(base, nil), constants.PathAPIAdminUpdates+"?refresh=true")
+	assert.Equal(t, false, data["enabled"])
+	assert.Equal(t, "v1.0.0", data["current"])
+}
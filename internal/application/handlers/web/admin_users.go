@@ -0,0 +1,240 @@
+package web
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/labstack/echo/v4"
+
+	"github.com/goformx/goforms/internal/application/constants"
+	mwcontext "github.com/goformx/goforms/internal/application/middleware/context"
+	"github.com/goformx/goforms/internal/application/response"
+	"github.com/goformx/goforms/internal/domain/entities"
+	"github.com/goformx/goforms/internal/domain/form"
+	"github.com/goformx/goforms/internal/domain/user"
+	"github.com/goformx/goforms/internal/infrastructure/repository/common"
+)
+
+// AdminUserHandler serves the admin user management endpoints under
+// /api/v1/admin/users. Admin access is enforced by the access middleware;
+// every change is recorded in the audit log by user.AdminService.
+type AdminUserHandler struct {
+	*BaseHandler
+	Users     user.AdminService
+	Forms     form.Service
+	Deletions user.DeletionService
+}
+
+// NewAdminUserHandler creates a new AdminUserHandler
+func NewAdminUserHandler(
+	base *BaseHandler, users user.AdminService, forms form.Service, deletions user.DeletionService,
+) *AdminUserHandler {
+	return &AdminUserHandler{BaseHandler: base, Users: users, Forms: forms, Deletions: deletions}
+}
+
+// SetRoleRequest is the body of PUT /api/v1/admin/users/:id/role
+type SetRoleRequest struct {
+	Role string `json:"role"`
+}
+
+// AdminFormSummary describes one of a user's forms in the admin user detail
+type AdminFormSummary struct {
+	ID        string    `json:"id"`
+	Title     string    `json:"title"`
+	Status    string    `json:"status"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// RegisterRoutes registers the admin user routes
+func (h *AdminUserHandler) RegisterRoutes(e *echo.Echo) {
+	users := e.Group(constants.PathAPIAdminUsers)
+	users.GET("", h.handleListUsers)
+	users.GET("/:id", h.handleGetUser)
+	users.DELETE("/:id", h.handleDelete)
+	users.PUT("/:id/role", h.handleSetRole)
+	users.POST("/:id/disable", h.handleDisable)
+	users.POST("/:id/enable", h.handleEnable)
+	users.POST("/:id/password-reset", h.handleRequirePasswordReset)
+	users.POST("/:id/impersonate", h.handleImpersonate)
+}
+
+// Register satisfies the Handler interface; routes are registered by RegisterHandlers
+func (h *AdminUserHandler) Register(_ *echo.Echo) {}
+
+// Start initializes the handler
+func (h *AdminUserHandler) Start(_ context.Context) error {
+	return nil
+}
+
+// Stop cleans up the handler
+func (h *AdminUserHandler) Stop(_ context.Context) error {
+	return nil
+}
+
+// GET /api/v1/admin/users?q=&role=&active=&offset=&limit= - searches users, newest first
+func (h *AdminUserHandler) handleListUsers(c echo.Context) error {
+	offset, limit, err := parsePage(c)
+	if err != nil {
+		return response.ErrorResponse(c, http.StatusBadRequest, err.Error())
+	}
+
+	filter := user.Filter{Query: c.QueryParam("q"), Role: c.QueryParam("role")}
+
+	if value := c.QueryParam("active"); value != "" {
+		active, parseErr := strconv.ParseBool(value)
+		if parseErr != nil {
+			return response.ErrorResponse(c, http.StatusBadRequest, "active must be true or false")
+		}
+
+		filter.Active = &active
+	}
+
+	users, total, err := h.Users.ListUsers(c.Request().Context(), filter, offset, limit)
+	if err != nil {
+		return h.handleUserError(c, err, "Failed to list users")
+	}
+
+	return response.Success(c, map[string]any{
+		"users":  users,
+		"total":  total,
+		"offset": offset,
+		"limit":  limit,
+	})
+}
+
+// GET /api/v1/admin/users/:id - returns a user with a summary of their forms
+func (h *AdminUserHandler) handleGetUser(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	u, err := h.Users.GetUser(ctx, c.Param("id"))
+	if err != nil {
+		return h.handleUserError(c, err, "Failed to get user")
+	}
+
+	forms, err := h.Forms.ListForms(ctx, u.ID)
+	if err != nil {
+		return h.HandleError(c, err, "Failed to list user forms")
+	}
+
+	summaries := make([]AdminFormSummary, 0, len(forms))
+	for _, f := range forms {
+		summaries = append(summaries, AdminFormSummary{
+			ID:        f.ID,
+			Title:     f.Title,
+			Status:    f.Status,
+			CreatedAt: f.CreatedAt,
+			UpdatedAt: f.UpdatedAt,
+		})
+	}
+
+	return response.Success(c, map[string]any{
+		"user":  u,
+		"forms": summaries,
+	})
+}
+
+// PUT /api/v1/admin/users/:id/role - changes a user's role and signs them out
+func (h *AdminUserHandler) handleSetRole(c echo.Context) error {
+	var req SetRoleRequest
+	if err := c.Bind(&req); err != nil {
+		return response.ErrorResponse(c, http.StatusBadRequest, "Invalid request body")
+	}
+
+	return h.change(c, "Failed to change user role", func(ctx context.Context, actor user.Actor, id string) (*entities.User, error) {
+		return h.Users.SetRole(ctx, actor, id, req.Role)
+	})
+}
+
+// POST /api/v1/admin/users/:id/disable - disables an account and signs the user out
+func (h *AdminUserHandler) handleDisable(c echo.Context) error {
+	return h.change(c, "Failed to disable user", func(ctx context.Context, actor user.Actor, id string) (*entities.User, error) {
+		return h.Users.SetActive(ctx, actor, id, false)
+	})
+}
+
+// POST /api/v1/admin/users/:id/enable - re-enables a disabled account
+func (h *AdminUserHandler) handleEnable(c echo.Context) error {
+	return h.change(c, "Failed to enable user", func(ctx context.Context, actor user.Actor, id string) (*entities.User, error) {
+		return h.Users.SetActive(ctx, actor, id, true)
+	})
+}
+
+// POST /api/v1/admin/users/:id/password-reset - requires a new password and signs the user out
+func (h *AdminUserHandler) handleRequirePasswordReset(c echo.Context) error {
+	return h.change(c, "Failed to require password reset", func(ctx context.Context, actor user.Actor, id string) (*entities.User, error) {
+		return h.Users.RequirePasswordReset(ctx, actor, id)
+	})
+}
+
+// DELETE /api/v1/admin/users/:id - deletes an account with its forms and
+// submissions at once, without a grace period, and signs the user out
+func (h *AdminUserHandler) handleDelete(c echo.Context) error {
+	actorID, ok := mwcontext.GetUserID(c)
+	if !ok {
+		return h.HandleForbidden(c, "Admin session required")
+	}
+
+	id := c.Param("id")
+
+	deletion, err := h.Deletions.DeleteUser(c.Request().Context(), user.Actor{ID: actorID, IPAddress: c.RealIP()}, id)
+	if err != nil {
+		return h.handleUserError(c, err, "Failed to delete user")
+	}
+
+	h.revokeSessions(id)
+
+	return response.Success(c, deletion)
+}
+
+// change applies an account change as the signed-in admin. The user's sessions
+// are revoked afterwards, so a new role or status applies from their next request.
+func (h *AdminUserHandler) change(
+	c echo.Context,
+	message string,
+	apply func(ctx context.Context, actor user.Actor, id string) (*entities.User, error),
+) error {
+	actorID, ok := mwcontext.GetUserID(c)
+	if !ok {
+		return h.HandleForbidden(c, "Admin session required")
+	}
+
+	u, err := apply(c.Request().Context(), user.Actor{ID: actorID, IPAddress: c.RealIP()}, c.Param("id"))
+	if err != nil {
+		return h.handleUserError(c, err, message)
+	}
+
+	h.revokeSessions(u.ID)
+
+	return response.Success(c, u)
+}
+
+// revokeSessions signs a user out of every session
+func (h *AdminUserHandler) revokeSessions(id string) {
+	if h.SessionManager == nil {
+		return
+	}
+
+	if revoked := h.SessionManager.DeleteUserSessions(id); revoked > 0 {
+		h.Logger.Info("user sessions revoked", "user_id", id, "count", revoked)
+	}
+}
+
+// handleUserError maps user administration errors to responses
+func (h *AdminUserHandler) handleUserError(c echo.Context, err error, message string) error {
+	switch {
+	case errors.Is(err, user.ErrRoleInvalid):
+		return response.ErrorResponse(c, http.StatusBadRequest, user.ErrRoleInvalid.Message)
+	case errors.Is(err, user.ErrSelfModification):
+		return response.ErrorResponse(c, http.StatusForbidden, user.ErrSelfModification.Message)
+	case errors.Is(err, user.ErrImpersonationNotAllowed):
+		return response.ErrorResponse(c, http.StatusForbidden, user.ErrImpersonationNotAllowed.Message)
+	case errors.Is(err, common.ErrNotFound):
+		return response.ErrorResponse(c, http.StatusNotFound, "User not found")
+	}
+
+	return h.HandleError(c, err, message)
+}
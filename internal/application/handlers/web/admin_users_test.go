@@ -0,0 +1,127 @@
+package web_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/goformx/goforms/internal/application/constants"
+	"github.com/goformx/goforms/internal/application/handlers/web"
+	"github.com/goformx/goforms/internal/domain/audit"
+	"github.com/goformx/goforms/internal/domain/entities"
+	"github.com/goformx/goforms/internal/domain/form/model"
+	"github.com/goformx/goforms/internal/domain/user"
+	"github.com/goformx/goforms/internal/infrastructure/event"
+	"github.com/goformx/goforms/internal/infrastructure/pii"
+	memorystore "github.com/goformx/goforms/internal/infrastructure/repository/memory"
+	mockform "github.com/goformx/goforms/test/mocks/form"
+	mocklogging "github.com/goformx/goforms/test/mocks/logging"
+)
+
+func TestAdminUserHandler(t *testing.T) {
+	ctrl := gomock.NewController(t)
+
+	logger := mocklogging.NewMockLogger(ctrl)
+	logger.EXPECT().Info(gomock.Any(), gomock.Any()).AnyTimes()
+
+	store := memorystore.NewStore(logger)
+	users := store.Users()
+	directory, ok := users.(user.DirectoryRepository)
+	require.True(t, ok)
+
+	target, err := entities.NewUser("grace@example.com", "password123", "Grace", "Hopper")
+	require.NoError(t, err)
+	require.NoError(t, users.Create(context.Background(), target))
+
+	auditService := audit.NewService(store.Audit(), logger)
+	base := &web.BaseHandler{Logger: logger}
+
+	forms := mockform.NewMockService(ctrl)
+	forms.EXPECT().ListForms(gomock.Any(), target.ID).
+		Return([]*model.Form{{ID: "form-1", Title: "Survey", Status: "published"}}, nil)
+
+	e := echo.New()
+	e.Use(func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			c.Set("user_id", "admin-1")
+			c.Set("role", "admin")
+
+			return next(c)
+		}
+	})
+	deletions, ok := users.(user.DeletionRepository)
+	require.True(t, ok)
+
+	classifier, err := pii.NewClassifier(nil)
+	require.NoError(t, err)
+
+	deletionService := user.NewDeletionService(users, deletions, store.Forms(), auditService, event.NewMemoryEventBus(logger),
+		classifier, user.DeletionOptions{}, logger)
+	web.NewAdminUserHandler(base, user.NewAdminService(users, directory, auditService, logger), forms, deletionService).RegisterRoutes(e)
+	web.NewAdminAuditHandler(base, auditService).RegisterRoutes(e)
+
+	call := func(method, path, body string) (int, map[string]any) {
+		req := httptest.NewRequest(method, path, strings.NewReader(body))
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, req)
+
+		var payload struct {
+			Data map[string]any `json:"data"`
+		}
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &payload))
+
+		return rec.Code, payload.Data
+	}
+
+	code, data := call(http.MethodGet, constants.PathAPIAdminUsers+"?q=hopper&active=true", "")
+	require.Equal(t, http.StatusOK, code)
+	assert.InDelta(t, 1, data["total"], 0)
+
+	code, _ = call(http.MethodGet, constants.PathAPIAdminUsers+"?active=maybe", "")
+	assert.Equal(t, http.StatusBadRequest, code)
+
+	code, data = call(http.MethodGet, constants.PathAPIAdminUsers+"/"+target.ID, "")
+	require.Equal(t, http.StatusOK, code)
+	assert.Len(t, data["forms"], 1)
+
+	code, _ = call(http.MethodPut, constants.PathAPIAdminUsers+"/"+target.ID+"/role", `{"role":"owner"}`)
+	assert.Equal(t, http.StatusBadRequest, code)
+
+	code, data = call(http.MethodPut, constants.PathAPIAdminUsers+"/"+target.ID+"/role", `{"role":"admin"}`)
+	require.Equal(t, http.StatusOK, code)
+	assert.Equal(t, "admin", data["role"])
+
+	code, data = call(http.MethodPost, constants.PathAPIAdminUsers+"/"+target.ID+"/disable", "")
+	require.Equal(t, http.StatusOK, code)
+	assert.Equal(t, false, data["active"])
+
+	code, _ = call(http.MethodPost, constants.PathAPIAdminUsers+"/admin-1/disable", "")
+	assert.Equal(t, http.StatusForbidden, code)
+
+	code, _ = call(http.MethodPost, constants.PathAPIAdminUsers+"/missing/password-reset", "")
+	assert.Equal(t, http.StatusNotFound, code)
+
+	code, data = call(http.MethodGet, constants.PathAPIAdminAudit+"?target_id="+target.ID, "")
+	require.Equal(t, http.StatusOK, code)
+	assert.InDelta(t, 2, data["total"], 0)
+
+	code, _ = call(http.MethodDelete, constants.PathAPIAdminUsers+"/admin-1", "")
+	assert.Equal(t, http.StatusForbidden, code)
+
+	code, data = call(http.MethodDelete, constants.PathAPIAdminUsers+"/"+target.ID, "")
+	require.Equal(t, http.StatusOK, code)
+	assert.Equal(t, target.ID, data["user_id"])
+
+	code, _ = call(http.MethodDelete, constants.PathAPIAdminUsers+"/"+target.ID, "")
+	assert.Equal(t, http.StatusNotFound, code)
+}
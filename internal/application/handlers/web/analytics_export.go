@@ -0,0 +1,257 @@
+package web
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"path"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+
+	"github.com/goformx/goforms/internal/application/constants"
+	"github.com/goformx/goforms/internal/domain/archive"
+	formdomain "github.com/goformx/goforms/internal/domain/form"
+	"github.com/goformx/goforms/internal/domain/form/model"
+	"github.com/goformx/goforms/internal/infrastructure/config"
+	"github.com/goformx/goforms/internal/infrastructure/logging"
+	"github.com/goformx/goforms/internal/infrastructure/scheduler"
+	"github.com/goformx/goforms/internal/infrastructure/storage"
+)
+
+// Submission export formats
+const (
+	exportFormatCSV     = "csv"
+	exportFormatParquet = "parquet"
+)
+
+// parquetExt names the files written by the analytics export
+const parquetExt = ".parquet"
+
+// exportStatuses are the form statuses whose submissions are exported
+var exportStatuses = []string{constants.FormStatusDraft, constants.FormStatusPublished, constants.FormStatusArchived}
+
+// analyticsStorage stores analytics export files
+type analyticsStorage interface {
+	Put(ctx context.Context, key string, data []byte) error
+	List(ctx context.Context, prefix string) ([]string, error)
+	Delete(ctx context.Context, key string) error
+}
+
+// AnalyticsExporter writes each form's submissions to <prefix>/<form id>.parquet
+// on a schedule, so BI tools can query them without touching the database
+type AnalyticsExporter struct {
+	forms       formdomain.Repository
+	formService formdomain.Service
+	archives    archive.Service
+	storage     analyticsStorage
+	prefix      string
+	logger      logging.Logger
+	runner      *scheduler.Runner
+}
+
+// NewAnalyticsExporter creates the scheduled analytics export, writing to the
+// configured object storage. It returns nil when storage.analytics.enabled is
+// unset. archives may be nil.
+func NewAnalyticsExporter(
+	cfg config.StorageConfig,
+	objects storage.Storage,
+	forms formdomain.Repository,
+	formService formdomain.Service,
+	archives archive.Service,
+	logger logging.Logger,
+) *AnalyticsExporter {
+	if !cfg.Analytics.Enabled || forms == nil {
+		return nil
+	}
+
+	e := &AnalyticsExporter{
+		forms:       forms,
+		formService: formService,
+		archives:    archives,
+		storage:     objects,
+		prefix:      strings.Trim(cfg.Analytics.Prefix, "/"),
+		logger:      logger,
+	}
+	e.runner = scheduler.NewRunner("analytics-export", cfg.Analytics.Interval, e.Run, logger)
+
+	return e
+}
+
+// Start begins the export schedule
+func (e *AnalyticsExporter) Start() {
+	if e == nil {
+		return
+	}
+
+	e.runner.Start()
+}
+
+// Stop stops the export schedule and waits for an in-flight run to finish
+func (e *AnalyticsExporter) Stop(ctx context.Context) {
+	if e == nil {
+		return
+	}
+
+	e.runner.Stop(ctx)
+}
+
+// Run exports every form's submissions and removes the files of deleted forms.
+// A failing form is logged and retried on the next run.
+func (e *AnalyticsExporter) Run(ctx context.Context) error {
+	exported := make(map[string]bool)
+
+	for _, status := range exportStatuses {
+		forms, err := e.forms.GetFormsByStatus(ctx, status)
+		if err != nil {
+			return fmt.Errorf("list %s forms: %w", status, err)
+		}
+
+		for _, formModel := range forms {
+			key := path.Join(e.prefix, formModel.ID+parquetExt)
+			exported[key] = true
+
+			if exportErr := e.export(ctx, formModel, key); exportErr != nil {
+				e.logger.Error("failed to export form submissions", "form_id", formModel.ID, "error", exportErr)
+			}
+		}
+	}
+
+	keys, err := e.storage.List(ctx, e.prefix)
+	if err != nil {
+		return fmt.Errorf("list analytics exports: %w", err)
+	}
+
+	for _, key := range keys {
+		if path.Dir(key) != e.prefix || !strings.HasSuffix(key, parquetExt) || exported[key] {
+			continue
+		}
+
+		if deleteErr := e.storage.Delete(ctx, key); deleteErr != nil {
+			return fmt.Errorf("delete stale analytics export %s: %w", key, deleteErr)
+		}
+	}
+
+	e.logger.Info("analytics export finished", "forms", len(exported))
+
+	return nil
+}
+
+// export writes one form's submissions, test submissions aside
+func (e *AnalyticsExporter) export(ctx context.Context, formModel *model.Form, key string) error {
+	submissions, err := exportSubmissions(ctx, e.formService, e.archives, formModel.ID)
+	if err != nil {
+		return err
+	}
+
+	submissions = model.WithoutTestSubmissions(submissions)
+
+	data, err := submissionsParquet(formModel, submissions)
+	if err != nil {
+		return err
+	}
+
+	if putErr := e.storage.Put(ctx, key, data); putErr != nil {
+		return fmt.Errorf("store analytics export: %w", putErr)
+	}
+
+	return nil
+}
+
+// exportSubmissions returns a form's submissions, archived ones included, oldest first
+func exportSubmissions(
+	ctx context.Context,
+	formService formdomain.Service,
+	archives archive.Service,
+	formID string,
+) ([]*model.FormSubmission, error) {
+	submissions, err := formService.ListFormSubmissions(ctx, formID)
+	if err != nil {
+		return nil, fmt.Errorf("list form submissions: %w", err)
+	}
+
+	if archives != nil {
+		archived, archiveErr := archives.List(ctx, formID)
+		if archiveErr != nil {
+			return nil, fmt.Errorf("list archived submissions: %w", archiveErr)
+		}
+
+		submissions = slices.Concat(archived, submissions)
+	}
+
+	slices.SortStableFunc(submissions, func(a, b *model.FormSubmission) int {
+		return a.SubmittedAt.Compare(b.SubmittedAt)
+	})
+
+	return submissions, nil
+}
+
+// GET /api/forms/:id/submissions/export - download all submissions (assertion auth).
+// ?format=csv (default) or ?format=parquet; archived submissions are included.
+// Supports the same ?view=, ?tag=, ?status= and ?q= filters as the submission list.
+// ?redact=true applies the form's redaction policy, for exports shared outside the team.
+// Test submissions are left out unless ?test=only or ?test=include asks for them.
+func (h *FormAPIHandler) handleExportSubmissions(c echo.Context) error {
+	form, err := h.getFormWithOwnershipOrError(c)
+	if err != nil || form == nil {
+		return err
+	}
+
+	format := strings.ToLower(c.QueryParam("format"))
+	if format == "" {
+		format = exportFormatCSV
+	}
+
+	if format != exportFormatCSV && format != exportFormatParquet {
+		return h.ResponseBuilder.BuildValidationErrorResponse(c, "format", "format must be csv or parquet")
+	}
+
+	filter, handled, err := h.submissionFilter(c, form.ID)
+	if handled {
+		return err
+	}
+
+	testFilter, handled, err := h.testSubmissionFilter(c)
+	if handled {
+		return err
+	}
+
+	submissions, err := exportSubmissions(c.Request().Context(), h.FormService, h.Archives, form.ID)
+	if err != nil {
+		h.Logger.Error("failed to load submissions for export", "error", err, "form_id", form.ID)
+
+		return h.HandleError(c, err, "Failed to export submissions")
+	}
+
+	submissions = filter.Apply(testFilter.Apply(submissions))
+
+	if redact, _ := strconv.ParseBool(c.QueryParam("redact")); redact {
+		submissions = formdomain.RedactSubmissions(form, submissions, h.Classifier)
+	}
+
+	var (
+		data        []byte
+		contentType string
+	)
+
+	if format == exportFormatParquet {
+		data, err = submissionsParquet(form, submissions)
+		contentType = "application/vnd.apache.parquet"
+	} else {
+		data, err = formdomain.SubmissionsCSV(form.Schema, submissions, h.formLocation(c.Request().Context(), form))
+		contentType = "text/csv; charset=utf-8"
+	}
+
+	if err != nil {
+		h.Logger.Error("failed to encode submission export", "error", err, "form_id", form.ID, "format", format)
+
+		return h.HandleError(c, err, "Failed to export submissions")
+	}
+
+	filename := "submissions-" + form.ID + "." + format
+	c.Response().Header().Set(echo.HeaderContentDisposition, fmt.Sprintf("attachment; filename=%q", filename))
+
+	return c.Blob(http.StatusOK, contentType, data)
+}
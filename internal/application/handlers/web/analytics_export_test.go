@@ -0,0 +1,77 @@
+package web_test
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/goformx/goforms/internal/application/handlers/web"
+	formdomain "github.com/goformx/goforms/internal/domain/form"
+	"github.com/goformx/goforms/internal/domain/form/model"
+	"github.com/goformx/goforms/internal/infrastructure/config"
+	memorystore "github.com/goformx/goforms/internal/infrastructure/repository/memory"
+	"github.com/goformx/goforms/internal/infrastructure/storage"
+	mocklogging "github.com/goformx/goforms/test/mocks/logging"
+)
+
+func TestAnalyticsExporter_Run(t *testing.T) {
+	ctx := context.Background()
+
+	logger := mocklogging.NewMockLogger(gomock.NewController(t))
+	logger.EXPECT().Info(gomock.Any(), gomock.Any()).AnyTimes()
+
+	store := memorystore.NewStore(logger)
+
+	formModel := model.NewForm("user-1", "Survey", "", model.JSON{"components": []any{
+		map[string]any{"key": "age", "type": "number", "input": true},
+		map[string]any{"key": "name", "type": "textfield", "input": true},
+	}})
+	formModel.Status = "published"
+	require.NoError(t, store.Forms().CreateForm(ctx, formModel))
+
+	require.NoError(t, store.Submissions().CreateSubmission(ctx, &model.FormSubmission{
+		FormID:      formModel.ID,
+		Data:        model.JSON{"age": 36.0, "name": "Ada"},
+		Status:      model.SubmissionStatusPending,
+		SubmittedAt: time.Now(),
+	}))
+
+	dir := t.TempDir()
+	stale := filepath.Join(dir, "analytics", "deleted-form.parquet")
+	require.NoError(t, os.MkdirAll(filepath.Dir(stale), 0o750))
+	require.NoError(t, os.WriteFile(stale, []byte("PAR1"), 0o600))
+
+	exporter := web.NewAnalyticsExporter(config.StorageConfig{
+		Analytics: config.AnalyticsConfig{Enabled: true, Interval: time.Hour, Prefix: "analytics"},
+	}, storage.NewLocal(dir), store.Forms(), formdomain.NewService(store.Forms(), nil, nil, nil, formdomain.ImageLimits{}, logger), nil, logger)
+	require.NotNil(t, exporter)
+
+	require.NoError(t, exporter.Run(ctx))
+
+	data, err := os.ReadFile(filepath.Join(dir, "analytics", formModel.ID+".parquet"))
+	require.NoError(t, err)
+	assert.True(t, bytes.HasPrefix(data, []byte("PAR1")))
+	assert.True(t, bytes.HasSuffix(data, []byte("PAR1")))
+
+	for _, column := range []string{"form_id", "submission_id", "submitted_at", "status", "age", "name"} {
+		assert.Contains(t, string(data), column)
+	}
+
+	_, err = os.Stat(stale)
+	require.ErrorIs(t, err, os.ErrNotExist, "exports of deleted forms are removed")
+}
+
+func TestNewAnalyticsExporter_Disabled(t *testing.T) {
+	exporter := web.NewAnalyticsExporter(config.StorageConfig{}, nil, nil, nil, nil, nil)
+	assert.Nil(t, exporter)
+
+	exporter.Start()
+	exporter.Stop(context.Background())
+}
@@ -12,6 +12,7 @@ import (
 	"github.com/goformx/goforms/internal/application/response"
 	domainerrors "github.com/goformx/goforms/internal/domain/common/errors"
 	"github.com/goformx/goforms/internal/domain/entities"
+	"github.com/goformx/goforms/internal/domain/flags"
 	"github.com/goformx/goforms/internal/domain/form"
 	"github.com/goformx/goforms/internal/domain/user"
 	"github.com/goformx/goforms/internal/infrastructure/config"
@@ -26,6 +27,8 @@ type BaseHandler struct {
 	FormService    form.Service
 	SessionManager *session.Manager
 	ErrorHandler   response.ErrorHandlerInterface
+	// Flags evaluates feature flags for the request's account
+	Flags flags.Client
 }
 
 // NewBaseHandler creates a new base handler with common dependencies
@@ -36,6 +39,7 @@ func NewBaseHandler(
 	formService form.Service,
 	sessionManager *session.Manager,
 	errorHandler response.ErrorHandlerInterface,
+	flagClient flags.Client,
 ) *BaseHandler {
 	return &BaseHandler{
 		Logger:         logger,
@@ -44,9 +48,22 @@ func NewBaseHandler(
 		FormService:    formService,
 		SessionManager: sessionManager,
 		ErrorHandler:   errorHandler,
+		Flags:          flagClient,
 	}
 }
 
+// FlagEnabled reports whether a feature flag is on for the request's user.
+// Unknown flags, and every flag without a flag client, are off.
+func (h *BaseHandler) FlagEnabled(c echo.Context, key string) bool {
+	if h.Flags == nil {
+		return false
+	}
+
+	userID, _ := mwcontext.GetUserID(c)
+
+	return h.Flags.Enabled(c.Request().Context(), key, userID)
+}
+
 // RequireAuthenticatedUser ensures the user is authenticated and returns the user object
 func (h *BaseHandler) RequireAuthenticatedUser(c echo.Context) (*entities.User, error) {
 	userID, ok := mwcontext.GetUserID(c)
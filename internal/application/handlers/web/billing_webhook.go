@@ -0,0 +1,91 @@
+package web
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+
+	"github.com/goformx/goforms/internal/application/constants"
+	"github.com/goformx/goforms/internal/application/response"
+	"github.com/goformx/goforms/internal/domain/billing"
+	"github.com/goformx/goforms/internal/infrastructure/stripe"
+)
+
+// BillingWebhookHandler serves Stripe's webhook at /api/v1/webhooks/stripe.
+// Requests carry no session; they authenticate with the Stripe-Signature
+// header, and the route is disabled (404) while billing is.
+type BillingWebhookHandler struct {
+	*BaseHandler
+	// Billing is nil when billing is disabled
+	Billing  billing.Service
+	Verifier *stripe.WebhookVerifier
+}
+
+// NewBillingWebhookHandler creates a new BillingWebhookHandler from the billing configuration
+func NewBillingWebhookHandler(base *BaseHandler, billingService billing.Service) *BillingWebhookHandler {
+	h := &BillingWebhookHandler{BaseHandler: base, Billing: billingService}
+
+	if billingService != nil {
+		cfg := base.Config.Billing.Stripe
+		h.Verifier = stripe.NewWebhookVerifier(cfg.WebhookSecret, cfg.WebhookTolerance)
+	}
+
+	return h
+}
+
+// RegisterRoutes registers the Stripe webhook route
+func (h *BillingWebhookHandler) RegisterRoutes(e *echo.Echo) {
+	e.POST(constants.PathAPIWebhooksStripe, h.handleStripe)
+}
+
+// DescribeRoutes describes how the webhook route authenticates
+func (h *BillingWebhookHandler) DescribeRoutes() []RouteGroup {
+	return []RouteGroup{{Prefix: constants.PathAPIWebhooksStripe, Auth: "provider_signature"}}
+}
+
+// Register satisfies the Handler interface; routes are registered by RegisterHandlers
+func (h *BillingWebhookHandler) Register(_ *echo.Echo) {}
+
+// Start initializes the handler
+func (h *BillingWebhookHandler) Start(_ context.Context) error {
+	return nil
+}
+
+// Stop cleans up the handler
+func (h *BillingWebhookHandler) Stop(_ context.Context) error {
+	return nil
+}
+
+// POST /api/v1/webhooks/stripe - receives Stripe's signed subscription events
+func (h *BillingWebhookHandler) handleStripe(c echo.Context) error {
+	if h.Billing == nil {
+		return response.ErrorResponse(c, http.StatusNotFound, "Billing webhook is not configured")
+	}
+
+	body, err := readWebhookBody(c)
+	if err != nil {
+		return response.ErrorResponse(c, http.StatusBadRequest, "Invalid request body")
+	}
+
+	// The signature covers the raw body, so it is checked before decoding
+	if verifyErr := h.Verifier.Verify(c.Request().Header.Get(stripe.SignatureHeader), body); verifyErr != nil {
+		h.Logger.Warn("billing webhook rejected", "provider", "stripe", "error", verifyErr)
+
+		return response.ErrorResponse(c, http.StatusForbidden, "Webhook verification failed")
+	}
+
+	event, err := stripe.ParseEvent(body)
+	if err != nil {
+		return response.ErrorResponse(c, http.StatusBadRequest, "Invalid Stripe event")
+	}
+
+	if handleErr := h.Billing.HandleEvent(c.Request().Context(), event); handleErr != nil {
+		h.Logger.Error("failed to apply billing event", "event_id", event.ID, "type", event.Type, "error", handleErr)
+
+		// An error status makes Stripe retry the delivery
+		return response.ErrorResponse(c, http.StatusInternalServerError, "Failed to apply billing event")
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
@@ -0,0 +1,240 @@
+package web
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+
+	"github.com/goformx/goforms/internal/application/constants"
+	"github.com/goformx/goforms/internal/application/middleware/assertion"
+	mwcontext "github.com/goformx/goforms/internal/application/middleware/context"
+	"github.com/goformx/goforms/internal/application/response"
+	domainerrors "github.com/goformx/goforms/internal/domain/common/errors"
+	"github.com/goformx/goforms/internal/domain/dataset"
+)
+
+// DatasetHandler manages the datasets of the requesting account at
+// /api/v1/datasets. Requests authenticate with a signed user assertion; there
+// is no session.
+type DatasetHandler struct {
+	*BaseHandler
+	Datasets            dataset.Service
+	AssertionMiddleware *assertion.Middleware
+
+	routes routeRecorder
+}
+
+// NewDatasetHandler creates a new DatasetHandler
+func NewDatasetHandler(base *BaseHandler, datasets dataset.Service) *DatasetHandler {
+	return &DatasetHandler{
+		BaseHandler:         base,
+		Datasets:            datasets,
+		AssertionMiddleware: assertion.NewMiddleware(base.Config, base.Logger),
+	}
+}
+
+// datasetRequest is the body of a dataset create or update
+type datasetRequest struct {
+	Name    string        `json:"name"`
+	Title   string        `json:"title"`
+	Kind    dataset.Kind  `json:"kind"`
+	Columns []string      `json:"columns"`
+	Rows    []dataset.Row `json:"rows"`
+}
+
+// RegisterRoutes registers the dataset routes
+func (h *DatasetHandler) RegisterRoutes(e *echo.Echo) {
+	h.routes.reset()
+
+	datasets := h.routes.group(e, constants.PathAPIDatasets, "assertion", named("assertion", h.AssertionMiddleware.Verify()))
+	datasets.GET("", h.handleList)
+	datasets.POST("", h.handleCreate)
+	datasets.GET("/:name", h.handleGet)
+	datasets.PUT("/:name", h.handleUpdate)
+	datasets.DELETE("/:name", h.handleDelete)
+	datasets.POST("/:name/csv", h.handleImportCSV)
+}
+
+// DescribeRoutes describes how the dataset routes authenticate
+func (h *DatasetHandler) DescribeRoutes() []RouteGroup {
+	return h.routes.described()
+}
+
+// Register satisfies the Handler interface; routes are registered by RegisterHandlers
+func (h *DatasetHandler) Register(_ *echo.Echo) {}
+
+// Start initializes the handler
+func (h *DatasetHandler) Start(_ context.Context) error {
+	return nil
+}
+
+// Stop cleans up the handler
+func (h *DatasetHandler) Stop(_ context.Context) error {
+	return nil
+}
+
+// owner returns the user the assertion middleware verified the request for
+func (h *DatasetHandler) owner(c echo.Context) (string, bool) {
+	return mwcontext.GetUserID(c)
+}
+
+// GET /api/v1/datasets - the account's datasets, without their rows
+func (h *DatasetHandler) handleList(c echo.Context) error {
+	ownerID, ok := h.owner(c)
+	if !ok {
+		return response.ErrorResponse(c, http.StatusUnauthorized, "Authentication required")
+	}
+
+	datasets, err := h.Datasets.List(c.Request().Context(), ownerID)
+	if err != nil {
+		return h.datasetError(c, err, "Failed to list datasets")
+	}
+
+	summaries := make([]map[string]any, 0, len(datasets))
+	for _, stored := range datasets {
+		summaries = append(summaries, datasetSummary(stored))
+	}
+
+	return response.Success(c, map[string]any{"datasets": summaries})
+}
+
+// POST /api/v1/datasets - create a dataset from JSON
+func (h *DatasetHandler) handleCreate(c echo.Context) error {
+	ownerID, ok := h.owner(c)
+	if !ok {
+		return response.ErrorResponse(c, http.StatusUnauthorized, "Authentication required")
+	}
+
+	var req datasetRequest
+	if err := c.Bind(&req); err != nil {
+		return response.ErrorResponse(c, http.StatusBadRequest, "Invalid request body")
+	}
+
+	created := &dataset.Dataset{
+		OwnerID: ownerID, Name: req.Name, Title: req.Title, Kind: req.Kind, Columns: req.Columns, Rows: req.Rows,
+	}
+	if err := h.Datasets.Create(c.Request().Context(), created); err != nil {
+		return h.datasetError(c, err, "Failed to create dataset")
+	}
+
+	return c.JSON(http.StatusCreated, response.APIResponse{Success: true, Data: created})
+}
+
+// GET /api/v1/datasets/:name - a dataset with its rows
+func (h *DatasetHandler) handleGet(c echo.Context) error {
+	ownerID, ok := h.owner(c)
+	if !ok {
+		return response.ErrorResponse(c, http.StatusUnauthorized, "Authentication required")
+	}
+
+	stored, err := h.Datasets.Get(c.Request().Context(), ownerID, c.Param("name"))
+	if err != nil {
+		return h.datasetError(c, err, "Failed to get dataset")
+	}
+
+	return response.Success(c, stored)
+}
+
+// PUT /api/v1/datasets/:name - replace a dataset's title, kind, columns and rows
+func (h *DatasetHandler) handleUpdate(c echo.Context) error {
+	ownerID, ok := h.owner(c)
+	if !ok {
+		return response.ErrorResponse(c, http.StatusUnauthorized, "Authentication required")
+	}
+
+	var req datasetRequest
+	if err := c.Bind(&req); err != nil {
+		return response.ErrorResponse(c, http.StatusBadRequest, "Invalid request body")
+	}
+
+	updated := &dataset.Dataset{
+		OwnerID: ownerID, Name: c.Param("name"), Title: req.Title, Kind: req.Kind, Columns: req.Columns, Rows: req.Rows,
+	}
+	if err := h.Datasets.Update(c.Request().Context(), updated); err != nil {
+		return h.datasetError(c, err, "Failed to update dataset")
+	}
+
+	return response.Success(c, updated)
+}
+
+// DELETE /api/v1/datasets/:name
+func (h *DatasetHandler) handleDelete(c echo.Context) error {
+	ownerID, ok := h.owner(c)
+	if !ok {
+		return response.ErrorResponse(c, http.StatusUnauthorized, "Authentication required")
+	}
+
+	if err := h.Datasets.Delete(c.Request().Context(), ownerID, c.Param("name")); err != nil {
+		return h.datasetError(c, err, "Failed to delete dataset")
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
+
+// POST /api/v1/datasets/:name/csv?kind=keyvalue|table - replace a dataset's
+// rows with a CSV file, sent as a multipart "file" field or the request body,
+// creating the dataset when it does not exist
+func (h *DatasetHandler) handleImportCSV(c echo.Context) error {
+	ownerID, ok := h.owner(c)
+	if !ok {
+		return response.ErrorResponse(c, http.StatusUnauthorized, "Authentication required")
+	}
+
+	var reader io.Reader = c.Request().Body
+
+	if fileHeader, err := c.FormFile("file"); err == nil {
+		file, openErr := fileHeader.Open()
+		if openErr != nil {
+			return response.ErrorResponse(c, http.StatusBadRequest, "Failed to open uploaded file")
+		}
+		defer file.Close()
+
+		reader = file
+	}
+
+	imported, err := h.Datasets.ImportCSV(c.Request().Context(), ownerID, c.Param("name"), dataset.Kind(c.QueryParam("kind")), reader)
+	if err != nil {
+		return h.datasetError(c, err, "Failed to import dataset")
+	}
+
+	return response.Success(c, datasetSummary(imported))
+}
+
+// datasetError responds to a dataset service error
+func (h *DatasetHandler) datasetError(c echo.Context, err error, message string) error {
+	switch {
+	case errors.Is(err, dataset.ErrDatasetNotFound):
+		return response.ErrorResponse(c, http.StatusNotFound, "Dataset not found")
+	case errors.Is(err, dataset.ErrDatasetExists):
+		return response.ErrorResponse(c, http.StatusConflict, dataset.ErrDatasetExists.Error())
+	}
+
+	if domainErr := domainerrors.GetDomainError(err); domainErr != nil {
+		switch domainErr.Code {
+		case domainerrors.ErrCodeValidation:
+			return response.ErrorResponse(c, http.StatusBadRequest, domainErr.Message)
+		case domainerrors.ErrCodeQuotaExceeded:
+			return response.ErrorResponse(c, http.StatusForbidden, domainErr.Message)
+		}
+	}
+
+	h.Logger.Error("dataset request failed", "path", c.Path(), "dataset", c.Param("name"), "error", err)
+
+	return response.ErrorResponse(c, http.StatusInternalServerError, message)
+}
+
+// datasetSummary describes a dataset without its rows
+func datasetSummary(stored *dataset.Dataset) map[string]any {
+	return map[string]any{
+		"name":       stored.Name,
+		"title":      stored.Title,
+		"kind":       stored.Kind,
+		"columns":    stored.Columns,
+		"row_count":  len(stored.Rows),
+		"created_at": stored.CreatedAt,
+		"updated_at": stored.UpdatedAt,
+	}
+}
@@ -0,0 +1,57 @@
+package web_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap/zaptest"
+
+	"github.com/goformx/goforms/internal/application/constants"
+	"github.com/goformx/goforms/internal/application/handlers/web"
+	"github.com/goformx/goforms/internal/domain/dataset"
+	"github.com/goformx/goforms/internal/infrastructure/config"
+	"github.com/goformx/goforms/internal/infrastructure/logging"
+	memorystore "github.com/goformx/goforms/internal/infrastructure/repository/memory"
+	"github.com/goformx/goforms/internal/infrastructure/sanitization"
+)
+
+func TestDatasetHandler_Assertion(t *testing.T) {
+	logger := logging.NewFromZap(zaptest.NewLogger(t), sanitization.NewService())
+	cfg := &config.Config{}
+	cfg.Security.Assertion = config.AssertionConfig{Secret: testAssertionSecret, TimestampSkewSeconds: 60}
+
+	datasets := dataset.NewService(memorystore.NewStore(logger).Datasets(), dataset.Limits{}, 0, logger)
+
+	e := echo.New()
+	web.NewDatasetHandler(&web.BaseHandler{Logger: logger, Config: cfg}, datasets).RegisterRoutes(e)
+
+	call := func(method, body string, signed bool) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, constants.PathAPIDatasets, strings.NewReader(body))
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+
+		if signed {
+			signedAssertion(req, formOwner, "owner@example.com")
+		}
+
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, req)
+
+		return rec
+	}
+
+	// The route group refuses unsigned requests before a handler runs
+	assert.Equal(t, http.StatusUnauthorized, call(http.MethodGet, "", false).Code)
+	assert.Equal(t, http.StatusUnauthorized,
+		call(http.MethodPost, `{"name":"cities","kind":"table","columns":["name"],"rows":[{"name":"Oslo"}]}`, false).Code)
+
+	assert.Equal(t, http.StatusCreated,
+		call(http.MethodPost, `{"name":"cities","kind":"table","columns":["name"],"rows":[{"name":"Oslo"}]}`, true).Code)
+
+	rec := call(http.MethodGet, "", true)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Contains(t, rec.Body.String(), `"cities"`)
+}
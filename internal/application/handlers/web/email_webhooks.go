@@ -0,0 +1,204 @@
+package web
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+
+	"github.com/goformx/goforms/internal/application/constants"
+	"github.com/goformx/goforms/internal/application/response"
+	"github.com/goformx/goforms/internal/domain/emaildelivery"
+	"github.com/goformx/goforms/internal/infrastructure/config"
+	"github.com/goformx/goforms/internal/infrastructure/email"
+	"github.com/goformx/goforms/internal/infrastructure/httpclient"
+)
+
+// maxWebhookBodySize bounds a webhook delivery; SendGrid batches events, SNS
+// messages are at most 256KB
+const maxWebhookBodySize = 4 << 20
+
+// EmailWebhookHandler serves the bounce and complaint webhooks under
+// /api/v1/webhooks/email. Requests carry no session; each provider's webhook
+// authenticates with the provider's signature and is disabled (404) until its
+// verification settings are configured.
+type EmailWebhookHandler struct {
+	*BaseHandler
+	Deliveries emaildelivery.Service
+	SNS        *email.SNSVerifier
+	SendGrid   *email.SendGridVerifier
+}
+
+// NewEmailWebhookHandler creates a new EmailWebhookHandler from the
+// email.webhooks configuration. SNS signing certificates are fetched with a
+// client from clients.
+func NewEmailWebhookHandler(
+	base *BaseHandler,
+	deliveries emaildelivery.Service,
+	clients *httpclient.Factory,
+) (*EmailWebhookHandler, error) {
+	h := &EmailWebhookHandler{BaseHandler: base, Deliveries: deliveries}
+	webhooks := base.Config.Email.Webhooks
+
+	if len(webhooks.SESTopicARNs) > 0 {
+		h.SNS = email.NewSNSVerifier(webhooks.SESTopicARNs, webhooks.MaxAge,
+			clients.Transport(config.ResilienceTargetEmail, "sns"))
+	}
+
+	if webhooks.SendGridVerificationKey != "" {
+		verifier, err := email.NewSendGridVerifier(webhooks.SendGridVerificationKey, webhooks.MaxAge)
+		if err != nil {
+			return nil, fmt.Errorf("create sendgrid webhook verifier: %w", err)
+		}
+
+		h.SendGrid = verifier
+	}
+
+	return h, nil
+}
+
+// RegisterRoutes registers the email webhook routes
+func (h *EmailWebhookHandler) RegisterRoutes(e *echo.Echo) {
+	webhooks := e.Group(constants.PathAPIWebhooksEmail)
+	webhooks.POST("/ses", h.handleSES)
+	webhooks.POST("/sendgrid", h.handleSendGrid)
+}
+
+// DescribeRoutes describes how the webhook routes authenticate; each handler
+// verifies its provider's request signature
+func (h *EmailWebhookHandler) DescribeRoutes() []RouteGroup {
+	return []RouteGroup{{Prefix: constants.PathAPIWebhooksEmail, Auth: "provider_signature"}}
+}
+
+// Register satisfies the Handler interface; routes are registered by RegisterHandlers
+func (h *EmailWebhookHandler) Register(_ *echo.Echo) {}
+
+// Start initializes the handler
+func (h *EmailWebhookHandler) Start(_ context.Context) error {
+	return nil
+}
+
+// Stop cleans up the handler
+func (h *EmailWebhookHandler) Stop(_ context.Context) error {
+	return nil
+}
+
+// POST /api/v1/webhooks/email/ses - receives SES notifications delivered by SNS
+func (h *EmailWebhookHandler) handleSES(c echo.Context) error {
+	if h.SNS == nil {
+		return response.ErrorResponse(c, http.StatusNotFound, "SES webhook is not configured")
+	}
+
+	body, err := readWebhookBody(c)
+	if err != nil {
+		return response.ErrorResponse(c, http.StatusBadRequest, "Invalid request body")
+	}
+
+	// SNS posts JSON with a text/plain content type, so the body is decoded by hand
+	var msg email.SNSMessage
+	if unmarshalErr := json.Unmarshal(body, &msg); unmarshalErr != nil {
+		return response.ErrorResponse(c, http.StatusBadRequest, "Invalid SNS message")
+	}
+
+	ctx := c.Request().Context()
+
+	if verifyErr := h.SNS.Verify(ctx, &msg); verifyErr != nil {
+		return h.rejectWebhook(c, "ses", verifyErr)
+	}
+
+	switch msg.Type {
+	case email.SNSTypeSubscriptionConfirmation:
+		if confirmErr := h.SNS.ConfirmSubscription(ctx, &msg); confirmErr != nil {
+			h.Logger.Warn("sns subscription confirmation failed", "topic", msg.TopicArn, "error", confirmErr)
+
+			return response.ErrorResponse(c, http.StatusBadGateway, "Failed to confirm SNS subscription")
+		}
+
+		h.Logger.Info("sns subscription confirmed", "topic", msg.TopicArn)
+
+		return c.NoContent(http.StatusNoContent)
+	case email.SNSTypeNotification:
+		events, parseErr := email.ParseSESNotification(msg.Message)
+		if parseErr != nil {
+			return response.ErrorResponse(c, http.StatusBadRequest, "Invalid SES notification")
+		}
+
+		return h.recordEvents(c, "ses", events)
+	default:
+		return c.NoContent(http.StatusNoContent)
+	}
+}
+
+// POST /api/v1/webhooks/email/sendgrid - receives SendGrid's signed event webhook
+func (h *EmailWebhookHandler) handleSendGrid(c echo.Context) error {
+	if h.SendGrid == nil {
+		return response.ErrorResponse(c, http.StatusNotFound, "SendGrid webhook is not configured")
+	}
+
+	body, err := readWebhookBody(c)
+	if err != nil {
+		return response.ErrorResponse(c, http.StatusBadRequest, "Invalid request body")
+	}
+
+	// The signature covers the raw body, so it is checked before decoding
+	signature := c.Request().Header.Get(email.SendGridSignatureHeader)
+	timestamp := c.Request().Header.Get(email.SendGridTimestampHeader)
+
+	if verifyErr := h.SendGrid.Verify(signature, timestamp, body); verifyErr != nil {
+		return h.rejectWebhook(c, "sendgrid", verifyErr)
+	}
+
+	events, err := email.ParseSendGridEvents(body)
+	if err != nil {
+		return response.ErrorResponse(c, http.StatusBadRequest, "Invalid SendGrid events")
+	}
+
+	return h.recordEvents(c, "sendgrid", events)
+}
+
+// recordEvents stores the bounces and complaints of a verified delivery
+func (h *EmailWebhookHandler) recordEvents(c echo.Context, provider string, events []*emaildelivery.Event) error {
+	if err := h.Deliveries.RecordEvents(c.Request().Context(), events); err != nil {
+		h.Logger.Error("failed to record email delivery events", "provider", provider, "error", err)
+
+		// An error status makes the provider retry the delivery
+		return response.ErrorResponse(c, http.StatusInternalServerError, "Failed to record delivery events")
+	}
+
+	if len(events) > 0 {
+		h.Logger.Info("email delivery events recorded", "provider", provider, "count", len(events))
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
+
+// rejectWebhook responds to a delivery that failed verification
+func (h *EmailWebhookHandler) rejectWebhook(c echo.Context, provider string, err error) error {
+	h.Logger.Warn("email webhook rejected", "provider", provider, "error", err)
+
+	if errors.Is(err, email.ErrWebhookSignature) || errors.Is(err, email.ErrWebhookExpired) ||
+		errors.Is(err, email.ErrWebhookTopic) {
+		return response.ErrorResponse(c, http.StatusForbidden, "Webhook verification failed")
+	}
+
+	// The signing certificate could not be fetched; let the provider retry
+	return response.ErrorResponse(c, http.StatusServiceUnavailable, "Webhook verification unavailable")
+}
+
+// readWebhookBody reads the raw request body, rejecting oversized deliveries
+func readWebhookBody(c echo.Context) ([]byte, error) {
+	body, err := io.ReadAll(io.LimitReader(c.Request().Body, maxWebhookBodySize+1))
+	if err != nil {
+		return nil, fmt.Errorf("read webhook body: %w", err)
+	}
+
+	if len(body) > maxWebhookBodySize {
+		return nil, errors.New("webhook body too large")
+	}
+
+	return body, nil
+}
@@ -0,0 +1,95 @@
+package web_test
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/goformx/goforms/internal/application/constants"
+	"github.com/goformx/goforms/internal/application/handlers/web"
+	"github.com/goformx/goforms/internal/domain/emaildelivery"
+	"github.com/goformx/goforms/internal/infrastructure/config"
+	"github.com/goformx/goforms/internal/infrastructure/email"
+	memorystore "github.com/goformx/goforms/internal/infrastructure/repository/memory"
+	mocklogging "github.com/goformx/goforms/test/mocks/logging"
+)
+
+func TestEmailWebhookHandler_SendGrid(t *testing.T) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	der, err := x509.MarshalPKIXPublicKey(&key.PublicKey)
+	require.NoError(t, err)
+
+	logger := mocklogging.NewMockLogger(gomock.NewController(t))
+	logger.EXPECT().Info(gomock.Any(), gomock.Any()).AnyTimes()
+	logger.EXPECT().Warn(gomock.Any(), gomock.Any()).AnyTimes()
+	logger.EXPECT().Debug(gomock.Any(), gomock.Any()).AnyTimes()
+
+	cfg := &config.Config{Email: config.EmailConfig{Webhooks: config.EmailWebhookConfig{
+		SendGridVerificationKey: base64.StdEncoding.EncodeToString(der),
+		MaxAge:                  time.Hour,
+	}}}
+
+	deliveries := emaildelivery.NewService(memorystore.NewStore(logger).EmailDeliveries(), logger)
+
+	handler, err := web.NewEmailWebhookHandler(&web.BaseHandler{Logger: logger, Config: cfg}, deliveries, nil)
+	require.NoError(t, err)
+
+	e := echo.New()
+	handler.RegisterRoutes(e)
+
+	body := `[{"email":"gone@example.com","event":"bounce","type":"bounce","timestamp":1768469400,"form_id":"form-1"}]`
+	post := func(path, signature string) int {
+		timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+		if signature == "" {
+			digest := sha256.Sum256([]byte(timestamp + body))
+			raw, signErr := ecdsa.SignASN1(rand.Reader, key, digest[:])
+			require.NoError(t, signErr)
+
+			signature = base64.StdEncoding.EncodeToString(raw)
+		}
+
+		req := httptest.NewRequest(http.MethodPost, constants.PathAPIWebhooksEmail+path, strings.NewReader(body))
+		req.Header.Set(email.SendGridSignatureHeader, signature)
+		req.Header.Set(email.SendGridTimestampHeader, timestamp)
+
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, req)
+
+		return rec.Code
+	}
+
+	assert.Equal(t, http.StatusForbidden, post("/sendgrid", base64.StdEncoding.EncodeToString([]byte("forged"))))
+
+	suppressed, err := deliveries.IsSuppressed(t.Context(), "gone@example.com")
+	require.NoError(t, err)
+	assert.False(t, suppressed, "unverified deliveries are ignored")
+
+	assert.Equal(t, http.StatusNoContent, post("/sendgrid", ""))
+
+	suppressed, err = deliveries.IsSuppressed(t.Context(), "gone@example.com")
+	require.NoError(t, err)
+	assert.True(t, suppressed)
+
+	stats, err := deliveries.Stats(t.Context(), "form-1")
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), stats.Bounced)
+
+	// SES is not configured
+	assert.Equal(t, http.StatusNotFound, post("/ses", ""))
+}
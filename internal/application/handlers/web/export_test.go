@@ -0,0 +1,10 @@
+package web
+
+// JoinStalledEditor adds an editor to a form's collaboration room whose
+// messages are never written out, as with a connection that stopped reading
+func (h *CollabHub) JoinStalledEditor(formID, userID string) {
+	h.join(formID, userID)
+}
+
+// CollabSendBuffer is the number of messages queued for an editor before they are dropped
+const CollabSendBuffer = collabSendBuffer
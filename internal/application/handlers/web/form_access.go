@@ -0,0 +1,175 @@
+package web
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/labstack/echo/v4"
+
+	"github.com/goformx/goforms/internal/application/response"
+	"github.com/goformx/goforms/internal/domain/form/model"
+	appconfig "github.com/goformx/goforms/internal/infrastructure/config"
+)
+
+// FormAccessRequest updates a form's access controls. A nil Passphrase keeps the
+// current passphrase; an empty one removes it.
+type FormAccessRequest struct {
+	Passphrase   *string  `json:"passphrase"`
+	RequireAuth  bool     `json:"require_auth"`
+	EmailDomains []string `json:"email_domains"`
+	// AllowedCountries and BlockedCountries are ISO 3166-1 alpha-2 codes; they need geoip.enabled
+	AllowedCountries []string `json:"allowed_countries"`
+	BlockedCountries []string `json:"blocked_countries"`
+}
+
+// unlockRateLimit bounds how many passphrases a client may try per window,
+// across all forms; security.rate_limit.routes may override it
+var unlockRateLimit = appconfig.RateLimitTier{Requests: 5, Window: time.Minute}
+
+// UnlockRequest is the passphrase entered on a protected form
+type UnlockRequest struct {
+	Passphrase string `json:"passphrase"`
+}
+
+// formAccessResponse converts access controls to their API representation; the
+// passphrase hash is never returned
+func formAccessResponse(form *model.Form) map[string]any {
+	access := form.GetAccess()
+
+	domains := nonNilStrings(access.EmailDomains)
+
+	return map[string]any{
+		"form_id":           form.ID,
+		"has_passphrase":    access.HasPassphrase(),
+		"require_auth":      access.RequiresSubmitter(),
+		"email_domains":     domains,
+		"allowed_countries": nonNilStrings(access.AllowedCountries),
+		"blocked_countries": nonNilStrings(access.BlockedCountries),
+	}
+}
+
+// GET /api/forms/:id/access - show a form's access controls (assertion auth)
+func (h *FormAPIHandler) handleGetFormAccess(c echo.Context) error {
+	form, err := h.getFormWithOwnershipOrError(c)
+	if err != nil || form == nil {
+		return err
+	}
+
+	return response.Success(c, formAccessResponse(form))
+}
+
+// PUT /api/forms/:id/access - replace a form's access controls (assertion auth)
+func (h *FormAPIHandler) handleUpdateFormAccess(c echo.Context) error {
+	form, err := h.getFormWithOwnershipOrError(c)
+	if err != nil || form == nil {
+		return err
+	}
+
+	var req FormAccessRequest
+	if bindErr := c.Bind(&req); bindErr != nil {
+		return h.ResponseBuilder.BuildErrorResponse(c, http.StatusBadRequest, "Invalid request body")
+	}
+
+	access := model.FormAccess{
+		PassphraseHash:   form.GetAccess().PassphraseHash,
+		RequireAuth:      req.RequireAuth,
+		EmailDomains:     req.EmailDomains,
+		AllowedCountries: req.AllowedCountries,
+		BlockedCountries: req.BlockedCountries,
+	}
+
+	if access.HasCountryRules() && h.GeoIP == nil {
+		return h.ResponseBuilder.BuildValidationErrorResponse(c, "allowed_countries",
+			"Country rules need GeoIP, which is not enabled on this server")
+	}
+
+	if req.Passphrase != nil {
+		access.PassphraseHash = ""
+
+		if *req.Passphrase != "" {
+			hash, hashErr := model.HashPassphrase(*req.Passphrase)
+			if hashErr != nil {
+				return h.formAccessError(c, hashErr, form.ID)
+			}
+
+			access.PassphraseHash = hash
+		}
+	}
+
+	if setErr := form.SetAccess(access); setErr != nil {
+		return h.formAccessError(c, setErr, form.ID)
+	}
+
+	if updateErr := h.FormService.UpdateForm(c.Request().Context(), form); updateErr != nil {
+		return h.formAccessError(c, updateErr, form.ID)
+	}
+
+	h.Logger.Info("form access controls updated",
+		"form_id", form.ID,
+		"has_passphrase", access.HasPassphrase(),
+		"require_auth", access.RequiresSubmitter(),
+		"email_domains", len(access.EmailDomains),
+		"allowed_countries", len(access.AllowedCountries),
+		"blocked_countries", len(access.BlockedCountries))
+
+	return response.Success(c, formAccessResponse(form))
+}
+
+// POST /forms/:id/unlock - exchange a form's passphrase for an access token (public).
+// The token is sent back as the X-Form-Access-Token header or ?access_token= parameter.
+func (h *FormAPIHandler) handleUnlockForm(c echo.Context) error {
+	form, err := h.getFormOrError(c)
+	if err != nil || form == nil {
+		return err
+	}
+
+	var req UnlockRequest
+	if bindErr := c.Bind(&req); bindErr != nil {
+		return h.ResponseBuilder.BuildErrorResponse(c, http.StatusBadRequest, "Invalid request body")
+	}
+
+	access := form.GetAccess()
+	if !access.HasPassphrase() {
+		return h.ResponseBuilder.BuildErrorResponse(c, http.StatusBadRequest, "This form does not require a passphrase")
+	}
+
+	if !access.CheckPassphrase(req.Passphrase) {
+		h.Logger.Warn("incorrect form passphrase", "form_id", form.ID, "ip", c.RealIP())
+
+		return h.ResponseBuilder.BuildErrorResponse(c, http.StatusUnauthorized, "Incorrect passphrase")
+	}
+
+	token, expires := h.FormAccessTokens.Issue(form)
+
+	return response.Success(c, map[string]any{
+		"token":      token,
+		"expires_at": expires.UTC().Format(time.RFC3339),
+	})
+}
+
+// formAccessError writes the response for a failed access control update
+func (h *FormAPIHandler) formAccessError(c echo.Context, err error, formID string) error {
+	switch {
+	case errors.Is(err, model.ErrPassphraseLength):
+		return h.ResponseBuilder.BuildValidationErrorResponse(c, "passphrase", err.Error())
+	case errors.Is(err, model.ErrEmailDomainInvalid), errors.Is(err, model.ErrTooManyEmailDomains):
+		return h.ResponseBuilder.BuildValidationErrorResponse(c, "email_domains", err.Error())
+	case errors.Is(err, model.ErrCountryInvalid), errors.Is(err, model.ErrTooManyCountries),
+		errors.Is(err, model.ErrCountryListsOverlap):
+		return h.ResponseBuilder.BuildValidationErrorResponse(c, "allowed_countries", err.Error())
+	}
+
+	h.Logger.Error("failed to update form access controls", "error", err, "form_id", formID)
+
+	return h.HandleError(c, err, "Failed to update access controls")
+}
+
+// nonNilStrings returns values, or an empty slice so JSON encodes [] rather than null
+func nonNilStrings(values []string) []string {
+	if values == nil {
+		return []string{}
+	}
+
+	return values
+}
@@ -0,0 +1,273 @@
+package web
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/labstack/echo/v4"
+
+	"github.com/goformx/goforms/internal/application/constants"
+	"github.com/goformx/goforms/internal/application/middleware/assertion"
+	mwcontext "github.com/goformx/goforms/internal/application/middleware/context"
+	"github.com/goformx/goforms/internal/application/response"
+	formdomain "github.com/goformx/goforms/internal/domain/form"
+	"github.com/goformx/goforms/internal/domain/form/model"
+	"github.com/goformx/goforms/internal/infrastructure/config"
+	"github.com/goformx/goforms/internal/infrastructure/logging"
+	"github.com/goformx/goforms/internal/infrastructure/repository/common"
+)
+
+const (
+	// formAccessTokenTTL is how long an unlocked form stays unlocked
+	formAccessTokenTTL = 12 * time.Hour
+
+	// formSubmitterIDKey is the Echo context key holding the verified submitter's user ID
+	formSubmitterIDKey = "form_submitter_id"
+	// formSubmitterEmailKey is the Echo context key holding the verified submitter's email
+	formSubmitterEmailKey = "form_submitter_email"
+)
+
+// Reasons a request is refused by the form access middleware
+const (
+	formAccessPassphraseRequired = "passphrase_required"
+	formAccessLoginRequired      = "login_required"
+	formAccessEmailNotAllowed    = "email_domain_not_allowed"
+)
+
+// FormAccessTokens issues and verifies the tokens handed out once a visitor has entered
+// a form's passphrase. Tokens are bound to the form and its current passphrase hash, so
+// changing the passphrase revokes every token issued for the old one.
+type FormAccessTokens struct {
+	secret []byte
+	ttl    time.Duration
+}
+
+// NewFormAccessTokens creates a token issuer signing with secret
+func NewFormAccessTokens(secret string, ttl time.Duration) *FormAccessTokens {
+	return &FormAccessTokens{secret: []byte(secret), ttl: ttl}
+}
+
+// Issue returns a token unlocking form until the returned expiry
+func (t *FormAccessTokens) Issue(form *model.Form) (string, time.Time) {
+	expires := time.Now().Add(t.ttl).Truncate(time.Second)
+	expiry := strconv.FormatInt(expires.Unix(), 10)
+
+	return expiry + "." + hex.EncodeToString(t.sign(form, expiry)), expires
+}
+
+// Verify reports whether token unlocks form
+func (t *FormAccessTokens) Verify(form *model.Form, token string) bool {
+	expiry, signature, found := strings.Cut(token, ".")
+	if !found {
+		return false
+	}
+
+	expiresAt, err := strconv.ParseInt(expiry, 10, 64)
+	if err != nil || time.Now().Unix() >= expiresAt {
+		return false
+	}
+
+	sigBytes, err := hex.DecodeString(signature)
+	if err != nil {
+		return false
+	}
+
+	return hmac.Equal(sigBytes, t.sign(form, expiry))
+}
+
+// sign computes the token signature for form and expiry
+func (t *FormAccessTokens) sign(form *model.Form, expiry string) []byte {
+	mac := hmac.New(sha256.New, t.secret)
+	mac.Write([]byte("form-access:" + form.ID + ":" + expiry + ":" + form.GetAccess().PassphraseHash))
+
+	return mac.Sum(nil)
+}
+
+// FormAccessMiddleware enforces per-form access controls (passphrase, signed-in
+// submitter, email domain allowlist) on the public form routes.
+type FormAccessMiddleware struct {
+	formService formdomain.Service
+	tokens      *FormAccessTokens
+	assertion   config.AssertionConfig
+	logger      logging.Logger
+}
+
+// NewFormAccessMiddleware creates the form access middleware. Submitters are identified
+// by the signed assertion headers the application sends when proxying a signed-in user.
+func NewFormAccessMiddleware(
+	formService formdomain.Service,
+	tokens *FormAccessTokens,
+	assertionConfig config.AssertionConfig,
+	logger logging.Logger,
+) *FormAccessMiddleware {
+	return &FormAccessMiddleware{
+		formService: formService,
+		tokens:      tokens,
+		assertion:   assertionConfig,
+		logger:      logger,
+	}
+}
+
+// Verify returns middleware for JSON endpoints; refusals are JSON errors
+func (m *FormAccessMiddleware) Verify() echo.MiddlewareFunc {
+	return m.middleware(m.denyJSON)
+}
+
+// VerifyPage returns middleware for the hosted form page; a missing passphrase
+// renders a passphrase prompt instead of an error
+func (m *FormAccessMiddleware) VerifyPage() echo.MiddlewareFunc {
+	return m.middleware(m.denyPage)
+}
+
+// middleware checks the form named by :id and calls deny when access is refused
+func (m *FormAccessMiddleware) middleware(
+	deny func(c echo.Context, form *model.Form, reason string) error,
+) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			if c.Request().Method == http.MethodOptions {
+				return next(c)
+			}
+
+			form, err := m.formService.GetForm(c.Request().Context(), c.Param("id"))
+			if errors.Is(err, common.ErrNotFound) || errors.Is(err, common.ErrInvalidInput) || (err == nil && form == nil) {
+				// The handler reports the missing form or malformed ID
+				return next(c)
+			}
+
+			if err != nil {
+				// The form's restrictions are unknown, so the request may not go through
+				m.logger.Error("failed to load form for access check", "form_id", c.Param("id"), "error", err)
+
+				return response.ErrorResponse(c, http.StatusInternalServerError, "Failed to get form")
+			}
+
+			if reason := m.check(c, form); reason != "" {
+				m.logger.Debug("form access refused", "form_id", form.ID, "reason", reason, "path", c.Path())
+
+				return deny(c, form, reason)
+			}
+
+			return next(c)
+		}
+	}
+}
+
+// check returns the reason access is refused, or "" when the request may proceed.
+// A verified submitter is recorded in the Echo context for the submission handler.
+func (m *FormAccessMiddleware) check(c echo.Context, form *model.Form) string {
+	access := form.GetAccess()
+	if !access.IsRestricted() {
+		return ""
+	}
+
+	if access.HasPassphrase() && !m.tokens.Verify(form, mwcontext.FormAccessToken(c)) {
+		return formAccessPassphraseRequired
+	}
+
+	if !access.RequiresSubmitter() {
+		return ""
+	}
+
+	userID, email, ok := assertion.VerifiedUserEmail(c.Request().Header, m.assertion)
+	if !ok {
+		return formAccessLoginRequired
+	}
+
+	if !access.AllowsEmail(email) || (len(access.EmailDomains) > 0 && email == "") {
+		return formAccessEmailNotAllowed
+	}
+
+	c.Set(formSubmitterIDKey, userID)
+
+	if email != "" {
+		c.Set(formSubmitterEmailKey, email)
+	}
+
+	return ""
+}
+
+// formAccessStatus maps a refusal reason to its HTTP status
+func formAccessStatus(reason string) int {
+	if reason == formAccessEmailNotAllowed {
+		return http.StatusForbidden
+	}
+
+	return http.StatusUnauthorized
+}
+
+// formAccessMessage describes a refusal reason
+func formAccessMessage(reason string) string {
+	switch reason {
+	case formAccessPassphraseRequired:
+		return "This form requires a passphrase"
+	case formAccessLoginRequired:
+		return "You must be signed in to use this form"
+	default:
+		return "Your email address is not allowed to submit this form"
+	}
+}
+
+// denyJSON refuses an API request
+func (m *FormAccessMiddleware) denyJSON(c echo.Context, _ *model.Form, reason string) error {
+	return c.JSON(formAccessStatus(reason), response.APIResponse{
+		Success: false,
+		Message: formAccessMessage(reason),
+		Data:    map[string]string{"code": reason},
+	})
+}
+
+// denyPage refuses a hosted page request, prompting for the passphrase when that is missing
+func (m *FormAccessMiddleware) denyPage(c echo.Context, form *model.Form, reason string) error {
+	if reason != formAccessPassphraseRequired {
+		return c.HTML(formAccessStatus(reason), `<!DOCTYPE html>
+<html>
+<head><meta charset="utf-8"><title>`+escapeHTML(form.Title)+`</title></head>
+<body><p>`+formAccessMessage(reason)+`</p></body>
+</html>`)
+	}
+
+	unlockURL := "/forms/" + escapeHTML(form.ID) + "/unlock"
+
+	return c.HTML(http.StatusUnauthorized, `<!DOCTYPE html>
+<html>
+<head>
+  <meta charset="utf-8">
+  <meta name="viewport" content="width=device-width, initial-scale=1">
+  <title>`+escapeHTML(form.Title)+`</title>
+</head>
+<body>
+  <form id="unlock">
+    <label for="passphrase">This form requires a passphrase</label>
+    <input id="passphrase" name="passphrase" type="password" autocomplete="off" required>
+    <button type="submit">Continue</button>
+    <p id="error" style="color: #dc2626;" hidden>Incorrect passphrase. Please try again.</p>
+  </form>
+  <script>
+    document.getElementById('unlock').addEventListener('submit', function(event) {
+      event.preventDefault();
+      fetch('`+unlockURL+`', {
+        method: 'POST',
+        headers: { 'Content-Type': 'application/json' },
+        body: JSON.stringify({ passphrase: document.getElementById('passphrase').value })
+      }).then(function(res) {
+        if (!res.ok) { throw new Error('unlock failed'); }
+        return res.json();
+      }).then(function(body) {
+        var url = new URL(window.location.href);
+        url.searchParams.set('`+constants.QueryParamFormAccessToken+`', body.data.token);
+        window.location.replace(url.toString());
+      }).catch(function() {
+        document.getElementById('error').hidden = false;
+      });
+    });
+  </script>
+</body>
+</html>`)
+}
@@ -0,0 +1,173 @@
+package web_test
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/goformx/goforms/internal/application/constants"
+	"github.com/goformx/goforms/internal/application/handlers/web"
+	"github.com/goformx/goforms/internal/domain/form/model"
+	"github.com/goformx/goforms/internal/infrastructure/config"
+	"github.com/goformx/goforms/internal/infrastructure/repository/common"
+	mockform "github.com/goformx/goforms/test/mocks/form"
+	mocklogging "github.com/goformx/goforms/test/mocks/logging"
+)
+
+const testAssertionSecret = "assertion-secret"
+
+func newFormAccessServer(t *testing.T, form *model.Form, tokens *web.FormAccessTokens) *echo.Echo {
+	t.Helper()
+
+	ctrl := gomock.NewController(t)
+	formService := mockform.NewMockService(ctrl)
+	formService.EXPECT().GetForm(gomock.Any(), form.ID).Return(form, nil).AnyTimes()
+
+	return newFormAccessServerWith(t, ctrl, formService, tokens)
+}
+
+// newFormAccessServerWith serves a form's schema behind the access middleware loading forms from formService
+func newFormAccessServerWith(
+	t *testing.T,
+	ctrl *gomock.Controller,
+	formService *mockform.MockService,
+	tokens *web.FormAccessTokens,
+) *echo.Echo {
+	t.Helper()
+
+	logger := mocklogging.NewMockLogger(ctrl)
+	logger.EXPECT().Debug(gomock.Any(), gomock.Any()).AnyTimes()
+	logger.EXPECT().Error(gomock.Any(), gomock.Any()).AnyTimes()
+
+	access := web.NewFormAccessMiddleware(formService, tokens, config.AssertionConfig{
+		Secret:               testAssertionSecret,
+		TimestampSkewSeconds: 60,
+	}, logger)
+
+	e := echo.New()
+	formsPublic := e.Group(constants.PathFormsPublic)
+	formsPublic.GET("/:id/schema", func(c echo.Context) error {
+		return c.String(http.StatusOK, "ok")
+	}, access.Verify())
+
+	return e
+}
+
+func signedAssertion(req *http.Request, userID, email string) {
+	timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+	mac := hmac.New(sha256.New, []byte(testAssertionSecret))
+	mac.Write([]byte(userID + ":" + timestamp + ":" + email))
+
+	req.Header.Set("X-User-Id", userID)
+	req.Header.Set("X-User-Email", email)
+	req.Header.Set("X-Timestamp", timestamp)
+	req.Header.Set("X-Signature", hex.EncodeToString(mac.Sum(nil)))
+}
+
+func TestFormAccessMiddleware_Passphrase(t *testing.T) {
+	hash, err := model.HashPassphrase("open sesame")
+	require.NoError(t, err)
+
+	form := &model.Form{ID: "form-1"}
+	require.NoError(t, form.SetAccess(model.FormAccess{PassphraseHash: hash}))
+
+	tokens := web.NewFormAccessTokens("token-secret", time.Hour)
+	e := newFormAccessServer(t, form, tokens)
+
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/forms/form-1/schema", http.NoBody))
+	assert.Equal(t, http.StatusUnauthorized, rec.Code)
+	assert.Contains(t, rec.Body.String(), "passphrase_required")
+
+	token, _ := tokens.Issue(form)
+	req := httptest.NewRequest(http.MethodGet, "/forms/form-1/schema", http.NoBody)
+	req.Header.Set("X-Form-Access-Token", token)
+	rec = httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	// Changing the passphrase revokes tokens issued for the old one
+	newHash, err := model.HashPassphrase("new passphrase")
+	require.NoError(t, err)
+	require.NoError(t, form.SetAccess(model.FormAccess{PassphraseHash: newHash}))
+	assert.False(t, tokens.Verify(form, token))
+}
+
+func TestFormAccessMiddleware_EmailDomains(t *testing.T) {
+	form := &model.Form{ID: "form-1"}
+	require.NoError(t, form.SetAccess(model.FormAccess{EmailDomains: []string{"example.com"}}))
+
+	e := newFormAccessServer(t, form, web.NewFormAccessTokens("token-secret", time.Hour))
+
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/forms/form-1/schema", http.NoBody))
+	assert.Equal(t, http.StatusUnauthorized, rec.Code)
+	assert.Contains(t, rec.Body.String(), "login_required")
+
+	req := httptest.NewRequest(http.MethodGet, "/forms/form-1/schema", http.NoBody)
+	signedAssertion(req, "user-1", "ada@other.org")
+	rec = httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+	assert.Equal(t, http.StatusForbidden, rec.Code)
+
+	req = httptest.NewRequest(http.MethodGet, "/forms/form-1/schema", http.NoBody)
+	signedAssertion(req, "user-1", "ada@example.com")
+	rec = httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+	assert.Equal(t, http.StatusOK, rec.Code)
+}
+
+func TestFormAccessMiddleware_FormLookupFailure(t *testing.T) {
+	tests := []struct {
+		name    string
+		err     error
+		want    int
+		handled bool
+	}{
+		{name: "form not found", err: fmt.Errorf("get form by ID: %w", common.NewNotFoundError("get", "form", "form-1")),
+			want: http.StatusOK, handled: true},
+		{name: "malformed form ID", err: fmt.Errorf("get form by ID: %w", common.NewInvalidInputError("get", "form", "form-1",
+			errors.New("invalid UUID length: 6"))), want: http.StatusOK, handled: true},
+		{name: "store unavailable", err: fmt.Errorf("get form by ID: %w", errors.New("connection refused")),
+			want: http.StatusInternalServerError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			formService := mockform.NewMockService(ctrl)
+			formService.EXPECT().GetForm(gomock.Any(), "form-1").Return(nil, tt.err)
+
+			e := newFormAccessServerWith(t, ctrl, formService, web.NewFormAccessTokens("token-secret", time.Hour))
+
+			rec := httptest.NewRecorder()
+			e.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/forms/form-1/schema", http.NoBody))
+			assert.Equal(t, tt.want, rec.Code)
+			assert.Equal(t, tt.handled, rec.Body.String() == "ok", "only a missing form is left to the handler to report")
+		})
+	}
+}
+
+func TestFormAccessMiddleware_UnknownForms(t *testing.T) {
+	server := newFormAPIServer(t, nil)
+
+	for _, path := range []string{"/not-a-uuid/schema", "/not-a-uuid/embed", "/00000000-0000-0000-0000-000000000000/schema"} {
+		t.Run(path, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			server.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, constants.PathFormsPublic+path, http.NoBody))
+			assert.Equal(t, http.StatusNotFound, rec.Code, rec.Body.String())
+		})
+	}
+}
@@ -0,0 +1,29 @@
+package web
+
+import (
+	"github.com/labstack/echo/v4"
+
+	"github.com/goformx/goforms/internal/application/accessibility"
+	"github.com/goformx/goforms/internal/application/response"
+)
+
+// GET /api/forms/:id/accessibility - audits the form's schema for accessibility
+// problems, for the builder to show next to the offending components (assertion auth)
+func (h *FormAPIHandler) handleFormAccessibility(c echo.Context) error {
+	form, err := h.getFormWithOwnershipOrError(c)
+	if err != nil || form == nil {
+		return err
+	}
+
+	report := accessibility.Audit(form.Schema)
+
+	return response.Success(c, map[string]any{
+		"form_id":    form.ID,
+		"version":    form.Version,
+		"passed":     report.Passed(),
+		"checked":    report.Checked,
+		"errors":     report.Errors,
+		"warnings":   report.Warnings,
+		"violations": report.Violations,
+	})
+}
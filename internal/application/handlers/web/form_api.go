@@ -9,16 +9,35 @@ import (
 	"time"
 
 	"github.com/labstack/echo/v4"
+	"go.uber.org/fx"
 
 	"github.com/goformx/goforms/internal/application/constants"
+	"github.com/goformx/goforms/internal/application/importer"
 	"github.com/goformx/goforms/internal/application/middleware/access"
 	"github.com/goformx/goforms/internal/application/middleware/assertion"
+	"github.com/goformx/goforms/internal/application/middleware/idempotency"
 	"github.com/goformx/goforms/internal/application/middleware/security"
+	"github.com/goformx/goforms/internal/application/render"
 	"github.com/goformx/goforms/internal/application/response"
 	"github.com/goformx/goforms/internal/application/validation"
+	"github.com/goformx/goforms/internal/domain/archive"
+	"github.com/goformx/goforms/internal/domain/audit"
+	"github.com/goformx/goforms/internal/domain/billing"
+	"github.com/goformx/goforms/internal/domain/common/events"
+	"github.com/goformx/goforms/internal/domain/emaildelivery"
+	"github.com/goformx/goforms/internal/domain/emailtemplate"
+	"github.com/goformx/goforms/internal/domain/encryption"
+	"github.com/goformx/goforms/internal/domain/extension"
 	formdomain "github.com/goformx/goforms/internal/domain/form"
 	"github.com/goformx/goforms/internal/domain/form/model"
+	"github.com/goformx/goforms/internal/domain/metering"
 	"github.com/goformx/goforms/internal/domain/user"
+	"github.com/goformx/goforms/internal/infrastructure/email"
+	"github.com/goformx/goforms/internal/infrastructure/geoip"
+	idempotencystore "github.com/goformx/goforms/internal/infrastructure/idempotency"
+	"github.com/goformx/goforms/internal/infrastructure/optionsource"
+	"github.com/goformx/goforms/internal/infrastructure/pii"
+	"github.com/goformx/goforms/internal/infrastructure/repository/common"
 	"github.com/goformx/goforms/internal/infrastructure/sanitization"
 )
 
@@ -33,60 +52,257 @@ type FormAPIHandler struct {
 	FormServiceHandler     *FormService
 	AssertionMiddleware    *assertion.Middleware
 	UserEnsurer            user.UserEnsurer
+	SubmissionStream       *SubmissionStreamHub
+	Collab                 *CollabHub
+	PDFRenderer            *SubmissionPDFRenderer
+	ReportService          formdomain.ReportService
+	ReportDispatcher       *formdomain.ReportDispatcher
+	QuotaService           formdomain.QuotaService
+	Idempotency            *idempotency.Middleware
+	TriageService          formdomain.TriageService
+	Importer               *importer.Importer
+	CORSCache              *FormCORSCache
+	PublicStats            *PublicStatsCache
+	Pages                  *render.Cache
+	FormAccess             *FormAccessMiddleware
+	FormAccessTokens       *FormAccessTokens
+	EmailDeliveries        emaildelivery.Service
+	// Throttle is nil when form.throttle is disabled
+	Throttle *SubmissionThrottle
+	// Archives is nil when storage.archive is disabled
+	Archives archive.Service
+	// Extensions runs the pre-validate, post-submit and pre-render hooks of each form
+	Extensions *extension.Runner
+	// Scripts is nil when form.scripts is disabled
+	Scripts *SubmissionScripts
+	Audit   audit.Service
+	// FileDownloadTokens signs the short-lived links submission attachments are downloaded through
+	FileDownloadTokens *SubmissionFileTokens
+	FileDownloads      *SubmissionFileMiddleware
+	// Activities holds each submission's status history, notes and webhook delivery results
+	Activities formdomain.ActivityService
+	// SchemaHistory undoes and redoes schema edits made in the builder
+	SchemaHistory formdomain.SchemaHistoryService
+	// SchemaMigrations rewrites stored answers after field keys or types change
+	SchemaMigrations formdomain.SchemaMigrationService
+	// Billing is nil when billing is disabled
+	Billing billing.Service
+	// Metering is nil when api.metering is disabled
+	Metering metering.Service
+	// Encryption is nil when form.encryption is disabled
+	Encryption encryption.Service
+	// Classifier finds personal data for redacted exports
+	Classifier *pii.Classifier
+	// Options fills the options of fields naming an option list
+	Options *optionsource.Resolver
+	// GeoIP is nil when geoip is disabled
+	GeoIP *geoip.Locator
+
+	// routes records the middleware RegisterRoutes applies, for DescribeRoutes
+	routes routeRecorder
+}
+
+// FormAPIHandlerParams groups the dependencies of the form API handler
+type FormAPIHandlerParams struct {
+	fx.In
+
+	Base               *BaseHandler
+	FormService        formdomain.Service
+	AccessManager      *access.Manager
+	FormValidator      *validation.FormValidator
+	Sanitizer          sanitization.ServiceInterface
+	UserEnsurer        user.UserEnsurer
+	EventBus           events.EventBus
+	ReportService      formdomain.ReportService
+	ReportDispatcher   *formdomain.ReportDispatcher
+	EmailSender        email.Sender
+	QuotaService       formdomain.QuotaService
+	IdempotencyRecords idempotencystore.Store
+	TriageService      formdomain.TriageService
+	ImportService      formdomain.ImportService
+	EmailTemplates     emailtemplate.Service
+	EmailDeliveries    emaildelivery.Service
+	Archives           archive.Service
+	Extensions         *extension.Runner
+	Audit              audit.Service
+	Activities         formdomain.ActivityService
+	SchemaHistory      formdomain.SchemaHistoryService
+	SchemaMigrations   formdomain.SchemaMigrationService
+	Billing            billing.Service
+	Metering           metering.Service
+	Encryption         encryption.Service
+	Classifier         *pii.Classifier
+	Pages              *render.Cache
+	Options            *optionsource.Resolver
+	GeoIP              *geoip.Locator
 }
 
 // NewFormAPIHandler creates a new FormAPIHandler.
-func NewFormAPIHandler(
-	base *BaseHandler,
-	formService formdomain.Service,
-	accessManager *access.Manager,
-	formValidator *validation.FormValidator,
-	sanitizer sanitization.ServiceInterface,
-	userEnsurer user.UserEnsurer,
-) *FormAPIHandler {
+func NewFormAPIHandler(p FormAPIHandlerParams) *FormAPIHandler {
+	base := p.Base
+
 	// Create dependencies
-	requestProcessor := NewFormRequestProcessor(sanitizer, formValidator, base.Logger)
+	requestProcessor := NewFormRequestProcessor(p.Sanitizer, p.FormValidator, base.Logger)
 	responseBuilder := NewFormResponseBuilder()
 	errorHandler := NewFormErrorHandler(responseBuilder)
 	comprehensiveValidator := validation.NewComprehensiveValidator()
-	formServiceHandler := NewFormService(formService, base.Logger)
+	formServiceHandler := NewFormService(p.FormService, p.SchemaHistory, base.Logger)
 	assertionMiddleware := assertion.NewMiddleware(base.Config, base.Logger)
 
+	accessTokens := NewFormAccessTokens(base.Config.Security.CSRF.Secret, formAccessTokenTTL)
+	fileTokens := NewSubmissionFileTokens(base.Config.Security.CSRF.Secret, base.Config.Storage.SignedURLTTL)
+
+	idempotencyRecords := p.IdempotencyRecords
+	if !base.Config.API.Idempotency.Enabled {
+		idempotencyRecords = nil
+	}
+
 	return &FormAPIHandler{
-		FormBaseHandler:        NewFormBaseHandler(base, formService, formValidator),
-		AccessManager:          accessManager,
+		FormBaseHandler:        NewFormBaseHandler(base, p.FormService, p.FormValidator),
+		AccessManager:          p.AccessManager,
 		RequestProcessor:       requestProcessor,
 		ResponseBuilder:        responseBuilder,
 		ErrorHandler:           errorHandler,
 		ComprehensiveValidator: comprehensiveValidator,
 		FormServiceHandler:     formServiceHandler,
 		AssertionMiddleware:    assertionMiddleware,
-		UserEnsurer:            userEnsurer,
+		UserEnsurer:            p.UserEnsurer,
+		SubmissionStream:       NewSubmissionStreamHub(p.EventBus, base.Logger),
+		Collab:                 NewCollabHub(p.FormService, p.SchemaHistory, base.Logger),
+		PDFRenderer:            NewSubmissionPDFRenderer(),
+		ReportService:          p.ReportService,
+		ReportDispatcher:       p.ReportDispatcher,
+		QuotaService:           p.QuotaService,
+		Idempotency:            idempotency.NewMiddleware(idempotencyRecords, base.Config.API.Idempotency, base.Logger),
+		TriageService:          p.TriageService,
+		Importer:               importer.New(p.FormService, p.ImportService, p.Sanitizer, base.Logger),
+		CORSCache:              NewFormCORSCache(p.FormService, p.EventBus, formCORSCacheTTL),
+		PublicStats:            NewPublicStatsCache(p.FormService, publicStatsCacheTTL),
+		Pages:                  p.Pages,
+		FormAccess: NewFormAccessMiddleware(
+			p.FormService, accessTokens, base.Config.Security.Assertion, base.Logger),
+		FormAccessTokens: accessTokens,
+		EmailDeliveries:  p.EmailDeliveries,
+		Throttle: NewSubmissionThrottle(
+			base.Config.Form.Throttle, base.UserService, p.EmailTemplates, p.EmailSender, base.Logger),
+		Archives:           p.Archives,
+		Extensions:         p.Extensions,
+		Scripts:            NewSubmissionScripts(base.Config.Form.Scripts, p.Audit, base.Logger),
+		Audit:              p.Audit,
+		FileDownloadTokens: fileTokens,
+		FileDownloads:      NewSubmissionFileMiddleware(fileTokens, base.Logger),
+		Activities:         p.Activities,
+		SchemaHistory:      p.SchemaHistory,
+		SchemaMigrations:   p.SchemaMigrations,
+		Billing:            p.Billing,
+		Metering:           p.Metering,
+		Encryption:         p.Encryption,
+		Classifier:         p.Classifier,
+		Options:            p.Options,
+		GeoIP:              p.GeoIP,
 	}
 }
 
 // RegisterRoutes registers API routes for forms.
 func (h *FormAPIHandler) RegisterRoutes(e *echo.Echo) {
+	h.routes.reset()
+
 	// Laravel API routes with assertion auth
 	h.RegisterLaravelRoutes(e)
 
 	// Public /forms routes for embed (schema, validation, submit, embed HTML)
 	h.RegisterPublicFormsRoutes(e)
+
+	// Signed attachment downloads at /files
+	h.RegisterFileRoutes(e)
+}
+
+// DescribeRoutes describes the group and route middleware RegisterRoutes applied,
+// as recorded while the routes were registered
+func (h *FormAPIHandler) DescribeRoutes() []RouteGroup {
+	return h.routes.described()
 }
 
 // RegisterLaravelRoutes registers /api/forms routes with assertion middleware for Laravel proxy.
 func (h *FormAPIHandler) RegisterLaravelRoutes(e *echo.Echo) {
-	formsLaravel := e.Group(constants.PathAPIFormsLaravel)
-	formsLaravel.Use(h.AssertionMiddleware.Verify())
-	formsLaravel.Use(h.ensureUserMiddleware())
+	formsLaravel := h.routes.group(e, constants.PathAPIFormsLaravel, "assertion",
+		named("assertion", h.AssertionMiddleware.Verify()), named("ensure_user", h.ensureUserMiddleware()))
 
 	formsLaravel.GET("", h.handleListForms)
-	formsLaravel.POST("", h.handleCreateForm)
+	h.routes.add(formsLaravel, http.MethodPost, "", h.handleCreateForm, named("idempotency", h.Idempotency.Handle()))
+	formsLaravel.GET("/usage", h.handleUsage)
+	requireBilling := named("require_billing", h.requireBilling)
+	h.routes.add(formsLaravel, http.MethodGet, "/billing", h.handleGetBilling, requireBilling)
+	h.routes.add(formsLaravel, http.MethodPost, "/billing/checkout", h.handleBillingCheckout, requireBilling)
+	h.routes.add(formsLaravel, http.MethodPost, "/billing/portal", h.handleBillingPortal, requireBilling)
+	requireEncryption := named("require_encryption", h.requireEncryption)
+	h.routes.add(formsLaravel, http.MethodGet, "/encryption", h.handleGetEncryption, requireEncryption)
+	h.routes.add(formsLaravel, http.MethodPut, "/encryption", h.handleUpdateEncryption, requireEncryption)
+	formsLaravel.GET("/preferences", h.handleGetPreferences)
+	formsLaravel.PUT("/preferences", h.handleUpdatePreferences)
+	formsLaravel.POST("/import", h.handleImportForm)
 	formsLaravel.GET("/:id", h.handleGetForm)
 	formsLaravel.PUT("/:id", h.handleUpdateForm)
 	formsLaravel.DELETE("/:id", h.handleDeleteForm)
+	formsLaravel.POST("/:id/publish", h.handleTransitionForm(model.TransitionPublish))
+	formsLaravel.POST("/:id/unpublish", h.handleTransitionForm(model.TransitionUnpublish))
+	formsLaravel.POST("/:id/archive", h.handleTransitionForm(model.TransitionArchive))
+	formsLaravel.GET(constants.RouteFormCollab, h.handleFormCollab)
+	formsLaravel.GET("/:id/schema/history", h.handleSchemaHistory)
+	formsLaravel.POST("/:id/schema/undo", h.handleSchemaUndo)
+	formsLaravel.POST("/:id/schema/redo", h.handleSchemaRedo)
+	formsLaravel.GET("/:id/schema/migration", h.handleSchemaMigrationPlan)
+	formsLaravel.POST("/:id/schema/migration", h.handleSchemaMigration)
+	formsLaravel.GET("/:id/pdf", h.handleFormPDF)
+	formsLaravel.GET("/:id/export/html", h.handleExportFormHTML)
+	formsLaravel.GET("/:id/embed/snippets", h.handleEmbedSnippets)
+	formsLaravel.GET("/:id/embed/methods", h.handleGetEmbedMethods)
+	formsLaravel.PUT("/:id/embed/methods", h.handleUpdateEmbedMethods)
+	formsLaravel.GET("/:id/stats/public", h.handleGetPublicStats)
+	formsLaravel.PUT("/:id/stats/public", h.handleUpdatePublicStats)
+	formsLaravel.GET("/:id/accessibility", h.handleFormAccessibility)
 	formsLaravel.GET("/:id/submissions", h.handleListSubmissions)
+	formsLaravel.GET(constants.RouteFormSubmissionStream, h.handleSubmissionStream)
+	formsLaravel.GET("/:id/submissions/export", h.handleExportSubmissions)
+	formsLaravel.POST("/:id/submissions/import", h.handleImportSubmissions)
+	formsLaravel.POST("/:id/submissions/test", h.handleTestSubmit)
 	formsLaravel.GET("/:id/submissions/:sid", h.handleGetSubmission)
+	formsLaravel.GET("/:id/submissions/:sid/pdf", h.handleSubmissionPDF)
+	formsLaravel.PUT("/:id/submissions/:sid/tags", h.handleSetSubmissionTags)
+	formsLaravel.PUT("/:id/submissions/:sid/status", h.handleSetSubmissionStatus)
+	formsLaravel.POST("/:id/submissions/:sid/notes", h.handleAddSubmissionNote)
+	formsLaravel.POST("/:id/submissions/:sid/files/:field/:index/link", h.handleSubmissionFileLink)
+	formsLaravel.GET("/:id/cors/origins", h.handleListCORSOrigins)
+	formsLaravel.POST("/:id/cors/origins", h.handleAddCORSOrigin)
+	formsLaravel.DELETE("/:id/cors/origins", h.handleRemoveCORSOrigin)
+	formsLaravel.POST("/:id/cors/check", h.handleCORSCheck)
+	formsLaravel.GET("/:id/access", h.handleGetFormAccess)
+	formsLaravel.PUT("/:id/access", h.handleUpdateFormAccess)
+	formsLaravel.GET("/:id/tags", h.handleListTags)
+	formsLaravel.GET("/:id/views", h.handleListViews)
+	formsLaravel.POST("/:id/views", h.handleCreateView)
+	formsLaravel.GET("/:id/views/:vid", h.handleGetView)
+	formsLaravel.PUT("/:id/views/:vid", h.handleUpdateView)
+	formsLaravel.DELETE("/:id/views/:vid", h.handleDeleteView)
+	formsLaravel.GET("/:id/reports", h.handleListReports)
+	formsLaravel.POST("/:id/reports", h.handleCreateReport)
+	formsLaravel.GET("/:id/reports/:rid", h.handleGetReport)
+	formsLaravel.PUT("/:id/reports/:rid", h.handleUpdateReport)
+	formsLaravel.DELETE("/:id/reports/:rid", h.handleDeleteReport)
+	formsLaravel.POST("/:id/reports/:rid/run", h.handleRunReport)
+	formsLaravel.GET("/:id/email/stats", h.handleEmailStats)
+	formsLaravel.GET("/:id/throttle", h.handleGetThrottle)
+	formsLaravel.DELETE("/:id/throttle", h.handleResetThrottle)
+	formsLaravel.GET("/:id/script", h.handleGetFormScript)
+	formsLaravel.PUT("/:id/script", h.handleUpdateFormScript)
+	formsLaravel.DELETE("/:id/script", h.handleDeleteFormScript)
+	formsLaravel.POST("/:id/script/test", h.handleTestFormScript)
+	formsLaravel.GET("/:id/notification-templates", h.handleListNotificationTemplates)
+	formsLaravel.PUT("/:id/notification-templates/:channel", h.handleUpdateNotificationTemplate)
+	formsLaravel.DELETE("/:id/notification-templates/:channel", h.handleDeleteNotificationTemplate)
+	formsLaravel.POST("/:id/notification-templates/:channel/render", h.handleRenderNotificationTemplate)
+	formsLaravel.GET("/:id/redaction-policy", h.handleGetRedactionPolicy)
+	formsLaravel.PUT("/:id/redaction-policy", h.handleUpdateRedactionPolicy)
 }
 
 // ensureUserMiddleware returns middleware that lazily syncs the Laravel user to a Go shadow row.
@@ -99,6 +315,9 @@ func (h *FormAPIHandler) ensureUserMiddleware() echo.MiddlewareFunc {
 				return next(c)
 			}
 			if err := h.UserEnsurer.EnsureUser(c.Request().Context(), userID); err != nil {
+				if errors.Is(err, user.ErrAccountDisabled) {
+					return h.HandleForbidden(c, "Account is disabled")
+				}
 				h.Logger.Error("failed to ensure Laravel user",
 					"user_id", h.Logger.SanitizeField("user_id", userID), "error", err)
 				return h.HandleError(c, err, "Failed to ensure user")
@@ -111,19 +330,32 @@ func (h *FormAPIHandler) ensureUserMiddleware() echo.MiddlewareFunc {
 // RegisterPublicFormsRoutes registers public routes at /forms/:id/... for cleaner embed URLs.
 // These routes bypass the /api/v1 prefix and are intended for cross-origin embedding.
 func (h *FormAPIHandler) RegisterPublicFormsRoutes(e *echo.Echo) {
-	formsPublic := e.Group(constants.PathFormsPublic)
-	formsPublic.Use(NewFormCORSMiddleware(h.FormService, h.Config.Security.CORS))
+	groupMiddleware := []namedMiddleware{named("form_cors", NewCachedFormCORSMiddleware(h.CORSCache, h.Config.Security.CORS))}
+	auth := ""
 
 	// Apply API key middleware if enabled (same as /api/v1/forms)
 	if h.Config.Security.APIKey.Enabled {
 		apiKeyAuth := security.NewAPIKeyAuth(h.Logger, h.Config)
-		formsPublic.Use(apiKeyAuth.Setup())
+		groupMiddleware = append(groupMiddleware, named("api_key", apiKeyAuth.Setup()))
+		auth = "api_key"
 	}
 
-	formsPublic.GET("/:id/schema", h.handleFormSchema)
-	formsPublic.GET("/:id/validation", h.handleFormValidationSchema)
-	formsPublic.POST("/:id/submit", h.handleFormSubmit)
-	formsPublic.GET("/:id/embed", h.handleFormEmbed)
+	formsPublic := h.routes.group(e, constants.PathFormsPublic, auth, groupMiddleware...)
+
+	formAccess := named("form_access", h.FormAccess.Verify())
+	formAccessPage := named("form_access_page", h.FormAccess.VerifyPage())
+
+	h.routes.add(formsPublic, http.MethodGet, "/:id/schema", h.handleFormSchema, formAccess)
+	h.routes.add(formsPublic, http.MethodGet, "/:id/validation", h.handleFormValidationSchema, formAccess)
+	h.routes.add(formsPublic, http.MethodGet, "/:id/fields", h.handleFormFields, formAccess)
+	h.routes.add(formsPublic, http.MethodPost, "/:id/submit", h.handleFormSubmit, formAccess,
+		named("idempotency", h.Idempotency.Handle()))
+	h.routes.limit(formsPublic.POST("/:id/unlock", h.handleUnlockForm), unlockRateLimit)
+	h.routes.limit(formsPublic.GET("/:id/stats", h.handlePublicFormStats), publicStatsRateLimit)
+	h.routes.add(formsPublic, http.MethodGet, "/:id/embed", h.handleFormEmbed, formAccessPage)
+
+	// Framed embed pages are navigated to, so neither CORS nor an API key applies
+	h.routes.add(e.Group(constants.PathEmbedFrame), http.MethodGet, "/:id", h.handleFormFrame, formAccessPage)
 }
 
 // Register registers the FormAPIHandler with the Echo instance.
@@ -164,10 +396,12 @@ func (h *FormAPIHandler) handleListForms(c echo.Context) error {
 // GET /api/v1/forms/:id
 func (h *FormAPIHandler) handleGetForm(c echo.Context) error {
 	form, err := h.getFormWithOwnershipOrError(c)
-	if err != nil {
+	if err != nil || form == nil {
 		return err
 	}
 
+	setFormETag(c, form)
+
 	// Build response with proper error checking
 	if respErr := h.ResponseBuilder.BuildFormResponse(c, form); respErr != nil {
 		h.Logger.Error("failed to build form response", "error", respErr, "form_id", form.ID)
@@ -181,12 +415,28 @@ func (h *FormAPIHandler) handleGetForm(c echo.Context) error {
 // GET /api/v1/forms/:id/schema
 func (h *FormAPIHandler) handleFormSchema(c echo.Context) error {
 	form, err := h.getFormOrError(c)
-	if err != nil {
+	if err != nil || form == nil {
+		return err
+	}
+
+	if handled, embedErr := h.checkScriptEmbed(c, form); handled {
+		return embedErr
+	}
+
+	schema, handled, err := h.runPreRender(c, form)
+	if handled {
 		return err
 	}
 
+	// Field library types are sent as the built-in components rendering them,
+	// and fields naming an option list with its current options
+	schema = model.RenderSchema(h.Options.ResolveSchema(c.Request().Context(), form.UserID, schema))
+
+	// Labels referencing answers are resolved with those passed as data[<key>]
+	schema = formdomain.PipeLabels(schema, prefillAnswers(c))
+
 	// Build response with proper error checking
-	if respErr := h.ResponseBuilder.BuildSchemaResponse(c, form.Schema); respErr != nil {
+	if respErr := h.ResponseBuilder.BuildSchemaResponse(c, schema); respErr != nil {
 		h.Logger.Error("failed to build schema response", "error", respErr, "form_id", form.ID)
 
 		return h.HandleError(c, respErr, "Failed to build response")
@@ -198,16 +448,21 @@ func (h *FormAPIHandler) handleFormSchema(c echo.Context) error {
 // GET /api/v1/forms/:id/validation
 func (h *FormAPIHandler) handleFormValidationSchema(c echo.Context) error {
 	form, err := h.getFormOrError(c)
-	if err != nil {
+	if err != nil || form == nil {
 		return err
 	}
 
+	if handled, embedErr := h.checkScriptEmbed(c, form); handled {
+		return embedErr
+	}
+
 	if validationErr := h.validateFormSchema(c, form); validationErr != nil {
 		return validationErr
 	}
 
 	// Generate client-side validation rules from form schema
-	clientValidation, err := h.ComprehensiveValidator.GenerateClientValidation(form.Schema)
+	clientValidation, err := h.ComprehensiveValidator.GenerateClientValidation(
+		h.Options.ResolveSchema(c.Request().Context(), form.UserID, form.Schema))
 	if err != nil {
 		h.Logger.Error("failed to generate client validation schema", "error", err, "form_id", form.ID)
 
@@ -217,6 +472,33 @@ func (h *FormAPIHandler) handleFormValidationSchema(c echo.Context) error {
 	return response.Success(c, clientValidation)
 }
 
+// GET /api/v1/forms/:id/fields returns the schema's input fields as a flat, normalized list
+func (h *FormAPIHandler) handleFormFields(c echo.Context) error {
+	form, err := h.getFormOrError(c)
+	if err != nil || form == nil {
+		return err
+	}
+
+	if handled, embedErr := h.checkScriptEmbed(c, form); handled {
+		return embedErr
+	}
+
+	if validationErr := h.validateFormSchema(c, form); validationErr != nil {
+		return validationErr
+	}
+
+	fields, ok := validation.NewSchemaParser().ExtractFields(form.Schema)
+	if !ok {
+		return h.wrapError("handle schema error",
+			h.ErrorHandler.HandleSchemaError(c, errors.New("form schema has no components")))
+	}
+
+	return response.Success(c, map[string]any{
+		"form_id": form.ID,
+		"fields":  fields,
+	})
+}
+
 // POST /api/forms - create form (assertion auth)
 func (h *FormAPIHandler) handleCreateForm(c echo.Context) error {
 	userID, ok := c.Get("user_id").(string)
@@ -231,6 +513,10 @@ func (h *FormAPIHandler) handleCreateForm(c echo.Context) error {
 
 	form, err := h.FormServiceHandler.CreateForm(c.Request().Context(), userID, req)
 	if err != nil {
+		if handled, quotaErr := h.handleQuotaError(c, err); handled {
+			return quotaErr
+		}
+
 		h.Logger.Error("failed to create form", "error", err)
 
 		return h.HandleError(c, err, "Failed to create form")
@@ -248,6 +534,7 @@ func (h *FormAPIHandler) handleCreateForm(c echo.Context) error {
 				"description": form.Description,
 				"status":      form.Status,
 				"schema":      form.Schema,
+				"version":     form.Version,
 				"created_at":  form.CreatedAt.Format(time.RFC3339),
 				"updated_at":  form.UpdatedAt.Format(time.RFC3339),
 			},
@@ -258,7 +545,7 @@ func (h *FormAPIHandler) handleCreateForm(c echo.Context) error {
 // PUT /api/forms/:id - update form (assertion auth)
 func (h *FormAPIHandler) handleUpdateForm(c echo.Context) error {
 	form, err := h.getFormWithOwnershipOrError(c)
-	if err != nil {
+	if err != nil || form == nil {
 		return err
 	}
 
@@ -267,7 +554,27 @@ func (h *FormAPIHandler) handleUpdateForm(c echo.Context) error {
 		return h.wrapError("handle update error", h.ErrorHandler.HandleSchemaError(c, err))
 	}
 
+	expectedVersion, hasPrecondition, versionErr := expectedFormVersion(c, req)
+	if versionErr != nil {
+		return h.ResponseBuilder.BuildErrorResponse(c, http.StatusBadRequest, "Invalid If-Match header")
+	}
+
+	if hasPrecondition && expectedVersion != form.Version {
+		return h.buildVersionConflictResponse(c, form)
+	}
+
 	if updateErr := h.FormServiceHandler.UpdateForm(c.Request().Context(), form, req); updateErr != nil {
+		if errors.Is(updateErr, model.ErrFormVersionConflict) {
+			current, getErr := h.FormService.GetForm(c.Request().Context(), form.ID)
+			if getErr == nil && current != nil {
+				return h.buildVersionConflictResponse(c, current)
+			}
+		}
+
+		if refused, respErr := h.refusedTransition(c, updateErr); refused {
+			return respErr
+		}
+
 		h.Logger.Error("failed to update form", "error", updateErr, "form_id", form.ID)
 
 		return h.HandleError(c, updateErr, "Failed to update form")
@@ -281,6 +588,8 @@ func (h *FormAPIHandler) handleUpdateForm(c echo.Context) error {
 		updatedForm = form
 	}
 
+	setFormETag(c, updatedForm)
+
 	if respErr := h.ResponseBuilder.BuildFormResponse(c, updatedForm); respErr != nil {
 		h.Logger.Error("failed to build form response", "error", respErr, "form_id", form.ID)
 
@@ -293,7 +602,7 @@ func (h *FormAPIHandler) handleUpdateForm(c echo.Context) error {
 // DELETE /api/forms/:id - delete form (assertion auth)
 func (h *FormAPIHandler) handleDeleteForm(c echo.Context) error {
 	form, err := h.getFormWithOwnershipOrError(c)
-	if err != nil {
+	if err != nil || form == nil {
 		return err
 	}
 
@@ -306,10 +615,24 @@ func (h *FormAPIHandler) handleDeleteForm(c echo.Context) error {
 	return c.JSON(http.StatusNoContent, nil)
 }
 
-// GET /api/forms/:id/submissions - list submissions (assertion auth)
+// GET /api/forms/:id/submissions - list submissions (assertion auth).
+// Supports ?view=<view id>, or ad-hoc ?tag=a&tag=b, ?status= and ?q= filters.
+// ?fields= selects the keys returned for each submission, e.g. ?fields=id,data.email.
+// ?include_archived=true adds submissions moved to the submission archive.
+// ?test=only or ?test=include shows the test submissions made from the builder's preview.
 func (h *FormAPIHandler) handleListSubmissions(c echo.Context) error {
 	form, err := h.getFormWithOwnershipOrError(c)
-	if err != nil {
+	if err != nil || form == nil {
+		return err
+	}
+
+	filter, handled, err := h.submissionFilter(c, form.ID)
+	if handled {
+		return err
+	}
+
+	testFilter, handled, err := h.testSubmissionFilter(c)
+	if handled {
 		return err
 	}
 
@@ -320,7 +643,16 @@ func (h *FormAPIHandler) handleListSubmissions(c echo.Context) error {
 		return h.HandleError(c, err, "Failed to list submissions")
 	}
 
-	if respErr := h.ResponseBuilder.BuildSubmissionListResponse(c, submissions); respErr != nil {
+	submissions, err = h.withArchived(c, form.ID, submissions)
+	if err != nil {
+		h.Logger.Error("failed to list archived submissions", "error", err, "form_id", form.ID)
+
+		return h.HandleError(c, err, "Failed to list submissions")
+	}
+
+	submissions = filter.Apply(testFilter.Apply(submissions))
+
+	if respErr := h.ResponseBuilder.BuildSubmissionListResponse(c, submissions, h.formLocation(c.Request().Context(), form)); respErr != nil {
 		h.Logger.Error("failed to build submission list response", "error", respErr, "form_id", form.ID)
 
 		return h.HandleError(c, respErr, "Failed to build response")
@@ -329,10 +661,12 @@ func (h *FormAPIHandler) handleListSubmissions(c echo.Context) error {
 	return nil
 }
 
-// GET /api/forms/:id/submissions/:sid - get submission (assertion auth)
+// GET /api/forms/:id/submissions/:sid - get the full submission document:
+// data, attachments, status history, notes and webhook deliveries (assertion auth).
+// Archived submissions are read from the submission archive.
 func (h *FormAPIHandler) handleGetSubmission(c echo.Context) error {
 	form, err := h.getFormWithOwnershipOrError(c)
-	if err != nil {
+	if err != nil || form == nil {
 		return err
 	}
 
@@ -341,85 +675,33 @@ func (h *FormAPIHandler) handleGetSubmission(c echo.Context) error {
 		return h.ResponseBuilder.BuildNotFoundResponse(c, "Submission")
 	}
 
-	submission, err := h.FormService.GetFormSubmission(c.Request().Context(), submissionID)
+	submission, err := h.getSubmission(c.Request().Context(), form.ID, submissionID)
 	if err != nil {
+		if errors.Is(err, model.ErrSubmissionNotFound) {
+			return h.ResponseBuilder.BuildNotFoundResponse(c, "Submission")
+		}
+
 		h.Logger.Error("failed to get submission", "error", err, "form_id", form.ID, "submission_id", submissionID)
 
 		return h.HandleError(c, err, "Failed to get submission")
 	}
 
-	if submission == nil || submission.FormID != form.ID {
-		return h.ResponseBuilder.BuildNotFoundResponse(c, "Submission")
-	}
-
-	return c.JSON(http.StatusOK, response.APIResponse{
-		Success: true,
-		Data: map[string]any{
-			"id":           submission.ID,
-			"form_id":      submission.FormID,
-			"status":       submission.Status,
-			"submitted_at": submission.SubmittedAt.Format(time.RFC3339),
-			"data":         submission.Data,
-		},
-	})
-}
-
-// GET /forms/:id/embed returns a minimal HTML page for embedding the form via iframe.
-// Loads Form.io from CDN and renders the form, posting to /forms/:id/submit.
-func (h *FormAPIHandler) handleFormEmbed(c echo.Context) error {
-	form, err := h.getFormOrError(c)
+	projection, err := response.ProjectionFromRequest(c)
 	if err != nil {
-		return err
+		return h.ResponseBuilder.BuildValidationErrorResponse(c, response.FieldsParam, err.Error())
 	}
 
-	if form.Schema == nil {
-		h.Logger.Warn("form schema is nil for embed", "form_id", form.ID)
+	detail, err := h.submissionDetail(c, form, submission)
+	if err != nil {
+		h.Logger.Error("failed to get submission activity", "error", err, "form_id", form.ID, "submission_id", submissionID)
 
-		return h.wrapError("handle embed error",
-			h.ErrorHandler.HandleSchemaError(c, errors.New("form schema is required")))
+		return h.HandleError(c, err, "Failed to get submission")
 	}
 
-	formID := form.ID
-	schemaURL := "/forms/" + formID + "/schema"
-	submitURL := "/forms/" + formID + "/submit"
-
-	html := `<!DOCTYPE html>
-<html>
-<head>
-  <meta charset="utf-8">
-  <meta name="viewport" content="width=device-width, initial-scale=1">
-  <title>` + escapeHTML(form.Title) + `</title>
-  <link rel="stylesheet" href="https://cdn.form.io/formiojs/formio.full.min.css">
-</head>
-<body>
-  <div id="formio"></div>
-  <script src="https://cdn.form.io/formiojs/formio.full.min.js"></script>
-  <script>
-    (function() {
-      var schemaUrl = '` + schemaURL + `';
-      var submitUrl = '` + submitURL + `';
-      var container = document.getElementById('formio');
-      Formio.createForm(container, schemaUrl, {
-        submit: submitUrl,
-        noSubmit: false
-      }).then(function(form) {
-        form.on('submit', function(submission) {
-          if (submission && submission.submission) {
-            window.parent.postMessage({ type: 'goformx:submitted', submission: submission.submission }, '*');
-          }
-        });
-      }).catch(function(err) {
-        container.innerHTML = '<p style="color: #dc2626;">Failed to load form. Please try again.</p>';
-        console.error('Form.io load error:', err);
-      });
-    })();
-  </script>
-</body>
-</html>`
-
-	c.Response().Header().Set("Content-Type", "text/html; charset=utf-8")
-
-	return c.HTML(http.StatusOK, html)
+	return c.JSON(http.StatusOK, response.APIResponse{
+		Success: true,
+		Data:    projection.Apply(detail),
+	})
 }
 
 // escapeHTML escapes HTML special characters for safe inclusion in attribute values.
@@ -439,10 +721,22 @@ func (h *FormAPIHandler) handleFormSubmit(c echo.Context) error {
 	h.logFormSubmissionRequest(c, formID)
 
 	form, err := h.getFormOrError(c)
-	if err != nil {
+	if err != nil || form == nil {
 		return err
 	}
 
+	if handled, embedErr := h.checkScriptEmbed(c, form); handled {
+		return embedErr
+	}
+
+	if handled, throttleErr := h.checkSubmissionThrottle(c, form); handled {
+		return throttleErr
+	}
+
+	if handled, countryErr := h.checkSubmissionCountry(c, form); handled {
+		return countryErr
+	}
+
 	if validationErr := h.validateFormSchema(c, form); validationErr != nil {
 		return validationErr
 	}
@@ -452,19 +746,38 @@ func (h *FormAPIHandler) handleFormSubmit(c echo.Context) error {
 		return err
 	}
 
+	submissionData, handled, err := h.runPreValidate(c, form, submissionData)
+	if handled {
+		return err
+	}
+
+	submissionData, handled, err = h.runSubmissionScript(c, form, submissionData)
+	if handled {
+		return err
+	}
+
 	if validationDataErr := h.validateSubmissionData(c, form, submissionData); validationDataErr != nil {
 		return validationDataErr
 	}
 
-	submission, err := h.createAndSubmitForm(c, form, submissionData)
+	submission, err := h.createAndSubmitForm(c, form, submissionData, false)
 	if err != nil {
 		return err
 	}
 
 	h.Logger.Info("Form submitted successfully", "form_id", form.ID, "submission_id", submission.ID)
 
+	h.Extensions.PostSubmit(form, submission)
+
+	if h.Metering != nil {
+		if meterErr := h.Metering.Record(context.WithoutCancel(c.Request().Context()),
+			metering.UserAccount(form.UserID), metering.MetricSubmissions, 1); meterErr != nil {
+			h.Logger.Warn("failed to record submission usage", "form_id", form.ID, "error", meterErr)
+		}
+	}
+
 	// Build response with proper error checking
-	if respErr := h.ResponseBuilder.BuildSubmissionResponse(c, submission); respErr != nil {
+	if respErr := h.ResponseBuilder.BuildSubmissionResponse(c, submission, confirmationMessage(form, submission)); respErr != nil {
 		h.Logger.Error(
 			"failed to build submission response",
 			"error", respErr,
@@ -480,23 +793,37 @@ func (h *FormAPIHandler) handleFormSubmit(c echo.Context) error {
 
 // Start initializes the form API handler.
 // This is called during application startup.
-func (h *FormAPIHandler) Start(_ context.Context) error {
-	return nil // No initialization needed
+func (h *FormAPIHandler) Start(ctx context.Context) error {
+	if err := h.SubmissionStream.Start(ctx); err != nil {
+		return fmt.Errorf("start submission stream: %w", err)
+	}
+
+	if err := h.CORSCache.Start(ctx); err != nil {
+		return fmt.Errorf("start form CORS cache: %w", err)
+	}
+
+	if err := h.Pages.Start(ctx); err != nil {
+		return fmt.Errorf("start page cache: %w", err)
+	}
+
+	return nil
 }
 
 // Stop cleans up any resources used by the form API handler.
 // This is called during application shutdown.
 func (h *FormAPIHandler) Stop(_ context.Context) error {
-	return nil // No cleanup needed
+	return nil
 }
 
 // Helper methods to reduce code duplication and improve SRP
 
-// getFormOrError retrieves a form by ID and handles common error cases
+// getFormOrError retrieves a form by ID and handles common error cases. Once it
+// has written the error response it returns a nil form and a nil error, so
+// callers return when either is nil.
 func (h *FormAPIHandler) getFormOrError(c echo.Context) (*model.Form, error) {
 	form, err := h.GetFormByID(c)
 	if err != nil {
-		return nil, h.HandleError(c, err, "Failed to get form")
+		return nil, h.handleFormLookupError(c, err)
 	}
 
 	if form == nil {
@@ -508,11 +835,13 @@ func (h *FormAPIHandler) getFormOrError(c echo.Context) (*model.Form, error) {
 	return form, nil
 }
 
-// getFormWithOwnershipOrError retrieves a form with ownership verification
+// getFormWithOwnershipOrError retrieves a form with ownership verification. Like
+// getFormOrError it returns a nil form and error once it has written the error
+// response, so callers return when either is nil.
 func (h *FormAPIHandler) getFormWithOwnershipOrError(c echo.Context) (*model.Form, error) {
 	form, err := h.GetFormWithOwnership(c)
 	if err != nil {
-		return nil, h.HandleError(c, err, "Failed to get form")
+		return nil, h.handleFormLookupError(c, err)
 	}
 
 	if form == nil {
@@ -524,6 +853,16 @@ func (h *FormAPIHandler) getFormWithOwnershipOrError(c echo.Context) (*model.For
 	return form, nil
 }
 
+// handleFormLookupError answers a failed form lookup: unknown and malformed IDs
+// are not found, anything else is a server error
+func (h *FormAPIHandler) handleFormLookupError(c echo.Context, err error) error {
+	if errors.Is(err, common.ErrNotFound) || errors.Is(err, common.ErrInvalidInput) {
+		return h.wrapError("handle form not found", h.ErrorHandler.HandleFormNotFoundError(c, ""))
+	}
+
+	return h.HandleError(c, err, "Failed to get form")
+}
+
 // validateFormSchema validates that form schema exists
 func (h *FormAPIHandler) validateFormSchema(c echo.Context, form *model.Form) error {
 	if form.Schema == nil {
@@ -563,7 +902,10 @@ func (h *FormAPIHandler) processSubmissionRequest(c echo.Context, formID string)
 
 // validateSubmissionData validates submission data against form schema
 func (h *FormAPIHandler) validateSubmissionData(c echo.Context, form *model.Form, submissionData model.JSON) error {
-	validationResult := h.ComprehensiveValidator.ValidateForm(form.Schema, submissionData)
+	// Choices are checked against the current options of fields naming an option list
+	schema := h.Options.ResolveSchema(c.Request().Context(), form.UserID, form.Schema)
+
+	validationResult := h.ComprehensiveValidator.ValidateForm(schema, submissionData)
 	if !validationResult.IsValid {
 		h.Logger.Warn("Form validation failed", "form_id", form.ID, "error_count", len(validationResult.Errors))
 
@@ -581,16 +923,42 @@ func (h *FormAPIHandler) createAndSubmitForm(
 	c echo.Context,
 	form *model.Form,
 	submissionData model.JSON,
+	test bool,
 ) (*model.FormSubmission, error) {
 	submission := &model.FormSubmission{
 		FormID:      form.ID,
 		Data:        submissionData,
 		SubmittedAt: time.Now(),
 		Status:      model.SubmissionStatusPending,
+		Test:        test,
 	}
 
+	if submitterID, ok := c.Get(formSubmitterIDKey).(string); ok {
+		submission.AddMetadata("submitter_user_id", submitterID)
+	}
+
+	if submitterEmail, ok := c.Get(formSubmitterEmailKey).(string); ok {
+		submission.AddMetadata("submitter_email", submitterEmail)
+	}
+
+	h.addLocationMetadata(c, submission)
+
 	err := h.FormService.SubmitForm(c.Request().Context(), submission)
 	if err != nil {
+		if handled, quotaErr := h.handleQuotaError(c, err); handled {
+			h.Logger.Warn("submission rejected by quota", "form_id", form.ID)
+
+			return nil, h.wrapError("handle quota error", quotaErr)
+		}
+
+		var duplicates *formdomain.DuplicateValuesError
+		if errors.As(err, &duplicates) {
+			h.Logger.Info("submission repeats unique values", "form_id", form.ID, "fields", duplicates.Fields)
+
+			return nil, h.wrapError("build multiple error response",
+				h.ResponseBuilder.BuildMultipleErrorResponse(c, duplicateValueErrors(duplicates)))
+		}
+
 		h.Logger.Error("Failed to submit form", "form_id", form.ID, "submission_id", submission.ID, "error", err)
 
 		return nil, h.wrapError("handle submission error", h.ErrorHandler.HandleSubmissionError(c, err))
@@ -599,6 +967,20 @@ func (h *FormAPIHandler) createAndSubmitForm(
 	return submission, nil
 }
 
+// duplicateValueErrors reports each unique field whose value was already submitted
+func duplicateValueErrors(duplicates *formdomain.DuplicateValuesError) []validation.Error {
+	validationErrors := make([]validation.Error, 0, len(duplicates.Fields))
+	for _, field := range duplicates.Fields {
+		validationErrors = append(validationErrors, validation.Error{
+			Field:   field,
+			Message: "This value has already been submitted",
+			Rule:    "unique",
+		})
+	}
+
+	return validationErrors
+}
+
 // wrapError provides consistent error wrapping
 func (h *FormAPIHandler) wrapError(ctx string, err error) error {
 	return fmt.Errorf("%s: %w", ctx, err)
@@ -0,0 +1,168 @@
+package web_test
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zaptest"
+
+	"github.com/goformx/goforms/internal/application/constants"
+	"github.com/goformx/goforms/internal/application/handlers/web"
+	"github.com/goformx/goforms/internal/application/response"
+	"github.com/goformx/goforms/internal/application/validation"
+	formdomain "github.com/goformx/goforms/internal/domain/form"
+	"github.com/goformx/goforms/internal/domain/form/model"
+	"github.com/goformx/goforms/internal/infrastructure/config"
+	"github.com/goformx/goforms/internal/infrastructure/event"
+	"github.com/goformx/goforms/internal/infrastructure/logging"
+	memorystore "github.com/goformx/goforms/internal/infrastructure/repository/memory"
+	"github.com/goformx/goforms/internal/infrastructure/sanitization"
+)
+
+// formOwner is the user the forms of formAPIServer belong to
+const formOwner = "user-1"
+
+// existingUsers accepts every asserted user as already known
+type existingUsers struct{}
+
+func (existingUsers) EnsureUser(context.Context, string) error { return nil }
+
+// formAPIServer is the form API over in-memory stores
+type formAPIServer struct {
+	*echo.Echo
+
+	handler *web.FormAPIHandler
+	forms   formdomain.Service
+}
+
+func newFormAPIServer(t *testing.T, zapLogger *zap.Logger) *formAPIServer {
+	t.Helper()
+
+	if zapLogger == nil {
+		zapLogger = zaptest.NewLogger(t)
+	}
+
+	logger := logging.NewFromZap(zapLogger, sanitization.NewService())
+	store := memorystore.NewStore(logger)
+	bus := event.NewMemoryEventBus(logger)
+	forms := formdomain.NewService(store.Forms(), bus, nil, nil, formdomain.ImageLimits{}, logger)
+
+	cfg := &config.Config{
+		App: config.AppConfig{URL: "https://forms.example.com"},
+		Security: config.SecurityConfig{
+			Assertion: config.AssertionConfig{Secret: testAssertionSecret, TimestampSkewSeconds: 60},
+			CSRF:      config.CSRFConfig{Secret: "csrf-secret"},
+			CORS:      config.CORSConfig{AllowedOrigins: []string{"https://app.example.com"}},
+		},
+	}
+
+	handler := web.NewFormAPIHandler(web.FormAPIHandlerParams{
+		Base: &web.BaseHandler{
+			Logger:       logger,
+			Config:       cfg,
+			FormService:  forms,
+			ErrorHandler: response.NewErrorHandler(logger, sanitization.NewService()),
+		},
+		FormService:   forms,
+		FormValidator: validation.NewFormValidator(logger),
+		Sanitizer:     sanitization.NewService(),
+		UserEnsurer:   existingUsers{},
+		EventBus:      bus,
+	})
+
+	e := echo.New()
+	handler.RegisterRoutes(e)
+
+	return &formAPIServer{Echo: e, handler: handler, forms: forms}
+}
+
+// newOwnedForm returns a form of formOwner with an empty schema
+func newOwnedForm(title string) *model.Form {
+	return model.NewForm(formOwner, title, "", model.JSON{"display": "form", "components": []any{}})
+}
+
+// createForm stores a form owned by formOwner
+func (s *formAPIServer) createForm(t *testing.T, form *model.Form) *model.Form {
+	t.Helper()
+
+	if form.UserID == "" {
+		form.UserID = formOwner
+	}
+
+	require.NoError(t, s.forms.CreateForm(t.Context(), form))
+
+	return form
+}
+
+// call sends a request to /api/forms as userID and returns the recorded response
+func (s *formAPIServer) call(t *testing.T, userID, method, path string, body any, header http.Header) *httptest.ResponseRecorder {
+	t.Helper()
+
+	reqBody := io.Reader(http.NoBody)
+	if body != nil {
+		encoded, err := json.Marshal(body)
+		require.NoError(t, err)
+
+		reqBody = strings.NewReader(string(encoded))
+	}
+
+	req := httptest.NewRequest(method, constants.PathAPIFormsLaravel+path, reqBody)
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+
+	for key, values := range header {
+		req.Header[key] = values
+	}
+
+	signedAssertion(req, userID, userID+"@example.com")
+
+	rec := httptest.NewRecorder()
+	s.ServeHTTP(rec, req)
+
+	return rec
+}
+
+func TestFormAPIHandler_DescribeRoutes(t *testing.T) {
+	srv := newFormAPIServer(t, nil)
+
+	registered := map[string]bool{}
+	for _, route := range srv.Routes() {
+		registered[route.Method+" "+route.Path] = true
+	}
+
+	var idempotent []string
+
+	for _, group := range srv.handler.DescribeRoutes() {
+		if group.Method == "" {
+			covered := false
+			for key := range registered {
+				_, path, _ := strings.Cut(key, " ")
+				covered = covered || strings.HasPrefix(path, group.Prefix)
+			}
+
+			require.True(t, covered, "group %s covers no registered route", group.Prefix)
+
+			continue
+		}
+
+		require.True(t, registered[group.Method+" "+group.Prefix], "%s %s is not registered", group.Method, group.Prefix)
+
+		for _, name := range group.Middleware {
+			if name == "idempotency" {
+				idempotent = append(idempotent, group.Method+" "+group.Prefix)
+			}
+		}
+	}
+
+	require.ElementsMatch(t, []string{
+		http.MethodPost + " " + constants.PathAPIFormsLaravel,
+		http.MethodPost + " " + constants.PathFormsPublic + "/:id/submit",
+	}, idempotent)
+}
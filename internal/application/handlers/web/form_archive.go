@@ -0,0 +1,71 @@
+package web
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+
+	"github.com/labstack/echo/v4"
+
+	"github.com/goformx/goforms/internal/domain/archive"
+	"github.com/goformx/goforms/internal/domain/form/model"
+	"github.com/goformx/goforms/internal/infrastructure/repository/common"
+)
+
+// includeArchivedParam opts a submission list into archived submissions
+const includeArchivedParam = "include_archived"
+
+// getSubmission returns a submission of a form, reading it from the submission
+// archive when it has been moved out of the database. It returns
+// model.ErrSubmissionNotFound when the form has no such submission.
+func (h *FormAPIHandler) getSubmission(ctx context.Context, formID, submissionID string) (*model.FormSubmission, error) {
+	submission, err := h.FormService.GetFormSubmission(ctx, submissionID)
+
+	switch {
+	case err == nil && submission != nil:
+		if submission.FormID != formID {
+			return nil, model.ErrSubmissionNotFound
+		}
+
+		return submission, nil
+	case err != nil && !errors.Is(err, common.ErrNotFound) && !errors.Is(err, model.ErrSubmissionNotFound):
+		return nil, err
+	}
+
+	if h.Archives == nil {
+		return nil, model.ErrSubmissionNotFound
+	}
+
+	submission, err = h.Archives.Get(ctx, formID, submissionID)
+	if err != nil {
+		if errors.Is(err, archive.ErrNotArchived) {
+			return nil, model.ErrSubmissionNotFound
+		}
+
+		return nil, fmt.Errorf("get archived submission: %w", err)
+	}
+
+	return submission, nil
+}
+
+// withArchived appends a form's archived submissions to submissions when the
+// request sets ?include_archived=true and archiving is enabled
+func (h *FormAPIHandler) withArchived(
+	c echo.Context,
+	formID string,
+	submissions []*model.FormSubmission,
+) ([]*model.FormSubmission, error) {
+	include, _ := strconv.ParseBool(c.QueryParam(includeArchivedParam))
+	if !include || h.Archives == nil {
+		return submissions, nil
+	}
+
+	archived, err := h.Archives.List(c.Request().Context(), formID)
+	if err != nil {
+		return nil, fmt.Errorf("list archived submissions: %w", err)
+	}
+
+	return slices.Concat(archived, submissions), nil
+}
@@ -0,0 +1,96 @@
+package web
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+
+	"github.com/goformx/goforms/internal/application/response"
+	"github.com/goformx/goforms/internal/domain/billing"
+)
+
+// CheckoutRequest selects the plan a checkout session subscribes to
+type CheckoutRequest struct {
+	Plan string `json:"plan"`
+}
+
+// requireBilling answers 409 while billing is disabled
+func (h *FormAPIHandler) requireBilling(next echo.HandlerFunc) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		if h.Billing == nil {
+			return response.ErrorResponse(c, http.StatusConflict, "Billing is disabled")
+		}
+
+		return next(c)
+	}
+}
+
+// GET /api/forms/billing - the plans and the authenticated user's subscription (assertion auth)
+func (h *FormAPIHandler) handleGetBilling(c echo.Context) error {
+	userID, ok := c.Get("user_id").(string)
+	if !ok {
+		return h.HandleForbidden(c, "User not authenticated")
+	}
+
+	account, err := h.Billing.Account(c.Request().Context(), userID)
+	if err != nil {
+		h.Logger.Error("failed to get billing account", "error", err, "user_id", h.Logger.SanitizeField("user_id", userID))
+
+		return h.HandleError(c, err, "Failed to get billing account")
+	}
+
+	return response.Success(c, map[string]any{
+		"plans":   h.Billing.Plans(),
+		"account": account,
+	})
+}
+
+// POST /api/forms/billing/checkout - starts a Stripe Checkout session for a plan (assertion auth)
+func (h *FormAPIHandler) handleBillingCheckout(c echo.Context) error {
+	userID, ok := c.Get("user_id").(string)
+	if !ok {
+		return h.HandleForbidden(c, "User not authenticated")
+	}
+
+	var req CheckoutRequest
+	if err := c.Bind(&req); err != nil || req.Plan == "" {
+		return response.ErrorResponse(c, http.StatusBadRequest, "A plan is required")
+	}
+
+	url, err := h.Billing.Checkout(c.Request().Context(), userID, req.Plan)
+
+	switch {
+	case errors.Is(err, billing.ErrPlanNotFound):
+		return response.ErrorResponse(c, http.StatusBadRequest, "Unknown plan")
+	case errors.Is(err, billing.ErrAlreadySubscribed):
+		return response.ErrorResponse(c, http.StatusConflict, err.Error())
+	case err != nil:
+		h.Logger.Error("failed to start billing checkout", "error", err, "user_id", h.Logger.SanitizeField("user_id", userID))
+
+		return response.ErrorResponse(c, http.StatusBadGateway, "Failed to start checkout")
+	}
+
+	return response.Success(c, map[string]any{"url": url})
+}
+
+// POST /api/forms/billing/portal - opens the Stripe Customer Portal for the user's subscription (assertion auth)
+func (h *FormAPIHandler) handleBillingPortal(c echo.Context) error {
+	userID, ok := c.Get("user_id").(string)
+	if !ok {
+		return h.HandleForbidden(c, "User not authenticated")
+	}
+
+	url, err := h.Billing.Portal(c.Request().Context(), userID)
+
+	switch {
+	case errors.Is(err, billing.ErrSubscriptionNotFound):
+		return response.ErrorResponse(c, http.StatusNotFound, "No subscription to manage")
+	case err != nil:
+		h.Logger.Error("failed to open billing portal", "error", err, "user_id", h.Logger.SanitizeField("user_id", userID))
+
+		return response.ErrorResponse(c, http.StatusBadGateway, "Failed to open billing portal")
+	}
+
+	return response.Success(c, map[string]any{"url": url})
+}
@@ -0,0 +1,460 @@
+package web
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/labstack/echo/v4"
+	"golang.org/x/net/websocket"
+
+	formdomain "github.com/goformx/goforms/internal/domain/form"
+	"github.com/goformx/goforms/internal/domain/form/model"
+	"github.com/goformx/goforms/internal/infrastructure/config"
+	"github.com/goformx/goforms/internal/infrastructure/logging"
+)
+
+// collabSendBuffer is the number of outbound messages queued per editor connection
+const collabSendBuffer = 32
+
+// errCollabOrigin is returned when a browser on an origin that is not allowed opens the builder websocket
+var errCollabOrigin = errors.New("origin may not open the collaboration websocket")
+
+// Collaboration message types exchanged over the builder websocket
+const (
+	CollabTypePresence   = "presence"
+	CollabTypeLocks      = "locks"
+	CollabTypeLock       = "lock"
+	CollabTypeUnlock     = "unlock"
+	CollabTypeLockDenied = "lock_denied"
+	CollabTypeSchema     = "schema"
+	CollabTypeConflict   = "conflict"
+	CollabTypeError      = "error"
+)
+
+// CollabMessage is the JSON envelope for builder collaboration messages
+type CollabMessage struct {
+	Type    string            `json:"type"`
+	UserID  string            `json:"user_id,omitempty"`
+	Field   string            `json:"field,omitempty"`
+	Schema  model.JSON        `json:"schema,omitempty"`
+	Version int64             `json:"version,omitempty"`
+	Users   []string          `json:"users,omitempty"`
+	Locks   map[string]string `json:"locks,omitempty"`
+	Error   string            `json:"error,omitempty"`
+}
+
+// collabClient is a single editor connection
+type collabClient struct {
+	userID string
+	send   chan CollabMessage
+}
+
+// collabRoom holds the editors and field locks for one form
+type collabRoom struct {
+	mu      sync.Mutex
+	clients map[*collabClient]struct{}
+	locks   map[string]*collabClient
+	// saveMu serializes schema writes so version checks and saves are atomic per form
+	saveMu sync.Mutex
+}
+
+// CollabHub coordinates collaborative editing sessions for the form builder.
+// Schema changes are persisted with last-writer-wins semantics guarded by a
+// version check, and broadcast to every editor of the form.
+type CollabHub struct {
+	formService formdomain.Service
+	history     formdomain.SchemaHistoryService
+	logger      logging.Logger
+	mu          sync.Mutex
+	rooms       map[string]*collabRoom
+}
+
+// NewCollabHub creates a new collaboration hub
+func NewCollabHub(formService formdomain.Service, history formdomain.SchemaHistoryService, logger logging.Logger) *CollabHub {
+	return &CollabHub{
+		formService: formService,
+		history:     history,
+		logger:      logger,
+		rooms:       make(map[string]*collabRoom),
+	}
+}
+
+// formVersion returns the concurrency token editors must echo back with schema changes
+func formVersion(form *model.Form) int64 {
+	return int64(form.Version)
+}
+
+// join registers an editor in the form's room and announces presence
+func (h *CollabHub) join(formID, userID string) *collabClient {
+	client := &collabClient{userID: userID, send: make(chan CollabMessage, collabSendBuffer)}
+
+	h.mu.Lock()
+	room, exists := h.rooms[formID]
+	if !exists {
+		room = &collabRoom{
+			clients: make(map[*collabClient]struct{}),
+			locks:   make(map[string]*collabClient),
+		}
+		h.rooms[formID] = room
+	}
+	h.mu.Unlock()
+
+	room.mu.Lock()
+	room.clients[client] = struct{}{}
+	room.mu.Unlock()
+
+	h.broadcastPresence(formID)
+	h.sendTo(formID, client, CollabMessage{Type: CollabTypeLocks, Locks: h.snapshotLocks(formID)})
+
+	return client
+}
+
+// leave removes an editor, releases their locks and announces presence
+func (h *CollabHub) leave(formID string, client *collabClient) {
+	room := h.room(formID)
+	if room == nil {
+		return
+	}
+
+	room.mu.Lock()
+	delete(room.clients, client)
+
+	released := false
+	for field, holder := range room.locks {
+		if holder == client {
+			delete(room.locks, field)
+			released = true
+		}
+	}
+
+	empty := len(room.clients) == 0
+	room.mu.Unlock()
+
+	if empty {
+		h.mu.Lock()
+		if h.rooms[formID] == room {
+			delete(h.rooms, formID)
+		}
+		h.mu.Unlock()
+
+		return
+	}
+
+	h.broadcastPresence(formID)
+
+	if released {
+		h.broadcast(formID, CollabMessage{Type: CollabTypeLocks, Locks: h.snapshotLocks(formID)})
+	}
+}
+
+// handleMessage processes an inbound message from an editor
+func (h *CollabHub) handleMessage(ctx context.Context, formID string, client *collabClient, msg CollabMessage) {
+	switch msg.Type {
+	case CollabTypeLock:
+		h.lockField(formID, client, msg.Field)
+	case CollabTypeUnlock:
+		h.unlockField(formID, client, msg.Field)
+	case CollabTypeSchema:
+		h.applySchema(ctx, formID, client, msg)
+	default:
+		h.sendTo(formID, client, CollabMessage{Type: CollabTypeError, Error: "unknown message type"})
+	}
+}
+
+// lockField grants a field lock unless another editor already holds it
+func (h *CollabHub) lockField(formID string, client *collabClient, field string) {
+	room := h.room(formID)
+	if room == nil || field == "" {
+		return
+	}
+
+	room.mu.Lock()
+	holder, locked := room.locks[field]
+	if locked && holder != client {
+		room.mu.Unlock()
+		h.sendTo(formID, client, CollabMessage{Type: CollabTypeLockDenied, Field: field, UserID: holder.userID})
+
+		return
+	}
+
+	room.locks[field] = client
+	room.mu.Unlock()
+
+	h.broadcast(formID, CollabMessage{Type: CollabTypeLocks, Locks: h.snapshotLocks(formID)})
+}
+
+// unlockField releases a field lock held by the editor
+func (h *CollabHub) unlockField(formID string, client *collabClient, field string) {
+	room := h.room(formID)
+	if room == nil {
+		return
+	}
+
+	room.mu.Lock()
+	if room.locks[field] != client {
+		room.mu.Unlock()
+
+		return
+	}
+
+	delete(room.locks, field)
+	room.mu.Unlock()
+
+	h.broadcast(formID, CollabMessage{Type: CollabTypeLocks, Locks: h.snapshotLocks(formID)})
+}
+
+// applySchema persists a schema change if the editor's version is current, otherwise
+// replies with a conflict carrying the latest schema so the editor can rebase.
+func (h *CollabHub) applySchema(ctx context.Context, formID string, client *collabClient, msg CollabMessage) {
+	room := h.room(formID)
+	if room == nil || msg.Schema == nil {
+		return
+	}
+
+	room.saveMu.Lock()
+	defer room.saveMu.Unlock()
+
+	form, err := h.formService.GetForm(ctx, formID)
+	if err != nil || form == nil {
+		h.logger.Error("failed to load form for collaborative update", "form_id", formID, "error", err)
+		h.sendTo(formID, client, CollabMessage{Type: CollabTypeError, Error: "failed to load form"})
+
+		return
+	}
+
+	if msg.Version != formVersion(form) {
+		h.sendTo(formID, client, CollabMessage{
+			Type:    CollabTypeConflict,
+			Schema:  form.Schema,
+			Version: formVersion(form),
+		})
+
+		return
+	}
+
+	previous := form.Schema
+	form.Schema = msg.Schema
+
+	if updateErr := h.formService.UpdateForm(ctx, form); updateErr != nil {
+		h.logger.Error("failed to save collaborative schema update", "form_id", formID, "error", updateErr)
+		h.sendTo(formID, client, CollabMessage{Type: CollabTypeError, Error: "failed to save schema"})
+
+		return
+	}
+
+	recordSchemaEdit(ctx, h.history, formID, previous, form.Schema, h.logger)
+
+	// Reload so the broadcast version matches what the next editor will be checked against
+	if saved, getErr := h.formService.GetForm(ctx, formID); getErr == nil && saved != nil {
+		form = saved
+	}
+
+	h.broadcastSchema(formID, client.userID, form)
+}
+
+// broadcastSchema sends a saved schema and its version to every editor of the form
+func (h *CollabHub) broadcastSchema(formID, userID string, form *model.Form) {
+	h.broadcast(formID, CollabMessage{
+		Type:    CollabTypeSchema,
+		UserID:  userID,
+		Schema:  form.Schema,
+		Version: formVersion(form),
+	})
+}
+
+// room returns the room for a form, or nil if nobody is editing it
+func (h *CollabHub) room(formID string) *collabRoom {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	return h.rooms[formID]
+}
+
+// snapshotLocks returns the current field locks keyed by field, valued by user ID
+func (h *CollabHub) snapshotLocks(formID string) map[string]string {
+	locks := make(map[string]string)
+
+	room := h.room(formID)
+	if room == nil {
+		return locks
+	}
+
+	room.mu.Lock()
+	defer room.mu.Unlock()
+
+	for field, holder := range room.locks {
+		locks[field] = holder.userID
+	}
+
+	return locks
+}
+
+// broadcastPresence sends the list of connected editors to the room
+func (h *CollabHub) broadcastPresence(formID string) {
+	room := h.room(formID)
+	if room == nil {
+		return
+	}
+
+	room.mu.Lock()
+	seen := make(map[string]struct{})
+	users := make([]string, 0, len(room.clients))
+
+	for client := range room.clients {
+		if _, dup := seen[client.userID]; dup {
+			continue
+		}
+
+		seen[client.userID] = struct{}{}
+		users = append(users, client.userID)
+	}
+	room.mu.Unlock()
+
+	sort.Strings(users)
+	h.broadcast(formID, CollabMessage{Type: CollabTypePresence, Users: users})
+}
+
+// broadcast queues a message for every editor in the room
+func (h *CollabHub) broadcast(formID string, msg CollabMessage) {
+	room := h.room(formID)
+	if room == nil {
+		return
+	}
+
+	room.mu.Lock()
+	clients := make([]*collabClient, 0, len(room.clients))
+	for client := range room.clients {
+		clients = append(clients, client)
+	}
+	room.mu.Unlock()
+
+	for _, client := range clients {
+		h.sendTo(formID, client, msg)
+	}
+}
+
+// sendTo queues a message for one editor without blocking the hub
+func (h *CollabHub) sendTo(formID string, client *collabClient, msg CollabMessage) {
+	select {
+	case client.send <- msg:
+	default:
+		h.logger.Warn("dropping collaboration message for slow editor", "form_id", formID, "type", msg.Type)
+	}
+}
+
+// GET /api/forms/:id/collab - websocket for collaborative builder editing (assertion auth)
+func (h *FormAPIHandler) handleFormCollab(c echo.Context) error {
+	form, err := h.getFormWithOwnershipOrError(c)
+	if err != nil || form == nil {
+		return err
+	}
+
+	userID, _ := c.Get("user_id").(string)
+	formID := form.ID
+
+	server := websocket.Server{
+		Handshake: func(_ *websocket.Config, req *http.Request) error {
+			return h.checkCollabOrigin(req)
+		},
+		Handler: func(ws *websocket.Conn) {
+			h.serveCollab(c.Request().Context(), ws, formID, userID, formVersion(form), form.Schema)
+		},
+	}
+	server.ServeHTTP(c.Response(), c.Request())
+
+	return nil
+}
+
+// checkCollabOrigin refuses the handshake of a browser on an origin other than
+// this server, app.url or a listed CORS origin; a "*" CORS origin does not count.
+// Requests without an Origin do not come from browsers and are let through.
+func (h *FormAPIHandler) checkCollabOrigin(req *http.Request) error {
+	origin := req.Header.Get(echo.HeaderOrigin)
+	if origin == "" {
+		return nil
+	}
+
+	if collabOriginAllowed(origin, req.Host, h.Config) {
+		return nil
+	}
+
+	h.Logger.Warn("refused collaboration websocket from another origin", "origin", origin)
+
+	return fmt.Errorf("%w: %s", errCollabOrigin, origin)
+}
+
+// collabOriginAllowed reports whether origin is the request's host, app.url or a
+// listed CORS origin
+func collabOriginAllowed(origin, host string, cfg *config.Config) bool {
+	parsed, err := url.Parse(origin)
+	if err != nil || parsed.Host == "" {
+		return false
+	}
+
+	if strings.EqualFold(parsed.Host, host) {
+		return true
+	}
+
+	allowed := append([]string{cfg.App.URL}, cfg.Security.CORS.AllowedOrigins...)
+
+	return slices.ContainsFunc(allowed, func(candidate string) bool {
+		listed, parseErr := url.Parse(candidate)
+
+		return parseErr == nil && listed.Host != "" &&
+			strings.EqualFold(listed.Scheme, parsed.Scheme) && strings.EqualFold(listed.Host, parsed.Host)
+	})
+}
+
+// serveCollab runs the read and write loops for a single editor connection
+func (h *FormAPIHandler) serveCollab(
+	ctx context.Context,
+	ws *websocket.Conn,
+	formID, userID string,
+	version int64,
+	schema model.JSON,
+) {
+	defer ws.Close()
+
+	client := h.Collab.join(formID, userID)
+	defer h.Collab.leave(formID, client)
+
+	done := make(chan struct{})
+	defer close(done)
+
+	go func() {
+		for {
+			select {
+			case <-done:
+				return
+			case msg := <-client.send:
+				if err := websocket.JSON.Send(ws, msg); err != nil {
+					_ = ws.Close()
+
+					return
+				}
+			}
+		}
+	}()
+
+	h.Collab.sendTo(formID, client, CollabMessage{Type: CollabTypeSchema, Schema: schema, Version: version})
+
+	for {
+		var msg CollabMessage
+		if err := websocket.JSON.Receive(ws, &msg); err != nil {
+			h.Logger.Debug("collaboration connection closed",
+				"form_id", formID,
+				"error", err)
+
+			return
+		}
+
+		h.Collab.handleMessage(ctx, formID, client, msg)
+	}
+}
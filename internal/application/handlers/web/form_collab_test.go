@@ -0,0 +1,245 @@
+package web_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zaptest/observer"
+	"golang.org/x/net/websocket"
+
+	"github.com/goformx/goforms/internal/application/constants"
+	"github.com/goformx/goforms/internal/application/handlers/web"
+	"github.com/goformx/goforms/internal/domain/form/model"
+)
+
+// collabPath is the builder websocket of a form
+func collabPath(formID string) string {
+	return constants.PathAPIFormsLaravel + "/" + formID + "/collab"
+}
+
+// dialCollab opens the builder websocket of a form as its owner
+func dialCollab(t *testing.T, srv *httptest.Server, formID string) *websocket.Conn {
+	t.Helper()
+
+	cfg, err := websocket.NewConfig("ws"+strings.TrimPrefix(srv.URL, "http")+collabPath(formID), srv.URL)
+	require.NoError(t, err)
+
+	req := httptest.NewRequest(http.MethodGet, collabPath(formID), http.NoBody)
+	signedAssertion(req, formOwner, formOwner+"@example.com")
+	cfg.Header = req.Header
+
+	ws, err := websocket.DialConfig(cfg)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = ws.Close() })
+
+	return ws
+}
+
+// expectCollab reads the next message other than a presence update and checks its type
+func expectCollab(t *testing.T, ws *websocket.Conn, msgType string) web.CollabMessage {
+	t.Helper()
+
+	require.NoError(t, ws.SetReadDeadline(time.Now().Add(5*time.Second)))
+
+	for {
+		var msg web.CollabMessage
+		require.NoError(t, websocket.JSON.Receive(ws, &msg))
+
+		if msg.Type == web.CollabTypePresence {
+			assert.Equal(t, []string{formOwner}, msg.Users, "editors are listed once per user")
+
+			continue
+		}
+
+		require.Equal(t, msgType, msg.Type, "message %+v", msg)
+
+		return msg
+	}
+}
+
+func sendCollab(t *testing.T, ws *websocket.Conn, msg web.CollabMessage) {
+	t.Helper()
+
+	require.NoError(t, websocket.JSON.Send(ws, msg))
+}
+
+// joinCollab opens the websocket and reads the messages sent on joining
+func joinCollab(t *testing.T, srv *httptest.Server, formID string) *websocket.Conn {
+	t.Helper()
+
+	ws := dialCollab(t, srv, formID)
+	expectCollab(t, ws, web.CollabTypeLocks)
+	expectCollab(t, ws, web.CollabTypeSchema)
+
+	return ws
+}
+
+func TestFormCollab_JoinLocksAndLeave(t *testing.T) {
+	server := newFormAPIServer(t, nil)
+	form := server.createForm(t, newOwnedForm("Survey"))
+	srv := httptest.NewServer(server)
+	defer srv.Close()
+
+	first := dialCollab(t, srv, form.ID)
+	assert.Empty(t, expectCollab(t, first, web.CollabTypeLocks).Locks)
+
+	joined := expectCollab(t, first, web.CollabTypeSchema)
+	assert.Equal(t, int64(1), joined.Version)
+	assert.Equal(t, "form", joined.Schema["display"])
+
+	second := joinCollab(t, srv, form.ID)
+
+	// A lock is announced to every editor
+	sendCollab(t, first, web.CollabMessage{Type: web.CollabTypeLock, Field: "email"})
+	assert.Equal(t, map[string]string{"email": formOwner}, expectCollab(t, first, web.CollabTypeLocks).Locks)
+	assert.Equal(t, map[string]string{"email": formOwner}, expectCollab(t, second, web.CollabTypeLocks).Locks)
+
+	// Another connection can neither take nor release it
+	sendCollab(t, second, web.CollabMessage{Type: web.CollabTypeLock, Field: "email"})
+	denied := expectCollab(t, second, web.CollabTypeLockDenied)
+	assert.Equal(t, "email", denied.Field)
+	assert.Equal(t, formOwner, denied.UserID)
+
+	sendCollab(t, second, web.CollabMessage{Type: web.CollabTypeUnlock, Field: "email"})
+
+	sendCollab(t, first, web.CollabMessage{Type: web.CollabTypeLock, Field: "name"})
+	both := map[string]string{"email": formOwner, "name": formOwner}
+	assert.Equal(t, both, expectCollab(t, first, web.CollabTypeLocks).Locks)
+	assert.Equal(t, both, expectCollab(t, second, web.CollabTypeLocks).Locks)
+
+	sendCollab(t, first, web.CollabMessage{Type: web.CollabTypeUnlock, Field: "email"})
+	assert.Equal(t, map[string]string{"name": formOwner}, expectCollab(t, first, web.CollabTypeLocks).Locks)
+	assert.Equal(t, map[string]string{"name": formOwner}, expectCollab(t, second, web.CollabTypeLocks).Locks)
+
+	sendCollab(t, second, web.CollabMessage{Type: "cursor"})
+	assert.Equal(t, "unknown message type", expectCollab(t, second, web.CollabTypeError).Error)
+
+	// Leaving releases the editor's locks
+	require.NoError(t, first.Close())
+	assert.Empty(t, expectCollab(t, second, web.CollabTypeLocks).Locks)
+
+	sendCollab(t, second, web.CollabMessage{Type: web.CollabTypeLock, Field: "name"})
+	assert.Equal(t, map[string]string{"name": formOwner}, expectCollab(t, second, web.CollabTypeLocks).Locks)
+}
+
+func TestFormCollab_SchemaChanges(t *testing.T) {
+	server := newFormAPIServer(t, nil)
+	form := server.createForm(t, newOwnedForm("Survey"))
+	srv := httptest.NewServer(server)
+	defer srv.Close()
+
+	first := joinCollab(t, srv, form.ID)
+	second := joinCollab(t, srv, form.ID)
+
+	edited := model.JSON{"display": "form", "components": []any{map[string]any{"key": "email", "type": "email"}}}
+	sendCollab(t, first, web.CollabMessage{Type: web.CollabTypeSchema, Schema: edited, Version: 1})
+
+	for _, ws := range []*websocket.Conn{first, second} {
+		saved := expectCollab(t, ws, web.CollabTypeSchema)
+		assert.Equal(t, int64(2), saved.Version)
+		assert.Equal(t, formOwner, saved.UserID)
+		assert.Len(t, saved.Schema["components"], 1)
+	}
+
+	// An editor behind the saved version gets the current schema back to rebase on
+	stale := model.JSON{"display": "form", "components": []any{}}
+	sendCollab(t, second, web.CollabMessage{Type: web.CollabTypeSchema, Schema: stale, Version: 1})
+
+	conflict := expectCollab(t, second, web.CollabTypeConflict)
+	assert.Equal(t, int64(2), conflict.Version)
+	assert.Len(t, conflict.Schema["components"], 1)
+
+	// The conflict is not broadcast: the first editor's next message is its own lock
+	sendCollab(t, first, web.CollabMessage{Type: web.CollabTypeLock, Field: "email"})
+	expectCollab(t, first, web.CollabTypeLocks)
+
+	stored, err := server.forms.GetForm(t.Context(), form.ID)
+	require.NoError(t, err)
+	assert.Equal(t, 2, stored.Version)
+	assert.Len(t, stored.Schema["components"], 1)
+}
+
+func TestFormCollab_SlowEditor(t *testing.T) {
+	core, logs := observer.New(zap.WarnLevel)
+	server := newFormAPIServer(t, zap.New(core))
+	form := server.createForm(t, newOwnedForm("Survey"))
+	srv := httptest.NewServer(server)
+	defer srv.Close()
+
+	ws := joinCollab(t, srv, form.ID)
+
+	// The stalled editor is queued its presence and lock snapshots on joining
+	server.handler.Collab.JoinStalledEditor(form.ID, formOwner)
+
+	const changes = 40
+	for range changes {
+		sendCollab(t, ws, web.CollabMessage{Type: web.CollabTypeLock, Field: "email"})
+		expectCollab(t, ws, web.CollabTypeLocks)
+		sendCollab(t, ws, web.CollabMessage{Type: web.CollabTypeUnlock, Field: "email"})
+		expectCollab(t, ws, web.CollabTypeLocks)
+	}
+
+	dropped := logs.FilterMessage("dropping collaboration message for slow editor").Len()
+	assert.Equal(t, 2+2*changes-web.CollabSendBuffer, dropped, "messages past the stalled editor's queue are dropped")
+}
+
+func TestFormCollab_Handshake(t *testing.T) {
+	server := newFormAPIServer(t, nil)
+	form := server.createForm(t, newOwnedForm("Survey"))
+	srv := httptest.NewServer(server)
+	defer srv.Close()
+
+	handshake := func(userID, origin string) int {
+		req, err := http.NewRequestWithContext(t.Context(), http.MethodGet, srv.URL+collabPath(form.ID), http.NoBody)
+		require.NoError(t, err)
+
+		req.Header.Set("Upgrade", "websocket")
+		req.Header.Set("Connection", "Upgrade")
+		req.Header.Set("Sec-WebSocket-Version", "13")
+		req.Header.Set("Sec-WebSocket-Key", "dGhlIHNhbXBsZSBub25jZQ==")
+
+		if origin != "" {
+			req.Header.Set("Origin", origin)
+		}
+
+		signedAssertion(req, userID, userID+"@example.com")
+
+		resp, err := http.DefaultClient.Do(req)
+		require.NoError(t, err)
+		_ = resp.Body.Close()
+
+		return resp.StatusCode
+	}
+
+	tests := []struct {
+		name   string
+		userID string
+		origin string
+		want   int
+	}{
+		{name: "same origin", userID: formOwner, origin: srv.URL, want: http.StatusSwitchingProtocols},
+		{name: "app url", userID: formOwner, origin: "https://forms.example.com", want: http.StatusSwitchingProtocols},
+		{name: "CORS origin", userID: formOwner, origin: "https://app.example.com", want: http.StatusSwitchingProtocols},
+		{name: "no origin", userID: formOwner, want: http.StatusSwitchingProtocols},
+		{name: "other origin", userID: formOwner, origin: "https://evil.example.com", want: http.StatusForbidden},
+		{name: "other scheme", userID: formOwner, origin: "http://app.example.com", want: http.StatusForbidden},
+		{name: "opaque origin", userID: formOwner, origin: "null", want: http.StatusForbidden},
+		{name: "not the owner", userID: "user-2", origin: srv.URL, want: http.StatusForbidden},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, handshake(tt.userID, tt.origin))
+		})
+	}
+
+	// A wildcard CORS origin does not open the websocket to every site
+	server.handler.Config.Security.CORS.AllowedOrigins = []string{"*"}
+	assert.Equal(t, http.StatusForbidden, handshake(formOwner, "https://evil.example.com"))
+}
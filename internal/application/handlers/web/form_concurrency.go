@@ -0,0 +1,70 @@
+package web
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/labstack/echo/v4"
+
+	"github.com/goformx/goforms/internal/application/response"
+	"github.com/goformx/goforms/internal/domain/form/model"
+)
+
+// errInvalidIfMatch is returned when the If-Match header is not a form version ETag
+var errInvalidIfMatch = errors.New("invalid If-Match header")
+
+// formETag returns the strong ETag for a form version
+func formETag(form *model.Form) string {
+	return `"` + strconv.Itoa(form.Version) + `"`
+}
+
+// setFormETag exposes the form version as an ETag for conditional updates
+func setFormETag(c echo.Context, form *model.Form) {
+	c.Response().Header().Set("ETag", formETag(form))
+}
+
+// expectedFormVersion returns the version precondition supplied with an update, from
+// the If-Match header or the request body. ok is false when no precondition was given.
+func expectedFormVersion(c echo.Context, req *FormUpdateRequest) (version int, ok bool, err error) {
+	ifMatch := strings.TrimSpace(c.Request().Header.Get("If-Match"))
+	if ifMatch != "" && ifMatch != "*" {
+		tag := strings.Trim(strings.TrimPrefix(ifMatch, "W/"), `"`)
+
+		parsed, parseErr := strconv.Atoi(tag)
+		if parseErr != nil || parsed <= 0 {
+			return 0, false, errInvalidIfMatch
+		}
+
+		return parsed, true, nil
+	}
+
+	if req != nil && req.Version != nil {
+		return *req.Version, true, nil
+	}
+
+	return 0, false, nil
+}
+
+// buildVersionConflictResponse returns 409 with the current form so the client can merge
+func (h *FormAPIHandler) buildVersionConflictResponse(c echo.Context, current *model.Form) error {
+	setFormETag(c, current)
+
+	return c.JSON(http.StatusConflict, response.APIResponse{
+		Success: false,
+		Message: "Form was modified by another request",
+		Data: map[string]any{
+			"form": map[string]any{
+				"id":          current.ID,
+				"title":       current.Title,
+				"description": current.Description,
+				"status":      current.Status,
+				"schema":      current.Schema,
+				"version":     current.Version,
+				"updated_at":  current.UpdatedAt.Format(time.RFC3339),
+			},
+		},
+	})
+}
@@ -0,0 +1,118 @@
+package web_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func ifMatch(tag string) http.Header {
+	return http.Header{"If-Match": []string{tag}}
+}
+
+// formVersionOf reads the version of the form in a form API response
+func formVersionOf(t *testing.T, body []byte) float64 {
+	t.Helper()
+
+	var payload struct {
+		Data struct {
+			Form struct {
+				Version float64 `json:"version"`
+			} `json:"form"`
+		} `json:"data"`
+	}
+	require.NoError(t, json.Unmarshal(body, &payload))
+
+	return payload.Data.Form.Version
+}
+
+func TestFormAPI_FormETag(t *testing.T) {
+	server := newFormAPIServer(t, nil)
+	form := server.createForm(t, newOwnedForm("Survey"))
+
+	rec := server.call(t, formOwner, http.MethodGet, "/"+form.ID, nil, nil)
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, `"1"`, rec.Header().Get("ETag"))
+
+	rec = server.call(t, formOwner, http.MethodPut, "/"+form.ID, map[string]any{"title": "Renamed"}, ifMatch(`"1"`))
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+	assert.Equal(t, `"2"`, rec.Header().Get("ETag"), "the ETag of a saved form is its new version")
+
+	rec = server.call(t, formOwner, http.MethodGet, "/"+form.ID, nil, nil)
+	assert.Equal(t, `"2"`, rec.Header().Get("ETag"))
+}
+
+func TestFormAPI_StaleVersionConflict(t *testing.T) {
+	server := newFormAPIServer(t, nil)
+	form := server.createForm(t, newOwnedForm("Survey"))
+
+	rec := server.call(t, formOwner, http.MethodPut, "/"+form.ID, map[string]any{"title": "First"}, ifMatch(`"1"`))
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+
+	// A second editor still holding version 1
+	rec = server.call(t, formOwner, http.MethodPut, "/"+form.ID, map[string]any{"title": "Second"}, ifMatch(`"1"`))
+	require.Equal(t, http.StatusConflict, rec.Code)
+	assert.Equal(t, `"2"`, rec.Header().Get("ETag"))
+	assert.InDelta(t, 2, formVersionOf(t, rec.Body.Bytes()), 0, "the conflict carries the current form to merge with")
+	assert.Contains(t, rec.Body.String(), `"title":"First"`)
+
+	// Without If-Match the version in the body is the precondition
+	rec = server.call(t, formOwner, http.MethodPut, "/"+form.ID, map[string]any{"title": "Second", "version": 1}, nil)
+	assert.Equal(t, http.StatusConflict, rec.Code)
+
+	rec = server.call(t, formOwner, http.MethodPut, "/"+form.ID, map[string]any{"title": "Second", "version": 2}, nil)
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+	assert.InDelta(t, 3, formVersionOf(t, rec.Body.Bytes()), 0)
+
+	// If-Match wins over the body
+	rec = server.call(t, formOwner, http.MethodPut, "/"+form.ID, map[string]any{"title": "Third", "version": 3}, ifMatch(`"2"`))
+	assert.Equal(t, http.StatusConflict, rec.Code)
+
+	stored, err := server.forms.GetForm(t.Context(), form.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "Second", stored.Title)
+	assert.Equal(t, 3, stored.Version)
+}
+
+func TestFormAPI_IfMatchParsing(t *testing.T) {
+	tests := []struct {
+		name    string
+		ifMatch string
+		want    int
+	}{
+		{name: "strong tag", ifMatch: `"1"`, want: http.StatusOK},
+		{name: "surrounding spaces", ifMatch: ` "1" `, want: http.StatusOK},
+		// Proxies that compress responses weaken their ETags, so clients send them back weak
+		{name: "weak tag", ifMatch: `W/"1"`, want: http.StatusOK},
+		{name: "unquoted", ifMatch: `1`, want: http.StatusOK},
+		{name: "any version", ifMatch: `*`, want: http.StatusOK},
+		{name: "stale weak tag", ifMatch: `W/"7"`, want: http.StatusConflict},
+		{name: "not a number", ifMatch: `"abc"`, want: http.StatusBadRequest},
+		{name: "zero", ifMatch: `"0"`, want: http.StatusBadRequest},
+		{name: "negative", ifMatch: `"-1"`, want: http.StatusBadRequest},
+		{name: "list of tags", ifMatch: `"1", "2"`, want: http.StatusBadRequest},
+		{name: "empty weak tag", ifMatch: `W/""`, want: http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := newFormAPIServer(t, nil)
+			form := server.createForm(t, newOwnedForm("Survey"))
+
+			rec := server.call(t, formOwner, http.MethodPut, "/"+form.ID, map[string]any{"title": "Renamed"}, ifMatch(tt.ifMatch))
+			assert.Equal(t, tt.want, rec.Code, rec.Body.String())
+
+			stored, err := server.forms.GetForm(t.Context(), form.ID)
+			require.NoError(t, err)
+
+			if tt.want == http.StatusOK {
+				assert.Equal(t, "Renamed", stored.Title)
+			} else {
+				assert.Equal(t, "Survey", stored.Title, "a refused update changes nothing")
+			}
+		})
+	}
+}
@@ -0,0 +1,310 @@
+package web
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+
+	"github.com/goformx/goforms/internal/application/response"
+	"github.com/goformx/goforms/internal/domain/form/model"
+)
+
+// errWildcardOriginNotAllowed is returned when "*" is added in production without
+// security.cors.allow_wildcard_origin
+var errWildcardOriginNotAllowed = errors.New(
+	"wildcard origin is not allowed in production unless security.cors.allow_wildcard_origin is set")
+
+// CORSOriginRequest names one origin to add to or remove from a form.
+// DELETE requests may pass it as the ?origin= query parameter.
+type CORSOriginRequest struct {
+	Origin string `json:"origin" query:"origin"`
+}
+
+// CORSCheckRequest describes a cross-origin request to evaluate against a form's rules
+type CORSCheckRequest struct {
+	Origin  string   `json:"origin"`
+	Method  string   `json:"method"`
+	Headers []string `json:"headers"`
+}
+
+// chromiumMaxPreflightAge is the longest Chromium caches a preflight response, in seconds
+const chromiumMaxPreflightAge = 7200
+
+// corsSafelistedMethods are sent cross-origin without a preflight
+var corsSafelistedMethods = []string{http.MethodGet, http.MethodHead, http.MethodPost}
+
+// corsSafelistedHeaders may be set cross-origin without a preflight. Content-Type is
+// left out: the JSON bodies the submit endpoint accepts always trigger a preflight.
+var corsSafelistedHeaders = []string{"accept", "accept-language", "content-language"}
+
+// corsOriginsResponse lists a form's allowed origins
+func (h *FormAPIHandler) corsOriginsResponse(form *model.Form) map[string]any {
+	origins := form.GetCorsOrigins()
+	if origins == nil {
+		origins = []string{}
+	}
+
+	return map[string]any{
+		"form_id":          form.ID,
+		"origins":          origins,
+		"wildcard_allowed": h.wildcardOriginAllowed(),
+	}
+}
+
+// wildcardOriginAllowed reports whether forms may allow every origin
+func (h *FormAPIHandler) wildcardOriginAllowed() bool {
+	return !h.Config.IsProduction() || h.Config.Security.CORS.AllowWildcardOrigin
+}
+
+// GET /api/forms/:id/cors/origins - list the origins allowed to embed a form (assertion auth)
+func (h *FormAPIHandler) handleListCORSOrigins(c echo.Context) error {
+	form, err := h.getFormWithOwnershipOrError(c)
+	if err != nil || form == nil {
+		return err
+	}
+
+	return response.Success(c, h.corsOriginsResponse(form))
+}
+
+// POST /api/forms/:id/cors/origins - allow an origin to embed a form (assertion auth).
+// Returns 201 when the origin was added and 200 when it was already allowed.
+func (h *FormAPIHandler) handleAddCORSOrigin(c echo.Context) error {
+	form, err := h.getFormWithOwnershipOrError(c)
+	if err != nil || form == nil {
+		return err
+	}
+
+	var req CORSOriginRequest
+	if bindErr := c.Bind(&req); bindErr != nil {
+		return h.ResponseBuilder.BuildErrorResponse(c, http.StatusBadRequest, "Invalid request body")
+	}
+
+	normalized, err := model.NormalizeCorsOrigin(req.Origin)
+	if err != nil {
+		return h.ResponseBuilder.BuildValidationErrorResponse(c, "origin", err.Error())
+	}
+
+	if normalized == model.WildcardOrigin && !h.wildcardOriginAllowed() {
+		return h.ResponseBuilder.BuildValidationErrorResponse(c, "origin", errWildcardOriginNotAllowed.Error())
+	}
+
+	_, added, err := form.AddCorsOrigin(normalized)
+	if err != nil {
+		return h.ResponseBuilder.BuildValidationErrorResponse(c, "origin", err.Error())
+	}
+
+	if !added {
+		return response.Success(c, h.corsOriginsResponse(form))
+	}
+
+	if updateErr := h.updateCORSOrigins(c, form); updateErr != nil {
+		return updateErr
+	}
+
+	h.Logger.Info("form CORS origin added", "form_id", form.ID, "origin", normalized)
+
+	return c.JSON(http.StatusCreated, response.APIResponse{
+		Success: true,
+		Message: "Origin added successfully",
+		Data:    h.corsOriginsResponse(form),
+	})
+}
+
+// DELETE /api/forms/:id/cors/origins - stop allowing an origin to embed a form (assertion auth)
+func (h *FormAPIHandler) handleRemoveCORSOrigin(c echo.Context) error {
+	form, err := h.getFormWithOwnershipOrError(c)
+	if err != nil || form == nil {
+		return err
+	}
+
+	var req CORSOriginRequest
+	if bindErr := c.Bind(&req); bindErr != nil {
+		return h.ResponseBuilder.BuildErrorResponse(c, http.StatusBadRequest, "Invalid request body")
+	}
+
+	normalized, err := form.RemoveCorsOrigin(req.Origin)
+	if errors.Is(err, model.ErrCorsOriginNotFound) {
+		return h.ResponseBuilder.BuildNotFoundResponse(c, "Origin")
+	}
+
+	if err != nil {
+		return h.ResponseBuilder.BuildValidationErrorResponse(c, "origin", err.Error())
+	}
+
+	if updateErr := h.updateCORSOrigins(c, form); updateErr != nil {
+		return updateErr
+	}
+
+	h.Logger.Info("form CORS origin removed", "form_id", form.ID, "origin", normalized)
+
+	return response.Success(c, h.corsOriginsResponse(form))
+}
+
+// updateCORSOrigins saves a form's changed origins and drops its cached CORS rules.
+// The form service also publishes form.updated, which invalidates the cache; doing
+// it here as well keeps the change visible even when event delivery is asynchronous.
+func (h *FormAPIHandler) updateCORSOrigins(c echo.Context, form *model.Form) error {
+	if err := h.FormService.UpdateForm(c.Request().Context(), form); err != nil {
+		h.Logger.Error("failed to update form CORS origins", "error", err, "form_id", form.ID)
+
+		return h.HandleError(c, err, "Failed to update allowed origins")
+	}
+
+	h.CORSCache.Invalidate(form.ID)
+
+	return nil
+}
+
+// POST /api/forms/:id/cors/check - explain the CORS headers the public form routes would
+// send for an origin and method, and why (assertion auth)
+func (h *FormAPIHandler) handleCORSCheck(c echo.Context) error {
+	form, err := h.getFormWithOwnershipOrError(c)
+	if err != nil || form == nil {
+		return err
+	}
+
+	var req CORSCheckRequest
+	if bindErr := c.Bind(&req); bindErr != nil {
+		return h.ResponseBuilder.BuildErrorResponse(c, http.StatusBadRequest, "Invalid request body")
+	}
+
+	origin := strings.TrimSpace(req.Origin)
+	if origin == "" {
+		return h.ResponseBuilder.BuildValidationErrorResponse(c, "origin", "origin is required")
+	}
+
+	method := strings.ToUpper(strings.TrimSpace(req.Method))
+	if method == "" {
+		method = http.MethodGet
+	}
+
+	origins, methods, headers := form.GetCorsConfig()
+	rules := formCORSRules{origins: origins, methods: methods, headers: headers}
+	corsConfig := h.Config.Security.CORS
+
+	actual := evaluateFormCORS(origin, method, rules, corsConfig)
+	preflight := evaluateFormCORS(origin, http.MethodOptions, rules, corsConfig)
+
+	methodAllowed := slices.ContainsFunc(actual.AllowedMethods, func(m string) bool {
+		return strings.EqualFold(m, method)
+	})
+	rejectedHeaders := corsRejectedHeaders(req.Headers, actual.AllowedHeaders)
+	preflightRequired := !slices.Contains(corsSafelistedMethods, method) || corsNeedsPreflight(req.Headers)
+
+	return response.Success(c, map[string]any{
+		"form_id":            form.ID,
+		"origin":             origin,
+		"method":             method,
+		"allowed":            actual.OriginAllowed && methodAllowed && len(rejectedHeaders) == 0,
+		"origin_allowed":     actual.OriginAllowed,
+		"matched_origin":     actual.MatchedOrigin,
+		"method_allowed":     methodAllowed,
+		"methods_source":     actual.MethodsSource,
+		"rejected_headers":   rejectedHeaders,
+		"headers_source":     actual.HeadersSource,
+		"preflight_required": preflightRequired,
+		"preflight":          corsCheckResponse(preflight),
+		"response":           corsCheckResponse(actual),
+		"reasons":            corsCheckReasons(form, actual, methodAllowed, rejectedHeaders, corsConfig.MaxAge),
+	})
+}
+
+// corsCheckResponse is the status and headers a route would answer with
+func corsCheckResponse(decision FormCORSDecision) map[string]any {
+	status := http.StatusOK
+
+	switch {
+	case !decision.OriginAllowed:
+		status = http.StatusForbidden
+	case decision.Preflight:
+		status = http.StatusNoContent
+	}
+
+	return map[string]any{
+		"status":  status,
+		"headers": decision.Headers,
+	}
+}
+
+// corsRejectedHeaders returns the requested headers that are neither safelisted nor allowed
+func corsRejectedHeaders(requested, allowed []string) []string {
+	rejected := []string{}
+
+	for _, header := range requested {
+		header = strings.TrimSpace(header)
+		if header == "" || slices.Contains(corsSafelistedHeaders, strings.ToLower(header)) {
+			continue
+		}
+
+		if !slices.ContainsFunc(allowed, func(a string) bool { return strings.EqualFold(a, header) }) {
+			rejected = append(rejected, header)
+		}
+	}
+
+	return rejected
+}
+
+// corsNeedsPreflight reports whether any requested header makes the browser send a preflight
+func corsNeedsPreflight(requested []string) bool {
+	for _, header := range requested {
+		header = strings.ToLower(strings.TrimSpace(header))
+		if header != "" && !slices.Contains(corsSafelistedHeaders, header) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// corsCheckReasons explains a CORS decision in plain sentences
+func corsCheckReasons(
+	form *model.Form,
+	decision FormCORSDecision,
+	methodAllowed bool,
+	rejectedHeaders []string,
+	maxAge int,
+) []string {
+	var reasons []string
+
+	switch {
+	case len(form.GetCorsOrigins()) == 0:
+		reasons = append(reasons, "the form has no allowed origins, so every cross-origin request is answered 403")
+	case !decision.OriginAllowed:
+		reasons = append(reasons, fmt.Sprintf(
+			"origin %s is not in the form's allowed origins %v and is answered 403; "+
+				"origins must match exactly, including scheme and port",
+			decision.Origin, form.GetCorsOrigins()))
+	case decision.MatchedOrigin == model.WildcardOrigin:
+		reasons = append(reasons, "origin is allowed by the form's wildcard (*) entry")
+	default:
+		reasons = append(reasons, fmt.Sprintf("origin matches the form's allowed origin %s", decision.MatchedOrigin))
+	}
+
+	if !methodAllowed {
+		reasons = append(reasons, fmt.Sprintf("method %s is not in the allowed methods %v (from %s); the browser will block it",
+			decision.Method, decision.AllowedMethods, decision.MethodsSource))
+	}
+
+	if len(rejectedHeaders) > 0 {
+		reasons = append(reasons, fmt.Sprintf("headers %v are not in the allowed headers %v (from %s); the browser will block the request",
+			rejectedHeaders, decision.AllowedHeaders, decision.HeadersSource))
+	}
+
+	switch {
+	case maxAge <= 0:
+		reasons = append(reasons, "no Access-Control-Max-Age is sent (security.cors.max_age), so browsers preflight every request")
+	case maxAge > chromiumMaxPreflightAge:
+		reasons = append(reasons, fmt.Sprintf(
+			"preflight responses may be cached for %d seconds (security.cors.max_age); Chromium caps this at %d",
+			maxAge, chromiumMaxPreflightAge))
+	default:
+		reasons = append(reasons, fmt.Sprintf(
+			"preflight responses may be cached for %d seconds (security.cors.max_age)", maxAge))
+	}
+
+	return reasons
+}
@@ -0,0 +1,149 @@
+package web
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/goformx/goforms/internal/domain/common/events"
+	formdomain "github.com/goformx/goforms/internal/domain/form"
+	formevents "github.com/goformx/goforms/internal/domain/form/events"
+	"github.com/goformx/goforms/internal/domain/form/model"
+)
+
+const (
+	// formCORSCacheTTL bounds how long cached rules may lag behind a change made
+	// on another instance; changes on this instance invalidate the entry immediately.
+	formCORSCacheTTL = time.Minute
+	// formCORSCacheMaxEntries caps the number of forms held in the cache
+	formCORSCacheMaxEntries = 10000
+)
+
+// formCORSRules are the CORS settings of one form
+type formCORSRules struct {
+	origins []string
+	methods []string
+	headers []string
+	expires time.Time
+}
+
+// FormCORSCache caches per-form CORS rules for the public form routes so that
+// cross-origin requests do not load the form on every call. Entries expire after
+// the TTL and are dropped as soon as a form.updated or form.deleted event is seen.
+type FormCORSCache struct {
+	formService formdomain.Service
+	eventBus    events.EventBus
+	ttl         time.Duration
+	mu          sync.RWMutex
+	entries     map[string]formCORSRules
+	startOnce   sync.Once
+}
+
+// NewFormCORSCache creates a CORS rule cache. A ttl of zero disables caching.
+func NewFormCORSCache(formService formdomain.Service, eventBus events.EventBus, ttl time.Duration) *FormCORSCache {
+	return &FormCORSCache{
+		formService: formService,
+		eventBus:    eventBus,
+		ttl:         ttl,
+		entries:     make(map[string]formCORSRules),
+	}
+}
+
+// Start subscribes the cache to form change events on the event bus
+func (c *FormCORSCache) Start(ctx context.Context) error {
+	var err error
+
+	c.startOnce.Do(func() {
+		if c.eventBus == nil {
+			return
+		}
+
+		if err = c.eventBus.Subscribe(ctx, string(formevents.FormUpdatedEventType), c.handleEvent); err != nil {
+			return
+		}
+
+		err = c.eventBus.Subscribe(ctx, string(formevents.FormDeletedEventType), c.handleEvent)
+	})
+
+	if err != nil {
+		return fmt.Errorf("subscribe to form events: %w", err)
+	}
+
+	return nil
+}
+
+// Get returns the CORS rules of a form. ok is false when the form cannot be loaded;
+// failures are not cached.
+func (c *FormCORSCache) Get(ctx context.Context, formID string) (formCORSRules, bool) {
+	if c.ttl > 0 {
+		c.mu.RLock()
+		rules, found := c.entries[formID]
+		c.mu.RUnlock()
+
+		if found && time.Now().Before(rules.expires) {
+			return rules, true
+		}
+	}
+
+	form, err := c.formService.GetForm(ctx, formID)
+	if err != nil || form == nil {
+		return formCORSRules{}, false
+	}
+
+	origins, methods, headers := form.GetCorsConfig()
+	rules := formCORSRules{
+		origins: origins,
+		methods: methods,
+		headers: headers,
+		expires: time.Now().Add(c.ttl),
+	}
+
+	if c.ttl > 0 {
+		c.store(formID, rules)
+	}
+
+	return rules, true
+}
+
+// Invalidate drops the cached rules of a form
+func (c *FormCORSCache) Invalidate(formID string) {
+	c.mu.Lock()
+	delete(c.entries, formID)
+	c.mu.Unlock()
+}
+
+// store caches rules, sweeping expired entries when the cache is full
+func (c *FormCORSCache) store(formID string, rules formCORSRules) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if len(c.entries) >= formCORSCacheMaxEntries {
+		now := time.Now()
+		for id, entry := range c.entries {
+			if now.After(entry.expires) {
+				delete(c.entries, id)
+			}
+		}
+
+		if len(c.entries) >= formCORSCacheMaxEntries {
+			clear(c.entries)
+		}
+	}
+
+	c.entries[formID] = rules
+}
+
+// handleEvent invalidates the form named by a form.updated or form.deleted event
+func (c *FormCORSCache) handleEvent(_ context.Context, event events.Event) error {
+	switch payload := event.Payload().(type) {
+	case *model.Form:
+		if payload != nil {
+			c.Invalidate(payload.ID)
+		}
+	case string:
+		c.Invalidate(payload)
+	}
+
+	return nil
+}
@@ -9,22 +9,31 @@ import (
 
 	"github.com/goformx/goforms/internal/application/constants"
 	formdomain "github.com/goformx/goforms/internal/domain/form"
+	"github.com/goformx/goforms/internal/domain/form/model"
 	"github.com/goformx/goforms/internal/infrastructure/config"
 )
 
 var defaultFormCORSMethods = []string{http.MethodGet, http.MethodPost, http.MethodOptions}
-var defaultFormCORSHeaders = []string{"Content-Type", "Accept", "Origin"}
+var defaultFormCORSHeaders = []string{"Content-Type", "Accept", "Origin", "Idempotency-Key", constants.HeaderFormAccessToken}
 
-// NewFormCORSMiddleware enforces per-form CORS rules for public endpoints.
+// NewFormCORSMiddleware enforces per-form CORS rules for public endpoints,
+// loading the form's rules on every request.
 func NewFormCORSMiddleware(formService formdomain.Service, corsConfig config.CORSConfig) echo.MiddlewareFunc {
+	return NewCachedFormCORSMiddleware(NewFormCORSCache(formService, nil, 0), corsConfig)
+}
+
+// NewCachedFormCORSMiddleware enforces per-form CORS rules for public endpoints,
+// reading the rules through cache.
+func NewCachedFormCORSMiddleware(cache *FormCORSCache, corsConfig config.CORSConfig) echo.MiddlewareFunc {
 	return func(next echo.HandlerFunc) echo.HandlerFunc {
 		return func(c echo.Context) error {
 			if !isPublicFormCORSRequest(c.Request().Method, c.Request().URL.Path) {
 				return next(c)
 			}
 
+			// Same-origin requests, such as the embed page submitting, need no CORS
 			origin := c.Request().Header.Get("Origin")
-			if origin == "" {
+			if origin == "" || !isCrossOrigin(c) {
 				return next(c)
 			}
 
@@ -33,22 +42,21 @@ func NewFormCORSMiddleware(formService formdomain.Service, corsConfig config.COR
 				return next(c)
 			}
 
-			form, err := formService.GetForm(c.Request().Context(), formID)
-			if err != nil || form == nil {
+			rules, ok := cache.Get(c.Request().Context(), formID)
+			if !ok {
 				return next(c)
 			}
 
-			allowedOrigins, allowedMethods, allowedHeaders := form.GetCorsConfig()
-			if !isOriginAllowed(origin, allowedOrigins) {
+			decision := evaluateFormCORS(origin, c.Request().Method, rules, corsConfig)
+			if !decision.OriginAllowed {
 				return c.NoContent(constants.StatusForbidden)
 			}
 
-			resolvedMethods := resolveCORSList(allowedMethods, corsConfig.AllowedMethods, defaultFormCORSMethods)
-			resolvedHeaders := resolveCORSList(allowedHeaders, corsConfig.AllowedHeaders, defaultFormCORSHeaders)
-
-			applyFormCORSHeaders(c, origin, resolvedMethods, resolvedHeaders, corsConfig)
+			for name, value := range decision.Headers {
+				c.Response().Header().Set(name, value)
+			}
 
-			if c.Request().Method == http.MethodOptions {
+			if decision.Preflight {
 				return c.NoContent(constants.StatusNoContent)
 			}
 
@@ -57,54 +65,97 @@ func NewFormCORSMiddleware(formService formdomain.Service, corsConfig config.COR
 	}
 }
 
-func resolveCORSList(list, fallback, defaultValues []string) []string {
+// Sources of a resolved CORS setting
+const (
+	corsSourceForm    = "form"
+	corsSourceConfig  = "config"
+	corsSourceDefault = "default"
+)
+
+// resolveCORSList returns the first non-empty list and where it came from:
+// the form, the security.cors configuration or the built-in defaults.
+func resolveCORSList(list, fallback, defaultValues []string) ([]string, string) {
 	if len(list) > 0 {
-		return list
+		return list, corsSourceForm
 	}
 	if len(fallback) > 0 {
-		return fallback
+		return fallback, corsSourceConfig
 	}
-	return defaultValues
+	return defaultValues, corsSourceDefault
+}
+
+// FormCORSDecision describes how the public form routes answer a cross-origin request
+type FormCORSDecision struct {
+	Origin        string `json:"origin"`
+	Method        string `json:"method"`
+	Preflight     bool   `json:"preflight"`
+	OriginAllowed bool   `json:"origin_allowed"`
+	// MatchedOrigin is the allowed origin entry that matched, "*" for the wildcard
+	MatchedOrigin  string            `json:"matched_origin,omitempty"`
+	AllowedMethods []string          `json:"allowed_methods"`
+	MethodsSource  string            `json:"methods_source"`
+	AllowedHeaders []string          `json:"allowed_headers"`
+	HeadersSource  string            `json:"headers_source"`
+	Headers        map[string]string `json:"headers"`
 }
 
-func applyFormCORSHeaders(
-	c echo.Context,
-	origin string,
-	allowedMethods []string,
-	allowedHeaders []string,
-	corsConfig config.CORSConfig,
-) {
-	headers := c.Response().Header()
-	headers.Set("Access-Control-Allow-Origin", origin)
-	headers.Set("Vary", "Origin")
-	headers.Set("Access-Control-Allow-Methods", strings.Join(allowedMethods, ", "))
-	headers.Set("Access-Control-Allow-Headers", strings.Join(allowedHeaders, ", "))
+// evaluateFormCORS decides the CORS response for origin and method against a form's rules.
+// For preflight requests method is OPTIONS; Vary then also covers the request method and
+// headers so shared caches keep preflight answers apart.
+func evaluateFormCORS(origin, method string, rules formCORSRules, corsConfig config.CORSConfig) FormCORSDecision {
+	decision := FormCORSDecision{
+		Origin:    origin,
+		Method:    method,
+		Preflight: method == http.MethodOptions,
+		Headers:   map[string]string{},
+	}
+
+	decision.AllowedMethods, decision.MethodsSource = resolveCORSList(
+		rules.methods, corsConfig.AllowedMethods, defaultFormCORSMethods)
+	decision.AllowedHeaders, decision.HeadersSource = resolveCORSList(
+		rules.headers, corsConfig.AllowedHeaders, defaultFormCORSHeaders)
+
+	decision.MatchedOrigin = matchOrigin(origin, rules.origins)
+	decision.OriginAllowed = decision.MatchedOrigin != ""
+
+	if !decision.OriginAllowed {
+		return decision
+	}
+
+	headers := decision.Headers
+	headers["Access-Control-Allow-Origin"] = origin
+	headers["Vary"] = "Origin"
+	headers["Access-Control-Allow-Methods"] = strings.Join(decision.AllowedMethods, ", ")
+	headers["Access-Control-Allow-Headers"] = strings.Join(decision.AllowedHeaders, ", ")
+
+	if decision.Preflight {
+		headers["Vary"] = "Origin, Access-Control-Request-Method, Access-Control-Request-Headers"
+	}
 
 	if corsConfig.AllowCredentials {
-		headers.Set("Access-Control-Allow-Credentials", "true")
+		headers["Access-Control-Allow-Credentials"] = "true"
 	}
 
 	if len(corsConfig.ExposedHeaders) > 0 {
-		headers.Set("Access-Control-Expose-Headers", strings.Join(corsConfig.ExposedHeaders, ", "))
+		headers["Access-Control-Expose-Headers"] = strings.Join(corsConfig.ExposedHeaders, ", ")
 	}
 
 	if corsConfig.MaxAge > 0 {
-		headers.Set("Access-Control-Max-Age", strconv.Itoa(corsConfig.MaxAge))
+		headers["Access-Control-Max-Age"] = strconv.Itoa(corsConfig.MaxAge)
 	}
-}
 
-func isOriginAllowed(origin string, allowedOrigins []string) bool {
-	if len(allowedOrigins) == 0 {
-		return false
-	}
+	return decision
+}
 
+// matchOrigin returns the allowed origin entry matching origin, or "" when none does
+func matchOrigin(origin string, allowedOrigins []string) string {
 	for _, allowed := range allowedOrigins {
-		if allowed == "*" || allowed == origin {
-			return true
+		if allowed == model.WildcardOrigin || allowed == origin {
+			return allowed
 		}
 	}
 
-	return false
+	return ""
 }
 
 func isPublicFormCORSRequest(method, requestPath string) bool {
@@ -117,9 +168,9 @@ func isPublicFormCORSRequest(method, requestPath string) bool {
 	switch {
 	case strings.HasSuffix(requestPath, "/schema"):
 		return method == http.MethodGet || method == http.MethodOptions
-	case strings.HasSuffix(requestPath, "/validation"):
+	case strings.HasSuffix(requestPath, "/validation"), strings.HasSuffix(requestPath, "/fields"):
 		return method == http.MethodGet || method == http.MethodOptions
-	case strings.HasSuffix(requestPath, "/submit"):
+	case strings.HasSuffix(requestPath, "/submit"), strings.HasSuffix(requestPath, "/unlock"):
 		return method == http.MethodPost || method == http.MethodOptions
 	case strings.HasSuffix(requestPath, "/embed"):
 		return method == http.MethodGet || method == http.MethodOptions
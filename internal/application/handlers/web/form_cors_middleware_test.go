@@ -4,16 +4,21 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 
 	"github.com/labstack/echo/v4"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"go.uber.org/mock/gomock"
 
 	"github.com/goformx/goforms/internal/application/constants"
 	"github.com/goformx/goforms/internal/application/handlers/web"
+	formevents "github.com/goformx/goforms/internal/domain/form/events"
 	"github.com/goformx/goforms/internal/domain/form/model"
 	"github.com/goformx/goforms/internal/infrastructure/config"
+	"github.com/goformx/goforms/internal/infrastructure/event"
 	mockform "github.com/goformx/goforms/test/mocks/form"
+	mocklogging "github.com/goformx/goforms/test/mocks/logging"
 )
 
 func TestFormCORSMiddleware_AllowsConfiguredOrigin(t *testing.T) {
@@ -111,6 +116,7 @@ func TestFormCORSMiddleware_AllowsPreflightForSubmit(t *testing.T) {
 	assert.Equal(t, http.StatusNoContent, rec.Code)
 	assert.Equal(t, "https://allowed.example", rec.Header().Get("Access-Control-Allow-Origin"))
 	assert.Contains(t, rec.Header().Get("Access-Control-Allow-Methods"), http.MethodPost)
+	assert.Contains(t, rec.Header().Get("Vary"), "Access-Control-Request-Method")
 }
 
 func TestFormCORSMiddleware_SkipsWhenOriginMissing(t *testing.T) {
@@ -163,3 +169,73 @@ func TestFormCORSMiddleware_AllowsPublicFormsPath(t *testing.T) {
 	assert.Equal(t, "https://embed.example", rec.Header().Get("Access-Control-Allow-Origin"))
 	assert.Equal(t, "ok", rec.Body.String())
 }
+
+func TestFormCORSMiddleware_SameOriginPasses(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	formService := mockform.NewMockService(ctrl)
+
+	e := echo.New()
+	formsPublic := e.Group(constants.PathFormsPublic)
+	formsPublic.Use(web.NewFormCORSMiddleware(formService, config.CORSConfig{}))
+	formsPublic.POST("/:id/submit", func(c echo.Context) error {
+		return c.String(http.StatusOK, "ok")
+	})
+
+	// The embed page posts from the server's own origin, which no form lists
+	req := httptest.NewRequest(http.MethodPost, "/forms/form-456/submit", http.NoBody)
+	req.Host = "api.goformx.example"
+	req.Header.Set("Origin", "https://api.goformx.example")
+	rec := httptest.NewRecorder()
+
+	e.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Empty(t, rec.Header().Get("Access-Control-Allow-Origin"))
+}
+
+func TestFormCORSCache_InvalidatedByFormUpdate(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	formService := mockform.NewMockService(ctrl)
+	mockLogger := mocklogging.NewMockLogger(ctrl)
+
+	form := &model.Form{
+		ID:          "form-123",
+		CorsOrigins: model.JSON{"origins": []any{"https://allowed.example"}},
+	}
+	updated := &model.Form{
+		ID:          "form-123",
+		CorsOrigins: model.JSON{"origins": []any{"https://other.example"}},
+	}
+
+	gomock.InOrder(
+		formService.EXPECT().GetForm(gomock.Any(), "form-123").Return(form, nil),
+		formService.EXPECT().GetForm(gomock.Any(), "form-123").Return(updated, nil),
+	)
+
+	bus := event.NewMemoryEventBus(mockLogger)
+	cache := web.NewFormCORSCache(formService, bus, time.Minute)
+	require.NoError(t, cache.Start(t.Context()))
+
+	e := echo.New()
+	formsPublic := e.Group(constants.PathFormsPublic)
+	formsPublic.Use(web.NewCachedFormCORSMiddleware(cache, config.CORSConfig{}))
+	formsPublic.GET("/:id/schema", func(c echo.Context) error {
+		return c.String(http.StatusOK, "ok")
+	})
+
+	request := func() int {
+		req := httptest.NewRequest(http.MethodGet, "/forms/form-123/schema", http.NoBody)
+		req.Header.Set("Origin", "https://allowed.example")
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, req)
+
+		return rec.Code
+	}
+
+	assert.Equal(t, http.StatusOK, request())
+	assert.Equal(t, http.StatusOK, request(), "second request is served from the cache")
+
+	require.NoError(t, bus.Publish(t.Context(), formevents.NewFormUpdatedEvent(updated)))
+
+	assert.Equal(t, http.StatusForbidden, request())
+}
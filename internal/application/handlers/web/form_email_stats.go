@@ -0,0 +1,24 @@
+package web
+
+import (
+	"github.com/labstack/echo/v4"
+
+	"github.com/goformx/goforms/internal/application/response"
+)
+
+// GET /api/forms/:id/email/stats - sent, bounce and complaint counts of a form's emails (assertion auth)
+func (h *FormAPIHandler) handleEmailStats(c echo.Context) error {
+	form, err := h.getFormWithOwnershipOrError(c)
+	if err != nil || form == nil {
+		return err
+	}
+
+	stats, err := h.EmailDeliveries.Stats(c.Request().Context(), form.ID)
+	if err != nil {
+		h.Logger.Error("failed to get email delivery stats", "form_id", form.ID, "error", err)
+
+		return h.HandleError(c, err, "Failed to get email delivery stats")
+	}
+
+	return response.Success(c, stats)
+}
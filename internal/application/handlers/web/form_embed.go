@@ -0,0 +1,402 @@
+package web
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+
+	"github.com/goformx/goforms/internal/application/accessibility"
+	"github.com/goformx/goforms/internal/application/constants"
+	"github.com/goformx/goforms/internal/application/render"
+	"github.com/goformx/goforms/internal/application/theme"
+	"github.com/goformx/goforms/internal/domain/form/model"
+)
+
+const (
+	// embedAuditParam turns on the accessibility audit of the embed page, e.g. ?a11y=audit
+	embedAuditParam = "a11y"
+	embedAuditValue = "audit"
+)
+
+// GET /forms/:id/embed returns a minimal HTML page for embedding the form via iframe.
+// Loads Form.io from CDN and renders the form, posting to /forms/:id/submit.
+// The page is cached per form version and locale, except when it carries an
+// access token or data[<key>] answers for piped labels. After a submission the
+// form is replaced by its confirmation message, when it has one. With ?a11y=audit the page also checks the rendered form for
+// accessibility problems and posts them, with the schema audit, to the parent
+// window as a goformx:a11y message.
+func (h *FormAPIHandler) handleFormEmbed(c echo.Context) error {
+	return h.serveEmbedPage(c, false)
+}
+
+// GET /embed/frame/:id serves the embed page for an iframe on one of the form's
+// CORS origins. Only those origins may frame it, and the page talks to its
+// parent through the frame protocol: goformx:ready, goformx:resize and
+// goformx:submitted messages, sent only to the parent's origin, and goformx:ping
+// from the parent, answered with the page's height.
+func (h *FormAPIHandler) handleFormFrame(c echo.Context) error {
+	return h.serveEmbedPage(c, true)
+}
+
+// serveEmbedPage serves the embed page, set up for the frame protocol when framed
+func (h *FormAPIHandler) serveEmbedPage(c echo.Context, framed bool) error {
+	form, err := h.getFormOrError(c)
+	if err != nil || form == nil {
+		return err
+	}
+
+	if !form.AllowsEmbedMethod(model.EmbedMethodIframe) {
+		return h.HandleForbidden(c, "This form cannot be embedded in a frame")
+	}
+
+	if form.Schema == nil {
+		h.Logger.Warn("form schema is nil for embed", "form_id", form.ID)
+
+		return h.wrapError("handle embed error",
+			h.ErrorHandler.HandleSchemaError(c, errors.New("form schema is required")))
+	}
+
+	formID := form.ID
+	schemaURL := "/forms/" + formID + "/schema"
+	submitURL := "/forms/" + formID + "/submit"
+	pages := h.Pages
+
+	// Pass an unlocked form's access token on to the schema and submit requests
+	if token := c.QueryParam(constants.QueryParamFormAccessToken); token != "" {
+		query := "?" + constants.QueryParamFormAccessToken + "=" + url.QueryEscape(token)
+		schemaURL += query
+		submitURL += query
+		pages = nil
+	}
+
+	// Pass answers for piped labels on to the schema request
+	if prefill := prefillQuery(c); prefill != "" {
+		if strings.Contains(schemaURL, "?") {
+			schemaURL += "&" + prefill
+		} else {
+			schemaURL += "?" + prefill
+		}
+
+		pages = nil
+	}
+
+	key := render.Key{
+		Page: "embed", FormID: formID, Version: form.Version, Locale: render.Locale(c), Theme: theme.FromRequest(c),
+	}
+
+	var frame *embedFrame
+	if framed {
+		key.Page = "frame"
+		frame = &embedFrame{FormID: formID, Origins: nonNilStrings(form.GetCorsOrigins())}
+		setFrameHeaders(c, frame.Origins)
+	}
+
+	var audit *accessibility.Report
+	if c.QueryParam(embedAuditParam) == embedAuditValue {
+		key.Page += "_audit"
+		audit = accessibility.Audit(form.Schema)
+	}
+
+	return pages.Serve(c, key, func(page *render.Stream) error {
+		return writeEmbedPage(page, form.Title, key, schemaURL, submitURL, audit, frame)
+	})
+}
+
+// embedFrame is what a framed embed page needs to talk to its parent
+type embedFrame struct {
+	FormID string `json:"formId"`
+	// Origins are the form's CORS origins, the only parents the page talks to
+	Origins []string `json:"origins"`
+}
+
+// setFrameHeaders lets the form's CORS origins, and only them, frame the page
+func setFrameHeaders(c echo.Context, origins []string) {
+	ancestors := "'none'"
+	if len(origins) > 0 {
+		ancestors = strings.Join(origins, " ")
+	}
+
+	header := c.Response().Header()
+	header.Del(echo.HeaderXFrameOptions)
+
+	policy := "frame-ancestors " + ancestors
+	if csp := header.Get(echo.HeaderContentSecurityPolicy); csp != "" {
+		policy = csp + "; " + policy
+	}
+
+	header.Set(echo.HeaderContentSecurityPolicy, policy)
+}
+
+// writeEmbedPage writes the embed page, flushing after the head so the browser
+// starts loading Form.io while the body is sent. Validation errors are listed
+// in a summary that takes focus and links to each field; audit is the schema
+// audit of an audit page, nil otherwise. A framed page, with frame set, sends
+// its messages only to an allowed parent; others send them to any parent.
+func writeEmbedPage(
+	page *render.Stream,
+	title string,
+	key render.Key,
+	schemaURL, submitURL string,
+	audit *accessibility.Report,
+	frame *embedFrame,
+) error {
+	lang := ""
+	if key.Locale != "" {
+		lang = ` lang="` + escapeHTML(key.Locale) + `"`
+	}
+
+	head := `<!DOCTYPE html>
+<html` + lang + theme.Attr(key.Theme) + `>
+<head>
+  <meta charset="utf-8">
+  <meta name="viewport" content="width=device-width, initial-scale=1">
+  <title>` + escapeHTML(title) + `</title>
+  <link rel="stylesheet" href="https://cdn.form.io/formiojs/formio.full.min.css">
+  <link rel="preload" href="https://cdn.form.io/formiojs/formio.full.min.js" as="script">
+  <style>
+` + theme.Stylesheet + `    body { background: var(--gf-bg); color: var(--gf-text); }
+    .gf-sr-only { position: absolute; width: 1px; height: 1px; padding: 0; margin: -1px; overflow: hidden;
+      clip: rect(0, 0, 0, 0); white-space: nowrap; border: 0; }
+    .gf-error-summary { margin: 0 0 1rem; padding: 1rem; border: 2px solid var(--gf-danger); border-radius: 0.5rem; }
+    .gf-error-summary h2 { margin: 0 0 0.5rem; font-size: 1.125rem; }
+    .gf-error-summary ul { margin: 0; padding-left: 1.25rem; }
+    .gf-error-summary a, .gf-load-error { color: var(--gf-danger); }
+    .gf-confirmation { margin: 0 0 1rem; padding: 1rem; border: 2px solid var(--gf-accent); border-radius: 0.5rem; }
+    :focus-visible { outline: 3px solid var(--gf-accent); outline-offset: 2px; }
+    [data-gf-a11y] { outline: 3px dashed var(--gf-danger); outline-offset: 2px; }
+  </style>
+</head>
+`
+	if _, err := page.WriteString(head); err != nil {
+		return fmt.Errorf("write embed head: %w", err)
+	}
+
+	if err := page.Flush(); err != nil {
+		return err
+	}
+
+	auditJSON := []byte("null")
+	if audit != nil {
+		var err error
+		// json.Marshal escapes <, > and &, so the report cannot end the script
+		if auditJSON, err = json.Marshal(audit); err != nil {
+			return fmt.Errorf("encode accessibility audit: %w", err)
+		}
+	}
+
+	frameScript := ""
+	if frame != nil {
+		// json.Marshal escapes <, > and &, so the origins cannot end the script
+		frameJSON, err := json.Marshal(frame)
+		if err != nil {
+			return fmt.Errorf("encode embed frame: %w", err)
+		}
+
+		frameScript = strings.Replace(embedFrameScript, "{{config}}", string(frameJSON), 1)
+	}
+
+	body := `<body>
+  <main>
+    <h1 id="gf-title" class="gf-sr-only">` + escapeHTML(title) + `</h1>
+    <div id="gf-error-summary" class="gf-error-summary" role="alert" aria-labelledby="gf-error-title" tabindex="-1" hidden>
+      <h2 id="gf-error-title">There is a problem</h2>
+      <ul id="gf-error-list"></ul>
+    </div>
+    <div id="gf-status" class="gf-sr-only" role="status" aria-live="polite"></div>
+    <div id="gf-confirmation" class="gf-confirmation" tabindex="-1" hidden></div>
+    <div id="formio" role="form" aria-labelledby="gf-title" aria-busy="true"></div>
+  </main>
+  <script src="https://cdn.form.io/formiojs/formio.full.min.js"></script>
+` + frameScript + `  <script>
+    (function() {
+      var post = window.goformxFrame ? window.goformxFrame.post : function(message) {
+        window.parent.postMessage(message, '*');
+      };
+      var schemaUrl = '` + schemaURL + `';
+      var submitUrl = '` + submitURL + `';
+      var schemaAudit = ` + string(auditJSON) + `;
+      var container = document.getElementById('formio');
+      var summary = document.getElementById('gf-error-summary');
+      var list = document.getElementById('gf-error-list');
+      var status = document.getElementById('gf-status');
+      var confirmation = document.getElementById('gf-confirmation');
+
+      function fieldOf(key) {
+        var name = 'data[' + key + ']';
+        return container.querySelector('[name="' + CSS.escape(name) + '"], [name^="' + CSS.escape(name + '[') + '"]');
+      }
+
+      function showErrors(errors) {
+        list.innerHTML = '';
+        (errors || []).forEach(function(error) {
+          var key = error.component && error.component.key;
+          var link = document.createElement('a');
+          link.href = '#';
+          link.textContent = error.message;
+          link.addEventListener('click', function(event) {
+            event.preventDefault();
+            var field = key && fieldOf(key);
+            if (field) field.focus();
+          });
+          var item = document.createElement('li');
+          item.appendChild(link);
+          list.appendChild(item);
+        });
+        summary.hidden = list.children.length === 0;
+        if (!summary.hidden) summary.focus();
+      }
+
+      function keyOf(el) {
+        var match = /^data\[([^\]]+)\]/.exec(el.getAttribute('name') || '');
+        return match ? match[1] : '';
+      }
+
+      function hasName(el) {
+        if (el.labels && el.labels.length) return true;
+        if ((el.getAttribute('aria-label') || '').trim() || (el.getAttribute('title') || '').trim()) return true;
+        var labelledBy = (el.getAttribute('aria-labelledby') || '').split(/\s+/).filter(function(id) {
+          var label = id && document.getElementById(id);
+          return label && label.textContent.trim();
+        });
+        if (labelledBy.length) return true;
+        return el.tagName === 'BUTTON' && el.textContent.trim() !== '';
+      }
+
+      function auditPage() {
+        var violations = [];
+        function report(el, rule, wcag, message) {
+          el.setAttribute('data-gf-a11y', rule);
+          violations.push({ rule: rule, severity: 'error', wcag: wcag, key: keyOf(el), message: message });
+        }
+        var ids = {};
+        container.querySelectorAll('[id]').forEach(function(el) {
+          if (ids[el.id]) report(el, 'duplicate-id', '4.1.1', 'Element id "' + el.id + '" is used more than once');
+          ids[el.id] = true;
+        });
+        container.querySelectorAll('input:not([type=hidden]), select, textarea, button').forEach(function(el) {
+          if (hasName(el)) return;
+          if (el.tagName === 'BUTTON') report(el, 'button-label-missing', '4.1.2', 'Button has no accessible name');
+          else report(el, 'label-missing', '1.3.1', 'Field has no label or accessible name');
+        });
+        container.querySelectorAll('img:not([alt])').forEach(function(el) {
+          report(el, 'image-alt-missing', '1.1.1', 'Image has no alt attribute');
+        });
+        container.querySelectorAll('[tabindex]').forEach(function(el) {
+          if (parseInt(el.getAttribute('tabindex'), 10) > 0) {
+            report(el, 'positive-tabindex', '2.4.3', 'Positive tabindex changes the keyboard order');
+          }
+        });
+        post({ type: 'goformx:a11y', schema: schemaAudit, page: violations });
+      }
+
+      Formio.createForm(container, schemaUrl, {
+        submit: submitUrl,
+        noSubmit: false,
+        noAlerts: true
+      }).then(function(form) {
+        container.setAttribute('aria-busy', 'false');
+        form.on('error', showErrors);
+        form.on('submitError', function() {
+          showErrors([{ message: 'The form could not be submitted. Please try again.' }]);
+        });
+        form.on('submit', function(submission) {
+          showErrors([]);
+          // The confirmation message is the owner's markup with the answers escaped by the server
+          var message = submission && submission.data && submission.data.confirmation_message;
+          if (message) {
+            confirmation.innerHTML = message;
+            confirmation.hidden = false;
+            container.hidden = true;
+            confirmation.focus();
+          }
+          status.textContent = message ? confirmation.textContent : 'Thank you, your response has been submitted.';
+          if (submission && submission.submission) {
+            post({ type: 'goformx:submitted', submission: submission.submission });
+          }
+        });
+        if (schemaAudit) form.ready.then(auditPage);
+        if (window.goformxFrame) form.ready.then(window.goformxFrame.ready);
+      }).catch(function(err) {
+        container.setAttribute('aria-busy', 'false');
+        container.innerHTML = '<p class="gf-load-error" role="alert">Failed to load form. Please try again.</p>';
+        console.error('Form.io load error:', err);
+      });
+    })();
+  </script>
+</body>
+</html>`
+
+	if _, err := page.WriteString(body); err != nil {
+		return fmt.Errorf("write embed body: %w", err)
+	}
+
+	return nil
+}
+
+// embedFrameScript is the parent side of the frame protocol; {{config}} is the
+// JSON of an embedFrame. The parent's origin is taken from the browser, or from
+// its first goformx:ping, and must be one of the form's origins; until it is
+// known no message is sent, except to any parent when the form allows "*".
+const embedFrameScript = `  <script>
+    window.goformxFrame = (function() {
+      var config = {{config}};
+      var anyOrigin = config.origins.indexOf('*') !== -1;
+      var parentOrigin = null;
+      var lastHeight = 0;
+
+      function allowed(origin) {
+        return anyOrigin || config.origins.indexOf(origin) !== -1;
+      }
+
+      function originOf(url) {
+        try { return new URL(url).origin; } catch (e) { return null; }
+      }
+
+      var candidate = (window.location.ancestorOrigins && window.location.ancestorOrigins[0]) || originOf(document.referrer);
+      if (candidate && allowed(candidate)) parentOrigin = candidate;
+
+      function post(message) {
+        var target = parentOrigin || (anyOrigin ? '*' : null);
+        if (!target || window.parent === window) return;
+        message.formId = config.formId;
+        window.parent.postMessage(message, target);
+      }
+
+      function height() {
+        return Math.ceil(document.documentElement.scrollHeight);
+      }
+
+      function resize(force) {
+        var current = height();
+        if (!force && current === lastHeight) return;
+        lastHeight = current;
+        post({ type: 'goformx:resize', height: current });
+      }
+
+      window.addEventListener('message', function(event) {
+        if (event.source !== window.parent || !event.data || event.data.type !== 'goformx:ping') return;
+        if (parentOrigin ? event.origin !== parentOrigin : !allowed(event.origin)) return;
+        parentOrigin = event.origin;
+        resize(true);
+      });
+
+      if (window.ResizeObserver) {
+        new ResizeObserver(function() { resize(false); }).observe(document.body);
+      } else {
+        window.addEventListener('resize', function() { resize(false); });
+      }
+
+      return {
+        post: post,
+        ready: function() {
+          lastHeight = height();
+          post({ type: 'goformx:ready', height: lastHeight });
+        }
+      };
+    })();
+  </script>
+`
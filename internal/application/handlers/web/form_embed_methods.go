@@ -0,0 +1,126 @@
+package web
+
+import (
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+
+	"github.com/goformx/goforms/internal/application/response"
+	formdomain "github.com/goformx/goforms/internal/domain/form"
+	"github.com/goformx/goforms/internal/domain/form/model"
+)
+
+// EmbedMethodsRequest replaces the embed methods a form allows
+type EmbedMethodsRequest struct {
+	Methods []model.EmbedMethod `json:"methods"`
+}
+
+// embedMethodsResponse converts a form's allowed embed methods to their API representation
+func embedMethodsResponse(form *model.Form) map[string]any {
+	return map[string]any{
+		"form_id":   form.ID,
+		"methods":   form.AllowedEmbedMethods(),
+		"available": model.EmbedMethods,
+	}
+}
+
+// GET /api/forms/:id/embed/methods - the embed methods a form allows (assertion auth)
+func (h *FormAPIHandler) handleGetEmbedMethods(c echo.Context) error {
+	form, err := h.getFormWithOwnershipOrError(c)
+	if err != nil || form == nil {
+		return err
+	}
+
+	return response.Success(c, embedMethodsResponse(form))
+}
+
+// PUT /api/forms/:id/embed/methods - choose how a form may be embedded (assertion auth).
+// Without iframe the embed page is refused; without script other sites cannot
+// call the public schema and submit endpoints.
+func (h *FormAPIHandler) handleUpdateEmbedMethods(c echo.Context) error {
+	form, err := h.getFormWithOwnershipOrError(c)
+	if err != nil || form == nil {
+		return err
+	}
+
+	var req EmbedMethodsRequest
+	if bindErr := c.Bind(&req); bindErr != nil {
+		return h.ResponseBuilder.BuildErrorResponse(c, http.StatusBadRequest, "Invalid request body")
+	}
+
+	if setErr := form.SetAllowedEmbedMethods(req.Methods); setErr != nil {
+		return h.ResponseBuilder.BuildValidationErrorResponse(c, "methods", setErr.Error())
+	}
+
+	if updateErr := h.FormService.UpdateForm(c.Request().Context(), form); updateErr != nil {
+		return h.HandleError(c, updateErr, "Failed to save embed methods")
+	}
+
+	h.Logger.Info("embed methods updated", "form_id", form.ID)
+
+	return response.Success(c, embedMethodsResponse(form))
+}
+
+// GET /api/forms/:id/embed/snippets - ready-made code embedding the form with
+// each method it allows: iframe, Hugo shortcode, Jekyll include, script tag and
+// React component (assertion auth). Script snippets only work from the form's
+// CORS origins, returned alongside.
+func (h *FormAPIHandler) handleEmbedSnippets(c echo.Context) error {
+	form, err := h.getFormWithOwnershipOrError(c)
+	if err != nil || form == nil {
+		return err
+	}
+
+	snippets := formdomain.EmbedSnippets(h.publicBaseURL(c), form)
+	if snippets == nil {
+		snippets = []formdomain.EmbedSnippet{}
+	}
+
+	return response.Success(c, map[string]any{
+		"form_id":  form.ID,
+		"methods":  form.AllowedEmbedMethods(),
+		"origins":  nonNilStrings(form.GetCorsOrigins()),
+		"snippets": snippets,
+	})
+}
+
+// publicBaseURL is where other sites reach the public form endpoints: app.url
+// or, when it is not set, the host the request was sent to
+func (h *FormAPIHandler) publicBaseURL(c echo.Context) string {
+	if base := strings.TrimSuffix(h.Config.App.GetServerURL(), "/"); base != "" {
+		return base
+	}
+
+	return c.Scheme() + "://" + c.Request().Host
+}
+
+// checkScriptEmbed refuses calls to the public form endpoints from pages on
+// other origins when the form does not allow the script embed method. The embed
+// page calls them from this server's own origin, so framed forms keep working.
+func (h *FormAPIHandler) checkScriptEmbed(c echo.Context, form *model.Form) (bool, error) {
+	if !isCrossOrigin(c) || form.AllowsEmbedMethod(model.EmbedMethodScript) {
+		return false, nil
+	}
+
+	return true, c.JSON(http.StatusForbidden, response.APIResponse{
+		Success: false,
+		Message: "This form cannot be embedded with a script.",
+		Data:    map[string]any{"code": "embed_method_not_allowed"},
+	})
+}
+
+// isCrossOrigin reports whether the request was sent by a page on another
+// origin. Requests without an Origin header, such as server-to-server calls,
+// are not.
+func isCrossOrigin(c echo.Context) bool {
+	origin := c.Request().Header.Get(echo.HeaderOrigin)
+	if origin == "" {
+		return false
+	}
+
+	parsed, err := url.Parse(origin)
+
+	return err != nil || !strings.EqualFold(parsed.Host, c.Request().Host)
+}
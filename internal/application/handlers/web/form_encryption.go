@@ -0,0 +1,78 @@
+package web
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+
+	"github.com/goformx/goforms/internal/application/response"
+	"github.com/goformx/goforms/internal/domain/audit"
+)
+
+// EncryptionRequest turns encryption at rest on or off for the user's submissions
+type EncryptionRequest struct {
+	Enabled *bool `json:"enabled"`
+}
+
+// requireEncryption answers 409 while submission encryption is disabled
+func (h *FormAPIHandler) requireEncryption(next echo.HandlerFunc) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		if h.Encryption == nil {
+			return response.ErrorResponse(c, http.StatusConflict, "Submission encryption is disabled")
+		}
+
+		return next(c)
+	}
+}
+
+// GET /api/forms/encryption - whether the authenticated user's submissions are encrypted at rest (assertion auth)
+func (h *FormAPIHandler) handleGetEncryption(c echo.Context) error {
+	userID, ok := c.Get("user_id").(string)
+	if !ok {
+		return h.HandleForbidden(c, "User not authenticated")
+	}
+
+	setting, err := h.Encryption.Setting(c.Request().Context(), userID)
+	if err != nil {
+		h.Logger.Error("failed to get encryption setting", "error", err, "user_id", h.Logger.SanitizeField("user_id", userID))
+
+		return h.HandleError(c, err, "Failed to get encryption setting")
+	}
+
+	return response.Success(c, map[string]any{"encryption": setting})
+}
+
+// PUT /api/forms/encryption - turns encryption at rest on or off for the user's
+// submissions. New submissions follow at once; stored ones on the next key
+// rotation run (assertion auth).
+func (h *FormAPIHandler) handleUpdateEncryption(c echo.Context) error {
+	userID, ok := c.Get("user_id").(string)
+	if !ok {
+		return h.HandleForbidden(c, "User not authenticated")
+	}
+
+	var req EncryptionRequest
+	if err := c.Bind(&req); err != nil || req.Enabled == nil {
+		return response.ErrorResponse(c, http.StatusBadRequest, "enabled is required")
+	}
+
+	setting, err := h.Encryption.SetEnabled(c.Request().Context(), userID, *req.Enabled)
+	if err != nil {
+		h.Logger.Error("failed to update encryption setting", "error", err, "user_id", h.Logger.SanitizeField("user_id", userID))
+
+		return h.HandleError(c, err, "Failed to update encryption setting")
+	}
+
+	if recordErr := h.Audit.Record(c.Request().Context(), &audit.Entry{
+		ActorID:    userID,
+		Action:     audit.ActionEncryptionUpdated,
+		TargetType: audit.TargetEncryption,
+		TargetID:   userID,
+		Details:    map[string]any{"enabled": setting.Enabled},
+		IPAddress:  c.RealIP(),
+	}); recordErr != nil {
+		h.Logger.Error("failed to record encryption audit entry", "error", recordErr)
+	}
+
+	return response.Success(c, map[string]any{"encryption": setting})
+}
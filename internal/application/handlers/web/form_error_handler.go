@@ -30,6 +30,8 @@ func (h *FormErrorHandlerImpl) HandleSchemaError(c echo.Context, err error) erro
 		return h.responseBuilder.BuildErrorResponse(c, http.StatusBadRequest, "Form schema is required")
 	case errors.Is(err, model.ErrFormInvalid):
 		return h.responseBuilder.BuildErrorResponse(c, http.StatusBadRequest, "Invalid form schema format")
+	case errors.Is(err, model.ErrFormStatusInvalid):
+		return h.responseBuilder.BuildErrorResponse(c, http.StatusBadRequest, model.ErrFormStatusInvalid.Error())
 	default:
 		return h.responseBuilder.BuildErrorResponse(c, http.StatusInternalServerError, "Failed to process form schema")
 	}
@@ -79,6 +79,13 @@ func TestFormErrorHandler_HandleSchemaError(t *testing.T) {
 			expectedBody:   "Invalid form schema format",
 			description:    "Should return 400 for invalid schema",
 		},
+		{
+			name:           "invalid status error",
+			err:            model.ErrFormStatusInvalid,
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   "status must be draft, published or archived",
+			description:    "Should return 400 for an unknown form status",
+		},
 		{
 			name:           "unknown schema error",
 			err:            domainerrors.New(domainerrors.ErrCodeServerError, "unknown schema error", nil),
@@ -0,0 +1,170 @@
+package web
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"slices"
+
+	"github.com/labstack/echo/v4"
+
+	"github.com/goformx/goforms/internal/application/theme"
+	"github.com/goformx/goforms/internal/domain/form/model"
+)
+
+// GET /api/forms/:id/export/html - download the form as a standalone HTML page
+// the owner can host anywhere (assertion auth). The rendered schema is inlined
+// and the page posts submissions to /forms/:id/submit on this server, so it is
+// only offered for forms allowing the script embed method and at least one
+// origin, and needing no passphrase or sign-in, which a static page cannot provide.
+func (h *FormAPIHandler) handleExportFormHTML(c echo.Context) error {
+	form, err := h.getFormWithOwnershipOrError(c)
+	if err != nil || form == nil {
+		return err
+	}
+
+	if form.Schema == nil {
+		return h.ResponseBuilder.BuildErrorResponse(c, http.StatusUnprocessableEntity, "The form has no schema to export")
+	}
+
+	origins := form.GetCorsOrigins()
+	if len(origins) == 0 {
+		return h.ResponseBuilder.BuildErrorResponse(c, http.StatusUnprocessableEntity,
+			"Add the origin that will host the page to the form's CORS origins before exporting it")
+	}
+
+	if !form.AllowsEmbedMethod(model.EmbedMethodScript) {
+		return h.ResponseBuilder.BuildErrorResponse(c, http.StatusUnprocessableEntity,
+			"Allow the script embed method before exporting the form")
+	}
+
+	if form.GetAccess().IsRestricted() {
+		return h.ResponseBuilder.BuildErrorResponse(c, http.StatusUnprocessableEntity,
+			"Forms with a passphrase or sign-in cannot be exported")
+	}
+
+	schema, handled, err := h.runPreRender(c, form)
+	if handled {
+		return err
+	}
+
+	schema = model.RenderSchema(h.Options.ResolveSchema(c.Request().Context(), form.UserID, schema))
+
+	page, err := formExportPage(form.Title, schema, origins, h.publicBaseURL(c)+"/forms/"+form.ID+"/submit")
+	if err != nil {
+		h.Logger.Error("failed to export form page", "error", err, "form_id", form.ID)
+
+		return h.HandleError(c, err, "Failed to export form")
+	}
+
+	filename := "form-" + form.ID + ".html"
+	c.Response().Header().Set(echo.HeaderContentDisposition, fmt.Sprintf("attachment; filename=%q", filename))
+
+	return c.Blob(http.StatusOK, echo.MIMETextHTMLCharsetUTF8, page)
+}
+
+// formExportPage builds a standalone page rendering schema with Form.io and
+// posting the answers to submitURL. The page refuses to render on an origin the
+// form does not allow, since the browser would block its submissions.
+func formExportPage(title string, schema model.JSON, origins []string, submitURL string) ([]byte, error) {
+	// json.Marshal escapes <, > and &, so none of the values can end the script
+	config, err := json.Marshal(map[string]any{
+		"schema":    schema,
+		"origins":   origins,
+		"anyOrigin": slices.Contains(origins, "*"),
+		"submitUrl": submitURL,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("encode exported form: %w", err)
+	}
+
+	page := `<!DOCTYPE html>
+<html>
+<head>
+  <meta charset="utf-8">
+  <meta name="viewport" content="width=device-width, initial-scale=1">
+  <title>` + escapeHTML(title) + `</title>
+  <link rel="stylesheet" href="https://cdn.form.io/formiojs/formio.full.min.css">
+  <style>
+` + theme.Stylesheet + `    body { background: var(--gf-bg); color: var(--gf-text); max-width: 48rem; margin: 2rem auto; padding: 0 1rem; }
+    .gf-error-summary { margin: 0 0 1rem; padding: 1rem; border: 2px solid var(--gf-danger); border-radius: 0.5rem; }
+    .gf-error-summary ul { margin: 0; padding-left: 1.25rem; }
+    .gf-load-error { color: var(--gf-danger); }
+    .gf-confirmation { margin: 0 0 1rem; padding: 1rem; border: 2px solid var(--gf-accent); border-radius: 0.5rem; }
+  </style>
+</head>
+<body>
+  <main>
+    <h1 id="gf-title">` + escapeHTML(title) + `</h1>
+    <div id="gf-error-summary" class="gf-error-summary" role="alert" tabindex="-1" hidden><ul id="gf-error-list"></ul></div>
+    <div id="gf-confirmation" class="gf-confirmation" role="status" tabindex="-1" hidden></div>
+    <div id="formio" role="form" aria-labelledby="gf-title"></div>
+  </main>
+  <script type="application/json" id="gf-config">` + string(config) + `</script>
+  <script src="https://cdn.form.io/formiojs/formio.full.min.js"></script>
+  <script>
+    (function() {
+      var config = JSON.parse(document.getElementById('gf-config').textContent);
+      var container = document.getElementById('formio');
+      var summary = document.getElementById('gf-error-summary');
+      var list = document.getElementById('gf-error-list');
+      var confirmation = document.getElementById('gf-confirmation');
+
+      function showErrors(messages) {
+        list.innerHTML = '';
+        messages.forEach(function(message) {
+          var item = document.createElement('li');
+          item.textContent = message;
+          list.appendChild(item);
+        });
+        summary.hidden = messages.length === 0;
+        if (!summary.hidden) summary.focus();
+      }
+
+      if (!config.anyOrigin && config.origins.indexOf(window.location.origin) === -1) {
+        container.innerHTML = '<p class="gf-load-error" role="alert">This form does not accept submissions from this site.</p>';
+        return;
+      }
+
+      Formio.createForm(container, config.schema, { noAlerts: true }).then(function(form) {
+        form.nosubmit = true;
+        form.on('submit', function(submission) {
+          showErrors([]);
+          fetch(config.submitUrl, {
+            method: 'POST',
+            headers: { 'Content-Type': 'application/json', 'Accept': 'application/json' },
+            body: JSON.stringify(submission.data)
+          }).then(function(res) {
+            return res.json().then(function(body) { return { ok: res.ok, body: body }; });
+          }).then(function(result) {
+            var data = (result.body && result.body.data) || {};
+            if (!result.ok) {
+              var errors = (data.errors || []).map(function(error) { return error.message; });
+              showErrors(errors.length ? errors : [result.body.message || 'The form could not be submitted.']);
+              form.emit('submitError');
+              return;
+            }
+            form.emit('submitDone', submission);
+            // The confirmation message is the owner's markup with the answers escaped by the server
+            if (data.confirmation_message) confirmation.innerHTML = data.confirmation_message;
+            else confirmation.textContent = 'Thank you, your response has been submitted.';
+            confirmation.hidden = false;
+            container.hidden = true;
+            confirmation.focus();
+          }).catch(function() {
+            showErrors(['The form could not be submitted. Please try again.']);
+            form.emit('submitError');
+          });
+        });
+      }).catch(function(err) {
+        container.innerHTML = '<p class="gf-load-error" role="alert">Failed to load form. Please try again.</p>';
+        console.error('Form.io load error:', err);
+      });
+    })();
+  </script>
+</body>
+</html>
+`
+
+	return []byte(page), nil
+}
@@ -0,0 +1,151 @@
+package web_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/goformx/goforms/internal/domain/form/model"
+)
+
+// exportConfigPattern captures the JSON config inlined in an exported page
+var exportConfigPattern = regexp.MustCompile(`(?s)<script type="application/json" id="gf-config">(.*?)</script>`)
+
+// newExportableForm returns a form of formOwner that may be exported
+func newExportableForm(t *testing.T, title string) *model.Form {
+	t.Helper()
+
+	form := newOwnedForm(title)
+	_, _, err := form.AddCorsOrigin("https://www.example.com")
+	require.NoError(t, err)
+
+	return form
+}
+
+func TestFormAPI_ExportFormHTML(t *testing.T) {
+	server := newFormAPIServer(t, nil)
+	form := server.createForm(t, newExportableForm(t, "Survey"))
+
+	rec := server.call(t, formOwner, http.MethodGet, "/"+form.ID+"/export/html", nil, nil)
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+	assert.Equal(t, `attachment; filename="form-`+form.ID+`.html"`, rec.Header().Get("Content-Disposition"))
+
+	match := exportConfigPattern.FindStringSubmatch(rec.Body.String())
+	require.Len(t, match, 2)
+
+	var config struct {
+		Origins   []string `json:"origins"`
+		AnyOrigin bool     `json:"anyOrigin"`
+		SubmitURL string   `json:"submitUrl"`
+	}
+	require.NoError(t, json.Unmarshal([]byte(match[1]), &config))
+	assert.Equal(t, []string{"https://www.example.com"}, config.Origins)
+	assert.False(t, config.AnyOrigin)
+	assert.Equal(t, "https://forms.example.com/forms/"+form.ID+"/submit", config.SubmitURL)
+}
+
+func TestFormAPI_ExportFormHTMLRefused(t *testing.T) {
+	tests := []struct {
+		name   string
+		userID string
+		form   func(t *testing.T) *model.Form
+		want   int
+	}{
+		{
+			name:   "no allowed origins",
+			userID: formOwner,
+			form:   func(*testing.T) *model.Form { return newOwnedForm("Survey") },
+			want:   http.StatusUnprocessableEntity,
+		},
+		{
+			name:   "script embed not allowed",
+			userID: formOwner,
+			form: func(t *testing.T) *model.Form {
+				form := newExportableForm(t, "Survey")
+				require.NoError(t, form.SetAllowedEmbedMethods([]model.EmbedMethod{model.EmbedMethodIframe}))
+
+				return form
+			},
+			want: http.StatusUnprocessableEntity,
+		},
+		{
+			name:   "passphrase",
+			userID: formOwner,
+			form: func(t *testing.T) *model.Form {
+				form := newExportableForm(t, "Survey")
+				require.NoError(t, form.SetAccess(model.FormAccess{PassphraseHash: "$2a$10$hash"}))
+
+				return form
+			},
+			want: http.StatusUnprocessableEntity,
+		},
+		{
+			name:   "sign-in",
+			userID: formOwner,
+			form: func(t *testing.T) *model.Form {
+				form := newExportableForm(t, "Survey")
+				require.NoError(t, form.SetAccess(model.FormAccess{RequireAuth: true}))
+
+				return form
+			},
+			want: http.StatusUnprocessableEntity,
+		},
+		{
+			name:   "not the owner",
+			userID: "user-2",
+			form:   func(t *testing.T) *model.Form { return newExportableForm(t, "Survey") },
+			want:   http.StatusForbidden,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := newFormAPIServer(t, nil)
+			form := server.createForm(t, tt.form(t))
+
+			rec := server.call(t, tt.userID, http.MethodGet, "/"+form.ID+"/export/html", nil, nil)
+			assert.Equal(t, tt.want, rec.Code, rec.Body.String())
+			assert.Empty(t, rec.Header().Get("Content-Disposition"), "no page is offered for download")
+			assert.NotContains(t, rec.Body.String(), "gf-config")
+		})
+	}
+}
+
+func TestFormAPI_ExportFormHTMLEscaping(t *testing.T) {
+	const breakout = `</script><script>alert(1)</script>`
+
+	server := newFormAPIServer(t, nil)
+	form := newExportableForm(t, "Survey "+breakout)
+	form.Schema = model.JSON{
+		"display":    "form",
+		"components": []any{map[string]any{"key": "name", "type": "textfield", "label": "Name " + breakout}},
+	}
+	server.createForm(t, form)
+
+	rec := server.call(t, formOwner, http.MethodGet, "/"+form.ID+"/export/html", nil, nil)
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+
+	page := rec.Body.String()
+	assert.NotContains(t, page, "<script>alert(1)")
+	assert.Equal(t, strings.Count(page, "<script"), strings.Count(page, "</script>"), "only the page's own scripts are closed")
+	assert.Contains(t, page, "<title>Survey &lt;/script&gt;")
+
+	// The label survives in the inlined config with its markup escaped for the script element
+	match := exportConfigPattern.FindStringSubmatch(page)
+	require.Len(t, match, 2)
+	assert.Contains(t, match[1], `Name \u003c/script\u003e\u003cscript\u003e`)
+
+	var config struct {
+		Schema model.JSON `json:"schema"`
+	}
+	require.NoError(t, json.Unmarshal([]byte(match[1]), &config))
+
+	components, _ := config.Schema["components"].([]any)
+	require.Len(t, components, 1)
+	assert.Equal(t, "Name "+breakout, components[0].(map[string]any)["label"])
+}
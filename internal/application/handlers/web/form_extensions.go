@@ -0,0 +1,62 @@
+package web
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+
+	"github.com/goformx/goforms/internal/application/response"
+	"github.com/goformx/goforms/internal/domain/extension"
+	"github.com/goformx/goforms/internal/domain/form/model"
+)
+
+// runPreValidate runs the form's pre-validate extensions. It returns the data to
+// validate, or handled with the response written when a hook rejected the
+// submission or failed under the reject policy.
+func (h *FormAPIHandler) runPreValidate(c echo.Context, form *model.Form, data model.JSON) (model.JSON, bool, error) {
+	data, err := h.Extensions.PreValidate(c.Request().Context(), form, data)
+	if err == nil {
+		return data, false, nil
+	}
+
+	var rejection *extension.RejectionError
+	if errors.As(err, &rejection) {
+		h.Logger.Info("submission rejected by extension", "form_id", form.ID, "extension", rejection.Extension)
+
+		message := rejection.Message
+		if message == "" {
+			message = "The submission was rejected."
+		}
+
+		return nil, true, c.JSON(http.StatusUnprocessableEntity, response.APIResponse{
+			Success: false,
+			Message: message,
+			Data:    map[string]any{"code": "submission_rejected"},
+		})
+	}
+
+	return nil, true, h.extensionFailed(c, form, err)
+}
+
+// runPreRender runs the form's pre-render extensions on a copy of its schema.
+// handled is set when a hook failed under the reject policy and the response is written.
+func (h *FormAPIHandler) runPreRender(c echo.Context, form *model.Form) (model.JSON, bool, error) {
+	schema, err := h.Extensions.PreRender(c.Request().Context(), form, form.Schema.Clone())
+	if err != nil {
+		return nil, true, h.extensionFailed(c, form, err)
+	}
+
+	return schema, false, nil
+}
+
+// extensionFailed responds to a hook that failed under the reject policy
+func (h *FormAPIHandler) extensionFailed(c echo.Context, form *model.Form, err error) error {
+	h.Logger.Error("form extension failed", "form_id", form.ID, "error", err)
+
+	return c.JSON(http.StatusBadGateway, response.APIResponse{
+		Success: false,
+		Message: "The form could not be processed. Please try again later.",
+		Data:    map[string]any{"code": "extension_failed"},
+	})
+}
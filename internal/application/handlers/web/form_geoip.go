@@ -0,0 +1,75 @@
+package web
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+
+	"github.com/goformx/goforms/internal/application/response"
+	"github.com/goformx/goforms/internal/domain/form/model"
+	"github.com/goformx/goforms/internal/infrastructure/geoip"
+)
+
+// formSubmitterLocationKey is the Echo context key holding the submitter's located address
+const formSubmitterLocationKey = "form_submitter_location"
+
+// locateSubmitter locates the client address once per request. It is false
+// when geoip is disabled or the address is not in the database.
+func (h *FormAPIHandler) locateSubmitter(c echo.Context) (geoip.Location, bool) {
+	if location, ok := c.Get(formSubmitterLocationKey).(geoip.Location); ok {
+		return location, true
+	}
+
+	location, ok := h.GeoIP.Locate(c.RealIP())
+	if ok {
+		c.Set(formSubmitterLocationKey, location)
+	}
+
+	return location, ok
+}
+
+// checkSubmissionCountry refuses submissions from countries the form does not
+// accept. The rules are not enforced while geoip is disabled.
+func (h *FormAPIHandler) checkSubmissionCountry(c echo.Context, form *model.Form) (bool, error) {
+	access := form.GetAccess()
+	if !access.HasCountryRules() {
+		return false, nil
+	}
+
+	if h.GeoIP == nil {
+		h.Logger.Warn("form country rules are not enforced because geoip is disabled", "form_id", form.ID)
+
+		return false, nil
+	}
+
+	location, _ := h.locateSubmitter(c)
+	if access.AllowsCountry(location.Country) {
+		return false, nil
+	}
+
+	h.Logger.Info("submission refused by country", "form_id", form.ID, "country", location.Country)
+
+	return true, c.JSON(http.StatusForbidden, response.APIResponse{
+		Success: false,
+		Message: "Submissions to this form are not accepted from your location.",
+		Data:    map[string]any{"code": "country_not_allowed"},
+	})
+}
+
+// addLocationMetadata records the submitter's country and region with a submission
+func (h *FormAPIHandler) addLocationMetadata(c echo.Context, submission *model.FormSubmission) {
+	location, ok := h.locateSubmitter(c)
+	if !ok {
+		return
+	}
+
+	submission.AddMetadata("geo_country", location.Country)
+
+	if location.Region != "" {
+		submission.AddMetadata("geo_region", location.Region)
+	}
+
+	if location.RegionName != "" {
+		submission.AddMetadata("geo_region_name", location.RegionName)
+	}
+}
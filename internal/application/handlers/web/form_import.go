@@ -0,0 +1,183 @@
+package web
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+
+	"github.com/goformx/goforms/internal/application/importer"
+	"github.com/goformx/goforms/internal/application/response"
+)
+
+// importFile reads the uploaded import file, from a multipart "file" field or
+// the raw request body, and infers its format from the "format" parameter,
+// the file name or the content type
+func importFile(c echo.Context) ([]byte, string, error) {
+	format := strings.ToLower(c.QueryParam("format"))
+	if format == "" {
+		format = strings.ToLower(c.FormValue("format"))
+	}
+
+	var (
+		reader      io.Reader
+		contentType = c.Request().Header.Get(echo.HeaderContentType)
+	)
+
+	if fileHeader, err := c.FormFile("file"); err == nil {
+		file, openErr := fileHeader.Open()
+		if openErr != nil {
+			return nil, "", errors.New("failed to open uploaded file")
+		}
+		defer file.Close()
+
+		reader = file
+		contentType = fileHeader.Header.Get(echo.HeaderContentType)
+
+		if format == "" {
+			format = strings.TrimPrefix(strings.ToLower(filepath.Ext(fileHeader.Filename)), ".")
+		}
+	} else {
+		reader = c.Request().Body
+	}
+
+	if format == "" {
+		switch {
+		case strings.HasPrefix(contentType, "text/csv"):
+			format = importer.FormatCSV
+		case strings.HasPrefix(contentType, echo.MIMEApplicationJSON):
+			format = importer.FormatJSON
+		}
+	}
+
+	data, err := io.ReadAll(io.LimitReader(reader, importer.MaxFileSize+1))
+	if err != nil {
+		return nil, "", errors.New("failed to read import file")
+	}
+
+	if len(data) > importer.MaxFileSize {
+		return nil, "", errors.New("import file is larger than 10 MB")
+	}
+
+	return data, format, nil
+}
+
+// importDryRun reads the dry_run flag from the query string or form
+func importDryRun(c echo.Context) bool {
+	value := c.QueryParam("dry_run")
+	if value == "" {
+		value = c.FormValue("dry_run")
+	}
+
+	dryRun, _ := strconv.ParseBool(value)
+
+	return dryRun
+}
+
+// POST /api/forms/:id/submissions/import - bulk-load historical submissions (assertion auth).
+// Accepts a CSV or JSON file with an optional "mapping" JSON object of column to field key.
+func (h *FormAPIHandler) handleImportSubmissions(c echo.Context) error {
+	form, err := h.getFormWithOwnershipOrError(c)
+	if err != nil || form == nil {
+		return err
+	}
+
+	mapping := map[string]string{}
+
+	rawMapping := c.QueryParam("mapping")
+	if rawMapping == "" {
+		rawMapping = c.FormValue("mapping")
+	}
+
+	if rawMapping != "" {
+		if decodeErr := json.Unmarshal([]byte(rawMapping), &mapping); decodeErr != nil {
+			return h.ResponseBuilder.BuildValidationErrorResponse(c, "mapping", "mapping must be a JSON object of column to field key")
+		}
+	}
+
+	data, format, err := importFile(c)
+	if err != nil {
+		return h.ResponseBuilder.BuildErrorResponse(c, http.StatusBadRequest, err.Error())
+	}
+
+	report, err := h.Importer.ImportSubmissions(c.Request().Context(), form, bytes.NewReader(data), importer.SubmissionOptions{
+		Format:  format,
+		Mapping: mapping,
+		DryRun:  importDryRun(c),
+	})
+	if err != nil {
+		return h.handleImportError(c, err, form.ID)
+	}
+
+	h.Logger.Info("submissions imported",
+		"form_id", form.ID,
+		"dry_run", report.DryRun,
+		"total", report.Total,
+		"imported", report.Imported,
+		"failed", report.Failed,
+	)
+
+	return response.Success(c, report)
+}
+
+// POST /api/forms/import - import a form exported from GoForms or another builder (assertion auth).
+// The ?source= parameter selects the converter (goforms, typeform or google_forms) and
+// defaults to "goforms".
+func (h *FormAPIHandler) handleImportForm(c echo.Context) error {
+	userID, ok := c.Get("user_id").(string)
+	if !ok {
+		return h.HandleForbidden(c, "User not authenticated")
+	}
+
+	data, _, err := importFile(c)
+	if err != nil {
+		return h.ResponseBuilder.BuildErrorResponse(c, http.StatusBadRequest, err.Error())
+	}
+
+	report, form, err := h.Importer.ImportForm(c.Request().Context(), userID, c.QueryParam("source"), data, importDryRun(c))
+	if err != nil {
+		if handled, quotaErr := h.handleQuotaError(c, err); handled {
+			return quotaErr
+		}
+
+		return h.handleImportError(c, err, "")
+	}
+
+	switch {
+	case !report.Valid:
+		return c.JSON(http.StatusUnprocessableEntity, response.APIResponse{
+			Success: false,
+			Message: "Imported form is invalid",
+			Data:    report,
+		})
+	case form == nil:
+		return response.Success(c, report)
+	default:
+		return c.JSON(http.StatusCreated, response.APIResponse{
+			Success: true,
+			Message: "Form imported successfully",
+			Data:    report,
+		})
+	}
+}
+
+// handleImportError maps importer errors to HTTP responses
+func (h *FormAPIHandler) handleImportError(c echo.Context, err error, formID string) error {
+	switch {
+	case errors.Is(err, importer.ErrUnsupportedFormat),
+		errors.Is(err, importer.ErrUnknownSource),
+		errors.Is(err, importer.ErrTooManyRows),
+		errors.Is(err, importer.ErrInvalidFile):
+		return h.ResponseBuilder.BuildErrorResponse(c, http.StatusBadRequest, err.Error())
+	}
+
+	h.Logger.Error("import failed", "error", err, "form_id", formID)
+
+	return h.HandleError(c, err, "Failed to import")
+}
@@ -1,6 +1,8 @@
 package web
 
 import (
+	"time"
+
 	"github.com/labstack/echo/v4"
 
 	"github.com/goformx/goforms/internal/application/validation"
@@ -19,6 +21,20 @@ type FormUpdateRequest struct {
 	Status      string     `json:"status"`
 	CorsOrigins string     `json:"cors_origins"`
 	Schema      model.JSON `json:"schema"`
+	// Version is the form version the client last read; used when If-Match is absent
+	Version *int `json:"version,omitempty"`
+	// SubmissionWriteMode set to "sync" stores this form's submissions synchronously
+	// even when write-behind is enabled; nil keeps the current mode
+	SubmissionWriteMode *string `json:"submission_write_mode,omitempty"`
+	// ThrottleMode set to "off" exempts this form from adaptive submission
+	// throttling and "adaptive" restores it; nil keeps the current mode
+	ThrottleMode *string `json:"throttle_mode,omitempty"`
+	// Timezone is the IANA time zone of the form's schedules, exports and
+	// notifications; "" uses the owner's and nil keeps the current zone
+	Timezone *string `json:"timezone,omitempty"`
+	// Extensions replaces the names of the extensions enabled for this form;
+	// nil keeps the current list and an empty list disables all
+	Extensions *[]string `json:"extensions,omitempty"`
 }
 
 // FormRetriever interface for retrieving forms
@@ -45,8 +61,8 @@ type FormResponseBuilder interface {
 	BuildSuccessResponse(c echo.Context, message string, data map[string]any) error
 	BuildErrorResponse(c echo.Context, statusCode int, message string) error
 	BuildSchemaResponse(c echo.Context, schema model.JSON) error
-	BuildSubmissionResponse(c echo.Context, submission *model.FormSubmission) error
-	BuildSubmissionListResponse(c echo.Context, submissions []*model.FormSubmission) error
+	BuildSubmissionResponse(c echo.Context, submission *model.FormSubmission, confirmation string) error
+	BuildSubmissionListResponse(c echo.Context, submissions []*model.FormSubmission, loc *time.Location) error
 	BuildFormResponse(c echo.Context, form *model.Form) error
 	BuildFormListResponse(c echo.Context, forms []*model.Form) error
 	BuildNotFoundResponse(c echo.Context, resource string) error
@@ -0,0 +1,51 @@
+package web
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+
+	"github.com/goformx/goforms/internal/domain/form/model"
+)
+
+// POST /api/forms/:id/publish, /unpublish and /archive - moves the form along
+// its lifecycle: publish offers a draft or archived form, unpublish takes a
+// published form back to draft and archive retires it (assertion auth)
+func (h *FormAPIHandler) handleTransitionForm(transition model.FormTransition) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		form, err := h.getFormWithOwnershipOrError(c)
+		if err != nil || form == nil {
+			return err
+		}
+
+		if transitionErr := h.FormService.TransitionForm(c.Request().Context(), form, transition); transitionErr != nil {
+			if refused, respErr := h.refusedTransition(c, transitionErr); refused {
+				return respErr
+			}
+
+			h.Logger.Error("failed to change form status", "error", transitionErr, "form_id", form.ID)
+
+			return h.HandleError(c, transitionErr, "Failed to change form status")
+		}
+
+		setFormETag(c, form)
+
+		return h.ResponseBuilder.BuildFormResponse(c, form)
+	}
+}
+
+// refusedTransition responds to a status change the form lifecycle refused,
+// reporting whether err was one; other errors are left to the caller
+func (h *FormAPIHandler) refusedTransition(c echo.Context, err error) (bool, error) {
+	switch {
+	case errors.Is(err, model.ErrFormTransitionNotAllowed):
+		return true, h.ResponseBuilder.BuildErrorResponse(c, http.StatusConflict, err.Error())
+	case errors.Is(err, model.ErrPublishRequiresOrigins):
+		return true, h.ResponseBuilder.BuildValidationErrorResponse(c, "cors_origins", err.Error())
+	case errors.Is(err, model.ErrFormStatusInvalid):
+		return true, h.ResponseBuilder.BuildValidationErrorResponse(c, "status", err.Error())
+	default:
+		return false, nil
+	}
+}
@@ -0,0 +1,227 @@
+package web
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/labstack/echo/v4"
+
+	"github.com/goformx/goforms/internal/application/response"
+	"github.com/goformx/goforms/internal/application/validation"
+	formdomain "github.com/goformx/goforms/internal/domain/form"
+	"github.com/goformx/goforms/internal/domain/form/model"
+	"github.com/goformx/goforms/internal/domain/notifytemplate"
+)
+
+// NotificationTemplateRequest is the body of PUT /api/forms/:id/notification-templates/:channel
+type NotificationTemplateRequest struct {
+	Source string `json:"source"`
+}
+
+// NotificationRenderRequest is the body of POST /api/forms/:id/notification-templates/:channel/render.
+// Source defaults to the form's template for the channel; without a submission
+// ID the template is rendered with sample variables.
+type NotificationRenderRequest struct {
+	Source       *string `json:"source"`
+	SubmissionID string  `json:"submission_id"`
+}
+
+// notificationSource returns the form's template for a channel, or the default
+func notificationSource(form *model.Form, channel notifytemplate.Channel) string {
+	if source := form.NotificationTemplate(string(channel)); source != "" {
+		return source
+	}
+
+	return notifytemplate.Default(channel)
+}
+
+// notificationTemplateResponse describes a form's template for one channel
+func notificationTemplateResponse(form *model.Form, channel notifytemplate.Channel) map[string]any {
+	return map[string]any{
+		"channel":      channel,
+		"source":       notificationSource(form, channel),
+		"custom":       form.NotificationTemplate(string(channel)) != "",
+		"content_type": channel.ContentType(),
+	}
+}
+
+// GET /api/forms/:id/notification-templates - the form's template for every channel (assertion auth)
+func (h *FormAPIHandler) handleListNotificationTemplates(c echo.Context) error {
+	form, err := h.getFormWithOwnershipOrError(c)
+	if err != nil || form == nil {
+		return err
+	}
+
+	templates := make([]map[string]any, 0, len(notifytemplate.Channels))
+	for _, channel := range notifytemplate.Channels {
+		templates = append(templates, notificationTemplateResponse(form, channel))
+	}
+
+	return response.Success(c, templates)
+}
+
+// PUT /api/forms/:id/notification-templates/:channel - saves the form's template for a channel (assertion auth)
+func (h *FormAPIHandler) handleUpdateNotificationTemplate(c echo.Context) error {
+	form, err := h.getFormWithOwnershipOrError(c)
+	if err != nil || form == nil {
+		return err
+	}
+
+	channel, err := notifytemplate.ParseChannel(c.Param("channel"))
+	if err != nil {
+		return h.ResponseBuilder.BuildValidationErrorResponse(c, "channel", err.Error())
+	}
+
+	var req NotificationTemplateRequest
+	if bindErr := c.Bind(&req); bindErr != nil {
+		return h.ResponseBuilder.BuildErrorResponse(c, http.StatusBadRequest, "Invalid request body")
+	}
+
+	if req.Source == "" {
+		return h.ResponseBuilder.BuildValidationErrorResponse(c, "source", "Template source is required")
+	}
+
+	// Rendering with sample variables also catches execution errors, such as
+	// invalid JSON from a webhook template, before the template is saved
+	sample := withConfirmation(notifytemplate.SampleVariables(form, h.formLocation(c.Request().Context(), form)), form)
+	if _, renderErr := renderNotification(channel, req.Source, sample); renderErr != nil {
+		return h.ResponseBuilder.BuildValidationErrorResponse(c, "source", renderErr.Error())
+	}
+
+	form.SetNotificationTemplate(string(channel), req.Source)
+
+	if updateErr := h.FormService.UpdateForm(c.Request().Context(), form); updateErr != nil {
+		return h.HandleError(c, updateErr, "Failed to save notification template")
+	}
+
+	h.Logger.Info("notification template updated", "form_id", form.ID, "channel", string(channel))
+
+	return response.Success(c, notificationTemplateResponse(form, channel))
+}
+
+// DELETE /api/forms/:id/notification-templates/:channel - restores the default template (assertion auth)
+func (h *FormAPIHandler) handleDeleteNotificationTemplate(c echo.Context) error {
+	form, err := h.getFormWithOwnershipOrError(c)
+	if err != nil || form == nil {
+		return err
+	}
+
+	channel, err := notifytemplate.ParseChannel(c.Param("channel"))
+	if err != nil {
+		return h.ResponseBuilder.BuildValidationErrorResponse(c, "channel", err.Error())
+	}
+
+	if form.NotificationTemplate(string(channel)) != "" {
+		form.SetNotificationTemplate(string(channel), "")
+
+		if updateErr := h.FormService.UpdateForm(c.Request().Context(), form); updateErr != nil {
+			return h.HandleError(c, updateErr, "Failed to reset notification template")
+		}
+
+		h.Logger.Info("notification template reset", "form_id", form.ID, "channel", string(channel))
+	}
+
+	return response.Success(c, notificationTemplateResponse(form, channel))
+}
+
+// POST /api/forms/:id/notification-templates/:channel/render - renders a template
+// against sample variables or a stored submission without sending anything (assertion auth)
+func (h *FormAPIHandler) handleRenderNotificationTemplate(c echo.Context) error {
+	form, err := h.getFormWithOwnershipOrError(c)
+	if err != nil || form == nil {
+		return err
+	}
+
+	channel, err := notifytemplate.ParseChannel(c.Param("channel"))
+	if err != nil {
+		return h.ResponseBuilder.BuildValidationErrorResponse(c, "channel", err.Error())
+	}
+
+	var req NotificationRenderRequest
+	if bindErr := c.Bind(&req); bindErr != nil {
+		return h.ResponseBuilder.BuildErrorResponse(c, http.StatusBadRequest, "Invalid request body")
+	}
+
+	source := notificationSource(form, channel)
+	if req.Source != nil {
+		source = *req.Source
+	}
+
+	vars := withConfirmation(notifytemplate.SampleVariables(form, h.formLocation(c.Request().Context(), form)), form)
+
+	if req.SubmissionID != "" {
+		submission, getErr := h.getSubmission(c.Request().Context(), form.ID, req.SubmissionID)
+		if getErr != nil {
+			if errors.Is(getErr, model.ErrSubmissionNotFound) {
+				return h.ResponseBuilder.BuildNotFoundResponse(c, "Submission")
+			}
+
+			return h.HandleError(c, getErr, "Failed to get submission")
+		}
+
+		vars = notificationVariables(form, submission, h.formLocation(c.Request().Context(), form))
+	}
+
+	output, err := renderNotification(channel, source, vars)
+	if err != nil {
+		return h.ResponseBuilder.BuildValidationErrorResponse(c, "source", err.Error())
+	}
+
+	return response.Success(c, map[string]any{
+		"channel":      channel,
+		"content_type": channel.ContentType(),
+		"output":       output,
+	})
+}
+
+// renderNotification parses and renders a notification template
+func renderNotification(channel notifytemplate.Channel, source string, vars map[string]any) (string, error) {
+	tmpl, err := notifytemplate.Parse(channel, source)
+	if err != nil {
+		return "", err
+	}
+
+	return tmpl.Render(vars)
+}
+
+// notificationVariables returns the template variables for a stored
+// submission, labelling its answers from the form schema with their answer
+// references resolved
+func notificationVariables(form *model.Form, submission *model.FormSubmission, loc *time.Location) map[string]any {
+	parser := validation.NewSchemaParser()
+	fields := make([]notifytemplate.Field, 0, len(submission.Data))
+
+	components, _ := parser.ExtractInputComponents(form.Schema)
+	for _, component := range components {
+		key, ok := parser.ExtractComponentKey(component)
+		if !ok {
+			continue
+		}
+
+		value, answered := submission.Data[key]
+		if !answered {
+			continue
+		}
+
+		label, _ := component["label"].(string)
+		if label == "" {
+			label = key
+		}
+
+		fields = append(fields, notifytemplate.Field{
+			Key: key, Label: formdomain.PipeText(label, submission.Data), Value: formdomain.FormatSubmissionValue(value),
+		})
+	}
+
+	return withConfirmation(notifytemplate.Variables(form, submission, fields, loc), form)
+}
+
+// withConfirmation adds Confirmation, the form's thank-you message with its
+// answer references resolved from the Data variable, to template variables
+func withConfirmation(vars map[string]any, form *model.Form) map[string]any {
+	data, _ := vars["Data"].(map[string]any)
+	vars["Confirmation"] = formdomain.PipeText(form.ConfirmationMessage(), data)
+
+	return vars
+}
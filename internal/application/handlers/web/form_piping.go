@@ -0,0 +1,79 @@
+package web
+
+import (
+	"net/url"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+
+	formdomain "github.com/goformx/goforms/internal/domain/form"
+	"github.com/goformx/goforms/internal/domain/form/model"
+)
+
+const (
+	// prefillParamPrefix and prefillParamSuffix wrap the component key of an
+	// answer passed in the query string, e.g. ?data[name]=Ada
+	prefillParamPrefix = "data["
+	prefillParamSuffix = "]"
+	// maxPrefillAnswers caps the answers read from the query string
+	maxPrefillAnswers = 50
+)
+
+// prefillAnswers returns the answers passed as data[<key>] query parameters,
+// which piped labels of GET /forms/:id/schema are resolved with
+func prefillAnswers(c echo.Context) model.JSON {
+	return prefillAnswersFrom(c.QueryParams())
+}
+
+// prefillAnswersFrom returns the data[<key>] answers of a query
+func prefillAnswersFrom(query url.Values) model.JSON {
+	answers := model.JSON{}
+
+	for name, values := range query {
+		if len(answers) == maxPrefillAnswers {
+			break
+		}
+
+		if key, ok := prefillKey(name); ok && len(values) > 0 {
+			answers[key] = values[0]
+		}
+	}
+
+	return answers
+}
+
+// prefillKey returns the component key of a data[<key>] query parameter
+func prefillKey(name string) (string, bool) {
+	if !strings.HasPrefix(name, prefillParamPrefix) || !strings.HasSuffix(name, prefillParamSuffix) {
+		return "", false
+	}
+
+	key := strings.TrimSuffix(strings.TrimPrefix(name, prefillParamPrefix), prefillParamSuffix)
+
+	return key, key != ""
+}
+
+// prefillQuery returns the data[<key>] parameters of a request, encoded for
+// passing on to the schema request of a hosted page, or ""
+func prefillQuery(c echo.Context) string {
+	query := url.Values{}
+
+	for name, values := range c.QueryParams() {
+		if _, ok := prefillKey(name); ok && len(values) > 0 && len(query) < maxPrefillAnswers {
+			query.Set(name, values[0])
+		}
+	}
+
+	return query.Encode()
+}
+
+// confirmationMessage returns the form's thank-you message with its answer
+// references resolved for a submission, escaped for HTML, or ""
+func confirmationMessage(form *model.Form, submission *model.FormSubmission) string {
+	message := form.ConfirmationMessage()
+	if message == "" {
+		return ""
+	}
+
+	return formdomain.PipeHTML(message, submission.Data)
+}
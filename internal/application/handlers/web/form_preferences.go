@@ -0,0 +1,108 @@
+package web
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+
+	"github.com/goformx/goforms/internal/application/response"
+	"github.com/goformx/goforms/internal/application/theme"
+	"github.com/goformx/goforms/internal/domain/entities"
+	"github.com/goformx/goforms/internal/domain/form/model"
+)
+
+// PreferencesRequest changes the dashboard preferences of the user
+type PreferencesRequest struct {
+	// Theme is system, light or dark
+	Theme *string `json:"theme"`
+	// Timezone is the IANA time zone of the user's forms that have none of their own; "" is UTC
+	Timezone *string `json:"timezone"`
+	// HealthEmail opts in to the weekly account health email
+	HealthEmail *bool `json:"health_email"`
+}
+
+// Preferences are the dashboard preferences of a user
+type Preferences struct {
+	Theme       string `json:"theme"`
+	Timezone    string `json:"timezone"`
+	HealthEmail bool   `json:"health_email"`
+}
+
+// preferencesOf returns the preferences stored on a user
+func preferencesOf(user *entities.User) Preferences {
+	name, err := theme.Parse(user.Theme)
+	if err != nil {
+		name = theme.System
+	}
+
+	return Preferences{Theme: name, Timezone: user.Timezone, HealthEmail: user.HealthEmail}
+}
+
+// GET /api/forms/preferences - the authenticated user's dashboard preferences (assertion auth)
+func (h *FormAPIHandler) handleGetPreferences(c echo.Context) error {
+	userID, ok := c.Get("user_id").(string)
+	if !ok {
+		return h.HandleForbidden(c, "User not authenticated")
+	}
+
+	user, err := h.UserService.GetUserByID(c.Request().Context(), userID)
+	if err != nil {
+		h.Logger.Error("failed to get user preferences", "error", err, "user_id", h.Logger.SanitizeField("user_id", userID))
+
+		return h.HandleError(c, err, "Failed to get preferences")
+	}
+
+	return response.Success(c, map[string]any{"preferences": preferencesOf(user)})
+}
+
+// PUT /api/forms/preferences - changes the authenticated user's dashboard
+// preferences; fields left out keep their value (assertion auth)
+func (h *FormAPIHandler) handleUpdatePreferences(c echo.Context) error {
+	userID, ok := c.Get("user_id").(string)
+	if !ok {
+		return h.HandleForbidden(c, "User not authenticated")
+	}
+
+	var req PreferencesRequest
+	if err := c.Bind(&req); err != nil {
+		return response.ErrorResponse(c, http.StatusBadRequest, "Invalid request body")
+	}
+
+	ctx := c.Request().Context()
+
+	user, err := h.UserService.GetUserByID(ctx, userID)
+	if err != nil {
+		h.Logger.Error("failed to get user preferences", "error", err, "user_id", h.Logger.SanitizeField("user_id", userID))
+
+		return h.HandleError(c, err, "Failed to update preferences")
+	}
+
+	if req.Theme != nil {
+		name, parseErr := theme.Parse(*req.Theme)
+		if parseErr != nil {
+			return h.ResponseBuilder.BuildValidationErrorResponse(c, "theme", parseErr.Error())
+		}
+
+		user.Theme = name
+	}
+
+	if req.Timezone != nil {
+		if zoneErr := model.ValidateTimezone(*req.Timezone); zoneErr != nil {
+			return h.ResponseBuilder.BuildValidationErrorResponse(c, "timezone", zoneErr.Error())
+		}
+
+		user.Timezone = *req.Timezone
+	}
+
+	if req.HealthEmail != nil {
+		user.HealthEmail = *req.HealthEmail
+	}
+
+	if err = h.UserService.UpdateUser(ctx, user); err != nil {
+		h.Logger.Error("failed to update user preferences", "error", err, "user_id", h.Logger.SanitizeField("user_id", userID))
+
+		return h.HandleError(c, err, "Failed to update preferences")
+	}
+
+	return response.Success(c, map[string]any{"preferences": preferencesOf(user)})
+}
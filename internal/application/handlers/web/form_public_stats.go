@@ -0,0 +1,194 @@
+package web
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/labstack/echo/v4"
+
+	"github.com/goformx/goforms/internal/application/response"
+	formdomain "github.com/goformx/goforms/internal/domain/form"
+	"github.com/goformx/goforms/internal/domain/form/model"
+	appconfig "github.com/goformx/goforms/internal/infrastructure/config"
+	"github.com/goformx/goforms/internal/infrastructure/repository/common"
+)
+
+const (
+	// publicStatsCacheTTL is how long published stats are served from the cache,
+	// by this server and, through Cache-Control, by browsers and CDNs
+	publicStatsCacheTTL = 5 * time.Minute
+	// publicStatsCacheMaxEntries caps the number of forms held in the cache
+	publicStatsCacheMaxEntries = 10000
+)
+
+// publicStatsRateLimit bounds how often a client may read public stats, across
+// all forms; security.rate_limit.routes may override it
+var publicStatsRateLimit = appconfig.RateLimitTier{Requests: 60, Window: time.Minute}
+
+// publicStatsEntry is the cached stats of one form
+type publicStatsEntry struct {
+	summary formdomain.PublicStatsSummary
+	// published is false when the form does not publish stats
+	published bool
+	expires   time.Time
+}
+
+// PublicStatsCache caches the published stats of forms, so badges shown on busy
+// pages do not aggregate the submissions on every view. Entries expire after
+// the TTL and are dropped when the owner changes what is published.
+type PublicStatsCache struct {
+	formService formdomain.Service
+	ttl         time.Duration
+	mu          sync.RWMutex
+	entries     map[string]publicStatsEntry
+}
+
+// NewPublicStatsCache creates a public stats cache. A ttl of zero disables caching.
+func NewPublicStatsCache(formService formdomain.Service, ttl time.Duration) *PublicStatsCache {
+	return &PublicStatsCache{
+		formService: formService,
+		ttl:         ttl,
+		entries:     make(map[string]publicStatsEntry),
+	}
+}
+
+// Get returns the published stats of a form. published is false when the form
+// does not publish stats. Failures are not cached.
+func (c *PublicStatsCache) Get(ctx context.Context, formID string) (formdomain.PublicStatsSummary, bool, error) {
+	if c.ttl > 0 {
+		c.mu.RLock()
+		entry, found := c.entries[formID]
+		c.mu.RUnlock()
+
+		if found && time.Now().Before(entry.expires) {
+			return entry.summary, entry.published, nil
+		}
+	}
+
+	entry, err := c.compute(ctx, formID)
+	if err != nil {
+		return formdomain.PublicStatsSummary{}, false, err
+	}
+
+	if c.ttl > 0 {
+		entry.expires = time.Now().Add(c.ttl)
+		c.store(formID, entry)
+	}
+
+	return entry.summary, entry.published, nil
+}
+
+// Invalidate drops the cached stats of a form
+func (c *PublicStatsCache) Invalidate(formID string) {
+	c.mu.Lock()
+	delete(c.entries, formID)
+	c.mu.Unlock()
+}
+
+// compute loads a form and aggregates its submissions when it publishes stats.
+// Unknown forms publish nothing, so probing IDs is answered from the cache.
+func (c *PublicStatsCache) compute(ctx context.Context, formID string) (publicStatsEntry, error) {
+	form, err := c.formService.GetForm(ctx, formID)
+	if errors.Is(err, common.ErrNotFound) {
+		return publicStatsEntry{}, nil
+	}
+
+	if err != nil {
+		return publicStatsEntry{}, fmt.Errorf("get form: %w", err)
+	}
+
+	if form == nil || !form.GetPublicStats().Enabled {
+		return publicStatsEntry{}, nil
+	}
+
+	submissions, err := c.formService.ListFormSubmissions(ctx, formID)
+	if err != nil {
+		return publicStatsEntry{}, fmt.Errorf("list submissions: %w", err)
+	}
+
+	return publicStatsEntry{summary: formdomain.ComputePublicStats(form, submissions), published: true}, nil
+}
+
+// store caches an entry, sweeping expired entries when the cache is full
+func (c *PublicStatsCache) store(formID string, entry publicStatsEntry) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if len(c.entries) >= publicStatsCacheMaxEntries {
+		now := time.Now()
+		for id, cached := range c.entries {
+			if now.After(cached.expires) {
+				delete(c.entries, id)
+			}
+		}
+
+		if len(c.entries) >= publicStatsCacheMaxEntries {
+			clear(c.entries)
+		}
+	}
+
+	c.entries[formID] = entry
+}
+
+// GET /api/forms/:id/stats/public - which stats anyone may read (assertion auth)
+func (h *FormAPIHandler) handleGetPublicStats(c echo.Context) error {
+	form, err := h.getFormWithOwnershipOrError(c)
+	if err != nil || form == nil {
+		return err
+	}
+
+	return response.Success(c, form.GetPublicStats())
+}
+
+// PUT /api/forms/:id/stats/public - choose which aggregate stats are published
+// at /forms/:id/stats: the response count and the average of a rating field
+// (assertion auth). Stats stay private until enabled is set.
+func (h *FormAPIHandler) handleUpdatePublicStats(c echo.Context) error {
+	form, err := h.getFormWithOwnershipOrError(c)
+	if err != nil || form == nil {
+		return err
+	}
+
+	var stats model.PublicStats
+	if bindErr := c.Bind(&stats); bindErr != nil {
+		return h.ResponseBuilder.BuildErrorResponse(c, http.StatusBadRequest, "Invalid request body")
+	}
+
+	if setErr := form.SetPublicStats(stats); setErr != nil {
+		return h.ResponseBuilder.BuildValidationErrorResponse(c, "rating_field", setErr.Error())
+	}
+
+	if updateErr := h.FormService.UpdateForm(c.Request().Context(), form); updateErr != nil {
+		return h.HandleError(c, updateErr, "Failed to save public stats settings")
+	}
+
+	h.PublicStats.Invalidate(form.ID)
+	h.Logger.Info("public stats settings updated", "form_id", form.ID)
+
+	return response.Success(c, form.GetPublicStats())
+}
+
+// GET /forms/:id/stats - the stats the owner published, for "X people
+// responded" badges (public, rate limited). Forms that publish no stats answer
+// 404, as if the endpoint did not exist.
+func (h *FormAPIHandler) handlePublicFormStats(c echo.Context) error {
+	summary, published, err := h.PublicStats.Get(c.Request().Context(), c.Param("id"))
+	if err != nil {
+		h.Logger.Error("failed to compute public stats", "error", err, "form_id", c.Param("id"))
+
+		return h.HandleError(c, err, "Failed to get form stats")
+	}
+
+	if !published {
+		return h.ResponseBuilder.BuildErrorResponse(c, http.StatusNotFound, "Form stats are not public")
+	}
+
+	c.Response().Header().Set("Cache-Control", "public, max-age="+strconv.Itoa(int(publicStatsCacheTTL.Seconds())))
+
+	return response.Success(c, summary)
+}
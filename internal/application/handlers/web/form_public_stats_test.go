@@ -0,0 +1,50 @@
+package web_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/goformx/goforms/internal/application/handlers/web"
+	"github.com/goformx/goforms/internal/domain/form/model"
+	mockform "github.com/goformx/goforms/test/mocks/form"
+)
+
+func TestPublicStatsCache(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	formService := mockform.NewMockService(ctrl)
+	ctx := context.Background()
+
+	private := &model.Form{ID: "private"}
+	public := &model.Form{ID: "public"}
+	require.NoError(t, public.SetPublicStats(model.PublicStats{Enabled: true, ResponseCount: true}))
+
+	formService.EXPECT().GetForm(gomock.Any(), "private").Return(private, nil).Times(1)
+	formService.EXPECT().GetForm(gomock.Any(), "public").Return(public, nil).Times(2)
+	formService.EXPECT().ListFormSubmissions(gomock.Any(), "public").
+		Return([]*model.FormSubmission{{Status: model.SubmissionStatusCompleted}}, nil).Times(2)
+
+	cache := web.NewPublicStatsCache(formService, time.Minute)
+
+	for range 2 {
+		_, published, err := cache.Get(ctx, "private")
+		require.NoError(t, err)
+		assert.False(t, published, "stats are private by default")
+
+		summary, published, err := cache.Get(ctx, "public")
+		require.NoError(t, err)
+		assert.True(t, published)
+		require.NotNil(t, summary.Responses)
+		assert.Equal(t, 1, *summary.Responses)
+	}
+
+	cache.Invalidate("public")
+
+	_, published, err := cache.Get(ctx, "public")
+	require.NoError(t, err)
+	assert.True(t, published, "invalidated stats are computed again")
+}
@@ -0,0 +1,46 @@
+package web
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+
+	"github.com/goformx/goforms/internal/application/response"
+	"github.com/goformx/goforms/internal/domain/form/model"
+)
+
+// GET /api/forms/:id/redaction-policy - how redacted exports treat each column (assertion auth)
+func (h *FormAPIHandler) handleGetRedactionPolicy(c echo.Context) error {
+	form, err := h.getFormWithOwnershipOrError(c)
+	if err != nil || form == nil {
+		return err
+	}
+
+	return response.Success(c, form.GetRedactionPolicy())
+}
+
+// PUT /api/forms/:id/redaction-policy - replaces the form's redaction policy,
+// applied to ?redact=true exports and emailed report attachments (assertion auth)
+func (h *FormAPIHandler) handleUpdateRedactionPolicy(c echo.Context) error {
+	form, err := h.getFormWithOwnershipOrError(c)
+	if err != nil || form == nil {
+		return err
+	}
+
+	var policy model.RedactionPolicy
+	if bindErr := c.Bind(&policy); bindErr != nil {
+		return h.ResponseBuilder.BuildErrorResponse(c, http.StatusBadRequest, "Invalid request body")
+	}
+
+	if setErr := form.SetRedactionPolicy(policy); setErr != nil {
+		return h.ResponseBuilder.BuildValidationErrorResponse(c, "policy", setErr.Error())
+	}
+
+	if updateErr := h.FormService.UpdateForm(c.Request().Context(), form); updateErr != nil {
+		return h.HandleError(c, updateErr, "Failed to save redaction policy")
+	}
+
+	h.Logger.Info("redaction policy updated", "form_id", form.ID)
+
+	return response.Success(c, form.GetRedactionPolicy())
+}
@@ -0,0 +1,213 @@
+package web
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/labstack/echo/v4"
+
+	"github.com/goformx/goforms/internal/application/response"
+	domainerrors "github.com/goformx/goforms/internal/domain/common/errors"
+	"github.com/goformx/goforms/internal/domain/form/model"
+)
+
+// ReportRequest is the payload for creating or updating a scheduled report
+type ReportRequest struct {
+	Name       string   `json:"name"`
+	Cron       string   `json:"cron"`
+	Timezone   string   `json:"timezone"`
+	Recipients []string `json:"recipients"`
+	Format     string   `json:"format"`
+	Active     *bool    `json:"active"`
+}
+
+// apply copies request fields onto a report schedule. A report without a
+// time zone runs in formTimezone.
+func (r *ReportRequest) apply(report *model.ReportSchedule, formTimezone *time.Location) {
+	report.Name = r.Name
+	report.Cron = r.Cron
+	report.Timezone = r.Timezone
+	report.Format = r.Format
+	report.SetRecipients(r.Recipients)
+
+	if report.Timezone == "" {
+		report.Timezone = formTimezone.String()
+	}
+
+	if r.Active != nil {
+		report.Active = *r.Active
+	}
+}
+
+// reportResponse converts a report schedule to its API representation
+func reportResponse(report *model.ReportSchedule) map[string]any {
+	formatTime := func(t *time.Time) any {
+		if t == nil {
+			return nil
+		}
+
+		return t.UTC().Format(time.RFC3339)
+	}
+
+	return map[string]any{
+		"id":          report.ID,
+		"form_id":     report.FormID,
+		"name":        report.Name,
+		"cron":        report.Cron,
+		"timezone":    report.Timezone,
+		"recipients":  report.GetRecipients(),
+		"format":      report.Format,
+		"active":      report.Active,
+		"last_run_at": formatTime(report.LastRunAt),
+		"next_run_at": formatTime(report.NextRunAt),
+		"created_at":  report.CreatedAt.Format(time.RFC3339),
+		"updated_at":  report.UpdatedAt.Format(time.RFC3339),
+	}
+}
+
+// GET /api/forms/:id/reports - list scheduled reports (assertion auth)
+func (h *FormAPIHandler) handleListReports(c echo.Context) error {
+	form, err := h.getFormWithOwnershipOrError(c)
+	if err != nil || form == nil {
+		return err
+	}
+
+	reports, err := h.ReportService.ListReports(c.Request().Context(), form.ID)
+	if err != nil {
+		h.Logger.Error("failed to list reports", "error", err, "form_id", form.ID)
+
+		return h.HandleError(c, err, "Failed to list reports")
+	}
+
+	items := make([]map[string]any, len(reports))
+	for i, report := range reports {
+		items[i] = reportResponse(report)
+	}
+
+	return response.Success(c, map[string]any{
+		"reports": items,
+		"count":   len(items),
+	})
+}
+
+// POST /api/forms/:id/reports - create a scheduled report (assertion auth)
+func (h *FormAPIHandler) handleCreateReport(c echo.Context) error {
+	form, err := h.getFormWithOwnershipOrError(c)
+	if err != nil || form == nil {
+		return err
+	}
+
+	var req ReportRequest
+	if bindErr := c.Bind(&req); bindErr != nil {
+		return h.ResponseBuilder.BuildErrorResponse(c, http.StatusBadRequest, "Invalid request body")
+	}
+
+	report := &model.ReportSchedule{
+		FormID: form.ID,
+		UserID: form.UserID,
+		Active: true,
+	}
+	req.apply(report, h.formLocation(c.Request().Context(), form))
+
+	if createErr := h.ReportService.CreateReport(c.Request().Context(), report); createErr != nil {
+		return h.handleReportError(c, createErr, form.ID)
+	}
+
+	return c.JSON(http.StatusCreated, response.APIResponse{
+		Success: true,
+		Message: "Report created successfully",
+		Data:    reportResponse(report),
+	})
+}
+
+// GET /api/forms/:id/reports/:rid - get a scheduled report (assertion auth)
+func (h *FormAPIHandler) handleGetReport(c echo.Context) error {
+	form, err := h.getFormWithOwnershipOrError(c)
+	if err != nil || form == nil {
+		return err
+	}
+
+	report, err := h.ReportService.GetReport(c.Request().Context(), form.ID, c.Param("rid"))
+	if err != nil {
+		return h.handleReportError(c, err, form.ID)
+	}
+
+	return response.Success(c, reportResponse(report))
+}
+
+// PUT /api/forms/:id/reports/:rid - update a scheduled report (assertion auth)
+func (h *FormAPIHandler) handleUpdateReport(c echo.Context) error {
+	form, err := h.getFormWithOwnershipOrError(c)
+	if err != nil || form == nil {
+		return err
+	}
+
+	report, err := h.ReportService.GetReport(c.Request().Context(), form.ID, c.Param("rid"))
+	if err != nil {
+		return h.handleReportError(c, err, form.ID)
+	}
+
+	var req ReportRequest
+	if bindErr := c.Bind(&req); bindErr != nil {
+		return h.ResponseBuilder.BuildErrorResponse(c, http.StatusBadRequest, "Invalid request body")
+	}
+
+	req.apply(report, h.formLocation(c.Request().Context(), form))
+
+	if updateErr := h.ReportService.UpdateReport(c.Request().Context(), report); updateErr != nil {
+		return h.handleReportError(c, updateErr, form.ID)
+	}
+
+	return response.Success(c, reportResponse(report))
+}
+
+// DELETE /api/forms/:id/reports/:rid - delete a scheduled report (assertion auth)
+func (h *FormAPIHandler) handleDeleteReport(c echo.Context) error {
+	form, err := h.getFormWithOwnershipOrError(c)
+	if err != nil || form == nil {
+		return err
+	}
+
+	if deleteErr := h.ReportService.DeleteReport(c.Request().Context(), form.ID, c.Param("rid")); deleteErr != nil {
+		return h.handleReportError(c, deleteErr, form.ID)
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
+
+// POST /api/forms/:id/reports/:rid/run - send a report immediately (assertion auth)
+func (h *FormAPIHandler) handleRunReport(c echo.Context) error {
+	form, err := h.getFormWithOwnershipOrError(c)
+	if err != nil || form == nil {
+		return err
+	}
+
+	report, err := h.ReportService.GetReport(c.Request().Context(), form.ID, c.Param("rid"))
+	if err != nil {
+		return h.handleReportError(c, err, form.ID)
+	}
+
+	if sendErr := h.ReportDispatcher.Send(c.Request().Context(), report, time.Now().UTC()); sendErr != nil {
+		h.Logger.Error("failed to send report", "error", sendErr, "form_id", form.ID, "report_id", report.ID)
+
+		return h.ResponseBuilder.BuildErrorResponse(c, http.StatusBadGateway, "Failed to send report")
+	}
+
+	return response.Success(c, reportResponse(report))
+}
+
+// handleReportError maps report service errors to HTTP responses
+func (h *FormAPIHandler) handleReportError(c echo.Context, err error, formID string) error {
+	if errors.Is(err, model.ErrReportNotFound) {
+		return h.ResponseBuilder.BuildNotFoundResponse(c, "Report")
+	}
+
+	if domainErr := domainerrors.GetDomainError(err); domainErr != nil && domainErr.Code == domainerrors.ErrCodeValidation {
+		return h.ResponseBuilder.BuildValidationErrorResponse(c, "report", domainErr.Message)
+	}
+
+	h.Logger.Error("report operation failed", "error", err, "form_id", formID)
+
+	return h.HandleError(c, err, "Failed to process report")
+}
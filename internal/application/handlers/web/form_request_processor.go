@@ -80,7 +80,7 @@ func (p *FormRequestProcessorImpl) ProcessUpdateRequest(c echo.Context) (*FormUp
 	}
 
 	// Validate CORS origins when publishing
-	if req.Status == "published" && strings.TrimSpace(req.CorsOrigins) == "" {
+	if req.Status == model.FormStatusPublished && strings.TrimSpace(req.CorsOrigins) == "" {
 		return nil, errors.New("CORS origins are required when publishing a form")
 	}
 
@@ -162,24 +162,37 @@ func (p *FormRequestProcessorImpl) validateUpdateRequest(req *FormUpdateRequest)
 
 	// Validate status if provided
 	if req.Status != "" {
-		validStatuses := []string{"draft", "published", "archived"}
-		isValid := false
+		if err := model.ValidateFormStatus(req.Status); err != nil {
+			return err
+		}
+
+		// Require CORS origins when publishing
+		if req.Status == model.FormStatusPublished && req.CorsOrigins == "" {
+			return errors.New("CORS origins are required when publishing a form")
+		}
+	}
 
-		for _, status := range validStatuses {
-			if req.Status == status {
-				isValid = true
+	if req.SubmissionWriteMode != nil {
+		if err := model.ValidateSubmissionWriteMode(*req.SubmissionWriteMode); err != nil {
+			return err
+		}
+	}
 
-				break
-			}
+	if req.ThrottleMode != nil {
+		if err := model.ValidateThrottleMode(*req.ThrottleMode); err != nil {
+			return err
 		}
+	}
 
-		if !isValid {
-			return errors.New("invalid form status")
+	if req.Timezone != nil {
+		if err := model.ValidateTimezone(*req.Timezone); err != nil {
+			return err
 		}
+	}
 
-		// Require CORS origins when publishing
-		if req.Status == "published" && req.CorsOrigins == "" {
-			return errors.New("CORS origins are required when publishing a form")
+	if req.Extensions != nil {
+		if err := model.ValidateExtensionNames(*req.Extensions); err != nil {
+			return err
 		}
 	}
 
@@ -48,39 +48,61 @@ func (b *FormResponseBuilderImpl) BuildSchemaResponse(c echo.Context, schema mod
 	})
 }
 
-// BuildSubmissionResponse builds a submission response
-func (b *FormResponseBuilderImpl) BuildSubmissionResponse(c echo.Context, submission *model.FormSubmission) error {
+// BuildSubmissionResponse builds a submission response, with the form's
+// confirmation message when it has one
+func (b *FormResponseBuilderImpl) BuildSubmissionResponse(c echo.Context, submission *model.FormSubmission, confirmation string) error {
+	data := map[string]any{
+		"submission_id": submission.ID,
+		"status":        submission.Status,
+		"submitted_at":  submission.SubmittedAt.Format(time.RFC3339),
+	}
+
+	if confirmation != "" {
+		data["confirmation_message"] = confirmation
+	}
+
 	return c.JSON(http.StatusOK, response.APIResponse{
 		Success: true,
 		Message: "Form submitted successfully",
-		Data: map[string]any{
-			"submission_id": submission.ID,
-			"status":        submission.Status,
-			"submitted_at":  submission.SubmittedAt.Format(time.RFC3339),
-		},
+		Data:    data,
 	})
 }
 
 // BuildFormResponse builds a form response
 func (b *FormResponseBuilderImpl) BuildFormResponse(c echo.Context, form *model.Form) error {
+	projection, err := response.ProjectionFromRequest(c)
+	if err != nil {
+		return b.BuildValidationErrorResponse(c, response.FieldsParam, err.Error())
+	}
+
 	return c.JSON(http.StatusOK, response.APIResponse{
 		Success: true,
 		Data: map[string]any{
-			"form": map[string]any{
-				"id":          form.ID,
-				"title":       form.Title,
-				"description": form.Description,
-				"status":      form.Status,
-				"schema":      form.Schema,
-				"created_at":  form.CreatedAt.Format(time.RFC3339),
-				"updated_at":  form.UpdatedAt.Format(time.RFC3339),
-			},
+			"form": projection.Apply(map[string]any{
+				"id":                    form.ID,
+				"title":                 form.Title,
+				"description":           form.Description,
+				"status":                form.Status,
+				"schema":                form.Schema,
+				"version":               form.Version,
+				"created_at":            form.CreatedAt.Format(time.RFC3339),
+				"updated_at":            form.UpdatedAt.Format(time.RFC3339),
+				"submission_write_mode": form.SubmissionWriteMode,
+				"throttle_mode":         form.ThrottleMode,
+				"timezone":              form.Timezone,
+				"extensions":            form.EnabledExtensions(),
+			}),
 		},
 	})
 }
 
 // BuildFormListResponse builds a form list response
 func (b *FormResponseBuilderImpl) BuildFormListResponse(c echo.Context, forms []*model.Form) error {
+	projection, err := response.ProjectionFromRequest(c)
+	if err != nil {
+		return b.BuildValidationErrorResponse(c, response.FieldsParam, err.Error())
+	}
+
 	formData := make([]map[string]any, len(forms))
 	for i, form := range forms {
 		formData[i] = map[string]any{
@@ -88,6 +110,7 @@ func (b *FormResponseBuilderImpl) BuildFormListResponse(c echo.Context, forms []
 			"title":       form.Title,
 			"description": form.Description,
 			"status":      form.Status,
+			"version":     form.Version,
 			"created_at":  form.CreatedAt.Format(time.RFC3339),
 			"updated_at":  form.UpdatedAt.Format(time.RFC3339),
 		}
@@ -96,7 +119,7 @@ func (b *FormResponseBuilderImpl) BuildFormListResponse(c echo.Context, forms []
 	return c.JSON(http.StatusOK, response.APIResponse{
 		Success: true,
 		Data: map[string]any{
-			"forms": formData,
+			"forms": projection.ApplyAll(formData),
 			"count": len(forms),
 		},
 	})
@@ -106,23 +129,33 @@ func (b *FormResponseBuilderImpl) BuildFormListResponse(c echo.Context, forms []
 func (b *FormResponseBuilderImpl) BuildSubmissionListResponse(
 	c echo.Context,
 	submissions []*model.FormSubmission,
+	loc *time.Location,
 ) error {
+	projection, err := response.ProjectionFromRequest(c)
+	if err != nil {
+		return b.BuildValidationErrorResponse(c, response.FieldsParam, err.Error())
+	}
+
 	submissionData := make([]map[string]any, len(submissions))
 	for i, submission := range submissions {
 		submissionData[i] = map[string]any{
 			"id":           submission.ID,
 			"form_id":      submission.FormID,
 			"status":       submission.Status,
-			"submitted_at": submission.SubmittedAt.Format(time.RFC3339),
+			"submitted_at": submission.SubmittedAt.In(loc).Format(time.RFC3339),
 			"data":         submission.Data,
+			"encrypted":    submission.Encrypted,
+			"tags":         submissionTags(submission),
+			"test":         submission.Test,
 		}
 	}
 
 	return c.JSON(http.StatusOK, response.APIResponse{
 		Success: true,
 		Data: map[string]any{
-			"submissions": submissionData,
+			"submissions": projection.ApplyAll(submissionData),
 			"count":       len(submissions),
+			"timezone":    loc.String(),
 		},
 	})
 }
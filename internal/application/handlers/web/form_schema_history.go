@@ -0,0 +1,83 @@
+package web
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+
+	"github.com/goformx/goforms/internal/application/response"
+	"github.com/goformx/goforms/internal/domain/form/model"
+)
+
+// GET /api/forms/:id/schema/history - how many schema edits can be undone and
+// redone (assertion auth)
+func (h *FormAPIHandler) handleSchemaHistory(c echo.Context) error {
+	form, err := h.getFormWithOwnershipOrError(c)
+	if err != nil || form == nil {
+		return err
+	}
+
+	history, err := h.SchemaHistory.History(c.Request().Context(), form.ID)
+	if err != nil {
+		h.Logger.Error("failed to load schema history", "error", err, "form_id", form.ID)
+
+		return h.HandleError(c, err, "Failed to load schema history")
+	}
+
+	return response.Success(c, history)
+}
+
+// POST /api/forms/:id/schema/undo - restores the schema the form had before its
+// latest edit (assertion auth)
+func (h *FormAPIHandler) handleSchemaUndo(c echo.Context) error {
+	return h.stepSchemaHistory(c, h.SchemaHistory.Undo)
+}
+
+// POST /api/forms/:id/schema/redo - reapplies the latest undone schema edit
+// (assertion auth)
+func (h *FormAPIHandler) handleSchemaRedo(c echo.Context) error {
+	return h.stepSchemaHistory(c, h.SchemaHistory.Redo)
+}
+
+// stepSchemaHistory undoes or redoes a schema edit and sends the new schema to
+// the form's collaborative editors. An If-Match header makes the step apply only
+// to the form version the builder shows.
+func (h *FormAPIHandler) stepSchemaHistory(c echo.Context, step func(context.Context, *model.Form) error) error {
+	form, err := h.getFormWithOwnershipOrError(c)
+	if err != nil || form == nil {
+		return err
+	}
+
+	expectedVersion, hasPrecondition, versionErr := expectedFormVersion(c, nil)
+	if versionErr != nil {
+		return h.ResponseBuilder.BuildErrorResponse(c, http.StatusBadRequest, "Invalid If-Match header")
+	}
+
+	if hasPrecondition && expectedVersion != form.Version {
+		return h.buildVersionConflictResponse(c, form)
+	}
+
+	if stepErr := step(c.Request().Context(), form); stepErr != nil {
+		switch {
+		case errors.Is(stepErr, model.ErrNothingToUndo), errors.Is(stepErr, model.ErrNothingToRedo):
+			return h.ResponseBuilder.BuildErrorResponse(c, http.StatusConflict, stepErr.Error())
+		case errors.Is(stepErr, model.ErrFormVersionConflict):
+			if current, getErr := h.FormService.GetForm(c.Request().Context(), form.ID); getErr == nil && current != nil {
+				return h.buildVersionConflictResponse(c, current)
+			}
+		}
+
+		h.Logger.Error("failed to step schema history", "error", stepErr, "form_id", form.ID)
+
+		return h.HandleError(c, stepErr, "Failed to update form schema")
+	}
+
+	userID, _ := c.Get("user_id").(string)
+	h.Collab.broadcastSchema(form.ID, userID, form)
+
+	setFormETag(c, form)
+
+	return h.ResponseBuilder.BuildFormResponse(c, form)
+}
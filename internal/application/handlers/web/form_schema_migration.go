@@ -0,0 +1,64 @@
+package web
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+
+	"github.com/goformx/goforms/internal/application/response"
+	domainerrors "github.com/goformx/goforms/internal/domain/common/errors"
+	"github.com/goformx/goforms/internal/domain/form/model"
+)
+
+// SchemaMigrationRequest is the body of POST /api/forms/:id/schema/migration
+type SchemaMigrationRequest struct {
+	Rules []model.MigrationRule `json:"rules"`
+	// Apply saves the migrated submissions; without it the migration is a dry run
+	Apply bool `json:"apply"`
+}
+
+// GET /api/forms/:id/schema/migration - lists the stored answers that no longer
+// fit the form's schema, with suggested migration rules (assertion auth)
+func (h *FormAPIHandler) handleSchemaMigrationPlan(c echo.Context) error {
+	form, err := h.getFormWithOwnershipOrError(c)
+	if err != nil || form == nil {
+		return err
+	}
+
+	plan, err := h.SchemaMigrations.Plan(c.Request().Context(), form)
+	if err != nil {
+		h.Logger.Error("failed to plan schema migration", "error", err, "form_id", form.ID)
+
+		return h.HandleError(c, err, "Failed to check submissions")
+	}
+
+	return response.Success(c, plan)
+}
+
+// POST /api/forms/:id/schema/migration - rewrites stored answers with rename,
+// cast and split rules (assertion auth). It is a dry run reporting what would
+// change unless the body sets apply.
+func (h *FormAPIHandler) handleSchemaMigration(c echo.Context) error {
+	form, err := h.getFormWithOwnershipOrError(c)
+	if err != nil || form == nil {
+		return err
+	}
+
+	var req SchemaMigrationRequest
+	if bindErr := c.Bind(&req); bindErr != nil {
+		return h.ResponseBuilder.BuildErrorResponse(c, http.StatusBadRequest, "Invalid request body")
+	}
+
+	result, err := h.SchemaMigrations.Migrate(c.Request().Context(), form, req.Rules, req.Apply)
+	if err != nil {
+		if domainErr := domainerrors.GetDomainError(err); domainErr != nil && domainErr.Code == domainerrors.ErrCodeValidation {
+			return h.ResponseBuilder.BuildValidationErrorResponse(c, "rules", domainErr.Message)
+		}
+
+		h.Logger.Error("failed to migrate submissions", "error", err, "form_id", form.ID)
+
+		return h.HandleError(c, err, "Failed to migrate submissions")
+	}
+
+	return response.Success(c, result)
+}
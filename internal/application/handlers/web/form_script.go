@@ -0,0 +1,307 @@
+package web
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+
+	"github.com/goformx/goforms/internal/application/response"
+	"github.com/goformx/goforms/internal/domain/audit"
+	"github.com/goformx/goforms/internal/domain/form/expression"
+	"github.com/goformx/goforms/internal/domain/form/model"
+	"github.com/goformx/goforms/internal/infrastructure/config"
+	"github.com/goformx/goforms/internal/infrastructure/logging"
+)
+
+// errScriptTooLarge is returned for a script over form.scripts.max_size
+var errScriptTooLarge = errors.New("script is too large")
+
+// SubmissionScripts compiles and runs form submission scripts within the
+// configured limits and records rejections and failures in the audit log
+type SubmissionScripts struct {
+	cfg    config.ScriptsConfig
+	audit  audit.Service
+	logger logging.Logger
+}
+
+// NewSubmissionScripts creates the script runner, or returns nil when
+// form.scripts is disabled
+func NewSubmissionScripts(cfg config.ScriptsConfig, auditService audit.Service, logger logging.Logger) *SubmissionScripts {
+	if !cfg.Enabled {
+		return nil
+	}
+
+	return &SubmissionScripts{cfg: cfg, audit: auditService, logger: logger}
+}
+
+// Compile checks the size of source and compiles it
+func (s *SubmissionScripts) Compile(source string) (*expression.Program, error) {
+	if len(source) > s.cfg.MaxSize {
+		return nil, fmt.Errorf("%w: at most %d bytes", errScriptTooLarge, s.cfg.MaxSize)
+	}
+
+	program, err := expression.Compile(source)
+	if err != nil {
+		return nil, fmt.Errorf("compile script: %w", err)
+	}
+
+	return program, nil
+}
+
+// Run compiles and runs source against a copy of data
+func (s *SubmissionScripts) Run(ctx context.Context, source string, data model.JSON) (expression.Result, error) {
+	program, err := s.Compile(source)
+	if err != nil {
+		return expression.Result{}, err
+	}
+
+	result, err := program.Run(ctx, data.Clone(), expression.Limits{
+		MaxSteps:  s.cfg.MaxSteps,
+		MaxMemory: s.cfg.MaxMemory,
+		Timeout:   s.cfg.Timeout,
+	})
+	if err != nil {
+		return result, fmt.Errorf("run script: %w", err)
+	}
+
+	return result, nil
+}
+
+// record writes an audit entry; a failed write is logged
+func (s *SubmissionScripts) record(ctx context.Context, entry *audit.Entry) {
+	if err := s.audit.Record(ctx, entry); err != nil {
+		s.logger.Error("failed to record form script audit entry",
+			"form_id", entry.TargetID, "action", entry.Action, "error", err)
+	}
+}
+
+// runSubmissionScript runs the form's script on the submitted data. It returns
+// the data to validate, or handled with the response written when the script
+// rejected the submission or failed under the reject policy. A failure under
+// the continue policy keeps the submitted data.
+func (h *FormAPIHandler) runSubmissionScript(c echo.Context, form *model.Form, data model.JSON) (model.JSON, bool, error) {
+	if h.Scripts == nil || !form.HasScript() {
+		return data, false, nil
+	}
+
+	ctx := c.Request().Context()
+
+	result, err := h.Scripts.Run(ctx, form.ScriptSource(), data)
+	if err != nil {
+		h.Logger.Warn("submission script failed", "form_id", form.ID, "error", err)
+		h.Scripts.record(ctx, &audit.Entry{
+			ActorID:    form.UserID,
+			Action:     audit.ActionFormScriptFailed,
+			TargetType: audit.TargetForm,
+			TargetID:   form.ID,
+			Details:    map[string]any{"error": err.Error(), "steps": result.Steps},
+			IPAddress:  c.RealIP(),
+		})
+
+		if h.Scripts.cfg.OnError != "reject" {
+			return data, false, nil
+		}
+
+		return nil, true, c.JSON(http.StatusUnprocessableEntity, response.APIResponse{
+			Success: false,
+			Message: "The submission could not be processed.",
+			Data:    map[string]any{"code": "script_failed"},
+		})
+	}
+
+	if result.Rejected {
+		h.Logger.Info("submission rejected by form script", "form_id", form.ID)
+		h.Scripts.record(ctx, &audit.Entry{
+			ActorID:    form.UserID,
+			Action:     audit.ActionFormScriptRejected,
+			TargetType: audit.TargetForm,
+			TargetID:   form.ID,
+			Details:    map[string]any{"message": result.Message, "steps": result.Steps},
+			IPAddress:  c.RealIP(),
+		})
+
+		message := result.Message
+		if message == "" {
+			message = "The submission was rejected."
+		}
+
+		return nil, true, c.JSON(http.StatusUnprocessableEntity, response.APIResponse{
+			Success: false,
+			Message: message,
+			Data:    map[string]any{"code": "submission_rejected"},
+		})
+	}
+
+	return model.JSON(result.Data), false, nil
+}
+
+// FormScriptRequest is the body of PUT /api/forms/:id/script
+type FormScriptRequest struct {
+	Source string `json:"source"`
+}
+
+// FormScriptTestRequest is the body of POST /api/forms/:id/script/test. Source
+// defaults to the form's saved script.
+type FormScriptTestRequest struct {
+	Source *string    `json:"source"`
+	Data   model.JSON `json:"data"`
+}
+
+// GET /api/forms/:id/script - the form's submission script (assertion auth)
+func (h *FormAPIHandler) handleGetFormScript(c echo.Context) error {
+	form, err := h.getFormWithOwnershipOrError(c)
+	if err != nil || form == nil {
+		return err
+	}
+
+	return response.Success(c, map[string]any{
+		"enabled": h.Scripts != nil,
+		"source":  form.ScriptSource(),
+	})
+}
+
+// PUT /api/forms/:id/script - compiles and saves the form's submission script (assertion auth)
+func (h *FormAPIHandler) handleUpdateFormScript(c echo.Context) error {
+	form, err := h.getFormWithOwnershipOrError(c)
+	if err != nil || form == nil {
+		return err
+	}
+
+	if h.Scripts == nil {
+		return response.ErrorResponse(c, http.StatusConflict, "Submission scripts are disabled")
+	}
+
+	var req FormScriptRequest
+	if bindErr := c.Bind(&req); bindErr != nil {
+		return h.ResponseBuilder.BuildErrorResponse(c, http.StatusBadRequest, "Invalid request body")
+	}
+
+	if req.Source == "" {
+		return h.ResponseBuilder.BuildValidationErrorResponse(c, "source", "Script source is required")
+	}
+
+	if _, compileErr := h.Scripts.Compile(req.Source); compileErr != nil {
+		return scriptError(c, compileErr)
+	}
+
+	form.SetScriptSource(req.Source)
+
+	if updateErr := h.FormService.UpdateForm(c.Request().Context(), form); updateErr != nil {
+		return h.HandleError(c, updateErr, "Failed to save form script")
+	}
+
+	h.Logger.Info("form script updated", "form_id", form.ID, "size", len(req.Source))
+	h.recordScriptChange(c, form, audit.ActionFormScriptUpdated, map[string]any{"size": len(req.Source)})
+
+	return response.Success(c, map[string]any{"enabled": true, "source": form.ScriptSource()})
+}
+
+// DELETE /api/forms/:id/script - removes the form's submission script (assertion auth)
+func (h *FormAPIHandler) handleDeleteFormScript(c echo.Context) error {
+	form, err := h.getFormWithOwnershipOrError(c)
+	if err != nil || form == nil {
+		return err
+	}
+
+	if !form.HasScript() {
+		return response.Success(c, map[string]any{"enabled": h.Scripts != nil, "source": ""})
+	}
+
+	form.SetScriptSource("")
+
+	if updateErr := h.FormService.UpdateForm(c.Request().Context(), form); updateErr != nil {
+		return h.HandleError(c, updateErr, "Failed to remove form script")
+	}
+
+	h.Logger.Info("form script removed", "form_id", form.ID)
+	h.recordScriptChange(c, form, audit.ActionFormScriptRemoved, nil)
+
+	return response.Success(c, map[string]any{"enabled": h.Scripts != nil, "source": ""})
+}
+
+// POST /api/forms/:id/script/test - runs a script against sample data without
+// storing anything (assertion auth)
+func (h *FormAPIHandler) handleTestFormScript(c echo.Context) error {
+	form, err := h.getFormWithOwnershipOrError(c)
+	if err != nil || form == nil {
+		return err
+	}
+
+	if h.Scripts == nil {
+		return response.ErrorResponse(c, http.StatusConflict, "Submission scripts are disabled")
+	}
+
+	var req FormScriptTestRequest
+	if bindErr := c.Bind(&req); bindErr != nil {
+		return h.ResponseBuilder.BuildErrorResponse(c, http.StatusBadRequest, "Invalid request body")
+	}
+
+	source := form.ScriptSource()
+	if req.Source != nil {
+		source = *req.Source
+	}
+
+	if source == "" {
+		return h.ResponseBuilder.BuildValidationErrorResponse(c, "source", "Script source is required")
+	}
+
+	result, runErr := h.Scripts.Run(c.Request().Context(), source, req.Data)
+	if runErr != nil {
+		return scriptError(c, runErr)
+	}
+
+	return response.Success(c, map[string]any{
+		"data":     result.Data,
+		"rejected": result.Rejected,
+		"message":  result.Message,
+		"steps":    result.Steps,
+	})
+}
+
+// recordScriptChange writes an audit entry for a change to a form's script; a failed write is logged
+func (h *FormAPIHandler) recordScriptChange(c echo.Context, form *model.Form, action string, details map[string]any) {
+	actorID, ok := c.Get("user_id").(string)
+	if !ok {
+		actorID = form.UserID
+	}
+
+	h.Scripts.record(c.Request().Context(), &audit.Entry{
+		ActorID:    actorID,
+		Action:     action,
+		TargetType: audit.TargetForm,
+		TargetID:   form.ID,
+		Details:    details,
+		IPAddress:  c.RealIP(),
+	})
+}
+
+// scriptError responds to a script that is too large, does not compile or
+// fails in a dry run, with the position of the error when there is one
+func scriptError(c echo.Context, err error) error {
+	data := map[string]any{"code": "script_invalid"}
+
+	var scriptErr *expression.Error
+	if errors.As(err, &scriptErr) {
+		data["line"] = scriptErr.Line
+		data["column"] = scriptErr.Col
+		err = scriptErr
+	}
+
+	switch {
+	case errors.Is(err, errScriptTooLarge):
+		data["code"] = "script_too_large"
+	case errors.Is(err, expression.ErrStepLimit), errors.Is(err, expression.ErrMemoryLimit), errors.Is(err, expression.ErrTimeout):
+		data["code"] = "script_limit_exceeded"
+	case errors.Is(err, expression.ErrEvaluation):
+		data["code"] = "script_failed"
+	}
+
+	return c.JSON(http.StatusUnprocessableEntity, response.APIResponse{
+		Success: false,
+		Message: err.Error(),
+		Data:    data,
+	})
+}
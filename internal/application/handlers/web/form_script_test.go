@@ -0,0 +1,46 @@
+package web_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/goformx/goforms/internal/application/handlers/web"
+	"github.com/goformx/goforms/internal/domain/form/expression"
+	"github.com/goformx/goforms/internal/domain/form/model"
+	"github.com/goformx/goforms/internal/infrastructure/config"
+)
+
+func TestSubmissionScripts_Run(t *testing.T) {
+	scripts := web.NewSubmissionScripts(config.ScriptsConfig{
+		Enabled:   true,
+		MaxSize:   256,
+		MaxSteps:  1000,
+		MaxMemory: 1 << 16,
+		Timeout:   time.Second,
+		OnError:   "continue",
+	}, nil, nil)
+	require.NotNil(t, scripts)
+
+	data := model.JSON{"first": "Ada", "last": "Lovelace"}
+
+	result, err := scripts.Run(context.Background(), `data.name = data.first + " " + data.last`, data)
+	require.NoError(t, err)
+	assert.Equal(t, "Ada Lovelace", result.Data["name"])
+	assert.NotContains(t, data, "name", "the submitted data is not changed")
+
+	_, err = scripts.Run(context.Background(), strings.Repeat(" ", 257), data)
+	require.Error(t, err)
+
+	_, err = scripts.Run(context.Background(), `let n = 0; for (const a of split(data.first, "")) { for (const b of keys(data)) { n += 1 } }`,
+		model.JSON{"first": strings.Repeat("x", 1000)})
+	require.ErrorIs(t, err, expression.ErrStepLimit)
+}
+
+func TestNewSubmissionScripts_Disabled(t *testing.T) {
+	assert.Nil(t, web.NewSubmissionScripts(config.ScriptsConfig{}, nil, nil))
+}
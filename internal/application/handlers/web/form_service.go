@@ -5,6 +5,7 @@ package web
 import (
 	"context"
 	"fmt"
+	"reflect"
 	"strings"
 
 	formdomain "github.com/goformx/goforms/internal/domain/form"
@@ -15,13 +16,20 @@ import (
 // FormService handles form-related business logic
 type FormService struct {
 	formService formdomain.Service
+	history     formdomain.SchemaHistoryService
 	logger      logging.Logger
 }
 
-// NewFormService creates a new FormService instance
-func NewFormService(formService formdomain.Service, logger logging.Logger) *FormService {
+// NewFormService creates a new FormService instance. Schema edits are recorded
+// in history so they can be undone.
+func NewFormService(
+	formService formdomain.Service,
+	history formdomain.SchemaHistoryService,
+	logger logging.Logger,
+) *FormService {
 	return &FormService{
 		formService: formService,
+		history:     history,
 		logger:      logger,
 	}
 }
@@ -53,23 +61,79 @@ func (s *FormService) CreateForm(ctx context.Context, userID string, req *FormCr
 func (s *FormService) UpdateForm(ctx context.Context, form *model.Form, req *FormUpdateRequest) error {
 	form.Title = req.Title
 	form.Description = req.Description
-	form.Status = req.Status
+
+	// A change of status goes through the form lifecycle
+	var transition model.FormTransition
+
+	if req.Status != "" && req.Status != form.Status {
+		next, err := model.TransitionTo(form.Status, req.Status)
+		if err != nil {
+			return fmt.Errorf("change form status: %w", err)
+		}
+
+		transition = next
+	}
 
 	if req.CorsOrigins != "" {
 		form.CorsOrigins = model.JSON{"origins": parseCSV(req.CorsOrigins)}
 	}
 
+	previousSchema := form.Schema
 	if req.Schema != nil {
 		form.Schema = req.Schema
 	}
 
-	if err := s.formService.UpdateForm(ctx, form); err != nil {
+	if req.SubmissionWriteMode != nil {
+		form.SubmissionWriteMode = *req.SubmissionWriteMode
+	}
+
+	if req.ThrottleMode != nil {
+		form.ThrottleMode = *req.ThrottleMode
+	}
+
+	if req.Timezone != nil {
+		form.Timezone = *req.Timezone
+	}
+
+	if req.Extensions != nil {
+		if err := form.SetEnabledExtensions(*req.Extensions); err != nil {
+			return fmt.Errorf("set form extensions: %w", err)
+		}
+	}
+
+	if transition != "" {
+		if err := s.formService.TransitionForm(ctx, form, transition); err != nil {
+			return fmt.Errorf("update form: %w", err)
+		}
+	} else if err := s.formService.UpdateForm(ctx, form); err != nil {
 		return fmt.Errorf("update form: %w", err)
 	}
 
+	if req.Schema != nil {
+		recordSchemaEdit(ctx, s.history, form.ID, previousSchema, form.Schema, s.logger)
+	}
+
 	return nil
 }
 
+// recordSchemaEdit keeps the schema a saved edit replaced in the form's undo
+// history. The edit is saved already, so a failure is only logged.
+func recordSchemaEdit(
+	ctx context.Context,
+	history formdomain.SchemaHistoryService,
+	formID string,
+	previous, current model.JSON,
+	logger logging.Logger,
+) {
+	if history == nil || reflect.DeepEqual(previous, current) {
+		return
+	}
+
+	if err := history.Record(ctx, formID, previous); err != nil {
+		logger.Error("failed to record schema edit", "form_id", formID, "error", err)
+	}
+}
+
 // DeleteForm deletes a form by ID
 func (s *FormService) DeleteForm(ctx context.Context, formID string) error {
 	if err := s.formService.DeleteForm(ctx, formID); err != nil {
@@ -0,0 +1,201 @@
+package web
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/labstack/echo/v4"
+
+	"github.com/goformx/goforms/internal/application/response"
+	domainerrors "github.com/goformx/goforms/internal/domain/common/errors"
+	formdomain "github.com/goformx/goforms/internal/domain/form"
+	"github.com/goformx/goforms/internal/domain/form/model"
+)
+
+// SubmissionStatusRequest is the body of PUT /api/forms/:id/submissions/:sid/status
+type SubmissionStatusRequest struct {
+	Status string `json:"status"`
+}
+
+// SubmissionNoteRequest is the body of POST /api/forms/:id/submissions/:sid/notes
+type SubmissionNoteRequest struct {
+	Text string `json:"text"`
+}
+
+// submissionDetail is the full submission document served to the dashboard
+// detail page and the SDK: the answers, the attachments with download links,
+// and the submission's status history, notes and webhook delivery results
+func (h *FormAPIHandler) submissionDetail(
+	c echo.Context,
+	form *model.Form,
+	submission *model.FormSubmission,
+) (map[string]any, error) {
+	activities, err := h.Activities.ListActivity(c.Request().Context(), submission.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	loc := h.formLocation(c.Request().Context(), form)
+
+	history := make([]map[string]any, 0)
+	notes := make([]map[string]any, 0)
+	deliveries := make([]map[string]any, 0)
+
+	for _, activity := range activities {
+		switch activity.Kind {
+		case model.ActivityStatusChanged:
+			history = append(history, map[string]any{
+				"from":       activity.Details["from"],
+				"to":         activity.Details["to"],
+				"actor_id":   activity.ActorID,
+				"changed_at": activity.CreatedAt.In(loc).Format(time.RFC3339),
+			})
+		case model.ActivityNote:
+			notes = append(notes, noteResponse(activity))
+		case model.ActivityWebhookDelivery:
+			deliveries = append(deliveries, map[string]any{
+				"extension":    activity.Details["extension"],
+				"success":      activity.Details["success"],
+				"error":        activity.Details["error"],
+				"duration_ms":  activity.Details["duration_ms"],
+				"delivered_at": activity.CreatedAt.In(loc).Format(time.RFC3339),
+			})
+		}
+	}
+
+	return map[string]any{
+		"id":                 submission.ID,
+		"form_id":            submission.FormID,
+		"status":             submission.Status,
+		"submitted_at":       submission.SubmittedAt.In(loc).Format(time.RFC3339),
+		"timezone":           loc.String(),
+		"data":               submission.Data,
+		"encrypted":          submission.Encrypted,
+		"tags":               submissionTags(submission),
+		"attachments":        h.attachmentsResponse(c, form, submission),
+		"history":            history,
+		"notes":              notes,
+		"webhook_deliveries": deliveries,
+	}, nil
+}
+
+// attachmentsResponse describes a submission's attachments. Files stored with
+// the submission get a signed download link issued to the requesting user.
+func (h *FormAPIHandler) attachmentsResponse(c echo.Context, form *model.Form, submission *model.FormSubmission) []map[string]any {
+	actorID, _ := c.Get("user_id").(string)
+
+	attachments := formdomain.SubmissionAttachments(form.Schema, submission.Data)
+	items := make([]map[string]any, 0, len(attachments))
+
+	for i := range attachments {
+		attachment := &attachments[i]
+		item := map[string]any{
+			"field":        attachment.Field,
+			"index":        attachment.Index,
+			"name":         attachment.Name,
+			"content_type": attachment.ContentType,
+			"size":         attachment.Size,
+			"inline":       attachment.Inline,
+		}
+
+		if attachment.Inline && actorID != "" {
+			link, expires := h.fileDownloadLink(SubmissionFile{
+				FormID:       form.ID,
+				SubmissionID: submission.ID,
+				Field:        attachment.Field,
+				Index:        attachment.Index,
+			}, actorID)
+			item["download_url"] = link
+			item["download_expires_at"] = expires.UTC()
+		}
+
+		items = append(items, item)
+	}
+
+	return items
+}
+
+// noteResponse converts a note to its API representation
+func noteResponse(note *model.SubmissionActivity) map[string]any {
+	return map[string]any{
+		"id":         note.ID,
+		"text":       note.Details["text"],
+		"author_id":  note.ActorID,
+		"created_at": note.CreatedAt.UTC().Format(time.RFC3339),
+	}
+}
+
+// PUT /api/forms/:id/submissions/:sid/status - change a submission's status (assertion auth)
+func (h *FormAPIHandler) handleSetSubmissionStatus(c echo.Context) error {
+	form, err := h.getFormWithOwnershipOrError(c)
+	if err != nil || form == nil {
+		return err
+	}
+
+	var req SubmissionStatusRequest
+	if bindErr := c.Bind(&req); bindErr != nil {
+		return h.ResponseBuilder.BuildErrorResponse(c, http.StatusBadRequest, "Invalid request body")
+	}
+
+	actorID, _ := c.Get("user_id").(string)
+
+	submission, err := h.Activities.SetSubmissionStatus(
+		c.Request().Context(), form.ID, c.Param("sid"), actorID, model.SubmissionStatus(req.Status))
+	if err != nil {
+		return h.handleActivityError(c, err, form.ID, "status")
+	}
+
+	h.Logger.Info("submission status changed", "form_id", form.ID, "submission_id", submission.ID, "status", req.Status)
+
+	return response.Success(c, map[string]any{
+		"id":     submission.ID,
+		"status": submission.Status,
+	})
+}
+
+// POST /api/forms/:id/submissions/:sid/notes - add a note to a submission (assertion auth)
+func (h *FormAPIHandler) handleAddSubmissionNote(c echo.Context) error {
+	form, err := h.getFormWithOwnershipOrError(c)
+	if err != nil || form == nil {
+		return err
+	}
+
+	var req SubmissionNoteRequest
+	if bindErr := c.Bind(&req); bindErr != nil {
+		return h.ResponseBuilder.BuildErrorResponse(c, http.StatusBadRequest, "Invalid request body")
+	}
+
+	// Archived submissions can be annotated too
+	submission, err := h.getSubmission(c.Request().Context(), form.ID, c.Param("sid"))
+	if err != nil {
+		return h.handleActivityError(c, err, form.ID, "text")
+	}
+
+	actorID, _ := c.Get("user_id").(string)
+
+	note, err := h.Activities.AddNote(c.Request().Context(), form.ID, submission.ID, actorID, req.Text)
+	if err != nil {
+		return h.handleActivityError(c, err, form.ID, "text")
+	}
+
+	return c.JSON(http.StatusCreated, response.APIResponse{
+		Success: true,
+		Data:    noteResponse(note),
+	})
+}
+
+// handleActivityError maps submission activity errors to API responses
+func (h *FormAPIHandler) handleActivityError(c echo.Context, err error, formID, field string) error {
+	if errors.Is(err, model.ErrSubmissionNotFound) {
+		return h.ResponseBuilder.BuildNotFoundResponse(c, "Submission")
+	}
+
+	if domainErr := domainerrors.GetDomainError(err); domainErr != nil && domainErr.Code == domainerrors.ErrCodeValidation {
+		return h.ResponseBuilder.BuildValidationErrorResponse(c, field, domainErr.Message)
+	}
+
+	h.Logger.Error("submission activity operation failed", "error", err, "form_id", formID)
+
+	return h.HandleError(c, err, "Failed to process request")
+}
@@ -0,0 +1,68 @@
+package web
+
+import (
+	"github.com/labstack/echo/v4"
+
+	"github.com/goformx/goforms/internal/domain/form/model"
+)
+
+// POST /api/forms/:id/submissions/test - submit the form from the builder's preview
+// (assertion auth). The submission runs the same pipeline as a public one and is
+// stored flagged as a test: it is left out of listings, exports, reports and quotas
+// unless asked for, and purged after form.test_submissions.retention. Extension
+// hooks and usage metering do not run for it.
+func (h *FormAPIHandler) handleTestSubmit(c echo.Context) error {
+	form, err := h.getFormWithOwnershipOrError(c)
+	if err != nil || form == nil {
+		return err
+	}
+
+	if validationErr := h.validateFormSchema(c, form); validationErr != nil {
+		return validationErr
+	}
+
+	submissionData, err := h.processSubmissionRequest(c, form.ID)
+	if err != nil {
+		return err
+	}
+
+	submissionData, handled, err := h.runPreValidate(c, form, submissionData)
+	if handled {
+		return err
+	}
+
+	submissionData, handled, err = h.runSubmissionScript(c, form, submissionData)
+	if handled {
+		return err
+	}
+
+	if validationDataErr := h.validateSubmissionData(c, form, submissionData); validationDataErr != nil {
+		return validationDataErr
+	}
+
+	submission, err := h.createAndSubmitForm(c, form, submissionData, true)
+	if err != nil {
+		return err
+	}
+
+	h.Logger.Info("test submission received", "form_id", form.ID, "submission_id", submission.ID)
+
+	if respErr := h.ResponseBuilder.BuildSubmissionResponse(c, submission, confirmationMessage(form, submission)); respErr != nil {
+		h.Logger.Error("failed to build submission response", "error", respErr, "form_id", form.ID, "submission_id", submission.ID)
+
+		return h.HandleError(c, respErr, "Failed to build response")
+	}
+
+	return nil
+}
+
+// testSubmissionFilter reads the ?test= filter of submission listings and exports.
+// handled is true when an error response was written.
+func (h *FormAPIHandler) testSubmissionFilter(c echo.Context) (model.TestSubmissionFilter, bool, error) {
+	filter, err := model.ParseTestSubmissionFilter(c.QueryParam("test"))
+	if err != nil {
+		return filter, true, h.ResponseBuilder.BuildValidationErrorResponse(c, "test", err.Error())
+	}
+
+	return filter, false, nil
+}
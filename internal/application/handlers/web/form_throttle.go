@@ -0,0 +1,204 @@
+package web
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/labstack/echo/v4"
+
+	"github.com/goformx/goforms/internal/application/response"
+	"github.com/goformx/goforms/internal/domain/emailtemplate"
+	formdomain "github.com/goformx/goforms/internal/domain/form"
+	"github.com/goformx/goforms/internal/domain/form/model"
+	"github.com/goformx/goforms/internal/domain/user"
+	"github.com/goformx/goforms/internal/infrastructure/config"
+	"github.com/goformx/goforms/internal/infrastructure/email"
+	"github.com/goformx/goforms/internal/infrastructure/logging"
+)
+
+// throttleNotifyTimeout bounds the time spent emailing an owner about a spike
+const throttleNotifyTimeout = 30 * time.Second
+
+// SubmissionThrottle applies adaptive throttling to public form submissions
+// and emails the form owner when a spike starts
+type SubmissionThrottle struct {
+	throttle   *formdomain.AdaptiveThrottle
+	multiplier float64
+	users      user.Service
+	templates  emailtemplate.Service
+	sender     email.Sender
+	logger     logging.Logger
+	now        func() time.Time
+}
+
+// NewSubmissionThrottle creates a submission throttle, or returns nil when
+// form.throttle is disabled. Without a sender owners are not notified.
+func NewSubmissionThrottle(
+	cfg config.ThrottleConfig,
+	users user.Service,
+	templates emailtemplate.Service,
+	sender email.Sender,
+	logger logging.Logger,
+) *SubmissionThrottle {
+	if !cfg.Enabled {
+		return nil
+	}
+
+	return &SubmissionThrottle{
+		throttle: formdomain.NewAdaptiveThrottle(formdomain.ThrottleOptions{
+			Multiplier: cfg.Multiplier,
+			MinRate:    cfg.MinRate,
+			Cooldown:   cfg.Cooldown,
+		}),
+		multiplier: cfg.Multiplier,
+		users:      users,
+		templates:  templates,
+		sender:     sender,
+		logger:     logger,
+		now:        time.Now,
+	}
+}
+
+// Allow counts a submission to form and decides whether to accept it. Forms
+// whose owner switched throttling off are always accepted.
+func (t *SubmissionThrottle) Allow(form *model.Form) formdomain.ThrottleDecision {
+	if t == nil || form.ThrottleDisabled() {
+		return formdomain.ThrottleDecision{Allowed: true}
+	}
+
+	decision := t.throttle.Allow(form.ID, t.now())
+
+	if decision.Spike {
+		t.logger.Warn("form submissions throttled",
+			"form_id", form.ID, "limit", decision.Limit, "throttled_until", decision.ThrottledUntil)
+
+		if t.sender != nil {
+			go t.notifyOwner(form, decision)
+		}
+	}
+
+	return decision
+}
+
+// notifyOwner emails the form owner that the form is being throttled
+func (t *SubmissionThrottle) notifyOwner(form *model.Form, decision formdomain.ThrottleDecision) {
+	ctx, cancel := context.WithTimeout(context.Background(), throttleNotifyTimeout)
+	defer cancel()
+
+	if err := t.sendOwnerNotice(ctx, form, decision); err != nil {
+		t.logger.Error("failed to notify owner of throttled form", "form_id", form.ID, "error", err)
+	}
+}
+
+// sendOwnerNotice renders and sends the owner's submission_throttled email
+func (t *SubmissionThrottle) sendOwnerNotice(
+	ctx context.Context,
+	form *model.Form,
+	decision formdomain.ThrottleDecision,
+) error {
+	owner, err := t.users.GetUserByID(ctx, form.UserID)
+	if err != nil {
+		return fmt.Errorf("get form owner: %w", err)
+	}
+
+	if owner.Email == "" {
+		return nil
+	}
+
+	rendered, err := t.templates.Render(ctx, form.UserID, emailtemplate.KindSubmissionThrottled, map[string]any{
+		"FormTitle":      form.Title,
+		"Multiplier":     t.multiplier,
+		"AllowedRate":    decision.Limit,
+		"ThrottledUntil": decision.ThrottledUntil.In(form.Location(owner.Timezone)).Format(time.RFC1123),
+	})
+	if err != nil {
+		return fmt.Errorf("render throttle email: %w", err)
+	}
+
+	msg := &email.Message{
+		To:      []string{owner.Email},
+		Subject: rendered.Subject,
+		Body:    rendered.Text,
+		HTML:    rendered.HTML,
+		FormID:  form.ID,
+	}
+
+	if sendErr := t.sender.Send(ctx, msg); sendErr != nil {
+		return fmt.Errorf("send throttle email: %w", sendErr)
+	}
+
+	return nil
+}
+
+// Status reports the form's learned rate and current limit
+func (t *SubmissionThrottle) Status(formID string) formdomain.ThrottleStatus {
+	return t.throttle.Status(formID, t.now())
+}
+
+// Reset lifts any current throttle on the form
+func (t *SubmissionThrottle) Reset(formID string) {
+	t.throttle.Reset(formID)
+}
+
+// checkSubmissionThrottle rejects a submission to a throttled form with 429.
+// It reports whether the request was handled.
+func (h *FormAPIHandler) checkSubmissionThrottle(c echo.Context, form *model.Form) (bool, error) {
+	decision := h.Throttle.Allow(form)
+	if decision.Allowed {
+		return false, nil
+	}
+
+	h.Logger.Warn("submission rejected by throttle", "form_id", form.ID, "limit", decision.Limit)
+
+	retryAfter := max(int(math.Ceil(decision.RetryAfter.Seconds())), 1)
+	c.Response().Header().Set("Retry-After", strconv.Itoa(retryAfter))
+
+	return true, c.JSON(http.StatusTooManyRequests, response.APIResponse{
+		Success: false,
+		Message: "This form is receiving unusually many submissions. Please try again shortly.",
+		Data: map[string]any{
+			"code":        "submission_throttled",
+			"retry_after": retryAfter,
+		},
+	})
+}
+
+// GET /api/forms/:id/throttle - the form's throttle mode, learned rate and current limit (assertion auth)
+func (h *FormAPIHandler) handleGetThrottle(c echo.Context) error {
+	form, err := h.getFormWithOwnershipOrError(c)
+	if err != nil || form == nil {
+		return err
+	}
+
+	data := map[string]any{
+		"enabled":       h.Throttle != nil && !form.ThrottleDisabled(),
+		"throttle_mode": form.ThrottleMode,
+	}
+
+	if h.Throttle != nil {
+		data["status"] = h.Throttle.Status(form.ID)
+	}
+
+	return response.Success(c, data)
+}
+
+// DELETE /api/forms/:id/throttle - lifts a current throttle; the learned rate is kept (assertion auth)
+func (h *FormAPIHandler) handleResetThrottle(c echo.Context) error {
+	form, err := h.getFormWithOwnershipOrError(c)
+	if err != nil || form == nil {
+		return err
+	}
+
+	if h.Throttle == nil {
+		return response.ErrorResponse(c, http.StatusConflict, "Submission throttling is disabled")
+	}
+
+	h.Throttle.Reset(form.ID)
+	h.Logger.Info("form throttle reset", "form_id", form.ID)
+
+	return response.Success(c, map[string]any{"status": h.Throttle.Status(form.ID)})
+}
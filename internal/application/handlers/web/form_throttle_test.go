@@ -0,0 +1,76 @@
+package web_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/goformx/goforms/internal/application/handlers/web"
+	"github.com/goformx/goforms/internal/domain/emailtemplate"
+	"github.com/goformx/goforms/internal/domain/entities"
+	"github.com/goformx/goforms/internal/domain/form/model"
+	"github.com/goformx/goforms/internal/infrastructure/config"
+	"github.com/goformx/goforms/internal/infrastructure/email"
+	memorystore "github.com/goformx/goforms/internal/infrastructure/repository/memory"
+	mocklogging "github.com/goformx/goforms/test/mocks/logging"
+	mockuser "github.com/goformx/goforms/test/mocks/user"
+)
+
+// channelSender delivers sent messages on a channel
+type channelSender chan *email.Message
+
+func (s channelSender) Send(_ context.Context, msg *email.Message) error {
+	s <- msg
+
+	return nil
+}
+
+func TestSubmissionThrottle_NotifiesOwnerOncePerSpike(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockLogger := mocklogging.NewMockLogger(ctrl)
+	mockLogger.EXPECT().Warn(gomock.Any(), gomock.Any()).AnyTimes()
+
+	users := mockuser.NewMockService(ctrl)
+	users.EXPECT().GetUserByID(gomock.Any(), "owner-1").Return(&entities.User{ID: "owner-1", Email: "owner@example.com"}, nil)
+
+	templates := emailtemplate.NewService(memorystore.NewStore(mockLogger).EmailTemplates(), "GoFormX", nil, mockLogger)
+	sender := make(channelSender, 1)
+
+	throttle := web.NewSubmissionThrottle(config.ThrottleConfig{
+		Enabled:    true,
+		Multiplier: 2,
+		MinRate:    1,
+		Cooldown:   time.Minute,
+	}, users, templates, sender, mockLogger)
+	require.NotNil(t, throttle)
+
+	form := &model.Form{ID: "form-1", UserID: "owner-1", Title: "Contact"}
+
+	assert.True(t, throttle.Allow(form).Allowed)
+	assert.True(t, throttle.Allow(form).Allowed)
+	assert.False(t, throttle.Allow(form).Allowed)
+	assert.False(t, throttle.Allow(form).Allowed)
+
+	select {
+	case msg := <-sender:
+		assert.Equal(t, []string{"owner@example.com"}, msg.To)
+		assert.Equal(t, "Submissions to Contact are being throttled", msg.Subject)
+		assert.Equal(t, "form-1", msg.FormID)
+	case <-time.After(time.Second):
+		t.Fatal("owner was not notified")
+	}
+
+	form.ThrottleMode = model.ThrottleModeOff
+	assert.True(t, throttle.Allow(form).Allowed, "owners can switch throttling off")
+}
+
+func TestNewSubmissionThrottle_Disabled(t *testing.T) {
+	throttle := web.NewSubmissionThrottle(config.ThrottleConfig{}, nil, nil, nil, nil)
+
+	assert.Nil(t, throttle)
+	assert.True(t, throttle.Allow(&model.Form{ID: "form-1"}).Allowed)
+}
@@ -0,0 +1,25 @@
+package web
+
+import (
+	"context"
+	"time"
+
+	"github.com/goformx/goforms/internal/domain/form/model"
+)
+
+// formLocation returns the time zone a form's times are shown in: the form's
+// own, else its owner's account time zone, else UTC
+func (h *FormAPIHandler) formLocation(ctx context.Context, form *model.Form) *time.Location {
+	if form.Timezone != "" {
+		return form.Location("")
+	}
+
+	owner, err := h.UserService.GetUserByID(ctx, form.UserID)
+	if err != nil {
+		h.Logger.Warn("failed to get form owner time zone", "form_id", form.ID, "error", err)
+
+		return time.UTC
+	}
+
+	return form.Location(owner.Timezone)
+}
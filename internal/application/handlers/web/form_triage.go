@@ -0,0 +1,276 @@
+package web
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/labstack/echo/v4"
+
+	"github.com/goformx/goforms/internal/application/response"
+	domainerrors "github.com/goformx/goforms/internal/domain/common/errors"
+	"github.com/goformx/goforms/internal/domain/form/model"
+)
+
+// SubmissionTagsRequest is the payload for replacing a submission's tags
+type SubmissionTagsRequest struct {
+	Tags []string `json:"tags"`
+}
+
+// ViewRequest is the payload for creating or updating a saved submission view
+type ViewRequest struct {
+	Name   string   `json:"name"`
+	Tags   []string `json:"tags"`
+	Status string   `json:"status"`
+	Search string   `json:"search"`
+}
+
+// apply copies request fields onto a saved view
+func (r *ViewRequest) apply(view *model.SubmissionView) error {
+	view.Name = r.Name
+	view.Status = r.Status
+	view.Search = r.Search
+
+	return view.SetTags(r.Tags)
+}
+
+// submissionTags returns the submission's tags, never nil so they encode as an array
+func submissionTags(submission *model.FormSubmission) []string {
+	tags := submission.GetTags()
+	if tags == nil {
+		return []string{}
+	}
+
+	return tags
+}
+
+// viewResponse converts a saved view to its API representation
+func viewResponse(view *model.SubmissionView) map[string]any {
+	tags := view.GetTags()
+	if tags == nil {
+		tags = []string{}
+	}
+
+	return map[string]any{
+		"id":         view.ID,
+		"form_id":    view.FormID,
+		"name":       view.Name,
+		"tags":       tags,
+		"status":     view.Status,
+		"search":     view.Search,
+		"created_at": view.CreatedAt.Format(time.RFC3339),
+		"updated_at": view.UpdatedAt.Format(time.RFC3339),
+	}
+}
+
+// submissionFilter builds the list filter from ?view=, or from ?tag=, ?status= and ?q=.
+// The bool result reports whether an error response was already written.
+func (h *FormAPIHandler) submissionFilter(c echo.Context, formID string) (model.SubmissionFilter, bool, error) {
+	if viewID := c.QueryParam("view"); viewID != "" {
+		view, err := h.TriageService.GetView(c.Request().Context(), formID, viewID)
+		if err != nil {
+			return model.SubmissionFilter{}, true, h.handleTriageError(c, err, formID)
+		}
+
+		return view.Filter(), false, nil
+	}
+
+	var rawTags []string
+
+	for _, value := range c.QueryParams()["tag"] {
+		for tag := range strings.SplitSeq(value, ",") {
+			if tag = strings.TrimSpace(tag); tag != "" {
+				rawTags = append(rawTags, tag)
+			}
+		}
+	}
+
+	tags, err := model.NormalizeTags(rawTags)
+	if err != nil {
+		return model.SubmissionFilter{}, true, h.ResponseBuilder.BuildValidationErrorResponse(c, "tag", err.Error())
+	}
+
+	return model.SubmissionFilter{
+		Tags:   tags,
+		Status: model.SubmissionStatus(c.QueryParam("status")),
+		Search: strings.TrimSpace(c.QueryParam("q")),
+	}, false, nil
+}
+
+// PUT /api/forms/:id/submissions/:sid/tags - replace a submission's tags (assertion auth)
+func (h *FormAPIHandler) handleSetSubmissionTags(c echo.Context) error {
+	form, err := h.getFormWithOwnershipOrError(c)
+	if err != nil || form == nil {
+		return err
+	}
+
+	var req SubmissionTagsRequest
+	if bindErr := c.Bind(&req); bindErr != nil {
+		return h.ResponseBuilder.BuildErrorResponse(c, http.StatusBadRequest, "Invalid request body")
+	}
+
+	submission, err := h.TriageService.SetSubmissionTags(c.Request().Context(), form.ID, c.Param("sid"), req.Tags)
+	if err != nil {
+		return h.handleTriageError(c, err, form.ID)
+	}
+
+	return response.Success(c, map[string]any{
+		"id":   submission.ID,
+		"tags": submissionTags(submission),
+	})
+}
+
+// GET /api/forms/:id/tags - tag usage counts across a form's submissions (assertion auth)
+func (h *FormAPIHandler) handleListTags(c echo.Context) error {
+	form, err := h.getFormWithOwnershipOrError(c)
+	if err != nil || form == nil {
+		return err
+	}
+
+	tags, err := h.TriageService.ListTags(c.Request().Context(), form.ID)
+	if err != nil {
+		return h.handleTriageError(c, err, form.ID)
+	}
+
+	return response.Success(c, map[string]any{
+		"tags":  tags,
+		"count": len(tags),
+	})
+}
+
+// GET /api/forms/:id/views - list saved submission views (assertion auth)
+func (h *FormAPIHandler) handleListViews(c echo.Context) error {
+	form, err := h.getFormWithOwnershipOrError(c)
+	if err != nil || form == nil {
+		return err
+	}
+
+	views, err := h.TriageService.ListViews(c.Request().Context(), form.ID)
+	if err != nil {
+		return h.handleTriageError(c, err, form.ID)
+	}
+
+	items := make([]map[string]any, len(views))
+	for i, view := range views {
+		items[i] = viewResponse(view)
+	}
+
+	return response.Success(c, map[string]any{
+		"views": items,
+		"count": len(items),
+	})
+}
+
+// POST /api/forms/:id/views - create a saved submission view (assertion auth)
+func (h *FormAPIHandler) handleCreateView(c echo.Context) error {
+	form, err := h.getFormWithOwnershipOrError(c)
+	if err != nil || form == nil {
+		return err
+	}
+
+	var req ViewRequest
+	if bindErr := c.Bind(&req); bindErr != nil {
+		return h.ResponseBuilder.BuildErrorResponse(c, http.StatusBadRequest, "Invalid request body")
+	}
+
+	view := &model.SubmissionView{
+		FormID: form.ID,
+		UserID: form.UserID,
+	}
+
+	if applyErr := req.apply(view); applyErr != nil {
+		return h.ResponseBuilder.BuildValidationErrorResponse(c, "tags", applyErr.Error())
+	}
+
+	if createErr := h.TriageService.CreateView(c.Request().Context(), view); createErr != nil {
+		return h.handleTriageError(c, createErr, form.ID)
+	}
+
+	return c.JSON(http.StatusCreated, response.APIResponse{
+		Success: true,
+		Message: "View created successfully",
+		Data:    viewResponse(view),
+	})
+}
+
+// GET /api/forms/:id/views/:vid - get a saved submission view (assertion auth)
+func (h *FormAPIHandler) handleGetView(c echo.Context) error {
+	form, err := h.getFormWithOwnershipOrError(c)
+	if err != nil || form == nil {
+		return err
+	}
+
+	view, err := h.TriageService.GetView(c.Request().Context(), form.ID, c.Param("vid"))
+	if err != nil {
+		return h.handleTriageError(c, err, form.ID)
+	}
+
+	return response.Success(c, viewResponse(view))
+}
+
+// PUT /api/forms/:id/views/:vid - update a saved submission view (assertion auth)
+func (h *FormAPIHandler) handleUpdateView(c echo.Context) error {
+	form, err := h.getFormWithOwnershipOrError(c)
+	if err != nil || form == nil {
+		return err
+	}
+
+	view, err := h.TriageService.GetView(c.Request().Context(), form.ID, c.Param("vid"))
+	if err != nil {
+		return h.handleTriageError(c, err, form.ID)
+	}
+
+	var req ViewRequest
+	if bindErr := c.Bind(&req); bindErr != nil {
+		return h.ResponseBuilder.BuildErrorResponse(c, http.StatusBadRequest, "Invalid request body")
+	}
+
+	if applyErr := req.apply(view); applyErr != nil {
+		return h.ResponseBuilder.BuildValidationErrorResponse(c, "tags", applyErr.Error())
+	}
+
+	if updateErr := h.TriageService.UpdateView(c.Request().Context(), view); updateErr != nil {
+		return h.handleTriageError(c, updateErr, form.ID)
+	}
+
+	return response.Success(c, viewResponse(view))
+}
+
+// DELETE /api/forms/:id/views/:vid - delete a saved submission view (assertion auth)
+func (h *FormAPIHandler) handleDeleteView(c echo.Context) error {
+	form, err := h.getFormWithOwnershipOrError(c)
+	if err != nil || form == nil {
+		return err
+	}
+
+	if deleteErr := h.TriageService.DeleteView(c.Request().Context(), form.ID, c.Param("vid")); deleteErr != nil {
+		return h.handleTriageError(c, deleteErr, form.ID)
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
+
+// handleTriageError maps tagging and saved view errors to HTTP responses
+func (h *FormAPIHandler) handleTriageError(c echo.Context, err error, formID string) error {
+	if errors.Is(err, model.ErrViewNotFound) {
+		return h.ResponseBuilder.BuildNotFoundResponse(c, "View")
+	}
+
+	if errors.Is(err, model.ErrSubmissionNotFound) {
+		return h.ResponseBuilder.BuildNotFoundResponse(c, "Submission")
+	}
+
+	if domainErr := domainerrors.GetDomainError(err); domainErr != nil && domainErr.Code == domainerrors.ErrCodeValidation {
+		field := "view"
+		if errors.Is(err, model.ErrTagInvalid) || errors.Is(err, model.ErrTooManyTags) {
+			field = "tags"
+		}
+
+		return h.ResponseBuilder.BuildValidationErrorResponse(c, field, domainErr.Message)
+	}
+
+	h.Logger.Error("triage operation failed", "error", err, "form_id", formID)
+
+	return h.HandleError(c, err, "Failed to process request")
+}
@@ -0,0 +1,78 @@
+package web
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/labstack/echo/v4"
+
+	"github.com/goformx/goforms/internal/application/response"
+	domainerrors "github.com/goformx/goforms/internal/domain/common/errors"
+	formdomain "github.com/goformx/goforms/internal/domain/form"
+)
+
+// usageResponse converts a usage snapshot to its API representation
+func usageResponse(usage *formdomain.Usage) map[string]any {
+	// A zero limit is unlimited and is reported as null
+	limit := func(value int64) any {
+		if value <= 0 {
+			return nil
+		}
+
+		return value
+	}
+
+	return map[string]any{
+		"period_start": usage.PeriodStart.Format(time.RFC3339),
+		"period_end":   usage.PeriodEnd.Format(time.RFC3339),
+		"forms": map[string]any{
+			"used":  usage.Forms,
+			"limit": limit(usage.Limits.MaxForms),
+		},
+		"submissions": map[string]any{
+			"used":  usage.Submissions,
+			"limit": limit(usage.Limits.MaxSubmissionsPerMonth),
+		},
+		"storage_bytes": map[string]any{
+			"used":  usage.StorageBytes,
+			"limit": limit(usage.Limits.MaxStorageBytes),
+		},
+	}
+}
+
+// GET /api/forms/usage - current plan usage for the authenticated user (assertion auth)
+func (h *FormAPIHandler) handleUsage(c echo.Context) error {
+	userID, ok := c.Get("user_id").(string)
+	if !ok {
+		return h.HandleForbidden(c, "User not authenticated")
+	}
+
+	usage, err := h.QuotaService.GetUsage(c.Request().Context(), userID)
+	if err != nil {
+		h.Logger.Error("failed to get usage", "error", err, "user_id", h.Logger.SanitizeField("user_id", userID))
+
+		return h.HandleError(c, err, "Failed to get usage")
+	}
+
+	return response.Success(c, usageResponse(usage))
+}
+
+// handleQuotaError writes a quota-specific error response when err is a quota error.
+// It reports false when err is some other kind of error.
+func (h *FormAPIHandler) handleQuotaError(c echo.Context, err error) (bool, error) {
+	domainErr := domainerrors.GetDomainError(err)
+	if domainErr == nil || !domainerrors.IsQuotaError(err) {
+		return false, nil
+	}
+
+	data := map[string]any{"code": string(domainErr.Code)}
+	for key, value := range domainErr.Context {
+		data[key] = value
+	}
+
+	return true, c.JSON(http.StatusForbidden, response.APIResponse{
+		Success: false,
+		Message: domainErr.Message,
+		Data:    data,
+	})
+}
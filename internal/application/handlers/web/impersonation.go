@@ -0,0 +1,144 @@
+package web
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/labstack/echo/v4"
+
+	"github.com/goformx/goforms/internal/application/constants"
+	mwcontext "github.com/goformx/goforms/internal/application/middleware/context"
+	"github.com/goformx/goforms/internal/application/middleware/session"
+	"github.com/goformx/goforms/internal/application/response"
+	"github.com/goformx/goforms/internal/domain/user"
+)
+
+// ImpersonationHandler serves the status and exit endpoints of an impersonation
+// session under /api/v1/impersonation. Sessions are started by AdminUserHandler.
+type ImpersonationHandler struct {
+	*BaseHandler
+	Users user.AdminService
+}
+
+// NewImpersonationHandler creates a new ImpersonationHandler
+func NewImpersonationHandler(base *BaseHandler, users user.AdminService) *ImpersonationHandler {
+	return &ImpersonationHandler{BaseHandler: base, Users: users}
+}
+
+// RegisterRoutes registers the impersonation routes
+func (h *ImpersonationHandler) RegisterRoutes(e *echo.Echo) {
+	e.GET(constants.PathAPIImpersonation, h.handleStatus)
+	e.POST(constants.PathAPIImpersonation+"/exit", h.handleExit)
+}
+
+// Register satisfies the Handler interface; routes are registered by RegisterHandlers
+func (h *ImpersonationHandler) Register(_ *echo.Echo) {}
+
+// Start initializes the handler
+func (h *ImpersonationHandler) Start(_ context.Context) error {
+	return nil
+}
+
+// Stop cleans up the handler
+func (h *ImpersonationHandler) Stop(_ context.Context) error {
+	return nil
+}
+
+// GET /api/v1/impersonation - reports whether the session is an impersonation, for the UI banner
+func (h *ImpersonationHandler) handleStatus(c echo.Context) error {
+	s, ok := impersonationSession(c)
+	if !ok {
+		return response.Success(c, map[string]any{"active": false})
+	}
+
+	return response.Success(c, map[string]any{
+		"active":          true,
+		"user_id":         s.UserID,
+		"email":           s.Email,
+		"impersonator_id": s.ImpersonatorID,
+		"expires_at":      s.ExpiresAt,
+	})
+}
+
+// POST /api/v1/impersonation/exit - ends the impersonation and restores the admin's session
+func (h *ImpersonationHandler) handleExit(c echo.Context) error {
+	s, ok := impersonationSession(c)
+	if !ok {
+		return response.ErrorResponse(c, http.StatusConflict, "Not impersonating a user")
+	}
+
+	cookie, err := c.Cookie(h.SessionManager.GetCookieName())
+	if err != nil {
+		return response.ErrorResponse(c, http.StatusConflict, "Not impersonating a user")
+	}
+
+	adminSessionID, restored := h.SessionManager.EndImpersonation(cookie.Value)
+
+	actor := user.Actor{ID: s.ImpersonatorID, IPAddress: c.RealIP()}
+	h.Users.EndImpersonation(c.Request().Context(), actor, s.UserID, map[string]any{"restored": restored})
+
+	if restored {
+		h.SessionManager.SetSessionCookie(c, adminSessionID)
+	} else {
+		h.SessionManager.ClearSessionCookie(c)
+	}
+
+	return response.Success(c, map[string]any{"restored": restored})
+}
+
+// impersonationSession returns the request's session when it is an impersonation
+func impersonationSession(c echo.Context) (*session.Session, bool) {
+	s, ok := c.Get(string(mwcontext.SessionKey)).(*session.Session)
+	if !ok || !s.IsImpersonation() {
+		return nil, false
+	}
+
+	return s, true
+}
+
+// POST /api/v1/admin/users/:id/impersonate - opens a time-limited session acting as the user.
+// The admin's own session is kept and restored on exit.
+func (h *AdminUserHandler) handleImpersonate(c echo.Context) error {
+	actorID, ok := mwcontext.GetUserID(c)
+	if !ok || h.SessionManager == nil {
+		return h.HandleForbidden(c, "Admin session required")
+	}
+
+	cookie, err := c.Cookie(h.SessionManager.GetCookieName())
+	if err != nil {
+		return h.HandleForbidden(c, "Admin session required")
+	}
+
+	ttl := h.Config.Session.ImpersonationTTL
+	expiresAt := time.Now().Add(ttl)
+
+	u, err := h.Users.StartImpersonation(
+		c.Request().Context(),
+		user.Actor{ID: actorID, IPAddress: c.RealIP()},
+		c.Param("id"),
+		map[string]any{"expires_at": expiresAt},
+	)
+	if err != nil {
+		return h.handleUserError(c, err, "Failed to impersonate user")
+	}
+
+	sessionID, err := h.SessionManager.CreateImpersonationSession(&session.Session{
+		UserID:                u.ID,
+		Email:                 u.Email,
+		Role:                  u.Role,
+		ImpersonatorID:        actorID,
+		ImpersonatorSessionID: cookie.Value,
+	}, ttl)
+	if err != nil {
+		return h.HandleError(c, err, "Failed to create impersonation session")
+	}
+
+	h.SessionManager.SetSessionCookie(c, sessionID)
+
+	return response.Success(c, map[string]any{
+		"user":            u,
+		"impersonator_id": actorID,
+		"expires_at":      expiresAt,
+	})
+}
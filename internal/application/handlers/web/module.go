@@ -9,37 +9,224 @@ import (
 
 	"github.com/labstack/echo/v4"
 
+	"github.com/goformx/goforms/internal/application/middleware"
 	"github.com/goformx/goforms/internal/application/middleware/access"
-	"github.com/goformx/goforms/internal/application/validation"
+	"github.com/goformx/goforms/internal/application/render"
+	"github.com/goformx/goforms/internal/domain/audit"
+	"github.com/goformx/goforms/internal/domain/backup"
+	"github.com/goformx/goforms/internal/domain/billing"
+	"github.com/goformx/goforms/internal/domain/common/events"
+	"github.com/goformx/goforms/internal/domain/dataset"
+	"github.com/goformx/goforms/internal/domain/deadletter"
+	"github.com/goformx/goforms/internal/domain/emaildelivery"
+	"github.com/goformx/goforms/internal/domain/emailtemplate"
+	"github.com/goformx/goforms/internal/domain/encryption"
+	"github.com/goformx/goforms/internal/domain/eventlog"
+	"github.com/goformx/goforms/internal/domain/extension"
+	"github.com/goformx/goforms/internal/domain/flags"
 	"github.com/goformx/goforms/internal/domain/form"
+	"github.com/goformx/goforms/internal/domain/metering"
+	"github.com/goformx/goforms/internal/domain/securityevent"
 	"github.com/goformx/goforms/internal/domain/user"
+	"github.com/goformx/goforms/internal/infrastructure/config"
+	"github.com/goformx/goforms/internal/infrastructure/email"
+	"github.com/goformx/goforms/internal/infrastructure/httpclient"
 	"github.com/goformx/goforms/internal/infrastructure/logging"
-	"github.com/goformx/goforms/internal/infrastructure/sanitization"
+	"github.com/goformx/goforms/internal/infrastructure/metrics"
+	"github.com/goformx/goforms/internal/infrastructure/updates"
 )
 
 // Module provides web handler dependencies
 var Module = fx.Module("web-handlers",
 	// Core dependencies
 	fx.Provide(NewBaseHandler),
+	fx.Provide(fx.Annotate(newPageCache, fx.ParamTags(``, ``, `optional:"true"`))),
 
 	// Handler providers
 	fx.Provide(
 		// Form API handler - authenticated access
+		fx.Annotate(
+			func(p FormAPIHandlerParams) Handler {
+				return NewFormAPIHandler(p)
+			},
+			fx.ResultTags(`group:"handlers"`),
+		),
+		// Admin email handler - admin session access
 		fx.Annotate(
 			func(
 				base *BaseHandler,
+				emailSender email.Sender,
+				emailTemplates emailtemplate.Service,
+				emailDeliveries emaildelivery.Service,
+			) Handler {
+				return NewAdminEmailHandler(base, emailSender, emailTemplates, emailDeliveries)
+			},
+			fx.ResultTags(`group:"handlers"`),
+		),
+		// Admin user management handler - admin session access
+		fx.Annotate(
+			func(base *BaseHandler, users user.AdminService, formService form.Service, deletions user.DeletionService) Handler {
+				return NewAdminUserHandler(base, users, formService, deletions)
+			},
+			fx.ResultTags(`group:"handlers"`),
+		),
+		// Admin audit log handler - admin session access
+		fx.Annotate(
+			func(base *BaseHandler, auditService audit.Service) Handler {
+				return NewAdminAuditHandler(base, auditService)
+			},
+			fx.ResultTags(`group:"handlers"`),
+		),
+		// Admin security event handler - admin session access
+		fx.Annotate(
+			func(base *BaseHandler, events securityevent.Service, accessManager *access.Manager) Handler {
+				return NewAdminSecurityHandler(base, events, accessManager)
+			},
+			fx.ResultTags(`group:"handlers"`),
+		),
+		// Admin stored event and redelivery handler - admin session access
+		fx.Annotate(
+			func(
+				base *BaseHandler,
+				events eventlog.Service,
 				formService form.Service,
-				accessManager *access.Manager,
-				formValidator *validation.FormValidator,
-				sanitizer sanitization.ServiceInterface,
-				userEnsurer user.UserEnsurer,
-			) (Handler, error) {
-				return NewFormAPIHandler(base, formService, accessManager, formValidator, sanitizer, userEnsurer), nil
+				activities form.ActivityService,
+				extensions *extension.Runner,
+				auditService audit.Service,
+			) Handler {
+				return NewAdminEventHandler(base, events, formService, activities, extensions, auditService)
+			},
+			fx.ParamTags(``, `optional:"true"`),
+			fx.ResultTags(`group:"handlers"`),
+		),
+		// Admin dead-letter handler - admin session access
+		fx.Annotate(
+			func(base *BaseHandler, deadLetters deadletter.Service, auditService audit.Service) Handler {
+				return NewAdminDeadLetterHandler(base, deadLetters, auditService)
+			},
+			fx.ParamTags(``, `optional:"true"`),
+			fx.ResultTags(`group:"handlers"`),
+		),
+		// Admin encryption key status and rotation handler - admin session access
+		fx.Annotate(
+			func(base *BaseHandler, encryptionService encryption.Service, auditService audit.Service) Handler {
+				return NewAdminEncryptionHandler(base, encryptionService, auditService)
+			},
+			fx.ParamTags(``, `optional:"true"`),
+			fx.ResultTags(`group:"handlers"`),
+		),
+		// Admin backup handler - admin session access
+		fx.Annotate(
+			func(base *BaseHandler, backups backup.Service, auditService audit.Service) Handler {
+				return NewAdminBackupHandler(base, backups, auditService)
+			},
+			fx.ResultTags(`group:"handlers"`),
+		),
+		// Admin route listing handler - admin session access
+		fx.Annotate(
+			func(base *BaseHandler, accessManager *access.Manager, middlewareManager *middleware.Manager) Handler {
+				return NewAdminRouteHandler(base, accessManager, middlewareManager)
+			},
+			fx.ResultTags(`group:"handlers"`),
+		),
+		// Admin feature flag handler - admin session access
+		fx.Annotate(
+			func(base *BaseHandler, flagService flags.Service, auditService audit.Service) Handler {
+				return NewAdminFlagHandler(base, flagService, auditService)
+			},
+			fx.ResultTags(`group:"handlers"`),
+		),
+		// Admin update notice handler - admin session access
+		fx.Annotate(
+			func(base *BaseHandler, checker *updates.Checker) Handler {
+				return NewAdminUpdateHandler(base, checker)
+			},
+			fx.ParamTags(``, `optional:"true"`),
+			fx.ResultTags(`group:"handlers"`),
+		),
+		// Admin runtime diagnostics handler - admin session access
+		fx.Annotate(
+			func(base *BaseHandler, registry *metrics.Registry) Handler {
+				return NewAdminOpsHandler(base, registry)
+			},
+			fx.ParamTags(``, `optional:"true"`),
+			fx.ResultTags(`group:"handlers"`),
+		),
+		// Theme stylesheet handler - public
+		fx.Annotate(
+			func(base *BaseHandler) Handler {
+				return NewThemeHandler(base)
+			},
+			fx.ResultTags(`group:"handlers"`),
+		),
+		// Impersonation status and exit handler - authenticated session access
+		fx.Annotate(
+			func(base *BaseHandler, users user.AdminService) Handler {
+				return NewImpersonationHandler(base, users)
+			},
+			fx.ResultTags(`group:"handlers"`),
+		),
+		// Email webhook handler - public, authenticated by provider signatures
+		fx.Annotate(
+			func(base *BaseHandler, emailDeliveries emaildelivery.Service, clients *httpclient.Factory) (Handler, error) {
+				return NewEmailWebhookHandler(base, emailDeliveries, clients)
+			},
+			fx.ResultTags(`group:"handlers"`),
+		),
+		// Stripe billing webhook handler - public, authenticated by Stripe's signature
+		fx.Annotate(
+			func(base *BaseHandler, billingService billing.Service) Handler {
+				return NewBillingWebhookHandler(base, billingService)
+			},
+			fx.ResultTags(`group:"handlers"`),
+		),
+		// Usage report handler - API key or assertion auth
+		fx.Annotate(
+			func(base *BaseHandler, meter metering.Service) Handler {
+				return NewUsageHandler(base, meter)
+			},
+			fx.ResultTags(`group:"handlers"`),
+		),
+		// Managed dataset handler - assertion auth
+		fx.Annotate(
+			func(base *BaseHandler, datasets dataset.Service) Handler {
+				return NewDatasetHandler(base, datasets)
+			},
+			fx.ResultTags(`group:"handlers"`),
+		),
+		// Account deletion handler - assertion auth
+		fx.Annotate(
+			func(base *BaseHandler, deletions user.DeletionService) Handler {
+				return NewAccountDeletionHandler(base, deletions)
+			},
+			fx.ResultTags(`group:"handlers"`),
+		),
+		// Account health report preview handler - assertion auth
+		fx.Annotate(
+			func(base *BaseHandler, reporter *form.HealthReporter) Handler {
+				return NewAccountHealthHandler(base, reporter)
 			},
 			fx.ResultTags(`group:"handlers"`),
 		),
 	),
 
+	// Scheduled analytics export, nil when storage.analytics is disabled
+	fx.Provide(NewAnalyticsExporter),
+	fx.Invoke(func(lc fx.Lifecycle, exporter *AnalyticsExporter) {
+		lc.Append(fx.Hook{
+			OnStart: func(_ context.Context) error {
+				exporter.Start()
+
+				return nil
+			},
+			OnStop: func(ctx context.Context) error {
+				exporter.Stop(ctx)
+
+				return nil
+			},
+		})
+	}),
+
 	// Lifecycle hooks
 	fx.Invoke(fx.Annotate(
 		func(lc fx.Lifecycle, handlers []Handler, logger logging.Logger) {
@@ -107,6 +294,46 @@ func (rr *RouteRegistrar) registerHandlerRoutes(e *echo.Echo, handler Handler) {
 	switch h := handler.(type) {
 	case *FormAPIHandler:
 		rr.registerFormAPIRoutes(e, h)
+	case *AdminEmailHandler:
+		h.RegisterRoutes(e)
+	case *AdminUserHandler:
+		h.RegisterRoutes(e)
+	case *AdminAuditHandler:
+		h.RegisterRoutes(e)
+	case *AdminSecurityHandler:
+		h.RegisterRoutes(e)
+	case *AdminBackupHandler:
+		h.RegisterRoutes(e)
+	case *AdminFlagHandler:
+		h.RegisterRoutes(e)
+	case *AdminUpdateHandler:
+		h.RegisterRoutes(e)
+	case *AdminEventHandler:
+		h.RegisterRoutes(e)
+	case *AdminDeadLetterHandler:
+		h.RegisterRoutes(e)
+	case *AdminEncryptionHandler:
+		h.RegisterRoutes(e)
+	case *AdminOpsHandler:
+		h.RegisterRoutes(e)
+	case *ThemeHandler:
+		h.RegisterRoutes(e)
+	case *AdminRouteHandler:
+		h.RegisterRoutes(e, rr.handlers)
+	case *ImpersonationHandler:
+		h.RegisterRoutes(e)
+	case *EmailWebhookHandler:
+		h.RegisterRoutes(e)
+	case *BillingWebhookHandler:
+		h.RegisterRoutes(e)
+	case *UsageHandler:
+		h.RegisterRoutes(e)
+	case *DatasetHandler:
+		h.RegisterRoutes(e)
+	case *AccountDeletionHandler:
+		h.RegisterRoutes(e)
+	case *AccountHealthHandler:
+		h.RegisterRoutes(e)
 	default:
 		// Unknown handler type - skip
 		_ = h
@@ -162,3 +389,14 @@ func RegisterHandlers(
 			"handlers_registered", len(handlers))
 	}
 }
+
+// newPageCache creates the cache of the rendered public pages and reports its
+// hits and misses as page_cache
+func newPageCache(cfg *config.Config, eventBus events.EventBus, registry *metrics.Registry) *render.Cache {
+	pages := render.NewCache(cfg.Cache.Pages, eventBus)
+	if registry != nil {
+		registry.Register("page_cache", pages.Metrics())
+	}
+
+	return pages
+}
@@ -0,0 +1,104 @@
+package web
+
+import (
+	"slices"
+	"sync"
+
+	"github.com/labstack/echo/v4"
+
+	appconfig "github.com/goformx/goforms/internal/infrastructure/config"
+)
+
+// namedMiddleware is group or route middleware with the name route listings show for it
+type namedMiddleware struct {
+	name string
+	fn   echo.MiddlewareFunc
+}
+
+// named pairs middleware with its name in route listings
+func named(name string, fn echo.MiddlewareFunc) namedMiddleware {
+	return namedMiddleware{name: name, fn: fn}
+}
+
+// routeRecorder registers groups and routes and records the middleware each was
+// given, so a handler's DescribeRoutes reports what registration applied rather
+// than a table kept alongside it
+type routeRecorder struct {
+	mu     sync.Mutex
+	groups []RouteGroup
+}
+
+// reset forgets what was recorded, before the routes are registered again
+func (r *routeRecorder) reset() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.groups = nil
+}
+
+// group creates a group using middleware and records it for the group's prefix
+func (r *routeRecorder) group(e *echo.Echo, prefix, auth string, middleware ...namedMiddleware) *echo.Group {
+	names, fns := splitMiddleware(middleware)
+
+	g := e.Group(prefix)
+	if len(fns) > 0 {
+		g.Use(fns...)
+	}
+
+	if auth != "" || len(names) > 0 {
+		r.record(RouteGroup{Prefix: prefix, Auth: auth, Middleware: names})
+	}
+
+	return g
+}
+
+// add registers a route with route middleware and records the middleware for it
+func (r *routeRecorder) add(
+	g *echo.Group,
+	method, path string,
+	handler echo.HandlerFunc,
+	middleware ...namedMiddleware,
+) *echo.Route {
+	names, fns := splitMiddleware(middleware)
+	route := g.Add(method, path, handler, fns...)
+
+	if len(names) > 0 {
+		r.record(RouteGroup{Prefix: route.Path, Method: method, Middleware: names})
+	}
+
+	return route
+}
+
+// limit records the rate limit of a registered route
+func (r *routeRecorder) limit(route *echo.Route, limit appconfig.RateLimitTier) {
+	r.record(RouteGroup{Prefix: route.Path, Method: route.Method, RateLimit: &limit})
+}
+
+// described returns what was recorded, in registration order
+func (r *routeRecorder) described() []RouteGroup {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return slices.Clone(r.groups)
+}
+
+// record appends a group
+func (r *routeRecorder) record(group RouteGroup) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.groups = append(r.groups, group)
+}
+
+// splitMiddleware separates the names of middleware from the functions
+func splitMiddleware(middleware []namedMiddleware) ([]string, []echo.MiddlewareFunc) {
+	names := make([]string, 0, len(middleware))
+	fns := make([]echo.MiddlewareFunc, 0, len(middleware))
+
+	for _, mw := range middleware {
+		names = append(names, mw.name)
+		fns = append(fns, mw.fn)
+	}
+
+	return names, fns
+}
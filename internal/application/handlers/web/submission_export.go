@@ -0,0 +1,114 @@
+package web
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+
+	formdomain "github.com/goformx/goforms/internal/domain/form"
+	"github.com/goformx/goforms/internal/domain/form/model"
+	"github.com/goformx/goforms/internal/infrastructure/parquet"
+)
+
+// parquetCreatedBy is recorded in the footer of exported Parquet files
+const parquetCreatedBy = "goforms"
+
+// submissionsParquet renders submissions as a Parquet file with one column per
+// data key. A data column whose values are all numbers or all booleans is typed
+// as such; other columns hold text, with lists and objects stored as JSON.
+func submissionsParquet(formModel *model.Form, submissions []*model.FormSubmission) ([]byte, error) {
+	keys := formdomain.SubmissionColumns(formModel.Schema, submissions)
+
+	columns := []parquet.Column{
+		{Name: "form_id", Type: parquet.String},
+		{Name: "submission_id", Type: parquet.String},
+		{Name: "submitted_at", Type: parquet.Timestamp},
+		{Name: "status", Type: parquet.String},
+	}
+
+	base := len(columns)
+	reserved := make(map[string]bool, base)
+	for _, column := range columns {
+		reserved[column.Name] = true
+	}
+
+	for _, key := range keys {
+		name := key
+		if reserved[name] {
+			name = "data_" + key
+		}
+
+		columns = append(columns, parquet.Column{Name: name, Type: parquetColumnType(key, submissions)})
+	}
+
+	table := parquet.NewTable(columns...)
+	table.CreatedBy = parquetCreatedBy
+
+	for _, submission := range submissions {
+		row := []any{submission.FormID, submission.ID, submission.SubmittedAt.UTC(), string(submission.Status)}
+
+		for i, key := range keys {
+			row = append(row, parquetValue(columns[base+i].Type, formdomain.SubmissionValue(submission.Data, key)))
+		}
+
+		if err := table.AddRow(row...); err != nil {
+			return nil, fmt.Errorf("add parquet row: %w", err)
+		}
+	}
+
+	data, err := table.Bytes()
+	if err != nil {
+		return nil, fmt.Errorf("write parquet: %w", err)
+	}
+
+	return data, nil
+}
+
+// parquetColumnType picks the column type for a data key from the submitted values
+func parquetColumnType(key string, submissions []*model.FormSubmission) parquet.Type {
+	numbers, booleans := 0, 0
+
+	for _, submission := range submissions {
+		switch formdomain.SubmissionValue(submission.Data, key).(type) {
+		case nil:
+		case float64:
+			numbers++
+		case bool:
+			booleans++
+		default:
+			return parquet.String
+		}
+	}
+
+	switch {
+	case numbers > 0 && booleans == 0:
+		return parquet.Double
+	case booleans > 0 && numbers == 0:
+		return parquet.Bool
+	default:
+		return parquet.String
+	}
+}
+
+// parquetValue converts a submitted value for a column of the given type; nil stays a null
+func parquetValue(typ parquet.Type, value any) any {
+	if value == nil || typ != parquet.String {
+		return value
+	}
+
+	switch val := value.(type) {
+	case string:
+		return val
+	case float64:
+		return strconv.FormatFloat(val, 'f', -1, 64)
+	case bool:
+		return strconv.FormatBool(val)
+	default:
+		encoded, err := json.Marshal(val)
+		if err != nil {
+			return fmt.Sprint(val)
+		}
+
+		return string(encoded)
+	}
+}
@@ -0,0 +1,288 @@
+package web
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"mime"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/labstack/echo/v4"
+
+	"github.com/goformx/goforms/internal/application/constants"
+	"github.com/goformx/goforms/internal/application/response"
+	"github.com/goformx/goforms/internal/domain/audit"
+	formdomain "github.com/goformx/goforms/internal/domain/form"
+	"github.com/goformx/goforms/internal/domain/form/model"
+	"github.com/goformx/goforms/internal/infrastructure/logging"
+)
+
+const (
+	// fileDownloadTokenParam is the query parameter carrying a download link's token
+	fileDownloadTokenParam = "token"
+	// fileDownloadActorKey is the Echo context key holding the user a download link was issued to
+	fileDownloadActorKey = "file_download_actor"
+)
+
+var (
+	// errDownloadLinkInvalid is returned for a download token that is malformed or
+	// signed for another file
+	errDownloadLinkInvalid = errors.New("download link is invalid")
+
+	// errDownloadLinkExpired is returned for a download token past its expiry
+	errDownloadLinkExpired = errors.New("download link has expired")
+)
+
+// SubmissionFile identifies one attachment of a submission
+type SubmissionFile struct {
+	FormID       string
+	SubmissionID string
+	Field        string
+	Index        int
+}
+
+// path returns the download path of the file, relative to the server root
+func (f SubmissionFile) path() string {
+	return fmt.Sprintf("%s/%s/%s/%s/%d", constants.PathFiles,
+		url.PathEscape(f.FormID), url.PathEscape(f.SubmissionID), url.PathEscape(f.Field), f.Index)
+}
+
+// SubmissionFileTokens issues and verifies the tokens of signed download links
+// for submission attachments. A token names the user it was issued to and
+// expires after ttl.
+type SubmissionFileTokens struct {
+	secret []byte
+	ttl    time.Duration
+	now    func() time.Time
+}
+
+// NewSubmissionFileTokens creates a download token issuer signing with secret
+func NewSubmissionFileTokens(secret string, ttl time.Duration) *SubmissionFileTokens {
+	return &SubmissionFileTokens{secret: []byte(secret), ttl: ttl, now: time.Now}
+}
+
+// Issue returns a token granting actorID access to file until the returned expiry
+func (t *SubmissionFileTokens) Issue(file SubmissionFile, actorID string) (string, time.Time) {
+	expires := t.now().Add(t.ttl).Truncate(time.Second)
+	expiry := strconv.FormatInt(expires.Unix(), 10)
+
+	return expiry + "." + actorID + "." + hex.EncodeToString(t.sign(file, actorID, expiry)), expires
+}
+
+// Verify checks token for file and returns the user it was issued to
+func (t *SubmissionFileTokens) Verify(file SubmissionFile, token string) (string, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return "", errDownloadLinkInvalid
+	}
+
+	expiry, actorID, signature := parts[0], parts[1], parts[2]
+
+	expiresAt, err := strconv.ParseInt(expiry, 10, 64)
+	if err != nil || actorID == "" {
+		return "", errDownloadLinkInvalid
+	}
+
+	sigBytes, err := hex.DecodeString(signature)
+	if err != nil || !hmac.Equal(sigBytes, t.sign(file, actorID, expiry)) {
+		return "", errDownloadLinkInvalid
+	}
+
+	if t.now().Unix() >= expiresAt {
+		return "", errDownloadLinkExpired
+	}
+
+	return actorID, nil
+}
+
+// sign computes the token signature for a file, actor and expiry
+func (t *SubmissionFileTokens) sign(file SubmissionFile, actorID, expiry string) []byte {
+	mac := hmac.New(sha256.New, t.secret)
+	mac.Write([]byte(strings.Join([]string{
+		"submission-file", file.FormID, file.SubmissionID, file.Field, strconv.Itoa(file.Index), actorID, expiry,
+	}, "\n")))
+
+	return mac.Sum(nil)
+}
+
+// SubmissionFileMiddleware admits requests to the download routes that carry a
+// valid token for the file they name
+type SubmissionFileMiddleware struct {
+	tokens *SubmissionFileTokens
+	logger logging.Logger
+}
+
+// NewSubmissionFileMiddleware creates the download link middleware
+func NewSubmissionFileMiddleware(tokens *SubmissionFileTokens, logger logging.Logger) *SubmissionFileMiddleware {
+	return &SubmissionFileMiddleware{tokens: tokens, logger: logger}
+}
+
+// Verify returns middleware refusing download requests without a valid token.
+// The user the link was issued to is recorded in the Echo context.
+func (m *SubmissionFileMiddleware) Verify() echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			c.Response().Header().Set(echo.HeaderCacheControl, "private, no-store")
+
+			file, ok := submissionFileParams(c)
+			if !ok {
+				return response.ErrorResponse(c, http.StatusNotFound, "File not found")
+			}
+
+			actorID, err := m.tokens.Verify(file, c.QueryParam(fileDownloadTokenParam))
+			if err != nil {
+				m.logger.Debug("download link refused", "submission_id", file.SubmissionID, "error", err)
+
+				code := "download_link_invalid"
+				if errors.Is(err, errDownloadLinkExpired) {
+					code = "download_link_expired"
+				}
+
+				return c.JSON(http.StatusForbidden, response.APIResponse{
+					Success: false,
+					Message: "This download link is invalid or has expired",
+					Data:    map[string]any{"code": code},
+				})
+			}
+
+			c.Set(fileDownloadActorKey, actorID)
+
+			return next(c)
+		}
+	}
+}
+
+// submissionFileParams reads the file named by the route parameters
+func submissionFileParams(c echo.Context) (SubmissionFile, bool) {
+	index, err := strconv.Atoi(c.Param("index"))
+	if err != nil || index < 0 {
+		return SubmissionFile{}, false
+	}
+
+	return SubmissionFile{
+		FormID:       c.Param("id"),
+		SubmissionID: c.Param("sid"),
+		Field:        c.Param("field"),
+		Index:        index,
+	}, true
+}
+
+// RegisterFileRoutes registers the signed download routes for submission attachments
+func (h *FormAPIHandler) RegisterFileRoutes(e *echo.Echo) {
+	files := h.routes.group(e, constants.PathFiles, "signed_link")
+	h.routes.add(files, http.MethodGet, "/:id/:sid/:field/:index", h.handleDownloadSubmissionFile,
+		named("file_download_token", h.FileDownloads.Verify()))
+}
+
+// POST /api/forms/:id/submissions/:sid/files/:field/:index/link - issues a
+// short-lived signed download link for an attachment (assertion auth)
+func (h *FormAPIHandler) handleSubmissionFileLink(c echo.Context) error {
+	form, err := h.getFormWithOwnershipOrError(c)
+	if err != nil || form == nil {
+		return err
+	}
+
+	actorID, ok := c.Get("user_id").(string)
+	if !ok {
+		return h.HandleForbidden(c, "User not authenticated")
+	}
+
+	file, ok := submissionFileParams(c)
+	if !ok {
+		return h.ResponseBuilder.BuildNotFoundResponse(c, "File")
+	}
+
+	if _, lookupErr := h.lookupSubmissionFile(c, file); lookupErr != nil {
+		return h.submissionFileError(c, file, lookupErr)
+	}
+
+	link, expires := h.fileDownloadLink(file, actorID)
+
+	h.Logger.Info("file download link issued", "form_id", form.ID, "submission_id", file.SubmissionID, "field", file.Field)
+
+	return response.Success(c, map[string]any{
+		"url":        link,
+		"expires_at": expires.UTC(),
+	})
+}
+
+// fileDownloadLink returns a signed download link for file issued to actorID
+func (h *FormAPIHandler) fileDownloadLink(file SubmissionFile, actorID string) (string, time.Time) {
+	token, expires := h.FileDownloadTokens.Issue(file, actorID)
+	link := strings.TrimSuffix(h.Config.App.GetServerURL(), "/") + file.path() + "?" +
+		url.Values{fileDownloadTokenParam: {token}}.Encode()
+
+	return link, expires
+}
+
+// GET /files/:id/:sid/:field/:index?token= - downloads an attachment through a signed link
+func (h *FormAPIHandler) handleDownloadSubmissionFile(c echo.Context) error {
+	file, _ := submissionFileParams(c)
+
+	attachment, err := h.lookupSubmissionFile(c, file)
+	if err != nil {
+		return h.submissionFileError(c, file, err)
+	}
+
+	actorID, _ := c.Get(fileDownloadActorKey).(string)
+
+	auditErr := h.Audit.Record(c.Request().Context(), &audit.Entry{
+		ActorID:    actorID,
+		Action:     audit.ActionSubmissionFileDownloaded,
+		TargetType: audit.TargetSubmission,
+		TargetID:   file.SubmissionID,
+		Details: map[string]any{
+			"form_id": file.FormID,
+			"field":   file.Field,
+			"index":   file.Index,
+			"name":    attachment.Name,
+			"size":    len(attachment.Content),
+		},
+		IPAddress: c.RealIP(),
+	})
+	if auditErr != nil {
+		// Downloads are only served once they are on record
+		h.Logger.Error("failed to record file download", "submission_id", file.SubmissionID, "error", auditErr)
+
+		return h.HandleError(c, auditErr, "Failed to download file")
+	}
+
+	header := c.Response().Header()
+	header.Set(echo.HeaderContentDisposition, mime.FormatMediaType("attachment", map[string]string{"filename": attachment.Name}))
+	header.Set(echo.HeaderXContentTypeOptions, "nosniff")
+	header.Set(echo.HeaderContentSecurityPolicy, "sandbox")
+
+	return c.Blob(http.StatusOK, attachment.ContentType, attachment.Content)
+}
+
+// lookupSubmissionFile loads the submission and returns the attachment named by file
+func (h *FormAPIHandler) lookupSubmissionFile(c echo.Context, file SubmissionFile) (*formdomain.Attachment, error) {
+	submission, err := h.getSubmission(c.Request().Context(), file.FormID, file.SubmissionID)
+	if err != nil {
+		return nil, err
+	}
+
+	return formdomain.SubmissionAttachment(submission.Data, file.Field, file.Index)
+}
+
+// submissionFileError responds to a failed attachment lookup
+func (h *FormAPIHandler) submissionFileError(c echo.Context, file SubmissionFile, err error) error {
+	switch {
+	case errors.Is(err, model.ErrSubmissionNotFound):
+		return h.ResponseBuilder.BuildNotFoundResponse(c, "Submission")
+	case errors.Is(err, formdomain.ErrAttachmentNotFound):
+		return h.ResponseBuilder.BuildNotFoundResponse(c, "File")
+	case errors.Is(err, formdomain.ErrAttachmentNotInline):
+		return response.ErrorResponse(c, http.StatusConflict, "This file is not stored with the submission")
+	}
+
+	h.Logger.Error("failed to get submission file", "submission_id", file.SubmissionID, "error", err)
+
+	return h.HandleError(c, err, "Failed to get file")
+}
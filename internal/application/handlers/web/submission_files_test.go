@@ -0,0 +1,65 @@
+package web_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+
+	"github.com/goformx/goforms/internal/application/constants"
+	"github.com/goformx/goforms/internal/application/handlers/web"
+	mocklogging "github.com/goformx/goforms/test/mocks/logging"
+)
+
+func TestSubmissionFileMiddleware(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	logger := mocklogging.NewMockLogger(ctrl)
+	logger.EXPECT().Debug(gomock.Any(), gomock.Any()).AnyTimes()
+
+	tokens := web.NewSubmissionFileTokens("secret", time.Minute)
+	expired := web.NewSubmissionFileTokens("secret", -time.Minute)
+	file := web.SubmissionFile{FormID: "form-1", SubmissionID: "sub-1", Field: "cv", Index: 0}
+
+	e := echo.New()
+	e.Group(constants.PathFiles).GET("/:id/:sid/:field/:index", func(c echo.Context) error {
+		return c.String(http.StatusOK, c.Get("file_download_actor").(string))
+	}, web.NewSubmissionFileMiddleware(tokens, logger).Verify())
+
+	valid, expires := tokens.Issue(file, "user-1")
+	assert.WithinDuration(t, time.Now().Add(time.Minute), expires, 2*time.Second)
+
+	stale, _ := expired.Issue(file, "user-1")
+	other, _ := tokens.Issue(web.SubmissionFile{FormID: "form-1", SubmissionID: "sub-1", Field: "cv", Index: 1}, "user-1")
+
+	tests := []struct {
+		name   string
+		path   string
+		token  string
+		status int
+		body   string
+	}{
+		{name: "valid", path: "/files/form-1/sub-1/cv/0", token: valid, status: http.StatusOK, body: "user-1"},
+		{name: "expired", path: "/files/form-1/sub-1/cv/0", token: stale, status: http.StatusForbidden, body: "download_link_expired"},
+		{name: "other file", path: "/files/form-1/sub-1/cv/0", token: other, status: http.StatusForbidden, body: "download_link_invalid"},
+		{name: "other submission", path: "/files/form-1/sub-2/cv/0", token: valid, status: http.StatusForbidden},
+		{name: "missing token", path: "/files/form-1/sub-1/cv/0", status: http.StatusForbidden},
+		{name: "bad index", path: "/files/form-1/sub-1/cv/x", token: valid, status: http.StatusNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, tt.path+"?"+url.Values{"token": {tt.token}}.Encode(), http.NoBody)
+			rec := httptest.NewRecorder()
+			e.ServeHTTP(rec, req)
+
+			assert.Equal(t, tt.status, rec.Code)
+			assert.Contains(t, rec.Body.String(), tt.body)
+			assert.Equal(t, "private, no-store", rec.Header().Get(echo.HeaderCacheControl))
+		})
+	}
+}
@@ -0,0 +1,231 @@
+package web
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+	"text/template"
+	"time"
+
+	"github.com/labstack/echo/v4"
+
+	"github.com/goformx/goforms/internal/application/validation"
+	formdomain "github.com/goformx/goforms/internal/domain/form"
+	"github.com/goformx/goforms/internal/domain/form/model"
+	"github.com/goformx/goforms/internal/infrastructure/pdf"
+)
+
+const (
+	// defaultPDFFooter is used when a form does not configure its own footer template
+	defaultPDFFooter = "Page {{.Page}} of {{.Pages}}"
+)
+
+// PDFTemplateData is exposed to per-form header and footer templates.
+// Templates live in the form schema under settings.pdf.header and settings.pdf.footer.
+type PDFTemplateData struct {
+	FormID       string
+	FormTitle    string
+	SubmissionID string
+	SubmittedAt  string
+	GeneratedAt  string
+	Page         int
+	Pages        int
+}
+
+// SubmissionPDFRenderer renders forms and submissions to PDF documents
+type SubmissionPDFRenderer struct {
+	schemaParser *validation.SchemaParser
+}
+
+// NewSubmissionPDFRenderer creates a new submission PDF renderer
+func NewSubmissionPDFRenderer() *SubmissionPDFRenderer {
+	return &SubmissionPDFRenderer{schemaParser: validation.NewSchemaParser()}
+}
+
+// Render renders a submission to PDF with its times in loc. A nil submission
+// renders the blank form.
+func (r *SubmissionPDFRenderer) Render(form *model.Form, submission *model.FormSubmission, loc *time.Location) ([]byte, error) {
+	header, footer, err := r.templates(form)
+	if err != nil {
+		return nil, err
+	}
+
+	data := PDFTemplateData{
+		FormID:      form.ID,
+		FormTitle:   form.Title,
+		GeneratedAt: time.Now().In(loc).Format(time.RFC3339),
+	}
+
+	var answers model.JSON
+
+	if submission != nil {
+		data.SubmissionID = submission.ID
+		data.SubmittedAt = submission.SubmittedAt.In(loc).Format(time.RFC3339)
+		answers = submission.Data
+	}
+
+	doc := pdf.NewDocument()
+	doc.Header = r.pageCallback(header, data)
+	doc.Footer = r.pageCallback(footer, data)
+
+	doc.AddText(form.Title, pdf.StyleTitle)
+
+	if form.Description != "" {
+		doc.AddText(form.Description, pdf.StyleBody)
+	}
+
+	if submission != nil {
+		doc.AddText(fmt.Sprintf("Submission %s - %s", data.SubmissionID, data.SubmittedAt), pdf.StyleMuted)
+	}
+
+	doc.AddSpace(12)
+
+	r.addFields(doc, form.Schema, answers, submission == nil)
+
+	return doc.Bytes(), nil
+}
+
+// addFields writes one label/value block per input component, with answer
+// references in labels resolved, followed by any submitted keys the schema
+// does not describe
+func (r *SubmissionPDFRenderer) addFields(doc *pdf.Document, schema, answers model.JSON, blank bool) {
+	seen := make(map[string]bool)
+
+	components, _ := r.schemaParser.ExtractInputComponents(schema)
+	for _, component := range components {
+		key, ok := r.schemaParser.ExtractComponentKey(component)
+		if !ok {
+			continue
+		}
+
+		seen[key] = true
+
+		label, _ := component["label"].(string)
+		if label == "" {
+			label = key
+		}
+
+		value := "________________________________________"
+		if !blank {
+			value = formdomain.FormatSubmissionValue(answers[key])
+		}
+
+		doc.AddText(formdomain.PipeText(label, answers), pdf.StyleLabel)
+		doc.AddText(value, pdf.StyleBody)
+		doc.AddSpace(6)
+	}
+
+	extra := make([]string, 0, len(answers))
+
+	for key := range answers {
+		if !seen[key] {
+			extra = append(extra, key)
+		}
+	}
+
+	sort.Strings(extra)
+
+	for _, key := range extra {
+		doc.AddText(key, pdf.StyleLabel)
+		doc.AddText(formdomain.FormatSubmissionValue(answers[key]), pdf.StyleBody)
+		doc.AddSpace(6)
+	}
+}
+
+// templates parses the form's header and footer templates
+func (r *SubmissionPDFRenderer) templates(form *model.Form) (header, footer *template.Template, err error) {
+	headerSource, footerSource := "{{.FormTitle}}", defaultPDFFooter
+
+	if settings, ok := form.Schema["settings"].(map[string]any); ok {
+		if pdfSettings, pdfOk := settings["pdf"].(map[string]any); pdfOk {
+			if value, valueOk := pdfSettings["header"].(string); valueOk {
+				headerSource = value
+			}
+
+			if value, valueOk := pdfSettings["footer"].(string); valueOk {
+				footerSource = value
+			}
+		}
+	}
+
+	header, err = template.New("header").Option("missingkey=zero").Parse(headerSource)
+	if err != nil {
+		return nil, nil, fmt.Errorf("parse pdf header template: %w", err)
+	}
+
+	footer, err = template.New("footer").Option("missingkey=zero").Parse(footerSource)
+	if err != nil {
+		return nil, nil, fmt.Errorf("parse pdf footer template: %w", err)
+	}
+
+	return header, footer, nil
+}
+
+// pageCallback adapts a template to the document's per-page callback.
+// Execution errors render an empty line rather than failing the whole document.
+func (r *SubmissionPDFRenderer) pageCallback(tmpl *template.Template, data PDFTemplateData) func(pdf.PageInfo) string {
+	return func(info pdf.PageInfo) string {
+		data.Page, data.Pages = info.Page, info.Pages
+
+		var buf bytes.Buffer
+		if err := tmpl.Execute(&buf, data); err != nil {
+			return ""
+		}
+
+		return buf.String()
+	}
+}
+
+// GET /api/forms/:id/pdf - render the blank form (assertion auth)
+func (h *FormAPIHandler) handleFormPDF(c echo.Context) error {
+	form, err := h.getFormWithOwnershipOrError(c)
+	if err != nil || form == nil {
+		return err
+	}
+
+	return h.writePDF(c, form, nil, "form-"+form.ID+".pdf")
+}
+
+// GET /api/forms/:id/submissions/:sid/pdf - render a submission (assertion auth)
+func (h *FormAPIHandler) handleSubmissionPDF(c echo.Context) error {
+	form, err := h.getFormWithOwnershipOrError(c)
+	if err != nil || form == nil {
+		return err
+	}
+
+	submissionID := c.Param("sid")
+
+	submission, err := h.getSubmission(c.Request().Context(), form.ID, submissionID)
+	if err != nil {
+		if errors.Is(err, model.ErrSubmissionNotFound) {
+			return h.ResponseBuilder.BuildNotFoundResponse(c, "Submission")
+		}
+
+		h.Logger.Error("failed to get submission", "error", err, "form_id", form.ID, "submission_id", submissionID)
+
+		return h.HandleError(c, err, "Failed to get submission")
+	}
+
+	return h.writePDF(c, form, submission, "submission-"+submission.ID+".pdf")
+}
+
+// writePDF renders and streams a PDF attachment
+func (h *FormAPIHandler) writePDF(
+	c echo.Context,
+	form *model.Form,
+	submission *model.FormSubmission,
+	filename string,
+) error {
+	document, err := h.PDFRenderer.Render(form, submission, h.formLocation(c.Request().Context(), form))
+	if err != nil {
+		h.Logger.Warn("failed to render pdf", "error", err, "form_id", form.ID)
+
+		return h.ResponseBuilder.BuildErrorResponse(c, http.StatusUnprocessableEntity, "Invalid PDF template")
+	}
+
+	c.Response().Header().Set(echo.HeaderContentDisposition, fmt.Sprintf("attachment; filename=%q", filename))
+
+	return c.Blob(http.StatusOK, "application/pdf", document)
+}
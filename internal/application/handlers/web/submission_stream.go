@@ -0,0 +1,325 @@
+package web
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/labstack/echo/v4"
+
+	"github.com/goformx/goforms/internal/domain/common/events"
+	formevents "github.com/goformx/goforms/internal/domain/form/events"
+	"github.com/goformx/goforms/internal/domain/form/model"
+	"github.com/goformx/goforms/internal/infrastructure/logging"
+	"github.com/goformx/goforms/internal/infrastructure/repository/common"
+)
+
+const (
+	// submissionStreamBuffer is the number of pending events held per connection
+	// before the connection is considered too slow and is closed.
+	submissionStreamBuffer = 32
+	// submissionStreamHeartbeat is the interval between SSE keep-alive comments
+	submissionStreamHeartbeat = 15 * time.Second
+	// submissionStreamRetryMs tells EventSource clients how long to wait before reconnecting
+	submissionStreamRetryMs = 3000
+	// submissionStreamSeenEvents is the number of event IDs remembered to skip replays
+	submissionStreamSeenEvents = 1024
+	// submissionStreamRevoked is the last chunk of a stream whose access was revoked
+	submissionStreamRevoked = "event: revoked\ndata: {}\n\n"
+	// submissionStreamRecheck is the interval at which a stream checks that its user
+	// still owns the form, for deletions and transfers the event bus did not carry
+	submissionStreamRecheck = time.Minute
+)
+
+// SubmissionStreamEvent is the payload pushed to stream subscribers for a new submission
+type SubmissionStreamEvent struct {
+	ID          string    `json:"id"`
+	FormID      string    `json:"form_id"`
+	Status      string    `json:"status"`
+	SubmittedAt time.Time `json:"submitted_at"`
+	// Test is set for submissions made from the builder's preview
+	Test bool `json:"test,omitempty"`
+}
+
+// submissionSubscriber is a single SSE connection waiting for events on one form
+type submissionSubscriber struct {
+	formID  string
+	ownerID string
+	events  chan SubmissionStreamEvent
+	// revoked is closed when the form is deleted or no longer owned by ownerID
+	revoked chan struct{}
+}
+
+// SubmissionStreamHub fans out form.submitted events from the event bus to SSE connections.
+// It subscribes to the bus once; connections register and unregister with the hub.
+// A replayed event that was already streamed is not streamed again. Connections are
+// revoked when their form is deleted or moves to another owner.
+type SubmissionStreamHub struct {
+	logger      logging.Logger
+	eventBus    events.EventBus
+	seen        *events.Deduplicator
+	mu          sync.RWMutex
+	subscribers map[*submissionSubscriber]struct{}
+	startOnce   sync.Once
+}
+
+// NewSubmissionStreamHub creates a new submission stream hub
+func NewSubmissionStreamHub(eventBus events.EventBus, logger logging.Logger) *SubmissionStreamHub {
+	return &SubmissionStreamHub{
+		logger:      logger,
+		eventBus:    eventBus,
+		seen:        events.NewDeduplicator(submissionStreamSeenEvents),
+		subscribers: make(map[*submissionSubscriber]struct{}),
+	}
+}
+
+// Start subscribes the hub to submission events on the event bus
+func (h *SubmissionStreamHub) Start(ctx context.Context) error {
+	var err error
+
+	h.startOnce.Do(func() {
+		if h.eventBus == nil {
+			return
+		}
+
+		if err = h.eventBus.Subscribe(ctx, string(formevents.FormSubmittedEventType), h.handleEvent); err != nil {
+			return
+		}
+
+		if err = h.eventBus.Subscribe(ctx, string(formevents.FormUpdatedEventType), h.handleFormChange); err != nil {
+			return
+		}
+
+		err = h.eventBus.Subscribe(ctx, string(formevents.FormDeletedEventType), h.handleFormChange)
+	})
+
+	if err != nil {
+		return fmt.Errorf("subscribe to submission events: %w", err)
+	}
+
+	return nil
+}
+
+// Subscribe registers a connection of ownerID for events on formID. The second
+// channel is closed when the connection is revoked. The returned function must
+// be called to unregister; it is safe to call more than once.
+func (h *SubmissionStreamHub) Subscribe(
+	formID, ownerID string,
+) (events <-chan SubmissionStreamEvent, revoked <-chan struct{}, unsubscribe func()) {
+	sub := &submissionSubscriber{
+		formID:  formID,
+		ownerID: ownerID,
+		events:  make(chan SubmissionStreamEvent, submissionStreamBuffer),
+		revoked: make(chan struct{}),
+	}
+
+	h.mu.Lock()
+	h.subscribers[sub] = struct{}{}
+	h.mu.Unlock()
+
+	return sub.events, sub.revoked, func() { h.remove(sub) }
+}
+
+// SubscriberCount returns the number of active stream connections
+func (h *SubmissionStreamHub) SubscriberCount() int {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+
+	return len(h.subscribers)
+}
+
+// handleEvent delivers a submission event to the subscribers of its form
+func (h *SubmissionStreamHub) handleEvent(_ context.Context, event events.Event) error {
+	submission, ok := event.Payload().(*model.FormSubmission)
+	if !ok || submission == nil || h.seen.Seen(event) {
+		return nil
+	}
+
+	h.Publish(SubmissionStreamEvent{
+		ID:          submission.ID,
+		FormID:      submission.FormID,
+		Status:      string(submission.Status),
+		SubmittedAt: submission.SubmittedAt,
+		Test:        submission.Test,
+	})
+
+	return nil
+}
+
+// handleFormChange revokes the connections of a deleted form, and of a form
+// whose owner changed
+func (h *SubmissionStreamHub) handleFormChange(_ context.Context, event events.Event) error {
+	switch payload := event.Payload().(type) {
+	case string:
+		h.Revoke(payload, func(string) bool { return true })
+	case *model.Form:
+		if payload != nil {
+			h.Revoke(payload.ID, func(ownerID string) bool { return ownerID != payload.UserID })
+		}
+	}
+
+	return nil
+}
+
+// Revoke ends the connections on formID whose owner matches; their revoked
+// channel is closed and they get no further events
+func (h *SubmissionStreamHub) Revoke(formID string, match func(ownerID string) bool) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	for sub := range h.subscribers {
+		if sub.formID != formID || !match(sub.ownerID) {
+			continue
+		}
+
+		delete(h.subscribers, sub)
+		close(sub.revoked)
+	}
+}
+
+// Publish delivers an event without blocking. Subscribers whose buffer is full are
+// dropped (their channel is closed) so a slow client cannot stall submissions.
+func (h *SubmissionStreamHub) Publish(evt SubmissionStreamEvent) {
+	var slow []*submissionSubscriber
+
+	h.mu.RLock()
+	for sub := range h.subscribers {
+		if sub.formID != evt.FormID {
+			continue
+		}
+
+		select {
+		case sub.events <- evt:
+		default:
+			slow = append(slow, sub)
+		}
+	}
+	h.mu.RUnlock()
+
+	for _, sub := range slow {
+		h.logger.Warn("dropping slow submission stream subscriber", "form_id", sub.formID)
+		h.remove(sub)
+	}
+}
+
+// remove unregisters a subscriber and closes its channel
+func (h *SubmissionStreamHub) remove(sub *submissionSubscriber) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if _, exists := h.subscribers[sub]; !exists {
+		return
+	}
+
+	delete(h.subscribers, sub)
+	close(sub.events)
+}
+
+// GET /api/forms/:id/submissions/stream - live submission events (assertion auth)
+func (h *FormAPIHandler) handleSubmissionStream(c echo.Context) error {
+	form, err := h.getFormWithOwnershipOrError(c)
+	if err != nil || form == nil {
+		return err
+	}
+
+	userID, _ := c.Get("user_id").(string)
+
+	stream, revoked, unsubscribe := h.SubmissionStream.Subscribe(form.ID, userID)
+	defer unsubscribe()
+
+	res := c.Response()
+	res.Header().Set(echo.HeaderContentType, "text/event-stream")
+	res.Header().Set(echo.HeaderCacheControl, "no-cache")
+	res.Header().Set(echo.HeaderConnection, "keep-alive")
+	res.Header().Set("X-Accel-Buffering", "no")
+	res.WriteHeader(http.StatusOK)
+
+	// Streams outlive the server write timeout; extend the deadline on every write.
+	rc := http.NewResponseController(res.Writer)
+
+	if !h.writeStreamChunk(rc, res, fmt.Sprintf("retry: %d\n\n", submissionStreamRetryMs)) {
+		return nil
+	}
+
+	heartbeat := time.NewTicker(submissionStreamHeartbeat)
+	defer heartbeat.Stop()
+
+	recheck := time.NewTicker(submissionStreamRecheck)
+	defer recheck.Stop()
+
+	ctx := c.Request().Context()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-heartbeat.C:
+			if !h.writeStreamChunk(rc, res, ": heartbeat\n\n") {
+				return nil
+			}
+		case <-recheck.C:
+			if !h.streamStillOwned(ctx, form.ID, userID) {
+				h.writeStreamChunk(rc, res, submissionStreamRevoked)
+
+				return nil
+			}
+		case <-revoked:
+			// The form was deleted or transferred; reconnecting gets a 404 or 403.
+			h.writeStreamChunk(rc, res, submissionStreamRevoked)
+
+			return nil
+		case evt, ok := <-stream:
+			if !ok {
+				// Dropped for falling behind; the client reconnects and refetches.
+				h.writeStreamChunk(rc, res, "event: overflow\ndata: {}\n\n")
+
+				return nil
+			}
+
+			data, marshalErr := json.Marshal(evt)
+			if marshalErr != nil {
+				h.Logger.Error("failed to encode submission stream event", "error", marshalErr, "form_id", form.ID)
+
+				continue
+			}
+
+			if !h.writeStreamChunk(rc, res, fmt.Sprintf("id: %s\nevent: submission\ndata: %s\n\n", evt.ID, data)) {
+				return nil
+			}
+		}
+	}
+}
+
+// streamStillOwned reports whether userID still owns the form. A lookup that fails
+// for another reason keeps the stream open until the next check.
+func (h *FormAPIHandler) streamStillOwned(ctx context.Context, formID, userID string) bool {
+	current, err := h.FormService.GetForm(ctx, formID)
+	if errors.Is(err, common.ErrNotFound) || errors.Is(err, common.ErrInvalidInput) {
+		return false
+	}
+
+	if err != nil {
+		h.Logger.Warn("failed to recheck submission stream access", "form_id", formID, "error", err)
+
+		return true
+	}
+
+	return current != nil && current.UserID == userID
+}
+
+// writeStreamChunk writes and flushes an SSE chunk, returning false if the client is gone
+func (h *FormAPIHandler) writeStreamChunk(rc *http.ResponseController, res *echo.Response, chunk string) bool {
+	_ = rc.SetWriteDeadline(time.Now().Add(submissionStreamHeartbeat * 2))
+
+	if _, err := res.Write([]byte(chunk)); err != nil {
+		return false
+	}
+
+	res.Flush()
+
+	return true
+}
@@ -0,0 +1,112 @@
+package web_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/goformx/goforms/internal/application/handlers/web"
+	formevents "github.com/goformx/goforms/internal/domain/form/events"
+	"github.com/goformx/goforms/internal/domain/form/model"
+	"github.com/goformx/goforms/internal/infrastructure/event"
+	mocklogging "github.com/goformx/goforms/test/mocks/logging"
+)
+
+func TestSubmissionStreamHub_DeliversToFormSubscribers(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockLogger := mocklogging.NewMockLogger(ctrl)
+	mockLogger.EXPECT().Warn(gomock.Any(), gomock.Any()).AnyTimes()
+
+	bus := event.NewMemoryEventBus(mockLogger)
+	hub := web.NewSubmissionStreamHub(bus, mockLogger)
+	require.NoError(t, hub.Start(context.Background()))
+
+	stream, _, unsubscribe := hub.Subscribe("form-1", "user-1")
+	defer unsubscribe()
+
+	other, _, unsubscribeOther := hub.Subscribe("form-2", "user-1")
+	defer unsubscribeOther()
+
+	submission := &model.FormSubmission{
+		ID:          "sub-1",
+		FormID:      "form-1",
+		Status:      model.SubmissionStatusPending,
+		SubmittedAt: time.Now(),
+	}
+	require.NoError(t, bus.Publish(context.Background(), formevents.NewFormSubmittedEvent(submission)))
+
+	select {
+	case evt := <-stream:
+		assert.Equal(t, "sub-1", evt.ID)
+		assert.Equal(t, "form-1", evt.FormID)
+	default:
+		t.Fatal("expected submission event for form-1")
+	}
+
+	assert.Empty(t, other)
+}
+
+func TestSubmissionStreamHub_DropsSlowSubscribers(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockLogger := mocklogging.NewMockLogger(ctrl)
+	mockLogger.EXPECT().Warn("dropping slow submission stream subscriber", gomock.Any()).Times(1)
+
+	hub := web.NewSubmissionStreamHub(nil, mockLogger)
+
+	stream, _, unsubscribe := hub.Subscribe("form-1", "user-1")
+	defer unsubscribe()
+
+	// Fill the buffer and overflow it by one
+	for i := 0; i <= cap(stream); i++ {
+		hub.Publish(web.SubmissionStreamEvent{ID: "sub", FormID: "form-1"})
+	}
+
+	assert.Equal(t, 0, hub.SubscriberCount())
+
+	for range stream {
+		// Drain; loop ends because the hub closed the channel.
+	}
+}
+
+func TestSubmissionStreamHub_RevokesOnDeleteAndTransfer(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockLogger := mocklogging.NewMockLogger(ctrl)
+	mockLogger.EXPECT().Warn(gomock.Any(), gomock.Any()).AnyTimes()
+
+	bus := event.NewMemoryEventBus(mockLogger)
+	hub := web.NewSubmissionStreamHub(bus, mockLogger)
+	require.NoError(t, hub.Start(context.Background()))
+
+	_, ownerRevoked, unsubscribeOwner := hub.Subscribe("form-1", "user-1")
+	defer unsubscribeOwner()
+
+	_, formerRevoked, unsubscribeFormer := hub.Subscribe("form-1", "user-2")
+	defer unsubscribeFormer()
+
+	_, otherRevoked, unsubscribeOther := hub.Subscribe("form-2", "user-1")
+	defer unsubscribeOther()
+
+	// Saving form-1 as user-1's ends the stream user-2 still had open
+	require.NoError(t, bus.Publish(context.Background(), formevents.NewFormUpdatedEvent(&model.Form{ID: "form-1", UserID: "user-1"})))
+	assert.Eventually(t, func() bool { return isClosed(formerRevoked) }, time.Second, time.Millisecond)
+	assert.False(t, isClosed(ownerRevoked))
+
+	require.NoError(t, bus.Publish(context.Background(), formevents.NewFormDeletedEvent("form-1")))
+	assert.Eventually(t, func() bool { return isClosed(ownerRevoked) }, time.Second, time.Millisecond)
+	assert.False(t, isClosed(otherRevoked))
+	assert.Equal(t, 1, hub.SubscriberCount())
+}
+
+// isClosed reports whether a revoked channel was closed
+func isClosed(ch <-chan struct{}) bool {
+	select {
+	case <-ch:
+		return true
+	default:
+		return false
+	}
+}
@@ -0,0 +1,58 @@
+package web
+
+import (
+	"context"
+	"net/http"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+
+	"github.com/goformx/goforms/internal/application/constants"
+	"github.com/goformx/goforms/internal/application/theme"
+)
+
+// themeStylesheetMaxAge is how long browsers may reuse the stylesheet before
+// revalidating it with its ETag
+const themeStylesheetMaxAge = "public, max-age=3600"
+
+// ThemeHandler serves the theme stylesheet at /css/theme.css for the Laravel
+// dashboard and form builder. The route is public.
+type ThemeHandler struct {
+	*BaseHandler
+}
+
+// NewThemeHandler creates a new ThemeHandler
+func NewThemeHandler(base *BaseHandler) *ThemeHandler {
+	return &ThemeHandler{BaseHandler: base}
+}
+
+// RegisterRoutes registers the stylesheet route
+func (h *ThemeHandler) RegisterRoutes(e *echo.Echo) {
+	e.GET(constants.PathThemeCSS, h.handleStylesheet)
+}
+
+// GET /css/theme.css - the theme variables and the Form.io overrides
+func (h *ThemeHandler) handleStylesheet(c echo.Context) error {
+	header := c.Response().Header()
+	header.Set(echo.HeaderCacheControl, themeStylesheetMaxAge)
+	header.Set("ETag", theme.ETag)
+
+	if strings.Contains(c.Request().Header.Get("If-None-Match"), theme.ETag) {
+		return c.NoContent(http.StatusNotModified)
+	}
+
+	return c.Blob(http.StatusOK, "text/css; charset=utf-8", []byte(theme.Stylesheet))
+}
+
+// Register satisfies the Handler interface; routes are registered by RegisterHandlers
+func (h *ThemeHandler) Register(_ *echo.Echo) {}
+
+// Start initializes the handler
+func (h *ThemeHandler) Start(_ context.Context) error {
+	return nil
+}
+
+// Stop cleans up the handler
+func (h *ThemeHandler) Stop(_ context.Context) error {
+	return nil
+}
@@ -0,0 +1,37 @@
+package web_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/goformx/goforms/internal/application/constants"
+	"github.com/goformx/goforms/internal/application/handlers/web"
+	"github.com/goformx/goforms/internal/application/theme"
+)
+
+func TestThemeHandler(t *testing.T) {
+	e := echo.New()
+	web.NewThemeHandler(&web.BaseHandler{}).RegisterRoutes(e)
+
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, constants.PathThemeCSS, http.NoBody))
+
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.Contains(t, rec.Header().Get(echo.HeaderContentType), "text/css")
+	assert.Contains(t, rec.Body.String(), `:root[data-theme="dark"]`)
+	assert.Contains(t, rec.Body.String(), ".formbuilder")
+
+	req := httptest.NewRequest(http.MethodGet, constants.PathThemeCSS, http.NoBody)
+	req.Header.Set("If-None-Match", rec.Header().Get("ETag"))
+
+	rec = httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusNotModified, rec.Code)
+	assert.Equal(t, theme.ETag, rec.Header().Get("ETag"))
+}
@@ -0,0 +1,143 @@
+package web
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/labstack/echo/v4"
+
+	"github.com/goformx/goforms/internal/application/constants"
+	"github.com/goformx/goforms/internal/application/middleware/security"
+	"github.com/goformx/goforms/internal/application/response"
+	domainerrors "github.com/goformx/goforms/internal/domain/common/errors"
+	"github.com/goformx/goforms/internal/domain/metering"
+)
+
+// usageDateLayout is the format of the from and to query parameters
+const usageDateLayout = "2006-01-02"
+
+// UsageHandler serves the usage report of the requesting account at
+// /api/v1/usage. Requests authenticate with an API key or a signed user
+// assertion; there is no session.
+type UsageHandler struct {
+	*BaseHandler
+	// Metering is nil when api.metering is disabled
+	Metering metering.Service
+
+	routes routeRecorder
+}
+
+// NewUsageHandler creates a new UsageHandler
+func NewUsageHandler(base *BaseHandler, meter metering.Service) *UsageHandler {
+	return &UsageHandler{BaseHandler: base, Metering: meter}
+}
+
+// RegisterRoutes registers the usage route
+func (h *UsageHandler) RegisterRoutes(e *echo.Echo) {
+	h.routes.reset()
+
+	usage := h.routes.group(e, constants.PathAPIUsage, "api_key_or_assertion",
+		named("api_key_or_assertion", security.RequireClient(h.Config)))
+	usage.GET("", h.handleUsage)
+}
+
+// DescribeRoutes describes how the usage route authenticates
+func (h *UsageHandler) DescribeRoutes() []RouteGroup {
+	return h.routes.described()
+}
+
+// Register satisfies the Handler interface; routes are registered by RegisterHandlers
+func (h *UsageHandler) Register(_ *echo.Echo) {}
+
+// Start initializes the handler
+func (h *UsageHandler) Start(_ context.Context) error {
+	return nil
+}
+
+// Stop cleans up the handler
+func (h *UsageHandler) Stop(_ context.Context) error {
+	return nil
+}
+
+// GET /api/v1/usage?from=YYYY-MM-DD&to=YYYY-MM-DD - the account's daily API calls
+// and submissions, by default from the start of the month through today
+func (h *UsageHandler) handleUsage(c echo.Context) error {
+	account, ok := security.ClientAccount(c)
+	if !ok {
+		return response.ErrorResponse(c, http.StatusUnauthorized, "Authentication required")
+	}
+
+	if h.Metering == nil {
+		return response.ErrorResponse(c, http.StatusConflict, "Usage metering is disabled")
+	}
+
+	now := time.Now().UTC()
+	from := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC)
+	to := now
+
+	for name, target := range map[string]*time.Time{"from": &from, "to": &to} {
+		value := c.QueryParam(name)
+		if value == "" {
+			continue
+		}
+
+		parsed, err := time.Parse(usageDateLayout, value)
+		if err != nil {
+			return response.ErrorResponse(c, http.StatusBadRequest, "Invalid "+name+" date, expected YYYY-MM-DD")
+		}
+
+		*target = parsed
+	}
+
+	report, err := h.Metering.Usage(c.Request().Context(), account, from, to)
+	if err != nil {
+		if domainErr := domainerrors.GetDomainError(err); domainErr != nil &&
+			domainErr.Code == domainerrors.ErrCodeValidation {
+			return response.ErrorResponse(c, http.StatusBadRequest, domainErr.Message)
+		}
+
+		h.Logger.Error("failed to get usage report", "account", account, "error", err)
+
+		return response.ErrorResponse(c, http.StatusInternalServerError, "Failed to get usage")
+	}
+
+	return response.Success(c, usageReportResponse(report))
+}
+
+// usageReportResponse formats a usage report with one entry per day
+func usageReportResponse(report *metering.Report) map[string]any {
+	daily := make([]map[string]any, 0, len(report.Days))
+	for _, day := range report.Days {
+		entry := map[string]any{"date": day.Day.Format(usageDateLayout)}
+		for _, metric := range metering.Metrics {
+			entry[metric] = day.Counts[metric]
+		}
+
+		daily = append(daily, entry)
+	}
+
+	period := map[string]any{
+		"start": report.Period.Start.Format(usageDateLayout),
+		"end":   report.Period.End.Format(usageDateLayout),
+	}
+
+	for _, metric := range metering.Metrics {
+		// A missing or zero limit is unlimited
+		var limit any
+		if n := report.Period.Limits[metric]; n > 0 {
+			limit = n
+		}
+
+		period[metric] = map[string]any{"used": report.Period.Used[metric], "limit": limit}
+	}
+
+	return map[string]any{
+		"account": report.Account,
+		"from":    report.From.Format(usageDateLayout),
+		"to":      report.To.Format(usageDateLayout),
+		"totals":  report.Totals,
+		"daily":   daily,
+		"period":  period,
+	}
+}
@@ -0,0 +1,90 @@
+package web_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/goformx/goforms/internal/application/constants"
+	"github.com/goformx/goforms/internal/application/handlers/web"
+	"github.com/goformx/goforms/internal/domain/metering"
+	"github.com/goformx/goforms/internal/infrastructure/config"
+	"github.com/goformx/goforms/internal/infrastructure/counter"
+	memorystore "github.com/goformx/goforms/internal/infrastructure/repository/memory"
+	mocklogging "github.com/goformx/goforms/test/mocks/logging"
+)
+
+func TestUsageHandler(t *testing.T) {
+	ctrl := gomock.NewController(t)
+
+	logger := mocklogging.NewMockLogger(ctrl)
+	logger.EXPECT().Info(gomock.Any(), gomock.Any()).AnyTimes()
+
+	cfg := &config.Config{}
+	cfg.Security.APIKey.Keys = []string{"test-api-key"}
+
+	meter := metering.NewService(memorystore.NewStore(logger).Metering(), counter.NewMemoryCounter(), nil, nil,
+		metering.Config{Limits: map[string]int64{metering.MetricAPICalls: 100}}, logger)
+
+	e := echo.New()
+	web.NewUsageHandler(&web.BaseHandler{Logger: logger, Config: cfg}, meter).RegisterRoutes(e)
+
+	call := func(query, apiKey string) (int, map[string]any) {
+		req := httptest.NewRequest(http.MethodGet, constants.PathAPIUsage+query, http.NoBody)
+		if apiKey != "" {
+			req.Header.Set("X-API-Key", apiKey)
+		}
+
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, req)
+
+		var payload struct {
+			Data map[string]any `json:"data"`
+		}
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &payload))
+
+		return rec.Code, payload.Data
+	}
+
+	code, _ := call("", "")
+	assert.Equal(t, http.StatusUnauthorized, code)
+
+	code, data := call("", "test-api-key")
+	require.Equal(t, http.StatusOK, code)
+
+	account, ok := data["account"].(string)
+	require.True(t, ok)
+	require.NoError(t, meter.Record(t.Context(), account, metering.MetricAPICalls, 2))
+
+	today := time.Now().UTC().Format(time.DateOnly)
+
+	code, data = call("?from="+today+"&to="+today, "test-api-key")
+	require.Equal(t, http.StatusOK, code)
+
+	daily, ok := data["daily"].([]any)
+	require.True(t, ok)
+	require.Len(t, daily, 1)
+
+	day, ok := daily[0].(map[string]any)
+	require.True(t, ok)
+	assert.Equal(t, today, day["date"])
+	assert.InDelta(t, 2, day[metering.MetricAPICalls], 0)
+
+	period, ok := data["period"].(map[string]any)
+	require.True(t, ok)
+	assert.Equal(t, map[string]any{"used": float64(2), "limit": float64(100)}, period[metering.MetricAPICalls])
+	assert.Equal(t, map[string]any{"used": float64(0), "limit": nil}, period[metering.MetricSubmissions])
+
+	code, _ = call("?from=yesterday", "test-api-key")
+	assert.Equal(t, http.StatusBadRequest, code)
+
+	code, _ = call("?from="+today+"&to=2000-01-01", "test-api-key")
+	assert.Equal(t, http.StatusBadRequest, code)
+}
@@ -0,0 +1,154 @@
+// Package convert defines the form converter contract used by the importer and
+// helpers for building Form.io components, the schema format GoForms stores.
+package convert
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+
+	"github.com/goformx/goforms/internal/domain/form/model"
+)
+
+// maxKeyLength bounds generated component keys
+const maxKeyLength = 64
+
+// Definition is a form converted from an import file
+type Definition struct {
+	Title       string
+	Description string
+	Schema      model.JSON
+	// Warnings lists parts of the source that could not be converted exactly
+	Warnings []string
+}
+
+// Warn records a conversion warning
+func (d *Definition) Warn(format string, args ...any) {
+	d.Warnings = append(d.Warnings, fmt.Sprintf(format, args...))
+}
+
+// Converter translates a form definition exported from a form builder into a Definition
+type Converter interface {
+	Convert(data []byte) (*Definition, error)
+}
+
+// Schema wraps components in a Form.io form schema ending with a submit button
+func Schema(components []any) model.JSON {
+	return model.JSON{
+		"display":    "form",
+		"components": append(components, SubmitButton()),
+	}
+}
+
+// SubmitButton returns the submit button added to every converted form
+func SubmitButton() map[string]any {
+	return map[string]any{
+		"type":   "button",
+		"key":    "submit",
+		"label":  "Submit",
+		"action": "submit",
+		"input":  true,
+	}
+}
+
+// Input returns an input component. Required adds validate.required.
+func Input(componentType, key, label string, required bool) map[string]any {
+	return map[string]any{
+		"type":     componentType,
+		"key":      key,
+		"label":    label,
+		"input":    true,
+		"validate": map[string]any{"required": required},
+	}
+}
+
+// Options returns a Form.io option list. Values are derived from labels.
+func Options(labels []string) []any {
+	keys := NewKeys()
+	values := make([]any, 0, len(labels))
+
+	for _, label := range labels {
+		values = append(values, map[string]any{"label": label, "value": keys.Next(label)})
+	}
+
+	return values
+}
+
+// Content returns a static text component
+func Content(key, html string) map[string]any {
+	return map[string]any{
+		"type":    "htmlelement",
+		"key":     key,
+		"tag":     "p",
+		"content": html,
+		"input":   false,
+	}
+}
+
+// Panel returns a layout panel holding components
+func Panel(key, title string, components []any) map[string]any {
+	return map[string]any{
+		"type":       "panel",
+		"key":        key,
+		"title":      title,
+		"input":      false,
+		"components": components,
+	}
+}
+
+// Keys generates unique component keys from labels
+type Keys struct {
+	used map[string]bool
+}
+
+// NewKeys creates an empty key generator
+func NewKeys() *Keys {
+	return &Keys{used: map[string]bool{"submit": true}}
+}
+
+// Next returns a camelCase key for label that has not been returned before
+func (k *Keys) Next(label string) string {
+	base := camelKey(label)
+	if base == "" {
+		base = "field"
+	}
+
+	key := base
+	for n := 2; k.used[key]; n++ {
+		key = fmt.Sprintf("%s%d", base, n)
+	}
+
+	k.used[key] = true
+
+	return key
+}
+
+// camelKey converts free text to a camelCase identifier, as the Form.io builder does
+func camelKey(label string) string {
+	var b strings.Builder
+
+	upper := false
+
+	for _, r := range label {
+		switch {
+		case r > unicode.MaxASCII || !(unicode.IsLetter(r) || unicode.IsDigit(r)):
+			upper = b.Len() > 0
+		case b.Len() == 0 && unicode.IsDigit(r):
+			continue
+		case upper:
+			b.WriteRune(unicode.ToUpper(r))
+
+			upper = false
+		case b.Len() == 0:
+			b.WriteRune(unicode.ToLower(r))
+		default:
+			b.WriteRune(r)
+		}
+
+		if b.Len() >= maxKeyLength {
+			break
+		}
+	}
+
+	return b.String()
+}
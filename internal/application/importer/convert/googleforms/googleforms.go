@@ -0,0 +1,298 @@
+// Package googleforms converts Google Forms definitions, as returned by the
+// Google Forms API (forms.get), into GoForms schemas.
+package googleforms
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"html"
+	"strconv"
+
+	"github.com/goformx/goforms/internal/application/importer/convert"
+)
+
+// Source is the importer source name for Google Forms
+const Source = "google_forms"
+
+// form is the subset of a Google Forms resource used by the converter
+type form struct {
+	Info struct {
+		Title         string `json:"title"`
+		DocumentTitle string `json:"documentTitle"`
+		Description   string `json:"description"`
+	} `json:"info"`
+	Items []item `json:"items"`
+}
+
+// item is a question, question group, section break or static content
+type item struct {
+	Title             string             `json:"title"`
+	Description       string             `json:"description"`
+	QuestionItem      *questionItem      `json:"questionItem"`
+	QuestionGroupItem *questionGroupItem `json:"questionGroupItem"`
+	PageBreakItem     *struct{}          `json:"pageBreakItem"`
+	TextItem          *struct{}          `json:"textItem"`
+	ImageItem         *struct{}          `json:"imageItem"`
+	VideoItem         *struct{}          `json:"videoItem"`
+}
+
+// questionItem wraps a single question
+type questionItem struct {
+	Question question `json:"question"`
+}
+
+// questionGroupItem is a grid of questions sharing the same options
+type questionGroupItem struct {
+	Questions []question `json:"questions"`
+	Grid      *struct {
+		Columns choiceQuestion `json:"columns"`
+	} `json:"grid"`
+}
+
+// question holds exactly one of the question kinds
+type question struct {
+	Required           bool            `json:"required"`
+	RowQuestion        *rowQuestion    `json:"rowQuestion"`
+	TextQuestion       *textQuestion   `json:"textQuestion"`
+	ChoiceQuestion     *choiceQuestion `json:"choiceQuestion"`
+	ScaleQuestion      *scaleQuestion  `json:"scaleQuestion"`
+	DateQuestion       *dateQuestion   `json:"dateQuestion"`
+	TimeQuestion       *struct{}       `json:"timeQuestion"`
+	FileUploadQuestion *struct{}       `json:"fileUploadQuestion"`
+	RatingQuestion     *ratingQuestion `json:"ratingQuestion"`
+}
+
+// rowQuestion is one row of a grid
+type rowQuestion struct {
+	Title string `json:"title"`
+}
+
+// textQuestion is a short or paragraph answer
+type textQuestion struct {
+	Paragraph bool `json:"paragraph"`
+}
+
+// choiceQuestion is a radio, checkbox or drop-down question
+type choiceQuestion struct {
+	Type    string   `json:"type"`
+	Options []option `json:"options"`
+}
+
+// option is a choice; IsOther marks the free-text "Other" option
+type option struct {
+	Value   string `json:"value"`
+	IsOther bool   `json:"isOther"`
+}
+
+// scaleQuestion is a linear scale
+type scaleQuestion struct {
+	Low  int `json:"low"`
+	High int `json:"high"`
+}
+
+// dateQuestion is a date, optionally with time
+type dateQuestion struct {
+	IncludeTime bool `json:"includeTime"`
+}
+
+// ratingQuestion is a star or heart rating
+type ratingQuestion struct {
+	RatingScaleLevel int `json:"ratingScaleLevel"`
+}
+
+// Converter converts Google Forms definitions
+type Converter struct{}
+
+// Convert translates a Google Forms resource. Sections become panels.
+func (Converter) Convert(data []byte) (*convert.Definition, error) {
+	var source form
+	if err := json.Unmarshal(data, &source); err != nil {
+		return nil, fmt.Errorf("decode google forms definition: %w", err)
+	}
+
+	if len(source.Items) == 0 {
+		return nil, errors.New("google forms definition has no items")
+	}
+
+	result := &convert.Definition{
+		Title:       source.Info.Title,
+		Description: source.Info.Description,
+	}
+
+	if result.Title == "" {
+		result.Title = source.Info.DocumentTitle
+	}
+
+	keys := convert.NewKeys()
+	sections := []any{}
+	current := []any{}
+	sectionTitle := ""
+	sectionKey := ""
+
+	for i := range source.Items {
+		it := &source.Items[i]
+
+		if it.PageBreakItem != nil {
+			sections = appendSection(sections, sectionKey, sectionTitle, current)
+			current = []any{}
+			sectionTitle = it.Title
+			sectionKey = keys.Next(it.Title + " section")
+
+			continue
+		}
+
+		current = append(current, convertItem(it, keys, result)...)
+	}
+
+	components := appendSection(sections, sectionKey, sectionTitle, current)
+	result.Schema = convert.Schema(components)
+
+	return result, nil
+}
+
+// appendSection adds the components of a section, wrapping all but the first in a panel
+func appendSection(sections []any, key, title string, components []any) []any {
+	if key == "" {
+		return append(sections, components...)
+	}
+
+	return append(sections, convert.Panel(key, title, components))
+}
+
+// convertItem converts one item to zero or more components
+func convertItem(it *item, keys *convert.Keys, result *convert.Definition) []any {
+	switch {
+	case it.QuestionItem != nil:
+		component := convertQuestion(&it.QuestionItem.Question, keys.Next(it.Title), it.Title, result)
+		if it.Description != "" {
+			component["description"] = it.Description
+		}
+
+		return []any{component}
+	case it.QuestionGroupItem != nil:
+		return convertGrid(it, keys, result)
+	case it.TextItem != nil:
+		content := "<strong>" + html.EscapeString(it.Title) + "</strong>"
+		if it.Description != "" {
+			content += "<br>" + html.EscapeString(it.Description)
+		}
+
+		return []any{convert.Content(keys.Next(it.Title), content)}
+	case it.ImageItem != nil, it.VideoItem != nil:
+		result.Warn("media item %q was skipped", it.Title)
+
+		return nil
+	default:
+		result.Warn("item %q has an unsupported type and was skipped", it.Title)
+
+		return nil
+	}
+}
+
+// convertQuestion maps a Google Forms question to a Form.io input component
+func convertQuestion(q *question, key, label string, result *convert.Definition) map[string]any {
+	switch {
+	case q.TextQuestion != nil && q.TextQuestion.Paragraph:
+		return convert.Input("textarea", key, label, q.Required)
+	case q.TextQuestion != nil:
+		return convert.Input("textfield", key, label, q.Required)
+	case q.ChoiceQuestion != nil:
+		return choiceInput(q.ChoiceQuestion, key, label, q.Required, result)
+	case q.ScaleQuestion != nil:
+		values := make([]string, 0, q.ScaleQuestion.High-q.ScaleQuestion.Low+1)
+		for n := q.ScaleQuestion.Low; n <= q.ScaleQuestion.High; n++ {
+			values = append(values, strconv.Itoa(n))
+		}
+
+		component := convert.Input("radio", key, label, q.Required)
+		component["values"] = convert.Options(values)
+		component["inline"] = true
+
+		return component
+	case q.DateQuestion != nil:
+		component := convert.Input("datetime", key, label, q.Required)
+		component["enableTime"] = q.DateQuestion.IncludeTime
+
+		return component
+	case q.TimeQuestion != nil:
+		return convert.Input("time", key, label, q.Required)
+	case q.FileUploadQuestion != nil:
+		return convert.Input("file", key, label, q.Required)
+	case q.RatingQuestion != nil:
+		component := convert.Input("number", key, label, q.Required)
+		component["validate"].(map[string]any)["min"] = float64(1)
+		component["validate"].(map[string]any)["max"] = float64(q.RatingQuestion.RatingScaleLevel)
+
+		return component
+	default:
+		result.Warn("question %q has an unsupported type and was imported as a text area", label)
+
+		return convert.Input("textarea", key, label, q.Required)
+	}
+}
+
+// choiceInput converts radio, checkbox and drop-down questions
+func choiceInput(
+	choice *choiceQuestion,
+	key, label string,
+	required bool,
+	result *convert.Definition,
+) map[string]any {
+	labels := make([]string, 0, len(choice.Options))
+
+	for _, opt := range choice.Options {
+		if opt.IsOther {
+			labels = append(labels, "Other")
+			result.Warn("question %q allowed a free-text \"Other\" answer; it was imported as a plain option", label)
+
+			continue
+		}
+
+		labels = append(labels, opt.Value)
+	}
+
+	switch choice.Type {
+	case "CHECKBOX":
+		component := convert.Input("selectboxes", key, label, required)
+		component["values"] = convert.Options(labels)
+
+		return component
+	case "DROP_DOWN":
+		component := convert.Input("select", key, label, required)
+		component["data"] = map[string]any{"values": convert.Options(labels)}
+
+		return component
+	default:
+		component := convert.Input("radio", key, label, required)
+		component["values"] = convert.Options(labels)
+
+		return component
+	}
+}
+
+// convertGrid converts a choice grid into one choice question per row inside a panel
+func convertGrid(it *item, keys *convert.Keys, result *convert.Definition) []any {
+	group := it.QuestionGroupItem
+	if group.Grid == nil {
+		result.Warn("question group %q has an unsupported type and was skipped", it.Title)
+
+		return nil
+	}
+
+	panelKey := keys.Next(it.Title + " grid")
+	rows := make([]any, 0, len(group.Questions))
+
+	for i := range group.Questions {
+		q := &group.Questions[i]
+
+		rowTitle := ""
+		if q.RowQuestion != nil {
+			rowTitle = q.RowQuestion.Title
+		}
+
+		rows = append(rows, choiceInput(&group.Grid.Columns, keys.Next(it.Title+" "+rowTitle), rowTitle, q.Required, result))
+	}
+
+	return []any{convert.Panel(panelKey, it.Title, rows)}
+}
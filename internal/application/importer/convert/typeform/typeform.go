@@ -0,0 +1,252 @@
+// Package typeform converts Typeform form definitions, as returned by the
+// Typeform Create API (GET /forms/{form_id}), into GoForms schemas.
+package typeform
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"html"
+
+	"github.com/goformx/goforms/internal/application/importer/convert"
+)
+
+// Source is the importer source name for Typeform
+const Source = "typeform"
+
+// defaultScaleSteps is the number of steps Typeform uses when a scale omits it
+const defaultScaleSteps = 5
+
+// definition is the subset of a Typeform form used by the converter
+type definition struct {
+	Title          string   `json:"title"`
+	Fields         []field  `json:"fields"`
+	WelcomeScreens []screen `json:"welcome_screens"`
+}
+
+// screen is a welcome or thank-you screen
+type screen struct {
+	Title      string `json:"title"`
+	Properties struct {
+		Description string `json:"description"`
+	} `json:"properties"`
+}
+
+// field is a Typeform question, statement or group
+type field struct {
+	Ref         string      `json:"ref"`
+	Title       string      `json:"title"`
+	Type        string      `json:"type"`
+	Properties  properties  `json:"properties"`
+	Validations validations `json:"validations"`
+}
+
+// properties holds type-specific field settings
+type properties struct {
+	Description            string   `json:"description"`
+	Choices                []choice `json:"choices"`
+	AllowMultipleSelection bool     `json:"allow_multiple_selection"`
+	AllowOtherChoice       bool     `json:"allow_other_choice"`
+	Steps                  int      `json:"steps"`
+	StartAtOne             bool     `json:"start_at_one"`
+	Fields                 []field  `json:"fields"`
+}
+
+// choice is an option of a choice field
+type choice struct {
+	Label string `json:"label"`
+}
+
+// validations holds a field's constraints
+type validations struct {
+	Required  bool     `json:"required"`
+	MaxLength *float64 `json:"max_length"`
+	MinValue  *float64 `json:"min_value"`
+	MaxValue  *float64 `json:"max_value"`
+}
+
+// Converter converts Typeform definitions
+type Converter struct{}
+
+// Convert translates a Typeform form definition
+func (Converter) Convert(data []byte) (*convert.Definition, error) {
+	var form definition
+	if err := json.Unmarshal(data, &form); err != nil {
+		return nil, fmt.Errorf("decode typeform definition: %w", err)
+	}
+
+	if len(form.Fields) == 0 {
+		return nil, errors.New("typeform definition has no fields")
+	}
+
+	result := &convert.Definition{Title: form.Title}
+
+	if len(form.WelcomeScreens) > 0 {
+		result.Description = form.WelcomeScreens[0].Properties.Description
+	}
+
+	keys := convert.NewKeys()
+	result.Schema = convert.Schema(convertFields(form.Fields, keys, result))
+
+	return result, nil
+}
+
+// convertFields converts a list of fields, descending into groups
+func convertFields(fields []field, keys *convert.Keys, result *convert.Definition) []any {
+	components := make([]any, 0, len(fields))
+
+	for i := range fields {
+		if component := convertField(&fields[i], keys, result); component != nil {
+			components = append(components, component)
+		}
+	}
+
+	return components
+}
+
+// convertField converts one field, returning nil for fields that are dropped
+func convertField(f *field, keys *convert.Keys, result *convert.Definition) map[string]any {
+	label := f.Title
+	key := keys.Next(label)
+
+	switch f.Type {
+	case "group":
+		return convert.Panel(key, label, convertFields(f.Properties.Fields, keys, result))
+	case "statement":
+		return convert.Content(key, html.EscapeString(label))
+	}
+
+	component := inputFor(f, key, result)
+	if component == nil {
+		return nil
+	}
+
+	if f.Properties.Description != "" {
+		component["description"] = f.Properties.Description
+	}
+
+	return component
+}
+
+// inputFor maps a Typeform question type to a Form.io input component
+func inputFor(f *field, key string, result *convert.Definition) map[string]any {
+	label := f.Title
+	required := f.Validations.Required
+
+	switch f.Type {
+	case "short_text", "long_text":
+		componentType := "textfield"
+		if f.Type == "long_text" {
+			componentType = "textarea"
+		}
+
+		component := convert.Input(componentType, key, label, required)
+		if f.Validations.MaxLength != nil {
+			setValidate(component, "maxLength", *f.Validations.MaxLength)
+		}
+
+		return component
+	case "email":
+		return convert.Input("email", key, label, required)
+	case "website":
+		return convert.Input("url", key, label, required)
+	case "phone_number":
+		return convert.Input("phoneNumber", key, label, required)
+	case "date":
+		return convert.Input("datetime", key, label, required)
+	case "file_upload":
+		return convert.Input("file", key, label, required)
+	case "legal":
+		return convert.Input("checkbox", key, label, required)
+	case "number":
+		component := convert.Input("number", key, label, required)
+		if f.Validations.MinValue != nil {
+			setValidate(component, "min", *f.Validations.MinValue)
+		}
+
+		if f.Validations.MaxValue != nil {
+			setValidate(component, "max", *f.Validations.MaxValue)
+		}
+
+		return component
+	case "yes_no":
+		component := convert.Input("radio", key, label, required)
+		component["values"] = convert.Options([]string{"Yes", "No"})
+
+		return component
+	case "multiple_choice", "picture_choice", "dropdown":
+		return choiceInput(f, key, result)
+	case "opinion_scale", "rating":
+		return scaleInput(f, key)
+	default:
+		result.Warn("question %q has unsupported type %q and was imported as a text area", label, f.Type)
+
+		return convert.Input("textarea", key, label, f.Validations.Required)
+	}
+}
+
+// choiceInput converts single and multiple choice questions
+func choiceInput(f *field, key string, result *convert.Definition) map[string]any {
+	labels := make([]string, 0, len(f.Properties.Choices))
+	for _, c := range f.Properties.Choices {
+		labels = append(labels, c.Label)
+	}
+
+	if f.Properties.AllowOtherChoice {
+		labels = append(labels, "Other")
+		result.Warn("question %q allowed a free-text \"Other\" answer; it was imported as a plain option", f.Title)
+	}
+
+	if f.Type == "picture_choice" {
+		result.Warn("question %q used picture choices; only the labels were imported", f.Title)
+	}
+
+	switch {
+	case f.Type == "dropdown":
+		component := convert.Input("select", key, f.Title, f.Validations.Required)
+		component["data"] = map[string]any{"values": convert.Options(labels)}
+
+		return component
+	case f.Properties.AllowMultipleSelection:
+		component := convert.Input("selectboxes", key, f.Title, f.Validations.Required)
+		component["values"] = convert.Options(labels)
+
+		return component
+	default:
+		component := convert.Input("radio", key, f.Title, f.Validations.Required)
+		component["values"] = convert.Options(labels)
+
+		return component
+	}
+}
+
+// scaleInput converts opinion scales and ratings to a bounded number
+func scaleInput(f *field, key string) map[string]any {
+	steps := f.Properties.Steps
+	if steps <= 0 {
+		steps = defaultScaleSteps
+	}
+
+	low := 0
+	if f.Type == "rating" || f.Properties.StartAtOne {
+		low = 1
+	}
+
+	high := low + steps - 1
+	if f.Type == "rating" {
+		high = steps
+	}
+
+	component := convert.Input("number", key, f.Title, f.Validations.Required)
+	setValidate(component, "min", float64(low))
+	setValidate(component, "max", float64(high))
+
+	return component
+}
+
+// setValidate sets a validation rule on a component built by convert.Input
+func setValidate(component map[string]any, rule string, value any) {
+	if validate, ok := component["validate"].(map[string]any); ok {
+		validate[rule] = value
+	}
+}
@@ -0,0 +1,141 @@
+package importer
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/goformx/goforms/internal/application/importer/convert"
+	"github.com/goformx/goforms/internal/application/validation"
+	"github.com/goformx/goforms/internal/domain/form/model"
+)
+
+// SourceGoForms is the source name of forms exported from GoForms itself
+const SourceGoForms = "goforms"
+
+// FormReport summarises a form import
+type FormReport struct {
+	DryRun   bool     `json:"dry_run"`
+	Source   string   `json:"source"`
+	Valid    bool     `json:"valid"`
+	Title    string   `json:"title"`
+	Fields   int      `json:"fields"`
+	Warnings []string `json:"warnings"`
+	Errors   []string `json:"errors"`
+	// FormID is set once the form has been created
+	FormID string `json:"form_id,omitempty"`
+}
+
+// ImportForm converts data from the given source and creates the form for userID.
+// Conversion and validation problems are returned in the report; the error result
+// is reserved for an unknown source, an unreadable file or a failure to store the form.
+func (i *Importer) ImportForm(
+	ctx context.Context,
+	userID, source string,
+	data []byte,
+	dryRun bool,
+) (*FormReport, *model.Form, error) {
+	if source == "" {
+		source = SourceGoForms
+	}
+
+	converter, ok := i.converters[strings.ToLower(source)]
+	if !ok {
+		return nil, nil, fmt.Errorf("%w: %q", ErrUnknownSource, source)
+	}
+
+	if len(data) > MaxFileSize {
+		return nil, nil, fmt.Errorf("%w: file is larger than %d bytes", ErrInvalidFile, MaxFileSize)
+	}
+
+	definition, err := converter.Convert(data)
+	if err != nil {
+		return nil, nil, fmt.Errorf("%w: %w", ErrInvalidFile, err)
+	}
+
+	report := &FormReport{
+		DryRun:   dryRun,
+		Source:   strings.ToLower(source),
+		Title:    i.sanitizer.String(definition.Title),
+		Warnings: definition.Warnings,
+		Errors:   []string{},
+	}
+
+	if report.Warnings == nil {
+		report.Warnings = []string{}
+	}
+
+	form := model.NewForm(userID, report.Title, i.sanitizer.String(definition.Description), definition.Schema)
+
+	if components, found := validation.NewSchemaParser().ExtractInputComponents(form.Schema); found {
+		report.Fields = len(components)
+	}
+
+	if validateErr := form.Validate(); validateErr != nil {
+		report.Errors = append(report.Errors, validateErr.Error())
+
+		return report, nil, nil
+	}
+
+	report.Valid = true
+
+	if dryRun {
+		return report, nil, nil
+	}
+
+	if createErr := i.forms.CreateForm(ctx, form); createErr != nil {
+		return report, nil, fmt.Errorf("create imported form: %w", createErr)
+	}
+
+	report.FormID = form.ID
+
+	return report, form, nil
+}
+
+// NativeConverter reads forms exported from GoForms. It accepts a bare
+// {title, description, schema} object as well as the form API response.
+type NativeConverter struct{}
+
+// nativeForm is the exported form shape
+type nativeForm struct {
+	Title       string     `json:"title"`
+	Description string     `json:"description"`
+	Schema      model.JSON `json:"schema"`
+}
+
+// Convert decodes a GoForms form export
+func (NativeConverter) Convert(data []byte) (*convert.Definition, error) {
+	var envelope struct {
+		nativeForm
+
+		Form *nativeForm `json:"form"`
+		Data *struct {
+			Form *nativeForm `json:"form"`
+		} `json:"data"`
+	}
+
+	if err := json.Unmarshal(data, &envelope); err != nil {
+		return nil, fmt.Errorf("decode form: %w", err)
+	}
+
+	exported := &envelope.nativeForm
+
+	switch {
+	case envelope.Data != nil && envelope.Data.Form != nil:
+		exported = envelope.Data.Form
+	case envelope.Form != nil:
+		exported = envelope.Form
+	}
+
+	if exported.Schema == nil {
+		return nil, errors.New("form schema is missing")
+	}
+
+	return &convert.Definition{
+		Title:       exported.Title,
+		Description: exported.Description,
+		Schema:      exported.Schema,
+	}, nil
+}
@@ -0,0 +1,83 @@
+// Package importer bulk-loads historical submissions from CSV or JSON files
+// and imports form definitions exported from GoForms or other form builders.
+// Every import can run as a dry run, which validates the input and reports
+// problems without writing anything.
+package importer
+
+import (
+	"errors"
+
+	"github.com/goformx/goforms/internal/application/importer/convert"
+	"github.com/goformx/goforms/internal/application/importer/convert/googleforms"
+	"github.com/goformx/goforms/internal/application/importer/convert/typeform"
+	formdomain "github.com/goformx/goforms/internal/domain/form"
+	"github.com/goformx/goforms/internal/infrastructure/logging"
+	"github.com/goformx/goforms/internal/infrastructure/sanitization"
+)
+
+const (
+	// MaxFileSize is the largest import file accepted
+	MaxFileSize = 10 << 20
+	// MaxRows is the largest number of submissions accepted in one import
+	MaxRows = 10000
+	// maxReportedErrors caps the row errors kept in a report
+	maxReportedErrors = 500
+)
+
+var (
+	// ErrUnsupportedFormat is returned for a submission file format other than csv or json
+	ErrUnsupportedFormat = errors.New("import format must be csv or json")
+
+	// ErrUnknownSource is returned when no converter is registered for a form source
+	ErrUnknownSource = errors.New("unknown form import source")
+
+	// ErrTooManyRows is returned when a file holds more than MaxRows submissions
+	ErrTooManyRows = errors.New("import file has more than 10000 rows")
+
+	// ErrInvalidFile is returned when an import file cannot be parsed
+	ErrInvalidFile = errors.New("import file is invalid")
+)
+
+// Importer imports submissions and forms
+type Importer struct {
+	forms      formdomain.Service
+	imports    formdomain.ImportService
+	sanitizer  sanitization.ServiceInterface
+	logger     logging.Logger
+	converters map[string]convert.Converter
+}
+
+// New creates a new importer with the GoForms, Typeform and Google Forms converters registered
+func New(
+	forms formdomain.Service,
+	imports formdomain.ImportService,
+	sanitizer sanitization.ServiceInterface,
+	logger logging.Logger,
+) *Importer {
+	imp := &Importer{
+		forms:      forms,
+		imports:    imports,
+		sanitizer:  sanitizer,
+		logger:     logger,
+		converters: make(map[string]convert.Converter),
+	}
+
+	imp.RegisterConverter(SourceGoForms, NativeConverter{})
+	imp.RegisterConverter(typeform.Source, typeform.Converter{})
+	imp.RegisterConverter(googleforms.Source, googleforms.Converter{})
+
+	return imp
+}
+
+// RegisterConverter makes a form converter available under the given source name
+func (i *Importer) RegisterConverter(source string, converter convert.Converter) {
+	i.converters[source] = converter
+}
+
+// RowError describes why one row of an import was rejected. Field is empty
+// for problems with the row as a whole.
+type RowError struct {
+	Row     int    `json:"row"`
+	Field   string `json:"field,omitempty"`
+	Message string `json:"message"`
+}
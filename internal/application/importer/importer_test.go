@@ -0,0 +1,206 @@
+package importer_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/goformx/goforms/internal/application/importer"
+	"github.com/goformx/goforms/internal/domain/form/model"
+	"github.com/goformx/goforms/internal/infrastructure/sanitization"
+	mockform "github.com/goformx/goforms/test/mocks/form"
+	mocklogging "github.com/goformx/goforms/test/mocks/logging"
+)
+
+// recordingImports stores imported submissions in memory
+type recordingImports struct {
+	submissions []*model.FormSubmission
+}
+
+func (r *recordingImports) ImportSubmission(_ context.Context, form *model.Form, submission *model.FormSubmission) error {
+	submission.FormID = form.ID
+	r.submissions = append(r.submissions, submission)
+
+	return nil
+}
+
+func testForm() *model.Form {
+	return &model.Form{
+		ID:     "form-1",
+		UserID: "user-1",
+		Schema: model.JSON{
+			"display": "form",
+			"components": []any{
+				map[string]any{"type": "textfield", "key": "name", "validate": map[string]any{"required": true}},
+				map[string]any{"type": "number", "key": "age"},
+				map[string]any{"type": "checkbox", "key": "subscribed"},
+			},
+		},
+	}
+}
+
+func newImporter(t *testing.T) (*importer.Importer, *recordingImports, *mockform.MockService) {
+	t.Helper()
+
+	ctrl := gomock.NewController(t)
+	logger := mocklogging.NewMockLogger(ctrl)
+	forms := mockform.NewMockService(ctrl)
+	imports := &recordingImports{}
+
+	return importer.New(forms, imports, sanitization.NewService(), logger), imports, forms
+}
+
+func TestImportSubmissions_CSV(t *testing.T) {
+	imp, imports, _ := newImporter(t)
+
+	file := "Full Name,age,subscribed,submitted_at,notes\n" +
+		"Ada,36,Yes,2024-03-01T10:00:00Z,first\n" +
+		",41,No,2024-03-02,missing name\n" +
+		"Alan,41,no,yesterday,bad date\n"
+
+	report, err := imp.ImportSubmissions(t.Context(), testForm(), strings.NewReader(file), importer.SubmissionOptions{
+		Format:  importer.FormatCSV,
+		Mapping: map[string]string{"Full Name": "name"},
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, 3, report.Total)
+	assert.Equal(t, 1, report.Imported)
+	assert.Equal(t, 2, report.Failed)
+	assert.Equal(t, []string{"notes"}, report.UnmappedColumns)
+	require.Len(t, report.Errors, 2)
+	assert.Equal(t, importer.RowError{Row: 3, Field: "name", Message: report.Errors[0].Message}, report.Errors[0])
+	assert.Equal(t, 4, report.Errors[1].Row)
+	assert.Equal(t, "submitted_at", report.Errors[1].Field)
+
+	require.Len(t, imports.submissions, 1)
+	imported := imports.submissions[0]
+	assert.Equal(t, model.JSON{"name": "Ada", "age": float64(36), "subscribed": true}, imported.Data)
+	assert.Equal(t, "2024-03-01T10:00:00Z", imported.SubmittedAt.Format("2006-01-02T15:04:05Z07:00"))
+	assert.Equal(t, model.SubmissionStatusCompleted, imported.Status)
+}
+
+func TestImportSubmissions_JSONDryRun(t *testing.T) {
+	imp, imports, _ := newImporter(t)
+
+	file := `[
+		{"data": {"name": "Ada", "age": 36}, "submitted_at": "2024-03-01T10:00:00Z", "status": "pending"},
+		{"name": "Grace"}
+	]`
+
+	report, err := imp.ImportSubmissions(t.Context(), testForm(), strings.NewReader(file), importer.SubmissionOptions{
+		Format: importer.FormatJSON,
+		DryRun: true,
+	})
+	require.NoError(t, err)
+
+	assert.True(t, report.DryRun)
+	assert.Equal(t, 2, report.Valid)
+	assert.Equal(t, 0, report.Imported)
+	assert.Empty(t, imports.submissions)
+}
+
+func TestImportSubmissions_RejectsUnknownMappingTarget(t *testing.T) {
+	imp, _, _ := newImporter(t)
+
+	_, err := imp.ImportSubmissions(t.Context(), testForm(), strings.NewReader("a\n1\n"), importer.SubmissionOptions{
+		Format:  importer.FormatCSV,
+		Mapping: map[string]string{"a": "missing"},
+	})
+	require.ErrorIs(t, err, importer.ErrInvalidFile)
+
+	_, err = imp.ImportSubmissions(t.Context(), testForm(), strings.NewReader(""), importer.SubmissionOptions{Format: "xlsx"})
+	require.ErrorIs(t, err, importer.ErrUnsupportedFormat)
+}
+
+func TestImportForm_Native(t *testing.T) {
+	imp, _, forms := newImporter(t)
+
+	export := `{"success": true, "data": {"form": {
+		"title": "Contact",
+		"description": "Get in touch",
+		"schema": {"display": "form", "components": [{"type": "textfield", "key": "name"}]}
+	}}}`
+
+	report, created, err := imp.ImportForm(t.Context(), "user-1", "", []byte(export), true)
+	require.NoError(t, err)
+	assert.True(t, report.Valid)
+	assert.Equal(t, "Contact", report.Title)
+	assert.Equal(t, 1, report.Fields)
+	assert.Nil(t, created)
+
+	forms.EXPECT().CreateForm(gomock.Any(), gomock.Any()).Return(nil)
+
+	report, created, err = imp.ImportForm(t.Context(), "user-1", importer.SourceGoForms, []byte(export), false)
+	require.NoError(t, err)
+	require.NotNil(t, created)
+	assert.Equal(t, "user-1", created.UserID)
+	assert.Equal(t, created.ID, report.FormID)
+
+	_, _, err = imp.ImportForm(t.Context(), "user-1", "unknown", []byte(export), true)
+	require.ErrorIs(t, err, importer.ErrUnknownSource)
+}
+
+func TestImportForm_Typeform(t *testing.T) {
+	imp, _, _ := newImporter(t)
+
+	export := `{
+		"title": "Feedback",
+		"welcome_screens": [{"title": "Hi", "properties": {"description": "Tell us more"}}],
+		"fields": [
+			{"title": "Your name", "type": "short_text", "validations": {"required": true, "max_length": 80}},
+			{"title": "Email", "type": "email"},
+			{"title": "How likely?", "type": "opinion_scale", "properties": {"steps": 11}},
+			{"title": "Topics", "type": "multiple_choice", "properties": {
+				"allow_multiple_selection": true, "allow_other_choice": true,
+				"choices": [{"label": "Pricing"}, {"label": "Support"}]
+			}},
+			{"title": "About you", "type": "group", "properties": {"fields": [
+				{"title": "Your name", "type": "short_text"}
+			]}},
+			{"title": "Signature", "type": "matrix"}
+		]
+	}`
+
+	report, created, err := imp.ImportForm(t.Context(), "user-1", "typeform", []byte(export), true)
+	require.NoError(t, err)
+	assert.Nil(t, created)
+	assert.True(t, report.Valid, report.Errors)
+	assert.Equal(t, "Feedback", report.Title)
+	assert.Equal(t, 6, report.Fields)
+	assert.Len(t, report.Warnings, 2)
+}
+
+func TestImportForm_GoogleForms(t *testing.T) {
+	imp, _, _ := newImporter(t)
+
+	export := `{
+		"info": {"title": "Event signup", "description": "Sign up for the event"},
+		"items": [
+			{"title": "Name", "questionItem": {"question": {"required": true, "textQuestion": {}}}},
+			{"title": "Session", "questionItem": {"question": {"choiceQuestion": {
+				"type": "DROP_DOWN", "options": [{"value": "Morning"}, {"value": "Afternoon"}]
+			}}}},
+			{"title": "Details", "pageBreakItem": {}},
+			{"title": "Rate us", "questionGroupItem": {
+				"questions": [{"rowQuestion": {"title": "Venue"}}, {"rowQuestion": {"title": "Food"}}],
+				"grid": {"columns": {"type": "RADIO", "options": [{"value": "Good"}, {"value": "Bad"}]}}
+			}},
+			{"title": "Logo", "imageItem": {}}
+		]
+	}`
+
+	report, _, err := imp.ImportForm(t.Context(), "user-1", "google_forms", []byte(export), true)
+	require.NoError(t, err)
+	assert.True(t, report.Valid, report.Errors)
+	assert.Equal(t, "Event signup", report.Title)
+	assert.Equal(t, 4, report.Fields)
+	assert.Len(t, report.Warnings, 1)
+
+	_, _, err = imp.ImportForm(t.Context(), "user-1", "google_forms", []byte(`{"items": []}`), true)
+	require.ErrorIs(t, err, importer.ErrInvalidFile)
+}
@@ -0,0 +1,450 @@
+package importer
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/goformx/goforms/internal/application/validation"
+	domainerrors "github.com/goformx/goforms/internal/domain/common/errors"
+	"github.com/goformx/goforms/internal/domain/form/model"
+)
+
+const (
+	// FormatCSV is a CSV file with a header row, as produced by the submission export
+	FormatCSV = "csv"
+	// FormatJSON is a JSON array of submission objects
+	FormatJSON = "json"
+
+	// columnSubmittedAt sets the submission time of an imported row
+	columnSubmittedAt = "submitted_at"
+	// columnStatus sets the status of an imported row
+	columnStatus = "status"
+	// columnSubmissionID is written by the export and ignored on import
+	columnSubmissionID = "submission_id"
+	// ignoreColumn as a mapping target skips a column
+	ignoreColumn = "-"
+)
+
+// timeLayouts are the accepted submitted_at formats
+var timeLayouts = []string{time.RFC3339, "2006-01-02 15:04:05", "2006-01-02T15:04:05", "2006-01-02"}
+
+// SubmissionOptions controls a submission import
+type SubmissionOptions struct {
+	// Format is FormatCSV or FormatJSON
+	Format string
+	// Mapping maps file columns (or JSON keys) to form field keys. Columns named
+	// like a field key are mapped automatically; map a column to "-" to skip it.
+	Mapping map[string]string
+	// DryRun validates every row without storing anything
+	DryRun bool
+}
+
+// SubmissionReport summarises a submission import
+type SubmissionReport struct {
+	DryRun          bool       `json:"dry_run"`
+	Total           int        `json:"total"`
+	Valid           int        `json:"valid"`
+	Imported        int        `json:"imported"`
+	Failed          int        `json:"failed"`
+	UnmappedColumns []string   `json:"unmapped_columns"`
+	Errors          []RowError `json:"errors"`
+	// Stopped is set when the import ended early, for example on a quota limit
+	Stopped bool `json:"stopped"`
+}
+
+// addError records a row error, keeping at most maxReportedErrors
+func (r *SubmissionReport) addError(row int, field, message string) {
+	if len(r.Errors) < maxReportedErrors {
+		r.Errors = append(r.Errors, RowError{Row: row, Field: field, Message: message})
+	}
+}
+
+// record is one parsed row of an import file
+type record struct {
+	row         int
+	data        model.JSON
+	submittedAt time.Time
+	status      model.SubmissionStatus
+	err         *RowError
+}
+
+// ImportSubmissions parses r and imports its rows as submissions of form.
+// Rows that fail validation are reported and skipped; the error result is
+// reserved for problems with the file or options as a whole.
+func (i *Importer) ImportSubmissions(
+	ctx context.Context,
+	form *model.Form,
+	r io.Reader,
+	opts SubmissionOptions,
+) (*SubmissionReport, error) {
+	fieldTypes := schemaFieldTypes(form.Schema)
+
+	for column, target := range opts.Mapping {
+		if target != ignoreColumn && !isReservedColumn(target) && fieldTypes[target] == "" {
+			return nil, fmt.Errorf("%w: column %q is mapped to unknown field %q", ErrInvalidFile, column, target)
+		}
+	}
+
+	records, unmapped, err := parseSubmissions(io.LimitReader(r, MaxFileSize+1), opts, fieldTypes)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &SubmissionReport{
+		DryRun:          opts.DryRun,
+		Total:           len(records),
+		UnmappedColumns: unmapped,
+		Errors:          []RowError{},
+	}
+
+	validator := validation.NewComprehensiveValidator()
+
+	for _, rec := range records {
+		if rec.err != nil {
+			report.Failed++
+			report.addError(rec.row, rec.err.Field, rec.err.Message)
+
+			continue
+		}
+
+		submission := &model.FormSubmission{
+			FormID:      form.ID,
+			Data:        rec.data,
+			SubmittedAt: rec.submittedAt,
+			Status:      rec.status,
+		}
+		submission.Sanitize(i.sanitizer)
+
+		result := validator.ValidateForm(form.Schema, submission.Data)
+		if !result.IsValid {
+			report.Failed++
+
+			for _, fieldErr := range result.Errors {
+				report.addError(rec.row, fieldErr.Field, fieldErr.Message)
+			}
+
+			continue
+		}
+
+		report.Valid++
+
+		if opts.DryRun {
+			continue
+		}
+
+		if importErr := i.imports.ImportSubmission(ctx, form, submission); importErr != nil {
+			report.Failed++
+
+			if domainerrors.IsQuotaError(importErr) {
+				report.addError(rec.row, "", "submission quota exceeded; import stopped")
+				report.Stopped = true
+
+				break
+			}
+
+			i.logger.Error("failed to import submission", "error", importErr, "form_id", form.ID, "row", rec.row)
+			report.addError(rec.row, "", "failed to store submission")
+
+			continue
+		}
+
+		report.Imported++
+	}
+
+	return report, nil
+}
+
+// parseSubmissions reads all records from an import file
+func parseSubmissions(
+	r io.Reader,
+	opts SubmissionOptions,
+	fieldTypes map[string]string,
+) ([]record, []string, error) {
+	raw, err := io.ReadAll(r)
+	if err != nil {
+		return nil, nil, fmt.Errorf("read import file: %w", err)
+	}
+
+	if len(raw) > MaxFileSize {
+		return nil, nil, fmt.Errorf("%w: file is larger than %d bytes", ErrInvalidFile, MaxFileSize)
+	}
+
+	switch strings.ToLower(opts.Format) {
+	case FormatCSV:
+		return parseCSV(raw, opts.Mapping, fieldTypes)
+	case FormatJSON:
+		return parseJSON(raw, opts.Mapping, fieldTypes)
+	default:
+		return nil, nil, ErrUnsupportedFormat
+	}
+}
+
+// parseCSV reads a CSV file whose first row names the columns
+func parseCSV(raw []byte, mapping map[string]string, fieldTypes map[string]string) ([]record, []string, error) {
+	reader := csv.NewReader(bytes.NewReader(bytes.TrimPrefix(raw, []byte("\ufeff"))))
+	reader.FieldsPerRecord = -1
+
+	header, err := reader.Read()
+	if errors.Is(err, io.EOF) {
+		return nil, nil, fmt.Errorf("%w: missing header row", ErrInvalidFile)
+	}
+
+	if err != nil {
+		return nil, nil, fmt.Errorf("%w: %w", ErrInvalidFile, err)
+	}
+
+	targets, unmapped := resolveColumns(header, mapping, fieldTypes)
+
+	var records []record
+
+	for row := 2; ; row++ {
+		values, readErr := reader.Read()
+		if errors.Is(readErr, io.EOF) {
+			break
+		}
+
+		if readErr != nil {
+			return nil, nil, fmt.Errorf("%w: row %d: %w", ErrInvalidFile, row, readErr)
+		}
+
+		if len(records) == MaxRows {
+			return nil, nil, ErrTooManyRows
+		}
+
+		fields := make(map[string]any, len(values))
+
+		for col, value := range values {
+			if col < len(targets) && targets[col] != "" && value != "" {
+				fields[targets[col]] = value
+			}
+		}
+
+		records = append(records, newRecord(row, fields, fieldTypes))
+	}
+
+	return records, unmapped, nil
+}
+
+// parseJSON reads a JSON array of submissions. Each element is either the
+// submission data itself or an object with "data", "submitted_at" and "status".
+func parseJSON(raw []byte, mapping map[string]string, fieldTypes map[string]string) ([]record, []string, error) {
+	var items []map[string]any
+	if err := json.Unmarshal(raw, &items); err != nil {
+		return nil, nil, fmt.Errorf("%w: expected a JSON array of objects: %w", ErrInvalidFile, err)
+	}
+
+	if len(items) > MaxRows {
+		return nil, nil, ErrTooManyRows
+	}
+
+	var records []record
+
+	unmapped := []string{}
+
+	for idx, item := range items {
+		fields := item
+
+		if data, ok := item["data"].(map[string]any); ok {
+			fields = data
+
+			for _, key := range []string{columnSubmittedAt, columnStatus} {
+				if value, exists := item[key]; exists {
+					fields[key] = value
+				}
+			}
+		}
+
+		keys := make([]string, 0, len(fields))
+		for key := range fields {
+			keys = append(keys, key)
+		}
+
+		slices.Sort(keys)
+
+		targets, missing := resolveColumns(keys, mapping, fieldTypes)
+		for _, key := range missing {
+			if !slices.Contains(unmapped, key) {
+				unmapped = append(unmapped, key)
+			}
+		}
+
+		mapped := make(map[string]any, len(fields))
+
+		for k, key := range keys {
+			if targets[k] != "" && fields[key] != nil {
+				mapped[targets[k]] = fields[key]
+			}
+		}
+
+		records = append(records, newRecord(idx+1, mapped, fieldTypes))
+	}
+
+	return records, unmapped, nil
+}
+
+// resolveColumns maps each column to a field key or reserved column, or ""
+// to skip it, and returns the columns that matched nothing
+func resolveColumns(columns []string, mapping map[string]string, fieldTypes map[string]string) ([]string, []string) {
+	targets := make([]string, len(columns))
+	unmapped := []string{}
+
+	for idx, column := range columns {
+		name := strings.TrimSpace(column)
+
+		target, mapped := mapping[name]
+
+		switch {
+		case mapped && target == ignoreColumn:
+			continue
+		case mapped:
+			targets[idx] = target
+		case name == columnSubmissionID:
+			continue
+		case isReservedColumn(name) || fieldTypes[name] != "":
+			targets[idx] = name
+		default:
+			unmapped = append(unmapped, name)
+		}
+	}
+
+	return targets, unmapped
+}
+
+// newRecord splits reserved columns from the submission data and converts
+// values to the types their fields expect
+func newRecord(row int, fields map[string]any, fieldTypes map[string]string) record {
+	rec := record{
+		row:         row,
+		data:        model.JSON{},
+		submittedAt: time.Now().UTC(),
+		status:      model.SubmissionStatusCompleted,
+	}
+
+	for key, value := range fields {
+		switch key {
+		case columnSubmittedAt:
+			submittedAt, ok := parseTime(value)
+			if !ok {
+				rec.err = &RowError{Row: row, Field: key, Message: "submitted_at must be an RFC 3339 timestamp or YYYY-MM-DD date"}
+
+				return rec
+			}
+
+			rec.submittedAt = submittedAt
+		case columnStatus:
+			status, ok := parseStatus(value)
+			if !ok {
+				rec.err = &RowError{Row: row, Field: key, Message: "status must be pending, processing, completed or failed"}
+
+				return rec
+			}
+
+			rec.status = status
+		default:
+			rec.data[key] = coerceValue(value, fieldTypes[key])
+		}
+	}
+
+	return rec
+}
+
+// isReservedColumn reports whether a column sets submission attributes rather than data
+func isReservedColumn(name string) bool {
+	return name == columnSubmittedAt || name == columnStatus
+}
+
+// parseTime parses a submitted_at value
+func parseTime(value any) (time.Time, bool) {
+	text, ok := value.(string)
+	if !ok {
+		return time.Time{}, false
+	}
+
+	for _, layout := range timeLayouts {
+		if parsed, err := time.Parse(layout, strings.TrimSpace(text)); err == nil {
+			return parsed.UTC(), true
+		}
+	}
+
+	return time.Time{}, false
+}
+
+// parseStatus parses a status value
+func parseStatus(value any) (model.SubmissionStatus, bool) {
+	text, _ := value.(string)
+
+	switch status := model.SubmissionStatus(strings.ToLower(strings.TrimSpace(text))); status {
+	case model.SubmissionStatusPending, model.SubmissionStatusProcessing,
+		model.SubmissionStatusCompleted, model.SubmissionStatusFailed:
+		return status, true
+	default:
+		return "", false
+	}
+}
+
+// coerceValue converts CSV text to the value type of a field. Values that do
+// not convert are kept as text so validation can report them.
+func coerceValue(value any, fieldType string) any {
+	text, ok := value.(string)
+	if !ok {
+		return value
+	}
+
+	// Undo the formula guard added by the CSV export
+	if len(text) > 1 && text[0] == '\'' && strings.ContainsRune("=+-@\t\r", rune(text[1])) {
+		text = text[1:]
+	}
+
+	switch fieldType {
+	case "number", "currency":
+		if number, err := strconv.ParseFloat(strings.TrimSpace(text), 64); err == nil {
+			return number
+		}
+	case "checkbox":
+		switch strings.ToLower(strings.TrimSpace(text)) {
+		case "true", "yes", "1":
+			return true
+		case "false", "no", "0":
+			return false
+		}
+	case "selectboxes", "datagrid", "editgrid", "file":
+		var decoded any
+		if err := json.Unmarshal([]byte(text), &decoded); err == nil {
+			return decoded
+		}
+	}
+
+	return text
+}
+
+// schemaFieldTypes maps each input component key in the schema to its type
+func schemaFieldTypes(schema model.JSON) map[string]string {
+	parser := validation.NewSchemaParser()
+	types := make(map[string]string)
+
+	components, _ := parser.ExtractInputComponents(schema)
+	for _, component := range components {
+		key, ok := parser.ExtractComponentKey(component)
+		if !ok || key == "" {
+			continue
+		}
+
+		componentType, _ := component["type"].(string)
+		if componentType == "" {
+			componentType = "textfield"
+		}
+
+		types[key] = componentType
+	}
+
+	return types
+}
@@ -3,7 +3,10 @@ package access
 
 import (
 	"fmt"
+	"maps"
 	"strings"
+	"sync"
+	"time"
 
 	"github.com/goformx/goforms/internal/application/constants"
 	"github.com/goformx/goforms/internal/domain/common/errors"
@@ -19,8 +22,27 @@ const (
 	Authenticated
 	// Admin means user must be an admin
 	Admin
+	// RouteAuth means the route group authenticates requests itself, with a
+	// signed user assertion or an API key, instead of a session
+	RouteAuth
 )
 
+// String returns the lower-case name of the access level
+func (l Level) String() string {
+	switch l {
+	case Public:
+		return "public"
+	case Authenticated:
+		return "authenticated"
+	case Admin:
+		return "admin"
+	case RouteAuth:
+		return "route"
+	}
+
+	return fmt.Sprintf("level(%d)", int(l))
+}
+
 // Rule defines a rule for route access
 type Rule struct {
 	Path        string
@@ -36,6 +58,8 @@ type Config struct {
 	PublicPaths []string
 	// AdminPaths are paths that require admin access
 	AdminPaths []string
+	// RouteAuthPaths are paths whose route groups authenticate requests
+	RouteAuthPaths []string
 }
 
 // DefaultConfig returns the default configuration
@@ -64,18 +88,77 @@ func DefaultConfig() *Config {
 	}
 }
 
-// Manager manages access control rules
+// Manager manages access control rules and temporary IP blocks
 type Manager struct {
 	config *Config
 	rules  []Rule
+
+	mu      sync.RWMutex
+	blocked map[string]time.Time
 }
 
 // NewManager creates a new access manager
 func NewManager(config *Config, rules []Rule) *Manager {
 	return &Manager{
-		config: config,
-		rules:  rules,
+		config:  config,
+		rules:   rules,
+		blocked: make(map[string]time.Time),
+	}
+}
+
+// BlockIP denies every request from ip until the given time
+func (am *Manager) BlockIP(ip string, until time.Time) {
+	am.mu.Lock()
+	defer am.mu.Unlock()
+
+	if current, ok := am.blocked[ip]; !ok || until.After(current) {
+		am.blocked[ip] = until
+	}
+}
+
+// UnblockIP lifts a block and reports whether ip was blocked
+func (am *Manager) UnblockIP(ip string) bool {
+	am.mu.Lock()
+	defer am.mu.Unlock()
+
+	until, ok := am.blocked[ip]
+	delete(am.blocked, ip)
+
+	return ok && time.Now().Before(until)
+}
+
+// BlockedUntil returns when the block on ip ends, or false when it is not blocked
+func (am *Manager) BlockedUntil(ip string) (time.Time, bool) {
+	am.mu.RLock()
+	until, ok := am.blocked[ip]
+	am.mu.RUnlock()
+
+	if !ok {
+		return time.Time{}, false
+	}
+
+	if time.Now().Before(until) {
+		return until, true
+	}
+
+	am.mu.Lock()
+	if current, exists := am.blocked[ip]; exists && !time.Now().Before(current) {
+		delete(am.blocked, ip)
 	}
+	am.mu.Unlock()
+
+	return time.Time{}, false
+}
+
+// BlockedIPs returns the active blocks by IP, dropping expired ones
+func (am *Manager) BlockedIPs() map[string]time.Time {
+	am.mu.Lock()
+	defer am.mu.Unlock()
+
+	now := time.Now()
+	maps.DeleteFunc(am.blocked, func(_ string, until time.Time) bool { return !now.Before(until) })
+
+	return maps.Clone(am.blocked)
 }
 
 // AddRule adds a new access rule
@@ -94,6 +177,17 @@ func (am *Manager) IsPublicPath(path string) bool {
 	return false
 }
 
+// IsRouteAuthPath checks if a path is authenticated by its route group
+func (am *Manager) IsRouteAuthPath(path string) bool {
+	for _, p := range am.config.RouteAuthPaths {
+		if path == p || strings.HasPrefix(path, p+"/") {
+			return true
+		}
+	}
+
+	return false
+}
+
 // IsAdminPath checks if a path requires admin access
 func (am *Manager) IsAdminPath(path string) bool {
 	for _, p := range am.config.AdminPaths {
@@ -141,6 +235,11 @@ func (am *Manager) GetRequiredAccess(path, method string) Level {
 		return Public
 	}
 
+	// Route groups with their own authentication are not session checked
+	if am.IsRouteAuthPath(path) {
+		return RouteAuth
+	}
+
 	// Check if path requires admin access
 	if am.IsAdminPath(path) {
 		return Admin
@@ -1,8 +1,12 @@
 package access_test
 
 import (
+	"net/http"
+	"net/http/httptest"
 	"testing"
+	"time"
 
+	"github.com/labstack/echo/v4"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
@@ -160,6 +164,25 @@ func TestManager_GetRequiredAccess(t *testing.T) {
 	}
 }
 
+func TestManager_RouteAuthPaths(t *testing.T) {
+	config := access.DefaultConfig()
+	config.RouteAuthPaths = []string{constants.PathAPIDatasets}
+	manager := access.NewManager(config, []access.Rule{{Path: constants.PathAPIv1, AccessLevel: access.Authenticated}})
+
+	// The route group authenticates, so neither a session nor the /api/v1 rule applies
+	assert.Equal(t, access.RouteAuth, manager.GetRequiredAccess(constants.PathAPIDatasets, http.MethodGet))
+	assert.Equal(t, access.RouteAuth, manager.GetRequiredAccess(constants.PathAPIDatasets+"/cities", http.MethodPut))
+	assert.False(t, manager.IsPublicPath(constants.PathAPIDatasets))
+
+	e := echo.New()
+	e.Use(access.Middleware(manager, nil))
+	e.GET(constants.PathAPIDatasets, func(c echo.Context) error { return c.NoContent(http.StatusNoContent) })
+
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, constants.PathAPIDatasets, http.NoBody))
+	assert.Equal(t, http.StatusNoContent, rec.Code)
+}
+
 func TestManager_AddRule(t *testing.T) {
 	config := access.DefaultConfig()
 	manager := access.NewManager(config, nil)
@@ -336,3 +359,38 @@ func TestDefaultRules(t *testing.T) {
 		assert.True(t, found, "Path %s should be in default rules", path)
 	}
 }
+
+func TestManager_BlockIP(t *testing.T) {
+	manager := access.NewManager(access.DefaultConfig(), nil)
+
+	manager.BlockIP("203.0.113.7", time.Now().Add(time.Minute))
+	manager.BlockIP("198.51.100.2", time.Now().Add(-time.Second))
+
+	_, blocked := manager.BlockedUntil("203.0.113.7")
+	assert.True(t, blocked)
+
+	_, blocked = manager.BlockedUntil("198.51.100.2")
+	assert.False(t, blocked, "expired blocks no longer apply")
+	assert.Len(t, manager.BlockedIPs(), 1)
+
+	e := echo.New()
+	e.Use(access.Middleware(manager, nil))
+	e.GET(constants.PathLogin, func(c echo.Context) error {
+		return c.NoContent(http.StatusOK)
+	})
+
+	req := httptest.NewRequest(http.MethodGet, constants.PathLogin, http.NoBody)
+	req.RemoteAddr = "203.0.113.7:4000"
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusForbidden, rec.Code)
+	assert.NotEmpty(t, rec.Header().Get(echo.HeaderRetryAfter))
+
+	assert.True(t, manager.UnblockIP("203.0.113.7"))
+	assert.False(t, manager.UnblockIP("203.0.113.7"))
+
+	rec = httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+	assert.Equal(t, http.StatusOK, rec.Code)
+}
@@ -3,6 +3,8 @@ package access
 
 import (
 	"net/http"
+	"strconv"
+	"time"
 
 	"github.com/labstack/echo/v4"
 
@@ -16,6 +18,14 @@ import (
 func Middleware(manager *Manager, _ logging.Logger) echo.MiddlewareFunc {
 	return func(next echo.HandlerFunc) echo.HandlerFunc {
 		return func(c echo.Context) error {
+			// Clients blocked by security event detection are refused everywhere
+			if until, blocked := manager.BlockedUntil(c.RealIP()); blocked {
+				retryAfter := int(time.Until(until).Seconds()) + 1
+				c.Response().Header().Set(echo.HeaderRetryAfter, strconv.Itoa(retryAfter))
+
+				return response.ErrorResponse(c, http.StatusForbidden, "Access temporarily blocked")
+			}
+
 			path := c.Request().URL.Path
 			method := c.Request().Method
 
@@ -28,6 +38,10 @@ func Middleware(manager *Manager, _ logging.Logger) echo.MiddlewareFunc {
 				// No authentication required
 				return next(c)
 
+			case RouteAuth:
+				// The route group's middleware authenticates the request
+				return next(c)
+
 			case Authenticated:
 				// Check if user is authenticated
 				if !context.IsAuthenticated(c) {
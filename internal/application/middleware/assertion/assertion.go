@@ -20,6 +20,9 @@ const (
 	headerUserID    = "X-User-Id"
 	headerTimestamp = "X-Timestamp"
 	headerSignature = "X-Signature"
+	// headerUserEmail optionally carries the user's verified email; when present it is
+	// part of the signed payload (user_id:timestamp:email)
+	headerUserEmail = "X-User-Email"
 
 	// FailureReasonContextKey is the Echo context key set when assertion verification fails (value: reason string).
 	// The request logging middleware can include it in the "request completed with client error" log.
@@ -44,7 +47,7 @@ func (m *Middleware) Verify() echo.MiddlewareFunc {
 
 	return func(next echo.HandlerFunc) echo.HandlerFunc {
 		return func(c echo.Context) error {
-			userID, failReason := verifyAssertionHeaders(c.Request().Header, cfg)
+			userID, _, failReason := verifyAssertionHeaders(c.Request().Header, cfg)
 			if failReason != "" {
 				m.logFailure(c, failReason)
 
@@ -58,43 +61,68 @@ func (m *Middleware) Verify() echo.MiddlewareFunc {
 	}
 }
 
-// verifyAssertionHeaders checks headers and config; returns (userID, "") on success or ("", reason) on failure.
-func verifyAssertionHeaders(headers http.Header, cfg appconfig.AssertionConfig) (userID, failureReason string) {
+// VerifiedUserID returns the user ID from valid assertion headers, or false when the
+// headers are missing or fail verification. It does not log or write a response.
+func VerifiedUserID(headers http.Header, cfg appconfig.AssertionConfig) (string, bool) {
+	userID, _, failReason := verifyAssertionHeaders(headers, cfg)
+
+	return userID, failReason == ""
+}
+
+// VerifiedUserEmail returns the user ID and the signed X-User-Email from valid assertion
+// headers. email is empty when the assertion did not include one.
+func VerifiedUserEmail(headers http.Header, cfg appconfig.AssertionConfig) (userID, email string, ok bool) {
+	userID, email, failReason := verifyAssertionHeaders(headers, cfg)
+
+	return userID, email, failReason == ""
+}
+
+// verifyAssertionHeaders checks headers and config; returns (userID, email, "") on success
+// or ("", "", reason) on failure.
+func verifyAssertionHeaders(
+	headers http.Header,
+	cfg appconfig.AssertionConfig,
+) (userID, email, failureReason string) {
 	userID = strings.TrimSpace(headers.Get(headerUserID))
+	email = strings.TrimSpace(headers.Get(headerUserEmail))
 	timestamp := strings.TrimSpace(headers.Get(headerTimestamp))
 	signature := strings.TrimSpace(headers.Get(headerSignature))
 
 	if userID == "" || timestamp == "" || signature == "" {
-		return "", "missing_headers"
+		return "", "", "missing_headers"
 	}
 
 	if cfg.Secret == "" {
-		return "", "empty_secret"
+		return "", "", "empty_secret"
 	}
 
 	ts, err := parseTimestamp(timestamp)
 	if err != nil {
-		return "", "timestamp_parse_error"
+		return "", "", "timestamp_parse_error"
 	}
 
 	skew := time.Duration(cfg.TimestampSkewSeconds) * time.Second
 	if time.Since(ts) > skew {
-		return "", "timestamp_too_old"
+		return "", "", "timestamp_too_old"
 	}
 
 	payload := userID + ":" + timestamp
+	if email != "" {
+		payload += ":" + email
+	}
+
 	expected := computeHMAC(cfg.Secret, payload)
 
 	sigBytes, err := hex.DecodeString(signature)
 	if err != nil {
-		return "", "signature_not_hex"
+		return "", "", "signature_not_hex"
 	}
 
 	if !hmacEqual(sigBytes, expected) {
-		return "", "signature_mismatch"
+		return "", "", "signature_mismatch"
 	}
 
-	return userID, ""
+	return userID, email, ""
 }
 
 func (m *Middleware) logFailure(c echo.Context, reason string) {
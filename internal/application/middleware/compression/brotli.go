@@ -0,0 +1,38 @@
+//go:build brotli
+
+package compression
+
+import (
+	"io"
+
+	"github.com/andybalholm/brotli"
+)
+
+func init() {
+	Register(brotliEncoder{})
+}
+
+// brotliEncoder compresses with Brotli. It is only built with the brotli tag
+// so default builds do not carry the encoder.
+type brotliEncoder struct{}
+
+// Encoding returns the Brotli content coding
+func (brotliEncoder) Encoding() string {
+	return "br"
+}
+
+// NewWriter returns a Brotli writer; level 0 selects the default level
+func (brotliEncoder) NewWriter(w io.Writer, level int) (io.WriteCloser, error) {
+	if level == 0 {
+		level = brotli.DefaultCompression
+	}
+
+	return brotli.NewWriterLevel(w, level), nil
+}
+
+// Reset reuses a Brotli writer for w
+func (brotliEncoder) Reset(zw io.WriteCloser, w io.Writer) {
+	if br, ok := zw.(*brotli.Writer); ok {
+		br.Reset(w)
+	}
+}
@@ -0,0 +1,441 @@
+// Package compression negotiates response compression from the Accept-Encoding
+// request header. Encoders are registered by content coding; gzip is always
+// available and Brotli is added when built with the brotli tag.
+package compression
+
+import (
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/labstack/echo/v4"
+
+	"github.com/goformx/goforms/internal/infrastructure/config"
+)
+
+// Encoder creates compressing writers for one content coding
+type Encoder interface {
+	// Encoding is the Content-Encoding token, such as "gzip" or "br"
+	Encoding() string
+	// NewWriter returns a writer compressing into w at level
+	NewWriter(w io.Writer, level int) (io.WriteCloser, error)
+	// Reset points a writer returned by NewWriter at w for reuse
+	Reset(zw io.WriteCloser, w io.Writer)
+}
+
+var (
+	encodersMu sync.RWMutex
+	encoders   = map[string]Encoder{}
+)
+
+// Register makes an encoder available to the middleware
+func Register(encoder Encoder) {
+	encodersMu.Lock()
+	defer encodersMu.Unlock()
+
+	encoders[encoder.Encoding()] = encoder
+}
+
+// lookup returns the registered encoder for a content coding
+func lookup(encoding string) (Encoder, bool) {
+	encodersMu.RLock()
+	defer encodersMu.RUnlock()
+
+	encoder, ok := encoders[encoding]
+
+	return encoder, ok
+}
+
+// Config configures the compression middleware
+type Config struct {
+	// Encodings lists the offered content codings, most preferred first.
+	// Codings without a registered encoder are ignored.
+	Encodings []string
+	// Levels holds the compression level per content coding
+	Levels map[string]int
+	// MinSize is the smallest body compressed; shorter responses are sent as is
+	MinSize int
+	// ContentTypes lists compressible media types; "text/*" matches a prefix
+	ContentTypes []string
+	// Skipper skips compression for matching requests
+	Skipper func(c echo.Context) bool
+}
+
+// FromConfig builds a middleware configuration from the web configuration
+func FromConfig(cfg config.CompressionConfig) Config {
+	return Config{
+		Encodings: cfg.Encodings,
+		Levels: map[string]int{
+			"gzip": cfg.GzipLevel,
+			"br":   cfg.BrotliLevel,
+		},
+		MinSize:      cfg.MinSize,
+		ContentTypes: cfg.ContentTypes,
+	}
+}
+
+// offer is an encoder with its configured level
+type offer struct {
+	encoder Encoder
+	level   int
+	pool    *sync.Pool
+}
+
+// Middleware returns Echo middleware compressing responses with the best
+// encoding both sides support. Responses that already carry a Content-Encoding,
+// such as precompressed static assets, are passed through untouched.
+func Middleware(cfg Config) echo.MiddlewareFunc {
+	var offers []offer
+
+	for _, encoding := range cfg.Encodings {
+		encoding = strings.ToLower(strings.TrimSpace(encoding))
+
+		encoder, ok := lookup(encoding)
+		if !ok || slices.ContainsFunc(offers, func(o offer) bool { return o.encoder.Encoding() == encoding }) {
+			continue
+		}
+
+		offers = append(offers, offer{encoder: encoder, level: cfg.Levels[encoding], pool: &sync.Pool{}})
+	}
+
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			if len(offers) == 0 || (cfg.Skipper != nil && cfg.Skipper(c)) {
+				return next(c)
+			}
+
+			req := c.Request()
+			res := c.Response()
+			res.Header().Add(echo.HeaderVary, echo.HeaderAcceptEncoding)
+
+			// Range responses are byte offsets into the identity body
+			if req.Method == http.MethodHead || req.Header.Get("Range") != "" {
+				return next(c)
+			}
+
+			chosen, ok := negotiate(req.Header.Get(echo.HeaderAcceptEncoding), offers)
+			if !ok {
+				return next(c)
+			}
+
+			cw := &compressWriter{
+				ResponseWriter: res.Writer,
+				offer:          chosen,
+				minSize:        cfg.MinSize,
+				contentTypes:   cfg.ContentTypes,
+			}
+			res.Writer = cw
+
+			defer func() {
+				cw.close()
+				res.Writer = cw.ResponseWriter
+			}()
+
+			return next(c)
+		}
+	}
+}
+
+// negotiate picks the offer with the highest client q-value; ties go to the
+// server's preference order
+func negotiate(acceptEncoding string, offers []offer) (offer, bool) {
+	if acceptEncoding == "" {
+		return offer{}, false
+	}
+
+	weights := parseAcceptEncoding(acceptEncoding)
+
+	best := -1
+	bestQ := 0.0
+
+	for i, o := range offers {
+		q, ok := weights[o.encoder.Encoding()]
+		if !ok {
+			q, ok = weights["*"]
+		}
+
+		if ok && q > bestQ {
+			best, bestQ = i, q
+		}
+	}
+
+	if best < 0 {
+		return offer{}, false
+	}
+
+	return offers[best], true
+}
+
+// parseAcceptEncoding maps each listed coding to its q-value
+func parseAcceptEncoding(header string) map[string]float64 {
+	weights := make(map[string]float64)
+
+	for part := range strings.SplitSeq(header, ",") {
+		coding, params, _ := strings.Cut(part, ";")
+
+		coding = strings.ToLower(strings.TrimSpace(coding))
+		if coding == "" {
+			continue
+		}
+
+		q := 1.0
+
+		for param := range strings.SplitSeq(params, ";") {
+			name, value, found := strings.Cut(strings.TrimSpace(param), "=")
+			if !found || !strings.EqualFold(name, "q") {
+				continue
+			}
+
+			parsed, err := strconv.ParseFloat(strings.TrimSpace(value), 64)
+			if err != nil || parsed < 0 || parsed > 1 {
+				parsed = 0
+			}
+
+			q = parsed
+		}
+
+		weights[coding] = q
+	}
+
+	return weights
+}
+
+// compressWriter holds back the start of the body until it knows whether the
+// response is worth compressing: the content type must be compressible, no
+// Content-Encoding may be set, and the body must reach the minimum size.
+type compressWriter struct {
+	http.ResponseWriter
+
+	offer        offer
+	minSize      int
+	contentTypes []string
+
+	status      int
+	buf         []byte
+	zw          io.WriteCloser
+	decided     bool
+	passthrough bool
+}
+
+// WriteHeader defers the status until the compression decision is made
+func (w *compressWriter) WriteHeader(code int) {
+	if w.decided {
+		w.ResponseWriter.WriteHeader(code)
+
+		return
+	}
+
+	if w.status == 0 {
+		w.status = code
+	}
+}
+
+// Write buffers until the minimum size is reached, then compresses or passes through
+func (w *compressWriter) Write(p []byte) (int, error) {
+	if !w.decided {
+		if !w.eligible(p) {
+			w.decide(false)
+		} else {
+			w.buf = append(w.buf, p...)
+			if len(w.buf) < w.minSize {
+				return len(p), nil
+			}
+
+			if err := w.start(); err != nil {
+				return 0, err
+			}
+
+			return len(p), nil
+		}
+	}
+
+	if w.passthrough {
+		n, err := w.ResponseWriter.Write(p)
+		if err != nil {
+			return n, fmt.Errorf("write response: %w", err)
+		}
+
+		return n, nil
+	}
+
+	n, err := w.zw.Write(p)
+	if err != nil {
+		return n, fmt.Errorf("compress response: %w", err)
+	}
+
+	return n, nil
+}
+
+// Flush sends what has been written so far; a pending body is compressed if eligible
+func (w *compressWriter) Flush() {
+	if !w.decided {
+		if len(w.buf) > 0 {
+			_ = w.start()
+		} else {
+			w.decide(false)
+		}
+	}
+
+	if flusher, ok := w.zw.(interface{ Flush() error }); ok && !w.passthrough {
+		_ = flusher.Flush()
+	}
+
+	if flusher, ok := w.ResponseWriter.(http.Flusher); ok {
+		flusher.Flush()
+	}
+}
+
+// Unwrap exposes the underlying writer to http.ResponseController
+func (w *compressWriter) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
+
+// eligible reports whether a response starting with p may be compressed
+func (w *compressWriter) eligible(p []byte) bool {
+	header := w.Header()
+
+	if header.Get(echo.HeaderContentEncoding) != "" {
+		return false
+	}
+
+	switch w.status {
+	case http.StatusNoContent, http.StatusNotModified, http.StatusPartialContent:
+		return false
+	}
+
+	contentType := header.Get(echo.HeaderContentType)
+	if contentType == "" {
+		contentType = http.DetectContentType(p)
+		header.Set(echo.HeaderContentType, contentType)
+	}
+
+	return compressible(contentType, w.contentTypes)
+}
+
+// start begins compressing, writing the headers and any buffered body
+func (w *compressWriter) start() error {
+	zw, err := w.writer()
+	if err != nil {
+		// Fall back to an uncompressed response rather than failing the request
+		w.decide(false)
+
+		return w.flushBuffer()
+	}
+
+	w.zw = zw
+	w.decide(true)
+
+	if _, err := w.zw.Write(w.buf); err != nil {
+		return fmt.Errorf("compress response: %w", err)
+	}
+
+	w.buf = nil
+
+	return nil
+}
+
+// writer takes a pooled compressing writer or creates one
+func (w *compressWriter) writer() (io.WriteCloser, error) {
+	if pooled, ok := w.offer.pool.Get().(io.WriteCloser); ok {
+		w.offer.encoder.Reset(pooled, w.ResponseWriter)
+
+		return pooled, nil
+	}
+
+	zw, err := w.offer.encoder.NewWriter(w.ResponseWriter, w.offer.level)
+	if err != nil {
+		return nil, fmt.Errorf("create %s writer: %w", w.offer.encoder.Encoding(), err)
+	}
+
+	return zw, nil
+}
+
+// decide fixes whether the body is compressed and writes the response headers
+func (w *compressWriter) decide(compress bool) {
+	w.decided = true
+	w.passthrough = !compress
+
+	if compress {
+		header := w.Header()
+		header.Set(echo.HeaderContentEncoding, w.offer.encoder.Encoding())
+		header.Del(echo.HeaderContentLength)
+		header.Del("Accept-Ranges")
+
+		if etag := header.Get("ETag"); etag != "" && !strings.HasPrefix(etag, "W/") {
+			header.Set("ETag", "W/"+etag)
+		}
+	}
+
+	if w.status != 0 {
+		w.ResponseWriter.WriteHeader(w.status)
+	}
+}
+
+// flushBuffer writes a held-back body uncompressed
+func (w *compressWriter) flushBuffer() error {
+	if len(w.buf) == 0 {
+		return nil
+	}
+
+	_, err := w.ResponseWriter.Write(w.buf)
+	w.buf = nil
+
+	if err != nil {
+		return fmt.Errorf("write response: %w", err)
+	}
+
+	return nil
+}
+
+// close finishes the response: short bodies are sent as is and the compressing
+// writer is returned to the pool
+func (w *compressWriter) close() {
+	if !w.decided {
+		if w.status == 0 && len(w.buf) == 0 {
+			// Nothing was written; leave the response to the error handler
+			return
+		}
+
+		w.decide(false)
+		_ = w.flushBuffer()
+
+		return
+	}
+
+	if w.zw != nil {
+		_ = w.zw.Close()
+		w.offer.pool.Put(w.zw)
+		w.zw = nil
+	}
+}
+
+// compressible reports whether contentType matches one of the allowed types
+func compressible(contentType string, allowed []string) bool {
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return false
+	}
+
+	for _, pattern := range allowed {
+		pattern = strings.ToLower(strings.TrimSpace(pattern))
+
+		if prefix, ok := strings.CutSuffix(pattern, "*"); ok {
+			if strings.HasPrefix(mediaType, prefix) {
+				return true
+			}
+
+			continue
+		}
+
+		if mediaType == pattern {
+			return true
+		}
+	}
+
+	return false
+}
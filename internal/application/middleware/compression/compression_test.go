@@ -0,0 +1,157 @@
+package compression_test
+
+import (
+	"compress/gzip"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/goformx/goforms/internal/application/middleware/compression"
+	"github.com/goformx/goforms/internal/infrastructure/config"
+)
+
+var largeJSON = `{"data":"` + strings.Repeat("goforms ", 512) + `"}`
+
+func newEcho() *echo.Echo {
+	mw := compression.Middleware(compression.FromConfig(config.CompressionConfig{
+		Encodings:    []string{"br", "gzip"},
+		GzipLevel:    gzip.BestSpeed,
+		MinSize:      1024,
+		ContentTypes: []string{"text/*", "application/json"},
+	}))
+
+	e := echo.New()
+	e.Use(mw)
+	e.GET("/large", func(c echo.Context) error {
+		return c.Blob(http.StatusOK, echo.MIMEApplicationJSON, []byte(largeJSON))
+	})
+	e.GET("/small", func(c echo.Context) error {
+		return c.JSON(http.StatusOK, map[string]string{"ok": "yes"})
+	})
+	e.GET("/image", func(c echo.Context) error {
+		return c.Blob(http.StatusOK, "image/png", []byte(largeJSON))
+	})
+	e.GET("/precompressed", func(c echo.Context) error {
+		c.Response().Header().Set(echo.HeaderContentEncoding, "gzip")
+
+		return c.Blob(http.StatusOK, "application/javascript", []byte(largeJSON))
+	})
+	e.GET("/empty", func(c echo.Context) error {
+		return c.NoContent(http.StatusNoContent)
+	})
+
+	return e
+}
+
+func get(e *echo.Echo, path, acceptEncoding string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodGet, path, http.NoBody)
+	if acceptEncoding != "" {
+		req.Header.Set(echo.HeaderAcceptEncoding, acceptEncoding)
+	}
+
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+
+	return rec
+}
+
+func TestMiddleware_CompressesLargeResponses(t *testing.T) {
+	rec := get(newEcho(), "/large", "gzip, deflate")
+
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "gzip", rec.Header().Get(echo.HeaderContentEncoding))
+	assert.Contains(t, rec.Header().Values(echo.HeaderVary), echo.HeaderAcceptEncoding)
+	assert.Less(t, rec.Body.Len(), len(largeJSON))
+
+	zr, err := gzip.NewReader(rec.Body)
+	require.NoError(t, err)
+
+	body, err := io.ReadAll(zr)
+	require.NoError(t, err)
+	assert.JSONEq(t, largeJSON, string(body))
+}
+
+func TestMiddleware_Negotiation(t *testing.T) {
+	tests := []struct {
+		name           string
+		acceptEncoding string
+		want           string
+	}{
+		{name: "no header", acceptEncoding: "", want: ""},
+		{name: "identity only", acceptEncoding: "identity", want: ""},
+		{name: "gzip refused", acceptEncoding: "gzip;q=0", want: ""},
+		{name: "wildcard", acceptEncoding: "*", want: "gzip"},
+		{name: "wildcard with gzip refused", acceptEncoding: "gzip;q=0, *;q=0.5", want: ""},
+		{name: "unregistered brotli falls back", acceptEncoding: "br;q=1.0, gzip;q=0.8", want: "gzip"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := get(newEcho(), "/large", tt.acceptEncoding)
+
+			assert.Equal(t, tt.want, rec.Header().Get(echo.HeaderContentEncoding))
+		})
+	}
+}
+
+func TestMiddleware_PassesThrough(t *testing.T) {
+	tests := []struct {
+		name     string
+		path     string
+		encoding string
+	}{
+		{name: "below minimum size", path: "/small"},
+		{name: "content type not compressible", path: "/image"},
+		{name: "already encoded", path: "/precompressed", encoding: "gzip"},
+		{name: "no content", path: "/empty"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := get(newEcho(), tt.path, "gzip")
+
+			assert.Equal(t, tt.encoding, rec.Header().Get(echo.HeaderContentEncoding))
+
+			if tt.path == "/precompressed" || tt.path == "/image" {
+				// The body must not be compressed a second time
+				assert.Equal(t, largeJSON, rec.Body.String())
+			}
+		})
+	}
+}
+
+func BenchmarkMiddleware_Gzip(b *testing.B) {
+	e := newEcho()
+
+	b.ReportAllocs()
+
+	for b.Loop() {
+		get(e, "/large", "gzip")
+	}
+}
+
+func BenchmarkMiddleware_Precompressed(b *testing.B) {
+	e := newEcho()
+
+	b.ReportAllocs()
+
+	for b.Loop() {
+		get(e, "/precompressed", "gzip")
+	}
+}
+
+func BenchmarkMiddleware_Identity(b *testing.B) {
+	e := newEcho()
+
+	b.ReportAllocs()
+
+	for b.Loop() {
+		get(e, "/large", "")
+	}
+}
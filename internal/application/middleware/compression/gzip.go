@@ -0,0 +1,40 @@
+package compression
+
+import (
+	"compress/gzip"
+	"fmt"
+	"io"
+)
+
+func init() {
+	Register(gzipEncoder{})
+}
+
+// gzipEncoder compresses with gzip from the standard library
+type gzipEncoder struct{}
+
+// Encoding returns the gzip content coding
+func (gzipEncoder) Encoding() string {
+	return "gzip"
+}
+
+// NewWriter returns a gzip writer; level 0 selects the default level
+func (gzipEncoder) NewWriter(w io.Writer, level int) (io.WriteCloser, error) {
+	if level == 0 {
+		level = gzip.DefaultCompression
+	}
+
+	zw, err := gzip.NewWriterLevel(w, level)
+	if err != nil {
+		return nil, fmt.Errorf("gzip level %d: %w", level, err)
+	}
+
+	return zw, nil
+}
+
+// Reset reuses a gzip writer for w
+func (gzipEncoder) Reset(zw io.WriteCloser, w io.Writer) {
+	if gz, ok := zw.(*gzip.Writer); ok {
+		gz.Reset(w)
+	}
+}
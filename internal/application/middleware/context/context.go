@@ -3,12 +3,15 @@ package context
 
 import (
 	"context"
+	"errors"
 	"time"
 
 	"github.com/google/uuid"
 	"github.com/labstack/echo/v4"
 
+	"github.com/goformx/goforms/internal/application/constants"
 	"github.com/goformx/goforms/internal/infrastructure/logging"
+	"github.com/goformx/goforms/internal/infrastructure/timeout"
 )
 
 // Key represents a key in the context
@@ -40,22 +43,40 @@ const (
 	SessionKey Key = "session"
 	// FormIDKey is the context key for form ID
 	FormIDKey Key = "form_id"
+	// ImpersonatorIDKey is the context key for the admin impersonating the user
+	ImpersonatorIDKey Key = "impersonator_id"
+	// ClientGoneKey marks a request whose client disconnected while it was served
+	ClientGoneKey Key = "client_gone"
 )
 
 // Middleware provides context handling for HTTP requests
 type Middleware struct {
 	logger         logging.Logger
 	requestTimeout time.Duration
+	// budgets split the request time among the database and outbound calls
+	budgets timeout.Budgets
+	// skipTimeout reports requests left without the request timeout
+	skipTimeout func(echo.Context) bool
 }
 
-// NewMiddleware creates a new context middleware
-func NewMiddleware(logger logging.Logger, requestTimeout time.Duration) *Middleware {
+// NewMiddleware creates a new context middleware. Request contexts carry
+// budgets, so database statements and outbound calls end before the request does.
+func NewMiddleware(logger logging.Logger, requestTimeout time.Duration, budgets timeout.Budgets) *Middleware {
 	return &Middleware{
 		logger:         logger,
 		requestTimeout: requestTimeout,
+		budgets:        budgets,
 	}
 }
 
+// SkipTimeout leaves the requests skip reports without the request timeout, for
+// long-lived streams that manage their own lifetime
+func (m *Middleware) SkipTimeout(skip func(echo.Context) bool) *Middleware {
+	m.skipTimeout = skip
+
+	return m
+}
+
 // WithContext adds context to the request
 func (m *Middleware) WithContext() echo.MiddlewareFunc {
 	return func(next echo.HandlerFunc) echo.HandlerFunc {
@@ -67,18 +88,42 @@ func (m *Middleware) WithContext() echo.MiddlewareFunc {
 				c.Request().Header.Set(RequestIDHeader, requestID)
 			}
 
+			// Echo the ID back so clients can quote it when reporting a problem
+			c.Response().Header().Set(RequestIDHeader, requestID)
+
 			// Create request context with timeout
-			ctx, cancel := context.WithTimeout(c.Request().Context(), m.requestTimeout)
+			parent := c.Request().Context()
+
+			var (
+				ctx    context.Context
+				cancel context.CancelFunc
+			)
+
+			if m.skipTimeout != nil && m.skipTimeout(c) {
+				ctx, cancel = context.WithCancel(parent)
+			} else {
+				ctx, cancel = context.WithTimeout(parent, m.requestTimeout)
+			}
+
 			defer cancel()
 
 			// Add request ID and logger to context
 			ctx = context.WithValue(ctx, RequestIDKey, requestID)
 			ctx = context.WithValue(ctx, LoggerKey, m.logger)
+			ctx = timeout.WithBudgets(ctx, m.budgets)
 
 			// Update request context
 			c.SetRequest(c.Request().WithContext(ctx))
 
-			return next(c)
+			err := next(c)
+
+			// The parent is cancelled, rather than past its deadline, only when
+			// the client has gone; checked here as the contexts end on return
+			if errors.Is(err, context.Canceled) && errors.Is(parent.Err(), context.Canceled) {
+				c.Set(string(ClientGoneKey), true)
+			}
+
+			return err
 		}
 	}
 }
@@ -114,6 +159,13 @@ func GetCorrelationID(ctx context.Context) string {
 
 // Echo Context Helpers
 
+// ClientGone reports whether the request failed because its client disconnected
+func ClientGone(c echo.Context) bool {
+	gone, ok := c.Get(string(ClientGoneKey)).(bool)
+
+	return ok && gone
+}
+
 // GetUserID retrieves the user ID from context
 func GetUserID(c echo.Context) (string, bool) {
 	if c == nil {
@@ -154,6 +206,17 @@ func IsAuthenticated(c echo.Context) bool {
 	return ok && userID != ""
 }
 
+// GetImpersonatorID retrieves the admin impersonating the user from context
+func GetImpersonatorID(c echo.Context) (string, bool) {
+	if c == nil {
+		return "", false
+	}
+
+	impersonatorID, ok := c.Get(string(ImpersonatorIDKey)).(string)
+
+	return impersonatorID, ok && impersonatorID != ""
+}
+
 // IsAdmin checks if the user is an admin
 func IsAdmin(c echo.Context) bool {
 	role, ok := GetRole(c)
@@ -198,6 +261,11 @@ func SetRole(c echo.Context, role string) {
 	c.Set(string(RoleKey), role)
 }
 
+// SetImpersonatorID sets the admin impersonating the user in context
+func SetImpersonatorID(c echo.Context, impersonatorID string) {
+	c.Set(string(ImpersonatorIDKey), impersonatorID)
+}
+
 // SetFirstName sets the user first name in context
 func SetFirstName(c echo.Context, firstName string) {
 	c.Set(string(FirstNameKey), firstName)
@@ -246,3 +314,12 @@ func SetFormID(c echo.Context, formID string) {
 func SetFormIDInContext(ctx context.Context, formID string) context.Context {
 	return context.WithValue(ctx, FormIDKey, formID)
 }
+
+// FormAccessToken returns the form access token sent in the header or query string
+func FormAccessToken(c echo.Context) string {
+	if token := c.Request().Header.Get(constants.HeaderFormAccessToken); token != "" {
+		return token
+	}
+
+	return c.QueryParam(constants.QueryParamFormAccessToken)
+}
@@ -0,0 +1,204 @@
+// Package idempotency provides Idempotency-Key handling for unsafe API requests.
+// A retried request with the same key and body replays the original response
+// instead of being processed again.
+package idempotency
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/labstack/echo/v4"
+
+	mwcontext "github.com/goformx/goforms/internal/application/middleware/context"
+	"github.com/goformx/goforms/internal/application/response"
+	"github.com/goformx/goforms/internal/infrastructure/config"
+	store "github.com/goformx/goforms/internal/infrastructure/idempotency"
+	"github.com/goformx/goforms/internal/infrastructure/logging"
+)
+
+const (
+	// HeaderIdempotencyKey is the request header carrying the client's key
+	HeaderIdempotencyKey = "Idempotency-Key"
+	// HeaderReplayed is set on responses replayed from a stored record
+	HeaderReplayed = "Idempotent-Replayed"
+
+	// MaxKeyLength is the longest accepted Idempotency-Key
+	MaxKeyLength = 255
+	// maxStoredBody is the largest response body kept for replay
+	maxStoredBody = 1 << 20
+	// storeTimeout bounds store calls made after the handler has finished
+	storeTimeout = 5 * time.Second
+)
+
+// replayedHeaders are the response headers kept with a record
+var replayedHeaders = []string{echo.HeaderContentType, echo.HeaderLocation}
+
+// Middleware makes POST handlers safe to retry with an Idempotency-Key header
+type Middleware struct {
+	store  store.Store
+	ttl    time.Duration
+	logger logging.Logger
+}
+
+// NewMiddleware creates a new idempotency middleware
+func NewMiddleware(s store.Store, cfg config.IdempotencyConfig, logger logging.Logger) *Middleware {
+	return &Middleware{
+		store:  s,
+		ttl:    cfg.TTL,
+		logger: logger,
+	}
+}
+
+// Handle returns the Echo middleware. Requests without an Idempotency-Key pass through unchanged.
+func (m *Middleware) Handle() echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			key := c.Request().Header.Get(HeaderIdempotencyKey)
+			if key == "" || m.store == nil {
+				return next(c)
+			}
+
+			if len(key) > MaxKeyLength {
+				return response.ErrorResponse(c, http.StatusBadRequest, "Idempotency-Key must be at most 255 characters")
+			}
+
+			body, err := io.ReadAll(c.Request().Body)
+			if err != nil {
+				return response.ErrorResponse(c, http.StatusBadRequest, "Failed to read request body")
+			}
+
+			c.Request().Body = io.NopCloser(bytes.NewReader(body))
+
+			storageKey := m.storageKey(c, key)
+			pending := &store.Record{Fingerprint: fingerprint(c.Request(), body)}
+
+			existing, reserved, err := m.store.Reserve(c.Request().Context(), storageKey, pending, m.ttl)
+			if err != nil {
+				// Fail open: a store outage should not block submissions
+				m.logger.Error("idempotency store unavailable", "error", err, "path", c.Path())
+
+				return next(c)
+			}
+
+			if !reserved {
+				return m.replay(c, existing, pending.Fingerprint)
+			}
+
+			return m.process(c, next, storageKey, pending)
+		}
+	}
+}
+
+// process runs the handler and stores its response, or releases the key when
+// the outcome should not be replayed
+func (m *Middleware) process(c echo.Context, next echo.HandlerFunc, storageKey string, record *store.Record) error {
+	recorder := &bodyRecorder{ResponseWriter: c.Response().Writer}
+	c.Response().Writer = recorder
+
+	handlerErr := next(c)
+
+	c.Response().Writer = recorder.ResponseWriter
+
+	ctx, cancel := context.WithTimeout(context.WithoutCancel(c.Request().Context()), storeTimeout)
+	defer cancel()
+
+	res := c.Response()
+	if !res.Committed || res.Status >= http.StatusInternalServerError || recorder.overflow {
+		if err := m.store.Release(ctx, storageKey); err != nil {
+			m.logger.Warn("failed to release idempotency key", "error", err, "path", c.Path())
+		}
+
+		return handlerErr
+	}
+
+	record.Completed = true
+	record.Status = res.Status
+	record.Body = recorder.body.Bytes()
+	record.Header = make(http.Header)
+
+	for _, name := range replayedHeaders {
+		if value := res.Header().Get(name); value != "" {
+			record.Header.Set(name, value)
+		}
+	}
+
+	if err := m.store.Save(ctx, storageKey, record, m.ttl); err != nil {
+		m.logger.Warn("failed to save idempotency record", "error", err, "path", c.Path())
+	}
+
+	return handlerErr
+}
+
+// replay answers a retried request from its stored record
+func (m *Middleware) replay(c echo.Context, existing *store.Record, requestFingerprint string) error {
+	if existing.Fingerprint != requestFingerprint {
+		return response.ErrorResponse(c, http.StatusUnprocessableEntity,
+			"Idempotency-Key was already used with a different request")
+	}
+
+	if !existing.Completed {
+		c.Response().Header().Set("Retry-After", "1")
+
+		return response.ErrorResponse(c, http.StatusConflict,
+			"A request with this Idempotency-Key is still being processed")
+	}
+
+	for name, values := range existing.Header {
+		for _, value := range values {
+			c.Response().Header().Add(name, value)
+		}
+	}
+
+	c.Response().Header().Set(HeaderReplayed, "true")
+
+	return c.Blob(existing.Status, existing.Header.Get(echo.HeaderContentType), existing.Body)
+}
+
+// storageKey scopes the client's key to the caller and route so keys cannot collide across users or endpoints.
+// Anonymous callers are told apart by their address and form access token, so one visitor
+// reusing another's key is processed afresh instead of being replayed the other's response.
+func (m *Middleware) storageKey(c echo.Context, key string) string {
+	scope := "anonymous:" + c.RealIP() + "\n" + mwcontext.FormAccessToken(c)
+	if userID, ok := mwcontext.GetUserID(c); ok {
+		scope = "user:" + userID
+	}
+
+	sum := sha256.Sum256([]byte(scope + "\n" + c.Request().Method + " " + c.Request().URL.Path + "\n" + key))
+
+	return hex.EncodeToString(sum[:])
+}
+
+// fingerprint identifies a request by its target and body
+func fingerprint(req *http.Request, body []byte) string {
+	h := sha256.New()
+	h.Write([]byte(req.Method + " " + req.URL.Path + "\n"))
+	h.Write(body)
+
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// bodyRecorder copies the response body while it is written to the client
+type bodyRecorder struct {
+	http.ResponseWriter
+	body     bytes.Buffer
+	overflow bool
+}
+
+// Write writes to the client and records the bytes up to maxStoredBody
+func (r *bodyRecorder) Write(b []byte) (int, error) {
+	if !r.overflow {
+		if r.body.Len()+len(b) > maxStoredBody {
+			r.overflow = true
+			r.body.Reset()
+		} else {
+			r.body.Write(b)
+		}
+	}
+
+	return r.ResponseWriter.Write(b)
+}
@@ -0,0 +1,184 @@
+package idempotency_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/goformx/goforms/internal/application/middleware/idempotency"
+	"github.com/goformx/goforms/internal/infrastructure/config"
+	store "github.com/goformx/goforms/internal/infrastructure/idempotency"
+	mocklogging "github.com/goformx/goforms/test/mocks/logging"
+)
+
+func newEcho(t *testing.T, status int, calls *atomic.Int32) *echo.Echo {
+	t.Helper()
+
+	ctrl := gomock.NewController(t)
+	logger := mocklogging.NewMockLogger(ctrl)
+	logger.EXPECT().Warn(gomock.Any(), gomock.Any()).AnyTimes()
+
+	mw := idempotency.NewMiddleware(store.NewMemoryStore(), config.IdempotencyConfig{
+		Enabled: true,
+		TTL:     time.Hour,
+	}, logger)
+
+	e := echo.New()
+	e.POST("/forms/:id/submit", func(c echo.Context) error {
+		n := calls.Add(1)
+
+		return c.JSON(status, map[string]any{"call": n})
+	}, mw.Handle())
+
+	return e
+}
+
+func post(e *echo.Echo, key, body string) *httptest.ResponseRecorder {
+	return postFrom(e, "192.0.2.1:1234", "", key, body)
+}
+
+// postFrom submits as an anonymous visitor at remoteAddr holding accessToken
+func postFrom(e *echo.Echo, remoteAddr, accessToken, key, body string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodPost, "/forms/abc/submit", strings.NewReader(body))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	req.RemoteAddr = remoteAddr
+
+	if accessToken != "" {
+		req.Header.Set("X-Form-Access-Token", accessToken)
+	}
+
+	if key != "" {
+		req.Header.Set(idempotency.HeaderIdempotencyKey, key)
+	}
+
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+
+	return rec
+}
+
+func TestMiddleware_ReplaysCompletedRequest(t *testing.T) {
+	var calls atomic.Int32
+
+	e := newEcho(t, http.StatusCreated, &calls)
+
+	first := post(e, "key-1", `{"name":"a"}`)
+	require.Equal(t, http.StatusCreated, first.Code)
+	assert.Empty(t, first.Header().Get(idempotency.HeaderReplayed))
+
+	second := post(e, "key-1", `{"name":"a"}`)
+	require.Equal(t, http.StatusCreated, second.Code)
+	assert.Equal(t, "true", second.Header().Get(idempotency.HeaderReplayed))
+	assert.JSONEq(t, first.Body.String(), second.Body.String())
+	assert.Contains(t, second.Header().Get(echo.HeaderContentType), echo.MIMEApplicationJSON)
+	assert.Equal(t, int32(1), calls.Load())
+}
+
+func TestMiddleware_ScopesAnonymousKeysByClient(t *testing.T) {
+	var calls atomic.Int32
+
+	e := newEcho(t, http.StatusCreated, &calls)
+
+	first := postFrom(e, "192.0.2.1:1234", "token-a", "key-1", `{"name":"a"}`)
+	require.Equal(t, http.StatusCreated, first.Code)
+
+	// Another visitor sending the same key and body is not replayed the first one's response
+	other := postFrom(e, "198.51.100.7:1234", "token-a", "key-1", `{"name":"a"}`)
+	require.Equal(t, http.StatusCreated, other.Code)
+	assert.Empty(t, other.Header().Get(idempotency.HeaderReplayed))
+
+	unlocked := postFrom(e, "192.0.2.1:1234", "token-b", "key-1", `{"name":"a"}`)
+	require.Equal(t, http.StatusCreated, unlocked.Code)
+	assert.Empty(t, unlocked.Header().Get(idempotency.HeaderReplayed))
+
+	// The first visitor retrying from another port is replayed
+	retry := postFrom(e, "192.0.2.1:5678", "token-a", "key-1", `{"name":"a"}`)
+	assert.Equal(t, "true", retry.Header().Get(idempotency.HeaderReplayed))
+	assert.JSONEq(t, first.Body.String(), retry.Body.String())
+	assert.Equal(t, int32(3), calls.Load())
+}
+
+func TestMiddleware_RejectsDifferentBody(t *testing.T) {
+	var calls atomic.Int32
+
+	e := newEcho(t, http.StatusCreated, &calls)
+
+	require.Equal(t, http.StatusCreated, post(e, "key-1", `{"name":"a"}`).Code)
+
+	rec := post(e, "key-1", `{"name":"b"}`)
+	assert.Equal(t, http.StatusUnprocessableEntity, rec.Code)
+	assert.Equal(t, int32(1), calls.Load())
+}
+
+func TestMiddleware_WithoutKeyPassesThrough(t *testing.T) {
+	var calls atomic.Int32
+
+	e := newEcho(t, http.StatusCreated, &calls)
+
+	post(e, "", `{}`)
+	post(e, "", `{}`)
+
+	assert.Equal(t, int32(2), calls.Load())
+}
+
+func TestMiddleware_ServerErrorsAreNotStored(t *testing.T) {
+	var calls atomic.Int32
+
+	e := newEcho(t, http.StatusInternalServerError, &calls)
+
+	post(e, "key-1", `{}`)
+	rec := post(e, "key-1", `{}`)
+
+	assert.Empty(t, rec.Header().Get(idempotency.HeaderReplayed))
+	assert.Equal(t, int32(2), calls.Load())
+}
+
+func TestMiddleware_RejectsLongKey(t *testing.T) {
+	var calls atomic.Int32
+
+	e := newEcho(t, http.StatusCreated, &calls)
+
+	rec := post(e, strings.Repeat("k", idempotency.MaxKeyLength+1), `{}`)
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	assert.Equal(t, int32(0), calls.Load())
+}
+
+func TestMiddleware_InFlightRequestConflicts(t *testing.T) {
+	records := store.NewMemoryStore()
+
+	ctrl := gomock.NewController(t)
+	logger := mocklogging.NewMockLogger(ctrl)
+	mw := idempotency.NewMiddleware(records, config.IdempotencyConfig{Enabled: true, TTL: time.Hour}, logger)
+
+	release := make(chan struct{})
+	started := make(chan struct{})
+
+	e := echo.New()
+	e.POST("/forms/:id/submit", func(c echo.Context) error {
+		close(started)
+		<-release
+
+		return c.NoContent(http.StatusCreated)
+	}, mw.Handle())
+
+	done := make(chan *httptest.ResponseRecorder)
+
+	go func() { done <- post(e, "key-1", `{}`) }()
+
+	<-started
+
+	rec := post(e, "key-1", `{}`)
+	assert.Equal(t, http.StatusConflict, rec.Code)
+	assert.Equal(t, "1", rec.Header().Get("Retry-After"))
+
+	close(release)
+	assert.Equal(t, http.StatusCreated, (<-done).Code)
+}
@@ -16,14 +16,22 @@ import (
 	"github.com/goformx/goforms/internal/application/middleware/access"
 	"github.com/goformx/goforms/internal/application/middleware/adapters"
 	"github.com/goformx/goforms/internal/application/middleware/assertion"
+	"github.com/goformx/goforms/internal/application/middleware/compression"
 	contextmw "github.com/goformx/goforms/internal/application/middleware/context"
 	"github.com/goformx/goforms/internal/application/middleware/security"
 	"github.com/goformx/goforms/internal/application/middleware/session"
+	"github.com/goformx/goforms/internal/application/response"
+	"github.com/goformx/goforms/internal/domain/audit"
 	formdomain "github.com/goformx/goforms/internal/domain/form"
+	"github.com/goformx/goforms/internal/domain/metering"
+	"github.com/goformx/goforms/internal/domain/securityevent"
 	"github.com/goformx/goforms/internal/domain/user"
 	appconfig "github.com/goformx/goforms/internal/infrastructure/config"
+	"github.com/goformx/goforms/internal/infrastructure/crashreport"
 	"github.com/goformx/goforms/internal/infrastructure/logging"
+	"github.com/goformx/goforms/internal/infrastructure/metrics"
 	"github.com/goformx/goforms/internal/infrastructure/sanitization"
+	"github.com/goformx/goforms/internal/infrastructure/timeout"
 	"github.com/goformx/goforms/internal/infrastructure/version"
 )
 
@@ -100,6 +108,10 @@ type Manager struct {
 	config            *ManagerConfig
 	contextMiddleware *contextmw.Middleware
 	pathChecker       *PathChecker
+	// global lists the names of the middleware applied to every route, in order
+	global []string
+	// rateLimiter is nil when rate limiting is disabled
+	rateLimiter *security.RateLimiter
 }
 
 // ManagerConfig contains all dependencies for the middleware manager
@@ -111,6 +123,16 @@ type ManagerConfig struct {
 	SessionManager *session.Manager
 	AccessManager  *access.Manager
 	Sanitizer      sanitization.ServiceInterface
+	// Audit records the requests of impersonation sessions; optional
+	Audit audit.Service
+	// SecurityEvents detects brute-force and anomalous traffic; optional
+	SecurityEvents securityevent.Service
+	// Metering counts API calls per account; optional
+	Metering metering.Service
+	// Metrics reports the rate limit violations; optional
+	Metrics *metrics.Registry
+	// CrashReports records the panics the recovery middleware catches; optional
+	CrashReports *crashreport.Reporter
 }
 
 // Validate ensures all required configuration is present
@@ -137,14 +159,46 @@ func NewManager(cfg *ManagerConfig) *Manager {
 		panic(fmt.Sprintf("invalid config: %v", err))
 	}
 
+	budgets := timeout.FromConfig(cfg.Config.Timeouts)
+	contextMiddleware := contextmw.NewMiddleware(cfg.Logger, cfg.Config.App.RequestTimeout, budgets).SkipTimeout(isStreamingPath)
+
 	return &Manager{
 		logger:            cfg.Logger,
 		config:            cfg,
-		contextMiddleware: contextmw.NewMiddleware(cfg.Logger, cfg.Config.App.RequestTimeout),
+		contextMiddleware: contextMiddleware,
 		pathChecker:       NewPathChecker(),
 	}
 }
 
+// GlobalMiddleware returns the names of the middleware Setup applied to every
+// route, outermost first
+func (m *Manager) GlobalMiddleware() []string {
+	return append([]string{}, m.global...)
+}
+
+// use applies middleware to every route and records its name
+func (m *Manager) use(e *echo.Echo, name string, mw echo.MiddlewareFunc) {
+	e.Use(mw)
+	m.global = append(m.global, name)
+}
+
+// SetRouteRateLimits applies the rate limits handlers declare on their routes.
+// It does nothing while rate limiting is disabled.
+func (m *Manager) SetRouteRateLimits(limits []security.RouteLimit) {
+	if m.rateLimiter != nil {
+		m.rateLimiter.SetRouteLimits(limits)
+	}
+}
+
+// RouteRateLimit returns the route limit that applies to a route, if any
+func (m *Manager) RouteRateLimit(method, route string) (security.RouteLimit, bool) {
+	if m.rateLimiter == nil {
+		return security.RouteLimit{}, false
+	}
+
+	return m.rateLimiter.RouteLimitFor(method, route)
+}
+
 // GetSessionManager returns the session manager
 func (m *Manager) GetSessionManager() *session.Manager {
 	return m.config.SessionManager
@@ -169,6 +223,9 @@ func (m *Manager) Setup(e *echo.Echo) {
 		e.Logger.SetLevel(log.INFO)
 	}
 
+	// Errors get an error page or JSON error carrying the request ID
+	e.HTTPErrorHandler = response.NewHTTPErrorHandler(m.logger)
+
 	m.setupBasicMiddleware(e)
 	m.setupSecurityMiddleware(e)
 	m.setupAuthMiddleware(e)
@@ -178,18 +235,27 @@ func (m *Manager) Setup(e *echo.Echo) {
 
 func (m *Manager) setupBasicMiddleware(e *echo.Echo) {
 	// Recovery middleware first
-	e.Use(Recovery(m.logger, m.config.Sanitizer))
+	m.use(e, "recovery", Recovery(m.logger, m.config.Sanitizer, m.config.CrashReports))
 
 	// Timeout middleware (using context-based timeout to avoid data races)
-	e.Use(echomw.ContextTimeoutWithConfig(echomw.ContextTimeoutConfig{
+	// Long-lived streams are excluded; they manage their own lifetime.
+	m.use(e, "timeout", echomw.ContextTimeoutWithConfig(echomw.ContextTimeoutConfig{
 		Timeout: m.config.Config.App.RequestTimeout,
+		Skipper: isStreamingPath,
 	}))
 
+	// Response compression; streams flush as they go and are left uncompressed
+	if m.config.Config.Web.Gzip {
+		compressionConfig := compression.FromConfig(m.config.Config.Web.Compression)
+		compressionConfig.Skipper = isStreamingPath
+		m.use(e, "compression", compression.Middleware(compressionConfig))
+	}
+
 	// Context middleware
-	e.Use(m.contextMiddleware.WithContext())
+	m.use(e, "context", m.contextMiddleware.WithContext())
 
 	// Logging middleware (using RequestLoggerWithConfig for race-free logging)
-	e.Use(echomw.RequestLoggerWithConfig(echomw.RequestLoggerConfig{
+	m.use(e, "request_logger", echomw.RequestLoggerWithConfig(echomw.RequestLoggerConfig{
 		LogURI:      true,
 		LogStatus:   true,
 		LogMethod:   true,
@@ -246,7 +312,7 @@ func (m *Manager) setupBasicMiddleware(e *echo.Echo) {
 	}))
 
 	// Slow request detection middleware
-	e.Use(SlowRequestDetectorWithConfig(m.logger, SlowRequestConfig{
+	m.use(e, "slow_request", SlowRequestDetectorWithConfig(m.logger, SlowRequestConfig{
 		Threshold:         DefaultSlowRequestThreshold,
 		VerySlowThreshold: VerySlowRequestThreshold,
 		Skipper:           NewSlowRequestSkipper(),
@@ -264,11 +330,11 @@ func (m *Manager) setupSecurityMiddleware(e *echo.Echo) {
 			MaxAge:           m.config.Config.Security.CORS.MaxAge,
 			Skipper:          shouldSkipGlobalCORS,
 		}
-		e.Use(echomw.CORSWithConfig(corsConfig))
+		m.use(e, "cors", echomw.CORSWithConfig(corsConfig))
 	}
 
 	// Secure middleware
-	e.Use(echomw.SecureWithConfig(echomw.SecureConfig{
+	m.use(e, "secure", echomw.SecureWithConfig(echomw.SecureConfig{
 		XSSProtection:         m.config.Config.Security.SecurityHeaders.XXSSProtection,
 		ContentTypeNosniff:    m.config.Config.Security.SecurityHeaders.XContentTypeOptions,
 		XFrameOptions:         m.config.Config.Security.SecurityHeaders.XFrameOptions,
@@ -278,7 +344,7 @@ func (m *Manager) setupSecurityMiddleware(e *echo.Echo) {
 	}))
 
 	// Set security config in context
-	e.Use(func(next echo.HandlerFunc) echo.HandlerFunc {
+	m.use(e, "security_config", func(next echo.HandlerFunc) echo.HandlerFunc {
 		return func(c echo.Context) error {
 			c.Set("security_config", m.config.Config.Security)
 			return next(c)
@@ -286,7 +352,12 @@ func (m *Manager) setupSecurityMiddleware(e *echo.Echo) {
 	})
 
 	// Additional security headers
-	e.Use(security.SetupSecurityHeaders())
+	m.use(e, "security_headers", security.SetupSecurityHeaders())
+
+	// Security event detection wraps CSRF so it sees CSRF failures
+	if m.config.SecurityEvents != nil && m.config.Config.Security.Events.Enabled {
+		m.use(e, "security_events", security.DetectEvents(m.config.SecurityEvents, m.logger))
+	}
 
 	// CSRF middleware
 	m.logger.Info("CSRF middleware configuration",
@@ -300,7 +371,7 @@ func (m *Manager) setupSecurityMiddleware(e *echo.Echo) {
 			m.config.Config.App.Environment == "development",
 			m.logger,
 		)
-		e.Use(csrfMiddleware)
+		m.use(e, "csrf", csrfMiddleware)
 		m.logger.Info("CSRF middleware registered",
 			"context_key", m.config.Config.Security.CSRF.ContextKey,
 			"cookie_name", m.config.Config.Security.CSRF.CookieName)
@@ -310,17 +381,30 @@ func (m *Manager) setupSecurityMiddleware(e *echo.Echo) {
 
 	// Rate limiting
 	if m.config.Config.Security.RateLimit.Enabled {
-		rateLimiter := security.NewRateLimiter(m.logger, m.config.Config, m.pathChecker)
-		e.Use(rateLimiter.Setup())
+		m.rateLimiter = security.NewRateLimiter(m.logger, m.config.Config, m.pathChecker)
+		if m.config.Metrics != nil {
+			m.config.Metrics.Register("rate_limit", m.rateLimiter.Metrics())
+		}
+
+		m.use(e, "rate_limit", m.rateLimiter.Setup())
+	}
+
+	// Usage metering counts the authenticated API calls the rate limiter let through
+	if m.config.Metering != nil {
+		m.use(e, "metering", security.MeterRequests(m.config.Metering, m.config.Config, m.pathChecker, m.logger))
 	}
 }
 
 func (m *Manager) setupAuthMiddleware(e *echo.Echo) {
 	if m.config.SessionManager != nil {
-		e.Use(m.config.SessionManager.Middleware())
+		m.use(e, "session", m.config.SessionManager.Middleware())
+
+		if m.config.Audit != nil {
+			m.use(e, "impersonation_audit", session.ImpersonationAudit(m.config.Audit, m.logger))
+		}
 	}
 
-	e.Use(access.Middleware(m.config.AccessManager, m.logger))
+	m.use(e, "access", access.Middleware(m.config.AccessManager, m.logger))
 }
 
 // isNoisePath checks if the path should be suppressed from logging
@@ -334,6 +418,19 @@ func isNoisePath(c echo.Context) bool {
 		strings.Contains(path, "chrome-devtools")
 }
 
+// isStreamingPath checks if the request is for the submission SSE stream or the
+// collaboration websocket, matched by their registered routes, or for a profile
+func isStreamingPath(c echo.Context) bool {
+	switch c.Path() {
+	case constants.PathAPIFormsLaravel + constants.RouteFormSubmissionStream,
+		constants.PathAPIFormsLaravel + constants.RouteFormCollab:
+		return true
+	}
+
+	// CPU profiles and traces run for as long as their ?seconds= asks
+	return strings.HasPrefix(c.Request().URL.Path, constants.PathAPIAdminDebug+"/pprof/")
+}
+
 // isFormRoute checks if the path is a form-related route
 func isFormRoute(path string) bool {
 	return strings.HasPrefix(path, "/forms/") ||
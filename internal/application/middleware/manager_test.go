@@ -10,6 +10,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	"go.uber.org/mock/gomock"
 
+	"github.com/goformx/goforms/internal/application/constants"
 	"github.com/goformx/goforms/internal/application/middleware"
 	"github.com/goformx/goforms/internal/application/middleware/access"
 	appconfig "github.com/goformx/goforms/internal/infrastructure/config"
@@ -187,3 +188,43 @@ func TestManager_RateLimiter_Scenarios(t *testing.T) {
 		})
 	}
 }
+
+func TestManager_Timeout_SkipsOnlyRegisteredStreams(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	cfg := createTestConfig()
+	cfg.Security.RateLimit.Enabled = false
+
+	e := echo.New()
+	middleware.NewManager(&middleware.ManagerConfig{
+		Logger:        createTestLogger(ctrl),
+		Config:        cfg,
+		AccessManager: createTestAccessManager(),
+		Sanitizer:     sanitization.NewService(),
+	}).Setup(e)
+
+	deadline := func(c echo.Context) error {
+		if _, ok := c.Request().Context().Deadline(); ok {
+			return c.String(http.StatusOK, "deadline")
+		}
+
+		return c.String(http.StatusOK, "none")
+	}
+
+	e.GET(constants.PathAPIFormsLaravel+constants.RouteFormSubmissionStream, deadline)
+	e.GET(constants.PathAPIFormsLaravel+"/:id/export/stream", deadline)
+
+	call := func(path string) string {
+		req := httptest.NewRequest(http.MethodGet, path, http.NoBody)
+
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, req)
+
+		return rec.Body.String()
+	}
+
+	assert.Equal(t, "none", call(constants.PathAPIFormsLaravel+"/form-1/submissions/stream"))
+	// Another path ending in /stream keeps the request timeout
+	assert.Equal(t, "deadline", call(constants.PathAPIFormsLaravel+"/form-1/export/stream"))
+}
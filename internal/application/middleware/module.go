@@ -12,10 +12,15 @@ import (
 	"github.com/goformx/goforms/internal/application/middleware/auth"
 	"github.com/goformx/goforms/internal/application/middleware/core"
 	"github.com/goformx/goforms/internal/application/middleware/session"
+	"github.com/goformx/goforms/internal/domain/audit"
 	formdomain "github.com/goformx/goforms/internal/domain/form"
+	"github.com/goformx/goforms/internal/domain/metering"
+	"github.com/goformx/goforms/internal/domain/securityevent"
 	"github.com/goformx/goforms/internal/domain/user"
 	"github.com/goformx/goforms/internal/infrastructure/config"
+	"github.com/goformx/goforms/internal/infrastructure/crashreport"
 	"github.com/goformx/goforms/internal/infrastructure/logging"
+	"github.com/goformx/goforms/internal/infrastructure/metrics"
 	"github.com/goformx/goforms/internal/infrastructure/sanitization"
 )
 
@@ -32,9 +37,10 @@ var Module = fx.Module("middleware",
 		fx.Annotate(
 			func(_ logging.Logger, pathManager *constants.PathManager) *access.Manager {
 				config := &access.Config{
-					DefaultAccess: access.Authenticated,
-					PublicPaths:   pathManager.PublicPaths,
-					AdminPaths:    pathManager.AdminPaths,
+					DefaultAccess:  access.Authenticated,
+					PublicPaths:    pathManager.PublicPaths,
+					AdminPaths:     pathManager.AdminPaths,
+					RouteAuthPaths: pathManager.RouteAuthPaths,
 				}
 				rules := generateAccessRules(pathManager)
 
@@ -42,6 +48,11 @@ var Module = fx.Module("middleware",
 			},
 		),
 
+		// The access manager applies the IP blocks of security event detection
+		func(accessManager *access.Manager) securityevent.Blocker {
+			return accessManager
+		},
+
 		// Session manager using path manager
 		fx.Annotate(
 			func(
@@ -56,8 +67,9 @@ var Module = fx.Module("middleware",
 					Config:        cfg,
 					PublicPaths:   pathManager.PublicPaths,
 					StaticPaths:   pathManager.StaticPaths,
-					// Laravel assertion auth: no session cookie; auth via X-User-Id/X-Signature
-					ExemptPaths: []string{constants.PathAPIFormsLaravel},
+					// Laravel assertion auth, provider webhooks and the routes authenticated by their route
+					// groups carry no session cookie; they authenticate with request signatures or API keys instead
+					ExemptPaths: append([]string{constants.PathAPIFormsLaravel, constants.PathAPIWebhooks}, pathManager.RouteAuthPaths...),
 				}
 
 				return session.NewManager(logger, sessionConfig, lc, accessManager)
@@ -108,6 +120,11 @@ var Module = fx.Module("middleware",
 				sessionManager *session.Manager,
 				accessManager *access.Manager,
 				sanitizer sanitization.ServiceInterface,
+				auditService audit.Service,
+				securityEvents securityevent.Service,
+				meter metering.Service,
+				registry *metrics.Registry,
+				crashReports *crashreport.Reporter,
 			) *Manager {
 				return NewManager(&ManagerConfig{
 					Logger:         logger,
@@ -117,6 +134,11 @@ var Module = fx.Module("middleware",
 					SessionManager: sessionManager,
 					AccessManager:  accessManager,
 					Sanitizer:      sanitizer,
+					Audit:          auditService,
+					SecurityEvents: securityEvents,
+					Metering:       meter,
+					Metrics:        registry,
+					CrashReports:   crashReports,
 				})
 			},
 		),
@@ -276,6 +298,8 @@ func generateAccessRules(pathManager *constants.PathManager) []access.Rule {
 		{Path: constants.PathFormsPublic + "/:id/validation", AccessLevel: access.Public},
 		{Path: constants.PathFormsPublic + "/:id/submit", AccessLevel: access.Public},
 		{Path: constants.PathFormsPublic + "/:id/embed", AccessLevel: access.Public},
+		{Path: constants.PathEmbedFrame + "/:id", AccessLevel: access.Public},
+		{Path: constants.PathFormsPublic + "/:id/stats", AccessLevel: access.Public},
 	}
 	rules = append(rules, publicFormRules...)
 
@@ -2,18 +2,26 @@ package middleware
 
 import (
 	"errors"
+	"fmt"
 	"net/http"
+	"runtime/debug"
 
 	"github.com/labstack/echo/v4"
 
+	contextmw "github.com/goformx/goforms/internal/application/middleware/context"
 	"github.com/goformx/goforms/internal/application/response"
 	domainerrors "github.com/goformx/goforms/internal/domain/common/errors"
+	"github.com/goformx/goforms/internal/infrastructure/crashreport"
 	"github.com/goformx/goforms/internal/infrastructure/logging"
 	"github.com/goformx/goforms/internal/infrastructure/sanitization"
 )
 
-// Recovery returns a middleware that recovers from panics
-func Recovery(logger logging.Logger, sanitizer sanitization.ServiceInterface) echo.MiddlewareFunc {
+// Recovery returns a middleware that recovers from panics. The reporter, which
+// may be nil, counts each panic and records a crash report with the stack
+// trace and request context.
+func Recovery(
+	logger logging.Logger, sanitizer sanitization.ServiceInterface, reporter *crashreport.Reporter,
+) echo.MiddlewareFunc {
 	logger = logger.WithComponent("recovery")
 
 	return func(next echo.HandlerFunc) echo.HandlerFunc {
@@ -21,7 +29,13 @@ func Recovery(logger logging.Logger, sanitizer sanitization.ServiceInterface) ec
 			defer func() {
 				if r := recover(); r != nil {
 					err := handlePanic(r)
-					handleError(c, err, logger, sanitizer)
+
+					panicLogger := logger
+					if id, file := captureCrash(c, r, debug.Stack(), reporter, sanitizer); id != "" {
+						panicLogger = logger.With("crash_id", id, "crash_report", file)
+					}
+
+					handleError(c, err, panicLogger, sanitizer)
 				}
 			}()
 
@@ -30,6 +44,42 @@ func Recovery(logger logging.Logger, sanitizer sanitization.ServiceInterface) ec
 	}
 }
 
+// captureCrash hands a recovered panic to the crash reporter. It returns the
+// report's ID and file, or "" when no report was recorded.
+func captureCrash(
+	c echo.Context, recovered any, stack []byte, reporter *crashreport.Reporter, sanitizer sanitization.ServiceInterface,
+) (id, file string) {
+	if reporter == nil {
+		return "", ""
+	}
+
+	req := c.Request()
+	userID, _ := contextmw.GetUserID(c)
+
+	report := &crashreport.Report{
+		RequestID: req.Header.Get(contextmw.RequestIDHeader),
+		Panic:     fmt.Sprint(recovered),
+		PanicType: fmt.Sprintf("%T", recovered),
+		Stack:     string(stack),
+		Request: crashreport.Request{
+			Method:     req.Method,
+			Path:       sanitizePath(req.URL.Path, sanitizer),
+			Route:      c.Path(),
+			RemoteAddr: c.RealIP(),
+			UserAgent:  sanitizer.SingleLine(req.UserAgent()),
+			UserID:     userID,
+		},
+	}
+
+	if reporter.GoroutineDump() {
+		report.Goroutines = crashreport.Goroutines()
+	}
+
+	file = reporter.Capture(report)
+
+	return report.ID, file
+}
+
 // sanitizePath sanitizes a URL path for safe logging
 func sanitizePath(path string, sanitizer sanitization.ServiceInterface) string {
 	if path == "" {
@@ -56,12 +106,22 @@ func handlePanic(r any) error {
 func handleError(c echo.Context, err error, logger logging.Logger, sanitizer sanitization.ServiceInterface) {
 	// Create a logger with request context
 	logger = logger.With(
-		"request_id", c.Request().Header.Get("X-Request-ID"),
+		"request_id", c.Request().Header.Get(contextmw.RequestIDHeader),
 		"method", c.Request().Method,
 		"path", sanitizePath(c.Request().URL.Path, sanitizer),
 		"remote_addr", c.Request().RemoteAddr,
 	)
 
+	// A handler that panicked after writing its response has nothing left to send
+	if c.Response().Committed {
+		logger.Error("recovered from panic after response was sent",
+			"error", err,
+			"error_type", "panic_after_response",
+		)
+
+		return
+	}
+
 	var domainErr *domainerrors.DomainError
 	if errors.As(err, &domainErr) {
 		logger.Error("recovered from panic with domain error",
@@ -72,7 +132,7 @@ func handleError(c echo.Context, err error, logger logging.Logger, sanitizer san
 		)
 
 		statusCode := domainerrors.GetHTTPStatus(domainErr.Code)
-		if jsonErr := response.ErrorResponse(c, statusCode, domainErr.Message); jsonErr != nil {
+		if jsonErr := response.ErrorPage(c, statusCode, domainErr.Message); jsonErr != nil {
 			logger.Error("failed to send error response",
 				"error", jsonErr,
 				"error_type", "response_error",
@@ -89,7 +149,7 @@ func handleError(c echo.Context, err error, logger logging.Logger, sanitizer san
 		"error_type", "panic_unknown_error",
 	)
 
-	if jsonErr := response.ErrorResponse(c, http.StatusInternalServerError, "Internal server error"); jsonErr != nil {
+	if jsonErr := response.ErrorPage(c, http.StatusInternalServerError, ""); jsonErr != nil {
 		logger.Error("failed to send error response",
 			"error", jsonErr,
 			"error_type", "response_error",
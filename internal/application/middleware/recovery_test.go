@@ -0,0 +1,68 @@
+package middleware_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/goformx/goforms/internal/application/middleware"
+	contextmw "github.com/goformx/goforms/internal/application/middleware/context"
+	"github.com/goformx/goforms/internal/infrastructure/config"
+	"github.com/goformx/goforms/internal/infrastructure/crashreport"
+	"github.com/goformx/goforms/internal/infrastructure/sanitization"
+)
+
+func TestRecovery_WritesCrashReport(t *testing.T) {
+	logger := createTestLogger(gomock.NewController(t))
+	logger.EXPECT().With(gomock.Any()).Return(logger).AnyTimes()
+
+	dir := t.TempDir()
+	reporter := crashreport.New(config.CrashReportsConfig{
+		Enabled:       true,
+		Directory:     dir,
+		MaxReports:    10,
+		GoroutineDump: true,
+	}, nil, logger)
+
+	e := echo.New()
+	e.Use(middleware.Recovery(logger, sanitization.NewService(), reporter))
+	e.GET("/forms/:id", func(c echo.Context) error {
+		c.Set(string(contextmw.UserIDKey), "user-1")
+		panic("nil form")
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/forms/abc", http.NoBody)
+	req.Header.Set(contextmw.RequestIDHeader, "req-1")
+
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusInternalServerError, rec.Code)
+
+	entries, err := os.ReadDir(dir)
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+
+	data, err := os.ReadFile(filepath.Join(dir, entries[0].Name()))
+	require.NoError(t, err)
+
+	var report crashreport.Report
+	require.NoError(t, json.Unmarshal(data, &report))
+
+	assert.Equal(t, "nil form", report.Panic)
+	assert.Equal(t, "string", report.PanicType)
+	assert.Equal(t, "req-1", report.RequestID)
+	assert.Equal(t, "/forms/:id", report.Request.Route)
+	assert.Equal(t, "user-1", report.Request.UserID)
+	assert.Contains(t, report.Stack, "recovery_test.go")
+	assert.NotEmpty(t, report.Goroutines)
+	assert.EqualValues(t, 1, reporter.GetMetrics()["panics"])
+}
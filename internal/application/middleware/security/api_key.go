@@ -83,6 +83,7 @@ func (a *APIKeyAuth) Setup() echo.MiddlewareFunc {
 					"method", c.Request().Method,
 					"ip", c.RealIP(),
 				)
+				c.Set(APIKeyFailureContextKey, true)
 				return echo.NewHTTPError(http.StatusUnauthorized, ErrMsgAPIInvalid)
 			}
 
@@ -197,6 +197,11 @@ func shouldSkipCSRFForRoute(logger logging.Logger, path string, isDevelopment bo
 		return true
 	}
 
+	// Webhooks are posted by email providers, which authenticate with signatures
+	if IsWebhookRoute(path) {
+		return true
+	}
+
 	if isDevelopment && IsAPIRoute(path) {
 		return true
 	}
@@ -219,6 +224,8 @@ func CreateCSRFErrorHandler(
 	logger logging.Logger,
 ) func(err error, c echo.Context) error {
 	return func(err error, c echo.Context) error {
+		c.Set(CSRFFailureContextKey, true)
+
 		if isDevelopment {
 			csrfToken := c.Request().Header.Get("X-Csrf-Token")
 			contextToken := ""
@@ -260,6 +267,11 @@ func IsAPIRoute(path string) bool {
 	return strings.HasPrefix(path, "/api/")
 }
 
+// IsWebhookRoute checks if the path is a provider webhook endpoint
+func IsWebhookRoute(path string) bool {
+	return strings.HasPrefix(path, constants.PathAPIWebhooks+"/")
+}
+
 // IsHealthRoute checks if the path is a health check route
 func IsHealthRoute(path string) bool {
 	return path == "/health" || path == "/health/" || path == "/healthz" || path == "/healthz/"
@@ -0,0 +1,75 @@
+package security
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+
+	"github.com/goformx/goforms/internal/application/middleware/assertion"
+	"github.com/goformx/goforms/internal/domain/securityevent"
+	"github.com/goformx/goforms/internal/infrastructure/logging"
+)
+
+const (
+	// CSRFFailureContextKey is set on the Echo context when a request fails CSRF validation
+	CSRFFailureContextKey = "csrf_failure"
+	// APIKeyFailureContextKey is set on the Echo context when a request presents an invalid API key
+	APIKeyFailureContextKey = "api_key_failure"
+)
+
+// DetectEvents returns middleware that reports failed logins, accepted form
+// submissions and CSRF failures to the security event service once the request
+// has been handled. It must run before the CSRF middleware so it sees its failures.
+func DetectEvents(events securityevent.Service, logger logging.Logger) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			err := next(c)
+
+			signal, ok := signalFor(c, err)
+			if !ok {
+				return err
+			}
+
+			// The request may have been cancelled; the signal must still be counted
+			if observeErr := events.Observe(context.WithoutCancel(c.Request().Context()), signal); observeErr != nil {
+				logger.Error("failed to observe security signal", "kind", signal.Kind, "error", observeErr)
+			}
+
+			return err
+		}
+	}
+}
+
+// signalFor derives the security signal of a handled request, if it has one
+func signalFor(c echo.Context, err error) (securityevent.Signal, bool) {
+	req := c.Request()
+	signal := securityevent.Signal{IPAddress: c.RealIP(), Subject: req.URL.Path}
+
+	switch {
+	case c.Get(CSRFFailureContextKey) != nil:
+		signal.Kind = securityevent.KindCSRFFailure
+	case isFailedLogin(c):
+		signal.Kind = securityevent.KindFailedLogin
+	case req.Method == http.MethodPost && IsFormSubmissionRoute(req.URL.Path) &&
+		err == nil && c.Response().Status < http.StatusBadRequest:
+		signal.Kind = securityevent.KindSubmissionBurst
+		signal.Subject = c.Param("id")
+	default:
+		return signal, false
+	}
+
+	return signal, true
+}
+
+// isFailedLogin reports whether the request's signed assertion or API key was rejected.
+// An unset assertion secret is a server misconfiguration, not a client failure.
+func isFailedLogin(c echo.Context) bool {
+	if c.Get(APIKeyFailureContextKey) != nil {
+		return true
+	}
+
+	reason, ok := c.Get(assertion.FailureReasonContextKey).(string)
+
+	return ok && reason != "" && reason != "empty_secret"
+}
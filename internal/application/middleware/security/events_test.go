@@ -0,0 +1,78 @@
+package security_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/goformx/goforms/internal/application/middleware/assertion"
+	"github.com/goformx/goforms/internal/application/middleware/security"
+	"github.com/goformx/goforms/internal/domain/securityevent"
+	mocklogging "github.com/goformx/goforms/test/mocks/logging"
+)
+
+// recordingEvents collects observed signals
+type recordingEvents struct {
+	signals []securityevent.Signal
+}
+
+func (r *recordingEvents) Observe(_ context.Context, signal securityevent.Signal) error {
+	r.signals = append(r.signals, signal)
+
+	return nil
+}
+
+func (r *recordingEvents) List(context.Context, securityevent.Filter, int, int) ([]*securityevent.Event, int64, error) {
+	return nil, 0, nil
+}
+
+func TestDetectEvents(t *testing.T) {
+	events := &recordingEvents{}
+	logger := mocklogging.NewMockLogger(gomock.NewController(t))
+
+	e := echo.New()
+	e.Use(security.DetectEvents(events, logger))
+	e.POST("/forms/:id/submit", func(c echo.Context) error {
+		if c.QueryParam("limited") != "" {
+			return c.NoContent(http.StatusTooManyRequests)
+		}
+
+		return c.NoContent(http.StatusCreated)
+	})
+	e.POST("/dashboard", func(c echo.Context) error {
+		c.Set(security.CSRFFailureContextKey, true)
+
+		return c.NoContent(http.StatusForbidden)
+	})
+	e.GET("/api/forms", func(c echo.Context) error {
+		c.Set(assertion.FailureReasonContextKey, c.QueryParam("reason"))
+
+		return c.NoContent(http.StatusUnauthorized)
+	})
+
+	call := func(method, target string) {
+		req := httptest.NewRequest(method, target, http.NoBody)
+		req.RemoteAddr = "203.0.113.7:4000"
+		e.ServeHTTP(httptest.NewRecorder(), req)
+	}
+
+	call(http.MethodPost, "/forms/form-1/submit")
+	call(http.MethodPost, "/forms/form-1/submit?limited=1")
+	call(http.MethodPost, "/dashboard")
+	call(http.MethodGet, "/api/forms?reason=signature_mismatch")
+	call(http.MethodGet, "/api/forms?reason=empty_secret")
+
+	require.Len(t, events.signals, 3)
+	assert.Equal(t, securityevent.Signal{
+		Kind: securityevent.KindSubmissionBurst, IPAddress: "203.0.113.7", Subject: "form-1",
+	}, events.signals[0])
+	assert.Equal(t, securityevent.KindCSRFFailure, events.signals[1].Kind)
+	assert.Equal(t, securityevent.KindFailedLogin, events.signals[2].Kind)
+	assert.Equal(t, "/api/forms", events.signals[2].Subject)
+}
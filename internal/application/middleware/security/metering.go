@@ -0,0 +1,101 @@
+package security
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+
+	"github.com/goformx/goforms/internal/application/middleware/assertion"
+	"github.com/goformx/goforms/internal/domain/metering"
+	appconfig "github.com/goformx/goforms/internal/infrastructure/config"
+	"github.com/goformx/goforms/internal/infrastructure/logging"
+)
+
+// AuthenticatedClient returns the account of a request authenticated by a valid
+// API key ("key:<hash>") or a verified user assertion ("user:<id>")
+func AuthenticatedClient(c echo.Context, config *appconfig.Config) (string, bool) {
+	apiKeyConfig := config.Security.APIKey
+
+	headerName := apiKeyConfig.HeaderName
+	if headerName == "" {
+		headerName = "X-API-Key"
+	}
+
+	apiKeys := &APIKeyAuth{config: config}
+	if apiKey := apiKeys.extractAPIKey(c, headerName, apiKeyConfig.QueryParam); apiKey != "" &&
+		apiKeys.validateAPIKey(apiKey, apiKeyConfig.Keys) {
+		// Hash the key so raw API keys are not retained as identifiers
+		sum := sha256.Sum256([]byte(apiKey))
+
+		return "key:" + hex.EncodeToString(sum[:8]), true
+	}
+
+	if userID, ok := assertion.VerifiedUserID(c.Request().Header, config.Security.Assertion); ok {
+		return metering.UserAccount(userID), true
+	}
+
+	return "", false
+}
+
+// clientAccountContextKey holds the account RequireClient authenticated
+const clientAccountContextKey = "client_account"
+
+// RequireClient returns middleware that refuses requests without a valid API key
+// or verified user assertion, and keeps the account for ClientAccount
+func RequireClient(config *appconfig.Config) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			account, ok := AuthenticatedClient(c, config)
+			if !ok {
+				return echo.NewHTTPError(http.StatusUnauthorized, "Authentication required")
+			}
+
+			c.Set(clientAccountContextKey, account)
+
+			return next(c)
+		}
+	}
+}
+
+// ClientAccount returns the account RequireClient authenticated the request as
+func ClientAccount(c echo.Context) (string, bool) {
+	account, ok := c.Get(clientAccountContextKey).(string)
+
+	return account, ok && account != ""
+}
+
+// MeterRequests returns middleware that counts each authenticated API request
+// against its account's api_calls usage once the request has been handled
+func MeterRequests(
+	meter metering.Service,
+	config *appconfig.Config,
+	pathChecker PathChecker,
+	logger logging.Logger,
+) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			err := next(c)
+
+			if !pathChecker.IsAPIPath(c.Request().URL.Path) {
+				return err
+			}
+
+			account, ok := AuthenticatedClient(c, config)
+			if !ok {
+				return err
+			}
+
+			// The request may have been cancelled; the call must still be counted
+			if recordErr := meter.Record(
+				context.WithoutCancel(c.Request().Context()), account, metering.MetricAPICalls, 1,
+			); recordErr != nil {
+				logger.Warn("failed to record API usage", "account", account, "error", recordErr)
+			}
+
+			return err
+		}
+	}
+}
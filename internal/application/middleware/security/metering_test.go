@@ -0,0 +1,85 @@
+package security_test
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/goformx/goforms/internal/application/middleware"
+	"github.com/goformx/goforms/internal/application/middleware/security"
+	"github.com/goformx/goforms/internal/domain/metering"
+	appconfig "github.com/goformx/goforms/internal/infrastructure/config"
+	"github.com/goformx/goforms/internal/infrastructure/counter"
+	memorystore "github.com/goformx/goforms/internal/infrastructure/repository/memory"
+	mocklogging "github.com/goformx/goforms/test/mocks/logging"
+)
+
+func TestMeterRequests(t *testing.T) {
+	logger := mocklogging.NewMockLogger(gomock.NewController(t))
+
+	cfg := &appconfig.Config{
+		Security: appconfig.SecurityConfig{
+			APIKey: appconfig.APIKeyConfig{Keys: []string{"key-one"}},
+			Assertion: appconfig.AssertionConfig{
+				Secret:               "test-secret",
+				TimestampSkewSeconds: 60,
+			},
+		},
+	}
+
+	meter := metering.NewService(memorystore.NewStore(logger).Metering(), counter.NewMemoryCounter(), nil, nil,
+		metering.Config{}, logger)
+
+	e := echo.New()
+	ok := func(c echo.Context) error {
+		return c.NoContent(http.StatusOK)
+	}
+
+	e.Use(security.MeterRequests(meter, cfg, middleware.NewPathChecker(), logger))
+	e.GET("/api/forms", ok)
+	e.GET("/forms/:id/schema", ok)
+
+	doRequest(e, map[string]string{"X-API-Key": "key-one"})
+	doRequest(e, map[string]string{"X-API-Key": "key-one"})
+	doRequest(e, assertionHeaders("user-1"))
+
+	// Anonymous calls, invalid keys and page loads are not metered
+	doRequest(e, nil)
+	doRequest(e, map[string]string{"X-API-Key": "wrong"})
+	doPathRequest(e, http.MethodGet, "/forms/form-1/schema", assertionHeaders("user-1"))
+
+	today := time.Now().UTC()
+
+	used := func(account string) int64 {
+		report, err := meter.Usage(t.Context(), account, today, today)
+		require.NoError(t, err)
+
+		return report.Totals[metering.MetricAPICalls]
+	}
+
+	assert.Equal(t, int64(1), used(metering.UserAccount("user-1")))
+	assert.Equal(t, int64(0), used("ip:203.0.113.7"))
+
+	req := doRequestContext(t, map[string]string{"X-API-Key": "key-one"})
+	account, authenticated := security.AuthenticatedClient(e.NewContext(req, nil), cfg)
+	require.True(t, authenticated)
+	assert.Equal(t, int64(2), used(account))
+}
+
+func doRequestContext(t *testing.T, headers map[string]string) *http.Request {
+	t.Helper()
+
+	req, err := http.NewRequestWithContext(t.Context(), http.MethodGet, "/api/forms", http.NoBody)
+	require.NoError(t, err)
+
+	for name, value := range headers {
+		req.Header.Set(name, value)
+	}
+
+	return req
+}
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"net/http"
 	"strings"
+	"sync"
 
 	"github.com/labstack/echo/v4"
 	echomw "github.com/labstack/echo/v4/middleware"
@@ -12,6 +13,7 @@ import (
 
 	appconfig "github.com/goformx/goforms/internal/infrastructure/config"
 	"github.com/goformx/goforms/internal/infrastructure/logging"
+	"github.com/goformx/goforms/internal/infrastructure/metrics"
 )
 
 const (
@@ -19,6 +21,9 @@ const (
 	RateLimitExceededMsg = "Rate limit exceeded: too many requests from the same form or origin"
 	// RateLimitDeniedMsg is returned when a request is denied
 	RateLimitDeniedMsg = "Rate limit exceeded: please try again later"
+
+	// floodLimitName identifies the token bucket limiter in logs and metrics
+	floodLimitName = "flood"
 )
 
 // RateLimiter handles rate limiting middleware setup
@@ -26,12 +31,19 @@ type RateLimiter struct {
 	logger      logging.Logger
 	config      *appconfig.Config
 	pathChecker PathChecker
+	tiers       *TierLimiter
+	metrics     *metrics.RateLimitMetrics
+
+	mu sync.RWMutex
+	// routes are the configured route limits followed by those handlers declare
+	routes []RouteLimit
 }
 
 // PathChecker interface for checking path types
 type PathChecker interface {
 	IsAuthPath(path string) bool
 	IsFormPath(path string) bool
+	IsAPIPath(path string) bool
 }
 
 // NewRateLimiter creates a new rate limiter
@@ -40,9 +52,55 @@ func NewRateLimiter(logger logging.Logger, config *appconfig.Config, pathChecker
 		logger:      logger,
 		config:      config,
 		pathChecker: pathChecker,
+		tiers:       NewTierLimiter(),
+		metrics:     metrics.NewRateLimitMetrics(),
+		routes:      configuredRouteLimits(config.Security.RateLimit.Routes),
 	}
 }
 
+// Metrics returns the counts of requests over a limit
+func (rl *RateLimiter) Metrics() *metrics.RateLimitMetrics {
+	return rl.metrics
+}
+
+// SetRouteLimits sets the route limits handlers declare. Configured limits for
+// the same routes take precedence.
+func (rl *RateLimiter) SetRouteLimits(limits []RouteLimit) {
+	configured := configuredRouteLimits(rl.config.Security.RateLimit.Routes)
+
+	rl.mu.Lock()
+	defer rl.mu.Unlock()
+
+	rl.routes = append(configured, limits...)
+}
+
+// routeLimit returns the route limit that applies to a request
+func (rl *RateLimiter) routeLimit(c echo.Context) (RouteLimit, bool) {
+	route := c.Path()
+	if route == "" {
+		route = c.Request().URL.Path
+	}
+
+	return rl.RouteLimitFor(c.Request().Method, route)
+}
+
+// RouteLimitFor returns the route limit that applies to a route pattern
+func (rl *RateLimiter) RouteLimitFor(method, route string) (RouteLimit, bool) {
+	rl.mu.RLock()
+	defer rl.mu.RUnlock()
+
+	return findRouteLimit(rl.routes, method, route)
+}
+
+// warnOnly reports whether requests over a limit in mode are let through
+func (rl *RateLimiter) warnOnly(mode string) bool {
+	if mode == "" {
+		mode = rl.config.Security.RateLimit.Mode
+	}
+
+	return mode == appconfig.RateLimitModeWarn
+}
+
 // Setup creates and configures rate limiting middleware
 func (rl *RateLimiter) Setup() echo.MiddlewareFunc {
 	rateLimitConfig := rl.config.Security.RateLimit
@@ -64,9 +122,129 @@ func (rl *RateLimiter) Setup() echo.MiddlewareFunc {
 		"window", rateLimitConfig.Window,
 		"skip_paths", rateLimitConfig.SkipPaths,
 		"skip_methods", rateLimitConfig.SkipMethods,
+		"authenticated_tier", rateLimitConfig.Authenticated,
+		"anonymous_tier", rateLimitConfig.Anonymous,
+		"mode", rateLimitConfig.Mode,
+		"route_limits", len(rateLimitConfig.Routes),
 	)
 
-	return echomw.RateLimiterWithConfig(rl.createConfig(rateLimitConfig))
+	// Tier budgets are checked first so every response carries X-RateLimit-* headers;
+	// the token bucket behind them still absorbs short bursts per identifier.
+	tiered := rl.tierMiddleware(rateLimitConfig)
+	flood := echomw.RateLimiterWithConfig(rl.createConfig(rateLimitConfig))
+
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return tiered(flood(next))
+	}
+}
+
+// tierMiddleware limits each client to its tier's budget per window. Tiers
+// cover API calls and form submissions only; page loads and public form
+// schemas are left to the flood limiter so embedded forms keep rendering.
+// A route limit replaces the tier budgets on its routes, whatever their kind.
+func (rl *RateLimiter) tierMiddleware(config appconfig.RateLimitConfig) echo.MiddlewareFunc {
+	skipper := rl.createSkipper(config)
+
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			if skipper(c) {
+				return next(c)
+			}
+
+			route, hasRoute := rl.routeLimit(c)
+			if !hasRoute && !rl.isTiered(c.Request()) {
+				return next(c)
+			}
+
+			identifier, authenticated := rl.identifyClient(c)
+
+			name, tier, mode := TierAnonymous, config.Anonymous, ""
+			if authenticated {
+				name, tier = TierAuthenticated, config.Authenticated
+			}
+
+			if hasRoute {
+				name, tier, mode = route.Name(), route.Limit, route.Mode
+			}
+
+			if tier.Requests <= 0 {
+				return next(c)
+			}
+
+			decision := rl.tiers.Allow(name+":"+identifier, tier)
+
+			warnOnly := !decision.Allowed && rl.warnOnly(mode)
+			if warnOnly {
+				// Monitor-only limits leave the response as if the request were allowed
+				decision.Allowed = true
+			}
+
+			for header, value := range decision.Headers(rl.tiers.now()) {
+				c.Response().Header().Set(header, value)
+			}
+
+			if warnOnly {
+				rl.metrics.Warned(name)
+				rl.logger.Warn("Rate limit exceeded in warn mode",
+					"path", c.Request().URL.Path,
+					"method", c.Request().Method,
+					"limit_name", name,
+					"limit", decision.Limit,
+				)
+
+				return next(c)
+			}
+
+			if !decision.Allowed {
+				rl.metrics.Blocked(name)
+				rl.logger.Warn("Rate limit tier exceeded",
+					"path", c.Request().URL.Path,
+					"method", c.Request().Method,
+					"tier", name,
+					"limit", decision.Limit,
+				)
+
+				return echo.NewHTTPError(http.StatusTooManyRequests, RateLimitDeniedMsg)
+			}
+
+			return next(c)
+		}
+	}
+}
+
+// publicFormReads are the form resources an embedded form loads to render
+var publicFormReads = []string{"/schema", "/validation", "/fields", "/embed"}
+
+// isTiered reports whether a request counts against the tier budgets
+func (rl *RateLimiter) isTiered(req *http.Request) bool {
+	path := req.URL.Path
+
+	if rl.pathChecker.IsFormPath(path) {
+		if req.Method == http.MethodPost && strings.HasSuffix(path, "/submit") {
+			return true
+		}
+
+		// Rendering a public form is a page load, also under /api/v1
+		if req.Method == http.MethodGet {
+			for _, suffix := range publicFormReads {
+				if strings.HasSuffix(path, suffix) {
+					return false
+				}
+			}
+		}
+	}
+
+	return rl.pathChecker.IsAPIPath(path)
+}
+
+// identifyClient keys authenticated requests by API key or verified user and
+// anonymous requests by client IP
+func (rl *RateLimiter) identifyClient(c echo.Context) (identifier string, authenticated bool) {
+	if account, ok := AuthenticatedClient(c, rl.config); ok {
+		return account, true
+	}
+
+	return "ip:" + c.RealIP(), false
 }
 
 func (rl *RateLimiter) validateConfig(config appconfig.RateLimitConfig) error {
@@ -116,13 +294,36 @@ func (rl *RateLimiter) createSkipper(config appconfig.RateLimitConfig) echomw.Sk
 }
 
 func (rl *RateLimiter) createStore(config appconfig.RateLimitConfig) echomw.RateLimiterStore {
-	return echomw.NewRateLimiterMemoryStoreWithConfig(
+	store := echomw.NewRateLimiterMemoryStoreWithConfig(
 		echomw.RateLimiterMemoryStoreConfig{
 			Rate:      rate.Limit(config.Requests),
 			Burst:     config.Burst,
 			ExpiresIn: config.Window,
 		},
 	)
+
+	if rl.warnOnly(config.Mode) {
+		return &warnStore{store: store, limiter: rl}
+	}
+
+	return store
+}
+
+// warnStore lets every request through the flood limiter in warn mode,
+// logging and counting those the store would have denied
+type warnStore struct {
+	store   echomw.RateLimiterStore
+	limiter *RateLimiter
+}
+
+// Allow reports every identifier as allowed
+func (s *warnStore) Allow(identifier string) (bool, error) {
+	if allowed, err := s.store.Allow(identifier); !allowed || err != nil {
+		s.limiter.metrics.Warned(floodLimitName)
+		s.limiter.logger.Warn("Rate limit exceeded in warn mode", "limit_name", floodLimitName, "identifier", identifier)
+	}
+
+	return true, nil
 }
 
 func (rl *RateLimiter) createIdentifierExtractor() echomw.Extractor {
@@ -168,6 +369,7 @@ func (rl *RateLimiter) createErrorHandler() func(c echo.Context, err error) erro
 
 func (rl *RateLimiter) createDenyHandler() func(c echo.Context, identifier string, err error) error {
 	return func(c echo.Context, identifier string, err error) error {
+		rl.metrics.Blocked(floodLimitName)
 		rl.logger.Warn("Rate limit denied",
 			"path", c.Request().URL.Path,
 			"method", c.Request().Method,
@@ -175,6 +377,7 @@ func (rl *RateLimiter) createDenyHandler() func(c echo.Context, identifier strin
 			"identifier", identifier,
 			"error", err,
 		)
+		c.Response().Header().Set(HeaderRetryAfter, "1")
 		return echo.NewHTTPError(http.StatusTooManyRequests, RateLimitDeniedMsg)
 	}
 }
@@ -0,0 +1,83 @@
+package security
+
+import (
+	"strings"
+
+	appconfig "github.com/goformx/goforms/internal/infrastructure/config"
+)
+
+// RouteLimit is a request budget that replaces the tier budgets on the routes
+// at or below Path. With a Method it applies to that one route only.
+type RouteLimit struct {
+	// Path is an Echo route pattern such as /forms/:id/unlock
+	Path   string
+	Method string
+	Limit  appconfig.RateLimitTier
+	// Mode is enforce or warn; empty uses security.rate_limit.mode
+	Mode string
+}
+
+// Name identifies the limit in logs and metrics
+func (l RouteLimit) Name() string {
+	if l.Method != "" {
+		return "route:" + l.Method + " " + l.Path
+	}
+
+	return "route:" + l.Path
+}
+
+// matches reports whether the limit applies to a request for a route pattern.
+// Requests that matched no route are compared by their URL path.
+func (l RouteLimit) matches(method, route string) bool {
+	if l.Method != "" {
+		return strings.EqualFold(l.Method, method) && route == l.Path
+	}
+
+	return route == l.Path || strings.HasPrefix(route, l.Path+"/")
+}
+
+// moreSpecific reports whether l is a closer match than other: a longer path,
+// or the same path for one method
+func (l RouteLimit) moreSpecific(other RouteLimit) bool {
+	if len(l.Path) != len(other.Path) {
+		return len(l.Path) > len(other.Path)
+	}
+
+	return l.Method != "" && other.Method == ""
+}
+
+// configuredRouteLimits converts security.rate_limit.routes
+func configuredRouteLimits(routes []appconfig.RouteRateLimit) []RouteLimit {
+	limits := make([]RouteLimit, 0, len(routes))
+	for _, route := range routes {
+		limits = append(limits, RouteLimit{
+			Path:   route.Path,
+			Method: strings.ToUpper(route.Method),
+			Limit:  appconfig.RateLimitTier{Requests: route.Requests, Window: route.Window},
+			Mode:   route.Mode,
+		})
+	}
+
+	return limits
+}
+
+// findRouteLimit returns the closest limit for a request. On a tie the earlier
+// limit wins, so configured limits override declared ones.
+func findRouteLimit(limits []RouteLimit, method, route string) (RouteLimit, bool) {
+	var (
+		found RouteLimit
+		ok    bool
+	)
+
+	for _, limit := range limits {
+		if !limit.matches(method, route) {
+			continue
+		}
+
+		if !ok || limit.moreSpecific(found) {
+			found, ok = limit, true
+		}
+	}
+
+	return found, ok
+}
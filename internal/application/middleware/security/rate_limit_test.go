@@ -0,0 +1,254 @@
+package security_test
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/goformx/goforms/internal/application/middleware"
+	"github.com/goformx/goforms/internal/application/middleware/security"
+	appconfig "github.com/goformx/goforms/internal/infrastructure/config"
+	mocklogging "github.com/goformx/goforms/test/mocks/logging"
+)
+
+func newTieredEcho(t *testing.T) *echo.Echo {
+	t.Helper()
+
+	e, _ := newRateLimitedEcho(t, nil)
+
+	return e
+}
+
+// newRateLimitedEcho serves a few API and form routes behind a rate limiter,
+// with the config adjusted by configure
+func newRateLimitedEcho(t *testing.T, configure func(*appconfig.Config)) (*echo.Echo, *security.RateLimiter) {
+	t.Helper()
+
+	ctrl := gomock.NewController(t)
+	logger := mocklogging.NewMockLogger(ctrl)
+	logger.EXPECT().Info(gomock.Any(), gomock.Any()).AnyTimes()
+	logger.EXPECT().Warn(gomock.Any(), gomock.Any()).AnyTimes()
+
+	cfg := &appconfig.Config{
+		App: appconfig.AppConfig{Environment: "production"},
+		Security: appconfig.SecurityConfig{
+			RateLimit: appconfig.RateLimitConfig{
+				Enabled:       true,
+				Requests:      1000,
+				Burst:         1000,
+				Window:        time.Minute,
+				Authenticated: appconfig.RateLimitTier{Requests: 3, Window: time.Minute},
+				Anonymous:     appconfig.RateLimitTier{Requests: 1, Window: time.Minute},
+			},
+			APIKey: appconfig.APIKeyConfig{Keys: []string{"key-one", "key-two"}},
+			Assertion: appconfig.AssertionConfig{
+				Secret:               "test-secret",
+				TimestampSkewSeconds: 60,
+			},
+		},
+	}
+
+	if configure != nil {
+		configure(cfg)
+	}
+
+	e := echo.New()
+	ok := func(c echo.Context) error {
+		return c.NoContent(http.StatusOK)
+	}
+
+	limiter := security.NewRateLimiter(logger, cfg, middleware.NewPathChecker())
+
+	e.Use(limiter.Setup())
+	e.GET("/api/forms", ok)
+	e.GET("/forms/:id/schema", ok)
+	e.GET("/forms/:id/embed", ok)
+	e.GET("/api/v1/forms/:id/validation", ok)
+	e.POST("/forms/:id/submit", ok)
+	e.POST("/forms/:id/unlock", ok)
+
+	return e, limiter
+}
+
+func doRequest(e *echo.Echo, headers map[string]string) *httptest.ResponseRecorder {
+	return doPathRequest(e, http.MethodGet, "/api/forms", headers)
+}
+
+func doPathRequest(e *echo.Echo, method, path string, headers map[string]string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, path, http.NoBody)
+	req.RemoteAddr = "203.0.113.7:1234"
+
+	for name, value := range headers {
+		req.Header.Set(name, value)
+	}
+
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+
+	return rec
+}
+
+func assertionHeaders(userID string) map[string]string {
+	timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+	mac := hmac.New(sha256.New, []byte("test-secret"))
+	mac.Write([]byte(userID + ":" + timestamp))
+
+	return map[string]string{
+		"X-User-Id":   userID,
+		"X-Timestamp": timestamp,
+		"X-Signature": hex.EncodeToString(mac.Sum(nil)),
+	}
+}
+
+func TestRateLimiter_AnonymousTier(t *testing.T) {
+	e := newTieredEcho(t)
+
+	rec := doRequest(e, nil)
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "1", rec.Header().Get(security.HeaderRateLimitLimit))
+	assert.Equal(t, "0", rec.Header().Get(security.HeaderRateLimitRemaining))
+	assert.NotEmpty(t, rec.Header().Get(security.HeaderRateLimitReset))
+
+	rec = doRequest(e, nil)
+	require.Equal(t, http.StatusTooManyRequests, rec.Code)
+	retryAfter, err := strconv.Atoi(rec.Header().Get(security.HeaderRetryAfter))
+	require.NoError(t, err)
+	assert.Positive(t, retryAfter)
+
+	// An invalid API key does not grant the authenticated tier
+	rec = doRequest(e, map[string]string{"X-API-Key": "wrong"})
+	assert.Equal(t, http.StatusTooManyRequests, rec.Code)
+}
+
+func TestRateLimiter_AuthenticatedTierPerKey(t *testing.T) {
+	e := newTieredEcho(t)
+
+	for i := range 3 {
+		rec := doRequest(e, map[string]string{"X-API-Key": "key-one"})
+		require.Equal(t, http.StatusOK, rec.Code)
+		assert.Equal(t, "3", rec.Header().Get(security.HeaderRateLimitLimit))
+		assert.Equal(t, strconv.Itoa(2-i), rec.Header().Get(security.HeaderRateLimitRemaining))
+	}
+
+	rec := doRequest(e, map[string]string{"X-API-Key": "key-one"})
+	assert.Equal(t, http.StatusTooManyRequests, rec.Code)
+
+	// Other keys, users and anonymous clients have their own budgets
+	rec = doRequest(e, map[string]string{"X-API-Key": "key-two"})
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	rec = doRequest(e, assertionHeaders("user-1"))
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "2", rec.Header().Get(security.HeaderRateLimitRemaining))
+
+	rec = doRequest(e, nil)
+	assert.Equal(t, http.StatusOK, rec.Code)
+}
+
+func TestRateLimiter_TiersSkipPublicPages(t *testing.T) {
+	e := newTieredEcho(t)
+
+	// Public form pages load well past the anonymous budget
+	for range 5 {
+		for _, path := range []string{"/forms/form-1/schema", "/forms/form-1/embed", "/api/v1/forms/form-1/validation"} {
+			rec := doPathRequest(e, http.MethodGet, path, nil)
+			require.Equal(t, http.StatusOK, rec.Code, path)
+			assert.Empty(t, rec.Header().Get(security.HeaderRateLimitLimit))
+		}
+	}
+
+	// Submissions count against the tier
+	rec := doPathRequest(e, http.MethodPost, "/forms/form-1/submit", nil)
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "1", rec.Header().Get(security.HeaderRateLimitLimit))
+
+	rec = doPathRequest(e, http.MethodPost, "/forms/form-1/submit", nil)
+	assert.Equal(t, http.StatusTooManyRequests, rec.Code)
+}
+
+func TestRateLimiter_WarnMode(t *testing.T) {
+	e, limiter := newRateLimitedEcho(t, func(cfg *appconfig.Config) {
+		cfg.Security.RateLimit.Mode = appconfig.RateLimitModeWarn
+	})
+
+	// Requests over the anonymous budget are let through and counted
+	for range 3 {
+		rec := doRequest(e, nil)
+		require.Equal(t, http.StatusOK, rec.Code)
+		assert.Empty(t, rec.Header().Get(security.HeaderRetryAfter))
+	}
+
+	snapshot := limiter.Metrics().GetMetrics()
+	assert.Equal(t, int64(2), snapshot["warned"])
+	assert.Equal(t, int64(0), snapshot["blocked"])
+	assert.Equal(t, map[string]int64{security.TierAnonymous: 2}, snapshot["warned_by_limit"])
+}
+
+func TestRateLimiter_RouteLimits(t *testing.T) {
+	e, limiter := newRateLimitedEcho(t, func(cfg *appconfig.Config) {
+		cfg.Security.RateLimit.Routes = []appconfig.RouteRateLimit{
+			// Configured limits override the declared one for the same route
+			{Path: "/forms/:id/submit", Method: http.MethodPost, Requests: 3, Window: time.Minute},
+			{Path: "/api/forms", Requests: 1, Window: time.Minute, Mode: appconfig.RateLimitModeWarn},
+		}
+	})
+
+	limiter.SetRouteLimits([]security.RouteLimit{
+		{Path: "/forms/:id/unlock", Method: http.MethodPost, Limit: appconfig.RateLimitTier{Requests: 2, Window: time.Minute}},
+		{Path: "/forms/:id/submit", Method: http.MethodPost, Limit: appconfig.RateLimitTier{Requests: 1, Window: time.Minute}},
+	})
+
+	// A declared limit applies to routes outside the tiers, across forms
+	for _, formID := range []string{"form-1", "form-2"} {
+		rec := doPathRequest(e, http.MethodPost, "/forms/"+formID+"/unlock", nil)
+		require.Equal(t, http.StatusOK, rec.Code)
+		assert.Equal(t, "2", rec.Header().Get(security.HeaderRateLimitLimit))
+	}
+
+	rec := doPathRequest(e, http.MethodPost, "/forms/form-3/unlock", nil)
+	assert.Equal(t, http.StatusTooManyRequests, rec.Code)
+
+	// The configured submit limit replaces the anonymous tier of 1
+	for range 3 {
+		rec = doPathRequest(e, http.MethodPost, "/forms/form-1/submit", nil)
+		require.Equal(t, http.StatusOK, rec.Code)
+		assert.Equal(t, "3", rec.Header().Get(security.HeaderRateLimitLimit))
+	}
+
+	rec = doPathRequest(e, http.MethodPost, "/forms/form-1/submit", nil)
+	assert.Equal(t, http.StatusTooManyRequests, rec.Code)
+
+	// A route limit in warn mode lets requests through under an enforcing limiter
+	for range 2 {
+		rec = doRequest(e, map[string]string{"X-API-Key": "key-one"})
+		require.Equal(t, http.StatusOK, rec.Code)
+	}
+
+	snapshot := limiter.Metrics().GetMetrics()
+	assert.Equal(t, map[string]int64{"route:POST /forms/:id/unlock": 1, "route:POST /forms/:id/submit": 1}, snapshot["blocked_by_limit"])
+	assert.Equal(t, map[string]int64{"route:/api/forms": 1}, snapshot["warned_by_limit"])
+}
+
+func TestTierLimiter_WindowResets(t *testing.T) {
+	limiter := security.NewTierLimiter()
+	tier := appconfig.RateLimitTier{Requests: 1, Window: 20 * time.Millisecond}
+
+	assert.True(t, limiter.Allow("client", tier).Allowed)
+	assert.False(t, limiter.Allow("client", tier).Allowed)
+
+	time.Sleep(30 * time.Millisecond)
+
+	decision := limiter.Allow("client", tier)
+	assert.True(t, decision.Allowed)
+	assert.Equal(t, 0, decision.Remaining)
+}
@@ -0,0 +1,125 @@
+package security
+
+import (
+	"math"
+	"strconv"
+	"sync"
+	"time"
+
+	appconfig "github.com/goformx/goforms/internal/infrastructure/config"
+)
+
+const (
+	// HeaderRateLimitLimit is the number of requests allowed per window
+	HeaderRateLimitLimit = "X-RateLimit-Limit"
+	// HeaderRateLimitRemaining is the number of requests left in the current window
+	HeaderRateLimitRemaining = "X-RateLimit-Remaining"
+	// HeaderRateLimitReset is the Unix time (seconds) at which the current window ends
+	HeaderRateLimitReset = "X-RateLimit-Reset"
+	// HeaderRetryAfter is the number of seconds to wait before retrying a limited request
+	HeaderRetryAfter = "Retry-After"
+
+	// TierAuthenticated applies to requests with a valid API key or user assertion
+	TierAuthenticated = "authenticated"
+	// TierAnonymous applies to all other requests
+	TierAnonymous = "anonymous"
+
+	// tierSweepInterval is how often expired windows are dropped from memory
+	tierSweepInterval = time.Minute
+)
+
+// TierDecision is the outcome of counting one request against a tier
+type TierDecision struct {
+	Allowed   bool
+	Limit     int
+	Remaining int
+	Reset     time.Time
+}
+
+// RetryAfter returns the whole number of seconds until the window resets
+func (d TierDecision) RetryAfter(now time.Time) int {
+	return int(math.Ceil(d.Reset.Sub(now).Seconds()))
+}
+
+// Headers returns the rate limit response headers for the decision
+func (d TierDecision) Headers(now time.Time) map[string]string {
+	headers := map[string]string{
+		HeaderRateLimitLimit:     strconv.Itoa(d.Limit),
+		HeaderRateLimitRemaining: strconv.Itoa(d.Remaining),
+		HeaderRateLimitReset:     strconv.FormatInt(d.Reset.Unix(), 10),
+	}
+
+	if !d.Allowed {
+		headers[HeaderRetryAfter] = strconv.Itoa(max(d.RetryAfter(now), 1))
+	}
+
+	return headers
+}
+
+// tierWindow is a fixed-window request counter for one client
+type tierWindow struct {
+	start  time.Time
+	window time.Duration
+	count  int
+}
+
+// TierLimiter enforces fixed-window request budgets per client identifier
+type TierLimiter struct {
+	mu        sync.Mutex
+	windows   map[string]*tierWindow
+	lastSweep time.Time
+	now       func() time.Time
+}
+
+// NewTierLimiter creates a new in-memory tier limiter
+func NewTierLimiter() *TierLimiter {
+	return &TierLimiter{
+		windows: make(map[string]*tierWindow),
+		now:     time.Now,
+	}
+}
+
+// Allow counts a request for key against tier and reports whether it may proceed
+func (l *TierLimiter) Allow(key string, tier appconfig.RateLimitTier) TierDecision {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := l.now()
+	l.sweep(now)
+
+	w, ok := l.windows[key]
+	if !ok || !now.Before(w.start.Add(w.window)) {
+		w = &tierWindow{start: now, window: tier.Window}
+		l.windows[key] = w
+	}
+
+	decision := TierDecision{
+		Limit: tier.Requests,
+		Reset: w.start.Add(w.window),
+	}
+
+	if w.count >= tier.Requests {
+		return decision
+	}
+
+	w.count++
+	decision.Allowed = true
+	decision.Remaining = tier.Requests - w.count
+
+	return decision
+}
+
+// sweep drops expired windows so idle clients do not accumulate
+func (l *TierLimiter) sweep(now time.Time) {
+	if now.Sub(l.lastSweep) < tierSweepInterval {
+		return
+	}
+
+	l.lastSweep = now
+
+	for key, w := range l.windows {
+		if !now.Before(w.start.Add(w.window)) {
+			delete(l.windows, key)
+		}
+	}
+}
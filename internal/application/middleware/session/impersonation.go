@@ -0,0 +1,77 @@
+package session
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+
+	"github.com/goformx/goforms/internal/application/constants"
+	mwcontext "github.com/goformx/goforms/internal/application/middleware/context"
+	"github.com/goformx/goforms/internal/domain/audit"
+	"github.com/goformx/goforms/internal/infrastructure/logging"
+)
+
+// ImpersonationAudit returns middleware that records every request made in an
+// impersonation session in the audit log, as the admin acting on the user. It
+// must run after the session middleware.
+func ImpersonationAudit(auditService audit.Service, logger logging.Logger) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			impersonatorID, ok := mwcontext.GetImpersonatorID(c)
+			if !ok || !shouldAuditImpersonatedRequest(c.Request()) {
+				return next(c)
+			}
+
+			err := next(c)
+
+			userID, _ := mwcontext.GetUserID(c)
+			entry := &audit.Entry{
+				ActorID:    impersonatorID,
+				Action:     audit.ActionImpersonationRequest,
+				TargetType: audit.TargetUser,
+				TargetID:   userID,
+				Details: map[string]any{
+					"method": c.Request().Method,
+					"path":   c.Request().URL.Path,
+					"status": responseStatus(c, err),
+				},
+				IPAddress: c.RealIP(),
+			}
+
+			// The request may have been cancelled; the record must still be written
+			if recordErr := auditService.Record(context.WithoutCancel(c.Request().Context()), entry); recordErr != nil {
+				logger.Error("failed to record impersonated request", "impersonator_id", impersonatorID, "error", recordErr)
+			}
+
+			return err
+		}
+	}
+}
+
+// shouldAuditImpersonatedRequest skips preflights and the status polling behind the impersonation banner
+func shouldAuditImpersonatedRequest(req *http.Request) bool {
+	switch req.Method {
+	case http.MethodHead, http.MethodOptions:
+		return false
+	case http.MethodGet:
+		return req.URL.Path != constants.PathAPIImpersonation
+	}
+
+	return true
+}
+
+// responseStatus is the status the request was answered with, or will be once err is handled
+func responseStatus(c echo.Context, err error) int {
+	if err == nil || c.Response().Committed {
+		return c.Response().Status
+	}
+
+	var httpErr *echo.HTTPError
+	if errors.As(err, &httpErr) {
+		return httpErr.Code
+	}
+
+	return http.StatusInternalServerError
+}
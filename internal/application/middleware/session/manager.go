@@ -5,6 +5,7 @@ import (
 	"context"
 	"crypto/rand"
 	"encoding/base64"
+	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
@@ -143,6 +144,49 @@ func (sm *Manager) saveSessions() error {
 
 // CreateSession creates a new session for a user
 func (sm *Manager) CreateSession(userID, email, role string) (string, error) {
+	return sm.storeSession(&Session{
+		UserID:    userID,
+		Email:     email,
+		Role:      role,
+		CreatedAt: time.Now(),
+		ExpiresAt: time.Now().Add(sm.expiryTime),
+	})
+}
+
+// CreateImpersonationSession creates a session in which an admin acts as another
+// user. The caller sets the user fields, ImpersonatorID and ImpersonatorSessionID;
+// the session expires after ttl.
+func (sm *Manager) CreateImpersonationSession(session *Session, ttl time.Duration) (string, error) {
+	if !session.IsImpersonation() {
+		return "", errors.New("impersonation session requires an impersonator")
+	}
+
+	session.CreatedAt = time.Now()
+	session.ExpiresAt = session.CreatedAt.Add(ttl)
+
+	return sm.storeSession(session)
+}
+
+// EndImpersonation removes an impersonation session and returns the admin's own
+// session ID when that session is still valid
+func (sm *Manager) EndImpersonation(sessionID string) (string, bool) {
+	session, exists := sm.GetSession(sessionID)
+	if !exists || !session.IsImpersonation() {
+		return "", false
+	}
+
+	sm.DeleteSession(sessionID)
+
+	admin, exists := sm.GetSession(session.ImpersonatorSessionID)
+	if !exists || time.Now().After(admin.ExpiresAt) || admin.UserID != session.ImpersonatorID {
+		return "", false
+	}
+
+	return session.ImpersonatorSessionID, true
+}
+
+// storeSession stores a session under a new random ID
+func (sm *Manager) storeSession(session *Session) (string, error) {
 	// Generate random session ID
 	sessionID := make([]byte, SessionIDLength)
 	if _, err := rand.Read(sessionID); err != nil {
@@ -151,15 +195,6 @@ func (sm *Manager) CreateSession(userID, email, role string) (string, error) {
 
 	sessionIDStr := base64.URLEncoding.EncodeToString(sessionID)
 
-	// Create session
-	session := &Session{
-		UserID:    userID,
-		Email:     email,
-		Role:      role,
-		CreatedAt: time.Now(),
-		ExpiresAt: time.Now().Add(sm.expiryTime),
-	}
-
 	// Store session
 	sm.mutex.Lock()
 	sm.sessions[sessionIDStr] = session
@@ -195,6 +230,32 @@ func (sm *Manager) DeleteSession(sessionID string) {
 	}
 }
 
+// DeleteUserSessions removes every session of a user, signing them out everywhere,
+// along with the impersonation sessions they opened, and returns the number of sessions removed
+func (sm *Manager) DeleteUserSessions(userID string) int {
+	sm.mutex.Lock()
+
+	removed := 0
+
+	for id, session := range sm.sessions {
+		if session.UserID == userID || session.ImpersonatorID == userID {
+			delete(sm.sessions, id)
+
+			removed++
+		}
+	}
+
+	sm.mutex.Unlock()
+
+	if removed > 0 {
+		if err := sm.saveSessions(); err != nil {
+			sm.logger.Error("failed to save sessions", "error", err)
+		}
+	}
+
+	return removed
+}
+
 // GetCookieName returns the name of the session cookie
 func (sm *Manager) GetCookieName() string {
 	return sm.cookieName
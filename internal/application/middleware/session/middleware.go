@@ -79,7 +79,12 @@ func (sm *Manager) processSession(c echo.Context, path string, next echo.Handler
 
 	// Check if session is expired
 	if time.Now().After(session.ExpiresAt) {
-		sm.DeleteSession(cookie.Value)
+		if adminSessionID, restored := sm.EndImpersonation(cookie.Value); restored {
+			// The admin is back in their own session from the next request
+			sm.SetSessionCookie(c, adminSessionID)
+		} else {
+			sm.DeleteSession(cookie.Value)
+		}
 		// For public paths, continue without authentication
 		if sm.isPublicPath(path) {
 			return next(c)
@@ -94,6 +99,12 @@ func (sm *Manager) processSession(c echo.Context, path string, next echo.Handler
 	context.SetEmail(c, session.Email)
 	context.SetRole(c, session.Role)
 
+	if session.IsImpersonation() {
+		context.SetImpersonatorID(c, session.ImpersonatorID)
+		c.Response().Header().Set(constants.HeaderXImpersonatedBy, session.ImpersonatorID)
+		c.Response().Header().Set(constants.HeaderXImpersonationExpires, session.ExpiresAt.UTC().Format(time.RFC3339))
+	}
+
 	return next(c)
 }
 
@@ -30,6 +30,15 @@ type Session struct {
 	Role      string    `json:"role"`
 	CreatedAt time.Time `json:"created_at"`
 	ExpiresAt time.Time `json:"expires_at"`
+	// ImpersonatorID is the admin acting as UserID; empty for an ordinary session
+	ImpersonatorID string `json:"impersonator_id,omitempty"`
+	// ImpersonatorSessionID is the admin's own session, restored when impersonation ends
+	ImpersonatorSessionID string `json:"impersonator_session_id,omitempty"`
+}
+
+// IsImpersonation reports whether the session was opened by an admin acting as another user
+func (s *Session) IsImpersonation() bool {
+	return s.ImpersonatorID != ""
 }
 
 // Storage defines the interface for session storage operations
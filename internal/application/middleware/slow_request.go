@@ -2,6 +2,7 @@
 package middleware
 
 import (
+	"strings"
 	"time"
 
 	"github.com/labstack/echo/v4"
@@ -120,6 +121,11 @@ func NewSlowRequestSkipper() func(c echo.Context) bool {
 			return true
 		}
 
+		// Skip long-lived event streams
+		if strings.HasSuffix(path, "/stream") {
+			return true
+		}
+
 		return false
 	}
 }
@@ -20,6 +20,7 @@ import (
 	"github.com/goformx/goforms/internal/domain/form"
 	"github.com/goformx/goforms/internal/domain/user"
 	"github.com/goformx/goforms/internal/infrastructure/config"
+	"github.com/goformx/goforms/internal/infrastructure/crashreport"
 	"github.com/goformx/goforms/internal/infrastructure/logging"
 	"github.com/goformx/goforms/internal/infrastructure/sanitization"
 	"github.com/goformx/goforms/internal/infrastructure/server"
@@ -113,9 +114,13 @@ func provideErrorHandler(
 	return response.NewErrorHandler(logger, sanitizer)
 }
 
-// provideRecoveryMiddleware creates a new recovery middleware with sanitization service
-func provideRecoveryMiddleware(logger logging.Logger, sanitizer sanitization.ServiceInterface) echo.MiddlewareFunc {
-	return middleware.Recovery(logger, sanitizer)
+// provideRecoveryMiddleware creates a new recovery middleware with sanitization service and crash reports
+func provideRecoveryMiddleware(
+	logger logging.Logger,
+	sanitizer sanitization.ServiceInterface,
+	reporter *crashreport.Reporter,
+) echo.MiddlewareFunc {
+	return middleware.Recovery(logger, sanitizer, reporter)
 }
 
 // New creates a new application instance
@@ -0,0 +1,196 @@
+package render
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/labstack/echo/v4"
+
+	"github.com/goformx/goforms/internal/domain/common/events"
+	formevents "github.com/goformx/goforms/internal/domain/form/events"
+	"github.com/goformx/goforms/internal/domain/form/model"
+	"github.com/goformx/goforms/internal/infrastructure/config"
+	"github.com/goformx/goforms/internal/infrastructure/metrics"
+)
+
+// Key identifies one rendering of a page
+type Key struct {
+	// Page names the page, e.g. "embed"
+	Page   string
+	FormID string
+	// Version is the form version the page was rendered from
+	Version int
+	Locale  string
+	// Theme is the theme the page was rendered in
+	Theme string
+}
+
+// page is a cached rendering, kept as the parts sent between flush points
+type page struct {
+	parts   [][]byte
+	expires time.Time
+}
+
+// Cache keeps rendered pages. Pages are keyed by form version, so a form
+// changed on another instance is rendered afresh once it is loaded; the TTL
+// and the form events only bound how long old renderings take up memory.
+type Cache struct {
+	config    config.PageCacheConfig
+	eventBus  events.EventBus
+	metrics   *metrics.CacheMetrics
+	mu        sync.RWMutex
+	entries   map[Key]page
+	startOnce sync.Once
+}
+
+// NewCache creates a page cache. eventBus may be nil.
+func NewCache(cfg config.PageCacheConfig, eventBus events.EventBus) *Cache {
+	return &Cache{
+		config:   cfg,
+		eventBus: eventBus,
+		metrics:  metrics.NewCacheMetrics(),
+		entries:  make(map[Key]page),
+	}
+}
+
+// Metrics returns the hit, miss and invalidation counters of the cache
+func (c *Cache) Metrics() *metrics.CacheMetrics {
+	return c.metrics
+}
+
+// Start subscribes the cache to form change events on the event bus
+func (c *Cache) Start(ctx context.Context) error {
+	if c == nil {
+		return nil
+	}
+
+	var err error
+
+	c.startOnce.Do(func() {
+		if c.eventBus == nil || !c.config.Enabled {
+			return
+		}
+
+		if err = c.eventBus.Subscribe(ctx, string(formevents.FormUpdatedEventType), c.handleEvent); err != nil {
+			return
+		}
+
+		err = c.eventBus.Subscribe(ctx, string(formevents.FormDeletedEventType), c.handleEvent)
+	})
+
+	if err != nil {
+		return fmt.Errorf("subscribe to form events: %w", err)
+	}
+
+	return nil
+}
+
+// Serve writes the page of key, calling render to render it on a miss. A nil
+// or disabled cache renders every time, for pages that must not be shared.
+func (c *Cache) Serve(ctx echo.Context, key Key, render func(*Stream) error) error {
+	if parts, ok := c.get(key); ok {
+		writeHeaders(ctx, key.Locale)
+		ctx.Response().WriteHeader(http.StatusOK)
+
+		if _, err := ctx.Response().Write(bytes.Join(parts, nil)); err != nil {
+			return fmt.Errorf("write cached page: %w", err)
+		}
+
+		return nil
+	}
+
+	stream := newStream(ctx, key.Locale)
+
+	if err := render(stream); err != nil {
+		if c != nil {
+			c.metrics.Error()
+		}
+
+		return fmt.Errorf("render %s page: %w", key.Page, err)
+	}
+
+	if err := stream.Flush(); err != nil {
+		return err
+	}
+
+	c.put(key, stream.parts)
+
+	return nil
+}
+
+// InvalidateForm drops the pages of a form
+func (c *Cache) InvalidateForm(formID string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for key := range c.entries {
+		if key.FormID == formID {
+			delete(c.entries, key)
+			c.metrics.Invalidation()
+		}
+	}
+}
+
+// get returns the cached parts of key, counting the lookup
+func (c *Cache) get(key Key) ([][]byte, bool) {
+	if c == nil || !c.config.Enabled {
+		return nil, false
+	}
+
+	c.mu.RLock()
+	entry, found := c.entries[key]
+	c.mu.RUnlock()
+
+	if !found || time.Now().After(entry.expires) {
+		c.metrics.Miss()
+
+		return nil, false
+	}
+
+	c.metrics.Hit()
+
+	return entry.parts, true
+}
+
+// put caches a page, sweeping expired pages when the cache is full
+func (c *Cache) put(key Key, parts [][]byte) {
+	if c == nil || !c.config.Enabled {
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if len(c.entries) >= c.config.MaxEntries {
+		now := time.Now()
+		for cached, entry := range c.entries {
+			if now.After(entry.expires) {
+				delete(c.entries, cached)
+			}
+		}
+
+		if len(c.entries) >= c.config.MaxEntries {
+			clear(c.entries)
+		}
+	}
+
+	c.entries[key] = page{parts: parts, expires: time.Now().Add(c.config.TTL)}
+}
+
+// handleEvent invalidates the form named by a form.updated or form.deleted event
+func (c *Cache) handleEvent(_ context.Context, event events.Event) error {
+	switch payload := event.Payload().(type) {
+	case *model.Form:
+		if payload != nil {
+			c.InvalidateForm(payload.ID)
+		}
+	case string:
+		c.InvalidateForm(payload)
+	}
+
+	return nil
+}
@@ -0,0 +1,119 @@
+// Package render serves the public HTML pages, such as the hosted form embed
+// page. A page is written to a Stream, which sends each part to the client as
+// soon as the page reaches a flush point, so the browser can fetch styles and
+// scripts while the rest is rendered. Cache keeps fully rendered pages keyed by
+// page, form version and locale; a form.updated or form.deleted event drops the
+// pages of the form.
+package render
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"net/http"
+	"regexp"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+)
+
+// LocaleParam is the query parameter that overrides the Accept-Language locale
+const LocaleParam = "locale"
+
+// localePattern matches the language tags used as cache keys, e.g. "en" or "pt-BR"
+var localePattern = regexp.MustCompile(`^[a-z]{2,3}(-[A-Za-z0-9]{2,8})?$`)
+
+// Stream writes a page to the response in parts. Writes are held until Flush,
+// so a page that fails before its first flush point can still answer with an
+// error.
+type Stream struct {
+	ctx     echo.Context
+	locale  string
+	pending bytes.Buffer
+	parts   [][]byte
+}
+
+// newStream creates a stream writing the page for locale to the response of c
+func newStream(c echo.Context, locale string) *Stream {
+	return &Stream{ctx: c, locale: locale}
+}
+
+// Write implements io.Writer
+func (s *Stream) Write(p []byte) (int, error) {
+	return s.pending.Write(p)
+}
+
+// WriteString implements io.StringWriter
+func (s *Stream) WriteString(text string) (int, error) {
+	return s.pending.WriteString(text)
+}
+
+// Flush is a flush point: it sends what was written since the last one
+func (s *Stream) Flush() error {
+	if s.pending.Len() == 0 {
+		return nil
+	}
+
+	if !s.Started() {
+		writeHeaders(s.ctx, s.locale)
+	}
+
+	part := bytes.Clone(s.pending.Bytes())
+	s.pending.Reset()
+	s.parts = append(s.parts, part)
+
+	response := s.ctx.Response()
+	if _, err := response.Write(part); err != nil {
+		return fmt.Errorf("write page: %w", err)
+	}
+
+	// Writers that cannot flush get the page when the handler returns
+	if err := http.NewResponseController(response.Writer).Flush(); err != nil && !errors.Is(err, http.ErrNotSupported) {
+		return fmt.Errorf("flush page: %w", err)
+	}
+
+	return nil
+}
+
+// Started reports whether part of the page has been sent
+func (s *Stream) Started() bool {
+	return len(s.parts) > 0
+}
+
+// Locale returns the locale a page is rendered for: the locale query
+// parameter, else the first Accept-Language tag. Tags that are not plain
+// language tags give "", the default locale.
+func Locale(c echo.Context) string {
+	locale := c.QueryParam(LocaleParam)
+	if locale == "" {
+		locale, _, _ = strings.Cut(c.Request().Header.Get("Accept-Language"), ",")
+		locale, _, _ = strings.Cut(locale, ";")
+	}
+
+	locale = strings.TrimSpace(locale)
+
+	language, region, found := strings.Cut(locale, "-")
+	locale = strings.ToLower(language)
+
+	if found {
+		locale += "-" + region
+	}
+
+	if !localePattern.MatchString(locale) {
+		return ""
+	}
+
+	return locale
+}
+
+// writeHeaders sets the headers of a rendered page
+func writeHeaders(c echo.Context, locale string) {
+	header := c.Response().Header()
+	header.Set(echo.HeaderContentType, echo.MIMETextHTMLCharsetUTF8)
+
+	if locale != "" {
+		header.Set("Content-Language", locale)
+	}
+
+	header.Add(echo.HeaderVary, "Accept-Language")
+}
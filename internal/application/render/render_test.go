@@ -0,0 +1,141 @@
+package render_test
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/goformx/goforms/internal/application/render"
+	formevents "github.com/goformx/goforms/internal/domain/form/events"
+	"github.com/goformx/goforms/internal/domain/form/model"
+	"github.com/goformx/goforms/internal/infrastructure/config"
+	"github.com/goformx/goforms/internal/infrastructure/event"
+	mocklogging "github.com/goformx/goforms/test/mocks/logging"
+)
+
+var pageConfig = config.PageCacheConfig{Enabled: true, TTL: time.Minute, MaxEntries: 10}
+
+// serve serves key through cache, counting the renderings in renders
+func serve(t *testing.T, cache *render.Cache, key render.Key, renders *int) *httptest.ResponseRecorder {
+	t.Helper()
+
+	rec := httptest.NewRecorder()
+	c := echo.New().NewContext(httptest.NewRequest(http.MethodGet, "/forms/form-1/embed", http.NoBody), rec)
+
+	require.NoError(t, cache.Serve(c, key, func(page *render.Stream) error {
+		*renders++
+
+		_, _ = page.WriteString("<head></head>")
+		if err := page.Flush(); err != nil {
+			return err
+		}
+
+		_, err := page.WriteString("<body>v" + strconv.Itoa(key.Version) + "</body>")
+
+		return err
+	}))
+
+	return rec
+}
+
+func TestCacheServesRenderedPagesPerVersionAndLocale(t *testing.T) {
+	cache := render.NewCache(pageConfig, nil)
+	key := render.Key{Page: "embed", FormID: "form-1", Version: 1, Locale: "de"}
+	renders := 0
+
+	first := serve(t, cache, key, &renders)
+	second := serve(t, cache, key, &renders)
+
+	assert.Equal(t, 1, renders)
+	assert.Equal(t, "<head></head><body>v1</body>", first.Body.String())
+	assert.Equal(t, first.Body.String(), second.Body.String())
+	assert.Equal(t, echo.MIMETextHTMLCharsetUTF8, second.Header().Get(echo.HeaderContentType))
+	assert.Equal(t, "de", second.Header().Get("Content-Language"))
+
+	key.Version = 2
+	assert.Equal(t, "<head></head><body>v2</body>", serve(t, cache, key, &renders).Body.String())
+
+	key.Locale = "fr"
+	serve(t, cache, key, &renders)
+	assert.Equal(t, 3, renders)
+
+	metrics := cache.Metrics().GetMetrics()
+	assert.Equal(t, int64(1), metrics["hits"])
+	assert.Equal(t, int64(3), metrics["misses"])
+}
+
+func TestCacheDropsPagesOnFormEvents(t *testing.T) {
+	logger := mocklogging.NewMockLogger(gomock.NewController(t))
+	logger.EXPECT().Debug(gomock.Any(), gomock.Any()).AnyTimes()
+
+	bus := event.NewMemoryEventBus(logger)
+	cache := render.NewCache(pageConfig, bus)
+	require.NoError(t, cache.Start(t.Context()))
+
+	key := render.Key{Page: "embed", FormID: "form-1", Version: 1}
+	renders := 0
+
+	serve(t, cache, key, &renders)
+	require.NoError(t, bus.Publish(t.Context(), formevents.NewFormUpdatedEvent(&model.Form{ID: "form-1"})))
+	serve(t, cache, key, &renders)
+
+	assert.Equal(t, 2, renders)
+	assert.Equal(t, int64(1), cache.Metrics().GetMetrics()["invalidations"])
+}
+
+func TestNilCacheRendersEveryTime(t *testing.T) {
+	var cache *render.Cache
+
+	key := render.Key{Page: "embed", FormID: "form-1", Version: 1}
+	renders := 0
+
+	serve(t, cache, key, &renders)
+	serve(t, cache, key, &renders)
+
+	assert.Equal(t, 2, renders)
+}
+
+func TestFailedRenderBeforeFlushWritesNothing(t *testing.T) {
+	cache := render.NewCache(pageConfig, nil)
+	rec := httptest.NewRecorder()
+	c := echo.New().NewContext(httptest.NewRequest(http.MethodGet, "/", http.NoBody), rec)
+
+	err := cache.Serve(c, render.Key{Page: "embed"}, func(page *render.Stream) error {
+		_, _ = page.WriteString("<head>")
+
+		return errors.New("boom")
+	})
+
+	require.Error(t, err)
+	assert.False(t, c.Response().Committed)
+	assert.Empty(t, rec.Header().Get(echo.HeaderContentType))
+	assert.Equal(t, int64(1), cache.Metrics().GetMetrics()["errors"])
+}
+
+func TestLocale(t *testing.T) {
+	tests := []struct {
+		query, acceptLanguage, want string
+	}{
+		{"", "", ""},
+		{"", "pt-BR,pt;q=0.9,en;q=0.8", "pt-BR"},
+		{"", "DE", "de"},
+		{"fr", "de", "fr"},
+		{"", "*", ""},
+		{"<script>", "", ""},
+	}
+
+	for _, tt := range tests {
+		req := httptest.NewRequest(http.MethodGet, "/?locale="+tt.query, http.NoBody)
+		req.Header.Set("Accept-Language", tt.acceptLanguage)
+
+		assert.Equal(t, tt.want, render.Locale(echo.New().NewContext(req, httptest.NewRecorder())), tt)
+	}
+}
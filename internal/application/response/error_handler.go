@@ -86,6 +86,7 @@ func (h *ErrorHandler) getStatusCode(code domainerrors.ErrorCode) int {
 		// Authorization errors
 		domainerrors.ErrCodeForbidden:        http.StatusForbidden,
 		domainerrors.ErrCodeInsufficientRole: http.StatusForbidden,
+		domainerrors.ErrCodeQuotaExceeded:    http.StatusForbidden,
 
 		// Resource errors
 		domainerrors.ErrCodeNotFound:     http.StatusNotFound,
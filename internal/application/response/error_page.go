@@ -0,0 +1,176 @@
+package response
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"html/template"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/labstack/echo/v4"
+
+	mwcontext "github.com/goformx/goforms/internal/application/middleware/context"
+	"github.com/goformx/goforms/internal/application/theme"
+	"github.com/goformx/goforms/internal/infrastructure/logging"
+)
+
+// ErrorDetails identifies a failed request, so a report from a user or an API
+// client can be matched with the server logs
+type ErrorDetails struct {
+	Status    int       `json:"status"`
+	RequestID string    `json:"request_id,omitempty"`
+	Timestamp time.Time `json:"timestamp"`
+}
+
+// serverErrorMessage is shown for 5xx errors instead of the underlying error
+const serverErrorMessage = "Something went wrong on our side. Please try again later."
+
+// errorPageTemplate renders the error page browsers get; it follows the
+// visitor's light or dark color scheme
+var errorPageTemplate = template.Must(template.New("error").Parse(`<!DOCTYPE html>
+<html lang="en"{{.ThemeAttr}}>
+<head>
+  <meta charset="utf-8">
+  <meta name="viewport" content="width=device-width, initial-scale=1">
+  <meta name="robots" content="noindex">
+  <title>{{.Status}} {{.Title}}</title>
+  <style>
+    {{.Stylesheet}}
+    body { margin: 0; min-height: 100vh; display: flex; align-items: center; justify-content: center;
+      background: var(--gf-bg); color: var(--gf-text); font: 16px/1.5 system-ui, -apple-system, "Segoe UI", sans-serif; }
+    main { max-width: 32rem; margin: 1.5rem; padding: 2rem; background: var(--gf-surface);
+      border: 1px solid var(--gf-border); border-radius: 0.75rem; }
+    h1 { margin: 0 0 0.5rem; font-size: 1.5rem; }
+    p { margin: 0 0 1rem; }
+    dl { margin: 1.5rem 0 0; padding-top: 1rem; border-top: 1px solid var(--gf-border);
+      display: grid; grid-template-columns: auto 1fr; gap: 0.25rem 1rem; font-size: 0.875rem; color: var(--gf-muted); }
+    .hint { margin: 1rem 0 0; font-size: 0.875rem; color: var(--gf-muted); }
+    dd { margin: 0; font-family: ui-monospace, SFMono-Regular, Menlo, monospace; word-break: break-all; }
+  </style>
+</head>
+<body>
+  <main>
+    <h1>{{.Status}} {{.Title}}</h1>
+    <p>{{.Message}}</p>
+    <dl>
+      {{- if .RequestID}}
+      <dt>Reference</dt><dd>{{.RequestID}}</dd>
+      {{- end}}
+      <dt>Time</dt><dd>{{.Timestamp}}</dd>
+    </dl>
+    {{- if .RequestID}}
+    <p class="hint">Include the reference when you contact support.</p>
+    {{- end}}
+  </main>
+</body>
+</html>
+`))
+
+// ErrorPage answers a failed request with its request ID and time: a themed
+// HTML page for browsers and a JSON error with the same details for API
+// clients. 5xx responses never show the underlying message.
+func ErrorPage(c echo.Context, status int, message string) error {
+	details := ErrorDetails{Status: status, RequestID: requestID(c), Timestamp: time.Now().UTC()}
+
+	if status >= http.StatusInternalServerError || message == "" {
+		message = defaultErrorMessage(status)
+	}
+
+	if c.Request().Method == http.MethodHead {
+		return c.NoContent(status)
+	}
+
+	if !wantsHTML(c) {
+		return c.JSON(status, APIResponse{Success: false, Message: message, Data: details})
+	}
+
+	var page bytes.Buffer
+	if err := errorPageTemplate.Execute(&page, map[string]any{
+		"Status":     status,
+		"Title":      http.StatusText(status),
+		"Message":    message,
+		"RequestID":  details.RequestID,
+		"Timestamp":  details.Timestamp.Format(time.RFC3339),
+		"Stylesheet": template.CSS(theme.Stylesheet),                      //nolint:gosec // constant stylesheet
+		"ThemeAttr":  template.HTMLAttr(theme.Attr(theme.FromRequest(c))), //nolint:gosec // attribute of a known theme name
+	}); err != nil {
+		return fmt.Errorf("render error page: %w", err)
+	}
+
+	c.Response().Header().Set(echo.HeaderCacheControl, "no-store")
+
+	return c.HTMLBlob(status, page.Bytes())
+}
+
+// NewHTTPErrorHandler returns Echo's error handler: errors returned by handlers
+// and middleware are answered with ErrorPage. Server errors are logged with
+// the request ID shown to the client.
+func NewHTTPErrorHandler(logger logging.Logger) echo.HTTPErrorHandler {
+	return func(err error, c echo.Context) {
+		if c.Response().Committed {
+			return
+		}
+
+		// Work cancelled because the client went away has no one to answer
+		if mwcontext.ClientGone(c) {
+			logger.Info("client disconnected before the response was written",
+				"request_id", requestID(c), "method", c.Request().Method)
+
+			return
+		}
+
+		status, message := http.StatusInternalServerError, ""
+
+		var httpErr *echo.HTTPError
+		if errors.As(err, &httpErr) {
+			status = httpErr.Code
+			if text, ok := httpErr.Message.(string); ok {
+				message = text
+			}
+		}
+
+		if status >= http.StatusInternalServerError {
+			logger.Error("request failed with server error",
+				"error", err, "status", status, "request_id", requestID(c), "method", c.Request().Method)
+		}
+
+		if pageErr := ErrorPage(c, status, message); pageErr != nil {
+			logger.Error("failed to send error response", "error", pageErr, "original_error", err)
+		}
+	}
+}
+
+// defaultErrorMessage is the message for a status without one of its own
+func defaultErrorMessage(status int) string {
+	if status >= http.StatusInternalServerError {
+		return serverErrorMessage
+	}
+
+	if text := http.StatusText(status); text != "" {
+		return text
+	}
+
+	return "Request failed"
+}
+
+// requestID returns the ID the context middleware gave the request
+func requestID(c echo.Context) string {
+	if id := c.Response().Header().Get(mwcontext.RequestIDHeader); id != "" {
+		return id
+	}
+
+	return c.Request().Header.Get(mwcontext.RequestIDHeader)
+}
+
+// wantsHTML reports whether the request comes from a browser navigating to a
+// page rather than from an API client
+func wantsHTML(c echo.Context) bool {
+	req := c.Request()
+	if strings.HasPrefix(req.URL.Path, "/api/") || req.Header.Get("X-Requested-With") == "XMLHttpRequest" {
+		return false
+	}
+
+	return strings.Contains(req.Header.Get(echo.HeaderAccept), echo.MIMETextHTML)
+}
@@ -0,0 +1,116 @@
+package response_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	contextmw "github.com/goformx/goforms/internal/application/middleware/context"
+	"github.com/goformx/goforms/internal/application/response"
+	"github.com/goformx/goforms/internal/infrastructure/timeout"
+	mocklogging "github.com/goformx/goforms/test/mocks/logging"
+)
+
+const requestID = "3c9a1f3e-2f55-4e43-9d8e-7f1b6f0a2c11"
+
+func serveError(t *testing.T, err error, path, accept string) *httptest.ResponseRecorder {
+	t.Helper()
+
+	logger := mocklogging.NewMockLogger(gomock.NewController(t))
+	logger.EXPECT().Error(gomock.Any(), gomock.Any()).AnyTimes()
+
+	e := echo.New()
+	e.HTTPErrorHandler = response.NewHTTPErrorHandler(logger)
+	e.GET("/*", func(echo.Context) error { return err })
+
+	req := httptest.NewRequest(http.MethodGet, path, http.NoBody)
+	req.Header.Set(contextmw.RequestIDHeader, requestID)
+	req.Header.Set(echo.HeaderAccept, accept)
+
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+
+	return rec
+}
+
+func TestHTTPErrorHandler_JSON(t *testing.T) {
+	rec := serveError(t, errors.New("dial tcp: connection refused"), "/api/v1/forms", echo.MIMEApplicationJSON)
+
+	require.Equal(t, http.StatusInternalServerError, rec.Code)
+	assert.NotContains(t, rec.Body.String(), "connection refused", "server errors never leak their cause")
+
+	var body struct {
+		Success bool                  `json:"success"`
+		Message string                `json:"message"`
+		Data    response.ErrorDetails `json:"data"`
+	}
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &body))
+
+	assert.False(t, body.Success)
+	assert.NotEmpty(t, body.Message)
+	assert.Equal(t, http.StatusInternalServerError, body.Data.Status)
+	assert.Equal(t, requestID, body.Data.RequestID)
+	assert.WithinDuration(t, time.Now(), body.Data.Timestamp, time.Minute)
+}
+
+func TestHTTPErrorHandler_HTMLPage(t *testing.T) {
+	rec := serveError(t, echo.NewHTTPError(http.StatusNotFound, "<b>Form not found</b>"), "/forms/abc", "text/html,application/xhtml+xml")
+
+	require.Equal(t, http.StatusNotFound, rec.Code)
+	assert.Contains(t, rec.Header().Get(echo.HeaderContentType), echo.MIMETextHTML)
+	assert.Equal(t, "no-store", rec.Header().Get(echo.HeaderCacheControl))
+
+	page := rec.Body.String()
+	assert.Contains(t, page, "404 Not Found")
+	assert.Contains(t, page, "&lt;b&gt;Form not found&lt;/b&gt;", "messages are escaped")
+	assert.Contains(t, page, requestID)
+	assert.Contains(t, page, "prefers-color-scheme: dark")
+}
+
+func TestHTTPErrorHandler_APIPathsGetJSON(t *testing.T) {
+	rec := serveError(t, echo.NewHTTPError(http.StatusForbidden, "Forbidden"), "/api/v1/forms/abc", "text/html")
+
+	require.Equal(t, http.StatusForbidden, rec.Code)
+	assert.Contains(t, rec.Header().Get(echo.HeaderContentType), echo.MIMEApplicationJSON)
+	assert.Contains(t, rec.Body.String(), requestID)
+}
+
+func TestHTTPErrorHandler_ClientGoneWritesNothing(t *testing.T) {
+	logger := mocklogging.NewMockLogger(gomock.NewController(t))
+	logger.EXPECT().Info("client disconnected before the response was written", gomock.Any()).Times(1)
+
+	e := echo.New()
+	e.HTTPErrorHandler = response.NewHTTPErrorHandler(logger)
+	e.Use(contextmw.NewMiddleware(logger, time.Minute, timeout.Budgets{}).WithContext())
+	e.GET("/*", func(c echo.Context) error {
+		<-c.Request().Context().Done()
+
+		return c.Request().Context().Err()
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	req := httptest.NewRequestWithContext(ctx, http.MethodGet, "/api/v1/forms", http.NoBody)
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+
+	assert.Empty(t, rec.Body.String())
+	assert.False(t, rec.Flushed)
+}
+
+func TestHTTPErrorHandler_HTMLPageTheme(t *testing.T) {
+	rec := serveError(t, echo.NewHTTPError(http.StatusNotFound), "/forms/abc?theme=dark", "text/html")
+
+	assert.Contains(t, rec.Body.String(), `<html lang="en" data-theme="dark">`)
+	assert.Contains(t, rec.Body.String(), "--gf-surface")
+}
@@ -0,0 +1,174 @@
+package response
+
+import (
+	"errors"
+	"reflect"
+	"regexp"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+)
+
+const (
+	// FieldsParam is the query parameter selecting the keys of a partial response
+	FieldsParam = "fields"
+	// maxFieldsLength bounds the length of a ?fields= value
+	maxFieldsLength = 1000
+	// maxFieldsDepth bounds the nesting of a selected path
+	maxFieldsDepth = 8
+)
+
+// ErrInvalidFields is returned for a malformed ?fields= value
+var ErrInvalidFields = errors.New(
+	"fields must be a comma-separated list of keys; use dots for nested keys, such as id,title,data.email")
+
+// fieldPathPattern matches one dotted key path
+var fieldPathPattern = regexp.MustCompile(`^[A-Za-z0-9_-]+(\.[A-Za-z0-9_-]+)*$`)
+
+// Projection selects keys of a response object. A nil Projection selects everything;
+// a key mapped to nil selects the whole value under it.
+type Projection map[string]Projection
+
+// ParseProjection parses a ?fields= value such as "id,title,data.email".
+// An empty value returns a nil Projection, which keeps every key.
+func ParseProjection(raw string) (Projection, error) {
+	raw = strings.TrimSpace(raw)
+	if raw == "" {
+		return nil, nil
+	}
+
+	if len(raw) > maxFieldsLength {
+		return nil, ErrInvalidFields
+	}
+
+	projection := Projection{}
+
+	for path := range strings.SplitSeq(raw, ",") {
+		path = strings.TrimSpace(path)
+		if path == "" {
+			continue
+		}
+
+		if !fieldPathPattern.MatchString(path) || strings.Count(path, ".") >= maxFieldsDepth {
+			return nil, ErrInvalidFields
+		}
+
+		projection.add(strings.Split(path, "."))
+	}
+
+	if len(projection) == 0 {
+		return nil, ErrInvalidFields
+	}
+
+	return projection, nil
+}
+
+// ProjectionFromRequest parses the request's ?fields= parameter
+func ProjectionFromRequest(c echo.Context) (Projection, error) {
+	return ParseProjection(c.QueryParam(FieldsParam))
+}
+
+// add selects a key path. Selecting a whole key overrides selections beneath it.
+func (p Projection) add(keys []string) {
+	child, exists := p[keys[0]]
+
+	if len(keys) == 1 {
+		p[keys[0]] = nil
+
+		return
+	}
+
+	if exists && child == nil {
+		return
+	}
+
+	if child == nil {
+		child = Projection{}
+		p[keys[0]] = child
+	}
+
+	child.add(keys[1:])
+}
+
+// Apply returns the selected keys of item. Nested selections descend into maps and
+// into each element of slices. Unknown keys are ignored.
+func (p Projection) Apply(item map[string]any) map[string]any {
+	if p == nil {
+		return item
+	}
+
+	projected := make(map[string]any, len(p))
+
+	for key, child := range p {
+		value, ok := item[key]
+		if !ok {
+			continue
+		}
+
+		projected[key] = child.project(value)
+	}
+
+	return projected
+}
+
+// ApplyAll applies the projection to every item of a list
+func (p Projection) ApplyAll(items []map[string]any) []map[string]any {
+	if p == nil {
+		return items
+	}
+
+	projected := make([]map[string]any, len(items))
+	for i, item := range items {
+		projected[i] = p.Apply(item)
+	}
+
+	return projected
+}
+
+// project applies the projection to a value of any shape. Named map types such as
+// model.JSON are handled through reflection.
+func (p Projection) project(value any) any {
+	if p == nil || value == nil {
+		return value
+	}
+
+	switch typed := value.(type) {
+	case map[string]any:
+		return p.Apply(typed)
+	case []any:
+		projected := make([]any, len(typed))
+		for i, element := range typed {
+			projected[i] = p.project(element)
+		}
+
+		return projected
+	}
+
+	reflected := reflect.ValueOf(value)
+
+	switch reflected.Kind() {
+	case reflect.Map:
+		if reflected.Type().Key().Kind() != reflect.String {
+			return value
+		}
+
+		item := make(map[string]any, reflected.Len())
+
+		iter := reflected.MapRange()
+		for iter.Next() {
+			item[iter.Key().String()] = iter.Value().Interface()
+		}
+
+		return p.Apply(item)
+	case reflect.Slice, reflect.Array:
+		projected := make([]any, reflected.Len())
+		for i := range reflected.Len() {
+			projected[i] = p.project(reflected.Index(i).Interface())
+		}
+
+		return projected
+	default:
+		// Scalars have no keys to select
+		return value
+	}
+}
@@ -0,0 +1,58 @@
+package response_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/goformx/goforms/internal/application/response"
+	"github.com/goformx/goforms/internal/domain/form/model"
+)
+
+func TestProjection_Apply(t *testing.T) {
+	projection, err := response.ParseProjection("id, data.email,schema.components.key")
+	require.NoError(t, err)
+
+	item := map[string]any{
+		"id":     "sub-1",
+		"status": "completed",
+		"data":   model.JSON{"email": "ada@example.com", "name": "Ada"},
+		"schema": model.JSON{
+			"display": "form",
+			"components": []any{
+				map[string]any{"key": "email", "type": "email"},
+				map[string]any{"key": "name", "type": "textfield"},
+			},
+		},
+	}
+
+	assert.Equal(t, map[string]any{
+		"id":   "sub-1",
+		"data": map[string]any{"email": "ada@example.com"},
+		"schema": map[string]any{
+			"components": []any{
+				map[string]any{"key": "email"},
+				map[string]any{"key": "name"},
+			},
+		},
+	}, projection.Apply(item))
+}
+
+func TestParseProjection(t *testing.T) {
+	projection, err := response.ParseProjection("")
+	require.NoError(t, err)
+	assert.Nil(t, projection)
+
+	item := map[string]any{"id": "1"}
+	assert.Equal(t, item, projection.Apply(item), "no selection keeps every key")
+
+	projection, err = response.ParseProjection("data.email,data")
+	require.NoError(t, err)
+	assert.Equal(t, response.Projection{"data": nil}, projection, "a whole key overrides nested selections")
+
+	for _, raw := range []string{",", "data..email", "id;drop", "a.b.c.d.e.f.g.h.i"} {
+		_, err = response.ParseProjection(raw)
+		require.ErrorIs(t, err, response.ErrInvalidFields, raw)
+	}
+}
@@ -0,0 +1,174 @@
+// Package revalidate checks stored submissions against a form's schema again,
+// after the schema or the validation rules changed, and reports the ones that
+// no longer conform. It can tag them so they are found in the submission list.
+//
+// Submissions are checked with the validator the submit endpoint runs, against
+// the form's current schema or one given by the caller, with option lists
+// resolved as on submit. Tagging goes through TriageService.SetSubmissionTags:
+// the tag is added to failing submissions and removed from passing ones, so
+// ?tag= lists exactly the submissions failing the last run. It backs
+// "goforms submissions validate --form <id> [--schema file] [--tag t]", which
+// exits with status 1 when any submission failed.
+package revalidate
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/goformx/goforms/internal/application/validation"
+	formdomain "github.com/goformx/goforms/internal/domain/form"
+	"github.com/goformx/goforms/internal/domain/form/model"
+	"github.com/goformx/goforms/internal/infrastructure/logging"
+	"github.com/goformx/goforms/internal/infrastructure/optionsource"
+)
+
+// maxReportedSubmissions caps the failing submissions listed in a report
+const maxReportedSubmissions = 500
+
+// Options controls a revalidation
+type Options struct {
+	// Schema replaces the form's current schema, e.g. to check submissions
+	// against a schema saved from another version of the form
+	Schema model.JSON
+	// Tag is added to the submissions that fail and removed from the ones that
+	// pass. Without it no tags change.
+	Tag string
+	// IncludeTest also checks the test submissions made from the builder's preview
+	IncludeTest bool
+}
+
+// SubmissionErrors lists why one submission fails validation
+type SubmissionErrors struct {
+	SubmissionID string             `json:"submission_id"`
+	SubmittedAt  time.Time          `json:"submitted_at"`
+	Errors       []validation.Error `json:"errors"`
+}
+
+// Report summarises a revalidation
+type Report struct {
+	FormID string `json:"form_id"`
+	Total  int    `json:"total"`
+	Valid  int    `json:"valid"`
+	Failed int    `json:"failed"`
+	// Tagged and Untagged count the submissions the tag was added to and removed from
+	Tagged   int `json:"tagged"`
+	Untagged int `json:"untagged"`
+	// Submissions lists the failing submissions, at most 500
+	Submissions []SubmissionErrors `json:"submissions"`
+}
+
+// Revalidator validates stored submissions with the validator the submit endpoint uses
+type Revalidator struct {
+	forms     formdomain.Service
+	triage    formdomain.TriageService
+	options   *optionsource.Resolver
+	validator *validation.ComprehensiveValidator
+	logger    logging.Logger
+}
+
+// New creates a new revalidator. A nil options resolver leaves option lists as
+// the schema has them.
+func New(
+	forms formdomain.Service,
+	triage formdomain.TriageService,
+	options *optionsource.Resolver,
+	logger logging.Logger,
+) *Revalidator {
+	return &Revalidator{
+		forms:     forms,
+		triage:    triage,
+		options:   options,
+		validator: validation.NewComprehensiveValidator(),
+		logger:    logger,
+	}
+}
+
+// Validate checks the form's submissions against its schema, or opts.Schema.
+// Submissions that fail are reported; the error result is reserved for
+// problems loading or tagging submissions.
+func (r *Revalidator) Validate(ctx context.Context, form *model.Form, opts Options) (*Report, error) {
+	tag := ""
+
+	if opts.Tag != "" {
+		tags, err := model.NormalizeTags([]string{opts.Tag})
+		if err != nil {
+			return nil, fmt.Errorf("tag: %w", err)
+		}
+
+		tag = tags[0]
+	}
+
+	schema := form.Schema
+	if opts.Schema != nil {
+		schema = opts.Schema
+	}
+
+	// Choices are checked against the current options of fields naming an option list
+	schema = r.options.ResolveSchema(ctx, form.UserID, schema)
+
+	submissions, err := r.forms.ListFormSubmissions(ctx, form.ID)
+	if err != nil {
+		return nil, fmt.Errorf("list form submissions: %w", err)
+	}
+
+	if !opts.IncludeTest {
+		submissions = model.WithoutTestSubmissions(submissions)
+	}
+
+	report := &Report{FormID: form.ID, Total: len(submissions), Submissions: []SubmissionErrors{}}
+
+	for _, submission := range submissions {
+		result := r.validator.ValidateForm(schema, submission.Data)
+		if result.IsValid {
+			report.Valid++
+		} else {
+			report.Failed++
+
+			if len(report.Submissions) < maxReportedSubmissions {
+				report.Submissions = append(report.Submissions, SubmissionErrors{
+					SubmissionID: submission.ID,
+					SubmittedAt:  submission.SubmittedAt,
+					Errors:       result.Errors,
+				})
+			}
+		}
+
+		if tag == "" || submission.HasTag(tag) != result.IsValid {
+			continue
+		}
+
+		if tagErr := r.retag(ctx, submission, tag, !result.IsValid); tagErr != nil {
+			return nil, tagErr
+		}
+
+		if result.IsValid {
+			report.Untagged++
+		} else {
+			report.Tagged++
+		}
+	}
+
+	r.logger.Info("submissions revalidated", "form_id", form.ID,
+		"total", report.Total, "failed", report.Failed, "tagged", report.Tagged, "untagged", report.Untagged)
+
+	return report, nil
+}
+
+// retag adds the tag to a submission or removes it
+func (r *Revalidator) retag(ctx context.Context, submission *model.FormSubmission, tag string, add bool) error {
+	tags := slices.DeleteFunc(slices.Clone(submission.GetTags()), func(existing string) bool {
+		return existing == tag
+	})
+
+	if add {
+		tags = append(tags, tag)
+	}
+
+	if _, err := r.triage.SetSubmissionTags(ctx, submission.FormID, submission.ID, tags); err != nil {
+		return fmt.Errorf("tag submission %s: %w", submission.ID, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,81 @@
+package revalidate_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/goformx/goforms/internal/application/revalidate"
+	domainform "github.com/goformx/goforms/internal/domain/form"
+	"github.com/goformx/goforms/internal/domain/form/model"
+	memorystore "github.com/goformx/goforms/internal/infrastructure/repository/memory"
+	mockform "github.com/goformx/goforms/test/mocks/form"
+	mocklogging "github.com/goformx/goforms/test/mocks/logging"
+)
+
+// requiredEmailSchema is a schema whose email field became required
+func requiredEmailSchema() model.JSON {
+	return model.JSON{"components": []any{
+		map[string]any{"key": "email", "type": "email", "input": true, "validate": map[string]any{"required": true}},
+	}}
+}
+
+func TestRevalidator_Validate(t *testing.T) {
+	ctx := t.Context()
+	ctrl := gomock.NewController(t)
+	logger := mocklogging.NewMockLogger(ctrl)
+	logger.EXPECT().Info(gomock.Any(), gomock.Any()).AnyTimes()
+
+	store := memorystore.NewStore(logger)
+	forms := store.Forms()
+
+	form := model.NewForm("user-1", "Newsletter", "", requiredEmailSchema())
+	require.NoError(t, forms.CreateForm(ctx, form))
+
+	valid := &model.FormSubmission{FormID: form.ID, Data: model.JSON{"email": "ada@example.com"}}
+	require.NoError(t, valid.SetTags([]string{"invalid", "vip"}))
+
+	missing := &model.FormSubmission{FormID: form.ID, Data: model.JSON{}}
+	test := &model.FormSubmission{FormID: form.ID, Data: model.JSON{}, Test: true}
+
+	for _, submission := range []*model.FormSubmission{valid, missing, test} {
+		require.NoError(t, forms.CreateSubmission(ctx, submission))
+	}
+
+	service := mockform.NewMockService(ctrl)
+	service.EXPECT().ListFormSubmissions(gomock.Any(), form.ID).DoAndReturn(forms.ListSubmissions).AnyTimes()
+
+	revalidator := revalidate.New(service, domainform.NewTriageService(store.Views(), forms, logger), nil, logger)
+
+	report, err := revalidator.Validate(ctx, form, revalidate.Options{Tag: "Invalid"})
+	require.NoError(t, err)
+	assert.Equal(t, 2, report.Total, "test submissions are skipped")
+	assert.Equal(t, 1, report.Valid)
+	assert.Equal(t, 1, report.Failed)
+	assert.Equal(t, 1, report.Tagged)
+	assert.Equal(t, 1, report.Untagged)
+	require.Len(t, report.Submissions, 1)
+	assert.Equal(t, missing.ID, report.Submissions[0].SubmissionID)
+	assert.Equal(t, "email", report.Submissions[0].Errors[0].Field)
+
+	stored, err := forms.GetSubmissionByID(ctx, missing.ID)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"invalid"}, stored.GetTags())
+
+	stored, err = forms.GetSubmissionByID(ctx, valid.ID)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"vip"}, stored.GetTags())
+
+	report, err = revalidator.Validate(ctx, form, revalidate.Options{
+		Schema:      model.JSON{"components": []any{map[string]any{"key": "email", "type": "email", "input": true}}},
+		IncludeTest: true,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, 3, report.Total)
+	assert.Equal(t, 3, report.Valid, "checked against the given schema")
+
+	_, err = revalidator.Validate(ctx, form, revalidate.Options{Tag: "not valid!"})
+	require.Error(t, err)
+}
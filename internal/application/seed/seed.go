@@ -0,0 +1,271 @@
+// Package seed fills a development or demo database with fake users, forms and
+// submissions so the application has realistic data without manual entry.
+// Seeding is additive: users are matched by email and reused, while forms and
+// submissions are always created anew.
+package seed
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand/v2"
+	"strings"
+	"time"
+
+	"github.com/goformx/goforms/internal/application/bench"
+	"github.com/goformx/goforms/internal/application/validation"
+	domainerrors "github.com/goformx/goforms/internal/domain/common/errors"
+	"github.com/goformx/goforms/internal/domain/entities"
+	formdomain "github.com/goformx/goforms/internal/domain/form"
+	"github.com/goformx/goforms/internal/domain/form/model"
+	"github.com/goformx/goforms/internal/domain/user"
+	"github.com/goformx/goforms/internal/infrastructure/logging"
+	"github.com/goformx/goforms/internal/infrastructure/repository/common"
+)
+
+const (
+	// DefaultPassword is the password given to seeded users
+	DefaultPassword = "password123"
+	// emailDomain is reserved for examples, so seeded addresses never reach anyone
+	emailDomain = "example.com"
+	// submissionWindow is how far back seeded submission timestamps are spread
+	submissionWindow = 90 * 24 * time.Hour
+	// maxReportedErrors caps the errors kept in a report
+	maxReportedErrors = 50
+)
+
+// Options configures a seeding run
+type Options struct {
+	// Users is the number of users to create or reuse
+	Users int
+	// Forms is the number of forms to create, spread over the users
+	Forms int
+	// Submissions is the number of submissions to create, spread over the forms
+	Submissions int
+	// Password is set on newly created users; DefaultPassword when empty
+	Password string
+	// Seed makes the generated data repeatable
+	Seed uint64
+}
+
+// Report summarizes a seeding run
+type Report struct {
+	Users       int      `json:"users"`
+	UsersReused int      `json:"users_reused"`
+	Forms       int      `json:"forms"`
+	Submissions int      `json:"submissions"`
+	Failed      int      `json:"failed"`
+	Errors      []string `json:"errors,omitempty"`
+	Password    string   `json:"password"`
+}
+
+// Seeder creates fake data through the domain services
+type Seeder struct {
+	users   user.Repository
+	forms   formdomain.Service
+	imports formdomain.ImportService
+	logger  logging.Logger
+}
+
+// New creates a seeder. Submissions are stored through imports, so seeding
+// publishes no submission events and sends no notifications.
+func New(
+	users user.Repository,
+	forms formdomain.Service,
+	imports formdomain.ImportService,
+	logger logging.Logger,
+) *Seeder {
+	return &Seeder{
+		users:   users,
+		forms:   forms,
+		imports: imports,
+		logger:  logger,
+	}
+}
+
+// Run creates the requested users, forms and submissions. Individual failures are
+// counted in the report; an error is returned only when nothing can be seeded.
+func (s *Seeder) Run(ctx context.Context, options Options) (*Report, error) {
+	if options.Users <= 0 {
+		return nil, errors.New("at least one user is required")
+	}
+
+	if options.Password == "" {
+		options.Password = DefaultPassword
+	}
+
+	random := rand.New(rand.NewPCG(options.Seed, options.Seed)) //nolint:gosec // fake data, not security sensitive
+	report := &Report{Password: options.Password}
+
+	owners := s.seedUsers(ctx, random, options, report)
+	if len(owners) == 0 {
+		return report, errors.New("no users could be created")
+	}
+
+	forms := s.seedForms(ctx, random, owners, options.Forms, report)
+	if len(forms) == 0 {
+		if options.Submissions > 0 {
+			return report, errors.New("no forms could be created")
+		}
+
+		return report, nil
+	}
+
+	s.seedSubmissions(ctx, random, forms, options, report)
+
+	s.logger.Info("seeding completed",
+		"users", report.Users,
+		"forms", report.Forms,
+		"submissions", report.Submissions,
+		"failed", report.Failed)
+
+	return report, nil
+}
+
+// seedUsers creates users, reusing any that already exist with the same email
+func (s *Seeder) seedUsers(ctx context.Context, random *rand.Rand, options Options, report *Report) []*entities.User {
+	owners := make([]*entities.User, 0, options.Users)
+
+	for i := range options.Users {
+		firstName := firstNames[random.IntN(len(firstNames))]
+		lastName := lastNames[random.IntN(len(lastNames))]
+		email := fmt.Sprintf("%s.%s.%d@%s",
+			strings.ToLower(firstName), strings.ToLower(strings.ReplaceAll(lastName, "-", "")), i+1, emailDomain)
+
+		existing, err := s.users.GetByEmail(ctx, email)
+		if err == nil && existing != nil {
+			report.UsersReused++
+			owners = append(owners, existing)
+
+			continue
+		}
+
+		if err != nil && !errors.Is(err, common.ErrNotFound) && !domainerrors.IsNotFound(err) {
+			report.fail(fmt.Errorf("look up user %s: %w", email, err))
+
+			continue
+		}
+
+		created, err := entities.NewUser(email, options.Password, firstName, lastName)
+		if err != nil {
+			report.fail(fmt.Errorf("build user %s: %w", email, err))
+
+			continue
+		}
+
+		if createErr := s.users.Create(ctx, created); createErr != nil {
+			report.fail(fmt.Errorf("create user %s: %w", email, createErr))
+
+			continue
+		}
+
+		report.Users++
+		owners = append(owners, created)
+	}
+
+	return owners
+}
+
+// seededForm is a created form and the generator for its submissions
+type seededForm struct {
+	form      *model.Form
+	generator *bench.Generator
+}
+
+// seedForms creates forms from the templates, spread round-robin over the owners
+func (s *Seeder) seedForms(
+	ctx context.Context,
+	random *rand.Rand,
+	owners []*entities.User,
+	count int,
+	report *Report,
+) []seededForm {
+	parser := validation.NewSchemaParser()
+	forms := make([]seededForm, 0, count)
+
+	for i := range count {
+		tmpl := templates[random.IntN(len(templates))]
+		owner := owners[i%len(owners)]
+
+		formModel := model.NewForm(owner.ID, fmt.Sprintf("%s #%d", tmpl.title, i+1), tmpl.description, tmpl.schema())
+
+		// Most forms are live; the rest show the other states in listings
+		switch roll := random.IntN(10); {
+		case roll < 7:
+			formModel.Status = "published"
+		case roll < 9:
+			formModel.Status = "draft"
+		default:
+			formModel.Status = "archived"
+		}
+
+		if err := s.forms.CreateForm(ctx, formModel); err != nil {
+			report.fail(fmt.Errorf("create form %q: %w", formModel.Title, err))
+
+			continue
+		}
+
+		fields, _ := parser.ExtractFields(formModel.Schema)
+
+		report.Forms++
+		forms = append(forms, seededForm{
+			form:      formModel,
+			generator: bench.NewGenerator(fields, random.Uint64()),
+		})
+	}
+
+	return forms
+}
+
+// seedSubmissions creates submissions spread over the forms and the past submissionWindow
+func (s *Seeder) seedSubmissions(
+	ctx context.Context,
+	random *rand.Rand,
+	forms []seededForm,
+	options Options,
+	report *Report,
+) {
+	now := time.Now()
+
+	for range options.Submissions {
+		target := forms[random.IntN(len(forms))]
+
+		submission := &model.FormSubmission{
+			Data:        target.generator.Submission(),
+			SubmittedAt: now.Add(-time.Duration(random.Int64N(int64(submissionWindow)))),
+			Status:      submissionStatus(random),
+			Metadata:    model.JSON{"source": "seed"},
+		}
+
+		if err := s.imports.ImportSubmission(ctx, target.form, submission); err != nil {
+			report.fail(fmt.Errorf("create submission for form %s: %w", target.form.ID, err))
+
+			continue
+		}
+
+		report.Submissions++
+	}
+}
+
+// submissionStatus picks a status, weighted towards completed submissions
+func submissionStatus(random *rand.Rand) model.SubmissionStatus {
+	switch roll := random.IntN(20); {
+	case roll < 16:
+		return model.SubmissionStatusCompleted
+	case roll < 18:
+		return model.SubmissionStatusPending
+	case roll < 19:
+		return model.SubmissionStatusProcessing
+	default:
+		return model.SubmissionStatusFailed
+	}
+}
+
+// fail records a failed item
+func (r *Report) fail(err error) {
+	r.Failed++
+
+	if len(r.Errors) < maxReportedErrors {
+		r.Errors = append(r.Errors, err.Error())
+	}
+}
@@ -0,0 +1,112 @@
+package seed_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/goformx/goforms/internal/application/seed"
+	"github.com/goformx/goforms/internal/application/validation"
+	"github.com/goformx/goforms/internal/domain/entities"
+	"github.com/goformx/goforms/internal/domain/form/model"
+	"github.com/goformx/goforms/internal/infrastructure/repository/common"
+	mockform "github.com/goformx/goforms/test/mocks/form"
+	mocklogging "github.com/goformx/goforms/test/mocks/logging"
+	mockuser "github.com/goformx/goforms/test/mocks/user"
+)
+
+// importRecorder stores imported submissions and checks them against the form schema
+type importRecorder struct {
+	t           *testing.T
+	validator   *validation.ComprehensiveValidator
+	submissions map[string][]*model.FormSubmission
+}
+
+func (r *importRecorder) ImportSubmission(_ context.Context, form *model.Form, submission *model.FormSubmission) error {
+	submission.FormID = form.ID
+
+	result := r.validator.ValidateForm(form.Schema, submission.Data)
+	assert.True(r.t, result.IsValid, "seeded submission for %q: %v", form.Title, result.Errors)
+
+	r.submissions[form.ID] = append(r.submissions[form.ID], submission)
+
+	return nil
+}
+
+func TestSeeder_Run(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	users := mockuser.NewMockRepository(ctrl)
+	forms := mockform.NewMockService(ctrl)
+	logger := mocklogging.NewMockLogger(ctrl)
+	logger.EXPECT().Info(gomock.Any(), gomock.Any()).AnyTimes()
+
+	imports := &importRecorder{
+		t:           t,
+		validator:   validation.NewComprehensiveValidator(),
+		submissions: map[string][]*model.FormSubmission{},
+	}
+
+	existing := &entities.User{ID: "existing-user", Email: "taken@example.com"}
+	lookups := 0
+
+	users.EXPECT().GetByEmail(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, _ string) (*entities.User, error) {
+			lookups++
+			if lookups == 1 {
+				return existing, nil
+			}
+
+			return nil, common.ErrNotFound
+		}).Times(3)
+	users.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil).Times(2)
+
+	created := map[string]*model.Form{}
+
+	forms.EXPECT().CreateForm(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, form *model.Form) error {
+			require.NoError(t, form.Validate())
+			created[form.ID] = form
+
+			return nil
+		}).Times(6)
+
+	report, err := seed.New(users, forms, imports, logger).Run(t.Context(), seed.Options{
+		Users:       3,
+		Forms:       6,
+		Submissions: 200,
+		Seed:        1,
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, 2, report.Users)
+	assert.Equal(t, 1, report.UsersReused)
+	assert.Equal(t, 6, report.Forms)
+	assert.Equal(t, 200, report.Submissions)
+	assert.Zero(t, report.Failed)
+	assert.Equal(t, seed.DefaultPassword, report.Password)
+
+	owners := map[string]int{}
+	for _, form := range created {
+		owners[form.UserID]++
+	}
+
+	assert.Len(t, owners, 3, "forms are spread over every user")
+	assert.Equal(t, 2, owners[existing.ID])
+
+	total := 0
+	for formID, submissions := range imports.submissions {
+		assert.Contains(t, created, formID)
+
+		total += len(submissions)
+	}
+
+	assert.Equal(t, 200, total)
+}
+
+func TestSeeder_RequiresUsers(t *testing.T) {
+	_, err := seed.New(nil, nil, nil, nil).Run(t.Context(), seed.Options{Forms: 1})
+	require.Error(t, err)
+}
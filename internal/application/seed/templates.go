@@ -0,0 +1,190 @@
+package seed
+
+import "github.com/goformx/goforms/internal/domain/form/model"
+
+// template is a form definition the seeder builds forms from
+type template struct {
+	title       string
+	description string
+	components  []any
+}
+
+var (
+	firstNames = []string{
+		"Ada", "Grace", "Alan", "Linus", "Margaret", "Dennis", "Barbara", "Ken",
+		"Radia", "Edsger", "Frances", "Donald", "Hedy", "Tim", "Katherine", "John",
+	}
+
+	lastNames = []string{
+		"Lovelace", "Hopper", "Turing", "Torvalds", "Hamilton", "Ritchie", "Liskov", "Thompson",
+		"Perlman", "Dijkstra", "Allen", "Knuth", "Lamarr", "Berners-Lee", "Johnson", "McCarthy",
+	}
+
+	// templates cover the common kinds of forms with a spread of field types
+	templates = []template{
+		{
+			title:       "Contact Us",
+			description: "Get in touch with our team",
+			components: []any{
+				textField("name", "Full Name", true, 2, 80),
+				emailField("email", "Email", true),
+				phoneField("phone", "Phone", false),
+				selectField("topic", "Topic", true, "sales", "support", "billing", "other"),
+				textArea("message", "Message", true, 10, 500),
+			},
+		},
+		{
+			title:       "Event Registration",
+			description: "Reserve your seat at the next meetup",
+			components: []any{
+				textField("name", "Name", true, 2, 80),
+				emailField("email", "Email", true),
+				numberField("guests", "Guests", true, 1, 5),
+				radioField("ticket", "Ticket", true, "general", "vip", "student"),
+				selectBoxesField("sessions", "Sessions", "keynote", "workshop", "panel"),
+				checkboxField("terms", "I agree to the code of conduct", true),
+			},
+		},
+		{
+			title:       "Customer Feedback",
+			description: "Tell us how we did",
+			components: []any{
+				numberField("rating", "Rating", true, 1, 5),
+				radioField("recommend", "Would you recommend us?", true, "yes", "no", "maybe"),
+				textArea("liked", "What did you like?", false, 0, 400),
+				textArea("improve", "What could we improve?", false, 0, 400),
+				emailField("email", "Email (optional)", false),
+			},
+		},
+		{
+			title:       "Job Application",
+			description: "Apply for an open position",
+			components: []any{
+				textField("first_name", "First Name", true, 1, 50),
+				textField("last_name", "Last Name", true, 1, 50),
+				emailField("email", "Email", true),
+				phoneField("phone", "Phone", true),
+				selectField("position", "Position", true, "engineer", "designer", "support", "marketing"),
+				numberField("experience", "Years of Experience", true, 0, 40),
+				urlField("portfolio", "Portfolio URL", false),
+				textArea("cover_letter", "Cover Letter", false, 0, 1000),
+			},
+		},
+		{
+			title:       "Newsletter Signup",
+			description: "Monthly product news, no spam",
+			components: []any{
+				emailField("email", "Email", true),
+				textField("name", "First Name", false, 0, 50),
+				selectBoxesField("interests", "Interests", "product", "engineering", "events"),
+				checkboxField("consent", "I agree to receive emails", true),
+			},
+		},
+		{
+			title:       "Support Request",
+			description: "Report a problem with your account",
+			components: []any{
+				emailField("email", "Account Email", true),
+				selectField("severity", "Severity", true, "low", "medium", "high", "urgent"),
+				textField("subject", "Subject", true, 5, 120),
+				textArea("details", "Details", true, 20, 2000),
+				dateField("occurred_on", "When did it happen?", false),
+			},
+		},
+	}
+)
+
+// schema wraps components in a Form.io form schema with a submit button
+func (t template) schema() model.JSON {
+	components := append([]any{}, t.components...)
+	components = append(components, map[string]any{
+		"type": "button", "key": "submit", "label": "Submit", "action": "submit",
+	})
+
+	return model.JSON{"display": "form", "components": components}
+}
+
+func textField(key, label string, required bool, minLength, maxLength int) map[string]any {
+	return component("textfield", key, label, map[string]any{
+		"required": required, "minLength": float64(minLength), "maxLength": float64(maxLength),
+	})
+}
+
+func textArea(key, label string, required bool, minLength, maxLength int) map[string]any {
+	validate := map[string]any{"required": required, "maxLength": float64(maxLength)}
+	if minLength > 0 {
+		validate["minLength"] = float64(minLength)
+	}
+
+	return component("textarea", key, label, validate)
+}
+
+func emailField(key, label string, required bool) map[string]any {
+	return component("email", key, label, map[string]any{"required": required})
+}
+
+func phoneField(key, label string, required bool) map[string]any {
+	return component("phoneNumber", key, label, map[string]any{"required": required})
+}
+
+func urlField(key, label string, required bool) map[string]any {
+	return component("url", key, label, map[string]any{"required": required})
+}
+
+func dateField(key, label string, required bool) map[string]any {
+	return component("date", key, label, map[string]any{"required": required})
+}
+
+func numberField(key, label string, required bool, minValue, maxValue float64) map[string]any {
+	return component("number", key, label, map[string]any{"required": required, "min": minValue, "max": maxValue})
+}
+
+func checkboxField(key, label string, required bool) map[string]any {
+	return component("checkbox", key, label, map[string]any{"required": required})
+}
+
+func selectField(key, label string, required bool, values ...string) map[string]any {
+	field := component("select", key, label, map[string]any{"required": required})
+	field["data"] = map[string]any{"values": options(values)}
+
+	return field
+}
+
+func radioField(key, label string, required bool, values ...string) map[string]any {
+	field := component("radio", key, label, map[string]any{"required": required})
+	field["values"] = options(values)
+
+	return field
+}
+
+func selectBoxesField(key, label string, values ...string) map[string]any {
+	field := component("selectboxes", key, label, map[string]any{"required": false})
+	field["values"] = options(values)
+
+	return field
+}
+
+func component(componentType, key, label string, validate map[string]any) map[string]any {
+	return map[string]any{
+		"type":     componentType,
+		"key":      key,
+		"label":    label,
+		"input":    true,
+		"validate": validate,
+	}
+}
+
+// options builds Form.io choices whose labels are the capitalized values
+func options(values []string) []any {
+	choices := make([]any, len(values))
+	for i, value := range values {
+		label := value
+		if label != "" {
+			label = string(label[0]-'a'+'A') + label[1:]
+		}
+
+		choices[i] = map[string]any{"label": label, "value": value}
+	}
+
+	return choices
+}
@@ -0,0 +1,108 @@
+// Package theme holds the light and dark color themes of the HTML this service
+// renders: the hosted form pages, the error page and, through /css/theme.css,
+// the Laravel dashboard and form builder. Colors are CSS variables (--gf-*);
+// the system theme follows the visitor's prefers-color-scheme, and a
+// data-theme attribute on <html> forces light or dark.
+package theme
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+)
+
+// Theme names
+const (
+	System = "system"
+	Light  = "light"
+	Dark   = "dark"
+)
+
+// Param is the query parameter a page's theme is taken from
+const Param = "theme"
+
+// ErrUnknown is returned for a name that is not a theme
+var ErrUnknown = errors.New("theme must be system, light or dark")
+
+// Parse returns the theme called name; "" is the system theme
+func Parse(name string) (string, error) {
+	switch name = strings.ToLower(strings.TrimSpace(name)); name {
+	case "", System:
+		return System, nil
+	case Light, Dark:
+		return name, nil
+	default:
+		return "", ErrUnknown
+	}
+}
+
+// FromRequest returns the theme a request asks for with the theme query
+// parameter; unknown names give the system theme
+func FromRequest(c echo.Context) string {
+	name, err := Parse(c.QueryParam(Param))
+	if err != nil {
+		return System
+	}
+
+	return name
+}
+
+// Attr returns the data-theme attribute of the <html> element for a theme,
+// with a leading space; the system theme needs none
+func Attr(name string) string {
+	if name == Light || name == Dark {
+		return ` data-theme="` + name + `"`
+	}
+
+	return ""
+}
+
+// Stylesheet defines the theme variables and applies them to Form.io forms and
+// the Form.io builder. Pages include it inline; the dashboard links it.
+const Stylesheet = `:root {
+  color-scheme: light dark;
+  --gf-bg: #f8fafc; --gf-surface: #ffffff; --gf-text: #0f172a; --gf-muted: #64748b;
+  --gf-border: #e2e8f0; --gf-input-bg: #ffffff; --gf-accent: #4f46e5; --gf-accent-text: #ffffff;
+  --gf-danger: #dc2626;
+}
+:root[data-theme="light"] { color-scheme: light; }
+:root[data-theme="dark"] {
+  color-scheme: dark;
+  --gf-bg: #0f172a; --gf-surface: #1e293b; --gf-text: #f1f5f9; --gf-muted: #94a3b8;
+  --gf-border: #334155; --gf-input-bg: #0b1220; --gf-accent: #818cf8; --gf-accent-text: #0f172a;
+  --gf-danger: #f87171;
+}
+@media (prefers-color-scheme: dark) {
+  :root:not([data-theme="light"]) {
+    --gf-bg: #0f172a; --gf-surface: #1e293b; --gf-text: #f1f5f9; --gf-muted: #94a3b8;
+    --gf-border: #334155; --gf-input-bg: #0b1220; --gf-accent: #818cf8; --gf-accent-text: #0f172a;
+    --gf-danger: #f87171;
+  }
+}
+.formio-form, .formbuilder { color: var(--gf-text); }
+.formio-form .form-control, .formio-form .form-select, .formio-form .choices__inner,
+.formbuilder .form-control, .formbuilder .form-select {
+  background-color: var(--gf-input-bg); color: var(--gf-text); border-color: var(--gf-border);
+}
+.formio-form .card, .formio-form .list-group-item, .formbuilder .card,
+.formbuilder .formcomponents .card-header, .formio-dialog .formio-dialog-content {
+  background-color: var(--gf-surface); color: var(--gf-text); border-color: var(--gf-border);
+}
+.formio-form .btn-primary, .formbuilder .btn-primary {
+  background-color: var(--gf-accent); border-color: var(--gf-accent); color: var(--gf-accent-text);
+}
+.formbuilder .formcomponent { background-color: var(--gf-surface); color: var(--gf-text); border-color: var(--gf-border); }
+.formbuilder .drag-container { border-color: var(--gf-border); }
+.formio-form .text-muted, .formio-form .form-text, .formbuilder .text-muted { color: var(--gf-muted) !important; }
+.formio-form .formio-errors .error, .formio-form .invalid-feedback { color: var(--gf-danger); }
+`
+
+// ETag identifies the current Stylesheet
+var ETag = func() string {
+	sum := sha256.Sum256([]byte(Stylesheet))
+
+	return `"` + hex.EncodeToString(sum[:8]) + `"`
+}()
@@ -0,0 +1,39 @@
+package theme_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/goformx/goforms/internal/application/theme"
+)
+
+func TestParse(t *testing.T) {
+	for name, want := range map[string]string{"": theme.System, "system": theme.System, " Dark ": theme.Dark, "light": theme.Light} {
+		got, err := theme.Parse(name)
+		require.NoError(t, err, name)
+		assert.Equal(t, want, got, name)
+	}
+
+	_, err := theme.Parse("sepia")
+	require.ErrorIs(t, err, theme.ErrUnknown)
+}
+
+func TestFromRequest(t *testing.T) {
+	themeOf := func(target string) string {
+		return theme.FromRequest(echo.New().NewContext(httptest.NewRequest(http.MethodGet, target, http.NoBody), httptest.NewRecorder()))
+	}
+
+	assert.Equal(t, theme.Dark, themeOf("/?theme=dark"))
+	assert.Equal(t, theme.System, themeOf("/"))
+	assert.Equal(t, theme.System, themeOf(`/?theme="><script>`), "unknown names fall back to the system theme")
+}
+
+func TestAttr(t *testing.T) {
+	assert.Equal(t, ` data-theme="dark"`, theme.Attr(theme.Dark))
+	assert.Empty(t, theme.Attr(theme.System))
+}
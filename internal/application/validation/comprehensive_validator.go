@@ -4,7 +4,9 @@ package validation
 
 import (
 	"errors"
+	"strings"
 
+	formdomain "github.com/goformx/goforms/internal/domain/form"
 	"github.com/goformx/goforms/internal/domain/form/model"
 )
 
@@ -14,10 +16,17 @@ type ComprehensiveValidator struct {
 	schemaParser   *SchemaParser
 }
 
-// NewComprehensiveValidator creates a new comprehensive form validator
+// NewComprehensiveValidator creates a new comprehensive form validator with
+// the built-in locale rules
 func NewComprehensiveValidator() *ComprehensiveValidator {
+	return NewComprehensiveValidatorWithLocaleRules(defaultLocaleRules)
+}
+
+// NewComprehensiveValidatorWithLocaleRules creates a comprehensive form
+// validator whose locale formats come from rules
+func NewComprehensiveValidatorWithLocaleRules(rules LocaleRuleProvider) *ComprehensiveValidator {
 	return &ComprehensiveValidator{
-		fieldValidator: NewFieldValidator(),
+		fieldValidator: NewFieldValidatorWithLocaleRules(rules),
 		schemaParser:   NewSchemaParser(),
 	}
 }
@@ -29,8 +38,8 @@ func (v *ComprehensiveValidator) ValidateForm(schema, submission model.JSON) Res
 		Errors:  []Error{},
 	}
 
-	// Extract components from schema
-	components, ok := v.schemaParser.ExtractComponents(schema)
+	// Extract input components from schema, including those nested in layouts
+	components, ok := v.schemaParser.ExtractInputComponents(schema)
 	if !ok {
 		result.IsValid = false
 		result.Errors = append(result.Errors, Error{
@@ -44,10 +53,8 @@ func (v *ComprehensiveValidator) ValidateForm(schema, submission model.JSON) Res
 
 	// Validate each component
 	for _, component := range components {
-		if componentMap, componentOk := component.(map[string]any); componentOk {
-			fieldErrors := v.validateComponent(componentMap, submission)
-			result.Errors = append(result.Errors, fieldErrors...)
-		}
+		fieldErrors := v.validateComponent(component, submission)
+		result.Errors = append(result.Errors, fieldErrors...)
 	}
 
 	// Check if any errors occurred
@@ -66,6 +73,11 @@ func (v *ComprehensiveValidator) validateComponent(component map[string]any, sub
 		return []Error{}
 	}
 
+	// Server-computed fields are derived after validation, so client values are ignored
+	if calculateServer, _ := component["calculateServer"].(bool); calculateServer {
+		return []Error{}
+	}
+
 	// Get field value from submission
 	fieldValue, exists := submission[key]
 	if !exists {
@@ -75,6 +87,12 @@ func (v *ComprehensiveValidator) validateComponent(component map[string]any, sub
 	// Extract validation rules
 	validation := v.schemaParser.ExtractValidationRules(component)
 
+	// A locale format may follow the country chosen in another field
+	if validation.Locale != nil && validation.Locale.CountryField != "" {
+		country, _ := formdomain.SubmissionValue(submission, validation.Locale.CountryField).(string)
+		validation.Locale.Country = strings.ToUpper(strings.TrimSpace(country))
+	}
+
 	// Validate field using field validator
 	return v.fieldValidator.ValidateField(key, fieldValue, &validation)
 }
@@ -83,22 +101,56 @@ func (v *ComprehensiveValidator) validateComponent(component map[string]any, sub
 func (v *ComprehensiveValidator) GenerateClientValidation(schema model.JSON) (map[string]any, error) {
 	clientRules := make(map[string]any)
 
-	components, ok := v.schemaParser.ExtractComponents(schema)
+	components, ok := v.schemaParser.ExtractInputComponents(schema)
 	if !ok {
 		return nil, errors.New("invalid schema: missing components")
 	}
 
 	for _, component := range components {
-		if componentMap, componentOk := component.(map[string]any); componentOk {
-			key, keyOk := v.schemaParser.ExtractComponentKey(componentMap)
-			if !keyOk {
-				continue
-			}
-
-			validation := v.schemaParser.ExtractValidationRules(componentMap)
-			clientRules[key] = v.schemaParser.ConvertToClientRules(&validation)
+		key, keyOk := v.schemaParser.ExtractComponentKey(component)
+		if !keyOk {
+			continue
+		}
+
+		validation := v.schemaParser.ExtractValidationRules(component)
+		rules := v.schemaParser.ConvertToClientRules(&validation)
+
+		if validation.Locale != nil {
+			rules["locale"] = v.clientLocaleRules(validation.Locale)
 		}
+
+		clientRules[key] = rules
 	}
 
 	return clientRules, nil
 }
+
+// clientLocaleRules describes a locale format for client-side validation: the
+// pattern of each country the value may be checked for, matched after the
+// normalization LocaleRule.Normalize describes
+func (v *ComprehensiveValidator) clientLocaleRules(locale *LocaleFormat) map[string]any {
+	var rules []LocaleRule
+
+	if locale.CountryField != "" {
+		rules = v.fieldValidator.locale.Rules(locale.Format)
+	} else if rule, ok := v.fieldValidator.locale.Rule(locale.Format, locale.Country); ok {
+		rules = []LocaleRule{rule}
+	}
+
+	patterns := make(map[string]any, len(rules))
+	for _, rule := range rules {
+		patterns[rule.Country] = map[string]any{"pattern": rule.Pattern.String(), "example": rule.Example}
+	}
+
+	clientRule := map[string]any{"format": locale.Format, "patterns": patterns}
+
+	if locale.Country != "" {
+		clientRule["country"] = locale.Country
+	}
+
+	if locale.CountryField != "" {
+		clientRule["countryField"] = locale.CountryField
+	}
+
+	return clientRule
+}
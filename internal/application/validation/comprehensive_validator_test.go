@@ -294,3 +294,109 @@ func TestComprehensiveValidator_Integration(t *testing.T) {
 	require.False(t, result.IsValid)
 	assert.NotEmpty(t, result.Errors)
 }
+
+func TestComprehensiveValidator_SchemaConstraints(t *testing.T) {
+	validator := setupTestComprehensiveValidator()
+
+	schema := model.JSON{
+		"type": "object",
+		"components": []any{
+			map[string]any{
+				"type": "panel",
+				"key":  "details",
+				"components": []any{
+					map[string]any{
+						"key":  "color",
+						"type": "select",
+						"data": map[string]any{
+							"values": []any{
+								map[string]any{"label": "Red", "value": "red"},
+								map[string]any{"label": "Blue", "value": "blue"},
+							},
+						},
+					},
+				},
+			},
+			map[string]any{
+				"type": "columns",
+				"key":  "columns",
+				"columns": []any{
+					map[string]any{
+						"components": []any{
+							map[string]any{
+								"key":  "terms",
+								"type": "checkbox",
+								"validate": map[string]any{
+									"required": true,
+								},
+							},
+						},
+					},
+				},
+			},
+			map[string]any{
+				"key":         "resume",
+				"type":        "file",
+				"fileMaxSize": "1MB",
+				"filePattern": ".pdf,image/*",
+			},
+			map[string]any{
+				"key":  "submit",
+				"type": "button",
+			},
+		},
+	}
+
+	tests := []struct {
+		name       string
+		submission model.JSON
+		wantRules  []string
+	}{
+		{
+			name: "valid nested submission",
+			submission: model.JSON{
+				"color":  "red",
+				"terms":  true,
+				"resume": []any{map[string]any{"name": "cv.pdf", "size": 2048, "type": "application/pdf"}},
+			},
+			wantRules: nil,
+		},
+		{
+			name: "value outside enum",
+			submission: model.JSON{
+				"color": "green",
+				"terms": true,
+			},
+			wantRules: []string{"options"},
+		},
+		{
+			name: "unchecked required checkbox",
+			submission: model.JSON{
+				"terms": false,
+			},
+			wantRules: []string{"required"},
+		},
+		{
+			name: "file constraints violated",
+			submission: model.JSON{
+				"terms":  true,
+				"resume": []any{map[string]any{"name": "cv.exe", "size": 2 << 20, "type": "application/octet-stream"}},
+			},
+			wantRules: []string{"fileMaxSize", "filePattern"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := validator.ValidateForm(schema, tt.submission)
+
+			rules := make([]string, 0, len(result.Errors))
+			for _, validationErr := range result.Errors {
+				rules = append(rules, validationErr.Rule)
+			}
+
+			assert.ElementsMatch(t, tt.wantRules, rules)
+			assert.Equal(t, len(tt.wantRules) == 0, result.IsValid)
+		})
+	}
+}
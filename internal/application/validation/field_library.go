@@ -0,0 +1,193 @@
+package validation
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/goformx/goforms/internal/domain/form/model"
+)
+
+// countryCodePattern matches an ISO 3166-1 alpha-2 country code
+var countryCodePattern = regexp.MustCompile(`^[A-Z]{2}$`)
+
+// validateE164 validates a phone number in E.164 form
+func (v *FieldValidator) validateE164(fieldName string, value any) *Error {
+	if strValue, ok := value.(string); ok && model.E164Pattern.MatchString(strings.TrimSpace(strValue)) {
+		return nil
+	}
+
+	return &Error{
+		Field:   fieldName,
+		Message: "Enter the phone number in international format, e.g. +14155550123",
+		Rule:    "phone",
+	}
+}
+
+// validateLocaleFormat validates a value against the locale format of its
+// country. Values are accepted when no country was given or the country has
+// no rule for the format.
+func (v *FieldValidator) validateLocaleFormat(fieldName string, value any, locale *LocaleFormat) *Error {
+	if locale == nil || locale.Country == "" {
+		return nil
+	}
+
+	rule, ok := v.locale.Rule(locale.Format, locale.Country)
+	if !ok {
+		return nil
+	}
+
+	if text, isString := value.(string); isString && rule.Match(text) {
+		return nil
+	}
+
+	return &Error{
+		Field:   fieldName,
+		Message: fmt.Sprintf("Enter a valid %s for %s, e.g. %s", localeFormatNames[locale.Format], rule.Country, rule.Example),
+		Rule:    locale.Format,
+	}
+}
+
+// localeFormatNames name the locale formats in error messages
+var localeFormatNames = map[string]string{
+	LocalePostalCode: "postal code",
+	LocalePhone:      "phone number",
+	LocaleVATID:      "VAT ID",
+}
+
+// validateScale validates a rating or NPS answer: a whole number within the scale
+func (v *FieldValidator) validateScale(fieldName string, value any, rules *ScaleRules) *Error {
+	if rules == nil {
+		return nil
+	}
+
+	number, ok := v.toFloat64(value)
+	if !ok || number != float64(int(number)) || int(number) < rules.Min || int(number) > rules.Max {
+		return &Error{
+			Field:   fieldName,
+			Message: fmt.Sprintf("Choose a whole number from %d to %d", rules.Min, rules.Max),
+			Rule:    "scale",
+		}
+	}
+
+	return nil
+}
+
+// validateMatrix validates the answers of a matrix, an object mapping question
+// values to chosen values. Every question must be answered when the matrix is
+// required; errors of one question name the field "<key>.<question>".
+func (v *FieldValidator) validateMatrix(fieldName string, value any, rules *FieldValidation) []Error {
+	answers, ok := value.(map[string]any)
+	if !ok {
+		return []Error{{Field: fieldName, Message: "Invalid answers", Rule: "matrix"}}
+	}
+
+	var errors []Error
+
+	for _, question := range rules.Matrix.Questions {
+		if rules.Required && isEmptyValue(answers[question]) {
+			errors = append(errors, Error{Field: fieldName + "." + question, Message: "This question is required", Rule: "required"})
+		}
+	}
+
+	questions := make([]string, 0, len(answers))
+	for question := range answers {
+		questions = append(questions, question)
+	}
+
+	sort.Strings(questions)
+
+	for _, question := range questions {
+		answer := answers[question]
+		if isEmptyValue(answer) {
+			continue
+		}
+
+		if !containsString(rules.Matrix.Questions, question) {
+			errors = append(errors, Error{Field: fieldName + "." + question, Message: "Unknown question", Rule: "matrix"})
+
+			continue
+		}
+
+		if choice, isString := answer.(string); !isString ||
+			(len(rules.Matrix.Values) > 0 && !containsString(rules.Matrix.Values, choice)) {
+			errors = append(errors, Error{Field: fieldName + "." + question, Message: "Invalid option selected", Rule: "options"})
+		}
+	}
+
+	return errors
+}
+
+// validateAddress validates a postal address. The street, city and country
+// are required when the address is required or partly filled in, and so is
+// the postal code of a country with a postal code locale rule, which the code
+// must match. Errors of one part name the field "<key>.<part>".
+func (v *FieldValidator) validateAddress(fieldName string, value any, rules *FieldValidation) []Error {
+	address, ok := value.(map[string]any)
+	if !ok {
+		return []Error{{Field: fieldName, Message: "Invalid address", Rule: "address"}}
+	}
+
+	parts := make(map[string]string, len(model.AddressParts))
+	filled := false
+
+	for _, part := range model.AddressParts {
+		text, isString := address[part].(string)
+		if address[part] != nil && !isString {
+			return []Error{{Field: fieldName + "." + part, Message: "Invalid address", Rule: "address"}}
+		}
+
+		parts[part] = strings.TrimSpace(text)
+		filled = filled || parts[part] != ""
+	}
+
+	if !rules.Required && !filled {
+		return nil
+	}
+
+	var errors []Error
+
+	for _, part := range []string{model.AddressStreet, model.AddressCity, model.AddressCountry} {
+		if parts[part] == "" {
+			errors = append(errors, Error{Field: fieldName + "." + part, Message: "This field is required", Rule: "required"})
+		}
+	}
+
+	code := strings.ToUpper(parts[model.AddressCountry])
+	if code == "" {
+		return errors
+	}
+
+	if !countryCodePattern.MatchString(code) ||
+		(len(rules.Address.Countries) > 0 && !containsString(rules.Address.Countries, code)) {
+		return append(errors, Error{Field: fieldName + "." + model.AddressCountry, Message: "Country is not accepted", Rule: "country"})
+	}
+
+	rule, known := v.locale.Rule(LocalePostalCode, code)
+	if !known {
+		return errors
+	}
+
+	postalCode := parts[model.AddressPostalCode]
+	name := code
+
+	if country, ok := model.LookupCountry(code); ok {
+		name = country.Name
+	}
+
+	switch {
+	case postalCode == "":
+		errors = append(errors, Error{
+			Field: fieldName + "." + model.AddressPostalCode, Message: "This field is required", Rule: "required",
+		})
+	case !rule.Match(postalCode):
+		errors = append(errors, Error{
+			Field:   fieldName + "." + model.AddressPostalCode,
+			Message: fmt.Sprintf("Postal code is not valid for %s, e.g. %s", name, rule.Example),
+			Rule:    "postalCode",
+		})
+	}
+
+	return errors
+}
@@ -0,0 +1,154 @@
+package validation_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/goformx/goforms/internal/application/validation"
+	"github.com/goformx/goforms/internal/domain/form/model"
+)
+
+func libraryFormSchema() model.JSON {
+	return model.JSON{
+		"type": "object",
+		"components": []any{
+			map[string]any{"type": "rating", "key": "stars", "label": "Rating", "scale": float64(4)},
+			map[string]any{"type": "nps", "key": "nps", "label": "How likely are you to recommend us?"},
+			map[string]any{"type": "matrix", "key": "service", "label": "Service", "validate": map[string]any{"required": true},
+				"questions": []any{
+					map[string]any{"label": "Speed", "value": "speed"},
+					map[string]any{"label": "Quality", "value": "quality"},
+				},
+				"values": []any{
+					map[string]any{"label": "Good", "value": "good"},
+					map[string]any{"label": "Bad", "value": "bad"},
+				},
+			},
+			map[string]any{"type": "postaladdress", "key": "home", "label": "Address", "validate": map[string]any{"required": true}},
+			map[string]any{"type": "phone", "key": "mobile", "label": "Mobile"},
+		},
+	}
+}
+
+func validLibrarySubmission() model.JSON {
+	return model.JSON{
+		"stars":   float64(4),
+		"nps":     float64(0),
+		"service": map[string]any{"speed": "good", "quality": "bad"},
+		"home":    map[string]any{"street": "1 Main St", "city": "Springfield", "postalCode": "62701", "country": "US"},
+		"mobile":  "+14155550123",
+	}
+}
+
+func errorFields(result validation.Result) map[string]string {
+	fields := map[string]string{}
+	for _, err := range result.Errors {
+		fields[err.Field] = err.Rule
+	}
+
+	return fields
+}
+
+func TestValidateForm_FieldLibraryAcceptsValidAnswers(t *testing.T) {
+	result := validation.NewComprehensiveValidator().ValidateForm(libraryFormSchema(), validLibrarySubmission())
+
+	assert.True(t, result.IsValid, result.Errors)
+}
+
+func TestValidateForm_FieldLibraryRejectsInvalidAnswers(t *testing.T) {
+	tests := map[string]struct {
+		key   string
+		value any
+		want  map[string]string
+	}{
+		"rating above scale":    {"stars", float64(5), map[string]string{"stars": "scale"}},
+		"rating not whole":      {"stars", 2.5, map[string]string{"stars": "scale"}},
+		"nps below zero":        {"nps", float64(-1), map[string]string{"nps": "scale"}},
+		"nps as text":           {"nps", "11", map[string]string{"nps": "scale"}},
+		"matrix missing answer": {"service", map[string]any{"speed": "good"}, map[string]string{"service.quality": "required"}},
+		"matrix unknown value":  {"service", map[string]any{"speed": "good", "quality": "meh"}, map[string]string{"service.quality": "options"}},
+		"matrix unknown row":    {"service", map[string]any{"speed": "good", "quality": "bad", "x": "good"}, map[string]string{"service.x": "matrix"}},
+		"address missing city": {"home", map[string]any{"street": "1 Main St", "postalCode": "62701", "country": "US"},
+			map[string]string{"home.city": "required"}},
+		"address bad postcode": {"home", map[string]any{"street": "1 Main St", "city": "X", "postalCode": "6270", "country": "US"},
+			map[string]string{"home.postalCode": "postalCode"}},
+		"address bad country": {"home", map[string]any{"street": "1 Main St", "city": "X", "country": "USA"},
+			map[string]string{"home.country": "country"}},
+		"address not an object": {"home", "1 Main St", map[string]string{"home": "address"}},
+		"phone not e164":        {"mobile", "(415) 555-0123", map[string]string{"mobile": "phone"}},
+		"phone without country": {"mobile", "4155550123", map[string]string{"mobile": "phone"}},
+	}
+
+	for name, tt := range tests {
+		submission := validLibrarySubmission()
+		submission[tt.key] = tt.value
+
+		result := validation.NewComprehensiveValidator().ValidateForm(libraryFormSchema(), submission)
+		assert.False(t, result.IsValid, name)
+		assert.Equal(t, tt.want, errorFields(result), name)
+	}
+}
+
+func TestValidateForm_AddressUsesCountryFormats(t *testing.T) {
+	schema := libraryFormSchema()
+	submission := validLibrarySubmission()
+
+	for country, postalCode := range map[string]string{"GB": "SW1A 1AA", "CA": "K1A 0B1", "NL": "1012 AB", "JP": "100-0001"} {
+		submission["home"] = map[string]any{"street": "1 Main St", "city": "X", "postalCode": postalCode, "country": country}
+		assert.True(t, validation.NewComprehensiveValidator().ValidateForm(schema, submission).IsValid, country)
+	}
+
+	submission["home"] = map[string]any{"street": "1 Main St", "city": "Dubai", "country": "AE"}
+	assert.True(t, validation.NewComprehensiveValidator().ValidateForm(schema, submission).IsValid, "countries without postal codes")
+}
+
+func TestValidateForm_AddressCountriesLimitTheCountry(t *testing.T) {
+	schema := model.JSON{"components": []any{
+		map[string]any{"type": "postaladdress", "key": "home", "countries": []any{"de", "AT"}},
+	}}
+
+	valid := model.JSON{"home": map[string]any{"street": "Hauptstr. 1", "city": "Wien", "postalCode": "1010", "country": "AT"}}
+	assert.True(t, validation.NewComprehensiveValidator().ValidateForm(schema, valid).IsValid)
+
+	other := model.JSON{"home": map[string]any{"street": "1 Main St", "city": "X", "postalCode": "62701", "country": "US"}}
+	assert.Equal(t, map[string]string{"home.country": "country"}, errorFields(validation.NewComprehensiveValidator().ValidateForm(schema, other)))
+
+	empty := model.JSON{"home": map[string]any{"street": "", "city": "", "country": ""}}
+	assert.True(t, validation.NewComprehensiveValidator().ValidateForm(schema, empty).IsValid, "an optional address may be left empty")
+}
+
+func TestExtractFields_FieldLibrary(t *testing.T) {
+	fields, ok := validation.NewSchemaParser().ExtractFields(libraryFormSchema())
+	require.True(t, ok)
+	require.Len(t, fields, 5)
+
+	stars, nps, service, home, mobile := fields[0], fields[1], fields[2], fields[3], fields[4]
+
+	require.NotNil(t, stars.Constraints)
+	assert.InDelta(t, 1, *stars.Constraints.Min, 0)
+	assert.InDelta(t, 4, *stars.Constraints.Max, 0)
+
+	require.NotNil(t, nps.Constraints)
+	require.NotNil(t, nps.Constraints.Min, "the zero minimum of NPS is kept")
+	assert.InDelta(t, 10, *nps.Constraints.Max, 0)
+
+	assert.Equal(t, []validation.Option{{Label: "Speed", Value: "speed"}, {Label: "Quality", Value: "quality"}}, service.Questions)
+	assert.Len(t, service.Options, 2)
+
+	assert.Equal(t, model.AddressParts, home.Parts)
+	assert.Len(t, home.Options, len(model.Countries))
+
+	assert.Equal(t, "e164", mobile.Format)
+}
+
+func TestGenerateClientValidation_FieldLibrary(t *testing.T) {
+	rules, err := validation.NewComprehensiveValidator().GenerateClientValidation(libraryFormSchema())
+	require.NoError(t, err)
+
+	assert.Equal(t, 0, rules["nps"].(map[string]any)["min"])
+	assert.Equal(t, 4, rules["stars"].(map[string]any)["max"])
+	assert.Equal(t, model.E164Pattern.String(), rules["mobile"].(map[string]any)["pattern"])
+	assert.Equal(t, []string{"speed", "quality"}, rules["service"].(map[string]any)["questions"])
+}
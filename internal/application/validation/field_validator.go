@@ -2,8 +2,12 @@ package validation
 
 import (
 	"fmt"
+	"path"
 	"regexp"
 	"strconv"
+	"strings"
+
+	"github.com/goformx/goforms/internal/domain/form/model"
 )
 
 // FieldValidator handles field-specific validation logic
@@ -12,15 +16,26 @@ type FieldValidator struct {
 	urlRegex   *regexp.Regexp
 	phoneRegex *regexp.Regexp
 	dateRegex  *regexp.Regexp
+	locale     LocaleRuleProvider
 }
 
-// NewFieldValidator creates a new field validator
+// defaultLocaleRules are the locale rules of validators created without their own
+var defaultLocaleRules = NewLocaleRules()
+
+// NewFieldValidator creates a new field validator with the built-in locale rules
 func NewFieldValidator() *FieldValidator {
+	return NewFieldValidatorWithLocaleRules(defaultLocaleRules)
+}
+
+// NewFieldValidatorWithLocaleRules creates a field validator checking locale
+// formats against rules
+func NewFieldValidatorWithLocaleRules(rules LocaleRuleProvider) *FieldValidator {
 	return &FieldValidator{
 		emailRegex: regexp.MustCompile(`^[a-zA-Z0-9._%+-]+@[a-zA-Z0-9.-]+\.[a-zA-Z]{2,}$`),
 		urlRegex:   regexp.MustCompile(`^https?://[^\s/$.?#].\S*$`),
 		phoneRegex: regexp.MustCompile(`^\+?[1-9]\d{0,15}$`),
 		dateRegex:  regexp.MustCompile(`^\d{4}-\d{2}-\d{2}$`),
+		locale:     rules,
 	}
 }
 
@@ -34,10 +49,24 @@ func (v *FieldValidator) ValidateField(fieldName string, value any, rules *Field
 	}
 
 	// Skip further validation if field is empty and not required
-	if value == nil || value == "" {
+	if isEmptyValue(value) {
 		return errors
 	}
 
+	// File uploads carry metadata objects rather than scalar values
+	if rules.File != nil {
+		return append(errors, v.validateFiles(fieldName, value, rules.File)...)
+	}
+
+	// Matrices and addresses are objects checked part by part
+	if rules.Matrix != nil {
+		return append(errors, v.validateMatrix(fieldName, value, rules)...)
+	}
+
+	if rules.Address != nil {
+		return append(errors, v.validateAddress(fieldName, value, rules)...)
+	}
+
 	// Type validation
 	if typeErrors := v.ValidateFieldType(fieldName, value, rules.Type); typeErrors != nil {
 		errors = append(errors, *typeErrors)
@@ -48,6 +77,11 @@ func (v *FieldValidator) ValidateField(fieldName string, value any, rules *Field
 		errors = append(errors, strErrors...)
 	}
 
+	// Rating and NPS scale validation
+	if scaleError := v.validateScale(fieldName, value, rules.Scale); scaleError != nil {
+		errors = append(errors, *scaleError)
+	}
+
 	// Numeric validations
 	if numErrors := v.validateNumericField(fieldName, value, rules); len(numErrors) > 0 {
 		errors = append(errors, numErrors...)
@@ -58,8 +92,13 @@ func (v *FieldValidator) ValidateField(fieldName string, value any, rules *Field
 		errors = append(errors, patternErrors...)
 	}
 
+	// Locale-specific format validation
+	if localeError := v.validateLocaleFormat(fieldName, value, rules.Locale); localeError != nil {
+		errors = append(errors, *localeError)
+	}
+
 	// Options validation
-	if optionsErrors := v.validateOptions(fieldName, value, rules.Options); len(optionsErrors) > 0 {
+	if optionsErrors := v.validateOptions(fieldName, value, rules); len(optionsErrors) > 0 {
 		errors = append(errors, optionsErrors...)
 	}
 
@@ -73,7 +112,12 @@ func (v *FieldValidator) ValidateField(fieldName string, value any, rules *Field
 
 // validateRequired validates if a required field has a value
 func (v *FieldValidator) validateRequired(fieldName string, value any, rules *FieldValidation) []Error {
-	if rules.Required && (value == nil || value == "") {
+	if !rules.Required {
+		return nil
+	}
+
+	// A required checkbox must be ticked, not merely present
+	if checked, ok := value.(bool); (ok && !checked && rules.Type == "checkbox") || isEmptyValue(value) {
 		return []Error{{
 			Field:   fieldName,
 			Message: rules.getMessage("required", "This field is required"),
@@ -162,29 +206,142 @@ func (v *FieldValidator) validatePattern(fieldName string, value any, pattern st
 	return nil
 }
 
-// validateOptions validates that a value is in the allowed options
-func (v *FieldValidator) validateOptions(fieldName string, value any, options []string) []Error {
-	if len(options) == 0 {
+// validateOptions validates that a value is in the allowed options.
+// Both option labels and option values are accepted, and multi-value
+// components (multiple selects, selectboxes) are checked per selection.
+func (v *FieldValidator) validateOptions(fieldName string, value any, rules *FieldValidation) []Error {
+	if len(rules.Options) == 0 && len(rules.Values) == 0 {
 		return nil
 	}
 
-	if strValue, ok := value.(string); ok {
-		for _, option := range options {
-			if strValue == option {
-				return nil
+	var selected []string
+
+	switch val := value.(type) {
+	case string:
+		selected = []string{val}
+	case []any:
+		for _, item := range val {
+			if strItem, ok := item.(string); ok {
+				selected = append(selected, strItem)
+			}
+		}
+	case map[string]any:
+		for key, checked := range val {
+			if isChecked, ok := checked.(bool); ok && isChecked {
+				selected = append(selected, key)
 			}
 		}
+	}
 
-		return []Error{{
+	for _, choice := range selected {
+		if !containsString(rules.Values, choice) && !containsString(rules.Options, choice) {
+			return []Error{{
+				Field:   fieldName,
+				Message: rules.getMessage("options", "Invalid option selected"),
+				Rule:    "options",
+			}}
+		}
+	}
+
+	return nil
+}
+
+// validateFiles validates uploaded file metadata against size and type constraints
+func (v *FieldValidator) validateFiles(fieldName string, value any, rules *FileRules) []Error {
+	files, ok := value.([]any)
+	if !ok {
+		files = []any{value}
+	}
+
+	var errors []Error
+
+	for _, file := range files {
+		fileMap, fileOk := file.(map[string]any)
+		if !fileOk {
+			return []Error{{
+				Field:   fieldName,
+				Message: "Invalid file upload",
+				Rule:    "file",
+			}}
+		}
+
+		if sizeErr := v.validateFileSize(fieldName, fileMap, rules); sizeErr != nil {
+			errors = append(errors, *sizeErr)
+		}
+
+		if patternErr := v.validateFilePattern(fieldName, fileMap, rules.Pattern); patternErr != nil {
+			errors = append(errors, *patternErr)
+		}
+	}
+
+	return errors
+}
+
+// validateFileSize validates the size reported for an uploaded file
+func (v *FieldValidator) validateFileSize(fieldName string, file map[string]any, rules *FileRules) *Error {
+	size, ok := v.toFloat64(file["size"])
+	if !ok {
+		return nil
+	}
+
+	if rules.MinSize > 0 && int64(size) < rules.MinSize {
+		return &Error{
 			Field:   fieldName,
-			Message: "Invalid option selected",
-			Rule:    "options",
-		}}
+			Message: fmt.Sprintf("File must be at least %d bytes", rules.MinSize),
+			Rule:    "fileMinSize",
+		}
+	}
+
+	if rules.MaxSize > 0 && int64(size) > rules.MaxSize {
+		return &Error{
+			Field:   fieldName,
+			Message: fmt.Sprintf("File must not exceed %d bytes", rules.MaxSize),
+			Rule:    "fileMaxSize",
+		}
 	}
 
 	return nil
 }
 
+// validateFilePattern validates an uploaded file's MIME type or extension.
+// Patterns follow the HTML accept syntax: ".pdf", "image/png" or "image/*".
+func (v *FieldValidator) validateFilePattern(fieldName string, file map[string]any, patterns []string) *Error {
+	if len(patterns) == 0 {
+		return nil
+	}
+
+	mimeType, _ := file["type"].(string)
+	name, _ := file["originalName"].(string)
+
+	if name == "" {
+		name, _ = file["name"].(string)
+	}
+
+	mimeType = strings.ToLower(mimeType)
+	extension := strings.ToLower(path.Ext(name))
+
+	for _, pattern := range patterns {
+		switch {
+		case strings.HasPrefix(pattern, "."):
+			if extension == pattern {
+				return nil
+			}
+		case strings.HasSuffix(pattern, "/*"):
+			if strings.HasPrefix(mimeType, strings.TrimSuffix(pattern, "*")) {
+				return nil
+			}
+		case mimeType == pattern:
+			return nil
+		}
+	}
+
+	return &Error{
+		Field:   fieldName,
+		Message: "File type is not allowed",
+		Rule:    "filePattern",
+	}
+}
+
 // validateCustomRules validates custom validation rules
 func (v *FieldValidator) validateCustomRules(fieldName string, value any, rules []Rule) []Error {
 	var errors []Error
@@ -207,6 +364,8 @@ func (v *FieldValidator) ValidateFieldType(fieldName string, value any, fieldTyp
 		return v.validateURL(fieldName, value)
 	case "phoneNumber":
 		return v.validatePhoneNumber(fieldName, value)
+	case model.FieldPhone:
+		return v.validateE164(fieldName, value)
 	case "date":
 		return v.validateDate(fieldName, value)
 	case "number":
@@ -376,3 +535,36 @@ func (v *FieldValidator) toFloat64(value any) (float64, bool) {
 
 	return 0, false
 }
+
+// isEmptyValue reports whether a submitted value should be treated as missing
+func isEmptyValue(value any) bool {
+	switch val := value.(type) {
+	case nil:
+		return true
+	case string:
+		return strings.TrimSpace(val) == ""
+	case []any:
+		return len(val) == 0
+	case map[string]any:
+		for _, checked := range val {
+			if isChecked, ok := checked.(bool); !ok || isChecked {
+				return false
+			}
+		}
+
+		return true
+	}
+
+	return false
+}
+
+// containsString reports whether a slice contains the given string
+func containsString(values []string, target string) bool {
+	for _, value := range values {
+		if value == target {
+			return true
+		}
+	}
+
+	return false
+}
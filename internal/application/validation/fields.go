@@ -0,0 +1,175 @@
+package validation
+
+import "github.com/goformx/goforms/internal/domain/form/model"
+
+// Field is a normalized description of one input component of a form schema,
+// for renderers and tooling that should not depend on the builder's JSON.
+type Field struct {
+	Key         string       `json:"key"`
+	Type        string       `json:"type"`
+	Label       string       `json:"label"`
+	Description string       `json:"description,omitempty"`
+	Placeholder string       `json:"placeholder,omitempty"`
+	Required    bool         `json:"required"`
+	Multiple    bool         `json:"multiple"`
+	Constraints *Constraints `json:"constraints,omitempty"`
+	Options     []Option     `json:"options,omitempty"`
+	// Format names the value format of a field whose type implies one, e.g. "e164"
+	Format string `json:"format,omitempty"`
+	// Questions are the rows of a matrix; Options are its columns
+	Questions []Option `json:"questions,omitempty"`
+	// Parts are the keys of an address value; Options are its countries
+	Parts []string `json:"parts,omitempty"`
+}
+
+// Constraints holds the limits set on a field. Unset limits are omitted,
+// so a zero minimum is distinguishable from no minimum.
+type Constraints struct {
+	MinLength *int       `json:"min_length,omitempty"`
+	MaxLength *int       `json:"max_length,omitempty"`
+	Min       *float64   `json:"min,omitempty"`
+	Max       *float64   `json:"max,omitempty"`
+	Pattern   string     `json:"pattern,omitempty"`
+	File      *FileRules `json:"file,omitempty"`
+}
+
+// Option is one choice of a select, radio or selectboxes field
+type Option struct {
+	Label string `json:"label"`
+	Value any    `json:"value"`
+}
+
+// ExtractFields flattens a form schema into normalized fields in display order.
+// Layout components are descended into and buttons are skipped.
+func (p *SchemaParser) ExtractFields(schema map[string]any) ([]Field, bool) {
+	components, ok := p.ExtractInputComponents(schema)
+	if !ok {
+		return nil, false
+	}
+
+	fields := make([]Field, 0, len(components))
+
+	for _, component := range components {
+		key, keyOk := p.ExtractComponentKey(component)
+		if !keyOk || key == "" {
+			continue
+		}
+
+		fields = append(fields, p.buildField(key, component))
+	}
+
+	return fields, true
+}
+
+// buildField normalizes a single input component
+func (p *SchemaParser) buildField(key string, component map[string]any) Field {
+	rules := p.ExtractValidationRules(component)
+
+	field := Field{
+		Key:      key,
+		Type:     rules.Type,
+		Required: rules.Required,
+		Multiple: rules.Multiple,
+		Options:  p.extractFieldOptions(component),
+	}
+
+	field.Label, _ = component["label"].(string)
+	field.Description, _ = component["description"].(string)
+	field.Placeholder, _ = component["placeholder"].(string)
+
+	constraints := p.extractConstraints(component)
+	constraints.File = rules.File
+
+	if rules.Scale != nil {
+		minVal, maxVal := float64(rules.Scale.Min), float64(rules.Scale.Max)
+		constraints.Min, constraints.Max = &minVal, &maxVal
+	}
+
+	if *constraints != (Constraints{}) {
+		field.Constraints = constraints
+	}
+
+	p.describeLibraryField(&field, component)
+
+	return field
+}
+
+// describeLibraryField adds what the field library types need beyond the
+// common properties
+func (p *SchemaParser) describeLibraryField(field *Field, component map[string]any) {
+	switch field.Type {
+	case model.FieldPhone:
+		field.Format = "e164"
+	case model.FieldMatrix, "survey":
+		field.Questions = p.extractQuestions(component)
+	case model.FieldAddress:
+		countries := model.AddressCountries(component)
+		field.Parts = model.AddressParts
+		field.Options = make([]Option, 0, len(countries))
+
+		for _, country := range countries {
+			field.Options = append(field.Options, Option{Label: country.Name, Value: country.Code})
+		}
+	}
+}
+
+// extractConstraints reads the limits configured under a component's validate key
+func (p *SchemaParser) extractConstraints(component map[string]any) *Constraints {
+	constraints := &Constraints{}
+
+	validate, ok := component["validate"].(map[string]any)
+	if !ok {
+		return constraints
+	}
+
+	if minLength, minLengthOk := toNumber(validate["minLength"]); minLengthOk {
+		value := int(minLength)
+		constraints.MinLength = &value
+	}
+
+	if maxLength, maxLengthOk := toNumber(validate["maxLength"]); maxLengthOk {
+		value := int(maxLength)
+		constraints.MaxLength = &value
+	}
+
+	if minVal, minOk := toNumber(validate["min"]); minOk {
+		constraints.Min = &minVal
+	}
+
+	if maxVal, maxOk := toNumber(validate["max"]); maxOk {
+		constraints.Max = &maxVal
+	}
+
+	constraints.Pattern, _ = validate["pattern"].(string)
+
+	return constraints
+}
+
+// extractFieldOptions returns a component's choices, keeping label and value paired
+func (p *SchemaParser) extractFieldOptions(component map[string]any) []Option {
+	values, ok := component["values"].([]any)
+	if !ok {
+		data, dataOk := component["data"].(map[string]any)
+		if !dataOk {
+			return nil
+		}
+
+		if values, ok = data["values"].([]any); !ok {
+			return nil
+		}
+	}
+
+	options := make([]Option, 0, len(values))
+
+	for _, value := range values {
+		valueMap, valueOk := value.(map[string]any)
+		if !valueOk {
+			continue
+		}
+
+		label, _ := valueMap["label"].(string)
+		options = append(options, Option{Label: label, Value: valueMap["value"]})
+	}
+
+	return options
+}
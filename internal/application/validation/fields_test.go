@@ -0,0 +1,74 @@
+package validation_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/goformx/goforms/internal/application/validation"
+)
+
+func TestSchemaParser_ExtractFields(t *testing.T) {
+	schema := map[string]any{
+		"display": "form",
+		"components": []any{
+			map[string]any{
+				"type":  "panel",
+				"key":   "contact",
+				"title": "Contact",
+				"components": []any{
+					map[string]any{
+						"type":        "textfield",
+						"key":         "name",
+						"label":       "Name",
+						"placeholder": "Jane Doe",
+						"validate":    map[string]any{"required": true, "minLength": float64(2), "maxLength": float64(40)},
+					},
+				},
+			},
+			map[string]any{
+				"type":     "number",
+				"key":      "age",
+				"label":    "Age",
+				"validate": map[string]any{"min": float64(0), "max": float64(120)},
+			},
+			map[string]any{
+				"type":  "select",
+				"key":   "size",
+				"label": "Size",
+				"data": map[string]any{"values": []any{
+					map[string]any{"label": "Small", "value": "s"},
+					map[string]any{"label": "Large", "value": "l"},
+				}},
+			},
+			map[string]any{"type": "button", "key": "submit", "label": "Submit"},
+		},
+	}
+
+	fields, ok := validation.NewSchemaParser().ExtractFields(schema)
+	require.True(t, ok)
+	require.Len(t, fields, 3)
+
+	name := fields[0]
+	assert.Equal(t, "name", name.Key)
+	assert.Equal(t, "textfield", name.Type)
+	assert.Equal(t, "Jane Doe", name.Placeholder)
+	assert.True(t, name.Required)
+	require.NotNil(t, name.Constraints)
+	assert.Equal(t, 2, *name.Constraints.MinLength)
+	assert.Equal(t, 40, *name.Constraints.MaxLength)
+
+	age := fields[1]
+	require.NotNil(t, age.Constraints)
+	require.NotNil(t, age.Constraints.Min, "a zero minimum is kept")
+	assert.InDelta(t, 0, *age.Constraints.Min, 0)
+	assert.InDelta(t, 120, *age.Constraints.Max, 0)
+
+	size := fields[2]
+	assert.Nil(t, size.Constraints)
+	assert.Equal(t, []validation.Option{{Label: "Small", Value: "s"}, {Label: "Large", Value: "l"}}, size.Options)
+
+	_, ok = validation.NewSchemaParser().ExtractFields(map[string]any{})
+	assert.False(t, ok)
+}
@@ -0,0 +1,238 @@
+package validation
+
+import (
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// Locale formats a field can be validated against, set on a component as
+//
+//	{"type": "textfield", "key": "vat", "localeFormat": {"format": "vatId", "country": "DE"}}
+//
+// or, to follow the country chosen in another field of the submission (a
+// top-level key or an address part such as "billing.country"),
+//
+//	{"localeFormat": {"format": "postalCode", "countryField": "billing.country"}}
+const (
+	LocalePostalCode = "postalCode"
+	LocalePhone      = "phone"
+	LocaleVATID      = "vatId"
+)
+
+// LocaleRule is the format of one kind of value in one country. Values are
+// normalized before they are matched: postal codes are upper-cased, phone
+// numbers lose separators and national numbers get the country calling code,
+// and VAT IDs lose separators and get the country prefix when entered without it.
+type LocaleRule struct {
+	Format  string
+	Country string
+	Pattern *regexp.Regexp
+	// Example is a valid value, shown in error messages
+	Example string
+
+	// prefix is the VAT ID prefix or the "+" and calling code of phone numbers
+	prefix string
+	// trunk is the national prefix dropped from phone numbers dialled at home
+	trunk string
+}
+
+// Normalize returns value in the form Pattern matches
+func (r LocaleRule) Normalize(value string) string {
+	value = strings.ToUpper(strings.TrimSpace(value))
+
+	switch r.Format {
+	case LocalePhone:
+		value = strings.NewReplacer(" ", "", "-", "", ".", "", "(", "", ")", "", "/", "").Replace(value)
+		if rest, ok := strings.CutPrefix(value, "00"); ok {
+			return "+" + rest
+		}
+
+		if strings.HasPrefix(value, "+") {
+			return value
+		}
+
+		if r.trunk != "" {
+			value = strings.TrimPrefix(value, r.trunk)
+		}
+
+		return r.prefix + value
+	case LocaleVATID:
+		value = strings.NewReplacer(" ", "", "-", "", ".", "").Replace(value)
+		if !strings.HasPrefix(value, r.prefix) {
+			value = r.prefix + value
+		}
+
+		return value
+	}
+
+	return strings.Join(strings.Fields(value), " ")
+}
+
+// Match reports whether value has the format once normalized
+func (r LocaleRule) Match(value string) bool {
+	return r.Pattern.MatchString(r.Normalize(value))
+}
+
+// LocaleRuleProvider supplies the locale-specific formats fields are validated
+// against, to the server-side validator and to the client rules of the
+// /validation endpoints
+type LocaleRuleProvider interface {
+	// Rule returns the rule of a format in a country
+	Rule(format, country string) (LocaleRule, bool)
+	// Rules returns the rules of a format for every country that has one, by country code
+	Rules(format string) []LocaleRule
+}
+
+// localeFormat is an entry of the built-in format tables
+type localeFormat struct {
+	pattern, example, prefix, trunk string
+}
+
+// postalCodeFormats lists the postal code formats of countries that have them
+var postalCodeFormats = map[string]localeFormat{
+	"AT": {pattern: `^\d{4}$`, example: "1010"},
+	"AU": {pattern: `^\d{4}$`, example: "2000"},
+	"BE": {pattern: `^\d{4}$`, example: "1000"},
+	"BR": {pattern: `^\d{5}-?\d{3}$`, example: "01310-100"},
+	"CA": {pattern: `^[ABCEGHJ-NPRSTVXY]\d[ABCEGHJ-NPRSTV-Z] ?\d[ABCEGHJ-NPRSTV-Z]\d$`, example: "K1A 0B1"},
+	"CH": {pattern: `^\d{4}$`, example: "8001"},
+	"DE": {pattern: `^\d{5}$`, example: "10115"},
+	"DK": {pattern: `^\d{4}$`, example: "1050"},
+	"ES": {pattern: `^\d{5}$`, example: "28001"},
+	"FR": {pattern: `^\d{5}$`, example: "75001"},
+	"GB": {pattern: `^[A-Z]{1,2}\d[A-Z\d]? ?\d[A-Z]{2}$`, example: "SW1A 1AA"},
+	"IE": {pattern: `^[A-Z]\d[\dW] ?[0-9A-Z]{4}$`, example: "D02 X285"},
+	"IN": {pattern: `^\d{6}$`, example: "110001"},
+	"IT": {pattern: `^\d{5}$`, example: "00118"},
+	"JP": {pattern: `^\d{3}-?\d{4}$`, example: "100-0001"},
+	"MX": {pattern: `^\d{5}$`, example: "06000"},
+	"NL": {pattern: `^\d{4} ?[A-Z]{2}$`, example: "1012 AB"},
+	"NO": {pattern: `^\d{4}$`, example: "0150"},
+	"NZ": {pattern: `^\d{4}$`, example: "6011"},
+	"PL": {pattern: `^\d{2}-\d{3}$`, example: "00-001"},
+	"PT": {pattern: `^\d{4}-\d{3}$`, example: "1000-001"},
+	"SE": {pattern: `^\d{3} ?\d{2}$`, example: "111 22"},
+	"SG": {pattern: `^\d{6}$`, example: "018956"},
+	"US": {pattern: `^\d{5}(-\d{4})?$`, example: "20500"},
+	"ZA": {pattern: `^\d{4}$`, example: "8001"},
+}
+
+// phoneFormats lists the E.164 numbers of each country, with the calling code
+// and the trunk prefix of its national numbers
+var phoneFormats = map[string]localeFormat{
+	"AE": {pattern: `^\+971[2-9]\d{7,8}$`, example: "+97141234567", prefix: "+971", trunk: "0"},
+	"AT": {pattern: `^\+43[1-9]\d{3,12}$`, example: "+4311234567", prefix: "+43", trunk: "0"},
+	"AU": {pattern: `^\+61[2-478]\d{8}$`, example: "+61212345678", prefix: "+61", trunk: "0"},
+	"BE": {pattern: `^\+32[1-9]\d{7,8}$`, example: "+3221234567", prefix: "+32", trunk: "0"},
+	"BR": {pattern: `^\+55[1-9]{2}9?\d{8}$`, example: "+5511912345678", prefix: "+55", trunk: "0"},
+	"CA": {pattern: `^\+1[2-9]\d{2}[2-9]\d{6}$`, example: "+16135550123", prefix: "+1", trunk: "1"},
+	"CH": {pattern: `^\+41[1-9]\d{8}$`, example: "+41441234567", prefix: "+41", trunk: "0"},
+	"DE": {pattern: `^\+49[1-9]\d{5,13}$`, example: "+49301234567", prefix: "+49", trunk: "0"},
+	"DK": {pattern: `^\+45[2-9]\d{7}$`, example: "+4532123456", prefix: "+45"},
+	"ES": {pattern: `^\+34[6-9]\d{8}$`, example: "+34912345678", prefix: "+34"},
+	"FR": {pattern: `^\+33[1-9]\d{8}$`, example: "+33123456789", prefix: "+33", trunk: "0"},
+	"GB": {pattern: `^\+44[1-9]\d{8,9}$`, example: "+442071234567", prefix: "+44", trunk: "0"},
+	"HK": {pattern: `^\+852[2-9]\d{7}$`, example: "+85221234567", prefix: "+852"},
+	"IE": {pattern: `^\+353[1-9]\d{6,8}$`, example: "+35311234567", prefix: "+353", trunk: "0"},
+	"IN": {pattern: `^\+91[6-9]\d{9}$`, example: "+919812345678", prefix: "+91", trunk: "0"},
+	"IT": {pattern: `^\+39\d{6,11}$`, example: "+390612345678", prefix: "+39"},
+	"JP": {pattern: `^\+81[1-9]\d{8,9}$`, example: "+81312345678", prefix: "+81", trunk: "0"},
+	"MX": {pattern: `^\+52\d{10}$`, example: "+525512345678", prefix: "+52"},
+	"NL": {pattern: `^\+31[1-9]\d{8}$`, example: "+31201234567", prefix: "+31", trunk: "0"},
+	"NO": {pattern: `^\+47[2-9]\d{7}$`, example: "+4721234567", prefix: "+47"},
+	"NZ": {pattern: `^\+64[2-9]\d{7,9}$`, example: "+6491234567", prefix: "+64", trunk: "0"},
+	"PL": {pattern: `^\+48[1-9]\d{8}$`, example: "+48221234567", prefix: "+48"},
+	"PT": {pattern: `^\+351[29]\d{8}$`, example: "+351212345678", prefix: "+351"},
+	"SE": {pattern: `^\+46[1-9]\d{6,9}$`, example: "+4681234567", prefix: "+46", trunk: "0"},
+	"SG": {pattern: `^\+65[689]\d{7}$`, example: "+6561234567", prefix: "+65"},
+	"US": {pattern: `^\+1[2-9]\d{2}[2-9]\d{6}$`, example: "+12025550123", prefix: "+1", trunk: "1"},
+	"ZA": {pattern: `^\+27[1-9]\d{8}$`, example: "+27211234567", prefix: "+27", trunk: "0"},
+}
+
+// vatIDFormats lists the VAT ID formats of the EU member states and of
+// countries with a comparable business tax ID. Only the format is checked,
+// not the check digits or whether the ID is registered.
+var vatIDFormats = map[string]localeFormat{
+	"AT": {pattern: `^ATU\d{8}$`, example: "ATU12345678", prefix: "AT"},
+	"AU": {pattern: `^\d{11}$`, example: "51824753556"},
+	"BE": {pattern: `^BE[01]\d{9}$`, example: "BE0123456789", prefix: "BE"},
+	"CH": {pattern: `^CHE\d{9}(MWST|TVA|IVA)?$`, example: "CHE123456789MWST", prefix: "CHE"},
+	"DE": {pattern: `^DE\d{9}$`, example: "DE123456789", prefix: "DE"},
+	"DK": {pattern: `^DK\d{8}$`, example: "DK12345678", prefix: "DK"},
+	"ES": {pattern: `^ES[A-Z0-9]\d{7}[A-Z0-9]$`, example: "ESX1234567X", prefix: "ES"},
+	"FI": {pattern: `^FI\d{8}$`, example: "FI12345678", prefix: "FI"},
+	"FR": {pattern: `^FR[A-HJ-NP-Z0-9]{2}\d{9}$`, example: "FR12345678901", prefix: "FR"},
+	"GB": {pattern: `^GB(\d{9}|\d{12}|GD[0-4]\d{2}|HA[5-9]\d{2})$`, example: "GB123456789", prefix: "GB"},
+	"GR": {pattern: `^EL\d{9}$`, example: "EL123456789", prefix: "EL"},
+	"IE": {pattern: `^IE(\d{7}[A-W][A-I]?|\d[A-Z+*]\d{5}[A-W])$`, example: "IE1234567T", prefix: "IE"},
+	"IT": {pattern: `^IT\d{11}$`, example: "IT12345678901", prefix: "IT"},
+	"LU": {pattern: `^LU\d{8}$`, example: "LU12345678", prefix: "LU"},
+	"NL": {pattern: `^NL\d{9}B\d{2}$`, example: "NL123456789B01", prefix: "NL"},
+	"NO": {pattern: `^NO\d{9}(MVA)?$`, example: "NO123456789MVA", prefix: "NO"},
+	"PL": {pattern: `^PL\d{10}$`, example: "PL1234567890", prefix: "PL"},
+	"PT": {pattern: `^PT\d{9}$`, example: "PT123456789", prefix: "PT"},
+	"SE": {pattern: `^SE\d{10}01$`, example: "SE123456789001", prefix: "SE"},
+}
+
+// LocaleRules is the built-in LocaleRuleProvider
+type LocaleRules struct {
+	rules map[string]map[string]LocaleRule
+}
+
+// NewLocaleRules creates the built-in locale rules
+func NewLocaleRules() *LocaleRules {
+	tables := map[string]map[string]localeFormat{
+		LocalePostalCode: postalCodeFormats,
+		LocalePhone:      phoneFormats,
+		LocaleVATID:      vatIDFormats,
+	}
+
+	rules := make(map[string]map[string]LocaleRule, len(tables))
+
+	for format, table := range tables {
+		rules[format] = make(map[string]LocaleRule, len(table))
+
+		for country, entry := range table {
+			rules[format][country] = LocaleRule{
+				Format:  format,
+				Country: country,
+				Pattern: regexp.MustCompile(entry.pattern),
+				Example: entry.example,
+				prefix:  entry.prefix,
+				trunk:   entry.trunk,
+			}
+		}
+	}
+
+	return &LocaleRules{rules: rules}
+}
+
+// Rule returns the rule of a format in a country
+func (l *LocaleRules) Rule(format, country string) (LocaleRule, bool) {
+	rule, ok := l.rules[format][strings.ToUpper(strings.TrimSpace(country))]
+
+	return rule, ok
+}
+
+// Rules returns the rules of a format for every country that has one, by country code
+func (l *LocaleRules) Rules(format string) []LocaleRule {
+	rules := make([]LocaleRule, 0, len(l.rules[format]))
+	for _, rule := range l.rules[format] {
+		rules = append(rules, rule)
+	}
+
+	sort.Slice(rules, func(i, j int) bool { return rules[i].Country < rules[j].Country })
+
+	return rules
+}
+
+// IsLocaleFormat reports whether format is a known locale format
+func IsLocaleFormat(format string) bool {
+	switch format {
+	case LocalePostalCode, LocalePhone, LocaleVATID:
+		return true
+	}
+
+	return false
+}
@@ -0,0 +1,126 @@
+package validation_test
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/goformx/goforms/internal/application/validation"
+	"github.com/goformx/goforms/internal/domain/form/model"
+)
+
+func TestLocaleRules_ExamplesMatch(t *testing.T) {
+	rules := validation.NewLocaleRules()
+
+	for _, format := range []string{validation.LocalePostalCode, validation.LocalePhone, validation.LocaleVATID} {
+		list := rules.Rules(format)
+		require.NotEmpty(t, list, format)
+
+		for _, rule := range list {
+			assert.True(t, rule.Match(rule.Example), "%s %s %s", format, rule.Country, rule.Example)
+		}
+	}
+}
+
+func TestLocaleRules_Normalize(t *testing.T) {
+	rules := validation.NewLocaleRules()
+
+	tests := []struct {
+		format, country, value, want string
+	}{
+		{validation.LocalePhone, "DE", "030 1234567", "+49301234567"},
+		{validation.LocalePhone, "US", "(202) 555-0123", "+12025550123"},
+		{validation.LocalePhone, "US", "1-202-555-0123", "+12025550123"},
+		{validation.LocalePhone, "GB", "0044 20 7123 4567", "+442071234567"},
+		{validation.LocalePhone, "IT", "06 1234 5678", "+390612345678"},
+		{validation.LocaleVATID, "DE", "de 123.456.789", "DE123456789"},
+		{validation.LocaleVATID, "DE", "123456789", "DE123456789"},
+		{validation.LocaleVATID, "AT", "U12345678", "ATU12345678"},
+		{validation.LocalePostalCode, "GB", " sw1a  1aa ", "SW1A 1AA"},
+	}
+
+	for _, tt := range tests {
+		rule, ok := rules.Rule(tt.format, tt.country)
+		require.True(t, ok, tt.country)
+		assert.Equal(t, tt.want, rule.Normalize(tt.value), tt.value)
+		assert.True(t, rule.Match(tt.value), tt.value)
+	}
+
+	rule, _ := rules.Rule(validation.LocalePhone, "DE")
+	assert.False(t, rule.Match("+12025550123"), "numbers of other countries do not match")
+
+	_, ok := rules.Rule(validation.LocaleVATID, "US")
+	assert.False(t, ok)
+}
+
+func localeFormSchema() model.JSON {
+	return model.JSON{"components": []any{
+		map[string]any{"type": "textfield", "key": "country"},
+		map[string]any{"type": "textfield", "key": "vat", "localeFormat": map[string]any{"format": "vatId", "country": "nl"}},
+		map[string]any{"type": "textfield", "key": "zip", "localeFormat": map[string]any{"format": "postalCode", "countryField": "country"}},
+		map[string]any{"type": "textfield", "key": "tel", "localeFormat": map[string]any{"format": "phone", "countryField": "billing.country"}},
+	}}
+}
+
+func TestValidateForm_LocaleFormats(t *testing.T) {
+	validator := validation.NewComprehensiveValidator()
+
+	valid := model.JSON{
+		"country": "us", "vat": "NL 123456789 B01", "zip": "20500-0001",
+		"billing": map[string]any{"country": "FR"}, "tel": "01 23 45 67 89",
+	}
+	result := validator.ValidateForm(localeFormSchema(), valid)
+	assert.True(t, result.IsValid, result.Errors)
+
+	invalid := model.JSON{
+		"country": "US", "vat": "DE123456789", "zip": "SW1A 1AA",
+		"billing": map[string]any{"country": "FR"}, "tel": "+12025550123",
+	}
+	assert.Equal(t, map[string]string{"vat": "vatId", "zip": "postalCode", "tel": "phone"},
+		errorFields(validator.ValidateForm(localeFormSchema(), invalid)))
+
+	unknown := model.JSON{"country": "XK", "vat": "NL123456789B01", "zip": "anything", "tel": "12"}
+	assert.True(t, validator.ValidateForm(localeFormSchema(), unknown).IsValid,
+		"values are accepted when the country has no rule or none was chosen")
+}
+
+func TestGenerateClientValidation_LocaleFormats(t *testing.T) {
+	rules, err := validation.NewComprehensiveValidator().GenerateClientValidation(localeFormSchema())
+	require.NoError(t, err)
+
+	vat, ok := rules["vat"].(map[string]any)["locale"].(map[string]any)
+	require.True(t, ok)
+	assert.Equal(t, "vatId", vat["format"])
+	assert.Equal(t, "NL", vat["country"])
+	assert.Len(t, vat["patterns"], 1)
+
+	zip := rules["zip"].(map[string]any)["locale"].(map[string]any)
+	assert.Equal(t, "country", zip["countryField"])
+	assert.Contains(t, zip["patterns"], "US")
+	assert.Contains(t, zip["patterns"], "GB")
+
+	assert.NotContains(t, rules["country"], "locale")
+}
+
+// fixedLocaleRules accepts only one value for every format and country
+type fixedLocaleRules struct{}
+
+func (fixedLocaleRules) Rule(format, country string) (validation.LocaleRule, bool) {
+	return validation.LocaleRule{Format: format, Country: country, Pattern: regexp.MustCompile(`^OK$`), Example: "OK"}, true
+}
+
+func (r fixedLocaleRules) Rules(format string) []validation.LocaleRule {
+	rule, _ := r.Rule(format, "ZZ")
+
+	return []validation.LocaleRule{rule}
+}
+
+func TestValidateForm_CustomLocaleRules(t *testing.T) {
+	validator := validation.NewComprehensiveValidatorWithLocaleRules(fixedLocaleRules{})
+
+	assert.True(t, validator.ValidateForm(localeFormSchema(), model.JSON{"country": "ZZ", "vat": "ok", "zip": "OK"}).IsValid)
+	assert.Equal(t, map[string]string{"zip": "postalCode"},
+		errorFields(validator.ValidateForm(localeFormSchema(), model.JSON{"country": "ZZ", "zip": "20500"})))
+}
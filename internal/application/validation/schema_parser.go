@@ -1,5 +1,12 @@
 package validation
 
+import (
+	"strconv"
+	"strings"
+
+	"github.com/goformx/goforms/internal/domain/form/model"
+)
+
 // SchemaParser handles parsing and extracting validation rules from form schemas
 type SchemaParser struct{}
 
@@ -19,6 +26,13 @@ func (p *SchemaParser) ExtractValidationRules(component map[string]any) FieldVal
 		Max:         0,
 		Pattern:     "",
 		Options:     []string{},
+		Values:      []string{},
+		Multiple:    false,
+		File:        nil,
+		Scale:       nil,
+		Matrix:      nil,
+		Address:     nil,
+		Locale:      nil,
 		CustomRules: []Rule{},
 		Conditional: map[string]any{},
 	}
@@ -32,6 +46,19 @@ func (p *SchemaParser) ExtractValidationRules(component map[string]any) FieldVal
 	// Extract options for select/radio/checkbox components
 	p.extractComponentOptions(component, &validation)
 
+	// Extract upload constraints for file components
+	p.extractFileRules(component, &validation)
+
+	// Extract the rules of the field library types
+	p.extractFieldLibraryRules(component, &validation)
+
+	// Extract the locale-specific format
+	p.extractLocaleFormat(component, &validation)
+
+	if multiple, multipleOk := component["multiple"].(bool); multipleOk {
+		validation.Multiple = multiple
+	}
+
 	return validation
 }
 
@@ -57,22 +84,22 @@ func (p *SchemaParser) extractRequired(validate map[string]any, validation *Fiel
 
 // extractLengthValidation extracts length validation rules
 func (p *SchemaParser) extractLengthValidation(validate map[string]any, validation *FieldValidation) {
-	if minLength, minLengthOk := validate["minLength"].(float64); minLengthOk {
+	if minLength, minLengthOk := toNumber(validate["minLength"]); minLengthOk {
 		validation.MinLength = int(minLength)
 	}
 
-	if maxLength, maxLengthOk := validate["maxLength"].(float64); maxLengthOk {
+	if maxLength, maxLengthOk := toNumber(validate["maxLength"]); maxLengthOk {
 		validation.MaxLength = int(maxLength)
 	}
 }
 
 // extractNumericValidation extracts numeric validation rules
 func (p *SchemaParser) extractNumericValidation(validate map[string]any, validation *FieldValidation) {
-	if minVal, minOk := validate["min"].(float64); minOk {
+	if minVal, minOk := toNumber(validate["min"]); minOk {
 		validation.Min = minVal
 	}
 
-	if maxVal, maxOk := validate["max"].(float64); maxOk {
+	if maxVal, maxOk := toNumber(validate["max"]); maxOk {
 		validation.Max = maxVal
 	}
 }
@@ -91,16 +118,21 @@ func (p *SchemaParser) extractComponentType(component map[string]any, validation
 	}
 }
 
-// extractComponentOptions extracts options for select/radio/checkbox components
+// extractComponentOptions extracts options for select/radio/checkbox components.
+// Select components keep their options under data.values while radio and
+// selectboxes components list them directly under values.
 func (p *SchemaParser) extractComponentOptions(component map[string]any, validation *FieldValidation) {
-	data, dataOk := component["data"].(map[string]any)
-	if !dataOk {
-		return
-	}
-
-	values, valuesOk := data["values"].([]any)
+	values, valuesOk := component["values"].([]any)
 	if !valuesOk {
-		return
+		data, dataOk := component["data"].(map[string]any)
+		if !dataOk {
+			return
+		}
+
+		values, valuesOk = data["values"].([]any)
+		if !valuesOk {
+			return
+		}
 	}
 
 	for _, value := range values {
@@ -118,6 +150,159 @@ func (p *SchemaParser) extractOptionValue(value any, validation *FieldValidation
 	if label, labelOk := valueMap["label"].(string); labelOk {
 		validation.Options = append(validation.Options, label)
 	}
+
+	if optionValue, optionValueOk := valueMap["value"].(string); optionValueOk {
+		validation.Values = append(validation.Values, optionValue)
+	}
+}
+
+// extractFileRules extracts size and pattern constraints for file components
+func (p *SchemaParser) extractFileRules(component map[string]any, validation *FieldValidation) {
+	if validation.Type != "file" {
+		return
+	}
+
+	rules := &FileRules{
+		MinSize: 0,
+		MaxSize: 0,
+		Pattern: []string{},
+	}
+
+	if minSize, minSizeOk := component["fileMinSize"].(string); minSizeOk {
+		rules.MinSize = parseFileSize(minSize)
+	}
+
+	if maxSize, maxSizeOk := component["fileMaxSize"].(string); maxSizeOk {
+		rules.MaxSize = parseFileSize(maxSize)
+	}
+
+	if pattern, patternOk := component["filePattern"].(string); patternOk {
+		for _, part := range strings.Split(pattern, ",") {
+			if part = strings.TrimSpace(part); part != "" && part != "*" {
+				rules.Pattern = append(rules.Pattern, strings.ToLower(part))
+			}
+		}
+	}
+
+	validation.File = rules
+}
+
+// extractFieldLibraryRules extracts the rules of ratings, NPS questions,
+// matrices, addresses and phone numbers
+func (p *SchemaParser) extractFieldLibraryRules(component map[string]any, validation *FieldValidation) {
+	switch validation.Type {
+	case model.FieldRating:
+		validation.Scale = &ScaleRules{Min: 1, Max: model.RatingScale(component)}
+	case model.FieldNPS:
+		validation.Scale = &ScaleRules{Min: 0, Max: model.NPSMax}
+	case model.FieldMatrix, "survey":
+		rules := &MatrixRules{Questions: []string{}, Values: validation.Values}
+
+		for _, question := range p.extractQuestions(component) {
+			if value, ok := question.Value.(string); ok {
+				rules.Questions = append(rules.Questions, value)
+			}
+		}
+
+		validation.Matrix = rules
+	case model.FieldAddress:
+		rules := &AddressRules{Countries: []string{}}
+
+		if _, listed := component["countries"]; listed {
+			for _, country := range model.AddressCountries(component) {
+				rules.Countries = append(rules.Countries, country.Code)
+			}
+		}
+
+		validation.Address = rules
+	}
+}
+
+// extractLocaleFormat extracts the localeFormat of a component; unknown
+// formats are ignored
+func (p *SchemaParser) extractLocaleFormat(component map[string]any, validation *FieldValidation) {
+	settings, ok := component["localeFormat"].(map[string]any)
+	if !ok {
+		return
+	}
+
+	format, _ := settings["format"].(string)
+	if !IsLocaleFormat(format) {
+		return
+	}
+
+	locale := &LocaleFormat{Format: format}
+	locale.Country, _ = settings["country"].(string)
+	locale.CountryField, _ = settings["countryField"].(string)
+	locale.Country = strings.ToUpper(strings.TrimSpace(locale.Country))
+
+	if locale.Country != "" || locale.CountryField != "" {
+		validation.Locale = locale
+	}
+}
+
+// extractQuestions returns the questions of a matrix or survey component
+func (p *SchemaParser) extractQuestions(component map[string]any) []Option {
+	questions, _ := component["questions"].([]any)
+	options := make([]Option, 0, len(questions))
+
+	for _, question := range questions {
+		if questionMap, ok := question.(map[string]any); ok {
+			label, _ := questionMap["label"].(string)
+			options = append(options, Option{Label: label, Value: questionMap["value"]})
+		}
+	}
+
+	return options
+}
+
+// parseFileSize converts a Form.io size string such as "10MB" into bytes.
+// Unparseable values yield 0, which disables the constraint.
+func parseFileSize(size string) int64 {
+	size = strings.ToUpper(strings.TrimSpace(size))
+
+	units := []struct {
+		suffix     string
+		multiplier float64
+	}{
+		{"GB", 1 << 30},
+		{"MB", 1 << 20},
+		{"KB", 1 << 10},
+		{"B", 1},
+	}
+
+	multiplier := 1.0
+
+	for _, unit := range units {
+		if strings.HasSuffix(size, unit.suffix) {
+			size = strings.TrimSpace(strings.TrimSuffix(size, unit.suffix))
+			multiplier = unit.multiplier
+
+			break
+		}
+	}
+
+	value, err := strconv.ParseFloat(size, 64)
+	if err != nil || value < 0 {
+		return 0
+	}
+
+	return int64(value * multiplier)
+}
+
+// toNumber converts a schema value to float64, accepting both decoded JSON
+// numbers and Go integer literals.
+func toNumber(value any) (float64, bool) {
+	switch val := value.(type) {
+	case float64:
+		return val, true
+	case int:
+		return float64(val), true
+	case int64:
+		return float64(val), true
+	}
+
+	return 0, false
 }
 
 // ExtractComponents extracts components from a form schema
@@ -127,6 +312,37 @@ func (p *SchemaParser) ExtractComponents(schema map[string]any) ([]any, bool) {
 	return components, ok
 }
 
+// ExtractInputComponents flattens a form schema into its input components,
+// descending into layout components such as panels, columns and tables.
+func (p *SchemaParser) ExtractInputComponents(schema map[string]any) ([]map[string]any, bool) {
+	components, ok := p.ExtractComponents(schema)
+	if !ok {
+		return nil, false
+	}
+
+	inputs := []map[string]any{}
+	p.collectInputComponents(components, &inputs)
+
+	return inputs, true
+}
+
+// collectInputComponents appends the input components found in the given list.
+// Layout components are descended into; inputs are not, so the sub-components
+// of an input such as a data grid are not collected on their own.
+func (p *SchemaParser) collectInputComponents(components []any, inputs *[]map[string]any) {
+	_ = model.WalkComponents(components, func(component map[string]any) error {
+		if model.IsLayoutComponent(component) {
+			return nil
+		}
+
+		if componentType, _ := component["type"].(string); componentType != "button" {
+			*inputs = append(*inputs, component)
+		}
+
+		return model.SkipChildren
+	})
+}
+
 // ExtractComponentKey extracts the key from a component
 func (p *SchemaParser) ExtractComponentKey(component map[string]any) (string, bool) {
 	key, ok := component["key"].(string)
@@ -170,5 +386,36 @@ func (p *SchemaParser) ConvertToClientRules(validation *FieldValidation) map[str
 		clientRules["options"] = validation.Options
 	}
 
+	if validation.Scale != nil {
+		clientRules["min"] = validation.Scale.Min
+		clientRules["max"] = validation.Scale.Max
+	}
+
+	if validation.Type == model.FieldPhone {
+		clientRules["pattern"] = model.E164Pattern.String()
+	}
+
+	if validation.Matrix != nil {
+		clientRules["questions"] = validation.Matrix.Questions
+	}
+
+	if validation.Address != nil && len(validation.Address.Countries) > 0 {
+		clientRules["countries"] = validation.Address.Countries
+	}
+
+	if validation.File != nil {
+		if validation.File.MinSize > 0 {
+			clientRules["fileMinSize"] = validation.File.MinSize
+		}
+
+		if validation.File.MaxSize > 0 {
+			clientRules["fileMaxSize"] = validation.File.MaxSize
+		}
+
+		if len(validation.File.Pattern) > 0 {
+			clientRules["filePattern"] = validation.File.Pattern
+		}
+	}
+
 	return clientRules
 }
@@ -18,10 +18,49 @@ type FieldValidation struct {
 	Max         float64        `json:"max,omitempty"`
 	Pattern     string         `json:"pattern,omitempty"`
 	Options     []string       `json:"options,omitempty"`
+	Values      []string       `json:"values,omitempty"`
+	Multiple    bool           `json:"multiple,omitempty"`
+	File        *FileRules     `json:"file,omitempty"`
+	Scale       *ScaleRules    `json:"scale,omitempty"`
+	Matrix      *MatrixRules   `json:"matrix,omitempty"`
+	Address     *AddressRules  `json:"address,omitempty"`
+	Locale      *LocaleFormat  `json:"locale,omitempty"`
 	CustomRules []Rule         `json:"custom_rules,omitempty"`
 	Conditional map[string]any `json:"conditional,omitempty"`
 }
 
+// FileRules represents the constraints placed on a file upload component
+type FileRules struct {
+	MinSize int64    `json:"min_size,omitempty"`
+	MaxSize int64    `json:"max_size,omitempty"`
+	Pattern []string `json:"pattern,omitempty"`
+}
+
+// ScaleRules bounds the whole number picked on a rating or NPS scale
+type ScaleRules struct {
+	Min int `json:"min"`
+	Max int `json:"max"`
+}
+
+// MatrixRules lists the questions of a matrix and the values each answer can take
+type MatrixRules struct {
+	Questions []string `json:"questions"`
+	Values    []string `json:"values"`
+}
+
+// AddressRules limits the countries of an address; empty accepts any country
+type AddressRules struct {
+	Countries []string `json:"countries,omitempty"`
+}
+
+// LocaleFormat is the locale-specific format a field's value must have: the
+// format in Country, or in the country submitted in CountryField
+type LocaleFormat struct {
+	Format       string `json:"format"`
+	Country      string `json:"country,omitempty"`
+	CountryField string `json:"country_field,omitempty"`
+}
+
 // Error represents a validation error for a specific field
 type Error struct {
 	Field   string `json:"field"`
@@ -0,0 +1,62 @@
+package command
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"os/signal"
+	"syscall"
+
+	"github.com/spf13/cobra"
+)
+
+// Compose files of the development and production stacks
+const (
+	composeFile     = "docker-compose.yml"
+	composeProdFile = "docker-compose.prod.yml"
+)
+
+// newComposeCommand creates "compose", which runs docker compose against the
+// development or production stack
+func newComposeCommand(stdout, stderr io.Writer) *cobra.Command {
+	var (
+		prod bool
+		file string
+	)
+
+	cmd := &cobra.Command{
+		Use:   "compose [flags] -- <docker compose arguments>",
+		Short: "Run docker compose with the development or production compose file",
+		Args:  cobra.MinimumNArgs(1),
+		RunE: func(_ *cobra.Command, args []string) error {
+			if file == "" {
+				file = composeFile
+				if prod {
+					file = composeProdFile
+				}
+			}
+
+			ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+			defer stop()
+
+			//nolint:gosec // The arguments are passed on to docker compose as given
+			compose := exec.CommandContext(ctx, "docker", append([]string{"compose", "-f", file}, args...)...)
+			compose.Stdin = os.Stdin
+			compose.Stdout = stdout
+			compose.Stderr = stderr
+
+			if err := compose.Run(); err != nil {
+				return fmt.Errorf("docker compose: %w", err)
+			}
+
+			return nil
+		},
+	}
+
+	cmd.Flags().BoolVar(&prod, "prod", false, "use "+composeProdFile)
+	cmd.Flags().StringVar(&file, "file", "", "compose file (default: "+composeFile+")")
+
+	return cmd
+}
@@ -0,0 +1,185 @@
+package command
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/spf13/cobra"
+
+	"github.com/goformx/goforms/internal/domain/deadletter"
+)
+
+// errDeadLettersDisabled is returned by the dead-letters commands while the dead-letter queue is off
+var errDeadLettersDisabled = errors.New("the dead-letter queue is disabled; set dead_letter.enabled")
+
+// deadLetterListReport is written after "dead-letters list"
+type deadLetterListReport struct {
+	DeadLetters []*deadletter.Entry `json:"dead_letters"`
+	Total       int64               `json:"total"`
+}
+
+// deadLetterPurgeReport is written after "dead-letters purge"
+type deadLetterPurgeReport struct {
+	Deleted int64 `json:"deleted"`
+}
+
+// newDeadLettersCommand creates "dead-letters" and its subcommands
+func newDeadLettersCommand(stdout io.Writer) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "dead-letters",
+		Short: "Inspect, retry and purge failed background work",
+	}
+
+	cmd.AddCommand(
+		newDeadLettersListCommand(stdout),
+		newDeadLettersShowCommand(stdout),
+		newDeadLettersRetryCommand(stdout),
+		newDeadLettersPurgeCommand(stdout),
+	)
+
+	return cmd
+}
+
+// newDeadLettersListCommand creates "dead-letters list"
+func newDeadLettersListCommand(stdout io.Writer) *cobra.Command {
+	var (
+		filter        deadletter.Filter
+		offset, limit int
+	)
+
+	cmd := &cobra.Command{
+		Use:   "list",
+		Short: "List dead letters, newest first",
+		Args:  cobra.NoArgs,
+		RunE: func(_ *cobra.Command, _ []string) error {
+			return withDeadLetters(func(ctx context.Context, letters deadletter.Service) error {
+				entries, total, err := letters.List(ctx, filter, offset, limit)
+				if err != nil {
+					return fmt.Errorf("list dead letters: %w", err)
+				}
+
+				if entries == nil {
+					entries = []*deadletter.Entry{}
+				}
+
+				return writeReport(stdout, deadLetterListReport{DeadLetters: entries, Total: total})
+			})
+		},
+	}
+
+	addDeadLetterFilterFlags(cmd, &filter)
+	cmd.Flags().StringVar(&filter.Status, "status", "", "pending or resolved (default: both)")
+	cmd.Flags().IntVar(&offset, "offset", 0, "number of dead letters to skip")
+	cmd.Flags().IntVar(&limit, "limit", 50, "maximum number of dead letters to list")
+
+	return cmd
+}
+
+// newDeadLettersShowCommand creates "dead-letters show"
+func newDeadLettersShowCommand(stdout io.Writer) *cobra.Command {
+	return &cobra.Command{
+		Use:   "show <id>",
+		Short: "Print a dead letter with its payload and error history",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(_ *cobra.Command, args []string) error {
+			return withDeadLetters(func(ctx context.Context, letters deadletter.Service) error {
+				entry, err := letters.Get(ctx, args[0])
+				if err != nil {
+					return fmt.Errorf("show dead letter: %w", err)
+				}
+
+				return writeReport(stdout, entry)
+			})
+		},
+	}
+}
+
+// newDeadLettersRetryCommand creates "dead-letters retry"
+func newDeadLettersRetryCommand(stdout io.Writer) *cobra.Command {
+	var filter deadletter.Filter
+
+	cmd := &cobra.Command{
+		Use:   "retry [<id>...]",
+		Short: "Retry pending dead letters, oldest first",
+		Long: "Retry the listed pending dead letters, or every pending one of --kind, oldest first. " +
+			"The work runs in this process, with this process's configuration.",
+		RunE: func(_ *cobra.Command, args []string) error {
+			filter.IDs = args
+
+			return withDeadLetters(func(ctx context.Context, letters deadletter.Service) error {
+				report, err := letters.RetryAll(ctx, filter)
+				if err != nil {
+					return fmt.Errorf("retry dead letters: %w", err)
+				}
+
+				if writeErr := writeReport(stdout, report); writeErr != nil {
+					return writeErr
+				}
+
+				if len(report.Failed) > 0 {
+					return fmt.Errorf("%d of %d dead letters failed to retry", len(report.Failed), report.Matched)
+				}
+
+				return nil
+			})
+		},
+	}
+
+	addDeadLetterFilterFlags(cmd, &filter)
+
+	return cmd
+}
+
+// newDeadLettersPurgeCommand creates "dead-letters purge"
+func newDeadLettersPurgeCommand(stdout io.Writer) *cobra.Command {
+	var (
+		filter deadletter.Filter
+		all    bool
+	)
+
+	cmd := &cobra.Command{
+		Use:   "purge [<id>...]",
+		Short: "Delete dead letters",
+		Long:  "Delete the listed dead letters, or those matching --kind and --status. Purging every dead letter requires --all.",
+		RunE: func(_ *cobra.Command, args []string) error {
+			filter.IDs = args
+
+			if !all && filter.Kind == "" && filter.Status == "" && len(filter.IDs) == 0 {
+				return errors.New("name dead letters, --kind or --status, or pass --all")
+			}
+
+			return withDeadLetters(func(ctx context.Context, letters deadletter.Service) error {
+				deleted, err := letters.Purge(ctx, filter)
+				if err != nil {
+					return fmt.Errorf("purge dead letters: %w", err)
+				}
+
+				return writeReport(stdout, deadLetterPurgeReport{Deleted: deleted})
+			})
+		},
+	}
+
+	addDeadLetterFilterFlags(cmd, &filter)
+	cmd.Flags().StringVar(&filter.Status, "status", "", "pending or resolved (default: both)")
+	cmd.Flags().BoolVar(&all, "all", false, "purge every dead letter")
+
+	return cmd
+}
+
+// addDeadLetterFilterFlags adds --kind
+func addDeadLetterFilterFlags(cmd *cobra.Command, filter *deadletter.Filter) {
+	cmd.Flags().StringVar(&filter.Kind, "kind", "", "webhook, email or submission (default: every kind)")
+}
+
+// withDeadLetters runs fn with the dead-letter service, failing while the dead-letter queue is off
+func withDeadLetters(fn func(ctx context.Context, letters deadletter.Service) error) error {
+	return withServices(func(ctx context.Context, svc *services) error {
+		if svc.deadLetters == nil {
+			return errDeadLettersDisabled
+		}
+
+		return fn(ctx, svc.deadLetters)
+	})
+}
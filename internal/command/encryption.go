@@ -0,0 +1,72 @@
+package command
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/spf13/cobra"
+
+	"github.com/goformx/goforms/internal/domain/encryption"
+)
+
+// errEncryptionDisabled is returned by the encryption commands while submission encryption is off
+var errEncryptionDisabled = errors.New("submission encryption is disabled; set form.encryption.enabled")
+
+// newEncryptionCommand creates "encryption" and its subcommands
+func newEncryptionCommand(stdout io.Writer) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "encryption",
+		Short: "Report submission encryption keys and rotate stored submissions",
+	}
+
+	cmd.AddCommand(
+		&cobra.Command{
+			Use:   "status",
+			Short: "Print the active and retired encryption key IDs",
+			Args:  cobra.NoArgs,
+			RunE: func(_ *cobra.Command, _ []string) error {
+				return withEncryption(func(_ context.Context, service encryption.Service) error {
+					return writeReport(stdout, service.Status())
+				})
+			},
+		},
+		&cobra.Command{
+			Use:   "rotate",
+			Short: "Move stored submissions onto the active key and in line with their accounts' settings",
+			Args:  cobra.NoArgs,
+			RunE: func(_ *cobra.Command, _ []string) error {
+				return withEncryption(func(ctx context.Context, service encryption.Service) error {
+					report, err := service.Rotate(ctx)
+					if err != nil {
+						return fmt.Errorf("rotate encryption keys: %w", err)
+					}
+
+					if writeErr := writeReport(stdout, report); writeErr != nil {
+						return writeErr
+					}
+
+					if report.Failed > 0 {
+						return fmt.Errorf("%d submissions could not be rotated", report.Failed)
+					}
+
+					return nil
+				})
+			},
+		},
+	)
+
+	return cmd
+}
+
+// withEncryption runs fn with the encryption service, or fails while submission encryption is off
+func withEncryption(fn func(ctx context.Context, service encryption.Service) error) error {
+	return withServices(func(ctx context.Context, svc *services) error {
+		if svc.encryption == nil {
+			return errEncryptionDisabled
+		}
+
+		return fn(ctx, svc.encryption)
+	})
+}
@@ -0,0 +1,160 @@
+package command
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/goformx/goforms/internal/domain/eventlog"
+)
+
+// errEventStoreDisabled is returned by the events commands while the event store is off
+var errEventStoreDisabled = errors.New("the event store is disabled; set events.store.enabled")
+
+// eventListReport is written after "events list"
+type eventListReport struct {
+	Events []*eventlog.Record `json:"events"`
+	Total  int64              `json:"total"`
+}
+
+// newEventsCommand creates "events" and its subcommands
+func newEventsCommand(stdout io.Writer) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "events",
+		Short: "Inspect and replay stored domain events",
+	}
+
+	cmd.AddCommand(newEventsListCommand(stdout), newEventsShowCommand(stdout), newEventsReplayCommand(stdout))
+
+	return cmd
+}
+
+// newEventsListCommand creates "events list"
+func newEventsListCommand(stdout io.Writer) *cobra.Command {
+	var (
+		name, since   string
+		offset, limit int
+	)
+
+	cmd := &cobra.Command{
+		Use:   "list",
+		Short: "List stored events, oldest first",
+		Args:  cobra.NoArgs,
+		RunE: func(_ *cobra.Command, _ []string) error {
+			filter, err := eventFilter(name, since)
+			if err != nil {
+				return err
+			}
+
+			return withEventLog(func(ctx context.Context, events eventlog.Service) error {
+				records, total, listErr := events.List(ctx, filter, offset, limit)
+				if listErr != nil {
+					return fmt.Errorf("list events: %w", listErr)
+				}
+
+				if records == nil {
+					records = []*eventlog.Record{}
+				}
+
+				return writeReport(stdout, eventListReport{Events: records, Total: total})
+			})
+		},
+	}
+
+	addEventFilterFlags(cmd, &name, &since)
+	cmd.Flags().IntVar(&offset, "offset", 0, "number of events to skip")
+	cmd.Flags().IntVar(&limit, "limit", 50, "maximum number of events to list")
+
+	return cmd
+}
+
+// newEventsShowCommand creates "events show"
+func newEventsShowCommand(stdout io.Writer) *cobra.Command {
+	return &cobra.Command{
+		Use:   "show <id>",
+		Short: "Print a stored event",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(_ *cobra.Command, args []string) error {
+			return withEventLog(func(ctx context.Context, events eventlog.Service) error {
+				record, err := events.Get(ctx, args[0])
+				if err != nil {
+					return fmt.Errorf("show event: %w", err)
+				}
+
+				return writeReport(stdout, record)
+			})
+		},
+	}
+}
+
+// newEventsReplayCommand creates "events replay"
+func newEventsReplayCommand(stdout io.Writer) *cobra.Command {
+	var name, since string
+
+	cmd := &cobra.Command{
+		Use:   "replay",
+		Short: "Republish stored events, oldest first",
+		Long: "Republish stored events, oldest first, marked as replays. They are published in this process, " +
+			"so they reach consumers with shared effects such as the Redis cache; use the admin API " +
+			"(POST /api/v1/admin/events/replay) to reach a running server's own subscribers.",
+		Args: cobra.NoArgs,
+		RunE: func(_ *cobra.Command, _ []string) error {
+			filter, err := eventFilter(name, since)
+			if err != nil {
+				return err
+			}
+
+			return withEventLog(func(ctx context.Context, events eventlog.Service) error {
+				report, replayErr := events.Replay(ctx, filter)
+				if replayErr != nil {
+					return fmt.Errorf("replay events: %w", replayErr)
+				}
+
+				if writeErr := writeReport(stdout, report); writeErr != nil {
+					return writeErr
+				}
+
+				if len(report.Failed) > 0 {
+					return fmt.Errorf("%d of %d events failed to replay", len(report.Failed), report.Matched)
+				}
+
+				return nil
+			})
+		},
+	}
+
+	addEventFilterFlags(cmd, &name, &since)
+
+	return cmd
+}
+
+// addEventFilterFlags adds --type and --since
+func addEventFilterFlags(cmd *cobra.Command, name, since *string) {
+	cmd.Flags().StringVar(name, "type", "", "event name, such as form.submitted (default: every event)")
+	cmd.Flags().StringVar(since, "since", "", "only events published within a duration, such as 24h, or since an RFC 3339 time")
+}
+
+// eventFilter builds the filter for --type and --since
+func eventFilter(name, since string) (eventlog.Filter, error) {
+	from, err := eventlog.ParseSince(since, time.Now())
+	if err != nil {
+		return eventlog.Filter{}, fmt.Errorf("--since: %w", err)
+	}
+
+	return eventlog.Filter{Name: name, Since: from}, nil
+}
+
+// withEventLog runs fn with the event log service, failing while the event store is off
+func withEventLog(fn func(ctx context.Context, events eventlog.Service) error) error {
+	return withServices(func(ctx context.Context, svc *services) error {
+		if svc.events == nil {
+			return errEventStoreDisabled
+		}
+
+		return fn(ctx, svc.events)
+	})
+}
@@ -0,0 +1,290 @@
+package command
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/url"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/golang-migrate/migrate/v4"
+	// Drivers for the databases and the migration files
+	_ "github.com/golang-migrate/migrate/v4/database/mysql"
+	_ "github.com/golang-migrate/migrate/v4/database/postgres"
+	_ "github.com/golang-migrate/migrate/v4/source/file"
+	"github.com/spf13/cobra"
+
+	"github.com/goformx/goforms/internal/infrastructure/config"
+	"github.com/goformx/goforms/internal/infrastructure/database"
+	"github.com/goformx/goforms/internal/infrastructure/logging"
+)
+
+// migrateReport is the migration state written after each migrate command
+type migrateReport struct {
+	Driver string `json:"driver"`
+	// Shard is the submission shard migrated, empty for the primary database
+	Shard string `json:"shard,omitempty"`
+	// Version is the last applied migration, 0 when none is
+	Version uint `json:"version"`
+	// Dirty is set when a migration failed halfway and the version must be forced
+	Dirty bool `json:"dirty"`
+}
+
+// migrationDialects maps drivers to their directory under migrations/
+//
+//nolint:gochecknoglobals // Read-only lookup table
+var migrationDialects = map[string]string{
+	"postgres": "postgresql",
+	"mariadb":  "mariadb",
+	"mysql":    "mariadb",
+}
+
+// newMigrateCommand creates "migrate" and its subcommands
+func newMigrateCommand(stdout io.Writer) *cobra.Command {
+	var path, shard string
+
+	cmd := &cobra.Command{
+		Use:   "migrate",
+		Short: "Apply or roll back database migrations",
+	}
+	cmd.PersistentFlags().StringVar(&path, "path", "migrations", "directory holding the postgresql and mariadb migrations")
+	cmd.PersistentFlags().StringVar(&shard, "shard", "",
+		"ID of a submission shard to migrate with the migrations under shards/ instead of the primary database")
+
+	// run opens the migrations, applies fn and reports the resulting version
+	run := func(fn func(m *migrate.Migrate) error) error {
+		m, driver, err := newMigrator(path, shard)
+		if err != nil {
+			return err
+		}
+		defer m.Close()
+
+		if err = fn(m); err != nil && !errors.Is(err, migrate.ErrNoChange) {
+			return fmt.Errorf("migrate: %w", err)
+		}
+
+		report := migrateReport{Driver: driver, Shard: shard}
+
+		report.Version, report.Dirty, err = m.Version()
+		if err != nil && !errors.Is(err, migrate.ErrNilVersion) {
+			return fmt.Errorf("read migration version: %w", err)
+		}
+
+		return writeReport(stdout, report)
+	}
+
+	var (
+		all   bool
+		steps int
+	)
+
+	down := &cobra.Command{
+		Use:   "down",
+		Short: "Roll back the last migration, or --steps or --all of them",
+		Args:  cobra.NoArgs,
+		RunE: func(_ *cobra.Command, _ []string) error {
+			return run(func(m *migrate.Migrate) error {
+				if all {
+					return m.Down()
+				}
+
+				return m.Steps(-steps)
+			})
+		},
+	}
+	down.Flags().BoolVar(&all, "all", false, "roll back every migration")
+	down.Flags().IntVar(&steps, "steps", 1, "number of migrations to roll back")
+
+	cmd.AddCommand(
+		&cobra.Command{
+			Use:   "up",
+			Short: "Apply all pending migrations",
+			Args:  cobra.NoArgs,
+			RunE: func(_ *cobra.Command, _ []string) error {
+				return run((*migrate.Migrate).Up)
+			},
+		},
+		down,
+		&cobra.Command{
+			Use:   "version",
+			Short: "Print the current migration version",
+			Args:  cobra.NoArgs,
+			RunE: func(_ *cobra.Command, _ []string) error {
+				return run(func(*migrate.Migrate) error { return nil })
+			},
+		},
+		&cobra.Command{
+			Use:   "force <version>",
+			Short: "Set the migration version without migrating, to clear a dirty state",
+			Args:  cobra.ExactArgs(1),
+			RunE: func(_ *cobra.Command, args []string) error {
+				version, err := strconv.Atoi(args[0])
+				if err != nil {
+					return fmt.Errorf("invalid version %q: %w", args[0], err)
+				}
+
+				return run(func(m *migrate.Migrate) error { return m.Force(version) })
+			},
+		},
+	)
+
+	return cmd
+}
+
+// newMigrator opens the migrations for the configured driver, of the primary
+// database or of the submission shard with the given ID
+func newMigrator(path, shard string) (m *migrate.Migrate, driver string, err error) {
+	cfg, err := config.NewViperConfig().Load()
+	if err != nil {
+		return nil, "", fmt.Errorf("load config: %w", err)
+	}
+
+	driver = strings.ToLower(cfg.Database.Driver)
+
+	dialect, ok := migrationDialects[driver]
+	if !ok {
+		return nil, driver, fmt.Errorf("no migrations for database driver %q", cfg.Database.Driver)
+	}
+
+	if shard != "" {
+		if cfg.Database, err = shardDatabase(&cfg.Database, shard); err != nil {
+			return nil, driver, err
+		}
+
+		path = filepath.Join(path, "shards")
+	}
+
+	dir, err := filepath.Abs(filepath.Join(path, dialect))
+	if err != nil {
+		return nil, driver, fmt.Errorf("resolve migrations directory: %w", err)
+	}
+
+	logger, err := newLogger(cfg)
+	if err != nil {
+		return nil, driver, err
+	}
+
+	// The token replaces the password for the length of the migration run
+	if cfg.Database.IAM.Enabled {
+		if cfg.Database.Password, err = database.IAMToken(context.Background(), &cfg.Database); err != nil {
+			return nil, driver, err
+		}
+	}
+
+	m, err = migrate.New((&url.URL{Scheme: "file", Path: dir}).String(), databaseURL(&cfg.Database))
+	if err != nil {
+		return nil, driver, fmt.Errorf("open migrations: %w", err)
+	}
+
+	m.Log = migrateLogger{logger: logger}
+
+	return m, driver, nil
+}
+
+// shardDatabase returns the connection settings of a configured submission shard
+func shardDatabase(db *config.DatabaseConfig, id string) (config.DatabaseConfig, error) {
+	for i := range db.Sharding.Shards {
+		if shard := &db.Sharding.Shards[i]; shard.ID == id {
+			return db.Shard(shard), nil
+		}
+	}
+
+	return config.DatabaseConfig{}, fmt.Errorf("no database shard %q in database.sharding.shards", id)
+}
+
+// databaseURL builds the migrate tool's URL for the database
+func databaseURL(db *config.DatabaseConfig) string {
+	dsn := url.URL{
+		User: url.UserPassword(db.Username, db.Password),
+		Path: "/" + db.Name,
+	}
+
+	address := net.JoinHostPort(db.Host, strconv.Itoa(db.Port))
+
+	if db.Driver == "postgres" {
+		dsn.Scheme = "postgres"
+		dsn.Host = address
+		dsn.RawQuery = postgresURLQuery(db).Encode()
+
+		return dsn.String()
+	}
+
+	// The MySQL driver takes a go-sql-driver DSN with the address in tcp(...) or
+	// unix(...), built by hand as url.URL would escape a socket path
+	host := "tcp(" + address + ")"
+	if db.Socket != "" {
+		host = "unix(" + db.Socket + ")"
+	}
+
+	return "mysql://" + dsn.User.String() + "@" + host + dsn.EscapedPath() + "?" + mysqlURLQuery(db).Encode()
+}
+
+// postgresURLQuery holds the TLS files and socket directory of a PostgreSQL URL
+func postgresURLQuery(db *config.DatabaseConfig) url.Values {
+	query := url.Values{"sslmode": {db.SSLMode}}
+
+	for key, file := range map[string]string{"sslrootcert": db.TLS.CAFile, "sslcert": db.TLS.CertFile, "sslkey": db.TLS.KeyFile} {
+		if file != "" {
+			query.Set(key, file)
+		}
+	}
+
+	// A host parameter overrides the URL's host, and may be a socket directory
+	if db.Socket != "" {
+		query.Set("host", db.Socket)
+	}
+
+	return query
+}
+
+// mysqlURLQuery holds the TLS settings of a MySQL URL. The migrate tool only
+// takes a CA file and client certificate together, and then checks the host
+// name unless the mode is require.
+func mysqlURLQuery(db *config.DatabaseConfig) url.Values {
+	query := url.Values{"multiStatements": {"true"}}
+
+	if db.IAM.Enabled {
+		query.Set("allowCleartextPasswords", "true")
+	}
+
+	switch {
+	case !db.TLSEnabled() || db.Socket != "":
+	case db.TLS.CAFile != "":
+		query.Set("tls", "goforms")
+		query.Set("x-tls-ca", db.TLS.CAFile)
+
+		if db.TLS.CertFile != "" {
+			query.Set("x-tls-cert", db.TLS.CertFile)
+			query.Set("x-tls-key", db.TLS.KeyFile)
+		}
+
+		if db.SSLMode == config.SSLModeRequire {
+			query.Set("x-tls-insecure-skip-verify", "true")
+		}
+	case db.SSLMode == config.SSLModeRequire:
+		query.Set("tls", "skip-verify")
+	default:
+		query.Set("tls", "true")
+	}
+
+	return query
+}
+
+// migrateLogger writes the migrate library's progress to the application logger
+type migrateLogger struct {
+	logger logging.Logger
+}
+
+// Printf implements migrate.Logger
+func (l migrateLogger) Printf(format string, v ...any) {
+	l.logger.Info(strings.TrimSpace(fmt.Sprintf(format, v...)))
+}
+
+// Verbose implements migrate.Logger
+func (l migrateLogger) Verbose() bool {
+	return false
+}
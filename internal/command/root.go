@@ -0,0 +1,251 @@
+// Package command provides the goforms command line: the HTTP server, database
+// migrations, user administration, the docker compose stacks, and the
+// administrative commands that run against the configured database or an
+// instance over HTTP. The goforms and goforms-cli binaries are shims that run it.
+//
+// Usage:
+//
+//	goforms [--config-dir <dir>] [<command>] [flags]
+//
+//	goforms [serve]
+//	goforms migrate up|down|version|force [--path migrations] [--all] [--steps <n>] [<version>]
+//	goforms user create --email <email> --password <password> [--first-name <name>] [--last-name <name>] [--role user|admin]
+//	goforms user list [--offset 0] [--limit 50]
+//	goforms user delete --id <id>
+//	goforms system info
+//	goforms events list|replay [--type <name>] [--since 24h] [--offset 0] [--limit 50]
+//	goforms events show <id>
+//	goforms dead-letters list [--kind webhook|email|submission] [--status pending|resolved] [--offset 0] [--limit 50]
+//	goforms dead-letters show <id>
+//	goforms dead-letters retry [--kind <kind>] [<id>...]
+//	goforms dead-letters purge [--kind <kind>] [--status pending|resolved] [--all] [<id>...]
+//	goforms encryption status|rotate
+//	goforms compose [--prod] [--file <path>] -- <docker compose arguments>
+//	goforms import submissions --form <id> --file <path> [--format csv|json] [--map column=field]... [--dry-run]
+//	goforms import form --user <id> --file <path> [--source goforms] [--dry-run]
+//	goforms submissions validate --form <id> [--schema <path>] [--tag <tag>] [--include-test]
+//	goforms seed [--users 5] [--forms 20] [--submissions 1000] [--password <password>] [--seed <n>]
+//	goforms bench submit --form <id> --rps <n> [--duration 30s] [--target <url>] [--api-key <key>]
+//	goforms k8s render --image <ref> [--format manifests|helm] [--namespace <ns>] [--replicas <n>] [--host <host>] [--dry-run]
+//	goforms doctor [--env-file <path>] [--skip-docker] [--skip-database] [--min-free <bytes>] [--timeout 5s]
+//	goforms env list [--file .env] [--show-secrets]
+//	goforms env get [--file .env] <KEY>
+//	goforms env set [--file .env] [--force] <KEY=VALUE>...
+//	goforms env encrypt [--file .env] [--age <recipient>]...
+//	goforms config docs [--format markdown|json] [--check <file>]
+//	goforms version [--check]
+//
+// Without a command goforms runs the server. goforms-cli accepts the same
+// commands and prints help instead.
+//
+// --config-dir reads config.yaml and its overlays from dir instead of searching
+// the standard paths, for every command that loads the configuration.
+//
+// migrate applies the migrations for the configured driver from
+// migrations/postgresql or migrations/mariadb, recording the version in the
+// database as the migrate tool does. Reports are written to stdout as JSON.
+//
+// user creates, lists and deletes accounts directly in the database, for
+// bootstrapping the first admin. system info reports the build, the config files
+// that were merged and the configured environment and database driver as JSON.
+//
+// events lists, shows and replays the domain events stored while
+// events.store.enabled is set. replay publishes in its own process, so it
+// reaches consumers with shared effects such as the Redis cache; the admin API
+// replays to a running server's subscribers.
+//
+// dead-letters lists, shows, retries and purges the background work kept while
+// dead_letter.enabled is set: post-submit hook deliveries, email and
+// write-behind submissions that still failed after their own retries. retry
+// runs the work in its own process; the admin API retries on a running server.
+//
+// encryption reports the submission encryption keys and rotates stored
+// submissions while form.encryption.enabled is set. Run rotate after putting a
+// new key first in form.encryption.keys, and drop the retired key once it
+// reports no failures. The exit status is 1 when any submission failed.
+//
+// compose runs docker compose with docker-compose.yml, or with
+// docker-compose.prod.yml when --prod is given.
+//
+// Import, seed and bench reports are written to stdout as JSON. The exit status
+// is 1 when any row or the form failed validation, when any seeded item could not
+// be created, or when any bench request failed.
+//
+// submissions validate checks a form's stored submissions against its current
+// schema, or the schema in --schema, with the validation the submit endpoint
+// runs, after the schema or the validation rules changed. The report is written
+// to stdout as JSON. --tag adds the tag to failing submissions and removes it
+// from passing ones, so they can be filtered in the submission list. Test
+// submissions are skipped unless --include-test is given. The exit status is 1
+// when any submission failed.
+//
+// k8s render loads and validates the configuration the server would run with and
+// writes Kubernetes manifests or a Helm values file to stdout. With --dry-run the
+// manifests are diffed against the cluster with kubectl diff instead.
+//
+// doctor checks Docker and Compose versions, the listen port, the database
+// connection, the configuration against its schema, free space for uploads and
+// the TLS certificate, and writes the results to stdout as JSON. The exit status
+// is 1 when any check failed.
+//
+// env edits the .env file in place, keeping its comments and order. set refuses
+// values that add configuration validation errors unless --force is given. list
+// and set warn about missing or default secrets. Files encrypted with sops are
+// decrypted and re-encrypted for the same age recipients; encrypt converts a
+// plain file. get prints the bare value; list and set write JSON.
+//
+// config docs writes the configuration reference to stdout: every key of the
+// configuration with its type, default, environment variables and description,
+// taken from the Config struct and the Viper defaults. With --check it writes
+// nothing and the exit status is 1 when the file differs from the reference.
+//
+// version writes the build's version information as JSON. With --check it also
+// asks GitHub for the latest release, with its changelog highlights, unless
+// update checks are disabled (UPDATES_ENABLED=false). The exit status is 1 when
+// the check failed.
+package command
+
+import (
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/goformx/goforms/internal/infrastructure"
+	"github.com/goformx/goforms/internal/infrastructure/config"
+	"github.com/goformx/goforms/internal/infrastructure/logging"
+	"github.com/goformx/goforms/internal/infrastructure/sanitization"
+)
+
+// Execute runs the goforms command line
+func Execute(args []string, stdout, stderr io.Writer) error {
+	root := NewRoot(stdout, stderr)
+	root.SetArgs(args)
+
+	if err := root.Execute(); err != nil {
+		return fmt.Errorf("goforms: %w", err)
+	}
+
+	return nil
+}
+
+// NewRoot creates the goforms command; without a subcommand it runs the server
+func NewRoot(stdout, stderr io.Writer) *cobra.Command {
+	var configDir string
+
+	root := &cobra.Command{
+		Use:   "goforms",
+		Short: "GoForms server and administrative commands",
+		Args:  cobra.NoArgs,
+		// Parse --config-dir before handing the rest to commands that parse their own flags
+		TraverseChildren: true,
+		SilenceUsage:     true,
+		SilenceErrors:    true,
+		PersistentPreRunE: func(_ *cobra.Command, _ []string) error {
+			// Every command loads the configuration through config.NewViperConfig,
+			// including the ones inside the Fx graph, which reads the directory from the environment
+			if configDir == "" {
+				return nil
+			}
+
+			if err := os.Setenv(config.DirEnv, configDir); err != nil {
+				return fmt.Errorf("set config directory: %w", err)
+			}
+
+			return nil
+		},
+		RunE: func(_ *cobra.Command, _ []string) error {
+			return serve()
+		},
+	}
+
+	root.PersistentFlags().StringVar(&configDir, "config-dir", "",
+		"directory holding config.yaml and its overlays (default: search the standard paths)")
+	root.SetOut(stdout)
+	root.SetErr(stderr)
+
+	root.AddCommand(
+		newServeCommand(),
+		newMigrateCommand(stdout),
+		newUserCommand(stdout),
+		newSystemCommand(stdout),
+		newEventsCommand(stdout),
+		newDeadLettersCommand(stdout),
+		newEncryptionCommand(stdout),
+		newShardsCommand(stdout),
+		newComposeCommand(stdout, stderr),
+		toolCommand("version", "Print version information", printVersion, stdout),
+	)
+	addToolCommands(root, stdout)
+
+	return root
+}
+
+// toolCommand wraps a command that parses its own flags with the flag package
+func toolCommand(use, short string, run func(args []string, stdout io.Writer) error, stdout io.Writer) *cobra.Command {
+	return &cobra.Command{
+		Use:                use,
+		Short:              short,
+		DisableFlagParsing: true,
+		RunE: func(_ *cobra.Command, args []string) error {
+			return run(args, stdout)
+		},
+	}
+}
+
+// addToolCommands adds the data, deployment and environment commands
+func addToolCommands(root *cobra.Command, stdout io.Writer) {
+	importCmd := &cobra.Command{Use: "import", Short: "Import submissions or forms from files"}
+	importCmd.AddCommand(
+		toolCommand("submissions", "Import submissions into a form from CSV or JSON", importSubmissions, stdout),
+		toolCommand("form", "Import a form exported from GoForms", importForm, stdout),
+	)
+
+	submissionsCmd := &cobra.Command{Use: "submissions", Short: "Check stored submissions"}
+	submissionsCmd.AddCommand(
+		toolCommand("validate", "Validate stored submissions against the form's schema", validateSubmissions, stdout),
+	)
+
+	benchCmd := &cobra.Command{Use: "bench", Short: "Generate load against an instance"}
+	benchCmd.AddCommand(toolCommand("submit", "Submit a form at a fixed rate", benchSubmit, stdout))
+
+	k8sCmd := &cobra.Command{Use: "k8s", Short: "Render Kubernetes deployments"}
+	k8sCmd.AddCommand(toolCommand("render", "Render manifests or Helm values", k8sRender, stdout))
+
+	envCmd := &cobra.Command{Use: "env", Short: "Edit the .env file"}
+	envCmd.AddCommand(
+		toolCommand("list", "List the variables", envList, stdout),
+		toolCommand("get", "Print a variable's value", envGet, stdout),
+		toolCommand("set", "Set variables", envSet, stdout),
+		toolCommand("encrypt", "Encrypt the file with sops", envEncrypt, stdout),
+	)
+
+	configCmd := &cobra.Command{Use: "config", Short: "Document the configuration"}
+	configCmd.AddCommand(toolCommand("docs", "Write the configuration reference", configDocs, stdout))
+
+	root.AddCommand(
+		importCmd,
+		submissionsCmd,
+		benchCmd,
+		k8sCmd,
+		envCmd,
+		configCmd,
+		toolCommand("seed", "Seed fake users, forms and submissions", seedData, stdout),
+		toolCommand("doctor", "Check the environment the server runs in", runDoctor, stdout),
+	)
+}
+
+// newLogger creates a logger with the server's logger factory, for commands
+// that run outside the Fx graph
+func newLogger(cfg *config.Config) (logging.Logger, error) {
+	factory, err := infrastructure.NewLoggerFactory(infrastructure.LoggerFactoryParams{
+		Config:    cfg,
+		Sanitizer: sanitization.NewService(),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return infrastructure.NewLogger(factory)
+}
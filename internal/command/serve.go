@@ -0,0 +1,126 @@
+package command
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/spf13/cobra"
+	"go.uber.org/fx"
+
+	appmiddleware "github.com/goformx/goforms/internal/application/middleware"
+	"github.com/goformx/goforms/internal/infrastructure/config"
+	"github.com/goformx/goforms/internal/infrastructure/logging"
+	"github.com/goformx/goforms/internal/infrastructure/server"
+	"github.com/goformx/goforms/internal/infrastructure/version"
+	"github.com/goformx/goforms/pkg/goforms"
+)
+
+// newServeCommand creates "serve", which runs the HTTP server until SIGINT or SIGTERM
+func newServeCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "serve",
+		Short: "Run the HTTP server",
+		Args:  cobra.NoArgs,
+		RunE: func(_ *cobra.Command, _ []string) error {
+			return serve()
+		},
+	}
+}
+
+// appParams collects all dependencies injected by Fx.
+type appParams struct {
+	fx.In
+
+	Lifecycle     fx.Lifecycle
+	Server        *server.Server
+	Logger        logging.Logger
+	Config        *config.Config
+	ConfigSources config.Sources
+
+	// New middleware system components
+	MigrationAdapter *appmiddleware.MigrationAdapter
+}
+
+// setupLifecycle configures startup and shutdown hooks.
+func setupLifecycle(p appParams) {
+	p.Lifecycle.Append(fx.Hook{
+		OnStart: func(ctx context.Context) error {
+			v := version.GetInfo()
+
+			p.Logger.Info("starting application",
+				"app", p.Config.App.Name,
+				"version", v.Version,
+				"environment", p.Config.App.Environment,
+				"build_time", v.BuildTime,
+				"git_commit", v.GitCommit,
+			)
+
+			for _, source := range p.ConfigSources {
+				p.Logger.Info("configuration file loaded",
+					"file", source.File,
+					"overridden_keys", source.Keys,
+				)
+			}
+
+			status := p.MigrationAdapter.GetMigrationStatus()
+			p.Logger.Info("middleware system status",
+				"new_system_enabled", status.NewSystemEnabled,
+				"registered_middleware_count", len(status.RegisteredMiddleware),
+				"available_chains_count", len(status.AvailableChains),
+			)
+
+			// Start server asynchronously
+			go func() {
+				if err := p.Server.Start(ctx); err != nil {
+					p.Logger.Fatal("server startup failed", "error", err)
+					os.Exit(1)
+				}
+			}()
+
+			return nil
+		},
+
+		OnStop: func(_ context.Context) error {
+			v := version.GetInfo()
+
+			p.Logger.Info("shutting down application",
+				"app", p.Config.App.Name,
+				"version", v.Version,
+				"build_time", v.BuildTime,
+				"git_commit", v.GitCommit,
+			)
+
+			return nil
+		},
+	})
+}
+
+// serve initializes the Fx application and manages graceful shutdown.
+func serve() error {
+	app := fx.New(
+		// The server is GoForms embedded in nothing but a listener
+		goforms.Module(),
+		fx.Invoke(setupLifecycle),
+	)
+
+	if err := app.Start(context.Background()); err != nil {
+		return fmt.Errorf("application startup failed: %w", err)
+	}
+
+	// Wait for termination signal
+	sigChan := make(chan os.Signal, 1)
+	signal.Notify(sigChan, syscall.SIGINT, syscall.SIGTERM)
+	<-sigChan
+
+	stopCtx, cancel := context.WithTimeout(context.Background(), lifecycleTimeout)
+	defer cancel()
+
+	if err := app.Stop(stopCtx); err != nil {
+		return fmt.Errorf("application shutdown failed: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,87 @@
+package command
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/spf13/cobra"
+
+	formstore "github.com/goformx/goforms/internal/infrastructure/repository/form"
+)
+
+// errShardingDisabled is returned by the shards commands while submissions are not sharded
+var errShardingDisabled = errors.New("submissions are not sharded; set database.sharding.enabled")
+
+// shardLocation is the output of "shards locate"
+type shardLocation struct {
+	FormID string `json:"form_id"`
+	Shard  string `json:"shard"`
+}
+
+// newShardsCommand creates "shards" and its subcommands
+func newShardsCommand(stdout io.Writer) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "shards",
+		Short: "Locate forms and move submissions between submission shards",
+	}
+
+	cmd.AddCommand(
+		&cobra.Command{
+			Use:   "locate <form-id>",
+			Short: "Print the shard that holds a form's submissions",
+			Args:  cobra.ExactArgs(1),
+			RunE: func(_ *cobra.Command, args []string) error {
+				return withShards(func(_ context.Context, svc *services) error {
+					return writeReport(stdout, shardLocation{FormID: args[0], Shard: svc.shards.Locate(args[0])})
+				})
+			},
+		},
+		newShardsRebalanceCommand(stdout),
+	)
+
+	return cmd
+}
+
+// newShardsRebalanceCommand creates "shards rebalance"
+func newShardsRebalanceCommand(stdout io.Writer) *cobra.Command {
+	var opts formstore.RebalanceOptions
+
+	cmd := &cobra.Command{
+		Use:   "rebalance",
+		Short: "Move submissions to the shards their forms belong to after shards were added or retired",
+		Args:  cobra.NoArgs,
+		RunE: func(_ *cobra.Command, _ []string) error {
+			return withShards(func(ctx context.Context, svc *services) error {
+				report, err := svc.rebalancer.Rebalance(ctx, opts)
+				if writeErr := writeReport(stdout, report); writeErr != nil {
+					return writeErr
+				}
+
+				if err != nil {
+					return fmt.Errorf("rebalance shards: %w", err)
+				}
+
+				return nil
+			})
+		},
+	}
+
+	cmd.Flags().BoolVar(&opts.DryRun, "dry-run", false, "report the forms that would move without moving them")
+	cmd.Flags().BoolVar(&opts.FromPrimary, "from-primary", false, "also move the submissions stored in the primary database")
+	cmd.Flags().IntVar(&opts.BatchSize, "batch-size", 500, "number of submissions moved per transaction")
+
+	return cmd
+}
+
+// withShards runs fn with the application services, or fails while submissions are not sharded
+func withShards(fn func(ctx context.Context, svc *services) error) error {
+	return withServices(func(ctx context.Context, svc *services) error {
+		if svc.shards == nil {
+			return errShardingDisabled
+		}
+
+		return fn(ctx, svc)
+	})
+}
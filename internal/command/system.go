@@ -0,0 +1,59 @@
+package command
+
+import (
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/goformx/goforms/internal/infrastructure/config"
+	"github.com/goformx/goforms/internal/infrastructure/version"
+)
+
+// systemReport describes the build and the configuration a server would start with
+type systemReport struct {
+	version.Info
+
+	Environment    string         `json:"environment"`
+	DatabaseDriver string         `json:"database_driver"`
+	ConfigDir      string         `json:"config_dir,omitempty"`
+	ConfigFiles    config.Sources `json:"config_files"`
+}
+
+// newSystemCommand creates "system" and its subcommands
+func newSystemCommand(stdout io.Writer) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "system",
+		Short: "Inspect the installation",
+	}
+
+	cmd.AddCommand(&cobra.Command{
+		Use:   "info",
+		Short: "Report the build and the config files that were merged",
+		Args:  cobra.NoArgs,
+		RunE: func(_ *cobra.Command, _ []string) error {
+			vc := config.NewViperConfig()
+
+			cfg, err := vc.LoadUnvalidated()
+			if err != nil {
+				return fmt.Errorf("load config: %w", err)
+			}
+
+			sources := vc.Sources()
+			if sources == nil {
+				sources = config.Sources{}
+			}
+
+			return writeReport(stdout, systemReport{
+				Info:           version.GetInfo(),
+				Environment:    cfg.App.Environment,
+				DatabaseDriver: cfg.Database.Driver,
+				ConfigDir:      os.Getenv(config.DirEnv),
+				ConfigFiles:    sources,
+			})
+		},
+	})
+
+	return cmd
+}
@@ -0,0 +1,832 @@
+package command
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"os/signal"
+	"path/filepath"
+	"slices"
+	"strings"
+	"syscall"
+	"time"
+
+	"go.uber.org/fx"
+
+	"github.com/goformx/goforms/internal/application/bench"
+	"github.com/goformx/goforms/internal/application/deploy"
+	"github.com/goformx/goforms/internal/application/doctor"
+	"github.com/goformx/goforms/internal/application/envfile"
+	"github.com/goformx/goforms/internal/application/importer"
+	"github.com/goformx/goforms/internal/application/revalidate"
+	"github.com/goformx/goforms/internal/application/seed"
+	"github.com/goformx/goforms/internal/domain"
+	"github.com/goformx/goforms/internal/domain/deadletter"
+	"github.com/goformx/goforms/internal/domain/encryption"
+	"github.com/goformx/goforms/internal/domain/eventlog"
+	"github.com/goformx/goforms/internal/domain/extension"
+	"github.com/goformx/goforms/internal/domain/form"
+	"github.com/goformx/goforms/internal/domain/user"
+	"github.com/goformx/goforms/internal/infrastructure"
+	"github.com/goformx/goforms/internal/infrastructure/config"
+	"github.com/goformx/goforms/internal/infrastructure/database"
+	"github.com/goformx/goforms/internal/infrastructure/email"
+	"github.com/goformx/goforms/internal/infrastructure/httpclient"
+	"github.com/goformx/goforms/internal/infrastructure/logging"
+	"github.com/goformx/goforms/internal/infrastructure/optionsource"
+	formstore "github.com/goformx/goforms/internal/infrastructure/repository/form"
+	"github.com/goformx/goforms/internal/infrastructure/sanitization"
+	"github.com/goformx/goforms/internal/infrastructure/updates"
+	"github.com/goformx/goforms/internal/infrastructure/version"
+)
+
+// lifecycleTimeout bounds application start and stop
+const lifecycleTimeout = 30 * time.Second
+
+// mappingFlag collects repeated --map column=field flags
+type mappingFlag map[string]string
+
+// String implements flag.Value
+func (m mappingFlag) String() string {
+	pairs := make([]string, 0, len(m))
+	for column, field := range m {
+		pairs = append(pairs, column+"="+field)
+	}
+
+	return strings.Join(pairs, ",")
+}
+
+// Set implements flag.Value
+func (m mappingFlag) Set(value string) error {
+	column, field, ok := strings.Cut(value, "=")
+	if !ok || column == "" || field == "" {
+		return fmt.Errorf("invalid mapping %q: expected column=field", value)
+	}
+
+	m[column] = field
+
+	return nil
+}
+
+// services holds the dependencies resolved from the application graph
+type services struct {
+	forms  form.Service
+	users  user.Repository
+	events eventlog.Service
+	// deadLetters retries with the handlers of the email sender, extension
+	// runner and form service, which are built alongside it
+	deadLetters deadletter.Service
+	encryption  encryption.Service
+	importer    *importer.Importer
+	seeder      *seed.Seeder
+	revalidator *revalidate.Revalidator
+	// shards and rebalancer are nil unless submissions are sharded
+	shards     *database.Shards
+	rebalancer *formstore.Rebalancer
+}
+
+// importSubmissions runs "import submissions"
+func importSubmissions(args []string, stdout io.Writer) error {
+	flags := flag.NewFlagSet("import submissions", flag.ContinueOnError)
+	formID := flags.String("form", "", "ID of the form to import into")
+	path := flags.String("file", "", "CSV or JSON file to import")
+	format := flags.String("format", "", "file format: csv or json (default: from the file extension)")
+	dryRun := flags.Bool("dry-run", false, "validate without storing submissions")
+	mapping := mappingFlag{}
+	flags.Var(mapping, "map", "map a file column to a form field key, as column=field (repeatable)")
+
+	if err := flags.Parse(args); err != nil {
+		return fmt.Errorf("parse flags: %w", err)
+	}
+
+	if *formID == "" || *path == "" {
+		return errors.New("--form and --file are required")
+	}
+
+	if *format == "" {
+		*format = strings.TrimPrefix(strings.ToLower(filepath.Ext(*path)), ".")
+	}
+
+	file, err := os.Open(*path)
+	if err != nil {
+		return fmt.Errorf("open import file: %w", err)
+	}
+	defer file.Close()
+
+	return withServices(func(ctx context.Context, svc *services) error {
+		target, getErr := svc.forms.GetForm(ctx, *formID)
+		if getErr != nil {
+			return fmt.Errorf("get form: %w", getErr)
+		}
+
+		report, importErr := svc.importer.ImportSubmissions(ctx, target, file, importer.SubmissionOptions{
+			Format:  *format,
+			Mapping: mapping,
+			DryRun:  *dryRun,
+		})
+		if importErr != nil {
+			return fmt.Errorf("import submissions: %w", importErr)
+		}
+
+		if writeErr := writeReport(stdout, report); writeErr != nil {
+			return writeErr
+		}
+
+		if report.Failed > 0 {
+			return fmt.Errorf("%d of %d rows failed", report.Failed, report.Total)
+		}
+
+		return nil
+	})
+}
+
+// validateSubmissions runs "submissions validate"
+func validateSubmissions(args []string, stdout io.Writer) error {
+	flags := flag.NewFlagSet("submissions validate", flag.ContinueOnError)
+	formID := flags.String("form", "", "ID of the form whose submissions are checked")
+	schemaPath := flags.String("schema", "", "JSON file with the schema to check against (default: the form's current schema)")
+	tag := flags.String("tag", "", "tag failing submissions, and untag passing ones, with this tag")
+	includeTest := flags.Bool("include-test", false, "also check test submissions made from the builder's preview")
+
+	if err := flags.Parse(args); err != nil {
+		return fmt.Errorf("parse flags: %w", err)
+	}
+
+	if *formID == "" {
+		return errors.New("--form is required")
+	}
+
+	opts := revalidate.Options{Tag: *tag, IncludeTest: *includeTest}
+
+	if *schemaPath != "" {
+		raw, err := os.ReadFile(*schemaPath)
+		if err != nil {
+			return fmt.Errorf("read schema file: %w", err)
+		}
+
+		if unmarshalErr := json.Unmarshal(raw, &opts.Schema); unmarshalErr != nil {
+			return fmt.Errorf("parse schema file: %w", unmarshalErr)
+		}
+	}
+
+	return withServices(func(ctx context.Context, svc *services) error {
+		target, getErr := svc.forms.GetForm(ctx, *formID)
+		if getErr != nil {
+			return fmt.Errorf("get form: %w", getErr)
+		}
+
+		report, validateErr := svc.revalidator.Validate(ctx, target, opts)
+		if validateErr != nil {
+			return fmt.Errorf("validate submissions: %w", validateErr)
+		}
+
+		if writeErr := writeReport(stdout, report); writeErr != nil {
+			return writeErr
+		}
+
+		if report.Failed > 0 {
+			return fmt.Errorf("%d of %d submissions failed validation", report.Failed, report.Total)
+		}
+
+		return nil
+	})
+}
+
+// importForm runs "import form"
+func importForm(args []string, stdout io.Writer) error {
+	flags := flag.NewFlagSet("import form", flag.ContinueOnError)
+	userID := flags.String("user", "", "ID of the user who will own the form")
+	path := flags.String("file", "", "exported form definition")
+	source := flags.String("source", importer.SourceGoForms, "tool the form was exported from (goforms, typeform, google_forms)")
+	dryRun := flags.Bool("dry-run", false, "validate without creating the form")
+
+	if err := flags.Parse(args); err != nil {
+		return fmt.Errorf("parse flags: %w", err)
+	}
+
+	if *userID == "" || *path == "" {
+		return errors.New("--user and --file are required")
+	}
+
+	data, err := os.ReadFile(*path)
+	if err != nil {
+		return fmt.Errorf("read import file: %w", err)
+	}
+
+	return withServices(func(ctx context.Context, svc *services) error {
+		report, _, importErr := svc.importer.ImportForm(ctx, *userID, *source, data, *dryRun)
+		if importErr != nil {
+			return fmt.Errorf("import form: %w", importErr)
+		}
+
+		if writeErr := writeReport(stdout, report); writeErr != nil {
+			return writeErr
+		}
+
+		if !report.Valid {
+			return errors.New("form failed validation")
+		}
+
+		return nil
+	})
+}
+
+// seedData runs "seed"
+func seedData(args []string, stdout io.Writer) error {
+	flags := flag.NewFlagSet("seed", flag.ContinueOnError)
+	users := flags.Int("users", 5, "users to create (existing seeded users are reused)")
+	forms := flags.Int("forms", 20, "forms to create, spread over the users")
+	submissions := flags.Int("submissions", 1000, "submissions to create, spread over the forms")
+	password := flags.String("password", seed.DefaultPassword, "password for new users")
+	randomSeed := flags.Uint64("seed", 1, "seed for the generated data")
+
+	if err := flags.Parse(args); err != nil {
+		return fmt.Errorf("parse flags: %w", err)
+	}
+
+	return withServices(func(ctx context.Context, svc *services) error {
+		report, seedErr := svc.seeder.Run(ctx, seed.Options{
+			Users:       *users,
+			Forms:       *forms,
+			Submissions: *submissions,
+			Password:    *password,
+			Seed:        *randomSeed,
+		})
+		if report != nil {
+			if writeErr := writeReport(stdout, report); writeErr != nil {
+				return writeErr
+			}
+		}
+
+		if seedErr != nil {
+			return fmt.Errorf("seed: %w", seedErr)
+		}
+
+		if report.Failed > 0 {
+			return fmt.Errorf("%d items could not be seeded", report.Failed)
+		}
+
+		return nil
+	})
+}
+
+// benchSubmit runs "bench submit". It needs no database, only a reachable instance.
+func benchSubmit(args []string, stdout io.Writer) error {
+	flags := flag.NewFlagSet("bench submit", flag.ContinueOnError)
+	formID := flags.String("form", "", "ID of the form to submit to")
+	rps := flags.Int("rps", 10, "submissions per second")
+	duration := flags.Duration("duration", 30*time.Second, "how long to send submissions for")
+	concurrency := flags.Int("concurrency", 64, "maximum requests in flight")
+	target := flags.String("target", "http://localhost:8080", "base URL of the instance to load")
+	apiKey := flags.String("api-key", "", "API key, when the instance requires one")
+	apiKeyHeader := flags.String("api-key-header", "X-API-Key", "header the API key is sent in")
+	timeout := flags.Duration("timeout", 10*time.Second, "per-request timeout")
+	seed := flags.Uint64("seed", uint64(time.Now().UnixNano()), "seed for the generated submissions")
+
+	if err := flags.Parse(args); err != nil {
+		return fmt.Errorf("parse flags: %w", err)
+	}
+
+	if *formID == "" {
+		return errors.New("--form is required")
+	}
+
+	headers := map[string]string{}
+	if *apiKey != "" {
+		headers[*apiKeyHeader] = *apiKey
+	}
+
+	client := bench.NewClient(bench.ClientOptions{
+		BaseURL:  *target,
+		Timeout:  *timeout,
+		Headers:  headers,
+		MaxConns: *concurrency,
+	})
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	report, err := bench.Run(ctx, client, bench.Options{
+		FormID:      *formID,
+		RPS:         *rps,
+		Duration:    *duration,
+		Concurrency: *concurrency,
+		Seed:        *seed,
+	})
+	if err != nil {
+		return fmt.Errorf("bench submit: %w", err)
+	}
+
+	if writeErr := writeReport(stdout, report); writeErr != nil {
+		return writeErr
+	}
+
+	if report.Failed > 0 {
+		return fmt.Errorf("%d of %d requests failed", report.Failed, report.Sent)
+	}
+
+	return nil
+}
+
+// k8sRender runs "k8s render". It needs no database; the configuration is
+// loaded and validated as the server would load it.
+func k8sRender(args []string, stdout io.Writer) error {
+	flags := flag.NewFlagSet("k8s render", flag.ContinueOnError)
+	format := flags.String("format", "manifests", "output: manifests or helm")
+	image := flags.String("image", "", "container image to deploy")
+	name := flags.String("name", "goforms", "name of the Kubernetes objects")
+	namespace := flags.String("namespace", "", "namespace of the Kubernetes objects")
+	replicas := flags.Int("replicas", 1, "replica count")
+	host := flags.String("host", "", "Ingress host; no Ingress without it")
+	ingressClass := flags.String("ingress-class", "", "Ingress class name")
+	tlsSecret := flags.String("tls-secret", "", "Secret holding the Ingress TLS certificate")
+	dryRun := flags.Bool("dry-run", false, "diff the manifests against the cluster with kubectl diff")
+
+	if err := flags.Parse(args); err != nil {
+		return fmt.Errorf("parse flags: %w", err)
+	}
+
+	if *dryRun && *format != "manifests" {
+		return errors.New("--dry-run diffs manifests; it cannot be combined with --format helm")
+	}
+
+	cfg, err := config.NewViperConfig().Load()
+	if err != nil {
+		return fmt.Errorf("load config: %w", err)
+	}
+
+	opts := deploy.Options{
+		Name:         *name,
+		Namespace:    *namespace,
+		Image:        *image,
+		Replicas:     *replicas,
+		Host:         *host,
+		IngressClass: *ingressClass,
+		TLSSecret:    *tlsSecret,
+	}
+
+	var rendered []byte
+
+	switch *format {
+	case "manifests":
+		rendered, err = deploy.RenderManifests(cfg, opts)
+	case "helm":
+		rendered, err = deploy.RenderHelmValues(cfg, opts)
+	default:
+		return fmt.Errorf("unknown format %q: expected manifests or helm", *format)
+	}
+
+	if err != nil {
+		return fmt.Errorf("render: %w", err)
+	}
+
+	if !*dryRun {
+		_, err = stdout.Write(rendered)
+
+		return err
+	}
+
+	return kubectlDiff(rendered, stdout)
+}
+
+// kubectlDiff diffs manifests against the cluster of the current kubectl
+// context. kubectl diff exits 1 when there are differences, which is not a failure.
+func kubectlDiff(manifests []byte, stdout io.Writer) error {
+	cmd := exec.CommandContext(context.Background(), "kubectl", "diff", "-f", "-")
+	cmd.Stdin = strings.NewReader(string(manifests))
+	cmd.Stdout = stdout
+	cmd.Stderr = os.Stderr
+
+	err := cmd.Run()
+
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) && exitErr.ExitCode() == 1 {
+		return nil
+	}
+
+	if err != nil {
+		return fmt.Errorf("kubectl diff: %w", err)
+	}
+
+	return nil
+}
+
+// runDoctor runs "doctor". It connects to the database directly, without the application graph.
+func runDoctor(args []string, stdout io.Writer) error {
+	flags := flag.NewFlagSet("doctor", flag.ContinueOnError)
+	envFile := flags.String("env-file", "", "env file to load before reading the configuration")
+	skipDocker := flags.Bool("skip-docker", false, "skip the Docker and Compose checks")
+	skipDatabase := flags.Bool("skip-database", false, "skip the database connection check")
+	minFree := flags.Uint64("min-free", doctor.DefaultMinFreeBytes, "free bytes required for uploads")
+	timeout := flags.Duration("timeout", doctor.DefaultTimeout, "timeout of each external check")
+
+	if err := flags.Parse(args); err != nil {
+		return fmt.Errorf("parse flags: %w", err)
+	}
+
+	if *envFile != "" {
+		file, err := envfile.Load(*envFile)
+		if err != nil {
+			return err
+		}
+
+		if err = file.Apply(); err != nil {
+			return err
+		}
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	report := doctor.Run(ctx, config.NewViperConfig().LoadUnvalidated, doctor.Options{
+		Timeout:      *timeout,
+		MinFreeBytes: *minFree,
+		SkipDocker:   *skipDocker,
+		SkipDatabase: *skipDatabase,
+		Ping:         database.Ping,
+	})
+
+	if err := writeReport(stdout, report); err != nil {
+		return err
+	}
+
+	if report.Failed() {
+		return errors.New("doctor found failing checks")
+	}
+
+	return nil
+}
+
+// versionReport is the output of "version"
+type versionReport struct {
+	version.Info
+	Update *updates.Status `json:"update,omitempty"`
+}
+
+// printVersion runs "version"
+func printVersion(args []string, stdout io.Writer) error {
+	flags := flag.NewFlagSet("version", flag.ContinueOnError)
+	check := flags.Bool("check", false, "check GitHub for a newer release")
+
+	if err := flags.Parse(args); err != nil {
+		return fmt.Errorf("parse flags: %w", err)
+	}
+
+	report := versionReport{Info: version.GetInfo()}
+	if !*check {
+		return writeReport(stdout, report)
+	}
+
+	cfg, err := config.NewViperConfig().LoadUnvalidated()
+	if err != nil {
+		return fmt.Errorf("load config: %w", err)
+	}
+
+	clients, err := httpclient.New(cfg.HTTPClient, nil)
+	if err != nil {
+		return err
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	status, checkErr := updates.New(cfg.Updates, clients, nil).Check(ctx)
+	report.Update = &status
+
+	if err = writeReport(stdout, report); err != nil {
+		return err
+	}
+
+	return checkErr
+}
+
+// configDocsHeader starts the Markdown reference
+const configDocsHeader = "# Configuration reference\n\n" +
+	"Generated by `goforms config docs`; do not edit. Keys are set in the\n" +
+	"configuration file or through the listed environment variables.\n\n"
+
+// configDocs runs "config docs"
+func configDocs(args []string, stdout io.Writer) error {
+	flags := flag.NewFlagSet("config docs", flag.ContinueOnError)
+	format := flags.String("format", "markdown", "output format: markdown or json")
+	check := flags.String("check", "", "compare the reference with this file instead of writing it")
+
+	if err := flags.Parse(args); err != nil {
+		return fmt.Errorf("parse flags: %w", err)
+	}
+
+	docs := config.Reference()
+
+	var out bytes.Buffer
+
+	switch *format {
+	case "markdown":
+		out.WriteString(configDocsHeader + config.ReferenceMarkdown(docs))
+	case "json":
+		if err := writeReport(&out, docs); err != nil {
+			return err
+		}
+	default:
+		return fmt.Errorf("unknown format %q", *format)
+	}
+
+	if *check == "" {
+		_, err := stdout.Write(out.Bytes())
+
+		return err
+	}
+
+	current, err := os.ReadFile(*check)
+	if err != nil {
+		return fmt.Errorf("read %s: %w", *check, err)
+	}
+
+	if !bytes.Equal(current, out.Bytes()) {
+		return fmt.Errorf("%s is out of date; regenerate it with goforms config docs", *check)
+	}
+
+	return nil
+}
+
+// stringsFlag collects repeated string flags
+type stringsFlag []string
+
+// String implements flag.Value
+func (s *stringsFlag) String() string {
+	return strings.Join(*s, ",")
+}
+
+// Set implements flag.Value
+func (s *stringsFlag) Set(value string) error {
+	*s = append(*s, value)
+
+	return nil
+}
+
+// envReport is the output of "env list" and "env set"
+type envReport struct {
+	File      string            `json:"file"`
+	Encrypted bool              `json:"encrypted"`
+	Values    map[string]string `json:"values,omitempty"`
+	Set       []string          `json:"set,omitempty"`
+	Warnings  []string          `json:"warnings,omitempty"`
+}
+
+// secretKeyParts mark keys whose values "env list" masks
+var secretKeyParts = []string{"SECRET", "PASSWORD", "TOKEN", "KEY", "DSN"}
+
+// openEnvFile opens the env file named by --file, decrypting it when encrypted
+func openEnvFile(path string) (file *envfile.File, encrypted bool, recipients []string, err error) {
+	file, encrypted, recipients, err = envfile.Open(context.Background(), path)
+	if err != nil {
+		return nil, false, nil, fmt.Errorf("open env file: %w", err)
+	}
+
+	return file, encrypted, recipients, nil
+}
+
+// envWarnings returns the required-key warnings, comparing with .env.example beside the file
+func envWarnings(file *envfile.File) []string {
+	example, err := envfile.Load(filepath.Join(filepath.Dir(file.Path()), ".env.example"))
+	if err != nil {
+		example = nil
+	}
+
+	return envfile.Warnings(file, example)
+}
+
+// envList runs "env list"
+func envList(args []string, stdout io.Writer) error {
+	flags := flag.NewFlagSet("env list", flag.ContinueOnError)
+	path := flags.String("file", ".env", "env file")
+	showSecrets := flags.Bool("show-secrets", false, "print secret values instead of masking them")
+
+	if err := flags.Parse(args); err != nil {
+		return fmt.Errorf("parse flags: %w", err)
+	}
+
+	file, encrypted, _, err := openEnvFile(*path)
+	if err != nil {
+		return err
+	}
+
+	values := file.Values()
+
+	for key, value := range values {
+		upper := strings.ToUpper(key)
+		if !*showSecrets && value != "" && slices.ContainsFunc(secretKeyParts, func(part string) bool {
+			return strings.Contains(upper, part)
+		}) {
+			values[key] = "********"
+		}
+	}
+
+	return writeReport(stdout, envReport{
+		File:      *path,
+		Encrypted: encrypted,
+		Values:    values,
+		Warnings:  envWarnings(file),
+	})
+}
+
+// envGet runs "env get"
+func envGet(args []string, stdout io.Writer) error {
+	flags := flag.NewFlagSet("env get", flag.ContinueOnError)
+	path := flags.String("file", ".env", "env file")
+
+	if err := flags.Parse(args); err != nil {
+		return fmt.Errorf("parse flags: %w", err)
+	}
+
+	if flags.NArg() != 1 {
+		return errors.New("env get takes one key")
+	}
+
+	file, _, _, err := openEnvFile(*path)
+	if err != nil {
+		return err
+	}
+
+	value, ok := file.Get(flags.Arg(0))
+	if !ok {
+		return fmt.Errorf("%s is not set in %s", flags.Arg(0), *path)
+	}
+
+	_, err = fmt.Fprintln(stdout, value)
+
+	return err
+}
+
+// envSet runs "env set"
+func envSet(args []string, stdout io.Writer) error {
+	flags := flag.NewFlagSet("env set", flag.ContinueOnError)
+	path := flags.String("file", ".env", "env file")
+	force := flags.Bool("force", false, "write values even when they add configuration validation errors")
+
+	if err := flags.Parse(args); err != nil {
+		return fmt.Errorf("parse flags: %w", err)
+	}
+
+	if flags.NArg() == 0 {
+		return errors.New("env set takes KEY=VALUE arguments")
+	}
+
+	file, encrypted, recipients, err := openEnvFile(*path)
+	if err != nil {
+		return err
+	}
+
+	before, err := envfile.Problems(file.Values())
+	if err != nil {
+		return err
+	}
+
+	keys := make([]string, 0, flags.NArg())
+
+	for _, assignment := range flags.Args() {
+		key, value, ok := strings.Cut(assignment, "=")
+		if !ok {
+			return fmt.Errorf("invalid assignment %q: expected KEY=VALUE", assignment)
+		}
+
+		if setErr := file.Set(key, value); setErr != nil {
+			return setErr
+		}
+
+		keys = append(keys, key)
+	}
+
+	after, err := envfile.Problems(file.Values())
+	if err != nil {
+		return err
+	}
+
+	if introduced := envfile.Introduced(before, after); len(introduced) > 0 && !*force {
+		return fmt.Errorf("not written, the values fail validation (use --force to write anyway): %s",
+			strings.Join(introduced, "; "))
+	}
+
+	if encrypted {
+		err = file.SaveEncrypted(context.Background(), recipients)
+	} else {
+		err = file.Save()
+	}
+
+	if err != nil {
+		return err
+	}
+
+	return writeReport(stdout, envReport{
+		File:      *path,
+		Encrypted: encrypted,
+		Set:       keys,
+		Warnings:  envWarnings(file),
+	})
+}
+
+// envEncrypt runs "env encrypt"
+func envEncrypt(args []string, stdout io.Writer) error {
+	flags := flag.NewFlagSet("env encrypt", flag.ContinueOnError)
+	path := flags.String("file", ".env", "env file")
+
+	var recipients stringsFlag
+	flags.Var(&recipients, "age", "age recipient to encrypt for (repeatable; default: the .sops.yaml creation rules)")
+
+	if err := flags.Parse(args); err != nil {
+		return fmt.Errorf("parse flags: %w", err)
+	}
+
+	file, err := envfile.Load(*path)
+	if err != nil {
+		return err
+	}
+
+	if file.Encrypted() {
+		return fmt.Errorf("%s is already encrypted", *path)
+	}
+
+	if err = file.SaveEncrypted(context.Background(), recipients); err != nil {
+		return err
+	}
+
+	return writeReport(stdout, envReport{File: *path, Encrypted: true})
+}
+
+// withServices starts the application graph without the HTTP server, runs fn and shuts down
+func withServices(fn func(ctx context.Context, svc *services) error) error {
+	var svc services
+
+	app := fx.New(
+		fx.NopLogger,
+		config.Module,
+		infrastructure.Module,
+		domain.Module,
+		fx.Invoke(func(
+			forms form.Service,
+			imports form.ImportService,
+			triage form.TriageService,
+			options *optionsource.Resolver,
+			users user.Repository,
+			events eventlog.Service,
+			deadLetters deadletter.Service,
+			encryptionService encryption.Service,
+			// Built so that they register their dead-letter retry handlers
+			_ email.Sender,
+			_ *extension.Runner,
+			sanitizer sanitization.ServiceInterface,
+			db database.DB,
+			shards *database.Shards,
+			logger logging.Logger,
+		) {
+			svc.forms = forms
+			svc.users = users
+			svc.events = events
+			svc.deadLetters = deadLetters
+			svc.encryption = encryptionService
+			svc.importer = importer.New(forms, imports, sanitizer, logger)
+			svc.seeder = seed.New(users, forms, imports, logger)
+			svc.revalidator = revalidate.New(forms, triage, options, logger)
+
+			if shards != nil {
+				svc.shards = shards
+				svc.rebalancer = formstore.NewRebalancer(db, shards, logger)
+			}
+		}),
+	)
+
+	startCtx, cancelStart := context.WithTimeout(context.Background(), lifecycleTimeout)
+	defer cancelStart()
+
+	if err := app.Start(startCtx); err != nil {
+		return fmt.Errorf("start application: %w", err)
+	}
+
+	runErr := fn(context.Background(), &svc)
+
+	stopCtx, cancelStop := context.WithTimeout(context.Background(), lifecycleTimeout)
+	defer cancelStop()
+
+	if err := app.Stop(stopCtx); err != nil && runErr == nil {
+		return fmt.Errorf("stop application: %w", err)
+	}
+
+	return runErr
+}
+
+// writeReport prints an import report as indented JSON
+func writeReport(w io.Writer, report any) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+
+	if err := encoder.Encode(report); err != nil {
+		return fmt.Errorf("write report: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,130 @@
+package command
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/spf13/cobra"
+
+	"github.com/goformx/goforms/internal/domain/entities"
+	"github.com/goformx/goforms/internal/domain/user"
+)
+
+// userDeletedReport is written after "user delete"
+type userDeletedReport struct {
+	ID      string `json:"id"`
+	Deleted bool   `json:"deleted"`
+}
+
+// newUserCommand creates "user" and its subcommands
+func newUserCommand(stdout io.Writer) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "user",
+		Short: "Create, list and delete user accounts",
+	}
+
+	cmd.AddCommand(newUserCreateCommand(stdout), newUserListCommand(stdout), newUserDeleteCommand(stdout))
+
+	return cmd
+}
+
+// newUserCreateCommand creates "user create"
+func newUserCreateCommand(stdout io.Writer) *cobra.Command {
+	var email, password, firstName, lastName, role string
+
+	cmd := &cobra.Command{
+		Use:   "create",
+		Short: "Create a user, such as the first admin",
+		Args:  cobra.NoArgs,
+		RunE: func(_ *cobra.Command, _ []string) error {
+			if role != user.RoleUser && role != user.RoleAdmin {
+				return user.ErrRoleInvalid
+			}
+
+			account, err := entities.NewUser(email, password, firstName, lastName)
+			if err != nil {
+				return fmt.Errorf("create user: %w", err)
+			}
+
+			account.Role = role
+
+			return withServices(func(ctx context.Context, svc *services) error {
+				if existing, getErr := svc.users.GetByEmail(ctx, email); getErr == nil && existing != nil {
+					return user.ErrEmailAlreadyExists
+				}
+
+				if createErr := svc.users.Create(ctx, account); createErr != nil {
+					return fmt.Errorf("create user: %w", createErr)
+				}
+
+				return writeReport(stdout, account)
+			})
+		},
+	}
+
+	cmd.Flags().StringVar(&email, "email", "", "email address to sign in with")
+	cmd.Flags().StringVar(&password, "password", "", "password, at least 8 characters")
+	cmd.Flags().StringVar(&firstName, "first-name", "", "first name")
+	cmd.Flags().StringVar(&lastName, "last-name", "", "last name")
+	cmd.Flags().StringVar(&role, "role", user.RoleUser, "user or admin")
+	_ = cmd.MarkFlagRequired("email")
+	_ = cmd.MarkFlagRequired("password")
+
+	return cmd
+}
+
+// newUserListCommand creates "user list"
+func newUserListCommand(stdout io.Writer) *cobra.Command {
+	var offset, limit int
+
+	cmd := &cobra.Command{
+		Use:   "list",
+		Short: "List users",
+		Args:  cobra.NoArgs,
+		RunE: func(_ *cobra.Command, _ []string) error {
+			return withServices(func(ctx context.Context, svc *services) error {
+				users, err := svc.users.List(ctx, offset, limit)
+				if err != nil {
+					return fmt.Errorf("list users: %w", err)
+				}
+
+				if users == nil {
+					users = []*entities.User{}
+				}
+
+				return writeReport(stdout, users)
+			})
+		},
+	}
+
+	cmd.Flags().IntVar(&offset, "offset", 0, "number of users to skip")
+	cmd.Flags().IntVar(&limit, "limit", 50, "maximum number of users to list")
+
+	return cmd
+}
+
+// newUserDeleteCommand creates "user delete"
+func newUserDeleteCommand(stdout io.Writer) *cobra.Command {
+	var id string
+
+	cmd := &cobra.Command{
+		Use:   "delete",
+		Short: "Delete a user",
+		Args:  cobra.NoArgs,
+		RunE: func(_ *cobra.Command, _ []string) error {
+			return withServices(func(ctx context.Context, svc *services) error {
+				if err := svc.users.Delete(ctx, id); err != nil {
+					return fmt.Errorf("delete user: %w", err)
+				}
+
+				return writeReport(stdout, userDeletedReport{ID: id, Deleted: true})
+			})
+		},
+	}
+
+	cmd.Flags().StringVar(&id, "id", "", "ID of the user to delete")
+	_ = cmd.MarkFlagRequired("id")
+
+	return cmd
+}
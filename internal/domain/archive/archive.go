@@ -0,0 +1,80 @@
+// Package archive moves old submissions out of the database into compressed
+// JSON Lines files in the storage backend and reads them back on demand.
+// Each archiving run writes one gzipped file per form under
+// <prefix>/<form ID>/, so a form's archived submissions can be found without
+// an index. A file is written before its submissions are deleted; a run that
+// fails in between archives those submissions again, and reads keep the copy
+// from the newest file.
+package archive
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/goformx/goforms/internal/domain/form/model"
+)
+
+// ErrNotArchived is returned when an archived submission cannot be found
+var ErrNotArchived = errors.New("submission is not archived")
+
+// record is an archived submission with the fields hidden from API responses carried alongside
+type record struct {
+	*model.FormSubmission
+
+	Tags model.JSON `json:"tags"`
+}
+
+// encodeFile writes submissions as gzipped JSON Lines
+func encodeFile(submissions []*model.FormSubmission) ([]byte, error) {
+	var buf bytes.Buffer
+
+	zw := gzip.NewWriter(&buf)
+	encoder := json.NewEncoder(zw)
+
+	for _, submission := range submissions {
+		if err := encoder.Encode(record{FormSubmission: submission, Tags: submission.Tags}); err != nil {
+			return nil, fmt.Errorf("encode archived submission %s: %w", submission.ID, err)
+		}
+	}
+
+	if err := zw.Close(); err != nil {
+		return nil, fmt.Errorf("compress archive: %w", err)
+	}
+
+	return buf.Bytes(), nil
+}
+
+// decodeFile reads the submissions in a gzipped JSON Lines file
+func decodeFile(data []byte) ([]*model.FormSubmission, error) {
+	zr, err := gzip.NewReader(bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("decompress archive: %w", err)
+	}
+	defer zr.Close()
+
+	var submissions []*model.FormSubmission
+
+	decoder := json.NewDecoder(zr)
+
+	for {
+		var r record
+		if decodeErr := decoder.Decode(&r); decodeErr != nil {
+			if errors.Is(decodeErr, io.EOF) {
+				return submissions, nil
+			}
+
+			return nil, fmt.Errorf("decode archived submission: %w", decodeErr)
+		}
+
+		if r.FormSubmission == nil {
+			continue
+		}
+
+		r.FormSubmission.Tags = r.Tags
+		submissions = append(submissions, r.FormSubmission)
+	}
+}
@@ -0,0 +1,282 @@
+package archive
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"path"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/goformx/goforms/internal/domain/form/model"
+	"github.com/goformx/goforms/internal/infrastructure/logging"
+)
+
+const (
+	// fileExt names archive files
+	fileExt = ".jsonl.gz"
+	// fileTimeLayout starts every archive file name, so a form's files sort by creation time
+	fileTimeLayout = "20060102T150405Z"
+)
+
+// Repository reads and deletes the submissions to archive
+type Repository interface {
+	// Expired returns up to limit submissions submitted before cutoff, oldest first
+	Expired(ctx context.Context, cutoff time.Time, limit int) ([]*model.FormSubmission, error)
+	// Delete deletes the submissions with the given IDs
+	Delete(ctx context.Context, ids []string) error
+}
+
+// Storage stores archive files in the configured storage backend
+type Storage interface {
+	Put(ctx context.Context, key string, data []byte) error
+	Get(ctx context.Context, key string) ([]byte, error)
+	// List lists the keys under prefix
+	List(ctx context.Context, prefix string) ([]string, error)
+}
+
+// Config holds the archiving settings
+type Config struct {
+	// After is the age, by submission time, at which submissions are archived
+	After time.Duration
+	// BatchSize is the number of submissions moved per repository round trip
+	BatchSize int
+	// Prefix is the storage key prefix archives are written under
+	Prefix string
+}
+
+// Service defines the interface for submission archiving
+type Service interface {
+	// Run archives every submission older than the configured age and returns how many were moved
+	Run(ctx context.Context) (int, error)
+	// RunScheduled archives old submissions; it is the archive schedule's job
+	RunScheduled(ctx context.Context) error
+	// Get returns an archived submission of a form, or ErrNotArchived
+	Get(ctx context.Context, formID, submissionID string) (*model.FormSubmission, error)
+	// List returns a form's archived submissions, oldest first
+	List(ctx context.Context, formID string) ([]*model.FormSubmission, error)
+}
+
+// service handles submission archiving
+type service struct {
+	repository Repository
+	storage    Storage
+	config     Config
+	logger     logging.Logger
+	now        func() time.Time
+	// mu lets one archiving run happen at a time
+	mu sync.Mutex
+}
+
+// NewService creates a new submission archiving service
+func NewService(repository Repository, storage Storage, cfg Config, logger logging.Logger) Service {
+	return &service{
+		repository: repository,
+		storage:    storage,
+		config:     cfg,
+		logger:     logger,
+		now:        time.Now,
+	}
+}
+
+// Run moves submissions older than the configured age to archive files, a batch at a time
+func (s *service) Run(ctx context.Context) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.now().UTC()
+	cutoff := now.Add(-s.config.After)
+	total := 0
+
+	for {
+		batch, err := s.repository.Expired(ctx, cutoff, s.config.BatchSize)
+		if err != nil {
+			return total, fmt.Errorf("list submissions to archive: %w", err)
+		}
+
+		if len(batch) == 0 {
+			break
+		}
+
+		if archiveErr := s.archive(ctx, now, batch); archiveErr != nil {
+			return total, archiveErr
+		}
+
+		total += len(batch)
+
+		if len(batch) < s.config.BatchSize {
+			break
+		}
+	}
+
+	if total > 0 {
+		s.logger.Info("submissions archived", "submissions", total, "cutoff", cutoff)
+	}
+
+	return total, nil
+}
+
+// RunScheduled archives old submissions
+func (s *service) RunScheduled(ctx context.Context) error {
+	if _, err := s.Run(ctx); err != nil {
+		return fmt.Errorf("scheduled submission archiving: %w", err)
+	}
+
+	return nil
+}
+
+// archive writes one batch to a file per form and then deletes it from the repository
+func (s *service) archive(ctx context.Context, now time.Time, batch []*model.FormSubmission) error {
+	byForm := make(map[string][]*model.FormSubmission)
+	ids := make([]string, 0, len(batch))
+
+	for _, submission := range batch {
+		byForm[submission.FormID] = append(byForm[submission.FormID], submission)
+		ids = append(ids, submission.ID)
+	}
+
+	for formID, submissions := range byForm {
+		if !validFormID(formID) {
+			return fmt.Errorf("archive submissions of form %q: invalid form ID", formID)
+		}
+
+		data, err := encodeFile(submissions)
+		if err != nil {
+			return err
+		}
+
+		key, err := s.fileKey(formID, now)
+		if err != nil {
+			return err
+		}
+
+		if putErr := s.storage.Put(ctx, key, data); putErr != nil {
+			return fmt.Errorf("store archive of form %s: %w", formID, putErr)
+		}
+	}
+
+	if err := s.repository.Delete(ctx, ids); err != nil {
+		return fmt.Errorf("delete archived submissions: %w", err)
+	}
+
+	return nil
+}
+
+// Get returns an archived submission of a form, searching the newest files first
+func (s *service) Get(ctx context.Context, formID, submissionID string) (*model.FormSubmission, error) {
+	keys, err := s.files(ctx, formID)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, key := range slices.Backward(keys) {
+		submissions, readErr := s.read(ctx, key)
+		if readErr != nil {
+			return nil, readErr
+		}
+
+		for _, submission := range submissions {
+			if submission.ID == submissionID {
+				return submission, nil
+			}
+		}
+	}
+
+	return nil, ErrNotArchived
+}
+
+// List returns a form's archived submissions, oldest first. A submission
+// archived more than once is returned once, as stored in the newest file.
+func (s *service) List(ctx context.Context, formID string) ([]*model.FormSubmission, error) {
+	keys, err := s.files(ctx, formID)
+	if err != nil {
+		return nil, err
+	}
+
+	byID := make(map[string]*model.FormSubmission)
+
+	for _, key := range keys {
+		submissions, readErr := s.read(ctx, key)
+		if readErr != nil {
+			return nil, readErr
+		}
+
+		for _, submission := range submissions {
+			byID[submission.ID] = submission
+		}
+	}
+
+	list := make([]*model.FormSubmission, 0, len(byID))
+	for _, submission := range byID {
+		list = append(list, submission)
+	}
+
+	slices.SortFunc(list, func(a, b *model.FormSubmission) int {
+		if n := a.SubmittedAt.Compare(b.SubmittedAt); n != 0 {
+			return n
+		}
+
+		return strings.Compare(a.ID, b.ID)
+	})
+
+	return list, nil
+}
+
+// files lists a form's archive files, oldest first
+func (s *service) files(ctx context.Context, formID string) ([]string, error) {
+	if !validFormID(formID) {
+		return nil, nil
+	}
+
+	keys, err := s.storage.List(ctx, path.Join(s.config.Prefix, formID))
+	if err != nil {
+		return nil, fmt.Errorf("list archives of form %s: %w", formID, err)
+	}
+
+	files := make([]string, 0, len(keys))
+
+	for _, key := range keys {
+		if strings.HasSuffix(key, fileExt) {
+			files = append(files, key)
+		}
+	}
+
+	slices.Sort(files)
+
+	return files, nil
+}
+
+// read reads the submissions in an archive file
+func (s *service) read(ctx context.Context, key string) ([]*model.FormSubmission, error) {
+	data, err := s.storage.Get(ctx, key)
+	if err != nil {
+		return nil, fmt.Errorf("read archive %s: %w", key, err)
+	}
+
+	submissions, err := decodeFile(data)
+	if err != nil {
+		return nil, fmt.Errorf("read archive %s: %w", key, err)
+	}
+
+	return submissions, nil
+}
+
+// fileKey returns a new archive file key for a form
+func (s *service) fileKey(formID string, now time.Time) (string, error) {
+	suffix := make([]byte, 4)
+	if _, err := rand.Read(suffix); err != nil {
+		return "", fmt.Errorf("generate archive name: %w", err)
+	}
+
+	name := now.Format(fileTimeLayout) + "-" + hex.EncodeToString(suffix) + fileExt
+
+	return path.Join(s.config.Prefix, formID, name), nil
+}
+
+// validFormID keeps form IDs that are not a single path segment out of storage keys
+func validFormID(formID string) bool {
+	return formID != "" && formID != "." && formID != ".." && !strings.ContainsAny(formID, `/\`)
+}
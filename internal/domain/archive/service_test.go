@@ -0,0 +1,82 @@
+package archive_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/goformx/goforms/internal/domain/archive"
+	"github.com/goformx/goforms/internal/domain/form/model"
+	memorystore "github.com/goformx/goforms/internal/infrastructure/repository/memory"
+	"github.com/goformx/goforms/internal/infrastructure/storage"
+	mocklogging "github.com/goformx/goforms/test/mocks/logging"
+)
+
+func TestService_ArchivesOldSubmissions(t *testing.T) {
+	ctx := context.Background()
+
+	logger := mocklogging.NewMockLogger(gomock.NewController(t))
+	logger.EXPECT().Info(gomock.Any(), gomock.Any()).AnyTimes()
+
+	store := memorystore.NewStore(logger)
+	submissions := store.Submissions()
+
+	formModel := model.NewForm("user-1", "Contact", "", model.JSON{"components": []any{}})
+	require.NoError(t, store.Forms().CreateForm(ctx, formModel))
+
+	now := time.Now()
+	submit := func(age time.Duration, name string) *model.FormSubmission {
+		submission := &model.FormSubmission{
+			FormID:      formModel.ID,
+			Data:        model.JSON{"name": name},
+			Tags:        model.JSON{"tags": []any{"vip"}},
+			Status:      model.SubmissionStatusPending,
+			SubmittedAt: now.Add(-age),
+		}
+		require.NoError(t, submissions.CreateSubmission(ctx, submission))
+
+		return submission
+	}
+
+	oldest := submit(72*time.Hour, "Ada")
+	old := submit(48*time.Hour, "Grace")
+	recent := submit(time.Hour, "Linus")
+
+	svc := archive.NewService(store.Archive(), storage.NewLocal(t.TempDir()),
+		archive.Config{After: 24 * time.Hour, BatchSize: 1, Prefix: "archive"}, logger)
+
+	moved, err := svc.Run(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 2, moved)
+
+	_, err = submissions.GetByID(ctx, old.ID)
+	require.Error(t, err, "archived submissions are removed from the database")
+
+	_, err = submissions.GetByID(ctx, recent.ID)
+	require.NoError(t, err)
+
+	got, err := svc.Get(ctx, formModel.ID, old.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "Grace", got.Data["name"])
+	assert.Equal(t, []any{"vip"}, got.Tags["tags"])
+
+	_, err = svc.Get(ctx, formModel.ID, recent.ID)
+	require.ErrorIs(t, err, archive.ErrNotArchived)
+
+	_, err = svc.Get(ctx, "../"+formModel.ID, old.ID)
+	require.ErrorIs(t, err, archive.ErrNotArchived)
+
+	listed, err := svc.List(ctx, formModel.ID)
+	require.NoError(t, err)
+	require.Len(t, listed, 2)
+	assert.Equal(t, oldest.ID, listed[0].ID)
+	assert.Equal(t, old.ID, listed[1].ID)
+
+	moved, err = svc.Run(ctx)
+	require.NoError(t, err)
+	assert.Zero(t, moved)
+}
@@ -0,0 +1,80 @@
+package audit
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	domainerrors "github.com/goformx/goforms/internal/domain/common/errors"
+	"github.com/goformx/goforms/internal/infrastructure/logging"
+)
+
+// Repository defines the interface for audit log storage
+type Repository interface {
+	CreateEntry(ctx context.Context, entry *Entry) error
+	// ListEntries lists matching entries, newest first, with the total count
+	ListEntries(ctx context.Context, filter Filter, offset, limit int) ([]*Entry, int64, error)
+}
+
+// Service defines the interface for the audit log
+type Service interface {
+	// Record stores an entry; CreatedAt is set when empty
+	Record(ctx context.Context, entry *Entry) error
+	// List lists matching entries, newest first, with the total count
+	List(ctx context.Context, filter Filter, offset, limit int) ([]*Entry, int64, error)
+}
+
+// service handles audit log business logic
+type service struct {
+	repository Repository
+	logger     logging.Logger
+	now        func() time.Time
+}
+
+// NewService creates a new audit service
+func NewService(repository Repository, logger logging.Logger) Service {
+	return &service{
+		repository: repository,
+		logger:     logger,
+		now:        time.Now,
+	}
+}
+
+// Record stores an audit entry
+func (s *service) Record(ctx context.Context, entry *Entry) error {
+	switch {
+	case entry.ActorID == "":
+		return validationError(ErrActorRequired)
+	case entry.Action == "":
+		return validationError(ErrActionRequired)
+	}
+
+	if entry.CreatedAt.IsZero() {
+		entry.CreatedAt = s.now().UTC()
+	}
+
+	if err := s.repository.CreateEntry(ctx, entry); err != nil {
+		return fmt.Errorf("record audit entry: %w", err)
+	}
+
+	s.logger.Info("audit entry recorded",
+		"actor_id", entry.ActorID, "action", entry.Action,
+		"target_type", entry.TargetType, "target_id", entry.TargetID)
+
+	return nil
+}
+
+// List lists matching audit entries, newest first
+func (s *service) List(ctx context.Context, filter Filter, offset, limit int) ([]*Entry, int64, error) {
+	entries, total, err := s.repository.ListEntries(ctx, filter, offset, limit)
+	if err != nil {
+		return nil, 0, fmt.Errorf("list audit entries: %w", err)
+	}
+
+	return entries, total, nil
+}
+
+// validationError wraps an error as a domain validation error
+func validationError(err error) error {
+	return domainerrors.New(domainerrors.ErrCodeValidation, err.Error(), err)
+}
@@ -0,0 +1,67 @@
+package audit_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/goformx/goforms/internal/domain/audit"
+	domainerrors "github.com/goformx/goforms/internal/domain/common/errors"
+	mocklogging "github.com/goformx/goforms/test/mocks/logging"
+)
+
+// memoryRepository is an in-memory audit.Repository for tests
+type memoryRepository struct {
+	entries []*audit.Entry
+}
+
+func (r *memoryRepository) CreateEntry(_ context.Context, entry *audit.Entry) error {
+	r.entries = append(r.entries, entry)
+
+	return nil
+}
+
+func (r *memoryRepository) ListEntries(_ context.Context, filter audit.Filter, _, _ int) ([]*audit.Entry, int64, error) {
+	var entries []*audit.Entry
+
+	for _, entry := range r.entries {
+		if filter.Matches(entry) {
+			entries = append(entries, entry)
+		}
+	}
+
+	return entries, int64(len(entries)), nil
+}
+
+func TestService_Record(t *testing.T) {
+	ctx := context.Background()
+
+	logger := mocklogging.NewMockLogger(gomock.NewController(t))
+	logger.EXPECT().Info(gomock.Any(), gomock.Any()).AnyTimes()
+
+	repo := &memoryRepository{}
+	svc := audit.NewService(repo, logger)
+
+	require.NoError(t, svc.Record(ctx, &audit.Entry{
+		ActorID: "admin-1", Action: audit.ActionUserDisabled, TargetType: audit.TargetUser, TargetID: "user-1",
+	}))
+	require.NoError(t, svc.Record(ctx, &audit.Entry{
+		ActorID: "admin-2", Action: audit.ActionUserEnabled, TargetType: audit.TargetUser, TargetID: "user-1",
+	}))
+	require.Len(t, repo.entries, 2)
+	assert.False(t, repo.entries[0].CreatedAt.IsZero())
+
+	entries, total, err := svc.List(ctx, audit.Filter{ActorID: "admin-1"}, 0, 10)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), total)
+	assert.Equal(t, audit.ActionUserDisabled, entries[0].Action)
+
+	for _, entry := range []*audit.Entry{{Action: audit.ActionUserDisabled}, {ActorID: "admin-1"}} {
+		domainErr := domainerrors.GetDomainError(svc.Record(ctx, entry))
+		require.NotNil(t, domainErr)
+		assert.Equal(t, domainerrors.ErrCodeValidation, domainErr.Code)
+	}
+}
@@ -0,0 +1,121 @@
+package backup
+
+import (
+	"bytes"
+	"compress/gzip"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+)
+
+// archiveMagic prefixes every sealed archive and is bound to it as additional data
+var archiveMagic = []byte("GFXBACKUP1\n")
+
+// deriveKey turns the configured secret into an AES-256 key
+func deriveKey(secret string) []byte {
+	key := sha256.Sum256([]byte(secret))
+
+	return key[:]
+}
+
+// checksum returns the hex SHA-256 of data
+func checksum(data []byte) string {
+	sum := sha256.Sum256(data)
+
+	return hex.EncodeToString(sum[:])
+}
+
+// encodeDataset serializes and compresses a dataset
+func encodeDataset(dataset *Dataset) ([]byte, error) {
+	var buf bytes.Buffer
+
+	zw := gzip.NewWriter(&buf)
+	if err := json.NewEncoder(zw).Encode(dataset); err != nil {
+		return nil, fmt.Errorf("encode backup dataset: %w", err)
+	}
+
+	if err := zw.Close(); err != nil {
+		return nil, fmt.Errorf("compress backup dataset: %w", err)
+	}
+
+	return buf.Bytes(), nil
+}
+
+// decodeDataset decompresses and parses a dataset
+func decodeDataset(data []byte) (*Dataset, error) {
+	zr, err := gzip.NewReader(bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("decompress backup dataset: %w", err)
+	}
+	defer zr.Close()
+
+	var dataset Dataset
+	if decodeErr := json.NewDecoder(zr).Decode(&dataset); decodeErr != nil {
+		return nil, fmt.Errorf("decode backup dataset: %w", decodeErr)
+	}
+
+	if dataset.Version > datasetVersion {
+		return nil, ErrUnsupportedVersion
+	}
+
+	return &dataset, nil
+}
+
+// seal encrypts plaintext with AES-256-GCM under a fresh random nonce
+func seal(key, plaintext []byte) ([]byte, error) {
+	gcm, err := newGCM(key)
+	if err != nil {
+		return nil, err
+	}
+
+	nonce := make([]byte, gcm.NonceSize())
+	if _, readErr := io.ReadFull(rand.Reader, nonce); readErr != nil {
+		return nil, fmt.Errorf("generate backup nonce: %w", readErr)
+	}
+
+	sealed := make([]byte, 0, len(archiveMagic)+len(nonce)+len(plaintext)+gcm.Overhead())
+	sealed = append(sealed, archiveMagic...)
+	sealed = append(sealed, nonce...)
+
+	return gcm.Seal(sealed, nonce, plaintext, archiveMagic), nil
+}
+
+// unseal decrypts an archive written by seal
+func unseal(key, sealed []byte) ([]byte, error) {
+	gcm, err := newGCM(key)
+	if err != nil {
+		return nil, err
+	}
+
+	if !bytes.HasPrefix(sealed, archiveMagic) || len(sealed) < len(archiveMagic)+gcm.NonceSize() {
+		return nil, ErrDecrypt
+	}
+
+	body := sealed[len(archiveMagic):]
+
+	plaintext, err := gcm.Open(nil, body[:gcm.NonceSize()], body[gcm.NonceSize():], archiveMagic)
+	if err != nil {
+		return nil, ErrDecrypt
+	}
+
+	return plaintext, nil
+}
+
+func newGCM(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, fmt.Errorf("create backup cipher: %w", err)
+	}
+
+	gcm, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, fmt.Errorf("create backup cipher: %w", err)
+	}
+
+	return gcm, nil
+}
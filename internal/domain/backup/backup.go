@@ -0,0 +1,100 @@
+// Package backup writes encrypted application backups to the storage backend.
+// A backup holds every form and submission; files uploaded to forms are stored
+// inline in their submissions and are captured with them. Each backup is a
+// gzipped JSON archive sealed with AES-256-GCM, stored with a manifest that
+// records SHA-256 checksums of the sealed and the decrypted archive so a
+// damaged or tampered backup is refused on restore.
+package backup
+
+import (
+	"errors"
+	"time"
+
+	"github.com/goformx/goforms/internal/domain/form/model"
+)
+
+// Triggers recorded on a backup
+const (
+	// TriggerScheduled marks a backup written by the backup schedule
+	TriggerScheduled = "scheduled"
+	// TriggerManual marks a backup requested through the admin API
+	TriggerManual = "manual"
+)
+
+// datasetVersion is the archive format version written by this package
+const datasetVersion = 1
+
+var (
+	// ErrBackupNotFound is returned when no backup has the given ID
+	ErrBackupNotFound = errors.New("backup not found")
+
+	// ErrChecksumMismatch is returned when an archive does not match its manifest
+	ErrChecksumMismatch = errors.New("backup checksum does not match its manifest")
+
+	// ErrDecrypt is returned when an archive cannot be decrypted with the configured key
+	ErrDecrypt = errors.New("backup cannot be decrypted with the configured key")
+
+	// ErrUnsupportedVersion is returned for an archive written by a newer format
+	ErrUnsupportedVersion = errors.New("backup archive version is not supported")
+)
+
+// Backup is the manifest of one stored backup
+type Backup struct {
+	ID          string    `json:"id"`
+	Trigger     string    `json:"trigger"`
+	CreatedAt   time.Time `json:"created_at"`
+	Forms       int       `json:"forms"`
+	Submissions int       `json:"submissions"`
+	// Size is the size in bytes of the sealed archive
+	Size int64 `json:"size"`
+	// Checksum is the SHA-256 of the sealed archive; it can be checked without the key
+	Checksum string `json:"checksum"`
+	// ContentChecksum is the SHA-256 of the decrypted archive
+	ContentChecksum string `json:"content_checksum"`
+}
+
+// Dataset is the application data captured in a backup
+type Dataset struct {
+	Version     int          `json:"version"`
+	CreatedAt   time.Time    `json:"created_at"`
+	Forms       []Form       `json:"forms"`
+	Submissions []Submission `json:"submissions"`
+}
+
+// Form is a form with the fields hidden from API responses carried alongside
+type Form struct {
+	*model.Form
+
+	AccessSettings model.JSON `json:"access_settings"`
+}
+
+// NewForm wraps f for a dataset
+func NewForm(f *model.Form) Form {
+	return Form{Form: f, AccessSettings: f.AccessSettings}
+}
+
+// Model returns the form with its hidden fields restored
+func (f Form) Model() *model.Form {
+	f.Form.AccessSettings = f.AccessSettings
+
+	return f.Form
+}
+
+// Submission is a submission with the fields hidden from API responses carried alongside
+type Submission struct {
+	*model.FormSubmission
+
+	Tags model.JSON `json:"tags"`
+}
+
+// NewSubmission wraps s for a dataset
+func NewSubmission(s *model.FormSubmission) Submission {
+	return Submission{FormSubmission: s, Tags: s.Tags}
+}
+
+// Model returns the submission with its hidden fields restored
+func (s Submission) Model() *model.FormSubmission {
+	s.FormSubmission.Tags = s.Tags
+
+	return s.FormSubmission
+}
@@ -0,0 +1,372 @@
+package backup
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"path"
+	"regexp"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/goformx/goforms/internal/domain/user"
+	"github.com/goformx/goforms/internal/infrastructure/logging"
+)
+
+const (
+	// manifestExt and archiveExt name the two objects stored for each backup
+	manifestExt = ".json"
+	archiveExt  = ".bak"
+	// idTimeLayout starts every backup ID, so IDs from different seconds sort by creation time
+	idTimeLayout = "20060102T150405Z"
+)
+
+// idPattern matches backup IDs and keeps other input out of storage keys
+var idPattern = regexp.MustCompile(`^\d{8}T\d{6}Z-[0-9a-f]{8}$`)
+
+// Repository reads and writes the data captured in backups
+type Repository interface {
+	// Dump reads every form and submission
+	Dump(ctx context.Context) (*Dataset, error)
+	// Restore writes the dataset's forms and submissions, replacing rows with the same IDs
+	Restore(ctx context.Context, dataset *Dataset) error
+}
+
+// Storage stores backup objects in the configured storage backend
+type Storage interface {
+	Put(ctx context.Context, key string, data []byte) error
+	// Get returns an error wrapping fs.ErrNotExist for a missing key
+	Get(ctx context.Context, key string) ([]byte, error)
+	// List lists the keys under prefix
+	List(ctx context.Context, prefix string) ([]string, error)
+	Delete(ctx context.Context, key string) error
+}
+
+// Config holds the backup settings
+type Config struct {
+	// EncryptionKey is the secret archives are sealed with
+	EncryptionKey string
+	// Retention is the number of backups kept
+	Retention int
+	// Prefix is the storage key prefix backups are written under
+	Prefix string
+}
+
+// Service defines the interface for application backups
+type Service interface {
+	// Create writes a backup of every form and submission and prunes old backups
+	Create(ctx context.Context, trigger string) (*Backup, error)
+	// List lists the stored backups, newest first
+	List(ctx context.Context) ([]*Backup, error)
+	// Verify checks a backup's checksums and that it decrypts
+	Verify(ctx context.Context, id string) (*Backup, error)
+	// Restore verifies a backup and writes its forms and submissions back
+	Restore(ctx context.Context, id string) (*Backup, error)
+	// RunScheduled writes a scheduled backup; it is the backup schedule's job
+	RunScheduled(ctx context.Context) error
+}
+
+// service handles application backups
+type service struct {
+	repository Repository
+	storage    Storage
+	owners     user.UserEnsurer
+	config     Config
+	key        []byte
+	logger     logging.Logger
+	now        func() time.Time
+	// mu lets one backup or restore run at a time
+	mu sync.Mutex
+}
+
+// NewService creates a new backup service. owners recreates the accounts of
+// restored forms whose owner no longer exists.
+func NewService(
+	repository Repository,
+	storage Storage,
+	owners user.UserEnsurer,
+	cfg Config,
+	logger logging.Logger,
+) Service {
+	return &service{
+		repository: repository,
+		storage:    storage,
+		owners:     owners,
+		config:     cfg,
+		key:        deriveKey(cfg.EncryptionKey),
+		logger:     logger,
+		now:        time.Now,
+	}
+}
+
+// Create writes a backup of every form and submission. The archive is stored
+// before its manifest, so a listed backup is always complete.
+func (s *service) Create(ctx context.Context, trigger string) (*Backup, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	dataset, err := s.repository.Dump(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("dump backup data: %w", err)
+	}
+
+	now := s.now().UTC()
+	dataset.Version = datasetVersion
+	dataset.CreatedAt = now
+
+	content, err := encodeDataset(dataset)
+	if err != nil {
+		return nil, err
+	}
+
+	sealed, err := seal(s.key, content)
+	if err != nil {
+		return nil, err
+	}
+
+	id, err := newID(now)
+	if err != nil {
+		return nil, err
+	}
+
+	b := &Backup{
+		ID:              id,
+		Trigger:         trigger,
+		CreatedAt:       now,
+		Forms:           len(dataset.Forms),
+		Submissions:     len(dataset.Submissions),
+		Size:            int64(len(sealed)),
+		Checksum:        checksum(sealed),
+		ContentChecksum: checksum(content),
+	}
+
+	if putErr := s.storage.Put(ctx, s.objectKey(id, archiveExt), sealed); putErr != nil {
+		return nil, fmt.Errorf("store backup archive: %w", putErr)
+	}
+
+	manifest, err := json.Marshal(b)
+	if err != nil {
+		return nil, fmt.Errorf("encode backup manifest: %w", err)
+	}
+
+	if putErr := s.storage.Put(ctx, s.objectKey(id, manifestExt), manifest); putErr != nil {
+		return nil, fmt.Errorf("store backup manifest: %w", putErr)
+	}
+
+	s.logger.Info("backup created",
+		"backup_id", b.ID, "trigger", b.Trigger, "forms", b.Forms, "submissions", b.Submissions, "size", b.Size)
+
+	if pruneErr := s.prune(ctx); pruneErr != nil {
+		s.logger.Error("failed to prune old backups", "error", pruneErr)
+	}
+
+	return b, nil
+}
+
+// RunScheduled writes a scheduled backup
+func (s *service) RunScheduled(ctx context.Context) error {
+	if _, err := s.Create(ctx, TriggerScheduled); err != nil {
+		return fmt.Errorf("scheduled backup: %w", err)
+	}
+
+	return nil
+}
+
+// List lists the stored backups, newest first
+func (s *service) List(ctx context.Context) ([]*Backup, error) {
+	keys, err := s.storage.List(ctx, s.config.Prefix)
+	if err != nil {
+		return nil, fmt.Errorf("list backups: %w", err)
+	}
+
+	backups := make([]*Backup, 0, len(keys))
+
+	for _, key := range keys {
+		id, ok := strings.CutSuffix(path.Base(key), manifestExt)
+		if !ok || !idPattern.MatchString(id) {
+			continue
+		}
+
+		b, getErr := s.manifest(ctx, id)
+		if getErr != nil {
+			return nil, getErr
+		}
+
+		backups = append(backups, b)
+	}
+
+	slices.SortFunc(backups, func(a, b *Backup) int {
+		if n := b.CreatedAt.Compare(a.CreatedAt); n != 0 {
+			return n
+		}
+
+		return strings.Compare(b.ID, a.ID)
+	})
+
+	return backups, nil
+}
+
+// Verify checks a backup's checksums and that it decrypts with the configured key
+func (s *service) Verify(ctx context.Context, id string) (*Backup, error) {
+	b, _, err := s.open(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	return b, nil
+}
+
+// Restore verifies a backup and writes its forms and submissions back. Rows
+// with the same IDs are replaced; rows created after the backup are kept.
+func (s *service) Restore(ctx context.Context, id string) (*Backup, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	b, content, err := s.open(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	dataset, err := decodeDataset(content)
+	if err != nil {
+		return nil, err
+	}
+
+	if ensureErr := s.ensureOwners(ctx, dataset); ensureErr != nil {
+		return nil, ensureErr
+	}
+
+	if restoreErr := s.repository.Restore(ctx, dataset); restoreErr != nil {
+		return nil, fmt.Errorf("restore backup data: %w", restoreErr)
+	}
+
+	s.logger.Info("backup restored", "backup_id", b.ID, "forms", b.Forms, "submissions", b.Submissions)
+
+	return b, nil
+}
+
+// open reads a backup's archive, checks it against the manifest and decrypts it
+func (s *service) open(ctx context.Context, id string) (*Backup, []byte, error) {
+	b, err := s.manifest(ctx, id)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	sealed, err := s.storage.Get(ctx, s.objectKey(id, archiveExt))
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil, nil, ErrChecksumMismatch
+		}
+
+		return nil, nil, fmt.Errorf("read backup archive: %w", err)
+	}
+
+	if checksum(sealed) != b.Checksum {
+		return nil, nil, ErrChecksumMismatch
+	}
+
+	content, err := unseal(s.key, sealed)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if checksum(content) != b.ContentChecksum {
+		return nil, nil, ErrChecksumMismatch
+	}
+
+	return b, content, nil
+}
+
+// manifest reads a backup's manifest
+func (s *service) manifest(ctx context.Context, id string) (*Backup, error) {
+	if !idPattern.MatchString(id) {
+		return nil, ErrBackupNotFound
+	}
+
+	data, err := s.storage.Get(ctx, s.objectKey(id, manifestExt))
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil, ErrBackupNotFound
+		}
+
+		return nil, fmt.Errorf("read backup manifest: %w", err)
+	}
+
+	var b Backup
+	if decodeErr := json.Unmarshal(data, &b); decodeErr != nil {
+		return nil, fmt.Errorf("decode backup manifest %s: %w", id, decodeErr)
+	}
+
+	return &b, nil
+}
+
+// ensureOwners recreates the accounts of restored forms whose owner no longer exists
+func (s *service) ensureOwners(ctx context.Context, dataset *Dataset) error {
+	if s.owners == nil {
+		return nil
+	}
+
+	seen := make(map[string]bool)
+
+	for _, f := range dataset.Forms {
+		if f.Form == nil || f.UserID == "" || seen[f.UserID] {
+			continue
+		}
+
+		seen[f.UserID] = true
+
+		if err := s.owners.EnsureUser(ctx, f.UserID); err != nil && !errors.Is(err, user.ErrAccountDisabled) {
+			return fmt.Errorf("ensure form owner %s: %w", f.UserID, err)
+		}
+	}
+
+	return nil
+}
+
+// prune deletes the backups beyond the retention count, oldest first
+func (s *service) prune(ctx context.Context) error {
+	if s.config.Retention <= 0 {
+		return nil
+	}
+
+	backups, err := s.List(ctx)
+	if err != nil {
+		return err
+	}
+
+	for _, b := range backups[min(s.config.Retention, len(backups)):] {
+		// The manifest goes first, so a half-deleted backup is no longer listed
+		if deleteErr := s.storage.Delete(ctx, s.objectKey(b.ID, manifestExt)); deleteErr != nil {
+			return fmt.Errorf("delete backup manifest %s: %w", b.ID, deleteErr)
+		}
+
+		if deleteErr := s.storage.Delete(ctx, s.objectKey(b.ID, archiveExt)); deleteErr != nil {
+			return fmt.Errorf("delete backup archive %s: %w", b.ID, deleteErr)
+		}
+
+		s.logger.Info("backup pruned", "backup_id", b.ID)
+	}
+
+	return nil
+}
+
+// objectKey returns the storage key of one of a backup's objects
+func (s *service) objectKey(id, ext string) string {
+	return path.Join(s.config.Prefix, id+ext)
+}
+
+// newID returns a backup ID that sorts by creation time
+func newID(now time.Time) (string, error) {
+	suffix := make([]byte, 4)
+	if _, err := rand.Read(suffix); err != nil {
+		return "", fmt.Errorf("generate backup ID: %w", err)
+	}
+
+	return now.UTC().Format(idTimeLayout) + "-" + hex.EncodeToString(suffix), nil
+}
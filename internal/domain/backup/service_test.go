@@ -0,0 +1,136 @@
+package backup_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/goformx/goforms/internal/domain/backup"
+	"github.com/goformx/goforms/internal/domain/form/model"
+	memorystore "github.com/goformx/goforms/internal/infrastructure/repository/memory"
+	"github.com/goformx/goforms/internal/infrastructure/storage"
+	mocklogging "github.com/goformx/goforms/test/mocks/logging"
+)
+
+const testKey = "0123456789abcdef0123456789abcdef"
+
+func TestService_CreateAndRestore(t *testing.T) {
+	ctx := context.Background()
+
+	logger := mocklogging.NewMockLogger(gomock.NewController(t))
+	logger.EXPECT().Info(gomock.Any(), gomock.Any()).AnyTimes()
+
+	store := memorystore.NewStore(logger)
+	forms := store.Forms()
+
+	formModel := model.NewForm("user-1", "Contact", "", model.JSON{"components": []any{}})
+	formModel.AccessSettings = model.JSON{"passphrase_hash": "hash"}
+	require.NoError(t, forms.CreateForm(ctx, formModel))
+
+	submission := &model.FormSubmission{
+		FormID: formModel.ID,
+		Data:   model.JSON{"name": "Ada", "file": "data:image/png;base64,iVBORw0KGgo="},
+		Tags:   model.JSON{"tags": []any{"vip"}},
+		Status: model.SubmissionStatusPending,
+	}
+	require.NoError(t, store.Submissions().CreateSubmission(ctx, submission))
+
+	dir := t.TempDir()
+	svc := backup.NewService(store.Backups(), storage.NewLocal(dir), nil,
+		backup.Config{EncryptionKey: testKey, Retention: 2, Prefix: "backups"}, logger)
+
+	created, err := svc.Create(ctx, backup.TriggerManual)
+	require.NoError(t, err)
+	assert.Equal(t, 1, created.Forms)
+	assert.Equal(t, 1, created.Submissions)
+	assert.Len(t, created.Checksum, 64)
+
+	archive, err := os.ReadFile(filepath.Join(dir, "backups", created.ID+".bak"))
+	require.NoError(t, err)
+	assert.NotContains(t, string(archive), "Contact", "archives are encrypted")
+
+	_, err = svc.Verify(ctx, created.ID)
+	require.NoError(t, err)
+
+	require.NoError(t, forms.DeleteForm(ctx, formModel.ID))
+
+	restored, err := svc.Restore(ctx, created.ID)
+	require.NoError(t, err)
+	assert.Equal(t, created.ID, restored.ID)
+
+	got, err := forms.GetFormByID(ctx, formModel.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "Contact", got.Title)
+	assert.Equal(t, "hash", got.AccessSettings["passphrase_hash"])
+
+	gotSubmission, err := store.Submissions().GetByID(ctx, submission.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "data:image/png;base64,iVBORw0KGgo=", gotSubmission.Data["file"])
+	assert.Equal(t, []any{"vip"}, gotSubmission.Tags["tags"])
+}
+
+func TestService_RefusesDamagedBackups(t *testing.T) {
+	ctx := context.Background()
+
+	logger := mocklogging.NewMockLogger(gomock.NewController(t))
+	logger.EXPECT().Info(gomock.Any(), gomock.Any()).AnyTimes()
+
+	store := memorystore.NewStore(logger)
+	dir := t.TempDir()
+	objects := storage.NewLocal(dir)
+	cfg := backup.Config{EncryptionKey: testKey, Retention: 5, Prefix: "backups"}
+
+	svc := backup.NewService(store.Backups(), objects, nil, cfg, logger)
+
+	created, err := svc.Create(ctx, backup.TriggerManual)
+	require.NoError(t, err)
+
+	cfg.EncryptionKey = "another-key-another-key-another-key"
+	_, err = backup.NewService(store.Backups(), objects, nil, cfg, logger).Verify(ctx, created.ID)
+	require.ErrorIs(t, err, backup.ErrDecrypt)
+
+	name := filepath.Join(dir, "backups", created.ID+".bak")
+	archive, err := os.ReadFile(name)
+	require.NoError(t, err)
+
+	archive[len(archive)-1] ^= 0xff
+	require.NoError(t, os.WriteFile(name, archive, 0o600))
+
+	_, err = svc.Restore(ctx, created.ID)
+	require.ErrorIs(t, err, backup.ErrChecksumMismatch)
+
+	_, err = svc.Verify(ctx, "../../etc/passwd")
+	require.ErrorIs(t, err, backup.ErrBackupNotFound)
+}
+
+func TestService_Retention(t *testing.T) {
+	ctx := context.Background()
+
+	logger := mocklogging.NewMockLogger(gomock.NewController(t))
+	logger.EXPECT().Info(gomock.Any(), gomock.Any()).AnyTimes()
+
+	svc := backup.NewService(memorystore.NewStore(logger).Backups(), storage.NewLocal(t.TempDir()), nil,
+		backup.Config{EncryptionKey: testKey, Retention: 2, Prefix: "backups"}, logger)
+
+	var ids []string
+
+	for range 3 {
+		created, err := svc.Create(ctx, backup.TriggerScheduled)
+		require.NoError(t, err)
+
+		ids = append(ids, created.ID)
+	}
+
+	backups, err := svc.List(ctx)
+	require.NoError(t, err)
+	require.Len(t, backups, 2)
+
+	listed := []string{backups[0].ID, backups[1].ID}
+	assert.NotContains(t, listed, ids[0], "the oldest backup is pruned")
+	assert.Contains(t, listed, ids[2])
+}
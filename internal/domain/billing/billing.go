@@ -0,0 +1,110 @@
+// Package billing ties hosted accounts to paid plans. Plans are defined in the
+// config file, each with a Stripe price and the quota limits it grants. Users
+// subscribe through Stripe Checkout and manage their subscription in the Stripe
+// Customer Portal; Stripe's webhooks keep the stored subscription in step, and
+// the quota service applies the limits of the subscribed plan.
+package billing
+
+import (
+	"errors"
+	"slices"
+	"time"
+
+	"github.com/goformx/goforms/internal/domain/form"
+)
+
+// Subscription statuses, as Stripe reports them
+const (
+	StatusActive            = "active"
+	StatusTrialing          = "trialing"
+	StatusPastDue           = "past_due"
+	StatusIncomplete        = "incomplete"
+	StatusIncompleteExpired = "incomplete_expired"
+	StatusUnpaid            = "unpaid"
+	StatusCanceled          = "canceled"
+	StatusPaused            = "paused"
+)
+
+// Stripe event types the service applies; other events are acknowledged and ignored
+const (
+	EventSubscriptionCreated = "customer.subscription.created"
+	EventSubscriptionUpdated = "customer.subscription.updated"
+	EventSubscriptionDeleted = "customer.subscription.deleted"
+)
+
+// entitledStatuses keep a subscription's plan limits. A past-due subscription
+// keeps them while Stripe retries the payment.
+var entitledStatuses = []string{StatusActive, StatusTrialing, StatusPastDue}
+
+var (
+	// ErrPlanNotFound is returned for a plan ID that is not configured
+	ErrPlanNotFound = errors.New("billing plan not found")
+
+	// ErrSubscriptionNotFound is returned when a user has never subscribed
+	ErrSubscriptionNotFound = errors.New("billing subscription not found")
+
+	// ErrAlreadySubscribed is returned when a user with an entitled subscription
+	// starts another checkout; plan changes go through the customer portal
+	ErrAlreadySubscribed = errors.New("already subscribed; change plans in the billing portal")
+)
+
+// Plan is a paid plan and the quota limits it grants
+type Plan struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+	// PriceID is the Stripe price subscribed to at checkout
+	PriceID string           `json:"-"`
+	Limits  form.QuotaLimits `json:"limits"`
+}
+
+// Subscription is a user's Stripe subscription as last reported by a webhook
+type Subscription struct {
+	UserID         string `gorm:"primaryKey;size:36"         json:"-"`
+	CustomerID     string `gorm:"not null;size:255;index"    json:"-"`
+	SubscriptionID string `gorm:"not null;size:255"          json:"-"`
+	// PlanID is empty when the subscribed price is not a configured plan
+	PlanID            string     `gorm:"size:100"                   json:"plan_id"`
+	Status            string     `gorm:"not null;size:50"           json:"status"`
+	CurrentPeriodEnd  *time.Time `json:"current_period_end,omitempty"`
+	CancelAtPeriodEnd bool       `gorm:"not null;default:false"     json:"cancel_at_period_end"`
+	// EventAt is the creation time of the last event applied, so older events
+	// delivered late are skipped
+	EventAt   time.Time `gorm:"not null"                json:"-"`
+	CreatedAt time.Time `gorm:"not null;autoCreateTime" json:"created_at"`
+	UpdatedAt time.Time `gorm:"not null;autoUpdateTime" json:"updated_at"`
+}
+
+// TableName specifies the table name for the Subscription model
+func (s *Subscription) TableName() string {
+	return "billing_subscriptions"
+}
+
+// Entitled reports whether the subscription grants its plan's limits
+func (s *Subscription) Entitled() bool {
+	return s.PlanID != "" && slices.Contains(entitledStatuses, s.Status)
+}
+
+// Event is a subscription event received from Stripe's webhook
+type Event struct {
+	ID        string
+	Type      string
+	CreatedAt time.Time
+	// UserID is the user_id metadata set on subscriptions at checkout
+	UserID            string
+	CustomerID        string
+	SubscriptionID    string
+	PriceID           string
+	Status            string
+	CurrentPeriodEnd  *time.Time
+	CancelAtPeriodEnd bool
+}
+
+// Checkout describes a Stripe Checkout session for a new subscription
+type Checkout struct {
+	UserID string
+	// CustomerID reuses the user's Stripe customer from an earlier subscription
+	CustomerID string
+	PriceID    string
+	SuccessURL string
+	CancelURL  string
+}
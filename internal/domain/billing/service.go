@@ -0,0 +1,287 @@
+package billing
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/goformx/goforms/internal/domain/form"
+	"github.com/goformx/goforms/internal/infrastructure/logging"
+)
+
+// Repository defines the interface for subscription storage
+type Repository interface {
+	// GetSubscription returns a user's subscription, or ErrSubscriptionNotFound
+	GetSubscription(ctx context.Context, userID string) (*Subscription, error)
+	// GetSubscriptionByCustomer returns the subscription of a Stripe customer, or ErrSubscriptionNotFound
+	GetSubscriptionByCustomer(ctx context.Context, customerID string) (*Subscription, error)
+	// SaveSubscription creates or replaces a user's subscription
+	SaveSubscription(ctx context.Context, subscription *Subscription) error
+}
+
+// Gateway creates the Stripe sessions users are sent to
+type Gateway interface {
+	// CreateCheckoutSession returns the URL of a Checkout session for a new subscription
+	CreateCheckoutSession(ctx context.Context, checkout *Checkout) (string, error)
+	// CreatePortalSession returns the URL of a Customer Portal session
+	CreatePortalSession(ctx context.Context, customerID, returnURL string) (string, error)
+}
+
+// Config holds the billing settings
+type Config struct {
+	Plans []Plan
+	// FreeLimits apply to users without an entitled subscription
+	FreeLimits      form.QuotaLimits
+	SuccessURL      string
+	CancelURL       string
+	PortalReturnURL string
+}
+
+// Account is a user's plan, limits and subscription
+type Account struct {
+	// Plan is nil on the free limits
+	Plan         *Plan            `json:"plan"`
+	Limits       form.QuotaLimits `json:"limits"`
+	Subscription *Subscription    `json:"subscription"`
+}
+
+// Service defines the interface for plans and subscriptions. It implements
+// form.PlanLimits, so the quota service applies each user's plan limits.
+type Service interface {
+	// Plans lists the configured plans
+	Plans() []Plan
+	// Account returns a user's plan, limits and subscription
+	Account(ctx context.Context, userID string) (*Account, error)
+	// Checkout returns the URL of a Stripe Checkout session subscribing the user to a plan
+	Checkout(ctx context.Context, userID, planID string) (string, error)
+	// Portal returns the URL of a Stripe Customer Portal session for the user
+	Portal(ctx context.Context, userID string) (string, error)
+	// HandleEvent applies a subscription event received from Stripe
+	HandleEvent(ctx context.Context, event *Event) error
+	// Limits returns the quota limits of the user's plan
+	Limits(ctx context.Context, userID string) (form.QuotaLimits, error)
+}
+
+// service handles billing business logic
+type service struct {
+	repository Repository
+	gateway    Gateway
+	config     Config
+	logger     logging.Logger
+}
+
+// NewService creates a new billing service
+func NewService(repository Repository, gateway Gateway, cfg Config, logger logging.Logger) Service {
+	return &service{
+		repository: repository,
+		gateway:    gateway,
+		config:     cfg,
+		logger:     logger,
+	}
+}
+
+// Plans lists the configured plans
+func (s *service) Plans() []Plan {
+	return append([]Plan{}, s.config.Plans...)
+}
+
+// Account returns a user's plan, limits and subscription
+func (s *service) Account(ctx context.Context, userID string) (*Account, error) {
+	subscription, err := s.subscription(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	account := &Account{Limits: s.config.FreeLimits, Subscription: subscription}
+
+	if subscription != nil && subscription.Entitled() {
+		if plan := s.plan(subscription.PlanID); plan != nil {
+			account.Plan = plan
+			account.Limits = plan.Limits
+		}
+	}
+
+	return account, nil
+}
+
+// Limits returns the quota limits of the user's plan
+func (s *service) Limits(ctx context.Context, userID string) (form.QuotaLimits, error) {
+	account, err := s.Account(ctx, userID)
+	if err != nil {
+		return form.QuotaLimits{}, err
+	}
+
+	return account.Limits, nil
+}
+
+// Checkout starts a Stripe Checkout session for a plan. The user ID is carried
+// on the session and the subscription, so webhooks can find the user.
+func (s *service) Checkout(ctx context.Context, userID, planID string) (string, error) {
+	plan := s.plan(planID)
+	if plan == nil {
+		return "", ErrPlanNotFound
+	}
+
+	subscription, err := s.subscription(ctx, userID)
+	if err != nil {
+		return "", err
+	}
+
+	checkout := &Checkout{
+		UserID:     userID,
+		PriceID:    plan.PriceID,
+		SuccessURL: s.config.SuccessURL,
+		CancelURL:  s.config.CancelURL,
+	}
+
+	if subscription != nil {
+		if subscription.Entitled() {
+			return "", ErrAlreadySubscribed
+		}
+
+		checkout.CustomerID = subscription.CustomerID
+	}
+
+	url, err := s.gateway.CreateCheckoutSession(ctx, checkout)
+	if err != nil {
+		return "", fmt.Errorf("create checkout session: %w", err)
+	}
+
+	return url, nil
+}
+
+// Portal starts a Stripe Customer Portal session for a user who has subscribed
+func (s *service) Portal(ctx context.Context, userID string) (string, error) {
+	subscription, err := s.subscription(ctx, userID)
+	if err != nil {
+		return "", err
+	}
+
+	if subscription == nil {
+		return "", ErrSubscriptionNotFound
+	}
+
+	url, err := s.gateway.CreatePortalSession(ctx, subscription.CustomerID, s.config.PortalReturnURL)
+	if err != nil {
+		return "", fmt.Errorf("create portal session: %w", err)
+	}
+
+	return url, nil
+}
+
+// HandleEvent stores the subscription state an event reports. Events for
+// unknown users and events older than the stored state are skipped.
+func (s *service) HandleEvent(ctx context.Context, event *Event) error {
+	switch event.Type {
+	case EventSubscriptionCreated, EventSubscriptionUpdated, EventSubscriptionDeleted:
+	default:
+		return nil
+	}
+
+	existing, err := s.owner(ctx, event)
+	if err != nil {
+		return err
+	}
+
+	userID := event.UserID
+	if userID == "" && existing != nil {
+		userID = existing.UserID
+	}
+
+	if userID == "" {
+		s.logger.Warn("billing event for unknown customer skipped", "event_id", event.ID, "type", event.Type)
+
+		return nil
+	}
+
+	if existing != nil && event.CreatedAt.Before(existing.EventAt) {
+		s.logger.Info("stale billing event skipped", "event_id", event.ID, "type", event.Type)
+
+		return nil
+	}
+
+	subscription := &Subscription{
+		UserID:            userID,
+		CustomerID:        event.CustomerID,
+		SubscriptionID:    event.SubscriptionID,
+		PlanID:            s.planForPrice(event.PriceID),
+		Status:            event.Status,
+		CurrentPeriodEnd:  event.CurrentPeriodEnd,
+		CancelAtPeriodEnd: event.CancelAtPeriodEnd,
+		EventAt:           event.CreatedAt,
+	}
+
+	if event.Type == EventSubscriptionDeleted {
+		subscription.Status = StatusCanceled
+	}
+
+	if subscription.PlanID == "" && event.PriceID != "" {
+		s.logger.Warn("billing subscription to an unconfigured price", "event_id", event.ID, "price_id", event.PriceID)
+	}
+
+	if saveErr := s.repository.SaveSubscription(ctx, subscription); saveErr != nil {
+		return fmt.Errorf("save billing subscription: %w", saveErr)
+	}
+
+	s.logger.Info("billing subscription updated",
+		"event_id", event.ID, "type", event.Type, "plan", subscription.PlanID, "status", subscription.Status)
+
+	return nil
+}
+
+// owner returns the stored subscription an event belongs to, found by the
+// user_id metadata or else by the Stripe customer
+func (s *service) owner(ctx context.Context, event *Event) (*Subscription, error) {
+	if event.UserID != "" {
+		return s.subscription(ctx, event.UserID)
+	}
+
+	subscription, err := s.repository.GetSubscriptionByCustomer(ctx, event.CustomerID)
+	if errors.Is(err, ErrSubscriptionNotFound) {
+		return nil, nil
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("get billing subscription: %w", err)
+	}
+
+	return subscription, nil
+}
+
+// subscription returns a user's subscription, or nil when they have none
+func (s *service) subscription(ctx context.Context, userID string) (*Subscription, error) {
+	subscription, err := s.repository.GetSubscription(ctx, userID)
+	if errors.Is(err, ErrSubscriptionNotFound) {
+		return nil, nil
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("get billing subscription: %w", err)
+	}
+
+	return subscription, nil
+}
+
+// plan returns the configured plan with an ID
+func (s *service) plan(id string) *Plan {
+	for i := range s.config.Plans {
+		if s.config.Plans[i].ID == id {
+			plan := s.config.Plans[i]
+
+			return &plan
+		}
+	}
+
+	return nil
+}
+
+// planForPrice returns the ID of the plan subscribed to with a Stripe price
+func (s *service) planForPrice(priceID string) string {
+	for _, plan := range s.config.Plans {
+		if priceID != "" && plan.PriceID == priceID {
+			return plan.ID
+		}
+	}
+
+	return ""
+}
@@ -0,0 +1,176 @@
+package billing_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/goformx/goforms/internal/domain/billing"
+	"github.com/goformx/goforms/internal/domain/form"
+	mocklogging "github.com/goformx/goforms/test/mocks/logging"
+)
+
+// memoryRepository is an in-memory billing.Repository for tests
+type memoryRepository struct {
+	subscriptions map[string]*billing.Subscription
+}
+
+func (r *memoryRepository) GetSubscription(_ context.Context, userID string) (*billing.Subscription, error) {
+	subscription, ok := r.subscriptions[userID]
+	if !ok {
+		return nil, billing.ErrSubscriptionNotFound
+	}
+
+	copied := *subscription
+
+	return &copied, nil
+}
+
+func (r *memoryRepository) GetSubscriptionByCustomer(_ context.Context, customerID string) (*billing.Subscription, error) {
+	for _, subscription := range r.subscriptions {
+		if subscription.CustomerID == customerID {
+			copied := *subscription
+
+			return &copied, nil
+		}
+	}
+
+	return nil, billing.ErrSubscriptionNotFound
+}
+
+func (r *memoryRepository) SaveSubscription(_ context.Context, subscription *billing.Subscription) error {
+	copied := *subscription
+	r.subscriptions[subscription.UserID] = &copied
+
+	return nil
+}
+
+// recordingGateway records the checkout sessions it is asked to create
+type recordingGateway struct {
+	checkouts []*billing.Checkout
+}
+
+func (g *recordingGateway) CreateCheckoutSession(_ context.Context, checkout *billing.Checkout) (string, error) {
+	g.checkouts = append(g.checkouts, checkout)
+
+	return "https://checkout.stripe.test/session", nil
+}
+
+func (g *recordingGateway) CreatePortalSession(_ context.Context, customerID, _ string) (string, error) {
+	return "https://billing.stripe.test/" + customerID, nil
+}
+
+var (
+	freeLimits = form.QuotaLimits{MaxForms: 3, MaxSubmissionsPerMonth: 100}
+	proLimits  = form.QuotaLimits{MaxForms: 50, MaxSubmissionsPerMonth: 10000}
+)
+
+func newTestService(t *testing.T) (billing.Service, *memoryRepository, *recordingGateway) {
+	t.Helper()
+
+	logger := mocklogging.NewMockLogger(gomock.NewController(t))
+	logger.EXPECT().Info(gomock.Any(), gomock.Any()).AnyTimes()
+	logger.EXPECT().Warn(gomock.Any(), gomock.Any()).AnyTimes()
+
+	repository := &memoryRepository{subscriptions: make(map[string]*billing.Subscription)}
+	gateway := &recordingGateway{}
+
+	service := billing.NewService(repository, gateway, billing.Config{
+		Plans:      []billing.Plan{{ID: "pro", Name: "Pro", PriceID: "price_pro", Limits: proLimits}},
+		FreeLimits: freeLimits,
+		SuccessURL: "https://app.example.com/billing/success",
+		CancelURL:  "https://app.example.com/billing",
+	}, logger)
+
+	return service, repository, gateway
+}
+
+func subscriptionEvent(eventType, status string, at time.Time) *billing.Event {
+	return &billing.Event{
+		ID:             "evt_" + status,
+		Type:           eventType,
+		CreatedAt:      at,
+		UserID:         "user-1",
+		CustomerID:     "cus_1",
+		SubscriptionID: "sub_1",
+		PriceID:        "price_pro",
+		Status:         status,
+	}
+}
+
+func TestService_SubscriptionLifecycle(t *testing.T) {
+	ctx := context.Background()
+	service, _, gateway := newTestService(t)
+
+	limits, err := service.Limits(ctx, "user-1")
+	require.NoError(t, err)
+	assert.Equal(t, freeLimits, limits)
+
+	url, err := service.Checkout(ctx, "user-1", "pro")
+	require.NoError(t, err)
+	assert.NotEmpty(t, url)
+	require.Len(t, gateway.checkouts, 1)
+	assert.Equal(t, "price_pro", gateway.checkouts[0].PriceID)
+	assert.Equal(t, "user-1", gateway.checkouts[0].UserID)
+
+	_, err = service.Checkout(ctx, "user-1", "enterprise")
+	require.ErrorIs(t, err, billing.ErrPlanNotFound)
+
+	now := time.Now().UTC()
+	require.NoError(t, service.HandleEvent(ctx, subscriptionEvent(billing.EventSubscriptionCreated, billing.StatusActive, now)))
+
+	account, err := service.Account(ctx, "user-1")
+	require.NoError(t, err)
+	require.NotNil(t, account.Plan)
+	assert.Equal(t, "pro", account.Plan.ID)
+	assert.Equal(t, proLimits, account.Limits)
+
+	_, err = service.Checkout(ctx, "user-1", "pro")
+	require.ErrorIs(t, err, billing.ErrAlreadySubscribed)
+
+	// A deletion arriving before the earlier update it follows still wins
+	require.NoError(t, service.HandleEvent(ctx, subscriptionEvent(billing.EventSubscriptionDeleted, billing.StatusActive, now.Add(time.Minute))))
+	require.NoError(t, service.HandleEvent(ctx, subscriptionEvent(billing.EventSubscriptionUpdated, billing.StatusActive, now.Add(30*time.Second))))
+
+	account, err = service.Account(ctx, "user-1")
+	require.NoError(t, err)
+	assert.Nil(t, account.Plan)
+	assert.Equal(t, billing.StatusCanceled, account.Subscription.Status)
+	assert.Equal(t, freeLimits, account.Limits)
+
+	// Resubscribing reuses the Stripe customer
+	_, err = service.Checkout(ctx, "user-1", "pro")
+	require.NoError(t, err)
+	assert.Equal(t, "cus_1", gateway.checkouts[1].CustomerID)
+}
+
+func TestService_HandleEventFindsUserByCustomer(t *testing.T) {
+	ctx := context.Background()
+	service, repository, _ := newTestService(t)
+
+	now := time.Now().UTC()
+	require.NoError(t, service.HandleEvent(ctx, subscriptionEvent(billing.EventSubscriptionCreated, billing.StatusActive, now)))
+
+	event := subscriptionEvent(billing.EventSubscriptionUpdated, billing.StatusPastDue, now.Add(time.Minute))
+	event.UserID = ""
+	require.NoError(t, service.HandleEvent(ctx, event))
+
+	assert.Equal(t, billing.StatusPastDue, repository.subscriptions["user-1"].Status)
+
+	// Events for customers with no known user are skipped
+	event = subscriptionEvent(billing.EventSubscriptionCreated, billing.StatusActive, now)
+	event.UserID, event.CustomerID = "", "cus_unknown"
+	require.NoError(t, service.HandleEvent(ctx, event))
+	assert.Len(t, repository.subscriptions, 1)
+
+	_, err := service.Portal(ctx, "user-2")
+	require.ErrorIs(t, err, billing.ErrSubscriptionNotFound)
+
+	url, err := service.Portal(ctx, "user-1")
+	require.NoError(t, err)
+	assert.Contains(t, url, "cus_1")
+}
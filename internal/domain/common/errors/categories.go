@@ -14,6 +14,7 @@ const (
 	CategorySystem         ErrorCategory = "system"
 	CategoryConflict       ErrorCategory = "conflict"
 	CategoryForbidden      ErrorCategory = "forbidden"
+	CategoryQuota          ErrorCategory = "quota"
 )
 
 // errorCategories maps error codes to their categories
@@ -56,6 +57,9 @@ var errorCategories = map[ErrorCode][]ErrorCategory{
 	ErrCodeConflict:      {CategoryConflict},
 	ErrCodeAlreadyExists: {CategoryConflict},
 
+	// Quota errors
+	ErrCodeQuotaExceeded: {CategoryQuota},
+
 	// System errors
 	ErrCodeServerError: {CategorySystem},
 	ErrCodeDatabase:    {CategorySystem},
@@ -52,6 +52,8 @@ const (
 	ErrCodeDatabase ErrorCode = "DB_ERROR"
 	// ErrCodeTimeout represents a timeout error
 	ErrCodeTimeout ErrorCode = "TIMEOUT"
+	// ErrCodeQuotaExceeded represents a plan quota being exhausted
+	ErrCodeQuotaExceeded ErrorCode = "QUOTA_EXCEEDED"
 
 	// ErrCodeFormValidation represents a form validation error
 	ErrCodeFormValidation ErrorCode = "FORM_VALIDATION_ERROR"
@@ -119,7 +121,7 @@ func GetHTTPStatus(code ErrorCode) int {
 		return http.StatusBadRequest
 	case ErrCodeUnauthorized, ErrCodeUserUnauthorized, ErrCodeAuthentication:
 		return http.StatusUnauthorized
-	case ErrCodeForbidden, ErrCodeFormAccessDenied, ErrCodeInsufficientRole:
+	case ErrCodeForbidden, ErrCodeFormAccessDenied, ErrCodeInsufficientRole, ErrCodeQuotaExceeded:
 		return http.StatusForbidden
 	case ErrCodeNotFound, ErrCodeFormNotFound, ErrCodeUserNotFound:
 		return http.StatusNotFound
@@ -249,3 +251,8 @@ func IsConflictError(err error) bool {
 func IsForbiddenError(err error) bool {
 	return HasCategory(err, CategoryForbidden)
 }
+
+// IsQuotaError checks if the error represents an exhausted quota
+func IsQuotaError(err error) bool {
+	return HasCategory(err, CategoryQuota)
+}
@@ -0,0 +1,66 @@
+package events
+
+import "sync"
+
+// Metadata keys set on published events
+const (
+	// MetadataEventID identifies a stored event; a replayed event keeps the ID it was stored with
+	MetadataEventID = "event_id"
+	// MetadataReplay is true on events republished from the event store
+	MetadataReplay = "replay"
+)
+
+// ID returns the event's stored ID, or "" when the event was not stored
+func ID(event Event) string {
+	id, _ := event.Metadata()[MetadataEventID].(string)
+
+	return id
+}
+
+// IsReplay reports whether the event was republished from the event store
+func IsReplay(event Event) bool {
+	replay, _ := event.Metadata()[MetadataReplay].(bool)
+
+	return replay
+}
+
+// Deduplicator remembers the IDs of recently handled events, so that a
+// consumer with side effects handles an event once however often it is
+// replayed. Only the most recent IDs are remembered.
+type Deduplicator struct {
+	mu    sync.Mutex
+	seen  map[string]struct{}
+	order []string
+	size  int
+}
+
+// NewDeduplicator creates a deduplicator remembering up to size event IDs
+func NewDeduplicator(size int) *Deduplicator {
+	return &Deduplicator{seen: make(map[string]struct{}, size), size: size}
+}
+
+// Seen records the event and reports whether it was handled before. Events
+// without an ID are never reported as seen.
+func (d *Deduplicator) Seen(event Event) bool {
+	id := ID(event)
+	if id == "" {
+		return false
+	}
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if _, ok := d.seen[id]; ok {
+		return true
+	}
+
+	if len(d.order) >= d.size {
+		delete(d.seen, d.order[0])
+		d.order = d.order[1:]
+	}
+
+	d.seen[id] = struct{}{}
+	d.order = append(d.order, id)
+
+	return false
+}
@@ -0,0 +1,314 @@
+// Package dataset manages named datasets account owners keep for their forms:
+// key/value lists, such as coupon codes and their discounts, or tables whose
+// first column is the key, such as products with a price and a SKU. Select,
+// radio and selectboxes fields take their options from a dataset with
+// "optionsSource": "dataset:<name>", and computed fields read its rows with
+// the lookup() expression function. Datasets are edited through the API or
+// replaced wholesale by uploading a CSV file.
+package dataset
+
+import (
+	"bytes"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// Kind is the shape of a dataset
+type Kind string
+
+const (
+	// KindKeyValue datasets have the columns key and value
+	KindKeyValue Kind = "keyvalue"
+	// KindTable datasets have named columns, the first of which is the key
+	KindTable Kind = "table"
+)
+
+// Key/value dataset columns
+const (
+	ColumnKey   = "key"
+	ColumnValue = "value"
+)
+
+const (
+	// MaxColumns is the maximum number of columns of a table dataset
+	MaxColumns = 50
+	// MaxCellLength is the maximum length of a column name or cell
+	MaxCellLength = 1024
+	// MaxCSVSize is the maximum size of an uploaded CSV file
+	MaxCSVSize = 10 << 20
+)
+
+// namePattern matches a dataset name: lowercase letters, digits, - and _
+var namePattern = regexp.MustCompile(`^[a-z0-9][a-z0-9_-]{0,62}$`)
+
+var (
+	// ErrDatasetNotFound is returned for a name the owner has no dataset under
+	ErrDatasetNotFound = errors.New("dataset not found")
+
+	// ErrDatasetExists is returned when creating a dataset under a name the owner already uses
+	ErrDatasetExists = errors.New("a dataset with this name already exists")
+
+	// ErrTooManyDatasets is returned when an owner reaches form.datasets.max_per_owner
+	ErrTooManyDatasets = errors.New("dataset limit reached")
+
+	// ErrNameInvalid is returned for a name that is not a lowercase slug
+	ErrNameInvalid = errors.New("dataset name must be 1-63 lowercase letters, digits, - or _")
+
+	// ErrKindInvalid is returned for an unknown dataset kind
+	ErrKindInvalid = errors.New("dataset kind must be keyvalue or table")
+
+	// ErrColumnsInvalid is returned when a table's columns are missing, repeated or too long
+	ErrColumnsInvalid = errors.New("dataset needs 1-50 distinct, non-empty columns")
+
+	// ErrTooManyRows is returned when a dataset has more rows than form.datasets.max_rows
+	ErrTooManyRows = errors.New("dataset has too many rows")
+
+	// ErrRowInvalid is returned for a row with an empty or repeated key, an unknown column or an oversized cell
+	ErrRowInvalid = errors.New("dataset row is invalid")
+
+	// ErrCSVTooLarge is returned for a CSV upload larger than MaxCSVSize
+	ErrCSVTooLarge = errors.New("dataset CSV is larger than 10 MB")
+)
+
+// Row maps the columns of a dataset row to their values
+type Row map[string]string
+
+// Dataset is a named list of rows owned by an account
+type Dataset struct {
+	ID      string `gorm:"column:uuid;primaryKey;type:uuid;default:gen_random_uuid()" json:"id"`
+	OwnerID string `gorm:"not null;size:36;uniqueIndex:idx_datasets_owner_name"     json:"-"`
+	Name    string `gorm:"not null;size:63;uniqueIndex:idx_datasets_owner_name"     json:"name"`
+	Title   string `gorm:"size:255"                                                 json:"title"`
+	Kind    Kind   `gorm:"not null;size:20"                                         json:"kind"`
+	// Columns name the columns of the rows; the first is the key
+	Columns   []string  `gorm:"column:column_names;serializer:json;not null" json:"columns"`
+	Rows      []Row     `gorm:"column:row_data;serializer:json;not null"     json:"rows"`
+	CreatedAt time.Time `gorm:"not null;autoCreateTime"                      json:"created_at"`
+	UpdatedAt time.Time `gorm:"not null;autoUpdateTime"                      json:"updated_at"`
+}
+
+// TableName specifies the table name for the Dataset model
+func (d *Dataset) TableName() string {
+	return "datasets"
+}
+
+// BeforeCreate is a GORM hook that runs before creating a dataset
+func (d *Dataset) BeforeCreate(_ *gorm.DB) error {
+	if d.ID == "" {
+		d.ID = uuid.New().String()
+	}
+
+	return nil
+}
+
+// Clone returns a deep copy of the dataset
+func (d *Dataset) Clone() *Dataset {
+	clone := *d
+	clone.Columns = append([]string(nil), d.Columns...)
+	clone.Rows = make([]Row, len(d.Rows))
+
+	for i, row := range d.Rows {
+		clone.Rows[i] = make(Row, len(row))
+		for column, value := range row {
+			clone.Rows[i][column] = value
+		}
+	}
+
+	return &clone
+}
+
+// KeyColumn returns the column rows are looked up by
+func (d *Dataset) KeyColumn() string {
+	if len(d.Columns) == 0 {
+		return ColumnKey
+	}
+
+	return d.Columns[0]
+}
+
+// Find returns the row whose key is key
+func (d *Dataset) Find(key string) (Row, bool) {
+	column := d.KeyColumn()
+
+	for _, row := range d.Rows {
+		if row[column] == key {
+			return row, true
+		}
+	}
+
+	return nil, false
+}
+
+// Option is one choice a dataset gives a field
+type Option struct {
+	Label string `json:"label"`
+	Value string `json:"value"`
+}
+
+// Options returns a choice per row: the key is the value, and labelColumn,
+// else the second column, else the key is the label
+func (d *Dataset) Options(labelColumn string) []Option {
+	key := d.KeyColumn()
+
+	if labelColumn == "" && len(d.Columns) > 1 {
+		labelColumn = d.Columns[1]
+	}
+
+	options := make([]Option, 0, len(d.Rows))
+
+	for _, row := range d.Rows {
+		label := row[labelColumn]
+		if label == "" {
+			label = row[key]
+		}
+
+		options = append(options, Option{Label: label, Value: row[key]})
+	}
+
+	return options
+}
+
+// Normalize trims names and columns, and gives key/value datasets their columns
+func (d *Dataset) Normalize() {
+	d.Name = strings.TrimSpace(d.Name)
+	d.Title = strings.TrimSpace(d.Title)
+
+	if d.Kind == "" {
+		d.Kind = KindKeyValue
+	}
+
+	if d.Kind == KindKeyValue {
+		d.Columns = []string{ColumnKey, ColumnValue}
+	}
+
+	for i, column := range d.Columns {
+		d.Columns[i] = strings.TrimSpace(column)
+	}
+
+	if d.Rows == nil {
+		d.Rows = []Row{}
+	}
+}
+
+// Validate checks the dataset's name, kind, columns and rows. Rows may not
+// exceed maxRows, unless maxRows is zero.
+func (d *Dataset) Validate(maxRows int) error {
+	if !namePattern.MatchString(d.Name) {
+		return ErrNameInvalid
+	}
+
+	if d.Kind != KindKeyValue && d.Kind != KindTable {
+		return ErrKindInvalid
+	}
+
+	if len(d.Columns) == 0 || len(d.Columns) > MaxColumns {
+		return ErrColumnsInvalid
+	}
+
+	columns := make(map[string]bool, len(d.Columns))
+
+	for _, column := range d.Columns {
+		if column == "" || len(column) > MaxCellLength || columns[column] {
+			return ErrColumnsInvalid
+		}
+
+		columns[column] = true
+	}
+
+	if maxRows > 0 && len(d.Rows) > maxRows {
+		return fmt.Errorf("%w: at most %d rows are allowed", ErrTooManyRows, maxRows)
+	}
+
+	keys := make(map[string]bool, len(d.Rows))
+	keyColumn := d.KeyColumn()
+
+	for i, row := range d.Rows {
+		key := row[keyColumn]
+		if key == "" || keys[key] {
+			return fmt.Errorf("%w: row %d has an empty or repeated %s", ErrRowInvalid, i+1, keyColumn)
+		}
+
+		keys[key] = true
+
+		for column, value := range row {
+			if !columns[column] {
+				return fmt.Errorf("%w: row %d has the unknown column %s", ErrRowInvalid, i+1, column)
+			}
+
+			if len(value) > MaxCellLength {
+				return fmt.Errorf("%w: row %d has a value longer than %d characters", ErrRowInvalid, i+1, MaxCellLength)
+			}
+		}
+	}
+
+	return nil
+}
+
+// ParseCSV reads the columns and rows of a dataset from CSV with a header
+// row. A key/value dataset takes its keys from the first column and values
+// from the second; a table takes its columns from the header. Cells are
+// trimmed and blank lines skipped.
+func ParseCSV(kind Kind, r io.Reader) ([]string, []Row, error) {
+	raw, err := io.ReadAll(io.LimitReader(r, MaxCSVSize+1))
+	if err != nil {
+		return nil, nil, fmt.Errorf("read dataset CSV: %w", err)
+	}
+
+	if len(raw) > MaxCSVSize {
+		return nil, nil, ErrCSVTooLarge
+	}
+
+	reader := csv.NewReader(bytes.NewReader(bytes.TrimPrefix(raw, []byte("\ufeff"))))
+	reader.TrimLeadingSpace = true
+	reader.FieldsPerRecord = -1
+
+	records, err := reader.ReadAll()
+	if err != nil {
+		return nil, nil, fmt.Errorf("%w: %w", ErrRowInvalid, err)
+	}
+
+	if len(records) == 0 {
+		return nil, nil, ErrColumnsInvalid
+	}
+
+	columns := make([]string, len(records[0]))
+	for i, column := range records[0] {
+		columns[i] = strings.TrimSpace(column)
+	}
+
+	if kind == KindKeyValue {
+		if len(columns) != 2 {
+			return nil, nil, fmt.Errorf("%w: a key/value CSV has two columns", ErrColumnsInvalid)
+		}
+
+		columns = []string{ColumnKey, ColumnValue}
+	}
+
+	rows := make([]Row, 0, len(records)-1)
+
+	for _, record := range records[1:] {
+		row := make(Row, len(columns))
+		blank := true
+
+		for i, column := range columns {
+			if i < len(record) {
+				row[column] = strings.TrimSpace(record[i])
+				blank = blank && row[column] == ""
+			}
+		}
+
+		if !blank {
+			rows = append(rows, row)
+		}
+	}
+
+	return columns, rows, nil
+}
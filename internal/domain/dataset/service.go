@@ -0,0 +1,293 @@
+package dataset
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"sync"
+	"time"
+
+	domainerrors "github.com/goformx/goforms/internal/domain/common/errors"
+	"github.com/goformx/goforms/internal/infrastructure/logging"
+)
+
+// Repository defines the interface for dataset storage
+type Repository interface {
+	// GetDataset returns an owner's dataset, or ErrDatasetNotFound
+	GetDataset(ctx context.Context, ownerID, name string) (*Dataset, error)
+	// ListDatasets lists an owner's datasets, ordered by name
+	ListDatasets(ctx context.Context, ownerID string) ([]*Dataset, error)
+	// CreateDataset stores a new dataset, or returns ErrDatasetExists
+	CreateDataset(ctx context.Context, dataset *Dataset) error
+	// UpdateDataset replaces the title, kind, columns and rows of a dataset, or returns ErrDatasetNotFound
+	UpdateDataset(ctx context.Context, dataset *Dataset) error
+	// DeleteDataset deletes an owner's dataset, or returns ErrDatasetNotFound
+	DeleteDataset(ctx context.Context, ownerID, name string) error
+}
+
+// Service defines the interface for managing datasets and reading them from forms
+type Service interface {
+	List(ctx context.Context, ownerID string) ([]*Dataset, error)
+	Get(ctx context.Context, ownerID, name string) (*Dataset, error)
+	// Create validates and stores a new dataset
+	Create(ctx context.Context, dataset *Dataset) error
+	// Update validates and replaces an existing dataset
+	Update(ctx context.Context, dataset *Dataset) error
+	Delete(ctx context.Context, ownerID, name string) error
+	// ImportCSV replaces the rows of an owner's dataset with those of a CSV
+	// file, creating the dataset when it does not exist
+	ImportCSV(ctx context.Context, ownerID, name string, kind Kind, r io.Reader) (*Dataset, error)
+	// Options returns the choices a dataset gives a field; see Dataset.Options
+	Options(ctx context.Context, ownerID, name, labelColumn string) ([]Option, error)
+	// LookupRow returns the row of an owner's dataset whose key is key
+	LookupRow(ctx context.Context, ownerID, name, key string) (map[string]string, bool, error)
+}
+
+// Limits bound the datasets of an owner
+type Limits struct {
+	// MaxRows caps the rows of one dataset; zero is unlimited
+	MaxRows int
+	// MaxPerOwner caps the datasets of one owner; zero is unlimited
+	MaxPerOwner int
+}
+
+// readCacheMaxEntries caps the number of datasets held by the read cache
+const readCacheMaxEntries = 1000
+
+// cachedDataset is a dataset read by forms and when it expires
+type cachedDataset struct {
+	dataset *Dataset
+	expires time.Time
+}
+
+// service handles dataset business logic
+type service struct {
+	repository Repository
+	limits     Limits
+	ttl        time.Duration
+	logger     logging.Logger
+
+	mu      sync.RWMutex
+	entries map[string]cachedDataset
+	// generation advances on every change; loads started before it changed are not cached
+	generation uint64
+}
+
+// NewService creates a new dataset service. Datasets read by forms, through
+// Options and LookupRow, are cached for ttl and dropped when changed through
+// the service; a ttl of zero reads them from the repository every time.
+func NewService(repository Repository, limits Limits, ttl time.Duration, logger logging.Logger) Service {
+	return &service{
+		repository: repository,
+		limits:     limits,
+		ttl:        ttl,
+		logger:     logger,
+		entries:    make(map[string]cachedDataset),
+	}
+}
+
+// List lists an owner's datasets
+func (s *service) List(ctx context.Context, ownerID string) ([]*Dataset, error) {
+	datasets, err := s.repository.ListDatasets(ctx, ownerID)
+	if err != nil {
+		return nil, fmt.Errorf("list datasets: %w", err)
+	}
+
+	return datasets, nil
+}
+
+// Get returns an owner's dataset
+func (s *service) Get(ctx context.Context, ownerID, name string) (*Dataset, error) {
+	dataset, err := s.repository.GetDataset(ctx, ownerID, name)
+	if err != nil {
+		return nil, fmt.Errorf("get dataset: %w", err)
+	}
+
+	return dataset, nil
+}
+
+// Create validates and stores a new dataset
+func (s *service) Create(ctx context.Context, dataset *Dataset) error {
+	dataset.Normalize()
+
+	if err := dataset.Validate(s.limits.MaxRows); err != nil {
+		return validationError(err)
+	}
+
+	if s.limits.MaxPerOwner > 0 {
+		existing, err := s.repository.ListDatasets(ctx, dataset.OwnerID)
+		if err != nil {
+			return fmt.Errorf("count datasets: %w", err)
+		}
+
+		if len(existing) >= s.limits.MaxPerOwner {
+			return domainerrors.New(domainerrors.ErrCodeQuotaExceeded,
+				fmt.Sprintf("accounts may keep at most %d datasets", s.limits.MaxPerOwner), ErrTooManyDatasets)
+		}
+	}
+
+	if err := s.repository.CreateDataset(ctx, dataset); err != nil {
+		return fmt.Errorf("create dataset: %w", err)
+	}
+
+	s.invalidate(dataset.OwnerID, dataset.Name)
+	s.logger.Info("dataset created", "owner_id", dataset.OwnerID, "dataset", dataset.Name, "rows", len(dataset.Rows))
+
+	return nil
+}
+
+// Update validates and replaces an existing dataset
+func (s *service) Update(ctx context.Context, dataset *Dataset) error {
+	dataset.Normalize()
+
+	if err := dataset.Validate(s.limits.MaxRows); err != nil {
+		return validationError(err)
+	}
+
+	if err := s.repository.UpdateDataset(ctx, dataset); err != nil {
+		return fmt.Errorf("update dataset: %w", err)
+	}
+
+	s.invalidate(dataset.OwnerID, dataset.Name)
+	s.logger.Info("dataset updated", "owner_id", dataset.OwnerID, "dataset", dataset.Name, "rows", len(dataset.Rows))
+
+	return nil
+}
+
+// Delete deletes an owner's dataset
+func (s *service) Delete(ctx context.Context, ownerID, name string) error {
+	if err := s.repository.DeleteDataset(ctx, ownerID, name); err != nil {
+		return fmt.Errorf("delete dataset: %w", err)
+	}
+
+	s.invalidate(ownerID, name)
+
+	return nil
+}
+
+// ImportCSV replaces or creates an owner's dataset from a CSV file. An
+// existing dataset keeps its title; kind applies to new datasets and is
+// otherwise taken from the stored one.
+func (s *service) ImportCSV(ctx context.Context, ownerID, name string, kind Kind, r io.Reader) (*Dataset, error) {
+	existing, err := s.repository.GetDataset(ctx, ownerID, name)
+	if err != nil && !errors.Is(err, ErrDatasetNotFound) {
+		return nil, fmt.Errorf("get dataset: %w", err)
+	}
+
+	if existing != nil {
+		kind = existing.Kind
+	}
+
+	if kind == "" {
+		kind = KindKeyValue
+	}
+
+	columns, rows, err := ParseCSV(kind, r)
+	if err != nil {
+		return nil, validationError(err)
+	}
+
+	if existing == nil {
+		dataset := &Dataset{OwnerID: ownerID, Name: name, Kind: kind, Columns: columns, Rows: rows}
+		if createErr := s.Create(ctx, dataset); createErr != nil {
+			return nil, createErr
+		}
+
+		return dataset, nil
+	}
+
+	existing.Columns = columns
+	existing.Rows = rows
+
+	if updateErr := s.Update(ctx, existing); updateErr != nil {
+		return nil, updateErr
+	}
+
+	return existing, nil
+}
+
+// Options returns the choices a dataset gives a field
+func (s *service) Options(ctx context.Context, ownerID, name, labelColumn string) ([]Option, error) {
+	dataset, err := s.read(ctx, ownerID, name)
+	if err != nil {
+		return nil, err
+	}
+
+	return dataset.Options(labelColumn), nil
+}
+
+// LookupRow returns a copy of the row of an owner's dataset whose key is key
+func (s *service) LookupRow(ctx context.Context, ownerID, name, key string) (map[string]string, bool, error) {
+	dataset, err := s.read(ctx, ownerID, name)
+	if err != nil {
+		return nil, false, err
+	}
+
+	row, found := dataset.Find(key)
+	if !found {
+		return nil, false, nil
+	}
+
+	clone := make(map[string]string, len(row))
+	for column, value := range row {
+		clone[column] = value
+	}
+
+	return clone, true, nil
+}
+
+// read returns a dataset for the form read path, from the cache while it is fresh
+func (s *service) read(ctx context.Context, ownerID, name string) (*Dataset, error) {
+	key := cacheKey(ownerID, name)
+
+	s.mu.RLock()
+	entry, ok := s.entries[key]
+	generation := s.generation
+	s.mu.RUnlock()
+
+	if ok && time.Now().Before(entry.expires) {
+		return entry.dataset, nil
+	}
+
+	dataset, err := s.Get(ctx, ownerID, name)
+	if err != nil {
+		return nil, err
+	}
+
+	if s.ttl <= 0 {
+		return dataset, nil
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.generation == generation {
+		if len(s.entries) >= readCacheMaxEntries {
+			s.entries = make(map[string]cachedDataset)
+		}
+
+		s.entries[key] = cachedDataset{dataset: dataset, expires: time.Now().Add(s.ttl)}
+	}
+
+	return dataset, nil
+}
+
+// invalidate drops a cached dataset
+func (s *service) invalidate(ownerID, name string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.generation++
+	delete(s.entries, cacheKey(ownerID, name))
+}
+
+// cacheKey identifies an owner's dataset in the read cache
+func cacheKey(ownerID, name string) string {
+	return ownerID + "/" + name
+}
+
+// validationError wraps a dataset error as a domain validation error
+func validationError(err error) error {
+	return domainerrors.New(domainerrors.ErrCodeValidation, err.Error(), err)
+}
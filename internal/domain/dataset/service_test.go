@@ -0,0 +1,156 @@
+package dataset_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	domainerrors "github.com/goformx/goforms/internal/domain/common/errors"
+	"github.com/goformx/goforms/internal/domain/dataset"
+	memorystore "github.com/goformx/goforms/internal/infrastructure/repository/memory"
+	mocklogging "github.com/goformx/goforms/test/mocks/logging"
+)
+
+// countingRepository counts the datasets read from the wrapped repository
+type countingRepository struct {
+	dataset.Repository
+
+	reads int
+}
+
+func (r *countingRepository) GetDataset(ctx context.Context, ownerID, name string) (*dataset.Dataset, error) {
+	r.reads++
+
+	return r.Repository.GetDataset(ctx, ownerID, name)
+}
+
+func newService(t *testing.T, limits dataset.Limits, ttl time.Duration) (dataset.Service, *countingRepository) {
+	t.Helper()
+
+	logger := mocklogging.NewMockLogger(gomock.NewController(t))
+	logger.EXPECT().Info(gomock.Any(), gomock.Any()).AnyTimes()
+
+	repository := &countingRepository{Repository: memorystore.NewStore(logger).Datasets()}
+
+	return dataset.NewService(repository, limits, ttl, logger), repository
+}
+
+func TestService_CreateAndLookup(t *testing.T) {
+	service, repository := newService(t, dataset.Limits{MaxRows: 10}, time.Minute)
+	ctx := t.Context()
+
+	coupons := &dataset.Dataset{
+		OwnerID: "user-1",
+		Name:    "coupons",
+		Rows:    []dataset.Row{{"key": "SPRING", "value": "10"}, {"key": "SUMMER", "value": "15"}},
+	}
+	require.NoError(t, service.Create(ctx, coupons))
+	assert.Equal(t, dataset.KindKeyValue, coupons.Kind)
+	assert.Equal(t, []string{"key", "value"}, coupons.Columns)
+
+	err := service.Create(ctx, &dataset.Dataset{OwnerID: "user-1", Name: "coupons"})
+	require.ErrorIs(t, err, dataset.ErrDatasetExists)
+
+	row, found, err := service.LookupRow(ctx, "user-1", "coupons", "SUMMER")
+	require.NoError(t, err)
+	assert.True(t, found)
+	assert.Equal(t, "15", row["value"])
+
+	options, err := service.Options(ctx, "user-1", "coupons", "")
+	require.NoError(t, err)
+	assert.Equal(t, []dataset.Option{{Label: "10", Value: "SPRING"}, {Label: "15", Value: "SUMMER"}}, options)
+	assert.Equal(t, 1, repository.reads, "reads by forms are cached")
+
+	coupons.Rows = []dataset.Row{{"key": "SPRING", "value": "20"}}
+	require.NoError(t, service.Update(ctx, coupons))
+
+	_, found, err = service.LookupRow(ctx, "user-1", "coupons", "SUMMER")
+	require.NoError(t, err)
+	assert.False(t, found, "updates drop the cached dataset")
+
+	_, _, err = service.LookupRow(ctx, "user-2", "coupons", "SPRING")
+	require.ErrorIs(t, err, dataset.ErrDatasetNotFound, "datasets belong to their owner")
+}
+
+func TestService_Validation(t *testing.T) {
+	service, _ := newService(t, dataset.Limits{MaxRows: 2, MaxPerOwner: 1}, 0)
+	ctx := t.Context()
+
+	tests := []struct {
+		name    string
+		dataset *dataset.Dataset
+		want    error
+	}{
+		{name: "bad name", dataset: &dataset.Dataset{Name: "Coupons!"}, want: dataset.ErrNameInvalid},
+		{name: "unknown kind", dataset: &dataset.Dataset{Name: "x", Kind: "list"}, want: dataset.ErrKindInvalid},
+		{name: "no columns", dataset: &dataset.Dataset{Name: "x", Kind: dataset.KindTable}, want: dataset.ErrColumnsInvalid},
+		{
+			name:    "repeated key",
+			dataset: &dataset.Dataset{Name: "x", Rows: []dataset.Row{{"key": "a"}, {"key": "a"}}},
+			want:    dataset.ErrRowInvalid,
+		},
+		{
+			name: "unknown column",
+			dataset: &dataset.Dataset{
+				Name: "x", Kind: dataset.KindTable, Columns: []string{"sku"}, Rows: []dataset.Row{{"sku": "a", "price": "1"}},
+			},
+			want: dataset.ErrRowInvalid,
+		},
+		{
+			name:    "too many rows",
+			dataset: &dataset.Dataset{Name: "x", Rows: []dataset.Row{{"key": "a"}, {"key": "b"}, {"key": "c"}}},
+			want:    dataset.ErrTooManyRows,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.dataset.OwnerID = "user-1"
+			err := service.Create(ctx, tt.dataset)
+			require.ErrorIs(t, err, tt.want)
+
+			domainErr := domainerrors.GetDomainError(err)
+			require.NotNil(t, domainErr)
+			assert.Equal(t, domainerrors.ErrCodeValidation, domainErr.Code)
+		})
+	}
+
+	require.NoError(t, service.Create(ctx, &dataset.Dataset{OwnerID: "user-1", Name: "first"}))
+
+	err := service.Create(ctx, &dataset.Dataset{OwnerID: "user-1", Name: "second"})
+	require.ErrorIs(t, err, dataset.ErrTooManyDatasets)
+}
+
+func TestService_ImportCSV(t *testing.T) {
+	service, _ := newService(t, dataset.Limits{MaxRows: 10}, time.Minute)
+	ctx := t.Context()
+
+	csv := "\ufeffsku, name, price\nA1,Widget,4.50\n\nB2,Gadget,12\n"
+
+	products, err := service.ImportCSV(ctx, "user-1", "products", dataset.KindTable, strings.NewReader(csv))
+	require.NoError(t, err)
+	assert.Equal(t, []string{"sku", "name", "price"}, products.Columns)
+	assert.Len(t, products.Rows, 2, "blank lines are skipped")
+
+	options, err := service.Options(ctx, "user-1", "products", "")
+	require.NoError(t, err)
+	assert.Equal(t, dataset.Option{Label: "Widget", Value: "A1"}, options[0])
+
+	// Importing again replaces the rows, keeping the stored kind
+	products, err = service.ImportCSV(ctx, "user-1", "products", "", strings.NewReader("sku,name,price\nC3,Gizmo,1\n"))
+	require.NoError(t, err)
+	assert.Equal(t, dataset.KindTable, products.Kind)
+
+	row, found, err := service.LookupRow(ctx, "user-1", "products", "C3")
+	require.NoError(t, err)
+	assert.True(t, found)
+	assert.Equal(t, "1", row["price"])
+
+	_, err = service.ImportCSV(ctx, "user-1", "pairs", dataset.KindKeyValue, strings.NewReader("a,b,c\n1,2,3\n"))
+	require.ErrorIs(t, err, dataset.ErrColumnsInvalid, "key/value CSV files have two columns")
+}
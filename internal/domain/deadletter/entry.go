@@ -0,0 +1,129 @@
+// Package deadletter keeps background work that failed after its own retries,
+// such as a post-submit hook, an outbound email or a write-behind submission,
+// so that it is not silently dropped. Each entry holds the payload needed to
+// run the work again and the history of its failures. Entries are retried by
+// the handler registered for their kind, one at a time or in bulk, and purged
+// once they are no longer wanted.
+package deadletter
+
+import (
+	"encoding/json"
+	"errors"
+	"slices"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// Kinds of failed work
+const (
+	// KindWebhook is a post-submit hook delivery
+	KindWebhook = "webhook"
+	// KindEmail is an outbound email message
+	KindEmail = "email"
+	// KindSubmission is a write-behind submission that could not be stored
+	KindSubmission = "submission"
+)
+
+// Entry statuses
+const (
+	// StatusPending entries are waiting to be retried
+	StatusPending = "pending"
+	// StatusResolved entries were retried successfully
+	StatusResolved = "resolved"
+)
+
+// MaxHistory is the number of failed attempts an entry keeps; older ones are dropped
+const MaxHistory = 20
+
+var (
+	// ErrEntryNotFound is returned for an unknown entry ID
+	ErrEntryNotFound = errors.New("dead letter not found")
+
+	// ErrAlreadyResolved is returned when retrying an entry that was retried successfully
+	ErrAlreadyResolved = errors.New("dead letter is already resolved")
+
+	// ErrNoRetryHandler is returned when no handler is registered for an entry's kind
+	ErrNoRetryHandler = errors.New("dead letter kind cannot be retried")
+
+	// ErrRetryFailed is returned when a retry failed again; the entry stays pending
+	ErrRetryFailed = errors.New("dead letter retry failed")
+
+	// ErrInvalidStatus is returned for a status filter other than pending or resolved
+	ErrInvalidStatus = errors.New("status must be pending or resolved")
+)
+
+// Attempt is one failed attempt at the work
+type Attempt struct {
+	At    time.Time `json:"at"`
+	Error string    `json:"error"`
+}
+
+// Entry is failed background work kept for inspection and retry
+type Entry struct {
+	ID   string `gorm:"column:uuid;primaryKey;type:uuid;default:gen_random_uuid()" json:"id"`
+	Kind string `gorm:"not null;size:50;index:idx_dead_letters_kind"              json:"kind"`
+	// Reference identifies the work for people, such as the hook and submission
+	Reference string `gorm:"size:255" json:"reference"`
+	// Payload is what the kind's retry handler needs to run the work again
+	Payload json.RawMessage `gorm:"serializer:json;not null"                          json:"payload"`
+	Status  string          `gorm:"not null;size:20;index:idx_dead_letters_status" json:"status"`
+	// Attempts counts every failed attempt, including those dropped from History
+	Attempts  int    `gorm:"not null;default:0" json:"attempts"`
+	LastError string `gorm:"type:text"          json:"last_error"`
+	// History lists the most recent failed attempts, oldest first
+	History    []Attempt  `gorm:"serializer:json"         json:"history"`
+	ResolvedAt *time.Time `json:"resolved_at,omitempty"`
+	CreatedAt  time.Time  `gorm:"not null;autoCreateTime" json:"created_at"`
+	UpdatedAt  time.Time  `gorm:"not null;autoUpdateTime" json:"updated_at"`
+}
+
+// TableName specifies the table name for the Entry model
+func (e *Entry) TableName() string {
+	return "dead_letters"
+}
+
+// BeforeCreate is a GORM hook that runs before creating an entry
+func (e *Entry) BeforeCreate(_ *gorm.DB) error {
+	if e.ID == "" {
+		e.ID = uuid.New().String()
+	}
+
+	return nil
+}
+
+// fail records a failed attempt at the work
+func (e *Entry) fail(at time.Time, err error) {
+	e.Attempts++
+	e.LastError = err.Error()
+	e.History = append(e.History, Attempt{At: at, Error: e.LastError})
+
+	if len(e.History) > MaxHistory {
+		e.History = slices.Clone(e.History[len(e.History)-MaxHistory:])
+	}
+}
+
+// Filter narrows a listing of entries; empty fields match everything
+type Filter struct {
+	Kind   string
+	Status string
+	// IDs restricts the filter to the listed entries
+	IDs []string
+}
+
+// Matches reports whether entry passes the filter
+func (f Filter) Matches(entry *Entry) bool {
+	return (f.Kind == "" || entry.Kind == f.Kind) &&
+		(f.Status == "" || entry.Status == f.Status) &&
+		(len(f.IDs) == 0 || slices.Contains(f.IDs, entry.ID))
+}
+
+// Validate checks the status filter
+func (f Filter) Validate() error {
+	if f.Status != "" && f.Status != StatusPending && f.Status != StatusResolved {
+		return ErrInvalidStatus
+	}
+
+	return nil
+}
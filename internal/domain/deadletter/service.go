@@ -0,0 +1,268 @@
+package deadletter
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/goformx/goforms/internal/infrastructure/logging"
+)
+
+// retryBatchSize is the number of entries read per page when collecting a bulk retry
+const retryBatchSize = 100
+
+// Repository defines the interface for stored dead letters
+type Repository interface {
+	Create(ctx context.Context, entry *Entry) error
+	// Get returns an entry, or ErrEntryNotFound
+	Get(ctx context.Context, id string) (*Entry, error)
+	Update(ctx context.Context, entry *Entry) error
+	// List lists matching entries, newest first, with the total count
+	List(ctx context.Context, filter Filter, offset, limit int) ([]*Entry, int64, error)
+	// Delete deletes the matching entries
+	Delete(ctx context.Context, filter Filter) (int64, error)
+	// DeleteResolvedBefore deletes the entries resolved before the given time
+	DeleteResolvedBefore(ctx context.Context, before time.Time) (int64, error)
+}
+
+// RetryFunc runs the work of a dead letter again from its payload
+type RetryFunc func(ctx context.Context, entry *Entry) error
+
+// Service defines the interface for the dead-letter queue
+type Service interface {
+	// Handle registers the retry handler for a kind of entry
+	Handle(kind string, retry RetryFunc)
+	// Record keeps failed work. Payload is encoded as JSON and passed back to
+	// the kind's retry handler; reference names the work for people.
+	Record(ctx context.Context, kind, reference string, payload any, cause error) error
+	// List lists matching entries, newest first, with the total count
+	List(ctx context.Context, filter Filter, offset, limit int) ([]*Entry, int64, error)
+	// Get returns an entry, or ErrEntryNotFound
+	Get(ctx context.Context, id string) (*Entry, error)
+	// Retry runs a pending entry's work again and returns the updated entry. A
+	// failed retry is added to the entry's history and returns ErrRetryFailed.
+	Retry(ctx context.Context, id string) (*Entry, error)
+	// RetryAll retries the matching pending entries, oldest first
+	RetryAll(ctx context.Context, filter Filter) (*RetryReport, error)
+	// Purge deletes the matching entries
+	Purge(ctx context.Context, filter Filter) (int64, error)
+	// Prune deletes the entries resolved longer ago than the retention
+	Prune(ctx context.Context) error
+}
+
+// RetryReport describes a bulk retry
+type RetryReport struct {
+	Matched int            `json:"matched"`
+	Retried int            `json:"retried"`
+	Failed  []RetryFailure `json:"failed"`
+}
+
+// RetryFailure is an entry whose retry failed
+type RetryFailure struct {
+	ID    string `json:"id"`
+	Kind  string `json:"kind"`
+	Error string `json:"error"`
+}
+
+// service handles dead-letter business logic
+type service struct {
+	repository Repository
+	retention  time.Duration
+	logger     logging.Logger
+	now        func() time.Time
+
+	mu       sync.RWMutex
+	handlers map[string]RetryFunc
+}
+
+// NewService creates a new dead-letter service. Resolved entries are kept for
+// retention.
+func NewService(repository Repository, retention time.Duration, logger logging.Logger) Service {
+	return &service{
+		repository: repository,
+		retention:  retention,
+		logger:     logger,
+		now:        time.Now,
+		handlers:   make(map[string]RetryFunc),
+	}
+}
+
+// Handle registers the retry handler for a kind of entry
+func (s *service) Handle(kind string, retry RetryFunc) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.handlers[kind] = retry
+}
+
+// Record keeps failed work
+func (s *service) Record(ctx context.Context, kind, reference string, payload any, cause error) error {
+	encoded, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("encode dead letter payload: %w", err)
+	}
+
+	entry := &Entry{
+		Kind:      kind,
+		Reference: reference,
+		Payload:   encoded,
+		Status:    StatusPending,
+	}
+	entry.fail(s.now().UTC(), cause)
+
+	if createErr := s.repository.Create(ctx, entry); createErr != nil {
+		return fmt.Errorf("create dead letter: %w", createErr)
+	}
+
+	s.logger.Warn("background work dead-lettered",
+		"dead_letter_id", entry.ID, "kind", kind, "reference", reference, "error", cause)
+
+	return nil
+}
+
+// List lists matching entries
+func (s *service) List(ctx context.Context, filter Filter, offset, limit int) ([]*Entry, int64, error) {
+	if err := filter.Validate(); err != nil {
+		return nil, 0, err
+	}
+
+	entries, total, err := s.repository.List(ctx, filter, offset, limit)
+	if err != nil {
+		return nil, 0, fmt.Errorf("list dead letters: %w", err)
+	}
+
+	return entries, total, nil
+}
+
+// Get returns an entry
+func (s *service) Get(ctx context.Context, id string) (*Entry, error) {
+	entry, err := s.repository.Get(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("get dead letter: %w", err)
+	}
+
+	return entry, nil
+}
+
+// Retry runs a pending entry's work again
+func (s *service) Retry(ctx context.Context, id string) (*Entry, error) {
+	entry, err := s.Get(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if entry.Status == StatusResolved {
+		return entry, fmt.Errorf("retry dead letter %s: %w", id, ErrAlreadyResolved)
+	}
+
+	s.mu.RLock()
+	retry, ok := s.handlers[entry.Kind]
+	s.mu.RUnlock()
+
+	if !ok {
+		return entry, fmt.Errorf("retry dead letter %s: %w: %s", id, ErrNoRetryHandler, entry.Kind)
+	}
+
+	retryErr := retry(ctx, entry)
+
+	now := s.now().UTC()
+	if retryErr != nil {
+		entry.fail(now, retryErr)
+	} else {
+		entry.Status = StatusResolved
+		entry.ResolvedAt = &now
+	}
+
+	if updateErr := s.repository.Update(ctx, entry); updateErr != nil {
+		return entry, fmt.Errorf("update dead letter: %w", updateErr)
+	}
+
+	if retryErr != nil {
+		return entry, fmt.Errorf("%w: %w", ErrRetryFailed, retryErr)
+	}
+
+	s.logger.Info("dead letter retried", "dead_letter_id", entry.ID, "kind", entry.Kind, "reference", entry.Reference)
+
+	return entry, nil
+}
+
+// RetryAll retries the matching pending entries. The matching entries are
+// collected before any is retried, so entries that resolve do not shift the
+// pages still to be read.
+func (s *service) RetryAll(ctx context.Context, filter Filter) (*RetryReport, error) {
+	filter.Status = StatusPending
+	report := &RetryReport{Failed: []RetryFailure{}}
+
+	var pending []*Entry
+
+	for offset := 0; ; offset += retryBatchSize {
+		entries, _, err := s.repository.List(ctx, filter, offset, retryBatchSize)
+		if err != nil {
+			return report, fmt.Errorf("list dead letters: %w", err)
+		}
+
+		pending = append(pending, entries...)
+
+		if len(entries) < retryBatchSize {
+			break
+		}
+	}
+
+	// Listed newest first; retry in the order the work failed
+	for i := len(pending) - 1; i >= 0; i-- {
+		entry := pending[i]
+		report.Matched++
+
+		if _, err := s.Retry(ctx, entry.ID); err != nil {
+			if errors.Is(err, ErrEntryNotFound) || errors.Is(err, ErrAlreadyResolved) {
+				// Purged or retried elsewhere since it was listed
+				report.Matched--
+
+				continue
+			}
+
+			report.Failed = append(report.Failed, RetryFailure{ID: entry.ID, Kind: entry.Kind, Error: err.Error()})
+
+			continue
+		}
+
+		report.Retried++
+	}
+
+	s.logger.Info("dead letters retried", "kind", filter.Kind, "retried", report.Retried, "failed", len(report.Failed))
+
+	return report, nil
+}
+
+// Purge deletes the matching entries
+func (s *service) Purge(ctx context.Context, filter Filter) (int64, error) {
+	if err := filter.Validate(); err != nil {
+		return 0, err
+	}
+
+	deleted, err := s.repository.Delete(ctx, filter)
+	if err != nil {
+		return 0, fmt.Errorf("purge dead letters: %w", err)
+	}
+
+	s.logger.Info("dead letters purged", "kind", filter.Kind, "status", filter.Status, "deleted", deleted)
+
+	return deleted, nil
+}
+
+// Prune deletes the entries resolved longer ago than the retention
+func (s *service) Prune(ctx context.Context) error {
+	deleted, err := s.repository.DeleteResolvedBefore(ctx, s.now().Add(-s.retention))
+	if err != nil {
+		return fmt.Errorf("prune dead letters: %w", err)
+	}
+
+	if deleted > 0 {
+		s.logger.Info("resolved dead letters pruned", "deleted", deleted, "retention", s.retention)
+	}
+
+	return nil
+}
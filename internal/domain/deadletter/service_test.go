@@ -0,0 +1,170 @@
+package deadletter_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/goformx/goforms/internal/domain/deadletter"
+	memorystore "github.com/goformx/goforms/internal/infrastructure/repository/memory"
+	mocklogging "github.com/goformx/goforms/test/mocks/logging"
+)
+
+func newService(t *testing.T) (deadletter.Service, *memorystore.DeadLetterStore) {
+	t.Helper()
+
+	logger := mocklogging.NewMockLogger(gomock.NewController(t))
+	logger.EXPECT().Info(gomock.Any(), gomock.Any()).AnyTimes()
+	logger.EXPECT().Warn(gomock.Any(), gomock.Any()).AnyTimes()
+
+	store := memorystore.NewDeadLetterStore()
+
+	return deadletter.NewService(store, time.Hour, logger), store
+}
+
+func TestService_RecordAndRetry(t *testing.T) {
+	ctx := context.Background()
+	svc, _ := newService(t)
+
+	var (
+		outage   = true
+		resent   []string
+		failures int
+	)
+
+	svc.Handle(deadletter.KindEmail, func(_ context.Context, entry *deadletter.Entry) error {
+		if outage {
+			failures++
+
+			return errors.New("connection refused")
+		}
+
+		resent = append(resent, string(entry.Payload))
+
+		return nil
+	})
+
+	require.NoError(t, svc.Record(ctx, deadletter.KindEmail, "welcome", map[string]string{"subject": "Hi"}, errors.New("timeout")))
+
+	entries, total, err := svc.List(ctx, deadletter.Filter{Kind: deadletter.KindEmail}, 0, 10)
+	require.NoError(t, err)
+	require.EqualValues(t, 1, total)
+
+	entry := entries[0]
+	assert.Equal(t, deadletter.StatusPending, entry.Status)
+	assert.Equal(t, 1, entry.Attempts)
+	assert.Equal(t, "timeout", entry.LastError)
+
+	// A failed retry is added to the history and the entry stays pending
+	retried, err := svc.Retry(ctx, entry.ID)
+	require.ErrorIs(t, err, deadletter.ErrRetryFailed)
+	assert.Equal(t, deadletter.StatusPending, retried.Status)
+	assert.Equal(t, 2, retried.Attempts)
+	require.Len(t, retried.History, 2)
+	assert.Equal(t, "connection refused", retried.History[1].Error)
+
+	outage = false
+
+	retried, err = svc.Retry(ctx, entry.ID)
+	require.NoError(t, err)
+	assert.Equal(t, deadletter.StatusResolved, retried.Status)
+	assert.NotNil(t, retried.ResolvedAt)
+	assert.Equal(t, []string{`{"subject":"Hi"}`}, resent)
+	assert.Equal(t, 1, failures)
+
+	_, err = svc.Retry(ctx, entry.ID)
+	require.ErrorIs(t, err, deadletter.ErrAlreadyResolved)
+}
+
+func TestService_RetryWithoutHandler(t *testing.T) {
+	ctx := context.Background()
+	svc, _ := newService(t)
+
+	require.NoError(t, svc.Record(ctx, deadletter.KindWebhook, "hook", map[string]string{}, errors.New("502")))
+
+	entries, _, err := svc.List(ctx, deadletter.Filter{}, 0, 10)
+	require.NoError(t, err)
+
+	_, err = svc.Retry(ctx, entries[0].ID)
+	require.ErrorIs(t, err, deadletter.ErrNoRetryHandler)
+
+	_, err = svc.Retry(ctx, "missing")
+	require.ErrorIs(t, err, deadletter.ErrEntryNotFound)
+}
+
+func TestService_RetryAllAndPurge(t *testing.T) {
+	ctx := context.Background()
+	svc, _ := newService(t)
+
+	var order []string
+
+	svc.Handle(deadletter.KindSubmission, func(_ context.Context, entry *deadletter.Entry) error {
+		order = append(order, entry.Reference)
+
+		if entry.Reference == "bad" {
+			return errors.New("constraint violation")
+		}
+
+		return nil
+	})
+
+	for _, reference := range []string{"first", "bad", "last"} {
+		require.NoError(t, svc.Record(ctx, deadletter.KindSubmission, reference, map[string]string{}, errors.New("timeout")))
+	}
+
+	require.NoError(t, svc.Record(ctx, deadletter.KindEmail, "other kind", map[string]string{}, errors.New("timeout")))
+
+	report, err := svc.RetryAll(ctx, deadletter.Filter{Kind: deadletter.KindSubmission})
+	require.NoError(t, err)
+	assert.Equal(t, 3, report.Matched)
+	assert.Equal(t, 2, report.Retried)
+	require.Len(t, report.Failed, 1)
+	assert.Equal(t, []string{"first", "bad", "last"}, order, "entries are retried in the order they failed")
+
+	// Resolved entries are not retried again
+	report, err = svc.RetryAll(ctx, deadletter.Filter{Kind: deadletter.KindSubmission})
+	require.NoError(t, err)
+	assert.Equal(t, 1, report.Matched)
+
+	deleted, err := svc.Purge(ctx, deadletter.Filter{Status: deadletter.StatusResolved})
+	require.NoError(t, err)
+	assert.EqualValues(t, 2, deleted)
+
+	_, total, err := svc.List(ctx, deadletter.Filter{}, 0, 10)
+	require.NoError(t, err)
+	assert.EqualValues(t, 2, total)
+
+	_, err = svc.Purge(ctx, deadletter.Filter{Status: "unknown"})
+	require.ErrorIs(t, err, deadletter.ErrInvalidStatus)
+}
+
+func TestService_Prune(t *testing.T) {
+	ctx := context.Background()
+	svc, store := newService(t)
+
+	svc.Handle(deadletter.KindEmail, func(context.Context, *deadletter.Entry) error { return nil })
+
+	require.NoError(t, svc.Record(ctx, deadletter.KindEmail, "old", map[string]string{}, errors.New("timeout")))
+	require.NoError(t, svc.Record(ctx, deadletter.KindEmail, "pending", map[string]string{}, errors.New("timeout")))
+
+	entries, _, err := store.List(ctx, deadletter.Filter{}, 0, 10)
+	require.NoError(t, err)
+
+	resolved := time.Now().Add(-2 * time.Hour)
+	old := entries[1]
+	old.Status = deadletter.StatusResolved
+	old.ResolvedAt = &resolved
+	require.NoError(t, store.Update(ctx, old))
+
+	require.NoError(t, svc.Prune(ctx))
+
+	remaining, _, err := store.List(ctx, deadletter.Filter{}, 0, 10)
+	require.NoError(t, err)
+	require.Len(t, remaining, 1)
+	assert.Equal(t, "pending", remaining[0].Reference)
+}
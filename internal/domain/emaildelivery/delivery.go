@@ -0,0 +1,140 @@
+// Package emaildelivery tracks what happens to outbound email once a provider
+// has accepted it. Sends are counted per form; bounces and complaints reported
+// by provider webhooks are counted too, and put the address on the suppression
+// list the mailer consults before every send.
+package emaildelivery
+
+import (
+	"errors"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// EventType classifies a delivery event
+type EventType string
+
+const (
+	// EventSent records a message accepted by the provider for one recipient
+	EventSent EventType = "sent"
+	// EventBounce records a message the recipient's server rejected
+	EventBounce EventType = "bounce"
+	// EventComplaint records a recipient marking a message as spam
+	EventComplaint EventType = "complaint"
+)
+
+// Suppression reasons
+const (
+	// ReasonBounce suppresses an address that bounced permanently
+	ReasonBounce = "bounce"
+	// ReasonComplaint suppresses an address whose owner complained
+	ReasonComplaint = "complaint"
+	// ReasonRejected suppresses an address the provider rejected when sending
+	ReasonRejected = "rejected"
+)
+
+// maxDetailLength bounds the provider diagnostic stored with events and suppressions
+const maxDetailLength = 500
+
+var (
+	// ErrSuppressionNotFound is returned when an address is not suppressed
+	ErrSuppressionNotFound = errors.New("email suppression not found")
+
+	// ErrAddressRequired is returned for an event or suppression without an address
+	ErrAddressRequired = errors.New("email address is required")
+)
+
+// Event is one delivery outcome for one recipient
+type Event struct {
+	ID        string    `gorm:"column:uuid;primaryKey;type:uuid;default:gen_random_uuid()" json:"id"`
+	FormID    string    `gorm:"size:36;index:idx_email_delivery_events_form_type" json:"form_id,omitempty"`
+	Type      EventType `gorm:"not null;size:20;index:idx_email_delivery_events_form_type" json:"type"`
+	Recipient string    `gorm:"not null;size:320" json:"recipient"`
+	Provider  string    `gorm:"not null;size:20" json:"provider"`
+	// Permanent marks a hard bounce; soft bounces are counted but not suppressed
+	Permanent bool `gorm:"not null;default:false" json:"permanent"`
+	// Detail is the provider's diagnostic, such as the SMTP reply of a bounce
+	Detail     string    `gorm:"size:500" json:"detail,omitempty"`
+	OccurredAt time.Time `gorm:"not null" json:"occurred_at"`
+	CreatedAt  time.Time `gorm:"not null;autoCreateTime" json:"created_at"`
+}
+
+// TableName specifies the table name for the Event model
+func (e *Event) TableName() string {
+	return "email_delivery_events"
+}
+
+// BeforeCreate is a GORM hook that runs before creating an event
+func (e *Event) BeforeCreate(_ *gorm.DB) error {
+	if e.ID == "" {
+		e.ID = uuid.New().String()
+	}
+
+	return nil
+}
+
+// Suppresses reports whether the event puts its recipient on the suppression list
+func (e *Event) Suppresses() bool {
+	return e.Type == EventComplaint || (e.Type == EventBounce && e.Permanent)
+}
+
+// Suppression is an address that must not receive email
+type Suppression struct {
+	Address string `gorm:"primaryKey;size:320" json:"address"`
+	Reason  string `gorm:"not null;size:20" json:"reason"`
+	// Provider reported the bounce or complaint; empty for rejected sends
+	Provider string `gorm:"size:20" json:"provider,omitempty"`
+	Detail   string `gorm:"size:500" json:"detail,omitempty"`
+	// FormID is the form whose email triggered the suppression, when known
+	FormID    string    `gorm:"size:36" json:"form_id,omitempty"`
+	CreatedAt time.Time `gorm:"not null;autoCreateTime" json:"created_at"`
+	UpdatedAt time.Time `gorm:"not null;autoUpdateTime" json:"updated_at"`
+}
+
+// TableName specifies the table name for the Suppression model
+func (s *Suppression) TableName() string {
+	return "email_suppressions"
+}
+
+// Stats summarizes the delivery events of a form
+type Stats struct {
+	FormID     string `json:"form_id"`
+	Sent       int64  `json:"sent"`
+	Bounced    int64  `json:"bounced"`
+	Complained int64  `json:"complained"`
+	// BounceRate and ComplaintRate are fractions of Sent; zero before the first send
+	BounceRate    float64 `json:"bounce_rate"`
+	ComplaintRate float64 `json:"complaint_rate"`
+}
+
+// NormalizeAddress reduces an address to the lowercase form suppressions are keyed by
+func NormalizeAddress(address string) string {
+	address = strings.TrimSpace(address)
+
+	// Reduce "Name <addr>" to addr
+	if start := strings.LastIndex(address, "<"); start >= 0 {
+		if end := strings.LastIndex(address, ">"); end > start {
+			address = address[start+1 : end]
+		}
+	}
+
+	return strings.ToLower(strings.TrimSpace(address))
+}
+
+// truncateDetail bounds a provider diagnostic to the stored length
+func truncateDetail(detail string) string {
+	if len(detail) <= maxDetailLength {
+		return detail
+	}
+
+	// Cut on a rune boundary
+	cut := maxDetailLength
+	for cut > 0 && !utf8.RuneStart(detail[cut]) {
+		cut--
+	}
+
+	return detail[:cut]
+}
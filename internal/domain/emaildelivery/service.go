@@ -0,0 +1,220 @@
+package emaildelivery
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	domainerrors "github.com/goformx/goforms/internal/domain/common/errors"
+	"github.com/goformx/goforms/internal/infrastructure/logging"
+)
+
+// Repository defines the interface for suppression and delivery event storage
+type Repository interface {
+	IsSuppressed(ctx context.Context, address string) (bool, error)
+	// SaveSuppression creates or replaces the suppression of an address
+	SaveSuppression(ctx context.Context, suppression *Suppression) error
+	// DeleteSuppression removes a suppression, or returns ErrSuppressionNotFound
+	DeleteSuppression(ctx context.Context, address string) error
+	// ListSuppressions lists suppressions, newest first, with the total count
+	ListSuppressions(ctx context.Context, offset, limit int) ([]*Suppression, int64, error)
+	CreateEvents(ctx context.Context, events []*Event) error
+	// CountEvents counts a form's events by type
+	CountEvents(ctx context.Context, formID string) (map[EventType]int64, error)
+}
+
+// Service defines the interface for the suppression list and delivery tracking
+type Service interface {
+	// IsSuppressed reports whether address must not receive email
+	IsSuppressed(ctx context.Context, address string) (bool, error)
+	// Suppress adds an address the provider rejected while sending; detail is the rejection
+	Suppress(ctx context.Context, address, detail string) error
+	// Unsuppress lets an address receive email again
+	Unsuppress(ctx context.Context, address string) error
+	ListSuppressions(ctx context.Context, offset, limit int) ([]*Suppression, int64, error)
+	// RecordSent counts a message sent for a form to each recipient
+	RecordSent(ctx context.Context, formID, provider string, recipients []string) error
+	// RecordEvents stores bounces and complaints reported by a provider and
+	// suppresses the addresses of hard bounces and complaints
+	RecordEvents(ctx context.Context, events []*Event) error
+	// Stats summarizes the delivery events of a form
+	Stats(ctx context.Context, formID string) (*Stats, error)
+}
+
+// service handles suppression and delivery tracking business logic
+type service struct {
+	repository Repository
+	logger     logging.Logger
+	now        func() time.Time
+}
+
+// NewService creates a new email delivery service
+func NewService(repository Repository, logger logging.Logger) Service {
+	return &service{
+		repository: repository,
+		logger:     logger,
+		now:        time.Now,
+	}
+}
+
+// IsSuppressed reports whether address is on the suppression list
+func (s *service) IsSuppressed(ctx context.Context, address string) (bool, error) {
+	suppressed, err := s.repository.IsSuppressed(ctx, NormalizeAddress(address))
+	if err != nil {
+		return false, fmt.Errorf("check email suppression: %w", err)
+	}
+
+	return suppressed, nil
+}
+
+// Suppress adds an address the provider rejected while sending
+func (s *service) Suppress(ctx context.Context, address, detail string) error {
+	return s.suppress(ctx, &Suppression{Address: address, Reason: ReasonRejected, Detail: detail})
+}
+
+// Unsuppress removes an address from the suppression list
+func (s *service) Unsuppress(ctx context.Context, address string) error {
+	address = NormalizeAddress(address)
+	if address == "" {
+		return validationError(ErrAddressRequired)
+	}
+
+	if err := s.repository.DeleteSuppression(ctx, address); err != nil {
+		return fmt.Errorf("delete email suppression: %w", err)
+	}
+
+	s.logger.Info("email address unsuppressed")
+
+	return nil
+}
+
+// ListSuppressions lists suppressions, newest first
+func (s *service) ListSuppressions(ctx context.Context, offset, limit int) ([]*Suppression, int64, error) {
+	suppressions, total, err := s.repository.ListSuppressions(ctx, offset, limit)
+	if err != nil {
+		return nil, 0, fmt.Errorf("list email suppressions: %w", err)
+	}
+
+	return suppressions, total, nil
+}
+
+// RecordSent counts a message sent for a form. Messages sent outside a form,
+// such as admin test emails, are not counted.
+func (s *service) RecordSent(ctx context.Context, formID, provider string, recipients []string) error {
+	if formID == "" || len(recipients) == 0 {
+		return nil
+	}
+
+	now := s.now().UTC()
+	events := make([]*Event, 0, len(recipients))
+
+	for _, recipient := range recipients {
+		events = append(events, &Event{
+			FormID:     formID,
+			Type:       EventSent,
+			Recipient:  NormalizeAddress(recipient),
+			Provider:   provider,
+			OccurredAt: now,
+		})
+	}
+
+	if err := s.repository.CreateEvents(ctx, events); err != nil {
+		return fmt.Errorf("record sent email: %w", err)
+	}
+
+	return nil
+}
+
+// RecordEvents stores provider-reported events and suppresses their recipients
+// where the event calls for it
+func (s *service) RecordEvents(ctx context.Context, events []*Event) error {
+	if len(events) == 0 {
+		return nil
+	}
+
+	for _, event := range events {
+		event.Recipient = NormalizeAddress(event.Recipient)
+		if event.Recipient == "" {
+			return validationError(ErrAddressRequired)
+		}
+
+		event.Detail = truncateDetail(event.Detail)
+
+		if event.OccurredAt.IsZero() {
+			event.OccurredAt = s.now().UTC()
+		}
+	}
+
+	if err := s.repository.CreateEvents(ctx, events); err != nil {
+		return fmt.Errorf("record email delivery events: %w", err)
+	}
+
+	for _, event := range events {
+		if !event.Suppresses() {
+			continue
+		}
+
+		reason := ReasonBounce
+		if event.Type == EventComplaint {
+			reason = ReasonComplaint
+		}
+
+		if err := s.suppress(ctx, &Suppression{
+			Address:  event.Recipient,
+			Reason:   reason,
+			Provider: event.Provider,
+			Detail:   event.Detail,
+			FormID:   event.FormID,
+		}); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Stats summarizes the delivery events of a form
+func (s *service) Stats(ctx context.Context, formID string) (*Stats, error) {
+	counts, err := s.repository.CountEvents(ctx, formID)
+	if err != nil {
+		return nil, fmt.Errorf("count email delivery events: %w", err)
+	}
+
+	stats := &Stats{
+		FormID:     formID,
+		Sent:       counts[EventSent],
+		Bounced:    counts[EventBounce],
+		Complained: counts[EventComplaint],
+	}
+
+	if stats.Sent > 0 {
+		stats.BounceRate = float64(stats.Bounced) / float64(stats.Sent)
+		stats.ComplaintRate = float64(stats.Complained) / float64(stats.Sent)
+	}
+
+	return stats, nil
+}
+
+// suppress normalizes and stores a suppression
+func (s *service) suppress(ctx context.Context, suppression *Suppression) error {
+	suppression.Address = NormalizeAddress(suppression.Address)
+	if suppression.Address == "" {
+		return validationError(ErrAddressRequired)
+	}
+
+	suppression.Detail = truncateDetail(suppression.Detail)
+
+	if err := s.repository.SaveSuppression(ctx, suppression); err != nil {
+		return fmt.Errorf("save email suppression: %w", err)
+	}
+
+	s.logger.Info("email address suppressed",
+		"reason", suppression.Reason, "provider", suppression.Provider, "form_id", suppression.FormID)
+
+	return nil
+}
+
+// validationError wraps an error as a domain validation error
+func validationError(err error) error {
+	return domainerrors.New(domainerrors.ErrCodeValidation, err.Error(), err)
+}
@@ -0,0 +1,157 @@
+package emaildelivery_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	domainerrors "github.com/goformx/goforms/internal/domain/common/errors"
+	"github.com/goformx/goforms/internal/domain/emaildelivery"
+	mocklogging "github.com/goformx/goforms/test/mocks/logging"
+)
+
+// memoryRepository is an in-memory emaildelivery.Repository for tests
+type memoryRepository struct {
+	suppressions map[string]*emaildelivery.Suppression
+	events       []*emaildelivery.Event
+}
+
+func newMemoryRepository() *memoryRepository {
+	return &memoryRepository{suppressions: map[string]*emaildelivery.Suppression{}}
+}
+
+func (r *memoryRepository) IsSuppressed(_ context.Context, address string) (bool, error) {
+	_, ok := r.suppressions[address]
+
+	return ok, nil
+}
+
+func (r *memoryRepository) SaveSuppression(_ context.Context, suppression *emaildelivery.Suppression) error {
+	r.suppressions[suppression.Address] = suppression
+
+	return nil
+}
+
+func (r *memoryRepository) DeleteSuppression(_ context.Context, address string) error {
+	if _, ok := r.suppressions[address]; !ok {
+		return emaildelivery.ErrSuppressionNotFound
+	}
+
+	delete(r.suppressions, address)
+
+	return nil
+}
+
+func (r *memoryRepository) ListSuppressions(
+	_ context.Context, _, _ int,
+) ([]*emaildelivery.Suppression, int64, error) {
+	suppressions := make([]*emaildelivery.Suppression, 0, len(r.suppressions))
+	for _, suppression := range r.suppressions {
+		suppressions = append(suppressions, suppression)
+	}
+
+	return suppressions, int64(len(suppressions)), nil
+}
+
+func (r *memoryRepository) CreateEvents(_ context.Context, events []*emaildelivery.Event) error {
+	r.events = append(r.events, events...)
+
+	return nil
+}
+
+func (r *memoryRepository) CountEvents(_ context.Context, formID string) (map[emaildelivery.EventType]int64, error) {
+	counts := map[emaildelivery.EventType]int64{}
+
+	for _, event := range r.events {
+		if event.FormID == formID {
+			counts[event.Type]++
+		}
+	}
+
+	return counts, nil
+}
+
+func newTestService(t *testing.T) (emaildelivery.Service, *memoryRepository) {
+	t.Helper()
+
+	logger := mocklogging.NewMockLogger(gomock.NewController(t))
+	logger.EXPECT().Info(gomock.Any(), gomock.Any()).AnyTimes()
+
+	repo := newMemoryRepository()
+
+	return emaildelivery.NewService(repo, logger), repo
+}
+
+func TestService_RecordEvents(t *testing.T) {
+	ctx := context.Background()
+	svc, repo := newTestService(t)
+
+	err := svc.RecordEvents(ctx, []*emaildelivery.Event{
+		{FormID: "form-1", Type: emaildelivery.EventBounce, Recipient: "Gone@Example.com", Provider: "ses", Permanent: true},
+		{FormID: "form-1", Type: emaildelivery.EventBounce, Recipient: "full@example.com", Provider: "ses"},
+		{FormID: "form-1", Type: emaildelivery.EventComplaint, Recipient: "angry@example.com", Provider: "ses"},
+	})
+	require.NoError(t, err)
+	require.Len(t, repo.events, 3)
+	assert.False(t, repo.events[0].OccurredAt.IsZero())
+
+	// Hard bounces and complaints are suppressed; soft bounces are not
+	for address, want := range map[string]bool{
+		"gone@example.com": true, "angry@example.com": true, "full@example.com": false,
+	} {
+		suppressed, suppressErr := svc.IsSuppressed(ctx, address)
+		require.NoError(t, suppressErr)
+		assert.Equal(t, want, suppressed, address)
+	}
+
+	assert.Equal(t, emaildelivery.ReasonBounce, repo.suppressions["gone@example.com"].Reason)
+	assert.Equal(t, emaildelivery.ReasonComplaint, repo.suppressions["angry@example.com"].Reason)
+
+	err = svc.RecordEvents(ctx, []*emaildelivery.Event{{Type: emaildelivery.EventBounce}})
+	domainErr := domainerrors.GetDomainError(err)
+	require.NotNil(t, domainErr)
+	assert.Equal(t, domainerrors.ErrCodeValidation, domainErr.Code)
+}
+
+func TestService_Unsuppress(t *testing.T) {
+	ctx := context.Background()
+	svc, _ := newTestService(t)
+
+	require.NoError(t, svc.Suppress(ctx, "Someone <someone@example.com>", "550 mailbox unavailable"))
+
+	suppressed, err := svc.IsSuppressed(ctx, "SOMEONE@example.com")
+	require.NoError(t, err)
+	assert.True(t, suppressed)
+
+	require.NoError(t, svc.Unsuppress(ctx, "someone@example.com"))
+	require.ErrorIs(t, svc.Unsuppress(ctx, "someone@example.com"), emaildelivery.ErrSuppressionNotFound)
+}
+
+func TestService_Stats(t *testing.T) {
+	ctx := context.Background()
+	svc, repo := newTestService(t)
+
+	// Messages sent outside a form are not counted
+	require.NoError(t, svc.RecordSent(ctx, "", "smtp", []string{"a@example.com"}))
+	assert.Empty(t, repo.events)
+
+	require.NoError(t, svc.RecordSent(ctx, "form-1", "smtp",
+		[]string{"a@example.com", "b@example.com", "c@example.com", "d@example.com"}))
+	require.NoError(t, svc.RecordEvents(ctx, []*emaildelivery.Event{
+		{FormID: "form-1", Type: emaildelivery.EventBounce, Recipient: "a@example.com"},
+	}))
+
+	stats, err := svc.Stats(ctx, "form-1")
+	require.NoError(t, err)
+	assert.Equal(t, int64(4), stats.Sent)
+	assert.Equal(t, int64(1), stats.Bounced)
+	assert.InDelta(t, 0.25, stats.BounceRate, 1e-9)
+	assert.Zero(t, stats.ComplaintRate)
+
+	stats, err = svc.Stats(ctx, "form-2")
+	require.NoError(t, err)
+	assert.Zero(t, stats.BounceRate)
+}
@@ -0,0 +1,222 @@
+package emailtemplate
+
+import "time"
+
+// defaults are the built-in templates used when an owner has no override
+var defaults = map[Kind]Template{
+	KindVerification: {
+		Kind:    KindVerification,
+		Subject: "Verify your email address for {{.AppName}}",
+		HTML: `<p>Hi {{.UserName}},</p>
+<p>Please confirm your email address by opening the link below. It expires in {{.ExpiresIn}}.</p>
+<p><a href="{{.VerificationURL}}">Verify email address</a></p>
+<p>If you did not create an account, you can ignore this email.</p>`,
+		Text: `Hi {{.UserName}},
+
+Please confirm your email address by opening the link below. It expires in {{.ExpiresIn}}.
+
+{{.VerificationURL}}
+
+If you did not create an account, you can ignore this email.
+`,
+	},
+	KindPasswordReset: {
+		Kind:    KindPasswordReset,
+		Subject: "Reset your {{.AppName}} password",
+		HTML: `<p>Hi {{.UserName}},</p>
+<p>We received a request to reset your password. The link below expires in {{.ExpiresIn}}.</p>
+<p><a href="{{.ResetURL}}">Reset password</a></p>
+<p>If you did not ask for a reset, you can ignore this email.</p>`,
+		Text: `Hi {{.UserName}},
+
+We received a request to reset your password. The link below expires in {{.ExpiresIn}}.
+
+{{.ResetURL}}
+
+If you did not ask for a reset, you can ignore this email.
+`,
+	},
+	KindSubmissionNotification: {
+		Kind:    KindSubmissionNotification,
+		Subject: "New submission for {{.FormTitle}}",
+		HTML: `<p>{{.FormTitle}} received a new submission on {{.SubmittedAt}}.</p>
+<table>
+{{- range .Fields}}
+<tr><th align="left">{{.Label}}</th><td>{{.Value}}</td></tr>
+{{- end}}
+</table>
+{{- if .SubmissionURL}}
+<p><a href="{{.SubmissionURL}}">View submission</a></p>
+{{- end}}`,
+		Text: `{{.FormTitle}} received a new submission on {{.SubmittedAt}}.
+{{range .Fields}}
+{{.Label}}: {{.Value}}
+{{- end}}
+{{if .SubmissionURL}}
+View submission: {{.SubmissionURL}}
+{{end}}`,
+	},
+	KindDigest: {
+		Kind:    KindDigest,
+		Subject: "{{.ReportName}}: {{.SubmissionCount}} new submission(s) for {{.FormTitle}}",
+		HTML: `<h1>{{.ReportName}}</h1>
+<p>Form: {{.FormTitle}}<br>
+Period: {{.PeriodStart}} to {{.PeriodEnd}}<br>
+New submissions: {{.SubmissionCount}}</p>
+{{- if .HasAttachment}}
+<p>The submissions are attached as a CSV file.</p>
+{{- end}}`,
+		Text: `{{.ReportName}}
+
+Form: {{.FormTitle}}
+Period: {{.PeriodStart}} to {{.PeriodEnd}}
+New submissions: {{.SubmissionCount}}
+{{if .HasAttachment}}
+The submissions are attached as a CSV file.
+{{end}}`,
+	},
+	KindSubmissionThrottled: {
+		Kind:    KindSubmissionThrottled,
+		Subject: "Submissions to {{.FormTitle}} are being throttled",
+		HTML: `<p>{{.FormTitle}} is receiving submissions at more than {{.Multiplier}} times its usual rate.</p>
+<p>Until {{.ThrottledUntil}}, the form accepts at most {{.AllowedRate}} submissions per minute. Extra submissions are asked to retry later.</p>
+<p>If this traffic is expected, you can switch off throttling for the form.</p>`,
+		Text: `{{.FormTitle}} is receiving submissions at more than {{.Multiplier}} times its usual rate.
+
+Until {{.ThrottledUntil}}, the form accepts at most {{.AllowedRate}} submissions per minute. Extra submissions are asked to retry later.
+
+If this traffic is expected, you can switch off throttling for the form.
+`,
+	},
+	KindAccountHealth: {
+		Kind:    KindAccountHealth,
+		Subject: "Your {{.AppName}} week: {{.SubmissionCount}} new submission(s)",
+		HTML: `<p>Hi {{.UserName}},</p>
+<p>Here is how your account did from {{.PeriodStart}} to {{.PeriodEnd}}.</p>
+<h2>Submissions</h2>
+<table>
+{{- range .Forms}}
+<tr><th align="left">{{.Title}}</th><td>{{.Submissions}}</td></tr>
+{{- else}}
+<tr><td>You have no forms yet.</td></tr>
+{{- end}}
+</table>
+{{- if .FailingDeliveries}}
+<h2>Failing integrations</h2>
+<ul>
+{{- range .Forms}}{{if .FailingDeliveries}}
+<li>{{.Title}}: {{.FailingDeliveries}} delivery(ies) waiting for a retry</li>
+{{- end}}{{end}}
+</ul>
+{{- end}}
+{{- if .Quotas}}
+<h2>Approaching quotas</h2>
+<ul>
+{{- range .Quotas}}
+<li>{{.Name}}: {{.Used}} of {{.Limit}} ({{.Percent}}%)</li>
+{{- end}}
+</ul>
+{{- end}}
+{{- if .SecurityEvents}}
+<h2>Security events</h2>
+<ul>
+{{- range .SecurityEvents}}
+<li>{{.At}}: {{.Description}}</li>
+{{- end}}
+</ul>
+{{- end}}
+<p>You receive this email because you turned on the weekly account health email in your preferences.</p>`,
+		Text: `Hi {{.UserName}},
+
+Here is how your account did from {{.PeriodStart}} to {{.PeriodEnd}}.
+
+Submissions
+{{- range .Forms}}
+{{.Title}}: {{.Submissions}}
+{{- else}}
+You have no forms yet.
+{{- end}}
+{{if .FailingDeliveries}}
+Failing integrations
+{{- range .Forms}}{{if .FailingDeliveries}}
+{{.Title}}: {{.FailingDeliveries}} delivery(ies) waiting for a retry
+{{- end}}{{end}}
+{{end}}{{if .Quotas}}
+Approaching quotas
+{{- range .Quotas}}
+{{.Name}}: {{.Used}} of {{.Limit}} ({{.Percent}}%)
+{{- end}}
+{{end}}{{if .SecurityEvents}}
+Security events
+{{- range .SecurityEvents}}
+{{.At}}: {{.Description}}
+{{- end}}
+{{end}}
+You receive this email because you turned on the weekly account health email in your preferences.
+`,
+	},
+}
+
+// Default returns the built-in template for kind
+func Default(kind Kind) (*Template, error) {
+	tmpl, ok := defaults[kind]
+	if !ok {
+		return nil, ErrKindInvalid
+	}
+
+	return &tmpl, nil
+}
+
+// SampleVariables returns example values for every variable a kind's templates
+// receive, used to preview templates
+func SampleVariables(kind Kind) map[string]any {
+	sentAt := time.Date(2026, time.January, 15, 9, 30, 0, 0, time.UTC).Format(time.RFC1123)
+	vars := map[string]any{"AppName": "GoFormX", "Flags": map[string]bool{}}
+
+	switch kind {
+	case KindVerification:
+		vars["UserName"] = "Ada Lovelace"
+		vars["VerificationURL"] = "https://example.com/verify-email?token=sample"
+		vars["ExpiresIn"] = "24 hours"
+	case KindPasswordReset:
+		vars["UserName"] = "Ada Lovelace"
+		vars["ResetURL"] = "https://example.com/reset-password?token=sample"
+		vars["ExpiresIn"] = "1 hour"
+	case KindSubmissionNotification:
+		vars["FormTitle"] = "Contact us"
+		vars["SubmissionID"] = "7f9c2ba4-e88f-4f5b-9d1c-1a2b3c4d5e6f"
+		vars["SubmittedAt"] = sentAt
+		vars["SubmissionURL"] = "https://example.com/forms/contact/submissions/7f9c2ba4"
+		vars["Fields"] = []map[string]any{
+			{"Label": "Name", "Value": "Ada Lovelace"},
+			{"Label": "Message", "Value": "Hello!"},
+		}
+	case KindDigest:
+		vars["ReportName"] = "Daily digest"
+		vars["FormTitle"] = "Contact us"
+		vars["PeriodStart"] = time.Date(2026, time.January, 14, 9, 30, 0, 0, time.UTC).Format(time.RFC1123)
+		vars["PeriodEnd"] = sentAt
+		vars["Timezone"] = "UTC"
+		vars["SubmissionCount"] = 3
+		vars["HasAttachment"] = true
+	case KindSubmissionThrottled:
+		vars["FormTitle"] = "Contact us"
+		vars["Multiplier"] = 5
+		vars["AllowedRate"] = 10
+		vars["ThrottledUntil"] = time.Date(2026, time.January, 15, 9, 45, 0, 0, time.UTC).Format(time.RFC1123)
+	case KindAccountHealth:
+		vars["UserName"] = "Ada Lovelace"
+		vars["PeriodStart"] = time.Date(2026, time.January, 8, 9, 30, 0, 0, time.UTC).Format(time.RFC1123)
+		vars["PeriodEnd"] = sentAt
+		vars["Timezone"] = "UTC"
+		vars["SubmissionCount"] = 12
+		vars["FailingDeliveries"] = 2
+		vars["Forms"] = []map[string]any{
+			{"ID": "7f9c2ba4-e88f-4f5b-9d1c-1a2b3c4d5e6f", "Title": "Contact us", "Submissions": 12, "FailingDeliveries": 2},
+		}
+		vars["Quotas"] = []map[string]any{{"Name": "Submissions this month", "Used": 850, "Limit": 1000, "Percent": 85}}
+		vars["SecurityEvents"] = []map[string]any{{"At": sentAt, "Description": "Burst of submissions to Contact us"}}
+	}
+
+	return vars
+}
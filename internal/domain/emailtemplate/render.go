@@ -0,0 +1,80 @@
+package emailtemplate
+
+import (
+	"fmt"
+	"html"
+	"regexp"
+	"strings"
+)
+
+// Rendered is a template executed with its variables
+type Rendered struct {
+	Subject string `json:"subject"`
+	HTML    string `json:"html"`
+	Text    string `json:"text"`
+}
+
+var (
+	// blockTagPattern matches tags that end a line of text
+	blockTagPattern = regexp.MustCompile(`(?i)<(br|/p|/div|/h[1-6]|/li|/tr)\b[^>]*>`)
+	// tagPattern matches any remaining tag
+	tagPattern = regexp.MustCompile(`<[^>]*>`)
+	// blankLinesPattern matches runs of blank lines
+	blankLinesPattern = regexp.MustCompile(`\n\s*\n\s*\n+`)
+)
+
+// Render executes the template with vars. A template without a text body gets
+// one derived from its HTML body, so every message has a plain-text part.
+func (t *Template) Render(vars map[string]any) (*Rendered, error) {
+	p, err := t.parse()
+	if err != nil {
+		return nil, err
+	}
+
+	var subject, text, body strings.Builder
+
+	if err = p.subject.Execute(&subject, vars); err != nil {
+		return nil, fmt.Errorf("render subject: %w", err)
+	}
+
+	if p.html != nil {
+		if err = p.html.Execute(&body, vars); err != nil {
+			return nil, fmt.Errorf("render html body: %w", err)
+		}
+	}
+
+	if p.text != nil {
+		if err = p.text.Execute(&text, vars); err != nil {
+			return nil, fmt.Errorf("render text body: %w", err)
+		}
+	}
+
+	rendered := &Rendered{
+		// Subjects are a single header line
+		Subject: strings.Join(strings.Fields(subject.String()), " "),
+		HTML:    body.String(),
+		Text:    text.String(),
+	}
+
+	if rendered.Text == "" {
+		rendered.Text = htmlToText(rendered.HTML)
+	}
+
+	return rendered, nil
+}
+
+// htmlToText is a rough plain-text rendering of an HTML body
+func htmlToText(body string) string {
+	text := blockTagPattern.ReplaceAllString(body, "$0\n")
+	text = tagPattern.ReplaceAllString(text, "")
+	text = html.UnescapeString(text)
+
+	lines := strings.Split(text, "\n")
+	for i, line := range lines {
+		lines[i] = strings.TrimSpace(line)
+	}
+
+	text = blankLinesPattern.ReplaceAllString(strings.Join(lines, "\n"), "\n\n")
+
+	return strings.TrimSpace(text) + "\n"
+}
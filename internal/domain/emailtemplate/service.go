@@ -0,0 +1,219 @@
+package emailtemplate
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+
+	domainerrors "github.com/goformx/goforms/internal/domain/common/errors"
+	"github.com/goformx/goforms/internal/domain/flags"
+	"github.com/goformx/goforms/internal/infrastructure/logging"
+)
+
+// Repository defines the interface for template override storage
+type Repository interface {
+	// GetTemplate returns an owner's override for kind, or ErrTemplateNotFound
+	GetTemplate(ctx context.Context, ownerID string, kind Kind) (*Template, error)
+	ListTemplates(ctx context.Context, ownerID string) ([]*Template, error)
+	// SaveTemplate creates or replaces the owner's override for the template's kind
+	SaveTemplate(ctx context.Context, template *Template) error
+	DeleteTemplate(ctx context.Context, ownerID string, kind Kind) error
+}
+
+// Service defines the interface for managing and rendering email templates
+type Service interface {
+	// Get returns the template an owner's emails of kind use: their override or the default
+	Get(ctx context.Context, ownerID string, kind Kind) (*Template, error)
+	// List returns the effective template of every kind for an owner
+	List(ctx context.Context, ownerID string) ([]*Template, error)
+	// Save validates and stores an owner's override
+	Save(ctx context.Context, template *Template) error
+	// Delete removes an owner's override so the default applies again
+	Delete(ctx context.Context, ownerID string, kind Kind) error
+	// Render renders the owner's template of kind with vars
+	Render(ctx context.Context, ownerID string, kind Kind, vars map[string]any) (*Rendered, error)
+	// Preview renders a template, which need not be saved, with sample variables
+	// overlaid by vars
+	Preview(template *Template, vars map[string]any) (*Rendered, error)
+}
+
+// service handles email template business logic
+type service struct {
+	repository Repository
+	appName    string
+	flags      flags.Client
+	logger     logging.Logger
+}
+
+// NewService creates a new email template service. appName fills the AppName
+// variable of every template; with a flag client, rendered templates get the
+// owner's feature flags as the Flags variable, such as {{if .Flags.new_footer}}.
+func NewService(repository Repository, appName string, flagClient flags.Client, logger logging.Logger) Service {
+	return &service{
+		repository: repository,
+		appName:    appName,
+		flags:      flagClient,
+		logger:     logger,
+	}
+}
+
+// Get returns the owner's override for kind, falling back to the default
+func (s *service) Get(ctx context.Context, ownerID string, kind Kind) (*Template, error) {
+	if _, err := ParseKind(string(kind)); err != nil {
+		return nil, validationError(err)
+	}
+
+	if ownerID != "" {
+		template, err := s.repository.GetTemplate(ctx, ownerID, kind)
+
+		switch {
+		case err == nil:
+			return template, nil
+		case !errors.Is(err, ErrTemplateNotFound):
+			return nil, fmt.Errorf("get email template: %w", err)
+		}
+	}
+
+	return Default(kind)
+}
+
+// List returns the effective template of every kind, in Kinds order
+func (s *service) List(ctx context.Context, ownerID string) ([]*Template, error) {
+	overrides := map[Kind]*Template{}
+
+	if ownerID != "" {
+		stored, err := s.repository.ListTemplates(ctx, ownerID)
+		if err != nil {
+			return nil, fmt.Errorf("list email templates: %w", err)
+		}
+
+		for _, template := range stored {
+			overrides[template.Kind] = template
+		}
+	}
+
+	templates := make([]*Template, 0, len(Kinds))
+
+	for _, kind := range Kinds {
+		if override, ok := overrides[kind]; ok {
+			templates = append(templates, override)
+
+			continue
+		}
+
+		template, err := Default(kind)
+		if err != nil {
+			return nil, err
+		}
+
+		templates = append(templates, template)
+	}
+
+	return templates, nil
+}
+
+// Save validates and stores an owner's override
+func (s *service) Save(ctx context.Context, template *Template) error {
+	if template.OwnerID == "" {
+		return validationError(ErrOwnerRequired)
+	}
+
+	// Previewing also catches execution errors, such as calling a missing
+	// method, before the template is used for real
+	if _, err := s.Preview(template, nil); err != nil {
+		return err
+	}
+
+	if err := s.repository.SaveTemplate(ctx, template); err != nil {
+		return fmt.Errorf("save email template: %w", err)
+	}
+
+	s.logger.Info("email template saved", "owner_id", template.OwnerID, "kind", string(template.Kind))
+
+	return nil
+}
+
+// Delete removes an owner's override
+func (s *service) Delete(ctx context.Context, ownerID string, kind Kind) error {
+	if _, err := ParseKind(string(kind)); err != nil {
+		return validationError(err)
+	}
+
+	if err := s.repository.DeleteTemplate(ctx, ownerID, kind); err != nil {
+		return fmt.Errorf("delete email template: %w", err)
+	}
+
+	return nil
+}
+
+// Render renders the owner's template of kind with vars
+func (s *service) Render(ctx context.Context, ownerID string, kind Kind, vars map[string]any) (*Rendered, error) {
+	template, err := s.Get(ctx, ownerID, kind)
+	if err != nil {
+		return nil, err
+	}
+
+	base := map[string]any{"Flags": s.ownerFlags(ctx, ownerID)}
+
+	rendered, err := template.Render(s.variables(base, vars))
+	if err != nil && !template.IsDefault() {
+		// A broken override must not stop the email; fall back to the default
+		s.logger.Warn("email template override failed to render, using default",
+			"owner_id", ownerID, "kind", string(kind), "error", err)
+
+		if template, err = Default(kind); err != nil {
+			return nil, err
+		}
+
+		rendered, err = template.Render(s.variables(base, vars))
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("render email template: %w", err)
+	}
+
+	return rendered, nil
+}
+
+// Preview renders a template with sample variables overlaid by vars
+func (s *service) Preview(template *Template, vars map[string]any) (*Rendered, error) {
+	if err := template.Validate(); err != nil {
+		return nil, validationError(err)
+	}
+
+	rendered, err := template.Render(s.variables(SampleVariables(template.Kind), vars))
+	if err != nil {
+		return nil, validationError(err)
+	}
+
+	return rendered, nil
+}
+
+// variables merges base and vars, with AppName set unless vars provide it
+func (s *service) variables(base, vars map[string]any) map[string]any {
+	merged := make(map[string]any, len(base)+len(vars)+1)
+	maps.Copy(merged, base)
+
+	if s.appName != "" {
+		merged["AppName"] = s.appName
+	}
+
+	maps.Copy(merged, vars)
+
+	return merged
+}
+
+// ownerFlags evaluates every feature flag for the template's owner
+func (s *service) ownerFlags(ctx context.Context, ownerID string) map[string]bool {
+	if s.flags == nil {
+		return map[string]bool{}
+	}
+
+	return s.flags.EnabledFlags(ctx, ownerID)
+}
+
+// validationError wraps a template error as a domain validation error
+func validationError(err error) error {
+	return domainerrors.New(domainerrors.ErrCodeValidation, err.Error(), err)
+}
@@ -0,0 +1,259 @@
+package emailtemplate_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	domainerrors "github.com/goformx/goforms/internal/domain/common/errors"
+	"github.com/goformx/goforms/internal/domain/emailtemplate"
+	"github.com/goformx/goforms/internal/domain/flags"
+	mocklogging "github.com/goformx/goforms/test/mocks/logging"
+)
+
+// memoryRepository is an in-memory emailtemplate.Repository for tests
+type memoryRepository struct {
+	templates map[string]*emailtemplate.Template
+}
+
+func newMemoryRepository() *memoryRepository {
+	return &memoryRepository{templates: map[string]*emailtemplate.Template{}}
+}
+
+func (r *memoryRepository) key(ownerID string, kind emailtemplate.Kind) string {
+	return ownerID + "/" + string(kind)
+}
+
+func (r *memoryRepository) GetTemplate(_ context.Context, ownerID string, kind emailtemplate.Kind) (*emailtemplate.Template, error) {
+	template, ok := r.templates[r.key(ownerID, kind)]
+	if !ok {
+		return nil, fmt.Errorf("get email template: %w", emailtemplate.ErrTemplateNotFound)
+	}
+
+	return template, nil
+}
+
+func (r *memoryRepository) ListTemplates(_ context.Context, ownerID string) ([]*emailtemplate.Template, error) {
+	var templates []*emailtemplate.Template
+
+	for _, template := range r.templates {
+		if template.OwnerID == ownerID {
+			templates = append(templates, template)
+		}
+	}
+
+	return templates, nil
+}
+
+func (r *memoryRepository) SaveTemplate(_ context.Context, template *emailtemplate.Template) error {
+	r.templates[r.key(template.OwnerID, template.Kind)] = template
+
+	return nil
+}
+
+func (r *memoryRepository) DeleteTemplate(_ context.Context, ownerID string, kind emailtemplate.Kind) error {
+	if _, ok := r.templates[r.key(ownerID, kind)]; !ok {
+		return emailtemplate.ErrTemplateNotFound
+	}
+
+	delete(r.templates, r.key(ownerID, kind))
+
+	return nil
+}
+
+// staticFlags is a flags.Client with fixed flags for every account
+type staticFlags map[string]bool
+
+func (f staticFlags) Enabled(_ context.Context, key, _ string) bool {
+	return f[key]
+}
+
+func (f staticFlags) EnabledFlags(_ context.Context, _ string) map[string]bool {
+	return f
+}
+
+func newTestService(t *testing.T, repo emailtemplate.Repository) emailtemplate.Service {
+	t.Helper()
+
+	return newFlaggedTestService(t, repo, nil)
+}
+
+func newFlaggedTestService(t *testing.T, repo emailtemplate.Repository, flagClient flags.Client) emailtemplate.Service {
+	t.Helper()
+
+	logger := mocklogging.NewMockLogger(gomock.NewController(t))
+	logger.EXPECT().Info(gomock.Any(), gomock.Any()).AnyTimes()
+	logger.EXPECT().Warn(gomock.Any(), gomock.Any()).AnyTimes()
+
+	return emailtemplate.NewService(repo, "GoFormX", flagClient, logger)
+}
+
+func TestService_RenderUsesOverrideOrDefault(t *testing.T) {
+	repo := newMemoryRepository()
+	service := newTestService(t, repo)
+	ctx := context.Background()
+
+	require.NoError(t, service.Save(ctx, &emailtemplate.Template{
+		OwnerID: "owner-1",
+		Kind:    emailtemplate.KindDigest,
+		Subject: "[{{.AppName}}] {{.SubmissionCount}} for {{.FormTitle}}",
+		HTML:    "<p>{{.SubmissionCount}} new</p><p>{{.FormTitle}}</p>",
+	}))
+
+	vars := map[string]any{"ReportName": "Weekly", "FormTitle": "Survey", "SubmissionCount": 4}
+
+	rendered, err := service.Render(ctx, "owner-1", emailtemplate.KindDigest, vars)
+	require.NoError(t, err)
+	assert.Equal(t, "[GoFormX] 4 for Survey", rendered.Subject)
+	assert.Equal(t, "4 new\nSurvey\n", rendered.Text, "text body is derived from the HTML body")
+
+	rendered, err = service.Render(ctx, "owner-2", emailtemplate.KindDigest, vars)
+	require.NoError(t, err)
+	assert.Equal(t, "Weekly: 4 new submission(s) for Survey", rendered.Subject)
+	assert.Contains(t, rendered.Text, "New submissions: 4")
+}
+
+func TestService_RenderExposesOwnerFlags(t *testing.T) {
+	repo := newMemoryRepository()
+	service := newFlaggedTestService(t, repo, staticFlags{"new_footer": true})
+	ctx := context.Background()
+
+	require.NoError(t, service.Save(ctx, &emailtemplate.Template{
+		OwnerID: "owner-1",
+		Kind:    emailtemplate.KindDigest,
+		Subject: "{{.FormTitle}}",
+		Text:    "{{if .Flags.new_footer}}new{{else}}old{{end}} {{if .Flags.unknown}}unknown{{end}}",
+	}))
+
+	rendered, err := service.Render(ctx, "owner-1", emailtemplate.KindDigest, map[string]any{"FormTitle": "Survey"})
+	require.NoError(t, err)
+	assert.Equal(t, "new ", rendered.Text)
+
+	preview, err := service.Preview(&emailtemplate.Template{
+		Kind:    emailtemplate.KindDigest,
+		Subject: "s",
+		Text:    "{{if .Flags.new_footer}}new{{else}}old{{end}}",
+	}, nil)
+	require.NoError(t, err)
+	assert.Equal(t, "old", preview.Text)
+}
+
+func TestService_RenderFallsBackWhenOverrideFails(t *testing.T) {
+	repo := newMemoryRepository()
+	// Stored directly, as a template saved before a variable changed type would be
+	repo.templates["owner-1/digest"] = &emailtemplate.Template{
+		OwnerID: "owner-1",
+		Kind:    emailtemplate.KindDigest,
+		Subject: "{{.FormTitle.Missing}}",
+		Text:    "body",
+	}
+
+	rendered, err := newTestService(t, repo).Render(context.Background(), "owner-1", emailtemplate.KindDigest,
+		map[string]any{"ReportName": "Weekly", "FormTitle": "Survey", "SubmissionCount": 1})
+	require.NoError(t, err)
+	assert.Equal(t, "Weekly: 1 new submission(s) for Survey", rendered.Subject)
+}
+
+func TestService_SaveValidates(t *testing.T) {
+	tests := []struct {
+		name     string
+		template *emailtemplate.Template
+		want     error
+	}{
+		{
+			name:     "missing owner",
+			template: &emailtemplate.Template{Kind: emailtemplate.KindDigest, Subject: "s", Text: "t"},
+			want:     emailtemplate.ErrOwnerRequired,
+		},
+		{
+			name:     "unknown kind",
+			template: &emailtemplate.Template{OwnerID: "o", Kind: "newsletter", Subject: "s", Text: "t"},
+			want:     emailtemplate.ErrKindInvalid,
+		},
+		{
+			name:     "missing body",
+			template: &emailtemplate.Template{OwnerID: "o", Kind: emailtemplate.KindDigest, Subject: "s"},
+			want:     emailtemplate.ErrBodyRequired,
+		},
+		{
+			name:     "parse error",
+			template: &emailtemplate.Template{OwnerID: "o", Kind: emailtemplate.KindDigest, Subject: "s", Text: "{{.Unclosed"},
+		},
+		{
+			name: "execution error",
+			template: &emailtemplate.Template{
+				OwnerID: "o", Kind: emailtemplate.KindDigest, Subject: "s", Text: "{{.FormTitle.Missing}}",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := newMemoryRepository()
+
+			err := newTestService(t, repo).Save(context.Background(), tt.template)
+			require.Error(t, err)
+			assert.True(t, domainerrors.IsValidation(err))
+
+			if tt.want != nil {
+				assert.ErrorIs(t, err, tt.want)
+			}
+
+			assert.Empty(t, repo.templates)
+		})
+	}
+}
+
+func TestService_PreviewEscapesHTML(t *testing.T) {
+	rendered, err := newTestService(t, newMemoryRepository()).Preview(&emailtemplate.Template{
+		Kind:    emailtemplate.KindSubmissionNotification,
+		Subject: "New {{.FormTitle}}",
+		HTML:    `<a href="{{.SubmissionURL}}">{{.FormTitle}}</a>`,
+	}, map[string]any{"FormTitle": "<b>Hi</b>", "SubmissionURL": "javascript:alert(1)"})
+	require.NoError(t, err)
+
+	assert.Equal(t, "New <b>Hi</b>", rendered.Subject)
+	assert.Equal(t, `<a href="#ZgotmplZ">&lt;b&gt;Hi&lt;/b&gt;</a>`, rendered.HTML)
+}
+
+func TestService_ListAndDelete(t *testing.T) {
+	service := newTestService(t, newMemoryRepository())
+	ctx := context.Background()
+
+	override := &emailtemplate.Template{OwnerID: "owner-1", Kind: emailtemplate.KindPasswordReset, Subject: "Reset", Text: "{{.ResetURL}}"}
+	require.NoError(t, service.Save(ctx, override))
+
+	templates, err := service.List(ctx, "owner-1")
+	require.NoError(t, err)
+	require.Len(t, templates, len(emailtemplate.Kinds))
+
+	for i, template := range templates {
+		assert.Equal(t, emailtemplate.Kinds[i], template.Kind)
+		assert.Equal(t, template.Kind != emailtemplate.KindPasswordReset, template.IsDefault())
+	}
+
+	require.NoError(t, service.Delete(ctx, "owner-1", emailtemplate.KindPasswordReset))
+	assert.ErrorIs(t, service.Delete(ctx, "owner-1", emailtemplate.KindPasswordReset), emailtemplate.ErrTemplateNotFound)
+
+	template, err := service.Get(ctx, "owner-1", emailtemplate.KindPasswordReset)
+	require.NoError(t, err)
+	assert.True(t, template.IsDefault())
+}
+
+func TestDefaults_RenderWithSampleVariables(t *testing.T) {
+	for _, kind := range emailtemplate.Kinds {
+		template, err := emailtemplate.Default(kind)
+		require.NoError(t, err)
+		require.NoError(t, template.Validate())
+
+		rendered, err := template.Render(emailtemplate.SampleVariables(kind))
+		require.NoError(t, err, kind)
+		assert.NotEmpty(t, rendered.Subject, kind)
+		assert.NotContains(t, rendered.Text, "<no value>", kind)
+		assert.NotContains(t, rendered.HTML, "<no value>", kind)
+	}
+}
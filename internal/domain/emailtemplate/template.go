@@ -0,0 +1,173 @@
+// Package emailtemplate renders transactional emails from built-in templates,
+// which account owners can override. Templates use Go template syntax: the
+// subject and text bodies are rendered with text/template and the HTML body
+// with html/template, so variables are escaped for their context. Templates
+// get the restricted function set of the notifytemplate package.
+package emailtemplate
+
+import (
+	"errors"
+	htmltemplate "html/template"
+	"slices"
+	"strings"
+	texttemplate "text/template"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+
+	"github.com/goformx/goforms/internal/domain/notifytemplate"
+)
+
+// Kind identifies a transactional email
+type Kind string
+
+const (
+	// KindVerification asks a user to confirm their email address
+	KindVerification Kind = "verification"
+	// KindPasswordReset carries a password reset link
+	KindPasswordReset Kind = "password_reset"
+	// KindSubmissionNotification tells a form owner about a new submission
+	KindSubmissionNotification Kind = "submission_notification"
+	// KindDigest summarizes the submissions of a scheduled report period
+	KindDigest Kind = "digest"
+	// KindSubmissionThrottled tells a form owner that a submission spike is being throttled
+	KindSubmissionThrottled Kind = "submission_throttled"
+	// KindAccountHealth summarizes a week of an account's activity for its owner
+	KindAccountHealth Kind = "account_health"
+)
+
+// Kinds lists every template kind
+var Kinds = []Kind{
+	KindVerification, KindPasswordReset, KindSubmissionNotification, KindDigest, KindSubmissionThrottled, KindAccountHealth,
+}
+
+const (
+	// MaxSubjectLength is the maximum length of a subject template
+	MaxSubjectLength = 255
+	// MaxBodyLength is the maximum length of an HTML or text body template
+	MaxBodyLength = 64 * 1024
+)
+
+var (
+	// ErrKindInvalid is returned for an unknown template kind
+	ErrKindInvalid = errors.New(
+		"template kind must be verification, password_reset, submission_notification, digest, submission_throttled " +
+			"or account_health")
+
+	// ErrTemplateNotFound is returned when an owner has no override for a kind
+	ErrTemplateNotFound = errors.New("email template not found")
+
+	// ErrOwnerRequired is returned when an override has no owner
+	ErrOwnerRequired = errors.New("template owner is required")
+
+	// ErrSubjectRequired is returned when a template has no subject
+	ErrSubjectRequired = errors.New("template subject is required")
+
+	// ErrBodyRequired is returned when a template has neither an HTML nor a text body
+	ErrBodyRequired = errors.New("template needs an HTML or a text body")
+
+	// ErrTemplateTooLong is returned when a subject or body exceeds its maximum length
+	ErrTemplateTooLong = errors.New("template subject must be at most 255 characters and bodies at most 64 KiB")
+)
+
+// ParseKind validates a template kind
+func ParseKind(value string) (Kind, error) {
+	kind := Kind(value)
+	if !slices.Contains(Kinds, kind) {
+		return "", ErrKindInvalid
+	}
+
+	return kind, nil
+}
+
+// Template is one version of a transactional email. Built-in defaults have no
+// ID or owner; overrides are stored per owner and kind.
+type Template struct {
+	ID        string    `gorm:"column:uuid;primaryKey;type:uuid;default:gen_random_uuid()" json:"id,omitempty"`
+	OwnerID   string    `gorm:"not null;size:36;uniqueIndex:idx_email_templates_owner_kind" json:"owner_id,omitempty"`
+	Kind      Kind      `gorm:"not null;size:50;uniqueIndex:idx_email_templates_owner_kind" json:"kind"`
+	Subject   string    `gorm:"not null;size:255"                                           json:"subject"`
+	HTML      string    `gorm:"column:html_body;type:text"                                  json:"html"`
+	Text      string    `gorm:"column:text_body;type:text"                                  json:"text"`
+	CreatedAt time.Time `gorm:"not null;autoCreateTime"                                     json:"created_at,omitzero"`
+	UpdatedAt time.Time `gorm:"not null;autoUpdateTime"                                     json:"updated_at,omitzero"`
+}
+
+// TableName specifies the table name for the Template model
+func (t *Template) TableName() string {
+	return "email_templates"
+}
+
+// BeforeCreate is a GORM hook that runs before creating a template
+func (t *Template) BeforeCreate(_ *gorm.DB) error {
+	if t.ID == "" {
+		t.ID = uuid.New().String()
+	}
+
+	return nil
+}
+
+// IsDefault reports whether the template is a built-in default
+func (t *Template) IsDefault() bool {
+	return t.OwnerID == ""
+}
+
+// Validate checks the template's fields and that every part parses
+func (t *Template) Validate() error {
+	if _, err := ParseKind(string(t.Kind)); err != nil {
+		return err
+	}
+
+	if strings.TrimSpace(t.Subject) == "" {
+		return ErrSubjectRequired
+	}
+
+	if strings.TrimSpace(t.HTML) == "" && strings.TrimSpace(t.Text) == "" {
+		return ErrBodyRequired
+	}
+
+	if len(t.Subject) > MaxSubjectLength || len(t.HTML) > MaxBodyLength || len(t.Text) > MaxBodyLength {
+		return ErrTemplateTooLong
+	}
+
+	if _, err := t.parse(); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// parsed holds the compiled parts of a template
+type parsed struct {
+	subject *texttemplate.Template
+	html    *htmltemplate.Template
+	text    *texttemplate.Template
+}
+
+// parse compiles the template's parts with the restricted function set of
+// notification templates. Missing variables render as empty values.
+func (t *Template) parse() (*parsed, error) {
+	var (
+		p   parsed
+		err error
+	)
+
+	if p.subject, err = notifytemplate.ParseText("subject", t.Subject); err != nil {
+		return nil, err
+	}
+
+	if t.HTML != "" {
+		if p.html, err = notifytemplate.ParseHTML("html body", t.HTML); err != nil {
+			return nil, err
+		}
+	}
+
+	if t.Text != "" {
+		if p.text, err = notifytemplate.ParseText("text body", t.Text); err != nil {
+			return nil, err
+		}
+	}
+
+	return &p, nil
+}
@@ -0,0 +1,87 @@
+package encryption
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+)
+
+// EnvelopeField is the only field of a sealed JSON value. Stored data and
+// metadata are JSON objects, so a sealed value stays valid in their columns:
+//
+//	{"$encrypted": {"key_id": "2025-01", "ciphertext": "<base64>"}}
+const EnvelopeField = "$encrypted"
+
+// SealJSON encrypts a JSON object into an envelope. A nil value stays nil.
+func (k *Keyring) SealJSON(value map[string]any) (map[string]any, error) {
+	if value == nil {
+		return nil, nil
+	}
+
+	plaintext, err := json.Marshal(value)
+	if err != nil {
+		return nil, fmt.Errorf("encode value to seal: %w", err)
+	}
+
+	keyID, sealed, err := k.Seal(plaintext)
+	if err != nil {
+		return nil, err
+	}
+
+	return map[string]any{EnvelopeField: map[string]any{
+		"key_id":     keyID,
+		"ciphertext": base64.StdEncoding.EncodeToString(sealed),
+	}}, nil
+}
+
+// OpenJSON decrypts an envelope written by SealJSON and returns the object
+// with the ID of the key it was sealed with. Any other value is returned as
+// it is with an empty key ID.
+func (k *Keyring) OpenJSON(value map[string]any) (map[string]any, string, error) {
+	keyID, ciphertext, ok := envelope(value)
+	if !ok {
+		return value, "", nil
+	}
+
+	sealed, err := base64.StdEncoding.DecodeString(ciphertext)
+	if err != nil {
+		return nil, keyID, fmt.Errorf("%w: ciphertext is not base64", ErrDecrypt)
+	}
+
+	plaintext, err := k.Open(keyID, sealed)
+	if err != nil {
+		return nil, keyID, err
+	}
+
+	var opened map[string]any
+	if unmarshalErr := json.Unmarshal(plaintext, &opened); unmarshalErr != nil {
+		return nil, keyID, fmt.Errorf("decode sealed value: %w", unmarshalErr)
+	}
+
+	return opened, keyID, nil
+}
+
+// SealedKey returns the ID of the key a JSON value is sealed with, or "" for a
+// value that is not sealed
+func SealedKey(value map[string]any) string {
+	keyID, _, _ := envelope(value)
+
+	return keyID
+}
+
+// envelope reads the key ID and ciphertext of a sealed value
+func envelope(value map[string]any) (keyID, ciphertext string, ok bool) {
+	if len(value) != 1 {
+		return "", "", false
+	}
+
+	sealed, ok := value[EnvelopeField].(map[string]any)
+	if !ok {
+		return "", "", false
+	}
+
+	keyID, _ = sealed["key_id"].(string)
+	ciphertext, _ = sealed["ciphertext"].(string)
+
+	return keyID, ciphertext, keyID != "" && ciphertext != ""
+}
@@ -0,0 +1,128 @@
+// Package encryption encrypts stored submissions at rest for the accounts that
+// turn it on. The data and metadata of each submission are sealed with
+// AES-256-GCM under the keyring's active key as they are written and opened
+// again as they are read, so services and handlers only see plaintext. A
+// rotation job moves stored rows onto the active key and in line with each
+// account's setting. Each Laravel account is the tenant the setting belongs to.
+package encryption
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+)
+
+var (
+	// ErrNoKeys is returned for a keyring without keys
+	ErrNoKeys = errors.New("at least one encryption key is required")
+
+	// ErrMalformedKey is returned for a key that is not id:secret
+	ErrMalformedKey = errors.New("encryption key must be id:secret")
+
+	// ErrUnknownKey is returned for data sealed with a key the keyring does not hold
+	ErrUnknownKey = errors.New("unknown encryption key")
+
+	// ErrDecrypt is returned for sealed data that does not open with its key
+	ErrDecrypt = errors.New("decrypt sealed data")
+)
+
+// Keyring holds the keys submissions are sealed with. The first key is active
+// and seals new data; the others are retired and only open data sealed before
+// a rotation.
+type Keyring struct {
+	active string
+	ids    []string
+	keys   map[string]cipher.AEAD
+}
+
+// NewKeyring creates a keyring from "id:secret" pairs, active key first. Each
+// AES-256 key is the SHA-256 of its secret.
+func NewKeyring(keys []string) (*Keyring, error) {
+	if len(keys) == 0 {
+		return nil, ErrNoKeys
+	}
+
+	keyring := &Keyring{keys: make(map[string]cipher.AEAD, len(keys))}
+
+	for _, key := range keys {
+		id, secret, ok := strings.Cut(key, ":")
+		if !ok || id == "" || secret == "" {
+			return nil, ErrMalformedKey
+		}
+
+		if _, exists := keyring.keys[id]; exists {
+			return nil, fmt.Errorf("encryption key %s is listed twice", id)
+		}
+
+		sum := sha256.Sum256([]byte(secret))
+
+		block, err := aes.NewCipher(sum[:])
+		if err != nil {
+			return nil, fmt.Errorf("create cipher for key %s: %w", id, err)
+		}
+
+		gcm, err := cipher.NewGCM(block)
+		if err != nil {
+			return nil, fmt.Errorf("create cipher for key %s: %w", id, err)
+		}
+
+		keyring.keys[id] = gcm
+		keyring.ids = append(keyring.ids, id)
+	}
+
+	keyring.active = keyring.ids[0]
+
+	return keyring, nil
+}
+
+// ActiveKey returns the ID of the key new data is sealed with
+func (k *Keyring) ActiveKey() string {
+	return k.active
+}
+
+// KeyIDs returns the IDs of the keyring's keys, active key first
+func (k *Keyring) KeyIDs() []string {
+	return append([]string{}, k.ids...)
+}
+
+// Seal encrypts plaintext with the active key and returns the key's ID. The
+// nonce is prepended to the ciphertext and the key ID is authenticated with it.
+func (k *Keyring) Seal(plaintext []byte) (string, []byte, error) {
+	gcm := k.keys[k.active]
+
+	nonce := make([]byte, gcm.NonceSize())
+	if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
+		return "", nil, fmt.Errorf("generate encryption nonce: %w", err)
+	}
+
+	sealed := make([]byte, 0, len(nonce)+len(plaintext)+gcm.Overhead())
+	sealed = append(sealed, nonce...)
+
+	return k.active, gcm.Seal(sealed, nonce, plaintext, []byte(k.active)), nil
+}
+
+// Open decrypts data sealed with the given key
+func (k *Keyring) Open(keyID string, sealed []byte) ([]byte, error) {
+	gcm, ok := k.keys[keyID]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrUnknownKey, keyID)
+	}
+
+	if len(sealed) < gcm.NonceSize()+gcm.Overhead() {
+		return nil, fmt.Errorf("%w: data is truncated", ErrDecrypt)
+	}
+
+	nonce, ciphertext := sealed[:gcm.NonceSize()], sealed[gcm.NonceSize():]
+
+	plaintext, err := gcm.Open(nil, nonce, ciphertext, []byte(keyID))
+	if err != nil {
+		return nil, fmt.Errorf("%w with key %s: %w", ErrDecrypt, keyID, err)
+	}
+
+	return plaintext, nil
+}
@@ -0,0 +1,89 @@
+package encryption_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/goformx/goforms/internal/domain/encryption"
+)
+
+const (
+	newKey = "2025-06:0123456789abcdef0123456789abcdef"
+	oldKey = "2024-01:fedcba9876543210fedcba9876543210"
+)
+
+func TestNewKeyring(t *testing.T) {
+	keyring, err := encryption.NewKeyring([]string{newKey, oldKey})
+	require.NoError(t, err)
+	assert.Equal(t, "2025-06", keyring.ActiveKey())
+	assert.Equal(t, []string{"2025-06", "2024-01"}, keyring.KeyIDs())
+
+	_, err = encryption.NewKeyring(nil)
+	require.ErrorIs(t, err, encryption.ErrNoKeys)
+
+	_, err = encryption.NewKeyring([]string{"no-separator"})
+	require.ErrorIs(t, err, encryption.ErrMalformedKey)
+
+	_, err = encryption.NewKeyring([]string{newKey, newKey})
+	require.Error(t, err)
+}
+
+func TestKeyring_SealAndOpenJSON(t *testing.T) {
+	keyring, err := encryption.NewKeyring([]string{newKey})
+	require.NoError(t, err)
+
+	value := map[string]any{"name": "Ada", "age": float64(36)}
+
+	sealed, err := keyring.SealJSON(value)
+	require.NoError(t, err)
+	assert.Equal(t, "2025-06", encryption.SealedKey(sealed))
+	assert.NotContains(t, sealed, "name")
+
+	opened, keyID, err := keyring.OpenJSON(sealed)
+	require.NoError(t, err)
+	assert.Equal(t, "2025-06", keyID)
+	assert.Equal(t, value, opened)
+
+	// Plaintext and nil values pass through
+	opened, keyID, err = keyring.OpenJSON(value)
+	require.NoError(t, err)
+	assert.Empty(t, keyID)
+	assert.Equal(t, value, opened)
+
+	sealed, err = keyring.SealJSON(nil)
+	require.NoError(t, err)
+	assert.Nil(t, sealed)
+}
+
+func TestKeyring_OpenAfterRotation(t *testing.T) {
+	old, err := encryption.NewKeyring([]string{oldKey})
+	require.NoError(t, err)
+
+	sealed, err := old.SealJSON(map[string]any{"email": "ada@example.com"})
+	require.NoError(t, err)
+
+	// The retired key still opens data sealed before the rotation
+	rotated, err := encryption.NewKeyring([]string{newKey, oldKey})
+	require.NoError(t, err)
+
+	opened, keyID, err := rotated.OpenJSON(sealed)
+	require.NoError(t, err)
+	assert.Equal(t, "2024-01", keyID)
+	assert.Equal(t, "ada@example.com", opened["email"])
+
+	// Once it is dropped the data no longer opens
+	dropped, err := encryption.NewKeyring([]string{newKey})
+	require.NoError(t, err)
+
+	_, _, err = dropped.OpenJSON(sealed)
+	require.ErrorIs(t, err, encryption.ErrUnknownKey)
+
+	// A key ID moved onto another key's ciphertext fails authentication
+	envelope := sealed[encryption.EnvelopeField].(map[string]any)
+	envelope["key_id"] = "2025-06"
+
+	_, _, err = rotated.OpenJSON(sealed)
+	require.ErrorIs(t, err, encryption.ErrDecrypt)
+}
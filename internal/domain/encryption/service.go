@@ -0,0 +1,261 @@
+package encryption
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/goformx/goforms/internal/infrastructure/logging"
+)
+
+// Service defines the interface for submission encryption at rest
+type Service interface {
+	// Setting returns an account's setting; an account that never changed it
+	// is not encrypted
+	Setting(ctx context.Context, userID string) (*Setting, error)
+	// SetEnabled turns encryption on or off for an account. New submissions
+	// follow at once; stored ones follow on the next rotation.
+	SetEnabled(ctx context.Context, userID string, enabled bool) (*Setting, error)
+	// Encrypts reports whether an account's submissions are sealed as they are
+	// written. The answer is cached for the setting cache TTL.
+	Encrypts(ctx context.Context, userID string) (bool, error)
+	// Keyring returns the keys submissions are sealed with
+	Keyring() *Keyring
+	// Status describes the configured keys and the last rotation
+	Status() Status
+	// Rotate rewrites the stored submissions that are sealed with a retired key
+	// or do not match their account's setting
+	Rotate(ctx context.Context) (*RotationReport, error)
+}
+
+// Options holds the encryption service settings
+type Options struct {
+	// SettingCacheTTL is how long Encrypts caches an account's setting
+	SettingCacheTTL time.Duration
+	// RotationBatchSize is the number of stored submissions read per rotation batch
+	RotationBatchSize int
+}
+
+// cachedSetting is an account's setting as cached by Encrypts
+type cachedSetting struct {
+	enabled bool
+	expires time.Time
+}
+
+// service handles encryption business logic
+type service struct {
+	repository Repository
+	keyring    *Keyring
+	options    Options
+	logger     logging.Logger
+	now        func() time.Time
+
+	mu       sync.Mutex
+	settings map[string]cachedSetting
+	last     *RotationReport
+	rotating atomic.Bool
+}
+
+// NewService creates a new encryption service
+func NewService(repository Repository, keyring *Keyring, options Options, logger logging.Logger) Service {
+	return &service{
+		repository: repository,
+		keyring:    keyring,
+		options:    options,
+		logger:     logger,
+		now:        time.Now,
+		settings:   make(map[string]cachedSetting),
+	}
+}
+
+// Setting returns an account's setting
+func (s *service) Setting(ctx context.Context, userID string) (*Setting, error) {
+	setting, err := s.repository.GetSetting(ctx, userID)
+	if errors.Is(err, ErrSettingNotFound) {
+		return &Setting{UserID: userID}, nil
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("get encryption setting: %w", err)
+	}
+
+	return setting, nil
+}
+
+// SetEnabled turns encryption on or off for an account
+func (s *service) SetEnabled(ctx context.Context, userID string, enabled bool) (*Setting, error) {
+	setting := &Setting{UserID: userID, Enabled: enabled, UpdatedAt: s.now().UTC()}
+
+	if err := s.repository.SaveSetting(ctx, setting); err != nil {
+		return nil, fmt.Errorf("save encryption setting: %w", err)
+	}
+
+	s.cache(userID, enabled)
+
+	s.logger.Info("submission encryption setting changed", "user_id", userID, "enabled", enabled)
+
+	return setting, nil
+}
+
+// Encrypts reports whether an account's submissions are sealed as they are written
+func (s *service) Encrypts(ctx context.Context, userID string) (bool, error) {
+	s.mu.Lock()
+	cached, ok := s.settings[userID]
+	s.mu.Unlock()
+
+	if ok && s.now().Before(cached.expires) {
+		return cached.enabled, nil
+	}
+
+	setting, err := s.Setting(ctx, userID)
+	if err != nil {
+		return false, err
+	}
+
+	s.cache(userID, setting.Enabled)
+
+	return setting.Enabled, nil
+}
+
+// cache stores an account's setting for the setting cache TTL
+func (s *service) cache(userID string, enabled bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.settings[userID] = cachedSetting{enabled: enabled, expires: s.now().Add(s.options.SettingCacheTTL)}
+}
+
+// Keyring returns the keys submissions are sealed with
+func (s *service) Keyring() *Keyring {
+	return s.keyring
+}
+
+// Status describes the configured keys and the last rotation
+func (s *service) Status() Status {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return Status{ActiveKey: s.keyring.ActiveKey(), Keys: s.keyring.KeyIDs(), LastRotation: s.last}
+}
+
+// Rotate rewrites the stored submissions that are sealed with a retired key or
+// do not match their account's setting. Submissions are read in ID order, so a
+// run resumes past rows it has rewritten; a row that cannot be rewritten is
+// counted and skipped.
+func (s *service) Rotate(ctx context.Context) (*RotationReport, error) {
+	if !s.rotating.CompareAndSwap(false, true) {
+		return nil, ErrRotationRunning
+	}
+	defer s.rotating.Store(false)
+
+	report := &RotationReport{StartedAt: s.now().UTC()}
+	// Settings are read once per run so every row of an account is treated alike
+	owners := make(map[string]bool)
+
+	for afterID := ""; ; {
+		payloads, err := s.repository.StoredPayloads(ctx, afterID, s.options.RotationBatchSize)
+		if err != nil {
+			return report, fmt.Errorf("list stored submissions: %w", err)
+		}
+
+		for _, payload := range payloads {
+			report.Scanned++
+
+			if rotateErr := s.rotate(ctx, payload, owners, report); rotateErr != nil {
+				report.Failed++
+
+				s.logger.Warn("failed to rotate submission encryption", "submission_id", payload.ID, "error", rotateErr)
+			}
+		}
+
+		if len(payloads) < s.options.RotationBatchSize {
+			break
+		}
+
+		afterID = payloads[len(payloads)-1].ID
+	}
+
+	report.FinishedAt = s.now().UTC()
+
+	s.mu.Lock()
+	s.last = report
+	s.mu.Unlock()
+
+	s.logger.Info("submission encryption rotated", "scanned", report.Scanned, "encrypted", report.Encrypted,
+		"decrypted", report.Decrypted, "rekeyed", report.Rekeyed, "failed", report.Failed)
+
+	return report, nil
+}
+
+// rotate rewrites a stored submission when it is sealed with a retired key or
+// does not match its account's setting
+func (s *service) rotate(ctx context.Context, payload *Payload, owners map[string]bool, report *RotationReport) error {
+	encrypt, ok := owners[payload.OwnerID]
+	if !ok {
+		setting, err := s.Setting(ctx, payload.OwnerID)
+		if err != nil {
+			return err
+		}
+
+		encrypt = setting.Enabled
+		owners[payload.OwnerID] = encrypt
+	}
+
+	want := ""
+	if encrypt {
+		want = s.keyring.ActiveKey()
+	}
+
+	current := SealedKey(payload.Data)
+	if current == want && (payload.Metadata == nil || SealedKey(payload.Metadata) == want) {
+		return nil
+	}
+
+	data, metadata, err := s.reseal(payload, encrypt)
+	if err != nil {
+		return err
+	}
+
+	payload.Data, payload.Metadata = data, metadata
+
+	if rewriteErr := s.repository.RewritePayload(ctx, payload); rewriteErr != nil {
+		return fmt.Errorf("rewrite submission: %w", rewriteErr)
+	}
+
+	switch {
+	case !encrypt:
+		report.Decrypted++
+	case current == "":
+		report.Encrypted++
+	default:
+		report.Rekeyed++
+	}
+
+	return nil
+}
+
+// reseal opens a payload's data and metadata and seals them with the active
+// key when encrypt is set
+func (s *service) reseal(payload *Payload, encrypt bool) (data, metadata map[string]any, err error) {
+	values := []map[string]any{payload.Data, payload.Metadata}
+
+	for i, value := range values {
+		opened, _, openErr := s.keyring.OpenJSON(value)
+		if openErr != nil {
+			return nil, nil, openErr
+		}
+
+		if encrypt {
+			if opened, err = s.keyring.SealJSON(opened); err != nil {
+				return nil, nil, err
+			}
+		}
+
+		values[i] = opened
+	}
+
+	return values[0], values[1], nil
+}
@@ -0,0 +1,149 @@
+package encryption_test
+
+import (
+	"context"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/goformx/goforms/internal/domain/encryption"
+	memorystore "github.com/goformx/goforms/internal/infrastructure/repository/memory"
+	mocklogging "github.com/goformx/goforms/test/mocks/logging"
+)
+
+// payloadStore keeps stored payloads for rotation on top of the in-memory settings
+type payloadStore struct {
+	*memorystore.EncryptionStore
+	payloads map[string]*encryption.Payload
+}
+
+func (s *payloadStore) StoredPayloads(_ context.Context, afterID string, limit int) ([]*encryption.Payload, error) {
+	ids := make([]string, 0, len(s.payloads))
+	for id := range s.payloads {
+		if id > afterID {
+			ids = append(ids, id)
+		}
+	}
+
+	sort.Strings(ids)
+
+	var payloads []*encryption.Payload
+
+	for _, id := range ids {
+		if len(payloads) == limit {
+			break
+		}
+
+		stored := *s.payloads[id]
+		payloads = append(payloads, &stored)
+	}
+
+	return payloads, nil
+}
+
+func (s *payloadStore) RewritePayload(_ context.Context, payload *encryption.Payload) error {
+	stored := *payload
+	s.payloads[payload.ID] = &stored
+
+	return nil
+}
+
+func newService(t *testing.T, keys []string, store encryption.Repository) encryption.Service {
+	t.Helper()
+
+	logger := mocklogging.NewMockLogger(gomock.NewController(t))
+	logger.EXPECT().Info(gomock.Any(), gomock.Any()).AnyTimes()
+	logger.EXPECT().Warn(gomock.Any(), gomock.Any()).AnyTimes()
+
+	keyring, err := encryption.NewKeyring(keys)
+	require.NoError(t, err)
+
+	return encryption.NewService(store, keyring, encryption.Options{
+		SettingCacheTTL:   time.Minute,
+		RotationBatchSize: 2,
+	}, logger)
+}
+
+func TestService_SetEnabled(t *testing.T) {
+	ctx := context.Background()
+	svc := newService(t, []string{newKey}, memorystore.NewEncryptionStore())
+
+	setting, err := svc.Setting(ctx, "user-1")
+	require.NoError(t, err)
+	assert.False(t, setting.Enabled, "accounts are not encrypted until they turn it on")
+
+	_, err = svc.SetEnabled(ctx, "user-1", true)
+	require.NoError(t, err)
+
+	encrypts, err := svc.Encrypts(ctx, "user-1")
+	require.NoError(t, err)
+	assert.True(t, encrypts)
+
+	encrypts, err = svc.Encrypts(ctx, "user-2")
+	require.NoError(t, err)
+	assert.False(t, encrypts)
+}
+
+func TestService_Rotate(t *testing.T) {
+	ctx := context.Background()
+
+	old, err := encryption.NewKeyring([]string{oldKey})
+	require.NoError(t, err)
+
+	sealedOld, err := old.SealJSON(map[string]any{"name": "Grace"})
+	require.NoError(t, err)
+
+	sealedOff, err := old.SealJSON(map[string]any{"name": "Alan"})
+	require.NoError(t, err)
+
+	store := &payloadStore{EncryptionStore: memorystore.NewEncryptionStore(), payloads: map[string]*encryption.Payload{
+		// Plaintext of an account that turned encryption on
+		"a": {ID: "a", OwnerID: "on", Data: map[string]any{"name": "Ada"}, Metadata: map[string]any{"ip": "10.0.0.1"}},
+		// Sealed with the retired key
+		"b": {ID: "b", OwnerID: "on", Data: sealedOld},
+		// Sealed for an account that turned encryption off
+		"c": {ID: "c", OwnerID: "off", Data: sealedOff},
+		// Plaintext of an account that never turned it on
+		"d": {ID: "d", OwnerID: "off", Data: map[string]any{"name": "Edsger"}},
+		// Sealed with a key that is no longer configured
+		"e": {ID: "e", OwnerID: "on", Data: map[string]any{encryption.EnvelopeField: map[string]any{
+			"key_id": "lost", "ciphertext": "AAAA",
+		}}},
+	}}
+
+	svc := newService(t, []string{newKey, oldKey}, store)
+
+	_, err = svc.SetEnabled(ctx, "on", true)
+	require.NoError(t, err)
+
+	report, err := svc.Rotate(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 5, report.Scanned)
+	assert.Equal(t, 1, report.Encrypted)
+	assert.Equal(t, 1, report.Rekeyed)
+	assert.Equal(t, 1, report.Decrypted)
+	assert.Equal(t, 1, report.Failed)
+
+	keyring := svc.Keyring()
+
+	assert.Equal(t, "2025-06", encryption.SealedKey(store.payloads["a"].Data))
+	assert.Equal(t, "2025-06", encryption.SealedKey(store.payloads["a"].Metadata))
+	assert.Equal(t, "2025-06", encryption.SealedKey(store.payloads["b"].Data))
+	assert.Equal(t, map[string]any{"name": "Alan"}, store.payloads["c"].Data)
+	assert.Equal(t, map[string]any{"name": "Edsger"}, store.payloads["d"].Data)
+
+	opened, _, err := keyring.OpenJSON(store.payloads["b"].Data)
+	require.NoError(t, err)
+	assert.Equal(t, "Grace", opened["name"])
+
+	// A second run finds nothing left to do but the row it cannot open
+	report, err = svc.Rotate(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 0, report.Encrypted+report.Rekeyed+report.Decrypted)
+	assert.Equal(t, 1, report.Failed)
+	assert.Equal(t, report, svc.Status().LastRotation)
+}
@@ -0,0 +1,75 @@
+package encryption
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+var (
+	// ErrSettingNotFound is returned for an account that never changed its setting
+	ErrSettingNotFound = errors.New("encryption setting not found")
+
+	// ErrRotationRunning is returned when a rotation is requested while one runs
+	ErrRotationRunning = errors.New("a key rotation is already running")
+)
+
+// Setting is an account's choice to encrypt its stored submissions
+type Setting struct {
+	UserID    string    `gorm:"column:user_id;primaryKey;size:36" json:"user_id"`
+	Enabled   bool      `gorm:"not null;default:false"            json:"enabled"`
+	UpdatedAt time.Time `gorm:"not null;autoUpdateTime"           json:"updated_at"`
+}
+
+// TableName specifies the table name for the Setting model
+func (s *Setting) TableName() string {
+	return "account_encryption"
+}
+
+// Payload is a stored submission's data and metadata as they are at rest,
+// sealed or not, with the account that owns its form
+type Payload struct {
+	ID       string
+	OwnerID  string
+	Data     map[string]any
+	Metadata map[string]any
+}
+
+// Repository defines the interface for encryption settings and the stored
+// submissions the rotation job rewrites
+type Repository interface {
+	// GetSetting returns an account's setting, or ErrSettingNotFound
+	GetSetting(ctx context.Context, userID string) (*Setting, error)
+	// SaveSetting creates or replaces an account's setting
+	SaveSetting(ctx context.Context, setting *Setting) error
+	// StoredPayloads lists up to limit stored submissions with IDs after
+	// afterID, in ID order, without opening sealed values
+	StoredPayloads(ctx context.Context, afterID string, limit int) ([]*Payload, error)
+	// RewritePayload stores a submission's data and metadata exactly as given
+	RewritePayload(ctx context.Context, payload *Payload) error
+}
+
+// RotationReport describes a rotation run
+type RotationReport struct {
+	StartedAt  time.Time `json:"started_at"`
+	FinishedAt time.Time `json:"finished_at"`
+	// Scanned is the number of stored submissions read
+	Scanned int `json:"scanned"`
+	// Encrypted counts plaintext submissions of accounts that turned encryption on
+	Encrypted int `json:"encrypted"`
+	// Decrypted counts sealed submissions of accounts that turned encryption off
+	Decrypted int `json:"decrypted"`
+	// Rekeyed counts submissions moved from a retired key to the active key
+	Rekeyed int `json:"rekeyed"`
+	// Failed counts submissions that could not be rewritten, such as those
+	// sealed with a key no longer configured
+	Failed int `json:"failed"`
+}
+
+// Status describes the configured keys and the last rotation
+type Status struct {
+	ActiveKey string   `json:"active_key"`
+	Keys      []string `json:"keys"`
+	// LastRotation is nil until a rotation has run since startup
+	LastRotation *RotationReport `json:"last_rotation"`
+}
@@ -27,16 +27,24 @@ const (
 
 // User represents a user entity
 type User struct {
-	ID             string         `gorm:"column:uuid;primaryKey;type:uuid;default:gen_random_uuid()" json:"id"`
-	Email          string         `gorm:"uniqueIndex;not null;size:255"                              json:"email"`
-	HashedPassword string         `gorm:"column:hashed_password;not null;size:255"                   json:"-"`
-	FirstName      string         `gorm:"not null;size:100"                                          json:"first_name"`
-	LastName       string         `gorm:"not null;size:100"                                          json:"last_name"`
-	Role           string         `gorm:"not null;size:50;default:user"                              json:"role"`
-	Active         bool           `gorm:"not null;default:true"                                      json:"active"`
-	CreatedAt      time.Time      `gorm:"not null;autoCreateTime"                                    json:"created_at"`
-	UpdatedAt      time.Time      `gorm:"not null;autoUpdateTime"                                    json:"updated_at"`
-	DeletedAt      gorm.DeletedAt `gorm:"index"                                                      json:"-"`
+	ID                    string         `gorm:"column:uuid;primaryKey;type:uuid;default:gen_random_uuid()" json:"id"`
+	Email                 string         `gorm:"uniqueIndex;not null;size:255"                              json:"email"`
+	HashedPassword        string         `gorm:"column:hashed_password;not null;size:255"                   json:"-"`
+	FirstName             string         `gorm:"not null;size:100"                                          json:"first_name"`
+	LastName              string         `gorm:"not null;size:100"                                          json:"last_name"`
+	Role                  string         `gorm:"not null;size:50;default:user"                              json:"role"`
+	Active                bool           `gorm:"not null;default:true"                                      json:"active"`
+	LastActiveAt          *time.Time     `gorm:"column:last_active_at"                                      json:"last_active_at,omitempty"`
+	PasswordResetRequired bool           `gorm:"not null;default:false"                                     json:"password_reset_required"`
+	Theme                 string         `gorm:"not null;size:20;default:system"                            json:"theme"`
+	Timezone              string         `gorm:"not null;size:64;default:''"                                json:"timezone"`
+	DeletionRequestedAt   *time.Time     `gorm:"column:deletion_requested_at"                               json:"deletion_requested_at,omitempty"`
+	DeletionScheduledAt   *time.Time     `gorm:"column:deletion_scheduled_at;index"                         json:"deletion_scheduled_at,omitempty"`
+	HealthEmail           bool           `gorm:"not null;default:false"                                     json:"health_email"`
+	HealthEmailSentAt     *time.Time     `gorm:"column:health_email_sent_at"                                json:"health_email_sent_at,omitempty"`
+	CreatedAt             time.Time      `gorm:"not null;autoCreateTime"                                    json:"created_at"`
+	UpdatedAt             time.Time      `gorm:"not null;autoUpdateTime"                                    json:"updated_at"`
+	DeletedAt             gorm.DeletedAt `gorm:"index"                                                      json:"-"`
 }
 
 // TableName specifies the table name for the User model
@@ -139,6 +147,7 @@ func (u *User) SetPassword(password string) error {
 	}
 
 	u.HashedPassword = string(hashedPassword)
+	u.PasswordResetRequired = false
 
 	return nil
 }
@@ -150,6 +159,11 @@ func (u *User) CheckPassword(password string) bool {
 	return err == nil
 }
 
+// DeletionPending reports whether the user has asked for the account to be deleted
+func (u *User) DeletionPending() bool {
+	return u.DeletionScheduledAt != nil
+}
+
 // Deactivate marks the user as inactive
 func (u *User) Deactivate() {
 	u.Active = false
@@ -0,0 +1,92 @@
+// Package eventlog persists published domain events so they can be inspected
+// and replayed. A Recorder wraps the event bus and stores each event before it
+// is dispatched, stamping it with an event ID. Replay republishes stored events
+// marked as replays, keeping their IDs, so consumers with side effects can skip
+// events they have already handled (see events.Deduplicator).
+package eventlog
+
+import (
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+var (
+	// ErrRecordNotFound is returned for an unknown event ID
+	ErrRecordNotFound = errors.New("stored event not found")
+
+	// ErrNotReplayable is returned for a stored event that cannot be republished
+	ErrNotReplayable = errors.New("stored event cannot be replayed")
+)
+
+// RawJSON is JSON text stored as a string and encoded as is
+type RawJSON string
+
+// MarshalJSON implements json.Marshaler
+func (r RawJSON) MarshalJSON() ([]byte, error) {
+	if r == "" {
+		return []byte("null"), nil
+	}
+
+	return []byte(r), nil
+}
+
+// Record is a stored domain event
+type Record struct {
+	ID   string `gorm:"column:uuid;primaryKey;type:uuid;default:gen_random_uuid()" json:"id"`
+	Name string `gorm:"not null;size:100;index:idx_domain_events_name"              json:"name"`
+	// Payload is the JSON encoding of the event payload
+	Payload  RawJSON `gorm:"type:text;not null" json:"payload"`
+	Metadata RawJSON `gorm:"type:text"          json:"metadata"`
+	// OccurredAt is when the event was published
+	OccurredAt time.Time `gorm:"not null;index:idx_domain_events_occurred_at" json:"occurred_at"`
+	CreatedAt  time.Time `gorm:"not null;autoCreateTime"                      json:"created_at"`
+}
+
+// TableName specifies the table name for the Record model
+func (r *Record) TableName() string {
+	return "domain_events"
+}
+
+// BeforeCreate is a GORM hook that runs before creating a record
+func (r *Record) BeforeCreate(_ *gorm.DB) error {
+	if r.ID == "" {
+		r.ID = uuid.New().String()
+	}
+
+	return nil
+}
+
+// Filter narrows a listing of stored events; empty fields match everything
+type Filter struct {
+	Name string
+	// Since matches events published at or after it
+	Since time.Time
+}
+
+// Matches reports whether record passes the filter
+func (f Filter) Matches(record *Record) bool {
+	return (f.Name == "" || record.Name == f.Name) &&
+		(f.Since.IsZero() || !record.OccurredAt.Before(f.Since))
+}
+
+// ParseSince reads a --since or ?since= value: a duration before now, such as
+// "24h", or an RFC 3339 time. An empty value is the zero time.
+func ParseSince(value string, now time.Time) (time.Time, error) {
+	if value == "" {
+		return time.Time{}, nil
+	}
+
+	if d, err := time.ParseDuration(value); err == nil {
+		return now.Add(-d), nil
+	}
+
+	since, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return time.Time{}, errors.New("since must be a duration such as 24h or an RFC 3339 time")
+	}
+
+	return since, nil
+}
@@ -0,0 +1,98 @@
+package eventlog
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/google/uuid"
+
+	"github.com/goformx/goforms/internal/domain/common/events"
+	"github.com/goformx/goforms/internal/infrastructure/logging"
+)
+
+// Recorder is an event bus that stores each published event before passing it
+// to the bus it wraps. Replayed events are passed on without being stored
+// again. A failure to store an event is logged and does not fail the publish.
+type Recorder struct {
+	events.EventBus
+
+	records Repository
+	logger  logging.Logger
+}
+
+// NewRecorder wraps bus so that published events are stored in records
+func NewRecorder(bus events.EventBus, records Repository, logger logging.Logger) *Recorder {
+	return &Recorder{EventBus: bus, records: records, logger: logger}
+}
+
+// Publish stores the event and publishes it
+func (r *Recorder) Publish(ctx context.Context, event events.Event) error {
+	r.record(ctx, event)
+
+	if err := r.EventBus.Publish(ctx, event); err != nil {
+		return fmt.Errorf("publish event: %w", err)
+	}
+
+	return nil
+}
+
+// PublishBatch stores the events and publishes them
+func (r *Recorder) PublishBatch(ctx context.Context, eventList []events.Event) error {
+	for _, event := range eventList {
+		r.record(ctx, event)
+	}
+
+	if err := r.EventBus.PublishBatch(ctx, eventList); err != nil {
+		return fmt.Errorf("publish events: %w", err)
+	}
+
+	return nil
+}
+
+// record stamps the event with an ID and stores it, unless it is a replay
+func (r *Recorder) record(ctx context.Context, event events.Event) {
+	if events.IsReplay(event) {
+		return
+	}
+
+	metadata := event.Metadata()
+
+	id := events.ID(event)
+	if id == "" {
+		id = uuid.New().String()
+		if metadata != nil {
+			metadata[events.MetadataEventID] = id
+		}
+	}
+
+	record, err := newRecord(id, event)
+	if err == nil {
+		err = r.records.Create(ctx, record)
+	}
+
+	if err != nil {
+		r.logger.Warn("failed to store domain event", "event", event.Name(), "event_id", id, "error", err)
+	}
+}
+
+// newRecord encodes an event for storage
+func newRecord(id string, event events.Event) (*Record, error) {
+	payload, err := json.Marshal(event.Payload())
+	if err != nil {
+		return nil, fmt.Errorf("encode event payload: %w", err)
+	}
+
+	metadata, err := json.Marshal(event.Metadata())
+	if err != nil {
+		return nil, fmt.Errorf("encode event metadata: %w", err)
+	}
+
+	return &Record{
+		ID:         id,
+		Name:       event.Name(),
+		Payload:    RawJSON(payload),
+		Metadata:   RawJSON(metadata),
+		OccurredAt: event.Timestamp().UTC(),
+	}, nil
+}
@@ -0,0 +1,177 @@
+package eventlog
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"time"
+
+	"github.com/goformx/goforms/internal/domain/common/events"
+	"github.com/goformx/goforms/internal/infrastructure/logging"
+)
+
+// replayBatchSize is the number of stored events read per page during a replay
+const replayBatchSize = 100
+
+// Repository defines the interface for stored domain events
+type Repository interface {
+	Create(ctx context.Context, record *Record) error
+	// Get returns a stored event, or ErrRecordNotFound
+	Get(ctx context.Context, id string) (*Record, error)
+	// List lists matching events, oldest first, with the total count
+	List(ctx context.Context, filter Filter, offset, limit int) ([]*Record, int64, error)
+	// DeleteBefore deletes the events published before the given time
+	DeleteBefore(ctx context.Context, before time.Time) (int64, error)
+}
+
+// Decoder rebuilds a published event from its stored name and payload
+type Decoder func(name string, payload []byte) (events.Event, error)
+
+// Service defines the interface for inspecting and replaying stored events
+type Service interface {
+	// List lists matching events, oldest first, with the total count
+	List(ctx context.Context, filter Filter, offset, limit int) ([]*Record, int64, error)
+	// Get returns a stored event, or ErrRecordNotFound
+	Get(ctx context.Context, id string) (*Record, error)
+	// Replay republishes the matching events, oldest first
+	Replay(ctx context.Context, filter Filter) (*ReplayReport, error)
+	// Prune deletes the events older than the retention
+	Prune(ctx context.Context) error
+}
+
+// ReplayReport describes a replay
+type ReplayReport struct {
+	Matched  int             `json:"matched"`
+	Replayed int             `json:"replayed"`
+	Failed   []ReplayFailure `json:"failed"`
+}
+
+// ReplayFailure is a stored event that could not be replayed
+type ReplayFailure struct {
+	ID    string `json:"id"`
+	Name  string `json:"name"`
+	Error string `json:"error"`
+}
+
+// service handles stored event business logic
+type service struct {
+	repository Repository
+	bus        events.Publisher
+	decode     Decoder
+	retention  time.Duration
+	logger     logging.Logger
+	now        func() time.Time
+}
+
+// NewService creates a new event log service. Replayed events are rebuilt
+// with decode and published on bus.
+func NewService(
+	repository Repository, bus events.Publisher, decode Decoder, retention time.Duration, logger logging.Logger,
+) Service {
+	return &service{
+		repository: repository,
+		bus:        bus,
+		decode:     decode,
+		retention:  retention,
+		logger:     logger,
+		now:        time.Now,
+	}
+}
+
+// List lists matching stored events
+func (s *service) List(ctx context.Context, filter Filter, offset, limit int) ([]*Record, int64, error) {
+	records, total, err := s.repository.List(ctx, filter, offset, limit)
+	if err != nil {
+		return nil, 0, fmt.Errorf("list stored events: %w", err)
+	}
+
+	return records, total, nil
+}
+
+// Get returns a stored event
+func (s *service) Get(ctx context.Context, id string) (*Record, error) {
+	record, err := s.repository.Get(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("get stored event: %w", err)
+	}
+
+	return record, nil
+}
+
+// Replay republishes the matching events. Events that cannot be rebuilt or
+// published are reported and the replay carries on.
+func (s *service) Replay(ctx context.Context, filter Filter) (*ReplayReport, error) {
+	report := &ReplayReport{Failed: []ReplayFailure{}}
+
+	for offset := 0; ; offset += replayBatchSize {
+		records, _, err := s.repository.List(ctx, filter, offset, replayBatchSize)
+		if err != nil {
+			return report, fmt.Errorf("list stored events: %w", err)
+		}
+
+		for _, record := range records {
+			report.Matched++
+
+			if replayErr := s.replay(ctx, record); replayErr != nil {
+				report.Failed = append(report.Failed, ReplayFailure{ID: record.ID, Name: record.Name, Error: replayErr.Error()})
+
+				continue
+			}
+
+			report.Replayed++
+		}
+
+		if len(records) < replayBatchSize {
+			break
+		}
+	}
+
+	s.logger.Info("stored events replayed",
+		"event", filter.Name, "since", filter.Since, "replayed", report.Replayed, "failed", len(report.Failed))
+
+	return report, nil
+}
+
+// replay rebuilds one stored event and publishes it as a replay
+func (s *service) replay(ctx context.Context, record *Record) error {
+	event, err := s.decode(record.Name, []byte(record.Payload))
+	if err != nil {
+		return fmt.Errorf("decode %s: %w", record.Name, err)
+	}
+
+	metadata := event.Metadata()
+	if metadata == nil {
+		return fmt.Errorf("decode %s: %w", record.Name, ErrNotReplayable)
+	}
+
+	if record.Metadata != "" {
+		var stored map[string]any
+		if decodeErr := json.Unmarshal([]byte(record.Metadata), &stored); decodeErr == nil {
+			maps.Copy(metadata, stored)
+		}
+	}
+
+	metadata[events.MetadataEventID] = record.ID
+	metadata[events.MetadataReplay] = true
+
+	if publishErr := s.bus.Publish(ctx, event); publishErr != nil {
+		return fmt.Errorf("publish %s: %w", record.Name, publishErr)
+	}
+
+	return nil
+}
+
+// Prune deletes the events older than the retention
+func (s *service) Prune(ctx context.Context) error {
+	deleted, err := s.repository.DeleteBefore(ctx, s.now().Add(-s.retention))
+	if err != nil {
+		return fmt.Errorf("prune stored events: %w", err)
+	}
+
+	if deleted > 0 {
+		s.logger.Info("stored events pruned", "deleted", deleted, "retention", s.retention)
+	}
+
+	return nil
+}
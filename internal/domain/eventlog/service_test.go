@@ -0,0 +1,111 @@
+package eventlog_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/goformx/goforms/internal/domain/common/events"
+	"github.com/goformx/goforms/internal/domain/eventlog"
+	formevents "github.com/goformx/goforms/internal/domain/form/events"
+	"github.com/goformx/goforms/internal/domain/form/model"
+	"github.com/goformx/goforms/internal/infrastructure/event"
+	memorystore "github.com/goformx/goforms/internal/infrastructure/repository/memory"
+	mocklogging "github.com/goformx/goforms/test/mocks/logging"
+)
+
+func TestService_RecordAndReplay(t *testing.T) {
+	ctx := context.Background()
+
+	logger := mocklogging.NewMockLogger(gomock.NewController(t))
+	logger.EXPECT().Info(gomock.Any(), gomock.Any()).AnyTimes()
+
+	records := memorystore.NewEventLogStore()
+	bus := eventlog.NewRecorder(event.NewMemoryEventBus(logger), records, logger)
+
+	var (
+		handled []string
+		replays int
+	)
+
+	seen := events.NewDeduplicator(8)
+
+	require.NoError(t, bus.Subscribe(ctx, string(formevents.FormSubmittedEventType), func(_ context.Context, e events.Event) error {
+		if events.IsReplay(e) {
+			replays++
+		}
+
+		if !seen.Seen(e) {
+			handled = append(handled, e.Payload().(*model.FormSubmission).ID)
+		}
+
+		return nil
+	}))
+
+	submitted := formevents.NewFormSubmittedEvent(&model.FormSubmission{ID: "sub-1", FormID: "form-1", Data: model.JSON{"a": "b"}})
+	require.NoError(t, bus.Publish(ctx, submitted))
+	require.NoError(t, bus.Publish(ctx, formevents.NewFormDeletedEvent("form-2")))
+
+	stored, total, err := records.List(ctx, eventlog.Filter{}, 0, 10)
+	require.NoError(t, err)
+	require.EqualValues(t, 2, total)
+	assert.Equal(t, events.ID(submitted), stored[0].ID, "published events carry their stored ID")
+	assert.Equal(t, "form.deleted", stored[1].Name)
+	assert.JSONEq(t, `"form-2"`, string(stored[1].Payload))
+
+	svc := eventlog.NewService(records, bus, formevents.Decode, time.Hour, logger)
+
+	report, err := svc.Replay(ctx, eventlog.Filter{Name: string(formevents.FormSubmittedEventType), Since: time.Now().Add(-time.Hour)})
+	require.NoError(t, err)
+	assert.Equal(t, 1, report.Matched)
+	assert.Equal(t, 1, report.Replayed)
+	assert.Empty(t, report.Failed)
+
+	assert.Equal(t, 1, replays)
+	assert.Equal(t, []string{"sub-1"}, handled, "a consumer handles a replayed event once")
+
+	_, total, err = records.List(ctx, eventlog.Filter{}, 0, 10)
+	require.NoError(t, err)
+	assert.EqualValues(t, 2, total, "replays are not stored again")
+
+	_, err = svc.Get(ctx, "missing")
+	require.ErrorIs(t, err, eventlog.ErrRecordNotFound)
+}
+
+func TestService_Prune(t *testing.T) {
+	ctx := context.Background()
+
+	logger := mocklogging.NewMockLogger(gomock.NewController(t))
+	logger.EXPECT().Info(gomock.Any(), gomock.Any()).AnyTimes()
+
+	records := memorystore.NewEventLogStore()
+	require.NoError(t, records.Create(ctx, &eventlog.Record{Name: "old", Payload: "{}", OccurredAt: time.Now().Add(-2 * time.Hour)}))
+	require.NoError(t, records.Create(ctx, &eventlog.Record{Name: "new", Payload: "{}", OccurredAt: time.Now()}))
+
+	svc := eventlog.NewService(records, nil, formevents.Decode, time.Hour, logger)
+	require.NoError(t, svc.Prune(ctx))
+
+	remaining, _, err := records.List(ctx, eventlog.Filter{}, 0, 10)
+	require.NoError(t, err)
+	require.Len(t, remaining, 1)
+	assert.Equal(t, "new", remaining[0].Name)
+}
+
+func TestParseSince(t *testing.T) {
+	now := time.Date(2026, 1, 2, 12, 0, 0, 0, time.UTC)
+
+	since, err := eventlog.ParseSince("24h", now)
+	require.NoError(t, err)
+	assert.Equal(t, now.Add(-24*time.Hour), since)
+
+	since, err = eventlog.ParseSince("2026-01-01T00:00:00Z", now)
+	require.NoError(t, err)
+	assert.Equal(t, time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC), since)
+
+	_, err = eventlog.ParseSince("yesterday", now)
+	require.Error(t, err)
+}
@@ -0,0 +1,151 @@
+// Package extension runs form lifecycle hooks. An extension is either a
+// compiled-in Go plugin, provided to fx in the "extensions" group, or an
+// external HTTP hook configured under form.extensions.hooks. Extensions run
+// for the forms that enable them by name, or for every form when their
+// settings mark them global. Each call is bounded by a timeout, and the
+// extension's error policy decides whether a failure is logged and skipped
+// or fails the request.
+package extension
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/goformx/goforms/internal/domain/form/model"
+)
+
+// Point is a place in the form lifecycle where extensions run
+type Point string
+
+const (
+	// PointPreValidate runs before a submission is validated. Hooks may replace
+	// the submitted data or reject the submission.
+	PointPreValidate Point = "pre_validate"
+	// PointPostSubmit runs in the background after a submission is stored
+	PointPostSubmit Point = "post_submit"
+	// PointPreRender runs before a form schema is served for rendering. Hooks
+	// may replace the schema.
+	PointPreRender Point = "pre_render"
+)
+
+// Points lists every hook point
+var Points = []Point{PointPreValidate, PointPostSubmit, PointPreRender}
+
+// Error policies
+const (
+	// OnErrorContinue logs a failed or timed-out hook and carries on without it
+	OnErrorContinue = "continue"
+	// OnErrorReject fails the request when the hook fails or times out
+	OnErrorReject = "reject"
+)
+
+// DefaultTimeout bounds a hook call when its settings have no timeout
+const DefaultTimeout = 2 * time.Second
+
+var (
+	// ErrHookFailed is returned when a hook with the reject policy fails
+	ErrHookFailed = errors.New("form extension failed")
+
+	// ErrDuplicateExtension is returned when two extensions share a name
+	ErrDuplicateExtension = errors.New("form extension is registered twice")
+
+	// ErrUnknownPoint is returned for an extension that handles an unknown hook point
+	ErrUnknownPoint = errors.New("unknown form extension hook point")
+
+	// ErrNotPostSubmit is returned when redelivering to an extension that does
+	// not run after the form's submissions
+	ErrNotPostSubmit = errors.New("form extension does not run after submissions of this form")
+)
+
+// RejectionError is returned when a pre-validate hook rejects a submission
+type RejectionError struct {
+	Extension string
+	Message   string
+}
+
+// Error implements the error interface
+func (e *RejectionError) Error() string {
+	return fmt.Sprintf("submission rejected by extension %s: %s", e.Extension, e.Message)
+}
+
+// Request is the input to a hook
+type Request struct {
+	Point  Point  `json:"point"`
+	FormID string `json:"form_id"`
+	// SubmissionID is set for post-submit hooks
+	SubmissionID string `json:"submission_id,omitempty"`
+	// DeliveryID is set for post-submit hooks. A redelivery carries the ID of
+	// the first delivery, so a hook can skip a submission it has handled.
+	DeliveryID string `json:"delivery_id,omitempty"`
+	// Data is the submitted data for pre-validate and post-submit hooks
+	Data model.JSON `json:"data,omitempty"`
+	// Schema is the form schema for pre-render hooks
+	Schema model.JSON `json:"schema,omitempty"`
+}
+
+// Result is a hook's response. The zero Result changes nothing.
+type Result struct {
+	// Data replaces the submitted data (pre-validate)
+	Data model.JSON `json:"data,omitempty"`
+	// Schema replaces the schema served (pre-render)
+	Schema model.JSON `json:"schema,omitempty"`
+	// Reject rejects the submission with Message (pre-validate)
+	Reject  bool   `json:"reject,omitempty"`
+	Message string `json:"message,omitempty"`
+}
+
+// Extension is a hook implementation
+type Extension interface {
+	// Name identifies the extension; forms enable it by this name
+	Name() string
+	// Points lists the hook points the extension handles
+	Points() []Point
+	// Handle runs the hook. The request's maps are copies the hook may change.
+	Handle(ctx context.Context, req Request) (Result, error)
+}
+
+// Settings controls how an extension is run
+type Settings struct {
+	// Timeout bounds each call; zero uses DefaultTimeout
+	Timeout time.Duration
+	// OnError is OnErrorContinue (the default) or OnErrorReject
+	OnError string
+	// Global runs the extension for every form instead of only the forms that enable it
+	Global bool
+}
+
+// ValidPoint reports whether p is a known hook point
+func ValidPoint(p Point) bool {
+	for _, point := range Points {
+		if p == point {
+			return true
+		}
+	}
+
+	return false
+}
+
+// DeliveryID returns the ID of the post-submit delivery of a submission to an extension
+func DeliveryID(submissionID, extension string) string {
+	return submissionID + ":" + extension
+}
+
+// Delivery is the outcome of one post-submit hook call
+type Delivery struct {
+	Extension    string
+	FormID       string
+	SubmissionID string
+	Duration     time.Duration
+	// Redelivery is set when an admin re-ran the hook
+	Redelivery bool
+	// Err is nil when the hook succeeded
+	Err error
+}
+
+// DeliveryRecorder stores the outcome of post-submit hooks, so they show in
+// the submission's history
+type DeliveryRecorder interface {
+	RecordDelivery(ctx context.Context, delivery Delivery) error
+}
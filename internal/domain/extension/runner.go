@@ -0,0 +1,296 @@
+package extension
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/goformx/goforms/internal/domain/form/model"
+	"github.com/goformx/goforms/internal/infrastructure/logging"
+)
+
+// registration is a registered extension and how it is run
+type registration struct {
+	extension Extension
+	settings  Settings
+	points    []Point
+}
+
+// Runner runs registered extensions at each hook point, in registration
+// order. A nil Runner runs nothing, so callers need no enabled check.
+type Runner struct {
+	logger        logging.Logger
+	registrations []registration
+	// deliveries records post-submit outcomes when set
+	deliveries DeliveryRecorder
+	// background tracks post-submit hooks still running
+	background sync.WaitGroup
+}
+
+// NewRunner creates a runner with no extensions
+func NewRunner(logger logging.Logger) *Runner {
+	return &Runner{logger: logger}
+}
+
+// Register adds an extension
+func (r *Runner) Register(ext Extension, settings Settings) error {
+	name := ext.Name()
+	if !model.ExtensionNamePattern.MatchString(name) {
+		return fmt.Errorf("register extension %q: %w", name, model.ErrExtensionNameInvalid)
+	}
+
+	if slices.Contains(r.Names(), name) {
+		return fmt.Errorf("register extension %q: %w", name, ErrDuplicateExtension)
+	}
+
+	points := ext.Points()
+	for _, point := range points {
+		if !ValidPoint(point) {
+			return fmt.Errorf("register extension %q: %w: %s", name, ErrUnknownPoint, point)
+		}
+	}
+
+	if settings.Timeout <= 0 {
+		settings.Timeout = DefaultTimeout
+	}
+
+	r.registrations = append(r.registrations, registration{extension: ext, settings: settings, points: points})
+
+	return nil
+}
+
+// SetDeliveryRecorder records the outcome of every post-submit hook with recorder
+func (r *Runner) SetDeliveryRecorder(recorder DeliveryRecorder) {
+	r.deliveries = recorder
+}
+
+// Names lists the registered extensions
+func (r *Runner) Names() []string {
+	if r == nil {
+		return nil
+	}
+
+	names := make([]string, 0, len(r.registrations))
+	for _, reg := range r.registrations {
+		names = append(names, reg.extension.Name())
+	}
+
+	return names
+}
+
+// PreValidate runs the form's pre-validate hooks and returns the data to
+// validate. A hook rejecting the submission returns a *RejectionError.
+func (r *Runner) PreValidate(ctx context.Context, form *model.Form, data model.JSON) (model.JSON, error) {
+	for _, reg := range r.active(form, PointPreValidate) {
+		result, err := r.call(ctx, reg, Request{Point: PointPreValidate, FormID: form.ID, Data: data.Clone()})
+		if err != nil {
+			return nil, err
+		}
+
+		if result.Reject {
+			return nil, &RejectionError{Extension: reg.extension.Name(), Message: result.Message}
+		}
+
+		if result.Data != nil {
+			data = result.Data
+		}
+	}
+
+	return data, nil
+}
+
+// PreRender runs the form's pre-render hooks and returns the schema to serve
+func (r *Runner) PreRender(ctx context.Context, form *model.Form, schema model.JSON) (model.JSON, error) {
+	for _, reg := range r.active(form, PointPreRender) {
+		result, err := r.call(ctx, reg, Request{Point: PointPreRender, FormID: form.ID, Schema: schema.Clone()})
+		if err != nil {
+			return nil, err
+		}
+
+		if result.Schema != nil {
+			schema = result.Schema
+		}
+	}
+
+	return schema, nil
+}
+
+// PostSubmit runs the form's post-submit hooks in the background. Failures are
+// logged whatever the error policy, since the submission is already stored,
+// and each outcome is passed to the delivery recorder.
+func (r *Runner) PostSubmit(form *model.Form, submission *model.FormSubmission) {
+	active := r.active(form, PointPostSubmit)
+	if len(active) == 0 {
+		return
+	}
+
+	req := Request{
+		Point:        PointPostSubmit,
+		FormID:       form.ID,
+		SubmissionID: submission.ID,
+		Data:         submission.Data.Clone(),
+	}
+
+	r.background.Add(1)
+
+	go func() {
+		defer r.background.Done()
+
+		for _, reg := range active {
+			// call returns rather than logs errors under OnErrorReject
+			reg.settings.OnError = OnErrorReject
+
+			hookReq := req
+			hookReq.Data = req.Data.Clone()
+			hookReq.DeliveryID = DeliveryID(submission.ID, reg.extension.Name())
+
+			start := time.Now()
+
+			_, err := r.call(context.Background(), reg, hookReq)
+			if err != nil {
+				r.logger.Warn("form extension failed; continuing without it",
+					"extension", reg.extension.Name(), "point", string(PointPostSubmit), "form_id", form.ID, "error", err)
+			}
+
+			r.recordDelivery(Delivery{
+				Extension:    reg.extension.Name(),
+				FormID:       form.ID,
+				SubmissionID: submission.ID,
+				Duration:     time.Since(start),
+				Err:          err,
+			})
+		}
+	}()
+}
+
+// Redeliver runs one of the form's post-submit hooks again for a submission
+// and waits for it, such as after the hook failed. The request carries the
+// same delivery ID as the first delivery. The outcome is passed to the
+// delivery recorder and returned.
+func (r *Runner) Redeliver(ctx context.Context, form *model.Form, submission *model.FormSubmission, name string) error {
+	active := r.active(form, PointPostSubmit)
+
+	idx := slices.IndexFunc(active, func(reg registration) bool { return reg.extension.Name() == name })
+	if idx < 0 {
+		return fmt.Errorf("redeliver to %q: %w", name, ErrNotPostSubmit)
+	}
+
+	reg := active[idx]
+	reg.settings.OnError = OnErrorReject
+
+	start := time.Now()
+
+	_, err := r.call(ctx, reg, Request{
+		Point:        PointPostSubmit,
+		FormID:       form.ID,
+		SubmissionID: submission.ID,
+		DeliveryID:   DeliveryID(submission.ID, name),
+		Data:         submission.Data.Clone(),
+	})
+
+	r.recordDelivery(Delivery{
+		Extension:    name,
+		FormID:       form.ID,
+		SubmissionID: submission.ID,
+		Duration:     time.Since(start),
+		Redelivery:   true,
+		Err:          err,
+	})
+
+	return err
+}
+
+// recordDelivery passes a post-submit outcome to the delivery recorder
+func (r *Runner) recordDelivery(delivery Delivery) {
+	if r.deliveries == nil {
+		return
+	}
+
+	if err := r.deliveries.RecordDelivery(context.Background(), delivery); err != nil {
+		r.logger.Warn("failed to record form extension delivery",
+			"extension", delivery.Extension, "submission_id", delivery.SubmissionID, "error", err)
+	}
+}
+
+// Stop waits for running post-submit hooks, or until ctx is done
+func (r *Runner) Stop(ctx context.Context) {
+	if r == nil {
+		return
+	}
+
+	done := make(chan struct{})
+
+	go func() {
+		r.background.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-ctx.Done():
+	}
+}
+
+// active returns the extensions that handle point for form
+func (r *Runner) active(form *model.Form, point Point) []registration {
+	if r == nil || form == nil {
+		return nil
+	}
+
+	var active []registration
+
+	for _, reg := range r.registrations {
+		if !slices.Contains(reg.points, point) {
+			continue
+		}
+
+		if reg.settings.Global || form.ExtensionEnabled(reg.extension.Name()) {
+			active = append(active, reg)
+		}
+	}
+
+	return active
+}
+
+// call runs one hook with its timeout and applies its error policy. Under
+// OnErrorContinue a failure is logged and the zero Result returned.
+func (r *Runner) call(ctx context.Context, reg registration, req Request) (result Result, err error) {
+	name := reg.extension.Name()
+
+	ctx, cancel := context.WithTimeout(ctx, reg.settings.Timeout)
+	defer cancel()
+
+	func() {
+		defer func() {
+			if recovered := recover(); recovered != nil {
+				err = fmt.Errorf("extension panicked: %v", recovered)
+			}
+		}()
+
+		result, err = reg.extension.Handle(ctx, req)
+	}()
+
+	if err == nil && ctx.Err() != nil {
+		err = ctx.Err()
+	}
+
+	if err == nil {
+		return result, nil
+	}
+
+	if errors.Is(err, context.DeadlineExceeded) {
+		err = fmt.Errorf("timed out after %s: %w", reg.settings.Timeout, err)
+	}
+
+	if reg.settings.OnError == OnErrorReject {
+		return Result{}, fmt.Errorf("%w: %s at %s: %w", ErrHookFailed, name, req.Point, err)
+	}
+
+	r.logger.Warn("form extension failed; continuing without it",
+		"extension", name, "point", string(req.Point), "form_id", req.FormID, "error", err)
+
+	return Result{}, nil
+}
@@ -0,0 +1,230 @@
+package extension_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/goformx/goforms/internal/domain/extension"
+	"github.com/goformx/goforms/internal/domain/form/model"
+	mocklogging "github.com/goformx/goforms/test/mocks/logging"
+)
+
+// funcExtension adapts a function to extension.Extension
+type funcExtension struct {
+	name   string
+	points []extension.Point
+	handle func(ctx context.Context, req extension.Request) (extension.Result, error)
+}
+
+func (f *funcExtension) Name() string              { return f.name }
+func (f *funcExtension) Points() []extension.Point { return f.points }
+func (f *funcExtension) Handle(ctx context.Context, req extension.Request) (extension.Result, error) {
+	return f.handle(ctx, req)
+}
+
+// deliveryLog collects recorded post-submit deliveries
+type deliveryLog struct {
+	mu         sync.Mutex
+	deliveries []extension.Delivery
+}
+
+func (d *deliveryLog) RecordDelivery(_ context.Context, delivery extension.Delivery) error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	d.deliveries = append(d.deliveries, delivery)
+
+	return nil
+}
+
+func newRunner(t *testing.T) *extension.Runner {
+	t.Helper()
+
+	logger := mocklogging.NewMockLogger(gomock.NewController(t))
+	logger.EXPECT().Warn(gomock.Any(), gomock.Any()).AnyTimes()
+
+	return extension.NewRunner(logger)
+}
+
+func TestRunner_PreValidate(t *testing.T) {
+	ctx := context.Background()
+	runner := newRunner(t)
+
+	require.NoError(t, runner.Register(&funcExtension{
+		name:   "trim",
+		points: []extension.Point{extension.PointPreValidate},
+		handle: func(_ context.Context, req extension.Request) (extension.Result, error) {
+			req.Data["name"] = "Ada"
+
+			return extension.Result{Data: req.Data}, nil
+		},
+	}, extension.Settings{}))
+	require.NoError(t, runner.Register(&funcExtension{
+		name:   "spam",
+		points: []extension.Point{extension.PointPreValidate},
+		handle: func(_ context.Context, req extension.Request) (extension.Result, error) {
+			return extension.Result{Reject: req.Data["name"] == "spam", Message: "looks like spam"}, nil
+		},
+	}, extension.Settings{Global: true}))
+
+	err := runner.Register(&funcExtension{name: "trim"}, extension.Settings{})
+	require.ErrorIs(t, err, extension.ErrDuplicateExtension)
+
+	err = runner.Register(&funcExtension{name: "late", points: []extension.Point{"post_render"}}, extension.Settings{})
+	require.ErrorIs(t, err, extension.ErrUnknownPoint)
+
+	form := &model.Form{ID: "form-1"}
+
+	// Only the global extension runs until the form enables trim
+	_, err = runner.PreValidate(ctx, form, model.JSON{"name": "spam"})
+
+	var rejection *extension.RejectionError
+	require.ErrorAs(t, err, &rejection)
+	assert.Equal(t, "spam", rejection.Extension)
+	assert.Equal(t, "looks like spam", rejection.Message)
+
+	require.NoError(t, form.SetEnabledExtensions([]string{"trim"}))
+
+	original := model.JSON{"name": " Ada "}
+	data, err := runner.PreValidate(ctx, form, original)
+	require.NoError(t, err)
+	assert.Equal(t, model.JSON{"name": "Ada"}, data)
+	assert.Equal(t, " Ada ", original["name"], "hooks work on a copy")
+}
+
+func TestRunner_ErrorPolicies(t *testing.T) {
+	ctx := context.Background()
+	runner := newRunner(t)
+
+	slow := func(ctx context.Context, _ extension.Request) (extension.Result, error) {
+		<-ctx.Done()
+
+		return extension.Result{}, ctx.Err()
+	}
+	broken := func(context.Context, extension.Request) (extension.Result, error) {
+		panic("boom")
+	}
+
+	require.NoError(t, runner.Register(&funcExtension{
+		name:   "slow",
+		points: []extension.Point{extension.PointPreRender},
+		handle: slow,
+	}, extension.Settings{Timeout: 10 * time.Millisecond, Global: true}))
+	require.NoError(t, runner.Register(&funcExtension{
+		name:   "broken",
+		points: []extension.Point{extension.PointPreRender},
+		handle: broken,
+	}, extension.Settings{OnError: extension.OnErrorReject}))
+
+	schema := model.JSON{"components": []any{}}
+
+	// Under the continue policy a timed-out hook is skipped
+	rendered, err := runner.PreRender(ctx, &model.Form{ID: "form-1"}, schema)
+	require.NoError(t, err)
+	assert.Equal(t, schema, rendered)
+
+	form := &model.Form{ID: "form-2"}
+	require.NoError(t, form.SetEnabledExtensions([]string{"broken"}))
+
+	_, err = runner.PreRender(ctx, form, schema)
+	require.ErrorIs(t, err, extension.ErrHookFailed)
+}
+
+func TestRunner_PostSubmit(t *testing.T) {
+	runner := newRunner(t)
+
+	var (
+		mu   sync.Mutex
+		seen []string
+	)
+
+	require.NoError(t, runner.Register(&funcExtension{
+		name:   "notify",
+		points: []extension.Point{extension.PointPostSubmit},
+		handle: func(_ context.Context, req extension.Request) (extension.Result, error) {
+			mu.Lock()
+			defer mu.Unlock()
+
+			seen = append(seen, req.SubmissionID)
+
+			return extension.Result{}, errors.New("ignored")
+		},
+	}, extension.Settings{OnError: extension.OnErrorReject, Global: true}))
+
+	require.NoError(t, runner.Register(&funcExtension{
+		name:   "archive",
+		points: []extension.Point{extension.PointPostSubmit},
+		handle: func(_ context.Context, _ extension.Request) (extension.Result, error) {
+			return extension.Result{}, nil
+		},
+	}, extension.Settings{Global: true}))
+
+	deliveries := &deliveryLog{}
+	runner.SetDeliveryRecorder(deliveries)
+
+	runner.PostSubmit(&model.Form{ID: "form-1"}, &model.FormSubmission{ID: "sub-1", Data: model.JSON{}})
+	runner.Stop(context.Background())
+
+	mu.Lock()
+	defer mu.Unlock()
+
+	assert.Equal(t, []string{"sub-1"}, seen)
+
+	require.Len(t, deliveries.deliveries, 2)
+	assert.Equal(t, "notify", deliveries.deliveries[0].Extension)
+	assert.Equal(t, "sub-1", deliveries.deliveries[0].SubmissionID)
+	require.ErrorContains(t, deliveries.deliveries[0].Err, "ignored")
+	assert.Equal(t, "archive", deliveries.deliveries[1].Extension)
+	require.NoError(t, deliveries.deliveries[1].Err)
+
+	var nilRunner *extension.Runner
+
+	data, err := nilRunner.PreValidate(context.Background(), &model.Form{}, model.JSON{"a": 1})
+	require.NoError(t, err)
+	assert.Equal(t, model.JSON{"a": 1}, data)
+}
+
+func TestRunner_Redeliver(t *testing.T) {
+	runner := newRunner(t)
+
+	var deliveryIDs []string
+
+	require.NoError(t, runner.Register(&funcExtension{
+		name:   "notify",
+		points: []extension.Point{extension.PointPostSubmit},
+		handle: func(_ context.Context, req extension.Request) (extension.Result, error) {
+			deliveryIDs = append(deliveryIDs, req.DeliveryID)
+
+			return extension.Result{}, errors.New("still down")
+		},
+	}, extension.Settings{}))
+
+	deliveries := &deliveryLog{}
+	runner.SetDeliveryRecorder(deliveries)
+
+	form := &model.Form{ID: "form-1", Extensions: model.JSON{"enabled": []any{"notify"}}}
+	submission := &model.FormSubmission{ID: "sub-1", Data: model.JSON{}}
+
+	runner.PostSubmit(form, submission)
+	runner.Stop(context.Background())
+
+	err := runner.Redeliver(context.Background(), form, submission, "notify")
+	require.ErrorIs(t, err, extension.ErrHookFailed)
+	require.ErrorContains(t, err, "still down")
+
+	assert.Equal(t, []string{"sub-1:notify", "sub-1:notify"}, deliveryIDs, "a redelivery keeps the delivery ID")
+
+	require.Len(t, deliveries.deliveries, 2)
+	assert.False(t, deliveries.deliveries[0].Redelivery)
+	assert.True(t, deliveries.deliveries[1].Redelivery)
+
+	err = runner.Redeliver(context.Background(), &model.Form{ID: "form-2"}, submission, "notify")
+	require.ErrorIs(t, err, extension.ErrNotPostSubmit)
+}
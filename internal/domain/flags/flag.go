@@ -0,0 +1,110 @@
+// Package flags evaluates feature flags. Flags come from providers: the flags
+// defined in the config file and the flags stored through the admin API, which
+// override config flags with the same key so they can be toggled at runtime
+// without a redeploy. A flag can be limited to a percentage of accounts, and
+// listed accounts always get an enabled flag. Each Laravel account is the
+// tenant a flag is evaluated for.
+package flags
+
+import (
+	"errors"
+	"hash/fnv"
+	"regexp"
+	"slices"
+	"time"
+)
+
+// Sources of a flag definition
+const (
+	// SourceConfig is a flag defined under flags.definitions in the config file
+	SourceConfig = "config"
+	// SourceStored is a flag stored through the admin API
+	SourceStored = "stored"
+)
+
+const (
+	// MaxKeyLength is the maximum length of a flag key
+	MaxKeyLength = 100
+	// MaxAccounts is the maximum number of accounts listed on a flag
+	MaxAccounts = 1000
+)
+
+// keyPattern restricts flag keys to lower-case words separated by dots, dashes or underscores
+var keyPattern = regexp.MustCompile(`^[a-z0-9]+([._-][a-z0-9]+)*$`)
+
+var (
+	// ErrFlagNotFound is returned when no provider defines a flag
+	ErrFlagNotFound = errors.New("feature flag not found")
+
+	// ErrKeyInvalid is returned for a malformed flag key
+	ErrKeyInvalid = errors.New(
+		"flag key must be at most 100 lower-case letters and digits separated by dots, dashes or underscores")
+
+	// ErrPercentageInvalid is returned for a rollout percentage outside 0 to 100
+	ErrPercentageInvalid = errors.New("flag percentage must be between 0 and 100")
+
+	// ErrTooManyAccounts is returned when a flag lists more than MaxAccounts accounts
+	ErrTooManyAccounts = errors.New("a flag can list at most 1000 accounts")
+)
+
+// Flag is a feature flag definition
+type Flag struct {
+	Key         string `gorm:"column:flag_key;primaryKey;size:100" json:"key"`
+	Description string `gorm:"size:255"                           json:"description,omitempty"`
+	Enabled     bool   `gorm:"not null;default:false"             json:"enabled"`
+	// Percentage is the share of accounts, 0 to 100, an enabled flag is on for
+	Percentage int `gorm:"not null;default:100" json:"percentage"`
+	// Accounts always get the flag while it is enabled, whatever the percentage
+	Accounts  []string  `gorm:"serializer:json"         json:"accounts"`
+	Source    string    `gorm:"-"                       json:"source"`
+	CreatedAt time.Time `gorm:"not null;autoCreateTime" json:"created_at"`
+	UpdatedAt time.Time `gorm:"not null;autoUpdateTime" json:"updated_at"`
+}
+
+// TableName specifies the table name for the Flag model
+func (f *Flag) TableName() string {
+	return "feature_flags"
+}
+
+// Validate checks the flag's key, percentage and accounts
+func (f *Flag) Validate() error {
+	switch {
+	case len(f.Key) > MaxKeyLength || !keyPattern.MatchString(f.Key):
+		return ErrKeyInvalid
+	case f.Percentage < 0 || f.Percentage > 100:
+		return ErrPercentageInvalid
+	case len(f.Accounts) > MaxAccounts:
+		return ErrTooManyAccounts
+	}
+
+	return nil
+}
+
+// EnabledFor reports whether the flag is on for an account. Listed accounts get
+// an enabled flag; other accounts are placed in a stable bucket from 0 to 99 per
+// flag and get it when their bucket is below the percentage.
+func (f *Flag) EnabledFor(accountID string) bool {
+	switch {
+	case !f.Enabled:
+		return false
+	case f.Percentage >= 100:
+		return true
+	case accountID != "" && slices.Contains(f.Accounts, accountID):
+		return true
+	case f.Percentage <= 0 || accountID == "":
+		return false
+	}
+
+	return Bucket(f.Key, accountID) < f.Percentage
+}
+
+// Bucket places an account in a bucket from 0 to 99 for a flag. Buckets differ
+// between flags, so the same accounts are not always the first to get a rollout.
+func Bucket(key, accountID string) int {
+	h := fnv.New32a()
+	_, _ = h.Write([]byte(key))
+	_, _ = h.Write([]byte{0})
+	_, _ = h.Write([]byte(accountID))
+
+	return int(h.Sum32() % 100)
+}
@@ -0,0 +1,280 @@
+package flags
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+
+	domainerrors "github.com/goformx/goforms/internal/domain/common/errors"
+	"github.com/goformx/goforms/internal/infrastructure/logging"
+)
+
+// Provider supplies flag definitions
+type Provider interface {
+	Flags(ctx context.Context) ([]*Flag, error)
+}
+
+// Repository defines the interface for stored flag definitions
+type Repository interface {
+	ListFlags(ctx context.Context) ([]*Flag, error)
+	// SaveFlag creates or replaces a flag
+	SaveFlag(ctx context.Context, flag *Flag) error
+	// DeleteFlag removes a flag, or returns ErrFlagNotFound
+	DeleteFlag(ctx context.Context, key string) error
+}
+
+// Client evaluates feature flags. Handlers and templates depend on it.
+type Client interface {
+	// Enabled reports whether a flag is on for an account; unknown flags are off
+	Enabled(ctx context.Context, key, accountID string) bool
+	// EnabledFlags evaluates every flag for an account
+	EnabledFlags(ctx context.Context, accountID string) map[string]bool
+}
+
+// Service defines the interface for evaluating and managing feature flags
+type Service interface {
+	Client
+	// List lists every flag, sorted by key, with stored flags in place of config flags
+	List(ctx context.Context) ([]*Flag, error)
+	// Get returns a flag, or ErrFlagNotFound
+	Get(ctx context.Context, key string) (*Flag, error)
+	// Set stores a flag, overriding a config flag with the same key
+	Set(ctx context.Context, flag *Flag) (*Flag, error)
+	// Delete removes a stored flag; a config flag with the same key applies again
+	Delete(ctx context.Context, key string) error
+	// Refresh reloads the flags from the providers; it is the refresh schedule's job
+	Refresh(ctx context.Context) error
+}
+
+// staticProvider provides a fixed list of flags
+type staticProvider struct {
+	flags []*Flag
+}
+
+// NewStaticProvider creates a provider of the flags defined in the config file
+func NewStaticProvider(flags []*Flag) Provider {
+	for _, flag := range flags {
+		flag.Source = SourceConfig
+	}
+
+	return &staticProvider{flags: flags}
+}
+
+// Flags returns copies of the configured flags
+func (p *staticProvider) Flags(_ context.Context) ([]*Flag, error) {
+	flags := make([]*Flag, 0, len(p.flags))
+	for _, flag := range p.flags {
+		copied := *flag
+		flags = append(flags, &copied)
+	}
+
+	return flags, nil
+}
+
+// repositoryProvider provides the stored flags
+type repositoryProvider struct {
+	repository Repository
+}
+
+// NewRepositoryProvider creates a provider of the flags stored through the admin API
+func NewRepositoryProvider(repository Repository) Provider {
+	return &repositoryProvider{repository: repository}
+}
+
+// Flags lists the stored flags
+func (p *repositoryProvider) Flags(ctx context.Context) ([]*Flag, error) {
+	flags, err := p.repository.ListFlags(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("list stored feature flags: %w", err)
+	}
+
+	for _, flag := range flags {
+		flag.Source = SourceStored
+	}
+
+	return flags, nil
+}
+
+// service evaluates flags from an in-memory copy of the providers' flags. The
+// copy is loaded on first use, after every change and by Refresh, so other
+// instances pick up changes within the refresh interval.
+type service struct {
+	config     Provider
+	repository Repository
+	stored     Provider
+	logger     logging.Logger
+
+	mu     sync.RWMutex
+	flags  map[string]*Flag
+	loaded bool
+}
+
+// NewService creates a new feature flag service. Stored flags take precedence
+// over config flags with the same key.
+func NewService(config Provider, repository Repository, logger logging.Logger) Service {
+	return &service{
+		config:     config,
+		repository: repository,
+		stored:     NewRepositoryProvider(repository),
+		logger:     logger,
+	}
+}
+
+// Enabled reports whether a flag is on for an account
+func (s *service) Enabled(ctx context.Context, key, accountID string) bool {
+	flags := s.current(ctx)
+
+	flag, ok := flags[key]
+
+	return ok && flag.EnabledFor(accountID)
+}
+
+// EnabledFlags evaluates every flag for an account
+func (s *service) EnabledFlags(ctx context.Context, accountID string) map[string]bool {
+	flags := s.current(ctx)
+
+	enabled := make(map[string]bool, len(flags))
+	for key, flag := range flags {
+		enabled[key] = flag.EnabledFor(accountID)
+	}
+
+	return enabled
+}
+
+// List lists every flag sorted by key
+func (s *service) List(ctx context.Context) ([]*Flag, error) {
+	flags, err := s.load(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	list := make([]*Flag, 0, len(flags))
+	for _, flag := range flags {
+		list = append(list, flag)
+	}
+
+	sort.Slice(list, func(i, j int) bool { return list[i].Key < list[j].Key })
+
+	return list, nil
+}
+
+// Get returns a flag
+func (s *service) Get(ctx context.Context, key string) (*Flag, error) {
+	flags, err := s.load(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	flag, ok := flags[key]
+	if !ok {
+		return nil, ErrFlagNotFound
+	}
+
+	return flag, nil
+}
+
+// Set validates and stores a flag
+func (s *service) Set(ctx context.Context, flag *Flag) (*Flag, error) {
+	if err := flag.Validate(); err != nil {
+		return nil, validationError(err)
+	}
+
+	if flag.Accounts == nil {
+		flag.Accounts = []string{}
+	}
+
+	if err := s.repository.SaveFlag(ctx, flag); err != nil {
+		return nil, fmt.Errorf("save feature flag: %w", err)
+	}
+
+	flag.Source = SourceStored
+
+	s.logger.Info("feature flag updated", "flag", flag.Key, "enabled", flag.Enabled, "percentage", flag.Percentage)
+
+	if err := s.Refresh(ctx); err != nil {
+		s.logger.Error("failed to refresh feature flags", "error", err)
+	}
+
+	return flag, nil
+}
+
+// Delete removes a stored flag
+func (s *service) Delete(ctx context.Context, key string) error {
+	if err := s.repository.DeleteFlag(ctx, key); err != nil {
+		return fmt.Errorf("delete feature flag: %w", err)
+	}
+
+	s.logger.Info("feature flag deleted", "flag", key)
+
+	if err := s.Refresh(ctx); err != nil {
+		s.logger.Error("failed to refresh feature flags", "error", err)
+	}
+
+	return nil
+}
+
+// Refresh reloads the flags from the providers
+func (s *service) Refresh(ctx context.Context) error {
+	flags := make(map[string]*Flag)
+
+	for _, provider := range []Provider{s.config, s.stored} {
+		if provider == nil {
+			continue
+		}
+
+		provided, err := provider.Flags(ctx)
+		if err != nil {
+			return fmt.Errorf("load feature flags: %w", err)
+		}
+
+		for _, flag := range provided {
+			flags[flag.Key] = flag
+		}
+	}
+
+	s.mu.Lock()
+	s.flags = flags
+	s.loaded = true
+	s.mu.Unlock()
+
+	return nil
+}
+
+// load returns the loaded flags, loading them on first use
+func (s *service) load(ctx context.Context) (map[string]*Flag, error) {
+	s.mu.RLock()
+	flags, loaded := s.flags, s.loaded
+	s.mu.RUnlock()
+
+	if loaded {
+		return flags, nil
+	}
+
+	if err := s.Refresh(ctx); err != nil {
+		return nil, err
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return s.flags, nil
+}
+
+// current returns the loaded flags for evaluation. A failed first load leaves
+// every flag off rather than failing the request.
+func (s *service) current(ctx context.Context) map[string]*Flag {
+	flags, err := s.load(ctx)
+	if err != nil {
+		s.logger.Error("failed to load feature flags", "error", err)
+
+		return nil
+	}
+
+	return flags
+}
+
+// validationError wraps an error as a domain validation error
+func validationError(err error) error {
+	return domainerrors.New(domainerrors.ErrCodeValidation, err.Error(), err)
+}
@@ -0,0 +1,122 @@
+package flags_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	domainerrors "github.com/goformx/goforms/internal/domain/common/errors"
+	"github.com/goformx/goforms/internal/domain/flags"
+	mocklogging "github.com/goformx/goforms/test/mocks/logging"
+)
+
+// memoryRepository is an in-memory flags.Repository for tests
+type memoryRepository struct {
+	flags map[string]*flags.Flag
+}
+
+func (r *memoryRepository) ListFlags(_ context.Context) ([]*flags.Flag, error) {
+	list := make([]*flags.Flag, 0, len(r.flags))
+	for _, flag := range r.flags {
+		copied := *flag
+		list = append(list, &copied)
+	}
+
+	return list, nil
+}
+
+func (r *memoryRepository) SaveFlag(_ context.Context, flag *flags.Flag) error {
+	copied := *flag
+	r.flags[flag.Key] = &copied
+
+	return nil
+}
+
+func (r *memoryRepository) DeleteFlag(_ context.Context, key string) error {
+	if _, ok := r.flags[key]; !ok {
+		return flags.ErrFlagNotFound
+	}
+
+	delete(r.flags, key)
+
+	return nil
+}
+
+func newTestService(t *testing.T, configured ...*flags.Flag) flags.Service {
+	t.Helper()
+
+	logger := mocklogging.NewMockLogger(gomock.NewController(t))
+	logger.EXPECT().Info(gomock.Any(), gomock.Any()).AnyTimes()
+
+	repository := &memoryRepository{flags: map[string]*flags.Flag{}}
+
+	return flags.NewService(flags.NewStaticProvider(configured), repository, logger)
+}
+
+func TestFlagEnabledFor(t *testing.T) {
+	flag := &flags.Flag{Key: "new-builder", Enabled: true, Percentage: 0, Accounts: []string{"acct-1"}}
+	assert.True(t, flag.EnabledFor("acct-1"))
+	assert.False(t, flag.EnabledFor("acct-2"))
+
+	flag.Enabled = false
+	assert.False(t, flag.EnabledFor("acct-1"))
+
+	rollout := &flags.Flag{Key: "new-builder", Enabled: true, Percentage: 30}
+	on := 0
+
+	for i := range 1000 {
+		account := fmt.Sprintf("acct-%d", i)
+		if rollout.EnabledFor(account) {
+			on++
+		}
+
+		assert.Equal(t, rollout.EnabledFor(account), rollout.EnabledFor(account))
+	}
+
+	assert.InDelta(t, 300, on, 60)
+	assert.False(t, rollout.EnabledFor(""))
+}
+
+func TestServiceStoredFlagsOverrideConfig(t *testing.T) {
+	ctx := context.Background()
+	svc := newTestService(t, &flags.Flag{Key: "beta", Enabled: false, Percentage: 100})
+
+	assert.False(t, svc.Enabled(ctx, "beta", "acct-1"))
+	assert.False(t, svc.Enabled(ctx, "unknown", "acct-1"))
+
+	flag, err := svc.Set(ctx, &flags.Flag{Key: "beta", Enabled: true, Percentage: 100})
+	require.NoError(t, err)
+	assert.Equal(t, flags.SourceStored, flag.Source)
+	assert.True(t, svc.Enabled(ctx, "beta", "acct-1"))
+	assert.Equal(t, map[string]bool{"beta": true}, svc.EnabledFlags(ctx, "acct-1"))
+
+	require.NoError(t, svc.Delete(ctx, "beta"))
+
+	flag, err = svc.Get(ctx, "beta")
+	require.NoError(t, err)
+	assert.Equal(t, flags.SourceConfig, flag.Source)
+	assert.False(t, svc.Enabled(ctx, "beta", "acct-1"))
+
+	require.ErrorIs(t, svc.Delete(ctx, "beta"), flags.ErrFlagNotFound)
+
+	_, err = svc.Get(ctx, "missing")
+	require.ErrorIs(t, err, flags.ErrFlagNotFound)
+}
+
+func TestServiceSetValidates(t *testing.T) {
+	svc := newTestService(t)
+
+	_, err := svc.Set(context.Background(), &flags.Flag{Key: "Bad Key", Enabled: true, Percentage: 100})
+	require.ErrorIs(t, err, flags.ErrKeyInvalid)
+
+	var domainErr *domainerrors.DomainError
+	require.ErrorAs(t, err, &domainErr)
+	assert.Equal(t, domainerrors.ErrCodeValidation, domainErr.Code)
+
+	_, err = svc.Set(context.Background(), &flags.Flag{Key: "beta", Percentage: 101})
+	require.ErrorIs(t, err, flags.ErrPercentageInvalid)
+}
@@ -0,0 +1,144 @@
+package form
+
+import (
+	"context"
+	stderrors "errors"
+	"fmt"
+
+	"github.com/goformx/goforms/internal/domain/common/errors"
+	"github.com/goformx/goforms/internal/domain/form/model"
+	"github.com/goformx/goforms/internal/infrastructure/logging"
+	"github.com/goformx/goforms/internal/infrastructure/repository/common"
+)
+
+// SubmissionActivityRepository defines the interface for submission activity storage
+type SubmissionActivityRepository interface {
+	CreateActivity(ctx context.Context, activity *model.SubmissionActivity) error
+	// ListActivitiesBySubmission lists a submission's activity, oldest first
+	ListActivitiesBySubmission(ctx context.Context, submissionID string) ([]*model.SubmissionActivity, error)
+}
+
+// ActivityService defines the interface for a submission's status history,
+// notes and webhook delivery results
+type ActivityService interface {
+	// SetSubmissionStatus changes the status of a submission belonging to the
+	// given form and records the change
+	SetSubmissionStatus(
+		ctx context.Context, formID, submissionID, actorID string, status model.SubmissionStatus,
+	) (*model.FormSubmission, error)
+	// AddNote records a note on a submission
+	AddNote(ctx context.Context, formID, submissionID, actorID, text string) (*model.SubmissionActivity, error)
+	// RecordActivity stores an activity record, such as a webhook delivery result
+	RecordActivity(ctx context.Context, activity *model.SubmissionActivity) error
+	// ListActivity lists a submission's activity, oldest first
+	ListActivity(ctx context.Context, submissionID string) ([]*model.SubmissionActivity, error)
+}
+
+// activityService handles submission activity business logic
+type activityService struct {
+	activities  SubmissionActivityRepository
+	submissions Repository
+	logger      logging.Logger
+}
+
+// NewActivityService creates a new submission activity service
+func NewActivityService(
+	activities SubmissionActivityRepository,
+	submissions Repository,
+	logger logging.Logger,
+) ActivityService {
+	return &activityService{
+		activities:  activities,
+		submissions: submissions,
+		logger:      logger,
+	}
+}
+
+// SetSubmissionStatus validates and stores the submission's new status. Setting
+// the current status again records nothing.
+func (s *activityService) SetSubmissionStatus(
+	ctx context.Context,
+	formID, submissionID, actorID string,
+	status model.SubmissionStatus,
+) (*model.FormSubmission, error) {
+	if !model.ValidSubmissionStatus(status) {
+		return nil, errors.New(errors.ErrCodeValidation, model.ErrSubmissionStatusInvalid.Error(), model.ErrSubmissionStatusInvalid)
+	}
+
+	submission, err := s.submissions.GetSubmissionByID(ctx, submissionID)
+	if err != nil && !stderrors.Is(err, common.ErrNotFound) {
+		return nil, fmt.Errorf("get submission: %w", err)
+	}
+
+	if submission == nil || submission.FormID != formID {
+		return nil, model.ErrSubmissionNotFound
+	}
+
+	previous := submission.Status
+	if previous == status {
+		return submission, nil
+	}
+
+	submission.Status = status
+
+	if updateErr := s.submissions.UpdateSubmission(ctx, submission); updateErr != nil {
+		return nil, fmt.Errorf("update submission status: %w", updateErr)
+	}
+
+	recordErr := s.RecordActivity(ctx, &model.SubmissionActivity{
+		FormID:       formID,
+		SubmissionID: submissionID,
+		Kind:         model.ActivityStatusChanged,
+		ActorID:      actorID,
+		Details:      model.JSON{"from": string(previous), "to": string(status)},
+	})
+	if recordErr != nil {
+		return nil, recordErr
+	}
+
+	return submission, nil
+}
+
+// AddNote validates and stores a note
+func (s *activityService) AddNote(
+	ctx context.Context,
+	formID, submissionID, actorID, text string,
+) (*model.SubmissionActivity, error) {
+	text, err := model.NormalizeNote(text)
+	if err != nil {
+		return nil, errors.New(errors.ErrCodeValidation, err.Error(), err)
+	}
+
+	note := &model.SubmissionActivity{
+		FormID:       formID,
+		SubmissionID: submissionID,
+		Kind:         model.ActivityNote,
+		ActorID:      actorID,
+		Details:      model.JSON{"text": text},
+	}
+
+	if recordErr := s.RecordActivity(ctx, note); recordErr != nil {
+		return nil, recordErr
+	}
+
+	return note, nil
+}
+
+// RecordActivity stores an activity record
+func (s *activityService) RecordActivity(ctx context.Context, activity *model.SubmissionActivity) error {
+	if err := s.activities.CreateActivity(ctx, activity); err != nil {
+		return fmt.Errorf("record submission activity: %w", err)
+	}
+
+	return nil
+}
+
+// ListActivity lists a submission's activity
+func (s *activityService) ListActivity(ctx context.Context, submissionID string) ([]*model.SubmissionActivity, error) {
+	activities, err := s.activities.ListActivitiesBySubmission(ctx, submissionID)
+	if err != nil {
+		return nil, fmt.Errorf("list submission activity: %w", err)
+	}
+
+	return activities, nil
+}
@@ -0,0 +1,62 @@
+package form_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	domainerrors "github.com/goformx/goforms/internal/domain/common/errors"
+	domainform "github.com/goformx/goforms/internal/domain/form"
+	"github.com/goformx/goforms/internal/domain/form/model"
+	memorystore "github.com/goformx/goforms/internal/infrastructure/repository/memory"
+	mocklogging "github.com/goformx/goforms/test/mocks/logging"
+)
+
+func TestActivityService(t *testing.T) {
+	logger := mocklogging.NewMockLogger(gomock.NewController(t))
+	logger.EXPECT().Info(gomock.Any(), gomock.Any()).AnyTimes()
+
+	store := memorystore.NewStore(logger)
+	service := domainform.NewActivityService(store.Activities(), store.Forms(), logger)
+	ctx := t.Context()
+
+	submission := &model.FormSubmission{
+		FormID:      "form-1",
+		Data:        model.JSON{"name": "Ada"},
+		Status:      model.SubmissionStatusPending,
+		SubmittedAt: time.Now(),
+	}
+	require.NoError(t, store.Forms().CreateSubmission(ctx, submission))
+
+	updated, err := service.SetSubmissionStatus(ctx, "form-1", submission.ID, "user-1", model.SubmissionStatusCompleted)
+	require.NoError(t, err)
+	assert.Equal(t, model.SubmissionStatusCompleted, updated.Status)
+
+	// Setting the current status again records nothing
+	_, err = service.SetSubmissionStatus(ctx, "form-1", submission.ID, "user-1", model.SubmissionStatusCompleted)
+	require.NoError(t, err)
+
+	_, err = service.SetSubmissionStatus(ctx, "form-1", submission.ID, "user-1", "archived")
+	assert.Equal(t, domainerrors.ErrCodeValidation, domainerrors.GetDomainError(err).Code)
+
+	_, err = service.SetSubmissionStatus(ctx, "form-2", submission.ID, "user-1", model.SubmissionStatusFailed)
+	require.ErrorIs(t, err, model.ErrSubmissionNotFound)
+
+	note, err := service.AddNote(ctx, "form-1", submission.ID, "user-1", "  Called back  ")
+	require.NoError(t, err)
+	assert.Equal(t, "Called back", note.Details["text"])
+
+	_, err = service.AddNote(ctx, "form-1", submission.ID, "user-1", " ")
+	require.ErrorIs(t, err, model.ErrNoteRequired)
+
+	activities, err := service.ListActivity(ctx, submission.ID)
+	require.NoError(t, err)
+	require.Len(t, activities, 2)
+	assert.Equal(t, model.ActivityStatusChanged, activities[0].Kind)
+	assert.Equal(t, model.JSON{"from": "pending", "to": "completed"}, activities[0].Details)
+	assert.Equal(t, "user-1", activities[0].ActorID)
+	assert.Equal(t, model.ActivityNote, activities[1].Kind)
+}
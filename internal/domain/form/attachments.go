@@ -0,0 +1,139 @@
+package form
+
+import (
+	"errors"
+	"mime"
+	"net/http"
+	"path"
+	"strings"
+
+	"github.com/goformx/goforms/internal/domain/form/model"
+)
+
+var (
+	// ErrAttachmentNotFound is returned when a submission has no file at a field and index
+	ErrAttachmentNotFound = errors.New("attachment not found")
+
+	// ErrAttachmentNotInline is returned for a file stored outside the submission, which
+	// cannot be served from it
+	ErrAttachmentNotInline = errors.New("attachment is not stored inline")
+)
+
+// Attachment is a file uploaded with a submission
+type Attachment struct {
+	Field       string
+	Index       int
+	Name        string
+	ContentType string
+	// Size is the length of the content for inline files, or the size the
+	// client reported for files stored elsewhere
+	Size int64
+	// Inline reports whether the file was sent as a base64 data URL and can be
+	// served from the submission
+	Inline  bool
+	Content []byte
+}
+
+// SubmissionAttachment returns the index-th file uploaded to field. Files are
+// only served when they were sent inline as base64 data URLs; the content type
+// is the declared type, falling back to the file extension and then to the
+// sniffed content.
+func SubmissionAttachment(data model.JSON, field string, index int) (*Attachment, error) {
+	files := fieldFiles(data[field])
+	if index < 0 || index >= len(files) {
+		return nil, ErrAttachmentNotFound
+	}
+
+	file, ok := files[index].(map[string]any)
+	if !ok {
+		return nil, ErrAttachmentNotFound
+	}
+
+	attachment := newAttachment(field, index, file)
+	if !attachment.Inline {
+		return nil, ErrAttachmentNotInline
+	}
+
+	return attachment, nil
+}
+
+// SubmissionAttachments lists the files uploaded to the file components of
+// schema, in schema order, without their content
+func SubmissionAttachments(schema, data model.JSON) []Attachment {
+	attachments := make([]Attachment, 0)
+
+	if data == nil {
+		return attachments
+	}
+
+	_ = model.WalkSchema(schema, func(component map[string]any) error {
+		if componentType, _ := component["type"].(string); componentType != "file" {
+			return nil
+		}
+
+		key, _ := component["key"].(string)
+
+		for index, value := range fieldFiles(data[key]) {
+			if file, isFile := value.(map[string]any); isFile {
+				attachment := newAttachment(key, index, file)
+				attachment.Content = nil
+				attachments = append(attachments, *attachment)
+			}
+		}
+
+		return nil
+	})
+
+	return attachments
+}
+
+// fieldFiles returns the files uploaded to a field, which holds one file or a list
+func fieldFiles(value any) []any {
+	if value == nil {
+		return nil
+	}
+
+	if files, ok := value.([]any); ok {
+		return files
+	}
+
+	return []any{value}
+}
+
+// newAttachment reads a file object of the form.io file component
+func newAttachment(field string, index int, file map[string]any) *Attachment {
+	content, inline := decodeDataURL(file["url"])
+
+	name, _ := file["originalName"].(string)
+	if name == "" {
+		name, _ = file["name"].(string)
+	}
+
+	if name == "" {
+		name = field
+	}
+
+	contentType, _ := file["type"].(string)
+	if contentType == "" {
+		contentType = mime.TypeByExtension(strings.ToLower(path.Ext(name)))
+	}
+
+	if contentType == "" && inline {
+		contentType = http.DetectContentType(content)
+	}
+
+	size := int64(len(content))
+	if reported, ok := file["size"].(float64); ok && !inline {
+		size = int64(reported)
+	}
+
+	return &Attachment{
+		Field:       field,
+		Index:       index,
+		Name:        path.Base(name),
+		ContentType: contentType,
+		Size:        size,
+		Inline:      inline,
+		Content:     content,
+	}
+}
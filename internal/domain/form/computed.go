@@ -0,0 +1,73 @@
+package form
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/goformx/goforms/internal/domain/common/errors"
+	"github.com/goformx/goforms/internal/domain/form/expression"
+	"github.com/goformx/goforms/internal/domain/form/model"
+)
+
+// ApplyComputedFields evaluates server-side calculated components and stores
+// their results in the submission data under each component key.
+// Only components flagged with calculateServer are evaluated; any value the
+// client sent for those keys is overwritten. Components are evaluated in
+// schema order, so later expressions may reference earlier computed fields.
+func ApplyComputedFields(schema, data model.JSON) error {
+	return ApplyComputedFieldsWithLookup(schema, data, nil)
+}
+
+// DatasetLookup reads the rows of the datasets form owners manage, for the
+// lookup() function of computed fields
+type DatasetLookup interface {
+	LookupRow(ctx context.Context, ownerID, name, key string) (map[string]string, bool, error)
+}
+
+// ownerLookup returns the lookup() of computed fields for a form owner's datasets
+func ownerLookup(ctx context.Context, datasets DatasetLookup, ownerID string) expression.Lookup {
+	if datasets == nil {
+		return nil
+	}
+
+	return func(name, key string) (map[string]string, bool, error) {
+		return datasets.LookupRow(ctx, ownerID, name, key)
+	}
+}
+
+// ApplyComputedFieldsWithLookup evaluates computed fields like
+// ApplyComputedFields, with lookup() reading dataset rows through lookup
+func ApplyComputedFieldsWithLookup(schema, data model.JSON, lookup expression.Lookup) error {
+	if schema == nil || data == nil {
+		return nil
+	}
+
+	return model.WalkSchema(schema, func(component map[string]any) error {
+		return applyComputedComponent(component, data, lookup)
+	})
+}
+
+// applyComputedComponent evaluates a single calculated component
+func applyComputedComponent(component map[string]any, data model.JSON, lookup expression.Lookup) error {
+	if calculateServer, _ := component["calculateServer"].(bool); !calculateServer {
+		return nil
+	}
+
+	key, keyOk := component["key"].(string)
+	source, sourceOk := component["calculateValue"].(string)
+
+	if !keyOk || !sourceOk || key == "" || source == "" {
+		return nil
+	}
+
+	value, err := expression.EvaluateWithLookup(source, data, lookup)
+	if err != nil {
+		return errors.New(errors.ErrCodeValidation,
+			fmt.Sprintf("failed to compute field %s", key), err).
+			WithContext("field", key)
+	}
+
+	data[key] = value
+
+	return nil
+}
@@ -0,0 +1,69 @@
+package form_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	domainform "github.com/goformx/goforms/internal/domain/form"
+	"github.com/goformx/goforms/internal/domain/form/model"
+)
+
+func TestApplyComputedFields(t *testing.T) {
+	schema := model.JSON{
+		"components": []any{
+			map[string]any{"key": "price", "type": "number"},
+			map[string]any{"key": "quantity", "type": "number"},
+			map[string]any{
+				"type": "panel",
+				"components": []any{
+					map[string]any{
+						"key":             "total",
+						"type":            "number",
+						"calculateServer": true,
+						"calculateValue":  "value = data.price * data.quantity",
+					},
+					map[string]any{
+						"key":             "summary",
+						"type":            "textfield",
+						"calculateServer": true,
+						"calculateValue":  "upper(data.name) + ' owes ' + round(total, 2)",
+					},
+				},
+			},
+			map[string]any{
+				"key":            "clientOnly",
+				"calculateValue": "value = 1",
+			},
+		},
+	}
+
+	data := model.JSON{
+		"name":       "ada",
+		"price":      2.5,
+		"quantity":   3,
+		"total":      999,
+		"clientOnly": "untouched",
+	}
+
+	require.NoError(t, domainform.ApplyComputedFields(schema, data))
+	assert.InDelta(t, 7.5, data["total"], 0.0001)
+	assert.Equal(t, "ADA owes 7.5", data["summary"])
+	assert.Equal(t, "untouched", data["clientOnly"])
+}
+
+func TestApplyComputedFields_InvalidExpression(t *testing.T) {
+	schema := model.JSON{
+		"components": []any{
+			map[string]any{
+				"key":             "broken",
+				"calculateServer": true,
+				"calculateValue":  "data.a / 0",
+			},
+		},
+	}
+
+	err := domainform.ApplyComputedFields(schema, model.JSON{"a": 1})
+	require.Error(t, err)
+}
@@ -0,0 +1,191 @@
+package form
+
+import (
+	"encoding/json"
+	"html"
+	"net/url"
+	"strings"
+
+	"github.com/goformx/goforms/internal/domain/form/model"
+)
+
+// EmbedSnippet is ready-made code putting a form on another site
+type EmbedSnippet struct {
+	// Name identifies the snippet: iframe, hugo, jekyll, script or react
+	Name string `json:"name"`
+	// Method is the embed method the snippet uses; it is offered only when the form allows it
+	Method model.EmbedMethod `json:"method"`
+	// Language is the syntax of Code, for highlighting
+	Language string `json:"language"`
+	Code     string `json:"code"`
+	// File is a file the snippet needs in the site, such as a Hugo shortcode
+	File *EmbedSnippetFile `json:"file,omitempty"`
+}
+
+// EmbedSnippetFile is a file to add to the site, at Path relative to its root
+type EmbedSnippetFile struct {
+	Path    string `json:"path"`
+	Content string `json:"content"`
+}
+
+// embedFormioScript is the Form.io build the script snippets load, the one the embed page uses
+const embedFormioScript = "https://cdn.form.io/formiojs/formio.full.min.js"
+
+// EmbedSnippets returns the snippets of the embed methods the form allows, with
+// the form's ID and baseURL, where the public form endpoints are served, filled in
+func EmbedSnippets(baseURL string, form *model.Form) []EmbedSnippet {
+	base := strings.TrimSuffix(baseURL, "/")
+	frameURL := base + "/embed/frame/" + form.ID
+	schemaURL := base + "/forms/" + form.ID + "/schema"
+	submitURL := base + "/forms/" + form.ID + "/submit"
+	title := html.EscapeString(form.Title)
+
+	var snippets []EmbedSnippet
+
+	if form.AllowsEmbedMethod(model.EmbedMethodIframe) {
+		snippets = append(snippets,
+			EmbedSnippet{
+				Name:     "iframe",
+				Method:   model.EmbedMethodIframe,
+				Language: "html",
+				Code:     iframeSnippet(form.ID, frameURL, title),
+			},
+			EmbedSnippet{
+				Name:     "hugo",
+				Method:   model.EmbedMethodIframe,
+				Language: "go-html-template",
+				Code:     `{{< goformx id="` + form.ID + `" title="` + shortcodeParam(form.Title) + `" >}}`,
+				File: &EmbedSnippetFile{
+					Path: "layouts/shortcodes/goformx.html",
+					Content: `<iframe src="` + html.EscapeString(base) + `/embed/frame/{{ .Get "id" }}" title="{{ .Get "title" }}"` +
+						` width="100%" height="{{ .Get "height" | default "600" }}" style="border: 0" loading="lazy"></iframe>` + "\n",
+				},
+			},
+			EmbedSnippet{
+				Name:     "jekyll",
+				Method:   model.EmbedMethodIframe,
+				Language: "liquid",
+				Code:     `{% include goformx.html id="` + form.ID + `" title="` + shortcodeParam(form.Title) + `" %}`,
+				File: &EmbedSnippetFile{
+					Path: "_includes/goformx.html",
+					Content: `<iframe src="` + html.EscapeString(base) + `/embed/frame/{{ include.id }}" title="{{ include.title | escape }}"` +
+						` width="100%" height="{{ include.height | default: 600 }}" style="border: 0" loading="lazy"></iframe>` + "\n",
+				},
+			},
+		)
+	}
+
+	if form.AllowsEmbedMethod(model.EmbedMethodScript) {
+		snippets = append(snippets,
+			EmbedSnippet{
+				Name:     "script",
+				Method:   model.EmbedMethodScript,
+				Language: "html",
+				Code:     scriptSnippet(form.ID, schemaURL, submitURL),
+			},
+			EmbedSnippet{
+				Name:     "react",
+				Method:   model.EmbedMethodScript,
+				Language: "tsx",
+				Code:     reactSnippet(schemaURL, submitURL),
+			},
+		)
+	}
+
+	return snippets
+}
+
+// iframeSnippet frames the form and sizes the frame to the form's height, from
+// the frame's goformx:resize messages
+func iframeSnippet(formID, frameURL, title string) string {
+	frameOrigin := frameURL
+	if parsed, err := url.Parse(frameURL); err == nil && parsed.Host != "" {
+		frameOrigin = parsed.Scheme + "://" + parsed.Host
+	}
+
+	return `<iframe id="goformx-frame-` + formID + `" src="` + html.EscapeString(frameURL) + `" title="` + title +
+		`" width="100%" height="600" style="border: 0" loading="lazy"></iframe>
+<script>
+  window.addEventListener('message', function (event) {
+    var frame = document.getElementById('goformx-frame-` + formID + `');
+    if (event.origin !== ` + jsString(frameOrigin) + ` || event.source !== frame.contentWindow) return;
+    if (event.data && (event.data.type === 'goformx:ready' || event.data.type === 'goformx:resize')) {
+      frame.style.height = event.data.height + 'px';
+    }
+  });
+</script>
+`
+}
+
+// scriptSnippet renders the form in a div of the host page
+func scriptSnippet(formID, schemaURL, submitURL string) string {
+	return `<div id="goformx-` + formID + `"></div>
+<script src="` + embedFormioScript + `"></script>
+<script>
+  fetch(` + jsString(schemaURL) + `)
+    .then(function (res) { return res.json(); })
+    .then(function (body) {
+      return Formio.createForm(document.getElementById('goformx-` + formID + `'), body.data, { noAlerts: true });
+    })
+    .then(function (form) {
+      form.nosubmit = true;
+      form.on('submit', function (submission) {
+        fetch(` + jsString(submitURL) + `, {
+          method: 'POST',
+          headers: { 'Content-Type': 'application/json' },
+          body: JSON.stringify(submission.data)
+        }).then(function (res) { form.emit(res.ok ? 'submitDone' : 'submitError', submission); });
+      });
+    });
+</script>
+`
+}
+
+// reactSnippet is a component stub rendering the form with formiojs
+func reactSnippet(schemaURL, submitURL string) string {
+	return `import { useEffect, useRef } from 'react';
+import { Formio } from 'formiojs';
+
+const SCHEMA_URL = ` + jsString(schemaURL) + `;
+const SUBMIT_URL = ` + jsString(submitURL) + `;
+
+export function GoFormXForm() {
+  const container = useRef<HTMLDivElement>(null);
+
+  useEffect(() => {
+    let form: any;
+
+    fetch(SCHEMA_URL)
+      .then((res) => res.json())
+      .then((body) => Formio.createForm(container.current, body.data, { noAlerts: true }))
+      .then((created) => {
+        form = created;
+        form.nosubmit = true;
+        form.on('submit', (submission: { data: Record<string, unknown> }) => {
+          fetch(SUBMIT_URL, {
+            method: 'POST',
+            headers: { 'Content-Type': 'application/json' },
+            body: JSON.stringify(submission.data),
+          }).then((res) => form.emit(res.ok ? 'submitDone' : 'submitError', submission));
+        });
+      });
+
+    return () => form?.destroy();
+  }, []);
+
+  return <div ref={container} />;
+}
+`
+}
+
+// jsString quotes s as a JavaScript string literal. Encoding a string cannot fail.
+func jsString(s string) string {
+	quoted, _ := json.Marshal(s)
+
+	return string(quoted)
+}
+
+// shortcodeParam makes s safe inside a double-quoted Hugo or Jekyll parameter
+func shortcodeParam(s string) string {
+	return strings.NewReplacer(`"`, "'", "\n", " ", "\r", " ").Replace(s)
+}
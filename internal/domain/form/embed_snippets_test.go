@@ -0,0 +1,51 @@
+package form_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	domainform "github.com/goformx/goforms/internal/domain/form"
+	"github.com/goformx/goforms/internal/domain/form/model"
+)
+
+func TestEmbedSnippets(t *testing.T) {
+	form := &model.Form{ID: "form-1", Title: `Say "hi" <now>`}
+
+	snippets := domainform.EmbedSnippets("https://api.example.com/", form)
+
+	names := make([]string, len(snippets))
+	byName := make(map[string]domainform.EmbedSnippet, len(snippets))
+
+	for i, snippet := range snippets {
+		names[i] = snippet.Name
+		byName[snippet.Name] = snippet
+	}
+
+	assert.Equal(t, []string{"iframe", "hugo", "jekyll", "script", "react"}, names)
+
+	assert.Contains(t, byName["iframe"].Code,
+		`<iframe id="goformx-frame-form-1" src="https://api.example.com/embed/frame/form-1" title="Say &#34;hi&#34; &lt;now&gt;"`)
+	assert.Contains(t, byName["iframe"].Code, `event.origin !== "https://api.example.com"`, "resize messages come from the frame's origin")
+
+	assert.Equal(t, `{{< goformx id="form-1" title="Say 'hi' <now>" >}}`, byName["hugo"].Code)
+	require.NotNil(t, byName["hugo"].File)
+	assert.Equal(t, "layouts/shortcodes/goformx.html", byName["hugo"].File.Path)
+	assert.Contains(t, byName["hugo"].File.Content, `src="https://api.example.com/embed/frame/{{ .Get "id" }}"`)
+
+	assert.Equal(t, `{% include goformx.html id="form-1" title="Say 'hi' <now>" %}`, byName["jekyll"].Code)
+
+	assert.Contains(t, byName["script"].Code, `fetch("https://api.example.com/forms/form-1/schema")`)
+	assert.Contains(t, byName["script"].Code, `fetch("https://api.example.com/forms/form-1/submit"`)
+	assert.Contains(t, byName["react"].Code, `const SUBMIT_URL = "https://api.example.com/forms/form-1/submit";`)
+
+	require.NoError(t, form.SetAllowedEmbedMethods([]model.EmbedMethod{model.EmbedMethodScript}))
+
+	for _, snippet := range domainform.EmbedSnippets("https://api.example.com", form) {
+		assert.Equal(t, model.EmbedMethodScript, snippet.Method, "only allowed methods are offered")
+	}
+
+	require.NoError(t, form.SetAllowedEmbedMethods(nil))
+	assert.Empty(t, domainform.EmbedSnippets("https://api.example.com", form))
+}
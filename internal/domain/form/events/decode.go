@@ -0,0 +1,52 @@
+package form
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/goformx/goforms/internal/domain/common/events"
+	"github.com/goformx/goforms/internal/domain/form/model"
+)
+
+// ErrUnknownEventType is returned by Decode for event names it cannot rebuild
+var ErrUnknownEventType = errors.New("unknown form event type")
+
+// Decode rebuilds a form event from its name and the JSON encoding of its
+// payload, as stored by the event log. Fields of a form that are not encoded,
+// such as its access settings, are empty in the rebuilt payload.
+func Decode(name string, payload []byte) (events.Event, error) {
+	var value any
+
+	switch EventType(name) {
+	case FormCreatedEventType, FormUpdatedEventType:
+		value = &model.Form{}
+	case FormSubmittedEventType:
+		value = &model.FormSubmission{}
+	case FormDeletedEventType:
+		value = new(string)
+	case FormPublishedEventType, FormUnpublishedEventType, FormArchivedEventType:
+		value = &Transition{}
+	case FormValidatedEventType, FormErrorEventType:
+		value = &map[string]any{}
+	case FormProcessedEventType, FormStateEventType, FieldEventType, AnalyticsEventType:
+		value = &map[string]string{}
+	default:
+		return nil, fmt.Errorf("%w: %s", ErrUnknownEventType, name)
+	}
+
+	if err := json.Unmarshal(payload, value); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidEventPayload, err)
+	}
+
+	switch v := value.(type) {
+	case *string:
+		return NewEvent(EventType(name), *v), nil
+	case *map[string]any:
+		return NewEvent(EventType(name), *v), nil
+	case *map[string]string:
+		return NewEvent(EventType(name), *v), nil
+	default:
+		return NewEvent(EventType(name), v), nil
+	}
+}
@@ -31,6 +31,12 @@ const (
 	FieldEventType EventType = "form.field"
 	// AnalyticsEventType represents an analytics event
 	AnalyticsEventType EventType = "form.analytics"
+	// FormPublishedEventType represents a form published event
+	FormPublishedEventType EventType = "form.published"
+	// FormUnpublishedEventType represents a form taken back to draft
+	FormUnpublishedEventType EventType = "form.unpublished"
+	// FormArchivedEventType represents a form archived event
+	FormArchivedEventType EventType = "form.archived"
 )
 
 // Event represents a form-related event
@@ -70,6 +76,26 @@ func NewFormDeletedEvent(formID string) *Event {
 	return NewEvent(FormDeletedEventType, formID)
 }
 
+// Transition is the payload of a form lifecycle event
+type Transition struct {
+	Form *model.Form `json:"form"`
+	// From is the status the form left; the form holds the one it entered
+	From string `json:"from"`
+}
+
+// transitionEvents are the event types published for each lifecycle transition
+var transitionEvents = map[model.FormTransition]EventType{
+	model.TransitionPublish:   FormPublishedEventType,
+	model.TransitionUnpublish: FormUnpublishedEventType,
+	model.TransitionArchive:   FormArchivedEventType,
+}
+
+// NewFormTransitionEvent creates the lifecycle event of a transition that
+// moved form out of the from status
+func NewFormTransitionEvent(transition model.FormTransition, form *model.Form, from string) *Event {
+	return NewEvent(transitionEvents[transition], &Transition{Form: form, From: from})
+}
+
 // NewFormSubmittedEvent creates a new form submitted event
 func NewFormSubmittedEvent(submission *model.FormSubmission) *Event {
 	return NewEvent(FormSubmittedEventType, submission)
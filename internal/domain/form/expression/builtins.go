@@ -0,0 +1,267 @@
+package expression
+
+import (
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// maxPatternLength bounds the regular expressions passed to matches
+const maxPatternLength = 256
+
+// call evaluates a built-in function call. Besides the functions of Call
+// (sum, min, max, round, abs, concat, upper, lower, trim, len), expressions
+// and scripts can call:
+//
+//	lookup(dataset, key[, column]) a dataset row's value column, or the named one
+//	has(object, key)        whether object has the key
+//	keys(object)            the object's keys, sorted
+//	append(list, values...) a new list with values added
+//	contains(value, item)   whether a string contains a substring or a list an item
+//	split(string, sep)      a list of the parts of string
+//	join(list, sep)         the items of list joined into a string
+//	replace(string, old, new)
+//	matches(string, pattern) whether string matches the regular expression
+//	number(value)           value as a number, or null when it is not numeric
+//	string(value)           value as a string
+//	typeof(value)           "null", "boolean", "number", "string", "list" or "object"
+//	now()                   the current time as an RFC 3339 string
+//
+// Scripts can also call reject(message), which rejects the submission and
+// stops the script.
+func (m *machine) call(e *callExpr) (any, error) {
+	args := make([]any, 0, len(e.args))
+
+	for _, arg := range e.args {
+		value, err := m.eval(arg)
+		if err != nil {
+			return nil, err
+		}
+
+		args = append(args, value)
+	}
+
+	if e.name == "reject" {
+		if len(args) > 1 {
+			return nil, errorf(e.pos(), "reject expects at most 1 argument")
+		}
+
+		m.rejected = true
+
+		if len(args) == 1 {
+			m.message = ToString(args[0])
+		}
+
+		return nil, errStop
+	}
+
+	result, err := m.builtin(e, args)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = m.alloc(e.pos(), size(result)); err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// arity maps functions to their accepted argument counts; -1 means any
+var arity = map[string][2]int{
+	"lookup":   {2, 3},
+	"has":      {2, 2},
+	"keys":     {1, 1},
+	"append":   {1, -1},
+	"contains": {2, 2},
+	"split":    {2, 2},
+	"join":     {2, 2},
+	"replace":  {3, 3},
+	"matches":  {2, 2},
+	"number":   {1, 1},
+	"string":   {1, 1},
+	"typeof":   {1, 1},
+	"now":      {0, 0},
+}
+
+// callFunctions are the functions evaluated by Call
+var callFunctions = []string{"sum", "min", "max", "round", "abs", "concat", "upper", "lower", "trim", "len"}
+
+// isBuiltin reports whether name is a built-in function. reject is one only in scripts.
+func isBuiltin(name string, inScript bool) bool {
+	_, ok := arity[name]
+
+	return ok || (inScript && name == "reject") || slices.Contains(callFunctions, name)
+}
+
+func (m *machine) builtin(e *callExpr, args []any) (any, error) {
+	bounds, ok := arity[e.name]
+	if !ok {
+		result, err := Call(e.name, args)
+		if err != nil {
+			return nil, errorAt(e.pos(), err)
+		}
+
+		return result, nil
+	}
+
+	if len(args) < bounds[0] || (bounds[1] >= 0 && len(args) > bounds[1]) {
+		return nil, errorf(e.pos(), "%s called with %d arguments", e.name, len(args))
+	}
+
+	switch e.name {
+	case "lookup":
+		return m.lookupRow(e, args)
+	case "has":
+		object, isObject := args[0].(map[string]any)
+		if !isObject {
+			return false, nil
+		}
+
+		_, exists := object[ToString(args[1])]
+
+		return exists, nil
+	case "keys":
+		object, isObject := args[0].(map[string]any)
+		if !isObject {
+			return nil, errorf(e.pos(), "keys needs an object, not %s", typeName(args[0]))
+		}
+
+		keys := sortedKeys(object)
+		list := make([]any, len(keys))
+
+		for i, key := range keys {
+			list[i] = key
+		}
+
+		return list, nil
+	case "append":
+		list, isList := args[0].([]any)
+		if !isList && args[0] != nil {
+			return nil, errorf(e.pos(), "append needs a list, not %s", typeName(args[0]))
+		}
+
+		return slices.Concat(list, args[1:]), nil
+	case "contains":
+		if list, isList := args[0].([]any); isList {
+			return slices.ContainsFunc(list, func(item any) bool { return equal(item, args[1]) }), nil
+		}
+
+		return strings.Contains(ToString(args[0]), ToString(args[1])), nil
+	case "split":
+		parts := strings.Split(ToString(args[0]), ToString(args[1]))
+		list := make([]any, len(parts))
+
+		for i, part := range parts {
+			list[i] = part
+		}
+
+		return list, nil
+	case "join":
+		list, isList := args[0].([]any)
+		if !isList {
+			return nil, errorf(e.pos(), "join needs a list, not %s", typeName(args[0]))
+		}
+
+		parts := make([]string, len(list))
+		for i, item := range list {
+			parts[i] = ToString(item)
+		}
+
+		return strings.Join(parts, ToString(args[1])), nil
+	case "replace":
+		return strings.ReplaceAll(
+			ToString(args[0]), ToString(args[1]), ToString(args[2])), nil
+	case "matches":
+		return m.matches(e, ToString(args[0]), ToString(args[1]))
+	case "number":
+		return toNumber(args[0]), nil
+	case "string":
+		return ToString(args[0]), nil
+	case "typeof":
+		return typeName(args[0]), nil
+	}
+
+	// now
+	return m.now().UTC().Format(time.RFC3339), nil
+}
+
+// matches reports whether s matches pattern. Go regular expressions run in
+// linear time, so a pattern cannot stall the script.
+func (m *machine) matches(e *callExpr, s, pattern string) (any, error) {
+	if len(pattern) > maxPatternLength {
+		return nil, errorf(e.pos(), "pattern is longer than %d characters", maxPatternLength)
+	}
+
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return nil, errorf(e.pos(), "invalid pattern: %v", err)
+	}
+
+	return re.MatchString(s), nil
+}
+
+// lookupRow implements lookup(dataset, key[, column])
+func (m *machine) lookupRow(e *callExpr, args []any) (any, error) {
+	if m.datasets == nil {
+		return nil, errorf(e.pos(), "datasets are not available")
+	}
+
+	column := "value"
+	if len(args) == 3 {
+		column = ToString(args[2])
+	}
+
+	row, found, err := m.datasets(ToString(args[0]), ToString(args[1]))
+	if err != nil {
+		return nil, errorf(e.pos(), "lookup in %s: %w", ToString(args[0]), err)
+	}
+
+	value, ok := row[column]
+	if !found || !ok {
+		return nil, nil
+	}
+
+	return value, nil
+}
+
+// toNumber converts numbers and numeric strings; anything else is null
+func toNumber(value any) any {
+	switch value := value.(type) {
+	case float64:
+		return value
+	case int:
+		return float64(value)
+	case int64:
+		return float64(value)
+	case bool:
+		return ToNumber(value)
+	case string:
+		if number, err := strconv.ParseFloat(strings.TrimSpace(value), 64); err == nil {
+			return number
+		}
+	}
+
+	return nil
+}
+
+// size approximates the bytes a function result occupies
+func size(value any) int {
+	switch value := value.(type) {
+	case string:
+		return len(value)
+	case []any:
+		total := listItemSize * len(value)
+		for _, item := range value {
+			if s, ok := item.(string); ok {
+				total += len(s)
+			}
+		}
+
+		return total
+	}
+
+	return 0
+}
@@ -0,0 +1,668 @@
+package expression
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"slices"
+	"time"
+)
+
+// dataVar names the submission data
+const dataVar = "data"
+
+// checkEvery is how many steps pass between deadline checks
+const checkEvery = 256
+
+// Approximate sizes charged against the allocation budget
+const (
+	listItemSize    = 16
+	objectEntrySize = 64
+)
+
+// errStop unwinds a run after return or reject
+var errStop = errors.New("script stopped")
+
+// scope holds the variables declared in a block
+type scope struct {
+	vars   map[string]any
+	consts map[string]bool
+}
+
+// machine holds the state of one run
+type machine struct {
+	ctx      context.Context
+	limits   Limits
+	deadline time.Time
+	now      func() time.Time
+	// datasets reads dataset rows for lookup(); nil when there are none
+	datasets Lookup
+	// fields resolves identifiers that are not declared, in expressions;
+	// nil in scripts, where they are an error
+	fields    map[string]any
+	steps     int
+	allocated int
+	scopes    []scope
+	rejected  bool
+	message   string
+}
+
+// newMachine prepares a run against data, with the default for each zero limit
+func newMachine(ctx context.Context, limits Limits, data map[string]any, datasets Lookup) *machine {
+	if limits.MaxSteps <= 0 {
+		limits.MaxSteps = DefaultMaxSteps
+	}
+
+	if limits.MaxMemory <= 0 {
+		limits.MaxMemory = DefaultMaxMemory
+	}
+
+	if limits.Timeout <= 0 {
+		limits.Timeout = DefaultTimeout
+	}
+
+	m := &machine{
+		ctx:      ctx,
+		limits:   limits,
+		deadline: time.Now().Add(limits.Timeout),
+		now:      time.Now,
+		datasets: datasets,
+	}
+	m.push()
+	m.scopes[0].vars[dataVar] = data
+	m.scopes[0].consts[dataVar] = true
+
+	return m
+}
+
+func (m *machine) push() {
+	m.scopes = append(m.scopes, scope{vars: map[string]any{}, consts: map[string]bool{}})
+}
+
+func (m *machine) pop() {
+	m.scopes = m.scopes[:len(m.scopes)-1]
+}
+
+// errorf returns a runtime error at node
+func errorf(at position, format string, args ...any) error {
+	return &Error{Line: at.line, Col: at.col, Err: fmt.Errorf("%w: "+format, append([]any{ErrEvaluation}, args...)...)}
+}
+
+// errorAt returns err at node
+func errorAt(at position, err error) error {
+	return &Error{Line: at.line, Col: at.col, Err: err}
+}
+
+// step charges one step and checks the deadline
+func (m *machine) step(at position) error {
+	m.steps++
+	if m.steps > m.limits.MaxSteps {
+		return errorAt(at, ErrStepLimit)
+	}
+
+	if m.steps%checkEvery == 0 {
+		if m.ctx.Err() != nil || m.now().After(m.deadline) {
+			return errorAt(at, ErrTimeout)
+		}
+	}
+
+	return nil
+}
+
+// alloc charges bytes against the allocation budget
+func (m *machine) alloc(at position, bytes int) error {
+	m.allocated += bytes
+	if m.allocated > m.limits.MaxMemory {
+		return errorAt(at, ErrMemoryLimit)
+	}
+
+	return nil
+}
+
+// lookup finds the scope declaring name
+func (m *machine) lookup(name string) (scope, bool) {
+	for i := len(m.scopes) - 1; i >= 0; i-- {
+		if _, ok := m.scopes[i].vars[name]; ok {
+			return m.scopes[i], true
+		}
+	}
+
+	return scope{}, false
+}
+
+// execBlock runs statements, in a new scope when scoped is set
+func (m *machine) execBlock(body []stmt, scoped bool) error {
+	if scoped {
+		m.push()
+		defer m.pop()
+	}
+
+	for _, s := range body {
+		if err := m.exec(s); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (m *machine) exec(s stmt) error {
+	if err := m.step(s.pos()); err != nil {
+		return err
+	}
+
+	switch s := s.(type) {
+	case *declStmt:
+		return m.execDecl(s)
+	case *assignStmt:
+		return m.execAssign(s)
+	case *ifStmt:
+		cond, err := m.eval(s.cond)
+		if err != nil {
+			return err
+		}
+
+		if Truthy(cond) {
+			return m.execBlock(s.then, true)
+		}
+
+		return m.execBlock(s.otherwise, true)
+	case *forStmt:
+		return m.execFor(s)
+	case *blockStmt:
+		return m.execBlock(s.body, true)
+	case *deleteStmt:
+		return m.execDelete(s)
+	case *returnStmt:
+		return errStop
+	case *callStmt:
+		_, err := m.eval(s.call)
+
+		return err
+	}
+
+	return errorf(s.pos(), "unsupported statement")
+}
+
+func (m *machine) execDecl(s *declStmt) error {
+	current := m.scopes[len(m.scopes)-1]
+	if _, ok := current.vars[s.name]; ok {
+		return errorf(s.pos(), "%s is already declared", s.name)
+	}
+
+	value, err := m.eval(s.value)
+	if err != nil {
+		return err
+	}
+
+	current.vars[s.name] = value
+	current.consts[s.name] = s.constant
+
+	return nil
+}
+
+func (m *machine) execAssign(s *assignStmt) error {
+	value, err := m.eval(s.value)
+	if err != nil {
+		return err
+	}
+
+	if s.op != "=" {
+		current, currentErr := m.eval(s.target)
+		if currentErr != nil {
+			return currentErr
+		}
+
+		if value, err = m.arithmetic(s.pos(), s.op[:1], current, value); err != nil {
+			return err
+		}
+	}
+
+	switch target := s.target.(type) {
+	case *identExpr:
+		declared, ok := m.lookup(target.name)
+		if !ok {
+			return errorf(s.pos(), "%s is not declared", target.name)
+		}
+
+		if declared.consts[target.name] {
+			return errorf(s.pos(), "cannot assign to constant %s", target.name)
+		}
+
+		declared.vars[target.name] = value
+
+		return nil
+	case *memberExpr:
+		object, objectErr := m.eval(target.object)
+		if objectErr != nil {
+			return objectErr
+		}
+
+		return m.setKey(target.pos(), object, target.name, value)
+	case *indexExpr:
+		object, objectErr := m.eval(target.object)
+		if objectErr != nil {
+			return objectErr
+		}
+
+		key, keyErr := m.eval(target.key)
+		if keyErr != nil {
+			return keyErr
+		}
+
+		return m.setKey(target.pos(), object, key, value)
+	}
+
+	return errorf(s.pos(), "cannot assign to this expression")
+}
+
+// setKey sets a property of an object or an element of a list
+func (m *machine) setKey(at position, object, key, value any) error {
+	switch object := object.(type) {
+	case map[string]any:
+		name := ToString(key)
+		if _, exists := object[name]; !exists {
+			if err := m.alloc(at, objectEntrySize+len(name)); err != nil {
+				return err
+			}
+		}
+
+		object[name] = value
+
+		return nil
+	case []any:
+		index, ok := listIndex(key, len(object))
+		if !ok {
+			return errorf(at, "list index %s is out of range", ToString(key))
+		}
+
+		object[index] = value
+
+		return nil
+	}
+
+	return errorf(at, "cannot set %s on %s", ToString(key), typeName(object))
+}
+
+func (m *machine) execFor(s *forStmt) error {
+	iterable, err := m.eval(s.iterable)
+	if err != nil {
+		return err
+	}
+
+	var items []any
+
+	switch value := iterable.(type) {
+	case nil:
+	case []any:
+		if s.keys {
+			return errorf(s.pos(), "for...in needs an object; use for...of for lists")
+		}
+
+		items = slices.Clone(value)
+	case map[string]any:
+		if !s.keys {
+			return errorf(s.pos(), "for...of needs a list; use for...in for object keys")
+		}
+
+		for _, key := range sortedKeys(value) {
+			items = append(items, key)
+		}
+	default:
+		return errorf(s.pos(), "cannot iterate over %s", typeName(iterable))
+	}
+
+	for _, item := range items {
+		if err = m.step(s.pos()); err != nil {
+			return err
+		}
+
+		m.push()
+		m.scopes[len(m.scopes)-1].vars[s.name] = item
+		err = m.execBlock(s.body, false)
+		m.pop()
+
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (m *machine) execDelete(s *deleteStmt) error {
+	var (
+		objectExpr expr
+		key        any
+	)
+
+	switch target := s.target.(type) {
+	case *memberExpr:
+		objectExpr, key = target.object, target.name
+	case *indexExpr:
+		objectExpr = target.object
+
+		value, err := m.eval(target.key)
+		if err != nil {
+			return err
+		}
+
+		key = value
+	}
+
+	object, err := m.eval(objectExpr)
+	if err != nil {
+		return err
+	}
+
+	switch object := object.(type) {
+	case nil:
+		return nil
+	case map[string]any:
+		delete(object, ToString(key))
+
+		return nil
+	}
+
+	return errorf(s.pos(), "cannot delete from %s", typeName(object))
+}
+
+func (m *machine) eval(e expr) (any, error) {
+	if err := m.step(e.pos()); err != nil {
+		return nil, err
+	}
+
+	switch e := e.(type) {
+	case *literalExpr:
+		return e.value, nil
+	case *identExpr:
+		declared, ok := m.lookup(e.name)
+		if !ok && m.fields != nil {
+			return m.fields[e.name], nil
+		}
+
+		if !ok {
+			return nil, errorf(e.pos(), "%s is not defined", e.name)
+		}
+
+		return declared.vars[e.name], nil
+	case *memberExpr:
+		object, err := m.eval(e.object)
+		if err != nil {
+			return nil, err
+		}
+
+		return property(object, e.name), nil
+	case *indexExpr:
+		return m.evalIndex(e)
+	case *callExpr:
+		return m.call(e)
+	case *unaryExpr:
+		operand, err := m.eval(e.operand)
+		if err != nil {
+			return nil, err
+		}
+
+		if e.op == "!" {
+			return !Truthy(operand), nil
+		}
+
+		return -ToNumber(operand), nil
+	case *binaryExpr:
+		return m.evalBinary(e)
+	case *ternaryExpr:
+		cond, err := m.eval(e.cond)
+		if err != nil {
+			return nil, err
+		}
+
+		if Truthy(cond) {
+			return m.eval(e.whenTrue)
+		}
+
+		return m.eval(e.whenFalse)
+	case *listExpr:
+		return m.evalList(e)
+	case *objectExpr:
+		return m.evalObject(e)
+	}
+
+	return nil, errorf(e.pos(), "unsupported expression")
+}
+
+func (m *machine) evalIndex(e *indexExpr) (any, error) {
+	object, err := m.eval(e.object)
+	if err != nil {
+		return nil, err
+	}
+
+	key, err := m.eval(e.key)
+	if err != nil {
+		return nil, err
+	}
+
+	if list, ok := object.([]any); ok {
+		if index, inRange := listIndex(key, len(list)); inRange {
+			return list[index], nil
+		}
+
+		return nil, nil
+	}
+
+	return property(object, ToString(key)), nil
+}
+
+func (m *machine) evalBinary(e *binaryExpr) (any, error) {
+	left, err := m.eval(e.left)
+	if err != nil {
+		return nil, err
+	}
+
+	// && and || short-circuit and return an operand, as in JavaScript
+	switch e.op {
+	case "&&":
+		if !Truthy(left) {
+			return left, nil
+		}
+
+		return m.eval(e.right)
+	case "||":
+		if Truthy(left) {
+			return left, nil
+		}
+
+		return m.eval(e.right)
+	}
+
+	right, err := m.eval(e.right)
+	if err != nil {
+		return nil, err
+	}
+
+	switch e.op {
+	case "+", "-", "*", "/", "%":
+		return m.arithmetic(e.pos(), e.op, left, right)
+	}
+
+	return compare(e.op, left, right), nil
+}
+
+// arithmetic applies + - * / %; + concatenates when either side is a string
+func (m *machine) arithmetic(at position, op string, left, right any) (any, error) {
+	_, leftIsString := left.(string)
+	_, rightIsString := right.(string)
+
+	if op == "+" && (leftIsString || rightIsString) {
+		joined := ToString(left) + ToString(right)
+		if err := m.alloc(at, len(joined)); err != nil {
+			return nil, err
+		}
+
+		return joined, nil
+	}
+
+	l, r := ToNumber(left), ToNumber(right)
+
+	switch op {
+	case "+":
+		return l + r, nil
+	case "-":
+		return l - r, nil
+	case "*":
+		return l * r, nil
+	}
+
+	if r == 0 {
+		return nil, errorf(at, "division by zero")
+	}
+
+	if op == "/" {
+		return l / r, nil
+	}
+
+	return math.Mod(l, r), nil
+}
+
+// compare applies a comparison operator. Equality is deep for lists and
+// objects; other values follow Compare.
+func compare(op string, left, right any) bool {
+	switch op {
+	case "==", "===":
+		return equal(left, right)
+	case "!=", "!==":
+		return !equal(left, right)
+	}
+
+	return Compare(op, left, right)
+}
+
+func (m *machine) evalList(e *listExpr) (any, error) {
+	if err := m.alloc(e.pos(), listItemSize*len(e.items)); err != nil {
+		return nil, err
+	}
+
+	list := make([]any, 0, len(e.items))
+
+	for _, item := range e.items {
+		value, err := m.eval(item)
+		if err != nil {
+			return nil, err
+		}
+
+		list = append(list, value)
+	}
+
+	return list, nil
+}
+
+func (m *machine) evalObject(e *objectExpr) (any, error) {
+	object := make(map[string]any, len(e.keys))
+
+	for i, key := range e.keys {
+		if err := m.alloc(e.pos(), objectEntrySize+len(key)); err != nil {
+			return nil, err
+		}
+
+		value, err := m.eval(e.values[i])
+		if err != nil {
+			return nil, err
+		}
+
+		object[key] = value
+	}
+
+	return object, nil
+}
+
+// property reads an object property; missing properties and properties of
+// null read as null. Strings and lists have a length property.
+func property(object any, name string) any {
+	switch object := object.(type) {
+	case map[string]any:
+		return object[name]
+	case string:
+		if name == "length" {
+			return float64(len([]rune(object)))
+		}
+	case []any:
+		if name == "length" {
+			return float64(len(object))
+		}
+	}
+
+	return nil
+}
+
+// listIndex converts a key to an index into a list of length n
+func listIndex(key any, n int) (int, bool) {
+	number, ok := key.(float64)
+	if !ok || number != math.Trunc(number) || number < 0 || number >= float64(n) {
+		return 0, false
+	}
+
+	return int(number), true
+}
+
+// equal compares values, lists and objects element by element
+func equal(left, right any) bool {
+	switch l := left.(type) {
+	case []any:
+		r, ok := right.([]any)
+
+		return ok && slices.EqualFunc(l, r, equal)
+	case map[string]any:
+		r, ok := right.(map[string]any)
+		if !ok || len(l) != len(r) {
+			return false
+		}
+
+		for key, value := range l {
+			other, exists := r[key]
+			if !exists || !equal(value, other) {
+				return false
+			}
+		}
+
+		return true
+	}
+
+	switch right.(type) {
+	case []any, map[string]any:
+		return false
+	}
+
+	return Compare("==", left, right)
+}
+
+// typeName names a value's type in error messages, as typeof would
+func typeName(value any) string {
+	switch value.(type) {
+	case nil:
+		return "null"
+	case bool:
+		return "boolean"
+	case string:
+		return "string"
+	case float64, int, int64:
+		return "number"
+	case []any:
+		return "list"
+	case map[string]any:
+		return "object"
+	}
+
+	return fmt.Sprintf("%T", value)
+}
+
+// sortedKeys returns an object's keys in sorted order
+func sortedKeys(object map[string]any) []string {
+	keys := make([]string, 0, len(object))
+	for key := range object {
+		keys = append(keys, key)
+	}
+
+	slices.Sort(keys)
+
+	return keys
+}
@@ -0,0 +1,131 @@
+// Package expression evaluates the JavaScript subset used on the server for
+// computed submission fields and submission scripts. Both share one lexer,
+// parser and evaluator.
+//
+// An expression, as in a computed field's calculateValue, is a single
+// side-effect free expression: number, string and boolean literals, field
+// references (data.total or total), arithmetic (+ - * / %), comparison
+// (== != < <= > >=), logical operators (&& || !), the ternary operator, member
+// access, indexing, list and object literals and the built-in functions. A
+// field that does not exist reads as null.
+//
+// A script is a small program run against the submitted data. It can
+// transform the data, set computed fields and reject the submission:
+//
+//	let total = sum(data.prices)
+//	data.total = round(total * 1.2, 2)
+//	if (data.email == "" && data.phone == "") {
+//		reject("Give an email address or a phone number")
+//	}
+//	for (const key in data) {
+//		if (matches(key, "^_")) { delete data[key] }
+//	}
+//
+// Scripts add let/const/var, assignment (= += -=), if/else, for...of over
+// lists, for...in over object keys, delete, return and reject. Variables must
+// be declared; the submission is the variable data. There are no user-defined
+// functions, no while loops and no host access beyond reading dataset rows
+// through the Lookup the caller supplies.
+//
+// Every run is bounded by Limits: a step budget for CPU, an allocation budget
+// for the strings, lists and objects it creates, and a wall-clock timeout.
+package expression
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// MaxLength is the maximum accepted length of an expression source
+const MaxLength = 1024
+
+var (
+	// ErrSyntax is returned when an expression or script cannot be parsed
+	ErrSyntax = errors.New("syntax error")
+
+	// ErrEvaluation is returned when an expression or script fails while running
+	ErrEvaluation = errors.New("evaluation error")
+
+	// ErrStepLimit is returned when a run exceeds its step budget
+	ErrStepLimit = errors.New("exceeded the step limit")
+
+	// ErrMemoryLimit is returned when a run exceeds its allocation budget
+	ErrMemoryLimit = errors.New("exceeded the memory limit")
+
+	// ErrTimeout is returned when a run goes past its timeout
+	ErrTimeout = errors.New("timed out")
+)
+
+// Error is a syntax or evaluation error with the position it occurred at
+type Error struct {
+	Line int
+	Col  int
+	Err  error
+}
+
+// Error implements the error interface
+func (e *Error) Error() string {
+	return fmt.Sprintf("line %d, column %d: %v", e.Line, e.Col, e.Err)
+}
+
+// Unwrap returns the underlying error
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+// Lookup returns the row of the dataset called name whose key is key, and
+// false when there is none
+type Lookup func(name, key string) (map[string]string, bool, error)
+
+// Evaluate parses and evaluates source against the given submission data.
+// A leading "value =" assignment, as written in Form.io calculateValue, is accepted.
+func Evaluate(source string, data map[string]any) (any, error) {
+	return EvaluateWithLookup(source, data, nil)
+}
+
+// EvaluateWithLookup evaluates source like Evaluate, with the lookup()
+// function reading dataset rows through lookup. lookup(dataset, key) returns
+// the value column of a key/value dataset, lookup(dataset, key, column) the
+// named column; both are null when the dataset has no row for key.
+func EvaluateWithLookup(source string, data map[string]any, lookup Lookup) (any, error) {
+	source = strings.TrimSpace(source)
+	source = strings.TrimSuffix(source, ";")
+
+	if strings.HasPrefix(source, "value") {
+		rest := strings.TrimSpace(strings.TrimPrefix(source, "value"))
+		if strings.HasPrefix(rest, "=") && !strings.HasPrefix(rest, "==") {
+			source = strings.TrimSpace(strings.TrimPrefix(rest, "="))
+		}
+	}
+
+	if len(source) > MaxLength {
+		return nil, fmt.Errorf("%w: expression exceeds %d characters", ErrSyntax, MaxLength)
+	}
+
+	tokens, err := tokenize(source)
+	if err != nil {
+		return nil, err
+	}
+
+	p := &parser{tokens: tokens, expression: true}
+
+	e, err := p.parseExpression()
+	if err != nil {
+		return nil, err
+	}
+
+	if p.peek().kind != tokenEOF {
+		return nil, p.unexpected("expected the end of the expression")
+	}
+
+	if data == nil {
+		data = map[string]any{}
+	}
+
+	m := newMachine(context.Background(), Limits{}, data, lookup)
+	m.fields = data
+
+	return m.eval(e)
+}
@@ -0,0 +1,93 @@
+package expression_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/goformx/goforms/internal/domain/form/expression"
+)
+
+func TestEvaluate(t *testing.T) {
+	data := map[string]any{
+		"a":     2.0,
+		"b":     "3",
+		"name":  "Ada",
+		"items": []any{1.0, 2.0, 3.5},
+		"address": map[string]any{
+			"city": "Paris",
+		},
+	}
+
+	tests := []struct {
+		name   string
+		source string
+		want   any
+	}{
+		{name: "arithmetic precedence", source: "1 + 2 * 3", want: 7.0},
+		{name: "numeric strings", source: "data.a * data.b", want: 6.0},
+		{name: "concatenation", source: "name + ' from ' + address.city", want: "Ada from Paris"},
+		{name: "assignment prefix", source: "value = data.a + 1;", want: 3.0},
+		{name: "ternary", source: "a > 1 ? 'big' : 'small'", want: "big"},
+		{name: "logical", source: "!(a == 2) || missing == null", want: true},
+		{name: "sum of list", source: "sum(items)", want: 6.5},
+		{name: "round", source: "round(10 / 3, 2)", want: 3.33},
+		{name: "missing field", source: "missing", want: nil},
+		{name: "index", source: "items[1] + address['city'].length", want: 7.0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := expression.Evaluate(tt.source, data)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestEvaluate_Errors(t *testing.T) {
+	for _, source := range []string{"1 +", "alert('x'", "a = 1", "unknown(1)", "1 / 0", "'open", "reject('x')", "let a = 1"} {
+		t.Run(source, func(t *testing.T) {
+			_, err := expression.Evaluate(source, map[string]any{"a": 1.0})
+			require.Error(t, err)
+		})
+	}
+}
+
+func TestEvaluateWithLookup(t *testing.T) {
+	rows := map[string]map[string]string{
+		"SPRING": {"key": "SPRING", "value": "10"},
+		"A1":     {"sku": "A1", "price": "4.5"},
+	}
+	lookup := func(name, key string) (map[string]string, bool, error) {
+		if name == "broken" {
+			return nil, false, errors.New("unavailable")
+		}
+
+		row, ok := rows[key]
+
+		return row, ok, nil
+	}
+
+	data := map[string]any{"coupon": "SPRING", "product": "A1", "quantity": 2.0}
+
+	got, err := expression.EvaluateWithLookup("100 - lookup('coupons', coupon)", data, lookup)
+	require.NoError(t, err)
+	assert.InDelta(t, 90.0, got, 0.0001)
+
+	got, err = expression.EvaluateWithLookup("lookup('products', product, 'price') * quantity", data, lookup)
+	require.NoError(t, err)
+	assert.InDelta(t, 9.0, got, 0.0001)
+
+	got, err = expression.EvaluateWithLookup("lookup('coupons', 'NONE') == null", data, lookup)
+	require.NoError(t, err)
+	assert.Equal(t, true, got)
+
+	_, err = expression.EvaluateWithLookup("lookup('broken', coupon)", data, lookup)
+	require.ErrorIs(t, err, expression.ErrEvaluation)
+
+	_, err = expression.Evaluate("lookup('coupons', coupon)", data)
+	require.ErrorIs(t, err, expression.ErrEvaluation, "lookup needs datasets")
+}
@@ -0,0 +1,217 @@
+package expression
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// Call invokes one of the built-in pure functions
+func Call(name string, args []any) (any, error) {
+	switch name {
+	case "sum":
+		total := 0.0
+		for _, arg := range flatten(args) {
+			total += ToNumber(arg)
+		}
+
+		return total, nil
+	case "min", "max":
+		return extreme(name, flatten(args))
+	case "round":
+		return round(args)
+	case "abs":
+		if len(args) != 1 {
+			return nil, fmt.Errorf("%w: abs expects 1 argument", ErrEvaluation)
+		}
+
+		return math.Abs(ToNumber(args[0])), nil
+	case "concat":
+		var b strings.Builder
+		for _, arg := range flatten(args) {
+			b.WriteString(ToString(arg))
+		}
+
+		return b.String(), nil
+	case "upper", "lower", "trim", "len":
+		return stringFunction(name, args)
+	}
+
+	return nil, fmt.Errorf("%w: unknown function %q", ErrEvaluation, name)
+}
+
+// extreme returns the smallest or largest numeric argument
+func extreme(name string, args []any) (any, error) {
+	if len(args) == 0 {
+		return nil, fmt.Errorf("%w: %s expects at least 1 argument", ErrEvaluation, name)
+	}
+
+	result := ToNumber(args[0])
+
+	for _, arg := range args[1:] {
+		value := ToNumber(arg)
+		if (name == "min" && value < result) || (name == "max" && value > result) {
+			result = value
+		}
+	}
+
+	return result, nil
+}
+
+// round rounds a number to an optional number of decimal places
+func round(args []any) (any, error) {
+	if len(args) == 0 || len(args) > 2 {
+		return nil, fmt.Errorf("%w: round expects 1 or 2 arguments", ErrEvaluation)
+	}
+
+	places := 0.0
+	if len(args) == 2 {
+		places = ToNumber(args[1])
+	}
+
+	factor := math.Pow(10, places)
+
+	return math.Round(ToNumber(args[0])*factor) / factor, nil
+}
+
+// stringFunction evaluates the single-argument string helpers
+func stringFunction(name string, args []any) (any, error) {
+	if len(args) != 1 {
+		return nil, fmt.Errorf("%w: %s expects 1 argument", ErrEvaluation, name)
+	}
+
+	if list, ok := args[0].([]any); ok && name == "len" {
+		return float64(len(list)), nil
+	}
+
+	value := ToString(args[0])
+
+	switch name {
+	case "upper":
+		return strings.ToUpper(value), nil
+	case "lower":
+		return strings.ToLower(value), nil
+	case "trim":
+		return strings.TrimSpace(value), nil
+	}
+
+	return float64(len([]rune(value))), nil
+}
+
+// flatten expands list arguments so sum(data.items) and sum(a, b) both work
+func flatten(args []any) []any {
+	var out []any
+
+	for _, arg := range args {
+		if list, ok := arg.([]any); ok {
+			out = append(out, list...)
+
+			continue
+		}
+
+		out = append(out, arg)
+	}
+
+	return out
+}
+
+// Compare applies a comparison operator, comparing numerically when both
+// sides are numeric and as strings otherwise
+func Compare(op string, left, right any) bool {
+	l, lErr := numeric(left)
+	r, rErr := numeric(right)
+
+	var cmp int
+
+	if lErr && rErr {
+		switch {
+		case l < r:
+			cmp = -1
+		case l > r:
+			cmp = 1
+		}
+	} else {
+		cmp = strings.Compare(ToString(left), ToString(right))
+	}
+
+	switch op {
+	case "==", "===":
+		return cmp == 0
+	case "!=", "!==":
+		return cmp != 0
+	case "<":
+		return cmp < 0
+	case "<=":
+		return cmp <= 0
+	case ">":
+		return cmp > 0
+	}
+
+	return cmp >= 0
+}
+
+// numeric reports whether a value is a number or a numeric string
+func numeric(value any) (float64, bool) {
+	switch val := value.(type) {
+	case float64:
+		return val, true
+	case int:
+		return float64(val), true
+	case int64:
+		return float64(val), true
+	case string:
+		f, err := strconv.ParseFloat(strings.TrimSpace(val), 64)
+
+		return f, err == nil
+	}
+
+	return 0, false
+}
+
+// Truthy follows JavaScript truthiness for the supported value types
+func Truthy(value any) bool {
+	switch val := value.(type) {
+	case nil:
+		return false
+	case bool:
+		return val
+	case string:
+		return val != ""
+	case float64:
+		return val != 0 && !math.IsNaN(val)
+	}
+
+	return true
+}
+
+// ToNumber coerces a value to float64; non-numeric values become 0
+func ToNumber(value any) float64 {
+	if b, ok := value.(bool); ok {
+		if b {
+			return 1
+		}
+
+		return 0
+	}
+
+	n, _ := numeric(value)
+
+	return n
+}
+
+// ToString renders a value the way it would appear in a concatenation
+func ToString(value any) string {
+	switch val := value.(type) {
+	case nil:
+		return ""
+	case string:
+		return val
+	case float64:
+		return strconv.FormatFloat(val, 'f', -1, 64)
+	case bool:
+		return strconv.FormatBool(val)
+	}
+
+	return fmt.Sprint(value)
+}
@@ -0,0 +1,203 @@
+package expression
+
+import (
+	"fmt"
+	"strings"
+)
+
+type tokenKind int
+
+const (
+	tokenEOF tokenKind = iota
+	tokenNumber
+	tokenString
+	tokenIdent
+	tokenPunct
+)
+
+type token struct {
+	kind tokenKind
+	text string
+	line int
+	col  int
+}
+
+// punctuation lists multi-character operators before their prefixes
+var punctuation = []string{
+	"===", "!==", "==", "!=", "<=", ">=", "&&", "||", "+=", "-=",
+	"+", "-", "*", "/", "%", "<", ">", "!", "?", ":", "(", ")", ",", ".", "[", "]", "{", "}", ";", "=",
+}
+
+// lexer tracks the position within the source
+type lexer struct {
+	source string
+	pos    int
+	line   int
+	col    int
+}
+
+// tokenize splits an expression or script into tokens, dropping whitespace and comments
+func tokenize(source string) ([]token, error) {
+	l := &lexer{source: source, line: 1, col: 1}
+
+	var tokens []token
+
+	for {
+		if err := l.skipSpace(); err != nil {
+			return nil, err
+		}
+
+		if l.pos >= len(l.source) {
+			return append(tokens, token{kind: tokenEOF, line: l.line, col: l.col}), nil
+		}
+
+		tok, err := l.next()
+		if err != nil {
+			return nil, err
+		}
+
+		tokens = append(tokens, tok)
+	}
+}
+
+// advance moves past n bytes, tracking lines and columns
+func (l *lexer) advance(n int) {
+	for range n {
+		if l.source[l.pos] == '\n' {
+			l.line++
+			l.col = 1
+		} else {
+			l.col++
+		}
+
+		l.pos++
+	}
+}
+
+func (l *lexer) errorf(format string, args ...any) error {
+	return &Error{Line: l.line, Col: l.col, Err: fmt.Errorf("%w: "+format, append([]any{ErrSyntax}, args...)...)}
+}
+
+// skipSpace skips whitespace and // and /* */ comments
+func (l *lexer) skipSpace() error {
+	for l.pos < len(l.source) {
+		rest := l.source[l.pos:]
+
+		switch {
+		case rest[0] == ' ' || rest[0] == '\t' || rest[0] == '\n' || rest[0] == '\r':
+			l.advance(1)
+		case strings.HasPrefix(rest, "//"):
+			end := strings.IndexByte(rest, '\n')
+			if end < 0 {
+				end = len(rest)
+			}
+
+			l.advance(end)
+		case strings.HasPrefix(rest, "/*"):
+			end := strings.Index(rest[2:], "*/")
+			if end < 0 {
+				return l.errorf("unterminated comment")
+			}
+
+			l.advance(end + 4)
+		default:
+			return nil
+		}
+	}
+
+	return nil
+}
+
+// next reads one token
+func (l *lexer) next() (token, error) {
+	rest := l.source[l.pos:]
+	tok := token{line: l.line, col: l.col}
+	ch := rest[0]
+
+	switch {
+	case isDigit(ch) || (ch == '.' && len(rest) > 1 && isDigit(rest[1])):
+		n := 0
+		for n < len(rest) && (isDigit(rest[n]) || rest[n] == '.') {
+			n++
+		}
+
+		tok.kind, tok.text = tokenNumber, rest[:n]
+		l.advance(n)
+	case ch == '"' || ch == '\'':
+		text, n, err := l.readString(rest)
+		if err != nil {
+			return token{}, err
+		}
+
+		tok.kind, tok.text = tokenString, text
+		l.advance(n)
+	case isIdentStart(ch):
+		n := 0
+		for n < len(rest) && (isIdentStart(rest[n]) || isDigit(rest[n])) {
+			n++
+		}
+
+		tok.kind, tok.text = tokenIdent, rest[:n]
+		l.advance(n)
+	default:
+		for _, punct := range punctuation {
+			if strings.HasPrefix(rest, punct) {
+				tok.kind, tok.text = tokenPunct, punct
+				l.advance(len(punct))
+
+				return tok, nil
+			}
+		}
+
+		return token{}, l.errorf("unexpected character %q", ch)
+	}
+
+	return tok, nil
+}
+
+// readString reads a quoted string with \n \t \r \\ \' and \" escapes. It
+// returns the string and the number of source bytes consumed.
+func (l *lexer) readString(rest string) (string, int, error) {
+	quote := rest[0]
+
+	var b strings.Builder
+
+	for i := 1; i < len(rest); i++ {
+		switch ch := rest[i]; ch {
+		case quote:
+			return b.String(), i + 1, nil
+		case '\n':
+			return "", 0, l.errorf("unterminated string")
+		case '\\':
+			i++
+			if i >= len(rest) {
+				return "", 0, l.errorf("unterminated string")
+			}
+
+			switch rest[i] {
+			case 'n':
+				b.WriteByte('\n')
+			case 't':
+				b.WriteByte('\t')
+			case 'r':
+				b.WriteByte('\r')
+			case '\\', '\'', '"':
+				b.WriteByte(rest[i])
+			default:
+				return "", 0, l.errorf("unknown escape \\%c", rest[i])
+			}
+		default:
+			b.WriteByte(ch)
+		}
+	}
+
+	return "", 0, l.errorf("unterminated string")
+}
+
+func isDigit(ch byte) bool {
+	return ch >= '0' && ch <= '9'
+}
+
+func isIdentStart(ch byte) bool {
+	return ch == '_' || ch == '$' || (ch >= 'a' && ch <= 'z') || (ch >= 'A' && ch <= 'Z')
+}
@@ -0,0 +1,772 @@
+package expression
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+)
+
+// position is where a node starts in the source
+type position struct {
+	line int
+	col  int
+}
+
+// Statements
+type (
+	stmt interface{ pos() position }
+
+	// declStmt is let, const or var
+	declStmt struct {
+		position
+		name     string
+		constant bool
+		value    expr
+	}
+
+	// assignStmt is target = value, target += value or target -= value
+	assignStmt struct {
+		position
+		target expr
+		op     string
+		value  expr
+	}
+
+	ifStmt struct {
+		position
+		cond      expr
+		then      []stmt
+		otherwise []stmt
+	}
+
+	// forStmt is for (const name of list) or for (const name in object)
+	forStmt struct {
+		position
+		name     string
+		keys     bool
+		iterable expr
+		body     []stmt
+	}
+
+	blockStmt struct {
+		position
+		body []stmt
+	}
+
+	deleteStmt struct {
+		position
+		target expr
+	}
+
+	returnStmt struct{ position }
+
+	// callStmt is a built-in function call whose result is discarded
+	callStmt struct {
+		position
+		call *callExpr
+	}
+)
+
+// Expressions
+type (
+	expr interface{ pos() position }
+
+	literalExpr struct {
+		position
+		value any
+	}
+
+	identExpr struct {
+		position
+		name string
+	}
+
+	memberExpr struct {
+		position
+		object expr
+		name   string
+	}
+
+	indexExpr struct {
+		position
+		object expr
+		key    expr
+	}
+
+	callExpr struct {
+		position
+		name string
+		args []expr
+	}
+
+	unaryExpr struct {
+		position
+		op      string
+		operand expr
+	}
+
+	binaryExpr struct {
+		position
+		op    string
+		left  expr
+		right expr
+	}
+
+	ternaryExpr struct {
+		position
+		cond      expr
+		whenTrue  expr
+		whenFalse expr
+	}
+
+	listExpr struct {
+		position
+		items []expr
+	}
+
+	objectExpr struct {
+		position
+		keys   []string
+		values []expr
+	}
+)
+
+func (p position) pos() position {
+	return p
+}
+
+// parser is a recursive descent parser producing statements and expressions
+type parser struct {
+	tokens []token
+	index  int
+	depth  int
+	// expression is set when parsing a lone expression rather than a script
+	expression bool
+}
+
+func (p *parser) peek() token {
+	return p.tokens[p.index]
+}
+
+func (p *parser) next() token {
+	tok := p.tokens[p.index]
+	if tok.kind != tokenEOF {
+		p.index++
+	}
+
+	return tok
+}
+
+func (p *parser) errorAt(tok token, format string, args ...any) error {
+	return &Error{Line: tok.line, Col: tok.col, Err: fmt.Errorf("%w: "+format, append([]any{ErrSyntax}, args...)...)}
+}
+
+// is reports whether the next token is the given punctuation or keyword
+func (p *parser) is(text string) bool {
+	tok := p.peek()
+
+	return (tok.kind == tokenPunct || tok.kind == tokenIdent) && tok.text == text
+}
+
+func (p *parser) accept(text string) bool {
+	if p.is(text) {
+		p.index++
+
+		return true
+	}
+
+	return false
+}
+
+func (p *parser) expect(text string) error {
+	if !p.accept(text) {
+		return p.unexpected(fmt.Sprintf("expected %q", text))
+	}
+
+	return nil
+}
+
+func (p *parser) unexpected(want string) error {
+	tok := p.peek()
+	if tok.kind == tokenEOF {
+		return p.errorAt(tok, "unexpected end of input, %s", want)
+	}
+
+	return p.errorAt(tok, "unexpected %q, %s", tok.text, want)
+}
+
+// enter guards against deeply nested input
+func (p *parser) enter() error {
+	p.depth++
+	if p.depth > maxDepth {
+		return p.errorAt(p.peek(), "nesting is deeper than %d levels", maxDepth)
+	}
+
+	return nil
+}
+
+func (p *parser) leave() {
+	p.depth--
+}
+
+func (p *parser) parseProgram() ([]stmt, error) {
+	var body []stmt
+
+	for p.peek().kind != tokenEOF {
+		if p.accept(";") {
+			continue
+		}
+
+		s, err := p.parseStatement()
+		if err != nil {
+			return nil, err
+		}
+
+		body = append(body, s)
+	}
+
+	return body, nil
+}
+
+func (p *parser) parseBlock() ([]stmt, error) {
+	if err := p.expect("{"); err != nil {
+		return nil, err
+	}
+
+	var body []stmt
+
+	for !p.accept("}") {
+		if p.peek().kind == tokenEOF {
+			return nil, p.unexpected(`expected "}"`)
+		}
+
+		if p.accept(";") {
+			continue
+		}
+
+		s, err := p.parseStatement()
+		if err != nil {
+			return nil, err
+		}
+
+		body = append(body, s)
+	}
+
+	return body, nil
+}
+
+// parseBody parses a block or a single statement, as after if, else and for
+func (p *parser) parseBody() ([]stmt, error) {
+	if p.is("{") {
+		return p.parseBlock()
+	}
+
+	if p.accept(";") {
+		return nil, nil
+	}
+
+	s, err := p.parseStatement()
+	if err != nil {
+		return nil, err
+	}
+
+	return []stmt{s}, nil
+}
+
+// parseStatement parses one statement
+func (p *parser) parseStatement() (stmt, error) {
+	if err := p.enter(); err != nil {
+		return nil, err
+	}
+	defer p.leave()
+
+	tok := p.peek()
+	at := position{line: tok.line, col: tok.col}
+
+	if tok.kind == tokenPunct && tok.text == "{" {
+		body, err := p.parseBlock()
+		if err != nil {
+			return nil, err
+		}
+
+		return &blockStmt{position: at, body: body}, nil
+	}
+
+	if tok.kind == tokenIdent {
+		switch tok.text {
+		case "let", "const", "var":
+			return p.parseDeclaration()
+		case "if":
+			return p.parseIf()
+		case "for":
+			return p.parseFor()
+		case "delete":
+			p.next()
+
+			target, err := p.parseTarget()
+			if err != nil {
+				return nil, err
+			}
+
+			p.accept(";")
+
+			return &deleteStmt{position: at, target: target}, nil
+		case "return":
+			p.next()
+			p.accept(";")
+
+			return &returnStmt{position: at}, nil
+		}
+	}
+
+	return p.parseSimpleStatement(at)
+}
+
+func (p *parser) parseDeclaration() (stmt, error) {
+	keyword := p.next()
+	at := position{line: keyword.line, col: keyword.col}
+
+	name := p.next()
+	if name.kind != tokenIdent || isKeyword(name.text) {
+		return nil, p.errorAt(name, "expected a variable name")
+	}
+
+	if err := p.expect("="); err != nil {
+		return nil, err
+	}
+
+	value, err := p.parseExpression()
+	if err != nil {
+		return nil, err
+	}
+
+	p.accept(";")
+
+	return &declStmt{position: at, name: name.text, constant: keyword.text == "const", value: value}, nil
+}
+
+func (p *parser) parseIf() (stmt, error) {
+	keyword := p.next()
+	s := &ifStmt{position: position{line: keyword.line, col: keyword.col}}
+
+	if err := p.expect("("); err != nil {
+		return nil, err
+	}
+
+	cond, err := p.parseExpression()
+	if err != nil {
+		return nil, err
+	}
+
+	if err = p.expect(")"); err != nil {
+		return nil, err
+	}
+
+	s.cond = cond
+
+	if s.then, err = p.parseBody(); err != nil {
+		return nil, err
+	}
+
+	if p.accept("else") {
+		if s.otherwise, err = p.parseBody(); err != nil {
+			return nil, err
+		}
+	}
+
+	return s, nil
+}
+
+func (p *parser) parseFor() (stmt, error) {
+	keyword := p.next()
+	s := &forStmt{position: position{line: keyword.line, col: keyword.col}}
+
+	if err := p.expect("("); err != nil {
+		return nil, err
+	}
+
+	if !p.accept("const") && !p.accept("let") && !p.accept("var") {
+		return nil, p.unexpected("expected const, let or var")
+	}
+
+	name := p.next()
+	if name.kind != tokenIdent || isKeyword(name.text) {
+		return nil, p.errorAt(name, "expected a variable name")
+	}
+
+	s.name = name.text
+
+	switch {
+	case p.accept("of"):
+	case p.accept("in"):
+		s.keys = true
+	default:
+		return nil, p.unexpected(`expected "of" or "in"`)
+	}
+
+	iterable, err := p.parseExpression()
+	if err != nil {
+		return nil, err
+	}
+
+	if err = p.expect(")"); err != nil {
+		return nil, err
+	}
+
+	s.iterable = iterable
+
+	if s.body, err = p.parseBody(); err != nil {
+		return nil, err
+	}
+
+	return s, nil
+}
+
+// parseSimpleStatement parses an assignment or a function call
+func (p *parser) parseSimpleStatement(at position) (stmt, error) {
+	start := p.peek()
+
+	left, err := p.parseExpression()
+	if err != nil {
+		return nil, err
+	}
+
+	for _, op := range []string{"=", "+=", "-="} {
+		if !p.accept(op) {
+			continue
+		}
+
+		if !isTarget(left) {
+			return nil, p.errorAt(start, "cannot assign to this expression")
+		}
+
+		value, valueErr := p.parseExpression()
+		if valueErr != nil {
+			return nil, valueErr
+		}
+
+		p.accept(";")
+
+		return &assignStmt{position: at, target: left, op: op, value: value}, nil
+	}
+
+	call, ok := left.(*callExpr)
+	if !ok {
+		return nil, p.errorAt(start, "expected an assignment or a function call")
+	}
+
+	p.accept(";")
+
+	return &callStmt{position: at, call: call}, nil
+}
+
+// parseTarget parses the operand of delete
+func (p *parser) parseTarget() (expr, error) {
+	start := p.peek()
+
+	target, err := p.parsePostfix()
+	if err != nil {
+		return nil, err
+	}
+
+	if _, ok := target.(*identExpr); ok || !isTarget(target) {
+		return nil, p.errorAt(start, "delete needs a property, such as data.name or data[key]")
+	}
+
+	return target, nil
+}
+
+// isTarget reports whether e can be assigned to
+func isTarget(e expr) bool {
+	switch e.(type) {
+	case *identExpr, *memberExpr, *indexExpr:
+		return true
+	}
+
+	return false
+}
+
+func (p *parser) parseExpression() (expr, error) {
+	if err := p.enter(); err != nil {
+		return nil, err
+	}
+	defer p.leave()
+
+	return p.parseTernary()
+}
+
+func (p *parser) parseTernary() (expr, error) {
+	cond, err := p.parseBinary(0)
+	if err != nil {
+		return nil, err
+	}
+
+	tok := p.peek()
+	if !p.accept("?") {
+		return cond, nil
+	}
+
+	whenTrue, err := p.parseExpression()
+	if err != nil {
+		return nil, err
+	}
+
+	if err = p.expect(":"); err != nil {
+		return nil, err
+	}
+
+	whenFalse, err := p.parseExpression()
+	if err != nil {
+		return nil, err
+	}
+
+	return &ternaryExpr{
+		position: position{line: tok.line, col: tok.col},
+		cond:     cond, whenTrue: whenTrue, whenFalse: whenFalse,
+	}, nil
+}
+
+// binaryLevels lists binary operators from lowest to highest precedence
+var binaryLevels = [][]string{
+	{"||"},
+	{"&&"},
+	{"===", "!==", "==", "!=", "<=", ">=", "<", ">"},
+	{"+", "-"},
+	{"*", "/", "%"},
+}
+
+func (p *parser) parseBinary(level int) (expr, error) {
+	if level == len(binaryLevels) {
+		return p.parseUnary()
+	}
+
+	left, err := p.parseBinary(level + 1)
+	if err != nil {
+		return nil, err
+	}
+
+	for {
+		tok := p.peek()
+		if tok.kind != tokenPunct || !slices.Contains(binaryLevels[level], tok.text) {
+			return left, nil
+		}
+
+		p.next()
+
+		right, rightErr := p.parseBinary(level + 1)
+		if rightErr != nil {
+			return nil, rightErr
+		}
+
+		left = &binaryExpr{position: position{line: tok.line, col: tok.col}, op: tok.text, left: left, right: right}
+	}
+}
+
+func (p *parser) parseUnary() (expr, error) {
+	tok := p.peek()
+	if tok.kind == tokenPunct && (tok.text == "-" || tok.text == "!") {
+		p.next()
+
+		if err := p.enter(); err != nil {
+			return nil, err
+		}
+		defer p.leave()
+
+		operand, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+
+		return &unaryExpr{position: position{line: tok.line, col: tok.col}, op: tok.text, operand: operand}, nil
+	}
+
+	return p.parsePostfix()
+}
+
+func (p *parser) parsePostfix() (expr, error) {
+	e, err := p.parsePrimary()
+	if err != nil {
+		return nil, err
+	}
+
+	for {
+		tok := p.peek()
+		at := position{line: tok.line, col: tok.col}
+
+		switch {
+		case p.accept("."):
+			name := p.next()
+			if name.kind != tokenIdent {
+				return nil, p.errorAt(name, "expected a property name")
+			}
+
+			e = &memberExpr{position: at, object: e, name: name.text}
+		case p.accept("["):
+			key, keyErr := p.parseExpression()
+			if keyErr != nil {
+				return nil, keyErr
+			}
+
+			if keyErr = p.expect("]"); keyErr != nil {
+				return nil, keyErr
+			}
+
+			e = &indexExpr{position: at, object: e, key: key}
+		case p.is("("):
+			return nil, p.errorAt(tok, "only built-in functions can be called")
+		default:
+			return e, nil
+		}
+	}
+}
+
+func (p *parser) parsePrimary() (expr, error) {
+	tok := p.next()
+	at := position{line: tok.line, col: tok.col}
+
+	switch tok.kind {
+	case tokenNumber:
+		value, err := strconv.ParseFloat(tok.text, 64)
+		if err != nil {
+			return nil, p.errorAt(tok, "invalid number %q", tok.text)
+		}
+
+		return &literalExpr{position: at, value: value}, nil
+	case tokenString:
+		return &literalExpr{position: at, value: tok.text}, nil
+	case tokenIdent:
+		return p.parseIdentifier(tok, at)
+	case tokenPunct:
+		switch tok.text {
+		case "(":
+			e, err := p.parseExpression()
+			if err != nil {
+				return nil, err
+			}
+
+			return e, p.expect(")")
+		case "[":
+			return p.parseList(at)
+		case "{":
+			return p.parseObject(at)
+		}
+	case tokenEOF:
+		return nil, p.errorAt(tok, "unexpected end of input")
+	}
+
+	return nil, p.errorAt(tok, "unexpected %q", tok.text)
+}
+
+func (p *parser) parseIdentifier(tok token, at position) (expr, error) {
+	switch tok.text {
+	case "true":
+		return &literalExpr{position: at, value: true}, nil
+	case "false":
+		return &literalExpr{position: at, value: false}, nil
+	case "null", "undefined":
+		return &literalExpr{position: at, value: nil}, nil
+	}
+
+	// Statement keywords are field names in an expression
+	if isKeyword(tok.text) && !p.expression {
+		return nil, p.errorAt(tok, "unexpected %q", tok.text)
+	}
+
+	if !p.accept("(") {
+		return &identExpr{position: at, name: tok.text}, nil
+	}
+
+	if !isBuiltin(tok.text, !p.expression) {
+		return nil, p.errorAt(tok, "unknown function %q", tok.text)
+	}
+
+	call := &callExpr{position: at, name: tok.text}
+
+	for !p.accept(")") {
+		if len(call.args) > 0 {
+			if err := p.expect(","); err != nil {
+				return nil, err
+			}
+		}
+
+		arg, err := p.parseExpression()
+		if err != nil {
+			return nil, err
+		}
+
+		call.args = append(call.args, arg)
+	}
+
+	return call, nil
+}
+
+func (p *parser) parseList(at position) (expr, error) {
+	list := &listExpr{position: at}
+
+	for !p.accept("]") {
+		if len(list.items) > 0 {
+			if err := p.expect(","); err != nil {
+				return nil, err
+			}
+
+			// Allow a trailing comma
+			if p.accept("]") {
+				break
+			}
+		}
+
+		item, err := p.parseExpression()
+		if err != nil {
+			return nil, err
+		}
+
+		list.items = append(list.items, item)
+	}
+
+	return list, nil
+}
+
+func (p *parser) parseObject(at position) (expr, error) {
+	object := &objectExpr{position: at}
+
+	for !p.accept("}") {
+		if len(object.keys) > 0 {
+			if err := p.expect(","); err != nil {
+				return nil, err
+			}
+
+			if p.accept("}") {
+				break
+			}
+		}
+
+		key := p.next()
+		if key.kind != tokenIdent && key.kind != tokenString {
+			return nil, p.errorAt(key, "expected a property name")
+		}
+
+		if err := p.expect(":"); err != nil {
+			return nil, err
+		}
+
+		value, err := p.parseExpression()
+		if err != nil {
+			return nil, err
+		}
+
+		object.keys = append(object.keys, key.text)
+		object.values = append(object.values, value)
+	}
+
+	return object, nil
+}
+
+// keywords cannot be used as variable names
+var keywords = []string{
+	"let", "const", "var", "if", "else", "for", "of", "in", "delete", "return",
+	"true", "false", "null", "undefined",
+}
+
+func isKeyword(name string) bool {
+	return slices.Contains(keywords, name)
+}
@@ -0,0 +1,77 @@
+package expression
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+// Default limits used for zero Limits fields
+const (
+	DefaultMaxSteps  = 100000
+	DefaultMaxMemory = 1 << 20
+	DefaultTimeout   = 100 * time.Millisecond
+)
+
+// maxDepth bounds the nesting of blocks and expressions
+const maxDepth = 64
+
+// Limits bounds a run; zero fields use the defaults
+type Limits struct {
+	// MaxSteps is the number of statements and expressions a run may evaluate
+	MaxSteps int
+	// MaxMemory is the number of bytes of strings, lists and objects a run may create
+	MaxMemory int
+	// Timeout bounds the wall-clock time of a run
+	Timeout time.Duration
+}
+
+// Result is the outcome of a run
+type Result struct {
+	// Data is the submission data after the script ran
+	Data map[string]any
+	// Rejected is set when the script called reject
+	Rejected bool
+	Message  string
+	// Steps is the number of steps the run took
+	Steps int
+}
+
+// Program is a compiled script. It is safe for concurrent use.
+type Program struct {
+	body []stmt
+}
+
+// Compile parses the source of a script
+func Compile(source string) (*Program, error) {
+	tokens, err := tokenize(source)
+	if err != nil {
+		return nil, err
+	}
+
+	p := &parser{tokens: tokens}
+
+	body, err := p.parseProgram()
+	if err != nil {
+		return nil, err
+	}
+
+	return &Program{body: body}, nil
+}
+
+// Run runs the program against data, which it changes in place; pass a copy
+// to keep the original. A rejection is reported in the Result, not as an error.
+func (p *Program) Run(ctx context.Context, data map[string]any, limits Limits) (Result, error) {
+	if data == nil {
+		data = map[string]any{}
+	}
+
+	m := newMachine(ctx, limits, data, nil)
+
+	err := m.execBlock(p.body, false)
+	if errors.Is(err, errStop) {
+		err = nil
+	}
+
+	return Result{Data: data, Rejected: m.rejected, Message: m.message, Steps: m.steps}, err
+}
@@ -0,0 +1,164 @@
+package expression_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/goformx/goforms/internal/domain/form/expression"
+)
+
+func run(t *testing.T, source string, data map[string]any, limits expression.Limits) (expression.Result, error) {
+	t.Helper()
+
+	program, err := expression.Compile(source)
+	require.NoError(t, err)
+
+	return program.Run(context.Background(), data, limits)
+}
+
+func TestRun_Transforms(t *testing.T) {
+	source := `
+		// computed fields
+		let total = sum(data.prices)
+		data.total = round(total * 1.2, 2)
+		data.name = trim(data.name)
+		data.tags = append(data.tags, "checked")
+		for (const key in data) {
+			if (matches(key, "^_")) { delete data[key] }
+		}
+		var count = 0
+		for (const price of data.prices) {
+			if (price > 1) { count += 1 } else { count -= 0 }
+		}
+		data.count = count
+		data.kind = data.total > 5 ? "large" : "small"
+	`
+	data := map[string]any{
+		"name":    "  Ada ",
+		"prices":  []any{1.0, 2.5, 3.0},
+		"tags":    []any{"new"},
+		"_secret": "x",
+	}
+
+	result, err := run(t, source, data, expression.Limits{})
+	require.NoError(t, err)
+	assert.False(t, result.Rejected)
+	assert.Equal(t, map[string]any{
+		"name":   "Ada",
+		"prices": []any{1.0, 2.5, 3.0},
+		"tags":   []any{"new", "checked"},
+		"total":  7.8,
+		"count":  2.0,
+		"kind":   "large",
+	}, result.Data)
+	assert.Positive(t, result.Steps)
+}
+
+func TestRun_Reject(t *testing.T) {
+	source := `
+		if (data.email == "" && data.phone == "") {
+			reject("Give an email address or a phone number")
+		}
+		data.reached = true
+	`
+
+	result, err := run(t, source, map[string]any{"email": "", "phone": ""}, expression.Limits{})
+	require.NoError(t, err)
+	assert.True(t, result.Rejected)
+	assert.Equal(t, "Give an email address or a phone number", result.Message)
+	assert.NotContains(t, result.Data, "reached")
+
+	result, err = run(t, source, map[string]any{"email": "a@b.c", "phone": ""}, expression.Limits{})
+	require.NoError(t, err)
+	assert.False(t, result.Rejected)
+	assert.Equal(t, true, result.Data["reached"])
+}
+
+func TestRun_Return(t *testing.T) {
+	result, err := run(t, `data.a = 1; return; data.b = 2`, nil, expression.Limits{})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]any{"a": 1.0}, result.Data)
+}
+
+func TestRun_Limits(t *testing.T) {
+	list := make([]any, 1000)
+	for i := range list {
+		list[i] = float64(i)
+	}
+
+	t.Run("steps", func(t *testing.T) {
+		source := `for (const a of data.list) { for (const b of data.list) { data.n = a + b } }`
+		_, err := run(t, source, map[string]any{"list": list}, expression.Limits{MaxSteps: 10000})
+		require.ErrorIs(t, err, expression.ErrStepLimit)
+	})
+
+	t.Run("memory", func(t *testing.T) {
+		source := `let s = "xxxxxxxx"; for (const i of data.list) { s = s + s }`
+		_, err := run(t, source, map[string]any{"list": list}, expression.Limits{MaxMemory: 4096})
+		require.ErrorIs(t, err, expression.ErrMemoryLimit)
+	})
+
+	t.Run("timeout", func(t *testing.T) {
+		source := `for (const a of data.list) { for (const b of data.list) { data.n = a + b } }`
+		_, err := run(t, source, map[string]any{"list": list}, expression.Limits{MaxSteps: 1 << 30, Timeout: time.Millisecond})
+		require.ErrorIs(t, err, expression.ErrTimeout)
+	})
+
+	t.Run("cancelled context", func(t *testing.T) {
+		program, err := expression.Compile(`for (const a of data.list) { data.n = a }`)
+		require.NoError(t, err)
+
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		_, err = program.Run(ctx, map[string]any{"list": list}, expression.Limits{})
+		require.ErrorIs(t, err, expression.ErrTimeout)
+	})
+}
+
+func TestCompile_Errors(t *testing.T) {
+	tests := []struct {
+		source string
+		line   int
+		col    int
+	}{
+		{source: "let a = ", line: 1, col: 9},
+		{source: "data.a = 1\nlet = 2", line: 2, col: 5},
+		{source: "alert('x')", line: 1, col: 1},
+		{source: "while (true) {}", line: 1, col: 1},
+		{source: "let s = 'open", line: 1, col: 9},
+		{source: "function f() {}", line: 1, col: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.source, func(t *testing.T) {
+			_, err := expression.Compile(tt.source)
+			require.ErrorIs(t, err, expression.ErrSyntax)
+
+			var exprErr *expression.Error
+			require.ErrorAs(t, err, &exprErr)
+			assert.Equal(t, tt.line, exprErr.Line)
+			assert.Equal(t, tt.col, exprErr.Col)
+		})
+	}
+}
+
+func TestRun_RuntimeErrors(t *testing.T) {
+	for _, source := range []string{
+		"data = {}",
+		"const a = 1; a = 2",
+		"data.a = missing",
+		"data.a.b = 1",
+		"data.a = 1 / 0",
+		"keys(1)",
+	} {
+		t.Run(source, func(t *testing.T) {
+			_, err := run(t, source, map[string]any{}, expression.Limits{})
+			require.ErrorIs(t, err, expression.ErrEvaluation)
+		})
+	}
+}
@@ -0,0 +1,346 @@
+package form
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/goformx/goforms/internal/domain/emailtemplate"
+	"github.com/goformx/goforms/internal/domain/entities"
+	"github.com/goformx/goforms/internal/domain/form/model"
+	"github.com/goformx/goforms/internal/infrastructure/email"
+	"github.com/goformx/goforms/internal/infrastructure/logging"
+	"github.com/goformx/goforms/internal/infrastructure/scheduler"
+)
+
+const (
+	// HealthReportPeriod is the time an account health email covers, and the
+	// time between two health emails to the same account
+	HealthReportPeriod = 7 * 24 * time.Hour
+	// HealthQuotaWarningPercent is the share of a quota limit, in percent, from
+	// which the health email warns about the quota
+	HealthQuotaWarningPercent = 80
+	// healthPollInterval is how often the reporter checks for due health emails
+	healthPollInterval = time.Hour
+	// healthRetryDelay is the delay before retrying a health email that failed
+	healthRetryDelay = time.Hour
+	// healthBatchSize bounds the health emails sent in one poll
+	healthBatchSize = 100
+	// healthSendTimeout bounds the time spent building and sending one health email
+	healthSendTimeout = 2 * time.Minute
+)
+
+// HealthEmailRepository finds and claims the accounts whose health email is due
+type HealthEmailRepository interface {
+	// ListHealthEmailsDue returns up to limit active users who opted in to the
+	// health email and were last sent one at or before before, or never
+	ListHealthEmailsDue(ctx context.Context, before time.Time, limit int) ([]*entities.User, error)
+	// SetHealthEmailSent moves a user's last health email time from last to
+	// sentAt if it is still last, reporting whether this caller moved it
+	SetHealthEmailSent(ctx context.Context, userID string, last *time.Time, sentAt time.Time) (bool, error)
+}
+
+// HealthSignals supplies the parts of an account health report that are
+// recorded outside the form domain
+type HealthSignals interface {
+	// FailingDeliveries counts, by form, the failed post-submit hook deliveries
+	// still waiting for a retry
+	FailingDeliveries(ctx context.Context, formIDs []string) (map[string]int, error)
+	// SecurityEvents lists the security events about a user's account or forms
+	// at or after since, newest first
+	SecurityEvents(ctx context.Context, userID string, formIDs []string, since time.Time) ([]HealthSecurityEvent, error)
+}
+
+// FormHealth is one form's line in an account health report
+type FormHealth struct {
+	FormID string `json:"form_id"`
+	Title  string `json:"title"`
+	// Submissions counts the submissions received during the period
+	Submissions int `json:"submissions"`
+	// FailingDeliveries counts the failed post-submit hook deliveries waiting for a retry
+	FailingDeliveries int `json:"failing_deliveries"`
+}
+
+// QuotaWarning is a quota whose usage reached HealthQuotaWarningPercent of its limit
+type QuotaWarning struct {
+	// Quota is QuotaForms, QuotaSubmissions or QuotaStorage
+	Quota   string `json:"quota"`
+	Used    int64  `json:"used"`
+	Limit   int64  `json:"limit"`
+	Percent int64  `json:"percent"`
+}
+
+// HealthSecurityEvent is a security-relevant event about an account or its forms
+type HealthSecurityEvent struct {
+	Kind        string    `json:"kind"`
+	Description string    `json:"description"`
+	At          time.Time `json:"at"`
+}
+
+// HealthReport summarizes a period of one account's activity
+type HealthReport struct {
+	UserID            string                `json:"user_id"`
+	PeriodStart       time.Time             `json:"period_start"`
+	PeriodEnd         time.Time             `json:"period_end"`
+	Forms             []FormHealth          `json:"forms"`
+	Submissions       int                   `json:"submissions"`
+	FailingDeliveries int                   `json:"failing_deliveries"`
+	Quotas            []QuotaWarning        `json:"quotas"`
+	SecurityEvents    []HealthSecurityEvent `json:"security_events"`
+}
+
+// HealthReporter emails a weekly account health report to the users who opted
+// in. Due accounts are claimed before they are sent, so with several instances
+// each email is sent once.
+type HealthReporter struct {
+	users       HealthEmailRepository
+	forms       Service
+	submissions SubmissionRangeRepository
+	quotas      QuotaService
+	signals     HealthSignals
+	templates   emailtemplate.Service
+	sender      email.Sender
+	logger      logging.Logger
+	runner      *scheduler.Runner
+	now         func() time.Time
+}
+
+// NewHealthReporter creates a new account health reporter. The emails are
+// rendered from the user's account health email template.
+func NewHealthReporter(
+	users HealthEmailRepository,
+	forms Service,
+	submissions SubmissionRangeRepository,
+	quotas QuotaService,
+	signals HealthSignals,
+	templates emailtemplate.Service,
+	sender email.Sender,
+	logger logging.Logger,
+) *HealthReporter {
+	r := &HealthReporter{
+		users:       users,
+		forms:       forms,
+		submissions: submissions,
+		quotas:      quotas,
+		signals:     signals,
+		templates:   templates,
+		sender:      sender,
+		logger:      logger,
+		now:         time.Now,
+	}
+	r.runner = scheduler.NewRunner("account-health-reporter", healthPollInterval, r.RunDue, logger)
+
+	return r
+}
+
+// Start begins polling for due health emails
+func (r *HealthReporter) Start() {
+	if r.users == nil || r.sender == nil {
+		return
+	}
+
+	r.runner.Start()
+}
+
+// Stop stops polling and waits for an in-flight run to finish
+func (r *HealthReporter) Stop(ctx context.Context) {
+	r.runner.Stop(ctx)
+}
+
+// RunDue claims and sends every health email that is due. A failing email is
+// logged and retried after healthRetryDelay; it does not block the others.
+func (r *HealthReporter) RunDue(ctx context.Context) error {
+	// Second precision, so the claimed time compares equal once stored
+	now := r.now().UTC().Truncate(time.Second)
+
+	due, err := r.users.ListHealthEmailsDue(ctx, now.Add(-HealthReportPeriod), healthBatchSize)
+	if err != nil {
+		return fmt.Errorf("load due health emails: %w", err)
+	}
+
+	for _, u := range due {
+		claimed, claimErr := r.users.SetHealthEmailSent(ctx, u.ID, u.HealthEmailSentAt, now)
+		if claimErr != nil {
+			r.logger.Error("failed to claim health email", "user_id", u.ID, "error", claimErr)
+
+			continue
+		}
+
+		if !claimed {
+			continue
+		}
+
+		sendErr := r.Send(ctx, u, now)
+		if sendErr == nil {
+			continue
+		}
+
+		r.logger.Error("failed to send health email", "user_id", u.ID, "error", sendErr)
+
+		// Make the email due again after the retry delay
+		if _, retryErr := r.users.SetHealthEmailSent(ctx, u.ID, &now,
+			now.Add(healthRetryDelay-HealthReportPeriod)); retryErr != nil {
+			r.logger.Error("failed to schedule health email retry", "user_id", u.ID, "error", retryErr)
+		}
+	}
+
+	return nil
+}
+
+// Send builds and emails the health report of the period ending at now
+func (r *HealthReporter) Send(ctx context.Context, u *entities.User, now time.Time) error {
+	ctx, cancel := context.WithTimeout(ctx, healthSendTimeout)
+	defer cancel()
+
+	report, err := r.Build(ctx, u.ID, now.Add(-HealthReportPeriod), now)
+	if err != nil {
+		return err
+	}
+
+	msg, err := r.buildMessage(ctx, u, report)
+	if err != nil {
+		return err
+	}
+
+	if sendErr := r.sender.Send(ctx, msg); sendErr != nil {
+		return fmt.Errorf("send health email: %w", sendErr)
+	}
+
+	r.logger.Info("account health email sent", "user_id", u.ID, "form_count", len(report.Forms),
+		"submission_count", report.Submissions)
+
+	return nil
+}
+
+// Build gathers the health report of a user's account for the period after
+// since and up to until
+func (r *HealthReporter) Build(ctx context.Context, userID string, since, until time.Time) (*HealthReport, error) {
+	forms, err := r.forms.ListForms(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("list forms: %w", err)
+	}
+
+	formIDs := make([]string, 0, len(forms))
+	for _, f := range forms {
+		formIDs = append(formIDs, f.ID)
+	}
+
+	failing := map[string]int{}
+	events := []HealthSecurityEvent{}
+
+	if r.signals != nil {
+		if failing, err = r.signals.FailingDeliveries(ctx, formIDs); err != nil {
+			return nil, fmt.Errorf("count failing deliveries: %w", err)
+		}
+
+		if events, err = r.signals.SecurityEvents(ctx, userID, formIDs, since); err != nil {
+			return nil, fmt.Errorf("list security events: %w", err)
+		}
+	}
+
+	report := &HealthReport{
+		UserID:         userID,
+		PeriodStart:    since,
+		PeriodEnd:      until,
+		Forms:          make([]FormHealth, 0, len(forms)),
+		Quotas:         []QuotaWarning{},
+		SecurityEvents: events,
+	}
+
+	for _, f := range forms {
+		submissions, listErr := r.submissions.ListSubmissionsBetween(ctx, f.ID, since, until)
+		if listErr != nil {
+			return nil, fmt.Errorf("list form submissions: %w", listErr)
+		}
+
+		submissions = model.WithoutTestSubmissions(submissions)
+
+		line := FormHealth{FormID: f.ID, Title: f.Title, Submissions: len(submissions), FailingDeliveries: failing[f.ID]}
+		report.Forms = append(report.Forms, line)
+		report.Submissions += line.Submissions
+		report.FailingDeliveries += line.FailingDeliveries
+	}
+
+	if r.quotas != nil {
+		usage, usageErr := r.quotas.GetUsage(ctx, userID)
+		if usageErr != nil {
+			return nil, fmt.Errorf("get usage: %w", usageErr)
+		}
+
+		report.Quotas = quotaWarnings(usage)
+	}
+
+	return report, nil
+}
+
+// quotaWarnings lists the quotas whose usage reached HealthQuotaWarningPercent of a set limit
+func quotaWarnings(usage *Usage) []QuotaWarning {
+	warnings := []QuotaWarning{}
+
+	for _, q := range []QuotaWarning{
+		{Quota: QuotaForms, Used: usage.Forms, Limit: usage.Limits.MaxForms},
+		{Quota: QuotaSubmissions, Used: usage.Submissions, Limit: usage.Limits.MaxSubmissionsPerMonth},
+		{Quota: QuotaStorage, Used: usage.StorageBytes, Limit: usage.Limits.MaxStorageBytes},
+	} {
+		if q.Limit <= 0 {
+			continue
+		}
+
+		q.Percent = q.Used * 100 / q.Limit
+		if q.Percent >= HealthQuotaWarningPercent {
+			warnings = append(warnings, q)
+		}
+	}
+
+	return warnings
+}
+
+// quotaNames are the names of the quotas in the health email
+var quotaNames = map[string]string{
+	QuotaForms:       "Forms",
+	QuotaSubmissions: "Submissions this month",
+	QuotaStorage:     "Stored submission data (bytes)",
+}
+
+// buildMessage renders the health email for a report, giving times in the
+// user's account time zone
+func (r *HealthReporter) buildMessage(ctx context.Context, u *entities.User, report *HealthReport) (*email.Message, error) {
+	loc, err := model.LoadTimezone(u.Timezone)
+	if err != nil {
+		loc = time.UTC
+	}
+
+	forms := make([]map[string]any, 0, len(report.Forms))
+	for _, f := range report.Forms {
+		forms = append(forms, map[string]any{
+			"ID": f.FormID, "Title": f.Title, "Submissions": f.Submissions, "FailingDeliveries": f.FailingDeliveries,
+		})
+	}
+
+	quotas := make([]map[string]any, 0, len(report.Quotas))
+	for _, q := range report.Quotas {
+		quotas = append(quotas, map[string]any{"Name": quotaNames[q.Quota], "Used": q.Used, "Limit": q.Limit, "Percent": q.Percent})
+	}
+
+	events := make([]map[string]any, 0, len(report.SecurityEvents))
+	for _, e := range report.SecurityEvents {
+		events = append(events, map[string]any{"At": e.At.In(loc).Format(time.RFC1123), "Description": e.Description})
+	}
+
+	rendered, err := r.templates.Render(ctx, u.ID, emailtemplate.KindAccountHealth, map[string]any{
+		"UserName":          u.FirstName,
+		"PeriodStart":       report.PeriodStart.In(loc).Format(time.RFC1123),
+		"PeriodEnd":         report.PeriodEnd.In(loc).Format(time.RFC1123),
+		"Timezone":          loc.String(),
+		"SubmissionCount":   report.Submissions,
+		"FailingDeliveries": report.FailingDeliveries,
+		"Forms":             forms,
+		"Quotas":            quotas,
+		"SecurityEvents":    events,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("render health email: %w", err)
+	}
+
+	return &email.Message{To: []string{u.Email}, Subject: rendered.Subject, Body: rendered.Text, HTML: rendered.HTML}, nil
+}
@@ -0,0 +1,162 @@
+package form_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/goformx/goforms/internal/domain/emailtemplate"
+	"github.com/goformx/goforms/internal/domain/entities"
+	domainform "github.com/goformx/goforms/internal/domain/form"
+	"github.com/goformx/goforms/internal/domain/form/model"
+	memorystore "github.com/goformx/goforms/internal/infrastructure/repository/memory"
+	mockform "github.com/goformx/goforms/test/mocks/form"
+	mocklogging "github.com/goformx/goforms/test/mocks/logging"
+)
+
+// fixedUsage is a quota service reporting the same usage for every user
+type fixedUsage struct {
+	domainform.QuotaService
+	usage *domainform.Usage
+}
+
+func (q *fixedUsage) GetUsage(_ context.Context, _ string) (*domainform.Usage, error) {
+	return q.usage, nil
+}
+
+// fixedSignals reports the same failing deliveries and security events for every user
+type fixedSignals struct {
+	failing map[string]int
+	events  []domainform.HealthSecurityEvent
+}
+
+func (s *fixedSignals) FailingDeliveries(_ context.Context, _ []string) (map[string]int, error) {
+	return s.failing, nil
+}
+
+func (s *fixedSignals) SecurityEvents(
+	_ context.Context, _ string, _ []string, _ time.Time,
+) ([]domainform.HealthSecurityEvent, error) {
+	return s.events, nil
+}
+
+// healthFixture is a health reporter over a memory store holding one user who
+// opted in, with one form
+type healthFixture struct {
+	store    *memorystore.Store
+	owner    *entities.User
+	sender   *recordingSender
+	reporter *domainform.HealthReporter
+}
+
+func newHealthFixture(t *testing.T) *healthFixture {
+	t.Helper()
+
+	ctx := context.Background()
+	ctrl := gomock.NewController(t)
+	logger := mocklogging.NewMockLogger(ctrl)
+	logger.EXPECT().Info(gomock.Any(), gomock.Any()).AnyTimes()
+	logger.EXPECT().Error(gomock.Any(), gomock.Any()).AnyTimes()
+
+	store := memorystore.NewStore(logger)
+
+	owner, err := entities.NewUser("ada@example.com", "password123", "Ada", "Lovelace")
+	require.NoError(t, err)
+
+	owner.HealthEmail = true
+	owner.Timezone = "Europe/Berlin"
+	require.NoError(t, store.Users().Create(ctx, owner))
+
+	forms := mockform.NewMockService(ctrl)
+	forms.EXPECT().ListForms(gomock.Any(), owner.ID).Return([]*model.Form{{ID: "form-1", Title: "Contact"}}, nil).AnyTimes()
+
+	for _, submission := range []*model.FormSubmission{
+		{ID: "recent", FormID: "form-1", SubmittedAt: time.Now().Add(-time.Hour)},
+		{ID: "old", FormID: "form-1", SubmittedAt: time.Now().Add(-8 * 24 * time.Hour)},
+	} {
+		require.NoError(t, store.Forms().CreateSubmission(ctx, submission))
+	}
+
+	users, ok := store.Users().(domainform.HealthEmailRepository)
+	require.True(t, ok)
+
+	submissions, _ := store.Forms().(domainform.SubmissionRangeRepository)
+	quotas := &fixedUsage{usage: &domainform.Usage{
+		Forms: 1, Submissions: 90, StorageBytes: 10,
+		Limits: domainform.QuotaLimits{MaxForms: 10, MaxSubmissionsPerMonth: 100},
+	}}
+	signals := &fixedSignals{
+		failing: map[string]int{"form-1": 2},
+		events: []domainform.HealthSecurityEvent{{
+			Kind: "user.role_changed", Description: "An administrator changed your role", At: time.Now(),
+		}},
+	}
+	templates := emailtemplate.NewService(store.EmailTemplates(), "GoFormX", nil, logger)
+	sender := &recordingSender{}
+
+	return &healthFixture{
+		store:    store,
+		owner:    owner,
+		sender:   sender,
+		reporter: domainform.NewHealthReporter(users, forms, submissions, quotas, signals, templates, sender, logger),
+	}
+}
+
+func TestHealthReporter_Build(t *testing.T) {
+	f := newHealthFixture(t)
+	now := time.Now().UTC()
+
+	report, err := f.reporter.Build(context.Background(), f.owner.ID, now.Add(-domainform.HealthReportPeriod), now)
+	require.NoError(t, err)
+
+	assert.Equal(t, []domainform.FormHealth{
+		{FormID: "form-1", Title: "Contact", Submissions: 1, FailingDeliveries: 2},
+	}, report.Forms, "only the submissions of the past week are counted")
+	assert.Equal(t, 1, report.Submissions)
+	assert.Equal(t, 2, report.FailingDeliveries)
+	assert.Equal(t, []domainform.QuotaWarning{
+		{Quota: domainform.QuotaSubmissions, Used: 90, Limit: 100, Percent: 90},
+	}, report.Quotas, "quotas under 80% and unlimited ones are left out")
+	require.Len(t, report.SecurityEvents, 1)
+}
+
+func TestHealthReporter_RunDue(t *testing.T) {
+	f := newHealthFixture(t)
+	ctx := context.Background()
+
+	require.NoError(t, f.reporter.RunDue(ctx))
+	require.Len(t, f.sender.messages, 1)
+
+	msg := f.sender.messages[0]
+	assert.Equal(t, []string{"ada@example.com"}, msg.To)
+	assert.Equal(t, "Your GoFormX week: 1 new submission(s)", msg.Subject)
+	assert.Contains(t, msg.Body, "Contact: 2 delivery(ies) waiting for a retry")
+	assert.Contains(t, msg.Body, "Submissions this month: 90 of 100 (90%)")
+	assert.Contains(t, msg.Body, "An administrator changed your role")
+	assert.Contains(t, msg.Body, "CE", "times are given in the account time zone")
+
+	require.NoError(t, f.reporter.RunDue(ctx))
+	assert.Len(t, f.sender.messages, 1, "the email is sent once a week")
+}
+
+func TestHealthReporter_RunDueRetriesFailures(t *testing.T) {
+	f := newHealthFixture(t)
+	ctx := context.Background()
+	f.sender.err = errors.New("smtp unavailable")
+
+	require.NoError(t, f.reporter.RunDue(ctx))
+	assert.Empty(t, f.sender.messages)
+
+	stored, err := f.store.Users().GetByID(ctx, f.owner.ID)
+	require.NoError(t, err)
+	require.NotNil(t, stored.HealthEmailSentAt)
+
+	// The failed email is due again an hour later
+	retryAt := stored.HealthEmailSentAt.Add(domainform.HealthReportPeriod)
+	assert.WithinDuration(t, time.Now().Add(time.Hour), retryAt, time.Minute)
+}
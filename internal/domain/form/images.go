@@ -0,0 +1,277 @@
+package form
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"mime"
+	"net/http"
+	"path"
+	"strings"
+
+	"github.com/goformx/goforms/internal/domain/form/imaging"
+	"github.com/goformx/goforms/internal/domain/form/model"
+)
+
+// imageProcessingKey is the file component property holding ImageOptions
+const imageProcessingKey = "imageProcessing"
+
+// ImageOptions configures server-side handling of files uploaded to a file
+// component, set as the component's imageProcessing property:
+//
+//	{"type": "file", "key": "photo", "storage": "base64",
+//	 "imageProcessing": {"sniffContentType": true, "stripExif": true,
+//	   "maxWidth": 2048, "maxHeight": 2048, "thumbnail": {"width": 256, "height": 256}}}
+//
+// Only files sent inline as base64 data URLs can be processed.
+type ImageOptions struct {
+	// SniffContentType rejects files whose content does not match their declared
+	// type or extension, and records the detected type
+	SniffContentType bool `json:"sniffContentType"`
+	// StripExif re-encodes JPEG and PNG images without their metadata
+	StripExif bool `json:"stripExif"`
+	// MaxWidth and MaxHeight scale larger images down; zero is unconstrained
+	MaxWidth  int `json:"maxWidth"`
+	MaxHeight int `json:"maxHeight"`
+	// Quality is the JPEG quality used when re-encoding
+	Quality int `json:"quality"`
+	// Thumbnail adds a scaled-down copy to the file under "thumbnail"
+	Thumbnail *ThumbnailOptions `json:"thumbnail"`
+
+	// maxPixels is the server's limit, not set by form owners
+	maxPixels int
+}
+
+// ImageLimits are the server-wide bounds on image processing
+type ImageLimits struct {
+	// MaxPixels is the largest image decoded; zero uses imaging.DefaultMaxPixels
+	MaxPixels int
+}
+
+// ThumbnailOptions bounds the size of generated thumbnails
+type ThumbnailOptions struct {
+	Width  int `json:"width"`
+	Height int `json:"height"`
+}
+
+// reencodes reports whether the options change image pixels or metadata
+func (o *ImageOptions) reencodes() bool {
+	return o.StripExif || o.MaxWidth > 0 || o.MaxHeight > 0 || o.Thumbnail != nil
+}
+
+// ApplyImageProcessing processes the files uploaded to file components that
+// configure imageProcessing, in place. A file whose content contradicts its
+// declared type, or an image over the limits, fails with model.ErrFormInvalid.
+func ApplyImageProcessing(schema, data model.JSON, limits ImageLimits) error {
+	if schema == nil || data == nil {
+		return nil
+	}
+
+	return model.WalkSchema(schema, func(component map[string]any) error {
+		options, configured := imageOptions(component)
+		if !configured {
+			return nil
+		}
+
+		key, _ := component["key"].(string)
+		options.maxPixels = limits.MaxPixels
+
+		return processFieldFiles(key, data[key], options)
+	})
+}
+
+// imageOptions reads the image processing options of a file component
+func imageOptions(component map[string]any) (*ImageOptions, bool) {
+	if componentType, _ := component["type"].(string); componentType != "file" {
+		return nil, false
+	}
+
+	raw, ok := component[imageProcessingKey].(map[string]any)
+	if !ok {
+		return nil, false
+	}
+
+	encoded, err := json.Marshal(raw)
+	if err != nil {
+		return nil, false
+	}
+
+	var options ImageOptions
+	if decodeErr := json.Unmarshal(encoded, &options); decodeErr != nil {
+		return nil, false
+	}
+
+	return &options, true
+}
+
+// processFieldFiles processes each file uploaded to one field
+func processFieldFiles(key string, value any, options *ImageOptions) error {
+	files, ok := value.([]any)
+	if !ok {
+		files = []any{value}
+	}
+
+	for _, file := range files {
+		fileMap, fileOk := file.(map[string]any)
+		if !fileOk {
+			continue
+		}
+
+		if err := processFile(key, fileMap, options); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// processFile sniffs, re-encodes and thumbnails one inline file
+func processFile(key string, file map[string]any, options *ImageOptions) error {
+	content, ok := decodeDataURL(file["url"])
+	if !ok {
+		return nil
+	}
+
+	name, _ := file["originalName"].(string)
+	if name == "" {
+		name, _ = file["name"].(string)
+	}
+
+	sniffed := mediaType(http.DetectContentType(content))
+
+	if options.SniffContentType {
+		if err := checkContentType(key, name, file, sniffed); err != nil {
+			return err
+		}
+
+		file["type"] = sniffed
+	}
+
+	if !options.reencodes() || (sniffed != "image/jpeg" && sniffed != "image/png") {
+		return nil
+	}
+
+	return reencodeImage(key, name, file, content, options)
+}
+
+// checkContentType rejects files whose declared type or extension contradicts their content
+func checkContentType(key, name string, file map[string]any, sniffed string) error {
+	declared, _ := file["type"].(string)
+
+	claims := []string{mediaType(declared)}
+	if ext := path.Ext(name); ext != "" {
+		claims = append(claims, mediaType(mime.TypeByExtension(strings.ToLower(ext))))
+	}
+
+	for _, claim := range claims {
+		if contentTypeMismatch(claim, sniffed) {
+			return fmt.Errorf("%w: %s: file %q contains %s, not %s", model.ErrFormInvalid, key, name, sniffed, claim)
+		}
+	}
+
+	return nil
+}
+
+// contentTypeMismatch reports whether content sniffed as sniffed cannot be a claimed file.
+// Only images and HTML are recognized reliably enough to reject on.
+func contentTypeMismatch(claimed, sniffed string) bool {
+	if claimed == "" || claimed == sniffed {
+		return false
+	}
+
+	// HTML passed off as any other type could run script when opened
+	if sniffed == "text/html" {
+		return true
+	}
+
+	// SVG is XML and is not recognized by content sniffing
+	if claimed == "image/svg+xml" {
+		return sniffed != "text/xml" && sniffed != "text/plain"
+	}
+
+	return strings.HasPrefix(claimed, "image/") || strings.HasPrefix(sniffed, "image/")
+}
+
+// reencodeImage orients, strips, resizes and thumbnails an image file
+func reencodeImage(key, name string, file map[string]any, content []byte, options *ImageOptions) error {
+	img, format, err := imaging.Decode(content, options.maxPixels)
+	if err != nil {
+		return fmt.Errorf("%w: %s: file %q is not a valid image: %w", model.ErrFormInvalid, key, name, err)
+	}
+
+	bounds := img.Bounds()
+	resized := imaging.Fit(img, options.MaxWidth, options.MaxHeight)
+
+	// Keep the uploaded bytes when nothing would change
+	if options.StripExif || resized != img || imaging.Orientation(content) > 1 {
+		encoded, encodeErr := imaging.Encode(resized, format, options.Quality)
+		if encodeErr != nil {
+			return fmt.Errorf("process image %q: %w", name, encodeErr)
+		}
+
+		setImage(file, encoded, format, resized.Bounds().Dx(), resized.Bounds().Dy())
+	} else {
+		file["width"], file["height"] = bounds.Dx(), bounds.Dy()
+	}
+
+	if options.Thumbnail != nil {
+		thumbnail := imaging.Fit(img, options.Thumbnail.Width, options.Thumbnail.Height)
+
+		encoded, encodeErr := imaging.Encode(thumbnail, format, options.Quality)
+		if encodeErr != nil {
+			return fmt.Errorf("create thumbnail for %q: %w", name, encodeErr)
+		}
+
+		thumb := map[string]any{}
+		setImage(thumb, encoded, format, thumbnail.Bounds().Dx(), thumbnail.Bounds().Dy())
+		file["thumbnail"] = thumb
+	}
+
+	return nil
+}
+
+// setImage stores encoded image bytes and their dimensions on a file object
+func setImage(file map[string]any, encoded []byte, format string, width, height int) {
+	contentType := "image/" + format
+
+	file["url"] = "data:" + contentType + ";base64," + base64.StdEncoding.EncodeToString(encoded)
+	file["type"] = contentType
+	file["size"] = len(encoded)
+	file["width"] = width
+	file["height"] = height
+}
+
+// decodeDataURL returns the content of a base64 data URL
+func decodeDataURL(value any) ([]byte, bool) {
+	url, ok := value.(string)
+	if !ok || !strings.HasPrefix(url, "data:") {
+		return nil, false
+	}
+
+	header, payload, found := strings.Cut(strings.TrimPrefix(url, "data:"), ",")
+	if !found || !strings.HasSuffix(header, ";base64") {
+		return nil, false
+	}
+
+	content, err := base64.StdEncoding.DecodeString(payload)
+	if err != nil {
+		return nil, false
+	}
+
+	return content, true
+}
+
+// mediaType lowercases a content type and drops its parameters
+func mediaType(contentType string) string {
+	base, _, _ := strings.Cut(contentType, ";")
+	base = strings.ToLower(strings.TrimSpace(base))
+
+	switch base {
+	case "image/jpg", "image/pjpeg":
+		return "image/jpeg"
+	case "image/x-png":
+		return "image/png"
+	}
+
+	return base
+}
@@ -0,0 +1,260 @@
+package form_test
+
+import (
+	"bytes"
+	"encoding/base64"
+	"image"
+	"image/color"
+	"image/jpeg"
+	"image/png"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	domainform "github.com/goformx/goforms/internal/domain/form"
+	"github.com/goformx/goforms/internal/domain/form/imaging"
+	"github.com/goformx/goforms/internal/domain/form/model"
+)
+
+func TestApplyImageProcessing(t *testing.T) {
+	t.Run("resizes images and adds thumbnails", func(t *testing.T) {
+		schema := imageSchema(map[string]any{
+			"maxWidth":  40,
+			"maxHeight": 40,
+			"thumbnail": map[string]any{"width": 10, "height": 10},
+		})
+		file := uploadedFile("photo.png", "image/png", encodePNG(t, 80, 40))
+		data := model.JSON{"photo": []any{file}}
+
+		require.NoError(t, domainform.ApplyImageProcessing(schema, data, domainform.ImageLimits{}))
+
+		assert.Equal(t, 40, file["width"])
+		assert.Equal(t, 20, file["height"])
+		assert.Equal(t, "image/png", file["type"])
+		assert.Equal(t, image.Point{X: 40, Y: 20}, decodedSize(t, file["url"]))
+
+		thumbnail, ok := file["thumbnail"].(map[string]any)
+		require.True(t, ok)
+		assert.Equal(t, 10, thumbnail["width"])
+		assert.Equal(t, 5, thumbnail["height"])
+		assert.Equal(t, image.Point{X: 10, Y: 5}, decodedSize(t, thumbnail["url"]))
+	})
+
+	t.Run("strips EXIF and applies its orientation", func(t *testing.T) {
+		schema := imageSchema(map[string]any{"stripExif": true})
+		original := withOrientation(encodeJPEG(t, 8, 4), 6)
+		file := uploadedFile("photo.jpg", "image/jpeg", original)
+		data := model.JSON{"photo": []any{file}}
+
+		require.NoError(t, domainform.ApplyImageProcessing(schema, data, domainform.ImageLimits{}))
+
+		content := dataURLContent(t, file["url"])
+		assert.NotContains(t, string(content), "Exif")
+		assert.Equal(t, image.Point{X: 4, Y: 8}, decodedSize(t, file["url"]))
+		assert.Equal(t, len(content), file["size"])
+	})
+
+	t.Run("rejects content that contradicts its type", func(t *testing.T) {
+		schema := imageSchema(map[string]any{"sniffContentType": true})
+		html := []byte("<!DOCTYPE html><html><script>alert(1)</script></html>")
+		data := model.JSON{"photo": []any{uploadedFile("photo.png", "image/png", html)}}
+
+		err := domainform.ApplyImageProcessing(schema, data, domainform.ImageLimits{})
+		require.ErrorIs(t, err, model.ErrFormInvalid)
+	})
+
+	t.Run("records the sniffed type", func(t *testing.T) {
+		schema := imageSchema(map[string]any{"sniffContentType": true})
+		file := uploadedFile("photo.PNG", "image/x-png", encodePNG(t, 2, 2))
+		data := model.JSON{"photo": []any{file}}
+
+		require.NoError(t, domainform.ApplyImageProcessing(schema, data, domainform.ImageLimits{}))
+		assert.Equal(t, "image/png", file["type"])
+	})
+
+	t.Run("rejects a mismatched extension", func(t *testing.T) {
+		schema := imageSchema(map[string]any{"sniffContentType": true})
+		file := uploadedFile("photo.jpg", "", encodePNG(t, 2, 2))
+		data := model.JSON{"photo": []any{file}}
+
+		err := domainform.ApplyImageProcessing(schema, data, domainform.ImageLimits{})
+		require.ErrorIs(t, err, model.ErrFormInvalid)
+	})
+
+	t.Run("rejects images over the pixel limit before decoding", func(t *testing.T) {
+		schema := imageSchema(map[string]any{"maxWidth": 10})
+		data := model.JSON{"photo": []any{uploadedFile("photo.png", "image/png", encodePNG(t, 80, 40))}}
+
+		err := domainform.ApplyImageProcessing(schema, data, domainform.ImageLimits{MaxPixels: 80*40 - 1})
+		require.ErrorIs(t, err, model.ErrFormInvalid)
+		require.ErrorIs(t, err, imaging.ErrTooLarge)
+	})
+
+	t.Run("leaves fields without options untouched", func(t *testing.T) {
+		schema := model.JSON{"components": []any{map[string]any{"key": "photo", "type": "file"}}}
+		file := uploadedFile("photo.png", "image/png", encodePNG(t, 80, 40))
+		url := file["url"]
+		data := model.JSON{"photo": []any{file}}
+
+		require.NoError(t, domainform.ApplyImageProcessing(schema, data, domainform.ImageLimits{}))
+		assert.Equal(t, url, file["url"])
+		assert.NotContains(t, file, "width")
+	})
+}
+
+func imageSchema(options map[string]any) model.JSON {
+	return model.JSON{
+		"components": []any{
+			map[string]any{
+				"type": "panel",
+				"components": []any{
+					map[string]any{"key": "photo", "type": "file", "imageProcessing": options},
+				},
+			},
+		},
+	}
+}
+
+func uploadedFile(name, contentType string, content []byte) map[string]any {
+	return map[string]any{
+		"name":         name,
+		"originalName": name,
+		"type":         contentType,
+		"size":         len(content),
+		"storage":      "base64",
+		"url":          "data:" + contentType + ";base64," + base64.StdEncoding.EncodeToString(content),
+	}
+}
+
+func testImage(width, height int) *image.RGBA {
+	img := image.NewRGBA(image.Rect(0, 0, width, height))
+	for y := range height {
+		for x := range width {
+			img.Set(x, y, color.RGBA{R: uint8(x * 16), G: uint8(y * 16), B: 128, A: 255})
+		}
+	}
+
+	return img
+}
+
+func encodePNG(t *testing.T, width, height int) []byte {
+	t.Helper()
+
+	var buf bytes.Buffer
+	require.NoError(t, png.Encode(&buf, testImage(width, height)))
+
+	return buf.Bytes()
+}
+
+func encodeJPEG(t *testing.T, width, height int) []byte {
+	t.Helper()
+
+	var buf bytes.Buffer
+	require.NoError(t, jpeg.Encode(&buf, testImage(width, height), nil))
+
+	return buf.Bytes()
+}
+
+// withOrientation inserts a big-endian EXIF segment holding only the orientation tag
+func withOrientation(jpegData []byte, orientation byte) []byte {
+	exif := []byte("Exif\x00\x00MM\x00\x2a\x00\x00\x00\x08" +
+		"\x00\x01" + // one IFD entry
+		"\x01\x12\x00\x03\x00\x00\x00\x01\x00" + string(orientation) + "\x00\x00" +
+		"\x00\x00\x00\x00") // no next IFD
+	length := len(exif) + 2
+	segment := append([]byte{0xFF, 0xE1, byte(length >> 8), byte(length)}, exif...)
+
+	return append(append(append([]byte{}, jpegData[:2]...), segment...), jpegData[2:]...)
+}
+
+func dataURLContent(t *testing.T, url any) []byte {
+	t.Helper()
+
+	value, ok := url.(string)
+	require.True(t, ok)
+
+	_, payload, found := strings.Cut(value, ",")
+	require.True(t, found)
+
+	content, err := base64.StdEncoding.DecodeString(payload)
+	require.NoError(t, err)
+
+	return content
+}
+
+func decodedSize(t *testing.T, url any) image.Point {
+	t.Helper()
+
+	cfg, _, err := image.DecodeConfig(bytes.NewReader(dataURLContent(t, url)))
+	require.NoError(t, err)
+
+	return image.Point{X: cfg.Width, Y: cfg.Height}
+}
+
+func TestSubmissionAttachment(t *testing.T) {
+	content := []byte("%PDF-1.4 sample")
+	data := model.JSON{
+		"cv": []any{
+			map[string]any{
+				"originalName": "cv.pdf",
+				"url":          "data:application/pdf;base64," + base64.StdEncoding.EncodeToString(content),
+			},
+			map[string]any{"name": "remote.pdf", "url": "https://files.example.com/remote.pdf"},
+		},
+		"name": "Ada",
+	}
+
+	attachment, err := domainform.SubmissionAttachment(data, "cv", 0)
+	require.NoError(t, err)
+	assert.Equal(t, "cv.pdf", attachment.Name)
+	assert.Equal(t, "application/pdf", attachment.ContentType)
+	assert.Equal(t, content, attachment.Content)
+
+	_, err = domainform.SubmissionAttachment(data, "cv", 1)
+	require.ErrorIs(t, err, domainform.ErrAttachmentNotInline)
+
+	_, err = domainform.SubmissionAttachment(data, "cv", 2)
+	require.ErrorIs(t, err, domainform.ErrAttachmentNotFound)
+
+	_, err = domainform.SubmissionAttachment(data, "name", 0)
+	require.ErrorIs(t, err, domainform.ErrAttachmentNotFound)
+}
+
+func TestSubmissionAttachments(t *testing.T) {
+	content := []byte("%PDF-1.4 sample")
+	schema := model.JSON{"components": []any{
+		map[string]any{"type": "textfield", "key": "name"},
+		map[string]any{"type": "panel", "components": []any{
+			map[string]any{"type": "file", "key": "cv"},
+		}},
+		map[string]any{"type": "file", "key": "photo"},
+	}}
+	data := model.JSON{
+		"name": "Ada",
+		"cv": []any{
+			map[string]any{
+				"originalName": "cv.pdf",
+				"url":          "data:application/pdf;base64," + base64.StdEncoding.EncodeToString(content),
+			},
+			map[string]any{"name": "remote.png", "size": float64(2048), "url": "https://files.example.com/remote.png"},
+		},
+	}
+
+	attachments := domainform.SubmissionAttachments(schema, data)
+	require.Len(t, attachments, 2)
+
+	assert.Equal(t, "cv", attachments[0].Field)
+	assert.Equal(t, "cv.pdf", attachments[0].Name)
+	assert.Equal(t, int64(len(content)), attachments[0].Size)
+	assert.True(t, attachments[0].Inline)
+	assert.Nil(t, attachments[0].Content)
+
+	assert.Equal(t, 1, attachments[1].Index)
+	assert.Equal(t, "image/png", attachments[1].ContentType)
+	assert.Equal(t, int64(2048), attachments[1].Size)
+	assert.False(t, attachments[1].Inline)
+
+	assert.Empty(t, domainform.SubmissionAttachments(model.JSON{}, data))
+}
@@ -0,0 +1,127 @@
+// Package imaging decodes, orients, resizes and re-encodes uploaded images using
+// only the standard library codecs. Re-encoding drops all embedded metadata, so
+// EXIF orientation is applied to the pixels first.
+package imaging
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"image"
+	"image/draw"
+	_ "image/gif" // registered so GIF uploads can be inspected
+	"image/jpeg"
+	"image/png"
+)
+
+const (
+	// FormatJPEG and FormatPNG are the formats that can be re-encoded
+	FormatJPEG = "jpeg"
+	FormatPNG  = "png"
+
+	// DefaultMaxPixels bounds the decoded size of an upload when no limit is
+	// configured, guarding against decompression bombs. Decoded images take 4
+	// bytes per pixel.
+	DefaultMaxPixels = 8_000_000
+
+	// DefaultQuality is the JPEG quality used when none is configured
+	DefaultQuality = 90
+)
+
+// ErrTooLarge is returned for images with more pixels than allowed
+var ErrTooLarge = errors.New("image dimensions too large")
+
+// Decode decodes an image, applying its EXIF orientation. It returns the
+// image and its format name as registered with the image package. Images
+// over maxPixels pixels (DefaultMaxPixels when zero) are rejected from their
+// header, before any pixels are decoded.
+func Decode(data []byte, maxPixels int) (*image.RGBA, string, error) {
+	if maxPixels <= 0 {
+		maxPixels = DefaultMaxPixels
+	}
+
+	cfg, format, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return nil, "", fmt.Errorf("decode image config: %w", err)
+	}
+
+	if int64(cfg.Width)*int64(cfg.Height) > int64(maxPixels) {
+		return nil, "", fmt.Errorf("%w: %dx%d", ErrTooLarge, cfg.Width, cfg.Height)
+	}
+
+	img, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, "", fmt.Errorf("decode image: %w", err)
+	}
+
+	rgba := toRGBA(img)
+
+	if format == FormatJPEG {
+		rgba = Orient(rgba, Orientation(data))
+	}
+
+	return rgba, format, nil
+}
+
+// Encode encodes an image as JPEG or PNG. Quality applies to JPEG only; zero uses DefaultQuality.
+func Encode(img image.Image, format string, quality int) ([]byte, error) {
+	var buf bytes.Buffer
+
+	switch format {
+	case FormatJPEG:
+		if quality <= 0 || quality > 100 {
+			quality = DefaultQuality
+		}
+
+		if err := jpeg.Encode(&buf, img, &jpeg.Options{Quality: quality}); err != nil {
+			return nil, fmt.Errorf("encode jpeg: %w", err)
+		}
+	case FormatPNG:
+		if err := png.Encode(&buf, img); err != nil {
+			return nil, fmt.Errorf("encode png: %w", err)
+		}
+	default:
+		return nil, fmt.Errorf("unsupported image format %q", format)
+	}
+
+	return buf.Bytes(), nil
+}
+
+// Fit scales img down to fit within maxWidth x maxHeight, keeping its aspect ratio.
+// A zero bound is unconstrained. Images that already fit are returned unchanged.
+func Fit(img *image.RGBA, maxWidth, maxHeight int) *image.RGBA {
+	width, height := FitSize(img.Bounds().Dx(), img.Bounds().Dy(), maxWidth, maxHeight)
+	if width == img.Bounds().Dx() && height == img.Bounds().Dy() {
+		return img
+	}
+
+	return resize(img, width, height)
+}
+
+// FitSize returns the dimensions of a width x height image scaled down to fit the bounds
+func FitSize(width, height, maxWidth, maxHeight int) (int, int) {
+	scale := 1.0
+
+	if maxWidth > 0 && width > maxWidth {
+		scale = float64(maxWidth) / float64(width)
+	}
+
+	if maxHeight > 0 && height > maxHeight {
+		scale = min(scale, float64(maxHeight)/float64(height))
+	}
+
+	if scale == 1 {
+		return width, height
+	}
+
+	return max(1, int(float64(width)*scale+0.5)), max(1, int(float64(height)*scale+0.5))
+}
+
+// toRGBA converts any image to RGBA with its origin at zero
+func toRGBA(img image.Image) *image.RGBA {
+	bounds := img.Bounds()
+	rgba := image.NewRGBA(image.Rect(0, 0, bounds.Dx(), bounds.Dy()))
+	draw.Draw(rgba, rgba.Bounds(), img, bounds.Min, draw.Src)
+
+	return rgba
+}
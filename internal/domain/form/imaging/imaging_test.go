@@ -0,0 +1,68 @@
+package imaging_test
+
+import (
+	"image"
+	"image/color"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/goformx/goforms/internal/domain/form/imaging"
+)
+
+func TestFitSize(t *testing.T) {
+	tests := []struct {
+		name                string
+		width, height       int
+		maxWidth, maxHeight int
+		wantW, wantH        int
+	}{
+		{"fits already", 100, 50, 200, 200, 100, 50},
+		{"width bound", 400, 200, 100, 0, 100, 50},
+		{"height bound", 200, 400, 0, 100, 50, 100},
+		{"tighter bound wins", 400, 100, 200, 20, 80, 20},
+		{"never below one pixel", 1000, 1, 10, 0, 10, 1},
+		{"unconstrained", 400, 200, 0, 0, 400, 200},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w, h := imaging.FitSize(tt.width, tt.height, tt.maxWidth, tt.maxHeight)
+			assert.Equal(t, tt.wantW, w)
+			assert.Equal(t, tt.wantH, h)
+		})
+	}
+}
+
+func TestOrient(t *testing.T) {
+	// 2x1 image: red on the left, blue on the right
+	red := color.RGBA{R: 255, A: 255}
+	blue := color.RGBA{B: 255, A: 255}
+	src := image.NewRGBA(image.Rect(0, 0, 2, 1))
+	src.SetRGBA(0, 0, red)
+	src.SetRGBA(1, 0, blue)
+
+	tests := []struct {
+		orientation int
+		size        image.Point
+		first       color.RGBA
+	}{
+		{1, image.Pt(2, 1), red},
+		{2, image.Pt(2, 1), blue},
+		{3, image.Pt(2, 1), blue},
+		{6, image.Pt(1, 2), red},
+		{8, image.Pt(1, 2), blue},
+	}
+
+	for _, tt := range tests {
+		dst := imaging.Orient(src, tt.orientation)
+		assert.Equal(t, tt.size, dst.Bounds().Size(), "orientation %d", tt.orientation)
+		assert.Equal(t, tt.first, dst.RGBAAt(0, 0), "orientation %d", tt.orientation)
+	}
+}
+
+func TestOrientation(t *testing.T) {
+	assert.Equal(t, 1, imaging.Orientation(nil))
+	assert.Equal(t, 1, imaging.Orientation([]byte("\x89PNG\r\n\x1a\n")))
+	assert.Equal(t, 1, imaging.Orientation([]byte{0xFF, 0xD8, 0xFF, 0xDA, 0x00, 0x02}))
+}
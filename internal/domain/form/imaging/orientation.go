@@ -0,0 +1,134 @@
+package imaging
+
+import (
+	"bytes"
+	"encoding/binary"
+	"image"
+)
+
+const (
+	orientationTag = 0x0112
+	jpegMarkerSOS  = 0xDA
+	jpegMarkerAPP1 = 0xE1
+	ifdEntrySize   = 12
+)
+
+// Orientation returns the EXIF orientation (1-8) of a JPEG, or 1 when it has none
+func Orientation(data []byte) int {
+	if len(data) < 4 || data[0] != 0xFF || data[1] != 0xD8 {
+		return 1
+	}
+
+	for offset := 2; offset+4 <= len(data); {
+		if data[offset] != 0xFF {
+			return 1
+		}
+
+		marker := data[offset+1]
+		if marker == jpegMarkerSOS {
+			return 1
+		}
+
+		length := int(binary.BigEndian.Uint16(data[offset+2:]))
+		end := offset + 2 + length
+
+		if length < 2 || end > len(data) {
+			return 1
+		}
+
+		segment := data[offset+4 : end]
+		if marker == jpegMarkerAPP1 && bytes.HasPrefix(segment, []byte("Exif\x00\x00")) {
+			return tiffOrientation(segment[6:])
+		}
+
+		offset = end
+	}
+
+	return 1
+}
+
+// tiffOrientation reads the orientation tag from the first IFD of a TIFF header
+func tiffOrientation(tiff []byte) int {
+	if len(tiff) < 8 {
+		return 1
+	}
+
+	var order binary.ByteOrder
+
+	switch string(tiff[:2]) {
+	case "II":
+		order = binary.LittleEndian
+	case "MM":
+		order = binary.BigEndian
+	default:
+		return 1
+	}
+
+	ifd := int(order.Uint32(tiff[4:]))
+	if ifd+2 > len(tiff) {
+		return 1
+	}
+
+	entries := int(order.Uint16(tiff[ifd:]))
+
+	for i := range entries {
+		entry := ifd + 2 + i*ifdEntrySize
+		if entry+ifdEntrySize > len(tiff) {
+			return 1
+		}
+
+		if order.Uint16(tiff[entry:]) == orientationTag {
+			if value := int(order.Uint16(tiff[entry+8:])); value >= 1 && value <= 8 {
+				return value
+			}
+
+			return 1
+		}
+	}
+
+	return 1
+}
+
+// Orient transforms src so it displays upright for the given EXIF orientation
+func Orient(src *image.RGBA, orientation int) *image.RGBA {
+	if orientation < 2 || orientation > 8 {
+		return src
+	}
+
+	w, h := src.Bounds().Dx(), src.Bounds().Dy()
+
+	// Orientations 5-8 rotate by a quarter turn, swapping the dimensions
+	dstW, dstH := w, h
+	if orientation >= 5 {
+		dstW, dstH = h, w
+	}
+
+	dst := image.NewRGBA(image.Rect(0, 0, dstW, dstH))
+
+	for y := range dstH {
+		for x := range dstW {
+			var sx, sy int
+
+			switch orientation {
+			case 2: // mirrored horizontally
+				sx, sy = w-1-x, y
+			case 3: // rotated 180
+				sx, sy = w-1-x, h-1-y
+			case 4: // mirrored vertically
+				sx, sy = x, h-1-y
+			case 5: // transposed
+				sx, sy = y, x
+			case 6: // needs a quarter turn clockwise
+				sx, sy = y, h-1-x
+			case 7: // transversed
+				sx, sy = w-1-y, h-1-x
+			case 8: // needs a quarter turn counter-clockwise
+				sx, sy = w-1-y, x
+			}
+
+			copy(dst.Pix[y*dst.Stride+x*4:y*dst.Stride+x*4+4], src.Pix[sy*src.Stride+sx*4:])
+		}
+	}
+
+	return dst
+}
@@ -0,0 +1,102 @@
+package imaging
+
+import (
+	"image"
+	"math"
+)
+
+// contribution is the weight of one source pixel in a destination pixel
+type contribution struct {
+	index  int
+	weight float64
+}
+
+// resize scales src to width x height by area averaging. It is meant for
+// downscaling, where every destination pixel covers one or more source pixels.
+// Pixels are premultiplied, so averaging keeps transparent edges clean.
+func resize(src *image.RGBA, width, height int) *image.RGBA {
+	return scaleRows(scaleColumns(src, width), height)
+}
+
+// weights returns, for each destination index, the source indexes it covers and their share
+func weights(srcSize, dstSize int) [][]contribution {
+	scale := float64(srcSize) / float64(dstSize)
+	all := make([][]contribution, dstSize)
+
+	for i := range dstSize {
+		start, end := float64(i)*scale, float64(i+1)*scale
+
+		var row []contribution
+
+		for j := int(start); j < srcSize && float64(j) < end; j++ {
+			coverage := math.Min(end, float64(j+1)) - math.Max(start, float64(j))
+			if coverage > 0 {
+				row = append(row, contribution{index: j, weight: coverage / scale})
+			}
+		}
+
+		all[i] = row
+	}
+
+	return all
+}
+
+// scaleColumns changes the width of src
+func scaleColumns(src *image.RGBA, width int) *image.RGBA {
+	height := src.Bounds().Dy()
+	dst := image.NewRGBA(image.Rect(0, 0, width, height))
+	columns := weights(src.Bounds().Dx(), width)
+
+	for y := range height {
+		srcRow := src.Pix[y*src.Stride:]
+		dstRow := dst.Pix[y*dst.Stride:]
+
+		for x, column := range columns {
+			var sum [4]float64
+
+			for _, c := range column {
+				for k := range 4 {
+					sum[k] += float64(srcRow[c.index*4+k]) * c.weight
+				}
+			}
+
+			for k := range 4 {
+				dstRow[x*4+k] = clamp(sum[k])
+			}
+		}
+	}
+
+	return dst
+}
+
+// scaleRows changes the height of src
+func scaleRows(src *image.RGBA, height int) *image.RGBA {
+	width := src.Bounds().Dx()
+	dst := image.NewRGBA(image.Rect(0, 0, width, height))
+	rows := weights(src.Bounds().Dy(), height)
+
+	for y, row := range rows {
+		dstRow := dst.Pix[y*dst.Stride:]
+
+		for x := range width {
+			var sum [4]float64
+
+			for _, c := range row {
+				offset := c.index*src.Stride + x*4
+				for k := range 4 {
+					sum[k] += float64(src.Pix[offset+k]) * c.weight
+				}
+			}
+
+			for k := range 4 {
+				dstRow[x*4+k] = clamp(sum[k])
+			}
+		}
+	}
+
+	return dst
+}
+
+func clamp(v float64) uint8 {
+	return uint8(math.Max(0, math.Min(255, math.Round(v))))
+}
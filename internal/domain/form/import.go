@@ -0,0 +1,57 @@
+package form
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/goformx/goforms/internal/domain/form/model"
+	"github.com/goformx/goforms/internal/infrastructure/logging"
+)
+
+// ImportService stores historical submissions. Unlike SubmitForm it keeps the
+// submission's original timestamp and status and publishes no events, so
+// imports do not trigger notifications or live streams.
+type ImportService interface {
+	ImportSubmission(ctx context.Context, form *model.Form, submission *model.FormSubmission) error
+}
+
+// importService handles submission import business logic
+type importService struct {
+	repository Repository
+	quotas     QuotaService
+	logger     logging.Logger
+}
+
+// NewImportService creates a new import service. A nil quotas disables quota enforcement.
+func NewImportService(repository Repository, quotas QuotaService, logger logging.Logger) ImportService {
+	return &importService{
+		repository: repository,
+		quotas:     quotas,
+		logger:     logger,
+	}
+}
+
+// ImportSubmission validates and stores one historical submission for form
+func (s *importService) ImportSubmission(ctx context.Context, form *model.Form, submission *model.FormSubmission) error {
+	submission.FormID = form.ID
+
+	if err := submission.Validate(); err != nil {
+		return fmt.Errorf("validate imported submission: %w", err)
+	}
+
+	if err := ApplyComputedFields(form.Schema, submission.Data); err != nil {
+		return fmt.Errorf("compute submission fields: %w", err)
+	}
+
+	if s.quotas != nil {
+		if err := s.quotas.CheckSubmission(ctx, form.UserID, submissionSize(submission.Data)); err != nil {
+			return fmt.Errorf("check submission quota: %w", err)
+		}
+	}
+
+	if err := s.repository.CreateSubmission(ctx, submission); err != nil {
+		return fmt.Errorf("create imported submission: %w", err)
+	}
+
+	return nil
+}
@@ -16,6 +16,9 @@ var (
 	// ErrFormInvalid is returned when a form is invalid
 	ErrFormInvalid = errors.New("form is invalid")
 
+	// ErrFormVersionConflict is returned when a form was modified since the caller last read it
+	ErrFormVersionConflict = errors.New("form was modified by another request")
+
 	// ErrSubmissionNotFound is returned when a form submission cannot be found
 	ErrSubmissionNotFound = errors.New("form submission not found")
 )
@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"slices"
 	"time"
 
 	"database/sql/driver"
@@ -67,11 +68,42 @@ type Form struct {
 	DeletedAt   gorm.DeletedAt `gorm:"index"                                                      json:"-"`
 	Fields      []Field        `gorm:"foreignKey:FormID"                                          json:"fields"`
 	Status      string         `gorm:"size:20;not null;default:'draft'"                           json:"status"`
+	Version     int            `gorm:"not null;default:1"                                         json:"version"`
 
 	// CORS settings for form embedding
 	CorsOrigins JSON `gorm:"type:json" json:"cors_origins"`
 	CorsMethods JSON `gorm:"type:json" json:"cors_methods"`
 	CorsHeaders JSON `gorm:"type:json" json:"cors_headers"`
+
+	// AccessSettings holds the passphrase hash and submitter requirements; see GetAccess
+	AccessSettings JSON `gorm:"type:json" json:"-"`
+
+	// SubmissionWriteMode selects how submissions are stored; see SubmissionWriteModeSync
+	SubmissionWriteMode string `gorm:"size:20;not null;default:''" json:"submission_write_mode"`
+
+	// ThrottleMode switches adaptive submission throttling; see ThrottleModeOff
+	ThrottleMode string `gorm:"size:20;not null;default:''" json:"throttle_mode"`
+
+	// Timezone is the IANA time zone of the form's times; "" uses the owner's. See Location
+	Timezone string `gorm:"size:64;not null;default:''" json:"timezone"`
+
+	// Extensions holds the names of the extensions enabled for the form; see EnabledExtensions
+	Extensions JSON `gorm:"type:json" json:"extensions"`
+
+	// Script holds the submission script run before validation; see ScriptSource
+	Script JSON `gorm:"type:json" json:"script"`
+
+	// NotificationTemplates holds the owner's notification templates by channel; see NotificationTemplate
+	NotificationTemplates JSON `gorm:"type:json" json:"notification_templates"`
+
+	// RedactionPolicy holds how redacted exports treat each column; see GetRedactionPolicy
+	RedactionPolicy JSON `gorm:"type:json" json:"-"`
+
+	// EmbedMethods holds the embed methods the owner allows; see AllowedEmbedMethods
+	EmbedMethods JSON `gorm:"type:json" json:"-"`
+
+	// PublicStats holds which aggregate stats anyone may read; see GetPublicStats
+	PublicStats JSON `gorm:"type:json" json:"-"`
 }
 
 // GetID returns the form's ID
@@ -100,7 +132,11 @@ func (f *Form) BeforeCreate(_ *gorm.DB) error {
 	}
 
 	if f.Status == "" {
-		f.Status = "draft"
+		f.Status = FormStatusDraft
+	}
+
+	if f.Version == 0 {
+		f.Version = 1
 	}
 
 	// Ensure CORS fields are properly initialized
@@ -144,9 +180,74 @@ func (f *Form) BeforeSave(_ *gorm.DB) error {
 	return nil
 }
 
+// Clone returns a deep copy of the form that can be changed without affecting f
+func (f *Form) Clone() *Form {
+	if f == nil {
+		return nil
+	}
+
+	clone := *f
+	clone.Schema = f.Schema.Clone()
+	clone.CorsOrigins = f.CorsOrigins.Clone()
+	clone.CorsMethods = f.CorsMethods.Clone()
+	clone.CorsHeaders = f.CorsHeaders.Clone()
+	clone.AccessSettings = f.AccessSettings.Clone()
+	clone.Extensions = f.Extensions.Clone()
+	clone.Script = f.Script.Clone()
+	clone.NotificationTemplates = f.NotificationTemplates.Clone()
+	clone.RedactionPolicy = f.RedactionPolicy.Clone()
+	clone.EmbedMethods = f.EmbedMethods.Clone()
+	clone.PublicStats = f.PublicStats.Clone()
+
+	if f.Fields != nil {
+		clone.Fields = make([]Field, len(f.Fields))
+		for i, field := range f.Fields {
+			field.Options = slices.Clone(field.Options)
+			clone.Fields[i] = field
+		}
+	}
+
+	return &clone
+}
+
 // JSON is a custom type for handling JSON data
 type JSON map[string]any
 
+// Clone returns a deep copy of j. Nested objects and arrays are copied as well.
+func (j JSON) Clone() JSON {
+	if j == nil {
+		return nil
+	}
+
+	clone := make(JSON, len(j))
+	for key, value := range j {
+		clone[key] = cloneJSONValue(value)
+	}
+
+	return clone
+}
+
+// cloneJSONValue deep copies a value decoded from JSON
+func cloneJSONValue(value any) any {
+	switch typed := value.(type) {
+	case map[string]any:
+		return map[string]any(JSON(typed).Clone())
+	case JSON:
+		return typed.Clone()
+	case []any:
+		clone := make([]any, len(typed))
+		for i, element := range typed {
+			clone[i] = cloneJSONValue(element)
+		}
+
+		return clone
+	case []string:
+		return slices.Clone(typed)
+	default:
+		return value
+	}
+}
+
 // Scan implements the sql.Scanner interface for JSON
 func (j *JSON) Scan(value any) error {
 	if value == nil {
@@ -236,7 +337,8 @@ func NewForm(userID, title, description string, schema JSON) *Form {
 		Description: description,
 		Schema:      schema,
 		Active:      true,
-		Status:      "draft",
+		Status:      FormStatusDraft,
+		Version:     1,
 		CreatedAt:   now,
 		UpdatedAt:   now,
 		DeletedAt:   gorm.DeletedAt{},
@@ -299,7 +401,7 @@ func (f *Form) validateSchema() error {
 		return err
 	}
 
-	return nil
+	return f.validateConfirmation()
 }
 
 // validateRequiredSchemaFields validates that all required schema fields are present
@@ -432,7 +534,11 @@ func extractStringSlice(data JSON, key string) []string {
 		return result
 	}
 
-	// First try to get the value directly by key
+	// First try to get the value directly by key; a form not yet stored holds a []string
+	if arr, ok := data[key].([]string); ok {
+		return arr
+	}
+
 	if arr, ok := data[key].([]any); ok {
 		for _, item := range arr {
 			if str, strOk := item.(string); strOk {
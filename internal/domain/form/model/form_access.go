@@ -0,0 +1,246 @@
+package model
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+
+	"golang.org/x/crypto/bcrypt"
+)
+
+const (
+	// MinPassphraseLength is the shortest passphrase accepted for a form
+	MinPassphraseLength = 4
+	// MaxPassphraseLength is the longest passphrase accepted; bcrypt ignores bytes past 72
+	MaxPassphraseLength = 72
+	// MaxEmailDomains is the maximum number of allowed submitter email domains
+	MaxEmailDomains = 50
+	// MaxCountries is the maximum number of countries in an allowed or blocked country list
+	MaxCountries = 250
+)
+
+var (
+	// ErrPassphraseLength is returned for a passphrase outside 4-72 characters
+	ErrPassphraseLength = errors.New("passphrase must be 4-72 characters")
+
+	// ErrEmailDomainInvalid is returned for an allowed email domain that is not a host name
+	ErrEmailDomainInvalid = errors.New("email domains must be host names such as example.com")
+
+	// ErrTooManyEmailDomains is returned when more than MaxEmailDomains domains are given
+	ErrTooManyEmailDomains = errors.New("at most 50 email domains are allowed")
+
+	// ErrCountryInvalid is returned for a country that is not an ISO 3166-1 alpha-2 code
+	ErrCountryInvalid = errors.New("countries must be ISO 3166-1 alpha-2 codes such as DE")
+
+	// ErrTooManyCountries is returned when a country list has more than MaxCountries countries
+	ErrTooManyCountries = errors.New("at most 250 countries are allowed")
+
+	// ErrCountryListsOverlap is returned when a country is both allowed and blocked
+	ErrCountryListsOverlap = errors.New("a country cannot be both allowed and blocked")
+)
+
+// emailDomainPattern matches a lowercase host name with at least one dot
+var emailDomainPattern = regexp.MustCompile(`^([a-z0-9]([a-z0-9-]*[a-z0-9])?\.)+[a-z]{2,63}$`)
+
+// countryPattern matches an uppercase ISO 3166-1 alpha-2 code
+var countryPattern = regexp.MustCompile(`^[A-Z]{2}$`)
+
+// FormAccess restricts who may open and submit a form. The zero value leaves the form open.
+type FormAccess struct {
+	// PassphraseHash is the bcrypt hash of the passphrase visitors must enter
+	PassphraseHash string
+	// RequireAuth requires submitters to be signed in through the application
+	RequireAuth bool
+	// EmailDomains, when set, limits submitters to these email domains and implies RequireAuth
+	EmailDomains []string
+	// AllowedCountries, when set, limits submissions to these countries, located by IP address
+	AllowedCountries []string
+	// BlockedCountries refuses submissions from these countries
+	BlockedCountries []string
+}
+
+// HasPassphrase reports whether visitors must enter a passphrase
+func (a FormAccess) HasPassphrase() bool {
+	return a.PassphraseHash != ""
+}
+
+// RequiresSubmitter reports whether a verified submitter identity is needed
+func (a FormAccess) RequiresSubmitter() bool {
+	return a.RequireAuth || len(a.EmailDomains) > 0
+}
+
+// IsRestricted reports whether any access control is enabled
+func (a FormAccess) IsRestricted() bool {
+	return a.HasPassphrase() || a.RequiresSubmitter()
+}
+
+// HasCountryRules reports whether submissions are accepted or refused by country
+func (a FormAccess) HasCountryRules() bool {
+	return len(a.AllowedCountries) > 0 || len(a.BlockedCountries) > 0
+}
+
+// AllowsCountry reports whether a submission from country may be accepted.
+// country is "" when the submitter could not be located; such submissions
+// are refused when AllowedCountries is set and accepted otherwise.
+func (a FormAccess) AllowsCountry(country string) bool {
+	country = strings.ToUpper(country)
+
+	if slices.Contains(a.BlockedCountries, country) {
+		return false
+	}
+
+	return len(a.AllowedCountries) == 0 || slices.Contains(a.AllowedCountries, country)
+}
+
+// CheckPassphrase reports whether passphrase matches the stored hash
+func (a FormAccess) CheckPassphrase(passphrase string) bool {
+	if !a.HasPassphrase() {
+		return true
+	}
+
+	return bcrypt.CompareHashAndPassword([]byte(a.PassphraseHash), []byte(passphrase)) == nil
+}
+
+// AllowsEmail reports whether a submitter with email may submit. Subdomains of an
+// allowed domain are accepted.
+func (a FormAccess) AllowsEmail(email string) bool {
+	if len(a.EmailDomains) == 0 {
+		return true
+	}
+
+	at := strings.LastIndex(email, "@")
+	if at < 0 {
+		return false
+	}
+
+	domain := strings.ToLower(strings.TrimSpace(email[at+1:]))
+
+	for _, allowed := range a.EmailDomains {
+		if domain == allowed || strings.HasSuffix(domain, "."+allowed) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// HashPassphrase validates and hashes a form passphrase
+func HashPassphrase(passphrase string) (string, error) {
+	if len(passphrase) < MinPassphraseLength || len(passphrase) > MaxPassphraseLength {
+		return "", ErrPassphraseLength
+	}
+
+	hash, err := bcrypt.GenerateFromPassword([]byte(passphrase), bcrypt.DefaultCost)
+	if err != nil {
+		return "", fmt.Errorf("hash passphrase: %w", err)
+	}
+
+	return string(hash), nil
+}
+
+// NormalizeEmailDomains lowercases, trims and de-duplicates email domains.
+// A leading "@" is accepted and removed.
+func NormalizeEmailDomains(domains []string) ([]string, error) {
+	normalized := make([]string, 0, len(domains))
+
+	for _, domain := range domains {
+		domain = strings.TrimPrefix(strings.ToLower(strings.TrimSpace(domain)), "@")
+		if len(domain) > 253 || !emailDomainPattern.MatchString(domain) {
+			return nil, ErrEmailDomainInvalid
+		}
+
+		if !slices.Contains(normalized, domain) {
+			normalized = append(normalized, domain)
+		}
+	}
+
+	if len(normalized) > MaxEmailDomains {
+		return nil, ErrTooManyEmailDomains
+	}
+
+	return normalized, nil
+}
+
+// NormalizeCountries uppercases, trims and de-duplicates country codes
+func NormalizeCountries(countries []string) ([]string, error) {
+	normalized := make([]string, 0, len(countries))
+
+	for _, country := range countries {
+		country = strings.ToUpper(strings.TrimSpace(country))
+		if !countryPattern.MatchString(country) {
+			return nil, ErrCountryInvalid
+		}
+
+		if !slices.Contains(normalized, country) {
+			normalized = append(normalized, country)
+		}
+	}
+
+	if len(normalized) > MaxCountries {
+		return nil, ErrTooManyCountries
+	}
+
+	return normalized, nil
+}
+
+// GetAccess returns the form's access controls
+func (f *Form) GetAccess() FormAccess {
+	access := FormAccess{
+		EmailDomains:     extractStringSlice(f.AccessSettings, "email_domains"),
+		AllowedCountries: extractStringSlice(f.AccessSettings, "allowed_countries"),
+		BlockedCountries: extractStringSlice(f.AccessSettings, "blocked_countries"),
+	}
+
+	if f.AccessSettings != nil {
+		access.PassphraseHash, _ = f.AccessSettings["passphrase_hash"].(string)
+		access.RequireAuth, _ = f.AccessSettings["require_auth"].(bool)
+	}
+
+	return access
+}
+
+// SetAccess replaces the form's access controls. Email domains and countries are normalized.
+func (f *Form) SetAccess(access FormAccess) error {
+	domains, err := NormalizeEmailDomains(access.EmailDomains)
+	if err != nil {
+		return err
+	}
+
+	allowed, err := NormalizeCountries(access.AllowedCountries)
+	if err != nil {
+		return err
+	}
+
+	blocked, err := NormalizeCountries(access.BlockedCountries)
+	if err != nil {
+		return err
+	}
+
+	for _, country := range allowed {
+		if slices.Contains(blocked, country) {
+			return ErrCountryListsOverlap
+		}
+	}
+
+	f.AccessSettings = JSON{
+		"passphrase_hash":   access.PassphraseHash,
+		"require_auth":      access.RequireAuth,
+		"email_domains":     anySlice(domains),
+		"allowed_countries": anySlice(allowed),
+		"blocked_countries": anySlice(blocked),
+	}
+
+	return nil
+}
+
+// anySlice converts strings for storage in a JSON column
+func anySlice(values []string) []any {
+	items := make([]any, len(values))
+	for i, value := range values {
+		items[i] = value
+	}
+
+	return items
+}
@@ -0,0 +1,69 @@
+package model_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/goformx/goforms/internal/domain/form/model"
+)
+
+func TestForm_Access(t *testing.T) {
+	form := &model.Form{}
+	assert.False(t, form.GetAccess().IsRestricted())
+
+	hash, err := model.HashPassphrase("open sesame")
+	require.NoError(t, err)
+
+	require.NoError(t, form.SetAccess(model.FormAccess{
+		PassphraseHash: hash,
+		EmailDomains:   []string{"@Example.com", "example.com", "corp.example.org"},
+	}))
+
+	access := form.GetAccess()
+	assert.True(t, access.HasPassphrase())
+	assert.True(t, access.RequiresSubmitter(), "email domains imply a signed-in submitter")
+	assert.Equal(t, []string{"example.com", "corp.example.org"}, access.EmailDomains)
+	assert.True(t, access.CheckPassphrase("open sesame"))
+	assert.False(t, access.CheckPassphrase("open"))
+
+	assert.True(t, access.AllowsEmail("ada@example.com"))
+	assert.True(t, access.AllowsEmail("ada@eu.example.com"))
+	assert.False(t, access.AllowsEmail("ada@badexample.com"))
+	assert.False(t, access.AllowsEmail("ada"))
+
+	_, err = model.HashPassphrase("abc")
+	require.ErrorIs(t, err, model.ErrPassphraseLength)
+
+	require.ErrorIs(t, form.SetAccess(model.FormAccess{EmailDomains: []string{"localhost"}}), model.ErrEmailDomainInvalid)
+}
+
+func TestForm_AccessCountries(t *testing.T) {
+	form := &model.Form{}
+	assert.False(t, form.GetAccess().HasCountryRules())
+	assert.True(t, form.GetAccess().AllowsCountry(""))
+
+	require.NoError(t, form.SetAccess(model.FormAccess{AllowedCountries: []string{"de", " AT ", "DE"}}))
+
+	access := form.GetAccess()
+	assert.True(t, access.HasCountryRules())
+	assert.False(t, access.IsRestricted(), "country rules do not gate opening the form")
+	assert.Equal(t, []string{"DE", "AT"}, access.AllowedCountries)
+	assert.True(t, access.AllowsCountry("de"))
+	assert.False(t, access.AllowsCountry("US"))
+	assert.False(t, access.AllowsCountry(""), "unlocated submitters are refused when countries are allowed")
+
+	require.NoError(t, form.SetAccess(model.FormAccess{BlockedCountries: []string{"ru"}}))
+
+	access = form.GetAccess()
+	assert.False(t, access.AllowsCountry("RU"))
+	assert.True(t, access.AllowsCountry("US"))
+	assert.True(t, access.AllowsCountry(""), "unlocated submitters are accepted when only countries are blocked")
+
+	require.ErrorIs(t, form.SetAccess(model.FormAccess{BlockedCountries: []string{"GER"}}), model.ErrCountryInvalid)
+	require.ErrorIs(t, form.SetAccess(model.FormAccess{
+		AllowedCountries: []string{"DE"},
+		BlockedCountries: []string{"de"},
+	}), model.ErrCountryListsOverlap)
+}
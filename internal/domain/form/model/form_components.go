@@ -0,0 +1,72 @@
+package model
+
+import "errors"
+
+// SkipChildren is returned by a WalkComponents callback to skip the children
+// of the component it was called with
+var SkipChildren = errors.New("skip children")
+
+// WalkComponents calls fn for every form.io component in components, in
+// schema order, descending into nested components, columns and table rows.
+// Walking stops at the first error fn returns, other than SkipChildren.
+func WalkComponents(components []any, fn func(component map[string]any) error) error {
+	for _, component := range components {
+		componentMap, ok := component.(map[string]any)
+		if !ok {
+			continue
+		}
+
+		if err := fn(componentMap); err != nil {
+			if errors.Is(err, SkipChildren) {
+				continue
+			}
+
+			return err
+		}
+
+		for _, nestedKey := range []string{"components", "columns"} {
+			if nested, nestedOk := componentMap[nestedKey].([]any); nestedOk {
+				if err := WalkComponents(nested, fn); err != nil {
+					return err
+				}
+			}
+		}
+
+		if rows, rowsOk := componentMap["rows"].([]any); rowsOk {
+			for _, row := range rows {
+				if cells, cellsOk := row.([]any); cellsOk {
+					if err := WalkComponents(cells, fn); err != nil {
+						return err
+					}
+				}
+			}
+		}
+	}
+
+	return nil
+}
+
+// WalkSchema walks the components of a form schema; see WalkComponents
+func WalkSchema(schema JSON, fn func(component map[string]any) error) error {
+	components, ok := schema["components"].([]any)
+	if !ok {
+		return nil
+	}
+
+	return WalkComponents(components, fn)
+}
+
+// IsLayoutComponent reports whether a component only groups other components.
+// Column and table cells carry no type, only nested components.
+func IsLayoutComponent(component map[string]any) bool {
+	switch component["type"] {
+	case "panel", "columns", "fieldset", "well", "table", "tabs":
+		return true
+	case nil:
+		_, hasChildren := component["components"]
+
+		return hasChildren
+	}
+
+	return false
+}
@@ -0,0 +1,43 @@
+package model_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/goformx/goforms/internal/domain/form/model"
+)
+
+func TestWalkSchema(t *testing.T) {
+	schema := model.JSON{"components": []any{
+		map[string]any{"type": "textfield", "key": "name"},
+		map[string]any{"type": "columns", "key": "cols", "columns": []any{
+			map[string]any{"components": []any{map[string]any{"type": "email", "key": "email"}}},
+		}},
+		map[string]any{"type": "table", "key": "table", "rows": []any{
+			[]any{map[string]any{"components": []any{map[string]any{"type": "number", "key": "age"}}}},
+		}},
+		map[string]any{"type": "datagrid", "key": "grid", "components": []any{
+			map[string]any{"type": "textfield", "key": "item"},
+		}},
+	}}
+
+	var keys []string
+
+	require.NoError(t, model.WalkSchema(schema, func(component map[string]any) error {
+		key, _ := component["key"].(string)
+		if key != "" {
+			keys = append(keys, key)
+		}
+
+		if key == "grid" {
+			return model.SkipChildren
+		}
+
+		return nil
+	}))
+
+	assert.Equal(t, []string{"name", "cols", "email", "table", "age", "grid"}, keys)
+	assert.NoError(t, model.WalkSchema(model.JSON{}, func(map[string]any) error { return assert.AnError }))
+}
@@ -0,0 +1,26 @@
+package model
+
+import "fmt"
+
+// MaxConfirmationLength is the maximum length of a form's confirmation message
+const MaxConfirmationLength = 2000
+
+// ConfirmationMessage returns the thank-you message shown after a
+// submission, set in the schema under settings.confirmation.message, or ""
+// when the form has none. The message may reference answers; see form.PipeHTML.
+func (f *Form) ConfirmationMessage() string {
+	settings, _ := f.Schema["settings"].(map[string]any)
+	confirmation, _ := settings["confirmation"].(map[string]any)
+	message, _ := confirmation["message"].(string)
+
+	return message
+}
+
+// validateConfirmation checks the length of the confirmation message
+func (f *Form) validateConfirmation() error {
+	if len(f.ConfirmationMessage()) > MaxConfirmationLength {
+		return fmt.Errorf("confirmation message must not exceed %d characters", MaxConfirmationLength)
+	}
+
+	return nil
+}
@@ -0,0 +1,120 @@
+package model
+
+import (
+	"errors"
+	"net/url"
+	"slices"
+	"strings"
+)
+
+const (
+	// MaxCorsOrigins is the maximum number of allowed origins on a form
+	MaxCorsOrigins = 50
+	// WildcardOrigin allows embedding from any origin
+	WildcardOrigin = "*"
+)
+
+var (
+	// ErrCorsOriginInvalid is returned for an origin that is not scheme://host[:port]
+	ErrCorsOriginInvalid = errors.New("origin must be an http or https scheme and host, such as https://example.com")
+
+	// ErrTooManyCorsOrigins is returned when a form would exceed MaxCorsOrigins origins
+	ErrTooManyCorsOrigins = errors.New("at most 50 allowed origins are supported per form")
+
+	// ErrCorsOriginNotFound is returned when removing an origin the form does not allow
+	ErrCorsOriginNotFound = errors.New("origin is not in the form's allowed origins")
+)
+
+// NormalizeCorsOrigin validates an origin and returns it in the form browsers send
+// in the Origin header: lowercase scheme and host, no trailing slash, default ports dropped.
+// The wildcard "*" is returned unchanged; whether it is permitted is a policy decision.
+func NormalizeCorsOrigin(origin string) (string, error) {
+	origin = strings.TrimSpace(origin)
+	if origin == WildcardOrigin {
+		return origin, nil
+	}
+
+	parsed, err := url.Parse(origin)
+	if err != nil || parsed.Host == "" || parsed.User != nil ||
+		parsed.RawQuery != "" || parsed.Fragment != "" || strings.Contains(parsed.Host, "*") {
+		return "", ErrCorsOriginInvalid
+	}
+
+	if parsed.Path != "" && parsed.Path != "/" {
+		return "", ErrCorsOriginInvalid
+	}
+
+	scheme := strings.ToLower(parsed.Scheme)
+	if scheme != "http" && scheme != "https" {
+		return "", ErrCorsOriginInvalid
+	}
+
+	host := strings.ToLower(parsed.Hostname())
+	port := parsed.Port()
+
+	if (scheme == "http" && port == "80") || (scheme == "https" && port == "443") {
+		port = ""
+	}
+
+	if port != "" {
+		host += ":" + port
+	}
+
+	return scheme + "://" + host, nil
+}
+
+// GetCorsOrigins returns the origins allowed to embed the form
+func (f *Form) GetCorsOrigins() []string {
+	return extractStringSlice(f.CorsOrigins, "origins")
+}
+
+// setCorsOrigins stores origins as []any so they read back the same after a database round trip
+func (f *Form) setCorsOrigins(origins []string) {
+	values := make([]any, len(origins))
+	for i, origin := range origins {
+		values[i] = origin
+	}
+
+	f.CorsOrigins = JSON{"origins": values}
+}
+
+// AddCorsOrigin normalizes origin and adds it to the allowed origins.
+// It reports whether the origin was added, false when it was already allowed.
+func (f *Form) AddCorsOrigin(origin string) (string, bool, error) {
+	normalized, err := NormalizeCorsOrigin(origin)
+	if err != nil {
+		return "", false, err
+	}
+
+	origins := f.GetCorsOrigins()
+	if slices.Contains(origins, normalized) {
+		return normalized, false, nil
+	}
+
+	if len(origins) >= MaxCorsOrigins {
+		return "", false, ErrTooManyCorsOrigins
+	}
+
+	f.setCorsOrigins(append(origins, normalized))
+
+	return normalized, true, nil
+}
+
+// RemoveCorsOrigin removes origin from the allowed origins
+func (f *Form) RemoveCorsOrigin(origin string) (string, error) {
+	normalized, err := NormalizeCorsOrigin(origin)
+	if err != nil {
+		return "", err
+	}
+
+	origins := f.GetCorsOrigins()
+
+	index := slices.Index(origins, normalized)
+	if index < 0 {
+		return "", ErrCorsOriginNotFound
+	}
+
+	f.setCorsOrigins(slices.Delete(origins, index, index+1))
+
+	return normalized, nil
+}
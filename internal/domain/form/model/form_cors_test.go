@@ -0,0 +1,55 @@
+package model_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/goformx/goforms/internal/domain/form/model"
+)
+
+func TestNormalizeCorsOrigin(t *testing.T) {
+	valid := map[string]string{
+		"https://Example.com":       "https://example.com",
+		"https://example.com/":      "https://example.com",
+		"https://example.com:443":   "https://example.com",
+		"http://localhost:5173":     "http://localhost:5173",
+		" https://app.example.com ": "https://app.example.com",
+		"*":                         "*",
+	}
+	for input, expected := range valid {
+		normalized, err := model.NormalizeCorsOrigin(input)
+		require.NoError(t, err, input)
+		assert.Equal(t, expected, normalized)
+	}
+
+	for _, input := range []string{
+		"", "example.com", "ftp://example.com", "https://example.com/path",
+		"https://user@example.com", "https://example.com?x=1", "https://*.example.com",
+	} {
+		_, err := model.NormalizeCorsOrigin(input)
+		require.ErrorIs(t, err, model.ErrCorsOriginInvalid, input)
+	}
+}
+
+func TestForm_AddRemoveCorsOrigin(t *testing.T) {
+	form := &model.Form{CorsOrigins: model.JSON{"origins": []any{"https://a.example"}}}
+
+	origin, added, err := form.AddCorsOrigin("https://B.example/")
+	require.NoError(t, err)
+	assert.True(t, added)
+	assert.Equal(t, "https://b.example", origin)
+
+	_, added, err = form.AddCorsOrigin("https://a.example")
+	require.NoError(t, err)
+	assert.False(t, added)
+	assert.Equal(t, []string{"https://a.example", "https://b.example"}, form.GetCorsOrigins())
+
+	_, err = form.RemoveCorsOrigin("https://a.example")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"https://b.example"}, form.GetCorsOrigins())
+
+	_, err = form.RemoveCorsOrigin("https://a.example")
+	require.ErrorIs(t, err, model.ErrCorsOriginNotFound)
+}
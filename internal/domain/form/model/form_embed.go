@@ -0,0 +1,71 @@
+package model
+
+import (
+	"errors"
+	"slices"
+)
+
+// EmbedMethod is a way a form is put on another site
+type EmbedMethod string
+
+const (
+	// EmbedMethodIframe frames the form's embed page, at /forms/:id/embed or
+	// /embed/frame/:id; the iframe, Hugo and Jekyll snippets use it
+	EmbedMethodIframe EmbedMethod = "iframe"
+	// EmbedMethodScript renders the form on the host page, which calls the public
+	// schema and submit endpoints cross-origin; the script tag and React snippets,
+	// and the standalone HTML export, use it
+	EmbedMethodScript EmbedMethod = "script"
+)
+
+// EmbedMethods lists the embed methods, in the order snippets are offered
+var EmbedMethods = []EmbedMethod{EmbedMethodIframe, EmbedMethodScript}
+
+// ErrEmbedMethodInvalid is returned for an unknown embed method
+var ErrEmbedMethodInvalid = errors.New(`embed methods must be "iframe" or "script"`)
+
+// AllowedEmbedMethods returns the embed methods the form allows. A form that
+// never chose allows them all.
+func (f *Form) AllowedEmbedMethods() []EmbedMethod {
+	if f.EmbedMethods == nil {
+		return slices.Clone(EmbedMethods)
+	}
+
+	names := extractStringSlice(f.EmbedMethods, "allowed")
+	methods := make([]EmbedMethod, 0, len(names))
+
+	for _, method := range EmbedMethods {
+		if slices.Contains(names, string(method)) {
+			methods = append(methods, method)
+		}
+	}
+
+	return methods
+}
+
+// AllowsEmbedMethod reports whether the form may be embedded with method
+func (f *Form) AllowsEmbedMethod(method EmbedMethod) bool {
+	return slices.Contains(f.AllowedEmbedMethods(), method)
+}
+
+// SetAllowedEmbedMethods validates and replaces the allowed embed methods.
+// An empty list allows none, so the form is only reachable on its own pages.
+func (f *Form) SetAllowedEmbedMethods(methods []EmbedMethod) error {
+	for _, method := range methods {
+		if !slices.Contains(EmbedMethods, method) {
+			return ErrEmbedMethodInvalid
+		}
+	}
+
+	values := make([]any, 0, len(methods))
+
+	for _, method := range EmbedMethods {
+		if slices.Contains(methods, method) {
+			values = append(values, string(method))
+		}
+	}
+
+	f.EmbedMethods = JSON{"allowed": values}
+
+	return nil
+}
@@ -0,0 +1,27 @@
+package model_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/goformx/goforms/internal/domain/form/model"
+)
+
+func TestForm_AllowedEmbedMethods(t *testing.T) {
+	form := &model.Form{}
+	assert.Equal(t, model.EmbedMethods, form.AllowedEmbedMethods(), "a form that never chose allows every method")
+
+	require.NoError(t, form.SetAllowedEmbedMethods([]model.EmbedMethod{model.EmbedMethodScript, model.EmbedMethodScript}))
+	assert.Equal(t, []model.EmbedMethod{model.EmbedMethodScript}, form.AllowedEmbedMethods())
+	assert.False(t, form.AllowsEmbedMethod(model.EmbedMethodIframe))
+
+	clone := form.Clone()
+	require.NoError(t, clone.SetAllowedEmbedMethods(nil))
+	assert.Empty(t, clone.AllowedEmbedMethods(), "an empty list allows none")
+	assert.True(t, form.AllowsEmbedMethod(model.EmbedMethodScript), "clone does not share the allowed list")
+
+	require.ErrorIs(t, form.SetAllowedEmbedMethods([]model.EmbedMethod{"popup"}), model.ErrEmbedMethodInvalid)
+	assert.Equal(t, []model.EmbedMethod{model.EmbedMethodScript}, form.AllowedEmbedMethods())
+}
@@ -0,0 +1,75 @@
+package model
+
+import (
+	"errors"
+	"regexp"
+	"slices"
+)
+
+// MaxFormExtensions is the maximum number of extensions enabled on a form
+const MaxFormExtensions = 20
+
+// ExtensionNamePattern matches extension names
+var ExtensionNamePattern = regexp.MustCompile(`^[a-z0-9][a-z0-9_-]{0,63}$`)
+
+var (
+	// ErrExtensionNameInvalid is returned for an extension name that is not lowercase letters, digits, - or _
+	ErrExtensionNameInvalid = errors.New("extension names must be lowercase letters, digits, - or _")
+
+	// ErrTooManyExtensions is returned when a form would enable more than MaxFormExtensions extensions
+	ErrTooManyExtensions = errors.New("at most 20 extensions can be enabled per form")
+)
+
+// EnabledExtensions returns the names of the extensions enabled for the form
+func (f *Form) EnabledExtensions() []string {
+	return extractStringSlice(f.Extensions, "enabled")
+}
+
+// ExtensionEnabled reports whether the named extension is enabled for the form
+func (f *Form) ExtensionEnabled(name string) bool {
+	return slices.Contains(f.EnabledExtensions(), name)
+}
+
+// ValidateExtensionNames checks the names a form would enable
+func ValidateExtensionNames(names []string) error {
+	unique := make(map[string]bool, len(names))
+
+	for _, name := range names {
+		if !ExtensionNamePattern.MatchString(name) {
+			return ErrExtensionNameInvalid
+		}
+
+		unique[name] = true
+	}
+
+	if len(unique) > MaxFormExtensions {
+		return ErrTooManyExtensions
+	}
+
+	return nil
+}
+
+// SetEnabledExtensions validates names and replaces the enabled extensions.
+// Duplicates are dropped; order is kept.
+func (f *Form) SetEnabledExtensions(names []string) error {
+	if err := ValidateExtensionNames(names); err != nil {
+		return err
+	}
+
+	values := make([]any, 0, len(names))
+	seen := make(map[string]bool, len(names))
+
+	for _, name := range names {
+		if seen[name] {
+			continue
+		}
+
+		seen[name] = true
+
+		values = append(values, name)
+	}
+
+	f.Extensions = JSON{"enabled": values}
+
+	return nil
+}
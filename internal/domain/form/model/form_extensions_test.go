@@ -0,0 +1,34 @@
+package model_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/goformx/goforms/internal/domain/form/model"
+)
+
+func TestForm_SetEnabledExtensions(t *testing.T) {
+	form := &model.Form{}
+	assert.False(t, form.ExtensionEnabled("geoip"))
+
+	require.NoError(t, form.SetEnabledExtensions([]string{"geoip", "spam-check", "geoip"}))
+	assert.Equal(t, []string{"geoip", "spam-check"}, form.EnabledExtensions())
+	assert.True(t, form.ExtensionEnabled("spam-check"))
+
+	clone := form.Clone()
+	require.NoError(t, clone.SetEnabledExtensions(nil))
+	assert.Empty(t, clone.EnabledExtensions())
+	assert.True(t, form.ExtensionEnabled("geoip"), "clone does not share the enabled list")
+
+	require.ErrorIs(t, form.SetEnabledExtensions([]string{"Geo IP"}), model.ErrExtensionNameInvalid)
+
+	tooMany := make([]string, model.MaxFormExtensions+1)
+	for i := range tooMany {
+		tooMany[i] = string(rune('a'+i%26)) + string(rune('a'+i/26))
+	}
+
+	require.ErrorIs(t, form.SetEnabledExtensions(tooMany), model.ErrTooManyExtensions)
+	assert.Equal(t, []string{"geoip", "spam-check"}, form.EnabledExtensions())
+}
@@ -0,0 +1,274 @@
+package model
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// Field types the builder offers on top of Form.io's built-in components.
+// Schemas store them as they are; RenderSchema turns them into built-in
+// components for the Form.io renderer, which submits values of the shape
+// described on each type.
+const (
+	// FieldRating is a star rating submitted as a whole number from 1 to its
+	// "scale" property (DefaultRatingScale when unset)
+	FieldRating = "rating"
+	// FieldNPS is a Net Promoter Score question submitted as a whole number from 0 to 10
+	FieldNPS = "nps"
+	// FieldMatrix asks each of its "questions" with the same "values" to choose
+	// from, like Form.io's survey component, and is submitted as an object
+	// mapping question values to chosen values
+	FieldMatrix = "matrix"
+	// FieldAddress is a postal address submitted as an object with the
+	// AddressParts; "countries" optionally limits the countries accepted
+	FieldAddress = "postaladdress"
+	// FieldPhone is a phone number in E.164 form, e.g. +14155550123
+	FieldPhone = "phone"
+)
+
+// Address parts, the keys of a submitted FieldAddress value
+const (
+	AddressStreet     = "street"
+	AddressStreet2    = "street2"
+	AddressCity       = "city"
+	AddressRegion     = "region"
+	AddressPostalCode = "postalCode"
+	AddressCountry    = "country"
+)
+
+const (
+	// DefaultRatingScale is the number of stars of a rating without a scale
+	DefaultRatingScale = 5
+	// MaxRatingScale is the largest rating scale
+	MaxRatingScale = 10
+	// NPSMax is the top of the Net Promoter Score scale
+	NPSMax = 10
+	// surveyType is Form.io's component for matrix questions
+	surveyType = "survey"
+)
+
+// AddressParts are the parts of an address in display order
+var AddressParts = []string{
+	AddressStreet, AddressStreet2, AddressCity, AddressRegion, AddressPostalCode, AddressCountry,
+}
+
+// E164Pattern matches a phone number in E.164 form
+var E164Pattern = regexp.MustCompile(`^\+[1-9][0-9]{1,14}$`)
+
+// Country is a country addresses can be entered for. Its formats, such as
+// postal codes, are checked by the validation package's locale rules.
+type Country struct {
+	// Code is the ISO 3166-1 alpha-2 code
+	Code string
+	Name string
+}
+
+// Countries are the countries offered when an address does not list its own
+var Countries = []Country{
+	{Code: "AE", Name: "United Arab Emirates"},
+	{Code: "AT", Name: "Austria"},
+	{Code: "AU", Name: "Australia"},
+	{Code: "BE", Name: "Belgium"},
+	{Code: "BR", Name: "Brazil"},
+	{Code: "CA", Name: "Canada"},
+	{Code: "CH", Name: "Switzerland"},
+	{Code: "DE", Name: "Germany"},
+	{Code: "DK", Name: "Denmark"},
+	{Code: "ES", Name: "Spain"},
+	{Code: "FR", Name: "France"},
+	{Code: "GB", Name: "United Kingdom"},
+	{Code: "HK", Name: "Hong Kong"},
+	{Code: "IE", Name: "Ireland"},
+	{Code: "IN", Name: "India"},
+	{Code: "IT", Name: "Italy"},
+	{Code: "JP", Name: "Japan"},
+	{Code: "MX", Name: "Mexico"},
+	{Code: "NL", Name: "Netherlands"},
+	{Code: "NO", Name: "Norway"},
+	{Code: "NZ", Name: "New Zealand"},
+	{Code: "PL", Name: "Poland"},
+	{Code: "PT", Name: "Portugal"},
+	{Code: "SE", Name: "Sweden"},
+	{Code: "SG", Name: "Singapore"},
+	{Code: "US", Name: "United States"},
+	{Code: "ZA", Name: "South Africa"},
+}
+
+// LookupCountry returns the country with an ISO 3166-1 alpha-2 code
+func LookupCountry(code string) (Country, bool) {
+	code = strings.ToUpper(strings.TrimSpace(code))
+
+	for _, country := range Countries {
+		if country.Code == code {
+			return country, true
+		}
+	}
+
+	return Country{}, false
+}
+
+// RatingScale returns the number of stars of a rating component
+func RatingScale(component map[string]any) int {
+	var scale int
+
+	switch value := component["scale"].(type) {
+	case float64:
+		scale = int(value)
+	case int:
+		scale = value
+	case string:
+		scale, _ = strconv.Atoi(value)
+	}
+
+	if scale < 2 {
+		return DefaultRatingScale
+	}
+
+	return min(scale, MaxRatingScale)
+}
+
+// AddressCountries returns the countries an address component accepts: its
+// "countries" codes, or all of Countries. Codes without an entry in Countries
+// are kept, named by their code.
+func AddressCountries(component map[string]any) []Country {
+	codes, _ := component["countries"].([]any)
+	if len(codes) == 0 {
+		return Countries
+	}
+
+	countries := make([]Country, 0, len(codes))
+
+	for _, code := range codes {
+		text, ok := code.(string)
+		if !ok || strings.TrimSpace(text) == "" {
+			continue
+		}
+
+		country, known := LookupCountry(text)
+		if !known {
+			country = Country{Code: strings.ToUpper(strings.TrimSpace(text)), Name: strings.ToUpper(strings.TrimSpace(text))}
+		}
+
+		countries = append(countries, country)
+	}
+
+	return countries
+}
+
+// RenderSchema returns a copy of schema whose library field types are replaced
+// by the built-in Form.io components rendering them: ratings and NPS questions
+// become inline radios submitting numbers, matrices become surveys, addresses
+// become containers of their parts and phone numbers become unmasked phone
+// number inputs checked against E.164. Keys, labels, descriptions,
+// conditions and validation of the fields are kept.
+func RenderSchema(schema JSON) JSON {
+	rendered := schema.Clone()
+
+	_ = WalkSchema(rendered, func(component map[string]any) error {
+		componentType, _ := component["type"].(string)
+
+		switch componentType {
+		case FieldRating:
+			renderScale(component, 1, RatingScale(component), "gf-rating")
+		case FieldNPS:
+			renderScale(component, 0, NPSMax, "gf-nps")
+		case FieldMatrix:
+			component["type"] = surveyType
+		case FieldAddress:
+			renderAddress(component)
+		case FieldPhone:
+			renderPhone(component)
+		}
+
+		return nil
+	})
+
+	return rendered
+}
+
+// renderScale turns a component into inline radios for the numbers from low to high
+func renderScale(component map[string]any, low, high int, class string) {
+	values := make([]any, 0, high-low+1)
+	for n := low; n <= high; n++ {
+		values = append(values, map[string]any{"label": strconv.Itoa(n), "value": strconv.Itoa(n)})
+	}
+
+	component["type"] = "radio"
+	component["inline"] = true
+	component["dataType"] = "number"
+	component["values"] = values
+	component["customClass"] = strings.TrimSpace(class + " " + stringOf(component["customClass"]))
+}
+
+// renderAddress turns an address component into a container of its parts.
+// The street, city and country are required when the address is.
+func renderAddress(component map[string]any) {
+	validate, _ := component["validate"].(map[string]any)
+	required, _ := validate["required"].(bool)
+
+	countries := AddressCountries(component)
+	options := make([]any, 0, len(countries))
+
+	for _, country := range countries {
+		options = append(options, map[string]any{"label": country.Name, "value": country.Code})
+	}
+
+	part := func(key, label, autocomplete string, partRequired bool) map[string]any {
+		return map[string]any{
+			"type": "textfield", "key": key, "label": label, "input": true,
+			"autocomplete": autocomplete, "validate": map[string]any{"required": partRequired},
+		}
+	}
+
+	country := map[string]any{
+		"type": "select", "key": AddressCountry, "label": "Country", "input": true, "autocomplete": "country",
+		"dataSrc": "values", "data": map[string]any{"values": options},
+		"validate": map[string]any{"required": required},
+	}
+
+	if len(options) == 1 {
+		country["defaultValue"] = countries[0].Code
+	}
+
+	component["type"] = "container"
+	component["tree"] = true
+	component["components"] = []any{
+		part(AddressStreet, "Street address", "address-line1", required),
+		part(AddressStreet2, "Apartment, suite, etc.", "address-line2", false),
+		part(AddressCity, "City", "address-level2", required),
+		part(AddressRegion, "State / province / region", "address-level1", false),
+		part(AddressPostalCode, "Postal code", "postal-code", false),
+		country,
+	}
+}
+
+// renderPhone turns a phone component into a phone number input without a
+// mask, which accepts international numbers
+func renderPhone(component map[string]any) {
+	validate, _ := component["validate"].(map[string]any)
+	if validate == nil {
+		validate = map[string]any{}
+	}
+
+	validate["pattern"] = E164Pattern.String()
+	if stringOf(validate["customMessage"]) == "" {
+		validate["customMessage"] = "Enter the number in international format, e.g. +14155550123"
+	}
+
+	component["type"] = "phoneNumber"
+	component["inputMask"] = ""
+	component["autocomplete"] = "tel"
+	component["validate"] = validate
+
+	if stringOf(component["placeholder"]) == "" {
+		component["placeholder"] = "+14155550123"
+	}
+}
+
+// stringOf returns value if it is a string, "" otherwise
+func stringOf(value any) string {
+	text, _ := value.(string)
+
+	return text
+}
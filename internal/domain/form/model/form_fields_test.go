@@ -0,0 +1,88 @@
+package model_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/goformx/goforms/internal/domain/form/model"
+)
+
+func TestRatingScale(t *testing.T) {
+	assert.Equal(t, model.DefaultRatingScale, model.RatingScale(map[string]any{}))
+	assert.Equal(t, 3, model.RatingScale(map[string]any{"scale": float64(3)}))
+	assert.Equal(t, 7, model.RatingScale(map[string]any{"scale": "7"}))
+	assert.Equal(t, model.MaxRatingScale, model.RatingScale(map[string]any{"scale": float64(50)}))
+	assert.Equal(t, model.DefaultRatingScale, model.RatingScale(map[string]any{"scale": float64(1)}))
+}
+
+func TestAddressCountries(t *testing.T) {
+	assert.Equal(t, model.Countries, model.AddressCountries(map[string]any{}))
+
+	countries := model.AddressCountries(map[string]any{"countries": []any{"de", "XK", ""}})
+	require.Len(t, countries, 2)
+	assert.Equal(t, "Germany", countries[0].Name)
+	assert.Equal(t, model.Country{Code: "XK", Name: "XK"}, countries[1], "unknown codes are kept, named by their code")
+}
+
+func TestRenderSchema(t *testing.T) {
+	schema := model.JSON{"components": []any{
+		map[string]any{"type": "panel", "key": "p", "components": []any{
+			map[string]any{"type": "rating", "key": "stars", "label": "Rating", "scale": float64(3)},
+		}},
+		map[string]any{"type": "nps", "key": "nps", "customClass": "wide"},
+		map[string]any{"type": "matrix", "key": "grid", "questions": []any{}, "values": []any{}},
+		map[string]any{"type": "postaladdress", "key": "home", "countries": []any{"NL"}, "validate": map[string]any{"required": true}},
+		map[string]any{"type": "phone", "key": "mobile", "validate": map[string]any{"required": true}},
+	}}
+
+	rendered := model.RenderSchema(schema)
+
+	components := map[string]map[string]any{}
+	require.NoError(t, model.WalkSchema(rendered, func(component map[string]any) error {
+		if key, _ := component["key"].(string); key != "" {
+			components[key] = component
+		}
+
+		return nil
+	}))
+
+	stars := components["stars"]
+	assert.Equal(t, "radio", stars["type"])
+	assert.Equal(t, "number", stars["dataType"])
+	assert.Len(t, stars["values"], 3)
+	assert.Equal(t, "Rating", stars["label"], "labels are kept")
+
+	nps := components["nps"]
+	assert.Len(t, nps["values"], 11)
+	assert.Equal(t, map[string]any{"label": "0", "value": "0"}, nps["values"].([]any)[0])
+	assert.Equal(t, "gf-nps wide", nps["customClass"])
+
+	assert.Equal(t, "survey", components["grid"]["type"])
+
+	home := components["home"]
+	assert.Equal(t, "container", home["type"])
+	require.Len(t, home["components"], len(model.AddressParts))
+	assert.Equal(t, true, components["street"]["validate"].(map[string]any)["required"], "the street is required with the address")
+	assert.Equal(t, "NL", components["country"]["defaultValue"], "a single country is preselected")
+
+	mobile := components["mobile"]
+	assert.Equal(t, "phoneNumber", mobile["type"])
+	assert.Equal(t, "", mobile["inputMask"])
+	assert.Equal(t, model.E164Pattern.String(), mobile["validate"].(map[string]any)["pattern"])
+	assert.Equal(t, true, mobile["validate"].(map[string]any)["required"])
+
+	assert.Equal(t, "rating", schema["components"].([]any)[0].(map[string]any)["components"].([]any)[0].(map[string]any)["type"],
+		"the stored schema is left alone")
+}
+
+func TestE164Pattern(t *testing.T) {
+	for _, number := range []string{"+14155550123", "+442071838750", "+81312345678"} {
+		assert.True(t, model.E164Pattern.MatchString(number), number)
+	}
+
+	for _, number := range []string{"14155550123", "+0123456", "+1 415 555 0123", "+1234567890123456"} {
+		assert.False(t, model.E164Pattern.MatchString(number), number)
+	}
+}
@@ -0,0 +1,26 @@
+package model
+
+// NotificationTemplate returns the form's template for a notification
+// channel, or "" when the form uses the default
+func (f *Form) NotificationTemplate(channel string) string {
+	source, _ := f.NotificationTemplates[channel].(string)
+
+	return source
+}
+
+// SetNotificationTemplate replaces the form's template for a channel; ""
+// restores the default
+func (f *Form) SetNotificationTemplate(channel, source string) {
+	templates := f.NotificationTemplates.Clone()
+	if templates == nil {
+		templates = JSON{}
+	}
+
+	if source == "" {
+		delete(templates, channel)
+	} else {
+		templates[channel] = source
+	}
+
+	f.NotificationTemplates = templates
+}
@@ -0,0 +1,23 @@
+package model_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/goformx/goforms/internal/domain/form/model"
+)
+
+func TestForm_SetNotificationTemplate(t *testing.T) {
+	form := &model.Form{}
+	assert.Empty(t, form.NotificationTemplate("slack"))
+
+	form.SetNotificationTemplate("slack", "New submission for {{.FormTitle}}")
+	assert.Equal(t, "New submission for {{.FormTitle}}", form.NotificationTemplate("slack"))
+
+	clone := form.Clone()
+	clone.SetNotificationTemplate("slack", "")
+	assert.Empty(t, clone.NotificationTemplate("slack"))
+	assert.NotNil(t, clone.NotificationTemplates, "an empty map is kept so the reset is saved")
+	assert.Equal(t, "New submission for {{.FormTitle}}", form.NotificationTemplate("slack"), "clone does not share templates")
+}
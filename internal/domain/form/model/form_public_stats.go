@@ -0,0 +1,62 @@
+package model
+
+import "errors"
+
+// ErrPublicStatsRatingField is returned when the published rating field is not
+// a rating field of the form
+var ErrPublicStatsRatingField = errors.New("rating field must be the key of a rating field of the form")
+
+// PublicStats says which aggregate stats of a form anyone may read, e.g. for an
+// "X people responded" badge. Nothing is published until the owner enables it.
+type PublicStats struct {
+	Enabled bool `json:"enabled"`
+	// ResponseCount publishes the number of responses
+	ResponseCount bool `json:"response_count"`
+	// RatingField is the key of a rating field whose average is published; "" publishes none
+	RatingField string `json:"rating_field"`
+}
+
+// GetPublicStats returns the form's public stats settings
+func (f *Form) GetPublicStats() PublicStats {
+	var stats PublicStats
+
+	if f.PublicStats == nil {
+		return stats
+	}
+
+	stats.Enabled, _ = f.PublicStats["enabled"].(bool)
+	stats.ResponseCount, _ = f.PublicStats["response_count"].(bool)
+	stats.RatingField, _ = f.PublicStats["rating_field"].(string)
+
+	return stats
+}
+
+// SetPublicStats validates and replaces the form's public stats settings
+func (f *Form) SetPublicStats(stats PublicStats) error {
+	if stats.RatingField != "" && !f.hasRatingField(stats.RatingField) {
+		return ErrPublicStatsRatingField
+	}
+
+	f.PublicStats = JSON{
+		"enabled":        stats.Enabled,
+		"response_count": stats.ResponseCount,
+		"rating_field":   stats.RatingField,
+	}
+
+	return nil
+}
+
+// hasRatingField reports whether the schema has a rating field with the key
+func (f *Form) hasRatingField(key string) bool {
+	found := false
+
+	_ = WalkSchema(f.Schema, func(component map[string]any) error {
+		if component["key"] == key && component["type"] == FieldRating {
+			found = true
+		}
+
+		return nil
+	})
+
+	return found
+}
@@ -0,0 +1,32 @@
+package model_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/goformx/goforms/internal/domain/form/model"
+)
+
+func TestForm_PublicStats(t *testing.T) {
+	form := &model.Form{Schema: model.JSON{"components": []any{
+		map[string]any{"type": "panel", "key": "page", "components": []any{
+			map[string]any{"type": model.FieldRating, "key": "stars"},
+			map[string]any{"type": "textfield", "key": "name"},
+		}},
+	}}}
+	assert.Equal(t, model.PublicStats{}, form.GetPublicStats(), "stats are private by default")
+
+	stats := model.PublicStats{Enabled: true, ResponseCount: true, RatingField: "stars"}
+	require.NoError(t, form.SetPublicStats(stats))
+	assert.Equal(t, stats, form.GetPublicStats())
+
+	clone := form.Clone()
+	require.NoError(t, clone.SetPublicStats(model.PublicStats{}))
+	assert.True(t, form.GetPublicStats().Enabled, "clone does not share the settings")
+
+	require.ErrorIs(t, form.SetPublicStats(model.PublicStats{Enabled: true, RatingField: "name"}), model.ErrPublicStatsRatingField)
+	require.ErrorIs(t, form.SetPublicStats(model.PublicStats{Enabled: true, RatingField: "missing"}), model.ErrPublicStatsRatingField)
+	assert.Equal(t, stats, form.GetPublicStats())
+}
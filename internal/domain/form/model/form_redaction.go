@@ -0,0 +1,126 @@
+package model
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+)
+
+// RedactionAction is what a redacted export does with a column's values
+type RedactionAction string
+
+const (
+	// RedactionKeep exports the column unchanged, even when it holds personal data
+	RedactionKeep RedactionAction = "keep"
+	// RedactionMask hides most of each value, keeping e.g. an email's domain
+	RedactionMask RedactionAction = "mask"
+	// RedactionHash replaces each value with a hash, so rows can still be matched up
+	RedactionHash RedactionAction = "hash"
+	// RedactionRemove empties the column
+	RedactionRemove RedactionAction = "remove"
+)
+
+// ErrInvalidRedactionAction is returned for an unknown redaction action
+var ErrInvalidRedactionAction = errors.New(`redaction action must be "keep", "mask", "hash" or "remove"`)
+
+// RedactionPolicy says how submissions are redacted in exports shared outside
+// the form owner's team
+type RedactionPolicy struct {
+	// Columns holds the action for a submission data key
+	Columns map[string]RedactionAction `json:"columns"`
+	// Detect is the action for other columns found to hold personal data;
+	// "" exports them unchanged
+	Detect RedactionAction `json:"detect"`
+}
+
+// IsZero reports whether the policy leaves every column unchanged
+func (p RedactionPolicy) IsZero() bool {
+	if p.Detect != "" && p.Detect != RedactionKeep {
+		return false
+	}
+
+	for _, action := range p.Columns {
+		if action != RedactionKeep {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Validate checks the policy's actions
+func (p RedactionPolicy) Validate() error {
+	if p.Detect != "" {
+		if err := validateRedactionAction(p.Detect); err != nil {
+			return fmt.Errorf("detect: %w", err)
+		}
+	}
+
+	keys := make([]string, 0, len(p.Columns))
+	for key := range p.Columns {
+		keys = append(keys, key)
+	}
+
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		if key == "" {
+			return errors.New("columns: column key must not be empty")
+		}
+
+		if err := validateRedactionAction(p.Columns[key]); err != nil {
+			return fmt.Errorf("columns.%s: %w", key, err)
+		}
+	}
+
+	return nil
+}
+
+// validateRedactionAction checks a redaction action
+func validateRedactionAction(action RedactionAction) error {
+	switch action {
+	case RedactionKeep, RedactionMask, RedactionHash, RedactionRemove:
+		return nil
+	default:
+		return ErrInvalidRedactionAction
+	}
+}
+
+// GetRedactionPolicy returns the form's export redaction policy
+func (f *Form) GetRedactionPolicy() RedactionPolicy {
+	policy := RedactionPolicy{Columns: map[string]RedactionAction{}}
+
+	if f.RedactionPolicy == nil {
+		return policy
+	}
+
+	if detect, ok := f.RedactionPolicy["detect"].(string); ok {
+		policy.Detect = RedactionAction(detect)
+	}
+
+	if columns, ok := f.RedactionPolicy["columns"].(map[string]any); ok {
+		for key, action := range columns {
+			if value, isString := action.(string); isString {
+				policy.Columns[key] = RedactionAction(value)
+			}
+		}
+	}
+
+	return policy
+}
+
+// SetRedactionPolicy replaces the form's export redaction policy
+func (f *Form) SetRedactionPolicy(policy RedactionPolicy) error {
+	if err := policy.Validate(); err != nil {
+		return err
+	}
+
+	columns := make(map[string]any, len(policy.Columns))
+	for key, action := range policy.Columns {
+		columns[key] = string(action)
+	}
+
+	f.RedactionPolicy = JSON{"columns": columns, "detect": string(policy.Detect)}
+
+	return nil
+}
@@ -0,0 +1,31 @@
+package model_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/goformx/goforms/internal/domain/form/model"
+)
+
+func TestForm_SetRedactionPolicy(t *testing.T) {
+	form := &model.Form{}
+	assert.True(t, form.GetRedactionPolicy().IsZero())
+
+	policy := model.RedactionPolicy{
+		Columns: map[string]model.RedactionAction{"email": model.RedactionHash, "name": model.RedactionKeep},
+		Detect:  model.RedactionMask,
+	}
+	require.NoError(t, form.SetRedactionPolicy(policy))
+	assert.Equal(t, policy, form.GetRedactionPolicy())
+	assert.False(t, form.GetRedactionPolicy().IsZero())
+
+	clone := form.Clone()
+	require.NoError(t, clone.SetRedactionPolicy(model.RedactionPolicy{}))
+	assert.Equal(t, policy, form.GetRedactionPolicy(), "clone does not share the policy")
+
+	err := form.SetRedactionPolicy(model.RedactionPolicy{Columns: map[string]model.RedactionAction{"ssn": "shred"}})
+	require.ErrorIs(t, err, model.ErrInvalidRedactionAction)
+	assert.Equal(t, policy, form.GetRedactionPolicy(), "an invalid policy is not saved")
+}
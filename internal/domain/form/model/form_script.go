@@ -0,0 +1,28 @@
+package model
+
+// ScriptSource returns the form's submission script, or "" when it has none
+func (f *Form) ScriptSource() string {
+	if f.Script == nil {
+		return ""
+	}
+
+	source, _ := f.Script["source"].(string)
+
+	return source
+}
+
+// HasScript reports whether the form has a submission script
+func (f *Form) HasScript() bool {
+	return f.ScriptSource() != ""
+}
+
+// SetScriptSource replaces the submission script; "" removes it
+func (f *Form) SetScriptSource(source string) {
+	if source == "" {
+		f.Script = JSON{}
+
+		return
+	}
+
+	f.Script = JSON{"source": source}
+}
@@ -16,8 +16,18 @@ type FormSubmission struct {
 	SubmittedAt time.Time        `gorm:"not null"                                                   json:"submitted_at"`
 	Status      SubmissionStatus `gorm:"not null;size:20"                                           json:"status"`
 	Metadata    JSON             `gorm:"type:jsonb"                                                 json:"metadata"`
+	Tags        JSON             `gorm:"type:json"                                                  json:"-"`
 	CreatedAt   time.Time        `gorm:"not null;autoCreateTime"                                    json:"created_at"`
 	UpdatedAt   time.Time        `gorm:"not null;autoUpdateTime"                                    json:"updated_at"`
+	// Test marks a submission made from the builder's preview. Test submissions
+	// are left out of listings, exports and reports unless asked for, and purged
+	// after form.test_submissions.retention.
+	Test bool `gorm:"not null;default:false" json:"test"`
+	// Encrypted reports that the data and metadata are stored encrypted. It is
+	// set as the submission is read or written; the values here are plaintext.
+	Encrypted bool `gorm:"-" json:"encrypted"`
+	// EncryptionKeyID names the key the stored data is encrypted with
+	EncryptionKeyID string `gorm:"-" json:"encryption_key_id,omitempty"`
 }
 
 // GetID returns the submission's ID
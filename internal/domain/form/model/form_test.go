@@ -1,6 +1,7 @@
 package model_test
 
 import (
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/require"
@@ -171,6 +172,23 @@ func TestForm_Validate(t *testing.T) {
 			wantErr:     true,
 			errContains: "invalid type 'invalid' for property 'name'",
 		},
+		{
+			name: "confirmation message too long",
+			form: model.NewForm(
+				"user123",
+				"Test Form",
+				"A test form description",
+				model.JSON{
+					"display":    "form",
+					"components": []any{map[string]any{"type": "textfield", "key": "name"}},
+					"settings": map[string]any{
+						"confirmation": map[string]any{"message": strings.Repeat("x", model.MaxConfirmationLength+1)},
+					},
+				},
+			),
+			wantErr:     true,
+			errContains: "confirmation message must not exceed",
+		},
 	}
 
 	for _, tt := range tests {
@@ -0,0 +1,127 @@
+package model
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+)
+
+// Form statuses
+const (
+	// FormStatusDraft forms are being built and are not yet offered to respondents
+	FormStatusDraft = "draft"
+	// FormStatusPublished forms are offered to respondents
+	FormStatusPublished = "published"
+	// FormStatusArchived forms are retired; their submissions are kept
+	FormStatusArchived = "archived"
+)
+
+// FormStatuses lists every form status
+var FormStatuses = []string{FormStatusDraft, FormStatusPublished, FormStatusArchived}
+
+// FormTransition names a change of a form's status
+type FormTransition string
+
+const (
+	// TransitionPublish offers a draft or archived form to respondents
+	TransitionPublish FormTransition = "publish"
+	// TransitionUnpublish takes a published form back to draft
+	TransitionUnpublish FormTransition = "unpublish"
+	// TransitionArchive retires a draft or published form
+	TransitionArchive FormTransition = "archive"
+)
+
+// formTransition is where a transition may start and where it leads
+type formTransition struct {
+	from []string
+	to   string
+}
+
+// formTransitions is the form lifecycle: each transition with the statuses it may start from
+var formTransitions = map[FormTransition]formTransition{
+	TransitionPublish:   {from: []string{FormStatusDraft, FormStatusArchived}, to: FormStatusPublished},
+	TransitionUnpublish: {from: []string{FormStatusPublished}, to: FormStatusDraft},
+	TransitionArchive:   {from: []string{FormStatusDraft, FormStatusPublished}, to: FormStatusArchived},
+}
+
+var (
+	// ErrFormStatusInvalid is returned for an unknown form status
+	ErrFormStatusInvalid = errors.New("status must be draft, published or archived")
+
+	// ErrFormTransitionInvalid is returned for an unknown transition
+	ErrFormTransitionInvalid = errors.New("transition must be publish, unpublish or archive")
+
+	// ErrFormTransitionNotAllowed is returned for a transition the form's current status does not allow
+	ErrFormTransitionNotAllowed = errors.New("form status does not allow this transition")
+
+	// ErrPublishRequiresOrigins is returned when publishing a form without CORS origins
+	ErrPublishRequiresOrigins = errors.New("CORS origins are required when publishing a form")
+)
+
+// ParseFormTransition validates a transition name
+func ParseFormTransition(value string) (FormTransition, error) {
+	transition := FormTransition(value)
+	if _, ok := formTransitions[transition]; !ok {
+		return "", ErrFormTransitionInvalid
+	}
+
+	return transition, nil
+}
+
+// ValidateFormStatus checks a form status
+func ValidateFormStatus(status string) error {
+	if !slices.Contains(FormStatuses, status) {
+		return ErrFormStatusInvalid
+	}
+
+	return nil
+}
+
+// TransitionTo returns the transition that moves a form from one status to
+// another, or ErrFormTransitionNotAllowed when the lifecycle has none. A form
+// without a status counts as a draft.
+func TransitionTo(from, to string) (FormTransition, error) {
+	if err := ValidateFormStatus(to); err != nil {
+		return "", err
+	}
+
+	if from == "" {
+		from = FormStatusDraft
+	}
+
+	for name, transition := range formTransitions {
+		if transition.to == to && slices.Contains(transition.from, from) {
+			return name, nil
+		}
+	}
+
+	return "", fmt.Errorf("%w: cannot move a %s form to %s", ErrFormTransitionNotAllowed, from, to)
+}
+
+// Transition moves the form along transition and returns the status it left.
+// A form without a status counts as a draft.
+func (f *Form) Transition(transition FormTransition) (string, error) {
+	rule, ok := formTransitions[transition]
+	if !ok {
+		return "", ErrFormTransitionInvalid
+	}
+
+	from := f.Status
+	if from == "" {
+		from = FormStatusDraft
+	}
+
+	if !slices.Contains(rule.from, from) {
+		return "", fmt.Errorf("%w: cannot %s a %s form", ErrFormTransitionNotAllowed, transition, from)
+	}
+
+	if rule.to == FormStatusPublished {
+		if origins, _, _ := f.GetCorsConfig(); len(origins) == 0 {
+			return "", ErrPublishRequiresOrigins
+		}
+	}
+
+	f.Status = rule.to
+
+	return from, nil
+}
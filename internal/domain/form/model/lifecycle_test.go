@@ -0,0 +1,70 @@
+package model_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/goformx/goforms/internal/domain/form/model"
+)
+
+func TestForm_Transition(t *testing.T) {
+	tests := []struct {
+		from       string
+		transition model.FormTransition
+		to         string
+		err        error
+	}{
+		{from: "", transition: model.TransitionPublish, to: model.FormStatusPublished},
+		{from: model.FormStatusDraft, transition: model.TransitionArchive, to: model.FormStatusArchived},
+		{from: model.FormStatusPublished, transition: model.TransitionUnpublish, to: model.FormStatusDraft},
+		{from: model.FormStatusPublished, transition: model.TransitionArchive, to: model.FormStatusArchived},
+		{from: model.FormStatusArchived, transition: model.TransitionPublish, to: model.FormStatusPublished},
+		{from: model.FormStatusPublished, transition: model.TransitionPublish, err: model.ErrFormTransitionNotAllowed},
+		{from: model.FormStatusDraft, transition: model.TransitionUnpublish, err: model.ErrFormTransitionNotAllowed},
+		{from: model.FormStatusArchived, transition: model.TransitionArchive, err: model.ErrFormTransitionNotAllowed},
+		{from: model.FormStatusDraft, transition: "restore", err: model.ErrFormTransitionInvalid},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.from+"/"+string(tt.transition), func(t *testing.T) {
+			form := &model.Form{Status: tt.from, CorsOrigins: model.JSON{"origins": []string{"https://example.com"}}}
+
+			_, err := form.Transition(tt.transition)
+			if tt.err != nil {
+				require.ErrorIs(t, err, tt.err)
+				assert.Equal(t, tt.from, form.Status, "a refused transition leaves the status")
+
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.to, form.Status)
+		})
+	}
+}
+
+func TestForm_TransitionPublishRequiresOrigins(t *testing.T) {
+	form := &model.Form{Status: model.FormStatusDraft}
+
+	_, err := form.Transition(model.TransitionPublish)
+	require.ErrorIs(t, err, model.ErrPublishRequiresOrigins)
+	assert.Equal(t, model.FormStatusDraft, form.Status)
+}
+
+func TestTransitionTo(t *testing.T) {
+	transition, err := model.TransitionTo(model.FormStatusDraft, model.FormStatusPublished)
+	require.NoError(t, err)
+	assert.Equal(t, model.TransitionPublish, transition)
+
+	transition, err = model.TransitionTo(model.FormStatusPublished, model.FormStatusDraft)
+	require.NoError(t, err)
+	assert.Equal(t, model.TransitionUnpublish, transition)
+
+	_, err = model.TransitionTo(model.FormStatusArchived, model.FormStatusDraft)
+	require.ErrorIs(t, err, model.ErrFormTransitionNotAllowed)
+
+	_, err = model.TransitionTo(model.FormStatusDraft, "deleted")
+	require.ErrorIs(t, err, model.ErrFormStatusInvalid)
+}
@@ -0,0 +1,143 @@
+package model
+
+import (
+	"errors"
+	"net/mail"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+const (
+	// MaxReportRecipients is the maximum number of recipients for a scheduled report
+	MaxReportRecipients = 20
+
+	// ReportFormatCSV attaches all new submissions as a CSV file
+	ReportFormatCSV = "csv"
+	// ReportFormatSummary sends the digest body without an attachment
+	ReportFormatSummary = "summary"
+)
+
+var (
+	// ErrReportNameRequired is returned when a report schedule has no name
+	ErrReportNameRequired = errors.New("report name is required")
+
+	// ErrReportCronRequired is returned when a report schedule has no cron expression
+	ErrReportCronRequired = errors.New("report cron expression is required")
+
+	// ErrReportRecipientsRequired is returned when a report schedule has no valid recipients
+	ErrReportRecipientsRequired = errors.New("report requires between 1 and 20 valid recipient addresses")
+
+	// ErrReportFormatInvalid is returned when a report format is not supported
+	ErrReportFormatInvalid = errors.New("report format must be csv or summary")
+
+	// ErrReportTimezoneInvalid is returned when a report timezone cannot be loaded
+	ErrReportTimezoneInvalid = errors.New("report timezone is invalid")
+
+	// ErrReportNotFound is returned when a report schedule cannot be found
+	ErrReportNotFound = errors.New("report schedule not found")
+)
+
+// ReportSchedule is a recurring email digest of a form's submissions
+type ReportSchedule struct {
+	ID         string     `gorm:"column:uuid;primaryKey;type:uuid;default:gen_random_uuid()" json:"id"`
+	FormID     string     `gorm:"not null;index;type:uuid"                                   json:"form_id"`
+	UserID     string     `gorm:"not null;index;type:uuid"                                   json:"user_id"`
+	Name       string     `gorm:"not null;size:100"                                          json:"name"`
+	Cron       string     `gorm:"not null;size:100"                                          json:"cron"`
+	Timezone   string     `gorm:"not null;size:64;default:'UTC'"                             json:"timezone"`
+	Recipients JSON       `gorm:"type:json"                                                  json:"-"`
+	Format     string     `gorm:"not null;size:20;default:'csv'"                             json:"format"`
+	Active     bool       `gorm:"not null;default:true"                                      json:"active"`
+	LastRunAt  *time.Time `json:"last_run_at"`
+	NextRunAt  *time.Time `gorm:"index"                                                      json:"next_run_at"`
+	// FailureCount counts the failed runs since the last successful one
+	FailureCount int       `gorm:"not null;default:0"                                         json:"failure_count"`
+	CreatedAt    time.Time `gorm:"not null;autoCreateTime"                                    json:"created_at"`
+	UpdatedAt    time.Time `gorm:"not null;autoUpdateTime"                                    json:"updated_at"`
+}
+
+// TableName specifies the table name for the ReportSchedule model
+func (r *ReportSchedule) TableName() string {
+	return "form_report_schedules"
+}
+
+// BeforeCreate is a GORM hook that runs before creating a report schedule
+func (r *ReportSchedule) BeforeCreate(_ *gorm.DB) error {
+	if r.ID == "" {
+		r.ID = uuid.New().String()
+	}
+
+	return nil
+}
+
+// GetRecipients returns the report's recipient addresses
+func (r *ReportSchedule) GetRecipients() []string {
+	return extractStringSlice(r.Recipients, "recipients")
+}
+
+// SetRecipients sets the report's recipient addresses, trimming whitespace.
+// Values are stored as []any so they read back the same before and after a database round trip.
+func (r *ReportSchedule) SetRecipients(recipients []string) {
+	cleaned := make([]any, 0, len(recipients))
+
+	for _, recipient := range recipients {
+		if recipient = strings.TrimSpace(recipient); recipient != "" {
+			cleaned = append(cleaned, recipient)
+		}
+	}
+
+	r.Recipients = JSON{"recipients": cleaned}
+}
+
+// Location returns the report's time zone, defaulting to UTC
+func (r *ReportSchedule) Location() (*time.Location, error) {
+	if r.Timezone == "" {
+		return time.UTC, nil
+	}
+
+	loc, err := time.LoadLocation(r.Timezone)
+	if err != nil {
+		return nil, ErrReportTimezoneInvalid
+	}
+
+	return loc, nil
+}
+
+// Validate validates the report schedule. Cron syntax is validated by the service.
+func (r *ReportSchedule) Validate() error {
+	if strings.TrimSpace(r.Name) == "" {
+		return ErrReportNameRequired
+	}
+
+	if strings.TrimSpace(r.Cron) == "" {
+		return ErrReportCronRequired
+	}
+
+	if r.Format == "" {
+		r.Format = ReportFormatCSV
+	}
+
+	if r.Format != ReportFormatCSV && r.Format != ReportFormatSummary {
+		return ErrReportFormatInvalid
+	}
+
+	if _, err := r.Location(); err != nil {
+		return err
+	}
+
+	recipients := r.GetRecipients()
+	if len(recipients) == 0 || len(recipients) > MaxReportRecipients {
+		return ErrReportRecipientsRequired
+	}
+
+	for _, recipient := range recipients {
+		if _, err := mail.ParseAddress(recipient); err != nil {
+			return ErrReportRecipientsRequired
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,245 @@
+package model
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// MigrationAction is what a migration rule does to the answers of a field
+type MigrationAction string
+
+const (
+	// MigrationRename moves the answers of Field to the key To
+	MigrationRename MigrationAction = "rename"
+	// MigrationCast converts the answers of Field to the value type Type
+	MigrationCast MigrationAction = "cast"
+	// MigrationSplit splits text answers of Field at Separator into the keys Into
+	MigrationSplit MigrationAction = "split"
+)
+
+// Value types submitted answers are checked and cast against
+const (
+	ValueTypeString  = "string"
+	ValueTypeNumber  = "number"
+	ValueTypeBoolean = "boolean"
+	ValueTypeArray   = "array"
+)
+
+// defaultSplitSeparator splits answers at spaces, e.g. a full name into first and last names
+const defaultSplitSeparator = " "
+
+// ErrMigrationRuleInvalid is returned for a migration rule that cannot be applied
+var ErrMigrationRuleInvalid = errors.New("invalid migration rule")
+
+// MigrationRule rewrites the answers of one field in stored submissions, so
+// they fit a schema whose field keys or types changed
+type MigrationRule struct {
+	Action MigrationAction `json:"action"`
+	// Field is the key the answers are stored under
+	Field string `json:"field"`
+	// To is the new key of a rename
+	To string `json:"to,omitempty"`
+	// Type is the value type of a cast: string, number, boolean or array
+	Type string `json:"type,omitempty"`
+	// Into are the keys a split fills in order; the last one takes the rest
+	Into []string `json:"into,omitempty"`
+	// Separator is where a split cuts the text, a space by default
+	Separator string `json:"separator,omitempty"`
+}
+
+// Validate checks the rule names its field and what the action needs
+func (r MigrationRule) Validate() error {
+	if r.Field == "" {
+		return fmt.Errorf("%w: field is required", ErrMigrationRuleInvalid)
+	}
+
+	switch r.Action {
+	case MigrationRename:
+		if r.To == "" || r.To == r.Field {
+			return fmt.Errorf("%w: rename of %s needs a different key in to", ErrMigrationRuleInvalid, r.Field)
+		}
+	case MigrationCast:
+		switch r.Type {
+		case ValueTypeString, ValueTypeNumber, ValueTypeBoolean, ValueTypeArray:
+		default:
+			return fmt.Errorf("%w: cast of %s needs a type of string, number, boolean or array", ErrMigrationRuleInvalid, r.Field)
+		}
+	case MigrationSplit:
+		if len(r.Into) < 2 {
+			return fmt.Errorf("%w: split of %s needs at least two keys in into", ErrMigrationRuleInvalid, r.Field)
+		}
+
+		for _, key := range r.Into {
+			if key == "" {
+				return fmt.Errorf("%w: split of %s has an empty key in into", ErrMigrationRuleInvalid, r.Field)
+			}
+		}
+	default:
+		return fmt.Errorf("%w: action must be rename, cast or split", ErrMigrationRuleInvalid)
+	}
+
+	return nil
+}
+
+// Apply rewrites the answer of the rule's field in data and reports whether
+// data changed. Submissions without an answer are left as they are. An error
+// means the answer cannot be migrated, and data is not changed.
+func (r MigrationRule) Apply(data JSON) (bool, error) {
+	value, ok := data[r.Field]
+	if !ok {
+		return false, nil
+	}
+
+	switch r.Action {
+	case MigrationRename:
+		if existing, taken := data[r.To]; taken && !IsEmptyValue(existing) {
+			return false, fmt.Errorf("%s already has an answer", r.To)
+		}
+
+		data[r.To] = value
+		delete(data, r.Field)
+
+		return true, nil
+	case MigrationCast:
+		if IsEmptyValue(value) || ValueType(value) == r.Type {
+			return false, nil
+		}
+
+		cast, err := CastValue(value, r.Type)
+		if err != nil {
+			return false, err
+		}
+
+		data[r.Field] = cast
+
+		return true, nil
+	case MigrationSplit:
+		return r.split(data, value)
+	}
+
+	return false, r.Validate()
+}
+
+// split cuts a text answer into the rule's keys; missing parts are left empty
+func (r MigrationRule) split(data JSON, value any) (bool, error) {
+	if IsEmptyValue(value) {
+		return false, nil
+	}
+
+	text, ok := value.(string)
+	if !ok {
+		return false, fmt.Errorf("%s holds a %s answer, only text can be split", r.Field, DescribeValueType(value))
+	}
+
+	separator := r.Separator
+	if separator == "" {
+		separator = defaultSplitSeparator
+	}
+
+	parts := strings.SplitN(strings.TrimSpace(text), separator, len(r.Into))
+
+	delete(data, r.Field)
+
+	for i, key := range r.Into {
+		data[key] = ""
+		if i < len(parts) {
+			data[key] = strings.TrimSpace(parts[i])
+		}
+	}
+
+	return true, nil
+}
+
+// ValueType names the value type of a submitted answer, or "" for one that is
+// neither text, a number, a boolean nor a list
+func ValueType(value any) string {
+	switch value.(type) {
+	case string:
+		return ValueTypeString
+	case float64, float32, int, int64:
+		return ValueTypeNumber
+	case bool:
+		return ValueTypeBoolean
+	case []any:
+		return ValueTypeArray
+	}
+
+	return ""
+}
+
+// describeValueType names the value type of an answer for error messages
+func DescribeValueType(value any) string {
+	if valueType := ValueType(value); valueType != "" {
+		return valueType
+	}
+
+	return "object"
+}
+
+// IsEmptyValue reports whether an answer was left blank
+func IsEmptyValue(value any) bool {
+	switch v := value.(type) {
+	case nil:
+		return true
+	case string:
+		return strings.TrimSpace(v) == ""
+	}
+
+	return false
+}
+
+// CastValue converts an answer to a value type. Blank answers are kept as they are.
+func CastValue(value any, valueType string) (any, error) {
+	if IsEmptyValue(value) || ValueType(value) == valueType {
+		return value, nil
+	}
+
+	switch valueType {
+	case ValueTypeString:
+		switch v := value.(type) {
+		case float64:
+			return strconv.FormatFloat(v, 'f', -1, 64), nil
+		case bool:
+			return strconv.FormatBool(v), nil
+		}
+	case ValueTypeNumber:
+		switch v := value.(type) {
+		case string:
+			if number, err := strconv.ParseFloat(strings.TrimSpace(v), 64); err == nil {
+				return number, nil
+			}
+		case bool:
+			if v {
+				return float64(1), nil
+			}
+
+			return float64(0), nil
+		}
+	case ValueTypeBoolean:
+		switch v := value.(type) {
+		case string:
+			if parsed, err := strconv.ParseBool(strings.TrimSpace(strings.ToLower(v))); err == nil {
+				return parsed, nil
+			}
+
+			switch strings.TrimSpace(strings.ToLower(v)) {
+			case "yes", "on":
+				return true, nil
+			case "no", "off":
+				return false, nil
+			}
+		case float64:
+			if v == 0 || v == 1 {
+				return v == 1, nil
+			}
+		}
+	case ValueTypeArray:
+		if ValueType(value) != "" {
+			return []any{value}, nil
+		}
+	}
+
+	return nil, fmt.Errorf("cannot cast the %s answer to %s", DescribeValueType(value), valueType)
+}
@@ -0,0 +1,73 @@
+package model_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/goformx/goforms/internal/domain/form/model"
+)
+
+func TestMigrationRule_Validate(t *testing.T) {
+	valid := []model.MigrationRule{
+		{Action: model.MigrationRename, Field: "name", To: "full_name"},
+		{Action: model.MigrationCast, Field: "age", Type: model.ValueTypeNumber},
+		{Action: model.MigrationSplit, Field: "name", Into: []string{"first", "last"}},
+	}
+	for _, rule := range valid {
+		assert.NoError(t, rule.Validate(), rule.Action)
+	}
+
+	invalid := []model.MigrationRule{
+		{Action: model.MigrationRename, To: "full_name"},
+		{Action: model.MigrationRename, Field: "name", To: "name"},
+		{Action: model.MigrationCast, Field: "age", Type: "date"},
+		{Action: model.MigrationSplit, Field: "name", Into: []string{"first"}},
+		{Action: model.MigrationSplit, Field: "name", Into: []string{"first", ""}},
+		{Action: "merge", Field: "name"},
+	}
+	for _, rule := range invalid {
+		assert.ErrorIs(t, rule.Validate(), model.ErrMigrationRuleInvalid, rule)
+	}
+}
+
+func TestMigrationRule_Apply(t *testing.T) {
+	data := model.JSON{"name": "Ada King Lovelace", "age": "36", "member": "yes", "tags": "vip"}
+
+	rules := []model.MigrationRule{
+		{Action: model.MigrationSplit, Field: "name", Into: []string{"first", "last"}},
+		{Action: model.MigrationCast, Field: "age", Type: model.ValueTypeNumber},
+		{Action: model.MigrationCast, Field: "member", Type: model.ValueTypeBoolean},
+		{Action: model.MigrationCast, Field: "tags", Type: model.ValueTypeArray},
+		{Action: model.MigrationRename, Field: "first", To: "given_name"},
+	}
+	for _, rule := range rules {
+		changed, err := rule.Apply(data)
+		require.NoError(t, err, rule)
+		assert.True(t, changed, rule)
+	}
+
+	assert.Equal(t, model.JSON{
+		"given_name": "Ada",
+		"last":       "King Lovelace",
+		"age":        float64(36),
+		"member":     true,
+		"tags":       []any{"vip"},
+	}, data)
+
+	changed, err := model.MigrationRule{Action: model.MigrationCast, Field: "age", Type: model.ValueTypeNumber}.Apply(data)
+	require.NoError(t, err)
+	assert.False(t, changed, "answers of the target type are left alone")
+
+	changed, err = model.MigrationRule{Action: model.MigrationRename, Field: "missing", To: "other"}.Apply(data)
+	require.NoError(t, err)
+	assert.False(t, changed, "submissions without an answer are left alone")
+
+	_, err = model.MigrationRule{Action: model.MigrationRename, Field: "given_name", To: "last"}.Apply(data)
+	require.Error(t, err, "a rename does not overwrite an answer")
+
+	_, err = model.MigrationRule{Action: model.MigrationCast, Field: "last", Type: model.ValueTypeNumber}.Apply(data)
+	require.Error(t, err)
+	assert.Equal(t, "King Lovelace", data["last"], "a failed rule leaves the answer")
+}
@@ -0,0 +1,52 @@
+package model
+
+import (
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// SchemaStack is the history of a form's schema edits a revision belongs to
+type SchemaStack string
+
+const (
+	// SchemaStackUndo holds the schemas a form had before its latest edits
+	SchemaStackUndo SchemaStack = "undo"
+	// SchemaStackRedo holds the schemas undone since the latest edit
+	SchemaStackRedo SchemaStack = "redo"
+)
+
+var (
+	// ErrNothingToUndo is returned when a form has no schema edit to undo
+	ErrNothingToUndo = errors.New("no schema edit to undo")
+
+	// ErrNothingToRedo is returned when a form has no undone schema edit to redo
+	ErrNothingToRedo = errors.New("no schema edit to redo")
+)
+
+// SchemaRevision is a form schema kept in the form's undo or redo history
+type SchemaRevision struct {
+	ID     string      `gorm:"column:uuid;primaryKey;type:uuid;default:gen_random_uuid()" json:"id"`
+	FormID string      `gorm:"not null;index;type:uuid"                                   json:"form_id"`
+	Stack  SchemaStack `gorm:"not null;size:10"                                           json:"stack"`
+	Schema JSON        `gorm:"type:json"                                                  json:"schema"`
+	// Seq orders the revisions of a stack; the highest is the top
+	Seq       int64     `gorm:"not null"               json:"seq"`
+	CreatedAt time.Time `gorm:"not null;autoCreateTime" json:"created_at"`
+}
+
+// TableName specifies the table name for the SchemaRevision model
+func (r *SchemaRevision) TableName() string {
+	return "form_schema_revisions"
+}
+
+// BeforeCreate is a GORM hook that runs before creating a schema revision
+func (r *SchemaRevision) BeforeCreate(_ *gorm.DB) error {
+	if r.ID == "" {
+		r.ID = uuid.New().String()
+	}
+
+	return nil
+}
@@ -0,0 +1,87 @@
+package model
+
+import (
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// SubmissionActivityKind is the type of a submission activity record
+type SubmissionActivityKind string
+
+const (
+	// ActivityStatusChanged records a change of submission status
+	ActivityStatusChanged SubmissionActivityKind = "status_changed"
+	// ActivityNote records a note left on a submission by a form owner
+	ActivityNote SubmissionActivityKind = "note"
+	// ActivityWebhookDelivery records the outcome of a post-submit hook
+	ActivityWebhookDelivery SubmissionActivityKind = "webhook_delivery"
+)
+
+// MaxNoteLength is the maximum length of a submission note
+const MaxNoteLength = 5000
+
+var (
+	// ErrNoteRequired is returned when a note has no text
+	ErrNoteRequired = errors.New("note text is required")
+
+	// ErrNoteTooLong is returned when a note exceeds MaxNoteLength
+	ErrNoteTooLong = errors.New("notes must be at most 5000 characters")
+
+	// ErrSubmissionStatusInvalid is returned for an unknown submission status
+	ErrSubmissionStatusInvalid = errors.New("status must be pending, processing, completed or failed")
+)
+
+// SubmissionActivity is one entry in a submission's history: a status change,
+// a note or a webhook delivery. Details holds the kind-specific values.
+type SubmissionActivity struct {
+	ID           string                 `gorm:"column:uuid;primaryKey;type:uuid;default:gen_random_uuid()" json:"id"`
+	FormID       string                 `gorm:"not null;index;type:uuid"                                   json:"form_id"`
+	SubmissionID string                 `gorm:"not null;index;type:uuid"                                   json:"submission_id"`
+	Kind         SubmissionActivityKind `gorm:"not null;size:30"                                           json:"kind"`
+	ActorID      string                 `gorm:"size:36"                                                    json:"actor_id,omitempty"`
+	Details      JSON                   `gorm:"type:json"                                                  json:"details"`
+	CreatedAt    time.Time              `gorm:"not null;autoCreateTime"                                    json:"created_at"`
+}
+
+// TableName specifies the table name for the SubmissionActivity model
+func (a *SubmissionActivity) TableName() string {
+	return "form_submission_activities"
+}
+
+// BeforeCreate is a GORM hook that runs before creating a submission activity
+func (a *SubmissionActivity) BeforeCreate(_ *gorm.DB) error {
+	if a.ID == "" {
+		a.ID = uuid.New().String()
+	}
+
+	return nil
+}
+
+// ValidSubmissionStatus reports whether status is a known submission status
+func ValidSubmissionStatus(status SubmissionStatus) bool {
+	switch status {
+	case SubmissionStatusPending, SubmissionStatusProcessing, SubmissionStatusCompleted, SubmissionStatusFailed:
+		return true
+	}
+
+	return false
+}
+
+// NormalizeNote trims a note and checks its length
+func NormalizeNote(text string) (string, error) {
+	text = strings.TrimSpace(text)
+
+	if text == "" {
+		return "", ErrNoteRequired
+	}
+
+	if len([]rune(text)) > MaxNoteLength {
+		return "", ErrNoteTooLong
+	}
+
+	return text, nil
+}
@@ -0,0 +1,244 @@
+package model
+
+import (
+	"errors"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+const (
+	// MaxSubmissionTags is the maximum number of tags on a submission or view
+	MaxSubmissionTags = 20
+	// MaxTagLength is the maximum length of a single tag
+	MaxTagLength = 50
+	// MaxViewNameLength is the maximum length of a saved view's name
+	MaxViewNameLength = 100
+	// MaxViewSearchLength is the maximum length of a saved view's search text
+	MaxViewSearchLength = 200
+)
+
+var (
+	// ErrTagInvalid is returned when a tag is empty, too long or uses unsupported characters
+	ErrTagInvalid = errors.New("tags must be 1-50 characters of letters, digits, spaces, '-', '_' or ':'")
+
+	// ErrTooManyTags is returned when more than MaxSubmissionTags tags are given
+	ErrTooManyTags = errors.New("at most 20 tags are allowed")
+
+	// ErrViewNameRequired is returned when a saved view has no name
+	ErrViewNameRequired = errors.New("view name is required")
+
+	// ErrViewNameTooLong is returned when a saved view name exceeds 100 characters
+	ErrViewNameTooLong = errors.New("view name must be at most 100 characters")
+
+	// ErrViewStatusInvalid is returned when a saved view filters on an unknown status
+	ErrViewStatusInvalid = errors.New("view status must be pending, processing, completed or failed")
+
+	// ErrViewSearchTooLong is returned when a saved view's search text is too long
+	ErrViewSearchTooLong = errors.New("view search must be at most 200 characters")
+
+	// ErrViewNotFound is returned when a saved view cannot be found
+	ErrViewNotFound = errors.New("submission view not found")
+)
+
+// tagPattern matches a normalized tag
+var tagPattern = regexp.MustCompile(`^[a-z0-9][a-z0-9 _:-]*$`)
+
+// NormalizeTags lowercases, trims and de-duplicates tags, preserving their order
+func NormalizeTags(tags []string) ([]string, error) {
+	normalized := make([]string, 0, len(tags))
+
+	for _, tag := range tags {
+		tag = strings.ToLower(strings.TrimSpace(tag))
+		if tag == "" || len(tag) > MaxTagLength || !tagPattern.MatchString(tag) {
+			return nil, ErrTagInvalid
+		}
+
+		if !slices.Contains(normalized, tag) {
+			normalized = append(normalized, tag)
+		}
+	}
+
+	if len(normalized) > MaxSubmissionTags {
+		return nil, ErrTooManyTags
+	}
+
+	return normalized, nil
+}
+
+// tagsJSON stores tags as []any so they read back the same before and after a database round trip
+func tagsJSON(tags []string) JSON {
+	values := make([]any, len(tags))
+	for i, tag := range tags {
+		values[i] = tag
+	}
+
+	return JSON{"tags": values}
+}
+
+// GetTags returns the submission's tags
+func (fs *FormSubmission) GetTags() []string {
+	return extractStringSlice(fs.Tags, "tags")
+}
+
+// SetTags replaces the submission's tags
+func (fs *FormSubmission) SetTags(tags []string) error {
+	normalized, err := NormalizeTags(tags)
+	if err != nil {
+		return err
+	}
+
+	fs.Tags = tagsJSON(normalized)
+
+	return nil
+}
+
+// HasTag reports whether the submission carries tag
+func (fs *FormSubmission) HasTag(tag string) bool {
+	return slices.Contains(fs.GetTags(), tag)
+}
+
+// SubmissionFilter selects submissions by tags, status and free text.
+// A submission matches when it has every tag, the status (if set) and
+// a data value containing the search text (if set).
+type SubmissionFilter struct {
+	Tags   []string
+	Status SubmissionStatus
+	Search string
+}
+
+// IsEmpty reports whether the filter matches every submission
+func (f SubmissionFilter) IsEmpty() bool {
+	return len(f.Tags) == 0 && f.Status == "" && f.Search == ""
+}
+
+// Matches reports whether the submission satisfies the filter
+func (f SubmissionFilter) Matches(submission *FormSubmission) bool {
+	if f.Status != "" && submission.Status != f.Status {
+		return false
+	}
+
+	for _, tag := range f.Tags {
+		if !submission.HasTag(tag) {
+			return false
+		}
+	}
+
+	if f.Search == "" {
+		return true
+	}
+
+	search := strings.ToLower(f.Search)
+
+	for _, value := range submission.Data {
+		if str, ok := value.(string); ok && strings.Contains(strings.ToLower(str), search) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Apply returns the submissions that match the filter
+func (f SubmissionFilter) Apply(submissions []*FormSubmission) []*FormSubmission {
+	if f.IsEmpty() {
+		return submissions
+	}
+
+	matched := make([]*FormSubmission, 0, len(submissions))
+
+	for _, submission := range submissions {
+		if f.Matches(submission) {
+			matched = append(matched, submission)
+		}
+	}
+
+	return matched
+}
+
+// SubmissionView is a saved submission filter on a form, such as "unread high-priority"
+type SubmissionView struct {
+	ID        string    `gorm:"column:uuid;primaryKey;type:uuid;default:gen_random_uuid()" json:"id"`
+	FormID    string    `gorm:"not null;index;type:uuid"                                   json:"form_id"`
+	UserID    string    `gorm:"not null;index;type:uuid"                                   json:"user_id"`
+	Name      string    `gorm:"not null;size:100"                                          json:"name"`
+	Tags      JSON      `gorm:"type:json"                                                  json:"-"`
+	Status    string    `gorm:"size:20"                                                    json:"status"`
+	Search    string    `gorm:"size:200"                                                   json:"search"`
+	CreatedAt time.Time `gorm:"not null;autoCreateTime"                                    json:"created_at"`
+	UpdatedAt time.Time `gorm:"not null;autoUpdateTime"                                    json:"updated_at"`
+}
+
+// TableName specifies the table name for the SubmissionView model
+func (v *SubmissionView) TableName() string {
+	return "form_submission_views"
+}
+
+// BeforeCreate is a GORM hook that runs before creating a submission view
+func (v *SubmissionView) BeforeCreate(_ *gorm.DB) error {
+	if v.ID == "" {
+		v.ID = uuid.New().String()
+	}
+
+	return nil
+}
+
+// GetTags returns the tags the view filters on
+func (v *SubmissionView) GetTags() []string {
+	return extractStringSlice(v.Tags, "tags")
+}
+
+// SetTags sets the tags the view filters on
+func (v *SubmissionView) SetTags(tags []string) error {
+	normalized, err := NormalizeTags(tags)
+	if err != nil {
+		return err
+	}
+
+	v.Tags = tagsJSON(normalized)
+
+	return nil
+}
+
+// Filter returns the view's submission filter
+func (v *SubmissionView) Filter() SubmissionFilter {
+	return SubmissionFilter{
+		Tags:   v.GetTags(),
+		Status: SubmissionStatus(v.Status),
+		Search: v.Search,
+	}
+}
+
+// Validate validates the submission view
+func (v *SubmissionView) Validate() error {
+	v.Name = strings.TrimSpace(v.Name)
+	v.Search = strings.TrimSpace(v.Search)
+
+	if v.Name == "" {
+		return ErrViewNameRequired
+	}
+
+	if len(v.Name) > MaxViewNameLength {
+		return ErrViewNameTooLong
+	}
+
+	if len(v.Search) > MaxViewSearchLength {
+		return ErrViewSearchTooLong
+	}
+
+	switch SubmissionStatus(v.Status) {
+	case "", SubmissionStatusPending, SubmissionStatusProcessing, SubmissionStatusCompleted, SubmissionStatusFailed:
+	default:
+		return ErrViewStatusInvalid
+	}
+
+	if _, err := NormalizeTags(v.GetTags()); err != nil {
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,77 @@
+package model_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/goformx/goforms/internal/domain/form/model"
+)
+
+func TestNormalizeTags(t *testing.T) {
+	tags, err := model.NormalizeTags([]string{" Unread ", "high-priority", "unread", "team:sales"})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"unread", "high-priority", "team:sales"}, tags)
+
+	_, err = model.NormalizeTags([]string{""})
+	require.ErrorIs(t, err, model.ErrTagInvalid)
+
+	_, err = model.NormalizeTags([]string{"<script>"})
+	require.ErrorIs(t, err, model.ErrTagInvalid)
+
+	_, err = model.NormalizeTags([]string{strings.Repeat("a", model.MaxTagLength+1)})
+	require.ErrorIs(t, err, model.ErrTagInvalid)
+
+	many := make([]string, model.MaxSubmissionTags+1)
+	for i := range many {
+		many[i] = "tag" + strings.Repeat("x", i)
+	}
+
+	_, err = model.NormalizeTags(many)
+	require.ErrorIs(t, err, model.ErrTooManyTags)
+}
+
+func TestSubmissionFilter_Apply(t *testing.T) {
+	newSubmission := func(status model.SubmissionStatus, name string, tags ...string) *model.FormSubmission {
+		s := &model.FormSubmission{Status: status, Data: model.JSON{"name": name}}
+		require.NoError(t, s.SetTags(tags))
+
+		return s
+	}
+
+	urgent := newSubmission(model.SubmissionStatusPending, "Ada Lovelace", "unread", "high-priority")
+	read := newSubmission(model.SubmissionStatusCompleted, "Alan Turing", "high-priority")
+	plain := newSubmission(model.SubmissionStatusPending, "Grace Hopper")
+	all := []*model.FormSubmission{urgent, read, plain}
+
+	tests := []struct {
+		name   string
+		filter model.SubmissionFilter
+		want   []*model.FormSubmission
+	}{
+		{name: "empty filter", filter: model.SubmissionFilter{}, want: all},
+		{name: "all tags required", filter: model.SubmissionFilter{Tags: []string{"unread", "high-priority"}}, want: []*model.FormSubmission{urgent}},
+		{name: "single tag", filter: model.SubmissionFilter{Tags: []string{"high-priority"}}, want: []*model.FormSubmission{urgent, read}},
+		{name: "status", filter: model.SubmissionFilter{Status: model.SubmissionStatusPending}, want: []*model.FormSubmission{urgent, plain}},
+		{name: "search", filter: model.SubmissionFilter{Search: "grace"}, want: []*model.FormSubmission{plain}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.filter.Apply(all))
+		})
+	}
+}
+
+func TestSubmissionView_Validate(t *testing.T) {
+	view := &model.SubmissionView{Name: "  Unread high-priority ", Status: "pending"}
+	require.NoError(t, view.SetTags([]string{"unread", "high-priority"}))
+	require.NoError(t, view.Validate())
+	assert.Equal(t, "Unread high-priority", view.Name)
+	assert.Equal(t, []string{"unread", "high-priority"}, view.Filter().Tags)
+
+	require.ErrorIs(t, (&model.SubmissionView{}).Validate(), model.ErrViewNameRequired)
+	require.ErrorIs(t, (&model.SubmissionView{Name: "x", Status: "archived"}).Validate(), model.ErrViewStatusInvalid)
+}
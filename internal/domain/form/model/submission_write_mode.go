@@ -0,0 +1,28 @@
+package model
+
+import "errors"
+
+const (
+	// SubmissionWriteModeDefault stores submissions as configured by form.write_behind
+	SubmissionWriteModeDefault = ""
+	// SubmissionWriteModeSync always stores submissions before the submit request returns
+	SubmissionWriteModeSync = "sync"
+)
+
+// ErrInvalidSubmissionWriteMode is returned for an unknown submission write mode
+var ErrInvalidSubmissionWriteMode = errors.New(`submission_write_mode must be "" or "sync"`)
+
+// ValidateSubmissionWriteMode checks a submission write mode
+func ValidateSubmissionWriteMode(mode string) error {
+	switch mode {
+	case SubmissionWriteModeDefault, SubmissionWriteModeSync:
+		return nil
+	default:
+		return ErrInvalidSubmissionWriteMode
+	}
+}
+
+// RequiresSyncSubmissions reports whether the form opted out of write-behind storage
+func (f *Form) RequiresSyncSubmissions() bool {
+	return f.SubmissionWriteMode == SubmissionWriteModeSync
+}
@@ -0,0 +1,64 @@
+package model
+
+import "errors"
+
+// TestSubmissionFilter says whether a listing shows test submissions
+type TestSubmissionFilter string
+
+const (
+	// TestSubmissionsExclude leaves test submissions out; it is the default
+	TestSubmissionsExclude TestSubmissionFilter = ""
+	// TestSubmissionsOnly shows only test submissions
+	TestSubmissionsOnly TestSubmissionFilter = "only"
+	// TestSubmissionsInclude shows test submissions alongside the others
+	TestSubmissionsInclude TestSubmissionFilter = "include"
+)
+
+// ErrTestSubmissionFilterInvalid is returned for an unknown test submission filter
+var ErrTestSubmissionFilterInvalid = errors.New(`test must be "exclude", "only" or "include"`)
+
+// ParseTestSubmissionFilter reads a test submission filter; "" and "exclude" leave test submissions out
+func ParseTestSubmissionFilter(value string) (TestSubmissionFilter, error) {
+	switch filter := TestSubmissionFilter(value); filter {
+	case TestSubmissionsExclude, TestSubmissionsOnly, TestSubmissionsInclude:
+		return filter, nil
+	case "exclude":
+		return TestSubmissionsExclude, nil
+	default:
+		return TestSubmissionsExclude, ErrTestSubmissionFilterInvalid
+	}
+}
+
+// Matches reports whether the filter shows the submission
+func (f TestSubmissionFilter) Matches(submission *FormSubmission) bool {
+	switch f {
+	case TestSubmissionsOnly:
+		return submission.Test
+	case TestSubmissionsInclude:
+		return true
+	default:
+		return !submission.Test
+	}
+}
+
+// Apply returns the submissions the filter shows
+func (f TestSubmissionFilter) Apply(submissions []*FormSubmission) []*FormSubmission {
+	if f == TestSubmissionsInclude {
+		return submissions
+	}
+
+	matched := make([]*FormSubmission, 0, len(submissions))
+
+	for _, submission := range submissions {
+		if f.Matches(submission) {
+			matched = append(matched, submission)
+		}
+	}
+
+	return matched
+}
+
+// WithoutTestSubmissions returns the submissions that are not test submissions
+func WithoutTestSubmissions(submissions []*FormSubmission) []*FormSubmission {
+	return TestSubmissionsExclude.Apply(submissions)
+}
@@ -0,0 +1,32 @@
+package model_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/goformx/goforms/internal/domain/form/model"
+)
+
+func TestTestSubmissionFilter(t *testing.T) {
+	live := &model.FormSubmission{ID: "live"}
+	test := &model.FormSubmission{ID: "test", Test: true}
+	submissions := []*model.FormSubmission{live, test}
+
+	for value, want := range map[string][]*model.FormSubmission{
+		"":        {live},
+		"exclude": {live},
+		"only":    {test},
+		"include": {live, test},
+	} {
+		filter, err := model.ParseTestSubmissionFilter(value)
+		require.NoError(t, err, value)
+		assert.Equal(t, want, filter.Apply(submissions), value)
+	}
+
+	assert.Equal(t, []*model.FormSubmission{live}, model.WithoutTestSubmissions(submissions))
+
+	_, err := model.ParseTestSubmissionFilter("true")
+	require.ErrorIs(t, err, model.ErrTestSubmissionFilterInvalid)
+}
@@ -0,0 +1,30 @@
+package model
+
+import "errors"
+
+const (
+	// ThrottleModeDefault throttles submission spikes when form.throttle is enabled
+	ThrottleModeDefault = ""
+	// ThrottleModeAdaptive is ThrottleModeDefault, set explicitly to turn throttling back on
+	ThrottleModeAdaptive = "adaptive"
+	// ThrottleModeOff never throttles the form's submissions
+	ThrottleModeOff = "off"
+)
+
+// ErrInvalidThrottleMode is returned for an unknown throttle mode
+var ErrInvalidThrottleMode = errors.New(`throttle_mode must be "", "adaptive" or "off"`)
+
+// ValidateThrottleMode checks a throttle mode
+func ValidateThrottleMode(mode string) error {
+	switch mode {
+	case ThrottleModeDefault, ThrottleModeAdaptive, ThrottleModeOff:
+		return nil
+	default:
+		return ErrInvalidThrottleMode
+	}
+}
+
+// ThrottleDisabled reports whether the owner switched off submission throttling
+func (f *Form) ThrottleDisabled() bool {
+	return f.ThrottleMode == ThrottleModeOff
+}
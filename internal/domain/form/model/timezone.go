@@ -0,0 +1,55 @@
+package model
+
+import (
+	"errors"
+	"time"
+)
+
+// MaxTimezoneLength is the longest time zone name stored for a form or account
+const MaxTimezoneLength = 64
+
+// ErrTimezoneInvalid is returned for a name that is not an IANA time zone
+var ErrTimezoneInvalid = errors.New("timezone must be an IANA time zone name such as Europe/Berlin")
+
+// LoadTimezone returns the IANA time zone called name; "" is UTC. "Local" is
+// refused, as it would depend on the server's settings.
+func LoadTimezone(name string) (*time.Location, error) {
+	if name == "" {
+		return time.UTC, nil
+	}
+
+	if name == "Local" || len(name) > MaxTimezoneLength {
+		return nil, ErrTimezoneInvalid
+	}
+
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		return nil, ErrTimezoneInvalid
+	}
+
+	return loc, nil
+}
+
+// ValidateTimezone checks a time zone name; "" leaves the default in place
+func ValidateTimezone(name string) error {
+	_, err := LoadTimezone(name)
+
+	return err
+}
+
+// Location returns the time zone the form's times are shown in: the form's
+// own, else ownerTimezone (the owner's account time zone), else UTC. A name
+// that no longer loads is skipped.
+func (f *Form) Location(ownerTimezone string) *time.Location {
+	for _, name := range []string{f.Timezone, ownerTimezone} {
+		if name == "" {
+			continue
+		}
+
+		if loc, err := LoadTimezone(name); err == nil {
+			return loc
+		}
+	}
+
+	return time.UTC
+}
@@ -0,0 +1,38 @@
+package model_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/goformx/goforms/internal/domain/form/model"
+)
+
+func TestLoadTimezone(t *testing.T) {
+	loc, err := model.LoadTimezone("")
+	require.NoError(t, err)
+	assert.Equal(t, time.UTC, loc)
+
+	loc, err = model.LoadTimezone("America/New_York")
+	require.NoError(t, err)
+	assert.Equal(t, "America/New_York", loc.String())
+
+	for _, name := range []string{"Local", "Mars/Olympus_Mons", "../../etc/passwd"} {
+		_, err = model.LoadTimezone(name)
+		require.ErrorIs(t, err, model.ErrTimezoneInvalid, name)
+	}
+}
+
+func TestForm_Location(t *testing.T) {
+	form := &model.Form{}
+	assert.Equal(t, time.UTC, form.Location(""))
+	assert.Equal(t, "Asia/Tokyo", form.Location("Asia/Tokyo").String(), "forms without a time zone use the owner's")
+
+	form.Timezone = "Europe/Berlin"
+	assert.Equal(t, "Europe/Berlin", form.Location("Asia/Tokyo").String())
+
+	form.Timezone = "Not/AZone"
+	assert.Equal(t, "Asia/Tokyo", form.Location("Asia/Tokyo").String(), "a time zone that no longer loads is skipped")
+}
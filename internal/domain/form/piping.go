@@ -0,0 +1,104 @@
+package form
+
+import (
+	"html"
+	"regexp"
+	"strings"
+
+	"github.com/goformx/goforms/internal/domain/form/model"
+)
+
+// pipePattern matches an answer reference: {{key}} or {{key|fallback}}, with
+// optional spaces. Keys are component keys, with dots into nested answers
+// such as address.city. Anything else between braces is left as written.
+var pipePattern = regexp.MustCompile(`\{\{\s*([A-Za-z_][A-Za-z0-9_-]*(?:\.[A-Za-z0-9_-]+)*)\s*(?:\|([^{}]*))?\}\}`)
+
+// PipeText replaces the answer references in source with the answers in
+// data, formatted for display. An unanswered reference takes its fallback,
+// or is removed.
+func PipeText(source string, data map[string]any) string {
+	return pipe(source, data, func(value string) string { return value })
+}
+
+// PipeHTML replaces the answer references in source like PipeText, escaping
+// the answers and fallbacks for HTML. The rest of source is the owner's
+// markup and is kept as written.
+func PipeHTML(source string, data map[string]any) string {
+	return pipe(source, data, html.EscapeString)
+}
+
+// pipe resolves the references in source, passing each replacement through escape
+func pipe(source string, data map[string]any, escape func(string) string) string {
+	if !strings.Contains(source, "{{") {
+		return source
+	}
+
+	return pipePattern.ReplaceAllStringFunc(source, func(reference string) string {
+		match := pipePattern.FindStringSubmatch(reference)
+
+		if value, ok := answerAt(data, match[1]); ok {
+			return escape(FormatSubmissionValue(value))
+		}
+
+		return escape(strings.TrimSpace(match[2]))
+	})
+}
+
+// answerAt returns the non-empty answer at a dotted key
+func answerAt(data map[string]any, key string) (any, bool) {
+	var value any = data
+
+	for part := range strings.SplitSeq(key, ".") {
+		answers, ok := value.(map[string]any)
+		if !ok {
+			if jsonAnswers, jsonOk := value.(model.JSON); jsonOk {
+				answers, ok = jsonAnswers, true
+			}
+		}
+
+		if !ok {
+			return nil, false
+		}
+
+		value = answers[part]
+	}
+
+	switch v := value.(type) {
+	case nil:
+		return nil, false
+	case string:
+		return v, strings.TrimSpace(v) != ""
+	case []any:
+		return v, len(v) > 0
+	}
+
+	return value, true
+}
+
+// PipeLabels returns a copy of schema whose component labels have their
+// answer references resolved with data. Answers are escaped for HTML, as
+// Form.io renders labels as markup. A schema without references is returned
+// as is.
+func PipeLabels(schema, data model.JSON) model.JSON {
+	if schema == nil {
+		return nil
+	}
+
+	piped := schema.Clone()
+	changed := false
+
+	_ = model.WalkSchema(piped, func(component map[string]any) error {
+		if label, ok := component["label"].(string); ok && strings.Contains(label, "{{") {
+			component["label"] = PipeHTML(label, data)
+			changed = true
+		}
+
+		return nil
+	})
+
+	if !changed {
+		return schema
+	}
+
+	return piped
+}
@@ -0,0 +1,75 @@
+package form_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	domainform "github.com/goformx/goforms/internal/domain/form"
+	"github.com/goformx/goforms/internal/domain/form/model"
+)
+
+func TestPipeText(t *testing.T) {
+	data := map[string]any{
+		"name":     "Ada",
+		"empty":    "  ",
+		"agree":    true,
+		"toppings": []any{"cheese", "olives"},
+		"address":  map[string]any{"city": "London"},
+	}
+
+	tests := []struct {
+		name   string
+		source string
+		want   string
+	}{
+		{name: "answer", source: "Thanks, {{name}}!", want: "Thanks, Ada!"},
+		{name: "spaces", source: "Thanks, {{ name }}!", want: "Thanks, Ada!"},
+		{name: "fallback", source: "Hi {{nickname | there}}", want: "Hi there"},
+		{name: "blank answer takes the fallback", source: "Hi {{empty|there}}", want: "Hi there"},
+		{name: "unanswered without fallback", source: "Hi {{nickname}}.", want: "Hi ."},
+		{name: "formatted answers", source: "{{agree}}: {{toppings}}", want: "Yes: cheese, olives"},
+		{name: "nested answer", source: "See you in {{address.city}}", want: "See you in London"},
+		{name: "not a reference", source: "{{ .Name }} {{name()}} {name}", want: "{{ .Name }} {{name()}} {name}"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, domainform.PipeText(tt.source, data))
+		})
+	}
+}
+
+func TestPipeHTML(t *testing.T) {
+	data := map[string]any{"name": `<script>alert("x")</script>`}
+
+	assert.Equal(t, "<p>Thanks, &lt;script&gt;alert(&#34;x&#34;)&lt;/script&gt;!</p>",
+		domainform.PipeHTML("<p>Thanks, {{name}}!</p>", data))
+	assert.Equal(t, "Hi &lt;you&gt;", domainform.PipeHTML("Hi {{nickname|<you>}}", data))
+}
+
+func TestPipeLabels(t *testing.T) {
+	schema := model.JSON{
+		"components": []any{
+			map[string]any{"key": "name", "type": "textfield", "label": "Name"},
+			map[string]any{
+				"type": "panel",
+				"components": []any{
+					map[string]any{"key": "age", "type": "number", "label": "How old are you, {{name|friend}}?"},
+				},
+			},
+		},
+	}
+
+	piped := domainform.PipeLabels(schema, model.JSON{"name": "<b>Ada</b>"})
+
+	panel, _ := piped["components"].([]any)[1].(map[string]any)
+	age, _ := panel["components"].([]any)[0].(map[string]any)
+	assert.Equal(t, "How old are you, &lt;b&gt;Ada&lt;/b&gt;?", age["label"])
+
+	original, _ := schema["components"].([]any)[1].(map[string]any)["components"].([]any)[0].(map[string]any)
+	assert.Equal(t, "How old are you, {{name|friend}}?", original["label"], "the stored schema is not changed")
+
+	plain := model.JSON{"components": []any{map[string]any{"key": "name", "label": "Name"}}}
+	assert.Equal(t, plain, domainform.PipeLabels(plain, nil))
+}
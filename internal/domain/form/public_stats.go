@@ -0,0 +1,106 @@
+package form
+
+import (
+	"math"
+	"strconv"
+
+	"github.com/goformx/goforms/internal/domain/form/model"
+)
+
+// PublicStatsSummary holds the stats of a form its owner published. Stats the
+// owner did not publish are nil, so they are left out of the response.
+type PublicStatsSummary struct {
+	FormID string `json:"form_id"`
+	// Responses counts the submissions that did not fail, test submissions aside
+	Responses *int `json:"responses,omitempty"`
+	// Rating is the average of the published rating field
+	Rating *PublicRating `json:"rating,omitempty"`
+}
+
+// PublicRating is the average answer of a rating field, rounded to one decimal
+type PublicRating struct {
+	Average float64 `json:"average"`
+	// Count counts the submissions answering the rating field
+	Count int `json:"count"`
+	// Scale is the number of stars of the field
+	Scale int `json:"scale"`
+}
+
+// ComputePublicStats aggregates the submissions of a form into the stats its
+// owner published. Failed and test submissions are not counted.
+func ComputePublicStats(form *model.Form, submissions []*model.FormSubmission) PublicStatsSummary {
+	settings := form.GetPublicStats()
+	summary := PublicStatsSummary{FormID: form.ID}
+
+	var (
+		responses int
+		total     float64
+		rated     int
+	)
+
+	for _, submission := range submissions {
+		if submission == nil || submission.IsFailed() || submission.Test {
+			continue
+		}
+
+		responses++
+
+		if settings.RatingField == "" {
+			continue
+		}
+
+		if value, ok := ratingValue(submission.Data[settings.RatingField]); ok {
+			total += value
+			rated++
+		}
+	}
+
+	if settings.ResponseCount {
+		summary.Responses = &responses
+	}
+
+	if settings.RatingField != "" {
+		rating := &PublicRating{Count: rated, Scale: ratingFieldScale(form, settings.RatingField)}
+		if rated > 0 {
+			rating.Average = math.Round(total/float64(rated)*10) / 10
+		}
+
+		summary.Rating = rating
+	}
+
+	return summary
+}
+
+// ratingValue reads a submitted rating, which Form.io sends as a number but
+// imports may hold as a string
+func ratingValue(value any) (float64, bool) {
+	switch v := value.(type) {
+	case float64:
+		return v, true
+	case int:
+		return float64(v), true
+	case int64:
+		return float64(v), true
+	case string:
+		parsed, err := strconv.ParseFloat(v, 64)
+
+		return parsed, err == nil
+	}
+
+	return 0, false
+}
+
+// ratingFieldScale returns the scale of the form's rating field with the key
+func ratingFieldScale(form *model.Form, key string) int {
+	scale := model.DefaultRatingScale
+
+	_ = model.WalkSchema(form.Schema, func(component map[string]any) error {
+		if component["key"] == key && component["type"] == model.FieldRating {
+			scale = model.RatingScale(component)
+		}
+
+		return nil
+	})
+
+	return scale
+}
@@ -0,0 +1,44 @@
+package form_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	domainform "github.com/goformx/goforms/internal/domain/form"
+	"github.com/goformx/goforms/internal/domain/form/model"
+)
+
+func TestComputePublicStats(t *testing.T) {
+	form := &model.Form{ID: "form-1", Schema: model.JSON{"components": []any{
+		map[string]any{"type": model.FieldRating, "key": "stars", "scale": float64(10)},
+	}}}
+
+	submissions := []*model.FormSubmission{
+		{Data: model.JSON{"stars": float64(8)}, Status: model.SubmissionStatusCompleted},
+		{Data: model.JSON{"stars": "9"}, Status: model.SubmissionStatusPending},
+		{Data: model.JSON{"stars": float64(10)}, Status: model.SubmissionStatusCompleted},
+		{Data: model.JSON{}, Status: model.SubmissionStatusCompleted},
+		{Data: model.JSON{"stars": float64(1)}, Status: model.SubmissionStatusFailed},
+		{Data: model.JSON{"stars": float64(1)}, Status: model.SubmissionStatusCompleted, Test: true},
+	}
+
+	summary := domainform.ComputePublicStats(form, submissions)
+	assert.Equal(t, "form-1", summary.FormID)
+	assert.Nil(t, summary.Responses, "nothing is published by default")
+	assert.Nil(t, summary.Rating)
+
+	require.NoError(t, form.SetPublicStats(model.PublicStats{Enabled: true, ResponseCount: true, RatingField: "stars"}))
+
+	summary = domainform.ComputePublicStats(form, submissions)
+	require.NotNil(t, summary.Responses)
+	assert.Equal(t, 4, *summary.Responses, "failed and test submissions are not counted")
+	assert.Equal(t, &domainform.PublicRating{Average: 9, Count: 3, Scale: 10}, summary.Rating)
+
+	require.NoError(t, form.SetPublicStats(model.PublicStats{Enabled: true, RatingField: "stars"}))
+
+	summary = domainform.ComputePublicStats(form, nil)
+	assert.Nil(t, summary.Responses)
+	assert.Equal(t, &domainform.PublicRating{Scale: 10}, summary.Rating)
+}
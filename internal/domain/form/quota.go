@@ -0,0 +1,208 @@
+package form
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/goformx/goforms/internal/domain/common/errors"
+	"github.com/goformx/goforms/internal/domain/form/model"
+	"github.com/goformx/goforms/internal/infrastructure/logging"
+)
+
+const (
+	// QuotaForms limits the number of forms a user may own
+	QuotaForms = "forms"
+	// QuotaSubmissions limits the number of submissions a user's forms may receive per month
+	QuotaSubmissions = "submissions"
+	// QuotaStorage limits the total size of submission data stored for a user's forms
+	QuotaStorage = "storage"
+)
+
+// QuotaLimits are the plan limits applied to each user. A zero limit means unlimited.
+type QuotaLimits struct {
+	MaxForms               int64 `json:"max_forms"`
+	MaxSubmissionsPerMonth int64 `json:"max_submissions_per_month"`
+	MaxStorageBytes        int64 `json:"max_storage_bytes"`
+}
+
+// Usage is a user's current consumption measured against their quota limits
+type Usage struct {
+	Forms        int64       `json:"forms"`
+	Submissions  int64       `json:"submissions"`
+	StorageBytes int64       `json:"storage_bytes"`
+	PeriodStart  time.Time   `json:"period_start"`
+	PeriodEnd    time.Time   `json:"period_end"`
+	Limits       QuotaLimits `json:"limits"`
+}
+
+// UsageRepository defines the interface for measuring per-user resource usage
+type UsageRepository interface {
+	// CountFormsByUser counts the forms owned by a user
+	CountFormsByUser(ctx context.Context, userID string) (int64, error)
+	// CountSubmissionsByUserSince counts submissions to a user's forms received at or
+	// after since, test submissions aside
+	CountSubmissionsByUserSince(ctx context.Context, userID string, since time.Time) (int64, error)
+	// SubmissionStorageByUser sums the stored size in bytes of submissions to a user's forms
+	SubmissionStorageByUser(ctx context.Context, userID string) (int64, error)
+}
+
+// PlanLimits resolves the limits of a user's plan, such as their billing subscription
+type PlanLimits interface {
+	Limits(ctx context.Context, userID string) (QuotaLimits, error)
+}
+
+// QuotaService defines the interface for tracking usage and enforcing plan quotas
+type QuotaService interface {
+	GetUsage(ctx context.Context, userID string) (*Usage, error)
+	// CheckFormCreate returns a quota error if the user cannot create another form
+	CheckFormCreate(ctx context.Context, userID string) error
+	// CheckSubmission returns a quota error if a form owned by the user cannot accept a submission of the given size
+	CheckSubmission(ctx context.Context, userID string, size int64) error
+}
+
+// quotaService measures usage from the repository and compares it to the
+// limits of each user's plan
+type quotaService struct {
+	repository UsageRepository
+	limits     QuotaLimits
+	plans      PlanLimits
+	logger     logging.Logger
+	now        func() time.Time
+}
+
+// NewQuotaService creates a new quota service. Without plans every user gets the given limits.
+func NewQuotaService(repository UsageRepository, limits QuotaLimits, plans PlanLimits, logger logging.Logger) QuotaService {
+	return &quotaService{
+		repository: repository,
+		limits:     limits,
+		plans:      plans,
+		logger:     logger,
+		now:        time.Now,
+	}
+}
+
+// limitsFor returns the limits of a user's plan. A failed lookup falls back to
+// the default limits rather than failing the request.
+func (s *quotaService) limitsFor(ctx context.Context, userID string) QuotaLimits {
+	if s.plans == nil {
+		return s.limits
+	}
+
+	limits, err := s.plans.Limits(ctx, userID)
+	if err != nil {
+		s.logger.Error("failed to resolve plan limits, using default limits", "error", err)
+
+		return s.limits
+	}
+
+	return limits
+}
+
+// GetUsage reports the user's usage for the current calendar month (UTC)
+func (s *quotaService) GetUsage(ctx context.Context, userID string) (*Usage, error) {
+	start, end := billingPeriod(s.now())
+
+	forms, err := s.repository.CountFormsByUser(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("count forms: %w", err)
+	}
+
+	submissions, err := s.repository.CountSubmissionsByUserSince(ctx, userID, start)
+	if err != nil {
+		return nil, fmt.Errorf("count submissions: %w", err)
+	}
+
+	storage, err := s.repository.SubmissionStorageByUser(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("measure storage: %w", err)
+	}
+
+	return &Usage{
+		Forms:        forms,
+		Submissions:  submissions,
+		StorageBytes: storage,
+		PeriodStart:  start,
+		PeriodEnd:    end,
+		Limits:       s.limitsFor(ctx, userID),
+	}, nil
+}
+
+// CheckFormCreate enforces the per-user form limit
+func (s *quotaService) CheckFormCreate(ctx context.Context, userID string) error {
+	limits := s.limitsFor(ctx, userID)
+	if limits.MaxForms <= 0 {
+		return nil
+	}
+
+	forms, err := s.repository.CountFormsByUser(ctx, userID)
+	if err != nil {
+		return fmt.Errorf("count forms: %w", err)
+	}
+
+	if forms >= limits.MaxForms {
+		return quotaExceeded(QuotaForms, "form limit reached for your plan", limits.MaxForms, forms)
+	}
+
+	return nil
+}
+
+// CheckSubmission enforces the monthly submission and storage limits
+func (s *quotaService) CheckSubmission(ctx context.Context, userID string, size int64) error {
+	limits := s.limitsFor(ctx, userID)
+
+	if limits.MaxSubmissionsPerMonth > 0 {
+		start, _ := billingPeriod(s.now())
+
+		submissions, err := s.repository.CountSubmissionsByUserSince(ctx, userID, start)
+		if err != nil {
+			return fmt.Errorf("count submissions: %w", err)
+		}
+
+		if submissions >= limits.MaxSubmissionsPerMonth {
+			return quotaExceeded(QuotaSubmissions, "monthly submission limit reached for this form's owner",
+				limits.MaxSubmissionsPerMonth, submissions)
+		}
+	}
+
+	if limits.MaxStorageBytes > 0 {
+		storage, err := s.repository.SubmissionStorageByUser(ctx, userID)
+		if err != nil {
+			return fmt.Errorf("measure storage: %w", err)
+		}
+
+		if storage+size > limits.MaxStorageBytes {
+			return quotaExceeded(QuotaStorage, "storage limit reached for this form's owner",
+				limits.MaxStorageBytes, storage)
+		}
+	}
+
+	return nil
+}
+
+// quotaExceeded builds a quota error carrying the quota name, limit and current usage
+func quotaExceeded(quota, message string, limit, used int64) error {
+	return errors.New(errors.ErrCodeQuotaExceeded, message, nil).
+		WithContext("quota", quota).
+		WithContext("limit", limit).
+		WithContext("used", used)
+}
+
+// billingPeriod returns the calendar month (UTC) containing t
+func billingPeriod(t time.Time) (start, end time.Time) {
+	t = t.UTC()
+	start = time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, time.UTC)
+
+	return start, start.AddDate(0, 1, 0)
+}
+
+// submissionSize approximates the stored size of a submission's data
+func submissionSize(data model.JSON) int64 {
+	encoded, err := json.Marshal(data)
+	if err != nil {
+		return 0
+	}
+
+	return int64(len(encoded))
+}
@@ -0,0 +1,195 @@
+package form_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	domainerrors "github.com/goformx/goforms/internal/domain/common/errors"
+	domainform "github.com/goformx/goforms/internal/domain/form"
+	"github.com/goformx/goforms/internal/domain/form/model"
+	mockevents "github.com/goformx/goforms/test/mocks/events"
+	mockform "github.com/goformx/goforms/test/mocks/form"
+	mocklogging "github.com/goformx/goforms/test/mocks/logging"
+)
+
+// fakeUsageRepository returns fixed usage figures
+type fakeUsageRepository struct {
+	forms       int64
+	submissions int64
+	storage     int64
+	since       time.Time
+}
+
+func (r *fakeUsageRepository) CountFormsByUser(context.Context, string) (int64, error) {
+	return r.forms, nil
+}
+
+func (r *fakeUsageRepository) CountSubmissionsByUserSince(_ context.Context, _ string, since time.Time) (int64, error) {
+	r.since = since
+
+	return r.submissions, nil
+}
+
+func (r *fakeUsageRepository) SubmissionStorageByUser(context.Context, string) (int64, error) {
+	return r.storage, nil
+}
+
+func TestQuotaService_GetUsage(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	logger := mocklogging.NewMockLogger(ctrl)
+
+	repo := &fakeUsageRepository{forms: 3, submissions: 42, storage: 2048}
+	limits := domainform.QuotaLimits{MaxForms: 5, MaxSubmissionsPerMonth: 100}
+	svc := domainform.NewQuotaService(repo, limits, nil, logger)
+
+	usage, err := svc.GetUsage(t.Context(), "user-1")
+	require.NoError(t, err)
+
+	assert.Equal(t, int64(3), usage.Forms)
+	assert.Equal(t, int64(42), usage.Submissions)
+	assert.Equal(t, int64(2048), usage.StorageBytes)
+	assert.Equal(t, limits, usage.Limits)
+	assert.Equal(t, 1, usage.PeriodStart.Day())
+	assert.Equal(t, usage.PeriodStart.AddDate(0, 1, 0), usage.PeriodEnd)
+	assert.Equal(t, usage.PeriodStart, repo.since)
+}
+
+func TestQuotaService_Enforcement(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	logger := mocklogging.NewMockLogger(ctrl)
+
+	tests := []struct {
+		name      string
+		repo      *fakeUsageRepository
+		limits    domainform.QuotaLimits
+		check     func(domainform.QuotaService) error
+		wantQuota string
+	}{
+		{
+			name:   "unlimited by default",
+			repo:   &fakeUsageRepository{forms: 1000, submissions: 1000, storage: 1 << 30},
+			limits: domainform.QuotaLimits{},
+			check: func(s domainform.QuotaService) error {
+				if err := s.CheckFormCreate(t.Context(), "user-1"); err != nil {
+					return err
+				}
+
+				return s.CheckSubmission(t.Context(), "user-1", 100)
+			},
+		},
+		{
+			name:      "form limit reached",
+			repo:      &fakeUsageRepository{forms: 5},
+			limits:    domainform.QuotaLimits{MaxForms: 5},
+			check:     func(s domainform.QuotaService) error { return s.CheckFormCreate(t.Context(), "user-1") },
+			wantQuota: domainform.QuotaForms,
+		},
+		{
+			name:   "form limit not reached",
+			repo:   &fakeUsageRepository{forms: 4},
+			limits: domainform.QuotaLimits{MaxForms: 5},
+			check:  func(s domainform.QuotaService) error { return s.CheckFormCreate(t.Context(), "user-1") },
+		},
+		{
+			name:      "monthly submissions reached",
+			repo:      &fakeUsageRepository{submissions: 10},
+			limits:    domainform.QuotaLimits{MaxSubmissionsPerMonth: 10},
+			check:     func(s domainform.QuotaService) error { return s.CheckSubmission(t.Context(), "user-1", 10) },
+			wantQuota: domainform.QuotaSubmissions,
+		},
+		{
+			name:      "storage would overflow",
+			repo:      &fakeUsageRepository{storage: 950},
+			limits:    domainform.QuotaLimits{MaxStorageBytes: 1000},
+			check:     func(s domainform.QuotaService) error { return s.CheckSubmission(t.Context(), "user-1", 100) },
+			wantQuota: domainform.QuotaStorage,
+		},
+		{
+			name:   "storage fits exactly",
+			repo:   &fakeUsageRepository{storage: 900},
+			limits: domainform.QuotaLimits{MaxStorageBytes: 1000},
+			check:  func(s domainform.QuotaService) error { return s.CheckSubmission(t.Context(), "user-1", 100) },
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.check(domainform.NewQuotaService(tt.repo, tt.limits, nil, logger))
+			if tt.wantQuota == "" {
+				require.NoError(t, err)
+
+				return
+			}
+
+			require.Error(t, err)
+			assert.True(t, domainerrors.IsQuotaError(err))
+			assert.Equal(t, tt.wantQuota, domainerrors.GetErrorDetails(err)["quota"])
+		})
+	}
+}
+
+// planLimits is a form.PlanLimits with fixed limits per user
+type planLimits map[string]domainform.QuotaLimits
+
+func (p planLimits) Limits(_ context.Context, userID string) (domainform.QuotaLimits, error) {
+	if limits, ok := p[userID]; ok {
+		return limits, nil
+	}
+
+	return domainform.QuotaLimits{}, errors.New("plan lookup failed")
+}
+
+func TestQuotaService_PlanLimits(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	logger := mocklogging.NewMockLogger(ctrl)
+	logger.EXPECT().Error(gomock.Any(), gomock.Any()).AnyTimes()
+
+	repo := &fakeUsageRepository{forms: 3}
+	plans := planLimits{"pro": {MaxForms: 10}}
+	svc := domainform.NewQuotaService(repo, domainform.QuotaLimits{MaxForms: 3}, plans, logger)
+
+	require.NoError(t, svc.CheckFormCreate(t.Context(), "pro"))
+
+	usage, err := svc.GetUsage(t.Context(), "pro")
+	require.NoError(t, err)
+	assert.Equal(t, int64(10), usage.Limits.MaxForms)
+
+	// A failed lookup falls back to the default limits
+	err = svc.CheckFormCreate(t.Context(), "free")
+	require.Error(t, err)
+	assert.True(t, domainerrors.IsQuotaError(err))
+}
+
+func TestService_CreateForm_quotaExceeded(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	t.Cleanup(ctrl.Finish)
+
+	repo := mockform.NewMockRepository(ctrl)
+	eventBus := mockevents.NewMockEventBus(ctrl)
+	logger := mocklogging.NewMockLogger(ctrl)
+
+	quotas := domainform.NewQuotaService(
+		&fakeUsageRepository{forms: 1},
+		domainform.QuotaLimits{MaxForms: 1},
+		nil,
+		logger,
+	)
+	svc := domainform.NewService(repo, eventBus, quotas, nil, domainform.ImageLimits{}, logger)
+
+	form := model.NewForm("user-1", "Test Form", "", model.JSON{
+		"type":       "object",
+		"properties": map[string]any{"name": map[string]any{"type": "string"}},
+	})
+
+	err := svc.CreateForm(t.Context(), form)
+	require.Error(t, err)
+	require.True(t, domainerrors.IsQuotaError(err), err.Error())
+	assert.Equal(t, http.StatusForbidden, domainerrors.GetDomainError(err).HTTPStatus())
+}
@@ -0,0 +1,192 @@
+package form
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"golang.org/x/sync/singleflight"
+
+	"github.com/goformx/goforms/internal/domain/common/events"
+	formevents "github.com/goformx/goforms/internal/domain/form/events"
+	"github.com/goformx/goforms/internal/domain/form/model"
+	"github.com/goformx/goforms/internal/infrastructure/logging"
+)
+
+// readCacheMaxEntries caps the number of forms held by the read cache
+const readCacheMaxEntries = 10000
+
+// cachedForm is a cached form and when it expires
+type cachedForm struct {
+	form    *model.Form
+	expires time.Time
+}
+
+// readCachingService coalesces concurrent GetForm calls for the same form into one
+// repository query and keeps the result for a short TTL. Entries are dropped when
+// the form is changed through this service or a form.updated/form.deleted event is
+// seen; the TTL bounds staleness for changes made on other instances.
+type readCachingService struct {
+	Service
+
+	ttl    time.Duration
+	logger logging.Logger
+	group  singleflight.Group
+
+	mu      sync.RWMutex
+	entries map[string]cachedForm
+	// generation advances on every invalidation; loads started before it changed are not cached
+	generation uint64
+}
+
+// NewReadCachingService wraps a form service with single-flight, short-TTL form
+// reads. A ttl of zero returns the service unchanged. Callers receive copies of
+// cached forms, so changing a returned form never affects other requests.
+func NewReadCachingService(
+	service Service,
+	eventBus events.EventBus,
+	ttl time.Duration,
+	logger logging.Logger,
+) (Service, error) {
+	if ttl <= 0 {
+		return service, nil
+	}
+
+	s := &readCachingService{
+		Service: service,
+		ttl:     ttl,
+		logger:  logger,
+		entries: make(map[string]cachedForm),
+	}
+
+	if eventBus != nil {
+		ctx := context.Background()
+
+		if err := eventBus.Subscribe(ctx, string(formevents.FormUpdatedEventType), s.handleEvent); err != nil {
+			return nil, fmt.Errorf("subscribe to form updated events: %w", err)
+		}
+
+		if err := eventBus.Subscribe(ctx, string(formevents.FormDeletedEventType), s.handleEvent); err != nil {
+			return nil, fmt.Errorf("subscribe to form deleted events: %w", err)
+		}
+	}
+
+	return s, nil
+}
+
+// GetForm returns a form from the cache, or loads it once for all concurrent callers
+func (s *readCachingService) GetForm(ctx context.Context, formID string) (*model.Form, error) {
+	s.mu.RLock()
+	entry, found := s.entries[formID]
+	generation := s.generation
+	s.mu.RUnlock()
+
+	if found && time.Now().Before(entry.expires) {
+		return entry.form.Clone(), nil
+	}
+
+	// The shared load must not be cut short because the first caller went away
+	loadCtx := context.WithoutCancel(ctx)
+
+	result, err, shared := s.group.Do(formID, func() (any, error) {
+		form, loadErr := s.Service.GetForm(loadCtx, formID)
+		if loadErr != nil || form == nil {
+			return form, loadErr
+		}
+
+		s.store(formID, form, generation)
+
+		return form, nil
+	})
+
+	if shared {
+		s.logger.Debug("coalesced form read", "form_id", formID)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	form, _ := result.(*model.Form)
+
+	return form.Clone(), nil
+}
+
+// UpdateForm updates a form and drops its cached copy
+func (s *readCachingService) UpdateForm(ctx context.Context, form *model.Form) error {
+	defer s.invalidate(form.ID)
+
+	return s.Service.UpdateForm(ctx, form)
+}
+
+// DeleteForm deletes a form and drops its cached copy
+func (s *readCachingService) DeleteForm(ctx context.Context, formID string) error {
+	defer s.invalidate(formID)
+
+	return s.Service.DeleteForm(ctx, formID)
+}
+
+// UpdateFormState changes a form's state and drops its cached copy
+func (s *readCachingService) UpdateFormState(ctx context.Context, formID, state string) error {
+	defer s.invalidate(formID)
+
+	return s.Service.UpdateFormState(ctx, formID, state)
+}
+
+// TransitionForm changes a form's status and drops its cached copy
+func (s *readCachingService) TransitionForm(ctx context.Context, form *model.Form, transition model.FormTransition) error {
+	defer s.invalidate(form.ID)
+
+	return s.Service.TransitionForm(ctx, form, transition)
+}
+
+// store caches a loaded form unless it was invalidated while the load was running
+func (s *readCachingService) store(formID string, form *model.Form, generation uint64) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.generation != generation {
+		return
+	}
+
+	if len(s.entries) >= readCacheMaxEntries {
+		now := time.Now()
+		for id, entry := range s.entries {
+			if now.After(entry.expires) {
+				delete(s.entries, id)
+			}
+		}
+
+		if len(s.entries) >= readCacheMaxEntries {
+			clear(s.entries)
+		}
+	}
+
+	s.entries[formID] = cachedForm{form: form.Clone(), expires: time.Now().Add(s.ttl)}
+}
+
+// invalidate drops a cached form. Loads already in flight are not cached and later
+// readers start a fresh load.
+func (s *readCachingService) invalidate(formID string) {
+	s.mu.Lock()
+	delete(s.entries, formID)
+	s.generation++
+	s.mu.Unlock()
+
+	s.group.Forget(formID)
+}
+
+// handleEvent invalidates the form named by a form.updated or form.deleted event
+func (s *readCachingService) handleEvent(_ context.Context, event events.Event) error {
+	switch payload := event.Payload().(type) {
+	case *model.Form:
+		if payload != nil {
+			s.invalidate(payload.ID)
+		}
+	case string:
+		s.invalidate(payload)
+	}
+
+	return nil
+}
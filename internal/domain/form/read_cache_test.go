@@ -0,0 +1,154 @@
+package form_test
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/goformx/goforms/internal/domain/common/events"
+	domainform "github.com/goformx/goforms/internal/domain/form"
+	formevents "github.com/goformx/goforms/internal/domain/form/events"
+	"github.com/goformx/goforms/internal/domain/form/model"
+	"github.com/goformx/goforms/internal/infrastructure/event"
+	mockform "github.com/goformx/goforms/test/mocks/form"
+	mocklogging "github.com/goformx/goforms/test/mocks/logging"
+)
+
+func newReadCache(t *testing.T, inner domainform.Service) (domainform.Service, events.EventBus) {
+	t.Helper()
+
+	ctrl := gomock.NewController(t)
+	logger := mocklogging.NewMockLogger(ctrl)
+	logger.EXPECT().Debug(gomock.Any(), gomock.Any()).AnyTimes()
+
+	bus := event.NewMemoryEventBus(logger)
+
+	svc, err := domainform.NewReadCachingService(inner, bus, time.Minute, logger)
+	require.NoError(t, err)
+
+	return svc, bus
+}
+
+func TestReadCachingService_CoalescesConcurrentReads(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	inner := mockform.NewMockService(ctrl)
+
+	var loads atomic.Int32
+
+	release := make(chan struct{})
+
+	inner.EXPECT().GetForm(gomock.Any(), "form-1").DoAndReturn(
+		func(_ context.Context, id string) (*model.Form, error) {
+			loads.Add(1)
+			<-release
+
+			return &model.Form{ID: id, Title: "Hot form"}, nil
+		}).Times(1)
+
+	svc, _ := newReadCache(t, inner)
+
+	const readers = 20
+
+	var wg sync.WaitGroup
+
+	forms := make([]*model.Form, readers)
+
+	for i := range readers {
+		wg.Go(func() {
+			form, err := svc.GetForm(t.Context(), "form-1")
+			assert.NoError(t, err)
+
+			forms[i] = form
+		})
+	}
+
+	// Give the readers time to join the in-flight load
+	time.Sleep(50 * time.Millisecond)
+	close(release)
+	wg.Wait()
+
+	assert.Equal(t, int32(1), loads.Load())
+
+	for _, form := range forms {
+		require.NotNil(t, form)
+		assert.Equal(t, "Hot form", form.Title)
+	}
+
+	// Later reads are served from the cache
+	_, err := svc.GetForm(t.Context(), "form-1")
+	require.NoError(t, err)
+	assert.Equal(t, int32(1), loads.Load())
+}
+
+func TestReadCachingService_ReturnsCopies(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	inner := mockform.NewMockService(ctrl)
+	inner.EXPECT().GetForm(gomock.Any(), "form-1").Return(&model.Form{
+		ID:     "form-1",
+		Title:  "Original",
+		Schema: model.JSON{"components": []any{map[string]any{"key": "email"}}},
+	}, nil).Times(1)
+
+	svc, _ := newReadCache(t, inner)
+
+	first, err := svc.GetForm(t.Context(), "form-1")
+	require.NoError(t, err)
+
+	first.Title = "Changed"
+	first.Schema["components"].([]any)[0].(map[string]any)["key"] = "changed"
+
+	second, err := svc.GetForm(t.Context(), "form-1")
+	require.NoError(t, err)
+	assert.Equal(t, "Original", second.Title)
+	assert.Equal(t, "email", second.Schema["components"].([]any)[0].(map[string]any)["key"])
+}
+
+func TestReadCachingService_InvalidatesOnEvents(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	inner := mockform.NewMockService(ctrl)
+	inner.EXPECT().GetForm(gomock.Any(), "form-1").Return(&model.Form{ID: "form-1", Title: "v1"}, nil)
+	inner.EXPECT().GetForm(gomock.Any(), "form-1").Return(&model.Form{ID: "form-1", Title: "v2"}, nil)
+	inner.EXPECT().GetForm(gomock.Any(), "form-1").Return(nil, model.ErrFormNotFound)
+
+	svc, bus := newReadCache(t, inner)
+
+	form, err := svc.GetForm(t.Context(), "form-1")
+	require.NoError(t, err)
+	assert.Equal(t, "v1", form.Title)
+
+	require.NoError(t, bus.Publish(t.Context(), formevents.NewFormUpdatedEvent(&model.Form{ID: "form-1"})))
+
+	form, err = svc.GetForm(t.Context(), "form-1")
+	require.NoError(t, err)
+	assert.Equal(t, "v2", form.Title)
+
+	require.NoError(t, bus.Publish(t.Context(), formevents.NewFormDeletedEvent("form-1")))
+
+	_, err = svc.GetForm(t.Context(), "form-1")
+	require.ErrorIs(t, err, model.ErrFormNotFound)
+}
+
+func TestReadCachingService_UpdateInvalidates(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	inner := mockform.NewMockService(ctrl)
+	inner.EXPECT().GetForm(gomock.Any(), "form-1").Return(&model.Form{ID: "form-1", Title: "v1"}, nil)
+	inner.EXPECT().UpdateForm(gomock.Any(), gomock.Any()).Return(nil)
+	inner.EXPECT().GetForm(gomock.Any(), "form-1").Return(&model.Form{ID: "form-1", Title: "v2"}, nil)
+
+	svc, _ := newReadCache(t, inner)
+
+	form, err := svc.GetForm(t.Context(), "form-1")
+	require.NoError(t, err)
+
+	require.NoError(t, svc.UpdateForm(t.Context(), form))
+
+	form, err = svc.GetForm(t.Context(), "form-1")
+	require.NoError(t, err)
+	assert.Equal(t, "v2", form.Title)
+}
@@ -0,0 +1,215 @@
+package form
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+
+	"github.com/goformx/goforms/internal/domain/form/model"
+	"github.com/goformx/goforms/internal/infrastructure/pii"
+)
+
+// redactionHashLength is the number of hex characters kept from a hashed value
+const redactionHashLength = 16
+
+// piiComponentTypes maps form.io component types to the personal data they collect
+var piiComponentTypes = map[string]pii.Kind{
+	"email":          pii.KindEmail,
+	"phoneNumber":    pii.KindPhone,
+	model.FieldPhone: pii.KindPhone,
+}
+
+// RedactSubmissions returns copies of submissions with their data redacted
+// under the form's redaction policy, for exports shared outside the owner's
+// team. Columns the policy names get their action. With a detect action,
+// email and phone components and columns whose values are mostly personal
+// data get that action, and personal data found inside other text is replaced
+// with a placeholder. The stored submissions are not changed.
+func RedactSubmissions(
+	formModel *model.Form, submissions []*model.FormSubmission, classifier *pii.Classifier,
+) []*model.FormSubmission {
+	policy := formModel.GetRedactionPolicy()
+	if policy.IsZero() {
+		return submissions
+	}
+
+	return redactSubmissions(formModel, policy, submissions, classifier)
+}
+
+// AnonymizeSubmissions returns copies of submissions with their personal data
+// removed, for submissions kept after their owner's account is deleted. Columns
+// the form's redaction policy redacts, file and signature components and
+// columns found to hold personal data are emptied, personal data inside other
+// text is replaced with a placeholder, and the metadata, which holds the
+// submitter's address and location, is dropped.
+func AnonymizeSubmissions(
+	formModel *model.Form, submissions []*model.FormSubmission, classifier *pii.Classifier,
+) []*model.FormSubmission {
+	policy := model.RedactionPolicy{Columns: make(map[string]model.RedactionAction), Detect: model.RedactionRemove}
+
+	for key, action := range formModel.GetRedactionPolicy().Columns {
+		if action != model.RedactionKeep {
+			policy.Columns[key] = model.RedactionRemove
+		}
+	}
+
+	_ = model.WalkSchema(formModel.Schema, func(component map[string]any) error {
+		key, _ := component["key"].(string)
+		if componentType, _ := component["type"].(string); key != "" && anonymizedComponentTypes[componentType] {
+			policy.Columns[key] = model.RedactionRemove
+		}
+
+		return nil
+	})
+
+	anonymized := redactSubmissions(formModel, policy, submissions, classifier)
+	for _, submission := range anonymized {
+		// Empty rather than nil, so stores that save changed fields only clear it
+		submission.Metadata = model.JSON{}
+	}
+
+	return anonymized
+}
+
+// anonymizedComponentTypes are the component types whose values are removed
+// whole by AnonymizeSubmissions
+var anonymizedComponentTypes = map[string]bool{"file": true, "signature": true}
+
+// redactSubmissions returns copies of submissions with their data redacted under policy
+func redactSubmissions(
+	formModel *model.Form, policy model.RedactionPolicy, submissions []*model.FormSubmission, classifier *pii.Classifier,
+) []*model.FormSubmission {
+	detect := policy.Detect != "" && policy.Detect != model.RedactionKeep && classifier != nil
+
+	var kinds map[string]pii.Kind
+	if detect {
+		kinds = classifyColumns(formModel.Schema, submissions, classifier)
+	}
+
+	redacted := make([]*model.FormSubmission, len(submissions))
+
+	for i, submission := range submissions {
+		clone := *submission
+		clone.Data = make(model.JSON, len(submission.Data))
+
+		for key, value := range submission.Data {
+			action, named := policy.Columns[key]
+			kind, classified := kinds[key]
+
+			switch {
+			case named:
+				clone.Data[key] = redactValue(classifier, formModel.ID, action, kind, value)
+			case classified:
+				clone.Data[key] = redactValue(classifier, formModel.ID, policy.Detect, kind, value)
+			case detect:
+				clone.Data[key] = redactText(classifier, value)
+			default:
+				clone.Data[key] = value
+			}
+		}
+
+		redacted[i] = &clone
+	}
+
+	return redacted
+}
+
+// classifyColumns returns the kind of personal data each column holds: the
+// kind its component collects, or the kind of at least half its text values
+func classifyColumns(
+	schema model.JSON, submissions []*model.FormSubmission, classifier *pii.Classifier,
+) map[string]pii.Kind {
+	kinds := make(map[string]pii.Kind)
+
+	_ = model.WalkSchema(schema, func(component map[string]any) error {
+		key, _ := component["key"].(string)
+		componentType, _ := component["type"].(string)
+
+		if kind, ok := piiComponentTypes[componentType]; ok && key != "" {
+			kinds[key] = kind
+		}
+
+		return nil
+	})
+
+	counts := make(map[string]map[pii.Kind]int)
+	values := make(map[string]int)
+
+	for _, submission := range submissions {
+		for key, value := range submission.Data {
+			text, ok := value.(string)
+			if !ok || text == "" {
+				continue
+			}
+
+			values[key]++
+
+			if kind := classifier.Classify(text); kind != "" {
+				if counts[key] == nil {
+					counts[key] = make(map[pii.Kind]int)
+				}
+
+				counts[key][kind]++
+			}
+		}
+	}
+
+	for key, byKind := range counts {
+		if _, ok := kinds[key]; ok {
+			continue
+		}
+
+		for _, kind := range []pii.Kind{pii.KindEmail, pii.KindPhone, pii.KindNationalID} {
+			if byKind[kind]*2 >= values[key] {
+				kinds[key] = kind
+
+				break
+			}
+		}
+	}
+
+	return kinds
+}
+
+// redactValue applies a redaction action to one value; nil stays nil. Without
+// a column kind, a masked value is masked as the kind of personal data it is.
+func redactValue(classifier *pii.Classifier, formID string, action model.RedactionAction, kind pii.Kind, value any) any {
+	if value == nil || action == model.RedactionKeep {
+		return value
+	}
+
+	text := FormatSubmissionValue(value)
+
+	switch action {
+	case model.RedactionRemove:
+		return nil
+	case model.RedactionHash:
+		// Hashing with the form ID keeps values comparable within the form's
+		// exports without matching the same value in another form's
+		sum := sha256.Sum256([]byte(formID + ":" + text))
+
+		return hex.EncodeToString(sum[:])[:redactionHashLength]
+	default:
+		if kind == "" && classifier != nil {
+			kind = classifier.Classify(text)
+		}
+
+		return pii.Mask(kind, text)
+	}
+}
+
+// redactText replaces personal data inside a text value, descending into lists
+func redactText(classifier *pii.Classifier, value any) any {
+	switch val := value.(type) {
+	case string:
+		return classifier.Redact(val)
+	case []any:
+		redacted := make([]any, len(val))
+		for i, item := range val {
+			redacted[i] = redactText(classifier, item)
+		}
+
+		return redacted
+	default:
+		return value
+	}
+}
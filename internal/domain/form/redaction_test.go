@@ -0,0 +1,93 @@
+package form_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	domainform "github.com/goformx/goforms/internal/domain/form"
+	"github.com/goformx/goforms/internal/domain/form/model"
+	"github.com/goformx/goforms/internal/infrastructure/pii"
+)
+
+func TestRedactSubmissions(t *testing.T) {
+	classifier, err := pii.NewClassifier([]string{"us"})
+	require.NoError(t, err)
+
+	form := &model.Form{ID: "form-1", Schema: model.JSON{"components": []any{
+		map[string]any{"key": "contact", "type": "email"},
+		map[string]any{"key": "name", "type": "textfield"},
+		map[string]any{"key": "ssn", "type": "textfield"},
+		map[string]any{"key": "notes", "type": "textarea"},
+		map[string]any{"key": "team", "type": "textfield"},
+	}}}
+
+	submissions := []*model.FormSubmission{
+		{ID: "s1", Data: model.JSON{
+			"contact": "ada@example.com", "name": "Ada", "ssn": "123-45-6789",
+			"notes": "call me on +1 555 123 4567", "team": "ada@example.com",
+		}},
+		{ID: "s2", Data: model.JSON{"contact": "grace@example.com", "name": "Grace", "ssn": nil, "notes": "none"}},
+	}
+
+	// Without a policy exports are unchanged
+	assert.Equal(t, submissions, domainform.RedactSubmissions(form, submissions, classifier))
+
+	require.NoError(t, form.SetRedactionPolicy(model.RedactionPolicy{
+		Columns: map[string]model.RedactionAction{"name": model.RedactionHash, "team": model.RedactionKeep},
+		Detect:  model.RedactionMask,
+	}))
+
+	redacted := domainform.RedactSubmissions(form, submissions, classifier)
+	require.Len(t, redacted, 2)
+
+	first := redacted[0].Data
+	assert.Equal(t, "a***@example.com", first["contact"])
+	assert.Len(t, first["name"], 16)
+	assert.NotEqual(t, "Ada", first["name"])
+	assert.Equal(t, "***-**-6789", first["ssn"], "a column of national IDs is detected from its values")
+	assert.Equal(t, "call me on [phone]", first["notes"])
+	assert.Equal(t, "ada@example.com", first["team"], "kept columns are exempt from detection")
+
+	assert.Nil(t, redacted[1].Data["ssn"])
+	assert.Equal(t, "Ada", submissions[0].Data["name"], "stored submissions are not changed")
+
+	again := domainform.RedactSubmissions(form, submissions, classifier)
+	assert.Equal(t, first["name"], again[0].Data["name"], "hashes are stable")
+}
+
+func TestAnonymizeSubmissions(t *testing.T) {
+	classifier, err := pii.NewClassifier([]string{"us"})
+	require.NoError(t, err)
+
+	form := &model.Form{ID: "form-1", Schema: model.JSON{"components": []any{
+		map[string]any{"key": "contact", "type": "email"},
+		map[string]any{"key": "name", "type": "textfield"},
+		map[string]any{"key": "notes", "type": "textarea"},
+		map[string]any{"key": "rating", "type": "number"},
+		map[string]any{"key": "id_scan", "type": "file"},
+		map[string]any{"key": "team", "type": "textfield"},
+	}}}
+	require.NoError(t, form.SetRedactionPolicy(model.RedactionPolicy{
+		Columns: map[string]model.RedactionAction{"name": model.RedactionHash, "team": model.RedactionKeep},
+	}))
+
+	submissions := []*model.FormSubmission{{ID: "s1", Data: model.JSON{
+		"contact": "ada@example.com", "name": "Ada", "notes": "call me on +1 555 123 4567",
+		"rating": float64(5), "id_scan": []any{map[string]any{"name": "passport.png"}}, "team": "research",
+	}, Metadata: model.JSON{"ip": "81.2.69.160", "geo_country": "GB"}}}
+
+	anonymized := domainform.AnonymizeSubmissions(form, submissions, classifier)
+	require.Len(t, anonymized, 1)
+
+	data := anonymized[0].Data
+	assert.Nil(t, data["contact"])
+	assert.Nil(t, data["name"], "columns the policy hashes are removed, as a hash still identifies the submitter")
+	assert.Nil(t, data["id_scan"])
+	assert.Equal(t, "call me on [phone]", data["notes"])
+	assert.Equal(t, float64(5), data["rating"])
+	assert.Equal(t, "research", data["team"])
+	assert.Empty(t, anonymized[0].Metadata)
+	assert.Equal(t, "81.2.69.160", submissions[0].Metadata["ip"], "the given submissions are not changed")
+}
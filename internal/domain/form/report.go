@@ -0,0 +1,232 @@
+package form
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/goformx/goforms/internal/domain/common/errors"
+	"github.com/goformx/goforms/internal/domain/form/model"
+	"github.com/goformx/goforms/internal/infrastructure/logging"
+	"github.com/goformx/goforms/internal/infrastructure/scheduler"
+)
+
+// ReportRepository defines the interface for scheduled report storage
+type ReportRepository interface {
+	CreateReport(ctx context.Context, report *model.ReportSchedule) error
+	GetReportByID(ctx context.Context, id string) (*model.ReportSchedule, error)
+	ListReportsByForm(ctx context.Context, formID string) ([]*model.ReportSchedule, error)
+	UpdateReport(ctx context.Context, report *model.ReportSchedule) error
+	DeleteReport(ctx context.Context, id string) error
+	// ListDueReports returns active reports whose next run is at or before now
+	ListDueReports(ctx context.Context, now time.Time) ([]*model.ReportSchedule, error)
+	// ClaimReport moves a report's next run to leaseUntil if it is still due at now,
+	// reporting whether this caller claimed it
+	ClaimReport(ctx context.Context, id string, now, leaseUntil time.Time) (bool, error)
+}
+
+// SubmissionRangeRepository lists a form's submissions by submission time
+type SubmissionRangeRepository interface {
+	// ListSubmissionsBetween returns submissions received after since and at or
+	// before until, oldest first
+	ListSubmissionsBetween(ctx context.Context, formID string, since, until time.Time) ([]*model.FormSubmission, error)
+}
+
+// ReportService defines the interface for managing scheduled submission reports
+type ReportService interface {
+	CreateReport(ctx context.Context, report *model.ReportSchedule) error
+	UpdateReport(ctx context.Context, report *model.ReportSchedule) error
+	DeleteReport(ctx context.Context, formID, reportID string) error
+	GetReport(ctx context.Context, formID, reportID string) (*model.ReportSchedule, error)
+	ListReports(ctx context.Context, formID string) ([]*model.ReportSchedule, error)
+	// DueReports claims and returns the reports that should run now
+	DueReports(ctx context.Context, now time.Time) ([]*model.ReportSchedule, error)
+	// CompleteRun records a run and schedules the next one
+	CompleteRun(ctx context.Context, report *model.ReportSchedule, ranAt time.Time) error
+	// FailRun records a failed run and schedules a retry with exponential backoff
+	FailRun(ctx context.Context, report *model.ReportSchedule, failedAt time.Time) error
+}
+
+const (
+	// ReportClaimLease is how long a claimed report is hidden from other instances.
+	// A run that neither completes nor fails within it is retried.
+	ReportClaimLease = 10 * time.Minute
+	// reportRetryBackoff is the delay before retrying a report that failed once;
+	// it doubles with each further failure up to reportMaxRetryBackoff
+	reportRetryBackoff    = time.Minute
+	reportMaxRetryBackoff = time.Hour
+)
+
+// reportService handles scheduled report business logic
+type reportService struct {
+	repository ReportRepository
+	logger     logging.Logger
+}
+
+// NewReportService creates a new report service
+func NewReportService(repository ReportRepository, logger logging.Logger) ReportService {
+	return &reportService{
+		repository: repository,
+		logger:     logger,
+	}
+}
+
+// CreateReport validates and stores a new report schedule
+func (s *reportService) CreateReport(ctx context.Context, report *model.ReportSchedule) error {
+	if err := s.schedule(report, time.Now()); err != nil {
+		return err
+	}
+
+	if err := s.repository.CreateReport(ctx, report); err != nil {
+		return fmt.Errorf("create report: %w", err)
+	}
+
+	return nil
+}
+
+// UpdateReport validates a report schedule and recomputes its next run
+func (s *reportService) UpdateReport(ctx context.Context, report *model.ReportSchedule) error {
+	if err := s.schedule(report, time.Now()); err != nil {
+		return err
+	}
+
+	if err := s.repository.UpdateReport(ctx, report); err != nil {
+		return fmt.Errorf("update report: %w", err)
+	}
+
+	return nil
+}
+
+// DeleteReport deletes a report schedule belonging to the given form
+func (s *reportService) DeleteReport(ctx context.Context, formID, reportID string) error {
+	if _, err := s.GetReport(ctx, formID, reportID); err != nil {
+		return err
+	}
+
+	if err := s.repository.DeleteReport(ctx, reportID); err != nil {
+		return fmt.Errorf("delete report: %w", err)
+	}
+
+	return nil
+}
+
+// GetReport retrieves a report schedule, scoped to its form
+func (s *reportService) GetReport(ctx context.Context, formID, reportID string) (*model.ReportSchedule, error) {
+	report, err := s.repository.GetReportByID(ctx, reportID)
+	if err != nil {
+		return nil, fmt.Errorf("get report: %w", err)
+	}
+
+	if report == nil || report.FormID != formID {
+		return nil, model.ErrReportNotFound
+	}
+
+	return report, nil
+}
+
+// ListReports lists the report schedules for a form
+func (s *reportService) ListReports(ctx context.Context, formID string) ([]*model.ReportSchedule, error) {
+	reports, err := s.repository.ListReportsByForm(ctx, formID)
+	if err != nil {
+		return nil, fmt.Errorf("list reports: %w", err)
+	}
+
+	return reports, nil
+}
+
+// DueReports claims the due reports for ReportClaimLease and returns those this
+// instance claimed; reports claimed by another instance are skipped
+func (s *reportService) DueReports(ctx context.Context, now time.Time) ([]*model.ReportSchedule, error) {
+	reports, err := s.repository.ListDueReports(ctx, now)
+	if err != nil {
+		return nil, fmt.Errorf("list due reports: %w", err)
+	}
+
+	leaseUntil := now.Add(ReportClaimLease)
+	claimed := make([]*model.ReportSchedule, 0, len(reports))
+
+	for _, report := range reports {
+		ok, claimErr := s.repository.ClaimReport(ctx, report.ID, now, leaseUntil)
+		if claimErr != nil {
+			return nil, fmt.Errorf("claim report: %w", claimErr)
+		}
+
+		if ok {
+			report.NextRunAt = &leaseUntil
+			claimed = append(claimed, report)
+		}
+	}
+
+	return claimed, nil
+}
+
+// CompleteRun records the run time and advances the schedule
+func (s *reportService) CompleteRun(ctx context.Context, report *model.ReportSchedule, ranAt time.Time) error {
+	if err := s.schedule(report, ranAt); err != nil {
+		return err
+	}
+
+	report.LastRunAt = &ranAt
+	report.FailureCount = 0
+
+	if err := s.repository.UpdateReport(ctx, report); err != nil {
+		return fmt.Errorf("complete report run: %w", err)
+	}
+
+	return nil
+}
+
+// FailRun counts the failure and retries after reportRetryBackoff, doubled for
+// each earlier consecutive failure and capped at reportMaxRetryBackoff
+func (s *reportService) FailRun(ctx context.Context, report *model.ReportSchedule, failedAt time.Time) error {
+	report.FailureCount++
+
+	retryAt := failedAt.Add(reportBackoff(report.FailureCount)).UTC()
+	report.NextRunAt = &retryAt
+
+	if err := s.repository.UpdateReport(ctx, report); err != nil {
+		return fmt.Errorf("fail report run: %w", err)
+	}
+
+	return nil
+}
+
+// reportBackoff returns the retry delay after the given number of consecutive failures
+func reportBackoff(failures int) time.Duration {
+	backoff := reportRetryBackoff
+	for i := 1; i < failures && backoff < reportMaxRetryBackoff; i++ {
+		backoff *= 2
+	}
+
+	return min(backoff, reportMaxRetryBackoff)
+}
+
+// schedule validates the report and computes its next run after from,
+// evaluating the cron expression in the report's time zone
+func (s *reportService) schedule(report *model.ReportSchedule, from time.Time) error {
+	if err := report.Validate(); err != nil {
+		return errors.New(errors.ErrCodeValidation, err.Error(), err)
+	}
+
+	cron, err := scheduler.ParseCron(report.Cron)
+	if err != nil {
+		return errors.New(errors.ErrCodeValidation, err.Error(), err)
+	}
+
+	loc, err := report.Location()
+	if err != nil {
+		return errors.New(errors.ErrCodeValidation, err.Error(), err)
+	}
+
+	next := cron.Next(from.In(loc))
+	if next.IsZero() {
+		report.NextRunAt = nil
+
+		return nil
+	}
+
+	next = next.UTC()
+	report.NextRunAt = &next
+
+	return nil
+}
@@ -0,0 +1,195 @@
+package form
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/goformx/goforms/internal/domain/emailtemplate"
+	"github.com/goformx/goforms/internal/domain/form/model"
+	"github.com/goformx/goforms/internal/infrastructure/email"
+	"github.com/goformx/goforms/internal/infrastructure/logging"
+	"github.com/goformx/goforms/internal/infrastructure/pii"
+	"github.com/goformx/goforms/internal/infrastructure/scheduler"
+)
+
+const (
+	// reportPollInterval is how often the dispatcher checks for due reports
+	reportPollInterval = time.Minute
+	// reportSendTimeout bounds the time spent building and sending one report
+	reportSendTimeout = 2 * time.Minute
+)
+
+// ReportDispatcher polls for due report schedules and emails submission digests.
+// Due reports are claimed before they are sent, so with several instances each
+// digest is sent once.
+type ReportDispatcher struct {
+	reports     ReportService
+	forms       Service
+	submissions SubmissionRangeRepository
+	templates   emailtemplate.Service
+	sender      email.Sender
+	classifier  *pii.Classifier
+	logger      logging.Logger
+	runner      *scheduler.Runner
+	now         func() time.Time
+}
+
+// NewReportDispatcher creates a new report dispatcher. Digests are rendered
+// from the form owner's digest email template; their CSV attachments are
+// redacted under the form's redaction policy, since they leave the app.
+func NewReportDispatcher(
+	reports ReportService,
+	forms Service,
+	submissions SubmissionRangeRepository,
+	templates emailtemplate.Service,
+	sender email.Sender,
+	classifier *pii.Classifier,
+	logger logging.Logger,
+) *ReportDispatcher {
+	d := &ReportDispatcher{
+		reports:     reports,
+		forms:       forms,
+		submissions: submissions,
+		templates:   templates,
+		sender:      sender,
+		classifier:  classifier,
+		logger:      logger,
+		now:         time.Now,
+	}
+	d.runner = scheduler.NewRunner("report-dispatcher", reportPollInterval, d.RunDue, logger)
+
+	return d
+}
+
+// Start begins polling for due reports
+func (d *ReportDispatcher) Start() {
+	if d.reports == nil || d.sender == nil {
+		return
+	}
+
+	d.runner.Start()
+}
+
+// Stop stops polling and waits for an in-flight run to finish
+func (d *ReportDispatcher) Stop(ctx context.Context) {
+	d.runner.Stop(ctx)
+}
+
+// RunDue claims and sends every report that is due. A failing report is
+// logged and retried with backoff; it does not block the others.
+func (d *ReportDispatcher) RunDue(ctx context.Context) error {
+	now := d.now().UTC()
+
+	due, err := d.reports.DueReports(ctx, now)
+	if err != nil {
+		return fmt.Errorf("load due reports: %w", err)
+	}
+
+	for _, report := range due {
+		sendErr := d.Send(ctx, report, now)
+		if sendErr == nil {
+			continue
+		}
+
+		d.logger.Error("failed to send scheduled report",
+			"report_id", report.ID, "form_id", report.FormID, "error", sendErr)
+
+		if failErr := d.reports.FailRun(ctx, report, now); failErr != nil {
+			d.logger.Error("failed to schedule report retry",
+				"report_id", report.ID, "form_id", report.FormID, "error", failErr)
+		}
+	}
+
+	return nil
+}
+
+// Send builds and emails one report digest, then advances its schedule
+func (d *ReportDispatcher) Send(ctx context.Context, report *model.ReportSchedule, now time.Time) error {
+	ctx, cancel := context.WithTimeout(ctx, reportSendTimeout)
+	defer cancel()
+
+	formModel, err := d.forms.GetForm(ctx, report.FormID)
+	if err != nil {
+		return fmt.Errorf("get report form: %w", err)
+	}
+
+	since := report.CreatedAt
+	if report.LastRunAt != nil {
+		since = *report.LastRunAt
+	}
+
+	submissions, err := d.submissions.ListSubmissionsBetween(ctx, report.FormID, since, now)
+	if err != nil {
+		return fmt.Errorf("list report submissions: %w", err)
+	}
+
+	submissions = model.WithoutTestSubmissions(submissions)
+
+	msg, err := d.buildMessage(ctx, report, formModel, submissions, since, now)
+	if err != nil {
+		return err
+	}
+
+	if sendErr := d.sender.Send(ctx, msg); sendErr != nil {
+		return fmt.Errorf("send report email: %w", sendErr)
+	}
+
+	if completeErr := d.reports.CompleteRun(ctx, report, now); completeErr != nil {
+		return fmt.Errorf("complete report run: %w", completeErr)
+	}
+
+	d.logger.Info("scheduled report sent",
+		"report_id", report.ID, "form_id", report.FormID, "submission_count", len(submissions))
+
+	return nil
+}
+
+// buildMessage renders the digest email for a report
+func (d *ReportDispatcher) buildMessage(
+	ctx context.Context,
+	report *model.ReportSchedule,
+	formModel *model.Form,
+	submissions []*model.FormSubmission,
+	since, until time.Time,
+) (*email.Message, error) {
+	msg := &email.Message{To: report.GetRecipients(), FormID: report.FormID}
+
+	// The period and the CSV times are given in the report's time zone
+	loc, err := report.Location()
+	if err != nil {
+		loc = time.UTC
+	}
+
+	if report.Format == model.ReportFormatCSV && len(submissions) > 0 {
+		data, csvErr := SubmissionsCSV(formModel.Schema, RedactSubmissions(formModel, submissions, d.classifier), loc)
+		if csvErr != nil {
+			return nil, fmt.Errorf("build report csv: %w", csvErr)
+		}
+
+		msg.Attachments = append(msg.Attachments, email.Attachment{
+			Filename:    fmt.Sprintf("submissions-%s.csv", until.In(loc).Format("2006-01-02")),
+			ContentType: "text/csv; charset=utf-8",
+			Data:        data,
+		})
+	}
+
+	rendered, err := d.templates.Render(ctx, formModel.UserID, emailtemplate.KindDigest, map[string]any{
+		"ReportName":      report.Name,
+		"FormTitle":       formModel.Title,
+		"PeriodStart":     since.In(loc).Format(time.RFC1123),
+		"PeriodEnd":       until.In(loc).Format(time.RFC1123),
+		"Timezone":        loc.String(),
+		"SubmissionCount": len(submissions),
+		"HasAttachment":   len(msg.Attachments) > 0,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("render report email: %w", err)
+	}
+
+	msg.Subject = rendered.Subject
+	msg.Body = rendered.Text
+	msg.HTML = rendered.HTML
+
+	return msg, nil
+}
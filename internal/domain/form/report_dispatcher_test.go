@@ -0,0 +1,225 @@
+package form_test
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/goformx/goforms/internal/domain/emailtemplate"
+	domainform "github.com/goformx/goforms/internal/domain/form"
+	"github.com/goformx/goforms/internal/domain/form/model"
+	"github.com/goformx/goforms/internal/infrastructure/email"
+	memorystore "github.com/goformx/goforms/internal/infrastructure/repository/memory"
+	mockform "github.com/goformx/goforms/test/mocks/form"
+	mocklogging "github.com/goformx/goforms/test/mocks/logging"
+)
+
+// recordingSender captures sent messages, failing while err is set
+type recordingSender struct {
+	messages []*email.Message
+	err      error
+}
+
+func (s *recordingSender) Send(_ context.Context, msg *email.Message) error {
+	if s.err != nil {
+		return s.err
+	}
+
+	s.messages = append(s.messages, msg)
+
+	return nil
+}
+
+// reportFixture is a report dispatcher over in-memory stores
+type reportFixture struct {
+	store   *memorystore.Store
+	reports domainform.ReportService
+	forms   *mockform.MockService
+	sender  *recordingSender
+	logger  *mocklogging.MockLogger
+}
+
+func newReportFixture(t *testing.T) *reportFixture {
+	t.Helper()
+
+	ctrl := gomock.NewController(t)
+	logger := mocklogging.NewMockLogger(ctrl)
+	logger.EXPECT().Info(gomock.Any(), gomock.Any()).AnyTimes()
+
+	forms := mockform.NewMockService(ctrl)
+	forms.EXPECT().GetForm(gomock.Any(), "form-1").Return(&model.Form{
+		ID:    "form-1",
+		Title: "Contact",
+		Schema: model.JSON{"components": []any{
+			map[string]any{"key": "name", "type": "textfield"},
+		}},
+	}, nil).AnyTimes()
+
+	store := memorystore.NewStore(logger)
+
+	return &reportFixture{
+		store:   store,
+		reports: domainform.NewReportService(store.Reports(), logger),
+		forms:   forms,
+		sender:  &recordingSender{},
+		logger:  logger,
+	}
+}
+
+func (f *reportFixture) dispatcher() *domainform.ReportDispatcher {
+	submissions, _ := f.store.Forms().(domainform.SubmissionRangeRepository)
+	templates := emailtemplate.NewService(f.store.EmailTemplates(), "GoFormX", nil, f.logger)
+
+	return domainform.NewReportDispatcher(f.reports, f.forms, submissions, templates, f.sender, nil, f.logger)
+}
+
+// createReport stores a daily CSV digest for form-1 that last ran at lastRun
+func (f *reportFixture) createReport(t *testing.T, lastRun time.Time) *model.ReportSchedule {
+	t.Helper()
+
+	report := &model.ReportSchedule{
+		FormID:    "form-1",
+		Name:      "Daily digest",
+		Cron:      "@daily",
+		Format:    model.ReportFormatCSV,
+		Active:    true,
+		LastRunAt: &lastRun,
+	}
+	report.SetRecipients([]string{"team@example.com"})
+	require.NoError(t, f.reports.CreateReport(context.Background(), report))
+
+	return report
+}
+
+func TestReportDispatcher_Send(t *testing.T) {
+	f := newReportFixture(t)
+
+	lastRun := time.Date(2026, 10, 14, 0, 0, 0, 0, time.UTC)
+	now := lastRun.Add(24 * time.Hour)
+
+	for _, submission := range []*model.FormSubmission{
+		{ID: "old", FormID: "form-1", SubmittedAt: lastRun.Add(-time.Hour), Data: model.JSON{"name": "Old"}},
+		{ID: "new", FormID: "form-1", SubmittedAt: lastRun.Add(time.Hour), Data: model.JSON{"name": "=cmd"}},
+		{ID: "late", FormID: "form-1", SubmittedAt: now.Add(time.Hour), Data: model.JSON{"name": "Late"}},
+	} {
+		require.NoError(t, f.store.Forms().CreateSubmission(context.Background(), submission))
+	}
+
+	report := f.createReport(t, lastRun)
+
+	require.NoError(t, f.dispatcher().Send(context.Background(), report, now))
+	require.Len(t, f.sender.messages, 1)
+
+	msg := f.sender.messages[0]
+	assert.Equal(t, []string{"team@example.com"}, msg.To)
+	assert.Equal(t, "form-1", msg.FormID)
+	assert.Equal(t, "Daily digest: 1 new submission(s) for Contact", msg.Subject)
+	assert.Contains(t, msg.Body, "New submissions: 1")
+	assert.Contains(t, msg.Body, "attached as a CSV file")
+	assert.Contains(t, msg.HTML, "<h1>Daily digest</h1>")
+	require.Len(t, msg.Attachments, 1)
+
+	csvBody := string(msg.Attachments[0].Data)
+	assert.True(t, strings.HasPrefix(csvBody, "submission_id,submitted_at,status,name\n"))
+	assert.Contains(t, csvBody, "new,")
+	assert.Contains(t, csvBody, "'=cmd")
+	assert.NotContains(t, csvBody, "old,")
+	assert.NotContains(t, csvBody, "late,")
+
+	require.NotNil(t, report.LastRunAt)
+	assert.Equal(t, now, *report.LastRunAt)
+	assert.Equal(t, time.Date(2026, 10, 16, 0, 0, 0, 0, time.UTC), *report.NextRunAt)
+}
+
+func TestReportDispatcher_SendInReportTimezone(t *testing.T) {
+	f := newReportFixture(t)
+
+	lastRun := time.Date(2026, 10, 14, 22, 0, 0, 0, time.UTC)
+	now := lastRun.Add(24 * time.Hour)
+
+	require.NoError(t, f.store.Forms().CreateSubmission(context.Background(), &model.FormSubmission{
+		ID: "new", FormID: "form-1", SubmittedAt: lastRun.Add(time.Hour), Data: model.JSON{"name": "Ada"},
+	}))
+
+	report := &model.ReportSchedule{
+		FormID: "form-1", Name: "Daily digest", Cron: "@daily", Timezone: "Europe/Berlin",
+		Format: model.ReportFormatCSV, Active: true, LastRunAt: &lastRun,
+	}
+	report.SetRecipients([]string{"team@example.com"})
+	require.NoError(t, f.reports.CreateReport(context.Background(), report))
+
+	require.NoError(t, f.dispatcher().Send(context.Background(), report, now))
+	require.Len(t, f.sender.messages, 1)
+
+	msg := f.sender.messages[0]
+	assert.Contains(t, msg.Body, "Period: Thu, 15 Oct 2026 00:00:00 CEST to Fri, 16 Oct 2026 00:00:00 CEST")
+	require.Len(t, msg.Attachments, 1)
+	assert.Equal(t, "submissions-2026-10-16.csv", msg.Attachments[0].Filename)
+	assert.Contains(t, string(msg.Attachments[0].Data), "new,2026-10-15T01:00:00+02:00,")
+}
+
+func TestReportDispatcher_RunDueClaimsOnce(t *testing.T) {
+	f := newReportFixture(t)
+	ctx := context.Background()
+
+	report := f.createReport(t, time.Now().Add(-48*time.Hour))
+
+	// Make the report due now
+	due := time.Now().Add(-time.Minute)
+	report.NextRunAt = &due
+	require.NoError(t, f.store.Reports().UpdateReport(ctx, report))
+
+	// Two instances poll the same store; only the first claims the report
+	require.NoError(t, f.dispatcher().RunDue(ctx))
+	require.NoError(t, f.dispatcher().RunDue(ctx))
+
+	assert.Len(t, f.sender.messages, 1)
+}
+
+func TestReportDispatcher_RunDueBacksOff(t *testing.T) {
+	f := newReportFixture(t)
+	f.logger.EXPECT().Error(gomock.Any(), gomock.Any()).AnyTimes()
+	ctx := context.Background()
+
+	report := f.createReport(t, time.Now().Add(-48*time.Hour))
+	f.sender.err = errors.New("smtp unavailable")
+
+	for attempt, backoff := range []time.Duration{time.Minute, 2 * time.Minute, 4 * time.Minute} {
+		stored, err := f.store.Reports().GetReportByID(ctx, report.ID)
+		require.NoError(t, err)
+
+		due := time.Now().Add(-time.Second)
+		stored.NextRunAt = &due
+		require.NoError(t, f.store.Reports().UpdateReport(ctx, stored))
+
+		before := time.Now()
+		require.NoError(t, f.dispatcher().RunDue(ctx))
+
+		stored, err = f.store.Reports().GetReportByID(ctx, report.ID)
+		require.NoError(t, err)
+		assert.Equal(t, attempt+1, stored.FailureCount)
+		assert.WithinDuration(t, before.Add(backoff), *stored.NextRunAt, 5*time.Second)
+	}
+
+	// A successful run resets the failure count
+	f.sender.err = nil
+
+	stored, err := f.store.Reports().GetReportByID(ctx, report.ID)
+	require.NoError(t, err)
+
+	due := time.Now().Add(-time.Second)
+	stored.NextRunAt = &due
+	require.NoError(t, f.store.Reports().UpdateReport(ctx, stored))
+	require.NoError(t, f.dispatcher().RunDue(ctx))
+
+	stored, err = f.store.Reports().GetReportByID(ctx, report.ID)
+	require.NoError(t, err)
+	assert.Zero(t, stored.FailureCount)
+	assert.Len(t, f.sender.messages, 1)
+}
@@ -0,0 +1,214 @@
+package form
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/goformx/goforms/internal/domain/form/model"
+	"github.com/goformx/goforms/internal/infrastructure/logging"
+	"github.com/goformx/goforms/internal/infrastructure/repository/common"
+)
+
+// SchemaHistoryLimit bounds the schema edits kept for undo, and the undone
+// edits kept for redo, per form
+const SchemaHistoryLimit = 50
+
+// SchemaRevisionRepository stores the undo and redo history of form schemas
+type SchemaRevisionRepository interface {
+	// CreateSchemaRevision stores a revision
+	CreateSchemaRevision(ctx context.Context, revision *model.SchemaRevision) error
+	// LatestSchemaRevision returns the top of a form's stack, or common.ErrNotFound
+	// when the stack is empty
+	LatestSchemaRevision(ctx context.Context, formID string, stack model.SchemaStack) (*model.SchemaRevision, error)
+	// DeleteSchemaRevision deletes a revision, reporting whether it was still stored
+	DeleteSchemaRevision(ctx context.Context, id string) (bool, error)
+	// TrimSchemaRevisions keeps the keep newest revisions of a form's stack and
+	// deletes the others
+	TrimSchemaRevisions(ctx context.Context, formID string, stack model.SchemaStack, keep int) error
+	// CountSchemaRevisions counts the revisions of a form's stack
+	CountSchemaRevisions(ctx context.Context, formID string, stack model.SchemaStack) (int, error)
+}
+
+// SchemaHistory tells the form builder which of its undo and redo actions apply
+type SchemaHistory struct {
+	FormID string `json:"form_id"`
+	// Undo counts the schema edits that can be undone
+	Undo int `json:"undo"`
+	// Redo counts the undone edits that can be redone
+	Redo  int `json:"redo"`
+	Limit int `json:"limit"`
+}
+
+// SchemaHistoryService keeps a bounded undo and redo history of each form's
+// schema edits, so an edit made by mistake in the builder can be reverted
+type SchemaHistoryService interface {
+	// Record keeps the schema a form had before an edit so the edit can be
+	// undone. It drops the edits undone before, which can no longer be redone.
+	Record(ctx context.Context, formID string, previous model.JSON) error
+	// Undo restores and saves the schema the form had before its latest edit
+	Undo(ctx context.Context, form *model.Form) error
+	// Redo reapplies and saves the latest undone edit
+	Redo(ctx context.Context, form *model.Form) error
+	// History counts the edits of a form that can be undone and redone
+	History(ctx context.Context, formID string) (*SchemaHistory, error)
+}
+
+// schemaHistoryService implements SchemaHistoryService
+type schemaHistoryService struct {
+	revisions SchemaRevisionRepository
+	forms     Service
+	logger    logging.Logger
+}
+
+// NewSchemaHistoryService creates a new schema undo history service. Undone and
+// redone schemas are saved through forms, so they are version-checked and
+// published like any other edit.
+func NewSchemaHistoryService(revisions SchemaRevisionRepository, forms Service, logger logging.Logger) SchemaHistoryService {
+	return &schemaHistoryService{
+		revisions: revisions,
+		forms:     forms,
+		logger:    logger,
+	}
+}
+
+// Record pushes the previous schema onto the undo stack and clears the redo stack
+func (s *schemaHistoryService) Record(ctx context.Context, formID string, previous model.JSON) error {
+	if err := s.push(ctx, formID, model.SchemaStackUndo, previous); err != nil {
+		return err
+	}
+
+	if err := s.revisions.TrimSchemaRevisions(ctx, formID, model.SchemaStackRedo, 0); err != nil {
+		return fmt.Errorf("clear schema redo history: %w", err)
+	}
+
+	return nil
+}
+
+// Undo moves the form back one edit
+func (s *schemaHistoryService) Undo(ctx context.Context, form *model.Form) error {
+	return s.step(ctx, form, model.SchemaStackUndo, model.SchemaStackRedo, model.ErrNothingToUndo)
+}
+
+// Redo moves the form forward one undone edit
+func (s *schemaHistoryService) Redo(ctx context.Context, form *model.Form) error {
+	return s.step(ctx, form, model.SchemaStackRedo, model.SchemaStackUndo, model.ErrNothingToRedo)
+}
+
+// History counts both stacks of a form
+func (s *schemaHistoryService) History(ctx context.Context, formID string) (*SchemaHistory, error) {
+	undo, err := s.revisions.CountSchemaRevisions(ctx, formID, model.SchemaStackUndo)
+	if err != nil {
+		return nil, fmt.Errorf("count schema undo history: %w", err)
+	}
+
+	redo, err := s.revisions.CountSchemaRevisions(ctx, formID, model.SchemaStackRedo)
+	if err != nil {
+		return nil, fmt.Errorf("count schema redo history: %w", err)
+	}
+
+	return &SchemaHistory{FormID: formID, Undo: undo, Redo: redo, Limit: SchemaHistoryLimit}, nil
+}
+
+// step pops the top of the from stack into the form's schema, saves the form
+// and pushes the schema it replaced onto the to stack. The revision is claimed
+// by deleting it, so concurrent steps never apply the same revision twice.
+func (s *schemaHistoryService) step(
+	ctx context.Context,
+	form *model.Form,
+	from, to model.SchemaStack,
+	empty error,
+) error {
+	revision, err := s.claim(ctx, form.ID, from)
+	if err != nil {
+		return err
+	}
+
+	if revision == nil {
+		return empty
+	}
+
+	current := form.Schema
+	form.Schema = revision.Schema
+
+	if updateErr := s.forms.UpdateForm(ctx, form); updateErr != nil {
+		form.Schema = current
+
+		// Put the revision back so the step can be tried again
+		if restoreErr := s.revisions.CreateSchemaRevision(ctx, revision); restoreErr != nil {
+			s.logger.Error("failed to restore schema revision", "form_id", form.ID, "stack", string(from), "error", restoreErr)
+		}
+
+		return fmt.Errorf("save form schema: %w", updateErr)
+	}
+
+	if pushErr := s.push(ctx, form.ID, to, current); pushErr != nil {
+		s.logger.Error("failed to keep replaced schema", "form_id", form.ID, "stack", string(to), "error", pushErr)
+	}
+
+	s.logger.Info("form schema restored from history", "form_id", form.ID, "stack", string(from))
+
+	return nil
+}
+
+// claim deletes and returns the top of a form's stack, or nil when it is empty
+func (s *schemaHistoryService) claim(ctx context.Context, formID string, stack model.SchemaStack) (*model.SchemaRevision, error) {
+	for {
+		revision, err := s.revisions.LatestSchemaRevision(ctx, formID, stack)
+		if errors.Is(err, common.ErrNotFound) {
+			return nil, nil
+		}
+
+		if err != nil {
+			return nil, fmt.Errorf("get schema %s history: %w", stack, err)
+		}
+
+		deleted, err := s.revisions.DeleteSchemaRevision(ctx, revision.ID)
+		if err != nil {
+			return nil, fmt.Errorf("claim schema revision: %w", err)
+		}
+
+		// A revision another request claimed first is skipped
+		if deleted {
+			return revision, nil
+		}
+	}
+}
+
+// push stores a schema on top of a form's stack and drops what exceeds the limit.
+// A form without a schema has nothing to go back to.
+func (s *schemaHistoryService) push(ctx context.Context, formID string, stack model.SchemaStack, schema model.JSON) error {
+	if schema == nil {
+		return nil
+	}
+
+	// Revisions are ordered by time, and always above the current top
+	seq := time.Now().UnixNano()
+
+	top, err := s.revisions.LatestSchemaRevision(ctx, formID, stack)
+	if err != nil && !errors.Is(err, common.ErrNotFound) {
+		return fmt.Errorf("get schema %s history: %w", stack, err)
+	}
+
+	if top != nil && top.Seq >= seq {
+		seq = top.Seq + 1
+	}
+
+	revision := &model.SchemaRevision{
+		FormID: formID,
+		Stack:  stack,
+		Schema: schema.Clone(),
+		Seq:    seq,
+	}
+
+	if createErr := s.revisions.CreateSchemaRevision(ctx, revision); createErr != nil {
+		return fmt.Errorf("store schema revision: %w", createErr)
+	}
+
+	if trimErr := s.revisions.TrimSchemaRevisions(ctx, formID, stack, SchemaHistoryLimit); trimErr != nil {
+		return fmt.Errorf("trim schema %s history: %w", stack, trimErr)
+	}
+
+	return nil
+}
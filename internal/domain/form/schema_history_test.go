@@ -0,0 +1,169 @@
+package form_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	domainform "github.com/goformx/goforms/internal/domain/form"
+	"github.com/goformx/goforms/internal/domain/form/model"
+	memorystore "github.com/goformx/goforms/internal/infrastructure/repository/memory"
+	mockform "github.com/goformx/goforms/test/mocks/form"
+	mocklogging "github.com/goformx/goforms/test/mocks/logging"
+)
+
+// schemaWithLabel is a one-field schema told apart by its label
+func schemaWithLabel(label string) model.JSON {
+	return model.JSON{"components": []any{map[string]any{"key": "name", "type": "textfield", "label": label}}}
+}
+
+// schemaLabel returns the label of a schemaWithLabel schema
+func schemaLabel(t *testing.T, schema model.JSON) string {
+	t.Helper()
+
+	components, ok := schema["components"].([]any)
+	require.True(t, ok)
+	require.Len(t, components, 1)
+
+	component, ok := components[0].(map[string]any)
+	require.True(t, ok)
+
+	label, _ := component["label"].(string)
+
+	return label
+}
+
+// schemaHistoryFixture is a schema history service over a memory store holding
+// one form, saving forms straight to the store
+type schemaHistoryFixture struct {
+	history domainform.SchemaHistoryService
+	forms   domainform.Repository
+	form    *model.Form
+}
+
+func newSchemaHistoryFixture(t *testing.T) *schemaHistoryFixture {
+	t.Helper()
+
+	ctrl := gomock.NewController(t)
+	logger := mocklogging.NewMockLogger(ctrl)
+	logger.EXPECT().Info(gomock.Any(), gomock.Any()).AnyTimes()
+	logger.EXPECT().Error(gomock.Any(), gomock.Any()).AnyTimes()
+
+	store := memorystore.NewStore(logger)
+	forms := store.Forms()
+
+	revisions, ok := forms.(domainform.SchemaRevisionRepository)
+	require.True(t, ok)
+
+	f := model.NewForm("user-1", "Contact form", "", schemaWithLabel("v0"))
+	require.NoError(t, forms.CreateForm(context.Background(), f))
+
+	service := mockform.NewMockService(ctrl)
+	service.EXPECT().UpdateForm(gomock.Any(), gomock.Any()).DoAndReturn(forms.UpdateForm).AnyTimes()
+
+	return &schemaHistoryFixture{
+		history: domainform.NewSchemaHistoryService(revisions, service, logger),
+		forms:   forms,
+		form:    f,
+	}
+}
+
+// edit saves a new schema the way the builder does and records it
+func (f *schemaHistoryFixture) edit(t *testing.T, label string) {
+	t.Helper()
+
+	ctx := context.Background()
+	previous := f.form.Schema
+	f.form.Schema = schemaWithLabel(label)
+
+	require.NoError(t, f.forms.UpdateForm(ctx, f.form))
+	require.NoError(t, f.history.Record(ctx, f.form.ID, previous))
+}
+
+// counts returns how many edits can be undone and redone
+func (f *schemaHistoryFixture) counts(t *testing.T) (int, int) {
+	t.Helper()
+
+	history, err := f.history.History(context.Background(), f.form.ID)
+	require.NoError(t, err)
+
+	return history.Undo, history.Redo
+}
+
+func TestSchemaHistory_UndoRedo(t *testing.T) {
+	ctx := context.Background()
+	f := newSchemaHistoryFixture(t)
+
+	f.edit(t, "v1")
+	f.edit(t, "v2")
+
+	undo, redo := f.counts(t)
+	assert.Equal(t, 2, undo)
+	assert.Equal(t, 0, redo)
+
+	require.NoError(t, f.history.Undo(ctx, f.form))
+	assert.Equal(t, "v1", schemaLabel(t, f.form.Schema))
+
+	require.NoError(t, f.history.Undo(ctx, f.form))
+	assert.Equal(t, "v0", schemaLabel(t, f.form.Schema))
+	require.ErrorIs(t, f.history.Undo(ctx, f.form), model.ErrNothingToUndo)
+
+	stored, err := f.forms.GetFormByID(ctx, f.form.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "v0", schemaLabel(t, stored.Schema), "an undone schema is saved")
+
+	require.NoError(t, f.history.Redo(ctx, f.form))
+	assert.Equal(t, "v1", schemaLabel(t, f.form.Schema))
+
+	undo, redo = f.counts(t)
+	assert.Equal(t, 1, undo)
+	assert.Equal(t, 1, redo)
+
+	// A new edit after an undo can no longer redo the undone ones
+	f.edit(t, "v3")
+
+	undo, redo = f.counts(t)
+	assert.Equal(t, 2, undo)
+	assert.Equal(t, 0, redo)
+	require.ErrorIs(t, f.history.Redo(ctx, f.form), model.ErrNothingToRedo)
+}
+
+func TestSchemaHistory_Bounded(t *testing.T) {
+	ctx := context.Background()
+	f := newSchemaHistoryFixture(t)
+
+	for i := 1; i <= domainform.SchemaHistoryLimit+5; i++ {
+		f.edit(t, fmt.Sprintf("v%d", i))
+	}
+
+	undo, _ := f.counts(t)
+	assert.Equal(t, domainform.SchemaHistoryLimit, undo)
+
+	for range domainform.SchemaHistoryLimit {
+		require.NoError(t, f.history.Undo(ctx, f.form))
+	}
+
+	// The five oldest edits were dropped, so undoing stops at the fifth schema
+	assert.Equal(t, "v5", schemaLabel(t, f.form.Schema))
+	require.ErrorIs(t, f.history.Undo(ctx, f.form), model.ErrNothingToUndo)
+}
+
+func TestSchemaHistory_StaleFormIsRefused(t *testing.T) {
+	ctx := context.Background()
+	f := newSchemaHistoryFixture(t)
+
+	f.edit(t, "v1")
+
+	stale := *f.form
+	stale.Version--
+
+	require.ErrorIs(t, f.history.Undo(ctx, &stale), model.ErrFormVersionConflict)
+	assert.Equal(t, "v1", schemaLabel(t, stale.Schema), "a refused undo leaves the schema alone")
+
+	undo, _ := f.counts(t)
+	assert.Equal(t, 1, undo, "a refused undo keeps the edit in the history")
+}
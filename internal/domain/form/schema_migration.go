@@ -0,0 +1,373 @@
+package form
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"slices"
+
+	"github.com/goformx/goforms/internal/domain/common/errors"
+	"github.com/goformx/goforms/internal/domain/form/model"
+	"github.com/goformx/goforms/internal/infrastructure/logging"
+)
+
+// IncompatibilityKind is how stored answers disagree with a form's schema
+type IncompatibilityKind string
+
+const (
+	// IncompatibilityUnknownField is answers stored under a key the schema no
+	// longer has, usually because the field was renamed or removed
+	IncompatibilityUnknownField IncompatibilityKind = "unknown_field"
+	// IncompatibilityTypeMismatch is answers whose value type the schema's field
+	// no longer takes, usually because the field's type changed
+	IncompatibilityTypeMismatch IncompatibilityKind = "type_mismatch"
+)
+
+// fieldValueTypes are the value types component types submit. Types not listed
+// submit objects, or values of several types, and are not checked.
+var fieldValueTypes = map[string]string{
+	"textfield":       model.ValueTypeString,
+	"textarea":        model.ValueTypeString,
+	"email":           model.ValueTypeString,
+	"url":             model.ValueTypeString,
+	"password":        model.ValueTypeString,
+	"phoneNumber":     model.ValueTypeString,
+	"datetime":        model.ValueTypeString,
+	"day":             model.ValueTypeString,
+	"time":            model.ValueTypeString,
+	"signature":       model.ValueTypeString,
+	model.FieldPhone:  model.ValueTypeString,
+	"number":          model.ValueTypeNumber,
+	"currency":        model.ValueTypeNumber,
+	model.FieldRating: model.ValueTypeNumber,
+	model.FieldNPS:    model.ValueTypeNumber,
+	"checkbox":        model.ValueTypeBoolean,
+	"datagrid":        model.ValueTypeArray,
+	"editgrid":        model.ValueTypeArray,
+	"file":            model.ValueTypeArray,
+}
+
+// nestedDataComponentTypes store the answers of their children inside their own
+// answer, so the children's keys are not top-level keys of the submission data
+var nestedDataComponentTypes = map[string]bool{
+	"container": true,
+	"datagrid":  true,
+	"editgrid":  true,
+	"datamap":   true,
+	"tree":      true,
+}
+
+// SchemaIncompatibility is one field whose stored answers do not fit the schema
+type SchemaIncompatibility struct {
+	Field string              `json:"field"`
+	Kind  IncompatibilityKind `json:"kind"`
+	// Expected is the value type the schema's field takes, for type mismatches
+	Expected string `json:"expected,omitempty"`
+	// Found counts the offending answers by value type
+	Found map[string]int `json:"found"`
+	// Submissions counts the submissions holding an offending answer
+	Submissions int `json:"submissions"`
+	// Castable reports whether every mismatching answer can be cast to Expected
+	Castable bool `json:"castable,omitempty"`
+}
+
+// SchemaMigrationPlan lists how a form's stored submissions disagree with its
+// current schema, with rules suggested to fix them
+type SchemaMigrationPlan struct {
+	FormID string `json:"form_id"`
+	// Submissions counts the submissions examined
+	Submissions       int                     `json:"submissions"`
+	Incompatibilities []SchemaIncompatibility `json:"incompatibilities"`
+	// Suggestions are rules that would resolve some incompatibilities. They are
+	// guesses for the owner to review, not applied on their own.
+	Suggestions []model.MigrationRule `json:"suggestions"`
+}
+
+// SchemaMigrationFailure is a submission a migration could not rewrite. It is
+// left as it was.
+type SchemaMigrationFailure struct {
+	SubmissionID string `json:"submission_id"`
+	Field        string `json:"field"`
+	Error        string `json:"error"`
+}
+
+// SchemaMigrationResult reports what a migration changed, or would change in a dry run
+type SchemaMigrationResult struct {
+	FormID string `json:"form_id"`
+	DryRun bool   `json:"dry_run"`
+	// Submissions counts the submissions examined
+	Submissions int `json:"submissions"`
+	// Changed counts the submissions the rules rewrite
+	Changed  int                      `json:"changed"`
+	Failures []SchemaMigrationFailure `json:"failures"`
+	// Remaining are the incompatibilities left once the rules are applied
+	Remaining []SchemaIncompatibility `json:"remaining"`
+}
+
+// SchemaMigrationService helps owners bring stored submissions in line with a
+// schema whose field keys or types changed: it finds the answers that no longer
+// fit and rewrites them with rename, cast and split rules
+type SchemaMigrationService interface {
+	// Plan finds the incompatibilities between a form's submissions and its schema
+	Plan(ctx context.Context, form *model.Form) (*SchemaMigrationPlan, error)
+	// Migrate applies rules to a form's submissions. Unless apply is set it is a
+	// dry run that reports what would change and saves nothing.
+	Migrate(ctx context.Context, form *model.Form, rules []model.MigrationRule, apply bool) (*SchemaMigrationResult, error)
+}
+
+// schemaMigrationService implements SchemaMigrationService
+type schemaMigrationService struct {
+	repository Repository
+	logger     logging.Logger
+}
+
+// NewSchemaMigrationService creates a new schema migration service
+func NewSchemaMigrationService(repository Repository, logger logging.Logger) SchemaMigrationService {
+	return &schemaMigrationService{
+		repository: repository,
+		logger:     logger,
+	}
+}
+
+// Plan lists the incompatibilities of the form's stored submissions
+func (s *schemaMigrationService) Plan(ctx context.Context, form *model.Form) (*SchemaMigrationPlan, error) {
+	submissions, err := s.repository.ListSubmissions(ctx, form.ID)
+	if err != nil {
+		return nil, fmt.Errorf("list form submissions: %w", err)
+	}
+
+	return PlanSchemaMigration(form, submissions), nil
+}
+
+// Migrate rewrites the form's submissions with the rules, in order. A
+// submission any rule fails on is reported and left unchanged.
+func (s *schemaMigrationService) Migrate(
+	ctx context.Context,
+	form *model.Form,
+	rules []model.MigrationRule,
+	apply bool,
+) (*SchemaMigrationResult, error) {
+	if len(rules) == 0 {
+		return nil, errors.New(errors.ErrCodeValidation, "at least one migration rule is required", nil)
+	}
+
+	for _, rule := range rules {
+		if err := rule.Validate(); err != nil {
+			return nil, errors.New(errors.ErrCodeValidation, err.Error(), err)
+		}
+	}
+
+	submissions, err := s.repository.ListSubmissions(ctx, form.ID)
+	if err != nil {
+		return nil, fmt.Errorf("list form submissions: %w", err)
+	}
+
+	result := &SchemaMigrationResult{
+		FormID:      form.ID,
+		DryRun:      !apply,
+		Submissions: len(submissions),
+		Failures:    []SchemaMigrationFailure{},
+	}
+
+	migrated := make([]*model.FormSubmission, 0, len(submissions))
+
+	for _, submission := range submissions {
+		data, changed, failure := migrateSubmissionData(submission, rules)
+		if failure != nil {
+			result.Failures = append(result.Failures, *failure)
+		}
+
+		if changed {
+			result.Changed++
+
+			if apply {
+				submission.Data = data
+				if updateErr := s.repository.UpdateSubmission(ctx, submission); updateErr != nil {
+					return nil, fmt.Errorf("update migrated submission %s: %w", submission.ID, updateErr)
+				}
+			} else {
+				preview := *submission
+				preview.Data = data
+				submission = &preview
+			}
+		}
+
+		migrated = append(migrated, submission)
+	}
+
+	result.Remaining = PlanSchemaMigration(form, migrated).Incompatibilities
+
+	if apply {
+		s.logger.Info("form submissions migrated", "form_id", form.ID,
+			"rules", len(rules), "changed", result.Changed, "failed", len(result.Failures))
+	}
+
+	return result, nil
+}
+
+// migrateSubmissionData applies the rules to a copy of the submission's data
+func migrateSubmissionData(
+	submission *model.FormSubmission,
+	rules []model.MigrationRule,
+) (model.JSON, bool, *SchemaMigrationFailure) {
+	data := submission.Data.Clone()
+	if data == nil {
+		return nil, false, nil
+	}
+
+	changed := false
+
+	for _, rule := range rules {
+		ruleChanged, err := rule.Apply(data)
+		if err != nil {
+			return nil, false, &SchemaMigrationFailure{SubmissionID: submission.ID, Field: rule.Field, Error: err.Error()}
+		}
+
+		changed = changed || ruleChanged
+	}
+
+	return data, changed, nil
+}
+
+// schemaDataFields returns the top-level data keys of a schema's fields, with
+// the value type each takes ("" when it is not checked)
+func schemaDataFields(schema model.JSON) map[string]string {
+	fields := make(map[string]string)
+
+	_ = model.WalkSchema(schema, func(component map[string]any) error {
+		if model.IsLayoutComponent(component) {
+			return nil
+		}
+
+		componentType, _ := component["type"].(string)
+		if key, _ := component["key"].(string); key != "" {
+			fields[key] = fieldValueTypes[componentType]
+		}
+
+		if nestedDataComponentTypes[componentType] {
+			return model.SkipChildren
+		}
+
+		return nil
+	})
+
+	return fields
+}
+
+// PlanSchemaMigration compares submissions with the form's schema. Blank answers
+// fit any field.
+func PlanSchemaMigration(form *model.Form, submissions []*model.FormSubmission) *SchemaMigrationPlan {
+	fields := schemaDataFields(form.Schema)
+	found := make(map[string]*SchemaIncompatibility)
+	answered := make(map[string]bool)
+
+	for _, submission := range submissions {
+		for key, value := range submission.Data {
+			if model.IsEmptyValue(value) {
+				continue
+			}
+
+			expected, known := fields[key]
+			if known {
+				answered[key] = true
+			}
+
+			if known && (expected == "" || model.ValueType(value) == expected) {
+				continue
+			}
+
+			incompatibility := &SchemaIncompatibility{Field: key, Kind: IncompatibilityUnknownField}
+			if known {
+				incompatibility.Kind = IncompatibilityTypeMismatch
+				incompatibility.Expected = expected
+				incompatibility.Castable = true
+			}
+
+			if existing, ok := found[key]; ok {
+				incompatibility = existing
+			} else {
+				incompatibility.Found = make(map[string]int)
+				found[key] = incompatibility
+			}
+
+			incompatibility.Found[model.DescribeValueType(value)]++
+			incompatibility.Submissions++
+
+			if _, err := model.CastValue(value, expected); known && err != nil {
+				incompatibility.Castable = false
+			}
+		}
+	}
+
+	plan := &SchemaMigrationPlan{
+		Submissions:       len(submissions),
+		Incompatibilities: []SchemaIncompatibility{},
+		Suggestions:       []model.MigrationRule{},
+	}
+	if form != nil {
+		plan.FormID = form.ID
+	}
+
+	for _, key := range slices.Sorted(maps.Keys(found)) {
+		plan.Incompatibilities = append(plan.Incompatibilities, *found[key])
+	}
+
+	plan.Suggestions = suggestMigrationRules(plan.Incompatibilities, fields, answered)
+
+	return plan
+}
+
+// suggestMigrationRules proposes a cast for every type mismatch whose answers
+// can all be cast, and a rename for an unknown field when exactly one field of
+// the schema, answered by no submission, takes its answers
+func suggestMigrationRules(
+	incompatibilities []SchemaIncompatibility,
+	fields map[string]string,
+	answered map[string]bool,
+) []model.MigrationRule {
+	suggestions := []model.MigrationRule{}
+	claimed := make(map[string]int)
+	renames := make(map[string]string)
+
+	for _, incompatibility := range incompatibilities {
+		if incompatibility.Kind != IncompatibilityUnknownField {
+			continue
+		}
+
+		var candidates []string
+
+		for key, expected := range fields {
+			if answered[key] {
+				continue
+			}
+
+			if expected == "" || incompatibility.Found[expected] == incompatibility.Submissions {
+				candidates = append(candidates, key)
+			}
+		}
+
+		if len(candidates) == 1 {
+			renames[incompatibility.Field] = candidates[0]
+			claimed[candidates[0]]++
+		}
+	}
+
+	for _, incompatibility := range incompatibilities {
+		switch incompatibility.Kind {
+		case IncompatibilityTypeMismatch:
+			if incompatibility.Castable {
+				suggestions = append(suggestions, model.MigrationRule{
+					Action: model.MigrationCast, Field: incompatibility.Field, Type: incompatibility.Expected,
+				})
+			}
+		case IncompatibilityUnknownField:
+			if to, ok := renames[incompatibility.Field]; ok && claimed[to] == 1 {
+				suggestions = append(suggestions, model.MigrationRule{
+					Action: model.MigrationRename, Field: incompatibility.Field, To: to,
+				})
+			}
+		}
+	}
+
+	return suggestions
+}
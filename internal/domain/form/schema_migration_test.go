@@ -0,0 +1,120 @@
+package form_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	domainerrors "github.com/goformx/goforms/internal/domain/common/errors"
+	domainform "github.com/goformx/goforms/internal/domain/form"
+	"github.com/goformx/goforms/internal/domain/form/model"
+	memorystore "github.com/goformx/goforms/internal/infrastructure/repository/memory"
+	mocklogging "github.com/goformx/goforms/test/mocks/logging"
+)
+
+// migratedSchema is a schema after the field "name" was renamed to "full_name"
+// and "age" changed from a text field to a number
+func migratedSchema() model.JSON {
+	return model.JSON{"components": []any{
+		map[string]any{"key": "full_name", "type": "textfield"},
+		map[string]any{"key": "age", "type": "number"},
+		map[string]any{"key": "address", "type": "container", "components": []any{
+			map[string]any{"key": "city", "type": "textfield"},
+		}},
+	}}
+}
+
+func TestPlanSchemaMigration(t *testing.T) {
+	form := &model.Form{ID: "form-1", Schema: migratedSchema()}
+	submissions := []*model.FormSubmission{
+		{Data: model.JSON{"name": "Ada Lovelace", "age": "36", "address": map[string]any{"city": "London"}}},
+		{Data: model.JSON{"name": "Alan Turing", "age": float64(41)}},
+		{Data: model.JSON{"name": "", "age": ""}},
+	}
+
+	plan := domainform.PlanSchemaMigration(form, submissions)
+	assert.Equal(t, "form-1", plan.FormID)
+	assert.Equal(t, 3, plan.Submissions)
+	assert.Equal(t, []domainform.SchemaIncompatibility{
+		{
+			Field: "age", Kind: domainform.IncompatibilityTypeMismatch, Expected: model.ValueTypeNumber,
+			Found: map[string]int{model.ValueTypeString: 1}, Submissions: 1, Castable: true,
+		},
+		{Field: "name", Kind: domainform.IncompatibilityUnknownField, Found: map[string]int{model.ValueTypeString: 2}, Submissions: 2},
+	}, plan.Incompatibilities, "blank answers and nested keys fit")
+	assert.Equal(t, []model.MigrationRule{
+		{Action: model.MigrationCast, Field: "age", Type: model.ValueTypeNumber},
+		{Action: model.MigrationRename, Field: "name", To: "full_name"},
+	}, plan.Suggestions)
+
+	submissions[0].Data["age"] = "thirty-six"
+	plan = domainform.PlanSchemaMigration(form, submissions)
+	require.Len(t, plan.Incompatibilities, 2)
+	assert.False(t, plan.Incompatibilities[0].Castable)
+	assert.Equal(t, []model.MigrationRule{{Action: model.MigrationRename, Field: "name", To: "full_name"}}, plan.Suggestions)
+}
+
+func TestSchemaMigrationService_Migrate(t *testing.T) {
+	ctx := context.Background()
+	logger := mocklogging.NewMockLogger(gomock.NewController(t))
+	logger.EXPECT().Info(gomock.Any(), gomock.Any()).AnyTimes()
+
+	forms := memorystore.NewStore(logger).Forms()
+	form := model.NewForm("user-1", "Contact form", "", migratedSchema())
+	require.NoError(t, forms.CreateForm(ctx, form))
+
+	for _, data := range []model.JSON{
+		{"name": "Ada Lovelace", "age": "36"},
+		{"name": "Alan Turing", "age": "unknown"},
+		{"full_name": "Grace Hopper", "age": float64(85)},
+	} {
+		require.NoError(t, forms.CreateSubmission(ctx, &model.FormSubmission{FormID: form.ID, Data: data}))
+	}
+
+	service := domainform.NewSchemaMigrationService(forms, logger)
+	rules := []model.MigrationRule{
+		{Action: model.MigrationRename, Field: "name", To: "full_name"},
+		{Action: model.MigrationCast, Field: "age", Type: model.ValueTypeNumber},
+	}
+
+	_, err := service.Migrate(ctx, form, []model.MigrationRule{{Action: model.MigrationCast, Field: "age", Type: "date"}}, false)
+	domainErr := domainerrors.GetDomainError(err)
+	require.NotNil(t, domainErr)
+	assert.Equal(t, domainerrors.ErrCodeValidation, domainErr.Code)
+
+	result, err := service.Migrate(ctx, form, rules, false)
+	require.NoError(t, err)
+	assert.True(t, result.DryRun)
+	assert.Equal(t, 3, result.Submissions)
+	assert.Equal(t, 1, result.Changed)
+	require.Len(t, result.Failures, 1)
+	assert.Equal(t, "age", result.Failures[0].Field)
+	require.Len(t, result.Remaining, 2, "the failed submission keeps its unknown field and mismatched age")
+
+	plan, err := service.Plan(ctx, form)
+	require.NoError(t, err)
+	assert.Len(t, plan.Incompatibilities, 2, "a dry run saves nothing")
+
+	result, err = service.Migrate(ctx, form, rules, true)
+	require.NoError(t, err)
+	assert.False(t, result.DryRun)
+	assert.Equal(t, 1, result.Changed)
+
+	submissions, err := forms.ListSubmissions(ctx, form.ID)
+	require.NoError(t, err)
+
+	migrated := 0
+
+	for _, submission := range submissions {
+		if submission.Data["full_name"] == "Ada Lovelace" {
+			assert.Equal(t, model.JSON{"full_name": "Ada Lovelace", "age": float64(36)}, submission.Data)
+
+			migrated++
+		}
+	}
+
+	assert.Equal(t, 1, migrated)
+}
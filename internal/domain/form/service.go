@@ -34,6 +34,9 @@ type Service interface {
 	GetFormSubmission(ctx context.Context, submissionID string) (*model.FormSubmission, error)
 	ListFormSubmissions(ctx context.Context, formID string) ([]*model.FormSubmission, error)
 	UpdateFormState(ctx context.Context, formID, state string) error
+	// TransitionForm moves a form along a lifecycle transition, saves it and
+	// publishes the transition's event
+	TransitionForm(ctx context.Context, form *model.Form, transition model.FormTransition) error
 	TrackFormAnalytics(ctx context.Context, formID, eventType string) error
 }
 
@@ -41,24 +44,73 @@ type Service interface {
 type formService struct {
 	repository Repository
 	eventBus   events.EventBus
+	quotas     QuotaService
+	datasets   DatasetLookup
+	images     ImageLimits
 	logger     logging.Logger
+	// submissions, when set, stores submissions write-behind
+	submissions *SubmissionQueue
 }
 
-// NewService creates a new form service
-func NewService(repository Repository, eventBus events.EventBus, logger logging.Logger) Service {
+// NewService creates a new form service. A nil quota service disables quota
+// enforcement; without datasets, computed fields calling lookup() fail.
+func NewService(
+	repository Repository,
+	eventBus events.EventBus,
+	quotas QuotaService,
+	datasets DatasetLookup,
+	images ImageLimits,
+	logger logging.Logger,
+) Service {
 	return &formService{
 		repository: repository,
 		eventBus:   eventBus,
+		quotas:     quotas,
+		datasets:   datasets,
+		images:     images,
 		logger:     logger,
 	}
 }
 
+// NewWriteBehindService creates a form service that hands submissions to queue instead
+// of inserting them before SubmitForm returns. Submission events are published once a
+// submission has been written. Forms with SubmissionWriteModeSync, and submissions
+// arriving while the queue is full, are still written synchronously.
+func NewWriteBehindService(
+	repository Repository,
+	eventBus events.EventBus,
+	quotas QuotaService,
+	datasets DatasetLookup,
+	images ImageLimits,
+	queue *SubmissionQueue,
+	logger logging.Logger,
+) Service {
+	s := &formService{
+		repository:  repository,
+		eventBus:    eventBus,
+		quotas:      quotas,
+		datasets:    datasets,
+		images:      images,
+		logger:      logger,
+		submissions: queue,
+	}
+	queue.onStored = s.publishSubmissionEvents
+
+	return s
+}
+
 // CreateForm creates a new form
 func (s *formService) CreateForm(ctx context.Context, form *model.Form) error {
 	if err := form.Validate(); err != nil {
 		return fmt.Errorf("form validation failed: %w", err)
 	}
 
+	if s.quotas != nil {
+		if err := s.quotas.CheckFormCreate(ctx, form.UserID); err != nil {
+			return fmt.Errorf("check form quota: %w", err)
+		}
+	}
+
 	// Set form ID if not already set
 	if form.ID == "" {
 		form.ID = uuid.New().String()
@@ -149,8 +201,36 @@ func (s *formService) SubmitForm(ctx context.Context, submission *model.FormSubm
 		return errors.New("form not found")
 	}
 
+	// Derive server-side computed fields so they are stored alongside the raw answers
+	lookup := ownerLookup(ctx, s.datasets, form.UserID)
+	if computeErr := ApplyComputedFieldsWithLookup(form.Schema, submission.Data, lookup); computeErr != nil {
+		return fmt.Errorf("compute submission fields: %w", computeErr)
+	}
+
+	if imageErr := ApplyImageProcessing(form.Schema, submission.Data, s.images); imageErr != nil {
+		return fmt.Errorf("process submission images: %w", imageErr)
+	}
+
+	// Enforce the form owner's plan quotas before storing anything; test
+	// submissions from the builder's preview do not count
+	if s.quotas != nil && !submission.Test {
+		if quotaErr := s.quotas.CheckSubmission(ctx, form.UserID, submissionSize(submission.Data)); quotaErr != nil {
+			return fmt.Errorf("check submission quota: %w", quotaErr)
+		}
+	}
+
+	// Unique values are checked as the submission is written, so it cannot wait in the
+	// queue. Test submissions do not claim them, so respondents can still use them.
+	var uniqueValues []UniqueValue
+	if !submission.Test {
+		uniqueValues = UniqueSubmissionValues(form.Schema, submission.Data)
+	}
+	if len(uniqueValues) == 0 && s.enqueueSubmission(form, submission) {
+		return nil
+	}
+
 	// Create the submission (validation already passed above)
-	if createErr := s.repository.CreateSubmission(ctx, submission); createErr != nil {
+	if createErr := s.createSubmission(ctx, submission, uniqueValues); createErr != nil {
 		return fmt.Errorf("create form submission: %w", createErr)
 	}
 
@@ -160,6 +240,41 @@ func (s *formService) SubmitForm(ctx context.Context, submission *model.FormSubm
 	return nil
 }
 
+// createSubmission stores a submission, together with its unique values when it has any
+func (s *formService) createSubmission(ctx context.Context, submission *model.FormSubmission, uniqueValues []UniqueValue) error {
+	if len(uniqueValues) == 0 {
+		return s.repository.CreateSubmission(ctx, submission)
+	}
+
+	uniques, ok := s.repository.(UniqueSubmissionRepository)
+	if !ok {
+		return ErrUniqueValuesUnsupported
+	}
+
+	return uniques.CreateSubmissionWithUniqueValues(ctx, submission, uniqueValues)
+}
+
+// enqueueSubmission hands a submission to the write-behind queue. It returns false when
+// the submission must be written synchronously instead.
+func (s *formService) enqueueSubmission(form *model.Form, submission *model.FormSubmission) bool {
+	if s.submissions == nil || form.RequiresSyncSubmissions() {
+		return false
+	}
+
+	// The ID is returned to the submitter before the row exists
+	if submission.ID == "" {
+		submission.ID = uuid.New().String()
+	}
+
+	if err := s.submissions.Enqueue(submission); err != nil {
+		s.logger.Warn("writing submission synchronously", "form_id", form.ID, "reason", err.Error())
+
+		return false
+	}
+
+	return true
+}
+
 // publishSubmissionEvents publishes all events related to a form submission
 func (s *formService) publishSubmissionEvents(ctx context.Context, submission *model.FormSubmission) {
 	// Publish form submitted event
@@ -218,6 +333,30 @@ func (s *formService) UpdateFormState(ctx context.Context, formID, state string)
 	return nil
 }
 
+// TransitionForm moves a form along a lifecycle transition. The form is saved
+// through UpdateForm, so form.updated is published as well as the event of
+// the transition.
+func (s *formService) TransitionForm(ctx context.Context, form *model.Form, transition model.FormTransition) error {
+	from, err := form.Transition(transition)
+	if err != nil {
+		return fmt.Errorf("transition form: %w", err)
+	}
+
+	if err = s.UpdateForm(ctx, form); err != nil {
+		form.Status = from
+
+		return err
+	}
+
+	if publishErr := s.eventBus.Publish(ctx, formevents.NewFormTransitionEvent(transition, form, from)); publishErr != nil {
+		s.logger.Error("failed to publish form transition event", "transition", string(transition), "error", publishErr)
+	}
+
+	s.logger.Info("form status changed", "form_id", form.ID, "from", from, "to", form.Status)
+
+	return nil
+}
+
 // TrackFormAnalytics tracks form analytics
 func (s *formService) TrackFormAnalytics(ctx context.Context, formID, eventType string) error {
 	event := formevents.NewAnalyticsEvent(formID, eventType)
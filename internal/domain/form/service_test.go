@@ -11,6 +11,7 @@ import (
 
 	"github.com/goformx/goforms/internal/domain/common/events"
 	domainform "github.com/goformx/goforms/internal/domain/form"
+	formevents "github.com/goformx/goforms/internal/domain/form/events"
 	"github.com/goformx/goforms/internal/domain/form/model"
 	mockevents "github.com/goformx/goforms/test/mocks/events"
 	mockform "github.com/goformx/goforms/test/mocks/form"
@@ -51,7 +52,7 @@ func TestService_CreateForm_minimal(t *testing.T) {
 	})
 	eventBus.EXPECT().Publish(gomock.Any(), gomock.Any()).Return(nil)
 
-	svc := domainform.NewService(repo, eventBus, logger)
+	svc := domainform.NewService(repo, eventBus, nil, nil, domainform.ImageLimits{}, logger)
 
 	ctx, cancel := context.WithTimeout(t.Context(), 2*time.Second)
 	defer cancel()
@@ -94,7 +95,7 @@ func TestService_ListForms(t *testing.T) {
 	t.Run("successful list", func(t *testing.T) {
 		repo.EXPECT().ListForms(gomock.Any(), userID).Return(expectedForms, nil)
 
-		svc := domainform.NewService(repo, eventBus, logger)
+		svc := domainform.NewService(repo, eventBus, nil, nil, domainform.ImageLimits{}, logger)
 
 		ctx, cancel := context.WithTimeout(t.Context(), 2*time.Second)
 		defer cancel()
@@ -109,7 +110,7 @@ func TestService_ListForms(t *testing.T) {
 	t.Run("repository error", func(t *testing.T) {
 		repo.EXPECT().ListForms(gomock.Any(), userID).Return(nil, errors.New("database error"))
 
-		svc := domainform.NewService(repo, eventBus, logger)
+		svc := domainform.NewService(repo, eventBus, nil, nil, domainform.ImageLimits{}, logger)
 
 		ctx, cancel := context.WithTimeout(t.Context(), 2*time.Second)
 		defer cancel()
@@ -123,7 +124,7 @@ func TestService_ListForms(t *testing.T) {
 	t.Run("empty list", func(t *testing.T) {
 		repo.EXPECT().ListForms(gomock.Any(), userID).Return([]*model.Form{}, nil)
 
-		svc := domainform.NewService(repo, eventBus, logger)
+		svc := domainform.NewService(repo, eventBus, nil, nil, domainform.ImageLimits{}, logger)
 
 		ctx, cancel := context.WithTimeout(t.Context(), 2*time.Second)
 		defer cancel()
@@ -179,7 +180,7 @@ func TestService_UpdateForm(t *testing.T) {
 			return nil
 		})
 
-		svc := domainform.NewService(repo, eventBus, logger)
+		svc := domainform.NewService(repo, eventBus, nil, nil, domainform.ImageLimits{}, logger)
 
 		ctx, cancel := context.WithTimeout(t.Context(), 2*time.Second)
 		defer cancel()
@@ -195,7 +196,7 @@ func TestService_UpdateForm(t *testing.T) {
 			Title:  "", // Invalid: empty title
 		}
 
-		svc := domainform.NewService(repo, eventBus, logger)
+		svc := domainform.NewService(repo, eventBus, nil, nil, domainform.ImageLimits{}, logger)
 
 		ctx, cancel := context.WithTimeout(t.Context(), 2*time.Second)
 		defer cancel()
@@ -208,7 +209,7 @@ func TestService_UpdateForm(t *testing.T) {
 	t.Run("repository error", func(t *testing.T) {
 		repo.EXPECT().UpdateForm(gomock.Any(), gomock.Any()).Return(errors.New("database error"))
 
-		svc := domainform.NewService(repo, eventBus, logger)
+		svc := domainform.NewService(repo, eventBus, nil, nil, domainform.ImageLimits{}, logger)
 
 		ctx, cancel := context.WithTimeout(t.Context(), 2*time.Second)
 		defer cancel()
@@ -223,7 +224,7 @@ func TestService_UpdateForm(t *testing.T) {
 		eventBus.EXPECT().Publish(gomock.Any(), gomock.Any()).Return(errors.New("event bus error"))
 		logger.EXPECT().Error(gomock.Any(), gomock.Any(), gomock.Any()).Return()
 
-		svc := domainform.NewService(repo, eventBus, logger)
+		svc := domainform.NewService(repo, eventBus, nil, nil, domainform.ImageLimits{}, logger)
 
 		ctx, cancel := context.WithTimeout(t.Context(), 2*time.Second)
 		defer cancel()
@@ -233,6 +234,59 @@ func TestService_UpdateForm(t *testing.T) {
 	})
 }
 
+func TestService_TransitionForm(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	t.Cleanup(ctrl.Finish)
+
+	repo := mockform.NewMockRepository(ctrl)
+	eventBus := mockevents.NewMockEventBus(ctrl)
+	logger := mocklogging.NewMockLogger(ctrl)
+	logger.EXPECT().Info(gomock.Any(), gomock.Any()).AnyTimes()
+
+	form := &model.Form{
+		ID:          "form123",
+		UserID:      "user123",
+		Title:       "Contact",
+		Status:      model.FormStatusDraft,
+		Schema:      model.JSON{"type": "object", "properties": map[string]any{}},
+		CorsOrigins: model.JSON{"origins": []any{"https://example.com"}},
+	}
+
+	svc := domainform.NewService(repo, eventBus, nil, nil, domainform.ImageLimits{}, logger)
+
+	t.Run("publish", func(t *testing.T) {
+		var published []events.Event
+
+		repo.EXPECT().UpdateForm(gomock.Any(), form).Return(nil)
+		eventBus.EXPECT().Publish(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, event events.Event) error {
+			published = append(published, event)
+
+			return nil
+		}).Times(2)
+
+		require.NoError(t, svc.TransitionForm(t.Context(), form, model.TransitionPublish))
+		require.Equal(t, model.FormStatusPublished, form.Status)
+
+		require.Len(t, published, 2)
+		require.Equal(t, "form.updated", published[0].Name())
+		require.Equal(t, "form.published", published[1].Name())
+		require.Equal(t, &formevents.Transition{Form: form, From: model.FormStatusDraft}, published[1].Payload())
+	})
+
+	t.Run("not allowed", func(t *testing.T) {
+		err := svc.TransitionForm(t.Context(), form, model.TransitionPublish)
+		require.ErrorIs(t, err, model.ErrFormTransitionNotAllowed)
+	})
+
+	t.Run("repository error keeps the status", func(t *testing.T) {
+		repo.EXPECT().UpdateForm(gomock.Any(), form).Return(errors.New("database error"))
+
+		err := svc.TransitionForm(t.Context(), form, model.TransitionArchive)
+		require.Error(t, err)
+		require.Equal(t, model.FormStatusPublished, form.Status)
+	})
+}
+
 func TestService_DeleteForm(t *testing.T) {
 	formID := "form123"
 
@@ -249,7 +303,7 @@ func TestService_DeleteForm(t *testing.T) {
 			return nil
 		})
 
-		svc := domainform.NewService(repo, eventBus, logger)
+		svc := domainform.NewService(repo, eventBus, nil, nil, domainform.ImageLimits{}, logger)
 
 		ctx, cancel := context.WithTimeout(t.Context(), 2*time.Second)
 		defer cancel()
@@ -266,7 +320,7 @@ func TestService_DeleteForm(t *testing.T) {
 
 		repo.EXPECT().DeleteForm(gomock.Any(), formID).Return(errors.New("database error"))
 
-		svc := domainform.NewService(repo, eventBus, logger)
+		svc := domainform.NewService(repo, eventBus, nil, nil, domainform.ImageLimits{}, logger)
 
 		ctx, cancel := context.WithTimeout(t.Context(), 2*time.Second)
 		defer cancel()
@@ -286,7 +340,7 @@ func TestService_DeleteForm(t *testing.T) {
 		eventBus.EXPECT().Publish(gomock.Any(), gomock.Any()).Return(errors.New("event bus error"))
 		logger.EXPECT().Error(gomock.Any(), gomock.Any(), gomock.Any()).Return()
 
-		svc := domainform.NewService(repo, eventBus, logger)
+		svc := domainform.NewService(repo, eventBus, nil, nil, domainform.ImageLimits{}, logger)
 
 		ctx, cancel := context.WithTimeout(t.Context(), 2*time.Second)
 		defer cancel()
@@ -301,7 +355,7 @@ func TestService_DeleteForm(t *testing.T) {
 		eventBus := mockevents.NewMockEventBus(ctrl)
 		logger := mocklogging.NewMockLogger(ctrl)
 
-		svc := domainform.NewService(repo, eventBus, logger)
+		svc := domainform.NewService(repo, eventBus, nil, nil, domainform.ImageLimits{}, logger)
 
 		ctx, cancel := context.WithTimeout(t.Context(), 2*time.Second)
 		defer cancel()
@@ -332,7 +386,7 @@ func TestService_GetForm(t *testing.T) {
 	t.Run("successful get", func(t *testing.T) {
 		repo.EXPECT().GetFormByID(gomock.Any(), "form123").Return(expectedForm, nil)
 
-		svc := domainform.NewService(repo, eventBus, logger)
+		svc := domainform.NewService(repo, eventBus, nil, nil, domainform.ImageLimits{}, logger)
 
 		ctx, cancel := context.WithTimeout(t.Context(), 2*time.Second)
 		defer cancel()
@@ -345,7 +399,7 @@ func TestService_GetForm(t *testing.T) {
 	t.Run("form not found", func(t *testing.T) {
 		repo.EXPECT().GetFormByID(gomock.Any(), "nonexistent").Return(nil, errors.New("not found"))
 
-		svc := domainform.NewService(repo, eventBus, logger)
+		svc := domainform.NewService(repo, eventBus, nil, nil, domainform.ImageLimits{}, logger)
 
 		ctx, cancel := context.WithTimeout(t.Context(), 2*time.Second)
 		defer cancel()
@@ -429,7 +483,7 @@ func TestService_SubmitForm(t *testing.T) {
 			return nil
 		})
 
-		svc := domainform.NewService(repo, eventBus, logger)
+		svc := domainform.NewService(repo, eventBus, nil, nil, domainform.ImageLimits{}, logger)
 
 		ctx, cancel := context.WithTimeout(t.Context(), 2*time.Second)
 		defer cancel()
@@ -442,7 +496,7 @@ func TestService_SubmitForm(t *testing.T) {
 		// Set up mock expectations
 		repo.EXPECT().GetFormByID(gomock.Any(), form.ID).Return(nil, nil)
 
-		svc := domainform.NewService(repo, eventBus, logger)
+		svc := domainform.NewService(repo, eventBus, nil, nil, domainform.ImageLimits{}, logger)
 
 		ctx, cancel := context.WithTimeout(t.Context(), 2*time.Second)
 		defer cancel()
@@ -458,7 +512,7 @@ func TestService_SubmitForm(t *testing.T) {
 			Data:   nil, // Missing required data
 		}
 
-		svc := domainform.NewService(repo, eventBus, logger)
+		svc := domainform.NewService(repo, eventBus, nil, nil, domainform.ImageLimits{}, logger)
 
 		ctx, cancel := context.WithTimeout(t.Context(), 2*time.Second)
 		defer cancel()
@@ -473,7 +527,7 @@ func TestService_SubmitForm(t *testing.T) {
 		repo.EXPECT().GetFormByID(gomock.Any(), form.ID).Return(form, nil)
 		repo.EXPECT().CreateSubmission(gomock.Any(), gomock.Any()).Return(errors.New("database error"))
 
-		svc := domainform.NewService(repo, eventBus, logger)
+		svc := domainform.NewService(repo, eventBus, nil, nil, domainform.ImageLimits{}, logger)
 
 		ctx, cancel := context.WithTimeout(t.Context(), 2*time.Second)
 		defer cancel()
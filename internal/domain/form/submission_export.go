@@ -0,0 +1,261 @@
+package form
+
+import (
+	"bytes"
+	"encoding/csv"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/goformx/goforms/internal/domain/form/model"
+)
+
+// SubmissionColumns returns the data keys to export: schema input components in
+// schema order, then any other submitted keys (such as computed fields) sorted by name.
+// Addresses and matrices get a column per part or question, named
+// "<key>.<part>"; SubmissionValue reads them.
+func SubmissionColumns(schema model.JSON, submissions []*model.FormSubmission) []string {
+	seen := make(map[string]bool)
+	columns := []string{}
+
+	_ = model.WalkSchema(schema, func(component map[string]any) error {
+		if model.IsLayoutComponent(component) {
+			return nil
+		}
+
+		key, ok := component["key"].(string)
+		if !ok || component["type"] == "button" || seen[key] {
+			return model.SkipChildren
+		}
+
+		seen[key] = true
+
+		if parts := componentParts(component); len(parts) > 0 {
+			for _, part := range parts {
+				columns = append(columns, key+"."+part)
+			}
+
+			return model.SkipChildren
+		}
+
+		columns = append(columns, key)
+
+		return model.SkipChildren
+	})
+
+	var extra []string
+
+	for _, submission := range submissions {
+		for key := range submission.Data {
+			if !seen[key] {
+				seen[key] = true
+				extra = append(extra, key)
+			}
+		}
+	}
+
+	sort.Strings(extra)
+
+	return append(columns, extra...)
+}
+
+// SubmissionsCSV renders submissions as CSV with one column per data key.
+// submitted_at is given in loc, with its UTC offset.
+func SubmissionsCSV(schema model.JSON, submissions []*model.FormSubmission, loc *time.Location) ([]byte, error) {
+	columns := SubmissionColumns(schema, submissions)
+
+	var buf bytes.Buffer
+
+	writer := csv.NewWriter(&buf)
+
+	header := append([]string{"submission_id", "submitted_at", "status"}, columns...)
+	if err := writer.Write(header); err != nil {
+		return nil, fmt.Errorf("write csv header: %w", err)
+	}
+
+	for _, submission := range submissions {
+		row := []string{
+			submission.ID,
+			submission.SubmittedAt.In(loc).Format(time.RFC3339),
+			string(submission.Status),
+		}
+
+		for _, column := range columns {
+			value := SubmissionValue(submission.Data, column)
+			if value == nil {
+				row = append(row, "")
+
+				continue
+			}
+
+			row = append(row, csvSafe(FormatSubmissionValue(value)))
+		}
+
+		if err := writer.Write(row); err != nil {
+			return nil, fmt.Errorf("write csv row: %w", err)
+		}
+	}
+
+	writer.Flush()
+
+	if err := writer.Error(); err != nil {
+		return nil, fmt.Errorf("flush csv: %w", err)
+	}
+
+	return buf.Bytes(), nil
+}
+
+// componentParts returns the parts of an address or the questions of a
+// matrix, which are exported as columns of their own
+func componentParts(component map[string]any) []string {
+	switch component["type"] {
+	case model.FieldAddress:
+		return model.AddressParts
+	case model.FieldMatrix, "survey":
+		questions, _ := component["questions"].([]any)
+		parts := make([]string, 0, len(questions))
+
+		for _, question := range questions {
+			if questionMap, ok := question.(map[string]any); ok {
+				if value, valueOk := questionMap["value"].(string); valueOk && value != "" {
+					parts = append(parts, value)
+				}
+			}
+		}
+
+		return parts
+	}
+
+	return nil
+}
+
+// SubmissionValue returns the value of an export column: the data key, or
+// for a "<key>.<part>" column of an address or matrix, the part of the key's object
+func SubmissionValue(data model.JSON, column string) any {
+	if value, ok := data[column]; ok {
+		return value
+	}
+
+	key, part, found := strings.Cut(column, ".")
+	if !found {
+		return nil
+	}
+
+	object, _ := data[key].(map[string]any)
+
+	return object[part]
+}
+
+// csvSafe neutralises values that spreadsheet applications would evaluate as formulas.
+// Plain numbers such as "-5" are left untouched.
+func csvSafe(value string) string {
+	if _, err := strconv.ParseFloat(value, 64); err == nil {
+		return value
+	}
+
+	if value != "" && strings.ContainsRune("=+-@\t\r", rune(value[0])) {
+		return "'" + value
+	}
+
+	return value
+}
+
+// FormatSubmissionValue renders a submitted value as human-readable text for documents and exports
+func FormatSubmissionValue(value any) string {
+	switch val := value.(type) {
+	case nil:
+		return "-"
+	case string:
+		if val == "" {
+			return "-"
+		}
+
+		return val
+	case bool:
+		if val {
+			return "Yes"
+		}
+
+		return "No"
+	case float64:
+		return strconv.FormatFloat(val, 'f', -1, 64)
+	case []any:
+		parts := make([]string, 0, len(val))
+		for _, item := range val {
+			parts = append(parts, FormatSubmissionValue(item))
+		}
+
+		return strings.Join(parts, ", ")
+	case map[string]any:
+		// File uploads carry a name; selectboxes map option values to booleans
+		if name, ok := val["originalName"].(string); ok {
+			return name
+		}
+
+		if name, ok := val["name"].(string); ok {
+			return name
+		}
+
+		if address, ok := formatAddress(val); ok {
+			return address
+		}
+
+		checked := make([]string, 0, len(val))
+
+		for key, item := range val {
+			if isChecked, ok := item.(bool); ok && isChecked {
+				checked = append(checked, key)
+			}
+		}
+
+		sort.Strings(checked)
+
+		if len(checked) == 0 {
+			// Matrix answers map questions to the values chosen
+			return formatAnswers(val)
+		}
+
+		return FormatSubmissionValue(strings.Join(checked, ", "))
+	}
+
+	return fmt.Sprint(value)
+}
+
+// formatAddress renders an address on one line, its parts in display order
+func formatAddress(address map[string]any) (string, bool) {
+	if _, ok := address[model.AddressCountry].(string); !ok {
+		return "", false
+	}
+
+	if _, ok := address[model.AddressStreet].(string); !ok {
+		return "", false
+	}
+
+	parts := make([]string, 0, len(model.AddressParts))
+
+	for _, part := range model.AddressParts {
+		if text, _ := address[part].(string); strings.TrimSpace(text) != "" {
+			parts = append(parts, strings.TrimSpace(text))
+		}
+	}
+
+	return FormatSubmissionValue(strings.Join(parts, ", ")), true
+}
+
+// formatAnswers renders an object of text answers as "question: answer"
+// pairs sorted by question
+func formatAnswers(answers map[string]any) string {
+	pairs := make([]string, 0, len(answers))
+
+	for question, answer := range answers {
+		if text, ok := answer.(string); ok && text != "" {
+			pairs = append(pairs, question+": "+text)
+		}
+	}
+
+	sort.Strings(pairs)
+
+	return FormatSubmissionValue(strings.Join(pairs, "; "))
+}
@@ -0,0 +1,72 @@
+package form_test
+
+import (
+	"encoding/csv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	domainform "github.com/goformx/goforms/internal/domain/form"
+	"github.com/goformx/goforms/internal/domain/form/model"
+)
+
+func fieldLibrarySchema() model.JSON {
+	return model.JSON{"components": []any{
+		map[string]any{"key": "stars", "type": "rating"},
+		map[string]any{"key": "home", "type": "postaladdress"},
+		map[string]any{"key": "service", "type": "matrix", "questions": []any{
+			map[string]any{"label": "Speed", "value": "speed"},
+			map[string]any{"label": "Quality", "value": "quality"},
+		}},
+	}}
+}
+
+func TestSubmissionColumns_SplitsAddressesAndMatrices(t *testing.T) {
+	columns := domainform.SubmissionColumns(fieldLibrarySchema(), nil)
+
+	assert.Equal(t, []string{
+		"stars",
+		"home.street", "home.street2", "home.city", "home.region", "home.postalCode", "home.country",
+		"service.speed", "service.quality",
+	}, columns)
+}
+
+func TestSubmissionsCSV_FieldLibrary(t *testing.T) {
+	submission := &model.FormSubmission{ID: "s1", SubmittedAt: time.Unix(0, 0), Status: model.SubmissionStatusPending, Data: model.JSON{
+		"stars":   float64(4),
+		"home":    map[string]any{"street": "1 Main St", "city": "Springfield", "postalCode": "62701", "country": "US"},
+		"service": map[string]any{"speed": "good"},
+	}}
+
+	data, err := domainform.SubmissionsCSV(fieldLibrarySchema(), []*model.FormSubmission{submission}, time.UTC)
+	require.NoError(t, err)
+
+	rows, err := csv.NewReader(strings.NewReader(string(data))).ReadAll()
+	require.NoError(t, err)
+	require.Len(t, rows, 2)
+
+	values := map[string]string{}
+	for i, column := range rows[0] {
+		values[column] = rows[1][i]
+	}
+
+	assert.Equal(t, "4", values["stars"])
+	assert.Equal(t, "1 Main St", values["home.street"])
+	assert.Equal(t, "US", values["home.country"])
+	assert.Equal(t, "", values["home.street2"])
+	assert.Equal(t, "good", values["service.speed"])
+	assert.Equal(t, "", values["service.quality"])
+}
+
+func TestFormatSubmissionValue_FieldLibrary(t *testing.T) {
+	address := map[string]any{"street": "1 Main St", "street2": "", "city": "Springfield", "postalCode": "62701", "country": "US"}
+	assert.Equal(t, "1 Main St, Springfield, 62701, US", domainform.FormatSubmissionValue(address))
+
+	answers := map[string]any{"speed": "good", "quality": "bad"}
+	assert.Equal(t, "quality: bad; speed: good", domainform.FormatSubmissionValue(answers))
+
+	assert.Equal(t, "a, c", domainform.FormatSubmissionValue(map[string]any{"a": true, "b": false, "c": true}), "selectboxes are unchanged")
+}
@@ -0,0 +1,48 @@
+package form
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/goformx/goforms/internal/infrastructure/logging"
+)
+
+// TestSubmissionRepository deletes the test submissions made from the builder's preview
+type TestSubmissionRepository interface {
+	// DeleteTestSubmissionsBefore deletes the test submissions received before
+	// before and returns how many there were
+	DeleteTestSubmissionsBefore(ctx context.Context, before time.Time) (int64, error)
+}
+
+// TestSubmissionPurger deletes test submissions once they are older than the retention
+type TestSubmissionPurger struct {
+	repository TestSubmissionRepository
+	retention  time.Duration
+	logger     logging.Logger
+	now        func() time.Time
+}
+
+// NewTestSubmissionPurger creates a purger deleting test submissions older than retention
+func NewTestSubmissionPurger(repository TestSubmissionRepository, retention time.Duration, logger logging.Logger) *TestSubmissionPurger {
+	return &TestSubmissionPurger{
+		repository: repository,
+		retention:  retention,
+		logger:     logger,
+		now:        time.Now,
+	}
+}
+
+// Purge deletes the test submissions received longer ago than the retention
+func (p *TestSubmissionPurger) Purge(ctx context.Context) error {
+	deleted, err := p.repository.DeleteTestSubmissionsBefore(ctx, p.now().Add(-p.retention))
+	if err != nil {
+		return fmt.Errorf("purge test submissions: %w", err)
+	}
+
+	if deleted > 0 {
+		p.logger.Info("test submissions purged", "deleted", deleted, "retention", p.retention)
+	}
+
+	return nil
+}
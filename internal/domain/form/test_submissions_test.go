@@ -0,0 +1,44 @@
+package form_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	domainform "github.com/goformx/goforms/internal/domain/form"
+	mocklogging "github.com/goformx/goforms/test/mocks/logging"
+)
+
+// testSubmissionRepository records the cutoffs test submissions are purged at
+type testSubmissionRepository struct {
+	cutoffs []time.Time
+	err     error
+}
+
+func (r *testSubmissionRepository) DeleteTestSubmissionsBefore(_ context.Context, before time.Time) (int64, error) {
+	r.cutoffs = append(r.cutoffs, before)
+
+	return 2, r.err
+}
+
+func TestTestSubmissionPurger_Purge(t *testing.T) {
+	logger := mocklogging.NewMockLogger(gomock.NewController(t))
+	logger.EXPECT().Info(gomock.Any(), gomock.Any()).AnyTimes()
+
+	repository := &testSubmissionRepository{}
+	purger := domainform.NewTestSubmissionPurger(repository, 7*24*time.Hour, logger)
+
+	started := time.Now()
+	require.NoError(t, purger.Purge(context.Background()))
+
+	require.Len(t, repository.cutoffs, 1)
+	assert.WithinDuration(t, started.Add(-7*24*time.Hour), repository.cutoffs[0], time.Second)
+
+	repository.err = errors.New("database down")
+	require.ErrorContains(t, purger.Purge(context.Background()), "database down")
+}
@@ -0,0 +1,204 @@
+package form
+
+import (
+	"math"
+	"sync"
+	"time"
+)
+
+const (
+	// throttleLearningRate weighs each finished minute into a form's learned rate
+	throttleLearningRate = 0.1
+	// throttleIdleTTL is how long a form without submissions keeps its learned rate
+	throttleIdleTTL = time.Hour
+	// throttleMaxDecayMinutes caps the idle minutes decayed at once; by then the rate is near zero
+	throttleMaxDecayMinutes = 120
+)
+
+// ThrottleOptions configures an AdaptiveThrottle
+type ThrottleOptions struct {
+	// Multiplier is how far above its learned per-minute rate a form may go
+	Multiplier float64
+	// MinRate is the per-minute rate assumed for quiet forms and forms still learning
+	MinRate int
+	// Cooldown is how long a spiking form stays throttled
+	Cooldown time.Duration
+}
+
+// ThrottleDecision is the outcome of counting one submission
+type ThrottleDecision struct {
+	Allowed bool
+	// Limit is the per-minute allowance in effect for the form
+	Limit int
+	// RetryAfter is how long a rejected client should wait
+	RetryAfter time.Duration
+	// ThrottledUntil is set while the form is throttled after a spike
+	ThrottledUntil *time.Time
+	// Spike reports that this submission started the throttle; it is set once per spike
+	Spike bool
+}
+
+// ThrottleStatus describes a form's learned rate and current limit
+type ThrottleStatus struct {
+	// Rate is the learned submissions per minute
+	Rate float64 `json:"rate"`
+	// Limit is the per-minute allowance in effect
+	Limit int `json:"limit"`
+	// Current is the number of submissions accepted this minute
+	Current        int        `json:"current"`
+	ThrottledUntil *time.Time `json:"throttled_until,omitempty"`
+}
+
+// formRate is the learned rate and current minute's count for one form
+type formRate struct {
+	minute         time.Time
+	count          int
+	rate           float64
+	spiked         bool
+	throttledUntil time.Time
+}
+
+// AdaptiveThrottle learns each form's normal submission rate and throttles a
+// form whose traffic spikes beyond a multiple of it. A spike tightens the
+// form's allowance to its normal rate for the cooldown. Minutes spent
+// throttled are not learned from, so an attack does not raise the baseline.
+// Rates are held in memory and relearned after a restart.
+type AdaptiveThrottle struct {
+	mu        sync.Mutex
+	options   ThrottleOptions
+	forms     map[string]*formRate
+	lastSweep time.Time
+}
+
+// NewAdaptiveThrottle creates a new adaptive throttle
+func NewAdaptiveThrottle(options ThrottleOptions) *AdaptiveThrottle {
+	return &AdaptiveThrottle{
+		options: options,
+		forms:   make(map[string]*formRate),
+	}
+}
+
+// Allow counts a submission to formID at now and decides whether to accept it
+func (t *AdaptiveThrottle) Allow(formID string, now time.Time) ThrottleDecision {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if now.Sub(t.lastSweep) >= throttleIdleTTL {
+		t.sweep(now)
+	}
+
+	r := t.advance(formID, now)
+	decision := ThrottleDecision{Allowed: true}
+
+	if r.throttled(now) {
+		decision.Limit = t.normalLimit(r)
+		until := r.throttledUntil
+		decision.ThrottledUntil = &until
+	} else {
+		decision.Limit = t.spikeLimit(r)
+	}
+
+	if r.count < decision.Limit {
+		r.count++
+
+		return decision
+	}
+
+	if !r.throttled(now) {
+		r.throttledUntil = now.Add(t.options.Cooldown)
+		decision.Spike = true
+		decision.Limit = t.normalLimit(r)
+		until := r.throttledUntil
+		decision.ThrottledUntil = &until
+	}
+
+	r.spiked = true
+	decision.Allowed = false
+	decision.RetryAfter = r.minute.Add(time.Minute).Sub(now)
+
+	return decision
+}
+
+// Status reports formID's learned rate and current limit at now
+func (t *AdaptiveThrottle) Status(formID string, now time.Time) ThrottleStatus {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	r := t.advance(formID, now)
+	status := ThrottleStatus{Rate: r.rate, Current: r.count, Limit: t.spikeLimit(r)}
+
+	if r.throttled(now) {
+		status.Limit = t.normalLimit(r)
+		until := r.throttledUntil
+		status.ThrottledUntil = &until
+	}
+
+	return status
+}
+
+// Reset lifts any throttle on formID, keeping its learned rate
+func (t *AdaptiveThrottle) Reset(formID string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if r, ok := t.forms[formID]; ok {
+		r.throttledUntil = time.Time{}
+	}
+}
+
+// advance returns formID's rate with its minute moved to now, learning from
+// the minutes that have finished since its last submission
+func (t *AdaptiveThrottle) advance(formID string, now time.Time) *formRate {
+	minute := now.Truncate(time.Minute)
+
+	r, ok := t.forms[formID]
+	if !ok {
+		r = &formRate{minute: minute}
+		t.forms[formID] = r
+
+		return r
+	}
+
+	if !minute.After(r.minute) {
+		return r
+	}
+
+	if !r.spiked && !r.throttled(r.minute) {
+		r.rate += throttleLearningRate * (float64(r.count) - r.rate)
+	}
+
+	idle := int(minute.Sub(r.minute)/time.Minute) - 1
+	r.rate *= math.Pow(1-throttleLearningRate, float64(min(idle, throttleMaxDecayMinutes)))
+
+	r.minute = minute
+	r.count = 0
+	r.spiked = false
+
+	return r
+}
+
+// normalLimit is the per-minute allowance while a form is throttled
+func (t *AdaptiveThrottle) normalLimit(r *formRate) int {
+	return int(math.Ceil(max(r.rate, float64(t.options.MinRate))))
+}
+
+// spikeLimit is the per-minute count beyond which a form is throttled
+func (t *AdaptiveThrottle) spikeLimit(r *formRate) int {
+	return int(math.Ceil(t.options.Multiplier * max(r.rate, float64(t.options.MinRate))))
+}
+
+// sweep drops forms that have been idle long enough to forget their rate
+func (t *AdaptiveThrottle) sweep(now time.Time) {
+	for formID, r := range t.forms {
+		if now.Sub(r.minute) >= throttleIdleTTL && !r.throttled(now) {
+			delete(t.forms, formID)
+		}
+	}
+
+	t.lastSweep = now
+}
+
+// throttled reports whether the form is throttled at now
+func (r *formRate) throttled(now time.Time) bool {
+	return now.Before(r.throttledUntil)
+}
@@ -0,0 +1,96 @@
+package form_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	domainform "github.com/goformx/goforms/internal/domain/form"
+)
+
+func newTestThrottle() *domainform.AdaptiveThrottle {
+	return domainform.NewAdaptiveThrottle(domainform.ThrottleOptions{
+		Multiplier: 3,
+		MinRate:    2,
+		Cooldown:   10 * time.Minute,
+	})
+}
+
+// submitN counts n submissions at now and returns the last decision
+func submitN(t *domainform.AdaptiveThrottle, formID string, n int, now time.Time) domainform.ThrottleDecision {
+	var decision domainform.ThrottleDecision
+	for range n {
+		decision = t.Allow(formID, now)
+	}
+
+	return decision
+}
+
+func TestAdaptiveThrottle_AllowsNormalTraffic(t *testing.T) {
+	throttle := newTestThrottle()
+	start := time.Date(2026, time.March, 1, 12, 0, 0, 0, time.UTC)
+
+	for i := range 30 {
+		decision := submitN(throttle, "form-1", 5, start.Add(time.Duration(i)*time.Minute))
+		require.True(t, decision.Allowed, "minute %d", i)
+	}
+
+	status := throttle.Status("form-1", start.Add(30*time.Minute))
+	assert.InDelta(t, 5, status.Rate, 1)
+	assert.Nil(t, status.ThrottledUntil)
+}
+
+func TestAdaptiveThrottle_SpikeTightensLimit(t *testing.T) {
+	throttle := newTestThrottle()
+	start := time.Date(2026, time.March, 1, 12, 0, 0, 0, time.UTC)
+
+	// MinRate 2 × Multiplier 3 allows six submissions in the first minute
+	decision := submitN(throttle, "form-1", 6, start)
+	require.True(t, decision.Allowed)
+
+	decision = throttle.Allow("form-1", start.Add(time.Second))
+	require.False(t, decision.Allowed)
+	assert.True(t, decision.Spike)
+	assert.Equal(t, 2, decision.Limit)
+	assert.Equal(t, 59*time.Second, decision.RetryAfter)
+	require.NotNil(t, decision.ThrottledUntil)
+	assert.Equal(t, start.Add(time.Second+10*time.Minute), *decision.ThrottledUntil)
+
+	decision = throttle.Allow("form-1", start.Add(2*time.Second))
+	assert.False(t, decision.Allowed)
+	assert.False(t, decision.Spike, "a spike is reported once")
+
+	// While throttled the form gets its normal allowance each minute
+	next := start.Add(time.Minute)
+	assert.True(t, submitN(throttle, "form-1", 2, next).Allowed)
+	assert.False(t, throttle.Allow("form-1", next).Allowed)
+
+	// After the cooldown the spike allowance returns
+	later := start.Add(11 * time.Minute)
+	assert.True(t, submitN(throttle, "form-1", 6, later).Allowed)
+}
+
+func TestAdaptiveThrottle_SpikeMinutesAreNotLearned(t *testing.T) {
+	throttle := newTestThrottle()
+	start := time.Date(2026, time.March, 1, 12, 0, 0, 0, time.UTC)
+
+	submitN(throttle, "form-1", 100, start)
+
+	status := throttle.Status("form-1", start.Add(time.Minute))
+	assert.Zero(t, status.Rate)
+}
+
+func TestAdaptiveThrottle_Reset(t *testing.T) {
+	throttle := newTestThrottle()
+	start := time.Date(2026, time.March, 1, 12, 0, 0, 0, time.UTC)
+
+	submitN(throttle, "form-1", 7, start)
+	require.NotNil(t, throttle.Status("form-1", start).ThrottledUntil)
+
+	throttle.Reset("form-1")
+
+	assert.Nil(t, throttle.Status("form-1", start).ThrottledUntil)
+	assert.True(t, throttle.Allow("form-2", start).Allowed, "other forms are unaffected")
+}
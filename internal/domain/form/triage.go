@@ -0,0 +1,177 @@
+package form
+
+import (
+	"context"
+	stderrors "errors"
+	"fmt"
+	"sort"
+
+	"github.com/goformx/goforms/internal/domain/common/errors"
+	"github.com/goformx/goforms/internal/domain/form/model"
+	"github.com/goformx/goforms/internal/infrastructure/logging"
+	"github.com/goformx/goforms/internal/infrastructure/repository/common"
+)
+
+// SubmissionViewRepository defines the interface for saved submission view storage
+type SubmissionViewRepository interface {
+	CreateView(ctx context.Context, view *model.SubmissionView) error
+	GetViewByID(ctx context.Context, id string) (*model.SubmissionView, error)
+	ListViewsByForm(ctx context.Context, formID string) ([]*model.SubmissionView, error)
+	UpdateView(ctx context.Context, view *model.SubmissionView) error
+	DeleteView(ctx context.Context, id string) error
+}
+
+// TagCount is the number of a form's submissions carrying a tag
+type TagCount struct {
+	Tag   string `json:"tag"`
+	Count int    `json:"count"`
+}
+
+// TriageService defines the interface for tagging submissions and managing saved views
+type TriageService interface {
+	// SetSubmissionTags replaces the tags on a submission belonging to the given form
+	SetSubmissionTags(ctx context.Context, formID, submissionID string, tags []string) (*model.FormSubmission, error)
+	// ListTags counts the tags used across a form's submissions, most used first
+	ListTags(ctx context.Context, formID string) ([]TagCount, error)
+	CreateView(ctx context.Context, view *model.SubmissionView) error
+	UpdateView(ctx context.Context, view *model.SubmissionView) error
+	DeleteView(ctx context.Context, formID, viewID string) error
+	GetView(ctx context.Context, formID, viewID string) (*model.SubmissionView, error)
+	ListViews(ctx context.Context, formID string) ([]*model.SubmissionView, error)
+}
+
+// triageService handles submission tagging and saved view business logic
+type triageService struct {
+	views       SubmissionViewRepository
+	submissions Repository
+	logger      logging.Logger
+}
+
+// NewTriageService creates a new triage service
+func NewTriageService(views SubmissionViewRepository, submissions Repository, logger logging.Logger) TriageService {
+	return &triageService{
+		views:       views,
+		submissions: submissions,
+		logger:      logger,
+	}
+}
+
+// SetSubmissionTags validates and stores the submission's new tags
+func (s *triageService) SetSubmissionTags(
+	ctx context.Context,
+	formID, submissionID string,
+	tags []string,
+) (*model.FormSubmission, error) {
+	submission, err := s.submissions.GetSubmissionByID(ctx, submissionID)
+	if err != nil && !stderrors.Is(err, common.ErrNotFound) {
+		return nil, fmt.Errorf("get submission: %w", err)
+	}
+
+	if submission == nil || submission.FormID != formID {
+		return nil, model.ErrSubmissionNotFound
+	}
+
+	if tagErr := submission.SetTags(tags); tagErr != nil {
+		return nil, errors.New(errors.ErrCodeValidation, tagErr.Error(), tagErr)
+	}
+
+	if updateErr := s.submissions.UpdateSubmission(ctx, submission); updateErr != nil {
+		return nil, fmt.Errorf("update submission tags: %w", updateErr)
+	}
+
+	return submission, nil
+}
+
+// ListTags counts tag usage across the form's submissions
+func (s *triageService) ListTags(ctx context.Context, formID string) ([]TagCount, error) {
+	submissions, err := s.submissions.ListSubmissions(ctx, formID)
+	if err != nil {
+		return nil, fmt.Errorf("list submissions: %w", err)
+	}
+
+	counts := make(map[string]int)
+
+	for _, submission := range submissions {
+		for _, tag := range submission.GetTags() {
+			counts[tag]++
+		}
+	}
+
+	tags := make([]TagCount, 0, len(counts))
+	for tag, count := range counts {
+		tags = append(tags, TagCount{Tag: tag, Count: count})
+	}
+
+	sort.Slice(tags, func(i, j int) bool {
+		if tags[i].Count != tags[j].Count {
+			return tags[i].Count > tags[j].Count
+		}
+
+		return tags[i].Tag < tags[j].Tag
+	})
+
+	return tags, nil
+}
+
+// CreateView validates and stores a new saved view
+func (s *triageService) CreateView(ctx context.Context, view *model.SubmissionView) error {
+	if err := view.Validate(); err != nil {
+		return errors.New(errors.ErrCodeValidation, err.Error(), err)
+	}
+
+	if err := s.views.CreateView(ctx, view); err != nil {
+		return fmt.Errorf("create view: %w", err)
+	}
+
+	return nil
+}
+
+// UpdateView validates and saves a saved view
+func (s *triageService) UpdateView(ctx context.Context, view *model.SubmissionView) error {
+	if err := view.Validate(); err != nil {
+		return errors.New(errors.ErrCodeValidation, err.Error(), err)
+	}
+
+	if err := s.views.UpdateView(ctx, view); err != nil {
+		return fmt.Errorf("update view: %w", err)
+	}
+
+	return nil
+}
+
+// DeleteView deletes a saved view belonging to the given form
+func (s *triageService) DeleteView(ctx context.Context, formID, viewID string) error {
+	if _, err := s.GetView(ctx, formID, viewID); err != nil {
+		return err
+	}
+
+	if err := s.views.DeleteView(ctx, viewID); err != nil {
+		return fmt.Errorf("delete view: %w", err)
+	}
+
+	return nil
+}
+
+// GetView retrieves a saved view, scoped to its form
+func (s *triageService) GetView(ctx context.Context, formID, viewID string) (*model.SubmissionView, error) {
+	view, err := s.views.GetViewByID(ctx, viewID)
+	if err != nil {
+		return nil, fmt.Errorf("get view: %w", err)
+	}
+
+	if view == nil || view.FormID != formID {
+		return nil, model.ErrViewNotFound
+	}
+
+	return view, nil
+}
+
+// ListViews lists the saved views for a form
+func (s *triageService) ListViews(ctx context.Context, formID string) ([]*model.SubmissionView, error) {
+	views, err := s.views.ListViewsByForm(ctx, formID)
+	if err != nil {
+		return nil, fmt.Errorf("list views: %w", err)
+	}
+
+	return views, nil
+}
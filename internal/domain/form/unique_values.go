@@ -0,0 +1,103 @@
+package form
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/goformx/goforms/internal/domain/form/model"
+)
+
+// ErrUniqueValuesUnsupported is returned when a form has unique fields but the
+// repository cannot enforce them
+var ErrUniqueValuesUnsupported = errors.New("repository does not support unique submission values")
+
+// UniqueValue is the value of a unique field in a submission. Values are stored
+// hashed, so enforcing uniqueness keeps no copy of the answer.
+type UniqueValue struct {
+	FieldKey string
+	// Hash is the hex SHA-256 of the normalized value
+	Hash string
+}
+
+// UniqueSubmissionRepository stores a submission together with the values of
+// its unique fields, atomically rejecting values another submission to the
+// same form already has
+type UniqueSubmissionRepository interface {
+	// CreateSubmissionWithUniqueValues stores the submission unless one of the
+	// values is taken, in which case it returns a *DuplicateValuesError naming
+	// every taken field and stores nothing
+	CreateSubmissionWithUniqueValues(ctx context.Context, submission *model.FormSubmission, values []UniqueValue) error
+}
+
+// DuplicateValuesError is returned when unique fields of a submission repeat
+// the values of an earlier submission
+type DuplicateValuesError struct {
+	// Fields are the keys of the fields whose values were taken
+	Fields []string
+}
+
+// Error implements the error interface
+func (e *DuplicateValuesError) Error() string {
+	return fmt.Sprintf("values already submitted for %s", strings.Join(e.Fields, ", "))
+}
+
+// UniqueSubmissionValues returns the values of the schema's unique fields,
+// those flagged with Form.io's "unique" property, in a submission. Empty values
+// are left out, so optional unique fields may be skipped by many submissions.
+// Text is compared trimmed and case-insensitively.
+func UniqueSubmissionValues(schema, data model.JSON) []UniqueValue {
+	var values []UniqueValue
+
+	_ = model.WalkSchema(schema, func(component map[string]any) error {
+		if unique, _ := component["unique"].(bool); !unique {
+			return nil
+		}
+
+		key, _ := component["key"].(string)
+		if key == "" {
+			return nil
+		}
+
+		if normalized, ok := normalizeUniqueValue(data[key]); ok {
+			sum := sha256.Sum256([]byte(normalized))
+			values = append(values, UniqueValue{FieldKey: key, Hash: hex.EncodeToString(sum[:])})
+		}
+
+		return nil
+	})
+
+	return values
+}
+
+// normalizeUniqueValue returns the text a value is compared by, and false for
+// an empty value
+func normalizeUniqueValue(value any) (string, bool) {
+	switch v := value.(type) {
+	case nil:
+		return "", false
+	case string:
+		text := strings.ToLower(strings.TrimSpace(v))
+
+		return text, text != ""
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64), true
+	case int:
+		return strconv.Itoa(v), true
+	case bool:
+		return strconv.FormatBool(v), true
+	default:
+		// Maps are encoded with sorted keys, so equal objects encode equally
+		encoded, err := json.Marshal(v)
+		if err != nil || string(encoded) == "{}" || string(encoded) == "[]" {
+			return "", false
+		}
+
+		return string(encoded), true
+	}
+}
@@ -0,0 +1,73 @@
+package form_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	domainform "github.com/goformx/goforms/internal/domain/form"
+	"github.com/goformx/goforms/internal/domain/form/model"
+	memorystore "github.com/goformx/goforms/internal/infrastructure/repository/memory"
+	mockevents "github.com/goformx/goforms/test/mocks/events"
+	mocklogging "github.com/goformx/goforms/test/mocks/logging"
+)
+
+func uniqueFieldsSchema() model.JSON {
+	return model.JSON{"components": []any{
+		map[string]any{"key": "email", "type": "email", "unique": true},
+		map[string]any{"key": "coupon", "type": "textfield", "unique": true},
+		map[string]any{"key": "name", "type": "textfield"},
+	}}
+}
+
+func TestUniqueSubmissionValues(t *testing.T) {
+	values := domainform.UniqueSubmissionValues(uniqueFieldsSchema(), model.JSON{"email": " Ada@Example.com ", "coupon": "", "name": "Ada"})
+	require.Len(t, values, 1, "empty values and fields that are not unique are left out")
+	assert.Equal(t, "email", values[0].FieldKey)
+	assert.Len(t, values[0].Hash, 64)
+
+	again := domainform.UniqueSubmissionValues(uniqueFieldsSchema(), model.JSON{"email": "ada@example.com"})
+	assert.Equal(t, values, again, "text is compared trimmed and case-insensitively")
+
+	other := domainform.UniqueSubmissionValues(uniqueFieldsSchema(), model.JSON{"email": "grace@example.com"})
+	assert.NotEqual(t, values[0].Hash, other[0].Hash)
+}
+
+func TestService_SubmitForm_UniqueValues(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	logger := mocklogging.NewMockLogger(ctrl)
+	logger.EXPECT().Info(gomock.Any(), gomock.Any()).AnyTimes()
+
+	eventBus := mockevents.NewMockEventBus(ctrl)
+	eventBus.EXPECT().Publish(gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+
+	store := memorystore.NewStore(logger)
+	ctx := t.Context()
+
+	formModel := &model.Form{UserID: "user-1", Title: "Registration", Schema: uniqueFieldsSchema()}
+	require.NoError(t, store.Forms().CreateForm(ctx, formModel))
+
+	service := domainform.NewService(store.Forms(), eventBus, nil, nil, domainform.ImageLimits{}, logger)
+
+	submit := func(data model.JSON) error {
+		return service.SubmitForm(ctx, &model.FormSubmission{
+			FormID: formModel.ID, Data: data, Status: model.SubmissionStatusPending, SubmittedAt: time.Now(),
+		})
+	}
+
+	require.NoError(t, submit(model.JSON{"email": "ada@example.com", "coupon": "SAVE10"}))
+	require.NoError(t, submit(model.JSON{"email": "grace@example.com"}), "an empty coupon is not a repeat")
+
+	err := submit(model.JSON{"email": "ADA@example.com", "coupon": "save10"})
+
+	var duplicates *domainform.DuplicateValuesError
+	require.ErrorAs(t, err, &duplicates)
+	assert.Equal(t, []string{"email", "coupon"}, duplicates.Fields)
+
+	submissions, err := store.Forms().ListSubmissions(ctx, formModel.ID)
+	require.NoError(t, err)
+	assert.Len(t, submissions, 2, "a rejected submission is not stored")
+}
@@ -0,0 +1,259 @@
+package form
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/goformx/goforms/internal/domain/form/model"
+	"github.com/goformx/goforms/internal/infrastructure/logging"
+)
+
+// ErrSubmissionQueueFull is returned by Enqueue when the queue has no room; the
+// caller stores the submission synchronously instead
+var ErrSubmissionQueueFull = errors.New("submission queue is full")
+
+// errSubmissionQueueStopped is returned by Enqueue once the queue has been stopped
+var errSubmissionQueueStopped = errors.New("submission queue is stopped")
+
+// SubmissionBatchRepository stores many submissions in one insert
+type SubmissionBatchRepository interface {
+	CreateSubmissions(ctx context.Context, submissions []*model.FormSubmission) error
+}
+
+// WriteBehindOptions configures a SubmissionQueue
+type WriteBehindOptions struct {
+	// BatchSize is the most submissions written in one insert
+	BatchSize int
+	// FlushInterval is the longest a queued submission waits for its batch
+	FlushInterval time.Duration
+	// QueueSize bounds the number of queued submissions
+	QueueSize int
+}
+
+// SubmissionQueue stores submissions in the background, batching them into inserts of
+// up to BatchSize rows or whatever has queued after FlushInterval. Queued submissions
+// live in memory: those not yet written are lost if the process dies, and Stop writes
+// the rest before returning. A batch that fails is retried one row at a time so a bad
+// row does not take the others with it; a row that still fails is passed to the
+// OnFailed callback, such as to dead-letter it.
+type SubmissionQueue struct {
+	repository SubmissionBatchRepository
+	options    WriteBehindOptions
+	logger     logging.Logger
+	// onStored is called for each submission once it has been written
+	onStored func(ctx context.Context, submission *model.FormSubmission)
+	// onFailed is called for each submission that could not be written
+	onFailed func(ctx context.Context, submission *model.FormSubmission, err error)
+
+	mu      sync.RWMutex
+	queue   chan *model.FormSubmission
+	stopped bool
+	done    chan struct{}
+}
+
+// NewSubmissionQueue creates a write-behind queue; call Start to begin writing
+func NewSubmissionQueue(
+	repository SubmissionBatchRepository,
+	options WriteBehindOptions,
+	logger logging.Logger,
+) *SubmissionQueue {
+	if options.BatchSize <= 0 {
+		options.BatchSize = 1
+	}
+
+	if options.FlushInterval <= 0 {
+		options.FlushInterval = time.Second
+	}
+
+	if options.QueueSize < options.BatchSize {
+		options.QueueSize = options.BatchSize
+	}
+
+	return &SubmissionQueue{
+		repository: repository,
+		options:    options,
+		logger:     logger.WithComponent("submission_queue"),
+		queue:      make(chan *model.FormSubmission, options.QueueSize),
+	}
+}
+
+// OnFailed sets the callback for submissions that could not be written. Call
+// it before Start.
+func (q *SubmissionQueue) OnFailed(fn func(ctx context.Context, submission *model.FormSubmission, err error)) {
+	q.onFailed = fn
+}
+
+// Write stores one submission straight away, bypassing the queue, and reports
+// it like a queued one once written, such as to retry a submission the queue
+// failed to write
+func (q *SubmissionQueue) Write(ctx context.Context, submission *model.FormSubmission) error {
+	single := []*model.FormSubmission{submission}
+
+	if err := q.repository.CreateSubmissions(ctx, single); err != nil {
+		return fmt.Errorf("write submission %s: %w", submission.ID, err)
+	}
+
+	q.stored(ctx, single)
+
+	return nil
+}
+
+// Start begins writing queued submissions in the background
+func (q *SubmissionQueue) Start() {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	if q.done != nil || q.stopped {
+		return
+	}
+
+	q.done = make(chan struct{})
+
+	go q.run(q.done)
+}
+
+// Stop refuses new submissions and waits until every queued submission has been
+// written or ctx expires
+func (q *SubmissionQueue) Stop(ctx context.Context) error {
+	q.mu.Lock()
+	if q.stopped {
+		q.mu.Unlock()
+
+		return nil
+	}
+
+	q.stopped = true
+	close(q.queue)
+
+	if q.done == nil {
+		// Never started; write what was queued before returning
+		q.done = make(chan struct{})
+
+		go q.run(q.done)
+	}
+
+	done := q.done
+	q.mu.Unlock()
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		q.logger.Error("submission queue stopped before draining", "pending", len(q.queue))
+
+		return fmt.Errorf("drain submission queue: %w", ctx.Err())
+	}
+}
+
+// Enqueue adds a submission to the queue without waiting
+func (q *SubmissionQueue) Enqueue(submission *model.FormSubmission) error {
+	q.mu.RLock()
+	defer q.mu.RUnlock()
+
+	if q.stopped {
+		return errSubmissionQueueStopped
+	}
+
+	select {
+	case q.queue <- submission:
+		return nil
+	default:
+		return ErrSubmissionQueueFull
+	}
+}
+
+// Pending returns the number of queued submissions not yet taken for a batch
+func (q *SubmissionQueue) Pending() int {
+	return len(q.queue)
+}
+
+// run collects batches until the queue is closed and drained
+func (q *SubmissionQueue) run(done chan struct{}) {
+	defer close(done)
+
+	batch := make([]*model.FormSubmission, 0, q.options.BatchSize)
+
+	timer := time.NewTimer(q.options.FlushInterval)
+	defer timer.Stop()
+
+	for {
+		select {
+		case submission, ok := <-q.queue:
+			if !ok {
+				q.flush(batch)
+
+				return
+			}
+
+			if len(batch) == 0 {
+				timer.Reset(q.options.FlushInterval)
+			}
+
+			batch = append(batch, submission)
+			if len(batch) >= q.options.BatchSize {
+				q.flush(batch)
+				batch = batch[:0]
+			}
+		case <-timer.C:
+			if len(batch) > 0 {
+				q.flush(batch)
+				batch = batch[:0]
+			}
+		}
+	}
+}
+
+// flush writes a batch, retrying row by row when the batch insert fails
+func (q *SubmissionQueue) flush(batch []*model.FormSubmission) {
+	if len(batch) == 0 {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), DefaultTimeout)
+	defer cancel()
+
+	start := time.Now()
+
+	err := q.repository.CreateSubmissions(ctx, batch)
+	if err == nil {
+		q.logger.Debug("submission batch written", "count", len(batch), "duration_ms", time.Since(start).Milliseconds())
+		q.stored(ctx, batch)
+
+		return
+	}
+
+	q.logger.Warn("submission batch insert failed, retrying individually", "count", len(batch), "error", err)
+
+	for _, submission := range batch {
+		single := []*model.FormSubmission{submission}
+
+		if singleErr := q.repository.CreateSubmissions(ctx, single); singleErr != nil {
+			q.logger.Error("failed to write queued submission",
+				"submission_id", submission.ID,
+				"form_id", submission.FormID,
+				"error", singleErr)
+
+			if q.onFailed != nil {
+				q.onFailed(ctx, submission, singleErr)
+			}
+
+			continue
+		}
+
+		q.stored(ctx, single)
+	}
+}
+
+// stored reports written submissions to the onStored callback
+func (q *SubmissionQueue) stored(ctx context.Context, submissions []*model.FormSubmission) {
+	if q.onStored == nil {
+		return
+	}
+
+	for _, submission := range submissions {
+		q.onStored(ctx, submission)
+	}
+}
@@ -0,0 +1,208 @@
+package form_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/goformx/goforms/internal/domain/common/events"
+	domainform "github.com/goformx/goforms/internal/domain/form"
+	formevents "github.com/goformx/goforms/internal/domain/form/events"
+	"github.com/goformx/goforms/internal/domain/form/model"
+	"github.com/goformx/goforms/internal/infrastructure/event"
+	mockform "github.com/goformx/goforms/test/mocks/form"
+	mocklogging "github.com/goformx/goforms/test/mocks/logging"
+)
+
+// batchRecorder records the batches written by a SubmissionQueue
+type batchRecorder struct {
+	mu      sync.Mutex
+	batches [][]string
+	// reject fails any insert containing this submission ID
+	reject string
+}
+
+func (r *batchRecorder) CreateSubmissions(_ context.Context, submissions []*model.FormSubmission) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	ids := make([]string, len(submissions))
+	for i, submission := range submissions {
+		if submission.ID == r.reject {
+			return errors.New("constraint violation")
+		}
+
+		ids[i] = submission.ID
+	}
+
+	r.batches = append(r.batches, ids)
+
+	return nil
+}
+
+func (r *batchRecorder) written() [][]string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return append([][]string(nil), r.batches...)
+}
+
+func newQueueLogger(t *testing.T) *mocklogging.MockLogger {
+	t.Helper()
+
+	ctrl := gomock.NewController(t)
+	logger := mocklogging.NewMockLogger(ctrl)
+	logger.EXPECT().WithComponent(gomock.Any()).Return(logger).AnyTimes()
+	logger.EXPECT().Debug(gomock.Any(), gomock.Any()).AnyTimes()
+	logger.EXPECT().Info(gomock.Any(), gomock.Any()).AnyTimes()
+	logger.EXPECT().Warn(gomock.Any(), gomock.Any()).AnyTimes()
+	logger.EXPECT().Error(gomock.Any(), gomock.Any()).AnyTimes()
+
+	return logger
+}
+
+func TestSubmissionQueue_BatchesBySize(t *testing.T) {
+	repo := &batchRecorder{}
+	queue := domainform.NewSubmissionQueue(repo, domainform.WriteBehindOptions{
+		BatchSize:     2,
+		FlushInterval: time.Hour,
+		QueueSize:     10,
+	}, newQueueLogger(t))
+	queue.Start()
+
+	for _, id := range []string{"a", "b", "c"} {
+		require.NoError(t, queue.Enqueue(&model.FormSubmission{ID: id}))
+	}
+
+	require.Eventually(t, func() bool { return len(repo.written()) == 1 }, time.Second, 5*time.Millisecond)
+
+	// Stop writes the partial batch
+	require.NoError(t, queue.Stop(t.Context()))
+	assert.Equal(t, [][]string{{"a", "b"}, {"c"}}, repo.written())
+
+	assert.Error(t, queue.Enqueue(&model.FormSubmission{ID: "d"}))
+}
+
+func TestSubmissionQueue_FlushesAfterInterval(t *testing.T) {
+	repo := &batchRecorder{}
+	queue := domainform.NewSubmissionQueue(repo, domainform.WriteBehindOptions{
+		BatchSize:     100,
+		FlushInterval: 10 * time.Millisecond,
+		QueueSize:     100,
+	}, newQueueLogger(t))
+	queue.Start()
+
+	t.Cleanup(func() { _ = queue.Stop(context.Background()) })
+
+	require.NoError(t, queue.Enqueue(&model.FormSubmission{ID: "a"}))
+
+	require.Eventually(t, func() bool { return len(repo.written()) == 1 }, time.Second, 5*time.Millisecond)
+}
+
+func TestSubmissionQueue_RetriesFailedBatchRowByRow(t *testing.T) {
+	repo := &batchRecorder{reject: "bad"}
+	queue := domainform.NewSubmissionQueue(repo, domainform.WriteBehindOptions{
+		BatchSize:     3,
+		FlushInterval: time.Hour,
+		QueueSize:     3,
+	}, newQueueLogger(t))
+
+	for _, id := range []string{"a", "bad", "c"} {
+		require.NoError(t, queue.Enqueue(&model.FormSubmission{ID: id}))
+	}
+
+	require.NoError(t, queue.Stop(t.Context()))
+	assert.Equal(t, [][]string{{"a"}, {"c"}}, repo.written())
+}
+
+func TestSubmissionQueue_ReportsFailedRowsAndWritesThemLater(t *testing.T) {
+	repo := &batchRecorder{reject: "bad"}
+	queue := domainform.NewSubmissionQueue(repo, domainform.WriteBehindOptions{
+		BatchSize:     2,
+		FlushInterval: time.Hour,
+		QueueSize:     2,
+	}, newQueueLogger(t))
+
+	var failed []*model.FormSubmission
+
+	queue.OnFailed(func(_ context.Context, submission *model.FormSubmission, err error) {
+		assert.Error(t, err)
+
+		failed = append(failed, submission)
+	})
+
+	for _, id := range []string{"a", "bad"} {
+		require.NoError(t, queue.Enqueue(&model.FormSubmission{ID: id}))
+	}
+
+	require.NoError(t, queue.Stop(t.Context()))
+	require.Len(t, failed, 1)
+	assert.Equal(t, "bad", failed[0].ID)
+
+	require.Error(t, queue.Write(t.Context(), failed[0]))
+
+	repo.reject = ""
+	require.NoError(t, queue.Write(t.Context(), failed[0]))
+	assert.Equal(t, [][]string{{"a"}, {"bad"}}, repo.written())
+}
+
+func TestSubmissionQueue_FullQueue(t *testing.T) {
+	queue := domainform.NewSubmissionQueue(&batchRecorder{}, domainform.WriteBehindOptions{
+		BatchSize: 1,
+		QueueSize: 1,
+	}, newQueueLogger(t))
+
+	require.NoError(t, queue.Enqueue(&model.FormSubmission{ID: "a"}))
+	require.ErrorIs(t, queue.Enqueue(&model.FormSubmission{ID: "b"}), domainform.ErrSubmissionQueueFull)
+}
+
+func TestWriteBehindService_SubmitForm(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	repo := mockform.NewMockRepository(ctrl)
+	logger := newQueueLogger(t)
+	bus := event.NewMemoryEventBus(logger)
+
+	var submitted []string
+
+	onSubmitted := func(_ context.Context, e events.Event) error {
+		submitted = append(submitted, e.Payload().(*model.FormSubmission).ID)
+
+		return nil
+	}
+	require.NoError(t, bus.Subscribe(t.Context(), string(formevents.FormSubmittedEventType), onSubmitted))
+
+	batches := &batchRecorder{}
+	queue := domainform.NewSubmissionQueue(batches, domainform.WriteBehindOptions{
+		BatchSize:     10,
+		FlushInterval: time.Hour,
+		QueueSize:     10,
+	}, logger)
+	svc := domainform.NewWriteBehindService(repo, bus, nil, nil, domainform.ImageLimits{}, queue, logger)
+
+	queued := &model.FormSubmission{FormID: "form-1", Data: model.JSON{"name": "Ada"}, SubmittedAt: time.Now()}
+	repo.EXPECT().GetFormByID(gomock.Any(), "form-1").Return(&model.Form{ID: "form-1"}, nil)
+
+	require.NoError(t, svc.SubmitForm(t.Context(), queued))
+	assert.NotEmpty(t, queued.ID, "an ID is assigned before the row is written")
+	assert.Empty(t, submitted, "events wait for the row to be written")
+
+	// A form opted out of write-behind is written before SubmitForm returns
+	direct := &model.FormSubmission{FormID: "form-2", Data: model.JSON{"name": "Grace"}, SubmittedAt: time.Now()}
+	repo.EXPECT().GetFormByID(gomock.Any(), "form-2").Return(&model.Form{
+		ID:                  "form-2",
+		SubmissionWriteMode: model.SubmissionWriteModeSync,
+	}, nil)
+	repo.EXPECT().CreateSubmission(gomock.Any(), direct).Return(nil)
+
+	require.NoError(t, svc.SubmitForm(t.Context(), direct))
+
+	require.NoError(t, queue.Stop(t.Context()))
+	assert.Equal(t, [][]string{{queued.ID}}, batches.written())
+	assert.Contains(t, submitted, queued.ID)
+}
@@ -0,0 +1,122 @@
+// Package metering counts API calls and submissions per account and reports
+// daily usage. An account is a user authenticated by assertion ("user:<id>") or
+// an API key ("key:<hash>"); submissions count against the form owner's user
+// account. Counts are kept in a shared counter store and flushed to the
+// database on a schedule, and crossing a threshold of a monthly limit raises
+// one event per account, metric, month and threshold.
+package metering
+
+import (
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/goformx/goforms/internal/domain/common/events"
+)
+
+// Metrics counted per account
+const (
+	MetricAPICalls    = "api_calls"
+	MetricSubmissions = "submissions"
+)
+
+// Metrics lists the counted metrics in report order
+var Metrics = []string{MetricAPICalls, MetricSubmissions}
+
+// ThresholdCrossedEventType is published when an account's usage crosses a threshold
+const ThresholdCrossedEventType = "usage.threshold_crossed"
+
+// userAccountPrefix marks the accounts of assertion-authenticated users
+const userAccountPrefix = "user:"
+
+// dayLayout formats the days counters are kept for
+const dayLayout = "2006-01-02"
+
+// MaxReportDays bounds the range of a usage report
+const MaxReportDays = 366
+
+// ErrInvalidRange is returned for a report range that is reversed or too long
+var ErrInvalidRange = errors.New("usage range must run forwards and span at most 366 days")
+
+// UserAccount returns the account of a user
+func UserAccount(userID string) string {
+	return userAccountPrefix + userID
+}
+
+// UserID returns the user of a user account
+func UserID(account string) (string, bool) {
+	return strings.CutPrefix(account, userAccountPrefix)
+}
+
+// DailyCount is an account's total for one metric on one UTC day
+type DailyCount struct {
+	Account   string    `gorm:"primaryKey;size:100"`
+	Metric    string    `gorm:"primaryKey;size:50"`
+	Day       time.Time `gorm:"primaryKey;type:date"`
+	Count     int64     `gorm:"not null;default:0"`
+	UpdatedAt time.Time `gorm:"not null;autoUpdateTime"`
+}
+
+// TableName specifies the table name for the DailyCount model
+func (d *DailyCount) TableName() string {
+	return "api_usage_daily"
+}
+
+// Alert records that an account's monthly usage crossed a threshold
+type Alert struct {
+	Account     string    `gorm:"primaryKey;size:100"          json:"account"`
+	Metric      string    `gorm:"primaryKey;size:50"           json:"metric"`
+	PeriodStart time.Time `gorm:"primaryKey"                   json:"period_start"`
+	// Threshold is the percentage of the limit that was reached
+	Threshold int       `gorm:"primaryKey;autoIncrement:false" json:"threshold"`
+	Used      int64     `gorm:"not null"                       json:"used"`
+	Limit     int64     `gorm:"column:usage_limit;not null"    json:"limit"`
+	CreatedAt time.Time `gorm:"not null;autoCreateTime"        json:"created_at"`
+}
+
+// TableName specifies the table name for the Alert model
+func (a *Alert) TableName() string {
+	return "api_usage_alerts"
+}
+
+// ThresholdEvent is published on the event bus when an alert is first recorded
+type ThresholdEvent struct {
+	events.BaseEvent
+	alert *Alert
+}
+
+// NewThresholdEvent creates a threshold crossed event
+func NewThresholdEvent(alert *Alert) *ThresholdEvent {
+	return &ThresholdEvent{BaseEvent: events.NewBaseEvent(ThresholdCrossedEventType), alert: alert}
+}
+
+// Payload returns the alert
+func (e *ThresholdEvent) Payload() any {
+	return e.alert
+}
+
+// DayUsage is an account's counts on one day, keyed by metric
+type DayUsage struct {
+	Day    time.Time
+	Counts map[string]int64
+}
+
+// PeriodUsage is an account's usage in the current calendar month. A zero or
+// missing limit is unlimited.
+type PeriodUsage struct {
+	Start  time.Time
+	End    time.Time
+	Used   map[string]int64
+	Limits map[string]int64
+}
+
+// Report is an account's daily usage over a range of days, including the
+// counts not yet flushed to the database
+type Report struct {
+	Account string
+	From    time.Time
+	To      time.Time
+	Days    []DayUsage
+	Totals  map[string]int64
+	Period  PeriodUsage
+}
@@ -0,0 +1,419 @@
+package metering
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"sync"
+	"time"
+
+	domainerrors "github.com/goformx/goforms/internal/domain/common/errors"
+	"github.com/goformx/goforms/internal/domain/common/events"
+	"github.com/goformx/goforms/internal/infrastructure/logging"
+)
+
+// Repository defines the interface for stored usage
+type Repository interface {
+	// AddCounts adds counts to the stored daily totals
+	AddCounts(ctx context.Context, counts []*DailyCount) error
+	// ListCounts returns an account's daily totals for the days from..to, ordered by day
+	ListCounts(ctx context.Context, account string, from, to time.Time) ([]*DailyCount, error)
+	// RecordAlert stores an alert; it reports false when the alert was already recorded
+	RecordAlert(ctx context.Context, alert *Alert) (bool, error)
+}
+
+// Counter holds the counts not yet flushed, shared between instances when it is Redis
+type Counter interface {
+	// Add adds n to the counter under key
+	Add(ctx context.Context, key string, n int64) error
+	// Get returns the counter under key, or 0
+	Get(ctx context.Context, key string) (int64, error)
+	// Take returns the counter under key and resets it
+	Take(ctx context.Context, key string) (int64, error)
+}
+
+// LimitSource returns an account's monthly limits that differ from the configured ones
+type LimitSource interface {
+	Limits(ctx context.Context, account string) (map[string]int64, error)
+}
+
+// Config holds the metering settings
+type Config struct {
+	// Limits are the monthly limits of every account, keyed by metric
+	Limits map[string]int64
+	// Thresholds are the percentages of a limit that raise an alert
+	Thresholds []int
+}
+
+// Service defines the interface for usage metering
+type Service interface {
+	// Record counts n units of a metric for an account
+	Record(ctx context.Context, account, metric string, n int64) error
+	// Flush writes the recorded counts to the database and raises the alerts of
+	// the thresholds crossed
+	Flush(ctx context.Context) error
+	// Usage reports an account's daily usage for the days from..to
+	Usage(ctx context.Context, account string, from, to time.Time) (*Report, error)
+}
+
+// counterKey identifies the counter of an account's metric on one day
+type counterKey struct {
+	account string
+	metric  string
+	day     string
+}
+
+// String returns the counter store key
+func (k counterKey) String() string {
+	return k.account + "|" + k.metric + "|" + k.day
+}
+
+// service handles metering business logic
+type service struct {
+	repository Repository
+	counter    Counter
+	limits     LimitSource
+	publisher  events.Publisher
+	config     Config
+	logger     logging.Logger
+	now        func() time.Time
+
+	mu sync.Mutex
+	// pending are the counters this instance has added to since its last flush
+	pending map[counterKey]struct{}
+}
+
+// NewService creates a new metering service. limits and publisher may be nil.
+func NewService(
+	repository Repository,
+	counter Counter,
+	limits LimitSource,
+	publisher events.Publisher,
+	cfg Config,
+	logger logging.Logger,
+) Service {
+	thresholds := slices.Clone(cfg.Thresholds)
+	slices.Sort(thresholds)
+	cfg.Thresholds = slices.Compact(thresholds)
+
+	return &service{
+		repository: repository,
+		counter:    counter,
+		limits:     limits,
+		publisher:  publisher,
+		config:     cfg,
+		logger:     logger,
+		now:        time.Now,
+		pending:    make(map[counterKey]struct{}),
+	}
+}
+
+// Record counts n units of a metric for an account on the current UTC day
+func (s *service) Record(ctx context.Context, account, metric string, n int64) error {
+	key := counterKey{account: account, metric: metric, day: s.now().UTC().Format(dayLayout)}
+
+	if err := s.counter.Add(ctx, key.String(), n); err != nil {
+		return fmt.Errorf("record usage: %w", err)
+	}
+
+	s.mu.Lock()
+	s.pending[key] = struct{}{}
+	s.mu.Unlock()
+
+	return nil
+}
+
+// Flush moves the counters this instance added to into the database. Counts
+// that cannot be written are put back and retried on the next flush.
+func (s *service) Flush(ctx context.Context) error {
+	s.mu.Lock()
+	pending := s.pending
+	s.pending = make(map[counterKey]struct{})
+	s.mu.Unlock()
+
+	if len(pending) == 0 {
+		return nil
+	}
+
+	var (
+		counts  []*DailyCount
+		taken   []counterKey
+		errs    []error
+		touched = make(map[string][]string)
+	)
+
+	for key := range pending {
+		n, err := s.counter.Take(ctx, key.String())
+		if err != nil {
+			s.requeue(key)
+			errs = append(errs, fmt.Errorf("take usage counter: %w", err))
+
+			continue
+		}
+
+		if n == 0 {
+			// Another instance flushed the shared counter first
+			continue
+		}
+
+		day, err := time.Parse(dayLayout, key.day)
+		if err != nil {
+			continue
+		}
+
+		counts = append(counts, &DailyCount{Account: key.account, Metric: key.metric, Day: day, Count: n})
+		taken = append(taken, key)
+
+		if !slices.Contains(touched[key.account], key.metric) {
+			touched[key.account] = append(touched[key.account], key.metric)
+		}
+	}
+
+	if len(counts) > 0 {
+		if err := s.repository.AddCounts(ctx, counts); err != nil {
+			s.restore(ctx, taken, counts)
+
+			return errors.Join(append(errs, fmt.Errorf("save usage counts: %w", err))...)
+		}
+	}
+
+	for account, metrics := range touched {
+		if err := s.checkThresholds(ctx, account, metrics); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// requeue marks a counter to be flushed again
+func (s *service) requeue(key counterKey) {
+	s.mu.Lock()
+	s.pending[key] = struct{}{}
+	s.mu.Unlock()
+}
+
+// restore puts taken counts back into their counters after a failed write
+func (s *service) restore(ctx context.Context, keys []counterKey, counts []*DailyCount) {
+	for i, key := range keys {
+		if err := s.counter.Add(ctx, key.String(), counts[i].Count); err != nil {
+			s.logger.Error("usage counts lost after failed flush",
+				"account", key.account, "metric", key.metric, "count", counts[i].Count, "error", err)
+
+			continue
+		}
+
+		s.requeue(key)
+	}
+}
+
+// checkThresholds records and publishes the alerts of the thresholds an
+// account's monthly usage has reached
+func (s *service) checkThresholds(ctx context.Context, account string, metrics []string) error {
+	if len(s.config.Thresholds) == 0 {
+		return nil
+	}
+
+	limits := s.limitsFor(ctx, account)
+	start, _ := s.month()
+
+	used, err := s.monthUsage(ctx, account)
+	if err != nil {
+		return err
+	}
+
+	for _, metric := range metrics {
+		limit := limits[metric]
+		if limit <= 0 {
+			continue
+		}
+
+		for _, threshold := range s.config.Thresholds {
+			if used[metric]*100 < limit*int64(threshold) {
+				break
+			}
+
+			alert := &Alert{
+				Account:     account,
+				Metric:      metric,
+				PeriodStart: start,
+				Threshold:   threshold,
+				Used:        used[metric],
+				Limit:       limit,
+			}
+
+			created, recordErr := s.repository.RecordAlert(ctx, alert)
+			if recordErr != nil {
+				return fmt.Errorf("record usage alert: %w", recordErr)
+			}
+
+			if !created {
+				continue
+			}
+
+			s.logger.Info("usage threshold crossed",
+				"account", account, "metric", metric, "threshold", threshold, "used", alert.Used, "limit", limit)
+
+			if s.publisher != nil {
+				if publishErr := s.publisher.Publish(ctx, NewThresholdEvent(alert)); publishErr != nil {
+					s.logger.Error("failed to publish usage threshold event", "account", account, "error", publishErr)
+				}
+			}
+		}
+	}
+
+	return nil
+}
+
+// Usage reports an account's daily usage for the days from..to, with today's
+// counts that are not yet flushed
+func (s *service) Usage(ctx context.Context, account string, from, to time.Time) (*Report, error) {
+	from, to = truncateDay(from), truncateDay(to)
+	if to.Before(from) || to.Sub(from) >= MaxReportDays*24*time.Hour {
+		return nil, domainerrors.New(domainerrors.ErrCodeValidation, ErrInvalidRange.Error(), ErrInvalidRange)
+	}
+
+	stored, err := s.repository.ListCounts(ctx, account, from, to)
+	if err != nil {
+		return nil, fmt.Errorf("list usage counts: %w", err)
+	}
+
+	byDay := make(map[time.Time]map[string]int64)
+	for _, count := range stored {
+		day := truncateDay(count.Day)
+		if byDay[day] == nil {
+			byDay[day] = make(map[string]int64)
+		}
+
+		byDay[day][count.Metric] += count.Count
+	}
+
+	today := truncateDay(s.now())
+	if !today.Before(from) && !today.After(to) {
+		unflushed, pendingErr := s.unflushed(ctx, account)
+		if pendingErr != nil {
+			return nil, pendingErr
+		}
+
+		if byDay[today] == nil {
+			byDay[today] = make(map[string]int64)
+		}
+
+		for metric, n := range unflushed {
+			byDay[today][metric] += n
+		}
+	}
+
+	report := &Report{Account: account, From: from, To: to, Totals: make(map[string]int64)}
+
+	for day := from; !day.After(to); day = day.AddDate(0, 0, 1) {
+		usage := DayUsage{Day: day, Counts: make(map[string]int64, len(Metrics))}
+
+		for _, metric := range Metrics {
+			usage.Counts[metric] = byDay[day][metric]
+			report.Totals[metric] += byDay[day][metric]
+		}
+
+		report.Days = append(report.Days, usage)
+	}
+
+	start, end := s.month()
+
+	used, err := s.monthUsage(ctx, account)
+	if err != nil {
+		return nil, err
+	}
+
+	unflushed, err := s.unflushed(ctx, account)
+	if err != nil {
+		return nil, err
+	}
+
+	for metric, n := range unflushed {
+		used[metric] += n
+	}
+
+	report.Period = PeriodUsage{Start: start, End: end, Used: used, Limits: s.limitsFor(ctx, account)}
+
+	return report, nil
+}
+
+// monthUsage returns an account's stored totals for the current month
+func (s *service) monthUsage(ctx context.Context, account string) (map[string]int64, error) {
+	start, _ := s.month()
+
+	counts, err := s.repository.ListCounts(ctx, account, start, truncateDay(s.now()))
+	if err != nil {
+		return nil, fmt.Errorf("list usage counts: %w", err)
+	}
+
+	used := make(map[string]int64, len(Metrics))
+	for _, metric := range Metrics {
+		used[metric] = 0
+	}
+
+	for _, count := range counts {
+		used[count.Metric] += count.Count
+	}
+
+	return used, nil
+}
+
+// unflushed returns an account's counts for today that are still in the counter store
+func (s *service) unflushed(ctx context.Context, account string) (map[string]int64, error) {
+	day := s.now().UTC().Format(dayLayout)
+	counts := make(map[string]int64, len(Metrics))
+
+	for _, metric := range Metrics {
+		n, err := s.counter.Get(ctx, counterKey{account: account, metric: metric, day: day}.String())
+		if err != nil {
+			return nil, fmt.Errorf("get usage counter: %w", err)
+		}
+
+		counts[metric] = n
+	}
+
+	return counts, nil
+}
+
+// limitsFor returns an account's monthly limits. When the limit source fails
+// the configured limits apply.
+func (s *service) limitsFor(ctx context.Context, account string) map[string]int64 {
+	limits := make(map[string]int64, len(s.config.Limits))
+	for metric, limit := range s.config.Limits {
+		limits[metric] = limit
+	}
+
+	if s.limits == nil {
+		return limits
+	}
+
+	overrides, err := s.limits.Limits(ctx, account)
+	if err != nil {
+		s.logger.Error("failed to get account usage limits, using configured limits", "account", account, "error", err)
+
+		return limits
+	}
+
+	for metric, limit := range overrides {
+		limits[metric] = limit
+	}
+
+	return limits
+}
+
+// month returns the start and end of the current UTC calendar month
+func (s *service) month() (start, end time.Time) {
+	now := s.now().UTC()
+	start = time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC)
+
+	return start, start.AddDate(0, 1, 0)
+}
+
+// truncateDay returns the start of t's UTC day
+func truncateDay(t time.Time) time.Time {
+	t = t.UTC()
+
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
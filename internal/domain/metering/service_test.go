@@ -0,0 +1,138 @@
+package metering_test
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	domainerrors "github.com/goformx/goforms/internal/domain/common/errors"
+	"github.com/goformx/goforms/internal/domain/common/events"
+	"github.com/goformx/goforms/internal/domain/metering"
+	"github.com/goformx/goforms/internal/infrastructure/counter"
+	memorystore "github.com/goformx/goforms/internal/infrastructure/repository/memory"
+	mocklogging "github.com/goformx/goforms/test/mocks/logging"
+)
+
+// recordingPublisher records the events it is given
+type recordingPublisher struct {
+	mu     sync.Mutex
+	alerts []*metering.Alert
+}
+
+func (p *recordingPublisher) Publish(_ context.Context, event events.Event) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if alert, ok := event.Payload().(*metering.Alert); ok {
+		p.alerts = append(p.alerts, alert)
+	}
+
+	return nil
+}
+
+func (p *recordingPublisher) PublishBatch(ctx context.Context, batch []events.Event) error {
+	for _, event := range batch {
+		if err := p.Publish(ctx, event); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func newLogger(t *testing.T) *mocklogging.MockLogger {
+	t.Helper()
+
+	logger := mocklogging.NewMockLogger(gomock.NewController(t))
+	logger.EXPECT().Info(gomock.Any(), gomock.Any()).AnyTimes()
+
+	return logger
+}
+
+func TestService_ThresholdAlerts(t *testing.T) {
+	ctx := context.Background()
+	logger := newLogger(t)
+	publisher := &recordingPublisher{}
+
+	service := metering.NewService(memorystore.NewStore(logger).Metering(), counter.NewMemoryCounter(), nil, publisher,
+		metering.Config{Limits: map[string]int64{metering.MetricAPICalls: 10}, Thresholds: []int{100, 80}}, logger)
+
+	account := metering.UserAccount("user-1")
+
+	for range 7 {
+		require.NoError(t, service.Record(ctx, account, metering.MetricAPICalls, 1))
+	}
+
+	require.NoError(t, service.Flush(ctx))
+	assert.Empty(t, publisher.alerts)
+
+	require.NoError(t, service.Record(ctx, account, metering.MetricAPICalls, 1))
+	require.NoError(t, service.Flush(ctx))
+	require.Len(t, publisher.alerts, 1)
+	assert.Equal(t, 80, publisher.alerts[0].Threshold)
+	assert.Equal(t, int64(8), publisher.alerts[0].Used)
+
+	// Each threshold is announced once a month
+	require.NoError(t, service.Record(ctx, account, metering.MetricAPICalls, 5))
+	require.NoError(t, service.Flush(ctx))
+	require.NoError(t, service.Record(ctx, account, metering.MetricAPICalls, 1))
+	require.NoError(t, service.Flush(ctx))
+	require.Len(t, publisher.alerts, 2)
+	assert.Equal(t, 100, publisher.alerts[1].Threshold)
+
+	// Unlimited metrics never alert
+	require.NoError(t, service.Record(ctx, account, metering.MetricSubmissions, 1000))
+	require.NoError(t, service.Flush(ctx))
+	assert.Len(t, publisher.alerts, 2)
+}
+
+func TestService_UsageReport(t *testing.T) {
+	ctx := context.Background()
+	logger := newLogger(t)
+	repository := memorystore.NewStore(logger).Metering()
+	shared := counter.NewMemoryCounter()
+	cfg := metering.Config{Limits: map[string]int64{metering.MetricSubmissions: 100}}
+
+	// Two instances sharing the counter store each flush what they find
+	first := metering.NewService(repository, shared, nil, nil, cfg, logger)
+	second := metering.NewService(repository, shared, nil, nil, cfg, logger)
+
+	account := metering.UserAccount("user-1")
+
+	require.NoError(t, first.Record(ctx, account, metering.MetricAPICalls, 3))
+	require.NoError(t, second.Record(ctx, account, metering.MetricAPICalls, 2))
+	require.NoError(t, second.Record(ctx, account, metering.MetricSubmissions, 1))
+	require.NoError(t, second.Flush(ctx))
+	require.NoError(t, first.Flush(ctx))
+
+	// Counts not yet flushed are reported too
+	require.NoError(t, first.Record(ctx, account, metering.MetricAPICalls, 1))
+
+	today := time.Now().UTC()
+
+	report, err := first.Usage(ctx, account, today.AddDate(0, 0, -2), today)
+	require.NoError(t, err)
+	require.Len(t, report.Days, 3)
+	assert.Equal(t, int64(0), report.Days[0].Counts[metering.MetricAPICalls])
+	assert.Equal(t, int64(6), report.Days[2].Counts[metering.MetricAPICalls])
+	assert.Equal(t, int64(6), report.Totals[metering.MetricAPICalls])
+	assert.Equal(t, int64(1), report.Totals[metering.MetricSubmissions])
+	assert.Equal(t, int64(6), report.Period.Used[metering.MetricAPICalls])
+	assert.Equal(t, int64(100), report.Period.Limits[metering.MetricSubmissions])
+
+	other, err := first.Usage(ctx, metering.UserAccount("user-2"), today, today)
+	require.NoError(t, err)
+	assert.Equal(t, int64(0), other.Totals[metering.MetricAPICalls])
+
+	_, err = first.Usage(ctx, account, today, today.AddDate(0, 0, -1))
+	require.Error(t, err)
+
+	domainErr := domainerrors.GetDomainError(err)
+	require.NotNil(t, domainErr)
+	assert.Equal(t, domainerrors.ErrCodeValidation, domainErr.Code)
+}
@@ -10,18 +10,68 @@
 package domain
 
 import (
+	"context"
+	"encoding/json"
 	"errors"
+	"fmt"
+	"slices"
+	"time"
 
 	"go.uber.org/fx"
 
+	"github.com/goformx/goforms/internal/domain/archive"
+	"github.com/goformx/goforms/internal/domain/audit"
+	"github.com/goformx/goforms/internal/domain/backup"
+	"github.com/goformx/goforms/internal/domain/billing"
 	"github.com/goformx/goforms/internal/domain/common/events"
+	"github.com/goformx/goforms/internal/domain/dataset"
+	"github.com/goformx/goforms/internal/domain/deadletter"
+	"github.com/goformx/goforms/internal/domain/emaildelivery"
+	"github.com/goformx/goforms/internal/domain/emailtemplate"
+	"github.com/goformx/goforms/internal/domain/encryption"
+	"github.com/goformx/goforms/internal/domain/eventlog"
+	"github.com/goformx/goforms/internal/domain/extension"
+	"github.com/goformx/goforms/internal/domain/flags"
 	"github.com/goformx/goforms/internal/domain/form"
+	formevents "github.com/goformx/goforms/internal/domain/form/events"
+	"github.com/goformx/goforms/internal/domain/form/model"
+	"github.com/goformx/goforms/internal/domain/metering"
+	"github.com/goformx/goforms/internal/domain/securityevent"
 	"github.com/goformx/goforms/internal/domain/user"
+	"github.com/goformx/goforms/internal/infrastructure/cache"
+	"github.com/goformx/goforms/internal/infrastructure/config"
 	"github.com/goformx/goforms/internal/infrastructure/database"
+	"github.com/goformx/goforms/internal/infrastructure/email"
+	"github.com/goformx/goforms/internal/infrastructure/exthook"
+	"github.com/goformx/goforms/internal/infrastructure/httpclient"
 	"github.com/goformx/goforms/internal/infrastructure/logging"
+	"github.com/goformx/goforms/internal/infrastructure/metrics"
+	"github.com/goformx/goforms/internal/infrastructure/pii"
+	archivestore "github.com/goformx/goforms/internal/infrastructure/repository/archive"
+	auditstore "github.com/goformx/goforms/internal/infrastructure/repository/audit"
+	backupstore "github.com/goformx/goforms/internal/infrastructure/repository/backup"
+	billingstore "github.com/goformx/goforms/internal/infrastructure/repository/billing"
+	datasetstore "github.com/goformx/goforms/internal/infrastructure/repository/dataset"
+	deadletterstore "github.com/goformx/goforms/internal/infrastructure/repository/deadletter"
+	emaildeliverystore "github.com/goformx/goforms/internal/infrastructure/repository/emaildelivery"
+	emailtemplatestore "github.com/goformx/goforms/internal/infrastructure/repository/emailtemplate"
+	encryptionstore "github.com/goformx/goforms/internal/infrastructure/repository/encryption"
+	eventlogstore "github.com/goformx/goforms/internal/infrastructure/repository/eventlog"
+	flagstore "github.com/goformx/goforms/internal/infrastructure/repository/flags"
 	formstore "github.com/goformx/goforms/internal/infrastructure/repository/form"
+	activitystore "github.com/goformx/goforms/internal/infrastructure/repository/form/activity"
+	reportstore "github.com/goformx/goforms/internal/infrastructure/repository/form/report"
 	formsubmissionstore "github.com/goformx/goforms/internal/infrastructure/repository/form/submission"
+	usagestore "github.com/goformx/goforms/internal/infrastructure/repository/form/usage"
+	viewstore "github.com/goformx/goforms/internal/infrastructure/repository/form/view"
+	memorystore "github.com/goformx/goforms/internal/infrastructure/repository/memory"
+	meteringstore "github.com/goformx/goforms/internal/infrastructure/repository/metering"
+	mongostore "github.com/goformx/goforms/internal/infrastructure/repository/mongodb"
+	securityeventstore "github.com/goformx/goforms/internal/infrastructure/repository/securityevent"
 	userstore "github.com/goformx/goforms/internal/infrastructure/repository/user"
+	"github.com/goformx/goforms/internal/infrastructure/scheduler"
+	"github.com/goformx/goforms/internal/infrastructure/storage"
+	"github.com/goformx/goforms/internal/infrastructure/stripe"
 )
 
 // UserServiceParams contains dependencies for creating a user service
@@ -51,10 +101,19 @@ type FormServiceParams struct {
 
 	Repository form.Repository
 	EventBus   events.EventBus
+	Quotas     form.QuotaService
+	Datasets   dataset.Service
+	Config     config.FormConfig
 	Logger     logging.Logger
+	Lifecycle  fx.Lifecycle
+	// Batches stores write-behind submissions; required when form.write_behind.enabled is set
+	Batches form.SubmissionBatchRepository `optional:"true"`
+	// DeadLetters keeps write-behind submissions that could not be stored; nil unless dead_letter.enabled is set
+	DeadLetters deadletter.Service `optional:"true"`
 }
 
-// NewFormService creates a new form service with dependencies
+// NewFormService creates a new form service with dependencies. Form reads are
+// coalesced and cached for form.read_cache_ttl.
 func NewFormService(p FormServiceParams) (form.Service, error) {
 	if p.Repository == nil {
 		return nil, errors.New("form repository is required")
@@ -68,44 +127,1544 @@ func NewFormService(p FormServiceParams) (form.Service, error) {
 		return nil, errors.New("logger is required")
 	}
 
-	return form.NewService(p.Repository, p.EventBus, p.Logger), nil
+	images := form.ImageLimits{MaxPixels: p.Config.Images.MaxPixels}
+	service := form.NewService(p.Repository, p.EventBus, p.Quotas, p.Datasets, images, p.Logger)
+
+	if writeBehind := p.Config.WriteBehind; writeBehind.Enabled {
+		if p.Batches == nil {
+			return nil, errors.New("submission batch repository is required for write-behind submissions")
+		}
+
+		queue := form.NewSubmissionQueue(p.Batches, form.WriteBehindOptions{
+			BatchSize:     writeBehind.BatchSize,
+			FlushInterval: writeBehind.FlushInterval,
+			QueueSize:     writeBehind.QueueSize,
+		}, p.Logger)
+
+		if p.DeadLetters != nil {
+			deadLetterSubmissions(queue, p.DeadLetters, p.Logger)
+		}
+
+		p.Lifecycle.Append(fx.Hook{
+			OnStart: func(_ context.Context) error {
+				queue.Start()
+
+				return nil
+			},
+			OnStop: queue.Stop,
+		})
+
+		service = form.NewWriteBehindService(p.Repository, p.EventBus, p.Quotas, p.Datasets, images, queue, p.Logger)
+	}
+
+	cached, err := form.NewReadCachingService(service, p.EventBus, p.Config.ReadCacheTTL, p.Logger)
+	if err != nil {
+		return nil, fmt.Errorf("create form read cache: %w", err)
+	}
+
+	return cached, nil
+}
+
+// deadLetterSubmissions dead-letters the submissions queue fails to store, and
+// retries them by writing them again
+func deadLetterSubmissions(queue *form.SubmissionQueue, letters deadletter.Service, logger logging.Logger) {
+	queue.OnFailed(func(ctx context.Context, submission *model.FormSubmission, err error) {
+		// The write may have failed because ctx timed out; keep the submission regardless
+		keepCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), form.DefaultTimeout)
+		defer cancel()
+
+		reference := fmt.Sprintf("submission %s to form %s", submission.ID, submission.FormID)
+		if recordErr := letters.Record(keepCtx, deadletter.KindSubmission, reference, submission, err); recordErr != nil {
+			logger.Error("failed to dead-letter queued submission", "submission_id", submission.ID, "error", recordErr)
+		}
+	})
+
+	letters.Handle(deadletter.KindSubmission, func(ctx context.Context, entry *deadletter.Entry) error {
+		var submission model.FormSubmission
+		if err := json.Unmarshal(entry.Payload, &submission); err != nil {
+			return fmt.Errorf("decode dead-lettered submission: %w", err)
+		}
+
+		return queue.Write(ctx, &submission)
+	})
+}
+
+// QuotaServiceParams contains dependencies for creating a quota service
+type QuotaServiceParams struct {
+	fx.In
+
+	Repository form.UsageRepository
+	Config     config.FormConfig
+	Billing    billing.Service
+	Logger     logging.Logger
+}
+
+// NewQuotaService creates a new quota service. Users get the limits of their
+// billing plan when billing is enabled, and the form.quota limits otherwise.
+func NewQuotaService(p QuotaServiceParams) (form.QuotaService, error) {
+	if p.Repository == nil {
+		return nil, errors.New("usage repository is required")
+	}
+
+	if p.Logger == nil {
+		return nil, errors.New("logger is required")
+	}
+
+	limits := form.QuotaLimits{
+		MaxForms:               int64(p.Config.Quota.MaxFormsPerUser),
+		MaxSubmissionsPerMonth: int64(p.Config.Quota.MaxSubmissionsPerMonth),
+		MaxStorageBytes:        p.Config.Quota.MaxStorageBytes,
+	}
+
+	var plans form.PlanLimits
+	if p.Billing != nil {
+		plans = p.Billing
+	}
+
+	return form.NewQuotaService(p.Repository, limits, plans, p.Logger), nil
+}
+
+// ReportServiceParams contains dependencies for creating a report service
+type ReportServiceParams struct {
+	fx.In
+
+	Repository form.ReportRepository
+	Logger     logging.Logger
+}
+
+// NewReportService creates a new scheduled report service with dependencies
+func NewReportService(p ReportServiceParams) (form.ReportService, error) {
+	if p.Repository == nil {
+		return nil, errors.New("report repository is required")
+	}
+
+	if p.Logger == nil {
+		return nil, errors.New("logger is required")
+	}
+
+	return form.NewReportService(p.Repository, p.Logger), nil
+}
+
+// ReportDispatcherParams contains dependencies for creating the report dispatcher
+type ReportDispatcherParams struct {
+	fx.In
+
+	Reports     form.ReportService
+	Forms       form.Service
+	Submissions form.SubmissionRangeRepository
+	Templates   emailtemplate.Service
+	Sender      email.Sender
+	Classifier  *pii.Classifier
+	Logger      logging.Logger
+	Lifecycle   fx.Lifecycle
+}
+
+// NewReportDispatcher creates the scheduled report dispatcher and starts polling
+// for due reports
+func NewReportDispatcher(p ReportDispatcherParams) (*form.ReportDispatcher, error) {
+	if p.Submissions == nil {
+		return nil, errors.New("submission range repository is required")
+	}
+
+	dispatcher := form.NewReportDispatcher(p.Reports, p.Forms, p.Submissions, p.Templates, p.Sender, p.Classifier, p.Logger)
+
+	p.Lifecycle.Append(fx.Hook{
+		OnStart: func(_ context.Context) error {
+			dispatcher.Start()
+
+			return nil
+		},
+		OnStop: func(ctx context.Context) error {
+			dispatcher.Stop(ctx)
+
+			return nil
+		},
+	})
+
+	return dispatcher, nil
+}
+
+// HealthReporterParams contains dependencies for creating the account health reporter
+type HealthReporterParams struct {
+	fx.In
+
+	Users          user.Repository
+	Forms          form.Service
+	Submissions    form.SubmissionRangeRepository
+	Quotas         form.QuotaService
+	Audit          audit.Service
+	SecurityEvents securityevent.Service
+	// DeadLetters is nil when dead_letter is disabled
+	DeadLetters deadletter.Service `optional:"true"`
+	Templates   emailtemplate.Service
+	Sender      email.Sender
+	Logger      logging.Logger
+	Lifecycle   fx.Lifecycle
+}
+
+// NewHealthReporter creates the account health reporter and starts emailing
+// the weekly report to the users who opted in. The user store must implement
+// form.HealthEmailRepository.
+func NewHealthReporter(p HealthReporterParams) (*form.HealthReporter, error) {
+	users, ok := p.Users.(form.HealthEmailRepository)
+	if !ok {
+		return nil, errors.New("user repository does not support health emails")
+	}
+
+	signals := &healthSignals{audit: p.Audit, security: p.SecurityEvents, letters: p.DeadLetters}
+	reporter := form.NewHealthReporter(users, p.Forms, p.Submissions, p.Quotas, signals, p.Templates, p.Sender, p.Logger)
+
+	p.Lifecycle.Append(fx.Hook{
+		OnStart: func(_ context.Context) error {
+			reporter.Start()
+
+			return nil
+		},
+		OnStop: func(ctx context.Context) error {
+			reporter.Stop(ctx)
+
+			return nil
+		},
+	})
+
+	return reporter, nil
+}
+
+// healthSignalLimit bounds the dead letters, audit entries and security events
+// read for one account health report
+const healthSignalLimit = 500
+
+// healthSignals reads failing hook deliveries from the dead-letter queue and
+// security events from the audit log and the security event detector
+type healthSignals struct {
+	audit    audit.Service
+	security securityevent.Service
+	letters  deadletter.Service
+}
+
+// FailingDeliveries implements form.HealthSignals. Without a dead-letter queue
+// failed deliveries are not kept, so none are counted.
+func (s *healthSignals) FailingDeliveries(ctx context.Context, formIDs []string) (map[string]int, error) {
+	failing := map[string]int{}
+	if s.letters == nil || len(formIDs) == 0 {
+		return failing, nil
+	}
+
+	entries, _, err := s.letters.List(ctx, deadletter.Filter{
+		Kind: deadletter.KindWebhook, Status: deadletter.StatusPending,
+	}, 0, healthSignalLimit)
+	if err != nil {
+		return nil, fmt.Errorf("list dead letters: %w", err)
+	}
+
+	for _, entry := range entries {
+		var payload webhookDeadLetter
+		if json.Unmarshal(entry.Payload, &payload) != nil || !slices.Contains(formIDs, payload.FormID) {
+			continue
+		}
+
+		failing[payload.FormID]++
+	}
+
+	return failing, nil
+}
+
+// SecurityEvents implements form.HealthSignals with the audit entries about
+// the account and the submission bursts detected against its forms
+func (s *healthSignals) SecurityEvents(
+	ctx context.Context, userID string, formIDs []string, since time.Time,
+) ([]form.HealthSecurityEvent, error) {
+	entries, _, err := s.audit.List(ctx, audit.Filter{TargetID: userID}, 0, healthSignalLimit)
+	if err != nil {
+		return nil, fmt.Errorf("list audit entries: %w", err)
+	}
+
+	events := []form.HealthSecurityEvent{}
+
+	for _, entry := range entries {
+		description, ok := auditDescriptions[entry.Action]
+		if !ok || entry.CreatedAt.Before(since) {
+			continue
+		}
+
+		events = append(events, form.HealthSecurityEvent{Kind: entry.Action, Description: description, At: entry.CreatedAt})
+	}
+
+	if len(formIDs) > 0 {
+		detected, _, listErr := s.security.List(ctx, securityevent.Filter{Kind: securityevent.KindSubmissionBurst},
+			0, healthSignalLimit)
+		if listErr != nil {
+			return nil, fmt.Errorf("list security events: %w", listErr)
+		}
+
+		for _, event := range detected {
+			if event.CreatedAt.Before(since) || !slices.Contains(formIDs, event.Subject) {
+				continue
+			}
+
+			events = append(events, form.HealthSecurityEvent{
+				Kind: event.Kind,
+				Description: fmt.Sprintf("Burst of %d submissions from %s to form %s",
+					event.Count, event.IPAddress, event.Subject),
+				At: event.CreatedAt,
+			})
+		}
+	}
+
+	slices.SortStableFunc(events, func(a, b form.HealthSecurityEvent) int { return b.At.Compare(a.At) })
+
+	return events, nil
+}
+
+// auditDescriptions describe the audit actions an account owner is told about;
+// other actions, such as each request made while impersonating, are left out
+var auditDescriptions = map[string]string{
+	audit.ActionUserRoleChanged:       "An administrator changed your role",
+	audit.ActionUserDisabled:          "An administrator disabled your account",
+	audit.ActionUserEnabled:           "An administrator enabled your account",
+	audit.ActionUserPasswordReset:     "An administrator required a password reset",
+	audit.ActionUserDeletionRequested: "The deletion of your account was requested",
+	audit.ActionUserDeletionCancelled: "The deletion of your account was cancelled",
+	audit.ActionImpersonationStarted:  "An administrator signed in as you",
+	audit.ActionImpersonationEnded:    "An administrator stopped signing in as you",
+}
+
+// TriageServiceParams contains dependencies for creating a triage service
+type TriageServiceParams struct {
+	fx.In
+
+	Views       form.SubmissionViewRepository
+	Submissions form.Repository
+	Logger      logging.Logger
+}
+
+// NewTriageService creates a new submission tagging and saved view service with dependencies
+func NewTriageService(p TriageServiceParams) (form.TriageService, error) {
+	if p.Views == nil || p.Submissions == nil {
+		return nil, errors.New("submission view and form repositories are required")
+	}
+
+	if p.Logger == nil {
+		return nil, errors.New("logger is required")
+	}
+
+	return form.NewTriageService(p.Views, p.Submissions, p.Logger), nil
+}
+
+// ActivityServiceParams contains dependencies for creating a submission activity service
+type ActivityServiceParams struct {
+	fx.In
+
+	Activities  form.SubmissionActivityRepository
+	Submissions form.Repository
+	Logger      logging.Logger
+}
+
+// NewActivityService creates a new submission history, notes and delivery service with dependencies
+func NewActivityService(p ActivityServiceParams) (form.ActivityService, error) {
+	if p.Activities == nil || p.Submissions == nil {
+		return nil, errors.New("submission activity and form repositories are required")
+	}
+
+	if p.Logger == nil {
+		return nil, errors.New("logger is required")
+	}
+
+	return form.NewActivityService(p.Activities, p.Submissions, p.Logger), nil
+}
+
+// SchemaHistoryServiceParams contains dependencies for creating a schema undo history service
+type SchemaHistoryServiceParams struct {
+	fx.In
+
+	Revisions form.SchemaRevisionRepository
+	Forms     form.Service
+	Logger    logging.Logger
+}
+
+// NewSchemaHistoryService creates a new form schema undo and redo service with dependencies
+func NewSchemaHistoryService(p SchemaHistoryServiceParams) (form.SchemaHistoryService, error) {
+	if p.Revisions == nil || p.Forms == nil {
+		return nil, errors.New("schema revision repository and form service are required")
+	}
+
+	if p.Logger == nil {
+		return nil, errors.New("logger is required")
+	}
+
+	return form.NewSchemaHistoryService(p.Revisions, p.Forms, p.Logger), nil
+}
+
+// ImportServiceParams contains dependencies for creating a submission import service
+type ImportServiceParams struct {
+	fx.In
+
+	Repository form.Repository
+	Quotas     form.QuotaService
+	Logger     logging.Logger
+}
+
+// NewImportService creates a new submission import service with dependencies
+func NewImportService(p ImportServiceParams) (form.ImportService, error) {
+	if p.Repository == nil {
+		return nil, errors.New("form repository is required")
+	}
+
+	if p.Logger == nil {
+		return nil, errors.New("logger is required")
+	}
+
+	return form.NewImportService(p.Repository, p.Quotas, p.Logger), nil
+}
+
+// SchemaMigrationServiceParams contains dependencies for creating a schema migration service
+type SchemaMigrationServiceParams struct {
+	fx.In
+
+	Repository form.Repository
+	Logger     logging.Logger
+}
+
+// NewSchemaMigrationService creates a new submission schema migration service with dependencies
+func NewSchemaMigrationService(p SchemaMigrationServiceParams) (form.SchemaMigrationService, error) {
+	if p.Repository == nil {
+		return nil, errors.New("form repository is required")
+	}
+
+	if p.Logger == nil {
+		return nil, errors.New("logger is required")
+	}
+
+	return form.NewSchemaMigrationService(p.Repository, p.Logger), nil
+}
+
+// EmailTemplateServiceParams contains dependencies for creating an email template service
+type EmailTemplateServiceParams struct {
+	fx.In
+
+	Repository emailtemplate.Repository
+	Config     config.AppConfig
+	Flags      flags.Client
+	Logger     logging.Logger
+}
+
+// NewEmailTemplateService creates a new email template service with dependencies
+func NewEmailTemplateService(p EmailTemplateServiceParams) (emailtemplate.Service, error) {
+	if p.Repository == nil {
+		return nil, errors.New("email template repository is required")
+	}
+
+	if p.Logger == nil {
+		return nil, errors.New("logger is required")
+	}
+
+	return emailtemplate.NewService(p.Repository, p.Config.Name, p.Flags, p.Logger), nil
+}
+
+// DatasetServiceParams contains dependencies for creating a dataset service
+type DatasetServiceParams struct {
+	fx.In
+
+	Repository dataset.Repository
+	Config     config.FormConfig
+	Logger     logging.Logger
+}
+
+// NewDatasetService creates a new dataset service with the limits and read
+// cache of form.datasets
+func NewDatasetService(p DatasetServiceParams) (dataset.Service, error) {
+	if p.Repository == nil {
+		return nil, errors.New("dataset repository is required")
+	}
+
+	if p.Logger == nil {
+		return nil, errors.New("logger is required")
+	}
+
+	limits := dataset.Limits{MaxRows: p.Config.Datasets.MaxRows, MaxPerOwner: p.Config.Datasets.MaxPerOwner}
+
+	return dataset.NewService(p.Repository, limits, p.Config.Datasets.CacheTTL, p.Logger), nil
+}
+
+// EmailDeliveryServiceParams contains dependencies for creating an email delivery service
+type EmailDeliveryServiceParams struct {
+	fx.In
+
+	Repository emaildelivery.Repository
+	Logger     logging.Logger
+}
+
+// NewEmailDeliveryService creates a new email suppression and delivery tracking service with dependencies
+func NewEmailDeliveryService(p EmailDeliveryServiceParams) (emaildelivery.Service, error) {
+	if p.Repository == nil {
+		return nil, errors.New("email delivery repository is required")
+	}
+
+	if p.Logger == nil {
+		return nil, errors.New("logger is required")
+	}
+
+	return emaildelivery.NewService(p.Repository, p.Logger), nil
+}
+
+// AuditServiceParams contains dependencies for creating an audit service
+type AuditServiceParams struct {
+	fx.In
+
+	Repository audit.Repository
+	Logger     logging.Logger
+}
+
+// NewAuditService creates a new audit log service with dependencies
+func NewAuditService(p AuditServiceParams) (audit.Service, error) {
+	if p.Repository == nil {
+		return nil, errors.New("audit repository is required")
+	}
+
+	if p.Logger == nil {
+		return nil, errors.New("logger is required")
+	}
+
+	return audit.NewService(p.Repository, p.Logger), nil
+}
+
+// UserAdminServiceParams contains dependencies for creating a user administration service
+type UserAdminServiceParams struct {
+	fx.In
+
+	Repo   user.Repository
+	Audit  audit.Service
+	Logger logging.Logger
+}
+
+// NewUserAdminService creates a new user administration service with dependencies.
+// The user store must implement user.DirectoryRepository.
+func NewUserAdminService(p UserAdminServiceParams) (user.AdminService, error) {
+	if p.Repo == nil {
+		return nil, errors.New("user repository is required")
+	}
+
+	directory, ok := p.Repo.(user.DirectoryRepository)
+	if !ok {
+		return nil, errors.New("user repository does not support the admin user directory")
+	}
+
+	if p.Audit == nil || p.Logger == nil {
+		return nil, errors.New("audit service and logger are required")
+	}
+
+	return user.NewAdminService(p.Repo, directory, p.Audit, p.Logger), nil
+}
+
+// UserDeletionServiceParams contains dependencies for creating the account deletion service
+type UserDeletionServiceParams struct {
+	fx.In
+
+	Repo        user.Repository
+	Forms       form.Service
+	Submissions form.Repository
+	Audit       audit.Service
+	EventBus    events.EventBus
+	Classifier  *pii.Classifier
+	Config      config.UserConfig
+	Logger      logging.Logger
+	Lifecycle   fx.Lifecycle
+}
+
+// NewUserDeletionService creates the account deletion service and purges the
+// accounts whose grace period ended every user.deletion.purge_interval. The
+// user store must implement user.DeletionRepository.
+func NewUserDeletionService(p UserDeletionServiceParams) (user.DeletionService, error) {
+	deletions, ok := p.Repo.(user.DeletionRepository)
+	if !ok {
+		return nil, errors.New("user repository does not support account deletion")
+	}
+
+	cfg := p.Config.Deletion
+	service := user.NewDeletionService(p.Repo, deletions, deletionForms{Service: p.Forms, submissions: p.Submissions},
+		p.Audit, p.EventBus, p.Classifier, user.DeletionOptions{
+			GracePeriod:          cfg.GracePeriod,
+			AnonymizeSubmissions: cfg.Submissions == config.UserDeletionAnonymize,
+		}, p.Logger)
+
+	runner := scheduler.NewRunner("user_deletion_purge", cfg.PurgeInterval, service.PurgeDue, p.Logger)
+
+	p.Lifecycle.Append(fx.Hook{
+		OnStart: func(_ context.Context) error {
+			runner.Start()
+
+			return nil
+		},
+		OnStop: func(ctx context.Context) error {
+			runner.Stop(ctx)
+
+			return nil
+		},
+	})
+
+	return service, nil
+}
+
+// deletionForms changes forms through the form service, so cached reads and
+// form events follow, and submissions through the form store
+type deletionForms struct {
+	form.Service
+	submissions form.Repository
+}
+
+// ListSubmissions lists a form's submissions
+func (f deletionForms) ListSubmissions(ctx context.Context, formID string) ([]*model.FormSubmission, error) {
+	return f.submissions.ListSubmissions(ctx, formID)
+}
+
+// UpdateSubmission saves a submission
+func (f deletionForms) UpdateSubmission(ctx context.Context, submission *model.FormSubmission) error {
+	return f.submissions.UpdateSubmission(ctx, submission)
+}
+
+// DeleteSubmission deletes a submission
+func (f deletionForms) DeleteSubmission(ctx context.Context, id string) error {
+	return f.submissions.DeleteSubmission(ctx, id)
+}
+
+// SecurityEventServiceParams contains dependencies for creating a security event service
+type SecurityEventServiceParams struct {
+	fx.In
+
+	Repository securityevent.Repository
+	Config     config.SecurityConfig
+	Logger     logging.Logger
+	// Blocker applies IP blocks; the access manager provides it
+	Blocker securityevent.Blocker `optional:"true"`
+	// Notifier is nil unless security.events.notify_emails is set
+	Notifier securityevent.Notifier `optional:"true"`
+}
+
+// NewSecurityEventService creates a new security event detection service with the
+// configured rules. With security.events.enabled unset no rule trips.
+func NewSecurityEventService(p SecurityEventServiceParams) (securityevent.Service, error) {
+	if p.Repository == nil {
+		return nil, errors.New("security event repository is required")
+	}
+
+	if p.Logger == nil {
+		return nil, errors.New("logger is required")
+	}
+
+	events := p.Config.Events
+	cfg := securityevent.Config{BlockDuration: events.BlockDuration, ExemptIPs: events.ExemptIPs}
+
+	if events.Enabled {
+		rule := func(r config.SecurityEventRuleConfig) securityevent.Rule {
+			return securityevent.Rule{Threshold: r.Threshold, Window: r.Window, Block: r.Block}
+		}
+
+		cfg.Rules = map[string]securityevent.Rule{
+			securityevent.KindFailedLogin:     rule(events.FailedLogin),
+			securityevent.KindSubmissionBurst: rule(events.SubmissionBurst),
+			securityevent.KindCSRFFailure:     rule(events.CSRFFailure),
+		}
+	}
+
+	return securityevent.NewService(p.Repository, cfg, p.Blocker, p.Notifier, p.Logger), nil
+}
+
+// BackupServiceParams contains dependencies for creating a backup service
+type BackupServiceParams struct {
+	fx.In
+
+	Repository backup.Repository
+	Owners     user.UserEnsurer
+	Storage    storage.Storage
+	Config     config.StorageConfig
+	Logger     logging.Logger
+	Lifecycle  fx.Lifecycle
+}
+
+// NewBackupService creates the backup service and starts the backup schedule.
+// It provides nil when storage.backup.enabled is unset.
+func NewBackupService(p BackupServiceParams) backup.Service {
+	cfg := p.Config.Backup
+	if !cfg.Enabled {
+		return nil
+	}
+
+	service := backup.NewService(p.Repository, p.Storage, p.Owners, backup.Config{
+		EncryptionKey: cfg.EncryptionKey,
+		Retention:     cfg.Retention,
+		Prefix:        cfg.Prefix,
+	}, p.Logger)
+
+	runner := scheduler.NewRunner("backup", cfg.Interval, service.RunScheduled, p.Logger)
+
+	p.Lifecycle.Append(fx.Hook{
+		OnStart: func(_ context.Context) error {
+			runner.Start()
+
+			return nil
+		},
+		OnStop: func(ctx context.Context) error {
+			runner.Stop(ctx)
+
+			return nil
+		},
+	})
+
+	return service
+}
+
+// ArchiveServiceParams contains dependencies for creating a submission archive service
+type ArchiveServiceParams struct {
+	fx.In
+
+	Repository archive.Repository
+	Storage    storage.Storage
+	Config     config.StorageConfig
+	Logger     logging.Logger
+	Lifecycle  fx.Lifecycle
+}
+
+// NewArchiveService creates the submission archive service and starts the archive
+// schedule. It provides nil when storage.archive.enabled is unset.
+func NewArchiveService(p ArchiveServiceParams) archive.Service {
+	cfg := p.Config.Archive
+	if !cfg.Enabled {
+		return nil
+	}
+
+	service := archive.NewService(p.Repository, p.Storage, archive.Config{
+		After:     cfg.After,
+		BatchSize: cfg.BatchSize,
+		Prefix:    cfg.Prefix,
+	}, p.Logger)
+
+	runner := scheduler.NewRunner("archive", cfg.Interval, service.RunScheduled, p.Logger)
+
+	p.Lifecycle.Append(fx.Hook{
+		OnStart: func(_ context.Context) error {
+			runner.Start()
+
+			return nil
+		},
+		OnStop: func(ctx context.Context) error {
+			runner.Stop(ctx)
+
+			return nil
+		},
+	})
+
+	return service
+}
+
+// BillingServiceParams contains dependencies for creating a billing service
+type BillingServiceParams struct {
+	fx.In
+
+	Repository billing.Repository
+	Config     config.BillingConfig
+	FormConfig config.FormConfig
+	Clients    *httpclient.Factory `optional:"true"`
+	Logger     logging.Logger
+}
+
+// NewBillingService creates the Stripe billing service. It provides nil when
+// billing.enabled is unset.
+func NewBillingService(p BillingServiceParams) billing.Service {
+	cfg := p.Config
+	if !cfg.Enabled {
+		return nil
+	}
+
+	plans := make([]billing.Plan, 0, len(cfg.Plans))
+	for _, plan := range cfg.Plans {
+		plans = append(plans, billing.Plan{
+			ID:      plan.ID,
+			Name:    plan.Name,
+			PriceID: plan.PriceID,
+			Limits: form.QuotaLimits{
+				MaxForms:               int64(plan.MaxForms),
+				MaxSubmissionsPerMonth: int64(plan.MaxSubmissionsPerMonth),
+				MaxStorageBytes:        plan.MaxStorageBytes,
+			},
+		})
+	}
+
+	gateway := stripe.NewClient(cfg.Stripe.SecretKey, cfg.Stripe.Endpoint,
+		p.Clients.Timeout(config.ResilienceTargetStripe, cfg.Stripe.Timeout),
+		p.Clients.Transport(config.ResilienceTargetStripe, "api"))
+
+	return billing.NewService(p.Repository, gateway, billing.Config{
+		Plans: plans,
+		FreeLimits: form.QuotaLimits{
+			MaxForms:               int64(p.FormConfig.Quota.MaxFormsPerUser),
+			MaxSubmissionsPerMonth: int64(p.FormConfig.Quota.MaxSubmissionsPerMonth),
+			MaxStorageBytes:        p.FormConfig.Quota.MaxStorageBytes,
+		},
+		SuccessURL:      cfg.SuccessURL,
+		CancelURL:       cfg.CancelURL,
+		PortalReturnURL: cfg.PortalReturnURL,
+	}, p.Logger)
+}
+
+// MeteringServiceParams contains dependencies for creating a usage metering service
+type MeteringServiceParams struct {
+	fx.In
+
+	Repository metering.Repository
+	Counter    metering.Counter
+	Billing    billing.Service
+	EventBus   events.EventBus
+	Config     config.APIConfig
+	FormConfig config.FormConfig
+	Logger     logging.Logger
+	Lifecycle  fx.Lifecycle
+}
+
+// NewMeteringService creates the usage metering service and flushes its counters
+// every api.metering.flush_interval and on shutdown. It provides nil when
+// api.metering.enabled is unset.
+func NewMeteringService(p MeteringServiceParams) metering.Service {
+	cfg := p.Config.Metering
+	if !cfg.Enabled {
+		return nil
+	}
+
+	var limits metering.LimitSource
+	if p.Billing != nil {
+		limits = meteringPlanLimits{plans: p.Billing}
+	}
+
+	service := metering.NewService(p.Repository, p.Counter, limits, p.EventBus, metering.Config{
+		Limits: map[string]int64{
+			metering.MetricAPICalls:    cfg.MonthlyAPICalls,
+			metering.MetricSubmissions: int64(p.FormConfig.Quota.MaxSubmissionsPerMonth),
+		},
+		Thresholds: cfg.Thresholds,
+	}, p.Logger)
+
+	runner := scheduler.NewRunner("metering", cfg.FlushInterval, service.Flush, p.Logger)
+
+	p.Lifecycle.Append(fx.Hook{
+		OnStart: func(_ context.Context) error {
+			runner.Start()
+
+			return nil
+		},
+		OnStop: func(ctx context.Context) error {
+			runner.Stop(ctx)
+
+			if err := service.Flush(ctx); err != nil {
+				p.Logger.Error("failed to flush usage counters on shutdown", "error", err)
+			}
+
+			return nil
+		},
+	})
+
+	return service
+}
+
+// meteringPlanLimits gives user accounts the submission limit of their billing plan
+type meteringPlanLimits struct {
+	plans form.PlanLimits
+}
+
+// Limits returns the plan limits of a user account; other accounts have none
+func (l meteringPlanLimits) Limits(ctx context.Context, account string) (map[string]int64, error) {
+	userID, ok := metering.UserID(account)
+	if !ok {
+		return nil, nil
+	}
+
+	limits, err := l.plans.Limits(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return map[string]int64{metering.MetricSubmissions: limits.MaxSubmissionsPerMonth}, nil
+}
+
+// FlagServiceParams contains dependencies for creating a feature flag service
+type FlagServiceParams struct {
+	fx.In
+
+	Repository flags.Repository
+	Config     config.FlagsConfig
+	Logger     logging.Logger
+	Lifecycle  fx.Lifecycle
+}
+
+// NewFlagService creates the feature flag service from the config-file flags and
+// the stored flags, and reloads the stored flags every flags.refresh_interval
+func NewFlagService(p FlagServiceParams) (flags.Service, error) {
+	if p.Repository == nil {
+		return nil, errors.New("feature flag repository is required")
+	}
+
+	if p.Logger == nil {
+		return nil, errors.New("logger is required")
+	}
+
+	configured := make([]*flags.Flag, 0, len(p.Config.Definitions))
+
+	for key, definition := range p.Config.Definitions {
+		flag := &flags.Flag{
+			Key:         key,
+			Description: definition.Description,
+			Enabled:     definition.Enabled,
+			Percentage:  100,
+			Accounts:    definition.Accounts,
+		}
+
+		if definition.Percentage != nil {
+			flag.Percentage = *definition.Percentage
+		}
+
+		configured = append(configured, flag)
+	}
+
+	service := flags.NewService(flags.NewStaticProvider(configured), p.Repository, p.Logger)
+
+	runner := scheduler.NewRunner("feature_flags", p.Config.RefreshInterval, service.Refresh, p.Logger)
+
+	p.Lifecycle.Append(fx.Hook{
+		OnStart: func(_ context.Context) error {
+			runner.Start()
+
+			return nil
+		},
+		OnStop: func(ctx context.Context) error {
+			runner.Stop(ctx)
+
+			return nil
+		},
+	})
+
+	return service, nil
+}
+
+// ExtensionRunnerParams contains dependencies for creating the form extension runner
+type ExtensionRunnerParams struct {
+	fx.In
+
+	Config    config.FormConfig
+	Logger    logging.Logger
+	Lifecycle fx.Lifecycle
+	// Clients creates each HTTP hook's client, with its own circuit
+	Clients *httpclient.Factory `optional:"true"`
+	// Activities records post-submit outcomes as webhook deliveries
+	Activities form.ActivityService
+	// Forms loads the form and submission of a dead-lettered delivery to retry it
+	Forms form.Service
+	// DeadLetters keeps failed post-submit deliveries; nil unless dead_letter.enabled is set
+	DeadLetters deadletter.Service `optional:"true"`
+	// Plugins are compiled-in extensions provided with fx.ResultTags(`group:"extensions"`)
+	Plugins []extension.Extension `group:"extensions"`
+}
+
+// NewExtensionRunner registers the compiled-in plugins and configured HTTP hooks.
+// The runner has no extensions unless form.extensions.enabled is set.
+func NewExtensionRunner(p ExtensionRunnerParams) (*extension.Runner, error) {
+	runner := extension.NewRunner(p.Logger)
+
+	cfg := p.Config.Extensions
+	if !cfg.Enabled {
+		return runner, nil
+	}
+
+	if p.Activities != nil {
+		runner.SetDeliveryRecorder(&activityDeliveries{activities: p.Activities, letters: p.DeadLetters})
+	}
+
+	if p.DeadLetters != nil {
+		p.DeadLetters.Handle(deadletter.KindWebhook, func(ctx context.Context, entry *deadletter.Entry) error {
+			return redeliverDeadLetter(ctx, p.Forms, runner, entry)
+		})
+	}
+
+	settings := func(s config.ExtensionSettingsConfig) extension.Settings {
+		timeout := s.Timeout
+		if timeout <= 0 {
+			timeout = cfg.Timeout
+		}
+
+		return extension.Settings{Timeout: timeout, OnError: s.OnError, Global: s.Global}
+	}
+
+	for _, plugin := range p.Plugins {
+		if err := runner.Register(plugin, settings(cfg.Plugins[plugin.Name()])); err != nil {
+			return nil, fmt.Errorf("register form extension plugin: %w", err)
+		}
+	}
+
+	for _, hook := range cfg.Hooks {
+		client := p.Clients.Client(config.ResilienceTargetHooks, hook.Name, 0)
+		if err := runner.Register(exthook.New(hook, client), settings(hook.ExtensionSettingsConfig)); err != nil {
+			return nil, fmt.Errorf("register form extension hook: %w", err)
+		}
+	}
+
+	p.Lifecycle.Append(fx.Hook{
+		OnStop: func(ctx context.Context) error {
+			runner.Stop(ctx)
+
+			return nil
+		},
+	})
+
+	p.Logger.Info("form extensions registered", "extensions", runner.Names())
+
+	return runner, nil
+}
+
+// webhookDeadLetter is the payload of a dead-lettered post-submit delivery
+type webhookDeadLetter struct {
+	FormID       string `json:"form_id"`
+	SubmissionID string `json:"submission_id"`
+	Extension    string `json:"extension"`
+}
+
+// redeliverDeadLetter runs a dead-lettered post-submit hook again
+func redeliverDeadLetter(ctx context.Context, forms form.Service, runner *extension.Runner, entry *deadletter.Entry) error {
+	var delivery webhookDeadLetter
+	if err := json.Unmarshal(entry.Payload, &delivery); err != nil {
+		return fmt.Errorf("decode dead-lettered delivery: %w", err)
+	}
+
+	submission, err := forms.GetFormSubmission(ctx, delivery.SubmissionID)
+	if err != nil {
+		return fmt.Errorf("get submission: %w", err)
+	}
+
+	target, err := forms.GetForm(ctx, delivery.FormID)
+	if err != nil {
+		return fmt.Errorf("get form: %w", err)
+	}
+
+	if redeliverErr := runner.Redeliver(ctx, target, submission, delivery.Extension); redeliverErr != nil {
+		return fmt.Errorf("redeliver: %w", redeliverErr)
+	}
+
+	return nil
+}
+
+// activityDeliveries records post-submit hook outcomes in the submission's
+// activity, and dead-letters failed first deliveries when letters is set.
+// Failed redeliveries are not dead-lettered again; a dead letter's own retry
+// failures are kept on the entry.
+type activityDeliveries struct {
+	activities form.ActivityService
+	letters    deadletter.Service
+}
+
+// RecordDelivery implements extension.DeliveryRecorder
+func (d *activityDeliveries) RecordDelivery(ctx context.Context, delivery extension.Delivery) error {
+	details := model.JSON{
+		"extension":   delivery.Extension,
+		"success":     delivery.Err == nil,
+		"duration_ms": delivery.Duration.Milliseconds(),
+	}
+
+	if delivery.Redelivery {
+		details["redelivery"] = true
+	}
+
+	if delivery.Err != nil {
+		details["error"] = delivery.Err.Error()
+	}
+
+	err := d.activities.RecordActivity(ctx, &model.SubmissionActivity{
+		FormID:       delivery.FormID,
+		SubmissionID: delivery.SubmissionID,
+		Kind:         model.ActivityWebhookDelivery,
+		Details:      details,
+	})
+
+	if delivery.Err != nil && !delivery.Redelivery && d.letters != nil {
+		reference := fmt.Sprintf("%s for submission %s", delivery.Extension, delivery.SubmissionID)
+		payload := webhookDeadLetter{FormID: delivery.FormID, SubmissionID: delivery.SubmissionID, Extension: delivery.Extension}
+
+		if recordErr := d.letters.Record(ctx, deadletter.KindWebhook, reference, payload, delivery.Err); recordErr != nil {
+			err = errors.Join(err, fmt.Errorf("dead-letter delivery: %w", recordErr))
+		}
+	}
+
+	return err
+}
+
+// eventLogPruneInterval is how often stored events past their retention are deleted
+const eventLogPruneInterval = time.Hour
+
+// EventLogRepositoryParams contains dependencies for creating the stored event repository
+type EventLogRepositoryParams struct {
+	fx.In
+	// DB is nil when database.driver is memory or mongodb
+	DB             database.DB `optional:"true"`
+	DatabaseConfig config.DatabaseConfig
+	Config         config.EventsConfig
+	Logger         logging.Logger
+}
+
+// NewEventLogRepository creates the stored event repository the event bus
+// records into. It provides nil unless events.store.enabled is set. It does not
+// depend on the event bus, unlike the stores, because the bus depends on it.
+func NewEventLogRepository(p EventLogRepositoryParams) eventlog.Repository {
+	if !p.Config.Store.Enabled {
+		return nil
+	}
+
+	if p.DatabaseConfig.Driver == config.DatabaseDriverMemory || p.DB == nil {
+		return memorystore.NewEventLogStore()
+	}
+
+	return eventlogstore.NewStore(p.DB, p.Logger)
+}
+
+// EventLogServiceParams contains dependencies for creating the stored event service
+type EventLogServiceParams struct {
+	fx.In
+
+	Repository eventlog.Repository `optional:"true"`
+	EventBus   events.EventBus
+	Config     config.EventsConfig
+	Logger     logging.Logger
+	Lifecycle  fx.Lifecycle
+}
+
+// NewEventLogService creates the service that lists and replays stored events
+// and starts pruning them. It provides nil unless events.store.enabled is set.
+func NewEventLogService(p EventLogServiceParams) eventlog.Service {
+	if p.Repository == nil {
+		return nil
+	}
+
+	service := eventlog.NewService(p.Repository, p.EventBus, formevents.Decode, p.Config.Store.Retention, p.Logger)
+
+	runner := scheduler.NewRunner("event_log_prune", eventLogPruneInterval, service.Prune, p.Logger)
+
+	p.Lifecycle.Append(fx.Hook{
+		OnStart: func(_ context.Context) error {
+			runner.Start()
+
+			return nil
+		},
+		OnStop: func(ctx context.Context) error {
+			runner.Stop(ctx)
+
+			return nil
+		},
+	})
+
+	return service
+}
+
+// deadLetterPruneInterval is how often resolved dead letters past their retention are deleted
+const deadLetterPruneInterval = time.Hour
+
+// DeadLetterRepositoryParams contains dependencies for creating the dead-letter repository
+type DeadLetterRepositoryParams struct {
+	fx.In
+	// DB is nil when database.driver is memory or mongodb
+	DB             database.DB `optional:"true"`
+	DatabaseConfig config.DatabaseConfig
+	Config         config.DeadLetterConfig
+	Logger         logging.Logger
+}
+
+// NewDeadLetterRepository creates the dead-letter repository. It provides nil
+// unless dead_letter.enabled is set. Like the stored event repository it does
+// not depend on the event bus, so the email sender can use it.
+func NewDeadLetterRepository(p DeadLetterRepositoryParams) deadletter.Repository {
+	if !p.Config.Enabled {
+		return nil
+	}
+
+	if p.DatabaseConfig.Driver == config.DatabaseDriverMemory || p.DB == nil {
+		return memorystore.NewDeadLetterStore()
+	}
+
+	return deadletterstore.NewStore(p.DB, p.Logger)
+}
+
+// DeadLetterServiceParams contains dependencies for creating the dead-letter service
+type DeadLetterServiceParams struct {
+	fx.In
+
+	Repository deadletter.Repository `optional:"true"`
+	Config     config.DeadLetterConfig
+	Logger     logging.Logger
+	Lifecycle  fx.Lifecycle
+}
+
+// NewDeadLetterService creates the dead-letter queue and starts pruning
+// resolved entries. Retry handlers are registered by the services whose work
+// is dead-lettered. It provides nil unless dead_letter.enabled is set.
+func NewDeadLetterService(p DeadLetterServiceParams) deadletter.Service {
+	if p.Repository == nil {
+		return nil
+	}
+
+	service := deadletter.NewService(p.Repository, p.Config.Retention, p.Logger)
+
+	runner := scheduler.NewRunner("dead_letter_prune", deadLetterPruneInterval, service.Prune, p.Logger)
+
+	p.Lifecycle.Append(fx.Hook{
+		OnStart: func(_ context.Context) error {
+			runner.Start()
+
+			return nil
+		},
+		OnStop: func(ctx context.Context) error {
+			runner.Stop(ctx)
+
+			return nil
+		},
+	})
+
+	return service
+}
+
+// testSubmissionPurgeInterval is how often test submissions past their retention are deleted
+const testSubmissionPurgeInterval = time.Hour
+
+// TestSubmissionPurgeParams contains dependencies for purging test submissions
+type TestSubmissionPurgeParams struct {
+	fx.In
+
+	Repository form.TestSubmissionRepository `optional:"true"`
+	FormConfig config.FormConfig
+	Logger     logging.Logger
+	Lifecycle  fx.Lifecycle
+}
+
+// RunTestSubmissionPurge deletes the test submissions made from the builder's
+// preview once they are older than form.test_submissions.retention
+func RunTestSubmissionPurge(p TestSubmissionPurgeParams) {
+	if p.Repository == nil {
+		return
+	}
+
+	purger := form.NewTestSubmissionPurger(p.Repository, p.FormConfig.TestSubmissions.Retention, p.Logger)
+	runner := scheduler.NewRunner("test_submission_purge", testSubmissionPurgeInterval, purger.Purge, p.Logger)
+
+	p.Lifecycle.Append(fx.Hook{
+		OnStart: func(_ context.Context) error {
+			runner.Start()
+
+			return nil
+		},
+		OnStop: func(ctx context.Context) error {
+			runner.Stop(ctx)
+
+			return nil
+		},
+	})
+}
+
+// EncryptionServiceParams contains dependencies for creating the submission encryption service
+type EncryptionServiceParams struct {
+	fx.In
+	// DB is nil when database.driver is memory or mongodb
+	DB database.DB `optional:"true"`
+	// Shards is nil unless database.sharding.enabled is set
+	Shards         *database.Shards `optional:"true"`
+	DatabaseConfig config.DatabaseConfig
+	FormConfig     config.FormConfig
+	Logger         logging.Logger
+	Lifecycle      fx.Lifecycle
+}
+
+// NewEncryptionService creates the submission encryption service and starts the
+// key rotation job. It provides nil unless form.encryption.enabled is set. The
+// stores install the plugin that seals and opens submissions with it.
+func NewEncryptionService(p EncryptionServiceParams) (encryption.Service, error) {
+	cfg := p.FormConfig.Encryption
+	if !cfg.Enabled {
+		return nil, nil
+	}
+
+	keyring, err := encryption.NewKeyring(cfg.Keys)
+	if err != nil {
+		return nil, fmt.Errorf("create encryption keyring: %w", err)
+	}
+
+	var repository encryption.Repository
+
+	switch {
+	case p.DatabaseConfig.Driver == config.DatabaseDriverMemory || p.DB == nil:
+		repository = memorystore.NewEncryptionStore()
+	case p.Shards != nil:
+		repository = encryptionstore.NewShardedStore(p.DB, p.Shards, p.Logger)
+	default:
+		repository = encryptionstore.NewStore(p.DB, p.Logger)
+	}
+
+	service := encryption.NewService(repository, keyring, encryption.Options{
+		SettingCacheTTL:   cfg.SettingCacheTTL,
+		RotationBatchSize: cfg.RotationBatchSize,
+	}, p.Logger)
+
+	if cfg.RotationInterval > 0 {
+		rotate := func(ctx context.Context) error {
+			_, rotateErr := service.Rotate(ctx)
+			if errors.Is(rotateErr, encryption.ErrRotationRunning) {
+				return nil
+			}
+
+			return rotateErr
+		}
+		runner := scheduler.NewRunner("submission_encryption_rotation", cfg.RotationInterval, rotate, p.Logger)
+
+		p.Lifecycle.Append(fx.Hook{
+			OnStart: func(_ context.Context) error {
+				runner.Start()
+
+				return nil
+			},
+			OnStop: func(ctx context.Context) error {
+				runner.Stop(ctx)
+
+				return nil
+			},
+		})
+	}
+
+	return service, nil
 }
 
 // StoreParams groups store dependencies
 type StoreParams struct {
 	fx.In
-	DB     database.DB
-	Logger logging.Logger
+	// DB is nil when database.driver is memory or mongodb
+	DB database.DB `optional:"true"`
+	// Shards is nil unless database.sharding.enabled is set
+	Shards         *database.Shards `optional:"true"`
+	DatabaseConfig config.DatabaseConfig
+	Lifecycle      fx.Lifecycle
+	Logger         logging.Logger
+	Cache          cache.Cache
+	CacheConfig    config.CacheConfig
+	EventBus       events.EventBus
+	Metrics        *metrics.Registry
+	// Encryption is nil unless form.encryption.enabled is set
+	Encryption encryption.Service `optional:"true"`
 }
 
 // Stores groups all store implementations
 type Stores struct {
 	fx.Out
-	UserRepository           user.Repository
-	FormRepository           form.Repository
-	FormSubmissionRepository form.SubmissionRepository
+	UserRepository            user.Repository
+	FormRepository            form.Repository
+	FormSubmissionRepository  form.SubmissionRepository
+	ReportRepository          form.ReportRepository
+	UsageRepository           form.UsageRepository
+	SubmissionViewRepository  form.SubmissionViewRepository
+	ActivityRepository        form.SubmissionActivityRepository
+	SubmissionBatchRepository form.SubmissionBatchRepository
+	SubmissionRangeRepository form.SubmissionRangeRepository
+	TestSubmissionRepository  form.TestSubmissionRepository
+	SchemaRevisionRepository  form.SchemaRevisionRepository
+	EmailTemplateRepository   emailtemplate.Repository
+	DatasetRepository         dataset.Repository
+	EmailDeliveryRepository   emaildelivery.Repository
+	AuditRepository           audit.Repository
+	SecurityEventRepository   securityevent.Repository
+	BackupRepository          backup.Repository
+	ArchiveRepository         archive.Repository
+	FlagRepository            flags.Repository
+	BillingRepository         billing.Repository
+	MeteringRepository        metering.Repository
 }
 
 // NewStores creates new store instances with proper validation and error handling
 func NewStores(p StoreParams) (Stores, error) {
+	if p.Logger == nil {
+		return Stores{}, errors.New("logger is required")
+	}
+
+	if p.DatabaseConfig.Driver == config.DatabaseDriverMemory {
+		return newMemoryStores(p)
+	}
+
+	if p.DatabaseConfig.Driver == config.DatabaseDriverMongoDB {
+		return newMongoStores(p)
+	}
+
 	if p.DB == nil {
 		return Stores{}, errors.New("database connection is required")
 	}
 
-	if p.Logger == nil {
-		return Stores{}, errors.New("logger is required")
+	// Submissions are sealed and opened on the connection every store shares
+	if p.Encryption != nil {
+		if err := p.DB.GetDB().Use(encryptionstore.NewPlugin(p.Encryption)); err != nil {
+			return Stores{}, fmt.Errorf("install submission encryption: %w", err)
+		}
+	}
+
+	if p.Shards != nil {
+		return newShardedStores(p)
 	}
 
 	// Initialize repositories using the interface
-	userRepo := userstore.NewStore(p.DB, p.Logger)
-	formRepo := formstore.NewStore(p.DB, p.Logger)
-	formSubmissionRepo := formsubmissionstore.NewStore(p.DB, p.Logger)
+	return newStores(p,
+		userstore.NewStore(p.DB, p.Logger),
+		formstore.NewStore(p.DB, p.Logger),
+		formsubmissionstore.NewStore(p.DB, p.Logger),
+		reportstore.NewStore(p.DB, p.Logger),
+		usagestore.NewStore(p.DB, p.Logger),
+		viewstore.NewStore(p.DB, p.Logger),
+		activitystore.NewStore(p.DB, p.Logger),
+		emailtemplatestore.NewStore(p.DB, p.Logger),
+		datasetstore.NewStore(p.DB, p.Logger),
+		emaildeliverystore.NewStore(p.DB, p.Logger),
+		auditstore.NewStore(p.DB, p.Logger),
+		securityeventstore.NewStore(p.DB, p.Logger),
+		backupstore.NewStore(p.DB, p.Logger),
+		archivestore.NewStore(p.DB, p.Logger),
+		flagstore.NewStore(p.DB, p.Logger),
+		billingstore.NewStore(p.DB, p.Logger),
+		meteringstore.NewStore(p.DB, p.Logger),
+	)
+}
+
+// newShardedStores keeps submissions in the shards by form ID and everything else
+// in the primary database. The generic submission repository, which no service
+// uses, reads the primary only.
+func newShardedStores(p StoreParams) (Stores, error) {
+	if p.Encryption != nil {
+		for _, id := range p.Shards.IDs() {
+			if err := p.Shards.DB(id).GetDB().Use(encryptionstore.NewShardPlugin(p.Encryption, p.DB)); err != nil {
+				return Stores{}, fmt.Errorf("install submission encryption on shard %s: %w", id, err)
+			}
+		}
+	}
+
+	return newStores(p,
+		userstore.NewStore(p.DB, p.Logger),
+		formstore.NewShardedStore(p.DB, p.Shards, p.Logger),
+		formsubmissionstore.NewStore(p.DB, p.Logger),
+		reportstore.NewStore(p.DB, p.Logger),
+		usagestore.NewShardedStore(p.DB, p.Shards, p.Logger),
+		viewstore.NewStore(p.DB, p.Logger),
+		activitystore.NewStore(p.DB, p.Logger),
+		emailtemplatestore.NewStore(p.DB, p.Logger),
+		datasetstore.NewStore(p.DB, p.Logger),
+		emaildeliverystore.NewStore(p.DB, p.Logger),
+		auditstore.NewStore(p.DB, p.Logger),
+		securityeventstore.NewStore(p.DB, p.Logger),
+		backupstore.NewShardedStore(p.DB, p.Shards, p.Logger),
+		archivestore.NewShardedStore(p.Shards, p.Logger),
+		flagstore.NewStore(p.DB, p.Logger),
+		billingstore.NewStore(p.DB, p.Logger),
+		meteringstore.NewStore(p.DB, p.Logger),
+	)
+}
+
+// newMemoryStores creates in-memory stores for every repository
+func newMemoryStores(p StoreParams) (Stores, error) {
+	store := newSnapshotStore(p)
+
+	return newStores(p, store.Users(), store.Forms(), store.Submissions(), store.Reports(), store.Usage(), store.Views(),
+		store.Activities(), store.EmailTemplates(), store.Datasets(), store.EmailDeliveries(), store.Audit(), store.SecurityEvents(), store.Backups(),
+		store.Archive(), store.Flags(), store.Billing(), store.Metering())
+}
+
+// newMongoStores stores forms, submissions and schema revisions in MongoDB and keeps
+// the other repositories in memory, loaded from and saved to the snapshot if one is set
+func newMongoStores(p StoreParams) (Stores, error) {
+	documents, err := mongostore.NewStore(p.DatabaseConfig.MongoDBURI(), p.DatabaseConfig.Name, p.Logger)
+	if err != nil {
+		return Stores{}, fmt.Errorf("create mongodb store: %w", err)
+	}
+
+	if p.Lifecycle != nil {
+		p.Lifecycle.Append(fx.Hook{
+			OnStart: documents.Open,
+			OnStop:  documents.Close,
+		})
+	}
+
+	if p.Encryption != nil {
+		p.Logger.Warn("submission encryption does not apply to MongoDB; submissions are stored in plaintext")
+	}
+
+	store := newSnapshotStore(p)
+
+	return newStores(p, store.Users(), documents.Forms(), documents.Submissions(), store.Reports(), documents.Usage(), store.Views(),
+		store.Activities(), store.EmailTemplates(), store.Datasets(), store.EmailDeliveries(), store.Audit(), store.SecurityEvents(),
+		documents.Backups(), documents.Archive(), store.Flags(), store.Billing(), store.Metering())
+}
+
+// newSnapshotStore creates an in-memory store. With a snapshot path the data is loaded
+// from the snapshot on start and written back on shutdown.
+func newSnapshotStore(p StoreParams) *memorystore.Store {
+	store := memorystore.NewStore(p.Logger)
+
+	if path := p.DatabaseConfig.SnapshotPath; path != "" && p.Lifecycle != nil {
+		p.Lifecycle.Append(fx.Hook{
+			OnStart: func(_ context.Context) error {
+				return store.Load(path)
+			},
+			OnStop: func(_ context.Context) error {
+				return store.Save(path)
+			},
+		})
+	}
+
+	return store
+}
+
+// newStores wraps the form store with the form cache when enabled and validates the stores
+func newStores(
+	p StoreParams,
+	userRepo user.Repository,
+	formRepo form.Repository,
+	formSubmissionRepo form.SubmissionRepository,
+	reportRepo form.ReportRepository,
+	usageRepo form.UsageRepository,
+	viewRepo form.SubmissionViewRepository,
+	activityRepo form.SubmissionActivityRepository,
+	templateRepo emailtemplate.Repository,
+	datasetRepo dataset.Repository,
+	deliveryRepo emaildelivery.Repository,
+	auditRepo audit.Repository,
+	securityEventRepo securityevent.Repository,
+	backupRepo backup.Repository,
+	archiveRepo archive.Repository,
+	flagRepo flags.Repository,
+	billingRepo billing.Repository,
+	meteringRepo metering.Repository,
+) (Stores, error) {
+	batchRepo, _ := formRepo.(form.SubmissionBatchRepository)
+	rangeRepo, _ := formRepo.(form.SubmissionRangeRepository)
+	testRepo, _ := formRepo.(form.TestSubmissionRepository)
+	revisionRepo, _ := formRepo.(form.SchemaRevisionRepository)
+
+	if p.CacheConfig.Forms.Enabled && p.Cache != nil {
+		formCacheMetrics := metrics.NewCacheMetrics()
+		if p.Metrics != nil {
+			p.Metrics.Register("form_cache", formCacheMetrics)
+		}
+
+		cachedFormRepo, err := formstore.NewCachedStore(
+			formRepo, p.Cache, p.CacheConfig.Forms.TTL, p.EventBus, formCacheMetrics, p.Logger)
+		if err != nil {
+			return Stores{}, fmt.Errorf("create form cache: %w", err)
+		}
+
+		formRepo = cachedFormRepo
+	}
 
 	// Validate repository instances
-	if userRepo == nil || formRepo == nil || formSubmissionRepo == nil {
+	if userRepo == nil || formRepo == nil || formSubmissionRepo == nil ||
+		reportRepo == nil || usageRepo == nil || viewRepo == nil || activityRepo == nil || templateRepo == nil || deliveryRepo == nil ||
+		datasetRepo == nil || auditRepo == nil || securityEventRepo == nil || backupRepo == nil || archiveRepo == nil ||
+		flagRepo == nil || billingRepo == nil || meteringRepo == nil {
 		p.Logger.Error("failed to create repository",
 			"operation", "repository_initialization",
-			"repository_type", "user/form/submission",
+			"repository_type",
+			"user/form/submission/report/usage/view/activity/email_template/dataset/email_delivery/"+
+				"audit/security_event/backup/archive/feature_flag/billing/metering",
 			"error_type", "nil_repository",
 		)
 
@@ -113,10 +1672,28 @@ func NewStores(p StoreParams) (Stores, error) {
 	}
 
 	return Stores{
-		Out:                      fx.Out{},
-		UserRepository:           userRepo,
-		FormRepository:           formRepo,
-		FormSubmissionRepository: formSubmissionRepo,
+		Out:                       fx.Out{},
+		UserRepository:            userRepo,
+		FormRepository:            formRepo,
+		FormSubmissionRepository:  formSubmissionRepo,
+		ReportRepository:          reportRepo,
+		UsageRepository:           usageRepo,
+		SubmissionViewRepository:  viewRepo,
+		ActivityRepository:        activityRepo,
+		SubmissionBatchRepository: batchRepo,
+		SubmissionRangeRepository: rangeRepo,
+		TestSubmissionRepository:  testRepo,
+		SchemaRevisionRepository:  revisionRepo,
+		EmailTemplateRepository:   templateRepo,
+		DatasetRepository:         datasetRepo,
+		EmailDeliveryRepository:   deliveryRepo,
+		AuditRepository:           auditRepo,
+		SecurityEventRepository:   securityEventRepo,
+		BackupRepository:          backupRepo,
+		ArchiveRepository:         archiveRepo,
+		FlagRepository:            flagRepo,
+		BillingRepository:         billingRepo,
+		MeteringRepository:        meteringRepo,
 	}, nil
 }
 
@@ -133,6 +1710,114 @@ var Module = fx.Module("domain",
 			NewFormService,
 			fx.As(new(form.Service)),
 		),
+		// Plan quota service
+		fx.Annotate(
+			NewQuotaService,
+			fx.As(new(form.QuotaService)),
+		),
+		// Scheduled report service
+		fx.Annotate(
+			NewReportService,
+			fx.As(new(form.ReportService)),
+		),
+		// Scheduled report dispatcher that emails due submission digests
+		NewReportDispatcher,
+		// Weekly account health email for the users who opted in
+		NewHealthReporter,
+		// Submission tagging and saved view service
+		fx.Annotate(
+			NewTriageService,
+			fx.As(new(form.TriageService)),
+		),
+		// Submission status history, notes and webhook delivery service
+		fx.Annotate(
+			NewActivityService,
+			fx.As(new(form.ActivityService)),
+		),
+		// Form schema undo and redo history
+		fx.Annotate(
+			NewSchemaHistoryService,
+			fx.As(new(form.SchemaHistoryService)),
+		),
+		// Historical submission import service
+		fx.Annotate(
+			NewImportService,
+			fx.As(new(form.ImportService)),
+		),
+		// Migration of stored submissions to changed field keys and types
+		fx.Annotate(
+			NewSchemaMigrationService,
+			fx.As(new(form.SchemaMigrationService)),
+		),
+		// Transactional email template service
+		fx.Annotate(
+			NewEmailTemplateService,
+			fx.As(new(emailtemplate.Service)),
+		),
+		// Managed dataset service for option lists and computed fields
+		fx.Annotate(
+			NewDatasetService,
+			fx.As(new(dataset.Service)),
+		),
+		// Email suppression list and delivery tracking service
+		fx.Annotate(
+			NewEmailDeliveryService,
+			fx.As(new(emaildelivery.Service)),
+		),
+		// Audit log service
+		fx.Annotate(
+			NewAuditService,
+			fx.As(new(audit.Service)),
+		),
+		// Brute-force and anomaly detection service
+		fx.Annotate(
+			NewSecurityEventService,
+			fx.As(new(securityevent.Service)),
+		),
+		// Encrypted scheduled backup service; nil unless storage.backup.enabled is set
+		fx.Annotate(
+			NewBackupService,
+			fx.As(new(backup.Service)),
+		),
+		// Submission archive service; nil unless storage.archive.enabled is set
+		fx.Annotate(
+			NewArchiveService,
+			fx.As(new(archive.Service)),
+		),
+		// Stripe subscription billing service; nil unless billing.enabled is set
+		fx.Annotate(
+			NewBillingService,
+			fx.As(new(billing.Service)),
+		),
+		// API usage metering service; nil unless api.metering.enabled is set
+		fx.Annotate(
+			NewMeteringService,
+			fx.As(new(metering.Service)),
+		),
+		// Feature flag service, also provided as the flags.Client handlers and templates use
+		fx.Annotate(
+			NewFlagService,
+			fx.As(new(flags.Service)),
+			fx.As(new(flags.Client)),
+		),
+		// Form extension runner for compiled-in plugins and HTTP hooks
+		NewExtensionRunner,
+		// Stored domain events the event bus records into; nil unless events.store.enabled is set
+		NewEventLogRepository,
+		// Stored event listing, replay and pruning; nil unless events.store.enabled is set
+		NewEventLogService,
+		// Failed background work kept for retry; nil unless dead_letter.enabled is set
+		NewDeadLetterRepository,
+		NewDeadLetterService,
+		// Submission encryption at rest and key rotation; nil unless form.encryption.enabled is set
+		NewEncryptionService,
+		// Admin user management service
+		fx.Annotate(
+			NewUserAdminService,
+			fx.As(new(user.AdminService)),
+		),
+		// Account deletion service and the purge of accounts whose grace period ended
+		NewUserDeletionService,
 		NewStores,
 		// User ensurer (ensures Go user row exists for assertion-authenticated requests)
 		fx.Annotate(
@@ -140,4 +1825,6 @@ var Module = fx.Module("domain",
 			fx.As(new(user.UserEnsurer)),
 		),
 	),
+	// Purge of test submissions past their retention
+	fx.Invoke(RunTestSubmissionPurge),
 )
@@ -0,0 +1,126 @@
+package notifytemplate
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// errCallDisabled is returned when a template uses the call builtin
+var errCallDisabled = errors.New("call is not available in notification templates")
+
+// Funcs returns the functions available to notification templates, in
+// addition to the safe builtins (and, or, not, eq, len, index, printf...):
+//
+//	upper, lower, trim       change case or strip surrounding space
+//	truncate N S             S cut to N characters, with "..." when cut
+//	default FALLBACK V       V, or FALLBACK when V is empty
+//	join SEP LIST            the items of LIST joined with SEP
+//	replace OLD NEW S        S with every OLD replaced by NEW
+//	json V                   V encoded as JSON, for webhook payloads
+//	slack S                  S with Slack's control characters escaped
+//	date LAYOUT V            an RFC 3339 time formatted with a Go layout
+//
+// The call builtin is replaced by a function that fails.
+func Funcs() map[string]any {
+	return map[string]any{
+		"call":     func(...any) (any, error) { return nil, errCallDisabled },
+		"upper":    strings.ToUpper,
+		"lower":    strings.ToLower,
+		"trim":     strings.TrimSpace,
+		"truncate": truncate,
+		"default":  defaultValue,
+		"join":     join,
+		"replace":  func(old, replacement, s string) string { return strings.ReplaceAll(s, old, replacement) },
+		"json":     toJSON,
+		"slack":    slackEscape,
+		"date":     formatDate,
+	}
+}
+
+// truncate shortens s to n characters
+func truncate(n int, s string) string {
+	if n < 0 || utf8.RuneCountInString(s) <= n {
+		return s
+	}
+
+	runes := []rune(s)
+	if n <= 3 {
+		return string(runes[:n])
+	}
+
+	return string(runes[:n-3]) + "..."
+}
+
+// defaultValue returns fallback when value is empty
+func defaultValue(fallback, value any) any {
+	if value == nil {
+		return fallback
+	}
+
+	v := reflect.ValueOf(value)
+
+	switch v.Kind() {
+	case reflect.String, reflect.Slice, reflect.Map, reflect.Array:
+		if v.Len() == 0 {
+			return fallback
+		}
+	case reflect.Bool:
+		if !v.Bool() {
+			return fallback
+		}
+	default:
+	}
+
+	return value
+}
+
+// join joins the items of a list, formatting each with fmt
+func join(sep string, value any) string {
+	v := reflect.ValueOf(value)
+	if v.Kind() != reflect.Slice && v.Kind() != reflect.Array {
+		return fmt.Sprint(value)
+	}
+
+	parts := make([]string, v.Len())
+	for i := range parts {
+		parts[i] = fmt.Sprint(v.Index(i).Interface())
+	}
+
+	return strings.Join(parts, sep)
+}
+
+// toJSON encodes value as JSON
+func toJSON(value any) (string, error) {
+	encoded, err := json.Marshal(value)
+	if err != nil {
+		return "", fmt.Errorf("json: %w", err)
+	}
+
+	return string(encoded), nil
+}
+
+// slackEscape escapes the characters Slack treats as markup controls
+func slackEscape(s string) string {
+	return strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;").Replace(s)
+}
+
+// formatDate formats an RFC 3339 timestamp; other values are returned unchanged
+func formatDate(layout string, value any) string {
+	switch v := value.(type) {
+	case time.Time:
+		return v.Format(layout)
+	case string:
+		if t, err := time.Parse(time.RFC3339, v); err == nil {
+			return t.Format(layout)
+		}
+
+		return v
+	}
+
+	return fmt.Sprint(value)
+}
@@ -0,0 +1,256 @@
+// Package notifytemplate renders the payloads of submission notifications,
+// which form owners can customize: webhook bodies, Slack messages and email
+// bodies. Each channel selects its template engine. Webhook payloads and Slack
+// messages use text/template; email bodies use html/template, so values are
+// escaped for their context.
+//
+// Templates are untrusted input and get a restricted function set (see
+// Funcs). The call builtin is disabled, {{define}}, {{block}} and
+// {{template}} are rejected so a template cannot recurse, ranging over a
+// number literal is rejected, and rendered output is capped at
+// MaxOutputLength.
+package notifytemplate
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	htmltemplate "html/template"
+	"slices"
+	texttemplate "text/template"
+	"text/template/parse"
+)
+
+// Channel is a notification destination with its own payload format
+type Channel string
+
+const (
+	// ChannelWebhook templates render the JSON body POSTed to a webhook
+	ChannelWebhook Channel = "webhook"
+	// ChannelSlack templates render the text of a Slack message
+	ChannelSlack Channel = "slack"
+	// ChannelEmail templates render the HTML body of an email
+	ChannelEmail Channel = "email"
+)
+
+// Channels lists every channel
+var Channels = []Channel{ChannelWebhook, ChannelSlack, ChannelEmail}
+
+const (
+	// MaxSourceLength is the maximum length of a template
+	MaxSourceLength = 16 * 1024
+	// MaxOutputLength is the maximum length of a rendered payload
+	MaxOutputLength = 64 * 1024
+)
+
+var (
+	// ErrChannelInvalid is returned for an unknown channel
+	ErrChannelInvalid = errors.New("notification channel must be webhook, slack or email")
+
+	// ErrTemplateTooLong is returned for a template over MaxSourceLength
+	ErrTemplateTooLong = errors.New("notification templates must be at most 16 KiB")
+
+	// ErrOutputTooLong is returned when a rendered payload exceeds MaxOutputLength
+	ErrOutputTooLong = errors.New("rendered notification is larger than 64 KiB")
+
+	// ErrInvalidJSON is returned when a webhook template does not render valid JSON
+	ErrInvalidJSON = errors.New("webhook templates must render valid JSON")
+
+	// ErrNotAllowed is returned for templates that use a disabled construct
+	ErrNotAllowed = errors.New("template construct is not allowed")
+)
+
+// ParseChannel validates a channel
+func ParseChannel(value string) (Channel, error) {
+	channel := Channel(value)
+	if !slices.Contains(Channels, channel) {
+		return "", ErrChannelInvalid
+	}
+
+	return channel, nil
+}
+
+// ContentType returns the media type of the channel's rendered payloads
+func (c Channel) ContentType() string {
+	if c == ChannelEmail {
+		return "text/html; charset=utf-8"
+	}
+
+	return "application/json"
+}
+
+// Template is a parsed notification template
+type Template struct {
+	channel Channel
+	text    *texttemplate.Template
+	html    *htmltemplate.Template
+}
+
+// Parse parses source with the engine of channel
+func Parse(channel Channel, source string) (*Template, error) {
+	if _, err := ParseChannel(string(channel)); err != nil {
+		return nil, err
+	}
+
+	if len(source) > MaxSourceLength {
+		return nil, ErrTemplateTooLong
+	}
+
+	t := &Template{channel: channel}
+
+	var err error
+
+	if channel == ChannelEmail {
+		t.html, err = ParseHTML(string(channel), source)
+	} else {
+		t.text, err = ParseText(string(channel), source)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return t, nil
+}
+
+// Render executes the template with vars and returns the payload to send:
+// the JSON body for webhooks, a Slack message payload for Slack and the HTML
+// body for email
+func (t *Template) Render(vars map[string]any) (string, error) {
+	out := &limitedBuffer{limit: MaxOutputLength}
+
+	var err error
+	if t.html != nil {
+		err = t.html.Execute(out, vars)
+	} else {
+		err = t.text.Execute(out, vars)
+	}
+
+	if err != nil {
+		return "", fmt.Errorf("render %s template: %w", t.channel, err)
+	}
+
+	switch t.channel {
+	case ChannelWebhook:
+		if !json.Valid(out.Bytes()) {
+			return "", ErrInvalidJSON
+		}
+	case ChannelSlack:
+		payload, marshalErr := json.Marshal(map[string]string{"text": out.String()})
+		if marshalErr != nil {
+			return "", fmt.Errorf("encode slack message: %w", marshalErr)
+		}
+
+		return string(payload), nil
+	case ChannelEmail:
+	}
+
+	return out.String(), nil
+}
+
+// ParseText parses a text/template with the restricted function set. Missing
+// variables render as empty values.
+func ParseText(name, source string) (*texttemplate.Template, error) {
+	t, err := texttemplate.New(name).Option("missingkey=zero").Funcs(Funcs()).Parse(source)
+	if err != nil {
+		return nil, fmt.Errorf("parse %s: %w", name, err)
+	}
+
+	if err = check(name, len(t.Templates()), t.Tree); err != nil {
+		return nil, err
+	}
+
+	return t, nil
+}
+
+// ParseHTML parses an html/template with the restricted function set. Missing
+// variables render as empty values.
+func ParseHTML(name, source string) (*htmltemplate.Template, error) {
+	t, err := htmltemplate.New(name).Option("missingkey=zero").Funcs(Funcs()).Parse(source)
+	if err != nil {
+		return nil, fmt.Errorf("parse %s: %w", name, err)
+	}
+
+	if err = check(name, len(t.Templates()), t.Tree); err != nil {
+		return nil, err
+	}
+
+	return t, nil
+}
+
+// check rejects associated templates and the constructs that could make
+// rendering run unbounded
+func check(name string, templates int, tree *parse.Tree) error {
+	if templates > 1 {
+		return fmt.Errorf("%w in %s: define and block", ErrNotAllowed, name)
+	}
+
+	if tree == nil || tree.Root == nil {
+		return nil
+	}
+
+	return walk(name, tree.Root)
+}
+
+func walk(name string, node parse.Node) error {
+	switch node := node.(type) {
+	case *parse.ListNode:
+		if node == nil {
+			return nil
+		}
+
+		for _, child := range node.Nodes {
+			if err := walk(name, child); err != nil {
+				return err
+			}
+		}
+	case *parse.TemplateNode:
+		return fmt.Errorf("%w in %s: template", ErrNotAllowed, name)
+	case *parse.RangeNode:
+		if rangesOverNumber(node.Pipe) {
+			return fmt.Errorf("%w in %s: range over a number", ErrNotAllowed, name)
+		}
+
+		return walkBranch(name, &node.BranchNode)
+	case *parse.IfNode:
+		return walkBranch(name, &node.BranchNode)
+	case *parse.WithNode:
+		return walkBranch(name, &node.BranchNode)
+	}
+
+	return nil
+}
+
+func walkBranch(name string, node *parse.BranchNode) error {
+	if err := walk(name, node.List); err != nil {
+		return err
+	}
+
+	return walk(name, node.ElseList)
+}
+
+// rangesOverNumber reports whether a range pipeline is a number literal
+func rangesOverNumber(pipe *parse.PipeNode) bool {
+	if pipe == nil || len(pipe.Cmds) != 1 || len(pipe.Cmds[0].Args) != 1 {
+		return false
+	}
+
+	_, ok := pipe.Cmds[0].Args[0].(*parse.NumberNode)
+
+	return ok
+}
+
+// limitedBuffer is a buffer that fails writes beyond limit
+type limitedBuffer struct {
+	bytes.Buffer
+	limit int
+}
+
+func (b *limitedBuffer) Write(p []byte) (int, error) {
+	if b.Len()+len(p) > b.limit {
+		return 0, ErrOutputTooLong
+	}
+
+	return b.Buffer.Write(p)
+}
@@ -0,0 +1,106 @@
+package notifytemplate_test
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/goformx/goforms/internal/domain/form/model"
+	"github.com/goformx/goforms/internal/domain/notifytemplate"
+)
+
+func TestDefaults_Render(t *testing.T) {
+	vars := notifytemplate.SampleVariables(&model.Form{ID: "form-1", Title: "Contact <us>"}, time.UTC)
+
+	for _, channel := range notifytemplate.Channels {
+		t.Run(string(channel), func(t *testing.T) {
+			tmpl, err := notifytemplate.Parse(channel, notifytemplate.Default(channel))
+			require.NoError(t, err)
+
+			out, err := tmpl.Render(vars)
+			require.NoError(t, err)
+
+			switch channel {
+			case notifytemplate.ChannelWebhook:
+				var payload map[string]any
+				require.NoError(t, json.Unmarshal([]byte(out), &payload))
+				assert.Equal(t, "Contact <us>", payload["form_title"])
+				assert.Equal(t, "Ada Lovelace", payload["data"].(map[string]any)["name"])
+			case notifytemplate.ChannelSlack:
+				var payload map[string]string
+				require.NoError(t, json.Unmarshal([]byte(out), &payload))
+				assert.Equal(t, "*New submission for Contact &lt;us&gt;*\n*Name:* Ada Lovelace\n*Message:* Hello!", payload["text"])
+			case notifytemplate.ChannelEmail:
+				assert.Contains(t, out, "Contact &lt;us&gt; received a new submission on Thu, 15 Jan 2026 09:30 UTC")
+			}
+		})
+	}
+}
+
+func TestParse_Restrictions(t *testing.T) {
+	tests := []struct {
+		name    string
+		channel notifytemplate.Channel
+		source  string
+		err     error
+	}{
+		{name: "unknown channel", channel: "sms", source: "hi", err: notifytemplate.ErrChannelInvalid},
+		{name: "too long", channel: notifytemplate.ChannelSlack, source: strings.Repeat("x", 16*1024+1),
+			err: notifytemplate.ErrTemplateTooLong},
+		{name: "define", channel: notifytemplate.ChannelSlack, source: `{{define "x"}}{{template "x"}}{{end}}`,
+			err: notifytemplate.ErrNotAllowed},
+		{name: "template", channel: notifytemplate.ChannelEmail, source: `{{template "email"}}`, err: notifytemplate.ErrNotAllowed},
+		{name: "block", channel: notifytemplate.ChannelSlack, source: `{{block "x" .}}hi{{end}}`, err: notifytemplate.ErrNotAllowed},
+		{name: "range over number", channel: notifytemplate.ChannelSlack, source: `{{if true}}{{range 1000000000}}{{end}}{{end}}`,
+			err: notifytemplate.ErrNotAllowed},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := notifytemplate.Parse(tt.channel, tt.source)
+			require.ErrorIs(t, err, tt.err)
+		})
+	}
+}
+
+func TestRender_Errors(t *testing.T) {
+	vars := map[string]any{"Items": []any{"a", "b", "c", "d", "e", "f", "g", "h"}, "Fn": func() string { return "called" }}
+
+	tmpl, err := notifytemplate.Parse(notifytemplate.ChannelSlack, `{{call .Fn}}`)
+	require.NoError(t, err)
+
+	_, err = tmpl.Render(vars)
+	require.ErrorContains(t, err, "call is not available")
+
+	tmpl, err = notifytemplate.Parse(notifytemplate.ChannelWebhook, `{"items": {{join "," .Items}}}`)
+	require.NoError(t, err)
+
+	_, err = tmpl.Render(vars)
+	require.ErrorIs(t, err, notifytemplate.ErrInvalidJSON)
+
+	tmpl, err = notifytemplate.Parse(notifytemplate.ChannelSlack, `{{range .Items}}`+strings.Repeat("x", 10*1024)+`{{end}}`)
+	require.NoError(t, err)
+
+	_, err = tmpl.Render(vars)
+	require.ErrorIs(t, err, notifytemplate.ErrOutputTooLong)
+}
+
+func TestFuncs(t *testing.T) {
+	tmpl, err := notifytemplate.Parse(notifytemplate.ChannelWebhook,
+		`{"a": {{json (upper .Name)}}, "b": {{json (truncate 6 .Message)}}, "c": {{json (default "none" .Missing)}},`+
+			` "d": {{json (join ", " .Tags)}}, "e": {{json (date "2006-01-02" .At)}}, "f": {{json (replace "-" "" "a-b")}}}`)
+	require.NoError(t, err)
+
+	out, err := tmpl.Render(map[string]any{
+		"Name":    "ada",
+		"Message": "Hello there",
+		"Tags":    []any{"x", 1.5},
+		"At":      "2026-01-15T09:30:00Z",
+	})
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"a": "ADA", "b": "Hel...", "c": "none", "d": "x, 1.5", "e": "2026-01-15", "f": "ab"}`, out)
+}
@@ -0,0 +1,81 @@
+package notifytemplate
+
+import (
+	"time"
+
+	"github.com/goformx/goforms/internal/domain/form/model"
+)
+
+// defaults are the templates used when a form has none of its own
+var defaults = map[Channel]string{
+	ChannelWebhook: `{
+  "event": "submission.created",
+  "form_id": {{json .FormID}},
+  "form_title": {{json .FormTitle}},
+  "submission_id": {{json .SubmissionID}},
+  "submitted_at": {{json .SubmittedAt}},
+  "data": {{json .Data}}
+}`,
+	ChannelSlack: `*New submission for {{slack .FormTitle}}*
+{{- range .Fields}}
+*{{slack .Label}}:* {{slack .Value}}
+{{- end}}`,
+	ChannelEmail: `<p>{{.FormTitle}} received a new submission on {{date "Mon, 02 Jan 2006 15:04 MST" .SubmittedAt}}.</p>
+<table>
+{{- range .Fields}}
+<tr><th align="left">{{.Label}}</th><td>{{.Value}}</td></tr>
+{{- end}}
+</table>`,
+}
+
+// Default returns the built-in template of channel
+func Default(channel Channel) string {
+	return defaults[channel]
+}
+
+// Field is one answer of a submission, labelled from the form schema
+type Field struct {
+	Key   string `json:"key"`
+	Label string `json:"label"`
+	Value string `json:"value"`
+}
+
+// Variables returns the variables a notification about submission receives:
+// FormID, FormTitle, SubmissionID, SubmittedAt (RFC 3339 in loc), Timezone
+// (the name of loc), Data (the raw answers) and Fields (the answers formatted
+// for display)
+func Variables(form *model.Form, submission *model.FormSubmission, fields []Field, loc *time.Location) map[string]any {
+	vars := map[string]any{
+		"FormID":    form.ID,
+		"FormTitle": form.Title,
+		"Fields":    fields,
+		"Data":      map[string]any{},
+		"Timezone":  loc.String(),
+	}
+
+	if submission != nil {
+		vars["SubmissionID"] = submission.ID
+		vars["SubmittedAt"] = submission.SubmittedAt.In(loc).Format(time.RFC3339)
+
+		if submission.Data != nil {
+			vars["Data"] = map[string]any(submission.Data)
+		}
+	}
+
+	return vars
+}
+
+// SampleVariables returns example variables for previewing templates of form
+func SampleVariables(form *model.Form, loc *time.Location) map[string]any {
+	submission := &model.FormSubmission{
+		ID:          "7f9c2ba4-e88f-4f5b-9d1c-1a2b3c4d5e6f",
+		FormID:      form.ID,
+		SubmittedAt: time.Date(2026, time.January, 15, 9, 30, 0, 0, time.UTC),
+		Data:        model.JSON{"name": "Ada Lovelace", "message": "Hello!"},
+	}
+
+	return Variables(form, submission, []Field{
+		{Key: "name", Label: "Name", Value: "Ada Lovelace"},
+		{Key: "message", Label: "Message", Value: "Hello!"},
+	}, loc)
+}
@@ -0,0 +1,89 @@
+package securityevent
+
+import (
+	"sync"
+	"time"
+)
+
+// sweepInterval is how often counters without recent signals are dropped
+const sweepInterval = time.Minute
+
+// Rule is a detection threshold for one signal kind
+type Rule struct {
+	// Threshold is the number of signals within Window that trips the rule; zero disables it
+	Threshold int
+	Window    time.Duration
+	// Block blocks the client IP when the rule trips
+	Block bool
+}
+
+// counter holds the recent signal times for one kind and IP
+type counter struct {
+	hits   []time.Time
+	window time.Duration
+}
+
+// detector counts signals per kind and IP in sliding windows
+type detector struct {
+	mu        sync.Mutex
+	counters  map[string]*counter
+	lastSweep time.Time
+}
+
+func newDetector() *detector {
+	return &detector{counters: make(map[string]*counter)}
+}
+
+// observe counts a signal at now and reports whether it trips rule. A tripped
+// counter is reset, so a sustained attack is reported once per threshold.
+func (d *detector) observe(key string, rule Rule, now time.Time) (int, bool) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if now.Sub(d.lastSweep) >= sweepInterval {
+		d.sweep(now)
+	}
+
+	c, ok := d.counters[key]
+	if !ok {
+		c = &counter{window: rule.Window}
+		d.counters[key] = c
+	}
+
+	c.window = rule.Window
+	c.hits = append(c.prune(now), now)
+
+	count := len(c.hits)
+	if count < rule.Threshold {
+		return count, false
+	}
+
+	delete(d.counters, key)
+
+	return count, true
+}
+
+// sweep drops counters whose signals have all left their window
+func (d *detector) sweep(now time.Time) {
+	for key, c := range d.counters {
+		if len(c.prune(now)) == 0 {
+			delete(d.counters, key)
+		}
+	}
+
+	d.lastSweep = now
+}
+
+// prune returns the hits still inside the window at now
+func (c *counter) prune(now time.Time) []time.Time {
+	cutoff := now.Add(-c.window)
+
+	i := 0
+	for i < len(c.hits) && !c.hits[i].After(cutoff) {
+		i++
+	}
+
+	c.hits = c.hits[i:]
+
+	return c.hits
+}
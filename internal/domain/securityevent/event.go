@@ -0,0 +1,69 @@
+// Package securityevent detects brute-force and anomalous traffic. Middleware
+// reports signals such as failed logins, form submissions and CSRF failures;
+// when one client IP sends too many of a kind within a rule's window, a
+// security event is recorded and the IP can be blocked and admins notified.
+package securityevent
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// Signal kinds reported by middleware
+const (
+	// KindFailedLogin is a request whose credentials or signed assertion were rejected
+	KindFailedLogin = "failed_login"
+	// KindSubmissionBurst is an accepted public form submission
+	KindSubmissionBurst = "submission_burst"
+	// KindCSRFFailure is a request rejected for a missing or invalid CSRF token
+	KindCSRFFailure = "csrf_failure"
+)
+
+// Signal is one observation reported by middleware
+type Signal struct {
+	Kind      string
+	IPAddress string
+	// Subject identifies what the request targeted, such as a form ID or path
+	Subject string
+}
+
+// Event is a detected pattern: Count signals of Kind from IPAddress within the window
+type Event struct {
+	ID            string `gorm:"column:uuid;primaryKey;type:uuid;default:gen_random_uuid()" json:"id"`
+	Kind          string `gorm:"not null;size:50;index:idx_security_events_kind"             json:"kind"`
+	IPAddress     string `gorm:"not null;size:45;index:idx_security_events_ip"               json:"ip_address"`
+	Subject       string `gorm:"size:255"                                                     json:"subject,omitempty"`
+	Count         int    `gorm:"not null"                                                     json:"count"`
+	WindowSeconds int    `gorm:"not null"                                                     json:"window_seconds"`
+	// BlockedUntil is set when the event blocked the IP
+	BlockedUntil *time.Time `gorm:"index"                   json:"blocked_until,omitempty"`
+	CreatedAt    time.Time  `gorm:"not null;autoCreateTime" json:"created_at"`
+}
+
+// TableName specifies the table name for the Event model
+func (e *Event) TableName() string {
+	return "security_events"
+}
+
+// BeforeCreate is a GORM hook that runs before creating an event
+func (e *Event) BeforeCreate(_ *gorm.DB) error {
+	if e.ID == "" {
+		e.ID = uuid.New().String()
+	}
+
+	return nil
+}
+
+// Filter narrows a listing of events; empty fields match everything
+type Filter struct {
+	Kind      string
+	IPAddress string
+}
+
+// Matches reports whether event passes the filter
+func (f Filter) Matches(event *Event) bool {
+	return (f.Kind == "" || event.Kind == f.Kind) &&
+		(f.IPAddress == "" || event.IPAddress == f.IPAddress)
+}
@@ -0,0 +1,136 @@
+package securityevent
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/goformx/goforms/internal/infrastructure/logging"
+)
+
+// notifyTimeout bounds the time spent notifying about one event
+const notifyTimeout = 30 * time.Second
+
+// Repository defines the interface for security event storage
+type Repository interface {
+	CreateEvent(ctx context.Context, event *Event) error
+	// ListEvents lists matching events, newest first, with the total count
+	ListEvents(ctx context.Context, filter Filter, offset, limit int) ([]*Event, int64, error)
+}
+
+// Blocker temporarily blocks client IPs; the access middleware implements it
+type Blocker interface {
+	BlockIP(ip string, until time.Time)
+}
+
+// Notifier tells admins about a security event
+type Notifier interface {
+	Notify(ctx context.Context, event *Event) error
+}
+
+// Config holds the detection rules by signal kind
+type Config struct {
+	Rules         map[string]Rule
+	BlockDuration time.Duration
+	// ExemptIPs are recorded but never blocked
+	ExemptIPs []string
+}
+
+// Service defines the interface for security event detection
+type Service interface {
+	// Observe counts a signal, recording an event when it trips the signal's rule
+	Observe(ctx context.Context, signal Signal) error
+	// List lists matching events, newest first, with the total count
+	List(ctx context.Context, filter Filter, offset, limit int) ([]*Event, int64, error)
+}
+
+// service handles security event detection
+type service struct {
+	repository Repository
+	config     Config
+	blocker    Blocker
+	notifier   Notifier
+	detector   *detector
+	logger     logging.Logger
+	now        func() time.Time
+}
+
+// NewService creates a new security event service. blocker and notifier are
+// optional; without them events are only recorded.
+func NewService(repository Repository, cfg Config, blocker Blocker, notifier Notifier, logger logging.Logger) Service {
+	return &service{
+		repository: repository,
+		config:     cfg,
+		blocker:    blocker,
+		notifier:   notifier,
+		detector:   newDetector(),
+		logger:     logger,
+		now:        time.Now,
+	}
+}
+
+// Observe counts a signal against its rule. When the rule trips, the event is
+// recorded, the IP is blocked if the rule blocks, and admins are notified.
+func (s *service) Observe(ctx context.Context, signal Signal) error {
+	rule, ok := s.config.Rules[signal.Kind]
+	if !ok || rule.Threshold <= 0 || signal.IPAddress == "" {
+		return nil
+	}
+
+	now := s.now()
+
+	count, tripped := s.detector.observe(signal.Kind+"|"+signal.IPAddress, rule, now)
+	if !tripped {
+		return nil
+	}
+
+	event := &Event{
+		Kind:          signal.Kind,
+		IPAddress:     signal.IPAddress,
+		Subject:       signal.Subject,
+		Count:         count,
+		WindowSeconds: int(rule.Window.Seconds()),
+		CreatedAt:     now.UTC(),
+	}
+
+	if rule.Block && s.blocker != nil && !slices.Contains(s.config.ExemptIPs, signal.IPAddress) {
+		until := now.Add(s.config.BlockDuration).UTC()
+		s.blocker.BlockIP(signal.IPAddress, until)
+		event.BlockedUntil = &until
+	}
+
+	s.logger.Warn("security event detected",
+		"kind", event.Kind, "ip_address", event.IPAddress, "count", event.Count,
+		"window_seconds", event.WindowSeconds, "blocked", event.BlockedUntil != nil)
+
+	if err := s.repository.CreateEvent(ctx, event); err != nil {
+		return fmt.Errorf("record security event: %w", err)
+	}
+
+	if s.notifier != nil {
+		go s.notify(event)
+	}
+
+	return nil
+}
+
+// notify sends the event to the notifier without holding up the request
+func (s *service) notify(event *Event) {
+	ctx, cancel := context.WithTimeout(context.Background(), notifyTimeout)
+	defer cancel()
+
+	if err := s.notifier.Notify(ctx, event); err != nil {
+		s.logger.Error("failed to notify security event", "event_id", event.ID, "kind", event.Kind, "error", err)
+	}
+}
+
+// List lists matching security events, newest first
+func (s *service) List(ctx context.Context, filter Filter, offset, limit int) ([]*Event, int64, error) {
+	events, total, err := s.repository.ListEvents(ctx, filter, offset, limit)
+	if err != nil {
+		return nil, 0, fmt.Errorf("list security events: %w", err)
+	}
+
+	return events, total, nil
+}
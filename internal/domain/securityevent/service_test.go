@@ -0,0 +1,111 @@
+package securityevent_test
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/goformx/goforms/internal/domain/securityevent"
+	memorystore "github.com/goformx/goforms/internal/infrastructure/repository/memory"
+	mocklogging "github.com/goformx/goforms/test/mocks/logging"
+)
+
+type recordingBlocker struct {
+	mu      sync.Mutex
+	blocked map[string]time.Time
+}
+
+func (b *recordingBlocker) BlockIP(ip string, until time.Time) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.blocked[ip] = until
+}
+
+type channelNotifier chan *securityevent.Event
+
+func (n channelNotifier) Notify(_ context.Context, event *securityevent.Event) error {
+	n <- event
+
+	return nil
+}
+
+func TestService_Observe(t *testing.T) {
+	ctx := context.Background()
+
+	logger := mocklogging.NewMockLogger(gomock.NewController(t))
+	logger.EXPECT().Warn(gomock.Any(), gomock.Any()).AnyTimes()
+
+	repo := memorystore.NewStore(logger).SecurityEvents()
+	blocker := &recordingBlocker{blocked: make(map[string]time.Time)}
+	notifier := make(channelNotifier, 4)
+
+	svc := securityevent.NewService(repo, securityevent.Config{
+		Rules: map[string]securityevent.Rule{
+			securityevent.KindFailedLogin: {Threshold: 3, Window: time.Minute, Block: true},
+			securityevent.KindCSRFFailure: {Threshold: 2, Window: time.Minute},
+		},
+		BlockDuration: 10 * time.Minute,
+		ExemptIPs:     []string{"10.0.0.1"},
+	}, blocker, notifier, logger)
+
+	observe := func(kind, ip string, times int) {
+		for range times {
+			require.NoError(t, svc.Observe(ctx, securityevent.Signal{Kind: kind, IPAddress: ip, Subject: "/api/forms"}))
+		}
+	}
+
+	// Below the threshold nothing is recorded
+	observe(securityevent.KindFailedLogin, "203.0.113.7", 2)
+	_, total, err := svc.List(ctx, securityevent.Filter{}, 0, 10)
+	require.NoError(t, err)
+	assert.Zero(t, total)
+
+	// The third failure trips the rule and blocks the IP
+	observe(securityevent.KindFailedLogin, "203.0.113.7", 1)
+
+	events, total, err := svc.List(ctx, securityevent.Filter{IPAddress: "203.0.113.7"}, 0, 10)
+	require.NoError(t, err)
+	require.Equal(t, int64(1), total)
+	assert.Equal(t, securityevent.KindFailedLogin, events[0].Kind)
+	assert.Equal(t, 3, events[0].Count)
+	assert.Equal(t, 60, events[0].WindowSeconds)
+	require.NotNil(t, events[0].BlockedUntil)
+	assert.Contains(t, blocker.blocked, "203.0.113.7")
+
+	select {
+	case event := <-notifier:
+		assert.Equal(t, "203.0.113.7", event.IPAddress)
+	case <-time.After(time.Second):
+		t.Fatal("security event was not notified")
+	}
+
+	// The counter restarts after an event
+	observe(securityevent.KindFailedLogin, "203.0.113.7", 2)
+	_, total, err = svc.List(ctx, securityevent.Filter{}, 0, 10)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), total)
+
+	// Exempt IPs are recorded but not blocked
+	observe(securityevent.KindFailedLogin, "10.0.0.1", 3)
+	events, _, err = svc.List(ctx, securityevent.Filter{IPAddress: "10.0.0.1"}, 0, 10)
+	require.NoError(t, err)
+	require.Len(t, events, 1)
+	assert.Nil(t, events[0].BlockedUntil)
+	assert.NotContains(t, blocker.blocked, "10.0.0.1")
+
+	// Rules without Block only record; kinds without a rule are ignored
+	observe(securityevent.KindCSRFFailure, "198.51.100.2", 2)
+	observe(securityevent.KindSubmissionBurst, "198.51.100.2", 100)
+
+	events, _, err = svc.List(ctx, securityevent.Filter{IPAddress: "198.51.100.2"}, 0, 10)
+	require.NoError(t, err)
+	require.Len(t, events, 1)
+	assert.Equal(t, securityevent.KindCSRFFailure, events[0].Kind)
+	assert.Nil(t, events[0].BlockedUntil)
+}
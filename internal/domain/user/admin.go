@@ -0,0 +1,254 @@
+package user
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/goformx/goforms/internal/domain/audit"
+	domainerrors "github.com/goformx/goforms/internal/domain/common/errors"
+	"github.com/goformx/goforms/internal/domain/entities"
+	"github.com/goformx/goforms/internal/infrastructure/logging"
+)
+
+// Roles a user can hold
+const (
+	RoleUser  = "user"
+	RoleAdmin = "admin"
+)
+
+// ActivityResolution is how stale a user's last activity may get before a
+// request records it again, so activity tracking costs at most one write per
+// user every few minutes
+const ActivityResolution = 5 * time.Minute
+
+var (
+	// ErrRoleInvalid is returned for a role other than RoleUser and RoleAdmin
+	ErrRoleInvalid = domainerrors.New(domainerrors.ErrCodeValidation, "role must be user or admin", nil)
+	// ErrSelfModification is returned when an admin changes their own role or status
+	ErrSelfModification = domainerrors.New(domainerrors.ErrCodeForbidden,
+		"admins cannot change their own role or status", nil)
+	// ErrAccountDisabled is returned when a disabled user signs in or makes a request
+	ErrAccountDisabled = domainerrors.New(domainerrors.ErrCodeUserDisabled, "account is disabled", nil)
+	// ErrImpersonationNotAllowed is returned when impersonating oneself, an admin or a disabled user
+	ErrImpersonationNotAllowed = domainerrors.New(domainerrors.ErrCodeForbidden,
+		"only active users other than admins can be impersonated", nil)
+	// ErrPasswordResetRequired is returned on password login until the user sets a new password
+	ErrPasswordResetRequired = domainerrors.New(domainerrors.ErrCodeAuthentication, "password reset required", nil)
+)
+
+// Filter narrows the admin user listing; empty fields match everything
+type Filter struct {
+	// Query matches the email, first name or last name, ignoring case
+	Query  string
+	Role   string
+	Active *bool
+}
+
+// DirectoryRepository is implemented by user stores that support the admin
+// user directory and activity tracking
+type DirectoryRepository interface {
+	// SearchUsers lists matching users, newest first, with the total count
+	SearchUsers(ctx context.Context, filter Filter, offset, limit int) ([]*entities.User, int64, error)
+	// TouchLastActive sets a user's last activity without changing UpdatedAt
+	TouchLastActive(ctx context.Context, id string, at time.Time) error
+}
+
+// Actor identifies the admin performing a change, for the audit log
+type Actor struct {
+	ID        string
+	IPAddress string
+}
+
+// AdminService defines the interface for administering user accounts. Every
+// change is recorded in the audit log.
+type AdminService interface {
+	ListUsers(ctx context.Context, filter Filter, offset, limit int) ([]*entities.User, int64, error)
+	GetUser(ctx context.Context, id string) (*entities.User, error)
+	// SetRole changes a user's role
+	SetRole(ctx context.Context, actor Actor, id, role string) (*entities.User, error)
+	// SetActive disables or re-enables a user's account
+	SetActive(ctx context.Context, actor Actor, id string, active bool) (*entities.User, error)
+	// RequirePasswordReset blocks password login until the user sets a new password
+	RequirePasswordReset(ctx context.Context, actor Actor, id string) (*entities.User, error)
+	// StartImpersonation checks that the actor may act as a user and records that they do
+	StartImpersonation(ctx context.Context, actor Actor, id string, details map[string]any) (*entities.User, error)
+	// EndImpersonation records the end of an impersonation session
+	EndImpersonation(ctx context.Context, actor Actor, id string, details map[string]any)
+}
+
+// adminService handles user administration business logic
+type adminService struct {
+	repo      Repository
+	directory DirectoryRepository
+	audit     audit.Service
+	logger    logging.Logger
+}
+
+// NewAdminService creates a new user administration service
+func NewAdminService(
+	repo Repository,
+	directory DirectoryRepository,
+	auditService audit.Service,
+	logger logging.Logger,
+) AdminService {
+	return &adminService{
+		repo:      repo,
+		directory: directory,
+		audit:     auditService,
+		logger:    logger,
+	}
+}
+
+// ListUsers lists matching users, newest first
+func (s *adminService) ListUsers(ctx context.Context, filter Filter, offset, limit int) ([]*entities.User, int64, error) {
+	if filter.Role != "" && !IsValidRole(filter.Role) {
+		return nil, 0, ErrRoleInvalid
+	}
+
+	users, total, err := s.directory.SearchUsers(ctx, filter, offset, limit)
+	if err != nil {
+		return nil, 0, fmt.Errorf("search users: %w", err)
+	}
+
+	return users, total, nil
+}
+
+// GetUser retrieves a user by ID
+func (s *adminService) GetUser(ctx context.Context, id string) (*entities.User, error) {
+	u, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("get user: %w", err)
+	}
+
+	return u, nil
+}
+
+// SetRole changes a user's role; setting the current role is a no-op
+func (s *adminService) SetRole(ctx context.Context, actor Actor, id, role string) (*entities.User, error) {
+	if !IsValidRole(role) {
+		return nil, ErrRoleInvalid
+	}
+
+	if actor.ID == id {
+		return nil, ErrSelfModification
+	}
+
+	u, err := s.GetUser(ctx, id)
+	if err != nil || u.Role == role {
+		return u, err
+	}
+
+	previous := u.Role
+	u.Role = role
+
+	return u, s.save(ctx, actor, u, audit.ActionUserRoleChanged, map[string]any{"before": previous, "after": role})
+}
+
+// SetActive disables or re-enables a user's account; setting the current status is a no-op
+func (s *adminService) SetActive(ctx context.Context, actor Actor, id string, active bool) (*entities.User, error) {
+	if actor.ID == id {
+		return nil, ErrSelfModification
+	}
+
+	u, err := s.GetUser(ctx, id)
+	if err != nil || u.Active == active {
+		return u, err
+	}
+
+	action := audit.ActionUserDisabled
+	if active {
+		action = audit.ActionUserEnabled
+		u.Activate()
+	} else {
+		u.Deactivate()
+	}
+
+	return u, s.save(ctx, actor, u, action, map[string]any{"before": !active, "after": active})
+}
+
+// RequirePasswordReset flags a user to set a new password before the next password login
+func (s *adminService) RequirePasswordReset(ctx context.Context, actor Actor, id string) (*entities.User, error) {
+	u, err := s.GetUser(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	u.PasswordResetRequired = true
+
+	return u, s.save(ctx, actor, u, audit.ActionUserPasswordReset, nil)
+}
+
+// StartImpersonation returns the user an admin is about to act as. Admins and
+// disabled users cannot be impersonated, so an impersonation never escalates.
+func (s *adminService) StartImpersonation(
+	ctx context.Context, actor Actor, id string, details map[string]any,
+) (*entities.User, error) {
+	if actor.ID == id {
+		return nil, ErrImpersonationNotAllowed
+	}
+
+	u, err := s.GetUser(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if u.Role == RoleAdmin || !u.Active {
+		return nil, ErrImpersonationNotAllowed
+	}
+
+	s.record(ctx, actor, u.ID, audit.ActionImpersonationStarted, details)
+
+	return u, nil
+}
+
+// EndImpersonation records the end of an impersonation session
+func (s *adminService) EndImpersonation(ctx context.Context, actor Actor, id string, details map[string]any) {
+	s.record(ctx, actor, id, audit.ActionImpersonationEnded, details)
+}
+
+// save stores a changed user and records the change in the audit log
+func (s *adminService) save(ctx context.Context, actor Actor, u *entities.User, action string, details map[string]any) error {
+	if err := s.repo.Update(ctx, u); err != nil {
+		return fmt.Errorf("update user: %w", err)
+	}
+
+	s.record(ctx, actor, u.ID, action, details)
+
+	return nil
+}
+
+// record writes an audit entry about a user. The action has already happened, so
+// a lost entry is logged rather than reported as a failure.
+func (s *adminService) record(ctx context.Context, actor Actor, id, action string, details map[string]any) {
+	err := s.audit.Record(ctx, &audit.Entry{
+		ActorID:    actor.ID,
+		Action:     action,
+		TargetType: audit.TargetUser,
+		TargetID:   id,
+		Details:    details,
+		IPAddress:  actor.IPAddress,
+	})
+	if err != nil {
+		s.logger.Error("failed to record user administration", "action", action, "user_id", id, "error", err)
+	}
+}
+
+// IsValidRole reports whether role is a role a user can hold
+func IsValidRole(role string) bool {
+	return role == RoleUser || role == RoleAdmin
+}
+
+// CheckCanSignIn returns ErrAccountDisabled for a disabled user
+func CheckCanSignIn(u *entities.User) error {
+	if !u.Active {
+		return ErrAccountDisabled
+	}
+
+	return nil
+}
+
+// ActivityStale reports whether u's last activity should be recorded again at now
+func ActivityStale(u *entities.User, now time.Time) bool {
+	return u.LastActiveAt == nil || now.Sub(*u.LastActiveAt) >= ActivityResolution
+}
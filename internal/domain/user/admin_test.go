@@ -0,0 +1,144 @@
+package user_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/goformx/goforms/internal/domain/audit"
+	"github.com/goformx/goforms/internal/domain/entities"
+	"github.com/goformx/goforms/internal/domain/user"
+	memorystore "github.com/goformx/goforms/internal/infrastructure/repository/memory"
+	mocklogging "github.com/goformx/goforms/test/mocks/logging"
+)
+
+func newTestAdminService(t *testing.T) (user.AdminService, audit.Service, *entities.User) {
+	t.Helper()
+
+	logger := mocklogging.NewMockLogger(gomock.NewController(t))
+	logger.EXPECT().Info(gomock.Any(), gomock.Any()).AnyTimes()
+
+	store := memorystore.NewStore(logger)
+	users := store.Users()
+	directory, ok := users.(user.DirectoryRepository)
+	require.True(t, ok)
+
+	for _, email := range []string{"ada@example.com", "grace@example.com"} {
+		u, err := entities.NewUser(email, "password123", "Test", "User")
+		require.NoError(t, err)
+		require.NoError(t, users.Create(context.Background(), u))
+	}
+
+	target, err := users.GetByEmail(context.Background(), "grace@example.com")
+	require.NoError(t, err)
+
+	auditService := audit.NewService(store.Audit(), logger)
+
+	return user.NewAdminService(users, directory, auditService, logger), auditService, target
+}
+
+func TestAdminService_ListUsers(t *testing.T) {
+	ctx := context.Background()
+	svc, _, target := newTestAdminService(t)
+
+	users, total, err := svc.ListUsers(ctx, user.Filter{Query: "GRACE"}, 0, 10)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), total)
+	assert.Equal(t, target.ID, users[0].ID)
+
+	inactive := false
+	_, total, err = svc.ListUsers(ctx, user.Filter{Active: &inactive}, 0, 10)
+	require.NoError(t, err)
+	assert.Zero(t, total)
+
+	_, _, err = svc.ListUsers(ctx, user.Filter{Role: "owner"}, 0, 10)
+	require.ErrorIs(t, err, user.ErrRoleInvalid)
+}
+
+func TestAdminService_Changes(t *testing.T) {
+	ctx := context.Background()
+	svc, auditService, target := newTestAdminService(t)
+	actor := user.Actor{ID: "admin-1", IPAddress: "203.0.113.7"}
+
+	u, err := svc.SetRole(ctx, actor, target.ID, user.RoleAdmin)
+	require.NoError(t, err)
+	assert.Equal(t, user.RoleAdmin, u.Role)
+
+	_, err = svc.SetRole(ctx, actor, target.ID, "owner")
+	require.ErrorIs(t, err, user.ErrRoleInvalid)
+
+	u, err = svc.SetActive(ctx, actor, target.ID, false)
+	require.NoError(t, err)
+	assert.False(t, u.Active)
+
+	// Unchanged values are not audited
+	_, err = svc.SetActive(ctx, actor, target.ID, false)
+	require.NoError(t, err)
+
+	u, err = svc.RequirePasswordReset(ctx, actor, target.ID)
+	require.NoError(t, err)
+	assert.True(t, u.PasswordResetRequired)
+
+	stored, err := svc.GetUser(ctx, target.ID)
+	require.NoError(t, err)
+	assert.False(t, stored.Active)
+	assert.True(t, stored.PasswordResetRequired)
+
+	// Admins cannot lock themselves out
+	_, err = svc.SetActive(ctx, user.Actor{ID: target.ID}, target.ID, true)
+	require.ErrorIs(t, err, user.ErrSelfModification)
+
+	entries, total, err := auditService.List(ctx, audit.Filter{TargetID: target.ID}, 0, 10)
+	require.NoError(t, err)
+	require.Equal(t, int64(3), total)
+
+	actions := make([]string, 0, len(entries))
+	for _, entry := range entries {
+		actions = append(actions, entry.Action)
+		assert.Equal(t, "admin-1", entry.ActorID)
+		assert.Equal(t, "203.0.113.7", entry.IPAddress)
+	}
+
+	assert.ElementsMatch(t,
+		[]string{audit.ActionUserRoleChanged, audit.ActionUserDisabled, audit.ActionUserPasswordReset}, actions)
+}
+
+func TestAdminService_Impersonation(t *testing.T) {
+	ctx := context.Background()
+	svc, auditService, target := newTestAdminService(t)
+	actor := user.Actor{ID: "admin-1"}
+
+	u, err := svc.StartImpersonation(ctx, actor, target.ID, nil)
+	require.NoError(t, err)
+	assert.Equal(t, target.ID, u.ID)
+
+	svc.EndImpersonation(ctx, actor, target.ID, map[string]any{"restored": true})
+
+	_, err = svc.StartImpersonation(ctx, user.Actor{ID: target.ID}, target.ID, nil)
+	require.ErrorIs(t, err, user.ErrImpersonationNotAllowed)
+
+	_, err = svc.SetActive(ctx, actor, target.ID, false)
+	require.NoError(t, err)
+
+	_, err = svc.StartImpersonation(ctx, actor, target.ID, nil)
+	require.ErrorIs(t, err, user.ErrImpersonationNotAllowed)
+
+	_, err = svc.SetActive(ctx, actor, target.ID, true)
+	require.NoError(t, err)
+	_, err = svc.SetRole(ctx, actor, target.ID, user.RoleAdmin)
+	require.NoError(t, err)
+
+	_, err = svc.StartImpersonation(ctx, actor, target.ID, nil)
+	require.ErrorIs(t, err, user.ErrImpersonationNotAllowed)
+
+	_, total, err := auditService.List(ctx, audit.Filter{TargetID: target.ID, Action: audit.ActionImpersonationStarted}, 0, 10)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), total)
+
+	_, total, err = auditService.List(ctx, audit.Filter{TargetID: target.ID, Action: audit.ActionImpersonationEnded}, 0, 10)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), total)
+}
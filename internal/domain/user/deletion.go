@@ -0,0 +1,369 @@
+package user
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/goformx/goforms/internal/domain/audit"
+	"github.com/goformx/goforms/internal/domain/common/events"
+	"github.com/goformx/goforms/internal/domain/entities"
+	formdomain "github.com/goformx/goforms/internal/domain/form"
+	"github.com/goformx/goforms/internal/domain/form/model"
+	"github.com/goformx/goforms/internal/infrastructure/logging"
+	"github.com/goformx/goforms/internal/infrastructure/pii"
+)
+
+// Account deletion events published on the event bus; the payload is a *Deletion
+const (
+	DeletionRequestedEventType = "user.deletion_requested"
+	DeletionCancelledEventType = "user.deletion_cancelled"
+	DeletedEventType           = "user.deleted"
+)
+
+// purgeBatchSize bounds the accounts purged by one run of PurgeDue
+const purgeBatchSize = 100
+
+// deletedEmailDomain is the email domain of an anonymized account; .invalid never resolves
+const deletedEmailDomain = "@deleted.invalid"
+
+// DeletionRepository is implemented by user stores that support account deletion
+type DeletionRepository interface {
+	// ListDeletionsDue lists up to limit users whose deletion is scheduled at or
+	// before now, earliest first
+	ListDeletionsDue(ctx context.Context, now time.Time, limit int) ([]*entities.User, error)
+	// Purge removes a user's row for good, including a soft-deleted one
+	Purge(ctx context.Context, id string) error
+}
+
+// DeletionForms is the form storage an account deletion works through
+type DeletionForms interface {
+	ListForms(ctx context.Context, userID string) ([]*model.Form, error)
+	UpdateForm(ctx context.Context, form *model.Form) error
+	DeleteForm(ctx context.Context, formID string) error
+	ListSubmissions(ctx context.Context, formID string) ([]*model.FormSubmission, error)
+	UpdateSubmission(ctx context.Context, submission *model.FormSubmission) error
+	DeleteSubmission(ctx context.Context, id string) error
+}
+
+// DeletionOptions configures account deletion
+type DeletionOptions struct {
+	// GracePeriod is how long an owner can cancel the deletion of their account
+	GracePeriod time.Duration
+	// AnonymizeSubmissions keeps a purged account's forms and submissions with
+	// their personal data removed; otherwise they are deleted with the account
+	AnonymizeSubmissions bool
+}
+
+// Deletion describes an account deletion, as published with its events
+type Deletion struct {
+	UserID string `json:"user_id"`
+	// ActorID is the user or admin who asked for the deletion
+	ActorID     string     `json:"actor_id"`
+	RequestedAt *time.Time `json:"requested_at,omitempty"`
+	ScheduledAt *time.Time `json:"scheduled_at,omitempty"`
+	// Forms and Submissions count what the deletion removed or anonymized
+	Forms       int `json:"forms"`
+	Submissions int `json:"submissions"`
+	// Anonymized reports that the forms and submissions were kept with their
+	// personal data removed rather than deleted
+	Anonymized bool `json:"anonymized"`
+}
+
+// DeletionEvent is published on the event bus as an account deletion is
+// requested, cancelled and carried out
+type DeletionEvent struct {
+	events.BaseEvent
+	deletion *Deletion
+}
+
+// NewDeletionEvent creates an account deletion event
+func NewDeletionEvent(eventType string, deletion *Deletion) *DeletionEvent {
+	return &DeletionEvent{BaseEvent: events.NewBaseEvent(eventType), deletion: deletion}
+}
+
+// Payload returns the deletion
+func (e *DeletionEvent) Payload() any {
+	return e.deletion
+}
+
+// DeletionService deletes user accounts. An owner's deletion of their own
+// account waits out a grace period in which it can be cancelled, and is then
+// carried out by PurgeDue; an admin deletion is carried out at once. Every
+// step is recorded in the audit log and published on the event bus.
+type DeletionService interface {
+	// RequestDeletion schedules the deletion of the actor's own account;
+	// requesting a pending deletion again is a no-op
+	RequestDeletion(ctx context.Context, actor Actor) (*entities.User, error)
+	// CancelDeletion cancels the pending deletion of the actor's own account;
+	// without one it is a no-op
+	CancelDeletion(ctx context.Context, actor Actor) (*entities.User, error)
+	// DeleteUser deletes an account with its forms and submissions at once
+	DeleteUser(ctx context.Context, actor Actor, id string) (*Deletion, error)
+	// PurgeDue carries out the deletions whose grace period has ended
+	PurgeDue(ctx context.Context) error
+}
+
+// deletionService handles account deletion business logic
+type deletionService struct {
+	repo       Repository
+	deletions  DeletionRepository
+	forms      DeletionForms
+	audit      audit.Service
+	publisher  events.Publisher
+	classifier *pii.Classifier
+	options    DeletionOptions
+	logger     logging.Logger
+	now        func() time.Time
+}
+
+// NewDeletionService creates a new account deletion service
+func NewDeletionService(
+	repo Repository,
+	deletions DeletionRepository,
+	forms DeletionForms,
+	auditService audit.Service,
+	publisher events.Publisher,
+	classifier *pii.Classifier,
+	options DeletionOptions,
+	logger logging.Logger,
+) DeletionService {
+	return &deletionService{
+		repo:       repo,
+		deletions:  deletions,
+		forms:      forms,
+		audit:      auditService,
+		publisher:  publisher,
+		classifier: classifier,
+		options:    options,
+		logger:     logger,
+		now:        time.Now,
+	}
+}
+
+// RequestDeletion schedules the deletion of the actor's account for the end of the grace period
+func (s *deletionService) RequestDeletion(ctx context.Context, actor Actor) (*entities.User, error) {
+	u, err := s.repo.GetByID(ctx, actor.ID)
+	if err != nil {
+		return nil, fmt.Errorf("get user: %w", err)
+	}
+
+	if u.DeletionPending() {
+		return u, nil
+	}
+
+	now := s.now().UTC()
+	scheduled := now.Add(s.options.GracePeriod)
+	u.DeletionRequestedAt, u.DeletionScheduledAt = &now, &scheduled
+
+	if err = s.repo.Update(ctx, u); err != nil {
+		return nil, fmt.Errorf("update user: %w", err)
+	}
+
+	s.logger.Info("account deletion requested", "user_id", u.ID, "scheduled_at", scheduled)
+	s.report(ctx, actor, DeletionRequestedEventType, audit.ActionUserDeletionRequested, &Deletion{
+		UserID: u.ID, ActorID: actor.ID, RequestedAt: &now, ScheduledAt: &scheduled,
+	})
+
+	return u, nil
+}
+
+// CancelDeletion clears the pending deletion of the actor's account
+func (s *deletionService) CancelDeletion(ctx context.Context, actor Actor) (*entities.User, error) {
+	u, err := s.repo.GetByID(ctx, actor.ID)
+	if err != nil {
+		return nil, fmt.Errorf("get user: %w", err)
+	}
+
+	if !u.DeletionPending() {
+		return u, nil
+	}
+
+	deletion := &Deletion{UserID: u.ID, ActorID: actor.ID, RequestedAt: u.DeletionRequestedAt, ScheduledAt: u.DeletionScheduledAt}
+	u.DeletionRequestedAt, u.DeletionScheduledAt = nil, nil
+
+	if err = s.repo.Update(ctx, u); err != nil {
+		return nil, fmt.Errorf("update user: %w", err)
+	}
+
+	s.logger.Info("account deletion cancelled", "user_id", u.ID)
+	s.report(ctx, actor, DeletionCancelledEventType, audit.ActionUserDeletionCancelled, deletion)
+
+	return u, nil
+}
+
+// DeleteUser deletes an account at once, whether or not a deletion is pending.
+// Its forms and submissions are deleted; anonymization only applies to purges.
+func (s *deletionService) DeleteUser(ctx context.Context, actor Actor, id string) (*Deletion, error) {
+	if actor.ID == id {
+		return nil, ErrSelfModification
+	}
+
+	u, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("get user: %w", err)
+	}
+
+	deletion, err := s.purge(ctx, actor, u, false)
+	if err != nil {
+		return nil, err
+	}
+
+	return deletion, nil
+}
+
+// PurgeDue deletes the accounts whose grace period has ended under the
+// configured submissions policy. A failed account is logged and retried on
+// the next run; the others are still purged.
+func (s *deletionService) PurgeDue(ctx context.Context) error {
+	due, err := s.deletions.ListDeletionsDue(ctx, s.now(), purgeBatchSize)
+	if err != nil {
+		return fmt.Errorf("list accounts due for deletion: %w", err)
+	}
+
+	var errs []error
+
+	for _, u := range due {
+		// The owner asked for the deletion, so the purge is recorded as theirs
+		if _, purgeErr := s.purge(ctx, Actor{ID: u.ID}, u, s.options.AnonymizeSubmissions); purgeErr != nil {
+			s.logger.Error("failed to purge deleted account", "user_id", u.ID, "error", purgeErr)
+			errs = append(errs, purgeErr)
+		}
+	}
+
+	if len(due) > 0 {
+		s.logger.Info("deleted accounts purged", "purged", len(due)-len(errs), "failed", len(errs))
+	}
+
+	return errors.Join(errs...)
+}
+
+// purge removes an account's forms and submissions, or anonymizes them when
+// anonymize is set, then removes the account. An anonymized account keeps a
+// disabled, soft-deleted row without personal data, which its forms still
+// reference.
+func (s *deletionService) purge(ctx context.Context, actor Actor, u *entities.User, anonymize bool) (*Deletion, error) {
+	deletion := &Deletion{
+		UserID: u.ID, ActorID: actor.ID, RequestedAt: u.DeletionRequestedAt, ScheduledAt: u.DeletionScheduledAt,
+		Anonymized: anonymize,
+	}
+
+	forms, err := s.forms.ListForms(ctx, u.ID)
+	if err != nil {
+		return nil, fmt.Errorf("list forms: %w", err)
+	}
+
+	for _, f := range forms {
+		count, formErr := s.purgeForm(ctx, f, anonymize)
+		deletion.Submissions += count
+
+		if formErr != nil {
+			return nil, fmt.Errorf("purge form %s: %w", f.ID, formErr)
+		}
+
+		deletion.Forms++
+	}
+
+	if anonymize {
+		err = s.anonymizeAccount(ctx, u)
+	} else {
+		err = s.deletions.Purge(ctx, u.ID)
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("delete user: %w", err)
+	}
+
+	s.logger.Info("account deleted", "user_id", u.ID, "forms", deletion.Forms,
+		"submissions", deletion.Submissions, "anonymized", anonymize)
+	s.report(ctx, actor, DeletedEventType, audit.ActionUserDeleted, deletion)
+
+	return deletion, nil
+}
+
+// purgeForm deletes a form and its submissions, or anonymizes the submissions
+// and unpublishes the form, returning the number of submissions
+func (s *deletionService) purgeForm(ctx context.Context, f *model.Form, anonymize bool) (int, error) {
+	submissions, err := s.forms.ListSubmissions(ctx, f.ID)
+	if err != nil {
+		return 0, fmt.Errorf("list submissions: %w", err)
+	}
+
+	if !anonymize {
+		for _, submission := range submissions {
+			if err = s.forms.DeleteSubmission(ctx, submission.ID); err != nil {
+				return 0, fmt.Errorf("delete submission: %w", err)
+			}
+		}
+
+		if err = s.forms.DeleteForm(ctx, f.ID); err != nil {
+			return 0, fmt.Errorf("delete form: %w", err)
+		}
+
+		return len(submissions), nil
+	}
+
+	for _, submission := range formdomain.AnonymizeSubmissions(f, submissions, s.classifier) {
+		if err = s.forms.UpdateSubmission(ctx, submission); err != nil {
+			return 0, fmt.Errorf("anonymize submission: %w", err)
+		}
+	}
+
+	if f.Status == model.FormStatusPublished {
+		f.Status = model.FormStatusDraft
+		if err = s.forms.UpdateForm(ctx, f); err != nil {
+			return 0, fmt.Errorf("unpublish form: %w", err)
+		}
+	}
+
+	return len(submissions), nil
+}
+
+// anonymizeAccount replaces an account's personal data, disables it and
+// soft-deletes it
+func (s *deletionService) anonymizeAccount(ctx context.Context, u *entities.User) error {
+	u.Email = "deleted-" + u.ID + deletedEmailDomain
+	u.FirstName, u.LastName = "", ""
+	// No password hashes to an empty string, so the account cannot sign in
+	u.HashedPassword = "!"
+	u.Timezone, u.LastActiveAt = "", nil
+	u.DeletionRequestedAt, u.DeletionScheduledAt = nil, nil
+	u.Deactivate()
+
+	if err := s.repo.Update(ctx, u); err != nil {
+		return fmt.Errorf("anonymize user: %w", err)
+	}
+
+	if err := s.repo.Delete(ctx, u.ID); err != nil {
+		return fmt.Errorf("soft-delete user: %w", err)
+	}
+
+	return nil
+}
+
+// report records a deletion step in the audit log and publishes its event.
+// The step has already happened, so a lost entry or event is logged rather
+// than reported as a failure.
+func (s *deletionService) report(ctx context.Context, actor Actor, eventType, action string, deletion *Deletion) {
+	err := s.audit.Record(ctx, &audit.Entry{
+		ActorID:    actor.ID,
+		Action:     action,
+		TargetType: audit.TargetUser,
+		TargetID:   deletion.UserID,
+		Details: map[string]any{
+			"scheduled_at": deletion.ScheduledAt,
+			"forms":        deletion.Forms,
+			"submissions":  deletion.Submissions,
+			"anonymized":   deletion.Anonymized,
+		},
+		IPAddress: actor.IPAddress,
+	})
+	if err != nil {
+		s.logger.Error("failed to record account deletion", "action", action, "user_id", deletion.UserID, "error", err)
+	}
+
+	if publishErr := s.publisher.Publish(ctx, NewDeletionEvent(eventType, deletion)); publishErr != nil {
+		s.logger.Error("failed to publish account deletion event", "event", eventType, "user_id", deletion.UserID, "error", publishErr)
+	}
+}
@@ -0,0 +1,191 @@
+package user_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/goformx/goforms/internal/domain/audit"
+	"github.com/goformx/goforms/internal/domain/common/events"
+	"github.com/goformx/goforms/internal/domain/entities"
+	"github.com/goformx/goforms/internal/domain/form/model"
+	"github.com/goformx/goforms/internal/domain/user"
+	"github.com/goformx/goforms/internal/infrastructure/event"
+	"github.com/goformx/goforms/internal/infrastructure/pii"
+	"github.com/goformx/goforms/internal/infrastructure/repository/common"
+	memorystore "github.com/goformx/goforms/internal/infrastructure/repository/memory"
+	mocklogging "github.com/goformx/goforms/test/mocks/logging"
+)
+
+// deletionFixture is a deletion service over a memory store holding one user
+// with a form and a submission
+type deletionFixture struct {
+	service    user.DeletionService
+	store      *memorystore.Store
+	audit      audit.Service
+	owner      *entities.User
+	form       *model.Form
+	submission *model.FormSubmission
+	events     []*user.Deletion
+}
+
+func newDeletionFixture(t *testing.T, anonymize bool) *deletionFixture {
+	t.Helper()
+
+	ctx := context.Background()
+	logger := mocklogging.NewMockLogger(gomock.NewController(t))
+	logger.EXPECT().Info(gomock.Any(), gomock.Any()).AnyTimes()
+
+	store := memorystore.NewStore(logger)
+	users := store.Users()
+	deletions, ok := users.(user.DeletionRepository)
+	require.True(t, ok)
+
+	owner, err := entities.NewUser("ada@example.com", "password123", "Ada", "Lovelace")
+	require.NoError(t, err)
+	require.NoError(t, users.Create(ctx, owner))
+
+	f := model.NewForm(owner.ID, "Contact form", "", model.JSON{"components": []any{
+		map[string]any{"key": "email", "type": "email"},
+		map[string]any{"key": "rating", "type": "number"},
+	}})
+	f.Status = "published"
+	require.NoError(t, store.Forms().CreateForm(ctx, f))
+
+	submission := &model.FormSubmission{
+		FormID: f.ID, Data: model.JSON{"email": "grace@example.com", "rating": float64(4)},
+		Status: model.SubmissionStatusCompleted, Metadata: model.JSON{"ip": "81.2.69.160"},
+	}
+	require.NoError(t, store.Forms().CreateSubmission(ctx, submission))
+
+	classifier, err := pii.NewClassifier(nil)
+	require.NoError(t, err)
+
+	fixture := &deletionFixture{
+		store: store, audit: audit.NewService(store.Audit(), logger), owner: owner, form: f, submission: submission,
+	}
+
+	bus := event.NewMemoryEventBus(logger)
+	for _, name := range []string{user.DeletionRequestedEventType, user.DeletionCancelledEventType, user.DeletedEventType} {
+		require.NoError(t, bus.Subscribe(ctx, name, func(_ context.Context, e events.Event) error {
+			deletion, _ := e.Payload().(*user.Deletion)
+			fixture.events = append(fixture.events, deletion)
+
+			return nil
+		}))
+	}
+
+	fixture.service = user.NewDeletionService(users, deletions, store.Forms(), fixture.audit, bus, classifier,
+		user.DeletionOptions{AnonymizeSubmissions: anonymize}, logger)
+
+	return fixture
+}
+
+// actions lists the audit actions recorded about the owner, oldest first
+func (f *deletionFixture) actions(t *testing.T) []string {
+	t.Helper()
+
+	entries, _, err := f.audit.List(context.Background(), audit.Filter{TargetID: f.owner.ID}, 0, 10)
+	require.NoError(t, err)
+
+	actions := make([]string, 0, len(entries))
+	for i := len(entries) - 1; i >= 0; i-- {
+		actions = append(actions, entries[i].Action)
+	}
+
+	return actions
+}
+
+func TestDeletionService_RequestAndCancel(t *testing.T) {
+	ctx := context.Background()
+	fixture := newDeletionFixture(t, true)
+	actor := user.Actor{ID: fixture.owner.ID, IPAddress: "192.0.2.1"}
+
+	requested, err := fixture.service.RequestDeletion(ctx, actor)
+	require.NoError(t, err)
+	require.True(t, requested.DeletionPending())
+
+	again, err := fixture.service.RequestDeletion(ctx, actor)
+	require.NoError(t, err)
+	assert.Equal(t, requested.DeletionScheduledAt, again.DeletionScheduledAt, "requesting again keeps the schedule")
+
+	cancelled, err := fixture.service.CancelDeletion(ctx, actor)
+	require.NoError(t, err)
+	assert.False(t, cancelled.DeletionPending())
+
+	// A cancelled deletion is not purged
+	require.NoError(t, fixture.service.PurgeDue(ctx))
+
+	stored, err := fixture.store.Users().GetByID(ctx, fixture.owner.ID)
+	require.NoError(t, err)
+	assert.Nil(t, stored.DeletionScheduledAt)
+
+	assert.Equal(t, []string{audit.ActionUserDeletionRequested, audit.ActionUserDeletionCancelled}, fixture.actions(t))
+	require.Len(t, fixture.events, 2)
+	assert.Equal(t, fixture.owner.ID, fixture.events[1].UserID)
+}
+
+func TestDeletionService_PurgeAnonymizes(t *testing.T) {
+	ctx := context.Background()
+	fixture := newDeletionFixture(t, true)
+
+	_, err := fixture.service.RequestDeletion(ctx, user.Actor{ID: fixture.owner.ID})
+	require.NoError(t, err)
+	require.NoError(t, fixture.service.PurgeDue(ctx), "a deletion without a grace period is due at once")
+
+	_, err = fixture.store.Users().GetByID(ctx, fixture.owner.ID)
+	require.ErrorIs(t, err, common.ErrNotFound)
+
+	stored, err := fixture.store.Forms().GetFormByID(ctx, fixture.form.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "draft", stored.Status, "the kept form is unpublished")
+
+	submission, err := fixture.store.Forms().GetSubmissionByID(ctx, fixture.submission.ID)
+	require.NoError(t, err)
+	assert.Nil(t, submission.Data["email"])
+	assert.Equal(t, float64(4), submission.Data["rating"])
+	assert.Empty(t, submission.Metadata)
+
+	assert.Equal(t, []string{audit.ActionUserDeletionRequested, audit.ActionUserDeleted}, fixture.actions(t))
+	require.Len(t, fixture.events, 2)
+	assert.Equal(t, &user.Deletion{
+		UserID: fixture.owner.ID, ActorID: fixture.owner.ID,
+		RequestedAt: fixture.events[0].RequestedAt, ScheduledAt: fixture.events[0].ScheduledAt,
+		Forms: 1, Submissions: 1, Anonymized: true,
+	}, fixture.events[1])
+}
+
+func TestDeletionService_DeleteUser(t *testing.T) {
+	ctx := context.Background()
+	fixture := newDeletionFixture(t, true)
+	admin := user.Actor{ID: "admin-1"}
+
+	_, err := fixture.service.DeleteUser(ctx, user.Actor{ID: fixture.owner.ID}, fixture.owner.ID)
+	require.ErrorIs(t, err, user.ErrSelfModification)
+
+	deletion, err := fixture.service.DeleteUser(ctx, admin, fixture.owner.ID)
+	require.NoError(t, err)
+	assert.Equal(t, 1, deletion.Forms)
+	assert.Equal(t, 1, deletion.Submissions)
+	assert.False(t, deletion.Anonymized, "an admin deletion removes the submissions whatever the purge policy")
+
+	_, err = fixture.store.Users().GetByID(ctx, fixture.owner.ID)
+	require.ErrorIs(t, err, common.ErrNotFound)
+
+	_, err = fixture.store.Forms().GetFormByID(ctx, fixture.form.ID)
+	require.ErrorIs(t, err, common.ErrNotFound)
+
+	_, err = fixture.store.Forms().GetSubmissionByID(ctx, fixture.submission.ID)
+	require.ErrorIs(t, err, common.ErrNotFound)
+
+	entries, _, err := fixture.audit.List(ctx, audit.Filter{ActorID: admin.ID}, 0, 10)
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+	assert.Equal(t, audit.ActionUserDeleted, entries[0].Action)
+
+	_, err = fixture.service.DeleteUser(ctx, admin, fixture.owner.ID)
+	require.ErrorIs(t, err, common.ErrNotFound)
+}
@@ -131,6 +131,10 @@ func (s *ServiceImpl) Login(ctx context.Context, login *Login) (*LoginResponse,
 		return nil, ErrInvalidCredentials
 	}
 
+	if err := checkPasswordLogin(user); err != nil {
+		return nil, err
+	}
+
 	return &LoginResponse{
 		User: user,
 	}, nil
@@ -207,5 +211,23 @@ func (s *ServiceImpl) Authenticate(ctx context.Context, email, password string)
 		return nil, ErrInvalidCredentials
 	}
 
+	if err := checkPasswordLogin(user); err != nil {
+		return nil, err
+	}
+
 	return user, nil
 }
+
+// checkPasswordLogin rejects a password login by a disabled user or one who must reset their password.
+// It runs after the password check, so account status is not revealed to a caller without the password.
+func checkPasswordLogin(u *entities.User) error {
+	if err := CheckCanSignIn(u); err != nil {
+		return err
+	}
+
+	if u.PasswordResetRequired {
+		return ErrPasswordResetRequired
+	}
+
+	return nil
+}
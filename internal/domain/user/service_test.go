@@ -239,6 +239,35 @@ func TestService_Login(t *testing.T) {
 		assert.ErrorIs(t, err, user.ErrInvalidCredentials)
 	})
 
+	t.Run("disabled account or pending password reset", func(t *testing.T) {
+		login := &user.Login{
+			Email:    "test@example.com",
+			Password: "password123",
+		}
+
+		disabled, err := entities.NewUser(login.Email, login.Password, "Test", "User")
+		require.NoError(t, err)
+		disabled.Deactivate()
+
+		resetRequired, err := entities.NewUser(login.Email, login.Password, "Test", "User")
+		require.NoError(t, err)
+		resetRequired.PasswordResetRequired = true
+
+		for testUser, want := range map[*entities.User]error{
+			disabled: user.ErrAccountDisabled, resetRequired: user.ErrPasswordResetRequired,
+		} {
+			repo.EXPECT().GetByEmail(gomock.Any(), login.Email).Return(testUser, nil)
+
+			result, loginErr := svc.Login(context.Background(), login)
+			require.ErrorIs(t, loginErr, want)
+			require.Nil(t, result)
+		}
+
+		// Setting a new password clears the reset requirement
+		require.NoError(t, resetRequired.SetPassword("new-password123"))
+		assert.False(t, resetRequired.PasswordResetRequired)
+	})
+
 	t.Run("database error", func(t *testing.T) {
 		login := &user.Login{
 			Email:    "test@example.com",
@@ -0,0 +1,52 @@
+// Package cache provides the key/value cache selected by cache.type. Values are
+// opaque bytes; callers choose their own encoding and key namespace.
+package cache
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/goformx/goforms/internal/infrastructure/config"
+	"github.com/goformx/goforms/internal/infrastructure/logging"
+	"github.com/goformx/goforms/internal/infrastructure/redis"
+)
+
+const (
+	// TypeMemory keeps entries in process memory
+	TypeMemory = "memory"
+	// TypeRedis keeps entries in Redis so they are shared between instances
+	TypeRedis = "redis"
+)
+
+// ErrUnknownType is returned when cache.type names an unsupported backend
+var ErrUnknownType = errors.New("unknown cache type")
+
+// Cache stores values with an expiry
+type Cache interface {
+	// Get returns the value stored under key; found is false for a missing or expired key
+	Get(ctx context.Context, key string) (value []byte, found bool, err error)
+	// Set stores value under key for ttl
+	Set(ctx context.Context, key string, value []byte, ttl time.Duration) error
+	// Delete removes keys; missing keys are ignored
+	Delete(ctx context.Context, keys ...string) error
+}
+
+// New returns the cache selected by cache.type
+func New(cfg config.CacheConfig, logger logging.Logger) (Cache, error) {
+	switch strings.ToLower(cfg.Type) {
+	case "", TypeMemory:
+		return NewMemoryCache(cfg.Memory.MaxSize), nil
+	case TypeRedis:
+		addr := cfg.Redis.Host + ":" + strconv.Itoa(cfg.Redis.Port)
+
+		logger.Info("using redis cache", "addr", addr, "db", cfg.Redis.DB)
+
+		return NewRedisCache(redis.NewClient(addr, cfg.Redis.Password, cfg.Redis.DB)), nil
+	default:
+		return nil, fmt.Errorf("%w: %s", ErrUnknownType, cfg.Type)
+	}
+}
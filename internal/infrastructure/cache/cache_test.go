@@ -0,0 +1,61 @@
+package cache_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/goformx/goforms/internal/infrastructure/cache"
+)
+
+func TestMemoryCache(t *testing.T) {
+	c := cache.NewMemoryCache(0)
+	ctx := t.Context()
+
+	require.NoError(t, c.Set(ctx, "a", []byte("1"), time.Minute))
+
+	value, found, err := c.Get(ctx, "a")
+	require.NoError(t, err)
+	assert.True(t, found)
+	assert.Equal(t, []byte("1"), value)
+
+	require.NoError(t, c.Delete(ctx, "a", "missing"))
+
+	_, found, err = c.Get(ctx, "a")
+	require.NoError(t, err)
+	assert.False(t, found)
+}
+
+func TestMemoryCache_Expiry(t *testing.T) {
+	c := cache.NewMemoryCache(0)
+	ctx := t.Context()
+
+	require.NoError(t, c.Set(ctx, "a", []byte("1"), time.Millisecond))
+	time.Sleep(5 * time.Millisecond)
+
+	_, found, err := c.Get(ctx, "a")
+	require.NoError(t, err)
+	assert.False(t, found)
+}
+
+func TestMemoryCache_MaxSize(t *testing.T) {
+	c := cache.NewMemoryCache(2)
+	ctx := t.Context()
+
+	require.NoError(t, c.Set(ctx, "first", []byte("1"), time.Minute))
+	require.NoError(t, c.Set(ctx, "second", []byte("2"), time.Hour))
+	require.NoError(t, c.Set(ctx, "third", []byte("3"), time.Hour))
+
+	// The entry closest to expiry makes room
+	_, found, err := c.Get(ctx, "first")
+	require.NoError(t, err)
+	assert.False(t, found)
+
+	for _, key := range []string{"second", "third"} {
+		_, found, err = c.Get(ctx, key)
+		require.NoError(t, err)
+		assert.True(t, found, key)
+	}
+}
@@ -0,0 +1,104 @@
+package cache
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// memoryEntry is a value with its expiry
+type memoryEntry struct {
+	value     []byte
+	expiresAt time.Time
+}
+
+// MemoryCache keeps entries in process memory. Entries are lost on restart and
+// are not shared between instances.
+type MemoryCache struct {
+	mu      sync.Mutex
+	entries map[string]memoryEntry
+	maxSize int
+	now     func() time.Time
+}
+
+// NewMemoryCache creates an in-memory cache holding at most maxSize entries.
+// A maxSize of zero or less leaves the cache unbounded.
+func NewMemoryCache(maxSize int) *MemoryCache {
+	return &MemoryCache{
+		entries: make(map[string]memoryEntry),
+		maxSize: maxSize,
+		now:     time.Now,
+	}
+}
+
+// Get returns the unexpired value stored under key
+func (c *MemoryCache) Get(_ context.Context, key string) ([]byte, bool, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	entry, ok := c.entries[key]
+	if !ok {
+		return nil, false, nil
+	}
+
+	if !c.now().Before(entry.expiresAt) {
+		delete(c.entries, key)
+
+		return nil, false, nil
+	}
+
+	return entry.value, true, nil
+}
+
+// Set stores value under key, making room by dropping expired entries and then
+// the entry closest to expiry when the cache is full
+func (c *MemoryCache) Set(_ context.Context, key string, value []byte, ttl time.Duration) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	now := c.now()
+
+	if _, exists := c.entries[key]; !exists && c.maxSize > 0 && len(c.entries) >= c.maxSize {
+		c.evict(now)
+	}
+
+	c.entries[key] = memoryEntry{value: value, expiresAt: now.Add(ttl)}
+
+	return nil
+}
+
+// Delete removes keys
+func (c *MemoryCache) Delete(_ context.Context, keys ...string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for _, key := range keys {
+		delete(c.entries, key)
+	}
+
+	return nil
+}
+
+// evict drops expired entries, or the entry closest to expiry when none have expired
+func (c *MemoryCache) evict(now time.Time) {
+	var (
+		oldestKey string
+		oldestAt  time.Time
+	)
+
+	for key, entry := range c.entries {
+		if !now.Before(entry.expiresAt) {
+			delete(c.entries, key)
+
+			continue
+		}
+
+		if oldestKey == "" || entry.expiresAt.Before(oldestAt) {
+			oldestKey, oldestAt = key, entry.expiresAt
+		}
+	}
+
+	if len(c.entries) >= c.maxSize && oldestKey != "" {
+		delete(c.entries, oldestKey)
+	}
+}
@@ -0,0 +1,68 @@
+package cache
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/goformx/goforms/internal/infrastructure/redis"
+)
+
+// redisKeyPrefix namespaces cache keys
+const redisKeyPrefix = "goforms:cache:"
+
+// RedisCache keeps entries in Redis with a per-key expiry
+type RedisCache struct {
+	client *redis.Client
+}
+
+// NewRedisCache creates a cache backed by client
+func NewRedisCache(client *redis.Client) *RedisCache {
+	return &RedisCache{client: client}
+}
+
+// Get returns the value stored under key
+func (c *RedisCache) Get(ctx context.Context, key string) ([]byte, bool, error) {
+	reply, err := c.client.Do(ctx, "GET", redisKeyPrefix+key)
+	if errors.Is(err, redis.ErrNil) {
+		return nil, false, nil
+	}
+
+	if err != nil {
+		return nil, false, fmt.Errorf("get cache key: %w", err)
+	}
+
+	return []byte(reply), true, nil
+}
+
+// Set stores value under key for ttl
+func (c *RedisCache) Set(ctx context.Context, key string, value []byte, ttl time.Duration) error {
+	if _, err := c.client.Do(ctx, "SET", redisKeyPrefix+key, string(value),
+		"PX", strconv.FormatInt(ttl.Milliseconds(), 10)); err != nil {
+		return fmt.Errorf("set cache key: %w", err)
+	}
+
+	return nil
+}
+
+// Delete removes keys
+func (c *RedisCache) Delete(ctx context.Context, keys ...string) error {
+	if len(keys) == 0 {
+		return nil
+	}
+
+	args := make([]string, 0, len(keys)+1)
+	args = append(args, "DEL")
+
+	for _, key := range keys {
+		args = append(args, redisKeyPrefix+key)
+	}
+
+	if _, err := c.client.Do(ctx, args...); err != nil {
+		return fmt.Errorf("delete cache keys: %w", err)
+	}
+
+	return nil
+}
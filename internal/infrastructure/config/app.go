@@ -9,25 +9,25 @@ import (
 // AppConfig holds application-level configuration
 type AppConfig struct {
 	// Application Info
-	Name        string `json:"name"`
-	Version     string `json:"version"`
-	Environment string `json:"environment"`
-	Debug       bool   `json:"debug"`
-	LogLevel    string `json:"log_level"`
+	Name        string `desc:"Application name shown in pages and emails" json:"name"        mapstructure:"name"        validate:"required"`
+	Version     string `desc:"Application version reported by the server" json:"version"     mapstructure:"version"`
+	Environment string `desc:"Runtime environment"                        json:"environment" mapstructure:"environment" validate:"environment"`
+	Debug       bool   `desc:"Enables debug output"                       json:"debug"       mapstructure:"debug"`
+	LogLevel    string `desc:"Log level: debug, info, warn or error"      json:"log_level"   mapstructure:"log_level"`
 
 	// Server Settings
-	URL            string        `json:"url"`
-	Scheme         string        `json:"scheme"`
-	Port           int           `json:"port"`
-	Host           string        `json:"host"`
-	ReadTimeout    time.Duration `json:"read_timeout"`
-	WriteTimeout   time.Duration `json:"write_timeout"`
-	IdleTimeout    time.Duration `json:"idle_timeout"`
-	RequestTimeout time.Duration `json:"request_timeout"`
+	URL            string        `desc:"Public base URL of the server"    json:"url"             mapstructure:"url"`
+	Scheme         string        `desc:"URL scheme: http or https"        json:"scheme"          mapstructure:"scheme"`
+	Port           int           `desc:"Port the server listens on"       json:"port"            mapstructure:"port"          validate:"min=1,max=65535"`
+	Host           string        `desc:"Address the server binds to"      json:"host"            mapstructure:"host"`
+	ReadTimeout    time.Duration `desc:"Time limit for reading requests"  json:"read_timeout"    mapstructure:"read_timeout"  validate:"gt=0"`
+	WriteTimeout   time.Duration `desc:"Time limit for writing responses" json:"write_timeout"   mapstructure:"write_timeout" validate:"gt=0"`
+	IdleTimeout    time.Duration `desc:"Keep-alive idle timeout"          json:"idle_timeout"    mapstructure:"idle_timeout"  validate:"gt=0"`
+	RequestTimeout time.Duration `desc:"Time limit for a request handler" json:"request_timeout" mapstructure:"request_timeout"`
 
 	// Development Settings
-	ViteDevHost string `json:"vite_dev_host"`
-	ViteDevPort string `json:"vite_dev_port"`
+	ViteDevHost string `desc:"Host of the Vite dev server in development" json:"vite_dev_host" mapstructure:"vite_dev_host"`
+	ViteDevPort string `desc:"Port of the Vite dev server in development" json:"vite_dev_port" mapstructure:"vite_dev_port"`
 }
 
 // IsDevelopment returns true if the application is running in development mode
@@ -0,0 +1,39 @@
+package config
+
+import "time"
+
+// BillingConfig holds Stripe billing configuration for hosted deployments.
+// Users without an entitled subscription get the form.quota limits.
+type BillingConfig struct {
+	Enabled bool `json:"enabled" mapstructure:"enabled"`
+	// SuccessURL and CancelURL are where Stripe Checkout returns the user
+	SuccessURL string `json:"success_url" mapstructure:"success_url"`
+	CancelURL  string `json:"cancel_url"  mapstructure:"cancel_url"`
+	// PortalReturnURL is where the Stripe Customer Portal returns the user
+	PortalReturnURL string              `json:"portal_return_url" mapstructure:"portal_return_url"`
+	Stripe          StripeConfig        `json:"stripe"            mapstructure:"stripe"`
+	Plans           []BillingPlanConfig `json:"plans"             mapstructure:"plans"`
+}
+
+// StripeConfig holds Stripe API and webhook settings
+type StripeConfig struct {
+	SecretKey string `json:"-" mapstructure:"secret_key"`
+	// WebhookSecret is the signing secret of the /api/v1/webhooks/stripe endpoint
+	WebhookSecret string `json:"-" mapstructure:"webhook_secret"`
+	// Endpoint overrides the API base URL, for tests
+	Endpoint string        `json:"endpoint" mapstructure:"endpoint"`
+	Timeout  time.Duration `json:"timeout"  mapstructure:"timeout"`
+	// WebhookTolerance is how old a signed webhook delivery may be
+	WebhookTolerance time.Duration `json:"webhook_tolerance" mapstructure:"webhook_tolerance"`
+}
+
+// BillingPlanConfig defines a paid plan: its Stripe price and the limits it
+// grants. A zero limit means unlimited.
+type BillingPlanConfig struct {
+	ID                     string `json:"id"                        mapstructure:"id"`
+	Name                   string `json:"name"                      mapstructure:"name"`
+	PriceID                string `json:"price_id"                  mapstructure:"price_id"`
+	MaxForms               int    `json:"max_forms"                 mapstructure:"max_forms"`
+	MaxSubmissionsPerMonth int    `json:"max_submissions_per_month" mapstructure:"max_submissions_per_month"`
+	MaxStorageBytes        int64  `json:"max_storage_bytes"         mapstructure:"max_storage_bytes"`
+}
@@ -10,23 +10,35 @@ import (
 
 // Config represents the complete application configuration
 type Config struct {
-	App      AppConfig      `json:"app"`
-	Database DatabaseConfig `json:"database"`
-	Security SecurityConfig `json:"security"`
-	Email    EmailConfig    `json:"email"`
-	Storage  StorageConfig  `json:"storage"`
-	Cache    CacheConfig    `json:"cache"`
-	Logging  LoggingConfig  `json:"logging"`
-	Session  SessionConfig  `json:"session"`
-	Auth     AuthConfig     `json:"auth"`
-	Form     FormConfig     `json:"form"`
-	API      APIConfig      `json:"api"`
-	Web      WebConfig      `json:"web"`
-	User     UserConfig     `json:"user"`
+	App          AppConfig          `json:"app"         mapstructure:"app"`
+	Database     DatabaseConfig     `json:"database"    mapstructure:"database"`
+	Security     SecurityConfig     `json:"security"    mapstructure:"security"`
+	Email        EmailConfig        `json:"email"       mapstructure:"email"`
+	Storage      StorageConfig      `json:"storage"     mapstructure:"storage"`
+	Cache        CacheConfig        `json:"cache"       mapstructure:"cache"`
+	Logging      LoggingConfig      `json:"logging"     mapstructure:"logging"`
+	Session      SessionConfig      `json:"session"     mapstructure:"session"`
+	Auth         AuthConfig         `json:"auth"        mapstructure:"auth"`
+	Form         FormConfig         `json:"form"        mapstructure:"form"`
+	API          APIConfig          `json:"api"         mapstructure:"api"`
+	Web          WebConfig          `json:"web"         mapstructure:"web"`
+	User         UserConfig         `json:"user"        mapstructure:"user"`
+	Flags        FlagsConfig        `json:"flags"       mapstructure:"flags"`
+	Billing      BillingConfig      `json:"billing"     mapstructure:"billing"`
+	Resilience   ResilienceConfig   `json:"resilience"  mapstructure:"resilience"`
+	HTTPClient   HTTPClientConfig   `json:"http_client" mapstructure:"http_client"`
+	Updates      UpdatesConfig      `json:"updates"     mapstructure:"updates"`
+	Events       EventsConfig       `json:"events"      mapstructure:"events"`
+	DeadLetter   DeadLetterConfig   `json:"dead_letter" mapstructure:"dead_letter"`
+	PII          PIIConfig          `json:"pii"         mapstructure:"pii"`
+	CrashReports CrashReportsConfig `json:"crash_reports" mapstructure:"crash_reports"`
+	Ops          OpsConfig          `json:"ops"           mapstructure:"ops"`
+	Timeouts     TimeoutsConfig     `json:"timeouts"      mapstructure:"timeouts"`
+	GeoIP        GeoIPConfig        `json:"geoip"         mapstructure:"geoip"`
 }
 
-// validateConfig validates the configuration
-func (c *Config) validateConfig() error {
+// Validate checks the settings the server refuses to start without
+func (c *Config) Validate() error {
 	var errs []string
 
 	// Validate core config sections
@@ -161,7 +173,7 @@ func (c *Config) GetConfigSummary() map[string]any {
 
 // IsValid checks if the configuration is valid
 func (c *Config) IsValid() bool {
-	return c.validateConfig() == nil
+	return c.Validate() == nil
 }
 
 // GetEnvironment returns the current environment
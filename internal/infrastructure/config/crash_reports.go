@@ -0,0 +1,18 @@
+package config
+
+// CrashReportsConfig holds the settings for crash reports. When a request
+// handler panics, the recovery middleware writes a report with the stack trace
+// and request context to Directory, and posts it to TrackerURL when one is set.
+type CrashReportsConfig struct {
+	Enabled bool `desc:"Writes a crash report for every recovered panic" json:"enabled" mapstructure:"enabled"`
+	// Directory holds the report files, one JSON file per panic
+	Directory string `desc:"Directory crash report files are written to" json:"directory" mapstructure:"directory"`
+	// MaxReports bounds the files kept in Directory; the oldest are removed first
+	MaxReports int `desc:"Most crash report files kept; the oldest are removed first" json:"max_reports" mapstructure:"max_reports"`
+	// GoroutineDump adds the stacks of all goroutines, which can be large
+	GoroutineDump bool `desc:"Adds a dump of all goroutines to each report" json:"goroutine_dump" mapstructure:"goroutine_dump"`
+	// TrackerURL receives each report as a JSON POST, e.g. an error tracker's ingest endpoint
+	TrackerURL string `desc:"Error tracker endpoint each report is posted to as JSON" json:"tracker_url" mapstructure:"tracker_url"`
+	// TrackerToken is sent to TrackerURL as a bearer token
+	TrackerToken string `desc:"Bearer token sent to the error tracker" json:"tracker_token" mapstructure:"tracker_token"`
+}
@@ -1,51 +1,198 @@
 package config
 
 import (
+	"cmp"
+	"crypto/tls"
+	"crypto/x509"
 	"errors"
 	"fmt"
+	"net"
+	"net/url"
+	"os"
+	"slices"
+	"strconv"
 	"strings"
 	"time"
 )
 
+// DatabaseDriverMemory keeps all data in process memory instead of a database server.
+// It is meant for development and demos; see DatabaseConfig.SnapshotPath.
+const DatabaseDriverMemory = "memory"
+
+// DatabaseDriverMongoDB stores forms, submissions and schema revisions in MongoDB.
+// The other repositories are kept in memory as with DatabaseDriverMemory.
+const DatabaseDriverMongoDB = "mongodb"
+
 // DatabaseConfig holds all database-related configuration
 type DatabaseConfig struct {
 	// Common database settings
-	Driver          string        `json:"driver"`
-	Host            string        `json:"host"`
-	Port            int           `json:"port"`
-	Name            string        `json:"name"`
-	Username        string        `json:"username"`
-	Password        string        `json:"password"`
-	MaxOpenConns    int           `json:"max_open_conns"`
-	MaxIdleConns    int           `json:"max_idle_conns"`
-	ConnMaxLifetime time.Duration `json:"conn_max_lifetime"`
-	ConnMaxIdleTime time.Duration `json:"conn_max_idle_time"`
-
-	// PostgreSQL specific settings
-	SSLMode string `json:"ssl_mode"`
+	Driver   string `desc:"postgres, mysql, mariadb, mongodb or memory" json:"driver"   mapstructure:"driver"   validate:"required,database_driver"`
+	Host     string `desc:"Database server host"               json:"host"     mapstructure:"host"     validate:"required_without=Socket"`
+	Port     int    `desc:"Database server port"               json:"port"     mapstructure:"port"     validate:"min=1,max=65535"`
+	Name     string `desc:"Database name"                      json:"name"     mapstructure:"name"     validate:"required"`
+	Username string `desc:"Database user"                      json:"username" mapstructure:"username" validate:"required"`
+	Password string `desc:"Database password"                  json:"password" mapstructure:"password"`
+
+	// Connection pool settings
+	MaxOpenConns    int           `desc:"Maximum open connections"          json:"max_open_conns"     mapstructure:"max_open_conns"     validate:"gt=0"`
+	MaxIdleConns    int           `desc:"Maximum idle connections"          json:"max_idle_conns"     mapstructure:"max_idle_conns"     validate:"gt=0"`
+	ConnMaxLifetime time.Duration `desc:"Maximum lifetime of a connection"  json:"conn_max_lifetime"  mapstructure:"conn_max_lifetime"  validate:"gt=0"`
+	ConnMaxIdleTime time.Duration `desc:"Maximum idle time of a connection" json:"conn_max_idle_time" mapstructure:"conn_max_idle_time" validate:"gt=0"`
+
+	// Socket is a unix socket connected to instead of the host and port: the socket
+	// file for MariaDB, or the directory holding it for PostgreSQL
+	Socket string `desc:"Unix socket used instead of the host; its directory for PostgreSQL" json:"socket" mapstructure:"socket"`
+
+	// TLS settings of PostgreSQL and MariaDB connections
+	SSLMode string `desc:"TLS mode: disable, require, verify-ca, verify-full, or for PostgreSQL allow or prefer" json:"ssl_mode" mapstructure:"ssl_mode"`
+
+	// TLS holds the certificate files used as SSLMode says
+	TLS DatabaseTLSConfig `json:"tls" mapstructure:"tls"`
+
+	// IAM authentication with Amazon RDS
+	IAM DatabaseIAMConfig `json:"iam" mapstructure:"iam"`
+
+	// Sharding spreads submissions over more databases by form ID
+	Sharding DatabaseShardingConfig `json:"sharding" mapstructure:"sharding"`
 
 	// MariaDB specific settings
-	RootPassword string `json:"root_password"`
+	RootPassword string `desc:"MariaDB root password" json:"root_password" mapstructure:"root_password"`
+
+	// In-memory driver settings
+	// SnapshotPath is a JSON file loaded at startup and written at shutdown; empty keeps nothing
+	SnapshotPath string `desc:"JSON file the memory driver loads at startup and writes at shutdown" json:"snapshot_path" mapstructure:"snapshot_path"`
+
+	// MongoDB driver settings
+	MongoDB MongoDBConfig `json:"mongodb" mapstructure:"mongodb"`
 
 	// Logging configuration
-	Logging DatabaseLoggingConfig `json:"logging"`
+	Logging DatabaseLoggingConfig `json:"logging" mapstructure:"logging"`
 }
 
 // DatabaseLoggingConfig holds database logging configuration
 type DatabaseLoggingConfig struct {
 	// SlowThreshold is the threshold for logging slow queries
-	SlowThreshold time.Duration `json:"slow_threshold"`
+	SlowThreshold time.Duration `desc:"Queries slower than this are logged" json:"slow_threshold" mapstructure:"slow_threshold"`
 	// Parameterized enables logging of query parameters
-	Parameterized bool `json:"parameterized"`
+	Parameterized bool `desc:"Logs query parameters" json:"parameterized" mapstructure:"parameterized"`
 	// IgnoreNotFound determines whether to ignore record not found errors
-	IgnoreNotFound bool `json:"ignore_not_found"`
+	IgnoreNotFound bool `desc:"Skips logging record not found errors" json:"ignore_not_found" mapstructure:"ignore_not_found"`
 	// LogLevel determines the verbosity of database logging
 	// Valid values: "silent", "error", "warn", "info"
-	LogLevel string `json:"log_level"`
+	LogLevel string `desc:"Database log level: silent, error, warn or info" json:"log_level" mapstructure:"log_level"`
+}
+
+// DatabaseTLSConfig holds the certificates of TLS database connections. How the
+// server certificate is checked is set by DatabaseConfig.SSLMode.
+type DatabaseTLSConfig struct {
+	// CAFile is a PEM bundle of the CAs that sign the server certificate; empty uses the system roots
+	CAFile string `desc:"PEM bundle of the CAs that sign the server certificate; empty uses the system roots" json:"ca_file" mapstructure:"ca_file"`
+	// CertFile and KeyFile are the PEM client certificate and its key, set together
+	CertFile string `desc:"PEM client certificate presented to the server; needs database.tls.key_file" json:"cert_file" mapstructure:"cert_file"`
+	KeyFile  string `desc:"PEM private key of the client certificate" json:"key_file" mapstructure:"key_file"`
+	// ServerName is checked against the server certificate in verify-full mode; empty uses the host
+	ServerName string `desc:"Name verify-full checks the server certificate for; empty uses the host" json:"server_name" mapstructure:"server_name"`
+}
+
+// DatabaseIAMConfig holds the settings of Amazon RDS IAM authentication, which
+// replaces the password with a short-lived token signed with the AWS credentials
+// of the environment
+type DatabaseIAMConfig struct {
+	Enabled bool   `desc:"Signs in with Amazon RDS IAM tokens instead of database.password" json:"enabled" mapstructure:"enabled"`
+	Region  string `desc:"AWS region of the RDS instance"                                     json:"region"  mapstructure:"region"`
+}
+
+// DatabaseShardingConfig spreads form submissions over more databases for very
+// large installs. Forms and every other table stay in the primary database; a
+// form's submissions and their unique values live in the shard its ID hashes
+// to on a consistent hash ring, so adding a shard only moves about 1/N of the
+// forms. Moved forms are copied over with "goforms shards rebalance".
+type DatabaseShardingConfig struct {
+	Enabled bool `desc:"Stores submissions in database.sharding.shards by form ID" json:"enabled" mapstructure:"enabled"`
+	// VirtualNodes is the number of points each shard has on the ring; more
+	// points spread forms more evenly
+	VirtualNodes int                   `desc:"Points each shard has on the hash ring" json:"virtual_nodes" mapstructure:"virtual_nodes"`
+	Shards       []DatabaseShardConfig `desc:"Databases submissions are spread over"  json:"shards"        mapstructure:"shards"`
+}
+
+// DatabaseShardConfig is one shard database. It shares the primary's driver,
+// SSL mode, TLS files, IAM and pool settings; an empty port, username or
+// password is the primary's too.
+type DatabaseShardConfig struct {
+	// ID places the shard on the hash ring; changing it moves the shard's forms
+	ID       string `desc:"Name placing the shard on the hash ring"           json:"id"       mapstructure:"id"`
+	Host     string `desc:"Shard database host"                               json:"host"     mapstructure:"host"`
+	Port     int    `desc:"Shard database port; 0 uses database.port"         json:"port"     mapstructure:"port"`
+	Socket   string `desc:"Unix socket used instead of the host"              json:"socket"   mapstructure:"socket"`
+	Name     string `desc:"Shard database name"                               json:"name"     mapstructure:"name"`
+	Username string `desc:"Shard database user; empty uses the primary's"     json:"username" mapstructure:"username"`
+	Password string `desc:"Shard database password; empty uses the primary's" json:"password" mapstructure:"password"`
+
+	// Retired keeps a shard connected but off the ring, so rebalancing moves its
+	// submissions to the other shards before it is removed
+	Retired bool `desc:"Keeps the shard off the hash ring so rebalancing empties it" json:"retired" mapstructure:"retired"`
+}
+
+// SSL modes of database.ssl_mode
+const (
+	SSLModeDisable    = "disable"
+	SSLModeAllow      = "allow"
+	SSLModePrefer     = "prefer"
+	SSLModeRequire    = "require"
+	SSLModeVerifyCA   = "verify-ca"
+	SSLModeVerifyFull = "verify-full"
+)
+
+// MongoDBConfig holds the settings of the MongoDB driver
+type MongoDBConfig struct {
+	// URI is the connection string; empty builds one from the host, port, username and password
+	URI string `desc:"MongoDB connection string; empty builds one from database.host, port, username and password" json:"uri" mapstructure:"uri"`
+}
+
+// MongoDBURI returns the MongoDB connection string
+func (c *DatabaseConfig) MongoDBURI() string {
+	if c.MongoDB.URI != "" {
+		return c.MongoDB.URI
+	}
+
+	uri := url.URL{Scheme: "mongodb", Host: net.JoinHostPort(c.Host, strconv.Itoa(c.Port)), Path: "/"}
+	if c.Username != "" {
+		uri.User = url.UserPassword(c.Username, c.Password)
+	}
+
+	return uri.String()
+}
+
+// Shard returns the connection settings of a shard: the primary's, with the
+// shard's address and name. The TLS server name is not carried over, so each
+// shard's certificate is checked against its own host.
+func (c *DatabaseConfig) Shard(shard *DatabaseShardConfig) DatabaseConfig {
+	db := *c
+	db.Host, db.Socket, db.Name = shard.Host, shard.Socket, shard.Name
+	db.Port = cmp.Or(shard.Port, c.Port)
+	db.Username = cmp.Or(shard.Username, c.Username)
+	db.Password = cmp.Or(shard.Password, c.Password)
+	db.TLS.ServerName = ""
+	db.Sharding = DatabaseShardingConfig{}
+
+	return db
 }
 
 // Validate validates the database configuration
 func (c *DatabaseConfig) Validate() error {
+	if c.Sharding.Enabled && (c.Driver == DatabaseDriverMemory || c.Driver == DatabaseDriverMongoDB) {
+		return fmt.Errorf("database sharding is not supported by the %s driver", c.Driver)
+	}
+
+	// The in-memory driver needs no connection settings
+	if c.Driver == DatabaseDriverMemory {
+		return nil
+	}
+
+	if c.Driver == DatabaseDriverMongoDB {
+		return c.validateMongoDBFields()
+	}
+
 	var errs []string
 
 	// Validate common fields
@@ -58,6 +205,15 @@ func (c *DatabaseConfig) Validate() error {
 		errs = append(errs, err.Error())
 	}
 
+	// Validate the TLS, socket and IAM settings
+	if err := c.validateConnectionFields(); err != nil {
+		errs = append(errs, err.Error())
+	}
+
+	if c.Sharding.Enabled {
+		errs = append(errs, c.Sharding.validate()...)
+	}
+
 	if len(errs) > 0 {
 		return fmt.Errorf("database config validation errors: %s", strings.Join(errs, "; "))
 	}
@@ -69,8 +225,8 @@ func (c *DatabaseConfig) Validate() error {
 func (c *DatabaseConfig) validateCommonFields() error {
 	var errs []string
 
-	if c.Host == "" {
-		errs = append(errs, "database host is required")
+	if c.Host == "" && c.Socket == "" {
+		errs = append(errs, "database host or socket is required")
 	}
 
 	if c.Port <= 0 || c.Port > 65535 {
@@ -81,7 +237,7 @@ func (c *DatabaseConfig) validateCommonFields() error {
 		errs = append(errs, "database username is required")
 	}
 
-	if c.Password == "" {
+	if c.Password == "" && !c.IAM.Enabled {
 		errs = append(errs, "database password is required")
 	}
 
@@ -108,6 +264,159 @@ func (c *DatabaseConfig) validateDriverSpecificFields() error {
 	}
 }
 
+// validateConnectionFields validates the SSL mode, the TLS files, the socket and
+// IAM authentication, reading the files so a bad path fails at startup rather
+// than at the first connection
+func (c *DatabaseConfig) validateConnectionFields() error {
+	var errs []string
+
+	if modes := c.sslModes(); c.SSLMode != "" && !slices.Contains(modes, c.SSLMode) {
+		errs = append(errs, fmt.Sprintf("%s SSL mode must be one of: %s", c.Driver, strings.Join(modes, ", ")))
+	}
+
+	if c.TLSEnabled() {
+		errs = append(errs, c.TLS.validate()...)
+	} else if c.TLS != (DatabaseTLSConfig{}) {
+		errs = append(errs, "database TLS files are set but SSL mode is disable")
+	}
+
+	if c.Socket != "" {
+		if _, err := os.Stat(c.Socket); err != nil {
+			errs = append(errs, fmt.Sprintf("database socket: %v", err))
+		}
+	}
+
+	if c.IAM.Enabled {
+		errs = append(errs, c.validateIAMFields()...)
+	}
+
+	if len(errs) > 0 {
+		return fmt.Errorf("%s", strings.Join(errs, "; "))
+	}
+
+	return nil
+}
+
+// validateIAMFields validates IAM authentication, which RDS only accepts over
+// TLS connections to the instance endpoint
+func (c *DatabaseConfig) validateIAMFields() []string {
+	var errs []string
+
+	if c.IAM.Region == "" {
+		errs = append(errs, "database IAM region is required")
+	}
+
+	if c.Socket != "" {
+		errs = append(errs, "database IAM authentication needs the RDS host, not a socket")
+	}
+
+	if !c.TLSEnabled() || c.SSLMode == SSLModeAllow || c.SSLMode == SSLModePrefer {
+		errs = append(errs, "database IAM authentication needs SSL mode require, verify-ca or verify-full")
+	}
+
+	return errs
+}
+
+// validate checks that there are shards and that each has a unique ID and an
+// address and database name of its own
+func (s *DatabaseShardingConfig) validate() []string {
+	var errs []string
+
+	if !slices.ContainsFunc(s.Shards, func(shard DatabaseShardConfig) bool { return !shard.Retired }) {
+		errs = append(errs, "database sharding needs at least one shard that is not retired")
+	}
+
+	if s.VirtualNodes <= 0 {
+		errs = append(errs, "database sharding virtual nodes must be greater than 0")
+	}
+
+	ids := make(map[string]bool, len(s.Shards))
+
+	for i, shard := range s.Shards {
+		switch {
+		case shard.ID == "":
+			errs = append(errs, fmt.Sprintf("database shard %d: id is required", i))
+		case ids[shard.ID]:
+			errs = append(errs, fmt.Sprintf("database shard %d: id %q is used by another shard", i, shard.ID))
+		}
+
+		ids[shard.ID] = true
+
+		if shard.Host == "" && shard.Socket == "" {
+			errs = append(errs, fmt.Sprintf("database shard %d: host or socket is required", i))
+		}
+
+		if shard.Name == "" {
+			errs = append(errs, fmt.Sprintf("database shard %d: name is required", i))
+		}
+	}
+
+	return errs
+}
+
+// sslModes lists the SSL modes of the driver. MariaDB has no opportunistic TLS,
+// so allow and prefer are PostgreSQL's alone.
+func (c *DatabaseConfig) sslModes() []string {
+	if c.Driver == "mariadb" {
+		return []string{SSLModeDisable, SSLModeRequire, SSLModeVerifyCA, SSLModeVerifyFull}
+	}
+
+	return []string{SSLModeDisable, SSLModeAllow, SSLModePrefer, SSLModeRequire, SSLModeVerifyCA, SSLModeVerifyFull}
+}
+
+// TLSEnabled reports whether connections may use TLS
+func (c *DatabaseConfig) TLSEnabled() bool {
+	return c.SSLMode != "" && c.SSLMode != SSLModeDisable
+}
+
+// validate reads the TLS files and checks that they hold what they should
+func (t *DatabaseTLSConfig) validate() []string {
+	var errs []string
+
+	if t.CAFile != "" {
+		if _, err := t.RootCAs(); err != nil {
+			errs = append(errs, err.Error())
+		}
+	}
+
+	switch {
+	case t.CertFile == "" && t.KeyFile == "":
+	case t.CertFile == "" || t.KeyFile == "":
+		errs = append(errs, "database TLS client certificate and key files must be set together")
+	default:
+		if _, err := tls.LoadX509KeyPair(t.CertFile, t.KeyFile); err != nil {
+			errs = append(errs, fmt.Sprintf("database TLS client certificate: %v", err))
+		}
+	}
+
+	return errs
+}
+
+// RootCAs returns the CAs that sign the server certificate: the CA file's, or
+// the system roots when none is set
+func (t *DatabaseTLSConfig) RootCAs() (*x509.CertPool, error) {
+	if t.CAFile == "" {
+		pool, err := x509.SystemCertPool()
+		if err != nil {
+			return nil, fmt.Errorf("database TLS system roots: %w", err)
+		}
+
+		return pool, nil
+	}
+
+	pem, err := os.ReadFile(t.CAFile)
+	if err != nil {
+		return nil, fmt.Errorf("database TLS CA file: %w", err)
+	}
+
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(pem) {
+		return nil, fmt.Errorf("database TLS CA file %s holds no PEM certificates", t.CAFile)
+	}
+
+	return pool, nil
+}
+
 // validatePostgresFields validates PostgreSQL-specific fields
 func (c *DatabaseConfig) validatePostgresFields() error {
 	if c.SSLMode == "" {
@@ -117,6 +426,26 @@ func (c *DatabaseConfig) validatePostgresFields() error {
 	return nil
 }
 
+// validateMongoDBFields validates the MongoDB driver's fields. The SQL connection
+// pool settings do not apply to it.
+func (c *DatabaseConfig) validateMongoDBFields() error {
+	var errs []string
+
+	if c.MongoDB.URI == "" && c.Host == "" {
+		errs = append(errs, "database host or MongoDB URI is required")
+	}
+
+	if c.Name == "" {
+		errs = append(errs, "database name is required")
+	}
+
+	if len(errs) > 0 {
+		return fmt.Errorf("database config validation errors: %s", strings.Join(errs, "; "))
+	}
+
+	return nil
+}
+
 // validateMariaDBFields validates MariaDB-specific fields
 func (c *DatabaseConfig) validateMariaDBFields() error {
 	if c.RootPassword == "" {
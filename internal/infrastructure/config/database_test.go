@@ -1,9 +1,19 @@
 package config_test
 
 import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"os"
+	"path/filepath"
 	"testing"
 	"time"
 
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
 	"github.com/goformx/goforms/internal/infrastructure/config"
@@ -238,6 +248,23 @@ func TestDatabaseConfig_ValidateDriverSpecific(t *testing.T) {
 			},
 			expectError: true,
 		},
+		{
+			name: "valid mongodb config",
+			dbConfig: config.DatabaseConfig{
+				Driver:  config.DatabaseDriverMongoDB,
+				Name:    "testdb",
+				MongoDB: config.MongoDBConfig{URI: "mongodb://localhost:27017"},
+			},
+			expectError: false,
+		},
+		{
+			name: "mongodb without host or URI",
+			dbConfig: config.DatabaseConfig{
+				Driver: config.DatabaseDriverMongoDB,
+				Name:   "testdb",
+			},
+			expectError: true,
+		},
 		{
 			name: "unsupported driver",
 			dbConfig: config.DatabaseConfig{
@@ -263,3 +290,269 @@ func TestDatabaseConfig_ValidateDriverSpecific(t *testing.T) {
 		})
 	}
 }
+
+func TestDatabaseConfig_MongoDBURI(t *testing.T) {
+	cfg := config.DatabaseConfig{Host: "db", Port: 27017, Username: "goforms", Password: "p@ss:word"}
+	require.Equal(t, "mongodb://goforms:p%40ss%3Aword@db:27017/", cfg.MongoDBURI())
+
+	cfg.MongoDB.URI = "mongodb+srv://cluster.example.com"
+	require.Equal(t, "mongodb+srv://cluster.example.com", cfg.MongoDBURI())
+}
+
+func TestDatabaseConfig_ValidateConnection(t *testing.T) {
+	certFile, keyFile := writeCertificate(t)
+	socket := t.TempDir()
+
+	notPEM := filepath.Join(t.TempDir(), "ca.pem")
+	require.NoError(t, os.WriteFile(notPEM, []byte("not a certificate"), 0o600))
+
+	tests := []struct {
+		name    string
+		modify  func(*config.DatabaseConfig)
+		wantErr string
+	}{
+		{
+			name: "verify-full with CA and client certificate",
+			modify: func(c *config.DatabaseConfig) {
+				c.SSLMode = config.SSLModeVerifyFull
+				c.TLS = config.DatabaseTLSConfig{CAFile: certFile, CertFile: certFile, KeyFile: keyFile}
+			},
+		},
+		{
+			name:    "unknown SSL mode",
+			modify:  func(c *config.DatabaseConfig) { c.SSLMode = "verify-everything" },
+			wantErr: "postgres SSL mode must be one of: disable, allow, prefer, require, verify-ca, verify-full",
+		},
+		{
+			name: "prefer is PostgreSQL's alone",
+			modify: func(c *config.DatabaseConfig) {
+				c.Driver = "mariadb"
+				c.RootPassword = "rootpass"
+				c.SSLMode = config.SSLModePrefer
+			},
+			wantErr: "mariadb SSL mode must be one of: disable, require, verify-ca, verify-full",
+		},
+		{
+			name: "missing CA file",
+			modify: func(c *config.DatabaseConfig) {
+				c.SSLMode = config.SSLModeVerifyCA
+				c.TLS.CAFile = filepath.Join(t.TempDir(), "missing.pem")
+			},
+			wantErr: "database TLS CA file: open",
+		},
+		{
+			name: "CA file without certificates",
+			modify: func(c *config.DatabaseConfig) {
+				c.SSLMode = config.SSLModeVerifyCA
+				c.TLS.CAFile = notPEM
+			},
+			wantErr: "holds no PEM certificates",
+		},
+		{
+			name: "client certificate without key",
+			modify: func(c *config.DatabaseConfig) {
+				c.SSLMode = config.SSLModeRequire
+				c.TLS.CertFile = certFile
+			},
+			wantErr: "database TLS client certificate and key files must be set together",
+		},
+		{
+			name: "TLS files with TLS disabled",
+			modify: func(c *config.DatabaseConfig) {
+				c.TLS.CAFile = certFile
+			},
+			wantErr: "database TLS files are set but SSL mode is disable",
+		},
+		{
+			name: "socket instead of host",
+			modify: func(c *config.DatabaseConfig) {
+				c.Host = ""
+				c.Socket = socket
+			},
+		},
+		{
+			name:    "missing socket",
+			modify:  func(c *config.DatabaseConfig) { c.Socket = filepath.Join(socket, "missing.sock") },
+			wantErr: "database socket: stat",
+		},
+		{
+			name: "IAM authentication without a password",
+			modify: func(c *config.DatabaseConfig) {
+				c.Password = ""
+				c.SSLMode = config.SSLModeVerifyFull
+				c.IAM = config.DatabaseIAMConfig{Enabled: true, Region: "eu-west-1"}
+			},
+		},
+		{
+			name: "IAM authentication without TLS or region",
+			modify: func(c *config.DatabaseConfig) {
+				c.IAM.Enabled = true
+			},
+			wantErr: "database IAM region is required; database IAM authentication needs SSL mode require, verify-ca or verify-full",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := config.DatabaseConfig{
+				Driver:   "postgres",
+				Host:     "localhost",
+				Port:     5432,
+				Name:     "testdb",
+				Username: "testuser",
+				Password: "testpass",
+				SSLMode:  config.SSLModeDisable,
+			}
+			tt.modify(&cfg)
+
+			err := cfg.Validate()
+			if tt.wantErr == "" {
+				require.NoError(t, err)
+
+				return
+			}
+
+			require.ErrorContains(t, err, tt.wantErr)
+		})
+	}
+}
+
+func TestDatabaseConfig_ValidateSharding(t *testing.T) {
+	tests := []struct {
+		name    string
+		modify  func(*config.DatabaseConfig)
+		wantErr string
+	}{
+		{
+			name: "two shards",
+		},
+		{
+			name:    "no shards",
+			modify:  func(c *config.DatabaseConfig) { c.Sharding.Shards = nil },
+			wantErr: "database sharding needs at least one shard that is not retired",
+		},
+		{
+			name: "every shard retired",
+			modify: func(c *config.DatabaseConfig) {
+				c.Sharding.Shards[0].Retired = true
+				c.Sharding.Shards[1].Retired = true
+			},
+			wantErr: "database sharding needs at least one shard that is not retired",
+		},
+		{
+			name:    "no virtual nodes",
+			modify:  func(c *config.DatabaseConfig) { c.Sharding.VirtualNodes = 0 },
+			wantErr: "database sharding virtual nodes must be greater than 0",
+		},
+		{
+			name:    "repeated shard ID",
+			modify:  func(c *config.DatabaseConfig) { c.Sharding.Shards[1].ID = "a" },
+			wantErr: `database shard 1: id "a" is used by another shard`,
+		},
+		{
+			name: "shard without ID, host or name",
+			modify: func(c *config.DatabaseConfig) {
+				c.Sharding.Shards[0] = config.DatabaseShardConfig{}
+			},
+			wantErr: "database shard 0: id is required; database shard 0: host or socket is required; database shard 0: name is required",
+		},
+		{
+			name:    "memory driver",
+			modify:  func(c *config.DatabaseConfig) { c.Driver = config.DatabaseDriverMemory },
+			wantErr: "database sharding is not supported by the memory driver",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := config.DatabaseConfig{
+				Driver:   "postgres",
+				Host:     "localhost",
+				Port:     5432,
+				Name:     "testdb",
+				Username: "testuser",
+				Password: "testpass",
+				SSLMode:  config.SSLModeDisable,
+				Sharding: config.DatabaseShardingConfig{
+					Enabled:      true,
+					VirtualNodes: config.DefaultShardVirtualNodes,
+					Shards: []config.DatabaseShardConfig{
+						{ID: "a", Host: "shard-a", Name: "submissions"},
+						{ID: "b", Host: "shard-b", Name: "submissions"},
+					},
+				},
+			}
+			if tt.modify != nil {
+				tt.modify(&cfg)
+			}
+
+			err := cfg.Validate()
+			if tt.wantErr == "" {
+				require.NoError(t, err)
+
+				return
+			}
+
+			require.ErrorContains(t, err, tt.wantErr)
+		})
+	}
+}
+
+func TestDatabaseConfig_Shard(t *testing.T) {
+	primary := config.DatabaseConfig{
+		Driver:   "postgres",
+		Host:     "primary",
+		Port:     5432,
+		Name:     "goforms",
+		Username: "goforms",
+		Password: "secret",
+		SSLMode:  config.SSLModeVerifyFull,
+		TLS:      config.DatabaseTLSConfig{CAFile: "/etc/ssl/ca.pem", ServerName: "primary.example.com"},
+		Sharding: config.DatabaseShardingConfig{Enabled: true},
+	}
+
+	shard := primary.Shard(&config.DatabaseShardConfig{ID: "a", Host: "shard-a", Port: 6432, Name: "submissions", Password: "other"})
+
+	assert.Equal(t, "shard-a", shard.Host)
+	assert.Equal(t, 6432, shard.Port)
+	assert.Equal(t, "submissions", shard.Name)
+	assert.Equal(t, "goforms", shard.Username, "an empty username is the primary's")
+	assert.Equal(t, "other", shard.Password)
+	assert.Equal(t, config.SSLModeVerifyFull, shard.SSLMode)
+	assert.Equal(t, "/etc/ssl/ca.pem", shard.TLS.CAFile)
+	assert.Empty(t, shard.TLS.ServerName, "the server name is checked against the shard's own host")
+	assert.False(t, shard.Sharding.Enabled)
+}
+
+// writeCertificate writes a self-signed certificate, which serves as its own CA, and its key
+func writeCertificate(t *testing.T) (certFile, keyFile string) {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "db.example.com"},
+		DNSNames:              []string{"db.example.com"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	require.NoError(t, err)
+
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	require.NoError(t, err)
+
+	dir := t.TempDir()
+	certFile = filepath.Join(dir, "cert.pem")
+	keyFile = filepath.Join(dir, "key.pem")
+
+	require.NoError(t, os.WriteFile(certFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0o600))
+	require.NoError(t, os.WriteFile(keyFile, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}), 0o600))
+
+	return certFile, keyFile
+}
@@ -0,0 +1,17 @@
+package config
+
+import "time"
+
+// MinDeadLetterRetention is the shortest time resolved dead letters are kept
+const MinDeadLetterRetention = time.Hour
+
+// DeadLetterConfig holds the settings for the dead-letter queue. Background
+// work that fails after its own retries, such as post-submit hooks, outbound
+// email and write-behind submissions, is kept there to be inspected and retried
+// with "goforms dead-letters" and the admin API.
+type DeadLetterConfig struct {
+	Enabled bool `desc:"Keeps failed background work so it can be inspected and retried" json:"enabled" mapstructure:"enabled"`
+	// Retention is how long dead letters are kept after a retry succeeds;
+	// unresolved ones are kept until they are purged
+	Retention time.Duration `desc:"Time resolved dead letters are kept; at least 1h" json:"retention" mapstructure:"retention"`
+}
@@ -22,6 +22,12 @@ const (
 	DefaultSessionMaxAge  = 24 * time.Hour
 	DefaultAuthTimeout    = 30 * time.Minute
 	DefaultLockoutTime    = 15 * time.Minute
+
+	DefaultFormReadCacheTTL = 5 * time.Second
+	DefaultFormCacheTTL     = time.Minute
+	DefaultPageCacheTTL     = 10 * time.Minute
+	DefaultSignedURLTTL     = 15 * time.Minute
+	DefaultImpersonationTTL = 30 * time.Minute
 )
 
 // Default connection pool settings
@@ -30,6 +36,9 @@ const (
 	DefaultMaxIdleConns = 25
 )
 
+// DefaultShardVirtualNodes is the number of points each shard has on the hash ring
+const DefaultShardVirtualNodes = 64
+
 // Default security settings
 const (
 	DefaultCSRFTokenLength = 32
@@ -38,6 +47,17 @@ const (
 	DefaultRateLimitBurst  = 200
 	DefaultAPIRateLimitRPS = 1000
 	DefaultAPIRateBurst    = 2000
+
+	DefaultAuthenticatedRateLimit = 20 // requests per minute
+	DefaultAnonymousRateLimit     = 5  // requests per minute
+)
+
+// Default security event detection settings
+const (
+	DefaultFailedLoginThreshold     = 10
+	DefaultSubmissionBurstThreshold = 30
+	DefaultCSRFFailureThreshold     = 20
+	DefaultSecurityBlockDuration    = 15 * time.Minute
 )
 
 // Default size limits
@@ -47,6 +67,8 @@ const (
 	DefaultMaxFields       = 100
 	DefaultMaxErrors       = 10
 	DefaultMemoryCacheSize = 1000
+
+	DefaultPageCacheMaxEntries = 1000
 )
 
 // Default logging settings
@@ -54,6 +76,21 @@ const (
 	DefaultLogMaxSize    = 100 // MB
 	DefaultLogMaxBackups = 3
 	DefaultLogMaxAge     = 28 // days
+	// DefaultLogTruncateLength is the number of characters the truncate strategy keeps
+	DefaultLogTruncateLength = 8
+)
+
+// Default crash report settings
+const (
+	// DefaultMaxCrashReports is the number of crash report files kept
+	DefaultMaxCrashReports = 50
+)
+
+// Default per-layer request time budgets
+const (
+	DefaultDatabaseBudget = 10 * time.Second
+	DefaultOutboundBudget = 20 * time.Second
+	DefaultTimeoutReserve = time.Second
 )
 
 // Default auth settings
@@ -65,6 +102,84 @@ const (
 
 // Validation thresholds
 const (
-	MinPasswordLengthThreshold = 6
-	MinSecretLength            = 32
+	MinSecretLength = 32
+	// MaxSignedURLTTL is the longest validity GCS accepts for V4 signed URLs
+	MaxSignedURLTTL = 7 * 24 * time.Hour
+)
+
+// Default response compression settings
+const (
+	DefaultGzipLevel          = 5
+	DefaultBrotliLevel        = 4
+	DefaultCompressionMinSize = 1024 // bytes
+)
+
+// Default write-behind submission storage settings
+const (
+	DefaultWriteBehindBatchSize     = 100
+	DefaultWriteBehindFlushInterval = 200 * time.Millisecond
+	DefaultWriteBehindQueueSize     = 10000
+)
+
+// Default submission encryption-at-rest settings
+const (
+	DefaultEncryptionSettingCacheTTL   = 30 * time.Second
+	DefaultEncryptionRotationInterval  = 24 * time.Hour
+	DefaultEncryptionRotationBatchSize = 500
+)
+
+// MinEncryptionSecretLength is the shortest secret accepted for an encryption key
+const MinEncryptionSecretLength = 32
+
+// Default scheduled backup settings
+const (
+	DefaultBackupInterval  = 24 * time.Hour
+	DefaultBackupRetention = 7
+)
+
+// Default submission archiving settings
+const (
+	DefaultArchiveAfter     = 365 * 24 * time.Hour
+	DefaultArchiveInterval  = 24 * time.Hour
+	DefaultArchiveBatchSize = 500
+)
+
+// DefaultAnalyticsInterval is the default time between analytics exports
+const DefaultAnalyticsInterval = time.Hour
+
+// DefaultExtensionTimeout is the default bound on a form extension hook call
+const DefaultExtensionTimeout = 2 * time.Second
+
+// Default option source settings
+const (
+	DefaultOptionSourceTTL     = 10 * time.Minute
+	DefaultOptionSourceTimeout = 5 * time.Second
+)
+
+// Default dataset settings
+const (
+	DefaultDatasetCacheTTL    = time.Minute
+	DefaultDatasetMaxRows     = 10000
+	DefaultDatasetMaxPerOwner = 100
+)
+
+// DefaultTestSubmissionRetention is how long test submissions from the builder's preview are kept
+const DefaultTestSubmissionRetention = 7 * 24 * time.Hour
+
+// Default submission script limits
+const (
+	DefaultScriptMaxSize   = 16 * 1024
+	DefaultScriptMaxSteps  = 100000
+	DefaultScriptMaxMemory = 1 << 20
+	DefaultScriptTimeout   = 100 * time.Millisecond
+)
+
+// DefaultImageMaxPixels is the default bound on decoded upload images (32MB decoded)
+const DefaultImageMaxPixels = 8_000_000
+
+// Default adaptive submission throttling settings
+const (
+	DefaultThrottleMultiplier = 5.0
+	DefaultThrottleMinRate    = 10 // submissions per minute
+	DefaultThrottleCooldown   = 15 * time.Minute
 )
@@ -0,0 +1,20 @@
+package config
+
+import "time"
+
+// MinEventRetention is the shortest time stored domain events are kept
+const MinEventRetention = time.Hour
+
+// EventsConfig holds the domain event settings
+type EventsConfig struct {
+	Store EventStoreConfig `json:"store" mapstructure:"store"`
+}
+
+// EventStoreConfig holds the settings for persisting published domain events.
+// Stored events can be listed and replayed with "goforms events" and the
+// admin API; the store is off by default.
+type EventStoreConfig struct {
+	Enabled bool `desc:"Stores published domain events so they can be inspected and replayed" json:"enabled" mapstructure:"enabled"`
+	// Retention is how long stored events are kept before they are pruned
+	Retention time.Duration `desc:"Time stored events are kept; at least 1h" json:"retention" mapstructure:"retention"`
+}
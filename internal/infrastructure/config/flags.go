@@ -0,0 +1,22 @@
+package config
+
+import "time"
+
+// FlagsConfig holds feature flag configuration. Flags defined here are the
+// defaults; flags stored through /api/v1/admin/flags override them at runtime.
+type FlagsConfig struct {
+	// RefreshInterval is how often each instance reloads the stored flags
+	RefreshInterval time.Duration `json:"refresh_interval" mapstructure:"refresh_interval"`
+	// Definitions are the config-file flags, keyed by flag key
+	Definitions map[string]FlagConfig `json:"definitions" mapstructure:"definitions"`
+}
+
+// FlagConfig defines one feature flag in the config file
+type FlagConfig struct {
+	Description string `json:"description" mapstructure:"description"`
+	Enabled     bool   `json:"enabled"     mapstructure:"enabled"`
+	// Percentage is the share of accounts, 0 to 100, the flag is on for; unset means 100
+	Percentage *int `json:"percentage" mapstructure:"percentage"`
+	// Accounts always get the flag while it is enabled
+	Accounts []string `json:"accounts" mapstructure:"accounts"`
+}
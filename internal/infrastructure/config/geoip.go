@@ -0,0 +1,30 @@
+package config
+
+import "time"
+
+// MinGeoIPUpdateInterval keeps database downloads inside MaxMind's daily limits
+const MinGeoIPUpdateInterval = time.Hour
+
+// GeoIPConfig holds the settings of the MaxMind country database submissions
+// are located with. It is off by default.
+type GeoIPConfig struct {
+	Enabled bool `desc:"Locates submitters by IP address for per-form country rules and submission metadata" json:"enabled" mapstructure:"enabled"`
+	// DatabasePath is the GeoLite2 or GeoIP2 Country or City database
+	DatabasePath string `desc:"Path of the MaxMind .mmdb Country or City database" json:"database_path" mapstructure:"database_path"`
+	// Update downloads new releases of the database
+	Update GeoIPUpdateConfig `json:"update" mapstructure:"update"`
+}
+
+// GeoIPUpdateConfig holds the settings of the scheduled database download
+type GeoIPUpdateConfig struct {
+	Enabled bool `desc:"Downloads the database at startup when missing and then every interval" json:"enabled" mapstructure:"enabled"`
+	// AccountID and LicenseKey authenticate the download
+	AccountID  string `desc:"MaxMind account ID"  json:"account_id" mapstructure:"account_id"`
+	LicenseKey string `desc:"MaxMind license key" json:"-"          mapstructure:"license_key"`
+	// EditionID names the database, e.g. GeoLite2-Country or GeoLite2-City
+	EditionID string `desc:"MaxMind database edition, e.g. GeoLite2-Country or GeoLite2-City" json:"edition_id" mapstructure:"edition_id"`
+	// Interval is how often a running server checks for a new release
+	Interval time.Duration `desc:"Time between download checks; at least 1h" json:"interval" mapstructure:"interval"`
+	// URL is the base URL of the database downloads
+	URL string `desc:"Base URL of the MaxMind database downloads" json:"url" mapstructure:"url"`
+}
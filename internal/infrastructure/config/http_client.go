@@ -0,0 +1,32 @@
+package config
+
+import "time"
+
+// HTTPClientConfig holds the settings of every outbound HTTP client: the
+// egress proxy, extra trusted CAs, certificate pins, timeouts and user agent
+type HTTPClientConfig struct {
+	// Proxy is the URL of the egress proxy. When empty, HTTP_PROXY, HTTPS_PROXY
+	// and NO_PROXY from the environment apply.
+	Proxy string `json:"proxy" mapstructure:"proxy"`
+	// NoProxy lists the hosts, domains (".example.com") and CIDRs reached
+	// directly when Proxy is set
+	NoProxy []string `json:"no_proxy" mapstructure:"no_proxy"`
+	// CABundle is a PEM file of CA certificates trusted besides the system roots
+	CABundle string `json:"ca_bundle" mapstructure:"ca_bundle"`
+	// UserAgent is sent on every request; empty uses "GoForms/<version>"
+	UserAgent string `json:"user_agent" mapstructure:"user_agent"`
+	// Timeouts override the request timeout of an integration, keyed by integration
+	Timeouts map[string]time.Duration `json:"timeouts" mapstructure:"timeouts"`
+	// Pins restrict the certificates accepted from hosts
+	Pins []TLSPinConfig `json:"pins" mapstructure:"pins"`
+}
+
+// TLSPinConfig pins a host to public keys. A connection to the host must have
+// a certificate in its verified chain whose key is one of the pins. Hosts are
+// matched by the TLS server name, so IP addresses cannot be pinned.
+type TLSPinConfig struct {
+	Host string `json:"host" mapstructure:"host"`
+	// SHA256 are base64 SHA-256 digests of SubjectPublicKeyInfo, optionally
+	// prefixed with "sha256/"
+	SHA256 []string `json:"sha256" mapstructure:"sha256"`
+}
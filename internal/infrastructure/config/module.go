@@ -9,6 +9,12 @@ import (
 var Module = fx.Module("config",
 	// Use Viper configuration provider instead of LoadFromEnv
 	NewViperConfigProvider(),
+	Sections,
+)
+
+// Sections provides each section of the *Config in the graph, for graphs that
+// supply a *Config instead of loading one with Module
+var Sections = fx.Options(
 	fx.Provide(NewAppConfig),
 	fx.Provide(NewDatabaseConfig),
 	fx.Provide(NewSecurityConfig),
@@ -22,6 +28,18 @@ var Module = fx.Module("config",
 	fx.Provide(NewAPIConfig),
 	fx.Provide(NewWebConfig),
 	fx.Provide(NewUserConfig),
+	fx.Provide(NewFlagsConfig),
+	fx.Provide(NewBillingConfig),
+	fx.Provide(NewResilienceConfig),
+	fx.Provide(NewHTTPClientConfig),
+	fx.Provide(NewUpdatesConfig),
+	fx.Provide(NewEventsConfig),
+	fx.Provide(NewDeadLetterConfig),
+	fx.Provide(NewPIIConfig),
+	fx.Provide(NewCrashReportsConfig),
+	fx.Provide(NewOpsConfig),
+	fx.Provide(NewTimeoutsConfig),
+	fx.Provide(NewGeoIPConfig),
 )
 
 // Individual config providers for fine-grained dependency injection
@@ -90,3 +108,63 @@ func NewWebConfig(cfg *Config) WebConfig {
 func NewUserConfig(cfg *Config) UserConfig {
 	return cfg.User
 }
+
+// NewFlagsConfig provides feature flag configuration
+func NewFlagsConfig(cfg *Config) FlagsConfig {
+	return cfg.Flags
+}
+
+// NewBillingConfig provides billing configuration
+func NewBillingConfig(cfg *Config) BillingConfig {
+	return cfg.Billing
+}
+
+// NewResilienceConfig provides outbound resilience configuration
+func NewResilienceConfig(cfg *Config) ResilienceConfig {
+	return cfg.Resilience
+}
+
+// NewHTTPClientConfig provides outbound HTTP client configuration
+func NewHTTPClientConfig(cfg *Config) HTTPClientConfig {
+	return cfg.HTTPClient
+}
+
+// NewUpdatesConfig provides release update check configuration
+func NewUpdatesConfig(cfg *Config) UpdatesConfig {
+	return cfg.Updates
+}
+
+// NewEventsConfig provides domain event configuration
+func NewEventsConfig(cfg *Config) EventsConfig {
+	return cfg.Events
+}
+
+// NewDeadLetterConfig provides dead-letter queue configuration
+func NewDeadLetterConfig(cfg *Config) DeadLetterConfig {
+	return cfg.DeadLetter
+}
+
+// NewPIIConfig provides personal data detection configuration
+func NewPIIConfig(cfg *Config) PIIConfig {
+	return cfg.PII
+}
+
+// NewCrashReportsConfig provides crash report configuration
+func NewCrashReportsConfig(cfg *Config) CrashReportsConfig {
+	return cfg.CrashReports
+}
+
+// NewOpsConfig provides runtime diagnostics configuration
+func NewOpsConfig(cfg *Config) OpsConfig {
+	return cfg.Ops
+}
+
+// NewTimeoutsConfig provides the per-layer request time budgets
+func NewTimeoutsConfig(cfg *Config) TimeoutsConfig {
+	return cfg.Timeouts
+}
+
+// NewGeoIPConfig provides submitter location configuration
+func NewGeoIPConfig(cfg *Config) GeoIPConfig {
+	return cfg.GeoIP
+}
@@ -0,0 +1,28 @@
+package config
+
+// MinOpsTokenLength is the shortest bearer token the diagnostics listener accepts
+const MinOpsTokenLength = 16
+
+// OpsConfig holds the settings for the runtime diagnostics: net/http/pprof
+// profiles, expvar variables and runtime statistics. They are served outside
+// production, and in production only when ops.pprof.enabled is set. Without
+// Address they are served to admins under /api/v1/admin/debug.
+type OpsConfig struct {
+	Pprof OpsPprofConfig `json:"pprof" mapstructure:"pprof"`
+	// Address serves the diagnostics on a listener of their own, e.g.
+	// 127.0.0.1:6060, instead of on the main server
+	Address string `desc:"Separate host:port for the diagnostics; empty serves them under /api/v1/admin/debug" json:"address" mapstructure:"address"`
+	// Token is the bearer token the diagnostics listener requires
+	Token string `desc:"Bearer token the separate diagnostics listener requires" json:"token" mapstructure:"token"`
+}
+
+// OpsPprofConfig switches the diagnostics on in production
+type OpsPprofConfig struct {
+	Enabled bool `desc:"Serves pprof, expvar and runtime stats in production" json:"enabled" mapstructure:"enabled"`
+}
+
+// DiagnosticsEnabled reports whether the runtime diagnostics are served: always
+// outside production, and in production when ops.pprof.enabled is set
+func (c *Config) DiagnosticsEnabled() bool {
+	return !c.IsProduction() || c.Ops.Pprof.Enabled
+}
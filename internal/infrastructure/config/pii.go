@@ -0,0 +1,11 @@
+package config
+
+// PIIConfig holds the settings for detecting personal data. The classifier
+// always looks for email addresses and phone numbers; Profiles add national ID
+// formats. It masks personal data in log values and in submission exports
+// under a form's redaction policy.
+type PIIConfig struct {
+	Profiles []string `desc:"National ID formats to detect: us, uk, ca" json:"profiles" mapstructure:"profiles"`
+	// RedactLogs masks personal data found in logged string and error values
+	RedactLogs bool `desc:"Masks email addresses, phone numbers and national IDs in log values" json:"redact_logs" mapstructure:"redact_logs"`
+}
@@ -0,0 +1,135 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/spf13/viper"
+)
+
+// DirEnv names the environment variable holding the configuration directory;
+// the binaries' --config-dir flag sets it
+const DirEnv = "GOFORMS_CONFIG_DIR"
+
+// localProfile is the overlay for settings of one machine, kept out of version control
+const localProfile = "local"
+
+// Source records a configuration file that was loaded and the keys it
+// changed from the defaults and the files loaded before it
+type Source struct {
+	File string   `json:"file"`
+	Keys []string `json:"keys"`
+}
+
+// Sources lists the loaded configuration files in the order they were merged
+type Sources []Source
+
+// profileFiles returns the overlays merged over the base file for an
+// environment: config.{env} and then config.local
+func profileFiles(env string) []string {
+	names := make([]string, 0, 2)
+
+	if env = strings.ToLower(strings.TrimSpace(env)); env != "" && env != localProfile {
+		names = append(names, "config."+env)
+	}
+
+	return append(names, "config."+localProfile)
+}
+
+// findConfigFile returns the file named name, with any extension Viper reads,
+// in dir, or an empty path when there is none
+func findConfigFile(dir, name string) string {
+	for _, ext := range viper.SupportedExts {
+		path := filepath.Join(dir, name+"."+ext)
+		if info, err := os.Stat(path); err == nil && !info.IsDir() {
+			return path
+		}
+	}
+
+	return ""
+}
+
+// loadProfiles reads the base config file and merges the overlays of the
+// environment from the same directory. Defaults < config < config.{env} <
+// config.local < environment variables.
+func (vc *ViperConfig) loadProfiles() error {
+	// layers tracks the file values over the defaults, without the environment,
+	// to tell which keys each file overrides
+	layers := viper.New()
+	setDefaults(layers)
+
+	dir := vc.dir
+
+	if err := vc.viper.ReadInConfig(); err != nil {
+		var configFileNotFoundError viper.ConfigFileNotFoundError
+		if !errors.As(err, &configFileNotFoundError) {
+			return fmt.Errorf("failed to read config file: %w", err)
+		}
+		// Config file not found is not an error - we can use environment variables
+	} else {
+		// Store config file path for later logging (once logger is available)
+		vc.configFilePath = vc.viper.ConfigFileUsed()
+		dir = filepath.Dir(vc.configFilePath)
+
+		if err := vc.addSource(layers, vc.configFilePath); err != nil {
+			return err
+		}
+	}
+
+	if dir == "" {
+		return nil
+	}
+
+	// The environment may come from the base file, so it is read after it
+	for _, name := range profileFiles(vc.viper.GetString("app.environment")) {
+		path := findConfigFile(dir, name)
+		if path == "" {
+			continue
+		}
+
+		if err := vc.addSource(layers, path); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// addSource merges the file at path into the configuration and records the
+// keys it overrides
+func (vc *ViperConfig) addSource(layers *viper.Viper, path string) error {
+	file := viper.New()
+	file.SetConfigFile(path)
+
+	if err := file.ReadInConfig(); err != nil {
+		return fmt.Errorf("failed to read config file %s: %w", path, err)
+	}
+
+	var keys []string
+
+	for _, key := range file.AllKeys() {
+		if fmt.Sprint(layers.Get(key)) != fmt.Sprint(file.Get(key)) {
+			keys = append(keys, key)
+		}
+	}
+
+	slices.Sort(keys)
+
+	settings := file.AllSettings()
+
+	if err := layers.MergeConfigMap(settings); err != nil {
+		return fmt.Errorf("failed to merge config file %s: %w", path, err)
+	}
+
+	if err := vc.viper.MergeConfigMap(settings); err != nil {
+		return fmt.Errorf("failed to merge config file %s: %w", path, err)
+	}
+
+	vc.sources = append(vc.sources, Source{File: path, Keys: keys})
+
+	return nil
+}
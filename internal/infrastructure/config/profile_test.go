@@ -0,0 +1,107 @@
+package config_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/goformx/goforms/internal/infrastructure/config"
+)
+
+// writeConfigFiles writes the named files into a new directory
+func writeConfigFiles(t *testing.T, files map[string]string) string {
+	t.Helper()
+
+	dir := t.TempDir()
+	for name, content := range files {
+		require.NoError(t, os.WriteFile(filepath.Join(dir, name), []byte(content), 0o600))
+	}
+
+	return dir
+}
+
+func TestViperConfig_Profiles(t *testing.T) {
+	dir := writeConfigFiles(t, map[string]string{
+		"config.yaml":            "app:\n  name: Base\n  port: 8090\n  environment: staging\n",
+		"config.staging.yaml":    "app:\n  port: 8091\n  name: Staging\n",
+		"config.production.yaml": "app:\n  port: 8092\n",
+		"config.local.yaml":      "app:\n  port: 8093\n",
+	})
+
+	t.Run("overlays merge in order", func(t *testing.T) {
+		vc := config.NewViperConfigIn(dir)
+
+		cfg, err := vc.LoadUnvalidated()
+		require.NoError(t, err)
+
+		assert.Equal(t, "Staging", cfg.App.Name)
+		assert.Equal(t, 8093, cfg.App.Port, "config.local wins over the environment overlay")
+
+		assert.Equal(t, config.Sources{
+			{File: filepath.Join(dir, "config.yaml"), Keys: []string{"app.environment", "app.name", "app.port"}},
+			{File: filepath.Join(dir, "config.staging.yaml"), Keys: []string{"app.name", "app.port"}},
+			{File: filepath.Join(dir, "config.local.yaml"), Keys: []string{"app.port"}},
+		}, vc.Sources())
+	})
+
+	t.Run("environment variables win over files", func(t *testing.T) {
+		t.Setenv("APP_ENVIRONMENT", "production")
+		t.Setenv("APP_NAME", "Env")
+
+		vc := config.NewViperConfigIn(dir)
+
+		cfg, err := vc.LoadUnvalidated()
+		require.NoError(t, err)
+
+		assert.Equal(t, "Env", cfg.App.Name)
+		assert.Equal(t, 8093, cfg.App.Port)
+		require.Len(t, vc.Sources(), 3)
+		assert.Equal(t, filepath.Join(dir, "config.production.yaml"), vc.Sources()[1].File, "the staging overlay is not merged")
+	})
+
+	t.Run("directory from the environment", func(t *testing.T) {
+		t.Setenv(config.DirEnv, dir)
+
+		vc := config.NewViperConfig()
+
+		_, err := vc.LoadUnvalidated()
+		require.NoError(t, err)
+		assert.Equal(t, filepath.Join(dir, "config.yaml"), vc.GetConfigFilePath())
+	})
+
+	t.Run("LoadForEnvironment picks the overlay", func(t *testing.T) {
+		vc := config.NewViperConfigIn(dir)
+
+		cfg, err := vc.LoadForEnvironment("production")
+		require.Error(t, err, "the base file leaves required settings unset")
+		assert.Nil(t, cfg)
+		require.Len(t, vc.Sources(), 3)
+		assert.Equal(t, filepath.Join(dir, "config.production.yaml"), vc.Sources()[1].File)
+	})
+
+	t.Run("overlays without a base file", func(t *testing.T) {
+		overlayOnly := writeConfigFiles(t, map[string]string{
+			"config.local.yaml": "app:\n  port: 8094\n",
+		})
+
+		vc := config.NewViperConfigIn(overlayOnly)
+
+		cfg, err := vc.LoadUnvalidated()
+		require.NoError(t, err)
+		assert.Equal(t, 8094, cfg.App.Port)
+		assert.Empty(t, vc.GetConfigFilePath())
+	})
+
+	t.Run("invalid overlay", func(t *testing.T) {
+		broken := writeConfigFiles(t, map[string]string{
+			"config.local.yaml": "app: [\n",
+		})
+
+		_, err := config.NewViperConfigIn(broken).LoadUnvalidated()
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "config.local.yaml")
+	})
+}
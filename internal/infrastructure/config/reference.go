@@ -0,0 +1,176 @@
+package config
+
+import (
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/spf13/viper"
+)
+
+// KeyDoc documents one configuration key
+type KeyDoc struct {
+	// Key is the Viper key; "[]" marks the fields of list entries, such as billing.plans[].id
+	Key  string `json:"key"`
+	Type string `json:"type"`
+	// Default is the built-in value, empty when there is none
+	Default string `json:"default,omitempty"`
+	// Env lists the environment variables that set the key
+	Env         []string `json:"env,omitempty"`
+	Description string   `json:"description,omitempty"`
+}
+
+// durationType is the type of duration fields
+//
+//nolint:gochecknoglobals // Compared against while walking Config
+var durationType = reflect.TypeFor[time.Duration]()
+
+// Reference documents every key of Config: its type from the struct field,
+// its default from the Viper defaults, the environment variables that set it
+// and the field's desc tag. Keys follow the fields' json tags.
+func Reference() []KeyDoc {
+	v := viper.New()
+	setDefaults(v)
+
+	bound := make(map[string][]string, len(envBindings))
+	for _, binding := range envBindings {
+		bound[binding.Key] = binding.Env
+	}
+
+	var docs []KeyDoc
+
+	var walk func(typ reflect.Type, prefix string, inList bool)
+
+	walk = func(typ reflect.Type, prefix string, inList bool) {
+		for i := range typ.NumField() {
+			field := typ.Field(i)
+			name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+			if name == "-" {
+				// Secrets are left out of JSON; their key is the mapstructure tag
+				name, _, _ = strings.Cut(field.Tag.Get("mapstructure"), ",")
+			}
+
+			if name == "" && field.Tag.Get("json") == "-" || !field.IsExported() {
+				continue
+			}
+
+			if name == "" {
+				name = strings.ToLower(field.Name)
+			}
+
+			key := prefix + name
+			fieldType := field.Type
+
+			if fieldType.Kind() == reflect.Struct && fieldType != durationType {
+				walk(fieldType, key+".", inList)
+
+				continue
+			}
+
+			doc := KeyDoc{
+				Key:         key,
+				Type:        typeName(fieldType),
+				Description: field.Tag.Get("desc"),
+			}
+
+			if !inList {
+				doc.Default = formatDefault(v.Get(key))
+				doc.Env = envNames(key, bound[key])
+			}
+
+			docs = append(docs, doc)
+
+			if elem := fieldType; elem.Kind() == reflect.Slice && elem.Elem().Kind() == reflect.Struct {
+				walk(elem.Elem(), key+"[].", true)
+			}
+		}
+	}
+
+	walk(reflect.TypeFor[Config](), "", false)
+
+	return docs
+}
+
+// envNames returns the variable derived from key, as AutomaticEnv reads it,
+// followed by the explicitly bound ones
+func envNames(key string, bound []string) []string {
+	names := []string{strings.ToUpper(strings.ReplaceAll(key, ".", "_"))}
+
+	for _, name := range bound {
+		if !slices.Contains(names, name) {
+			names = append(names, name)
+		}
+	}
+
+	return names
+}
+
+// typeName describes a field's type for the reference
+func typeName(typ reflect.Type) string {
+	switch {
+	case typ == durationType:
+		return "duration"
+	case typ.Kind() == reflect.Pointer:
+		return typeName(typ.Elem())
+	case typ.Kind() == reflect.Slice && typ.Elem().Kind() == reflect.Struct:
+		return "list of objects"
+	case typ.Kind() == reflect.Slice:
+		return "list of " + typeName(typ.Elem())
+	case typ.Kind() == reflect.Map && typ.Elem().Kind() == reflect.Struct:
+		return "map of " + typeName(typ.Key()) + " to objects"
+	case typ.Kind() == reflect.Map:
+		return "map of " + typeName(typ.Key()) + " to " + typeName(typ.Elem())
+	case typ.Kind() == reflect.Interface:
+		return "any"
+	default:
+		return typ.Kind().String()
+	}
+}
+
+// formatDefault renders a default value; lists are comma separated
+func formatDefault(value any) string {
+	switch v := value.(type) {
+	case nil:
+		return ""
+	case []string:
+		return strings.Join(v, ",")
+	case time.Duration:
+		return v.String()
+	default:
+		return fmt.Sprint(v)
+	}
+}
+
+// ReferenceMarkdown renders the reference as a Markdown table
+func ReferenceMarkdown(docs []KeyDoc) string {
+	var out strings.Builder
+
+	out.WriteString("| Key | Type | Default | Environment | Description |\n")
+	out.WriteString("|-----|------|---------|-------------|-------------|\n")
+
+	cell := func(value string) string {
+		return strings.ReplaceAll(strings.ReplaceAll(value, "|", `\|`), "\n", " ")
+	}
+
+	code := func(value string) string {
+		if value == "" {
+			return ""
+		}
+
+		return "`" + cell(value) + "`"
+	}
+
+	for _, doc := range docs {
+		env := make([]string, 0, len(doc.Env))
+		for _, name := range doc.Env {
+			env = append(env, code(name))
+		}
+
+		fmt.Fprintf(&out, "| %s | %s | %s | %s | %s |\n",
+			code(doc.Key), doc.Type, code(doc.Default), strings.Join(env, ", "), cell(doc.Description))
+	}
+
+	return out.String()
+}
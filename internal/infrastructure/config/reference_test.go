@@ -0,0 +1,57 @@
+package config
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReference(t *testing.T) {
+	docs := Reference()
+
+	byKey := make(map[string]KeyDoc, len(docs))
+	for _, doc := range docs {
+		byKey[doc.Key] = doc
+	}
+
+	t.Run("every default is documented", func(t *testing.T) {
+		v := viper.New()
+		setDefaults(v)
+
+		for _, key := range v.AllKeys() {
+			assert.Contains(t, byKey, key, "default %s has no field in Config", key)
+		}
+	})
+
+	t.Run("every binding is documented", func(t *testing.T) {
+		for _, binding := range envBindings {
+			assert.Contains(t, byKey, binding.Key, "binding %s has no field in Config", binding.Key)
+		}
+	})
+
+	t.Run("key details", func(t *testing.T) {
+		port := byKey["app.port"]
+		assert.Equal(t, "int", port.Type)
+		assert.Equal(t, "8080", port.Default)
+		assert.Equal(t, []string{"APP_PORT"}, port.Env)
+		assert.NotEmpty(t, port.Description)
+
+		assert.Equal(t, []string{"DATABASE_HOST", "DB_HOST"}, byKey["database.host"].Env)
+		assert.Equal(t, "duration", byKey["updates.interval"].Type)
+		assert.Equal(t, "24h", byKey["updates.interval"].Default)
+	})
+
+	t.Run("secrets use their mapstructure key", func(t *testing.T) {
+		require.Contains(t, byKey, "billing.stripe.secret_key")
+		assert.Contains(t, byKey["billing.stripe.secret_key"].Env, "STRIPE_SECRET_KEY")
+	})
+
+	t.Run("markdown", func(t *testing.T) {
+		markdown := ReferenceMarkdown(docs)
+		assert.Contains(t, markdown, "| `app.port` | int | `8080` | `APP_PORT` |")
+		assert.Equal(t, len(docs)+2, strings.Count(markdown, "\n"))
+	})
+}
@@ -0,0 +1,49 @@
+package config
+
+import "time"
+
+// Outbound integrations that resilience.targets can tune
+const (
+	ResilienceTargetEmail        = "email"
+	ResilienceTargetHooks        = "hooks"
+	ResilienceTargetStripe       = "stripe"
+	ResilienceTargetStorage      = "storage"
+	ResilienceTargetUpdates      = "updates"
+	ResilienceTargetCrashReports = "crash_reports"
+	ResilienceTargetOptions      = "options"
+	ResilienceTargetGeoIP        = "geoip"
+)
+
+// ResilienceTargets lists the outbound integrations with a resilience policy
+var ResilienceTargets = []string{
+	ResilienceTargetEmail,
+	ResilienceTargetHooks,
+	ResilienceTargetStripe,
+	ResilienceTargetStorage,
+	ResilienceTargetUpdates,
+	ResilienceTargetCrashReports,
+	ResilienceTargetOptions,
+	ResilienceTargetGeoIP,
+}
+
+// ResilienceConfig holds the circuit breaker and retry settings of outbound calls
+type ResilienceConfig struct {
+	Enabled bool `json:"enabled" mapstructure:"enabled"`
+	// Targets override each integration's default policy, keyed by integration
+	Targets map[string]ResiliencePolicyConfig `json:"targets" mapstructure:"targets"`
+}
+
+// ResiliencePolicyConfig overrides an integration's policy. Zero values keep the default.
+type ResiliencePolicyConfig struct {
+	// MaxAttempts bounds the attempts of one call, the first included
+	MaxAttempts int `json:"max_attempts" mapstructure:"max_attempts"`
+	// Backoff is the delay before the first retry; it doubles up to MaxBackoff
+	Backoff    time.Duration `json:"backoff"     mapstructure:"backoff"`
+	MaxBackoff time.Duration `json:"max_backoff" mapstructure:"max_backoff"`
+	// Timeout is the budget of one call across all its attempts
+	Timeout time.Duration `json:"timeout" mapstructure:"timeout"`
+	// FailureThreshold is how many consecutive failures open the circuit
+	FailureThreshold int `json:"failure_threshold" mapstructure:"failure_threshold"`
+	// Cooldown is how long an open circuit rejects calls before a trial call
+	Cooldown time.Duration `json:"cooldown" mapstructure:"cooldown"`
+}
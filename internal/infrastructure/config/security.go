@@ -16,162 +16,216 @@ const MinAPIKeyLength = 16
 
 // SecurityConfig represents the enhanced security configuration
 type SecurityConfig struct {
-	CSRF            CSRFConfig            `json:"csrf"`
-	CORS            CORSConfig            `json:"cors"`
-	RateLimit       RateLimitConfig       `json:"rate_limit"`
-	CSP             CSPConfig             `json:"csp"`
-	TLS             TLSConfig             `json:"tls"`
-	Encryption      EncryptionConfig      `json:"encryption"`
-	SecurityHeaders SecurityHeadersConfig `json:"security_headers"`
-	CookieSecurity  CookieSecurityConfig  `json:"cookie_security"`
-	TrustProxy      TrustProxyConfig      `json:"trust_proxy"`
-	Assertion       AssertionConfig       `json:"assertion"`
-	APIKey          APIKeyConfig          `json:"api_key"`
-	SecureCookie    bool                  `json:"secure_cookie"`
-	Debug           bool                  `json:"debug"`
+	CSRF            CSRFConfig            `json:"csrf"             mapstructure:"csrf"`
+	CORS            CORSConfig            `json:"cors"             mapstructure:"cors"`
+	RateLimit       RateLimitConfig       `json:"rate_limit"       mapstructure:"rate_limit"`
+	CSP             CSPConfig             `json:"csp"              mapstructure:"csp"`
+	TLS             TLSConfig             `json:"tls"              mapstructure:"tls"`
+	Encryption      EncryptionConfig      `json:"encryption"       mapstructure:"encryption"`
+	SecurityHeaders SecurityHeadersConfig `json:"security_headers" mapstructure:"security_headers"`
+	CookieSecurity  CookieSecurityConfig  `json:"cookie_security"  mapstructure:"cookie_security"`
+	TrustProxy      TrustProxyConfig      `json:"trust_proxy"      mapstructure:"trust_proxy"`
+	Assertion       AssertionConfig       `json:"assertion"        mapstructure:"assertion"`
+	APIKey          APIKeyConfig          `json:"api_key"          mapstructure:"api_key"`
+	Events          SecurityEventsConfig  `json:"events"           mapstructure:"events"`
+	SecureCookie    bool                  `json:"secure_cookie"    mapstructure:"secure_cookie"`
+	Debug           bool                  `json:"debug"            mapstructure:"debug"`
 }
 
 // CSRFConfig represents enhanced CSRF configuration
 type CSRFConfig struct {
-	Enabled        bool     `json:"enabled"`
-	Secret         string   `json:"secret"`
-	TokenName      string   `json:"token_name"`
-	HeaderName     string   `json:"header_name"`
-	TokenLength    int      `json:"token_length"`
-	TokenLookup    string   `json:"token_lookup"`
-	ContextKey     string   `json:"context_key"`
-	CookieName     string   `json:"cookie_name"`
-	CookiePath     string   `json:"cookie_path"`
-	CookieDomain   string   `json:"cookie_domain"`
-	CookieHTTPOnly bool     `json:"cookie_http_only"`
-	CookieSameSite string   `json:"cookie_same_site"`
-	CookieMaxAge   int      `json:"cookie_max_age"`
-	CookieSecure   bool     `json:"cookie_secure"`
-	ErrorHandler   string   `json:"error_handler"`
-	SkipPaths      []string `json:"skip_paths"`
+	Enabled        bool     `json:"enabled"          mapstructure:"enabled"`
+	Secret         string   `json:"secret"           mapstructure:"secret"`
+	TokenName      string   `json:"token_name"       mapstructure:"token_name"`
+	HeaderName     string   `json:"header_name"      mapstructure:"header_name"`
+	TokenLength    int      `json:"token_length"     mapstructure:"token_length"`
+	TokenLookup    string   `json:"token_lookup"     mapstructure:"token_lookup"`
+	ContextKey     string   `json:"context_key"      mapstructure:"context_key"`
+	CookieName     string   `json:"cookie_name"      mapstructure:"cookie_name"`
+	CookiePath     string   `json:"cookie_path"      mapstructure:"cookie_path"`
+	CookieDomain   string   `json:"cookie_domain"    mapstructure:"cookie_domain"`
+	CookieHTTPOnly bool     `json:"cookie_http_only" mapstructure:"cookie_http_only"`
+	CookieSameSite string   `json:"cookie_same_site" mapstructure:"cookie_same_site"`
+	CookieMaxAge   int      `json:"cookie_max_age"   mapstructure:"cookie_max_age"`
+	CookieSecure   bool     `json:"cookie_secure"    mapstructure:"cookie_secure"`
+	ErrorHandler   string   `json:"error_handler"    mapstructure:"error_handler"`
+	SkipPaths      []string `json:"skip_paths"       mapstructure:"skip_paths"`
 }
 
 // CORSConfig represents enhanced CORS configuration
 type CORSConfig struct {
-	Enabled             bool     `json:"enabled"`
-	AllowedOrigins      []string `json:"allowed_origins"`
-	AllowedMethods      []string `json:"allowed_methods"`
-	AllowedHeaders      []string `json:"allowed_headers"`
-	ExposedHeaders      []string `json:"exposed_headers"`
-	AllowCredentials    bool     `json:"allow_credentials"`
-	MaxAge              int      `json:"max_age"`
-	AllowOriginPatterns []string `json:"allow_origin_patterns"`
-	AllowWildcardOrigin bool     `json:"allow_wildcard_origin"`
-	OptionStatusCode    int      `json:"option_status_code"`
+	Enabled             bool     `json:"enabled"               mapstructure:"enabled"`
+	AllowedOrigins      []string `json:"allowed_origins"       mapstructure:"allowed_origins"`
+	AllowedMethods      []string `json:"allowed_methods"       mapstructure:"allowed_methods"`
+	AllowedHeaders      []string `json:"allowed_headers"       mapstructure:"allowed_headers"`
+	ExposedHeaders      []string `json:"exposed_headers"       mapstructure:"exposed_headers"`
+	AllowCredentials    bool     `json:"allow_credentials"     mapstructure:"allow_credentials"`
+	MaxAge              int      `json:"max_age"               mapstructure:"max_age"`
+	AllowOriginPatterns []string `json:"allow_origin_patterns" mapstructure:"allow_origin_patterns"`
+	AllowWildcardOrigin bool     `json:"allow_wildcard_origin" mapstructure:"allow_wildcard_origin"`
+	OptionStatusCode    int      `json:"option_status_code"    mapstructure:"option_status_code"`
 }
 
 // RateLimitConfig represents enhanced rate limiting configuration
 type RateLimitConfig struct {
-	Enabled        bool                     `json:"enabled"`
-	RPS            int                      `json:"rps"`
-	Requests       int                      `json:"requests"` // Alias for RPS
-	Burst          int                      `json:"burst"`
-	Window         time.Duration            `json:"window"`
-	PerIP          bool                     `json:"per_ip"`
-	SkipPaths      []string                 `json:"skip_paths"`
-	SkipMethods    []string                 `json:"skip_methods"`
-	EndpointLimits map[string]EndpointLimit `json:"endpoint_limits"`
-	Store          string                   `json:"store"` // memory, redis
-	KeyGenerator   string                   `json:"key_generator"`
+	Enabled        bool                     `json:"enabled"         mapstructure:"enabled"`
+	RPS            int                      `json:"rps"             mapstructure:"rps"`
+	Requests       int                      `json:"requests"        mapstructure:"requests"` // Alias for RPS
+	Burst          int                      `json:"burst"           mapstructure:"burst"`
+	Window         time.Duration            `json:"window"          mapstructure:"window"`
+	PerIP          bool                     `json:"per_ip"          mapstructure:"per_ip"`
+	SkipPaths      []string                 `json:"skip_paths"      mapstructure:"skip_paths"`
+	SkipMethods    []string                 `json:"skip_methods"    mapstructure:"skip_methods"`
+	EndpointLimits map[string]EndpointLimit `json:"endpoint_limits" mapstructure:"endpoint_limits"`
+	Store          string                   `json:"store"           mapstructure:"store"` // memory, redis
+	KeyGenerator   string                   `json:"key_generator"   mapstructure:"key_generator"`
+	Authenticated  RateLimitTier            `json:"authenticated"   mapstructure:"authenticated"` // Per API key or user
+	Anonymous      RateLimitTier            `json:"anonymous"       mapstructure:"anonymous"`     // Per IP or form/origin
+	Mode           string                   `json:"mode"            mapstructure:"mode"`          // enforce, warn
+	Routes         []RouteRateLimit         `json:"routes"          mapstructure:"routes"`        // Override the limits handlers declare
+}
+
+// Rate limit modes
+const (
+	// RateLimitModeEnforce rejects requests over a limit with 429
+	RateLimitModeEnforce = "enforce"
+	// RateLimitModeWarn logs and counts requests over a limit but lets them through
+	RateLimitModeWarn = "warn"
+)
+
+// RouteRateLimit replaces the tier budgets on the routes at or below Path. With
+// a Method it applies to that one route only. Paths are Echo route patterns,
+// such as /forms/:id/unlock.
+type RouteRateLimit struct {
+	Path     string        `json:"path"     mapstructure:"path"`
+	Method   string        `json:"method"   mapstructure:"method"`
+	Requests int           `json:"requests" mapstructure:"requests"`
+	Window   time.Duration `json:"window"   mapstructure:"window"`
+	// Mode is enforce or warn; empty uses security.rate_limit.mode
+	Mode string `json:"mode" mapstructure:"mode"`
+}
+
+// RateLimitTier is a request budget per window for one class of client.
+// Zero requests disables the tier.
+type RateLimitTier struct {
+	Requests int           `json:"requests" mapstructure:"requests"`
+	Window   time.Duration `json:"window"   mapstructure:"window"`
 }
 
 // EndpointLimit represents specific rate limits for endpoints
 type EndpointLimit struct {
-	RPS    int           `json:"rps"`
-	Burst  int           `json:"burst"`
-	Window time.Duration `json:"window"`
+	RPS    int           `json:"rps"    mapstructure:"rps"`
+	Burst  int           `json:"burst"  mapstructure:"burst"`
+	Window time.Duration `json:"window" mapstructure:"window"`
 }
 
 // CSPConfig represents enhanced Content Security Policy configuration
 type CSPConfig struct {
-	Enabled     bool   `json:"enabled"`
-	DefaultSrc  string `json:"default_src"`
-	ScriptSrc   string `json:"script_src"`
-	StyleSrc    string `json:"style_src"`
-	ImgSrc      string `json:"img_src"`
-	ConnectSrc  string `json:"connect_src"`
-	FontSrc     string `json:"font_src"`
-	ObjectSrc   string `json:"object_src"`
-	MediaSrc    string `json:"media_src"`
-	FrameSrc    string `json:"frame_src"`
-	FormAction  string `json:"form_action"`
-	BaseURI     string `json:"base_uri"`
-	ManifestSrc string `json:"manifest_src"`
-	WorkerSrc   string `json:"worker_src"`
-	ReportURI   string `json:"report_uri"`
-	ReportOnly  bool   `json:"report_only"`
+	Enabled     bool   `json:"enabled"      mapstructure:"enabled"`
+	DefaultSrc  string `json:"default_src"  mapstructure:"default_src"`
+	ScriptSrc   string `json:"script_src"   mapstructure:"script_src"`
+	StyleSrc    string `json:"style_src"    mapstructure:"style_src"`
+	ImgSrc      string `json:"img_src"      mapstructure:"img_src"`
+	ConnectSrc  string `json:"connect_src"  mapstructure:"connect_src"`
+	FontSrc     string `json:"font_src"     mapstructure:"font_src"`
+	ObjectSrc   string `json:"object_src"   mapstructure:"object_src"`
+	MediaSrc    string `json:"media_src"    mapstructure:"media_src"`
+	FrameSrc    string `json:"frame_src"    mapstructure:"frame_src"`
+	FormAction  string `json:"form_action"  mapstructure:"form_action"`
+	BaseURI     string `json:"base_uri"     mapstructure:"base_uri"`
+	ManifestSrc string `json:"manifest_src" mapstructure:"manifest_src"`
+	WorkerSrc   string `json:"worker_src"   mapstructure:"worker_src"`
+	ReportURI   string `json:"report_uri"   mapstructure:"report_uri"`
+	ReportOnly  bool   `json:"report_only"  mapstructure:"report_only"`
 }
 
 // TLSConfig represents enhanced TLS configuration
 type TLSConfig struct {
-	Enabled      bool     `json:"enabled"`
-	CertFile     string   `json:"cert_file"`
-	KeyFile      string   `json:"key_file"`
-	MinVersion   string   `json:"min_version"`
-	CipherSuites []string `json:"cipher_suites"`
-	AutoCert     bool     `json:"auto_cert"`
-	AutoCertHost string   `json:"auto_cert_host"`
+	Enabled      bool     `json:"enabled"        mapstructure:"enabled"`
+	CertFile     string   `json:"cert_file"      mapstructure:"cert_file"`
+	KeyFile      string   `json:"key_file"       mapstructure:"key_file"`
+	MinVersion   string   `json:"min_version"    mapstructure:"min_version"`
+	CipherSuites []string `json:"cipher_suites"  mapstructure:"cipher_suites"`
+	AutoCert     bool     `json:"auto_cert"      mapstructure:"auto_cert"`
+	AutoCertHost string   `json:"auto_cert_host" mapstructure:"auto_cert_host"`
 }
 
 // SecurityHeadersConfig represents security headers configuration
 type SecurityHeadersConfig struct {
-	Enabled                 bool   `json:"enabled"`
-	XFrameOptions           string `json:"x_frame_options"`
-	XContentTypeOptions     string `json:"x_content_type_options"`
-	XXSSProtection          string `json:"xxss_protection"`
-	ReferrerPolicy          string `json:"referrer_policy"`
-	PermissionsPolicy       string `json:"permissions_policy"`
-	StrictTransportSecurity string `json:"strict_transport_security"`
-	ContentTypeNoSniff      bool   `json:"content_type_no_sniff"`
+	Enabled                 bool   `json:"enabled"                   mapstructure:"enabled"`
+	XFrameOptions           string `json:"x_frame_options"           mapstructure:"x_frame_options"`
+	XContentTypeOptions     string `json:"x_content_type_options"    mapstructure:"x_content_type_options"`
+	XXSSProtection          string `json:"x_xss_protection"          mapstructure:"x_xss_protection"`
+	ReferrerPolicy          string `json:"referrer_policy"           mapstructure:"referrer_policy"`
+	PermissionsPolicy       string `json:"permissions_policy"        mapstructure:"permissions_policy"`
+	StrictTransportSecurity string `json:"strict_transport_security" mapstructure:"strict_transport_security"`
+	ContentTypeNoSniff      bool   `json:"content_type_no_sniff"     mapstructure:"content_type_no_sniff"`
 }
 
 // CookieSecurityConfig represents default cookie security settings
 type CookieSecurityConfig struct {
-	Secure   bool   `json:"secure"`
-	HTTPOnly bool   `json:"http_only"`
-	SameSite string `json:"same_site"`
-	Path     string `json:"path"`
-	Domain   string `json:"domain"`
-	MaxAge   int    `json:"max_age"`
+	Secure   bool   `json:"secure"    mapstructure:"secure"`
+	HTTPOnly bool   `json:"http_only" mapstructure:"http_only"`
+	SameSite string `json:"same_site" mapstructure:"same_site"`
+	Path     string `json:"path"      mapstructure:"path"`
+	Domain   string `json:"domain"    mapstructure:"domain"`
+	MaxAge   int    `json:"max_age"   mapstructure:"max_age"`
 }
 
 // TrustProxyConfig represents proxy trust configuration
 type TrustProxyConfig struct {
-	Enabled        bool     `json:"enabled"`
-	TrustedProxies []string `json:"trusted_proxies"`
-	TrustedHeaders []string `json:"trusted_headers"`
+	Enabled        bool     `json:"enabled"         mapstructure:"enabled"`
+	TrustedProxies []string `json:"trusted_proxies" mapstructure:"trusted_proxies"`
+	TrustedHeaders []string `json:"trusted_headers" mapstructure:"trusted_headers"`
 }
 
 // EncryptionConfig represents encryption configuration
 type EncryptionConfig struct {
-	Key            string `json:"key"`
-	Algorithm      string `json:"algorithm"`
-	KeySize        int    `json:"key_size"`
-	SaltLength     int    `json:"salt_length"`
-	Iterations     int    `json:"iterations"`
-	EnableAES      bool   `json:"enable_aes"`
-	EnableChaCha20 bool   `json:"enable_cha_cha20"`
+	Key            string `json:"key"              mapstructure:"key"`
+	Algorithm      string `json:"algorithm"        mapstructure:"algorithm"`
+	KeySize        int    `json:"key_size"         mapstructure:"key_size"`
+	SaltLength     int    `json:"salt_length"      mapstructure:"salt_length"`
+	Iterations     int    `json:"iterations"       mapstructure:"iterations"`
+	EnableAES      bool   `json:"enable_aes"       mapstructure:"enable_aes"`
+	EnableChaCha20 bool   `json:"enable_cha_cha20" mapstructure:"enable_cha_cha20"`
 }
 
 // AssertionConfig represents Laravel signed assertion verification configuration
 type AssertionConfig struct {
-	Secret               string `json:"secret"`
-	TimestampSkewSeconds int    `json:"timestamp_skew_seconds"`
+	Secret               string `json:"secret"                 mapstructure:"secret"`
+	TimestampSkewSeconds int    `json:"timestamp_skew_seconds" mapstructure:"timestamp_skew_seconds"`
 }
 
 // APIKeyConfig represents API key authentication configuration
 type APIKeyConfig struct {
-	Enabled     bool     `json:"enabled"`
-	Keys        []string `json:"keys"`         // List of valid API keys
-	HeaderName  string   `json:"header_name"`  // Header name to read API key from (default: X-API-Key)
-	QueryParam  string   `json:"query_param"`  // Query parameter name (optional)
-	SkipPaths   []string `json:"skip_paths"`   // Paths to skip API key validation
-	SkipMethods []string `json:"skip_methods"` // HTTP methods to skip (e.g., OPTIONS)
+	Enabled     bool     `json:"enabled"      mapstructure:"enabled"`
+	Keys        []string `json:"keys"         mapstructure:"keys"`         // List of valid API keys
+	HeaderName  string   `json:"header_name"  mapstructure:"header_name"`  // Header name to read API key from (default: X-API-Key)
+	QueryParam  string   `json:"query_param"  mapstructure:"query_param"`  // Query parameter name (optional)
+	SkipPaths   []string `json:"skip_paths"   mapstructure:"skip_paths"`   // Paths to skip API key validation
+	SkipMethods []string `json:"skip_methods" mapstructure:"skip_methods"` // HTTP methods to skip (e.g., OPTIONS)
+}
+
+// SecurityEventsConfig configures detection of brute-force and anomalous traffic.
+// Each rule counts signals per client IP within its window; reaching the threshold
+// records a security event and, when the rule blocks, blocks the IP for BlockDuration.
+type SecurityEventsConfig struct {
+	Enabled         bool                    `json:"enabled"          mapstructure:"enabled"`
+	FailedLogin     SecurityEventRuleConfig `json:"failed_login"     mapstructure:"failed_login"`
+	SubmissionBurst SecurityEventRuleConfig `json:"submission_burst" mapstructure:"submission_burst"`
+	CSRFFailure     SecurityEventRuleConfig `json:"csrf_failure"     mapstructure:"csrf_failure"`
+	BlockDuration   time.Duration           `json:"block_duration"   mapstructure:"block_duration"`
+	// ExemptIPs are never blocked, such as the Laravel frontend's address
+	ExemptIPs []string `json:"exempt_ips" mapstructure:"exempt_ips"`
+	// NotifyEmails receive an email for every security event
+	NotifyEmails []string `json:"notify_emails" mapstructure:"notify_emails"`
+}
+
+// SecurityEventRuleConfig is a detection threshold. Zero threshold disables the rule.
+type SecurityEventRuleConfig struct {
+	Threshold int           `json:"threshold" mapstructure:"threshold"`
+	Window    time.Duration `json:"window"    mapstructure:"window"`
+	Block     bool          `json:"block"     mapstructure:"block"`
 }
 
 // Validate validates the security configuration
@@ -4,97 +4,275 @@ import (
 	"time"
 )
 
+// Email providers selectable with email.provider
+const (
+	EmailProviderSMTP     = "smtp"
+	EmailProviderSES      = "ses"
+	EmailProviderSendGrid = "sendgrid"
+	EmailProviderMailgun  = "mailgun"
+)
+
 // EmailConfig holds email-related configuration
 type EmailConfig struct {
-	Host       string `json:"host"`
-	Port       int    `json:"port"`
-	Username   string `json:"username"`
-	Password   string `json:"password"`
-	From       string `json:"from"`
-	UseTLS     bool   `json:"use_tls"`
-	UseSSL     bool   `json:"use_ssl"`
-	Template   string `json:"template"`
-	Timeout    int    `json:"timeout"`
-	MaxRetries int    `json:"max_retries"`
+	// Provider selects the delivery backend. When empty, SMTP is used if host
+	// is set and messages are only logged otherwise.
+	Provider string `json:"provider" mapstructure:"provider"`
+	Host     string `json:"host"     mapstructure:"host"`
+	Port     int    `json:"port"     mapstructure:"port"`
+	Username string `json:"username" mapstructure:"username"`
+	Password string `json:"password" mapstructure:"password"`
+	From     string `json:"from"     mapstructure:"from"`
+	UseTLS   bool   `json:"use_tls"  mapstructure:"use_tls"`
+	UseSSL   bool   `json:"use_ssl"  mapstructure:"use_ssl"`
+	Template string `json:"template" mapstructure:"template"`
+	Timeout  int    `json:"timeout"  mapstructure:"timeout"`
+	// MaxRetries bounds retries of failed deliveries. Zero uses the provider's
+	// default and a negative value disables retrying.
+	MaxRetries int `json:"max_retries" mapstructure:"max_retries"`
+	// RetryBackoff is the delay before the first retry, doubled for each
+	// further attempt. Zero uses the provider's default.
+	RetryBackoff time.Duration `json:"retry_backoff" mapstructure:"retry_backoff"`
+	// Suppressed lists addresses that never receive email
+	Suppressed []string            `json:"suppressed" mapstructure:"suppressed"`
+	SES        SESEmailConfig      `json:"ses"        mapstructure:"ses"`
+	SendGrid   SendGridEmailConfig `json:"sendgrid"   mapstructure:"sendgrid"`
+	Mailgun    MailgunEmailConfig  `json:"mailgun"    mapstructure:"mailgun"`
+	Webhooks   EmailWebhookConfig  `json:"webhooks"   mapstructure:"webhooks"`
+}
+
+// SESEmailConfig holds Amazon SES API settings
+type SESEmailConfig struct {
+	Region          string `json:"region"        mapstructure:"region"`
+	AccessKeyID     string `json:"access_key_id" mapstructure:"access_key_id"`
+	SecretAccessKey string `json:"-"             mapstructure:"secret_access_key"`
+	SessionToken    string `json:"-"             mapstructure:"session_token"`
+	// Endpoint overrides the regional API endpoint, e.g. for a VPC endpoint
+	Endpoint string `json:"endpoint" mapstructure:"endpoint"`
+}
+
+// SendGridEmailConfig holds SendGrid API settings
+type SendGridEmailConfig struct {
+	APIKey   string `json:"-"        mapstructure:"api_key"`
+	Endpoint string `json:"endpoint" mapstructure:"endpoint"`
+}
+
+// MailgunEmailConfig holds Mailgun API settings
+type MailgunEmailConfig struct {
+	Domain string `json:"domain" mapstructure:"domain"`
+	APIKey string `json:"-"      mapstructure:"api_key"`
+	// Endpoint selects the API region, e.g. https://api.eu.mailgun.net
+	Endpoint string `json:"endpoint" mapstructure:"endpoint"`
+}
+
+// EmailWebhookConfig holds settings for the bounce and complaint webhooks under
+// /api/v1/webhooks/email. Each provider's webhook is disabled until configured.
+type EmailWebhookConfig struct {
+	// SESTopicARNs lists the SNS topics whose SES notifications are accepted
+	SESTopicARNs []string `json:"ses_topic_arns" mapstructure:"ses_topic_arns"`
+	// SendGridVerificationKey is the public key of SendGrid's signed event webhook
+	SendGridVerificationKey string `json:"sendgrid_verification_key" mapstructure:"sendgrid_verification_key"`
+	// MaxAge rejects deliveries signed longer ago than this, limiting replays
+	MaxAge time.Duration `json:"max_age" mapstructure:"max_age"`
 }
 
+// Storage types selectable with storage.type
+const (
+	StorageTypeLocal = "local"
+	StorageTypeGCS   = "gcs"
+	StorageTypeAzure = "azure"
+)
+
 // StorageConfig holds storage-related configuration
 type StorageConfig struct {
-	Type        string             `json:"type"`
-	Local       LocalStorageConfig `json:"local"`
-	S3          S3StorageConfig    `json:"s3"`
-	MaxSize     int64              `json:"max_size"`
-	AllowedExts []string           `json:"allowed_exts"`
+	Type        string             `json:"type"               mapstructure:"type"`
+	Local       LocalStorageConfig `json:"local"              mapstructure:"local"`
+	S3          S3StorageConfig    `json:"s3"                 mapstructure:"s3"`
+	GCS         GCSStorageConfig   `json:"gcs"                mapstructure:"gcs"`
+	Azure       AzureStorageConfig `json:"azure"              mapstructure:"azure"`
+	MaxSize     int64              `json:"max_size"           mapstructure:"max_size"`
+	AllowedExts []string           `json:"allowed_extensions" mapstructure:"allowed_extensions"`
+	// SignedURLTTL is how long signed download URLs issued by cloud backends stay valid
+	SignedURLTTL time.Duration   `json:"signed_url_ttl" mapstructure:"signed_url_ttl"`
+	Backup       BackupConfig    `json:"backup"         mapstructure:"backup"`
+	Archive      ArchiveConfig   `json:"archive"        mapstructure:"archive"`
+	Analytics    AnalyticsConfig `json:"analytics"      mapstructure:"analytics"`
+}
+
+// BackupConfig holds scheduled application backup configuration. Backups are
+// written encrypted to the storage backend under Prefix.
+type BackupConfig struct {
+	Enabled bool `json:"enabled" mapstructure:"enabled"`
+	// Interval is the time between scheduled backups
+	Interval time.Duration `json:"interval" mapstructure:"interval"`
+	// Retention is the number of backups kept; older ones are deleted
+	Retention int `json:"retention" mapstructure:"retention"`
+	// EncryptionKey is the secret backups are encrypted with; a backup can only
+	// be restored with the key it was written with
+	EncryptionKey string `json:"-" mapstructure:"encryption_key"`
+	// Prefix is the directory or key prefix backups are stored under
+	Prefix string `json:"prefix" mapstructure:"prefix"`
+}
+
+// ArchiveConfig holds submission archiving configuration. Submissions older
+// than After are moved from the database to compressed files under Prefix.
+type ArchiveConfig struct {
+	Enabled bool `json:"enabled" mapstructure:"enabled"`
+	// After is the age, by submission time, at which submissions are archived
+	After time.Duration `json:"after" mapstructure:"after"`
+	// Interval is the time between archiving runs
+	Interval time.Duration `json:"interval" mapstructure:"interval"`
+	// BatchSize is the number of submissions moved per database round trip
+	BatchSize int `json:"batch_size" mapstructure:"batch_size"`
+	// Prefix is the directory or key prefix archives are stored under
+	Prefix string `json:"prefix" mapstructure:"prefix"`
+}
+
+// AnalyticsConfig holds the scheduled analytics export configuration. Each
+// form's submissions are written as a Parquet file under Prefix for BI tools.
+type AnalyticsConfig struct {
+	Enabled bool `json:"enabled" mapstructure:"enabled"`
+	// Interval is the time between exports
+	Interval time.Duration `json:"interval" mapstructure:"interval"`
+	// Prefix is the directory or key prefix exports are stored under
+	Prefix string `json:"prefix" mapstructure:"prefix"`
 }
 
 // LocalStorageConfig holds local storage configuration
 type LocalStorageConfig struct {
-	Path string `json:"path"`
+	Path string `json:"path" mapstructure:"path"`
 }
 
 // S3StorageConfig holds S3 storage configuration
 type S3StorageConfig struct {
-	Bucket    string `json:"bucket"`
-	Region    string `json:"region"`
-	AccessKey string `json:"access_key"`
-	SecretKey string `json:"secret_key"`
-	Endpoint  string `json:"endpoint"`
+	Bucket    string `json:"bucket"     mapstructure:"bucket"`
+	Region    string `json:"region"     mapstructure:"region"`
+	AccessKey string `json:"access_key" mapstructure:"access_key"`
+	SecretKey string `json:"secret_key" mapstructure:"secret_key"`
+	Endpoint  string `json:"endpoint"   mapstructure:"endpoint"`
+}
+
+// GCSStorageConfig holds Google Cloud Storage configuration
+type GCSStorageConfig struct {
+	Bucket    string `json:"bucket"     mapstructure:"bucket"`
+	ProjectID string `json:"project_id" mapstructure:"project_id"`
+	// CredentialsFile is a service account key file; with neither it nor CredentialsJSON
+	// set, application default credentials are used
+	CredentialsFile string `json:"credentials_file" mapstructure:"credentials_file"`
+	// CredentialsJSON is a service account key given inline
+	CredentialsJSON string `json:"-" mapstructure:"credentials_json"`
+	// KMSKeyName encrypts new objects with a customer-managed Cloud KMS key
+	KMSKeyName string `json:"kms_key_name" mapstructure:"kms_key_name"`
+	// Endpoint overrides the API endpoint, e.g. for an emulator
+	Endpoint string `json:"endpoint" mapstructure:"endpoint"`
+}
+
+// AzureStorageConfig holds Azure Blob Storage configuration
+type AzureStorageConfig struct {
+	AccountName string `json:"account_name" mapstructure:"account_name"`
+	// AccountKey authenticates requests and signs SAS URLs
+	AccountKey string `json:"-" mapstructure:"account_key"`
+	// ConnectionString replaces AccountName and AccountKey when set
+	ConnectionString string `json:"-"         mapstructure:"connection_string"`
+	Container        string `json:"container" mapstructure:"container"`
+	// EncryptionScope encrypts new blobs with a named encryption scope
+	EncryptionScope string `json:"encryption_scope" mapstructure:"encryption_scope"`
+	// Endpoint overrides the blob service URL, e.g. for Azurite
+	Endpoint string `json:"endpoint" mapstructure:"endpoint"`
 }
 
 // CacheConfig holds cache-related configuration
 type CacheConfig struct {
-	Type   string        `json:"type"`
-	Redis  RedisConfig   `json:"redis"`
-	Memory MemoryConfig  `json:"memory"`
-	TTL    time.Duration `json:"ttl"`
+	Type   string        `json:"type"   mapstructure:"type"`
+	Redis  RedisConfig   `json:"redis"  mapstructure:"redis"`
+	Memory MemoryConfig  `json:"memory" mapstructure:"memory"`
+	TTL    time.Duration `json:"ttl"    mapstructure:"ttl"`
+	// Forms configures caching of form aggregates in the form repository
+	Forms FormCacheConfig `json:"forms" mapstructure:"forms"`
+	// Pages configures caching of rendered public pages
+	Pages PageCacheConfig `json:"pages" mapstructure:"pages"`
+}
+
+// FormCacheConfig holds form repository cache configuration. The cache is
+// shared between instances, so it requires the redis cache type.
+type FormCacheConfig struct {
+	Enabled bool          `json:"enabled" mapstructure:"enabled"`
+	TTL     time.Duration `json:"ttl"     mapstructure:"ttl"`
+}
+
+// PageCacheConfig holds the rendered page cache configuration. Each instance
+// keeps its own pages keyed by form version, so no shared cache is needed.
+type PageCacheConfig struct {
+	Enabled    bool          `json:"enabled"     mapstructure:"enabled"`
+	TTL        time.Duration `json:"ttl"         mapstructure:"ttl"`
+	MaxEntries int           `json:"max_entries" mapstructure:"max_entries"`
 }
 
 // RedisConfig holds Redis cache configuration
 type RedisConfig struct {
-	Host     string `json:"host"`
-	Port     int    `json:"port"`
-	Password string `json:"password"`
-	DB       int    `json:"db"`
+	Host     string `json:"host"     mapstructure:"host"`
+	Port     int    `json:"port"     mapstructure:"port"`
+	Password string `json:"password" mapstructure:"password"`
+	DB       int    `json:"db"       mapstructure:"db"`
 }
 
 // MemoryConfig holds memory cache configuration
 type MemoryConfig struct {
-	MaxSize int `json:"max_size"`
+	MaxSize int `json:"max_size" mapstructure:"max_size"`
 }
 
 // LoggingConfig holds logging-related configuration
 type LoggingConfig struct {
-	Level      string `json:"level"`
-	Format     string `json:"format"`
-	Output     string `json:"output"`
-	File       string `json:"file"`
-	MaxSize    int    `json:"max_size"`
-	MaxBackups int    `json:"max_backups"`
-	MaxAge     int    `json:"max_age"`
-	Compress   bool   `json:"compress"`
+	Level      string `json:"level"       mapstructure:"level"       validate:"oneofci=debug info warn error fatal"`
+	Format     string `json:"format"      mapstructure:"format"      validate:"oneofci=json console"`
+	Output     string `json:"output"      mapstructure:"output"`
+	File       string `json:"file"        mapstructure:"file"`
+	MaxSize    int    `json:"max_size"    mapstructure:"max_size"    validate:"gt=0"`
+	MaxBackups int    `json:"max_backups" mapstructure:"max_backups" validate:"min=0"`
+	MaxAge     int    `json:"max_age"     mapstructure:"max_age"     validate:"min=0"`
+	Compress   bool   `json:"compress"    mapstructure:"compress"`
+	// Sanitization adds to and overrides the built-in log field sanitization
+	Sanitization LogSanitizationConfig `json:"sanitization" mapstructure:"sanitization"`
+}
+
+// LogSanitizationConfig holds operator rules for sanitizing log fields. Each
+// list holds key patterns, matched case-insensitively: a pattern with * or ?
+// is a glob matched against the whole key, any other pattern matches keys
+// containing it. Allow wins over every other rule; the configured strategies
+// win over the built-in rules, which mask keys such as "password" and shorten
+// IDs.
+type LogSanitizationConfig struct {
+	Mask     []string `desc:"Log field key patterns whose values are replaced with ****" json:"mask"     mapstructure:"mask"`
+	Hash     []string `desc:"Log field key patterns whose values are replaced with a short SHA-256 hash" json:"hash"     mapstructure:"hash"`
+	Truncate []string `desc:"Log field key patterns whose values are cut to truncate_length characters" json:"truncate" mapstructure:"truncate"`
+	Drop     []string `desc:"Log field key patterns left out of log entries" json:"drop"     mapstructure:"drop"`
+	Allow    []string `desc:"Log field key patterns logged as is, overriding the built-in rules" json:"allow"    mapstructure:"allow"`
+	// TruncateLength is the number of characters the truncate strategy keeps
+	TruncateLength int `desc:"Characters kept by the truncate strategy" json:"truncate_length" mapstructure:"truncate_length"`
 }
 
 // SessionConfig holds session-related configuration
 type SessionConfig struct {
-	Type       string        `json:"type"`
-	Secret     string        `json:"secret"`
-	MaxAge     time.Duration `json:"max_age"`
-	Domain     string        `json:"domain"`
-	Path       string        `json:"path"`
-	Secure     bool          `json:"secure"`
-	HTTPOnly   bool          `json:"http_only"`
-	SameSite   string        `json:"same_site"`
-	Store      string        `json:"store"`
-	StoreFile  string        `json:"store_file"`
-	CookieName string        `json:"cookie_name"`
+	Type       string        `json:"type"        mapstructure:"type"`
+	Secret     string        `json:"secret"      mapstructure:"secret"`
+	MaxAge     time.Duration `json:"max_age"     mapstructure:"max_age"`
+	Domain     string        `json:"domain"      mapstructure:"domain"`
+	Path       string        `json:"path"        mapstructure:"path"`
+	Secure     bool          `json:"secure"      mapstructure:"secure"`
+	HTTPOnly   bool          `json:"http_only"   mapstructure:"http_only"`
+	SameSite   string        `json:"same_site"   mapstructure:"same_site"`
+	Store      string        `json:"store"       mapstructure:"store"`
+	StoreFile  string        `json:"store_file"  mapstructure:"store_file"`
+	CookieName string        `json:"cookie_name" mapstructure:"cookie_name"`
+	// ImpersonationTTL is the lifetime of a session an admin opens as another user
+	ImpersonationTTL time.Duration `json:"impersonation_ttl" mapstructure:"impersonation_ttl"`
 }
 
 // AuthConfig holds authentication-related configuration
 type AuthConfig struct {
-	RequireEmailVerification bool          `json:"require_email_verification"`
-	PasswordMinLength        int           `json:"password_min_length"`
-	PasswordRequireSpecial   bool          `json:"password_require_special"`
-	SessionTimeout           time.Duration `json:"session_timeout"`
-	MaxLoginAttempts         int           `json:"max_login_attempts"`
-	LockoutDuration          time.Duration `json:"lockout_duration"`
+	RequireEmailVerification bool          `json:"require_email_verification" mapstructure:"require_email_verification"`
+	PasswordMinLength        int           `json:"password_min_length"        mapstructure:"password_min_length" validate:"min=6"`
+	PasswordRequireSpecial   bool          `json:"password_require_special"   mapstructure:"password_require_special"`
+	SessionTimeout           time.Duration `json:"session_timeout"            mapstructure:"session_timeout"     validate:"gt=0"`
+	MaxLoginAttempts         int           `json:"max_login_attempts"         mapstructure:"max_login_attempts"  validate:"gt=0"`
+	LockoutDuration          time.Duration `json:"lockout_duration"           mapstructure:"lockout_duration"    validate:"gt=0"`
 }
@@ -0,0 +1,15 @@
+package config
+
+import "time"
+
+// TimeoutsConfig holds the time budgets of the layers serving a request.
+// app.request_timeout bounds the whole request; each database statement and
+// outbound call made for it gets its layer's budget, cut short so it ends
+// Reserve before the request's deadline. A zero budget leaves that layer
+// bounded by the request deadline alone. Background work keeps its own limits.
+type TimeoutsConfig struct {
+	Database time.Duration `desc:"Time budget of one database statement made for a request; 0 for none" json:"database" mapstructure:"database"`
+	Outbound time.Duration `desc:"Time budget of one outbound call made for a request; 0 for none" json:"outbound" mapstructure:"outbound"`
+	// Reserve is kept back from the request deadline to write the response
+	Reserve time.Duration `desc:"Time kept back from the request deadline to write the response" json:"reserve" mapstructure:"reserve"`
+}
@@ -0,0 +1,18 @@
+package config
+
+import "time"
+
+// MinUpdateCheckInterval keeps periodic checks inside GitHub's unauthenticated rate limit
+const MinUpdateCheckInterval = time.Hour
+
+// UpdatesConfig holds the release update check settings. Checks are on by
+// default; setting updates.enabled to false (UPDATES_ENABLED=false) opts out.
+type UpdatesConfig struct {
+	Enabled bool `desc:"Checks GitHub for newer releases" json:"enabled" mapstructure:"enabled"`
+	// Repository is the GitHub owner/name whose releases are checked
+	Repository string `desc:"GitHub owner/name whose releases are checked" json:"repository" mapstructure:"repository"`
+	// Interval is how often a running server checks
+	Interval time.Duration `desc:"Time between checks of a running server; at least 1h" json:"interval" mapstructure:"interval"`
+	// APIURL is the GitHub API base URL
+	APIURL string `desc:"GitHub API base URL" json:"api_url" mapstructure:"api_url"`
+}
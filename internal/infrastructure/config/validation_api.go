@@ -28,4 +28,47 @@ func validateAPIConfig(cfg APIConfig, result *ValidationResult) {
 				"API rate limit burst must be positive", cfg.RateLimit.Burst)
 		}
 	}
+
+	if cfg.Idempotency.Enabled {
+		if cfg.Idempotency.Store != "memory" && cfg.Idempotency.Store != "redis" {
+			result.AddError("api.idempotency.store",
+				"idempotency store must be memory or redis", cfg.Idempotency.Store)
+		}
+
+		if cfg.Idempotency.TTL <= 0 {
+			result.AddError("api.idempotency.ttl",
+				"idempotency TTL must be positive", cfg.Idempotency.TTL)
+		}
+	}
+
+	if cfg.Metering.Enabled {
+		validateMeteringConfig(cfg.Metering, result)
+	}
+}
+
+// validateMeteringConfig validates API usage metering configuration
+func validateMeteringConfig(cfg MeteringConfig, result *ValidationResult) {
+	if cfg.Store != "memory" && cfg.Store != "redis" {
+		result.AddError("api.metering.store",
+			"metering store must be memory or redis", cfg.Store)
+	}
+
+	if cfg.FlushInterval <= 0 {
+		result.AddError("api.metering.flush_interval",
+			"metering flush interval must be positive", cfg.FlushInterval)
+	}
+
+	if cfg.MonthlyAPICalls < 0 {
+		result.AddError("api.metering.monthly_api_calls",
+			"monthly API call limit must be non-negative", cfg.MonthlyAPICalls)
+	}
+
+	for _, threshold := range cfg.Thresholds {
+		if threshold <= 0 || threshold > 100 {
+			result.AddError("api.metering.thresholds",
+				"metering thresholds must be percentages between 1 and 100", cfg.Thresholds)
+
+			break
+		}
+	}
 }
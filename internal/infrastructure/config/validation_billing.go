@@ -0,0 +1,67 @@
+// Package config provides validation utilities for Viper-based configuration
+package config
+
+import (
+	"fmt"
+	"net/url"
+)
+
+// validateBillingConfig validates billing configuration
+func validateBillingConfig(cfg BillingConfig, result *ValidationResult) {
+	if !cfg.Enabled {
+		return
+	}
+
+	if cfg.Stripe.SecretKey == "" {
+		result.AddError("billing.stripe.secret_key",
+			"Stripe secret key is required when billing is enabled", "")
+	}
+
+	if cfg.Stripe.WebhookSecret == "" {
+		result.AddError("billing.stripe.webhook_secret",
+			"Stripe webhook signing secret is required when billing is enabled", "")
+	}
+
+	for field, value := range map[string]string{
+		"billing.success_url":       cfg.SuccessURL,
+		"billing.cancel_url":        cfg.CancelURL,
+		"billing.portal_return_url": cfg.PortalReturnURL,
+	} {
+		if parsed, err := url.Parse(value); err != nil || parsed.Host == "" ||
+			(parsed.Scheme != "http" && parsed.Scheme != "https") {
+			result.AddError(field, "must be an absolute http or https URL", value)
+		}
+	}
+
+	if len(cfg.Plans) == 0 {
+		result.AddError("billing.plans", "at least one plan is required when billing is enabled", cfg.Plans)
+	}
+
+	ids := make(map[string]bool, len(cfg.Plans))
+	prices := make(map[string]bool, len(cfg.Plans))
+
+	for i, plan := range cfg.Plans {
+		field := fmt.Sprintf("billing.plans[%d]", i)
+
+		switch {
+		case plan.ID == "":
+			result.AddError(field+".id", "plan ID is required", plan.ID)
+		case ids[plan.ID]:
+			result.AddError(field+".id", "plan ID is used twice", plan.ID)
+		}
+
+		switch {
+		case plan.PriceID == "":
+			result.AddError(field+".price_id", "Stripe price ID is required", plan.PriceID)
+		case prices[plan.PriceID]:
+			result.AddError(field+".price_id", "Stripe price is used by two plans", plan.PriceID)
+		}
+
+		ids[plan.ID] = true
+		prices[plan.PriceID] = true
+
+		if plan.MaxForms < 0 || plan.MaxSubmissionsPerMonth < 0 || plan.MaxStorageBytes < 0 {
+			result.AddError(field, "plan limits cannot be negative", plan.ID)
+		}
+	}
+}
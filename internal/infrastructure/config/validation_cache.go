@@ -58,4 +58,22 @@ func validateCacheTTL(cfg CacheConfig, result *ValidationResult) {
 	if cfg.TTL <= 0 {
 		result.AddError("cache.ttl", "cache TTL must be positive", cfg.TTL)
 	}
+
+	if cfg.Forms.Enabled && cfg.Forms.TTL <= 0 {
+		result.AddError("cache.forms.ttl", "form cache TTL must be positive", cfg.Forms.TTL)
+	}
+
+	if cfg.Pages.Enabled && cfg.Pages.TTL <= 0 {
+		result.AddError("cache.pages.ttl", "page cache TTL must be positive", cfg.Pages.TTL)
+	}
+
+	if cfg.Pages.Enabled && cfg.Pages.MaxEntries <= 0 {
+		result.AddError("cache.pages.max_entries", "page cache max entries must be positive", cfg.Pages.MaxEntries)
+	}
+
+	// A per-instance memory cache serves forms changed on other instances
+	// until the TTL expires, which fails optimistic-locking updates
+	if cfg.Forms.Enabled && !strings.EqualFold(cfg.Type, "redis") {
+		result.AddError("cache.forms.enabled", "form cache requires the redis cache type", cfg.Type)
+	}
 }
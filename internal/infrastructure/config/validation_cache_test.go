@@ -0,0 +1,74 @@
+package config_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/goformx/goforms/internal/infrastructure/config"
+)
+
+func TestValidateConfig_FormCache(t *testing.T) {
+	redis := config.RedisConfig{Host: "localhost", Port: 6379}
+
+	tests := []struct {
+		name   string
+		cache  config.CacheConfig
+		fields []string
+	}{
+		{
+			name:  "disabled with memory cache",
+			cache: config.CacheConfig{Type: "memory", TTL: time.Hour},
+		},
+		{
+			name: "enabled with redis",
+			cache: config.CacheConfig{
+				Type: "redis", TTL: time.Hour, Redis: redis,
+				Forms: config.FormCacheConfig{Enabled: true, TTL: time.Minute},
+			},
+		},
+		{
+			name: "enabled with memory cache and no TTL",
+			cache: config.CacheConfig{
+				Type: "memory", TTL: time.Hour,
+				Forms: config.FormCacheConfig{Enabled: true},
+			},
+			fields: []string{"cache.forms.ttl", "cache.forms.enabled"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := config.ValidateConfig(&config.Config{Cache: tt.cache})
+
+			var fields []string
+
+			for _, validationErr := range result.Errors {
+				if strings.HasPrefix(validationErr.Field, "cache.forms.") {
+					fields = append(fields, validationErr.Field)
+				}
+			}
+
+			assert.ElementsMatch(t, tt.fields, fields)
+		})
+	}
+}
+
+func TestValidateConfig_PageCache(t *testing.T) {
+	result := config.ValidateConfig(&config.Config{Cache: config.CacheConfig{
+		Type: "memory", TTL: time.Hour,
+		Pages: config.PageCacheConfig{Enabled: true},
+	}})
+
+	var fields []string
+
+	for _, validationErr := range result.Errors {
+		if strings.HasPrefix(validationErr.Field, "cache.pages.") {
+			fields = append(fields, validationErr.Field)
+		}
+	}
+
+	assert.ElementsMatch(t, []string{"cache.pages.ttl", "cache.pages.max_entries"}, fields)
+}
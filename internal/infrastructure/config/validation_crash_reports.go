@@ -0,0 +1,25 @@
+package config
+
+import "net/url"
+
+// validateCrashReportsConfig validates crash report configuration
+func validateCrashReportsConfig(cfg CrashReportsConfig, result *ValidationResult) {
+	if !cfg.Enabled {
+		return
+	}
+
+	if cfg.Directory == "" {
+		result.AddError("crash_reports.directory", "is required when crash reports are enabled", cfg.Directory)
+	}
+
+	if cfg.MaxReports < 1 {
+		result.AddError("crash_reports.max_reports", "must be at least 1", cfg.MaxReports)
+	}
+
+	if cfg.TrackerURL != "" {
+		parsed, err := url.Parse(cfg.TrackerURL)
+		if err != nil || parsed.Host == "" || (parsed.Scheme != "http" && parsed.Scheme != "https") {
+			result.AddError("crash_reports.tracker_url", "must be an http or https URL", cfg.TrackerURL)
+		}
+	}
+}
@@ -0,0 +1,9 @@
+// Package config provides validation utilities for Viper-based configuration
+package config
+
+// validateDeadLetterConfig validates dead-letter queue configuration
+func validateDeadLetterConfig(cfg DeadLetterConfig, result *ValidationResult) {
+	if cfg.Enabled && cfg.Retention < MinDeadLetterRetention {
+		result.AddError("dead_letter.retention", "must be at least 1h", cfg.Retention)
+	}
+}
@@ -1,8 +1,88 @@
 // Package config provides validation utilities for Viper-based configuration
 package config
 
+import (
+	"crypto/x509"
+	"encoding/base64"
+	"regexp"
+	"strings"
+)
+
+// snsTopicARNPattern matches SNS topic ARNs in any AWS partition
+var snsTopicARNPattern = regexp.MustCompile(`^arn:aws[a-z-]*:sns:[a-z0-9-]+:\d{12}:[A-Za-z0-9_-]+(\.fifo)?$`)
+
 // validateEmailConfig validates email configuration
 func validateEmailConfig(cfg EmailConfig, result *ValidationResult) {
+	switch strings.ToLower(cfg.Provider) {
+	case "":
+		validateEmailSMTP(cfg, result)
+	case EmailProviderSMTP:
+		if cfg.Host == "" {
+			result.AddError("email.host", "email host is required for the smtp provider", cfg.Host)
+		}
+
+		validateEmailSMTP(cfg, result)
+	case EmailProviderSES:
+		validateEmailSES(cfg.SES, result)
+	case EmailProviderSendGrid:
+		if cfg.SendGrid.APIKey == "" {
+			result.AddError("email.sendgrid.api_key", "SendGrid API key is required", "")
+		}
+	case EmailProviderMailgun:
+		if cfg.Mailgun.Domain == "" {
+			result.AddError("email.mailgun.domain", "Mailgun sending domain is required", cfg.Mailgun.Domain)
+		}
+
+		if cfg.Mailgun.APIKey == "" {
+			result.AddError("email.mailgun.api_key", "Mailgun API key is required", "")
+		}
+	default:
+		result.AddError("email.provider", "unsupported email provider", cfg.Provider)
+	}
+
+	// API providers reject messages without a sender
+	if cfg.Provider != "" && !strings.EqualFold(cfg.Provider, EmailProviderSMTP) && cfg.From == "" {
+		result.AddError("email.from", "email from address is required", cfg.From)
+	}
+
+	if cfg.RetryBackoff < 0 {
+		result.AddError("email.retry_backoff", "email retry backoff cannot be negative", cfg.RetryBackoff)
+	}
+
+	for _, address := range cfg.Suppressed {
+		if !isValidEmail(address) {
+			result.AddError("email.suppressed", "invalid email format", address)
+		}
+	}
+
+	validateEmailWebhooks(cfg.Webhooks, result)
+}
+
+func validateEmailWebhooks(cfg EmailWebhookConfig, result *ValidationResult) {
+	for _, arn := range cfg.SESTopicARNs {
+		if !snsTopicARNPattern.MatchString(arn) {
+			result.AddError("email.webhooks.ses_topic_arns", "invalid SNS topic ARN", arn)
+		}
+	}
+
+	if cfg.SendGridVerificationKey != "" {
+		der, err := base64.StdEncoding.DecodeString(cfg.SendGridVerificationKey)
+		if err == nil {
+			_, err = x509.ParsePKIXPublicKey(der)
+		}
+
+		if err != nil {
+			result.AddError("email.webhooks.sendgrid_verification_key",
+				"SendGrid verification key must be a base64 encoded public key", "")
+		}
+	}
+
+	if cfg.MaxAge < 0 {
+		result.AddError("email.webhooks.max_age", "email webhook max age cannot be negative", cfg.MaxAge)
+	}
+}
+
+func validateEmailSMTP(cfg EmailConfig, result *ValidationResult) {
 	if cfg.Host != "" {
 		if cfg.Username == "" {
 			result.AddError("email.username",
@@ -20,3 +100,17 @@ func validateEmailConfig(cfg EmailConfig, result *ValidationResult) {
 		}
 	}
 }
+
+func validateEmailSES(cfg SESEmailConfig, result *ValidationResult) {
+	if cfg.Region == "" && cfg.Endpoint == "" {
+		result.AddError("email.ses.region", "SES region is required", cfg.Region)
+	}
+
+	if cfg.AccessKeyID == "" {
+		result.AddError("email.ses.access_key_id", "SES access key ID is required", cfg.AccessKeyID)
+	}
+
+	if cfg.SecretAccessKey == "" {
+		result.AddError("email.ses.secret_access_key", "SES secret access key is required", "")
+	}
+}
@@ -0,0 +1,97 @@
+package config_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/goformx/goforms/internal/infrastructure/config"
+)
+
+func TestValidateConfig_Email(t *testing.T) {
+	tests := []struct {
+		name   string
+		email  config.EmailConfig
+		fields []string
+	}{
+		{
+			name:  "not configured",
+			email: config.EmailConfig{},
+		},
+		{
+			name:  "smtp from host",
+			email: config.EmailConfig{Host: "smtp.example.com", Port: 587, Username: "user", From: "a@example.com"},
+		},
+		{
+			name:   "explicit smtp without host",
+			email:  config.EmailConfig{Provider: config.EmailProviderSMTP},
+			fields: []string{"email.host"},
+		},
+		{
+			name: "ses",
+			email: config.EmailConfig{
+				Provider: config.EmailProviderSES,
+				From:     "a@example.com",
+				SES:      config.SESEmailConfig{Region: "eu-west-1", AccessKeyID: "AKID", SecretAccessKey: "secret"},
+			},
+		},
+		{
+			name:   "ses without credentials or sender",
+			email:  config.EmailConfig{Provider: config.EmailProviderSES, SES: config.SESEmailConfig{Region: "eu-west-1"}},
+			fields: []string{"email.ses.access_key_id", "email.ses.secret_access_key", "email.from"},
+		},
+		{
+			name:   "sendgrid without API key",
+			email:  config.EmailConfig{Provider: config.EmailProviderSendGrid, From: "a@example.com"},
+			fields: []string{"email.sendgrid.api_key"},
+		},
+		{
+			name:   "mailgun without domain",
+			email:  config.EmailConfig{Provider: config.EmailProviderMailgun, From: "a@example.com", Mailgun: config.MailgunEmailConfig{APIKey: "key"}},
+			fields: []string{"email.mailgun.domain"},
+		},
+		{
+			name:   "unsupported provider and invalid suppressed address",
+			email:  config.EmailConfig{Provider: "pigeon", From: "a@example.com", Suppressed: []string{"nobody"}},
+			fields: []string{"email.provider", "email.suppressed"},
+		},
+		{
+			name: "webhooks",
+			email: config.EmailConfig{Webhooks: config.EmailWebhookConfig{
+				SESTopicARNs: []string{"arn:aws:sns:us-east-1:123456789012:ses-notifications"},
+				SendGridVerificationKey: "MFkwEwYHKoZIzj0CAQYIKoZIzj0DAQcDQgAECVjY/fBdkOIZunTka8gDAmWCNlbAT1LE" +
+					"8KN9UeoxasGtVRkHcMAuwHi/aJGlttA1pf6WezXNIjo6Jx+YM6lRFg==",
+				MaxAge: time.Hour,
+			}},
+		},
+		{
+			name: "webhooks with invalid settings",
+			email: config.EmailConfig{Webhooks: config.EmailWebhookConfig{
+				SESTopicARNs:            []string{"ses-notifications"},
+				SendGridVerificationKey: "not a key",
+				MaxAge:                  -time.Second,
+			}},
+			fields: []string{
+				"email.webhooks.ses_topic_arns", "email.webhooks.sendgrid_verification_key", "email.webhooks.max_age",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := config.ValidateConfig(&config.Config{Email: tt.email})
+
+			var fields []string
+
+			for _, validationErr := range result.Errors {
+				if strings.HasPrefix(validationErr.Field, "email.") {
+					fields = append(fields, validationErr.Field)
+				}
+			}
+
+			assert.ElementsMatch(t, tt.fields, fields)
+		})
+	}
+}
@@ -0,0 +1,9 @@
+// Package config provides validation utilities for Viper-based configuration
+package config
+
+// validateEventsConfig validates domain event configuration
+func validateEventsConfig(cfg EventsConfig, result *ValidationResult) {
+	if cfg.Store.Enabled && cfg.Store.Retention < MinEventRetention {
+		result.AddError("events.store.retention", "must be at least 1h", cfg.Store.Retention)
+	}
+}
@@ -0,0 +1,29 @@
+// Package config provides validation utilities for Viper-based configuration
+package config
+
+import "regexp"
+
+// flagKeyPattern matches feature flag keys: lower-case words separated by dots, dashes or underscores
+var flagKeyPattern = regexp.MustCompile(`^[a-z0-9]+([._-][a-z0-9]+)*$`)
+
+// validateFlagsConfig validates feature flag configuration
+func validateFlagsConfig(cfg FlagsConfig, result *ValidationResult) {
+	if cfg.RefreshInterval <= 0 {
+		result.AddError("flags.refresh_interval",
+			"flag refresh interval must be positive", cfg.RefreshInterval)
+	}
+
+	for key, flag := range cfg.Definitions {
+		field := "flags.definitions." + key
+
+		if len(key) > 100 || !flagKeyPattern.MatchString(key) {
+			result.AddError(field,
+				"flag key must be at most 100 lower-case letters and digits separated by dots, dashes or underscores", key)
+		}
+
+		if flag.Percentage != nil && (*flag.Percentage < 0 || *flag.Percentage > 100) {
+			result.AddError(field+".percentage",
+				"flag percentage must be between 0 and 100", *flag.Percentage)
+		}
+	}
+}
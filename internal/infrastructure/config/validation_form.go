@@ -1,6 +1,20 @@
 // Package config provides validation utilities for Viper-based configuration
 package config
 
+import (
+	"fmt"
+	"net/url"
+	"regexp"
+	"slices"
+	"strings"
+)
+
+// extensionNamePattern matches extension names; it mirrors model.ExtensionNamePattern
+var extensionNamePattern = regexp.MustCompile(`^[a-z0-9][a-z0-9_-]{0,63}$`)
+
+// extensionPoints are the form extension hook points
+var extensionPoints = []string{"pre_validate", "post_submit", "pre_render"}
+
 // validateFormConfig validates form configuration
 func validateFormConfig(cfg FormConfig, result *ValidationResult) {
 	if cfg.MaxFileSize <= 0 {
@@ -22,4 +36,230 @@ func validateFormConfig(cfg FormConfig, result *ValidationResult) {
 		result.AddError("form.validation.max_errors",
 			"max errors must be positive", cfg.Validation.MaxErrors)
 	}
+
+	if cfg.Quota.MaxFormsPerUser < 0 {
+		result.AddError("form.quota.max_forms_per_user",
+			"max forms per user must not be negative", cfg.Quota.MaxFormsPerUser)
+	}
+
+	if cfg.Quota.MaxSubmissionsPerMonth < 0 {
+		result.AddError("form.quota.max_submissions_per_month",
+			"max submissions per month must not be negative", cfg.Quota.MaxSubmissionsPerMonth)
+	}
+
+	if cfg.Quota.MaxStorageBytes < 0 {
+		result.AddError("form.quota.max_storage_bytes",
+			"max storage bytes must not be negative", cfg.Quota.MaxStorageBytes)
+	}
+
+	validateFormThrottle(cfg.Throttle, result)
+	validateFormExtensions(cfg.Extensions, result)
+	validateFormScripts(cfg.Scripts, result)
+	validateFormEncryption(cfg.Encryption, result)
+	validateFormOptions(cfg.Options, result)
+	validateFormDatasets(cfg.Datasets, result)
+
+	if cfg.TestSubmissions.Retention < MinTestSubmissionRetention {
+		result.AddError("form.test_submissions.retention",
+			"test submission retention must be at least 1h", cfg.TestSubmissions.Retention)
+	}
+
+	if cfg.Images.MaxPixels < 0 {
+		result.AddError("form.images.max_pixels", "image max pixels must not be negative", cfg.Images.MaxPixels)
+	}
+}
+
+func validateFormThrottle(cfg ThrottleConfig, result *ValidationResult) {
+	if !cfg.Enabled {
+		return
+	}
+
+	if cfg.Multiplier <= 1 {
+		result.AddError("form.throttle.multiplier",
+			"throttle multiplier must be greater than 1", cfg.Multiplier)
+	}
+
+	if cfg.MinRate <= 0 {
+		result.AddError("form.throttle.min_rate",
+			"throttle minimum rate must be positive", cfg.MinRate)
+	}
+
+	if cfg.Cooldown <= 0 {
+		result.AddError("form.throttle.cooldown",
+			"throttle cooldown must be positive", cfg.Cooldown)
+	}
+}
+
+func validateFormExtensions(cfg ExtensionsConfig, result *ValidationResult) {
+	if !cfg.Enabled {
+		return
+	}
+
+	if cfg.Timeout <= 0 {
+		result.AddError("form.extensions.timeout",
+			"extension timeout must be positive", cfg.Timeout)
+	}
+
+	names := make(map[string]bool, len(cfg.Hooks))
+
+	for i, hook := range cfg.Hooks {
+		field := fmt.Sprintf("form.extensions.hooks[%d]", i)
+
+		if !extensionNamePattern.MatchString(hook.Name) {
+			result.AddError(field+".name",
+				"hook name must be lowercase letters, digits, - or _", hook.Name)
+		} else if names[hook.Name] {
+			result.AddError(field+".name", "hook name is used twice", hook.Name)
+		}
+
+		names[hook.Name] = true
+
+		if parsed, err := url.Parse(hook.URL); err != nil || parsed.Host == "" ||
+			(parsed.Scheme != "http" && parsed.Scheme != "https") {
+			result.AddError(field+".url", "hook URL must be an absolute http or https URL", hook.URL)
+		}
+
+		if len(hook.Points) == 0 {
+			result.AddError(field+".points", "hook must handle at least one point", hook.Points)
+		}
+
+		for _, point := range hook.Points {
+			if !slices.Contains(extensionPoints, point) {
+				result.AddError(field+".points",
+					"hook points must be pre_validate, post_submit or pre_render", point)
+			}
+		}
+
+		validateExtensionSettings(field, hook.ExtensionSettingsConfig, result)
+	}
+
+	for name, settings := range cfg.Plugins {
+		validateExtensionSettings("form.extensions.plugins."+name, settings, result)
+	}
+}
+
+func validateExtensionSettings(field string, cfg ExtensionSettingsConfig, result *ValidationResult) {
+	if cfg.Timeout < 0 {
+		result.AddError(field+".timeout", "extension timeout must not be negative", cfg.Timeout)
+	}
+
+	if cfg.OnError != "" && cfg.OnError != "continue" && cfg.OnError != "reject" {
+		result.AddError(field+".on_error", "on_error must be continue or reject", cfg.OnError)
+	}
+}
+
+func validateFormOptions(cfg OptionSourcesConfig, result *ValidationResult) {
+	if cfg.TTL <= 0 {
+		result.AddError("form.options.ttl", "option source TTL must be positive", cfg.TTL)
+	}
+
+	if cfg.Timeout <= 0 {
+		result.AddError("form.options.timeout", "option source timeout must be positive", cfg.Timeout)
+	}
+
+	names := make(map[string]bool, len(cfg.Sources))
+
+	for i, source := range cfg.Sources {
+		field := fmt.Sprintf("form.options.sources[%d]", i)
+
+		if !extensionNamePattern.MatchString(source.Name) {
+			result.AddError(field+".name",
+				"option source name must be lowercase letters, digits, - or _", source.Name)
+		} else if names[source.Name] {
+			result.AddError(field+".name", "option source name is used twice", source.Name)
+		}
+
+		names[source.Name] = true
+
+		if parsed, err := url.Parse(source.URL); err != nil || parsed.Host == "" ||
+			(parsed.Scheme != "http" && parsed.Scheme != "https") {
+			result.AddError(field+".url", "option source URL must be an absolute http or https URL", source.URL)
+		}
+
+		if source.TTL < 0 {
+			result.AddError(field+".ttl", "option source TTL must not be negative", source.TTL)
+		}
+	}
+}
+
+func validateFormDatasets(cfg DatasetsConfig, result *ValidationResult) {
+	if cfg.CacheTTL < 0 {
+		result.AddError("form.datasets.cache_ttl", "dataset cache TTL must not be negative", cfg.CacheTTL)
+	}
+
+	if cfg.MaxRows <= 0 {
+		result.AddError("form.datasets.max_rows", "dataset max rows must be positive", cfg.MaxRows)
+	}
+
+	if cfg.MaxPerOwner < 0 {
+		result.AddError("form.datasets.max_per_owner", "datasets per owner must not be negative", cfg.MaxPerOwner)
+	}
+}
+
+func validateFormScripts(cfg ScriptsConfig, result *ValidationResult) {
+	if !cfg.Enabled {
+		return
+	}
+
+	if cfg.MaxSize <= 0 {
+		result.AddError("form.scripts.max_size", "script max size must be positive", cfg.MaxSize)
+	}
+
+	if cfg.MaxSteps <= 0 {
+		result.AddError("form.scripts.max_steps", "script max steps must be positive", cfg.MaxSteps)
+	}
+
+	if cfg.MaxMemory <= 0 {
+		result.AddError("form.scripts.max_memory", "script max memory must be positive", cfg.MaxMemory)
+	}
+
+	if cfg.Timeout <= 0 {
+		result.AddError("form.scripts.timeout", "script timeout must be positive", cfg.Timeout)
+	}
+
+	if cfg.OnError != "continue" && cfg.OnError != "reject" {
+		result.AddError("form.scripts.on_error", "on_error must be continue or reject", cfg.OnError)
+	}
+}
+
+func validateFormEncryption(cfg SubmissionEncryptionConfig, result *ValidationResult) {
+	if !cfg.Enabled {
+		return
+	}
+
+	if len(cfg.Keys) == 0 {
+		result.AddError("form.encryption.keys", "at least one encryption key is required", nil)
+	}
+
+	ids := make(map[string]bool, len(cfg.Keys))
+
+	for i, key := range cfg.Keys {
+		field := fmt.Sprintf("form.encryption.keys[%d]", i)
+
+		// The secret is never echoed back in the error
+		id, secret, ok := strings.Cut(key, ":")
+
+		switch {
+		case !ok || !extensionNamePattern.MatchString(id):
+			result.AddError(field, "key must be id:secret with an id of lowercase letters, digits, - or _", id)
+		case ids[id]:
+			result.AddError(field, "key id is used twice", id)
+		case len(secret) < MinEncryptionSecretLength:
+			result.AddError(field, fmt.Sprintf("key secret must be at least %d characters", MinEncryptionSecretLength), id)
+		}
+
+		ids[id] = true
+	}
+
+	if cfg.SettingCacheTTL < 0 {
+		result.AddError("form.encryption.setting_cache_ttl", "setting cache TTL must not be negative", cfg.SettingCacheTTL)
+	}
+
+	if cfg.RotationInterval < 0 {
+		result.AddError("form.encryption.rotation_interval", "rotation interval must not be negative", cfg.RotationInterval)
+	}
+
+	if cfg.RotationBatchSize <= 0 {
+		result.AddError("form.encryption.rotation_batch_size", "rotation batch size must be positive", cfg.RotationBatchSize)
+	}
 }
@@ -0,0 +1,282 @@
+package config_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/goformx/goforms/internal/infrastructure/config"
+)
+
+func TestValidateConfig_FormExtensions(t *testing.T) {
+	hook := config.ExtensionHookConfig{
+		Name:   "spam-check",
+		URL:    "https://hooks.example.com/spam",
+		Points: []string{"pre_validate"},
+	}
+
+	tests := []struct {
+		name       string
+		extensions config.ExtensionsConfig
+		fields     []string
+	}{
+		{
+			name:       "disabled with invalid hooks",
+			extensions: config.ExtensionsConfig{Hooks: []config.ExtensionHookConfig{{Name: "Bad Name"}}},
+		},
+		{
+			name: "valid hook and plugin override",
+			extensions: config.ExtensionsConfig{
+				Enabled: true,
+				Timeout: time.Second,
+				Hooks:   []config.ExtensionHookConfig{hook},
+				Plugins: map[string]config.ExtensionSettingsConfig{"geoip": {OnError: "reject", Global: true}},
+			},
+		},
+		{
+			name: "duplicate name, bad URL, unknown point and policy",
+			extensions: config.ExtensionsConfig{
+				Enabled: true,
+				Timeout: time.Second,
+				Hooks: []config.ExtensionHookConfig{
+					hook,
+					{
+						Name:                    "spam-check",
+						URL:                     "/relative",
+						Points:                  []string{"post_render"},
+						ExtensionSettingsConfig: config.ExtensionSettingsConfig{OnError: "retry"},
+					},
+				},
+			},
+			fields: []string{
+				"form.extensions.hooks[1].name",
+				"form.extensions.hooks[1].url",
+				"form.extensions.hooks[1].points",
+				"form.extensions.hooks[1].on_error",
+			},
+		},
+		{
+			name: "missing timeout and negative plugin timeout",
+			extensions: config.ExtensionsConfig{
+				Enabled: true,
+				Plugins: map[string]config.ExtensionSettingsConfig{"geoip": {Timeout: -time.Second}},
+			},
+			fields: []string{"form.extensions.timeout", "form.extensions.plugins.geoip.timeout"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := config.ValidateConfig(&config.Config{Form: config.FormConfig{Extensions: tt.extensions}})
+
+			var fields []string
+
+			for _, validationErr := range result.Errors {
+				if strings.HasPrefix(validationErr.Field, "form.extensions.") {
+					fields = append(fields, validationErr.Field)
+				}
+			}
+
+			assert.ElementsMatch(t, tt.fields, fields)
+		})
+	}
+}
+
+func TestValidateConfig_FormScripts(t *testing.T) {
+	valid := config.ScriptsConfig{
+		Enabled:   true,
+		MaxSize:   config.DefaultScriptMaxSize,
+		MaxSteps:  config.DefaultScriptMaxSteps,
+		MaxMemory: config.DefaultScriptMaxMemory,
+		Timeout:   config.DefaultScriptTimeout,
+		OnError:   "continue",
+	}
+
+	tests := []struct {
+		name    string
+		scripts config.ScriptsConfig
+		fields  []string
+	}{
+		{name: "disabled with zero limits", scripts: config.ScriptsConfig{}},
+		{name: "valid", scripts: valid},
+		{
+			name:    "zero limits and unknown policy",
+			scripts: config.ScriptsConfig{Enabled: true, OnError: "ignore"},
+			fields: []string{
+				"form.scripts.max_size",
+				"form.scripts.max_steps",
+				"form.scripts.max_memory",
+				"form.scripts.timeout",
+				"form.scripts.on_error",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := config.ValidateConfig(&config.Config{Form: config.FormConfig{Scripts: tt.scripts}})
+
+			var fields []string
+
+			for _, validationErr := range result.Errors {
+				if strings.HasPrefix(validationErr.Field, "form.scripts.") {
+					fields = append(fields, validationErr.Field)
+				}
+			}
+
+			assert.ElementsMatch(t, tt.fields, fields)
+		})
+	}
+}
+
+func TestValidateConfig_FormImages(t *testing.T) {
+	result := config.ValidateConfig(&config.Config{Form: config.FormConfig{Images: config.ImagesConfig{MaxPixels: -1}}})
+
+	var fields []string
+
+	for _, validationErr := range result.Errors {
+		if strings.HasPrefix(validationErr.Field, "form.images.") {
+			fields = append(fields, validationErr.Field)
+		}
+	}
+
+	assert.Equal(t, []string{"form.images.max_pixels"}, fields)
+}
+
+func TestValidateConfig_FormEncryption(t *testing.T) {
+	secret := strings.Repeat("s", config.MinEncryptionSecretLength)
+	valid := config.SubmissionEncryptionConfig{
+		Enabled:           true,
+		Keys:              []string{"k2:" + secret, "k1:" + secret},
+		SettingCacheTTL:   config.DefaultEncryptionSettingCacheTTL,
+		RotationInterval:  config.DefaultEncryptionRotationInterval,
+		RotationBatchSize: config.DefaultEncryptionRotationBatchSize,
+	}
+
+	tests := []struct {
+		name       string
+		encryption config.SubmissionEncryptionConfig
+		fields     []string
+	}{
+		{name: "disabled without keys", encryption: config.SubmissionEncryptionConfig{}},
+		{name: "valid", encryption: valid},
+		{
+			name:       "no keys",
+			encryption: config.SubmissionEncryptionConfig{Enabled: true, RotationBatchSize: 1},
+			fields:     []string{"form.encryption.keys"},
+		},
+		{
+			name: "malformed, duplicate and short keys",
+			encryption: config.SubmissionEncryptionConfig{
+				Enabled:           true,
+				Keys:              []string{secret, "k1:" + secret, "k1:" + secret, "k2:short"},
+				RotationInterval:  -time.Hour,
+				RotationBatchSize: 0,
+			},
+			fields: []string{
+				"form.encryption.keys[0]",
+				"form.encryption.keys[2]",
+				"form.encryption.keys[3]",
+				"form.encryption.rotation_interval",
+				"form.encryption.rotation_batch_size",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := config.ValidateConfig(&config.Config{Form: config.FormConfig{Encryption: tt.encryption}})
+
+			var fields []string
+
+			for _, validationErr := range result.Errors {
+				if strings.HasPrefix(validationErr.Field, "form.encryption.") {
+					fields = append(fields, validationErr.Field)
+				}
+			}
+
+			assert.ElementsMatch(t, tt.fields, fields)
+		})
+	}
+}
+
+func TestValidateConfig_FormOptions(t *testing.T) {
+	products := config.OptionSourceConfig{Name: "products", URL: "https://api.example.com/products"}
+
+	tests := []struct {
+		name    string
+		options config.OptionSourcesConfig
+		fields  []string
+	}{
+		{
+			name:    "valid",
+			options: config.OptionSourcesConfig{TTL: time.Minute, Timeout: time.Second, Sources: []config.OptionSourceConfig{products}},
+		},
+		{
+			name: "zero TTL and timeout, duplicate name, bad URL and negative TTL",
+			options: config.OptionSourcesConfig{Sources: []config.OptionSourceConfig{
+				products,
+				{Name: "products", URL: "ftp://example.com/list", TTL: -time.Second},
+			}},
+			fields: []string{
+				"form.options.ttl",
+				"form.options.timeout",
+				"form.options.sources[1].name",
+				"form.options.sources[1].url",
+				"form.options.sources[1].ttl",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := config.ValidateConfig(&config.Config{Form: config.FormConfig{Options: tt.options}})
+
+			var fields []string
+
+			for _, validationErr := range result.Errors {
+				if strings.HasPrefix(validationErr.Field, "form.options.") {
+					fields = append(fields, validationErr.Field)
+				}
+			}
+
+			assert.ElementsMatch(t, tt.fields, fields)
+		})
+	}
+}
+
+func TestValidateConfig_FormDatasets(t *testing.T) {
+	tests := []struct {
+		name     string
+		datasets config.DatasetsConfig
+		fields   []string
+	}{
+		{
+			name:     "valid",
+			datasets: config.DatasetsConfig{CacheTTL: time.Minute, MaxRows: 100},
+		},
+		{
+			name:     "negative TTL and owner limit, zero rows",
+			datasets: config.DatasetsConfig{CacheTTL: -time.Second, MaxPerOwner: -1},
+			fields:   []string{"form.datasets.cache_ttl", "form.datasets.max_rows", "form.datasets.max_per_owner"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := config.ValidateConfig(&config.Config{Form: config.FormConfig{Datasets: tt.datasets}})
+
+			var fields []string
+
+			for _, validationErr := range result.Errors {
+				if strings.HasPrefix(validationErr.Field, "form.datasets.") {
+					fields = append(fields, validationErr.Field)
+				}
+			}
+
+			assert.ElementsMatch(t, tt.fields, fields)
+		})
+	}
+}
@@ -0,0 +1,42 @@
+// Package config provides validation utilities for Viper-based configuration
+package config
+
+import (
+	"net/url"
+	"regexp"
+)
+
+// geoIPEditionPattern matches a MaxMind edition ID such as GeoLite2-Country
+var geoIPEditionPattern = regexp.MustCompile(`^[A-Za-z0-9-]{1,64}$`)
+
+// validateGeoIPConfig validates the submitter location settings
+func validateGeoIPConfig(cfg GeoIPConfig, result *ValidationResult) {
+	if !cfg.Enabled {
+		return
+	}
+
+	if cfg.DatabasePath == "" {
+		result.AddError("geoip.database_path", "is required when geoip is enabled", cfg.DatabasePath)
+	}
+
+	if !cfg.Update.Enabled {
+		return
+	}
+
+	if cfg.Update.AccountID == "" || cfg.Update.LicenseKey == "" {
+		result.AddError("geoip.update", "account_id and license_key are required to download the database", cfg.Update.AccountID)
+	}
+
+	if !geoIPEditionPattern.MatchString(cfg.Update.EditionID) {
+		result.AddError("geoip.update.edition_id", "must be a MaxMind edition ID such as GeoLite2-Country", cfg.Update.EditionID)
+	}
+
+	if cfg.Update.Interval < MinGeoIPUpdateInterval {
+		result.AddError("geoip.update.interval", "must be at least 1h", cfg.Update.Interval)
+	}
+
+	parsed, err := url.Parse(cfg.Update.URL)
+	if err != nil || parsed.Host == "" || (parsed.Scheme != "http" && parsed.Scheme != "https") {
+		result.AddError("geoip.update.url", "must be an http or https URL", cfg.Update.URL)
+	}
+}
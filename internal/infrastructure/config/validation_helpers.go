@@ -46,19 +46,32 @@ func (r *ValidationResult) AddError(field, message string, value any) {
 func ValidateConfig(cfg *Config) ValidationResult {
 	result := ValidationResult{IsValid: true}
 
+	// Validate the rules in the validate struct tags
+	validateTags(cfg, &result)
+
 	// Validate each configuration section
 	validateAppConfig(cfg.App, &result)
-	validateDatabaseConfig(cfg.Database, &result)
 	validateSecurityConfig(cfg.Security, &result)
 	validateEmailConfig(cfg.Email, &result)
 	validateStorageConfig(cfg.Storage, &result)
 	validateCacheConfig(cfg.Cache, &result)
 	validateLoggingConfig(cfg.Logging, &result)
 	validateSessionConfig(cfg.Session, &result)
-	validateAuthConfig(cfg.Auth, &result)
 	validateFormConfig(cfg.Form, &result)
 	validateAPIConfig(cfg.API, &result)
 	validateWebConfig(cfg.Web, &result)
+	validateFlagsConfig(cfg.Flags, &result)
+	validateBillingConfig(cfg.Billing, &result)
+	validateResilienceConfig(cfg.Resilience, &result)
+	validateHTTPClientConfig(cfg.HTTPClient, &result)
+	validateUpdatesConfig(cfg.Updates, &result)
+	validateEventsConfig(cfg.Events, &result)
+	validateDeadLetterConfig(cfg.DeadLetter, &result)
+	validatePIIConfig(cfg.PII, &result)
+	validateCrashReportsConfig(cfg.CrashReports, &result)
+	validateOpsConfig(cfg.Ops, &result)
+	validateTimeoutsConfig(cfg.Timeouts, cfg.App.RequestTimeout, &result)
+	validateGeoIPConfig(cfg.GeoIP, &result)
 	validateUserConfig(cfg.User, &result)
 
 	// Validate cross-section dependencies
@@ -0,0 +1,57 @@
+// Package config provides validation utilities for Viper-based configuration
+package config
+
+import (
+	"crypto/sha256"
+	"encoding/base64"
+	"fmt"
+	"net"
+	"net/url"
+	"slices"
+	"strings"
+)
+
+// validateHTTPClientConfig validates outbound HTTP client configuration
+func validateHTTPClientConfig(cfg HTTPClientConfig, result *ValidationResult) {
+	if cfg.Proxy != "" {
+		parsed, err := url.Parse(cfg.Proxy)
+		if err != nil || parsed.Host == "" ||
+			(parsed.Scheme != "http" && parsed.Scheme != "https" && parsed.Scheme != "socks5") {
+			result.AddError("http_client.proxy", "must be an http, https or socks5 URL", cfg.Proxy)
+		}
+	}
+
+	for integration, timeout := range cfg.Timeouts {
+		field := "http_client.timeouts." + integration
+
+		if !slices.Contains(ResilienceTargets, integration) {
+			result.AddError(field, "unknown outbound integration", integration)
+		}
+
+		if timeout < 0 {
+			result.AddError(field, "timeout cannot be negative", timeout)
+		}
+	}
+
+	for i, pin := range cfg.Pins {
+		field := fmt.Sprintf("http_client.pins[%d]", i)
+
+		switch {
+		case pin.Host == "":
+			result.AddError(field+".host", "pinned host is required", pin.Host)
+		case net.ParseIP(pin.Host) != nil:
+			result.AddError(field+".host", "must be a host name; IP connections carry no server name to pin", pin.Host)
+		}
+
+		if len(pin.SHA256) == 0 {
+			result.AddError(field+".sha256", "at least one public key digest is required", pin.SHA256)
+		}
+
+		for _, digest := range pin.SHA256 {
+			decoded, err := base64.StdEncoding.DecodeString(strings.TrimPrefix(digest, "sha256/"))
+			if err != nil || len(decoded) != sha256.Size {
+				result.AddError(field+".sha256", "must be base64 SHA-256 digests", digest)
+			}
+		}
+	}
+}
@@ -2,53 +2,16 @@
 package config
 
 import (
+	"fmt"
+	"path"
 	"path/filepath"
 	"strings"
 )
 
 // validateLoggingConfig validates logging configuration
 func validateLoggingConfig(cfg LoggingConfig, result *ValidationResult) {
-	validateLoggingLevel(cfg, result)
-	validateLoggingFormat(cfg, result)
-	validateLoggingFileOutput(cfg, result)
-	validateLoggingRotation(cfg, result)
-}
-
-func validateLoggingLevel(cfg LoggingConfig, result *ValidationResult) {
-	validLevels := []string{"debug", "info", "warn", "error", "fatal"}
-	levelValid := false
-
-	for _, level := range validLevels {
-		if strings.EqualFold(cfg.Level, level) {
-			levelValid = true
-
-			break
-		}
-	}
-
-	if !levelValid {
-		result.AddError("logging.level", "invalid log level", cfg.Level)
-	}
-}
-
-func validateLoggingFormat(cfg LoggingConfig, result *ValidationResult) {
-	validFormats := []string{"json", "console"}
-	formatValid := false
-
-	for _, format := range validFormats {
-		if strings.EqualFold(cfg.Format, format) {
-			formatValid = true
-
-			break
-		}
-	}
-
-	if !formatValid {
-		result.AddError("logging.format", "invalid log format", cfg.Format)
-	}
-}
+	validateLogSanitization(cfg.Sanitization, result)
 
-func validateLoggingFileOutput(cfg LoggingConfig, result *ValidationResult) {
 	if cfg.Output != "file" {
 		return
 	}
@@ -67,19 +30,48 @@ func validateLoggingFileOutput(cfg LoggingConfig, result *ValidationResult) {
 	}
 }
 
-func validateLoggingRotation(cfg LoggingConfig, result *ValidationResult) {
-	if cfg.MaxSize <= 0 {
-		result.AddError("logging.max_size",
-			"log max size must be positive", cfg.MaxSize)
+// validateLogSanitization checks the log sanitization key patterns. A pattern
+// may appear under one strategy only, so the rule a key gets is unambiguous.
+func validateLogSanitization(cfg LogSanitizationConfig, result *ValidationResult) {
+	if cfg.TruncateLength <= 0 {
+		result.AddError("logging.sanitization.truncate_length", "must be positive", cfg.TruncateLength)
 	}
 
-	if cfg.MaxBackups < 0 {
-		result.AddError("logging.max_backups",
-			"log max backups must be non-negative", cfg.MaxBackups)
-	}
+	seen := make(map[string]string)
+
+	for _, list := range []struct {
+		name     string
+		patterns []string
+	}{
+		{"mask", cfg.Mask},
+		{"hash", cfg.Hash},
+		{"truncate", cfg.Truncate},
+		{"drop", cfg.Drop},
+		{"allow", cfg.Allow},
+	} {
+		for i, pattern := range list.patterns {
+			field := fmt.Sprintf("logging.sanitization.%s[%d]", list.name, i)
+			normalized := strings.ToLower(strings.TrimSpace(pattern))
+
+			if normalized == "" {
+				result.AddError(field, "pattern must not be empty", pattern)
 
-	if cfg.MaxAge < 0 {
-		result.AddError("logging.max_age",
-			"log max age must be non-negative", cfg.MaxAge)
+				continue
+			}
+
+			if _, err := path.Match(normalized, ""); err != nil {
+				result.AddError(field, "invalid glob pattern", pattern)
+
+				continue
+			}
+
+			if other, ok := seen[normalized]; ok && other != list.name {
+				result.AddError(field, "pattern is also listed under "+other, pattern)
+
+				continue
+			}
+
+			seen[normalized] = list.name
+		}
 	}
 }
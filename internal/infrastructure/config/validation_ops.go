@@ -0,0 +1,25 @@
+package config
+
+import (
+	"fmt"
+	"net"
+	"strconv"
+)
+
+// validateOpsConfig validates runtime diagnostics configuration
+func validateOpsConfig(cfg OpsConfig, result *ValidationResult) {
+	if cfg.Address == "" {
+		return
+	}
+
+	_, port, err := net.SplitHostPort(cfg.Address)
+	if err != nil {
+		result.AddError("ops.address", "must be host:port", cfg.Address)
+	} else if number, convErr := strconv.Atoi(port); convErr != nil || number < 1 || number > 65535 {
+		result.AddError("ops.address", "must have a port between 1 and 65535", cfg.Address)
+	}
+
+	if len(cfg.Token) < MinOpsTokenLength {
+		result.AddError("ops.token", fmt.Sprintf("must be at least %d characters when ops.address is set", MinOpsTokenLength), "")
+	}
+}
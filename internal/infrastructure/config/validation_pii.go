@@ -0,0 +1,21 @@
+// Package config provides validation utilities for Viper-based configuration
+package config
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// piiProfiles are the national ID profiles; it mirrors pii.Profiles
+var piiProfiles = []string{"ca", "uk", "us"}
+
+// validatePIIConfig validates personal data detection configuration
+func validatePIIConfig(cfg PIIConfig, result *ValidationResult) {
+	for i, profile := range cfg.Profiles {
+		if !slices.Contains(piiProfiles, strings.ToLower(profile)) {
+			result.AddError(fmt.Sprintf("pii.profiles[%d]", i),
+				"unknown profile; must be one of "+strings.Join(piiProfiles, ", "), profile)
+		}
+	}
+}
@@ -0,0 +1,29 @@
+// Package config provides validation utilities for Viper-based configuration
+package config
+
+import "slices"
+
+// validateResilienceConfig validates outbound resilience configuration
+func validateResilienceConfig(cfg ResilienceConfig, result *ValidationResult) {
+	for target, policy := range cfg.Targets {
+		field := "resilience.targets." + target
+
+		if !slices.Contains(ResilienceTargets, target) {
+			result.AddError(field, "unknown outbound integration", target)
+
+			continue
+		}
+
+		if policy.MaxAttempts < 0 || policy.FailureThreshold < 0 {
+			result.AddError(field, "attempts and failure threshold cannot be negative", policy)
+		}
+
+		if policy.Backoff < 0 || policy.MaxBackoff < 0 || policy.Timeout < 0 || policy.Cooldown < 0 {
+			result.AddError(field, "durations cannot be negative", policy)
+		}
+
+		if policy.Backoff > 0 && policy.MaxBackoff > 0 && policy.MaxBackoff < policy.Backoff {
+			result.AddError(field+".max_backoff", "must not be less than backoff", policy.MaxBackoff)
+		}
+	}
+}
@@ -1,11 +1,17 @@
 // Package config provides validation utilities for Viper-based configuration
 package config
 
+import (
+	"fmt"
+	"strings"
+)
+
 // validateSecurityConfig validates security configuration
 func validateSecurityConfig(cfg SecurityConfig, result *ValidationResult) {
 	validateSecurityCSRF(cfg, result)
 	validateSecurityCORS(cfg, result)
 	validateSecurityRateLimit(cfg, result)
+	validateSecurityEvents(cfg, result)
 	validateSecurityTLS(cfg, result)
 }
 
@@ -55,6 +61,83 @@ func validateSecurityRateLimit(cfg SecurityConfig, result *ValidationResult) {
 		result.AddError("security.rate_limit.window",
 			"rate limit window must be positive", cfg.RateLimit.Window)
 	}
+
+	validateRateLimitTier("security.rate_limit.authenticated", cfg.RateLimit.Authenticated, result)
+	validateRateLimitTier("security.rate_limit.anonymous", cfg.RateLimit.Anonymous, result)
+
+	if !validRateLimitMode(cfg.RateLimit.Mode) {
+		result.AddError("security.rate_limit.mode",
+			"rate limit mode must be enforce or warn", cfg.RateLimit.Mode)
+	}
+
+	for i, route := range cfg.RateLimit.Routes {
+		field := fmt.Sprintf("security.rate_limit.routes[%d]", i)
+
+		if !strings.HasPrefix(route.Path, "/") {
+			result.AddError(field+".path", "route rate limit path must start with /", route.Path)
+		}
+
+		if route.Requests <= 0 {
+			result.AddError(field+".requests", "route rate limit requests must be positive", route.Requests)
+		}
+
+		if route.Window <= 0 {
+			result.AddError(field+".window", "route rate limit window must be positive", route.Window)
+		}
+
+		if !validRateLimitMode(route.Mode) {
+			result.AddError(field+".mode", "route rate limit mode must be enforce or warn", route.Mode)
+		}
+	}
+}
+
+// validRateLimitMode reports whether mode is a rate limit mode; empty uses the default
+func validRateLimitMode(mode string) bool {
+	return mode == "" || mode == RateLimitModeEnforce || mode == RateLimitModeWarn
+}
+
+func validateRateLimitTier(field string, tier RateLimitTier, result *ValidationResult) {
+	if tier.Requests < 0 {
+		result.AddError(field+".requests",
+			"rate limit tier requests must not be negative", tier.Requests)
+	}
+
+	if tier.Requests > 0 && tier.Window <= 0 {
+		result.AddError(field+".window",
+			"rate limit tier window must be positive", tier.Window)
+	}
+}
+
+func validateSecurityEvents(cfg SecurityConfig, result *ValidationResult) {
+	if !cfg.Events.Enabled {
+		return
+	}
+
+	rules := map[string]SecurityEventRuleConfig{
+		"failed_login":     cfg.Events.FailedLogin,
+		"submission_burst": cfg.Events.SubmissionBurst,
+		"csrf_failure":     cfg.Events.CSRFFailure,
+	}
+
+	blocks := false
+
+	for name, rule := range rules {
+		field := "security.events." + name
+		if rule.Threshold < 0 {
+			result.AddError(field+".threshold", "security event threshold must not be negative", rule.Threshold)
+		}
+
+		if rule.Threshold > 0 && rule.Window <= 0 {
+			result.AddError(field+".window", "security event window must be positive", rule.Window)
+		}
+
+		blocks = blocks || (rule.Threshold > 0 && rule.Block)
+	}
+
+	if blocks && cfg.Events.BlockDuration <= 0 {
+		result.AddError("security.events.block_duration",
+			"block duration must be positive when a security event rule blocks", cfg.Events.BlockDuration)
+	}
 }
 
 func validateSecurityTLS(cfg SecurityConfig, result *ValidationResult) {
@@ -51,6 +51,11 @@ func validateSessionDuration(cfg SessionConfig, result *ValidationResult) {
 		result.AddError("session.max_age",
 			"session max age must be positive", cfg.MaxAge)
 	}
+
+	if cfg.ImpersonationTTL <= 0 || (cfg.MaxAge > 0 && cfg.ImpersonationTTL > cfg.MaxAge) {
+		result.AddError("session.impersonation_ttl",
+			"impersonation TTL must be positive and no longer than the session max age", cfg.ImpersonationTTL)
+	}
 }
 
 func validateSessionFile(cfg SessionConfig, result *ValidationResult) {
@@ -2,6 +2,7 @@
 package config
 
 import (
+	"os"
 	"strings"
 )
 
@@ -9,7 +10,84 @@ import (
 func validateStorageConfig(cfg StorageConfig, result *ValidationResult) {
 	validateStorageType(cfg, result)
 	validateStorageLocal(cfg, result)
+	validateStorageGCS(cfg, result)
+	validateStorageAzure(cfg, result)
 	validateStorageLimits(cfg, result)
+	validateStorageBackup(cfg, result)
+	validateStorageArchive(cfg, result)
+	validateStorageAnalytics(cfg, result)
+}
+
+func validateStorageBackup(cfg StorageConfig, result *ValidationResult) {
+	if !cfg.Backup.Enabled {
+		return
+	}
+
+	if cfg.Backup.Interval <= 0 {
+		result.AddError("storage.backup.interval", "backup interval must be positive", cfg.Backup.Interval)
+	}
+
+	if cfg.Backup.Retention <= 0 {
+		result.AddError("storage.backup.retention", "backup retention must be positive", cfg.Backup.Retention)
+	}
+
+	if len(cfg.Backup.EncryptionKey) < MinSecretLength {
+		result.AddError("storage.backup.encryption_key",
+			"backup encryption key must be at least 32 characters long", "***")
+	}
+
+	if cfg.Backup.Prefix == "" || strings.Contains(cfg.Backup.Prefix, "..") {
+		result.AddError("storage.backup.prefix",
+			"backup prefix must be a relative path without ..", cfg.Backup.Prefix)
+	}
+}
+
+func validateStorageArchive(cfg StorageConfig, result *ValidationResult) {
+	if !cfg.Archive.Enabled {
+		return
+	}
+
+	if cfg.Archive.After <= 0 {
+		result.AddError("storage.archive.after", "archive age must be positive", cfg.Archive.After)
+	}
+
+	if cfg.Archive.Interval <= 0 {
+		result.AddError("storage.archive.interval", "archive interval must be positive", cfg.Archive.Interval)
+	}
+
+	if cfg.Archive.BatchSize <= 0 {
+		result.AddError("storage.archive.batch_size", "archive batch size must be positive", cfg.Archive.BatchSize)
+	}
+
+	if cfg.Archive.Prefix == "" || strings.Contains(cfg.Archive.Prefix, "..") {
+		result.AddError("storage.archive.prefix",
+			"archive prefix must be a relative path without ..", cfg.Archive.Prefix)
+	} else if cfg.Backup.Enabled && strings.Trim(cfg.Archive.Prefix, "/") == strings.Trim(cfg.Backup.Prefix, "/") {
+		result.AddError("storage.archive.prefix",
+			"archive prefix must differ from the backup prefix", cfg.Archive.Prefix)
+	}
+}
+
+func validateStorageAnalytics(cfg StorageConfig, result *ValidationResult) {
+	if !cfg.Analytics.Enabled {
+		return
+	}
+
+	if cfg.Analytics.Interval <= 0 {
+		result.AddError("storage.analytics.interval", "analytics export interval must be positive", cfg.Analytics.Interval)
+	}
+
+	prefix := strings.Trim(cfg.Analytics.Prefix, "/")
+
+	switch {
+	case prefix == "" || strings.Contains(prefix, ".."):
+		result.AddError("storage.analytics.prefix",
+			"analytics prefix must be a relative path without ..", cfg.Analytics.Prefix)
+	case cfg.Backup.Enabled && prefix == strings.Trim(cfg.Backup.Prefix, "/"),
+		cfg.Archive.Enabled && prefix == strings.Trim(cfg.Archive.Prefix, "/"):
+		result.AddError("storage.analytics.prefix",
+			"analytics prefix must differ from the backup and archive prefixes", cfg.Analytics.Prefix)
+	}
 }
 
 func validateStorageType(cfg StorageConfig, result *ValidationResult) {
@@ -19,7 +97,7 @@ func validateStorageType(cfg StorageConfig, result *ValidationResult) {
 		return
 	}
 
-	supportedTypes := []string{"local"}
+	supportedTypes := []string{StorageTypeLocal, StorageTypeGCS, StorageTypeAzure}
 	for _, storageType := range supportedTypes {
 		if strings.EqualFold(cfg.Type, storageType) {
 			return
@@ -30,7 +108,7 @@ func validateStorageType(cfg StorageConfig, result *ValidationResult) {
 }
 
 func validateStorageLocal(cfg StorageConfig, result *ValidationResult) {
-	if !strings.EqualFold(cfg.Type, "local") {
+	if !strings.EqualFold(cfg.Type, StorageTypeLocal) {
 		return
 	}
 
@@ -47,6 +125,68 @@ func validateStorageLocal(cfg StorageConfig, result *ValidationResult) {
 	}
 }
 
+func validateStorageGCS(cfg StorageConfig, result *ValidationResult) {
+	if !strings.EqualFold(cfg.Type, StorageTypeGCS) {
+		return
+	}
+
+	if cfg.GCS.Bucket == "" {
+		result.AddError("storage.gcs.bucket", "GCS bucket is required", cfg.GCS.Bucket)
+	}
+
+	if cfg.GCS.CredentialsFile != "" && cfg.GCS.CredentialsJSON != "" {
+		result.AddError("storage.gcs.credentials_file",
+			"set either a GCS credentials file or inline credentials, not both", cfg.GCS.CredentialsFile)
+	}
+
+	if cfg.GCS.CredentialsFile != "" {
+		if info, err := os.Stat(cfg.GCS.CredentialsFile); err != nil || info.IsDir() {
+			result.AddError("storage.gcs.credentials_file",
+				"GCS credentials file must be a readable file", cfg.GCS.CredentialsFile)
+		}
+	}
+
+	if cfg.GCS.KMSKeyName != "" && !strings.HasPrefix(cfg.GCS.KMSKeyName, "projects/") {
+		result.AddError("storage.gcs.kms_key_name",
+			"GCS KMS key must be a full resource name (projects/.../cryptoKeys/...)", cfg.GCS.KMSKeyName)
+	}
+
+	validateStorageSignedURLTTL(cfg, result)
+}
+
+func validateStorageAzure(cfg StorageConfig, result *ValidationResult) {
+	if !strings.EqualFold(cfg.Type, StorageTypeAzure) {
+		return
+	}
+
+	if cfg.Azure.Container == "" {
+		result.AddError("storage.azure.container", "Azure container is required", cfg.Azure.Container)
+	}
+
+	// SAS URLs are signed with the account key, so a key is needed unless a
+	// connection string carries it
+	if cfg.Azure.ConnectionString == "" {
+		if cfg.Azure.AccountName == "" {
+			result.AddError("storage.azure.account_name",
+				"Azure account name is required without a connection string", cfg.Azure.AccountName)
+		}
+
+		if cfg.Azure.AccountKey == "" {
+			result.AddError("storage.azure.account_key",
+				"Azure account key is required without a connection string", "***")
+		}
+	}
+
+	validateStorageSignedURLTTL(cfg, result)
+}
+
+func validateStorageSignedURLTTL(cfg StorageConfig, result *ValidationResult) {
+	if cfg.SignedURLTTL <= 0 || cfg.SignedURLTTL > MaxSignedURLTTL {
+		result.AddError("storage.signed_url_ttl",
+			"signed URL TTL must be positive and at most 7 days", cfg.SignedURLTTL)
+	}
+}
+
 func validateStorageLimits(cfg StorageConfig, result *ValidationResult) {
 	if cfg.MaxSize <= 0 {
 		result.AddError("storage.max_size",
@@ -0,0 +1,179 @@
+package config_test
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/goformx/goforms/internal/infrastructure/config"
+)
+
+func TestValidateConfig_Storage(t *testing.T) {
+	credentials := filepath.Join(t.TempDir(), "key.json")
+	if err := os.WriteFile(credentials, []byte("{}"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name    string
+		storage config.StorageConfig
+		fields  []string
+	}{
+		{
+			name: "gcs with credentials file and KMS key",
+			storage: config.StorageConfig{
+				Type: config.StorageTypeGCS,
+				GCS: config.GCSStorageConfig{
+					Bucket:          "uploads",
+					CredentialsFile: credentials,
+					KMSKeyName:      "projects/p/locations/global/keyRings/r/cryptoKeys/k",
+				},
+			},
+		},
+		{
+			name: "gcs without bucket, with both credential sources and a bare KMS key",
+			storage: config.StorageConfig{
+				Type: config.StorageTypeGCS,
+				GCS: config.GCSStorageConfig{
+					CredentialsFile: credentials,
+					CredentialsJSON: "{}",
+					KMSKeyName:      "my-key",
+				},
+			},
+			fields: []string{"storage.gcs.bucket", "storage.gcs.credentials_file", "storage.gcs.kms_key_name"},
+		},
+		{
+			name: "gcs with missing credentials file",
+			storage: config.StorageConfig{
+				Type: config.StorageTypeGCS,
+				GCS:  config.GCSStorageConfig{Bucket: "uploads", CredentialsFile: "/does/not/exist.json"},
+			},
+			fields: []string{"storage.gcs.credentials_file"},
+		},
+		{
+			name: "azure with account key and encryption scope",
+			storage: config.StorageConfig{
+				Type: config.StorageTypeAzure,
+				Azure: config.AzureStorageConfig{
+					AccountName:     "goforms",
+					AccountKey:      "a2V5",
+					Container:       "uploads",
+					EncryptionScope: "scope",
+				},
+			},
+		},
+		{
+			name: "azure with connection string",
+			storage: config.StorageConfig{
+				Type: config.StorageTypeAzure,
+				Azure: config.AzureStorageConfig{
+					ConnectionString: "UseDevelopmentStorage=true",
+					Container:        "uploads",
+				},
+			},
+		},
+		{
+			name: "azure without credentials or container",
+			storage: config.StorageConfig{
+				Type: config.StorageTypeAzure,
+			},
+			fields: []string{"storage.azure.container", "storage.azure.account_name", "storage.azure.account_key"},
+		},
+		{
+			name: "signed URL TTL beyond 7 days",
+			storage: config.StorageConfig{
+				Type:         config.StorageTypeAzure,
+				Azure:        config.AzureStorageConfig{ConnectionString: "UseDevelopmentStorage=true", Container: "uploads"},
+				SignedURLTTL: 8 * 24 * time.Hour,
+			},
+			fields: []string{"storage.signed_url_ttl"},
+		},
+		{
+			name: "local backups",
+			storage: config.StorageConfig{
+				Type:  config.StorageTypeLocal,
+				Local: config.LocalStorageConfig{Path: t.TempDir()},
+				Backup: config.BackupConfig{
+					Enabled:       true,
+					Interval:      time.Hour,
+					Retention:     3,
+					EncryptionKey: "0123456789abcdef0123456789abcdef",
+					Prefix:        "backups",
+				},
+			},
+		},
+		{
+			name: "backups on azure with a short key and no retention",
+			storage: config.StorageConfig{
+				Type:   config.StorageTypeAzure,
+				Azure:  config.AzureStorageConfig{ConnectionString: "UseDevelopmentStorage=true", Container: "uploads"},
+				Backup: config.BackupConfig{Enabled: true, Interval: time.Hour, EncryptionKey: "short", Prefix: "../backups"},
+			},
+			fields: []string{"storage.backup.retention", "storage.backup.encryption_key", "storage.backup.prefix"},
+		},
+		{
+			name: "archives on azure",
+			storage: config.StorageConfig{
+				Type:    config.StorageTypeAzure,
+				Azure:   config.AzureStorageConfig{ConnectionString: "UseDevelopmentStorage=true", Container: "uploads"},
+				Archive: config.ArchiveConfig{Enabled: true, After: time.Hour, Interval: time.Hour, BatchSize: 100, Prefix: "archive"},
+			},
+		},
+		{
+			name: "archive sharing the backup prefix",
+			storage: config.StorageConfig{
+				Type:  config.StorageTypeLocal,
+				Local: config.LocalStorageConfig{Path: t.TempDir()},
+				Backup: config.BackupConfig{
+					Enabled:       true,
+					Interval:      time.Hour,
+					Retention:     3,
+					EncryptionKey: "0123456789abcdef0123456789abcdef",
+					Prefix:        "backups",
+				},
+				Archive: config.ArchiveConfig{Enabled: true, Interval: time.Hour, BatchSize: 100, Prefix: "backups/"},
+			},
+			fields: []string{"storage.archive.after", "storage.archive.prefix"},
+		},
+		{
+			name: "analytics sharing the archive prefix",
+			storage: config.StorageConfig{
+				Type:      config.StorageTypeLocal,
+				Local:     config.LocalStorageConfig{Path: t.TempDir()},
+				Archive:   config.ArchiveConfig{Enabled: true, After: time.Hour, Interval: time.Hour, BatchSize: 100, Prefix: "archive"},
+				Analytics: config.AnalyticsConfig{Enabled: true, Prefix: "/archive"},
+			},
+			fields: []string{"storage.analytics.interval", "storage.analytics.prefix"},
+		},
+		{
+			name:    "unsupported type",
+			storage: config.StorageConfig{Type: "ftp"},
+			fields:  []string{"storage.type"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.storage.MaxSize = config.DefaultMaxFileSize
+			if tt.storage.SignedURLTTL == 0 {
+				tt.storage.SignedURLTTL = config.DefaultSignedURLTTL
+			}
+
+			result := config.ValidateConfig(&config.Config{Storage: tt.storage})
+
+			var fields []string
+
+			for _, validationErr := range result.Errors {
+				if strings.HasPrefix(validationErr.Field, "storage.") {
+					fields = append(fields, validationErr.Field)
+				}
+			}
+
+			assert.ElementsMatch(t, tt.fields, fields)
+		})
+	}
+}
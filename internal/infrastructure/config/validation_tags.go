@@ -0,0 +1,96 @@
+// Package config provides validation utilities for Viper-based configuration
+package config
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+
+	"github.com/go-playground/validator/v10"
+)
+
+// tagValidator checks the validate tags of the config structs
+//
+//nolint:gochecknoglobals // Caches struct metadata across validations
+var tagValidator = newTagValidator()
+
+// newTagValidator creates a validator that names fields by their config key
+// and knows the config's enumerations
+func newTagValidator() *validator.Validate {
+	v := validator.New(validator.WithRequiredStructEnabled())
+
+	v.RegisterTagNameFunc(func(field reflect.StructField) string {
+		name, _, _ := strings.Cut(field.Tag.Get("mapstructure"), ",")
+
+		return name
+	})
+
+	// oneofci is oneof, ignoring case
+	_ = v.RegisterValidation("oneofci", func(fl validator.FieldLevel) bool {
+		return slices.ContainsFunc(strings.Fields(fl.Param()), func(option string) bool {
+			return strings.EqualFold(fl.Field().String(), option)
+		})
+	})
+
+	v.RegisterAlias("environment", "oneofci=development staging production test")
+	v.RegisterAlias("database_driver", "oneofci=postgres mysql mariadb "+DatabaseDriverMongoDB+" "+DatabaseDriverMemory)
+
+	return v
+}
+
+// validateTags checks the validate tags of every field and adds an error for
+// each field that fails, named by its key
+func validateTags(cfg *Config, result *ValidationResult) {
+	var fieldErrs validator.ValidationErrors
+	if !errors.As(tagValidator.Struct(cfg), &fieldErrs) {
+		return
+	}
+
+	// The in-memory driver needs no connection settings, and the MongoDB driver
+	// checks its own in DatabaseConfig.Validate
+	skipDatabase := strings.EqualFold(cfg.Database.Driver, DatabaseDriverMemory) ||
+		strings.EqualFold(cfg.Database.Driver, DatabaseDriverMongoDB)
+
+	for _, fieldErr := range fieldErrs {
+		_, key, _ := strings.Cut(fieldErr.Namespace(), ".")
+
+		if skipDatabase && strings.HasPrefix(key, "database.") {
+			continue
+		}
+
+		value := fieldErr.Value()
+		if name := fieldErr.StructField(); strings.Contains(name, "Password") || strings.Contains(name, "Secret") {
+			value = "***"
+		}
+
+		result.AddError(key, tagMessage(fieldErr), value)
+	}
+}
+
+// tagMessage describes the rule a field failed
+func tagMessage(fieldErr validator.FieldError) string {
+	param := fieldErr.Param()
+
+	switch fieldErr.ActualTag() {
+	case "required", "required_without":
+		return "is required"
+	case "oneof", "oneofci":
+		return "must be one of: " + strings.Join(strings.Fields(param), ", ")
+	case "gt":
+		return "must be greater than " + param
+	case "min":
+		if fieldErr.Kind() == reflect.String {
+			return "must be at least " + param + " characters long"
+		}
+
+		return "must be at least " + param
+	case "max":
+		return "must be at most " + param
+	case "email":
+		return "must be a valid email address"
+	default:
+		return fmt.Sprintf("failed the %s rule", fieldErr.Tag())
+	}
+}
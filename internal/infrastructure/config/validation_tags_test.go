@@ -0,0 +1,97 @@
+package config_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/goformx/goforms/internal/infrastructure/config"
+)
+
+func TestValidateConfig_Tags(t *testing.T) {
+	tests := []struct {
+		name   string
+		mutate func(cfg *config.Config)
+		errors map[string]string
+		// valid lists keys that must have no errors
+		valid []string
+	}{
+		{
+			name:   "defaults",
+			mutate: func(*config.Config) {},
+			valid:  []string{"app.port", "app.environment", "database.driver", "database.host", "logging.level"},
+		},
+		{
+			name: "out of range and missing values",
+			mutate: func(cfg *config.Config) {
+				cfg.App.Port = 70000
+				cfg.Database.Host = ""
+				cfg.Auth.MaxLoginAttempts = 0
+			},
+			errors: map[string]string{
+				"app.port":                "must be at most 65535",
+				"database.host":           "is required",
+				"auth.max_login_attempts": "must be greater than 0",
+			},
+		},
+		{
+			name: "enumerations ignore case",
+			mutate: func(cfg *config.Config) {
+				cfg.App.Environment = "Production"
+				cfg.Logging.Format = "xml"
+				cfg.Database.Driver = "oracle"
+			},
+			errors: map[string]string{
+				"logging.format":  "must be one of: json, console",
+				"database.driver": "must be one of: postgres, mysql, mariadb, mongodb, memory",
+			},
+			valid: []string{"app.environment"},
+		},
+		{
+			name: "memory driver needs no connection settings",
+			mutate: func(cfg *config.Config) {
+				cfg.Database = config.DatabaseConfig{Driver: config.DatabaseDriverMemory}
+			},
+			valid: []string{"database.host", "database.port", "database.max_open_conns"},
+		},
+		{
+			name: "secrets are masked",
+			mutate: func(cfg *config.Config) {
+				cfg.User.Admin.Password = "short"
+			},
+			errors: map[string]string{
+				"user.admin.password": "must be at least 8 characters long",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg, err := config.NewViperConfig().LoadUnvalidated()
+			require.NoError(t, err)
+
+			tt.mutate(cfg)
+
+			errors := make(map[string]string)
+
+			for _, validationErr := range config.ValidateConfig(cfg).Errors {
+				assert.NotContains(t, tt.valid, validationErr.Field)
+
+				if _, ok := tt.errors[validationErr.Field]; !ok {
+					continue
+				}
+
+				errors[validationErr.Field] = validationErr.Message
+
+				if validationErr.Field == "user.admin.password" {
+					assert.Equal(t, "***", validationErr.Value)
+				}
+			}
+
+			for key, message := range tt.errors {
+				assert.Equal(t, message, errors[key], key)
+			}
+		})
+	}
+}
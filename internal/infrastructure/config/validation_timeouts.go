@@ -0,0 +1,20 @@
+package config
+
+import "time"
+
+// validateTimeoutsConfig validates the per-layer request time budgets
+func validateTimeoutsConfig(cfg TimeoutsConfig, requestTimeout time.Duration, result *ValidationResult) {
+	for field, budget := range map[string]time.Duration{
+		"timeouts.database": cfg.Database,
+		"timeouts.outbound": cfg.Outbound,
+		"timeouts.reserve":  cfg.Reserve,
+	} {
+		if budget < 0 {
+			result.AddError(field, "must not be negative", budget)
+		}
+	}
+
+	if requestTimeout > 0 && cfg.Reserve >= requestTimeout {
+		result.AddError("timeouts.reserve", "must be shorter than app.request_timeout", cfg.Reserve)
+	}
+}
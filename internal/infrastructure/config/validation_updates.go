@@ -0,0 +1,28 @@
+// Package config provides validation utilities for Viper-based configuration
+package config
+
+import (
+	"net/url"
+	"strings"
+)
+
+// validateUpdatesConfig validates release update check configuration
+func validateUpdatesConfig(cfg UpdatesConfig, result *ValidationResult) {
+	if !cfg.Enabled {
+		return
+	}
+
+	owner, name, ok := strings.Cut(cfg.Repository, "/")
+	if !ok || owner == "" || name == "" || strings.Contains(name, "/") {
+		result.AddError("updates.repository", "must be a GitHub owner/name", cfg.Repository)
+	}
+
+	if cfg.Interval < MinUpdateCheckInterval {
+		result.AddError("updates.interval", "must be at least 1h", cfg.Interval)
+	}
+
+	parsed, err := url.Parse(cfg.APIURL)
+	if err != nil || parsed.Host == "" || (parsed.Scheme != "http" && parsed.Scheme != "https") {
+		result.AddError("updates.api_url", "must be an http or https URL", cfg.APIURL)
+	}
+}
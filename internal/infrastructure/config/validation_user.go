@@ -1,22 +1,19 @@
 // Package config provides validation utilities for Viper-based configuration
 package config
 
-// validateUserConfig validates user configuration
+// validateUserConfig validates the account deletion settings
 func validateUserConfig(cfg UserConfig, result *ValidationResult) {
-	// Validate admin user configuration
-	if cfg.Admin.Email != "" && !isValidEmail(cfg.Admin.Email) {
-		result.AddError("user.admin.email",
-			"invalid admin email format", cfg.Admin.Email)
+	deletion := cfg.Deletion
+
+	if deletion.GracePeriod < 0 {
+		result.AddError("user.deletion.grace_period", "must not be negative", deletion.GracePeriod)
 	}
 
-	if cfg.Admin.Password != "" && len(cfg.Admin.Password) < 8 {
-		result.AddError("user.admin.password",
-			"admin password must be at least 8 characters long", "***")
+	if deletion.PurgeInterval < MinUserDeletionPurgeInterval {
+		result.AddError("user.deletion.purge_interval", "must be at least 1m", deletion.PurgeInterval)
 	}
 
-	// Validate default user configuration
-	if cfg.Default.Role == "" {
-		result.AddError("user.default.role",
-			"default user role is required", cfg.Default.Role)
+	if deletion.Submissions != UserDeletionAnonymize && deletion.Submissions != UserDeletionDelete {
+		result.AddError("user.deletion.submissions", "must be anonymize or delete", deletion.Submissions)
 	}
 }
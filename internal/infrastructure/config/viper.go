@@ -3,12 +3,14 @@
 package config
 
 import (
-	"errors"
 	"fmt"
 	"os"
+	"reflect"
 	"strings"
 	"time"
+	"unicode"
 
+	"github.com/go-viper/mapstructure/v2"
 	"github.com/spf13/viper"
 	"go.uber.org/fx"
 )
@@ -16,7 +18,9 @@ import (
 // ViperConfig represents the Viper-based configuration loader
 type ViperConfig struct {
 	viper          *viper.Viper
-	configFilePath string // Path to loaded config file, available after Load()
+	dir            string  // Directory holding the config files; empty searches the default paths
+	configFilePath string  // Path to loaded config file, available after Load()
+	sources        Sources // Files merged by Load, in order
 }
 
 // GetConfigFilePath returns the path to the loaded config file
@@ -24,523 +28,203 @@ func (vc *ViperConfig) GetConfigFilePath() string {
 	return vc.configFilePath
 }
 
-// NewViperConfig creates a new Viper configuration instance
-func NewViperConfig() *ViperConfig {
-	v := viper.New()
-
-	// Set default values
-	setDefaults(v)
+// Sources returns the config files merged by Load and the keys each overrides
+func (vc *ViperConfig) Sources() Sources {
+	return vc.sources
+}
 
-	// Configure Viper with best practices
-	v.SetEnvKeyReplacer(strings.NewReplacer(".", "_"))
-	v.AutomaticEnv()
+// EnvBinding maps a config key to environment variables read in addition to
+// the one derived from the key, such as DB_HOST for database.host
+type EnvBinding struct {
+	Key string
+	Env []string
+}
 
+// envBindings are the environment variables bound to keys explicitly
+//
+//nolint:gochecknoglobals // Bound by every ViperConfig and listed by Reference
+var envBindings = []EnvBinding{
 	// Bind DB_* environment variables to database.* config keys
 	// This allows users to use the common DB_ prefix convention
-	_ = v.BindEnv("database.host", "DB_HOST")
-	_ = v.BindEnv("database.port", "DB_PORT")
-	_ = v.BindEnv("database.name", "DB_NAME", "DB_DATABASE")
-	_ = v.BindEnv("database.username", "DB_USERNAME", "DB_USER")
-	_ = v.BindEnv("database.password", "DB_PASSWORD")
-	_ = v.BindEnv("database.driver", "DB_CONNECTION", "DB_DRIVER")
-	_ = v.BindEnv("database.ssl_mode", "DB_SSL_MODE")
+	{Key: "database.host", Env: []string{"DB_HOST"}},
+	{Key: "database.port", Env: []string{"DB_PORT"}},
+	{Key: "database.name", Env: []string{"DB_NAME", "DB_DATABASE"}},
+	{Key: "database.username", Env: []string{"DB_USERNAME", "DB_USER"}},
+	{Key: "database.password", Env: []string{"DB_PASSWORD"}},
+	{Key: "database.driver", Env: []string{"DB_CONNECTION", "DB_DRIVER"}},
+	{Key: "database.ssl_mode", Env: []string{"DB_SSL_MODE"}},
+	{Key: "database.tls.ca_file", Env: []string{"DB_SSL_CA"}},
+	{Key: "database.tls.cert_file", Env: []string{"DB_SSL_CERT"}},
+	{Key: "database.tls.key_file", Env: []string{"DB_SSL_KEY"}},
+	{Key: "database.socket", Env: []string{"DB_SOCKET"}},
+	{Key: "database.iam.enabled", Env: []string{"DB_IAM_AUTH"}},
+	{Key: "database.iam.region", Env: []string{"DB_IAM_REGION", "AWS_REGION"}},
+	{Key: "database.snapshot_path", Env: []string{"DB_SNAPSHOT_PATH"}},
+
+	// Bind the standard cloud SDK environment variables to the storage backends
+	{Key: "storage.gcs.credentials_file", Env: []string{"STORAGE_GCS_CREDENTIALS_FILE", "GOOGLE_APPLICATION_CREDENTIALS"}},
+	{Key: "storage.gcs.project_id", Env: []string{"STORAGE_GCS_PROJECT_ID", "GOOGLE_CLOUD_PROJECT"}},
+	{Key: "storage.azure.account_name", Env: []string{"STORAGE_AZURE_ACCOUNT_NAME", "AZURE_STORAGE_ACCOUNT"}},
+	{Key: "storage.azure.account_key", Env: []string{"STORAGE_AZURE_ACCOUNT_KEY", "AZURE_STORAGE_KEY"}},
+	{Key: "storage.azure.connection_string", Env: []string{"STORAGE_AZURE_CONNECTION_STRING", "AZURE_STORAGE_CONNECTION_STRING"}},
+
+	// Bind the providers' conventional environment variables to the email drivers
+	{Key: "email.ses.region", Env: []string{"EMAIL_SES_REGION", "AWS_REGION"}},
+	{Key: "email.ses.access_key_id", Env: []string{"EMAIL_SES_ACCESS_KEY_ID", "AWS_ACCESS_KEY_ID"}},
+	{Key: "email.ses.secret_access_key", Env: []string{"EMAIL_SES_SECRET_ACCESS_KEY", "AWS_SECRET_ACCESS_KEY"}},
+	{Key: "email.ses.session_token", Env: []string{"EMAIL_SES_SESSION_TOKEN", "AWS_SESSION_TOKEN"}},
+	{Key: "email.sendgrid.api_key", Env: []string{"EMAIL_SENDGRID_API_KEY", "SENDGRID_API_KEY"}},
+	{Key: "email.mailgun.api_key", Env: []string{"EMAIL_MAILGUN_API_KEY", "MAILGUN_API_KEY"}},
+	{Key: "email.mailgun.domain", Env: []string{"EMAIL_MAILGUN_DOMAIN", "MAILGUN_DOMAIN"}},
+	{Key: "email.webhooks.sendgrid_verification_key", Env: []string{"EMAIL_WEBHOOKS_SENDGRID_VERIFICATION_KEY", "SENDGRID_WEBHOOK_VERIFICATION_KEY"}},
+
+	// Bind Stripe's conventional environment variables to the billing settings
+	{Key: "billing.stripe.secret_key", Env: []string{"BILLING_STRIPE_SECRET_KEY", "STRIPE_SECRET_KEY"}},
+	{Key: "billing.stripe.webhook_secret", Env: []string{"BILLING_STRIPE_WEBHOOK_SECRET", "STRIPE_WEBHOOK_SECRET"}},
 
 	// Bind CORS_* environment variables for convenience
-	_ = v.BindEnv("security.cors.allowed_origins", "CORS_ALLOWED_ORIGINS", "CORS_ORIGINS")
-	_ = v.BindEnv("security.cors.allowed_methods", "CORS_ALLOWED_METHODS")
-	_ = v.BindEnv("security.cors.allowed_headers", "CORS_ALLOWED_HEADERS")
-	_ = v.BindEnv("security.cors.allow_credentials", "CORS_ALLOW_CREDENTIALS")
-	_ = v.BindEnv("security.cors.max_age", "CORS_MAX_AGE")
+	{Key: "security.cors.allowed_origins", Env: []string{"CORS_ALLOWED_ORIGINS", "CORS_ORIGINS"}},
+	{Key: "security.cors.allowed_methods", Env: []string{"CORS_ALLOWED_METHODS"}},
+	{Key: "security.cors.allowed_headers", Env: []string{"CORS_ALLOWED_HEADERS"}},
+	{Key: "security.cors.allow_credentials", Env: []string{"CORS_ALLOW_CREDENTIALS"}},
+	{Key: "security.cors.max_age", Env: []string{"CORS_MAX_AGE"}},
+	{Key: "security.cors.allow_wildcard_origin", Env: []string{"CORS_ALLOW_WILDCARD_ORIGIN"}},
 
 	// Bind GOFORMS_SHARED_SECRET for Laravel-Go assertion verification
-	_ = v.BindEnv("security.assertion.secret", "GOFORMS_SHARED_SECRET")
+	{Key: "security.assertion.secret", Env: []string{"GOFORMS_SHARED_SECRET"}},
 
-	// Set config file search paths (order matters - first found wins)
-	v.AddConfigPath(".")
-	v.AddConfigPath("./config")
-	v.AddConfigPath("/etc/goforms")
-	v.AddConfigPath("$HOME/.goforms")
-
-	// Set config file names (without extension) - try multiple formats
-	v.SetConfigName("config")
-	v.SetConfigType("yaml") // Default to YAML
-
-	return &ViperConfig{viper: v}
+	// Support API_KEYS with comma-separated keys
+	{Key: "security.api_key.keys", Env: []string{"SECURITY_API_KEY_KEYS", "API_KEYS"}},
 }
 
-// Load loads configuration using Viper with improved error handling
-func (vc *ViperConfig) Load() (*Config, error) {
-	if err := vc.loadConfigFiles(); err != nil {
-		return nil, fmt.Errorf("failed to load configuration files: %w", err)
-	}
-
-	config := &Config{}
-
-	if err := vc.loadAllConfigSections(config); err != nil {
-		return nil, fmt.Errorf("failed to load configuration sections: %w", err)
-	}
-
-	// Validate configuration with detailed error reporting
-	if err := config.validateConfig(); err != nil {
-		return nil, fmt.Errorf("configuration validation failed: %w", err)
-	}
-
-	return config, nil
+// NewViperConfig creates a new Viper configuration instance reading the
+// directory named by GOFORMS_CONFIG_DIR, or searching the default paths
+func NewViperConfig() *ViperConfig {
+	return NewViperConfigIn(os.Getenv(DirEnv))
 }
 
-// loadConfigFiles loads configuration files with better error handling
-func (vc *ViperConfig) loadConfigFiles() error {
-	// Try to read config file
-	if err := vc.viper.ReadInConfig(); err != nil {
-		var configFileNotFoundError viper.ConfigFileNotFoundError
-		if errors.As(err, &configFileNotFoundError) {
-			// Config file not found is not an error - we can use environment variables
-			// Silent in production, no logging needed here
-			return nil
-		}
-
-		return fmt.Errorf("failed to read config file: %w", err)
-	}
-
-	// Store config file path for later logging (once logger is available)
-	vc.configFilePath = vc.viper.ConfigFileUsed()
-
-	// Try to merge additional config files (like .env)
-	if err := vc.viper.MergeInConfig(); err != nil {
-		var configFileNotFoundError viper.ConfigFileNotFoundError
-		if errors.As(err, &configFileNotFoundError) {
-			// Additional config file not found is not an error
-			return nil
-		}
-
-		return fmt.Errorf("failed to merge additional config: %w", err)
-	}
+// NewViperConfigIn creates a Viper configuration instance reading config
+// files from dir; an empty dir searches the default paths
+func NewViperConfigIn(dir string) *ViperConfig {
+	v := viper.New()
 
-	return nil
-}
+	// Set default values
+	setDefaults(v)
 
-// loadAllConfigSections loads all configuration sections
-func (vc *ViperConfig) loadAllConfigSections(config *Config) error {
-	loaders := []func(*Config) error{
-		vc.loadAppConfig,
-		vc.loadDatabaseConfig,
-		vc.loadSecurityConfig,
-		vc.loadEmailConfig,
-		vc.loadStorageConfig,
-		vc.loadCacheConfig,
-		vc.loadLoggingConfig,
-		vc.loadSessionConfig,
-		vc.loadAuthConfig,
-		vc.loadFormConfig,
-		vc.loadAPIConfig,
-		vc.loadWebConfig,
-		vc.loadUserConfig,
-	}
+	// Configure Viper with best practices
+	v.SetEnvKeyReplacer(strings.NewReplacer(".", "_"))
+	v.AutomaticEnv()
 
-	for _, loader := range loaders {
-		if err := loader(config); err != nil {
-			return err
+	// Unmarshal only decodes keys Viper knows of, so every key is bound to its
+	// automatic variable before the explicit bindings replace some
+	for _, doc := range Reference() {
+		if bindable(doc) {
+			_ = v.BindEnv(doc.Key)
 		}
 	}
 
-	return nil
-}
-
-// loadAppConfig loads application configuration
-func (vc *ViperConfig) loadAppConfig(config *Config) error {
-	config.App = AppConfig{
-		Name:           vc.viper.GetString("app.name"),
-		Version:        vc.viper.GetString("app.version"),
-		Environment:    vc.viper.GetString("app.environment"),
-		Debug:          vc.viper.GetBool("app.debug"),
-		LogLevel:       vc.viper.GetString("app.log_level"),
-		URL:            vc.viper.GetString("app.url"),
-		Scheme:         vc.viper.GetString("app.scheme"),
-		Port:           vc.viper.GetInt("app.port"),
-		Host:           vc.viper.GetString("app.host"),
-		ReadTimeout:    vc.viper.GetDuration("app.read_timeout"),
-		WriteTimeout:   vc.viper.GetDuration("app.write_timeout"),
-		IdleTimeout:    vc.viper.GetDuration("app.idle_timeout"),
-		RequestTimeout: vc.viper.GetDuration("app.request_timeout"),
-		ViteDevHost:    vc.viper.GetString("app.vite_dev_host"),
-		ViteDevPort:    vc.viper.GetString("app.vite_dev_port"),
-	}
-
-	return nil
-}
-
-// loadDatabaseConfig loads database configuration
-func (vc *ViperConfig) loadDatabaseConfig(config *Config) error {
-	config.Database = DatabaseConfig{
-		Driver:          vc.viper.GetString("database.driver"),
-		Host:            vc.viper.GetString("database.host"),
-		Port:            vc.viper.GetInt("database.port"),
-		Name:            vc.viper.GetString("database.name"),
-		Username:        vc.viper.GetString("database.username"),
-		Password:        vc.viper.GetString("database.password"),
-		SSLMode:         vc.viper.GetString("database.ssl_mode"),
-		MaxOpenConns:    vc.viper.GetInt("database.max_open_conns"),
-		MaxIdleConns:    vc.viper.GetInt("database.max_idle_conns"),
-		ConnMaxLifetime: vc.viper.GetDuration("database.conn_max_lifetime"),
-		ConnMaxIdleTime: vc.viper.GetDuration("database.conn_max_idle_time"),
-	}
-
-	return nil
-}
-
-// loadCSRFConfig loads CSRF configuration from viper
-func (vc *ViperConfig) loadCSRFConfig() CSRFConfig {
-	return CSRFConfig{
-		Enabled:        vc.viper.GetBool("security.csrf.enabled"),
-		Secret:         vc.viper.GetString("security.csrf.secret"),
-		TokenName:      vc.viper.GetString("security.csrf.token_name"),
-		HeaderName:     vc.viper.GetString("security.csrf.header_name"),
-		TokenLength:    vc.viper.GetInt("security.csrf.token_length"),
-		TokenLookup:    vc.viper.GetString("security.csrf.token_lookup"),
-		ContextKey:     vc.viper.GetString("security.csrf.context_key"),
-		CookieName:     vc.viper.GetString("security.csrf.cookie_name"),
-		CookiePath:     vc.viper.GetString("security.csrf.cookie_path"),
-		CookieDomain:   vc.viper.GetString("security.csrf.cookie_domain"),
-		CookieHTTPOnly: vc.viper.GetBool("security.csrf.cookie_http_only"),
-		CookieSameSite: vc.viper.GetString("security.csrf.cookie_same_site"),
-		CookieMaxAge:   vc.viper.GetInt("security.csrf.cookie_max_age"),
-	}
-}
-
-// loadCORSConfig loads CORS configuration from viper
-func (vc *ViperConfig) loadCORSConfig() CORSConfig {
-	return CORSConfig{
-		Enabled:          vc.viper.GetBool("security.cors.enabled"),
-		AllowedOrigins:   vc.viper.GetStringSlice("security.cors.allowed_origins"),
-		AllowedMethods:   vc.viper.GetStringSlice("security.cors.allowed_methods"),
-		AllowedHeaders:   vc.viper.GetStringSlice("security.cors.allowed_headers"),
-		ExposedHeaders:   vc.viper.GetStringSlice("security.cors.exposed_headers"),
-		AllowCredentials: vc.viper.GetBool("security.cors.allow_credentials"),
-		MaxAge:           vc.viper.GetInt("security.cors.max_age"),
-	}
-}
-
-// loadAssertionConfig loads assertion verification configuration from viper
-func (vc *ViperConfig) loadAssertionConfig() AssertionConfig {
-	return AssertionConfig{
-		Secret:               vc.viper.GetString("security.assertion.secret"),
-		TimestampSkewSeconds: vc.viper.GetInt("security.assertion.timestamp_skew_seconds"),
+	for _, binding := range envBindings {
+		_ = v.BindEnv(append([]string{binding.Key}, binding.Env...)...)
 	}
-}
 
-// loadAPIKeyConfig loads API key configuration from viper
-func (vc *ViperConfig) loadAPIKeyConfig() APIKeyConfig {
-	// Support environment variable with comma-separated keys
-	keysEnv := os.Getenv("API_KEYS")
-	var keys []string
-	if keysEnv != "" {
-		keys = strings.Split(keysEnv, ",")
-		// Trim whitespace from each key
-		for i, key := range keys {
-			keys[i] = strings.TrimSpace(key)
-		}
+	// Set config file search paths (order matters - first found wins)
+	if dir != "" {
+		v.AddConfigPath(dir)
 	} else {
-		keys = vc.viper.GetStringSlice("security.api_key.keys")
-	}
-
-	headerName := vc.viper.GetString("security.api_key.header_name")
-	if headerName == "" {
-		headerName = "X-API-Key" // Default header name
-	}
-
-	return APIKeyConfig{
-		Enabled:     vc.viper.GetBool("security.api_key.enabled"),
-		Keys:        keys,
-		HeaderName:  headerName,
-		QueryParam:  vc.viper.GetString("security.api_key.query_param"),
-		SkipPaths:   vc.viper.GetStringSlice("security.api_key.skip_paths"),
-		SkipMethods: vc.viper.GetStringSlice("security.api_key.skip_methods"),
-	}
-}
-
-// loadRateLimitConfig loads rate limit configuration from viper
-func (vc *ViperConfig) loadRateLimitConfig() RateLimitConfig {
-	return RateLimitConfig{
-		Enabled:  vc.viper.GetBool("security.rate_limit.enabled"),
-		RPS:      vc.viper.GetInt("security.rate_limit.rps"),
-		Requests: vc.viper.GetInt("security.rate_limit.rps"),
-		Burst:    vc.viper.GetInt("security.rate_limit.burst"),
-		Window:   vc.viper.GetDuration("security.rate_limit.window"),
-		PerIP:    vc.viper.GetBool("security.rate_limit.per_ip"),
-		SkipPaths: []string{
-			"/health",
-			"/metrics",
-			"/favicon.ico",
-			"/robots.txt",
-			"/static/",
-			"/assets/",
-		},
-		SkipMethods: []string{"OPTIONS"},
-	}
-}
-
-// loadCSPConfig loads CSP configuration from viper
-func (vc *ViperConfig) loadCSPConfig() CSPConfig {
-	return CSPConfig{
-		Enabled:    vc.viper.GetBool("security.csp.enabled"),
-		DefaultSrc: vc.viper.GetString("security.csp.default_src"),
-		ScriptSrc:  vc.viper.GetString("security.csp.script_src"),
-		StyleSrc:   vc.viper.GetString("security.csp.style_src"),
-		ImgSrc:     vc.viper.GetString("security.csp.img_src"),
-		ConnectSrc: vc.viper.GetString("security.csp.connect_src"),
-		FontSrc:    vc.viper.GetString("security.csp.font_src"),
-		ObjectSrc:  vc.viper.GetString("security.csp.object_src"),
-		MediaSrc:   vc.viper.GetString("security.csp.media_src"),
-		FrameSrc:   vc.viper.GetString("security.csp.frame_src"),
-		ReportURI:  vc.viper.GetString("security.csp.report_uri"),
+		v.AddConfigPath(".")
+		v.AddConfigPath("./config")
+		v.AddConfigPath("/etc/goforms")
+		v.AddConfigPath("$HOME/.goforms")
 	}
-}
 
-// loadSecurityHeadersConfig loads security headers configuration from viper
-func (vc *ViperConfig) loadSecurityHeadersConfig() SecurityHeadersConfig {
-	return SecurityHeadersConfig{
-		Enabled:                 vc.viper.GetBool("security.security_headers.enabled"),
-		XFrameOptions:           vc.viper.GetString("security.security_headers.x_frame_options"),
-		XContentTypeOptions:     vc.viper.GetString("security.security_headers.x_content_type_options"),
-		XXSSProtection:          vc.viper.GetString("security.security_headers.x_xss_protection"),
-		ReferrerPolicy:          vc.viper.GetString("security.security_headers.referrer_policy"),
-		PermissionsPolicy:       vc.viper.GetString("security.security_headers.permissions_policy"),
-		StrictTransportSecurity: vc.viper.GetString("security.security_headers.strict_transport_security"),
-	}
-}
-
-// loadSecurityConfig loads security configuration
-func (vc *ViperConfig) loadSecurityConfig(config *Config) error {
-	config.Security = SecurityConfig{
-		CSRF:      vc.loadCSRFConfig(),
-		CORS:      vc.loadCORSConfig(),
-		RateLimit: vc.loadRateLimitConfig(),
-		CSP:       vc.loadCSPConfig(),
-		TLS: TLSConfig{
-			Enabled:  vc.viper.GetBool("security.tls.enabled"),
-			CertFile: vc.viper.GetString("security.tls.cert_file"),
-			KeyFile:  vc.viper.GetString("security.tls.key_file"),
-		},
-		Encryption: EncryptionConfig{
-			Key: vc.viper.GetString("security.encryption.key"),
-		},
-		SecurityHeaders: vc.loadSecurityHeadersConfig(),
-		CookieSecurity: CookieSecurityConfig{
-			Secure:   vc.viper.GetBool("security.cookie_security.secure"),
-			HTTPOnly: vc.viper.GetBool("security.cookie_security.http_only"),
-			SameSite: vc.viper.GetString("security.cookie_security.same_site"),
-			Path:     vc.viper.GetString("security.cookie_security.path"),
-			Domain:   vc.viper.GetString("security.cookie_security.domain"),
-			MaxAge:   vc.viper.GetInt("security.cookie_security.max_age"),
-		},
-		TrustProxy: TrustProxyConfig{
-			Enabled:        vc.viper.GetBool("security.trust_proxy.enabled"),
-			TrustedProxies: vc.viper.GetStringSlice("security.trust_proxy.trusted_proxies"),
-		},
-		Assertion:    vc.loadAssertionConfig(),
-		APIKey:       vc.loadAPIKeyConfig(),
-		SecureCookie: vc.viper.GetBool("security.secure_cookie"),
-		Debug:        vc.viper.GetBool("security.debug"),
-	}
+	// Set config file names (without extension) - try multiple formats
+	v.SetConfigName("config")
+	v.SetConfigType("yaml") // Default to YAML
 
-	return nil
+	return &ViperConfig{viper: v, dir: dir}
 }
 
-// loadEmailConfig loads email configuration
-func (vc *ViperConfig) loadEmailConfig(config *Config) error {
-	config.Email = EmailConfig{
-		Host:     vc.viper.GetString("email.host"),
-		Port:     vc.viper.GetInt("email.port"),
-		Username: vc.viper.GetString("email.username"),
-		Password: vc.viper.GetString("email.password"),
-		From:     vc.viper.GetString("email.from"),
-		UseTLS:   vc.viper.GetBool("email.use_tls"),
-		UseSSL:   vc.viper.GetBool("email.use_ssl"),
-		Template: vc.viper.GetString("email.template"),
+// Load loads configuration using Viper with improved error handling
+func (vc *ViperConfig) Load() (*Config, error) {
+	config, err := vc.LoadUnvalidated()
+	if err != nil {
+		return nil, err
 	}
 
-	return nil
-}
-
-// loadStorageConfig loads storage configuration
-func (vc *ViperConfig) loadStorageConfig(config *Config) error {
-	config.Storage = StorageConfig{
-		Type: vc.viper.GetString("storage.type"),
-		Local: LocalStorageConfig{
-			Path: vc.viper.GetString("storage.local.path"),
-		},
-		S3: S3StorageConfig{
-			Bucket:    vc.viper.GetString("storage.s3.bucket"),
-			Region:    vc.viper.GetString("storage.s3.region"),
-			AccessKey: vc.viper.GetString("storage.s3.access_key"),
-			SecretKey: vc.viper.GetString("storage.s3.secret_key"),
-			Endpoint:  vc.viper.GetString("storage.s3.endpoint"),
-		},
-		MaxSize:     vc.viper.GetInt64("storage.max_size"),
-		AllowedExts: vc.viper.GetStringSlice("storage.allowed_extensions"),
+	// Validate configuration with detailed error reporting
+	if err := config.Validate(); err != nil {
+		return nil, fmt.Errorf("configuration validation failed: %w", err)
 	}
 
-	return nil
+	return config, nil
 }
 
-// loadCacheConfig loads cache configuration
-func (vc *ViperConfig) loadCacheConfig(config *Config) error {
-	config.Cache = CacheConfig{
-		Type: vc.viper.GetString("cache.type"),
-		Redis: RedisConfig{
-			Host:     vc.viper.GetString("cache.redis.host"),
-			Port:     vc.viper.GetInt("cache.redis.port"),
-			Password: vc.viper.GetString("cache.redis.password"),
-			DB:       vc.viper.GetInt("cache.redis.db"),
-		},
-		Memory: MemoryConfig{
-			MaxSize: vc.viper.GetInt("cache.memory.max_size"),
-		},
-		TTL: vc.viper.GetDuration("cache.ttl"),
+// LoadUnvalidated loads configuration without validating it, for tools that
+// report on invalid settings instead of refusing them
+func (vc *ViperConfig) LoadUnvalidated() (*Config, error) {
+	if err := vc.loadProfiles(); err != nil {
+		return nil, fmt.Errorf("failed to load configuration files: %w", err)
 	}
 
-	return nil
-}
+	config := &Config{}
 
-// loadLoggingConfig loads logging configuration
-func (vc *ViperConfig) loadLoggingConfig(config *Config) error {
-	config.Logging = LoggingConfig{
-		Level:      vc.viper.GetString("logging.level"),
-		Format:     vc.viper.GetString("logging.format"),
-		Output:     vc.viper.GetString("logging.output"),
-		File:       vc.viper.GetString("logging.file"),
-		MaxSize:    vc.viper.GetInt("logging.max_size"),
-		MaxBackups: vc.viper.GetInt("logging.max_backups"),
-		MaxAge:     vc.viper.GetInt("logging.max_age"),
-		Compress:   vc.viper.GetBool("logging.compress"),
+	if err := vc.decodeConfig(config); err != nil {
+		return nil, err
 	}
 
-	return nil
+	return config, nil
 }
 
-// loadSessionConfig loads session configuration
-func (vc *ViperConfig) loadSessionConfig(config *Config) error {
-	config.Session = SessionConfig{
-		Type:       vc.viper.GetString("session.type"),
-		Secret:     vc.viper.GetString("session.secret"),
-		MaxAge:     vc.viper.GetDuration("session.max_age"),
-		Domain:     vc.viper.GetString("session.domain"),
-		Path:       vc.viper.GetString("session.path"),
-		Secure:     vc.viper.GetBool("session.secure"),
-		HTTPOnly:   vc.viper.GetBool("session.http_only"),
-		SameSite:   vc.viper.GetString("session.same_site"),
-		Store:      vc.viper.GetString("session.store"),
-		StoreFile:  vc.viper.GetString("session.store_file"),
-		CookieName: vc.viper.GetString("session.cookie_name"),
+// decodeConfig decodes every key into config in one pass
+func (vc *ViperConfig) decodeConfig(config *Config) error {
+	if err := vc.viper.Unmarshal(config, viper.DecodeHook(mapstructure.ComposeDecodeHookFunc(
+		mapstructure.StringToTimeDurationHookFunc(),
+		stringToSliceHook,
+	))); err != nil {
+		return fmt.Errorf("failed to decode configuration: %w", err)
 	}
 
-	return nil
-}
-
-// loadAuthConfig loads authentication configuration
-func (vc *ViperConfig) loadAuthConfig(config *Config) error {
-	config.Auth = AuthConfig{
-		RequireEmailVerification: vc.viper.GetBool("auth.require_email_verification"),
-		PasswordMinLength:        vc.viper.GetInt("auth.password_min_length"),
-		PasswordRequireSpecial:   vc.viper.GetBool("auth.password_require_special"),
-		SessionTimeout:           vc.viper.GetDuration("auth.session_timeout"),
-		MaxLoginAttempts:         vc.viper.GetInt("auth.max_login_attempts"),
-		LockoutDuration:          vc.viper.GetDuration("auth.lockout_duration"),
-	}
+	config.normalize()
 
 	return nil
 }
 
-// loadFormConfig loads form configuration
-func (vc *ViperConfig) loadFormConfig(config *Config) error {
-	config.Form = FormConfig{
-		MaxFileSize:      vc.viper.GetInt64("form.max_file_size"),
-		AllowedFileTypes: vc.viper.GetStringSlice("form.allowed_file_types"),
-		MaxFields:        vc.viper.GetInt("form.max_fields"),
-		MaxMemory:        vc.viper.GetInt64("form.max_memory"),
-		Validation: ValidationConfig{
-			StrictMode: vc.viper.GetBool("form.validation.strict_mode"),
-			MaxErrors:  vc.viper.GetInt("form.validation.max_errors"),
-		},
-	}
-
-	return nil
+// bindable reports whether a key can be set from a single environment
+// variable: lists of objects, their fields and maps cannot
+func bindable(doc KeyDoc) bool {
+	return !strings.Contains(doc.Key, "[]") &&
+		doc.Type != "list of objects" &&
+		!strings.HasPrefix(doc.Type, "map of ")
 }
 
-// loadAPIConfig loads API configuration
-func (vc *ViperConfig) loadAPIConfig(config *Config) error {
-	config.API = APIConfig{
-		Version:    vc.viper.GetString("api.version"),
-		Prefix:     vc.viper.GetString("api.prefix"),
-		Timeout:    vc.viper.GetDuration("api.timeout"),
-		MaxRetries: vc.viper.GetInt("api.max_retries"),
-		RateLimit: RateLimitConfig{
-			Enabled: vc.viper.GetBool("api.rate_limit.enabled"),
-			RPS:     vc.viper.GetInt("api.rate_limit.rps"),
-			Burst:   vc.viper.GetInt("api.rate_limit.burst"),
-		},
-	}
-
-	return nil
-}
+// normalize fills the settings derived from others
+func (c *Config) normalize() {
+	// Requests is an alias for RPS
+	c.Security.RateLimit.Requests = c.Security.RateLimit.RPS
 
-// loadWebConfig loads web configuration
-func (vc *ViperConfig) loadWebConfig(config *Config) error {
-	config.Web = WebConfig{
-		TemplateDir:  vc.viper.GetString("web.template_dir"),
-		StaticDir:    vc.viper.GetString("web.static_dir"),
-		AssetsDir:    vc.viper.GetString("web.assets_dir"),
-		ReadTimeout:  vc.viper.GetDuration("web.read_timeout"),
-		WriteTimeout: vc.viper.GetDuration("web.write_timeout"),
-		IdleTimeout:  vc.viper.GetDuration("web.idle_timeout"),
-		Gzip:         vc.viper.GetBool("web.gzip"),
+	if c.Security.APIKey.HeaderName == "" {
+		c.Security.APIKey.HeaderName = "X-API-Key"
 	}
-
-	return nil
 }
 
-// loadUserConfig loads user configuration
-func (vc *ViperConfig) loadUserConfig(config *Config) error {
-	config.User = UserConfig{
-		Admin: AdminUserConfig{
-			Email:    vc.viper.GetString("user.admin.email"),
-			Password: vc.viper.GetString("user.admin.password"),
-			Name:     vc.viper.GetString("user.admin.name"),
-		},
-		Default: DefaultUserConfig{
-			Role:        vc.viper.GetString("user.default.role"),
-			Permissions: vc.viper.GetStringSlice("user.default.permissions"),
-		},
+// stringToSliceHook splits strings decoded into slices, such as list values
+// from environment variables, on commas and whitespace
+func stringToSliceHook(from, to reflect.Type, data any) (any, error) {
+	if from.Kind() != reflect.String || to.Kind() != reflect.Slice {
+		return data, nil
 	}
 
-	return nil
+	return strings.FieldsFunc(reflect.ValueOf(data).String(), func(r rune) bool {
+		return r == ',' || unicode.IsSpace(r)
+	}), nil
 }
 
-// LoadForEnvironment loads configuration for a specific environment
+// LoadForEnvironment loads configuration with the overlay of env merged
+// instead of the one app.environment names, and sets app.environment to env
 func (vc *ViperConfig) LoadForEnvironment(env string) (*Config, error) {
-	// Set environment-specific config file
-	vc.viper.SetConfigName(fmt.Sprintf("config.%s", env))
-
-	// Also try to load .env file for the environment
-	envFile := fmt.Sprintf(".env.%s", env)
-	if _, err := os.Stat(envFile); err == nil {
-		vc.viper.SetConfigFile(envFile)
-		vc.viper.SetConfigType("env")
-
-		if mergeErr := vc.viper.MergeInConfig(); mergeErr != nil {
-			return nil, fmt.Errorf("failed to merge env config: %w", mergeErr)
-		}
-	}
-
-	config, err := vc.Load()
-	if err != nil {
-		return nil, err
-	}
-
-	// Override the environment setting
-	config.App.Environment = env
+	vc.viper.Set("app.environment", env)
 
-	return config, nil
+	return vc.Load()
 }
 
 // setDefaults sets default configuration values
@@ -558,6 +242,17 @@ func setDefaults(v *viper.Viper) {
 	setAPIDefaults(v)
 	setWebDefaults(v)
 	setUserDefaults(v)
+	setFlagsDefaults(v)
+	setBillingDefaults(v)
+	setResilienceDefaults(v)
+	setUpdatesDefaults(v)
+	setEventsDefaults(v)
+	setDeadLetterDefaults(v)
+	setPIIDefaults(v)
+	setCrashReportsDefaults(v)
+	setOpsDefaults(v)
+	setTimeoutsDefaults(v)
+	setGeoIPDefaults(v)
 }
 
 // setAppDefaults sets application default values
@@ -588,10 +283,21 @@ func setDatabaseDefaults(v *viper.Viper) {
 	v.SetDefault("database.username", "goforms")
 	v.SetDefault("database.password", "goforms")
 	v.SetDefault("database.ssl_mode", "disable")
+	v.SetDefault("database.tls.ca_file", "")
+	v.SetDefault("database.tls.cert_file", "")
+	v.SetDefault("database.tls.key_file", "")
+	v.SetDefault("database.tls.server_name", "")
+	v.SetDefault("database.socket", "")
+	v.SetDefault("database.iam.enabled", false)
+	v.SetDefault("database.iam.region", "")
+	v.SetDefault("database.sharding.enabled", false)
+	v.SetDefault("database.sharding.virtual_nodes", DefaultShardVirtualNodes)
 	v.SetDefault("database.max_open_conns", DefaultMaxOpenConns)
 	v.SetDefault("database.max_idle_conns", DefaultMaxIdleConns)
 	v.SetDefault("database.conn_max_lifetime", DefaultConnLifetime)
 	v.SetDefault("database.conn_max_idle_time", DefaultConnIdleTime)
+	v.SetDefault("database.snapshot_path", "")
+	v.SetDefault("database.mongodb.uri", "")
 }
 
 // setCSRFDefaults sets CSRF default values
@@ -616,7 +322,10 @@ func setCORSDefaults(v *viper.Viper) {
 	v.SetDefault("security.cors.enabled", true)
 	v.SetDefault("security.cors.allowed_origins", []string{"*"})
 	v.SetDefault("security.cors.allowed_methods", []string{"GET", "POST", "PUT", "DELETE", "OPTIONS"})
-	allowedHeaders := []string{"Content-Type", "Authorization", "X-Csrf-Token", "X-Requested-With", "X-API-Key"}
+	allowedHeaders := []string{
+		"Content-Type", "Authorization", "X-Csrf-Token", "X-Requested-With", "X-API-Key", "Idempotency-Key",
+		"X-Form-Access-Token",
+	}
 	v.SetDefault("security.cors.allowed_headers", allowedHeaders)
 	v.SetDefault("security.cors.exposed_headers", []string{})
 	v.SetDefault("security.cors.allow_credentials", true)
@@ -666,6 +375,23 @@ func setSecurityHeadersDefaults(v *viper.Viper) {
 	v.SetDefault("security.security_headers.strict_transport_security", "")
 }
 
+// setSecurityEventsDefaults sets security event detection default values
+func setSecurityEventsDefaults(v *viper.Viper) {
+	v.SetDefault("security.events.enabled", true)
+	v.SetDefault("security.events.failed_login.threshold", DefaultFailedLoginThreshold)
+	v.SetDefault("security.events.failed_login.window", "5m")
+	v.SetDefault("security.events.failed_login.block", true)
+	v.SetDefault("security.events.submission_burst.threshold", DefaultSubmissionBurstThreshold)
+	v.SetDefault("security.events.submission_burst.window", "1m")
+	v.SetDefault("security.events.submission_burst.block", true)
+	v.SetDefault("security.events.csrf_failure.threshold", DefaultCSRFFailureThreshold)
+	v.SetDefault("security.events.csrf_failure.window", "10m")
+	v.SetDefault("security.events.csrf_failure.block", false)
+	v.SetDefault("security.events.block_duration", DefaultSecurityBlockDuration)
+	v.SetDefault("security.events.exempt_ips", []string{})
+	v.SetDefault("security.events.notify_emails", []string{})
+}
+
 // setSecurityDefaults sets security default values
 func setSecurityDefaults(v *viper.Viper) {
 	setCSRFDefaults(v)
@@ -677,6 +403,21 @@ func setSecurityDefaults(v *viper.Viper) {
 	v.SetDefault("security.rate_limit.burst", DefaultRateLimitBurst)
 	v.SetDefault("security.rate_limit.window", "1m")
 	v.SetDefault("security.rate_limit.per_ip", false)
+	v.SetDefault("security.rate_limit.authenticated.requests", DefaultAuthenticatedRateLimit)
+	v.SetDefault("security.rate_limit.authenticated.window", "1m")
+	v.SetDefault("security.rate_limit.anonymous.requests", DefaultAnonymousRateLimit)
+	v.SetDefault("security.rate_limit.anonymous.window", "1m")
+	v.SetDefault("security.rate_limit.mode", RateLimitModeEnforce)
+	v.SetDefault("security.rate_limit.skip_paths", []string{
+		"/health",
+		"/metrics",
+		"/favicon.ico",
+		"/robots.txt",
+		"/static/",
+		"/assets/",
+	})
+	v.SetDefault("security.rate_limit.skip_methods", []string{"OPTIONS"})
+	setSecurityEventsDefaults(v)
 	setCSPDefaults(v)
 	v.SetDefault("security.tls.enabled", false)
 	v.SetDefault("security.encryption.key", "")
@@ -699,6 +440,8 @@ func setEmailDefaults(v *viper.Viper) {
 	v.SetDefault("email.use_tls", true)
 	v.SetDefault("email.use_ssl", false)
 	v.SetDefault("email.template", "default")
+	v.SetDefault("email.ses.region", "us-east-1")
+	v.SetDefault("email.webhooks.max_age", time.Hour)
 }
 
 // setStorageDefaults sets storage default values
@@ -706,8 +449,21 @@ func setStorageDefaults(v *viper.Viper) {
 	v.SetDefault("storage.type", "local")
 	v.SetDefault("storage.local.path", "./uploads")
 	v.SetDefault("storage.s3.region", "us-east-1")
+	v.SetDefault("storage.signed_url_ttl", DefaultSignedURLTTL)
 	v.SetDefault("storage.max_size", DefaultMaxFileSize)
 	v.SetDefault("storage.allowed_extensions", []string{".jpg", ".jpeg", ".png", ".gif", ".pdf", ".doc", ".docx"})
+	v.SetDefault("storage.backup.enabled", false)
+	v.SetDefault("storage.backup.interval", DefaultBackupInterval)
+	v.SetDefault("storage.backup.retention", DefaultBackupRetention)
+	v.SetDefault("storage.backup.prefix", "backups")
+	v.SetDefault("storage.archive.enabled", false)
+	v.SetDefault("storage.archive.after", DefaultArchiveAfter)
+	v.SetDefault("storage.archive.interval", DefaultArchiveInterval)
+	v.SetDefault("storage.archive.batch_size", DefaultArchiveBatchSize)
+	v.SetDefault("storage.archive.prefix", "archive")
+	v.SetDefault("storage.analytics.enabled", false)
+	v.SetDefault("storage.analytics.interval", DefaultAnalyticsInterval)
+	v.SetDefault("storage.analytics.prefix", "analytics")
 }
 
 // setCacheDefaults sets cache default values
@@ -718,6 +474,11 @@ func setCacheDefaults(v *viper.Viper) {
 	v.SetDefault("cache.redis.db", 0)
 	v.SetDefault("cache.memory.max_size", DefaultMemoryCacheSize)
 	v.SetDefault("cache.ttl", 1*time.Hour)
+	v.SetDefault("cache.forms.enabled", false)
+	v.SetDefault("cache.forms.ttl", DefaultFormCacheTTL)
+	v.SetDefault("cache.pages.enabled", true)
+	v.SetDefault("cache.pages.ttl", DefaultPageCacheTTL)
+	v.SetDefault("cache.pages.max_entries", DefaultPageCacheMaxEntries)
 }
 
 // setLoggingDefaults sets logging default values
@@ -730,6 +491,12 @@ func setLoggingDefaults(v *viper.Viper) {
 	v.SetDefault("logging.max_backups", DefaultLogMaxBackups)
 	v.SetDefault("logging.max_age", DefaultLogMaxAge)
 	v.SetDefault("logging.compress", true)
+	v.SetDefault("logging.sanitization.mask", []string{})
+	v.SetDefault("logging.sanitization.hash", []string{})
+	v.SetDefault("logging.sanitization.truncate", []string{})
+	v.SetDefault("logging.sanitization.drop", []string{})
+	v.SetDefault("logging.sanitization.allow", []string{})
+	v.SetDefault("logging.sanitization.truncate_length", DefaultLogTruncateLength)
 }
 
 // setSessionDefaults sets session default values
@@ -744,6 +511,7 @@ func setSessionDefaults(v *viper.Viper) {
 	v.SetDefault("session.store", "memory")
 	v.SetDefault("session.store_file", "storage/sessions/sessions.json")
 	v.SetDefault("session.cookie_name", "session")
+	v.SetDefault("session.impersonation_ttl", DefaultImpersonationTTL)
 }
 
 // setAuthDefaults sets authentication default values
@@ -764,6 +532,38 @@ func setFormDefaults(v *viper.Viper) {
 	v.SetDefault("form.max_memory", DefaultMaxFormMemory)
 	v.SetDefault("form.validation.strict_mode", false)
 	v.SetDefault("form.validation.max_errors", DefaultMaxErrors)
+	v.SetDefault("form.quota.max_forms_per_user", 0)
+	v.SetDefault("form.quota.max_submissions_per_month", 0)
+	v.SetDefault("form.quota.max_storage_bytes", 0)
+	v.SetDefault("form.read_cache_ttl", DefaultFormReadCacheTTL)
+	v.SetDefault("form.write_behind.enabled", false)
+	v.SetDefault("form.write_behind.batch_size", DefaultWriteBehindBatchSize)
+	v.SetDefault("form.write_behind.flush_interval", DefaultWriteBehindFlushInterval)
+	v.SetDefault("form.write_behind.queue_size", DefaultWriteBehindQueueSize)
+	v.SetDefault("form.throttle.enabled", false)
+	v.SetDefault("form.throttle.multiplier", DefaultThrottleMultiplier)
+	v.SetDefault("form.throttle.min_rate", DefaultThrottleMinRate)
+	v.SetDefault("form.throttle.cooldown", DefaultThrottleCooldown)
+	v.SetDefault("form.extensions.enabled", false)
+	v.SetDefault("form.extensions.timeout", DefaultExtensionTimeout)
+	v.SetDefault("form.scripts.enabled", false)
+	v.SetDefault("form.scripts.max_size", DefaultScriptMaxSize)
+	v.SetDefault("form.scripts.max_steps", DefaultScriptMaxSteps)
+	v.SetDefault("form.scripts.max_memory", DefaultScriptMaxMemory)
+	v.SetDefault("form.scripts.timeout", DefaultScriptTimeout)
+	v.SetDefault("form.images.max_pixels", DefaultImageMaxPixels)
+	v.SetDefault("form.scripts.on_error", "continue")
+	v.SetDefault("form.encryption.enabled", false)
+	v.SetDefault("form.encryption.keys", []string{})
+	v.SetDefault("form.encryption.setting_cache_ttl", DefaultEncryptionSettingCacheTTL)
+	v.SetDefault("form.encryption.rotation_interval", DefaultEncryptionRotationInterval)
+	v.SetDefault("form.encryption.rotation_batch_size", DefaultEncryptionRotationBatchSize)
+	v.SetDefault("form.options.ttl", DefaultOptionSourceTTL)
+	v.SetDefault("form.options.timeout", DefaultOptionSourceTimeout)
+	v.SetDefault("form.datasets.cache_ttl", DefaultDatasetCacheTTL)
+	v.SetDefault("form.datasets.max_rows", DefaultDatasetMaxRows)
+	v.SetDefault("form.datasets.max_per_owner", DefaultDatasetMaxPerOwner)
+	v.SetDefault("form.test_submissions.retention", DefaultTestSubmissionRetention)
 }
 
 // setAPIDefaults sets API default values
@@ -775,6 +575,14 @@ func setAPIDefaults(v *viper.Viper) {
 	v.SetDefault("api.rate_limit.enabled", true)
 	v.SetDefault("api.rate_limit.rps", DefaultAPIRateLimitRPS)
 	v.SetDefault("api.rate_limit.burst", DefaultAPIRateBurst)
+	v.SetDefault("api.idempotency.enabled", true)
+	v.SetDefault("api.idempotency.store", "memory")
+	v.SetDefault("api.idempotency.ttl", "24h")
+	v.SetDefault("api.metering.enabled", true)
+	v.SetDefault("api.metering.store", "memory")
+	v.SetDefault("api.metering.flush_interval", "1m")
+	v.SetDefault("api.metering.monthly_api_calls", 0)
+	v.SetDefault("api.metering.thresholds", []int{80, 100})
 }
 
 // setWebDefaults sets web default values
@@ -786,6 +594,19 @@ func setWebDefaults(v *viper.Viper) {
 	v.SetDefault("web.write_timeout", DefaultWriteTimeout)
 	v.SetDefault("web.idle_timeout", DefaultIdleTimeout)
 	v.SetDefault("web.gzip", true)
+	v.SetDefault("web.compression.encodings", []string{"br", "gzip"})
+	v.SetDefault("web.compression.gzip_level", DefaultGzipLevel)
+	v.SetDefault("web.compression.brotli_level", DefaultBrotliLevel)
+	v.SetDefault("web.compression.min_size", DefaultCompressionMinSize)
+	v.SetDefault("web.compression.content_types", []string{
+		"text/*",
+		"application/json",
+		"application/javascript",
+		"application/xml",
+		"application/problem+json",
+		"application/manifest+json",
+		"image/svg+xml",
+	})
 }
 
 // setUserDefaults sets user default values
@@ -795,13 +616,94 @@ func setUserDefaults(v *viper.Viper) {
 	v.SetDefault("user.admin.name", "Administrator")
 	v.SetDefault("user.default.role", "user")
 	v.SetDefault("user.default.permissions", []string{"read"})
+	v.SetDefault("user.deletion.grace_period", "720h")
+	v.SetDefault("user.deletion.purge_interval", "1h")
+	v.SetDefault("user.deletion.submissions", UserDeletionAnonymize)
+}
+
+// setFlagsDefaults sets feature flag default values
+func setFlagsDefaults(v *viper.Viper) {
+	v.SetDefault("flags.refresh_interval", "30s")
+}
+
+// setBillingDefaults sets billing default values
+func setBillingDefaults(v *viper.Viper) {
+	v.SetDefault("billing.enabled", false)
+	v.SetDefault("billing.stripe.timeout", "10s")
+	v.SetDefault("billing.stripe.webhook_tolerance", "5m")
+}
+
+// setResilienceDefaults sets outbound resilience default values
+func setResilienceDefaults(v *viper.Viper) {
+	v.SetDefault("resilience.enabled", true)
+}
+
+// setUpdatesDefaults sets release update check default values
+func setUpdatesDefaults(v *viper.Viper) {
+	v.SetDefault("updates.enabled", true)
+	v.SetDefault("updates.repository", "goformx/goforms")
+	v.SetDefault("updates.interval", "24h")
+	v.SetDefault("updates.api_url", "https://api.github.com")
+}
+
+// setEventsDefaults sets domain event default values
+func setEventsDefaults(v *viper.Viper) {
+	v.SetDefault("events.store.enabled", false)
+	v.SetDefault("events.store.retention", "168h")
+}
+
+// setDeadLetterDefaults sets dead-letter queue default values
+func setDeadLetterDefaults(v *viper.Viper) {
+	v.SetDefault("dead_letter.enabled", true)
+	v.SetDefault("dead_letter.retention", "720h")
+}
+
+// setPIIDefaults sets personal data detection default values
+func setPIIDefaults(v *viper.Viper) {
+	v.SetDefault("pii.profiles", []string{"us"})
+	v.SetDefault("pii.redact_logs", true)
+}
+
+// setCrashReportsDefaults sets crash report default values
+func setCrashReportsDefaults(v *viper.Viper) {
+	v.SetDefault("crash_reports.enabled", true)
+	v.SetDefault("crash_reports.directory", "storage/crash-reports")
+	v.SetDefault("crash_reports.max_reports", DefaultMaxCrashReports)
+	v.SetDefault("crash_reports.goroutine_dump", false)
+}
+
+// setOpsDefaults sets runtime diagnostics default values
+func setOpsDefaults(v *viper.Viper) {
+	v.SetDefault("ops.pprof.enabled", false)
+}
+
+// setTimeoutsDefaults sets the per-layer request time budget default values
+func setTimeoutsDefaults(v *viper.Viper) {
+	v.SetDefault("timeouts.database", DefaultDatabaseBudget)
+	v.SetDefault("timeouts.outbound", DefaultOutboundBudget)
+	v.SetDefault("timeouts.reserve", DefaultTimeoutReserve)
+}
+
+// setGeoIPDefaults sets submitter location default values
+func setGeoIPDefaults(v *viper.Viper) {
+	v.SetDefault("geoip.enabled", false)
+	v.SetDefault("geoip.database_path", "storage/geoip/GeoLite2-Country.mmdb")
+	v.SetDefault("geoip.update.enabled", false)
+	v.SetDefault("geoip.update.edition_id", "GeoLite2-Country")
+	v.SetDefault("geoip.update.interval", "72h")
+	v.SetDefault("geoip.update.url", "https://download.maxmind.com/geoip/databases")
 }
 
 // NewViperConfigProvider creates an Fx provider for Viper configuration
 func NewViperConfigProvider() fx.Option {
-	return fx.Provide(func() (*Config, error) {
+	return fx.Provide(func() (*Config, Sources, error) {
 		vc := NewViperConfig()
 
-		return vc.Load()
+		cfg, err := vc.Load()
+		if err != nil {
+			return nil, nil, err
+		}
+
+		return cfg, vc.Sources(), nil
 	})
 }
@@ -0,0 +1,40 @@
+package config_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/goformx/goforms/internal/infrastructure/config"
+)
+
+func TestViperConfig_LoadUnvalidated(t *testing.T) {
+	t.Run("environment", func(t *testing.T) {
+		t.Setenv("APP_PORT", "9090")
+		t.Setenv("DB_HOST", "db.internal")
+		t.Setenv("API_KEYS", "first, second")
+		t.Setenv("BILLING_STRIPE_ENDPOINT", "http://stripe.test")
+		t.Setenv("SECURITY_CORS_EXPOSED_HEADERS", "X-Request-ID,X-Total")
+
+		cfg, err := config.NewViperConfig().LoadUnvalidated()
+		require.NoError(t, err)
+
+		assert.Equal(t, 9090, cfg.App.Port)
+		assert.Equal(t, "db.internal", cfg.Database.Host)
+		assert.Equal(t, []string{"first", "second"}, cfg.Security.APIKey.Keys)
+		assert.Equal(t, "http://stripe.test", cfg.Billing.Stripe.Endpoint, "keys without a default are read too")
+		assert.Equal(t, []string{"X-Request-ID", "X-Total"}, cfg.Security.CORS.ExposedHeaders)
+		assert.Equal(t, cfg.Security.RateLimit.RPS, cfg.Security.RateLimit.Requests)
+	})
+
+	t.Run("invalid values name their keys", func(t *testing.T) {
+		t.Setenv("APP_PORT", "eighty")
+		t.Setenv("DATABASE_MAX_OPEN_CONNS", "many")
+
+		_, err := config.NewViperConfig().LoadUnvalidated()
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "app.port")
+		assert.Contains(t, err.Error(), "database.max_open_conns")
+	})
+}
@@ -4,54 +4,283 @@ import "time"
 
 // FormConfig holds form-related configuration
 type FormConfig struct {
-	MaxFileSize      int64            `json:"max_file_size"`
-	AllowedFileTypes []string         `json:"allowed_file_types"`
-	MaxFields        int              `json:"max_fields"`
-	MaxMemory        int64            `json:"max_memory"`
-	Validation       ValidationConfig `json:"validation"`
+	MaxFileSize      int64            `json:"max_file_size"      mapstructure:"max_file_size"`
+	AllowedFileTypes []string         `json:"allowed_file_types" mapstructure:"allowed_file_types"`
+	MaxFields        int              `json:"max_fields"         mapstructure:"max_fields"`
+	MaxMemory        int64            `json:"max_memory"         mapstructure:"max_memory"`
+	Validation       ValidationConfig `json:"validation"         mapstructure:"validation"`
+	Quota            QuotaConfig      `json:"quota"              mapstructure:"quota"`
+	// ReadCacheTTL is how long form reads are cached; zero disables the cache
+	ReadCacheTTL time.Duration              `json:"read_cache_ttl" mapstructure:"read_cache_ttl"`
+	WriteBehind  WriteBehindConfig          `json:"write_behind"   mapstructure:"write_behind"`
+	Throttle     ThrottleConfig             `json:"throttle"       mapstructure:"throttle"`
+	Extensions   ExtensionsConfig           `json:"extensions"     mapstructure:"extensions"`
+	Scripts      ScriptsConfig              `json:"scripts"        mapstructure:"scripts"`
+	Images       ImagesConfig               `json:"images"         mapstructure:"images"`
+	Encryption   SubmissionEncryptionConfig `json:"encryption"     mapstructure:"encryption"`
+	Options      OptionSourcesConfig        `json:"options"        mapstructure:"options"`
+	Datasets     DatasetsConfig             `json:"datasets"       mapstructure:"datasets"`
+	// TestSubmissions holds the settings of submissions made from the builder's preview
+	TestSubmissions TestSubmissionsConfig `json:"test_submissions" mapstructure:"test_submissions"`
+}
+
+// MinTestSubmissionRetention is the shortest time test submissions are kept
+const MinTestSubmissionRetention = time.Hour
+
+// TestSubmissionsConfig holds the settings of test submissions, which the
+// builder's preview makes without counting against quotas or showing up in
+// listings, exports and reports by default
+type TestSubmissionsConfig struct {
+	// Retention is how long test submissions are kept before they are purged
+	Retention time.Duration `desc:"Time test submissions from the builder's preview are kept; at least 1h" json:"retention" mapstructure:"retention"`
+}
+
+// DatasetsConfig holds limits on the datasets account owners manage for
+// their forms' option lists and computed fields
+type DatasetsConfig struct {
+	// CacheTTL is how long a dataset read by the form read path is cached; zero disables the cache
+	CacheTTL time.Duration `desc:"Time a dataset read by forms is cached; 0 disables" json:"cache_ttl" mapstructure:"cache_ttl"`
+	// MaxRows caps the rows of one dataset
+	MaxRows int `desc:"Maximum rows of one dataset" json:"max_rows" mapstructure:"max_rows"`
+	// MaxPerOwner caps the datasets of one account; zero is unlimited
+	MaxPerOwner int `desc:"Maximum datasets of one account; 0 is unlimited" json:"max_per_owner" mapstructure:"max_per_owner"`
+}
+
+// OptionSourcesConfig holds the option lists select, radio and selectboxes
+// fields can take their options from by name. Lists are fetched server-side
+// and cached, so forms stay current without editing their schema.
+type OptionSourcesConfig struct {
+	// TTL is how long a fetched list is cached when its source sets none
+	TTL time.Duration `desc:"Time a fetched option list is cached" json:"ttl" mapstructure:"ttl"`
+	// Timeout bounds each fetch
+	Timeout time.Duration        `desc:"Bound on each option list fetch" json:"timeout" mapstructure:"timeout"`
+	Sources []OptionSourceConfig `desc:"Named option lists fetched over HTTP" json:"sources" mapstructure:"sources"`
+}
+
+// OptionSourceConfig configures an option list fetched with a GET request
+// returning JSON: an array of objects, or an object holding one under Path.
+type OptionSourceConfig struct {
+	Name string `desc:"Name fields use in optionsSource" json:"name" mapstructure:"name"`
+	URL  string `desc:"URL fetched with GET, returning JSON" json:"url"  mapstructure:"url"`
+	// Path is the dot-separated path of the array in the response; empty when
+	// the response is the array
+	Path string `desc:"Dot-separated path of the option array in the response" json:"path" mapstructure:"path"`
+	// ValueProperty and LabelProperty name the properties of each item holding
+	// the option's value and label; they default to "value" and "label"
+	ValueProperty string `desc:"Item property holding the option value; default value" json:"value_property" mapstructure:"value_property"`
+	LabelProperty string `desc:"Item property holding the option label; default label" json:"label_property" mapstructure:"label_property"`
+	// TTL overrides form.options.ttl for this source
+	TTL time.Duration `desc:"Cache time overriding form.options.ttl" json:"ttl" mapstructure:"ttl"`
+	// Headers are sent with each fetch, such as an API key
+	Headers map[string]string `desc:"Headers sent with each fetch, such as an API key" json:"-" mapstructure:"headers"`
+}
+
+// SubmissionEncryptionConfig holds submission encryption-at-rest configuration. Accounts
+// that turn encryption on have the data and metadata of their submissions
+// stored encrypted with the active key; a rotation job re-encrypts rows still
+// under a retired key and brings stored rows in line with each account's setting.
+type SubmissionEncryptionConfig struct {
+	Enabled bool `desc:"Lets accounts encrypt their stored submissions" json:"enabled" mapstructure:"enabled"`
+	// Keys are "id:secret" pairs; the first encrypts new data and the rest
+	// only decrypt. A key must stay listed until rotation has moved rows off it.
+	Keys []string `desc:"Encryption keys as id:secret; the first is active" json:"-" mapstructure:"keys"`
+	// SettingCacheTTL is how long an account's setting is cached by the write path
+	SettingCacheTTL time.Duration `desc:"Time an account's encryption setting is cached" json:"setting_cache_ttl" mapstructure:"setting_cache_ttl"`
+	// RotationInterval is the time between rotation runs; zero only rotates on request
+	RotationInterval time.Duration `desc:"Time between rotation runs; 0 rotates on request" json:"rotation_interval" mapstructure:"rotation_interval"`
+	// RotationBatchSize is the number of submissions read per rotation batch
+	RotationBatchSize int `desc:"Submissions read per rotation batch" json:"rotation_batch_size" mapstructure:"rotation_batch_size"`
+}
+
+// ImagesConfig holds limits on processing uploaded images
+type ImagesConfig struct {
+	// MaxPixels is the largest image, in pixels, decoded for processing.
+	// Each pixel takes 4 bytes once decoded.
+	MaxPixels int `json:"max_pixels" mapstructure:"max_pixels"`
+}
+
+// ScriptsConfig holds submission script configuration. A form's script runs
+// before each submission is validated, bounded by the limits below.
+type ScriptsConfig struct {
+	Enabled bool `json:"enabled" mapstructure:"enabled"`
+	// MaxSize is the largest script source accepted, in bytes
+	MaxSize int `json:"max_size" mapstructure:"max_size"`
+	// MaxSteps is the CPU budget of a run, in evaluated statements and expressions
+	MaxSteps int `json:"max_steps" mapstructure:"max_steps"`
+	// MaxMemory is the number of bytes of strings, lists and objects a run may create
+	MaxMemory int `json:"max_memory" mapstructure:"max_memory"`
+	// Timeout bounds the wall-clock time of a run
+	Timeout time.Duration `json:"timeout" mapstructure:"timeout"`
+	// OnError is "continue" to keep the submitted data when a script fails or "reject" to refuse the submission
+	OnError string `json:"on_error" mapstructure:"on_error"`
+}
+
+// ExtensionsConfig holds form extension configuration. Compiled-in plugins are
+// provided through fx; external HTTP hooks are listed under Hooks. Both run
+// only for forms that enable them by name unless marked global.
+type ExtensionsConfig struct {
+	Enabled bool `json:"enabled" mapstructure:"enabled"`
+	// Timeout bounds each hook call that does not set its own
+	Timeout time.Duration         `json:"timeout" mapstructure:"timeout"`
+	Hooks   []ExtensionHookConfig `json:"hooks"   mapstructure:"hooks"`
+	// Plugins overrides the settings of compiled-in plugins, keyed by name
+	Plugins map[string]ExtensionSettingsConfig `json:"plugins" mapstructure:"plugins"`
+}
+
+// ExtensionSettingsConfig controls how one extension is run
+type ExtensionSettingsConfig struct {
+	// Timeout bounds each call; zero uses form.extensions.timeout
+	Timeout time.Duration `json:"timeout" mapstructure:"timeout"`
+	// OnError is "continue" (the default) to skip a failing hook or "reject" to fail the request
+	OnError string `json:"on_error" mapstructure:"on_error"`
+	// Global runs the extension for every form
+	Global bool `json:"global" mapstructure:"global"`
+}
+
+// ExtensionHookConfig configures an external HTTP hook. Each call POSTs the hook
+// request as JSON; when Secret is set the body is signed with HMAC-SHA256.
+type ExtensionHookConfig struct {
+	Name string `json:"name" mapstructure:"name"`
+	URL  string `json:"url"  mapstructure:"url"`
+	// Points lists the hook points: pre_validate, post_submit and pre_render
+	Points []string `json:"points" mapstructure:"points"`
+	Secret string   `json:"-"      mapstructure:"secret"`
+
+	ExtensionSettingsConfig `mapstructure:",squash"`
+}
+
+// ThrottleConfig holds adaptive per-form submission throttling configuration.
+// Each form's normal submission rate is learned; a spike beyond Multiplier
+// times that rate tightens the form's limit for Cooldown.
+type ThrottleConfig struct {
+	Enabled bool `json:"enabled" mapstructure:"enabled"`
+	// Multiplier is how far above its learned rate a form may go before it is throttled
+	Multiplier float64 `json:"multiplier" mapstructure:"multiplier"`
+	// MinRate is the per-minute rate assumed for quiet forms and forms still learning
+	MinRate int `json:"min_rate" mapstructure:"min_rate"`
+	// Cooldown is how long a spiking form stays throttled
+	Cooldown time.Duration `json:"cooldown" mapstructure:"cooldown"`
+}
+
+// WriteBehindConfig holds write-behind submission storage configuration.
+// Queued submissions are held in memory until their batch is written.
+type WriteBehindConfig struct {
+	Enabled bool `json:"enabled" mapstructure:"enabled"`
+	// BatchSize is the most submissions written in one insert
+	BatchSize int `json:"batch_size" mapstructure:"batch_size"`
+	// FlushInterval is the longest a queued submission waits for its batch
+	FlushInterval time.Duration `json:"flush_interval" mapstructure:"flush_interval"`
+	// QueueSize bounds queued submissions; a full queue falls back to synchronous writes
+	QueueSize int `json:"queue_size" mapstructure:"queue_size"`
+}
+
+// QuotaConfig holds per-user plan quotas. A zero limit means unlimited.
+type QuotaConfig struct {
+	MaxFormsPerUser        int   `json:"max_forms_per_user"        mapstructure:"max_forms_per_user"`
+	MaxSubmissionsPerMonth int   `json:"max_submissions_per_month" mapstructure:"max_submissions_per_month"`
+	MaxStorageBytes        int64 `json:"max_storage_bytes"         mapstructure:"max_storage_bytes"`
 }
 
 // ValidationConfig holds form validation configuration
 type ValidationConfig struct {
-	StrictMode bool `json:"strict_mode"`
-	MaxErrors  int  `json:"max_errors"`
+	StrictMode bool `json:"strict_mode" mapstructure:"strict_mode"`
+	MaxErrors  int  `json:"max_errors"  mapstructure:"max_errors"`
 }
 
 // APIConfig holds API-related configuration
 type APIConfig struct {
-	Version    string          `json:"version"`
-	Prefix     string          `json:"prefix"`
-	Timeout    time.Duration   `json:"timeout"`
-	MaxRetries int             `json:"max_retries"`
-	RateLimit  RateLimitConfig `json:"rate_limit"`
+	Version     string            `json:"version"     mapstructure:"version"`
+	Prefix      string            `json:"prefix"      mapstructure:"prefix"`
+	Timeout     time.Duration     `json:"timeout"     mapstructure:"timeout"`
+	MaxRetries  int               `json:"max_retries" mapstructure:"max_retries"`
+	RateLimit   RateLimitConfig   `json:"rate_limit"  mapstructure:"rate_limit"`
+	Idempotency IdempotencyConfig `json:"idempotency" mapstructure:"idempotency"`
+	Metering    MeteringConfig    `json:"metering"    mapstructure:"metering"`
+}
+
+// IdempotencyConfig holds Idempotency-Key handling configuration
+type IdempotencyConfig struct {
+	Enabled bool          `json:"enabled" mapstructure:"enabled"`
+	Store   string        `json:"store"   mapstructure:"store"` // memory, redis
+	TTL     time.Duration `json:"ttl"     mapstructure:"ttl"`
+}
+
+// MeteringConfig holds per-account API usage metering configuration
+type MeteringConfig struct {
+	Enabled bool   `json:"enabled" mapstructure:"enabled"`
+	Store   string `json:"store"   mapstructure:"store"` // memory, redis
+	// FlushInterval is how often counters are written to the database
+	FlushInterval time.Duration `json:"flush_interval" mapstructure:"flush_interval"`
+	// MonthlyAPICalls is each account's monthly API call allowance; 0 is unlimited
+	MonthlyAPICalls int64 `json:"monthly_api_calls" mapstructure:"monthly_api_calls"`
+	// Thresholds are the percentages of a limit that raise a usage event
+	Thresholds []int `json:"thresholds" mapstructure:"thresholds"`
 }
 
 // WebConfig holds web-related configuration
 type WebConfig struct {
-	TemplateDir  string        `json:"template_dir"`
-	StaticDir    string        `json:"static_dir"`
-	AssetsDir    string        `json:"assets_dir"`
-	ReadTimeout  time.Duration `json:"read_timeout"`
-	WriteTimeout time.Duration `json:"write_timeout"`
-	IdleTimeout  time.Duration `json:"idle_timeout"`
-	Gzip         bool          `json:"gzip"`
+	TemplateDir  string        `json:"template_dir"  mapstructure:"template_dir"`
+	StaticDir    string        `json:"static_dir"    mapstructure:"static_dir"`
+	AssetsDir    string        `json:"assets_dir"    mapstructure:"assets_dir"`
+	ReadTimeout  time.Duration `json:"read_timeout"  mapstructure:"read_timeout"  validate:"gt=0"`
+	WriteTimeout time.Duration `json:"write_timeout" mapstructure:"write_timeout" validate:"gt=0"`
+	IdleTimeout  time.Duration `json:"idle_timeout"  mapstructure:"idle_timeout"  validate:"gt=0"`
+	// Gzip enables response compression; the encodings used are set in Compression
+	Gzip        bool              `json:"gzip"        mapstructure:"gzip"`
+	Compression CompressionConfig `json:"compression" mapstructure:"compression"`
+}
+
+// CompressionConfig holds response compression configuration
+type CompressionConfig struct {
+	// Encodings lists the content codings offered, most preferred first (br, gzip)
+	Encodings   []string `json:"encodings"    mapstructure:"encodings"`
+	GzipLevel   int      `json:"gzip_level"   mapstructure:"gzip_level"`
+	BrotliLevel int      `json:"brotli_level" mapstructure:"brotli_level"`
+	// MinSize is the smallest response body, in bytes, worth compressing
+	MinSize int `json:"min_size" mapstructure:"min_size"`
+	// ContentTypes lists the compressible media types; a trailing "*" matches a prefix
+	ContentTypes []string `json:"content_types" mapstructure:"content_types"`
 }
 
 // UserConfig holds user-related configuration
 type UserConfig struct {
-	Admin   AdminUserConfig   `json:"admin"`
-	Default DefaultUserConfig `json:"default"`
+	Admin    AdminUserConfig    `json:"admin"    mapstructure:"admin"`
+	Default  DefaultUserConfig  `json:"default"  mapstructure:"default"`
+	Deletion UserDeletionConfig `json:"deletion" mapstructure:"deletion"`
+}
+
+// What the scheduled purge of a deleted account does with its forms' submissions
+const (
+	// UserDeletionAnonymize keeps the forms and submissions with their personal data removed
+	UserDeletionAnonymize = "anonymize"
+	// UserDeletionDelete deletes the forms and submissions with the account
+	UserDeletionDelete = "delete"
+)
+
+// MinUserDeletionPurgeInterval is the shortest time between purges of accounts due for deletion
+const MinUserDeletionPurgeInterval = time.Minute
+
+// UserDeletionConfig holds the settings for account deletion. An account its
+// owner deletes is purged once the grace period ends, unless the deletion is
+// cancelled first; an admin deletion is carried out at once.
+type UserDeletionConfig struct {
+	// GracePeriod is how long an owner can cancel the deletion of their account
+	GracePeriod time.Duration `desc:"Time an owner has to cancel the deletion of their account" json:"grace_period" mapstructure:"grace_period"`
+	// PurgeInterval is how often accounts whose grace period ended are purged
+	PurgeInterval time.Duration `desc:"Time between purges of deleted accounts; at least 1m" json:"purge_interval" mapstructure:"purge_interval"`
+	// Submissions is UserDeletionAnonymize or UserDeletionDelete
+	Submissions string `desc:"What a purge does with the account's submissions: anonymize or delete" json:"submissions" mapstructure:"submissions"`
 }
 
 // AdminUserConfig holds admin user configuration
 type AdminUserConfig struct {
-	Email    string `json:"email"`
-	Password string `json:"password"`
-	Name     string `json:"name"`
+	Email    string `json:"email"    mapstructure:"email"    validate:"omitempty,email"`
+	Password string `json:"password" mapstructure:"password" validate:"omitempty,min=8"`
+	Name     string `json:"name"     mapstructure:"name"`
 }
 
 // DefaultUserConfig holds default user configuration
 type DefaultUserConfig struct {
-	Role        string   `json:"role"`
-	Permissions []string `json:"permissions"`
+	Role        string   `json:"role"        mapstructure:"role" validate:"required"`
+	Permissions []string `json:"permissions" mapstructure:"permissions"`
 }
@@ -0,0 +1,42 @@
+// Package counter provides the integer counters usage metering accumulates
+// counts in between flushes, selected by api.metering.store. Redis counters are
+// shared between instances; memory counters are per process.
+package counter
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+
+	"github.com/goformx/goforms/internal/domain/metering"
+	"github.com/goformx/goforms/internal/infrastructure/config"
+	"github.com/goformx/goforms/internal/infrastructure/logging"
+	"github.com/goformx/goforms/internal/infrastructure/redis"
+)
+
+const (
+	// StoreMemory keeps counters in process memory
+	StoreMemory = "memory"
+	// StoreRedis keeps counters in Redis
+	StoreRedis = "redis"
+)
+
+// ErrUnknownStore is returned when api.metering.store names an unsupported backend
+var ErrUnknownStore = errors.New("unknown metering store")
+
+// New returns the counter store selected by api.metering.store. Redis uses the cache.redis settings.
+func New(cfg *config.Config, logger logging.Logger) (metering.Counter, error) {
+	switch cfg.API.Metering.Store {
+	case "", StoreMemory:
+		return NewMemoryCounter(), nil
+	case StoreRedis:
+		redisCfg := cfg.Cache.Redis
+		addr := redisCfg.Host + ":" + strconv.Itoa(redisCfg.Port)
+
+		logger.Info("using redis metering counters", "addr", addr, "db", redisCfg.DB)
+
+		return NewRedisCounter(redis.NewClient(addr, redisCfg.Password, redisCfg.DB)), nil
+	default:
+		return nil, fmt.Errorf("%w: %s", ErrUnknownStore, cfg.API.Metering.Store)
+	}
+}
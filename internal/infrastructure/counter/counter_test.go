@@ -0,0 +1,48 @@
+package counter_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/goformx/goforms/internal/domain/metering"
+	"github.com/goformx/goforms/internal/infrastructure/counter"
+	"github.com/goformx/goforms/internal/infrastructure/redis"
+	"github.com/goformx/goforms/internal/infrastructure/redis/redistest"
+)
+
+func exerciseCounter(t *testing.T, c metering.Counter) {
+	t.Helper()
+
+	ctx := t.Context()
+
+	n, err := c.Get(ctx, "calls")
+	require.NoError(t, err)
+	assert.Equal(t, int64(0), n)
+
+	require.NoError(t, c.Add(ctx, "calls", 2))
+	require.NoError(t, c.Add(ctx, "calls", 3))
+
+	n, err = c.Get(ctx, "calls")
+	require.NoError(t, err)
+	assert.Equal(t, int64(5), n)
+
+	n, err = c.Take(ctx, "calls")
+	require.NoError(t, err)
+	assert.Equal(t, int64(5), n)
+
+	n, err = c.Take(ctx, "calls")
+	require.NoError(t, err)
+	assert.Equal(t, int64(0), n)
+}
+
+func TestMemoryCounter(t *testing.T) {
+	exerciseCounter(t, counter.NewMemoryCounter())
+}
+
+func TestRedisCounter(t *testing.T) {
+	addr := redistest.Start(t)
+
+	exerciseCounter(t, counter.NewRedisCounter(redis.NewClient(addr, "", 0)))
+}
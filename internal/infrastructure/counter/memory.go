@@ -0,0 +1,46 @@
+package counter
+
+import (
+	"context"
+	"sync"
+)
+
+// MemoryCounter keeps counters in process memory
+type MemoryCounter struct {
+	mu     sync.Mutex
+	counts map[string]int64
+}
+
+// NewMemoryCounter creates an empty in-memory counter store
+func NewMemoryCounter() *MemoryCounter {
+	return &MemoryCounter{counts: make(map[string]int64)}
+}
+
+// Add adds n to the counter under key
+func (c *MemoryCounter) Add(_ context.Context, key string, n int64) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.counts[key] += n
+
+	return nil
+}
+
+// Get returns the counter under key, or 0
+func (c *MemoryCounter) Get(_ context.Context, key string) (int64, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.counts[key], nil
+}
+
+// Take returns the counter under key and removes it
+func (c *MemoryCounter) Take(_ context.Context, key string) (int64, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	n := c.counts[key]
+	delete(c.counts, key)
+
+	return n, nil
+}
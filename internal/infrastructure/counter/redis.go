@@ -0,0 +1,78 @@
+package counter
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/goformx/goforms/internal/infrastructure/redis"
+)
+
+const (
+	// redisKeyPrefix namespaces counter keys
+	redisKeyPrefix = "goforms:metering:"
+	// redisCounterTTL expires counters an instance stopped before flushing
+	redisCounterTTL = 48 * time.Hour
+)
+
+// RedisCounter keeps counters in Redis. INCRBY and GETDEL are atomic, so each
+// count is flushed by exactly one instance.
+type RedisCounter struct {
+	client *redis.Client
+}
+
+// NewRedisCounter creates a counter store backed by client
+func NewRedisCounter(client *redis.Client) *RedisCounter {
+	return &RedisCounter{client: client}
+}
+
+// Add adds n to the counter under key; a new counter expires after two days
+func (c *RedisCounter) Add(ctx context.Context, key string, n int64) error {
+	reply, err := c.client.Do(ctx, "INCRBY", redisKeyPrefix+key, strconv.FormatInt(n, 10))
+	if err != nil {
+		return fmt.Errorf("increment metering counter: %w", err)
+	}
+
+	if reply == strconv.FormatInt(n, 10) {
+		if _, err = c.client.Do(ctx, "PEXPIRE", redisKeyPrefix+key,
+			strconv.FormatInt(redisCounterTTL.Milliseconds(), 10)); err != nil {
+			return fmt.Errorf("expire metering counter: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// Get returns the counter under key, or 0
+func (c *RedisCounter) Get(ctx context.Context, key string) (int64, error) {
+	reply, err := c.client.Do(ctx, "GET", redisKeyPrefix+key)
+
+	return parseCount(reply, err)
+}
+
+// Take returns the counter under key and deletes it
+func (c *RedisCounter) Take(ctx context.Context, key string) (int64, error) {
+	reply, err := c.client.Do(ctx, "GETDEL", redisKeyPrefix+key)
+
+	return parseCount(reply, err)
+}
+
+// parseCount decodes a counter reply; a missing counter is 0
+func parseCount(reply string, err error) (int64, error) {
+	if errors.Is(err, redis.ErrNil) {
+		return 0, nil
+	}
+
+	if err != nil {
+		return 0, fmt.Errorf("read metering counter: %w", err)
+	}
+
+	n, err := strconv.ParseInt(reply, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("parse metering counter: %w", err)
+	}
+
+	return n, nil
+}
@@ -0,0 +1,262 @@
+// Package crashreport records the panics the recovery middleware catches. Each
+// report holds the panic value, the stack of the panicking goroutine, the
+// request it was serving and, optionally, the stacks of all goroutines. Reports
+// are written as JSON files to crash_reports.directory, which keeps the newest
+// crash_reports.max_reports, and are posted to an error tracker when
+// crash_reports.tracker_url is set. Panics are counted either way.
+package crashreport
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"runtime"
+	"slices"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/goformx/goforms/internal/infrastructure/config"
+	"github.com/goformx/goforms/internal/infrastructure/httpclient"
+	"github.com/goformx/goforms/internal/infrastructure/logging"
+	"github.com/goformx/goforms/internal/infrastructure/version"
+)
+
+const (
+	// filePrefix and fileSuffix name report files; names sort by time
+	filePrefix = "crash-"
+	fileSuffix = ".json"
+	// trackerTimeout bounds each post to the error tracker
+	trackerTimeout = 10 * time.Second
+	// maxGoroutineDump bounds the size of a goroutine dump
+	maxGoroutineDump = 8 << 20
+)
+
+// Request is the request a panicking handler was serving
+type Request struct {
+	Method     string `json:"method"`
+	Path       string `json:"path"`
+	Route      string `json:"route,omitempty"`
+	RemoteAddr string `json:"remote_addr,omitempty"`
+	UserAgent  string `json:"user_agent,omitempty"`
+	UserID     string `json:"user_id,omitempty"`
+}
+
+// Report describes one recovered panic
+type Report struct {
+	ID        string    `json:"id"`
+	Time      time.Time `json:"time"`
+	RequestID string    `json:"request_id,omitempty"`
+	// Panic is the recovered value and PanicType its Go type
+	Panic     string `json:"panic"`
+	PanicType string `json:"panic_type"`
+	// Stack is the stack of the panicking goroutine
+	Stack string `json:"stack"`
+	// Goroutines is the stacks of all goroutines, when crash_reports.goroutine_dump is set
+	Goroutines string       `json:"goroutines,omitempty"`
+	Request    Request      `json:"request"`
+	Version    version.Info `json:"version"`
+	Host       string       `json:"host,omitempty"`
+}
+
+// Reporter writes crash reports and counts panics
+type Reporter struct {
+	config config.CrashReportsConfig
+	client *http.Client
+	logger logging.Logger
+	host   string
+
+	// mu serializes writing and pruning report files
+	mu sync.Mutex
+
+	panics         atomic.Int64
+	written        atomic.Int64
+	writeFailures  atomic.Int64
+	trackerSent    atomic.Int64
+	trackerFailure atomic.Int64
+}
+
+// New creates the crash reporter. clients may be nil.
+func New(cfg config.CrashReportsConfig, clients *httpclient.Factory, logger logging.Logger) *Reporter {
+	host, _ := os.Hostname()
+
+	reporter := &Reporter{config: cfg, logger: logger, host: host}
+	if cfg.Enabled && cfg.TrackerURL != "" {
+		reporter.client = clients.Client(config.ResilienceTargetCrashReports, "tracker", trackerTimeout)
+	}
+
+	return reporter
+}
+
+// GoroutineDump reports whether reports should include the stacks of all goroutines
+func (r *Reporter) GoroutineDump() bool {
+	return r != nil && r.config.Enabled && r.config.GoroutineDump
+}
+
+// Capture counts a panic and, when crash reports are enabled, writes its
+// report and posts it to the error tracker in the background. It fills in the
+// report's ID, time, version and host, and returns the path of the report
+// file, or "" when none was written.
+func (r *Reporter) Capture(report *Report) string {
+	if r == nil {
+		return ""
+	}
+
+	r.panics.Add(1)
+
+	if !r.config.Enabled {
+		return ""
+	}
+
+	report.ID = uuid.New().String()
+	report.Time = time.Now().UTC()
+	report.Version = version.GetInfo()
+	report.Host = r.host
+
+	path, err := r.write(report)
+	if err != nil {
+		r.writeFailures.Add(1)
+		r.logger.Error("failed to write crash report", "error", err, "crash_id", report.ID)
+	} else {
+		r.written.Add(1)
+	}
+
+	if r.client != nil {
+		go r.send(*report)
+	}
+
+	return path
+}
+
+// write stores a report in the crash report directory and removes the oldest
+// files over crash_reports.max_reports
+func (r *Reporter) write(report *Report) (string, error) {
+	data, err := json.MarshalIndent(report, "", "  ")
+	if err != nil {
+		return "", fmt.Errorf("encode crash report: %w", err)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if mkdirErr := os.MkdirAll(r.config.Directory, 0o750); mkdirErr != nil {
+		return "", fmt.Errorf("create crash report directory: %w", mkdirErr)
+	}
+
+	name := filePrefix + report.Time.Format("20060102T150405.000000000Z") + "-" + report.ID[:8] + fileSuffix
+	path := filepath.Join(r.config.Directory, name)
+
+	if writeErr := os.WriteFile(path, data, 0o600); writeErr != nil {
+		return "", fmt.Errorf("write crash report: %w", writeErr)
+	}
+
+	r.prune()
+
+	return path, nil
+}
+
+// prune removes the oldest report files over crash_reports.max_reports
+func (r *Reporter) prune() {
+	entries, err := os.ReadDir(r.config.Directory)
+	if err != nil {
+		return
+	}
+
+	var names []string
+
+	for _, entry := range entries {
+		if !entry.IsDir() && strings.HasPrefix(entry.Name(), filePrefix) && strings.HasSuffix(entry.Name(), fileSuffix) {
+			names = append(names, entry.Name())
+		}
+	}
+
+	if len(names) <= r.config.MaxReports {
+		return
+	}
+
+	slices.Sort(names)
+
+	for _, name := range names[:len(names)-r.config.MaxReports] {
+		if removeErr := os.Remove(filepath.Join(r.config.Directory, name)); removeErr != nil {
+			r.logger.Warn("failed to remove old crash report", "file", name, "error", removeErr)
+		}
+	}
+}
+
+// send posts a report to the error tracker
+func (r *Reporter) send(report Report) {
+	ctx, cancel := context.WithTimeout(context.Background(), trackerTimeout)
+	defer cancel()
+
+	if err := r.post(ctx, &report); err != nil {
+		r.trackerFailure.Add(1)
+		r.logger.Warn("failed to send crash report to error tracker", "error", err, "crash_id", report.ID)
+
+		return
+	}
+
+	r.trackerSent.Add(1)
+}
+
+// post sends one report as JSON to crash_reports.tracker_url
+func (r *Reporter) post(ctx context.Context, report *Report) error {
+	body, err := json.Marshal(report)
+	if err != nil {
+		return fmt.Errorf("encode crash report: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, r.config.TrackerURL, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("create error tracker request: %w", err)
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+
+	if r.config.TrackerToken != "" {
+		req.Header.Set("Authorization", "Bearer "+r.config.TrackerToken)
+	}
+
+	resp, err := r.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("post crash report: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
+		return fmt.Errorf("error tracker returned %s", resp.Status)
+	}
+
+	return nil
+}
+
+// GetMetrics returns the panic and report counts
+func (r *Reporter) GetMetrics() map[string]any {
+	return map[string]any{
+		"panics":           r.panics.Load(),
+		"reports_written":  r.written.Load(),
+		"write_failures":   r.writeFailures.Load(),
+		"tracker_sent":     r.trackerSent.Load(),
+		"tracker_failures": r.trackerFailure.Load(),
+	}
+}
+
+// Goroutines returns the stacks of all goroutines, cut at 8 MiB
+func Goroutines() string {
+	buf := make([]byte, 1<<20)
+
+	for {
+		n := runtime.Stack(buf, true)
+		if n < len(buf) || len(buf) >= maxGoroutineDump {
+			return string(buf[:n])
+		}
+
+		buf = make([]byte, 2*len(buf))
+	}
+}
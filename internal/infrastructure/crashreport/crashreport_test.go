@@ -0,0 +1,104 @@
+package crashreport_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/goformx/goforms/internal/infrastructure/config"
+	"github.com/goformx/goforms/internal/infrastructure/crashreport"
+	mocklogging "github.com/goformx/goforms/test/mocks/logging"
+)
+
+func TestReporter_WritesAndPrunesReports(t *testing.T) {
+	dir := t.TempDir()
+	reporter := crashreport.New(config.CrashReportsConfig{
+		Enabled:    true,
+		Directory:  dir,
+		MaxReports: 2,
+	}, nil, mocklogging.NewMockLogger(gomock.NewController(t)))
+
+	var paths []string
+	for range 3 {
+		paths = append(paths, reporter.Capture(&crashreport.Report{
+			RequestID: "req-1",
+			Panic:     "boom",
+			Stack:     "goroutine 1 [running]:",
+			Request:   crashreport.Request{Method: http.MethodGet, Path: "/forms/abc"},
+		}))
+	}
+
+	entries, err := os.ReadDir(dir)
+	require.NoError(t, err)
+	require.Len(t, entries, 2, "the oldest report is removed")
+	assert.NoFileExists(t, paths[0])
+
+	data, err := os.ReadFile(paths[2])
+	require.NoError(t, err)
+
+	var report crashreport.Report
+	require.NoError(t, json.Unmarshal(data, &report))
+	assert.NotEmpty(t, report.ID)
+	assert.Equal(t, "boom", report.Panic)
+	assert.Equal(t, "/forms/abc", report.Request.Path)
+	assert.NotEmpty(t, report.Version.GoVersion)
+
+	metrics := reporter.GetMetrics()
+	assert.EqualValues(t, 3, metrics["panics"])
+	assert.EqualValues(t, 3, metrics["reports_written"])
+}
+
+func TestReporter_PostsToTracker(t *testing.T) {
+	received := make(chan crashreport.Report, 1)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "Bearer secret", r.Header.Get("Authorization"))
+
+		var report crashreport.Report
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&report))
+		received <- report
+
+		w.WriteHeader(http.StatusAccepted)
+	}))
+	defer server.Close()
+
+	reporter := crashreport.New(config.CrashReportsConfig{
+		Enabled:      true,
+		Directory:    t.TempDir(),
+		MaxReports:   10,
+		TrackerURL:   server.URL,
+		TrackerToken: "secret",
+	}, nil, mocklogging.NewMockLogger(gomock.NewController(t)))
+
+	reporter.Capture(&crashreport.Report{Panic: "boom"})
+
+	select {
+	case report := <-received:
+		assert.Equal(t, "boom", report.Panic)
+	case <-time.After(5 * time.Second):
+		t.Fatal("crash report was not posted")
+	}
+
+	assert.Eventually(t, func() bool { return reporter.GetMetrics()["tracker_sent"] == int64(1) }, time.Second, 10*time.Millisecond)
+}
+
+func TestReporter_DisabledOnlyCounts(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "crashes")
+	reporter := crashreport.New(config.CrashReportsConfig{Directory: dir, MaxReports: 10}, nil, nil)
+
+	assert.Empty(t, reporter.Capture(&crashreport.Report{Panic: "boom"}))
+	assert.NoDirExists(t, dir)
+	assert.EqualValues(t, 1, reporter.GetMetrics()["panics"])
+	assert.False(t, reporter.GoroutineDump())
+}
+
+func TestGoroutines(t *testing.T) {
+	assert.Contains(t, crashreport.Goroutines(), "TestGoroutines")
+}
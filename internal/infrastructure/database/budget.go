@@ -0,0 +1,80 @@
+package database
+
+import (
+	"context"
+	"errors"
+
+	"gorm.io/gorm"
+
+	"github.com/goformx/goforms/internal/infrastructure/timeout"
+)
+
+// budgetKey is the statement setting holding a statement's budget
+const budgetKey = "goforms:request_budget"
+
+// statementBudget is the budget context of one statement and the context it replaced
+type statementBudget struct {
+	parent context.Context
+	cancel context.CancelFunc
+}
+
+// BudgetPlugin is a GORM plugin that bounds each statement made for a request
+// by the request's database budget (timeouts.database), so a query outliving
+// the request is cancelled instead of holding a connection. Row and Rows
+// statements are skipped: their results are read after the callbacks return.
+type BudgetPlugin struct{}
+
+// Name returns the plugin name
+func (BudgetPlugin) Name() string {
+	return "goforms:request_budget"
+}
+
+// Initialize registers the plugin's callbacks
+func (p BudgetPlugin) Initialize(db *gorm.DB) error {
+	return errors.Join(
+		db.Callback().Create().Before("gorm:create").Register("goforms:budget_create", p.start),
+		db.Callback().Create().After("gorm:create").Register("goforms:budget_create_done", p.finish),
+		db.Callback().Query().Before("gorm:query").Register("goforms:budget_query", p.start),
+		db.Callback().Query().After("gorm:query").Register("goforms:budget_query_done", p.finish),
+		db.Callback().Update().Before("gorm:update").Register("goforms:budget_update", p.start),
+		db.Callback().Update().After("gorm:update").Register("goforms:budget_update_done", p.finish),
+		db.Callback().Delete().Before("gorm:delete").Register("goforms:budget_delete", p.start),
+		db.Callback().Delete().After("gorm:delete").Register("goforms:budget_delete_done", p.finish),
+		db.Callback().Raw().Before("gorm:raw").Register("goforms:budget_raw", p.start),
+		db.Callback().Raw().After("gorm:raw").Register("goforms:budget_raw_done", p.finish),
+	)
+}
+
+// start replaces the statement's context with one bounded by the request's budget
+func (BudgetPlugin) start(db *gorm.DB) {
+	// Clear what an earlier statement of a reused query chain left behind
+	db.InstanceSet(budgetKey, statementBudget{})
+
+	if db.Statement.Context == nil {
+		return
+	}
+
+	if _, ok := timeout.FromContext(db.Statement.Context); !ok {
+		return
+	}
+
+	parent := db.Statement.Context
+	ctx, cancel := timeout.Database(parent)
+	db.Statement.Context = ctx
+	db.InstanceSet(budgetKey, statementBudget{parent: parent, cancel: cancel})
+}
+
+// finish releases the statement's budget context and puts the request's
+// context back, since a query chain may run further statements, such as a
+// Count and then a Find
+func (BudgetPlugin) finish(db *gorm.DB) {
+	value, ok := db.InstanceGet(budgetKey)
+	if !ok {
+		return
+	}
+
+	if budget, isBudget := value.(statementBudget); isBudget && budget.cancel != nil {
+		budget.cancel()
+		db.Statement.Context = budget.parent
+	}
+}
@@ -0,0 +1,239 @@
+package database
+
+import (
+	"cmp"
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+
+	mysqldriver "github.com/go-sql-driver/mysql"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/stdlib"
+	"gorm.io/driver/mysql"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+
+	"github.com/goformx/goforms/internal/infrastructure/config"
+)
+
+// postgresDialector opens PostgreSQL with the configured TLS files, socket and
+// IAM authentication
+func postgresDialector(cfg *config.Config) (gorm.Dialector, error) {
+	connConfig, err := pgx.ParseConfig(buildPostgresDSN(cfg))
+	if err != nil {
+		return nil, fmt.Errorf("parse PostgreSQL settings: %w", err)
+	}
+
+	// pgx checks verify-full certificates against the host; a server name overrides it
+	if name := cfg.Database.TLS.ServerName; name != "" {
+		setServerName(connConfig.TLSConfig, name)
+
+		for _, fallback := range connConfig.Fallbacks {
+			setServerName(fallback.TLSConfig, name)
+		}
+	}
+
+	var options []stdlib.OptionOpenDB
+
+	if cfg.Database.IAM.Enabled {
+		tokens, tokenErr := newIAMTokenSource(context.Background(), &cfg.Database)
+		if tokenErr != nil {
+			return nil, tokenErr
+		}
+
+		options = append(options, stdlib.OptionBeforeConnect(func(ctx context.Context, connConfig *pgx.ConnConfig) error {
+			token, signErr := tokens.Token(ctx)
+			if signErr != nil {
+				return signErr
+			}
+
+			connConfig.Password = token
+
+			return nil
+		}))
+	}
+
+	return postgres.New(postgres.Config{Conn: stdlib.OpenDB(*connConfig, options...)}), nil
+}
+
+// mariaDBDialector opens MariaDB with the configured TLS files, socket and IAM
+// authentication
+func mariaDBDialector(cfg *config.Config) (gorm.Dialector, error) {
+	dsnConfig, err := mysqldriver.ParseDSN(buildMariaDBDSN(cfg))
+	if err != nil {
+		return nil, fmt.Errorf("parse MariaDB settings: %w", err)
+	}
+
+	if cfg.Database.TLSEnabled() && cfg.Database.Socket == "" {
+		if dsnConfig.TLS, err = mariaDBTLSConfig(&cfg.Database); err != nil {
+			return nil, err
+		}
+	}
+
+	if cfg.Database.IAM.Enabled {
+		tokens, tokenErr := newIAMTokenSource(context.Background(), &cfg.Database)
+		if tokenErr != nil {
+			return nil, tokenErr
+		}
+
+		// RDS takes the token through the cleartext plugin, safe over the required TLS
+		dsnConfig.AllowCleartextPasswords = true
+
+		if err = dsnConfig.Apply(mysqldriver.BeforeConnect(func(ctx context.Context, dsnConfig *mysqldriver.Config) error {
+			token, signErr := tokens.Token(ctx)
+			if signErr != nil {
+				return signErr
+			}
+
+			dsnConfig.Passwd = token
+
+			return nil
+		})); err != nil {
+			return nil, fmt.Errorf("configure RDS IAM authentication: %w", err)
+		}
+	}
+
+	connector, err := mysqldriver.NewConnector(dsnConfig)
+	if err != nil {
+		return nil, fmt.Errorf("create MariaDB connector: %w", err)
+	}
+
+	return mysql.New(mysql.Config{Conn: sql.OpenDB(connector)}), nil
+}
+
+// buildPostgresDSN builds the PostgreSQL connection string
+func buildPostgresDSN(cfg *config.Config) string {
+	db := cfg.Database
+
+	host := db.Host
+	if db.Socket != "" {
+		host = db.Socket
+	}
+
+	settings := []string{
+		"host=" + postgresValue(host),
+		fmt.Sprintf("port=%d", db.Port),
+		"user=" + postgresValue(db.Username),
+		"password=" + postgresValue(db.Password),
+		"dbname=" + postgresValue(db.Name),
+		"sslmode=" + postgresValue(db.SSLMode),
+	}
+
+	files := []struct{ key, path string }{
+		{"sslrootcert", db.TLS.CAFile},
+		{"sslcert", db.TLS.CertFile},
+		{"sslkey", db.TLS.KeyFile},
+	}
+
+	for _, file := range files {
+		if file.path != "" {
+			settings = append(settings, file.key+"="+postgresValue(file.path))
+		}
+	}
+
+	return strings.Join(settings, " ")
+}
+
+// postgresValue quotes a connection string value when it is empty or holds
+// spaces, quotes or backslashes
+func postgresValue(value string) string {
+	if value != "" && !strings.ContainsAny(value, ` '\`) {
+		return value
+	}
+
+	return "'" + strings.NewReplacer(`\`, `\\`, `'`, `\'`).Replace(value) + "'"
+}
+
+// buildMariaDBDSN builds the MariaDB connection string
+func buildMariaDBDSN(cfg *config.Config) string {
+	address := fmt.Sprintf("tcp(%s:%d)", cfg.Database.Host, cfg.Database.Port)
+	if cfg.Database.Socket != "" {
+		address = fmt.Sprintf("unix(%s)", cfg.Database.Socket)
+	}
+
+	return fmt.Sprintf("%s:%s@%s/%s?charset=utf8mb4&parseTime=True&loc=UTC",
+		cfg.Database.Username,
+		cfg.Database.Password,
+		address,
+		cfg.Database.Name,
+	)
+}
+
+// mariaDBTLSConfig builds the TLS settings of the SSL mode, which the MySQL
+// driver has no names for: require encrypts without checking the server,
+// verify-ca checks its certificate chain and verify-full its name too
+func mariaDBTLSConfig(cfg *config.DatabaseConfig) (*tls.Config, error) {
+	rootCAs, err := cfg.TLS.RootCAs()
+	if err != nil {
+		return nil, err
+	}
+
+	tlsConfig := &tls.Config{
+		MinVersion: tls.VersionTLS12,
+		RootCAs:    rootCAs,
+		ServerName: cmp.Or(cfg.TLS.ServerName, cfg.Host),
+	}
+
+	if cfg.TLS.CertFile != "" {
+		certificate, certErr := tls.LoadX509KeyPair(cfg.TLS.CertFile, cfg.TLS.KeyFile)
+		if certErr != nil {
+			return nil, fmt.Errorf("database TLS client certificate: %w", certErr)
+		}
+
+		tlsConfig.Certificates = []tls.Certificate{certificate}
+	}
+
+	switch cfg.SSLMode {
+	case config.SSLModeRequire:
+		tlsConfig.InsecureSkipVerify = true //nolint:gosec // require mode encrypts without verifying, as in libpq
+	case config.SSLModeVerifyCA:
+		tlsConfig.InsecureSkipVerify = true //nolint:gosec // The chain is verified below, without the host name
+		tlsConfig.VerifyPeerCertificate = verifyChain(rootCAs)
+	}
+
+	return tlsConfig, nil
+}
+
+// verifyChain verifies the server certificate chain against the roots,
+// ignoring the name it was issued to
+func verifyChain(roots *x509.CertPool) func([][]byte, [][]*x509.Certificate) error {
+	return func(rawCerts [][]byte, _ [][]*x509.Certificate) error {
+		if len(rawCerts) == 0 {
+			return errors.New("database server sent no certificate")
+		}
+
+		certs := make([]*x509.Certificate, 0, len(rawCerts))
+
+		for _, raw := range rawCerts {
+			cert, err := x509.ParseCertificate(raw)
+			if err != nil {
+				return fmt.Errorf("parse database server certificate: %w", err)
+			}
+
+			certs = append(certs, cert)
+		}
+
+		intermediates := x509.NewCertPool()
+		for _, cert := range certs[1:] {
+			intermediates.AddCert(cert)
+		}
+
+		if _, err := certs[0].Verify(x509.VerifyOptions{Roots: roots, Intermediates: intermediates}); err != nil {
+			return fmt.Errorf("verify database server certificate: %w", err)
+		}
+
+		return nil
+	}
+}
+
+// setServerName sets the name checked against the server certificate of a
+// TLS connection; nil settings mean TLS is off
+func setServerName(tlsConfig *tls.Config, name string) {
+	if tlsConfig != nil {
+		tlsConfig.ServerName = name
+	}
+}
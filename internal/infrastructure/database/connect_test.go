@@ -0,0 +1,71 @@
+package database
+
+import (
+	"context"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	v4 "github.com/aws/aws-sdk-go-v2/aws/signer/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/goformx/goforms/internal/infrastructure/config"
+)
+
+func TestBuildPostgresDSN(t *testing.T) {
+	cfg := &config.Config{Database: config.DatabaseConfig{
+		Host:     "db",
+		Port:     5432,
+		Username: "goforms",
+		Password: "it's secret",
+		Name:     "goforms",
+		SSLMode:  config.SSLModeVerifyFull,
+		TLS:      config.DatabaseTLSConfig{CAFile: "/etc/ssl/rds ca.pem"},
+	}}
+
+	assert.Equal(t,
+		`host=db port=5432 user=goforms password='it\'s secret' dbname=goforms sslmode=verify-full sslrootcert='/etc/ssl/rds ca.pem'`,
+		buildPostgresDSN(cfg))
+
+	cfg.Database.Socket = "/var/run/postgresql"
+	cfg.Database.Password = ""
+	assert.True(t, strings.HasPrefix(buildPostgresDSN(cfg), "host=/var/run/postgresql port=5432 user=goforms password='' "))
+}
+
+func TestBuildMariaDBDSN(t *testing.T) {
+	cfg := &config.Config{Database: config.DatabaseConfig{Host: "db", Port: 3306, Username: "goforms", Password: "secret", Name: "goforms"}}
+	assert.Equal(t, "goforms:secret@tcp(db:3306)/goforms?charset=utf8mb4&parseTime=True&loc=UTC", buildMariaDBDSN(cfg))
+
+	cfg.Database.Socket = "/run/mysqld/mysqld.sock"
+	assert.Equal(t, "goforms:secret@unix(/run/mysqld/mysqld.sock)/goforms?charset=utf8mb4&parseTime=True&loc=UTC", buildMariaDBDSN(cfg))
+}
+
+func TestIAMTokenSource_Token(t *testing.T) {
+	source := &iamTokenSource{
+		credentials: aws.NewCredentialsCache(aws.CredentialsProviderFunc(func(context.Context) (aws.Credentials, error) {
+			return aws.Credentials{AccessKeyID: "AKIDEXAMPLE", SecretAccessKey: "secret"}, nil
+		})),
+		signer:   v4.NewSigner(),
+		endpoint: "db.abc.eu-west-1.rds.amazonaws.com:5432",
+		region:   "eu-west-1",
+		user:     "goforms",
+	}
+
+	token, err := source.Token(t.Context())
+	require.NoError(t, err)
+
+	host, rawQuery, found := strings.Cut(token, "/?")
+	require.True(t, found, "the token is a presigned URL without its scheme")
+	assert.Equal(t, "db.abc.eu-west-1.rds.amazonaws.com:5432", host)
+
+	query, err := url.ParseQuery(rawQuery)
+	require.NoError(t, err)
+	assert.Equal(t, "connect", query.Get("Action"))
+	assert.Equal(t, "goforms", query.Get("DBUser"))
+	assert.Equal(t, "900", query.Get("X-Amz-Expires"))
+	assert.Contains(t, query.Get("X-Amz-Credential"), "AKIDEXAMPLE/")
+	assert.Contains(t, query.Get("X-Amz-Credential"), "/eu-west-1/rds-db/aws4_request")
+	assert.NotEmpty(t, query.Get("X-Amz-Signature"))
+}
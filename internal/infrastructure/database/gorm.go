@@ -8,8 +8,6 @@ import (
 	"strings"
 	"time"
 
-	"gorm.io/driver/mysql"
-	"gorm.io/driver/postgres"
 	"gorm.io/gorm"
 	"gorm.io/gorm/logger"
 
@@ -61,6 +59,11 @@ func New(cfg *config.Config, appLogger logging.Logger) (*GormDB, error) {
 		return nil, err
 	}
 
+	// Bound the statements made for a request by its database budget
+	if useErr := db.Use(BudgetPlugin{}); useErr != nil {
+		return nil, fmt.Errorf("register request budget plugin: %w", useErr)
+	}
+
 	// Configure connection pool
 	if poolErr := configureConnectionPool(db, cfg); poolErr != nil {
 		return nil, poolErr
@@ -83,6 +86,27 @@ func New(cfg *config.Config, appLogger logging.Logger) (*GormDB, error) {
 	}, nil
 }
 
+// Ping opens a connection with the configured settings, pings the database and
+// closes it, for preflight checks that run without the application
+func Ping(ctx context.Context, cfg *config.Config) error {
+	db, err := createDatabaseConnection(cfg, &gorm.Config{Logger: logger.Discard})
+	if err != nil {
+		return err
+	}
+
+	sqlDB, err := db.DB()
+	if err != nil {
+		return fmt.Errorf("failed to get database instance: %w", err)
+	}
+	defer sqlDB.Close()
+
+	if pingErr := sqlDB.PingContext(ctx); pingErr != nil {
+		return fmt.Errorf("failed to ping database: %w", pingErr)
+	}
+
+	return nil
+}
+
 // configureGormLogger configures the GORM logger with the specified settings
 func configureGormLogger(cfg *config.Config, appLogger logging.Logger) logger.Interface {
 	// Map our log levels to GORM log levels
@@ -123,11 +147,19 @@ func createDatabaseConnection(cfg *config.Config, gormConfig *gorm.Config) (*gor
 	// Create database connection based on the selected driver
 	switch cfg.Database.Driver {
 	case "postgres":
-		dsn := buildPostgresDSN(cfg)
-		db, err = gorm.Open(postgres.Open(dsn), gormConfig)
+		dialector, dialErr := postgresDialector(cfg)
+		if dialErr != nil {
+			return nil, dialErr
+		}
+
+		db, err = gorm.Open(dialector, gormConfig)
 	case "mariadb":
-		dsn := buildMariaDBDSN(cfg)
-		db, err = gorm.Open(mysql.Open(dsn), gormConfig)
+		dialector, dialErr := mariaDBDialector(cfg)
+		if dialErr != nil {
+			return nil, dialErr
+		}
+
+		db, err = gorm.Open(dialector, gormConfig)
 	default:
 		return nil, fmt.Errorf("unsupported database connection type: %s", cfg.Database.Driver)
 	}
@@ -139,29 +171,6 @@ func createDatabaseConnection(cfg *config.Config, gormConfig *gorm.Config) (*gor
 	return db, nil
 }
 
-// buildPostgresDSN builds the PostgreSQL connection string
-func buildPostgresDSN(cfg *config.Config) string {
-	return fmt.Sprintf("host=%s port=%d user=%s password=%s dbname=%s sslmode=%s",
-		cfg.Database.Host,
-		cfg.Database.Port,
-		cfg.Database.Username,
-		cfg.Database.Password,
-		cfg.Database.Name,
-		cfg.Database.SSLMode,
-	)
-}
-
-// buildMariaDBDSN builds the MariaDB connection string
-func buildMariaDBDSN(cfg *config.Config) string {
-	return fmt.Sprintf("%s:%s@tcp(%s:%d)/%s?charset=utf8mb4&parseTime=True&loc=UTC",
-		cfg.Database.Username,
-		cfg.Database.Password,
-		cfg.Database.Host,
-		cfg.Database.Port,
-		cfg.Database.Name,
-	)
-}
-
 // configureConnectionPool configures the database connection pool
 func configureConnectionPool(db *gorm.DB, cfg *config.Config) error {
 	sqlDB, err := db.DB()
@@ -0,0 +1,162 @@
+package database
+
+import (
+	"cmp"
+	"crypto/sha256"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/goformx/goforms/internal/infrastructure/config"
+	"github.com/goformx/goforms/internal/infrastructure/logging"
+)
+
+// Ring is a consistent hash ring of shard IDs. Each shard has a number of
+// points on the ring and a key belongs to the shard of the first point at or
+// after its hash, so adding or removing a shard only moves the keys next to
+// that shard's points.
+type Ring struct {
+	points []ringPoint
+}
+
+// ringPoint is one of a shard's points on the ring
+type ringPoint struct {
+	hash  uint64
+	shard string
+}
+
+// NewRing places each shard on the ring with the given number of points
+func NewRing(shardIDs []string, virtualNodes int) *Ring {
+	ring := &Ring{points: make([]ringPoint, 0, len(shardIDs)*virtualNodes)}
+
+	for _, id := range shardIDs {
+		for i := range virtualNodes {
+			ring.points = append(ring.points, ringPoint{hash: ringHash(id + "#" + strconv.Itoa(i)), shard: id})
+		}
+	}
+
+	// Points that hash alike are ordered by shard, so every ring built from the
+	// same shards agrees
+	slices.SortFunc(ring.points, func(a, b ringPoint) int {
+		return cmp.Or(cmp.Compare(a.hash, b.hash), strings.Compare(a.shard, b.shard))
+	})
+
+	return ring
+}
+
+// Locate returns the shard a key belongs to, or "" for an empty ring
+func (r *Ring) Locate(key string) string {
+	if len(r.points) == 0 {
+		return ""
+	}
+
+	hash := ringHash(key)
+
+	i, _ := slices.BinarySearchFunc(r.points, hash, func(point ringPoint, target uint64) int {
+		return cmp.Compare(point.hash, target)
+	})
+	if i == len(r.points) {
+		i = 0
+	}
+
+	return r.points[i].shard
+}
+
+// ringHash places a key on the ring with the first 8 bytes of its SHA-256,
+// which spreads similar keys such as UUIDs evenly
+func ringHash(key string) uint64 {
+	sum := sha256.Sum256([]byte(key))
+
+	return binary.BigEndian.Uint64(sum[:8])
+}
+
+// Shards holds the connections of the databases submissions are sharded over
+// and routes each form to one of them by its ID. Retired shards are connected
+// but not on the ring, so no form is routed to them.
+type Shards struct {
+	ring *Ring
+	ids  []string
+	dbs  map[string]DB
+}
+
+// NewShards routes forms over the given shard connections, except the retired ones
+func NewShards(dbs map[string]DB, retired []string, virtualNodes int) *Shards {
+	ids := make([]string, 0, len(dbs))
+	for id := range dbs {
+		ids = append(ids, id)
+	}
+
+	slices.Sort(ids)
+
+	active := slices.DeleteFunc(slices.Clone(ids), func(id string) bool { return slices.Contains(retired, id) })
+
+	return &Shards{ring: NewRing(active, virtualNodes), ids: ids, dbs: dbs}
+}
+
+// OpenShards connects to each configured shard with the primary's driver, TLS
+// and pool settings. Connections already opened are closed when one fails.
+func OpenShards(cfg *config.Config, logger logging.Logger) (*Shards, error) {
+	dbs := make(map[string]DB, len(cfg.Database.Sharding.Shards))
+
+	var retired []string
+
+	for i := range cfg.Database.Sharding.Shards {
+		shard := &cfg.Database.Sharding.Shards[i]
+
+		shardCfg := *cfg
+		shardCfg.Database = cfg.Database.Shard(shard)
+
+		db, err := New(&shardCfg, logger.With("shard", shard.ID))
+		if err != nil {
+			for _, opened := range dbs {
+				_ = opened.Close()
+			}
+
+			return nil, fmt.Errorf("connect to database shard %s: %w", shard.ID, err)
+		}
+
+		dbs[shard.ID] = db
+
+		if shard.Retired {
+			retired = append(retired, shard.ID)
+		}
+	}
+
+	return NewShards(dbs, retired, cfg.Database.Sharding.VirtualNodes), nil
+}
+
+// Locate returns the ID of the shard holding a form's submissions
+func (s *Shards) Locate(formID string) string {
+	return s.ring.Locate(strings.ToLower(strings.TrimSpace(formID)))
+}
+
+// For returns the connection of the shard holding a form's submissions
+func (s *Shards) For(formID string) DB {
+	return s.dbs[s.Locate(formID)]
+}
+
+// IDs lists the IDs of every shard in order, retired ones included
+func (s *Shards) IDs() []string {
+	return slices.Clone(s.ids)
+}
+
+// DB returns the connection of a shard, or nil for an unknown ID
+func (s *Shards) DB(id string) DB {
+	return s.dbs[id]
+}
+
+// Close closes every shard connection
+func (s *Shards) Close() error {
+	var errs []error
+
+	for _, id := range s.ids {
+		if err := s.dbs[id].Close(); err != nil {
+			errs = append(errs, fmt.Errorf("close database shard %s: %w", id, err))
+		}
+	}
+
+	return errors.Join(errs...)
+}
@@ -0,0 +1,62 @@
+package database
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRing_Locate(t *testing.T) {
+	ring := NewRing([]string{"a", "b", "c", "d"}, 64)
+	counts := make(map[string]int)
+
+	for range 10000 {
+		key := uuid.NewString()
+		shard := ring.Locate(key)
+
+		assert.Equal(t, shard, ring.Locate(key), "a key always belongs to the same shard")
+
+		counts[shard]++
+	}
+
+	assert.Len(t, counts, 4)
+
+	for shard, count := range counts {
+		assert.InDelta(t, 2500, count, 750, "shard %s has about a quarter of the keys", shard)
+	}
+
+	assert.Empty(t, NewRing(nil, 64).Locate("key"))
+}
+
+func TestRing_AddShard(t *testing.T) {
+	before := NewRing([]string{"a", "b", "c"}, 64)
+	after := NewRing([]string{"a", "b", "c", "d"}, 64)
+	moved := 0
+
+	for range 10000 {
+		key := uuid.NewString()
+		if from, to := before.Locate(key), after.Locate(key); from != to {
+			assert.Equal(t, "d", to, "keys only move to the new shard")
+
+			moved++
+		}
+	}
+
+	assert.InDelta(t, 2500, moved, 750, "about a quarter of the keys move")
+}
+
+func TestShards_Locate(t *testing.T) {
+	shards := NewShards(map[string]DB{"b": nil, "a": nil, "c": nil}, []string{"c"}, 64)
+	ring := NewRing([]string{"a", "b"}, 64)
+
+	assert.Equal(t, []string{"a", "b", "c"}, shards.IDs(), "retired shards are listed")
+
+	for range 1000 {
+		id := uuid.NewString()
+
+		assert.Equal(t, shards.Locate(id), shards.Locate(" "+strings.ToUpper(id)+" "), "form IDs are normalized")
+		assert.Equal(t, ring.Locate(id), shards.Locate(id), "no form is routed to a retired shard")
+	}
+}
@@ -0,0 +1,50 @@
+package email
+
+import (
+	"context"
+	"time"
+
+	"github.com/goformx/goforms/internal/infrastructure/logging"
+)
+
+// deadLetterTimeout bounds keeping a failed message once the send's own context may be done
+const deadLetterTimeout = 5 * time.Second
+
+// DeadLetterQueue keeps messages that could not be delivered
+type DeadLetterQueue interface {
+	DeadLetter(ctx context.Context, msg *Message, err error) error
+}
+
+// WithDeadLetters wraps sender so that a message still failing after the
+// sender's own retries is passed to queue, to be retried later. Permanent
+// failures, such as rejected or suppressed recipients, are not kept, since
+// sending the same message again cannot succeed. The send error is returned
+// either way.
+func WithDeadLetters(sender Sender, queue DeadLetterQueue, logger logging.Logger) Sender {
+	return &deadLetterSender{next: sender, queue: queue, logger: logger}
+}
+
+// deadLetterSender passes messages that failed transiently to a dead-letter queue
+type deadLetterSender struct {
+	next   Sender
+	queue  DeadLetterQueue
+	logger logging.Logger
+}
+
+// Send delivers msg, dead-lettering it when delivery fails transiently
+func (s *deadLetterSender) Send(ctx context.Context, msg *Message) error {
+	err := s.next.Send(ctx, msg)
+	if err == nil || IsPermanent(err) {
+		return err
+	}
+
+	// The send may have failed because ctx is done; keep the message regardless
+	keepCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), deadLetterTimeout)
+	defer cancel()
+
+	if queueErr := s.queue.DeadLetter(keepCtx, msg, err); queueErr != nil {
+		s.logger.Error("failed to dead-letter email", "subject", msg.Subject, "error", queueErr)
+	}
+
+	return err
+}
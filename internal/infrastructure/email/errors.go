@@ -0,0 +1,79 @@
+package email
+
+import (
+	"errors"
+	"fmt"
+	"net/textproto"
+	"time"
+)
+
+var (
+	// ErrRecipientsSuppressed is returned when every recipient of a message is on the suppression list
+	ErrRecipientsSuppressed = errors.New("all email recipients are suppressed")
+
+	// ErrWebhookSignature is returned when a webhook delivery is not signed by the provider
+	ErrWebhookSignature = errors.New("webhook signature is invalid")
+
+	// ErrWebhookExpired is returned when a webhook delivery was signed too long ago to be trusted
+	ErrWebhookExpired = errors.New("webhook delivery is too old")
+
+	// ErrWebhookTopic is returned for an SNS message from a topic that is not configured
+	ErrWebhookTopic = errors.New("webhook topic is not accepted")
+)
+
+// DeliveryError describes a message the provider did not accept
+type DeliveryError struct {
+	Provider string
+	// StatusCode is the HTTP status or SMTP reply code, zero when no response was received
+	StatusCode int
+	// Permanent is set when retrying the same message cannot succeed
+	Permanent bool
+	// RetryAfter is the delay the provider asked for before the next attempt
+	RetryAfter time.Duration
+	// Recipients lists addresses the provider rejected outright
+	Recipients []string
+	Err        error
+}
+
+// Error implements error
+func (e *DeliveryError) Error() string {
+	if e.StatusCode != 0 {
+		return fmt.Sprintf("%s delivery failed (%d): %v", e.Provider, e.StatusCode, e.Err)
+	}
+
+	return fmt.Sprintf("%s delivery failed: %v", e.Provider, e.Err)
+}
+
+// Unwrap returns the underlying error
+func (e *DeliveryError) Unwrap() error {
+	return e.Err
+}
+
+// IsPermanent reports whether retrying a failed send cannot succeed
+func IsPermanent(err error) bool {
+	if errors.Is(err, ErrNoRecipients) || errors.Is(err, ErrRecipientsSuppressed) {
+		return true
+	}
+
+	var deliveryErr *DeliveryError
+
+	return errors.As(err, &deliveryErr) && deliveryErr.Permanent
+}
+
+// smtpError classifies an SMTP failure. 5xx replies are permanent; connection
+// failures and 4xx replies are worth retrying.
+func smtpError(err error, recipients ...string) error {
+	deliveryErr := &DeliveryError{Provider: "smtp", Err: err}
+
+	var protoErr *textproto.Error
+	if errors.As(err, &protoErr) {
+		deliveryErr.StatusCode = protoErr.Code
+		deliveryErr.Permanent = protoErr.Code >= 500
+
+		if deliveryErr.Permanent {
+			deliveryErr.Recipients = recipients
+		}
+	}
+
+	return deliveryErr
+}
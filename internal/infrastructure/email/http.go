@@ -0,0 +1,85 @@
+package email
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"net/mail"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// maxErrorBody bounds how much of a provider's error response is kept
+const maxErrorBody = 4096
+
+// newHTTPClient returns the client used by the HTTP API providers
+func newHTTPClient(timeoutSeconds int) *http.Client {
+	return &http.Client{Timeout: requestTimeout(timeoutSeconds)}
+}
+
+// requestTimeout returns the configured timeout of a provider call, or the default
+func requestTimeout(timeoutSeconds int) time.Duration {
+	if timeoutSeconds > 0 {
+		return time.Duration(timeoutSeconds) * time.Second
+	}
+
+	return defaultTimeout
+}
+
+// doAPIRequest sends req and classifies the response. Throttling and server
+// errors can be retried; any other error status means the request was rejected.
+func doAPIRequest(client *http.Client, provider string, req *http.Request) error {
+	resp, err := client.Do(req)
+	if err != nil {
+		return &DeliveryError{Provider: provider, Err: fmt.Errorf("send request: %w", err)}
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+		_, _ = io.Copy(io.Discard, resp.Body)
+
+		return nil
+	}
+
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, maxErrorBody))
+
+	retryable := resp.StatusCode == http.StatusTooManyRequests ||
+		resp.StatusCode == http.StatusRequestTimeout ||
+		resp.StatusCode >= http.StatusInternalServerError
+
+	return &DeliveryError{
+		Provider:   provider,
+		StatusCode: resp.StatusCode,
+		Permanent:  !retryable,
+		RetryAfter: parseRetryAfter(resp.Header.Get("Retry-After")),
+		Err:        fmt.Errorf("%s", strings.TrimSpace(string(body))),
+	}
+}
+
+// parseRetryAfter reads a Retry-After header given in seconds or as an HTTP date
+func parseRetryAfter(value string) time.Duration {
+	if value == "" {
+		return 0
+	}
+
+	if seconds, err := strconv.Atoi(value); err == nil && seconds > 0 {
+		return time.Duration(seconds) * time.Second
+	}
+
+	if at, err := http.ParseTime(value); err == nil {
+		return max(0, time.Until(at))
+	}
+
+	return 0
+}
+
+// parseFrom splits the configured sender into display name and address
+func parseFrom(from string) (name, address string) {
+	parsed, err := mail.ParseAddress(from)
+	if err != nil {
+		return "", addressOnly(from)
+	}
+
+	return parsed.Name, parsed.Address
+}
@@ -0,0 +1,105 @@
+package email
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"mime/multipart"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/goformx/goforms/internal/infrastructure/config"
+	"github.com/goformx/goforms/internal/infrastructure/logging"
+)
+
+// defaultMailgunEndpoint is the Mailgun US region API base URL
+const defaultMailgunEndpoint = "https://api.mailgun.net"
+
+// MailgunSender delivers messages through the Mailgun MIME messages API,
+// so attachments and headers are encoded exactly as for SMTP
+type MailgunSender struct {
+	cfg    config.MailgunEmailConfig
+	from   string
+	client *http.Client
+	logger logging.Logger
+}
+
+// NewMailgunSender creates a Mailgun sender. A nil client uses one bounded by email.timeout.
+func NewMailgunSender(cfg config.EmailConfig, client *http.Client, logger logging.Logger) *MailgunSender {
+	if client == nil {
+		client = newHTTPClient(cfg.Timeout)
+	}
+
+	return &MailgunSender{
+		cfg:    cfg.Mailgun,
+		from:   cfg.From,
+		client: client,
+		logger: logger,
+	}
+}
+
+// Send delivers a message, honouring the context deadline
+func (s *MailgunSender) Send(ctx context.Context, msg *Message) error {
+	if len(msg.To) == 0 {
+		return ErrNoRecipients
+	}
+
+	message, err := buildMessage(s.from, msg)
+	if err != nil {
+		return fmt.Errorf("build email: %w", err)
+	}
+
+	var body bytes.Buffer
+
+	form := multipart.NewWriter(&body)
+
+	for _, recipient := range msg.To {
+		if err = form.WriteField("to", recipient); err != nil {
+			return fmt.Errorf("write mailgun recipient: %w", err)
+		}
+	}
+
+	if msg.FormID != "" {
+		// Custom variables are echoed back in Mailgun's event webhooks
+		if err = form.WriteField("v:"+formIDTag, msg.FormID); err != nil {
+			return fmt.Errorf("write mailgun variable: %w", err)
+		}
+	}
+
+	part, err := form.CreateFormFile("message", "message.mime")
+	if err != nil {
+		return fmt.Errorf("create mailgun message part: %w", err)
+	}
+
+	if _, err = part.Write(message); err != nil {
+		return fmt.Errorf("write mailgun message: %w", err)
+	}
+
+	if err = form.Close(); err != nil {
+		return fmt.Errorf("close mailgun form: %w", err)
+	}
+
+	endpoint := s.cfg.Endpoint
+	if endpoint == "" {
+		endpoint = defaultMailgunEndpoint
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost,
+		strings.TrimSuffix(endpoint, "/")+"/v3/"+url.PathEscape(s.cfg.Domain)+"/messages.mime", &body)
+	if err != nil {
+		return fmt.Errorf("create mailgun request: %w", err)
+	}
+
+	req.SetBasicAuth("api", s.cfg.APIKey)
+	req.Header.Set("Content-Type", form.FormDataContentType())
+
+	if err = doAPIRequest(s.client, config.EmailProviderMailgun, req); err != nil {
+		return err
+	}
+
+	s.logger.Debug("email sent", "provider", config.EmailProviderMailgun,
+		"subject", msg.Subject, "recipient_count", len(msg.To))
+
+	return nil
+}
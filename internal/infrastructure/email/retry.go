@@ -0,0 +1,52 @@
+package email
+
+import (
+	"time"
+
+	"github.com/goformx/goforms/internal/infrastructure/config"
+	"github.com/goformx/goforms/internal/infrastructure/resilience"
+)
+
+// defaultRetryPolicies reflect each provider's failure modes: SMTP servers
+// greylist for minutes, while the HTTP APIs mostly fail on short rate limits.
+// MaxAttempts counts the first delivery attempt.
+var defaultRetryPolicies = map[string]resilience.Policy{
+	config.EmailProviderSMTP: {
+		MaxAttempts: 4, Backoff: 5 * time.Second, MaxBackoff: time.Minute, FailureThreshold: 5, Cooldown: time.Minute,
+	},
+	config.EmailProviderSES: {
+		MaxAttempts: 5, Backoff: 500 * time.Millisecond, MaxBackoff: 20 * time.Second, FailureThreshold: 5, Cooldown: time.Minute,
+	},
+	config.EmailProviderSendGrid: {
+		MaxAttempts: 5, Backoff: time.Second, MaxBackoff: 30 * time.Second, FailureThreshold: 5, Cooldown: time.Minute,
+	},
+	config.EmailProviderMailgun: {
+		MaxAttempts: 5, Backoff: time.Second, MaxBackoff: 30 * time.Second, FailureThreshold: 5, Cooldown: time.Minute,
+	},
+}
+
+// retryPolicy returns the provider's default policy with the email.max_retries
+// and email.retry_backoff overrides applied
+func retryPolicy(provider string, cfg config.EmailConfig) resilience.Policy {
+	policy := defaultRetryPolicies[provider]
+
+	switch {
+	case cfg.MaxRetries < 0:
+		policy.MaxAttempts = 1
+	case cfg.MaxRetries > 0:
+		policy.MaxAttempts = cfg.MaxRetries + 1
+	}
+
+	if cfg.RetryBackoff > 0 {
+		policy.Backoff = cfg.RetryBackoff
+		policy.MaxBackoff = max(policy.MaxBackoff, cfg.RetryBackoff)
+	}
+
+	return policy
+}
+
+// RetryDelay returns the delay the provider asked for before the next attempt,
+// which the provider's target waits instead of its backoff
+func (e *DeliveryError) RetryDelay() time.Duration {
+	return e.RetryAfter
+}
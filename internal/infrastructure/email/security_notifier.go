@@ -0,0 +1,54 @@
+package email
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/goformx/goforms/internal/domain/securityevent"
+)
+
+// SecurityNotifier emails security events to a fixed list of recipients
+type SecurityNotifier struct {
+	sender     Sender
+	recipients []string
+	appName    string
+}
+
+// NewSecurityNotifier creates a notifier that emails recipients about each security event
+func NewSecurityNotifier(sender Sender, recipients []string, appName string) *SecurityNotifier {
+	return &SecurityNotifier{sender: sender, recipients: recipients, appName: appName}
+}
+
+// Notify implements securityevent.Notifier
+func (n *SecurityNotifier) Notify(ctx context.Context, event *securityevent.Event) error {
+	var body strings.Builder
+
+	fmt.Fprintf(&body, "%s detected %d %s signals from %s within %s.\n\n",
+		n.appName, event.Count, strings.ReplaceAll(event.Kind, "_", " "), event.IPAddress,
+		time.Duration(event.WindowSeconds)*time.Second)
+
+	if event.Subject != "" {
+		fmt.Fprintf(&body, "Last target: %s\n", event.Subject)
+	}
+
+	if event.BlockedUntil != nil {
+		fmt.Fprintf(&body, "The address is blocked until %s.\n", event.BlockedUntil.UTC().Format(time.RFC1123))
+	} else {
+		body.WriteString("The address was not blocked.\n")
+	}
+
+	fmt.Fprintf(&body, "Detected at %s.\n", event.CreatedAt.UTC().Format(time.RFC1123))
+
+	err := n.sender.Send(ctx, &Message{
+		To:      n.recipients,
+		Subject: fmt.Sprintf("[%s] Security alert: %s from %s", n.appName, event.Kind, event.IPAddress),
+		Body:    body.String(),
+	})
+	if err != nil {
+		return fmt.Errorf("send security alert: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,436 @@
+// Package email provides outbound email delivery over SMTP or the SES,
+// SendGrid and Mailgun HTTP APIs, with retries and a suppression list.
+package email
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net"
+	"net/smtp"
+	"net/textproto"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/goformx/goforms/internal/infrastructure/config"
+	"github.com/goformx/goforms/internal/infrastructure/httpclient"
+	"github.com/goformx/goforms/internal/infrastructure/logging"
+	"github.com/goformx/goforms/internal/infrastructure/resilience"
+)
+
+const (
+	// defaultTimeout is used when email.timeout is not configured
+	defaultTimeout = 30 * time.Second
+	// base64LineLength is the maximum encoded line length allowed by RFC 2045
+	base64LineLength = 76
+)
+
+// ErrNoRecipients is returned when a message has no recipients
+var ErrNoRecipients = errors.New("email message has no recipients")
+
+// Attachment is a file attached to a message
+type Attachment struct {
+	Filename    string `json:"filename"`
+	ContentType string `json:"content_type"`
+	Data        []byte `json:"data"`
+}
+
+// formIDTag names the provider tag that carries Message.FormID
+const formIDTag = "form_id"
+
+// Message is an outbound plain-text email. The JSON encoding is how
+// dead-lettered messages are kept.
+type Message struct {
+	To      []string `json:"to"`
+	Subject string   `json:"subject"`
+	// Body is the plain-text body
+	Body string `json:"body"`
+	// HTML is an optional HTML alternative to Body
+	HTML        string       `json:"html,omitempty"`
+	Attachments []Attachment `json:"attachments,omitempty"`
+	// FormID attributes the message to a form. Providers echo it back in
+	// bounce and complaint notifications, so delivery stats can be kept per form.
+	FormID string `json:"form_id,omitempty"`
+}
+
+// Sender delivers email messages
+type Sender interface {
+	Send(ctx context.Context, msg *Message) error
+}
+
+// NewSender returns a sender for the configured provider that retries transient
+// failures and skips suppressed recipients. Without a provider or SMTP host,
+// messages are only logged. Addresses in email.suppressed are always skipped;
+// suppressions, when set, holds the rest of the list, which is kept in memory
+// otherwise. Accepted messages are reported to recorder when it is set. The
+// HTTP API providers use a client from clients, and each attempt goes through
+// the provider's circuit when outbound calls are guarded.
+func NewSender(
+	cfg *config.Config,
+	suppressions SuppressionList,
+	recorder DeliveryRecorder,
+	clients *httpclient.Factory,
+	logger logging.Logger,
+) Sender {
+	if cfg == nil {
+		return &logSender{logger: logger}
+	}
+
+	provider := Provider(cfg.Email)
+
+	var (
+		driver     Sender
+		httpClient = clients.BaseClient(config.ResilienceTargetEmail, requestTimeout(cfg.Email.Timeout))
+	)
+
+	switch provider {
+	case config.EmailProviderSMTP:
+		driver = &SMTPSender{cfg: cfg.Email, logger: logger}
+	case config.EmailProviderSES:
+		driver = NewSESSender(cfg.Email, httpClient, logger)
+	case config.EmailProviderSendGrid:
+		driver = NewSendGridSender(cfg.Email, httpClient, logger)
+	case config.EmailProviderMailgun:
+		driver = NewMailgunSender(cfg.Email, httpClient, logger)
+	default:
+		return &logSender{logger: logger}
+	}
+
+	policy := retryPolicy(provider, cfg.Email)
+
+	target := clients.TargetWithPolicy(config.ResilienceTargetEmail, provider, policy)
+	if target == nil {
+		// Outbound calls are not guarded: deliveries are still retried, without a circuit
+		policy.FailureThreshold = 0
+		target = resilience.NewTarget(config.ResilienceTargetEmail+":"+provider, policy, logger)
+	}
+
+	var next Sender = &guardedSender{next: driver, target: target}
+
+	if recorder != nil {
+		next = &recordingSender{next: next, recorder: recorder, provider: provider, logger: logger}
+	}
+
+	var list SuppressionList = NewMemorySuppressionList(cfg.Email.Suppressed...)
+	if suppressions != nil {
+		list = &configuredSuppressionList{configured: NewMemorySuppressionList(cfg.Email.Suppressed...), next: suppressions}
+	}
+
+	return &suppressingSender{next: next, list: list, logger: logger}
+}
+
+// Provider returns the delivery provider NewSender uses for cfg, or an empty
+// string when messages are only logged
+func Provider(cfg config.EmailConfig) string {
+	if cfg.Provider == "" && cfg.Host != "" {
+		return config.EmailProviderSMTP
+	}
+
+	return strings.ToLower(cfg.Provider)
+}
+
+// guardedSender sends through a provider's target, which retries transient
+// failures by the provider's policy and, when outbound calls are guarded, keeps
+// its circuit. Rejections by the provider are not retried and do not count
+// against the circuit, and an open circuit fails deliveries as retryable.
+type guardedSender struct {
+	next   Sender
+	target *resilience.Target
+}
+
+// Send delivers msg, retrying transient failures, unless the provider's circuit is open
+func (s *guardedSender) Send(ctx context.Context, msg *Message) error {
+	return s.target.Do(ctx, func(ctx context.Context) error {
+		if err := s.next.Send(ctx, msg); err != nil {
+			if IsPermanent(err) {
+				return resilience.Permanent(err)
+			}
+
+			return err
+		}
+
+		return nil
+	})
+}
+
+// logSender is used when SMTP is not configured; it records what would have been sent
+type logSender struct {
+	logger logging.Logger
+}
+
+// Send logs the message instead of delivering it
+func (s *logSender) Send(_ context.Context, msg *Message) error {
+	if len(msg.To) == 0 {
+		return ErrNoRecipients
+	}
+
+	s.logger.Warn("email not sent: SMTP is not configured",
+		"subject", msg.Subject,
+		"recipient_count", len(msg.To),
+		"attachment_count", len(msg.Attachments))
+
+	return nil
+}
+
+// SMTPSender delivers messages through the configured SMTP server
+type SMTPSender struct {
+	cfg    config.EmailConfig
+	logger logging.Logger
+}
+
+// Send delivers a message, honouring the context deadline
+func (s *SMTPSender) Send(ctx context.Context, msg *Message) error {
+	if len(msg.To) == 0 {
+		return ErrNoRecipients
+	}
+
+	body, err := buildMessage(s.cfg.From, msg)
+	if err != nil {
+		return fmt.Errorf("build email: %w", err)
+	}
+
+	timeout := defaultTimeout
+	if s.cfg.Timeout > 0 {
+		timeout = time.Duration(s.cfg.Timeout) * time.Second
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	client, err := s.dial(ctx)
+	if err != nil {
+		return smtpError(err)
+	}
+	defer client.Close()
+
+	if err = s.deliver(client, msg.To, body); err != nil {
+		return err
+	}
+
+	s.logger.Debug("email sent", "subject", msg.Subject, "recipient_count", len(msg.To))
+
+	return nil
+}
+
+// dial connects to the SMTP server, using implicit TLS when use_ssl is set
+// and STARTTLS when use_tls is set
+func (s *SMTPSender) dial(ctx context.Context) (*smtp.Client, error) {
+	addr := net.JoinHostPort(s.cfg.Host, strconv.Itoa(s.cfg.Port))
+	tlsConfig := &tls.Config{ServerName: s.cfg.Host, MinVersion: tls.VersionTLS12}
+
+	var (
+		conn net.Conn
+		err  error
+	)
+
+	if s.cfg.UseSSL {
+		dialer := &tls.Dialer{Config: tlsConfig}
+		conn, err = dialer.DialContext(ctx, "tcp", addr)
+	} else {
+		var dialer net.Dialer
+		conn, err = dialer.DialContext(ctx, "tcp", addr)
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("connect to smtp server: %w", err)
+	}
+
+	if deadline, ok := ctx.Deadline(); ok {
+		_ = conn.SetDeadline(deadline)
+	}
+
+	client, err := smtp.NewClient(conn, s.cfg.Host)
+	if err != nil {
+		conn.Close()
+
+		return nil, fmt.Errorf("create smtp client: %w", err)
+	}
+
+	if s.cfg.UseTLS && !s.cfg.UseSSL {
+		if err = client.StartTLS(tlsConfig); err != nil {
+			client.Close()
+
+			return nil, fmt.Errorf("start tls: %w", err)
+		}
+	}
+
+	if s.cfg.Username != "" {
+		auth := smtp.PlainAuth("", s.cfg.Username, s.cfg.Password, s.cfg.Host)
+		if err = client.Auth(auth); err != nil {
+			client.Close()
+
+			return nil, fmt.Errorf("smtp auth: %w", err)
+		}
+	}
+
+	return client, nil
+}
+
+// deliver runs the MAIL/RCPT/DATA exchange
+func (s *SMTPSender) deliver(client *smtp.Client, to []string, body []byte) error {
+	if err := client.Mail(addressOnly(s.cfg.From)); err != nil {
+		return smtpError(fmt.Errorf("smtp mail from: %w", err))
+	}
+
+	for _, recipient := range to {
+		if err := client.Rcpt(addressOnly(recipient)); err != nil {
+			return smtpError(fmt.Errorf("smtp rcpt to: %w", err), recipient)
+		}
+	}
+
+	w, err := client.Data()
+	if err != nil {
+		return smtpError(fmt.Errorf("smtp data: %w", err))
+	}
+
+	if _, err = w.Write(body); err != nil {
+		return smtpError(fmt.Errorf("write email body: %w", err))
+	}
+
+	if err = w.Close(); err != nil {
+		return smtpError(fmt.Errorf("finish email body: %w", err))
+	}
+
+	if err = client.Quit(); err != nil {
+		return fmt.Errorf("smtp quit: %w", err)
+	}
+
+	return nil
+}
+
+// writeBody writes the text body, wrapped in a multipart/alternative part with
+// the HTML body when the message has one
+func writeBody(writer *multipart.Writer, msg *Message) error {
+	if msg.HTML == "" {
+		return writeTextPart(writer, "text/plain; charset=utf-8", msg.Body)
+	}
+
+	var alternatives bytes.Buffer
+
+	alternative := multipart.NewWriter(&alternatives)
+
+	if err := writeTextPart(alternative, "text/plain; charset=utf-8", msg.Body); err != nil {
+		return err
+	}
+
+	if err := writeTextPart(alternative, "text/html; charset=utf-8", msg.HTML); err != nil {
+		return err
+	}
+
+	if err := alternative.Close(); err != nil {
+		return fmt.Errorf("close alternative part: %w", err)
+	}
+
+	part, err := writer.CreatePart(textproto.MIMEHeader{
+		"Content-Type": {fmt.Sprintf("multipart/alternative; boundary=%q", alternative.Boundary())},
+	})
+	if err != nil {
+		return fmt.Errorf("create alternative part: %w", err)
+	}
+
+	if _, err = part.Write(alternatives.Bytes()); err != nil {
+		return fmt.Errorf("write alternative part: %w", err)
+	}
+
+	return nil
+}
+
+// writeTextPart writes a base64-encoded text part
+func writeTextPart(writer *multipart.Writer, contentType, body string) error {
+	part, err := writer.CreatePart(textproto.MIMEHeader{
+		"Content-Type":              {contentType},
+		"Content-Transfer-Encoding": {"base64"},
+	})
+	if err != nil {
+		return fmt.Errorf("create body part: %w", err)
+	}
+
+	writeBase64(part, []byte(body))
+
+	return nil
+}
+
+// buildMessage renders a MIME message with a text body, an optional HTML
+// alternative and optional attachments
+func buildMessage(from string, msg *Message) ([]byte, error) {
+	var buf bytes.Buffer
+
+	writer := multipart.NewWriter(&buf)
+
+	header := []string{
+		"From: " + sanitizeHeader(from),
+		"To: " + sanitizeHeader(strings.Join(msg.To, ", ")),
+		"Subject: " + mime.QEncoding.Encode("utf-8", sanitizeHeader(msg.Subject)),
+		"Date: " + time.Now().Format(time.RFC1123Z),
+		"MIME-Version: 1.0",
+		fmt.Sprintf("Content-Type: multipart/mixed; boundary=%q", writer.Boundary()),
+	}
+
+	buf.WriteString(strings.Join(header, "\r\n") + "\r\n\r\n")
+
+	err := writeBody(writer, msg)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, attachment := range msg.Attachments {
+		contentType := attachment.ContentType
+		if contentType == "" {
+			contentType = "application/octet-stream"
+		}
+
+		part, partErr := writer.CreatePart(textproto.MIMEHeader{
+			"Content-Type":              {contentType},
+			"Content-Transfer-Encoding": {"base64"},
+			"Content-Disposition": {
+				mime.FormatMediaType("attachment", map[string]string{"filename": attachment.Filename}),
+			},
+		})
+		if partErr != nil {
+			return nil, fmt.Errorf("create attachment part: %w", partErr)
+		}
+
+		writeBase64(part, attachment.Data)
+	}
+
+	if err = writer.Close(); err != nil {
+		return nil, fmt.Errorf("close multipart writer: %w", err)
+	}
+
+	return buf.Bytes(), nil
+}
+
+// writeBase64 writes data base64-encoded in RFC 2045 line lengths
+func writeBase64(w io.Writer, data []byte) {
+	encoded := base64.StdEncoding.EncodeToString(data)
+
+	for len(encoded) > base64LineLength {
+		_, _ = w.Write([]byte(encoded[:base64LineLength] + "\r\n"))
+		encoded = encoded[base64LineLength:]
+	}
+
+	_, _ = w.Write([]byte(encoded + "\r\n"))
+}
+
+// sanitizeHeader strips CR and LF to prevent header injection
+func sanitizeHeader(value string) string {
+	return strings.NewReplacer("\r", "", "\n", "").Replace(value)
+}
+
+// addressOnly extracts the bare address from a "Name <addr>" string
+func addressOnly(from string) string {
+	if start := strings.LastIndex(from, "<"); start >= 0 {
+		if end := strings.LastIndex(from, ">"); end > start {
+			return from[start+1 : end]
+		}
+	}
+
+	return strings.TrimSpace(from)
+}
@@ -0,0 +1,451 @@
+package email
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"net/mail"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/goformx/goforms/internal/infrastructure/config"
+	"github.com/goformx/goforms/internal/infrastructure/resilience"
+	mocklogging "github.com/goformx/goforms/test/mocks/logging"
+)
+
+func newTestLogger(t *testing.T) *mocklogging.MockLogger {
+	t.Helper()
+
+	logger := mocklogging.NewMockLogger(gomock.NewController(t))
+	logger.EXPECT().Debug(gomock.Any(), gomock.Any()).AnyTimes()
+	logger.EXPECT().Info(gomock.Any(), gomock.Any()).AnyTimes()
+	logger.EXPECT().Warn(gomock.Any(), gomock.Any()).AnyTimes()
+	logger.EXPECT().Error(gomock.Any(), gomock.Any()).AnyTimes()
+
+	return logger
+}
+
+// scriptedSender returns the queued errors in order, then succeeds
+type scriptedSender struct {
+	errs []error
+	sent []*Message
+}
+
+func (s *scriptedSender) Send(_ context.Context, msg *Message) error {
+	s.sent = append(s.sent, msg)
+
+	if len(s.errs) == 0 {
+		return nil
+	}
+
+	err := s.errs[0]
+	s.errs = s.errs[1:]
+
+	return err
+}
+
+func TestNewSender_Provider(t *testing.T) {
+	logger := newTestLogger(t)
+
+	tests := []struct {
+		name   string
+		email  config.EmailConfig
+		driver any
+	}{
+		{"no host logs messages", config.EmailConfig{}, nil},
+		{"host selects smtp", config.EmailConfig{Host: "smtp.example.com"}, &SMTPSender{}},
+		{"ses", config.EmailConfig{Provider: "SES"}, &SESSender{}},
+		{"sendgrid", config.EmailConfig{Provider: config.EmailProviderSendGrid}, &SendGridSender{}},
+		{"mailgun", config.EmailConfig{Provider: config.EmailProviderMailgun}, &MailgunSender{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sender := NewSender(&config.Config{Email: tt.email}, nil, nil, nil, logger)
+
+			if tt.driver == nil {
+				assert.IsType(t, &logSender{}, sender)
+
+				return
+			}
+
+			suppressing, ok := sender.(*suppressingSender)
+			require.True(t, ok)
+
+			guarded, ok := suppressing.next.(*guardedSender)
+			require.True(t, ok)
+			assert.IsType(t, tt.driver, guarded.next)
+			assert.Equal(t, config.ResilienceTargetEmail+":"+Provider(tt.email), guarded.target.Name())
+		})
+	}
+}
+
+func TestGuardedSender_Retries(t *testing.T) {
+	transient := &DeliveryError{Provider: "test", StatusCode: http.StatusServiceUnavailable}
+	permanent := &DeliveryError{Provider: "test", StatusCode: http.StatusBadRequest, Permanent: true}
+	throttled := &DeliveryError{Provider: "test", StatusCode: http.StatusTooManyRequests, RetryAfter: time.Hour}
+
+	// The retry-after delay is capped by MaxBackoff, so the test does not wait an hour
+	policy := resilience.Policy{MaxAttempts: 3, Backoff: time.Millisecond, MaxBackoff: 5 * time.Millisecond}
+
+	tests := []struct {
+		name     string
+		errs     []error
+		wantErr  error
+		attempts int
+	}{
+		{"succeeds after transient failures", []error{transient, transient}, nil, 3},
+		{"gives up after max retries", []error{transient, transient, transient}, transient, 3},
+		{"does not retry permanent failures", []error{permanent}, permanent, 1},
+		{"retries throttled deliveries", []error{throttled}, nil, 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			driver := &scriptedSender{errs: tt.errs}
+			sender := &guardedSender{next: driver, target: resilience.NewTarget("email:test", policy, newTestLogger(t))}
+
+			err := sender.Send(context.Background(), &Message{To: []string{"a@example.com"}})
+
+			if tt.wantErr == nil {
+				require.NoError(t, err)
+			} else {
+				require.ErrorIs(t, err, tt.wantErr)
+			}
+
+			assert.Len(t, driver.sent, tt.attempts)
+		})
+	}
+}
+
+func TestRetryPolicy_Overrides(t *testing.T) {
+	policy := retryPolicy(config.EmailProviderSES, config.EmailConfig{})
+	assert.Equal(t, defaultRetryPolicies[config.EmailProviderSES], policy)
+
+	policy = retryPolicy(config.EmailProviderSES, config.EmailConfig{MaxRetries: -1})
+	assert.Equal(t, 1, policy.MaxAttempts)
+
+	policy = retryPolicy(config.EmailProviderSMTP, config.EmailConfig{MaxRetries: 7, RetryBackoff: 2 * time.Minute})
+	assert.Equal(t, resilience.Policy{
+		MaxAttempts: 8, Backoff: 2 * time.Minute, MaxBackoff: 2 * time.Minute, FailureThreshold: 5, Cooldown: time.Minute,
+	}, policy)
+}
+
+func TestSuppressingSender(t *testing.T) {
+	ctx := context.Background()
+	list := NewMemorySuppressionList("Bounced@Example.com")
+	driver := &scriptedSender{}
+	sender := &suppressingSender{next: driver, list: list, logger: newTestLogger(t)}
+
+	require.NoError(t, sender.Send(ctx, &Message{To: []string{"bounced@example.com", "ok@example.com"}}))
+	require.Len(t, driver.sent, 1)
+	assert.Equal(t, []string{"ok@example.com"}, driver.sent[0].To)
+
+	err := sender.Send(ctx, &Message{To: []string{"Someone <BOUNCED@example.com>"}})
+	require.ErrorIs(t, err, ErrRecipientsSuppressed)
+	assert.True(t, IsPermanent(err))
+
+	// A permanent rejection suppresses the rejected recipient for later messages
+	driver.errs = []error{&DeliveryError{Provider: "smtp", StatusCode: 550, Permanent: true,
+		Recipients: []string{"gone@example.com"}, Err: errors.New("no such user")}}
+	require.Error(t, sender.Send(ctx, &Message{To: []string{"gone@example.com"}}))
+
+	suppressed, err := list.IsSuppressed(ctx, "gone@example.com")
+	require.NoError(t, err)
+	assert.True(t, suppressed)
+
+	require.NoError(t, list.Unsuppress(ctx, "gone@example.com"))
+	require.NoError(t, sender.Send(ctx, &Message{To: []string{"gone@example.com"}}))
+}
+
+func TestGuardedSender(t *testing.T) {
+	ctx := context.Background()
+	outbound := resilience.NewRegistry(config.ResilienceConfig{
+		Enabled: true,
+		Targets: map[string]config.ResiliencePolicyConfig{
+			config.ResilienceTargetEmail: {FailureThreshold: 2, Cooldown: time.Hour},
+		},
+	}, newTestLogger(t))
+	target := outbound.TargetWithPolicy(config.ResilienceTargetEmail, config.EmailProviderSMTP, resilience.Policy{MaxAttempts: 1})
+
+	rejected := &DeliveryError{Provider: "smtp", StatusCode: 550, Permanent: true, Err: errors.New("no such user")}
+	driver := &scriptedSender{errs: []error{rejected, rejected, errors.New("timeout"), errors.New("timeout")}}
+	sender := &guardedSender{next: driver, target: target}
+
+	// Rejected messages do not count against the provider
+	for range 2 {
+		err := sender.Send(ctx, &Message{To: []string{"gone@example.com"}})
+		require.ErrorAs(t, err, new(*DeliveryError))
+	}
+
+	assert.Equal(t, resilience.StateClosed, target.State())
+
+	for range 2 {
+		require.Error(t, sender.Send(ctx, &Message{To: []string{"ok@example.com"}}))
+	}
+
+	err := sender.Send(ctx, &Message{To: []string{"ok@example.com"}})
+	require.ErrorIs(t, err, resilience.ErrCircuitOpen)
+	assert.False(t, IsPermanent(err))
+	assert.Len(t, driver.sent, 4)
+}
+
+// sentRecorder records RecordSent calls
+type sentRecorder struct {
+	formIDs    []string
+	recipients [][]string
+}
+
+func (r *sentRecorder) RecordSent(_ context.Context, formID, _ string, recipients []string) error {
+	r.formIDs = append(r.formIDs, formID)
+	r.recipients = append(r.recipients, recipients)
+
+	return nil
+}
+
+func TestRecordingSender(t *testing.T) {
+	ctx := context.Background()
+	recorder := &sentRecorder{}
+	driver := &scriptedSender{errs: []error{errors.New("connection refused")}}
+	sender := &recordingSender{next: driver, recorder: recorder, provider: "smtp", logger: newTestLogger(t)}
+
+	// Failed sends are not counted
+	require.Error(t, sender.Send(ctx, &Message{To: []string{"a@example.com"}, FormID: "form-1"}))
+	assert.Empty(t, recorder.formIDs)
+
+	require.NoError(t, sender.Send(ctx, &Message{To: []string{"a@example.com"}, FormID: "form-1"}))
+	assert.Equal(t, []string{"form-1"}, recorder.formIDs)
+	assert.Equal(t, [][]string{{"a@example.com"}}, recorder.recipients)
+}
+
+// deadLetters records the messages passed to a dead-letter queue
+type deadLetters struct {
+	subjects []string
+}
+
+func (d *deadLetters) DeadLetter(_ context.Context, msg *Message, _ error) error {
+	d.subjects = append(d.subjects, msg.Subject)
+
+	return nil
+}
+
+func TestWithDeadLetters(t *testing.T) {
+	ctx := context.Background()
+	queue := &deadLetters{}
+	driver := &scriptedSender{errs: []error{
+		errors.New("connection refused"),
+		&DeliveryError{Provider: "smtp", StatusCode: 550, Permanent: true, Err: errors.New("no such user")},
+	}}
+	sender := WithDeadLetters(driver, queue, newTestLogger(t))
+
+	// Transient failures are kept
+	require.Error(t, sender.Send(ctx, &Message{To: []string{"a@example.com"}, Subject: "transient"}))
+	// Permanent failures are not
+	require.Error(t, sender.Send(ctx, &Message{To: []string{"a@example.com"}, Subject: "permanent"}))
+	require.NoError(t, sender.Send(ctx, &Message{To: []string{"a@example.com"}, Subject: "sent"}))
+
+	assert.Equal(t, []string{"transient"}, queue.subjects)
+}
+
+func TestConfiguredSuppressionList(t *testing.T) {
+	ctx := context.Background()
+	runtime := NewMemorySuppressionList()
+	list := &configuredSuppressionList{configured: NewMemorySuppressionList("fixed@example.com"), next: runtime}
+
+	require.NoError(t, list.Suppress(ctx, "bounced@example.com", "bounce"))
+
+	for _, address := range []string{"fixed@example.com", "bounced@example.com"} {
+		suppressed, err := list.IsSuppressed(ctx, address)
+		require.NoError(t, err)
+		assert.True(t, suppressed, address)
+	}
+
+	// Configured addresses stay suppressed
+	require.NoError(t, list.Unsuppress(ctx, "fixed@example.com"))
+
+	suppressed, err := list.IsSuppressed(ctx, "fixed@example.com")
+	require.NoError(t, err)
+	assert.True(t, suppressed)
+}
+
+func TestBuildMessage_HTMLAlternative(t *testing.T) {
+	raw, err := buildMessage("noreply@example.com", &Message{
+		To:          []string{"a@example.com"},
+		Subject:     "Hello",
+		Body:        "Plain body",
+		HTML:        "<p>HTML body</p>",
+		Attachments: []Attachment{{Filename: "report.csv", ContentType: "text/csv", Data: []byte("a,b")}},
+	})
+	require.NoError(t, err)
+
+	msg, err := mail.ReadMessage(bytes.NewReader(raw))
+	require.NoError(t, err)
+
+	mediaType, params, err := mime.ParseMediaType(msg.Header.Get("Content-Type"))
+	require.NoError(t, err)
+	assert.Equal(t, "multipart/mixed", mediaType)
+
+	mixed := multipart.NewReader(msg.Body, params["boundary"])
+
+	body, err := mixed.NextPart()
+	require.NoError(t, err)
+
+	mediaType, params, err = mime.ParseMediaType(body.Header.Get("Content-Type"))
+	require.NoError(t, err)
+	assert.Equal(t, "multipart/alternative", mediaType)
+
+	alternative := multipart.NewReader(body, params["boundary"])
+
+	var bodies []string
+
+	for {
+		part, partErr := alternative.NextPart()
+		if errors.Is(partErr, io.EOF) {
+			break
+		}
+
+		require.NoError(t, partErr)
+
+		decoded, readErr := io.ReadAll(base64.NewDecoder(base64.StdEncoding, part))
+		require.NoError(t, readErr)
+
+		bodies = append(bodies, part.Header.Get("Content-Type")+": "+string(decoded))
+	}
+
+	assert.Equal(t, []string{
+		"text/plain; charset=utf-8: Plain body",
+		"text/html; charset=utf-8: <p>HTML body</p>",
+	}, bodies)
+
+	attachment, err := mixed.NextPart()
+	require.NoError(t, err)
+	assert.Equal(t, "report.csv", attachment.FileName())
+}
+
+func TestSendGridSender(t *testing.T) {
+	var got sendGridRequest
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/v3/mail/send", r.URL.Path)
+		assert.Equal(t, "Bearer sg-key", r.Header.Get("Authorization"))
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&got))
+		w.WriteHeader(http.StatusAccepted)
+	}))
+	defer server.Close()
+
+	sender := NewSendGridSender(config.EmailConfig{
+		From:     "GoFormX <noreply@example.com>",
+		SendGrid: config.SendGridEmailConfig{APIKey: "sg-key", Endpoint: server.URL},
+	}, nil, newTestLogger(t))
+
+	err := sender.Send(context.Background(), &Message{
+		To:          []string{"a@example.com", "B <b@example.com>"},
+		Subject:     "Hello",
+		Body:        "Body",
+		HTML:        "<p>Body</p>",
+		Attachments: []Attachment{{Filename: "report.csv", ContentType: "text/csv", Data: []byte("a,b")}},
+		FormID:      "form-1",
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, map[string]string{"form_id": "form-1"}, got.CustomArgs)
+
+	assert.Equal(t, sendGridAddress{Email: "noreply@example.com", Name: "GoFormX"}, got.From)
+	assert.Equal(t, []sendGridContent{{Type: "text/plain", Value: "Body"}, {Type: "text/html", Value: "<p>Body</p>"}}, got.Content)
+	require.Len(t, got.Personalizations, 1)
+	assert.Equal(t, []sendGridAddress{{Email: "a@example.com"}, {Email: "b@example.com"}}, got.Personalizations[0].To)
+	require.Len(t, got.Attachments, 1)
+	assert.Equal(t, base64.StdEncoding.EncodeToString([]byte("a,b")), got.Attachments[0].Content)
+}
+
+func TestMailgunSender(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/v3/mg.example.com/messages.mime", r.URL.Path)
+
+		user, pass, ok := r.BasicAuth()
+		assert.True(t, ok)
+		assert.Equal(t, "api", user)
+		assert.Equal(t, "mg-key", pass)
+
+		assert.NoError(t, r.ParseMultipartForm(1<<20))
+		assert.Equal(t, []string{"a@example.com"}, r.MultipartForm.Value["to"])
+
+		file, _, err := r.FormFile("message")
+		if assert.NoError(t, err) {
+			message, _ := io.ReadAll(file)
+			assert.Contains(t, string(message), "Subject: Hello")
+		}
+
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	sender := NewMailgunSender(config.EmailConfig{
+		From:    "noreply@example.com",
+		Mailgun: config.MailgunEmailConfig{Domain: "mg.example.com", APIKey: "mg-key", Endpoint: server.URL},
+	}, nil, newTestLogger(t))
+
+	require.NoError(t, sender.Send(context.Background(), &Message{To: []string{"a@example.com"}, Subject: "Hello"}))
+}
+
+func TestSESSender(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/v2/email/outbound-emails", r.URL.Path)
+		assert.True(t, strings.HasPrefix(r.Header.Get("Authorization"),
+			"AWS4-HMAC-SHA256 Credential=AKID/20240102/eu-west-1/ses/aws4_request, "+
+				"SignedHeaders=content-type;host;x-amz-date, Signature="))
+
+		var req sesSendEmailRequest
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+		assert.Equal(t, []string{"a@example.com"}, req.Destination.ToAddresses)
+		assert.Contains(t, string(req.Content.Raw.Data), "Subject: Hello")
+
+		w.Header().Set("Retry-After", "2")
+		w.WriteHeader(http.StatusTooManyRequests)
+	}))
+	defer server.Close()
+
+	sender := NewSESSender(config.EmailConfig{
+		From: "noreply@example.com",
+		SES: config.SESEmailConfig{
+			Region: "eu-west-1", AccessKeyID: "AKID", SecretAccessKey: "secret", Endpoint: server.URL,
+		},
+	}, nil, newTestLogger(t))
+	sender.now = func() time.Time { return time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC) }
+
+	err := sender.Send(context.Background(), &Message{To: []string{"a@example.com"}, Subject: "Hello"})
+
+	var deliveryErr *DeliveryError
+	require.ErrorAs(t, err, &deliveryErr)
+	assert.Equal(t, http.StatusTooManyRequests, deliveryErr.StatusCode)
+	assert.False(t, deliveryErr.Permanent)
+	assert.Equal(t, 2*time.Second, deliveryErr.RetryAfter)
+}
+
+func TestSignV4(t *testing.T) {
+	// "get-vanilla" from the AWS Signature Version 4 test suite
+	req := httptest.NewRequest(http.MethodGet, "https://example.amazonaws.com/", http.NoBody)
+	req.Header = http.Header{}
+
+	signV4(req, nil, awsCredentials{
+		AccessKeyID:     "AKIDEXAMPLE",
+		SecretAccessKey: "wJalrXUtnFEMI/K7MDENG+bPxRfiCYEXAMPLEKEY",
+	}, "us-east-1", "service", time.Date(2015, 8, 30, 12, 36, 0, 0, time.UTC))
+
+	assert.Equal(t, "AWS4-HMAC-SHA256 Credential=AKIDEXAMPLE/20150830/us-east-1/service/aws4_request, "+
+		"SignedHeaders=host;x-amz-date, "+
+		"Signature=5fa00fa31553b73ebf1942676e86291e8372ff2a2260956d9b8aae1d763fbf31",
+		req.Header.Get("Authorization"))
+}
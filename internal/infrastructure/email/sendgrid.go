@@ -0,0 +1,144 @@
+package email
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/goformx/goforms/internal/infrastructure/config"
+	"github.com/goformx/goforms/internal/infrastructure/logging"
+)
+
+// defaultSendGridEndpoint is the SendGrid v3 API base URL
+const defaultSendGridEndpoint = "https://api.sendgrid.com"
+
+// SendGridSender delivers messages through the SendGrid v3 mail send API
+type SendGridSender struct {
+	cfg    config.SendGridEmailConfig
+	from   string
+	client *http.Client
+	logger logging.Logger
+}
+
+// NewSendGridSender creates a SendGrid sender. A nil client uses one bounded by email.timeout.
+func NewSendGridSender(cfg config.EmailConfig, client *http.Client, logger logging.Logger) *SendGridSender {
+	if client == nil {
+		client = newHTTPClient(cfg.Timeout)
+	}
+
+	return &SendGridSender{
+		cfg:    cfg.SendGrid,
+		from:   cfg.From,
+		client: client,
+		logger: logger,
+	}
+}
+
+type sendGridAddress struct {
+	Email string `json:"email"`
+	Name  string `json:"name,omitempty"`
+}
+
+type sendGridContent struct {
+	Type  string `json:"type"`
+	Value string `json:"value"`
+}
+
+type sendGridAttachment struct {
+	Content     string `json:"content"`
+	Filename    string `json:"filename"`
+	Type        string `json:"type,omitempty"`
+	Disposition string `json:"disposition"`
+}
+
+type sendGridPersonalization struct {
+	To []sendGridAddress `json:"to"`
+}
+
+type sendGridRequest struct {
+	Personalizations []sendGridPersonalization `json:"personalizations"`
+	From             sendGridAddress           `json:"from"`
+	Subject          string                    `json:"subject"`
+	Content          []sendGridContent         `json:"content"`
+	Attachments      []sendGridAttachment      `json:"attachments,omitempty"`
+	// CustomArgs are echoed back in event webhook payloads
+	CustomArgs map[string]string `json:"custom_args,omitempty"`
+}
+
+// Send delivers a message, honouring the context deadline
+func (s *SendGridSender) Send(ctx context.Context, msg *Message) error {
+	if len(msg.To) == 0 {
+		return ErrNoRecipients
+	}
+
+	payload, err := json.Marshal(s.request(msg))
+	if err != nil {
+		return fmt.Errorf("encode sendgrid request: %w", err)
+	}
+
+	endpoint := s.cfg.Endpoint
+	if endpoint == "" {
+		endpoint = defaultSendGridEndpoint
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost,
+		strings.TrimSuffix(endpoint, "/")+"/v3/mail/send", bytes.NewReader(payload))
+	if err != nil {
+		return fmt.Errorf("create sendgrid request: %w", err)
+	}
+
+	req.Header.Set("Authorization", "Bearer "+s.cfg.APIKey)
+	req.Header.Set("Content-Type", "application/json")
+
+	if err = doAPIRequest(s.client, config.EmailProviderSendGrid, req); err != nil {
+		return err
+	}
+
+	s.logger.Debug("email sent", "provider", config.EmailProviderSendGrid,
+		"subject", msg.Subject, "recipient_count", len(msg.To))
+
+	return nil
+}
+
+// request builds the mail send payload. All recipients share one personalization,
+// matching the single To header an SMTP delivery would carry.
+func (s *SendGridSender) request(msg *Message) *sendGridRequest {
+	name, address := parseFrom(s.from)
+
+	req := &sendGridRequest{
+		From:    sendGridAddress{Email: address, Name: name},
+		Subject: sanitizeHeader(msg.Subject),
+		Content: []sendGridContent{{Type: "text/plain", Value: msg.Body}},
+	}
+
+	if msg.HTML != "" {
+		// SendGrid requires text/plain before text/html
+		req.Content = append(req.Content, sendGridContent{Type: "text/html", Value: msg.HTML})
+	}
+
+	var personalization sendGridPersonalization
+	for _, recipient := range msg.To {
+		personalization.To = append(personalization.To, sendGridAddress{Email: addressOnly(recipient)})
+	}
+
+	req.Personalizations = []sendGridPersonalization{personalization}
+
+	if msg.FormID != "" {
+		req.CustomArgs = map[string]string{formIDTag: msg.FormID}
+	}
+
+	for _, attachment := range msg.Attachments {
+		req.Attachments = append(req.Attachments, sendGridAttachment{
+			Content:     base64.StdEncoding.EncodeToString(attachment.Data),
+			Filename:    attachment.Filename,
+			Type:        attachment.ContentType,
+			Disposition: "attachment",
+		})
+	}
+
+	return req
+}
@@ -0,0 +1,124 @@
+package email
+
+import (
+	"crypto/ecdsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/goformx/goforms/internal/domain/emaildelivery"
+	"github.com/goformx/goforms/internal/infrastructure/config"
+)
+
+// Headers of SendGrid's signed event webhook
+const (
+	SendGridSignatureHeader = "X-Twilio-Email-Event-Webhook-Signature"
+	SendGridTimestampHeader = "X-Twilio-Email-Event-Webhook-Timestamp"
+)
+
+// SendGridVerifier authenticates deliveries of SendGrid's signed event webhook
+type SendGridVerifier struct {
+	key    *ecdsa.PublicKey
+	maxAge time.Duration
+	now    func() time.Time
+}
+
+// NewSendGridVerifier creates a verifier for the base64 verification key shown
+// in SendGrid's signed event webhook settings. A zero maxAge accepts deliveries
+// of any age.
+func NewSendGridVerifier(verificationKey string, maxAge time.Duration) (*SendGridVerifier, error) {
+	der, err := base64.StdEncoding.DecodeString(verificationKey)
+	if err != nil {
+		return nil, fmt.Errorf("decode sendgrid verification key: %w", err)
+	}
+
+	parsed, err := x509.ParsePKIXPublicKey(der)
+	if err != nil {
+		return nil, fmt.Errorf("parse sendgrid verification key: %w", err)
+	}
+
+	key, ok := parsed.(*ecdsa.PublicKey)
+	if !ok {
+		return nil, errors.New("sendgrid verification key is not an ECDSA public key")
+	}
+
+	return &SendGridVerifier{key: key, maxAge: maxAge, now: time.Now}, nil
+}
+
+// Verify checks the signature SendGrid made over the timestamp and raw body
+func (v *SendGridVerifier) Verify(signature, timestamp string, body []byte) error {
+	if v.maxAge > 0 {
+		seconds, err := strconv.ParseInt(timestamp, 10, 64)
+		if err != nil {
+			return fmt.Errorf("parse sendgrid timestamp: %w", ErrWebhookSignature)
+		}
+
+		if v.now().Sub(time.Unix(seconds, 0)) > v.maxAge {
+			return ErrWebhookExpired
+		}
+	}
+
+	sig, err := base64.StdEncoding.DecodeString(signature)
+	if err != nil {
+		return fmt.Errorf("decode sendgrid signature: %w", ErrWebhookSignature)
+	}
+
+	digest := sha256.Sum256(append([]byte(timestamp), body...))
+	if !ecdsa.VerifyASN1(v.key, digest[:], sig) {
+		return ErrWebhookSignature
+	}
+
+	return nil
+}
+
+// sendGridEvent is one entry of an event webhook payload. Custom args of the
+// message are merged into the event, so form_id sits at the top level.
+type sendGridEvent struct {
+	Email     string `json:"email"`
+	Event     string `json:"event"`
+	Type      string `json:"type"`
+	Reason    string `json:"reason"`
+	Timestamp int64  `json:"timestamp"`
+	FormID    string `json:"form_id"`
+}
+
+// ParseSendGridEvents converts an event webhook payload into delivery events.
+// Bounces and spam reports are kept; other events, such as deliveries and
+// opens, are skipped. A bounce of type "blocked" is temporary.
+func ParseSendGridEvents(body []byte) ([]*emaildelivery.Event, error) {
+	var payload []sendGridEvent
+	if err := json.Unmarshal(body, &payload); err != nil {
+		return nil, fmt.Errorf("decode sendgrid events: %w", err)
+	}
+
+	events := make([]*emaildelivery.Event, 0, len(payload))
+
+	for _, entry := range payload {
+		event := &emaildelivery.Event{
+			FormID:     entry.FormID,
+			Recipient:  entry.Email,
+			Provider:   config.EmailProviderSendGrid,
+			Detail:     entry.Reason,
+			OccurredAt: time.Unix(entry.Timestamp, 0).UTC(),
+		}
+
+		switch entry.Event {
+		case "bounce":
+			event.Type = emaildelivery.EventBounce
+			event.Permanent = entry.Type != "blocked"
+		case "spamreport":
+			event.Type = emaildelivery.EventComplaint
+		default:
+			continue
+		}
+
+		events = append(events, event)
+	}
+
+	return events, nil
+}
@@ -0,0 +1,121 @@
+package email
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/goformx/goforms/internal/infrastructure/config"
+	"github.com/goformx/goforms/internal/infrastructure/logging"
+)
+
+// sesService is the signing name of the SES API
+const sesService = "ses"
+
+// SESSender delivers messages through the Amazon SES v2 SendEmail API as raw
+// MIME, so attachments and headers are encoded exactly as for SMTP
+type SESSender struct {
+	cfg    config.SESEmailConfig
+	from   string
+	client *http.Client
+	logger logging.Logger
+	now    func() time.Time
+}
+
+// NewSESSender creates an SES sender. A nil client uses one bounded by email.timeout.
+func NewSESSender(cfg config.EmailConfig, client *http.Client, logger logging.Logger) *SESSender {
+	if client == nil {
+		client = newHTTPClient(cfg.Timeout)
+	}
+
+	return &SESSender{
+		cfg:    cfg.SES,
+		from:   cfg.From,
+		client: client,
+		logger: logger,
+		now:    time.Now,
+	}
+}
+
+type sesSendEmailRequest struct {
+	FromEmailAddress string `json:"FromEmailAddress"`
+	Destination      struct {
+		ToAddresses []string `json:"ToAddresses"`
+	} `json:"Destination"`
+	Content struct {
+		Raw struct {
+			// Data is encoded as base64 by encoding/json, as the API expects
+			Data []byte `json:"Data"`
+		} `json:"Raw"`
+	} `json:"Content"`
+	// EmailTags are included in the SNS bounce and complaint notifications
+	EmailTags []sesMessageTag `json:"EmailTags,omitempty"`
+}
+
+type sesMessageTag struct {
+	Name  string `json:"Name"`
+	Value string `json:"Value"`
+}
+
+// Send delivers a message, honouring the context deadline
+func (s *SESSender) Send(ctx context.Context, msg *Message) error {
+	if len(msg.To) == 0 {
+		return ErrNoRecipients
+	}
+
+	message, err := buildMessage(s.from, msg)
+	if err != nil {
+		return fmt.Errorf("build email: %w", err)
+	}
+
+	var request sesSendEmailRequest
+
+	request.FromEmailAddress = sanitizeHeader(s.from)
+	request.Destination.ToAddresses = msg.To
+	request.Content.Raw.Data = message
+
+	if msg.FormID != "" {
+		request.EmailTags = []sesMessageTag{{Name: formIDTag, Value: msg.FormID}}
+	}
+
+	payload, err := json.Marshal(request)
+	if err != nil {
+		return fmt.Errorf("encode ses request: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost,
+		s.endpoint()+"/v2/email/outbound-emails", bytes.NewReader(payload))
+	if err != nil {
+		return fmt.Errorf("create ses request: %w", err)
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+
+	signV4(req, payload, awsCredentials{
+		AccessKeyID:     s.cfg.AccessKeyID,
+		SecretAccessKey: s.cfg.SecretAccessKey,
+		SessionToken:    s.cfg.SessionToken,
+	}, s.cfg.Region, sesService, s.now())
+
+	if err = doAPIRequest(s.client, config.EmailProviderSES, req); err != nil {
+		return err
+	}
+
+	s.logger.Debug("email sent", "provider", config.EmailProviderSES,
+		"subject", msg.Subject, "recipient_count", len(msg.To))
+
+	return nil
+}
+
+// endpoint returns the API base URL for the configured region
+func (s *SESSender) endpoint() string {
+	if s.cfg.Endpoint != "" {
+		return strings.TrimSuffix(s.cfg.Endpoint, "/")
+	}
+
+	return "https://email." + s.cfg.Region + ".amazonaws.com"
+}
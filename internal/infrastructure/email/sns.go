@@ -0,0 +1,358 @@
+package email
+
+import (
+	"context"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"regexp"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/goformx/goforms/internal/domain/emaildelivery"
+	"github.com/goformx/goforms/internal/infrastructure/config"
+)
+
+// SNS message types
+const (
+	SNSTypeNotification             = "Notification"
+	SNSTypeSubscriptionConfirmation = "SubscriptionConfirmation"
+	SNSTypeUnsubscribeConfirmation  = "UnsubscribeConfirmation"
+)
+
+// maxCertificateSize bounds a downloaded SNS signing certificate
+const maxCertificateSize = 64 * 1024
+
+// snsHostPattern matches the hosts SNS signing certificates and subscription
+// confirmations are served from
+var snsHostPattern = regexp.MustCompile(`^sns\.[a-z0-9-]+\.amazonaws\.com(\.cn)?$`)
+
+// SNSMessage is the envelope Amazon SNS posts to HTTPS subscriptions
+type SNSMessage struct {
+	Type             string `json:"Type"`
+	MessageID        string `json:"MessageId"`
+	Token            string `json:"Token"`
+	TopicArn         string `json:"TopicArn"`
+	Subject          string `json:"Subject"`
+	Message          string `json:"Message"`
+	SubscribeURL     string `json:"SubscribeURL"`
+	Timestamp        string `json:"Timestamp"`
+	SignatureVersion string `json:"SignatureVersion"`
+	Signature        string `json:"Signature"`
+	SigningCertURL   string `json:"SigningCertURL"`
+}
+
+// SNSVerifier authenticates SNS messages: the topic must be configured, the
+// message recent, and the signature made with Amazon's signing certificate
+type SNSVerifier struct {
+	topics []string
+	maxAge time.Duration
+	client *http.Client
+	now    func() time.Time
+
+	mu    sync.Mutex
+	certs map[string]*x509.Certificate
+	// fetch downloads a signing certificate; replaced in tests
+	fetch func(ctx context.Context, certURL string) (*x509.Certificate, error)
+}
+
+// NewSNSVerifier creates a verifier accepting messages from topicARNs. A zero
+// maxAge accepts messages of any age. Certificates are fetched through
+// transport, or http.DefaultTransport when it is nil.
+func NewSNSVerifier(topicARNs []string, maxAge time.Duration, transport http.RoundTripper) *SNSVerifier {
+	client := newHTTPClient(0)
+	client.Transport = transport
+
+	v := &SNSVerifier{
+		topics: topicARNs,
+		maxAge: maxAge,
+		client: client,
+		now:    time.Now,
+		certs:  make(map[string]*x509.Certificate),
+	}
+	v.fetch = v.fetchCertificate
+
+	return v
+}
+
+// Verify checks that msg comes from a configured topic and is signed by SNS
+func (v *SNSVerifier) Verify(ctx context.Context, msg *SNSMessage) error {
+	if !slices.Contains(v.topics, msg.TopicArn) {
+		return ErrWebhookTopic
+	}
+
+	if v.maxAge > 0 {
+		sentAt, err := time.Parse(time.RFC3339, msg.Timestamp)
+		if err != nil {
+			return fmt.Errorf("parse sns timestamp: %w", ErrWebhookSignature)
+		}
+
+		if v.now().Sub(sentAt) > v.maxAge {
+			return ErrWebhookExpired
+		}
+	}
+
+	algorithm, err := snsSignatureAlgorithm(msg.SignatureVersion)
+	if err != nil {
+		return err
+	}
+
+	signature, err := base64.StdEncoding.DecodeString(msg.Signature)
+	if err != nil {
+		return fmt.Errorf("decode sns signature: %w", ErrWebhookSignature)
+	}
+
+	certURL, err := snsURL(msg.SigningCertURL)
+	if err != nil {
+		return err
+	}
+
+	cert, err := v.certificate(ctx, certURL)
+	if err != nil {
+		return err
+	}
+
+	signed, err := msg.stringToSign()
+	if err != nil {
+		return err
+	}
+
+	if err = cert.CheckSignature(algorithm, signed, signature); err != nil {
+		return fmt.Errorf("check sns signature: %w", ErrWebhookSignature)
+	}
+
+	return nil
+}
+
+// ConfirmSubscription visits the SubscribeURL of a verified subscription
+// confirmation, which activates the subscription
+func (v *SNSVerifier) ConfirmSubscription(ctx context.Context, msg *SNSMessage) error {
+	subscribeURL, err := snsURL(msg.SubscribeURL)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, subscribeURL, http.NoBody)
+	if err != nil {
+		return fmt.Errorf("create sns confirmation request: %w", err)
+	}
+
+	resp, err := v.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("confirm sns subscription: %w", err)
+	}
+	defer resp.Body.Close()
+
+	_, _ = io.Copy(io.Discard, resp.Body)
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("confirm sns subscription: unexpected status %d", resp.StatusCode)
+	}
+
+	return nil
+}
+
+// certificate returns the signing certificate at certURL, downloading it once
+func (v *SNSVerifier) certificate(ctx context.Context, certURL string) (*x509.Certificate, error) {
+	v.mu.Lock()
+	cert, ok := v.certs[certURL]
+	v.mu.Unlock()
+
+	if ok {
+		return cert, nil
+	}
+
+	cert, err := v.fetch(ctx, certURL)
+	if err != nil {
+		return nil, err
+	}
+
+	v.mu.Lock()
+	v.certs[certURL] = cert
+	v.mu.Unlock()
+
+	return cert, nil
+}
+
+// fetchCertificate downloads and parses a PEM signing certificate
+func (v *SNSVerifier) fetchCertificate(ctx context.Context, certURL string) (*x509.Certificate, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, certURL, http.NoBody)
+	if err != nil {
+		return nil, fmt.Errorf("create sns certificate request: %w", err)
+	}
+
+	resp, err := v.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("download sns certificate: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("download sns certificate: unexpected status %d", resp.StatusCode)
+	}
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxCertificateSize))
+	if err != nil {
+		return nil, fmt.Errorf("download sns certificate: %w", err)
+	}
+
+	block, _ := pem.Decode(body)
+	if block == nil {
+		return nil, errors.New("sns certificate is not PEM encoded")
+	}
+
+	cert, err := x509.ParseCertificate(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("parse sns certificate: %w", err)
+	}
+
+	return cert, nil
+}
+
+// stringToSign builds the canonical message SNS signs for the message type
+func (m *SNSMessage) stringToSign() ([]byte, error) {
+	var fields [][2]string
+
+	switch m.Type {
+	case SNSTypeNotification:
+		fields = [][2]string{{"Message", m.Message}, {"MessageId", m.MessageID}}
+		if m.Subject != "" {
+			fields = append(fields, [2]string{"Subject", m.Subject})
+		}
+
+		fields = append(fields, [][2]string{{"Timestamp", m.Timestamp}, {"TopicArn", m.TopicArn}, {"Type", m.Type}}...)
+	case SNSTypeSubscriptionConfirmation, SNSTypeUnsubscribeConfirmation:
+		fields = [][2]string{
+			{"Message", m.Message},
+			{"MessageId", m.MessageID},
+			{"SubscribeURL", m.SubscribeURL},
+			{"Timestamp", m.Timestamp},
+			{"Token", m.Token},
+			{"TopicArn", m.TopicArn},
+			{"Type", m.Type},
+		}
+	default:
+		return nil, fmt.Errorf("unsupported sns message type %q: %w", m.Type, ErrWebhookSignature)
+	}
+
+	var b strings.Builder
+	for _, field := range fields {
+		b.WriteString(field[0] + "\n" + field[1] + "\n")
+	}
+
+	return []byte(b.String()), nil
+}
+
+// snsSignatureAlgorithm maps an SNS signature version to its algorithm
+func snsSignatureAlgorithm(version string) (x509.SignatureAlgorithm, error) {
+	switch version {
+	case "1":
+		return x509.SHA1WithRSA, nil
+	case "2":
+		return x509.SHA256WithRSA, nil
+	default:
+		return x509.UnknownSignatureAlgorithm,
+			fmt.Errorf("unsupported sns signature version %q: %w", version, ErrWebhookSignature)
+	}
+}
+
+// snsURL checks that a certificate or confirmation URL points at SNS over HTTPS
+func snsURL(raw string) (string, error) {
+	parsed, err := url.Parse(raw)
+	if err != nil || parsed.Scheme != "https" || !snsHostPattern.MatchString(parsed.Hostname()) {
+		return "", fmt.Errorf("sns url %q is not an amazonaws.com sns endpoint: %w", raw, ErrWebhookSignature)
+	}
+
+	return parsed.String(), nil
+}
+
+// sesNotification is an SES bounce or complaint notification, published either
+// as a notification (notificationType) or an event (eventType)
+type sesNotification struct {
+	NotificationType string `json:"notificationType"`
+	EventType        string `json:"eventType"`
+	Bounce           *struct {
+		BounceType        string    `json:"bounceType"`
+		BounceSubType     string    `json:"bounceSubType"`
+		Timestamp         time.Time `json:"timestamp"`
+		BouncedRecipients []struct {
+			EmailAddress   string `json:"emailAddress"`
+			DiagnosticCode string `json:"diagnosticCode"`
+		} `json:"bouncedRecipients"`
+	} `json:"bounce"`
+	Complaint *struct {
+		ComplaintFeedbackType string    `json:"complaintFeedbackType"`
+		Timestamp             time.Time `json:"timestamp"`
+		ComplainedRecipients  []struct {
+			EmailAddress string `json:"emailAddress"`
+		} `json:"complainedRecipients"`
+	} `json:"complaint"`
+	Mail struct {
+		Tags map[string][]string `json:"tags"`
+	} `json:"mail"`
+}
+
+// ParseSESNotification converts the Message of an SES notification into
+// delivery events. Notifications other than bounces and complaints, such as
+// deliveries, yield no events.
+func ParseSESNotification(message string) ([]*emaildelivery.Event, error) {
+	var notification sesNotification
+	if err := json.Unmarshal([]byte(message), &notification); err != nil {
+		return nil, fmt.Errorf("decode ses notification: %w", err)
+	}
+
+	var formID string
+	if tags := notification.Mail.Tags[formIDTag]; len(tags) > 0 {
+		formID = tags[0]
+	}
+
+	kind := notification.NotificationType
+	if kind == "" {
+		kind = notification.EventType
+	}
+
+	var events []*emaildelivery.Event
+
+	switch {
+	case kind == "Bounce" && notification.Bounce != nil:
+		bounce := notification.Bounce
+		for _, recipient := range bounce.BouncedRecipients {
+			detail := recipient.DiagnosticCode
+			if detail == "" {
+				detail = bounce.BounceType + "/" + bounce.BounceSubType
+			}
+
+			events = append(events, &emaildelivery.Event{
+				FormID:     formID,
+				Type:       emaildelivery.EventBounce,
+				Recipient:  recipient.EmailAddress,
+				Provider:   config.EmailProviderSES,
+				Permanent:  bounce.BounceType == "Permanent",
+				Detail:     detail,
+				OccurredAt: bounce.Timestamp,
+			})
+		}
+	case kind == "Complaint" && notification.Complaint != nil:
+		complaint := notification.Complaint
+		for _, recipient := range complaint.ComplainedRecipients {
+			events = append(events, &emaildelivery.Event{
+				FormID:     formID,
+				Type:       emaildelivery.EventComplaint,
+				Recipient:  recipient.EmailAddress,
+				Provider:   config.EmailProviderSES,
+				Detail:     complaint.ComplaintFeedbackType,
+				OccurredAt: complaint.Timestamp,
+			})
+		}
+	}
+
+	return events, nil
+}
@@ -0,0 +1,176 @@
+package email
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/goformx/goforms/internal/infrastructure/logging"
+)
+
+// SuppressionList holds addresses that must not receive email, such as
+// addresses that bounced permanently
+type SuppressionList interface {
+	IsSuppressed(ctx context.Context, address string) (bool, error)
+	Suppress(ctx context.Context, address, reason string) error
+	Unsuppress(ctx context.Context, address string) error
+}
+
+// DeliveryRecorder records messages a provider accepted, the denominator of
+// per-form bounce and complaint rates
+type DeliveryRecorder interface {
+	RecordSent(ctx context.Context, formID, provider string, recipients []string) error
+}
+
+// MemorySuppressionList is a process-local SuppressionList. Addresses added at
+// runtime are lost on restart; permanent entries belong in email.suppressed.
+type MemorySuppressionList struct {
+	mu      sync.RWMutex
+	entries map[string]string
+}
+
+// NewMemorySuppressionList creates a suppression list holding the given addresses
+func NewMemorySuppressionList(addresses ...string) *MemorySuppressionList {
+	list := &MemorySuppressionList{entries: make(map[string]string, len(addresses))}
+	for _, address := range addresses {
+		list.entries[normalizeAddress(address)] = "configured"
+	}
+
+	return list
+}
+
+// IsSuppressed reports whether address is on the list
+func (l *MemorySuppressionList) IsSuppressed(_ context.Context, address string) (bool, error) {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+
+	_, ok := l.entries[normalizeAddress(address)]
+
+	return ok, nil
+}
+
+// Suppress adds address to the list
+func (l *MemorySuppressionList) Suppress(_ context.Context, address, reason string) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.entries[normalizeAddress(address)] = reason
+
+	return nil
+}
+
+// Unsuppress removes address from the list
+func (l *MemorySuppressionList) Unsuppress(_ context.Context, address string) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	delete(l.entries, normalizeAddress(address))
+
+	return nil
+}
+
+// configuredSuppressionList consults the addresses of email.suppressed before
+// a runtime list; runtime changes never touch the configured addresses
+type configuredSuppressionList struct {
+	configured *MemorySuppressionList
+	next       SuppressionList
+}
+
+// IsSuppressed reports whether address is configured or on the runtime list
+func (l *configuredSuppressionList) IsSuppressed(ctx context.Context, address string) (bool, error) {
+	if suppressed, _ := l.configured.IsSuppressed(ctx, address); suppressed {
+		return true, nil
+	}
+
+	return l.next.IsSuppressed(ctx, address)
+}
+
+// Suppress adds address to the runtime list
+func (l *configuredSuppressionList) Suppress(ctx context.Context, address, reason string) error {
+	return l.next.Suppress(ctx, address, reason)
+}
+
+// Unsuppress removes address from the runtime list
+func (l *configuredSuppressionList) Unsuppress(ctx context.Context, address string) error {
+	return l.next.Unsuppress(ctx, address)
+}
+
+// normalizeAddress reduces "Name <Addr>" to a lowercase bare address
+func normalizeAddress(address string) string {
+	return strings.ToLower(addressOnly(address))
+}
+
+// suppressingSender drops suppressed recipients and suppresses the ones a provider rejects
+type suppressingSender struct {
+	next   Sender
+	list   SuppressionList
+	logger logging.Logger
+}
+
+// Send delivers msg to its recipients that are not suppressed
+func (s *suppressingSender) Send(ctx context.Context, msg *Message) error {
+	if len(msg.To) == 0 {
+		return ErrNoRecipients
+	}
+
+	recipients := make([]string, 0, len(msg.To))
+
+	for _, recipient := range msg.To {
+		suppressed, err := s.list.IsSuppressed(ctx, recipient)
+		if err != nil {
+			return fmt.Errorf("check suppression list: %w", err)
+		}
+
+		if !suppressed {
+			recipients = append(recipients, recipient)
+		}
+	}
+
+	if dropped := len(msg.To) - len(recipients); dropped > 0 {
+		s.logger.Info("skipping suppressed email recipients", "subject", msg.Subject, "suppressed_count", dropped)
+	}
+
+	if len(recipients) == 0 {
+		return ErrRecipientsSuppressed
+	}
+
+	filtered := *msg
+	filtered.To = recipients
+
+	err := s.next.Send(ctx, &filtered)
+
+	var deliveryErr *DeliveryError
+	if errors.As(err, &deliveryErr) && deliveryErr.Permanent {
+		for _, rejected := range deliveryErr.Recipients {
+			if suppressErr := s.list.Suppress(ctx, rejected, deliveryErr.Error()); suppressErr != nil {
+				s.logger.Error("failed to suppress rejected email recipient", "error", suppressErr)
+			}
+		}
+	}
+
+	return err
+}
+
+// recordingSender reports accepted messages to a DeliveryRecorder
+type recordingSender struct {
+	next     Sender
+	recorder DeliveryRecorder
+	provider string
+	logger   logging.Logger
+}
+
+// Send delivers msg and records it once the provider has accepted it. A failure
+// to record is logged; the message has already been sent.
+func (s *recordingSender) Send(ctx context.Context, msg *Message) error {
+	if err := s.next.Send(ctx, msg); err != nil {
+		return err
+	}
+
+	if err := s.recorder.RecordSent(ctx, msg.FormID, s.provider, msg.To); err != nil {
+		s.logger.Error("failed to record sent email", "form_id", msg.FormID, "error", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,207 @@
+package email
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/base64"
+	"math/big"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/goformx/goforms/internal/domain/emaildelivery"
+)
+
+const testTopicARN = "arn:aws:sns:us-east-1:123456789012:ses-notifications"
+
+// newTestSNSVerifier returns a verifier whose certificate downloads return a
+// self-signed certificate for key
+func newTestSNSVerifier(t *testing.T, key *rsa.PrivateKey) *SNSVerifier {
+	t.Helper()
+
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "sns.amazonaws.com"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	require.NoError(t, err)
+
+	cert, err := x509.ParseCertificate(der)
+	require.NoError(t, err)
+
+	verifier := NewSNSVerifier([]string{testTopicARN}, time.Hour, nil)
+	verifier.fetch = func(_ context.Context, _ string) (*x509.Certificate, error) { return cert, nil }
+
+	return verifier
+}
+
+// signSNS signs msg with key using signature version 2
+func signSNS(t *testing.T, key *rsa.PrivateKey, msg *SNSMessage) {
+	t.Helper()
+
+	msg.SignatureVersion = "2"
+	msg.SigningCertURL = "https://sns.us-east-1.amazonaws.com/SimpleNotificationService-abc.pem"
+
+	signed, err := msg.stringToSign()
+	require.NoError(t, err)
+
+	digest := sha256.Sum256(signed)
+	signature, err := rsa.SignPKCS1v15(rand.Reader, key, crypto.SHA256, digest[:])
+	require.NoError(t, err)
+
+	msg.Signature = base64.StdEncoding.EncodeToString(signature)
+}
+
+func TestSNSVerifier_Verify(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	verifier := newTestSNSVerifier(t, key)
+
+	newMessage := func() *SNSMessage {
+		msg := &SNSMessage{
+			Type:      SNSTypeNotification,
+			MessageID: "message-1",
+			TopicArn:  testTopicARN,
+			Message:   `{"notificationType":"Delivery"}`,
+			Timestamp: time.Now().UTC().Format(time.RFC3339),
+		}
+		signSNS(t, key, msg)
+
+		return msg
+	}
+
+	require.NoError(t, verifier.Verify(ctx, newMessage()))
+
+	tampered := newMessage()
+	tampered.Message = `{"notificationType":"Bounce"}`
+	require.ErrorIs(t, verifier.Verify(ctx, tampered), ErrWebhookSignature)
+
+	otherTopic := newMessage()
+	otherTopic.TopicArn = "arn:aws:sns:us-east-1:123456789012:other"
+	require.ErrorIs(t, verifier.Verify(ctx, otherTopic), ErrWebhookTopic)
+
+	foreignCert := newMessage()
+	foreignCert.SigningCertURL = "https://example.com/cert.pem"
+	require.ErrorIs(t, verifier.Verify(ctx, foreignCert), ErrWebhookSignature)
+
+	stale := &SNSMessage{
+		Type:      SNSTypeNotification,
+		MessageID: "message-2",
+		TopicArn:  testTopicARN,
+		Message:   "{}",
+		Timestamp: time.Now().Add(-2 * time.Hour).UTC().Format(time.RFC3339),
+	}
+	signSNS(t, key, stale)
+	require.ErrorIs(t, verifier.Verify(ctx, stale), ErrWebhookExpired)
+}
+
+func TestParseSESNotification(t *testing.T) {
+	bounce := `{
+		"notificationType": "Bounce",
+		"bounce": {
+			"bounceType": "Permanent",
+			"bounceSubType": "General",
+			"timestamp": "2026-01-15T09:30:00Z",
+			"bouncedRecipients": [{"emailAddress": "gone@example.com", "diagnosticCode": "smtp; 550 5.1.1 user unknown"}]
+		},
+		"mail": {"tags": {"form_id": ["form-1"]}}
+	}`
+
+	events, err := ParseSESNotification(bounce)
+	require.NoError(t, err)
+	require.Len(t, events, 1)
+	assert.Equal(t, emaildelivery.EventBounce, events[0].Type)
+	assert.Equal(t, "gone@example.com", events[0].Recipient)
+	assert.Equal(t, "form-1", events[0].FormID)
+	assert.True(t, events[0].Permanent)
+	assert.Equal(t, "smtp; 550 5.1.1 user unknown", events[0].Detail)
+
+	complaint := `{
+		"eventType": "Complaint",
+		"complaint": {"complaintFeedbackType": "abuse", "complainedRecipients": [{"emailAddress": "angry@example.com"}]},
+		"mail": {}
+	}`
+
+	events, err = ParseSESNotification(complaint)
+	require.NoError(t, err)
+	require.Len(t, events, 1)
+	assert.Equal(t, emaildelivery.EventComplaint, events[0].Type)
+	assert.Empty(t, events[0].FormID)
+
+	events, err = ParseSESNotification(`{"notificationType": "Delivery", "mail": {}}`)
+	require.NoError(t, err)
+	assert.Empty(t, events)
+
+	_, err = ParseSESNotification("not json")
+	require.Error(t, err)
+}
+
+func TestSendGridVerifier_Verify(t *testing.T) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	der, err := x509.MarshalPKIXPublicKey(&key.PublicKey)
+	require.NoError(t, err)
+
+	verifier, err := NewSendGridVerifier(base64.StdEncoding.EncodeToString(der), time.Hour)
+	require.NoError(t, err)
+
+	body := []byte(`[{"email":"gone@example.com","event":"bounce"}]`)
+	sign := func(timestamp string, payload []byte) string {
+		digest := sha256.Sum256(append([]byte(timestamp), payload...))
+		signature, signErr := ecdsa.SignASN1(rand.Reader, key, digest[:])
+		require.NoError(t, signErr)
+
+		return base64.StdEncoding.EncodeToString(signature)
+	}
+
+	now := strconv.FormatInt(time.Now().Unix(), 10)
+	require.NoError(t, verifier.Verify(sign(now, body), now, body))
+	require.ErrorIs(t, verifier.Verify(sign(now, body), now, []byte("[]")), ErrWebhookSignature)
+
+	old := strconv.FormatInt(time.Now().Add(-2*time.Hour).Unix(), 10)
+	require.ErrorIs(t, verifier.Verify(sign(old, body), old, body), ErrWebhookExpired)
+
+	_, err = NewSendGridVerifier("not a key", time.Hour)
+	require.Error(t, err)
+}
+
+func TestParseSendGridEvents(t *testing.T) {
+	body := []byte(`[
+		{"email": "gone@example.com", "event": "bounce", "type": "bounce", "reason": "550 user unknown",
+			"timestamp": 1768469400, "form_id": "form-1"},
+		{"email": "later@example.com", "event": "bounce", "type": "blocked", "timestamp": 1768469400},
+		{"email": "angry@example.com", "event": "spamreport", "timestamp": 1768469400},
+		{"email": "fine@example.com", "event": "delivered", "timestamp": 1768469400}
+	]`)
+
+	events, err := ParseSendGridEvents(body)
+	require.NoError(t, err)
+	require.Len(t, events, 3)
+
+	assert.Equal(t, emaildelivery.EventBounce, events[0].Type)
+	assert.True(t, events[0].Permanent)
+	assert.Equal(t, "form-1", events[0].FormID)
+	assert.Equal(t, time.Unix(1768469400, 0).UTC(), events[0].OccurredAt)
+
+	assert.False(t, events[1].Permanent)
+	assert.Equal(t, emaildelivery.EventComplaint, events[2].Type)
+
+	_, err = ParseSendGridEvents([]byte("{}"))
+	require.Error(t, err)
+}
@@ -0,0 +1,135 @@
+// Package exthook implements form extensions as external HTTP hooks. Each call
+// POSTs the extension.Request as JSON to the hook URL; the response body is an
+// extension.Result, and an empty 2xx response changes nothing.
+//
+// When the hook has a secret, the request carries X-Timestamp (Unix seconds)
+// and X-Signature, the hex HMAC-SHA256 of "<timestamp>.<body>" keyed by the secret.
+// Post-submit requests carry an Idempotency-Key, the delivery ID, which is the
+// same when an admin redelivers the submission.
+package exthook
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/goformx/goforms/internal/domain/extension"
+	"github.com/goformx/goforms/internal/infrastructure/config"
+)
+
+// Request headers
+const (
+	HeaderTimestamp = "X-Timestamp"
+	HeaderSignature = "X-Signature"
+	HeaderPoint     = "X-Goforms-Hook-Point"
+	HeaderDelivery  = "Idempotency-Key"
+)
+
+// maxResponseBody bounds how much of a hook response is read
+const maxResponseBody = 1 << 20
+
+// Hook is an extension served by an external HTTP endpoint
+type Hook struct {
+	name   string
+	url    string
+	secret string
+	points []extension.Point
+	client *http.Client
+	now    func() time.Time
+}
+
+// New creates a hook from its configuration. Calls are bounded by the
+// runner's per-hook timeout through the request context.
+func New(cfg config.ExtensionHookConfig, client *http.Client) *Hook {
+	if client == nil {
+		client = http.DefaultClient
+	}
+
+	points := make([]extension.Point, 0, len(cfg.Points))
+	for _, point := range cfg.Points {
+		points = append(points, extension.Point(point))
+	}
+
+	return &Hook{name: cfg.Name, url: cfg.URL, secret: cfg.Secret, points: points, client: client, now: time.Now}
+}
+
+// Name returns the hook's name
+func (h *Hook) Name() string {
+	return h.name
+}
+
+// Points returns the hook points the hook handles
+func (h *Hook) Points() []extension.Point {
+	return h.points
+}
+
+// Handle posts req to the hook URL and decodes its result
+func (h *Hook) Handle(ctx context.Context, req extension.Request) (extension.Result, error) {
+	body, err := json.Marshal(req)
+	if err != nil {
+		return extension.Result{}, fmt.Errorf("encode hook request: %w", err)
+	}
+
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, h.url, bytes.NewReader(body))
+	if err != nil {
+		return extension.Result{}, fmt.Errorf("create hook request: %w", err)
+	}
+
+	httpReq.Header.Set("Content-Type", "application/json")
+	httpReq.Header.Set(HeaderPoint, string(req.Point))
+
+	if req.DeliveryID != "" {
+		httpReq.Header.Set(HeaderDelivery, req.DeliveryID)
+	}
+
+	if h.secret != "" {
+		timestamp := strconv.FormatInt(h.now().Unix(), 10)
+		httpReq.Header.Set(HeaderTimestamp, timestamp)
+		httpReq.Header.Set(HeaderSignature, Sign(h.secret, timestamp, body))
+	}
+
+	resp, err := h.client.Do(httpReq)
+	if err != nil {
+		return extension.Result{}, fmt.Errorf("call hook: %w", err)
+	}
+	defer resp.Body.Close()
+
+	payload, err := io.ReadAll(io.LimitReader(resp.Body, maxResponseBody))
+	if err != nil {
+		return extension.Result{}, fmt.Errorf("read hook response: %w", err)
+	}
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return extension.Result{}, fmt.Errorf("hook responded %d: %s", resp.StatusCode, strings.TrimSpace(string(payload)))
+	}
+
+	var result extension.Result
+
+	if len(bytes.TrimSpace(payload)) == 0 {
+		return result, nil
+	}
+
+	if err = json.Unmarshal(payload, &result); err != nil {
+		return extension.Result{}, fmt.Errorf("decode hook response: %w", err)
+	}
+
+	return result, nil
+}
+
+// Sign returns the hex HMAC-SHA256 signature of a hook request body
+func Sign(secret, timestamp string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp + "."))
+	mac.Write(body)
+
+	return hex.EncodeToString(mac.Sum(nil))
+}
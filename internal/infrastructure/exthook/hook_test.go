@@ -0,0 +1,83 @@
+package exthook_test
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/goformx/goforms/internal/domain/extension"
+	"github.com/goformx/goforms/internal/domain/form/model"
+	"github.com/goformx/goforms/internal/infrastructure/config"
+	"github.com/goformx/goforms/internal/infrastructure/exthook"
+)
+
+func TestHook_Handle(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(r.Body)
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+
+			return
+		}
+
+		if r.Header.Get(exthook.HeaderSignature) != exthook.Sign("s3cret", r.Header.Get(exthook.HeaderTimestamp), body) {
+			w.WriteHeader(http.StatusUnauthorized)
+
+			return
+		}
+
+		var req extension.Request
+		if err = json.Unmarshal(body, &req); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+
+			return
+		}
+
+		switch req.FormID {
+		case "empty":
+			w.WriteHeader(http.StatusNoContent)
+		case "down":
+			http.Error(w, "maintenance", http.StatusServiceUnavailable)
+		default:
+			_ = json.NewEncoder(w).Encode(extension.Result{Reject: req.Data["email"] == nil, Message: "email required"})
+		}
+	}))
+	defer server.Close()
+
+	hook := exthook.New(config.ExtensionHookConfig{
+		Name:   "checker",
+		URL:    server.URL,
+		Points: []string{"pre_validate"},
+		Secret: "s3cret",
+	}, server.Client())
+
+	assert.Equal(t, "checker", hook.Name())
+	assert.Equal(t, []extension.Point{extension.PointPreValidate}, hook.Points())
+
+	ctx := context.Background()
+	req := extension.Request{Point: extension.PointPreValidate, FormID: "form-1", Data: model.JSON{}}
+
+	result, err := hook.Handle(ctx, req)
+	require.NoError(t, err)
+	assert.True(t, result.Reject)
+	assert.Equal(t, "email required", result.Message)
+
+	req.FormID = "empty"
+	result, err = hook.Handle(ctx, req)
+	require.NoError(t, err)
+	assert.Equal(t, extension.Result{}, result)
+
+	req.FormID = "down"
+	_, err = hook.Handle(ctx, req)
+	require.ErrorContains(t, err, "hook responded 503: maintenance")
+
+	unsigned := exthook.New(config.ExtensionHookConfig{Name: "unsigned", URL: server.URL}, server.Client())
+	_, err = unsigned.Handle(ctx, req)
+	require.ErrorContains(t, err, "hook responded 401")
+}
@@ -0,0 +1,161 @@
+// Package geoip locates IP addresses with a MaxMind GeoLite2 or GeoIP2
+// Country or City database. Forms use it to accept or refuse submissions by
+// country and to record the submitter's country and region with each
+// submission. The database is read into memory and can be replaced while the
+// server runs; Updater downloads new releases from MaxMind on a schedule.
+package geoip
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"net/netip"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/goformx/goforms/internal/infrastructure/config"
+	"github.com/goformx/goforms/internal/infrastructure/logging"
+)
+
+// Location is where an IP address is
+type Location struct {
+	// Country is the ISO 3166-1 alpha-2 code, e.g. DE
+	Country     string `json:"country"`
+	CountryName string `json:"country_name,omitempty"`
+	// Region is the ISO 3166-2 code of the first subdivision, e.g. BY; City databases only
+	Region     string `json:"region,omitempty"`
+	RegionName string `json:"region_name,omitempty"`
+}
+
+// Locator looks up the location of IP addresses
+type Locator struct {
+	path   string
+	logger logging.Logger
+
+	mu     sync.RWMutex
+	reader *Reader
+}
+
+// New creates the locator and loads the database at geoip.database_path. It
+// returns nil when geoip.enabled is unset. A missing or invalid database is
+// logged, and addresses are not located until Load succeeds.
+func New(cfg config.GeoIPConfig, logger logging.Logger) *Locator {
+	if !cfg.Enabled {
+		return nil
+	}
+
+	locator := &Locator{path: cfg.DatabasePath, logger: logger}
+
+	if err := locator.Load(); err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			logger.Warn("geoip database not found; submitters are not located until it is downloaded",
+				"file", cfg.DatabasePath)
+		} else {
+			logger.Error("failed to load geoip database", "file", cfg.DatabasePath, "error", err)
+		}
+	}
+
+	return locator
+}
+
+// NewFromReader creates a locator for an opened database
+func NewFromReader(reader *Reader) *Locator {
+	return &Locator{reader: reader}
+}
+
+// Load reads the database file, replacing the database in use when it is valid
+func (l *Locator) Load() error {
+	buffer, err := os.ReadFile(l.path)
+	if err != nil {
+		return fmt.Errorf("read geoip database: %w", err)
+	}
+
+	reader, err := NewReader(buffer)
+	if err != nil {
+		return fmt.Errorf("open geoip database: %w", err)
+	}
+
+	l.use(reader)
+
+	return nil
+}
+
+// use replaces the database in use
+func (l *Locator) use(reader *Reader) {
+	l.mu.Lock()
+	l.reader = reader
+	l.mu.Unlock()
+
+	if l.logger != nil {
+		metadata := reader.Metadata()
+		l.logger.Info("geoip database loaded", "file", l.path, "type", metadata.DatabaseType,
+			"built_at", time.Unix(int64(metadata.BuildEpoch), 0).UTC().Format(time.RFC3339))
+	}
+}
+
+// Locate returns the location of an IP address. It is false for a nil
+// locator, before a database is loaded, and for addresses the database does
+// not place in a country, such as private ranges.
+func (l *Locator) Locate(address string) (Location, bool) {
+	if l == nil {
+		return Location{}, false
+	}
+
+	ip, err := netip.ParseAddr(strings.TrimSpace(address))
+	if err != nil {
+		return Location{}, false
+	}
+
+	l.mu.RLock()
+	reader := l.reader
+	l.mu.RUnlock()
+
+	if reader == nil {
+		return Location{}, false
+	}
+
+	record, found, err := reader.Lookup(ip.WithZone(""))
+	if err != nil || !found {
+		return Location{}, false
+	}
+
+	location := locationOf(record)
+
+	return location, location.Country != ""
+}
+
+// locationOf reads a Country or City record. Anycast and EU-wide networks
+// have no country; their registered country is used instead.
+func locationOf(record map[string]any) Location {
+	var location Location
+
+	for _, key := range []string{"country", "registered_country"} {
+		if country, ok := record[key].(map[string]any); ok {
+			location.Country, _ = country["iso_code"].(string)
+			location.CountryName = englishName(country)
+
+			if location.Country != "" {
+				break
+			}
+		}
+	}
+
+	if subdivisions, ok := record["subdivisions"].([]any); ok && len(subdivisions) > 0 {
+		if subdivision, subOk := subdivisions[0].(map[string]any); subOk {
+			location.Region, _ = subdivision["iso_code"].(string)
+			location.RegionName = englishName(subdivision)
+		}
+	}
+
+	return location
+}
+
+// englishName returns the English name of a record
+func englishName(record map[string]any) string {
+	names, _ := record["names"].(map[string]any)
+	name, _ := names["en"].(string)
+
+	return name
+}
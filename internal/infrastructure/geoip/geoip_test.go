@@ -0,0 +1,177 @@
+package geoip_test
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/goformx/goforms/internal/infrastructure/config"
+	"github.com/goformx/goforms/internal/infrastructure/geoip"
+	mocklogging "github.com/goformx/goforms/test/mocks/logging"
+)
+
+// cityDatabase is a City database locating 81.2.69.0/24 in England and
+// 203.0.113.0/24 only by its registered country
+func cityDatabase(t *testing.T) []byte {
+	t.Helper()
+
+	return buildDatabase(t, 6, 24, []network{
+		{prefix: "81.2.69.0/24", record: cityRecord("GB", "United Kingdom", "ENG", "England")},
+		{prefix: "203.0.113.0/24", record: map[string]any{
+			"registered_country": map[string]any{"iso_code": "AU", "names": map[string]any{"en": "Australia"}},
+		}},
+	})
+}
+
+func TestLocator_Locate(t *testing.T) {
+	reader, err := geoip.NewReader(cityDatabase(t))
+	require.NoError(t, err)
+
+	locator := geoip.NewFromReader(reader)
+
+	location, ok := locator.Locate("81.2.69.160")
+	require.True(t, ok)
+	assert.Equal(t, geoip.Location{
+		Country: "GB", CountryName: "United Kingdom", Region: "ENG", RegionName: "England",
+	}, location)
+
+	location, ok = locator.Locate("203.0.113.7")
+	require.True(t, ok)
+	assert.Equal(t, geoip.Location{Country: "AU", CountryName: "Australia"}, location)
+
+	_, ok = locator.Locate("10.1.2.3")
+	assert.False(t, ok)
+
+	_, ok = locator.Locate("not an address")
+	assert.False(t, ok)
+
+	var disabled *geoip.Locator
+
+	_, ok = disabled.Locate("81.2.69.160")
+	assert.False(t, ok)
+	assert.Nil(t, geoip.New(config.GeoIPConfig{}, nil))
+}
+
+// archive gzips a tar archive holding the database as MaxMind packages it
+func archive(t *testing.T, database []byte) []byte {
+	t.Helper()
+
+	var buffer bytes.Buffer
+
+	gz := gzip.NewWriter(&buffer)
+	tw := tar.NewWriter(gz)
+
+	require.NoError(t, tw.WriteHeader(&tar.Header{Name: "GeoLite2-City_20260101/", Typeflag: tar.TypeDir, Mode: 0o755}))
+	require.NoError(t, tw.WriteHeader(&tar.Header{
+		Name: "GeoLite2-City_20260101/GeoLite2-City.mmdb", Typeflag: tar.TypeReg, Mode: 0o644, Size: int64(len(database)),
+	}))
+	_, err := tw.Write(database)
+	require.NoError(t, err)
+	require.NoError(t, tw.Close())
+	require.NoError(t, gz.Close())
+
+	return buffer.Bytes()
+}
+
+func TestUpdater_Update(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	logger := mocklogging.NewMockLogger(ctrl)
+	logger.EXPECT().Warn(gomock.Any(), gomock.Any()).AnyTimes()
+	logger.EXPECT().Info(gomock.Any(), gomock.Any()).AnyTimes()
+	logger.EXPECT().Debug(gomock.Any(), gomock.Any()).AnyTimes()
+
+	released := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	body := archive(t, cityDatabase(t))
+	downloads := 0
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/GeoLite2-City/download", r.URL.Path)
+		assert.Equal(t, "tar.gz", r.URL.Query().Get("suffix"))
+
+		account, key, ok := r.BasicAuth()
+		assert.True(t, ok)
+		assert.Equal(t, "12345", account)
+		assert.Equal(t, "license", key)
+
+		if since, err := http.ParseTime(r.Header.Get("If-Modified-Since")); err == nil && !released.After(since) {
+			w.WriteHeader(http.StatusNotModified)
+
+			return
+		}
+
+		downloads++
+
+		w.Header().Set("Last-Modified", released.Format(http.TimeFormat))
+		_, _ = w.Write(body)
+	}))
+	defer server.Close()
+
+	cfg := config.GeoIPConfig{
+		Enabled:      true,
+		DatabasePath: filepath.Join(t.TempDir(), "geoip", "GeoLite2-City.mmdb"),
+		Update: config.GeoIPUpdateConfig{
+			Enabled:    true,
+			AccountID:  "12345",
+			LicenseKey: "license",
+			EditionID:  "GeoLite2-City",
+			URL:        server.URL,
+		},
+	}
+
+	locator := geoip.New(cfg, logger)
+	require.NotNil(t, locator)
+
+	_, ok := locator.Locate("81.2.69.160")
+	assert.False(t, ok, "nothing is located before the first download")
+
+	updater := geoip.NewUpdater(cfg, locator, nil, logger)
+	require.NoError(t, updater.Update(context.Background()))
+
+	location, ok := locator.Locate("81.2.69.160")
+	require.True(t, ok)
+	assert.Equal(t, "GB", location.Country)
+
+	require.NoError(t, updater.Update(context.Background()))
+	assert.Equal(t, 1, downloads, "an unchanged release is not downloaded again")
+
+	// A restarted server loads the downloaded file
+	_, ok = geoip.New(cfg, logger).Locate("81.2.69.160")
+	assert.True(t, ok)
+
+	cfg.Update.Enabled = false
+	assert.Nil(t, geoip.NewUpdater(cfg, locator, nil, logger))
+}
+
+func TestUpdater_RefusesInvalidDownloads(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	logger := mocklogging.NewMockLogger(ctrl)
+	logger.EXPECT().Warn(gomock.Any(), gomock.Any()).AnyTimes()
+
+	body := archive(t, []byte("not a database"))
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write(body)
+	}))
+	defer server.Close()
+
+	cfg := config.GeoIPConfig{
+		Enabled:      true,
+		DatabasePath: filepath.Join(t.TempDir(), "GeoLite2-Country.mmdb"),
+		Update:       config.GeoIPUpdateConfig{Enabled: true, EditionID: "GeoLite2-Country", URL: server.URL},
+	}
+
+	locator := geoip.New(cfg, logger)
+	err := geoip.NewUpdater(cfg, locator, nil, logger).Update(context.Background())
+	require.ErrorIs(t, err, geoip.ErrInvalidDatabase)
+	assert.NoFileExists(t, cfg.DatabasePath, "an invalid download does not replace the database")
+}
@@ -0,0 +1,453 @@
+package geoip
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math"
+	"math/big"
+	"net/netip"
+)
+
+// metadataMarker starts the metadata section at the end of a MaxMind DB file
+var metadataMarker = []byte("\xAB\xCD\xEFMaxMind.com")
+
+const (
+	// metadataMaxSize bounds how far from the end of the file the marker is searched for
+	metadataMaxSize = 128 * 1024
+	// dataSectionSeparator is the size of the zeros between the search tree and the data
+	dataSectionSeparator = 16
+	// maxDecodeDepth bounds the nesting of maps and arrays in a damaged file
+	maxDecodeDepth = 32
+)
+
+// Data section field types
+const (
+	typeExtended  = 0
+	typePointer   = 1
+	typeString    = 2
+	typeDouble    = 3
+	typeBytes     = 4
+	typeUint16    = 5
+	typeUint32    = 6
+	typeMap       = 7
+	typeInt32     = 8
+	typeUint64    = 9
+	typeUint128   = 10
+	typeArray     = 11
+	typeContainer = 12
+	typeEndMarker = 13
+	typeBool      = 14
+	typeFloat     = 15
+)
+
+var (
+	// ErrInvalidDatabase is returned for a file that is not a MaxMind DB or is damaged
+	ErrInvalidDatabase = errors.New("invalid MaxMind database")
+
+	// ErrIPVersion is returned when looking up an IPv6 address in an IPv4 database
+	ErrIPVersion = errors.New("database does not cover IPv6 addresses")
+)
+
+// Metadata describes a MaxMind database
+type Metadata struct {
+	DatabaseType string   `json:"database_type"`
+	Description  string   `json:"description,omitempty"`
+	Languages    []string `json:"languages,omitempty"`
+	IPVersion    uint     `json:"ip_version"`
+	NodeCount    uint     `json:"node_count"`
+	RecordSize   uint     `json:"record_size"`
+	// BuildEpoch is when the database was built, in seconds since the Unix epoch
+	BuildEpoch uint64 `json:"build_epoch"`
+}
+
+// Reader looks up records in a MaxMind DB (.mmdb) file held in memory. It
+// implements the binary format described at
+// https://maxmind.github.io/MaxMind-DB/ and is safe for concurrent use.
+type Reader struct {
+	buffer   []byte
+	data     []byte
+	metadata Metadata
+	nodeSize uint
+	// ipv4Start is the node IPv4 addresses start from in an IPv6 tree
+	ipv4Start uint
+}
+
+// NewReader parses a MaxMind DB file
+func NewReader(buffer []byte) (*Reader, error) {
+	start := max(0, len(buffer)-metadataMaxSize)
+
+	index := bytes.LastIndex(buffer[start:], metadataMarker)
+	if index < 0 {
+		return nil, fmt.Errorf("%w: metadata not found", ErrInvalidDatabase)
+	}
+
+	metadataStart := start + index + len(metadataMarker)
+
+	raw, _, err := (&decoder{buffer: buffer[metadataStart:]}).decode(0, 0)
+	if err != nil {
+		return nil, fmt.Errorf("%w: metadata: %w", ErrInvalidDatabase, err)
+	}
+
+	fields, ok := raw.(map[string]any)
+	if !ok {
+		return nil, fmt.Errorf("%w: metadata is not a map", ErrInvalidDatabase)
+	}
+
+	metadata := Metadata{
+		NodeCount:  uint(toUint64(fields["node_count"])),
+		RecordSize: uint(toUint64(fields["record_size"])),
+		IPVersion:  uint(toUint64(fields["ip_version"])),
+		BuildEpoch: toUint64(fields["build_epoch"]),
+	}
+	metadata.DatabaseType, _ = fields["database_type"].(string)
+
+	if descriptions, descOk := fields["description"].(map[string]any); descOk {
+		metadata.Description, _ = descriptions["en"].(string)
+	}
+
+	if languages, langOk := fields["languages"].([]any); langOk {
+		for _, language := range languages {
+			if name, nameOk := language.(string); nameOk {
+				metadata.Languages = append(metadata.Languages, name)
+			}
+		}
+	}
+
+	switch metadata.RecordSize {
+	case 24, 28, 32:
+	default:
+		return nil, fmt.Errorf("%w: unsupported record size %d", ErrInvalidDatabase, metadata.RecordSize)
+	}
+
+	if metadata.IPVersion != 4 && metadata.IPVersion != 6 {
+		return nil, fmt.Errorf("%w: unsupported IP version %d", ErrInvalidDatabase, metadata.IPVersion)
+	}
+
+	nodeSize := metadata.RecordSize / 4
+	treeSize := metadata.NodeCount * nodeSize
+
+	dataStart := treeSize + dataSectionSeparator
+	if metadata.NodeCount == 0 || dataStart > uint(start+index) {
+		return nil, fmt.Errorf("%w: search tree is larger than the file", ErrInvalidDatabase)
+	}
+
+	reader := &Reader{
+		buffer:   buffer,
+		data:     buffer[dataStart : start+index],
+		metadata: metadata,
+		nodeSize: nodeSize,
+	}
+
+	if metadata.IPVersion == 6 {
+		// IPv4 addresses are stored under ::/96
+		node := uint(0)
+		for i := 0; i < 96 && node < metadata.NodeCount; i++ {
+			node = reader.record(node, 0)
+		}
+
+		reader.ipv4Start = node
+	}
+
+	return reader, nil
+}
+
+// Metadata returns the metadata of the database
+func (r *Reader) Metadata() Metadata {
+	return r.metadata
+}
+
+// Lookup returns the record of the network containing ip, decoded into maps,
+// slices, strings, numbers and booleans. The second result is false when the
+// database has no record for the address.
+func (r *Reader) Lookup(ip netip.Addr) (map[string]any, bool, error) {
+	ip = ip.Unmap()
+
+	node, bits := uint(0), 128
+	if ip.Is4() {
+		bits = 32
+		if r.metadata.IPVersion == 6 {
+			node = r.ipv4Start
+		}
+	} else if r.metadata.IPVersion == 4 {
+		return nil, false, ErrIPVersion
+	}
+
+	address := ip.AsSlice()
+	nodeCount := r.metadata.NodeCount
+
+	for i := 0; i < bits && node < nodeCount; i++ {
+		bit := (address[i>>3] >> (7 - uint(i&7))) & 1
+		node = r.record(node, uint(bit))
+	}
+
+	if node == nodeCount {
+		return nil, false, nil
+	}
+
+	if node < nodeCount {
+		return nil, false, fmt.Errorf("%w: search tree is deeper than the address", ErrInvalidDatabase)
+	}
+
+	offset := node - nodeCount - dataSectionSeparator
+	if offset >= uint(len(r.data)) {
+		return nil, false, fmt.Errorf("%w: record pointer past the data section", ErrInvalidDatabase)
+	}
+
+	value, _, err := (&decoder{buffer: r.data}).decode(offset, 0)
+	if err != nil {
+		return nil, false, fmt.Errorf("%w: %w", ErrInvalidDatabase, err)
+	}
+
+	record, ok := value.(map[string]any)
+	if !ok {
+		return nil, false, fmt.Errorf("%w: record is not a map", ErrInvalidDatabase)
+	}
+
+	return record, true, nil
+}
+
+// record returns the left (0) or right (1) record of a search tree node
+func (r *Reader) record(node, side uint) uint {
+	b := r.buffer[node*r.nodeSize : (node+1)*r.nodeSize]
+
+	switch r.metadata.RecordSize {
+	case 24:
+		b = b[side*3:]
+
+		return uint(b[0])<<16 | uint(b[1])<<8 | uint(b[2])
+	case 28:
+		if side == 0 {
+			return uint(b[3]&0xF0)<<20 | uint(b[0])<<16 | uint(b[1])<<8 | uint(b[2])
+		}
+
+		return uint(b[3]&0x0F)<<24 | uint(b[4])<<16 | uint(b[5])<<8 | uint(b[6])
+	default:
+		return uint(binary.BigEndian.Uint32(b[side*4:]))
+	}
+}
+
+// decoder decodes the data section format
+type decoder struct {
+	buffer []byte
+}
+
+// decode decodes the field at offset, returning it and the offset after it
+func (d *decoder) decode(offset uint, depth int) (any, uint, error) {
+	if depth > maxDecodeDepth {
+		return nil, 0, errors.New("data nested too deeply")
+	}
+
+	fieldType, size, offset, err := d.control(offset)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	if fieldType == typePointer {
+		pointer, next, pointerErr := d.pointer(size, offset)
+		if pointerErr != nil {
+			return nil, 0, pointerErr
+		}
+
+		value, _, valueErr := d.decode(pointer, depth+1)
+
+		return value, next, valueErr
+	}
+
+	return d.value(fieldType, size, offset, depth)
+}
+
+// control reads a control byte and any extended type and size bytes
+func (d *decoder) control(offset uint) (fieldType int, size, next uint, err error) {
+	if offset >= uint(len(d.buffer)) {
+		return 0, 0, 0, errors.New("unexpected end of data")
+	}
+
+	ctrl := d.buffer[offset]
+	offset++
+	fieldType = int(ctrl >> 5)
+
+	if fieldType == typeExtended {
+		if offset >= uint(len(d.buffer)) {
+			return 0, 0, 0, errors.New("unexpected end of data")
+		}
+
+		fieldType = int(d.buffer[offset]) + 7
+		offset++
+	}
+
+	size = uint(ctrl & 0x1F)
+
+	if fieldType == typePointer {
+		// Pointers keep their size bits for pointer()
+		return fieldType, uint(ctrl & 0x1F), offset, nil
+	}
+
+	if size >= 29 {
+		extra := size - 28
+		if offset+extra > uint(len(d.buffer)) {
+			return 0, 0, 0, errors.New("unexpected end of data")
+		}
+
+		var n uint
+		for _, b := range d.buffer[offset : offset+extra] {
+			n = n<<8 | uint(b)
+		}
+
+		switch size {
+		case 29:
+			size = 29 + n
+		case 30:
+			size = 285 + n
+		default:
+			size = 65821 + n
+		}
+
+		offset += extra
+	}
+
+	return fieldType, size, offset, nil
+}
+
+// pointer reads a pointer whose control byte had the size bits bits
+func (d *decoder) pointer(bits, offset uint) (pointer, next uint, err error) {
+	length := (bits>>3)&0x3 + 1
+	if offset+length > uint(len(d.buffer)) {
+		return 0, 0, errors.New("unexpected end of data")
+	}
+
+	var n uint
+	for _, b := range d.buffer[offset : offset+length] {
+		n = n<<8 | uint(b)
+	}
+
+	switch length {
+	case 1:
+		pointer = (bits&0x7)<<8 | n
+	case 2:
+		pointer = 2048 + ((bits&0x7)<<16 | n)
+	case 3:
+		pointer = 526336 + ((bits&0x7)<<24 | n)
+	default:
+		pointer = n
+	}
+
+	return pointer, offset + length, nil
+}
+
+// value decodes a field of a known type and size
+func (d *decoder) value(fieldType int, size, offset uint, depth int) (any, uint, error) {
+	switch fieldType {
+	case typeMap:
+		return d.decodeMap(size, offset, depth)
+	case typeArray:
+		return d.decodeArray(size, offset, depth)
+	case typeBool:
+		return size != 0, offset, nil
+	case typeContainer, typeEndMarker:
+		return nil, offset, nil
+	}
+
+	if offset+size > uint(len(d.buffer)) {
+		return nil, 0, errors.New("unexpected end of data")
+	}
+
+	b, next := d.buffer[offset:offset+size], offset+size
+
+	switch fieldType {
+	case typeString:
+		return string(b), next, nil
+	case typeBytes:
+		return bytes.Clone(b), next, nil
+	case typeDouble:
+		if size != 8 {
+			return nil, 0, errors.New("double is not 8 bytes")
+		}
+
+		return math.Float64frombits(binary.BigEndian.Uint64(b)), next, nil
+	case typeFloat:
+		if size != 4 {
+			return nil, 0, errors.New("float is not 4 bytes")
+		}
+
+		return float64(math.Float32frombits(binary.BigEndian.Uint32(b))), next, nil
+	case typeUint16, typeUint32, typeUint64:
+		if size > 8 {
+			return nil, 0, errors.New("unsigned integer is too long")
+		}
+
+		var n uint64
+		for _, c := range b {
+			n = n<<8 | uint64(c)
+		}
+
+		return n, next, nil
+	case typeInt32:
+		if size > 4 {
+			return nil, 0, errors.New("int32 is too long")
+		}
+
+		var n uint32
+		for _, c := range b {
+			n = n<<8 | uint32(c)
+		}
+
+		return int64(int32(n)), next, nil
+	case typeUint128:
+		return new(big.Int).SetBytes(b), next, nil
+	default:
+		return nil, 0, fmt.Errorf("unknown field type %d", fieldType)
+	}
+}
+
+// decodeMap decodes size key/value pairs
+func (d *decoder) decodeMap(size, offset uint, depth int) (any, uint, error) {
+	values := make(map[string]any, min(size, 64))
+
+	for range size {
+		key, next, err := d.decode(offset, depth+1)
+		if err != nil {
+			return nil, 0, err
+		}
+
+		name, ok := key.(string)
+		if !ok {
+			return nil, 0, errors.New("map key is not a string")
+		}
+
+		value, after, err := d.decode(next, depth+1)
+		if err != nil {
+			return nil, 0, err
+		}
+
+		values[name] = value
+		offset = after
+	}
+
+	return values, offset, nil
+}
+
+// decodeArray decodes size values
+func (d *decoder) decodeArray(size, offset uint, depth int) (any, uint, error) {
+	values := make([]any, 0, min(size, 64))
+
+	for range size {
+		value, next, err := d.decode(offset, depth+1)
+		if err != nil {
+			return nil, 0, err
+		}
+
+		values = append(values, value)
+		offset = next
+	}
+
+	return values, offset, nil
+}
+
+// toUint64 converts a decoded unsigned integer, or returns 0
+func toUint64(value any) uint64 {
+	n, _ := value.(uint64)
+
+	return n
+}
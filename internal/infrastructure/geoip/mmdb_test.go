@@ -0,0 +1,320 @@
+package geoip_test
+
+import (
+	"bytes"
+	"encoding/binary"
+	"math"
+	"net/netip"
+	"sort"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/goformx/goforms/internal/infrastructure/geoip"
+)
+
+// network is a network of a test database and its record
+type network struct {
+	prefix string
+	record map[string]any
+}
+
+// treeNode is a search tree node of a test database; each side holds a
+// *treeNode, the index of a record, or nil
+type treeNode struct {
+	sides [2]any
+	id    int
+}
+
+// buildDatabase writes a MaxMind DB file holding networks
+func buildDatabase(t *testing.T, ipVersion, recordSize int, networks []network) []byte {
+	t.Helper()
+
+	root := &treeNode{}
+
+	for i, n := range networks {
+		prefix := netip.MustParsePrefix(n.prefix)
+		address, bits := prefix.Addr().AsSlice(), prefix.Bits()
+
+		if ipVersion == 6 && prefix.Addr().Is4() {
+			address, bits = append(make([]byte, 12), address...), bits+96
+		}
+
+		node := root
+		for depth := range bits {
+			bit := (address[depth/8] >> (7 - depth%8)) & 1
+			if depth == bits-1 {
+				node.sides[bit] = i
+
+				break
+			}
+
+			child, ok := node.sides[bit].(*treeNode)
+			if !ok {
+				child = &treeNode{}
+				node.sides[bit] = child
+			}
+
+			node = child
+		}
+	}
+
+	// Number the nodes breadth first
+	nodes := []*treeNode{root}
+	for i := 0; i < len(nodes); i++ {
+		nodes[i].id = i
+
+		for _, side := range nodes[i].sides {
+			if child, ok := side.(*treeNode); ok {
+				nodes = append(nodes, child)
+			}
+		}
+	}
+
+	var data bytes.Buffer
+
+	offsets := make([]int, len(networks))
+	for i, n := range networks {
+		offsets[i] = data.Len()
+		encode(&data, n.record)
+	}
+
+	nodeCount := len(nodes)
+	records := func(node *treeNode) [2]uint32 {
+		var values [2]uint32
+
+		for side, value := range node.sides {
+			switch v := value.(type) {
+			case *treeNode:
+				values[side] = uint32(v.id)
+			case int:
+				values[side] = uint32(nodeCount + 16 + offsets[v])
+			default:
+				values[side] = uint32(nodeCount)
+			}
+		}
+
+		return values
+	}
+
+	var file bytes.Buffer
+
+	for _, node := range nodes {
+		r := records(node)
+
+		switch recordSize {
+		case 24:
+			file.Write([]byte{byte(r[0] >> 16), byte(r[0] >> 8), byte(r[0]), byte(r[1] >> 16), byte(r[1] >> 8), byte(r[1])})
+		case 28:
+			file.Write([]byte{
+				byte(r[0] >> 16), byte(r[0] >> 8), byte(r[0]),
+				byte(r[0]>>24)<<4 | byte(r[1]>>24),
+				byte(r[1] >> 16), byte(r[1] >> 8), byte(r[1]),
+			})
+		default:
+			_ = binary.Write(&file, binary.BigEndian, r)
+		}
+	}
+
+	file.Write(make([]byte, 16))
+	file.Write(data.Bytes())
+	file.WriteString("\xAB\xCD\xEFMaxMind.com")
+	encode(&file, map[string]any{
+		"node_count":                  uint32(nodeCount),
+		"record_size":                 uint16(recordSize),
+		"ip_version":                  uint16(ipVersion),
+		"database_type":               "GeoLite2-City",
+		"languages":                   []any{"en"},
+		"build_epoch":                 uint64(1767225600),
+		"binary_format_major_version": uint16(2),
+		"binary_format_minor_version": uint16(0),
+		"description":                 map[string]any{"en": "Test database"},
+	})
+
+	return file.Bytes()
+}
+
+// encode writes a value in the data section format
+func encode(buf *bytes.Buffer, value any) {
+	switch v := value.(type) {
+	case string:
+		control(buf, 2, len(v))
+		buf.WriteString(v)
+	case float64:
+		control(buf, 3, 8)
+		_ = binary.Write(buf, binary.BigEndian, math.Float64bits(v))
+	case uint16:
+		control(buf, 5, 2)
+		_ = binary.Write(buf, binary.BigEndian, v)
+	case uint32:
+		control(buf, 6, 4)
+		_ = binary.Write(buf, binary.BigEndian, v)
+	case uint64:
+		control(buf, 9, 8)
+		_ = binary.Write(buf, binary.BigEndian, v)
+	case bool:
+		size := 0
+		if v {
+			size = 1
+		}
+
+		control(buf, 14, size)
+	case []any:
+		control(buf, 11, len(v))
+
+		for _, item := range v {
+			encode(buf, item)
+		}
+	case map[string]any:
+		control(buf, 7, len(v))
+
+		keys := make([]string, 0, len(v))
+		for key := range v {
+			keys = append(keys, key)
+		}
+
+		sort.Strings(keys)
+
+		for _, key := range keys {
+			encode(buf, key)
+			encode(buf, v[key])
+		}
+	}
+}
+
+// control writes the control byte of a field
+func control(buf *bytes.Buffer, fieldType, size int) {
+	sizeBits, extra := size, []byte(nil)
+
+	switch {
+	case size >= 65821:
+		sizeBits, extra = 31, []byte{byte((size - 65821) >> 16), byte((size - 65821) >> 8), byte(size - 65821)}
+	case size >= 285:
+		sizeBits, extra = 30, []byte{byte((size - 285) >> 8), byte(size - 285)}
+	case size >= 29:
+		sizeBits, extra = 29, []byte{byte(size - 29)}
+	}
+
+	if fieldType > 7 {
+		buf.WriteByte(byte(sizeBits))
+		buf.WriteByte(byte(fieldType - 7))
+	} else {
+		buf.WriteByte(byte(fieldType<<5 | sizeBits))
+	}
+
+	buf.Write(extra)
+}
+
+// cityRecord is the record of a City database network
+func cityRecord(country, countryName, region, regionName string) map[string]any {
+	return map[string]any{
+		"country": map[string]any{"iso_code": country, "names": map[string]any{"en": countryName}},
+		"subdivisions": []any{
+			map[string]any{"iso_code": region, "names": map[string]any{"en": regionName}},
+		},
+		"location": map[string]any{"latitude": 48.137, "accuracy_radius": uint16(20)},
+	}
+}
+
+func TestReader_Lookup(t *testing.T) {
+	networks := []network{
+		{prefix: "81.2.69.0/24", record: cityRecord("GB", "United Kingdom", "ENG", "England")},
+		{prefix: "89.160.20.112/28", record: cityRecord("SE", "Sweden", "E", "Östergötland County")},
+		{prefix: "2001:db8::/32", record: cityRecord("DE", "Germany", "BY", "Bavaria")},
+		{prefix: "203.0.113.0/24", record: map[string]any{
+			"registered_country": map[string]any{"iso_code": "AU", "names": map[string]any{"en": "Australia"}},
+			"is_anycast":         true,
+			"long_name":          string(bytes.Repeat([]byte("x"), 300)),
+		}},
+	}
+
+	for _, recordSize := range []int{24, 28, 32} {
+		reader, err := geoip.NewReader(buildDatabase(t, 6, recordSize, networks))
+		require.NoError(t, err, "record size %d", recordSize)
+
+		assert.Equal(t, "GeoLite2-City", reader.Metadata().DatabaseType)
+		assert.Equal(t, uint(recordSize), reader.Metadata().RecordSize)
+		assert.Equal(t, []string{"en"}, reader.Metadata().Languages)
+
+		record, found, err := reader.Lookup(netip.MustParseAddr("89.160.20.120"))
+		require.NoError(t, err)
+		require.True(t, found)
+
+		country, _ := record["country"].(map[string]any)
+		assert.Equal(t, "SE", country["iso_code"])
+
+		location, _ := record["location"].(map[string]any)
+		assert.InDelta(t, 48.137, location["latitude"], 0.0001)
+		assert.Equal(t, uint64(20), location["accuracy_radius"])
+
+		record, found, err = reader.Lookup(netip.MustParseAddr("2001:db8:1::1"))
+		require.NoError(t, err)
+		require.True(t, found)
+		assert.Contains(t, record, "subdivisions")
+
+		record, found, err = reader.Lookup(netip.MustParseAddr("203.0.113.9"))
+		require.NoError(t, err)
+		require.True(t, found)
+		assert.Equal(t, true, record["is_anycast"])
+		assert.Len(t, record["long_name"], 300)
+
+		_, found, err = reader.Lookup(netip.MustParseAddr("10.0.0.1"))
+		require.NoError(t, err)
+		assert.False(t, found)
+	}
+}
+
+func TestReader_IPv4Database(t *testing.T) {
+	reader, err := geoip.NewReader(buildDatabase(t, 4, 24, []network{
+		{prefix: "81.2.69.0/24", record: cityRecord("GB", "United Kingdom", "ENG", "England")},
+	}))
+	require.NoError(t, err)
+
+	_, found, err := reader.Lookup(netip.MustParseAddr("::ffff:81.2.69.160"))
+	require.NoError(t, err)
+	assert.True(t, found, "IPv4-mapped addresses are looked up as IPv4")
+
+	_, _, err = reader.Lookup(netip.MustParseAddr("2001:db8::1"))
+	require.ErrorIs(t, err, geoip.ErrIPVersion)
+}
+
+func TestReader_Pointers(t *testing.T) {
+	// A record whose values point at a string and a map written before it
+	var data bytes.Buffer
+	encode(&data, "Germany")
+	mapOffset := data.Len()
+	encode(&data, map[string]any{"iso_code": "DE"})
+	recordOffset := data.Len()
+	control(&data, 7, 2)
+	encode(&data, "a")
+	data.Write([]byte{0x20, 0x00}) // pointer to "Germany"
+	encode(&data, "b")
+	data.Write([]byte{0x20, byte(mapOffset)}) // pointer to the iso_code map
+
+	var file bytes.Buffer
+	// One node: every address goes to the record
+	pointer := uint32(1 + 16 + recordOffset)
+	file.Write([]byte{byte(pointer >> 16), byte(pointer >> 8), byte(pointer), byte(pointer >> 16), byte(pointer >> 8), byte(pointer)})
+	file.Write(make([]byte, 16))
+	file.Write(data.Bytes())
+	file.WriteString("\xAB\xCD\xEFMaxMind.com")
+	encode(&file, map[string]any{"node_count": uint32(1), "record_size": uint16(24), "ip_version": uint16(4)})
+
+	reader, err := geoip.NewReader(file.Bytes())
+	require.NoError(t, err)
+
+	record, found, err := reader.Lookup(netip.MustParseAddr("192.0.2.1"))
+	require.NoError(t, err)
+	require.True(t, found)
+	assert.Equal(t, map[string]any{"a": "Germany", "b": map[string]any{"iso_code": "DE"}}, record)
+}
+
+func TestNewReader_Invalid(t *testing.T) {
+	_, err := geoip.NewReader([]byte("not a database"))
+	require.ErrorIs(t, err, geoip.ErrInvalidDatabase)
+
+	valid := buildDatabase(t, 6, 24, []network{{prefix: "81.2.69.0/24", record: map[string]any{}}})
+	_, err = geoip.NewReader(valid[len(valid)-200:])
+	require.ErrorIs(t, err, geoip.ErrInvalidDatabase, "a truncated search tree is refused")
+}
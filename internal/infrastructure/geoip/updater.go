@@ -0,0 +1,187 @@
+package geoip
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/goformx/goforms/internal/infrastructure/config"
+	"github.com/goformx/goforms/internal/infrastructure/httpclient"
+	"github.com/goformx/goforms/internal/infrastructure/logging"
+)
+
+const (
+	// downloadTimeout bounds each database download
+	downloadTimeout = 5 * time.Minute
+	// MaxDatabaseSize bounds the size of a downloaded database
+	MaxDatabaseSize = 512 << 20
+)
+
+// ErrDatabaseNotInArchive is returned when a download has no .mmdb file
+var ErrDatabaseNotInArchive = errors.New("downloaded archive has no .mmdb file")
+
+// Updater downloads new releases of the database and loads them into a Locator
+type Updater struct {
+	config  config.GeoIPUpdateConfig
+	path    string
+	locator *Locator
+	client  *http.Client
+	logger  logging.Logger
+}
+
+// NewUpdater creates the database updater. It returns nil when locator is
+// nil or geoip.update.enabled is unset. clients may be nil.
+func NewUpdater(cfg config.GeoIPConfig, locator *Locator, clients *httpclient.Factory, logger logging.Logger) *Updater {
+	if locator == nil || !cfg.Update.Enabled {
+		return nil
+	}
+
+	return &Updater{
+		config:  cfg.Update,
+		path:    cfg.DatabasePath,
+		locator: locator,
+		client:  clients.Client(config.ResilienceTargetGeoIP, cfg.Update.EditionID, downloadTimeout),
+		logger:  logger,
+	}
+}
+
+// Update downloads the database when MaxMind has a release newer than the
+// file on disk, replaces the file and loads it. The file is only replaced
+// once the download has been read as a valid database.
+func (u *Updater) Update(ctx context.Context) error {
+	if u == nil {
+		return nil
+	}
+
+	endpoint := strings.TrimSuffix(u.config.URL, "/") + "/" + url.PathEscape(u.config.EditionID) + "/download?suffix=tar.gz"
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, http.NoBody)
+	if err != nil {
+		return fmt.Errorf("create geoip download request: %w", err)
+	}
+
+	req.SetBasicAuth(u.config.AccountID, u.config.LicenseKey)
+
+	if info, statErr := os.Stat(u.path); statErr == nil {
+		req.Header.Set("If-Modified-Since", info.ModTime().UTC().Format(http.TimeFormat))
+	}
+
+	resp, err := u.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("download geoip database: %w", err)
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusNotModified:
+		u.logger.Debug("geoip database is up to date", "edition", u.config.EditionID)
+
+		return nil
+	case http.StatusOK:
+	default:
+		return fmt.Errorf("download geoip database: %s", resp.Status)
+	}
+
+	buffer, err := extractDatabase(resp.Body)
+	if err != nil {
+		return err
+	}
+
+	reader, err := NewReader(buffer)
+	if err != nil {
+		return fmt.Errorf("open downloaded geoip database: %w", err)
+	}
+
+	modified, _ := http.ParseTime(resp.Header.Get("Last-Modified"))
+	if err = u.write(buffer, modified); err != nil {
+		return err
+	}
+
+	u.locator.use(reader)
+
+	return nil
+}
+
+// write replaces the database file, dated modified when it is known
+func (u *Updater) write(buffer []byte, modified time.Time) error {
+	dir := filepath.Dir(u.path)
+	if err := os.MkdirAll(dir, 0o750); err != nil {
+		return fmt.Errorf("create geoip database directory: %w", err)
+	}
+
+	file, err := os.CreateTemp(dir, ".geoip-*.mmdb")
+	if err != nil {
+		return fmt.Errorf("create geoip database file: %w", err)
+	}
+
+	defer func() { _ = os.Remove(file.Name()) }()
+
+	if _, err = file.Write(buffer); err != nil {
+		_ = file.Close()
+
+		return fmt.Errorf("write geoip database: %w", err)
+	}
+
+	if err = file.Close(); err != nil {
+		return fmt.Errorf("write geoip database: %w", err)
+	}
+
+	if !modified.IsZero() {
+		if err = os.Chtimes(file.Name(), modified, modified); err != nil {
+			return fmt.Errorf("date geoip database: %w", err)
+		}
+	}
+
+	if err = os.Rename(file.Name(), u.path); err != nil {
+		return fmt.Errorf("replace geoip database: %w", err)
+	}
+
+	return nil
+}
+
+// extractDatabase returns the .mmdb file of a gzipped tar archive
+func extractDatabase(body io.Reader) ([]byte, error) {
+	gz, err := gzip.NewReader(body)
+	if err != nil {
+		return nil, fmt.Errorf("read geoip archive: %w", err)
+	}
+	defer gz.Close()
+
+	archive := tar.NewReader(gz)
+
+	for {
+		header, nextErr := archive.Next()
+		if errors.Is(nextErr, io.EOF) {
+			return nil, ErrDatabaseNotInArchive
+		}
+
+		if nextErr != nil {
+			return nil, fmt.Errorf("read geoip archive: %w", nextErr)
+		}
+
+		if header.Typeflag != tar.TypeReg || !strings.HasSuffix(header.Name, ".mmdb") {
+			continue
+		}
+
+		if header.Size > MaxDatabaseSize {
+			return nil, fmt.Errorf("geoip database is larger than %d MB", MaxDatabaseSize>>20)
+		}
+
+		var buffer bytes.Buffer
+		if _, err = io.Copy(&buffer, io.LimitReader(archive, MaxDatabaseSize)); err != nil {
+			return nil, fmt.Errorf("read geoip database: %w", err)
+		}
+
+		return buffer.Bytes(), nil
+	}
+}
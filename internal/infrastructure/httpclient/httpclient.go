@@ -0,0 +1,247 @@
+// Package httpclient builds the HTTP clients of outbound integrations from one
+// configuration: the egress proxy, extra trusted CAs, certificate pins, the
+// user agent and per-integration timeouts. Clients share one connection pool,
+// and calls go through the integration target's circuit in resilience.
+package httpclient
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+
+	"golang.org/x/net/http/httpproxy"
+
+	"github.com/goformx/goforms/internal/infrastructure/config"
+	"github.com/goformx/goforms/internal/infrastructure/resilience"
+	"github.com/goformx/goforms/internal/infrastructure/timeout"
+	"github.com/goformx/goforms/internal/infrastructure/version"
+)
+
+// Factory creates outbound HTTP clients. A nil factory creates plain clients
+// using http.DefaultTransport, with no circuits.
+type Factory struct {
+	config    config.HTTPClientConfig
+	transport http.RoundTripper
+	outbound  *resilience.Registry
+}
+
+// New creates the factory of outbound HTTP clients. outbound may be nil.
+func New(cfg config.HTTPClientConfig, outbound *resilience.Registry) (*Factory, error) {
+	base, ok := http.DefaultTransport.(*http.Transport)
+	if !ok {
+		return nil, fmt.Errorf("default transport is %T", http.DefaultTransport)
+	}
+
+	transport := base.Clone()
+
+	proxy, err := proxyFunc(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	transport.Proxy = proxy
+
+	tlsConfig, err := tlsConfig(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	transport.TLSClientConfig = tlsConfig
+
+	userAgent := cfg.UserAgent
+	if userAgent == "" {
+		userAgent = "GoForms/" + version.GetInfo().Version
+	}
+
+	return &Factory{
+		config:    cfg,
+		transport: &userAgentTransport{base: transport, userAgent: userAgent},
+		outbound:  outbound,
+	}, nil
+}
+
+// proxyFunc returns the proxy selection of the configured egress proxy, or of
+// the environment when none is configured
+func proxyFunc(cfg config.HTTPClientConfig) (func(*http.Request) (*url.URL, error), error) {
+	if cfg.Proxy == "" {
+		return http.ProxyFromEnvironment, nil
+	}
+
+	if _, err := url.Parse(cfg.Proxy); err != nil {
+		return nil, fmt.Errorf("parse http client proxy: %w", err)
+	}
+
+	selectProxy := (&httpproxy.Config{
+		HTTPProxy:  cfg.Proxy,
+		HTTPSProxy: cfg.Proxy,
+		NoProxy:    strings.Join(cfg.NoProxy, ","),
+	}).ProxyFunc()
+
+	return func(req *http.Request) (*url.URL, error) {
+		return selectProxy(req.URL)
+	}, nil
+}
+
+// tlsConfig returns the TLS settings trusting the CA bundle and checking the pins
+func tlsConfig(cfg config.HTTPClientConfig) (*tls.Config, error) {
+	tlsConfig := &tls.Config{MinVersion: tls.VersionTLS12}
+
+	if cfg.CABundle != "" {
+		pem, err := os.ReadFile(cfg.CABundle)
+		if err != nil {
+			return nil, fmt.Errorf("read http client CA bundle: %w", err)
+		}
+
+		roots, err := x509.SystemCertPool()
+		if err != nil {
+			roots = x509.NewCertPool()
+		}
+
+		if !roots.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("http client CA bundle %s has no certificates", cfg.CABundle)
+		}
+
+		tlsConfig.RootCAs = roots
+	}
+
+	pins, err := newPins(cfg.Pins)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(pins) > 0 {
+		tlsConfig.VerifyConnection = pins.verify
+	}
+
+	return tlsConfig, nil
+}
+
+// Timeout returns the request timeout of an integration: http_client.timeouts.<integration>,
+// or fallback
+func (f *Factory) Timeout(integration string, fallback time.Duration) time.Duration {
+	if f == nil {
+		return fallback
+	}
+
+	if timeout, ok := f.config.Timeouts[integration]; ok && timeout > 0 {
+		return timeout
+	}
+
+	return fallback
+}
+
+// Client returns a client for the target called name of an integration. Its
+// timeout is http_client.timeouts.<integration>, or fallback; zero leaves
+// calls bounded by their context. Calls made for a request also end within
+// the request's outbound budget (timeouts.outbound), retries included.
+func (f *Factory) Client(integration, name string, fallback time.Duration) *http.Client {
+	return &http.Client{
+		Timeout:   f.Timeout(integration, fallback),
+		Transport: f.Transport(integration, name),
+	}
+}
+
+// Transport returns the round tripper of the target called name of an integration
+func (f *Factory) Transport(integration, name string) http.RoundTripper {
+	if f == nil {
+		return &budgetTransport{base: http.DefaultTransport}
+	}
+
+	return &budgetTransport{base: f.outbound.Transport(integration, name, f.transport)}
+}
+
+// BaseClient returns a client with the proxy, TLS and user agent settings but
+// no circuit, for callers that guard their calls through Target
+func (f *Factory) BaseClient(integration string, fallback time.Duration) *http.Client {
+	if f == nil {
+		return &http.Client{Timeout: fallback, Transport: &budgetTransport{base: http.DefaultTransport}}
+	}
+
+	return &http.Client{Timeout: f.Timeout(integration, fallback), Transport: &budgetTransport{base: f.transport}}
+}
+
+// Target returns the circuit of the target called name of an integration, or
+// nil when outbound calls are not guarded
+func (f *Factory) Target(integration, name string) *resilience.Target {
+	if f == nil {
+		return nil
+	}
+
+	return f.outbound.Target(integration, name)
+}
+
+// TargetWithPolicy returns the target called name of an integration with base
+// as its default policy, or nil when outbound calls are not guarded
+func (f *Factory) TargetWithPolicy(integration, name string, base resilience.Policy) *resilience.Target {
+	if f == nil {
+		return nil
+	}
+
+	return f.outbound.TargetWithPolicy(integration, name, base)
+}
+
+// budgetTransport bounds the calls made for a request by the request's
+// outbound budget; other calls pass through unchanged
+type budgetTransport struct {
+	base http.RoundTripper
+}
+
+// RoundTrip implements http.RoundTripper
+func (t *budgetTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if _, ok := timeout.FromContext(req.Context()); !ok {
+		return t.base.RoundTrip(req)
+	}
+
+	ctx, cancel := timeout.Outbound(req.Context())
+
+	resp, err := t.base.RoundTrip(req.WithContext(ctx))
+	if err != nil {
+		cancel()
+
+		return nil, err
+	}
+
+	// The budget lasts until the caller has read the body
+	resp.Body = &cancelOnClose{ReadCloser: resp.Body, cancel: cancel}
+
+	return resp, nil
+}
+
+// cancelOnClose releases a call's budget context when its body is closed
+type cancelOnClose struct {
+	io.ReadCloser
+	cancel context.CancelFunc
+}
+
+// Close implements io.Closer
+func (b *cancelOnClose) Close() error {
+	err := b.ReadCloser.Close()
+	b.cancel()
+
+	return err
+}
+
+// userAgentTransport sets the User-Agent of requests that have none
+type userAgentTransport struct {
+	base      http.RoundTripper
+	userAgent string
+}
+
+// RoundTrip implements http.RoundTripper
+func (t *userAgentTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if req.Header.Get("User-Agent") != "" {
+		return t.base.RoundTrip(req)
+	}
+
+	req = req.Clone(req.Context())
+	req.Header.Set("User-Agent", t.userAgent)
+
+	return t.base.RoundTrip(req)
+}
@@ -0,0 +1,135 @@
+package httpclient_test
+
+import (
+	"context"
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/goformx/goforms/internal/infrastructure/config"
+	"github.com/goformx/goforms/internal/infrastructure/httpclient"
+	"github.com/goformx/goforms/internal/infrastructure/timeout"
+)
+
+// newTLSServer starts a TLS server and writes its certificate to a CA bundle file
+func newTLSServer(t *testing.T, handler http.HandlerFunc) (server *httptest.Server, bundle string) {
+	t.Helper()
+
+	server = httptest.NewTLSServer(handler)
+	t.Cleanup(server.Close)
+
+	bundle = filepath.Join(t.TempDir(), "ca.pem")
+	certPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw})
+	require.NoError(t, os.WriteFile(bundle, certPEM, 0o600))
+
+	return server, bundle
+}
+
+func TestFactoryTrustsCABundleAndSetsUserAgent(t *testing.T) {
+	server, bundle := newTLSServer(t, func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(r.UserAgent()))
+	})
+
+	// Without the bundle the test certificate is not trusted
+	factory, err := httpclient.New(config.HTTPClientConfig{}, nil)
+	require.NoError(t, err)
+
+	_, err = factory.Client(config.ResilienceTargetHooks, "crm", time.Second).Get(server.URL)
+	require.Error(t, err)
+
+	factory, err = httpclient.New(config.HTTPClientConfig{CABundle: bundle, UserAgent: "goforms-test"}, nil)
+	require.NoError(t, err)
+
+	resp, err := factory.Client(config.ResilienceTargetHooks, "crm", time.Second).Get(server.URL)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	body := make([]byte, 64)
+	n, _ := resp.Body.Read(body)
+	assert.Equal(t, "goforms-test", string(body[:n]))
+}
+
+func TestFactoryProxy(t *testing.T) {
+	var proxied []string
+
+	proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		proxied = append(proxied, r.URL.String())
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer proxy.Close()
+
+	factory, err := httpclient.New(config.HTTPClientConfig{
+		Proxy:   proxy.URL,
+		NoProxy: []string{"direct.invalid"},
+	}, nil)
+	require.NoError(t, err)
+
+	client := factory.Client(config.ResilienceTargetHooks, "crm", time.Second)
+
+	resp, err := client.Get("http://hooks.invalid/notify")
+	require.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusNoContent, resp.StatusCode)
+
+	_, err = client.Get("http://direct.invalid/notify")
+	require.Error(t, err)
+
+	assert.Equal(t, []string{"http://hooks.invalid/notify"}, proxied)
+}
+
+func TestFactoryTimeouts(t *testing.T) {
+	factory, err := httpclient.New(config.HTTPClientConfig{
+		Timeouts: map[string]time.Duration{config.ResilienceTargetStripe: 3 * time.Second},
+	}, nil)
+	require.NoError(t, err)
+
+	assert.Equal(t, 3*time.Second, factory.Client(config.ResilienceTargetStripe, "api", 10*time.Second).Timeout)
+	assert.Equal(t, 10*time.Second, factory.Client(config.ResilienceTargetEmail, "ses", 10*time.Second).Timeout)
+
+	var nilFactory *httpclient.Factory
+	assert.Equal(t, 5*time.Second, nilFactory.BaseClient(config.ResilienceTargetEmail, 5*time.Second).Timeout)
+	assert.Nil(t, nilFactory.Target(config.ResilienceTargetEmail, "ses"))
+}
+
+func TestFactoryRejectsBadSettings(t *testing.T) {
+	_, err := httpclient.New(config.HTTPClientConfig{CABundle: filepath.Join(t.TempDir(), "missing.pem")}, nil)
+	require.Error(t, err)
+
+	_, err = httpclient.New(config.HTTPClientConfig{
+		Pins: []config.TLSPinConfig{{Host: "api.stripe.com", SHA256: []string{"not-a-digest"}}},
+	}, nil)
+	require.Error(t, err)
+}
+
+func TestClientCallsEndWithinTheRequestBudget(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-r.Context().Done():
+		case <-time.After(5 * time.Second):
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	client := (*httpclient.Factory)(nil).Client("webhooks", "test", 0)
+	ctx := timeout.WithBudgets(context.Background(), timeout.Budgets{Outbound: 50 * time.Millisecond})
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, server.URL, http.NoBody)
+	require.NoError(t, err)
+
+	start := time.Now()
+	resp, err := client.Do(req)
+
+	if resp != nil {
+		_ = resp.Body.Close()
+	}
+
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.Less(t, time.Since(start), 2*time.Second)
+}
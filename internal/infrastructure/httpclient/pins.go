@@ -0,0 +1,72 @@
+package httpclient
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"crypto/tls"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/goformx/goforms/internal/infrastructure/config"
+)
+
+// ErrPinMismatch is returned for a connection to a pinned host whose chain has no pinned key
+var ErrPinMismatch = errors.New("certificate chain does not match the pinned public keys")
+
+// pins maps lower-case hosts to their pinned SubjectPublicKeyInfo digests
+type pins map[string][][]byte
+
+// newPins decodes the configured pins
+func newPins(configured []config.TLSPinConfig) (pins, error) {
+	decoded := make(pins, len(configured))
+
+	for _, pin := range configured {
+		host := strings.ToLower(pin.Host)
+
+		for _, digest := range pin.SHA256 {
+			sum, err := base64.StdEncoding.DecodeString(strings.TrimPrefix(digest, "sha256/"))
+			if err != nil || len(sum) != sha256.Size {
+				return nil, fmt.Errorf("http client pin for %s is not a base64 SHA-256 digest", pin.Host)
+			}
+
+			decoded[host] = append(decoded[host], sum)
+		}
+	}
+
+	return decoded, nil
+}
+
+// verify runs after the chain is verified; for a pinned host it requires a
+// certificate of the verified chain to carry a pinned key
+func (p pins) verify(state tls.ConnectionState) error {
+	host := strings.ToLower(state.ServerName)
+
+	expected, ok := p[host]
+	if !ok {
+		return nil
+	}
+
+	for _, chain := range state.VerifiedChains {
+		for _, cert := range chain {
+			sum := sha256.Sum256(cert.RawSubjectPublicKeyInfo)
+
+			for _, pin := range expected {
+				if bytes.Equal(sum[:], pin) {
+					return nil
+				}
+			}
+		}
+	}
+
+	return fmt.Errorf("%s: %w", host, ErrPinMismatch)
+}
+
+// PublicKeyPin returns the pin of a certificate's public key, in the format
+// http_client.pins takes
+func PublicKeyPin(rawSubjectPublicKeyInfo []byte) string {
+	sum := sha256.Sum256(rawSubjectPublicKeyInfo)
+
+	return "sha256/" + base64.StdEncoding.EncodeToString(sum[:])
+}
@@ -0,0 +1,39 @@
+package httpclient
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/goformx/goforms/internal/infrastructure/config"
+)
+
+func TestPinsVerify(t *testing.T) {
+	server := httptest.NewTLSServer(nil)
+	defer server.Close()
+
+	cert := server.Certificate()
+	pin := PublicKeyPin(cert.RawSubjectPublicKeyInfo)
+	other := PublicKeyPin([]byte("another key"))
+
+	state := func(host string) tls.ConnectionState {
+		return tls.ConnectionState{ServerName: host, VerifiedChains: [][]*x509.Certificate{{cert}}}
+	}
+
+	pinned, err := newPins([]config.TLSPinConfig{{Host: "API.example.com", SHA256: []string{other, pin}}})
+	require.NoError(t, err)
+	require.NoError(t, pinned.verify(state("api.example.com")))
+
+	pinned, err = newPins([]config.TLSPinConfig{{Host: "api.example.com", SHA256: []string{other}}})
+	require.NoError(t, err)
+	require.ErrorIs(t, pinned.verify(state("api.example.com")), ErrPinMismatch)
+
+	// Hosts without pins only need a verified chain
+	require.NoError(t, pinned.verify(state("hooks.example.com")))
+
+	_, err = newPins([]config.TLSPinConfig{{Host: "api.example.com", SHA256: []string{"not-a-digest"}}})
+	require.Error(t, err)
+}
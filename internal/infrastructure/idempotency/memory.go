@@ -0,0 +1,87 @@
+package idempotency
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// memorySweepInterval is how often expired records are dropped
+const memorySweepInterval = time.Minute
+
+// memoryEntry is a record with its expiry
+type memoryEntry struct {
+	record    Record
+	expiresAt time.Time
+}
+
+// MemoryStore keeps idempotency records in process memory. Records are lost on
+// restart and are not shared between instances.
+type MemoryStore struct {
+	mu        sync.Mutex
+	entries   map[string]memoryEntry
+	lastSweep time.Time
+	now       func() time.Time
+}
+
+// NewMemoryStore creates a new in-memory store
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{
+		entries: make(map[string]memoryEntry),
+		now:     time.Now,
+	}
+}
+
+// Reserve claims key unless an unexpired record already exists
+func (s *MemoryStore) Reserve(_ context.Context, key string, record *Record, ttl time.Duration) (*Record, bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.now()
+	s.sweep(now)
+
+	if entry, ok := s.entries[key]; ok && now.Before(entry.expiresAt) {
+		existing := entry.record
+
+		return &existing, false, nil
+	}
+
+	s.entries[key] = memoryEntry{record: *record, expiresAt: now.Add(ttl)}
+
+	return nil, true, nil
+}
+
+// Save stores the completed record for key
+func (s *MemoryStore) Save(_ context.Context, key string, record *Record, ttl time.Duration) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.entries[key] = memoryEntry{record: *record, expiresAt: s.now().Add(ttl)}
+
+	return nil
+}
+
+// Release forgets key
+func (s *MemoryStore) Release(_ context.Context, key string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.entries, key)
+
+	return nil
+}
+
+// sweep periodically drops expired records; callers must hold the lock
+func (s *MemoryStore) sweep(now time.Time) {
+	if now.Sub(s.lastSweep) < memorySweepInterval {
+		return
+	}
+
+	s.lastSweep = now
+
+	for key, entry := range s.entries {
+		if !now.Before(entry.expiresAt) {
+			delete(s.entries, key)
+		}
+	}
+}
@@ -0,0 +1,83 @@
+package idempotency
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/goformx/goforms/internal/infrastructure/redis"
+)
+
+// redisKeyPrefix namespaces idempotency keys
+const redisKeyPrefix = "goforms:idempotency:"
+
+// RedisStore keeps idempotency records in Redis using SET NX so that
+// concurrent instances agree on which request owns a key
+type RedisStore struct {
+	client *redis.Client
+}
+
+// NewRedisStore creates a store for the Redis server at addr
+func NewRedisStore(addr, password string, db int) *RedisStore {
+	return &RedisStore{client: redis.NewClient(addr, password, db)}
+}
+
+// Reserve claims key with SET NX; when the key exists the stored record is returned
+func (s *RedisStore) Reserve(ctx context.Context, key string, record *Record, ttl time.Duration) (*Record, bool, error) {
+	payload, err := json.Marshal(record)
+	if err != nil {
+		return nil, false, fmt.Errorf("encode idempotency record: %w", err)
+	}
+
+	reply, err := s.client.Do(ctx, "SET", redisKeyPrefix+key, string(payload), "NX", "PX", strconv.FormatInt(ttl.Milliseconds(), 10))
+	if err == nil && reply == "OK" {
+		return nil, true, nil
+	}
+
+	if err != nil && !errors.Is(err, redis.ErrNil) {
+		return nil, false, fmt.Errorf("reserve idempotency key: %w", err)
+	}
+
+	reply, err = s.client.Do(ctx, "GET", redisKeyPrefix+key)
+	if errors.Is(err, redis.ErrNil) {
+		// The key expired between SET and GET; report it as in flight so the client retries
+		return &Record{Fingerprint: record.Fingerprint}, false, nil
+	}
+
+	if err != nil {
+		return nil, false, fmt.Errorf("get idempotency key: %w", err)
+	}
+
+	var existing Record
+	if decodeErr := json.Unmarshal([]byte(reply), &existing); decodeErr != nil {
+		return nil, false, fmt.Errorf("decode idempotency record: %w", decodeErr)
+	}
+
+	return &existing, false, nil
+}
+
+// Save stores the completed record for key
+func (s *RedisStore) Save(ctx context.Context, key string, record *Record, ttl time.Duration) error {
+	payload, err := json.Marshal(record)
+	if err != nil {
+		return fmt.Errorf("encode idempotency record: %w", err)
+	}
+
+	if _, err = s.client.Do(ctx, "SET", redisKeyPrefix+key, string(payload), "PX", strconv.FormatInt(ttl.Milliseconds(), 10)); err != nil {
+		return fmt.Errorf("save idempotency key: %w", err)
+	}
+
+	return nil
+}
+
+// Release deletes key
+func (s *RedisStore) Release(ctx context.Context, key string) error {
+	if _, err := s.client.Do(ctx, "DEL", redisKeyPrefix+key); err != nil {
+		return fmt.Errorf("release idempotency key: %w", err)
+	}
+
+	return nil
+}
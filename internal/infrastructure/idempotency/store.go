@@ -0,0 +1,64 @@
+// Package idempotency stores request fingerprints and responses so that
+// retried requests carrying the same Idempotency-Key are not processed twice.
+package idempotency
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/goformx/goforms/internal/infrastructure/config"
+	"github.com/goformx/goforms/internal/infrastructure/logging"
+)
+
+const (
+	// StoreMemory keeps records in process memory
+	StoreMemory = "memory"
+	// StoreRedis keeps records in Redis so they are shared between instances
+	StoreRedis = "redis"
+)
+
+// ErrUnknownStore is returned when api.idempotency.store names an unsupported backend
+var ErrUnknownStore = errors.New("unknown idempotency store")
+
+// Record is the state kept for one idempotency key
+type Record struct {
+	// Fingerprint identifies the request body and target the key was first used with
+	Fingerprint string `json:"fingerprint"`
+	// Completed is false while the original request is still being processed
+	Completed bool        `json:"completed"`
+	Status    int         `json:"status,omitempty"`
+	Header    http.Header `json:"header,omitempty"`
+	Body      []byte      `json:"body,omitempty"`
+}
+
+// Store persists idempotency records
+type Store interface {
+	// Reserve claims key for an in-flight request. When the key is already
+	// claimed it returns the existing record and false.
+	Reserve(ctx context.Context, key string, record *Record, ttl time.Duration) (*Record, bool, error)
+	// Save stores the completed record for key
+	Save(ctx context.Context, key string, record *Record, ttl time.Duration) error
+	// Release forgets key so the request can be retried
+	Release(ctx context.Context, key string) error
+}
+
+// NewStore returns the store selected by api.idempotency.store. Redis uses the cache.redis settings.
+func NewStore(cfg *config.Config, logger logging.Logger) (Store, error) {
+	switch cfg.API.Idempotency.Store {
+	case "", StoreMemory:
+		return NewMemoryStore(), nil
+	case StoreRedis:
+		redisCfg := cfg.Cache.Redis
+		addr := redisCfg.Host + ":" + strconv.Itoa(redisCfg.Port)
+
+		logger.Info("using redis idempotency store", "addr", addr, "db", redisCfg.DB)
+
+		return NewRedisStore(addr, redisCfg.Password, redisCfg.DB), nil
+	default:
+		return nil, fmt.Errorf("%w: %s", ErrUnknownStore, cfg.API.Idempotency.Store)
+	}
+}
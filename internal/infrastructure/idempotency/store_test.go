@@ -0,0 +1,72 @@
+package idempotency_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/goformx/goforms/internal/infrastructure/idempotency"
+	"github.com/goformx/goforms/internal/infrastructure/redis/redistest"
+)
+
+func exerciseStore(t *testing.T, s idempotency.Store) {
+	t.Helper()
+
+	ctx := t.Context()
+	pending := &idempotency.Record{Fingerprint: "fp-1"}
+
+	existing, reserved, err := s.Reserve(ctx, "key", pending, time.Minute)
+	require.NoError(t, err)
+	assert.True(t, reserved)
+	assert.Nil(t, existing)
+
+	existing, reserved, err = s.Reserve(ctx, "key", &idempotency.Record{Fingerprint: "fp-2"}, time.Minute)
+	require.NoError(t, err)
+	assert.False(t, reserved)
+	require.NotNil(t, existing)
+	assert.Equal(t, "fp-1", existing.Fingerprint)
+	assert.False(t, existing.Completed)
+
+	done := &idempotency.Record{Fingerprint: "fp-1", Completed: true, Status: 201, Body: []byte(`{"ok":true}`)}
+	require.NoError(t, s.Save(ctx, "key", done, time.Minute))
+
+	existing, reserved, err = s.Reserve(ctx, "key", pending, time.Minute)
+	require.NoError(t, err)
+	assert.False(t, reserved)
+	assert.True(t, existing.Completed)
+	assert.Equal(t, 201, existing.Status)
+	assert.JSONEq(t, `{"ok":true}`, string(existing.Body))
+
+	require.NoError(t, s.Release(ctx, "key"))
+
+	_, reserved, err = s.Reserve(ctx, "key", pending, time.Minute)
+	require.NoError(t, err)
+	assert.True(t, reserved)
+}
+
+func TestMemoryStore(t *testing.T) {
+	exerciseStore(t, idempotency.NewMemoryStore())
+}
+
+func TestMemoryStore_Expiry(t *testing.T) {
+	s := idempotency.NewMemoryStore()
+	record := &idempotency.Record{Fingerprint: "fp"}
+
+	_, reserved, err := s.Reserve(t.Context(), "key", record, 10*time.Millisecond)
+	require.NoError(t, err)
+	require.True(t, reserved)
+
+	time.Sleep(20 * time.Millisecond)
+
+	_, reserved, err = s.Reserve(t.Context(), "key", record, time.Minute)
+	require.NoError(t, err)
+	assert.True(t, reserved)
+}
+
+func TestRedisStore(t *testing.T) {
+	addr := redistest.Start(t)
+
+	exerciseStore(t, idempotency.NewRedisStore(addr, "", 0))
+}
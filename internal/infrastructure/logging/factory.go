@@ -48,7 +48,7 @@ func NewFactory(cfg *FactoryConfig, sanitizer sanitization.ServiceInterface) (*F
 		outputPaths:    cfg.OutputPaths,
 		errorPaths:     cfg.ErrorOutputPaths,
 		sanitizer:      sanitizer,
-		fieldSanitizer: NewSanitizer(),
+		fieldSanitizer: NewSanitizer().WithRedactor(cfg.Redactor).WithRules(cfg.SanitizationRules),
 		LogLevel:       cfg.LogLevel,
 	}, nil
 }
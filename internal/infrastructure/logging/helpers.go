@@ -125,20 +125,25 @@ func convertToZapFields(fields []any, fieldSanitizer *Sanitizer) []zap.Field {
 
 // createOptimizedField creates a zap field with type preservation and selective sanitization
 func createOptimizedField(key string, value any, fieldSanitizer *Sanitizer) zap.Field {
-	// Check if this is a sensitive field first
-	if isSensitiveKey(key) {
-		return zap.String(key, "****")
+	// Operator rules and sensitive keys come first
+	strategy, ruled := fieldSanitizer.strategy(key)
+
+	switch {
+	case ruled && strategy == StrategyDrop:
+		return zap.Skip()
+	case ruled && strategy != strategyAllow:
+		return zap.String(key, fieldSanitizer.SanitizeField(key, value))
 	}
 
 	// Preserve native types when possible
 	switch v := value.(type) {
 	case string:
 		// Only sanitize strings that need it
-		if needsStringSanitization(key, v) {
+		if strategy == strategyAllow || needsStringSanitization(key, v) {
 			return zap.String(key, fieldSanitizer.SanitizeField(key, v))
 		}
 
-		return zap.String(key, v)
+		return zap.String(key, fieldSanitizer.redact(v))
 	case int:
 		return zap.Int(key, v)
 	case int64:
@@ -148,6 +153,11 @@ func createOptimizedField(key string, value any, fieldSanitizer *Sanitizer) zap.
 	case bool:
 		return zap.Bool(key, v)
 	case error:
+		// Keep the error's structure unless its message carries personal data
+		if redacted := fieldSanitizer.redact(v.Error()); redacted != v.Error() {
+			return zap.String(key, redacted)
+		}
+
 		return zap.Error(v)
 	default:
 		// For complex types, use sanitization
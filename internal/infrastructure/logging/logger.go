@@ -26,6 +26,12 @@ func newLogger(
 	}
 }
 
+// NewFromZap creates a logger writing to an existing zap logger, such as one
+// owned by a program embedding GoForms
+func NewFromZap(zapLogger *zap.Logger, sanitizer sanitization.ServiceInterface) Logger {
+	return newLogger(zapLogger, sanitizer, NewSanitizer())
+}
+
 // With returns a new logger with the given fields
 func (l *logger) With(fields ...any) Logger {
 	zapFields := convertToZapFields(fields, l.fieldSanitizer)
@@ -83,6 +89,10 @@ func (l *logger) WithError(err error) Logger {
 func (l *logger) WithFields(fields map[string]any) Logger {
 	zapFields := make([]zap.Field, 0, len(fields))
 	for k, v := range fields {
+		if strategy, _ := l.fieldSanitizer.strategy(k); strategy == StrategyDrop {
+			continue
+		}
+
 		zapFields = append(zapFields, zap.String(k, l.SanitizeField(k, v)))
 	}
 
@@ -0,0 +1,135 @@
+package logging
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"path"
+	"strings"
+	"unicode/utf8"
+)
+
+// Strategy is how the sanitizer treats the value of a log field whose key
+// matches a rule
+type Strategy string
+
+const (
+	// StrategyMask replaces the value with "****"
+	StrategyMask Strategy = "mask"
+	// StrategyHash replaces the value with a short SHA-256 hash, so equal
+	// values can still be correlated across entries
+	StrategyHash Strategy = "hash"
+	// StrategyTruncate keeps the first SanitizationRules.TruncateLength characters
+	StrategyTruncate Strategy = "truncate"
+	// StrategyDrop leaves the field out of the entry
+	StrategyDrop Strategy = "drop"
+	// strategyAllow logs the value without the built-in key rules
+	strategyAllow Strategy = "allow"
+)
+
+const (
+	// maskedValue replaces the value of a masked field
+	maskedValue = "****"
+	// hashPrefix marks a hashed value
+	hashPrefix = "sha256:"
+	// hashLength is the number of hex characters kept from a hash
+	hashLength = 12
+	// truncatedSuffix marks a truncated value
+	truncatedSuffix = "…"
+	// defaultTruncateLength is used when SanitizationRules.TruncateLength is unset
+	defaultTruncateLength = 8
+)
+
+// SanitizationRules holds operator rules for sanitizing log fields. Each list
+// holds key patterns, matched case-insensitively: a pattern with * or ? is a
+// glob matched against the whole key, any other pattern matches keys
+// containing it. Allow wins over every other rule; the strategies win over the
+// built-in rules, and are tried in the order drop, mask, hash, truncate.
+type SanitizationRules struct {
+	Mask     []string
+	Hash     []string
+	Truncate []string
+	Drop     []string
+	Allow    []string
+	// TruncateLength is the number of characters StrategyTruncate keeps
+	TruncateLength int
+}
+
+// strategy returns the rule for a log field key: an operator rule, then the
+// built-in mask for sensitive keys. ok is false when no rule matches and the
+// built-in key-based sanitization applies.
+func (r *SanitizationRules) strategy(key string) (strategy Strategy, ok bool) {
+	keyLower := strings.ToLower(key)
+
+	if r != nil {
+		for _, rule := range []struct {
+			strategy Strategy
+			patterns []string
+		}{
+			{strategyAllow, r.Allow},
+			{StrategyDrop, r.Drop},
+			{StrategyMask, r.Mask},
+			{StrategyHash, r.Hash},
+			{StrategyTruncate, r.Truncate},
+		} {
+			for _, pattern := range rule.patterns {
+				if matchesKey(pattern, keyLower) {
+					return rule.strategy, true
+				}
+			}
+		}
+	}
+
+	if isSensitiveKey(key) {
+		return StrategyMask, true
+	}
+
+	return "", false
+}
+
+// apply returns value under a masking strategy. Applying a strategy to its
+// own output returns it unchanged, since handlers often pass values through
+// SanitizeField before logging them under the same key.
+func (r *SanitizationRules) apply(strategy Strategy, value string) string {
+	switch strategy {
+	case StrategyMask:
+		return maskedValue
+	case StrategyHash:
+		if strings.HasPrefix(value, hashPrefix) && len(value) == len(hashPrefix)+hashLength {
+			return value
+		}
+
+		sum := sha256.Sum256([]byte(value))
+
+		return hashPrefix + hex.EncodeToString(sum[:])[:hashLength]
+	case StrategyTruncate:
+		length := defaultTruncateLength
+		if r != nil && r.TruncateLength > 0 {
+			length = r.TruncateLength
+		}
+
+		if utf8.RuneCountInString(value) <= length ||
+			strings.HasSuffix(value, truncatedSuffix) && utf8.RuneCountInString(value) == length+1 {
+			return value
+		}
+
+		return string([]rune(value)[:length]) + truncatedSuffix
+	default:
+		return value
+	}
+}
+
+// matchesKey reports whether a lower-cased key matches a rule pattern
+func matchesKey(pattern, key string) bool {
+	pattern = strings.ToLower(strings.TrimSpace(pattern))
+	if pattern == "" {
+		return false
+	}
+
+	if strings.ContainsAny(pattern, "*?[") {
+		matched, err := path.Match(pattern, key)
+
+		return err == nil && matched
+	}
+
+	return strings.Contains(key, pattern)
+}
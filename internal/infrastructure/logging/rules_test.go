@@ -0,0 +1,88 @@
+package logging_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zaptest/observer"
+
+	"github.com/goformx/goforms/internal/infrastructure/logging"
+	"github.com/goformx/goforms/internal/infrastructure/sanitization"
+)
+
+func newObservedLogger(t *testing.T, rules *logging.SanitizationRules) (logging.Logger, *observer.ObservedLogs) {
+	t.Helper()
+
+	factory, err := logging.NewFactory(&logging.FactoryConfig{
+		AppName:           "goforms-test",
+		Environment:       "test",
+		SanitizationRules: rules,
+	}, sanitization.NewService())
+	require.NoError(t, err)
+
+	core, logs := observer.New(zap.DebugLevel)
+
+	logger, err := factory.WithTestCore(core).CreateLogger()
+	require.NoError(t, err)
+
+	return logger, logs
+}
+
+func TestSanitizationRules(t *testing.T) {
+	const formID = "550e8400-e29b-41d4-a716-446655440000"
+
+	logger, logs := newObservedLogger(t, &logging.SanitizationRules{
+		Hash:           []string{"*_email"},
+		Truncate:       []string{"trace"},
+		Drop:           []string{"body"},
+		Allow:          []string{"form_id", "cache_key"},
+		Mask:           []string{"tenant"},
+		TruncateLength: 4,
+	})
+
+	logger.Info("rules",
+		"form_id", logger.SanitizeField("form_id", formID),
+		"submission_id", logger.SanitizeField("submission_id", formID),
+		"cache_key", "forms:1",
+		"password", "hunter2",
+		"owner_email", "ada@example.com",
+		"trace_id", "abcdef",
+		"request_body", "{}",
+		"tenant_name", "acme",
+		"count", 3,
+	)
+
+	require.Equal(t, 1, logs.Len())
+	fields := logs.All()[0].ContextMap()
+
+	assert.Equal(t, formID, fields["form_id"], "allowed keys skip the built-in ID shortening")
+	assert.Equal(t, "550e8400...0000", fields["submission_id"], "other IDs are still shortened")
+	assert.Equal(t, "forms:1", fields["cache_key"], "allow overrides the built-in mask for *key*")
+	assert.Equal(t, "****", fields["password"])
+	assert.Regexp(t, `^sha256:[0-9a-f]{12}$`, fields["owner_email"])
+	assert.Equal(t, "abcd…", fields["trace_id"])
+	assert.NotContains(t, fields, "request_body")
+	assert.Equal(t, "****", fields["tenant_name"])
+	assert.EqualValues(t, 3, fields["count"])
+}
+
+func TestSanitizationRules_SanitizeFieldIsStable(t *testing.T) {
+	logger, logs := newObservedLogger(t, &logging.SanitizationRules{
+		Hash:           []string{"user_id"},
+		Truncate:       []string{"trace_id"},
+		TruncateLength: 4,
+	})
+
+	// Handlers pass values through SanitizeField before logging them under the same key
+	logger.Info("stable",
+		"user_id", logger.SanitizeField("user_id", "user-1"),
+		"trace_id", logger.SanitizeField("trace_id", "abcdef"),
+	)
+	logger.Info("direct", "user_id", "user-1", "trace_id", "abcdef")
+
+	entries := logs.All()
+	require.Len(t, entries, 2)
+	assert.Equal(t, entries[1].ContextMap(), entries[0].ContextMap())
+}
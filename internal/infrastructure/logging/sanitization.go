@@ -11,6 +11,10 @@ import (
 type Sanitizer struct {
 	// Cache for repeated sanitization operations
 	cache map[string]string
+	// redactor masks personal data in values; nil leaves it
+	redactor Redactor
+	// rules holds the operator's key rules; nil applies the built-in rules only
+	rules *SanitizationRules
 }
 
 // NewSanitizer creates a new sanitizer instance
@@ -20,11 +24,51 @@ func NewSanitizer() *Sanitizer {
 	}
 }
 
+// WithRedactor masks the personal data redactor finds in sanitized values
+func (s *Sanitizer) WithRedactor(redactor Redactor) *Sanitizer {
+	s.redactor = redactor
+
+	return s
+}
+
+// WithRules applies operator rules on top of the built-in key rules
+func (s *Sanitizer) WithRules(rules *SanitizationRules) *Sanitizer {
+	s.rules = rules
+
+	return s
+}
+
+// strategy returns the rule for a field key; see SanitizationRules.strategy
+func (s *Sanitizer) strategy(key string) (Strategy, bool) {
+	var rules *SanitizationRules
+	if s != nil {
+		rules = s.rules
+	}
+
+	return rules.strategy(key)
+}
+
+// redact masks personal data in value when the sanitizer has a redactor
+func (s *Sanitizer) redact(value string) string {
+	if s == nil || s.redactor == nil {
+		return value
+	}
+
+	return s.redactor.Redact(value)
+}
+
 // SanitizeField sanitizes a field value based on its key and type
 func (s *Sanitizer) SanitizeField(key string, value any) string {
-	// Check for sensitive fields first
-	if isSensitiveKey(key) {
-		return "****"
+	// Operator rules and sensitive keys come first
+	if strategy, ok := s.strategy(key); ok {
+		switch strategy {
+		case StrategyDrop:
+			return ""
+		case strategyAllow:
+			return s.redact(s.sanitizeGenericString(fieldText(value)))
+		default:
+			return s.rules.apply(strategy, fieldText(value))
+		}
 	}
 
 	// Handle different value types
@@ -39,6 +83,18 @@ func (s *Sanitizer) SanitizeField(key string, value any) string {
 	}
 }
 
+// fieldText returns a field value as text
+func fieldText(value any) string {
+	switch v := value.(type) {
+	case string:
+		return v
+	case error:
+		return v.Error()
+	default:
+		return fmt.Sprintf("%v", v)
+	}
+}
+
 // sanitizeString sanitizes a string value based on the field key
 func (s *Sanitizer) sanitizeString(key, value string) string {
 	// Check cache first
@@ -47,7 +103,7 @@ func (s *Sanitizer) sanitizeString(key, value string) string {
 		return cached
 	}
 
-	sanitized := s.sanitizeByKey(key, value)
+	sanitized := s.redact(s.sanitizeByKey(key, value))
 
 	// Cache the result
 	s.cache[cacheKey] = sanitized
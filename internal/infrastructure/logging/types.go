@@ -87,6 +87,15 @@ type FactoryConfig struct {
 	OutputPaths      []string
 	ErrorOutputPaths []string
 	Fields           map[string]any
+	// Redactor, when set, masks personal data in logged string and error values
+	Redactor Redactor
+	// SanitizationRules, when set, adds operator rules to the built-in field sanitization
+	SanitizationRules *SanitizationRules
+}
+
+// Redactor masks personal data, such as email addresses, in free text
+type Redactor interface {
+	Redact(value string) string
 }
 
 // LogLevel represents the severity of a log message
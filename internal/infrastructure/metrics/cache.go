@@ -0,0 +1,57 @@
+package metrics
+
+import (
+	"sync/atomic"
+)
+
+// CacheMetrics counts lookups of one cache
+type CacheMetrics struct {
+	hits          atomic.Int64
+	misses        atomic.Int64
+	errors        atomic.Int64
+	invalidations atomic.Int64
+}
+
+// NewCacheMetrics creates zeroed cache metrics
+func NewCacheMetrics() *CacheMetrics {
+	return &CacheMetrics{}
+}
+
+// Hit records a lookup served from the cache
+func (m *CacheMetrics) Hit() {
+	m.hits.Add(1)
+}
+
+// Miss records a lookup that fell through to the backing store
+func (m *CacheMetrics) Miss() {
+	m.misses.Add(1)
+}
+
+// Error records a failed cache operation
+func (m *CacheMetrics) Error() {
+	m.errors.Add(1)
+}
+
+// Invalidation records an entry dropped because its source changed
+func (m *CacheMetrics) Invalidation() {
+	m.invalidations.Add(1)
+}
+
+// GetMetrics returns the current counters and the hit ratio
+func (m *CacheMetrics) GetMetrics() map[string]any {
+	hits := m.hits.Load()
+	misses := m.misses.Load()
+
+	hitRatio := 0.0
+	if lookups := hits + misses; lookups > 0 {
+		hitRatio = float64(hits) / float64(lookups)
+	}
+
+	return map[string]any{
+		"hits":          hits,
+		"misses":        misses,
+		"errors":        m.errors.Load(),
+		"invalidations": m.invalidations.Load(),
+		"hit_ratio":     hitRatio,
+	}
+}
@@ -0,0 +1,60 @@
+package metrics
+
+import (
+	"maps"
+	"sync"
+)
+
+// RateLimitMetrics counts the requests that went over a rate limit, by limit
+type RateLimitMetrics struct {
+	mu      sync.Mutex
+	blocked map[string]int64
+	warned  map[string]int64
+}
+
+// NewRateLimitMetrics creates zeroed rate limit metrics
+func NewRateLimitMetrics() *RateLimitMetrics {
+	return &RateLimitMetrics{
+		blocked: make(map[string]int64),
+		warned:  make(map[string]int64),
+	}
+}
+
+// Blocked records a request rejected by a limit
+func (m *RateLimitMetrics) Blocked(limit string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.blocked[limit]++
+}
+
+// Warned records a request over a limit in warn mode, which was let through
+func (m *RateLimitMetrics) Warned(limit string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.warned[limit]++
+}
+
+// GetMetrics returns the totals and the counts per limit
+func (m *RateLimitMetrics) GetMetrics() map[string]any {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return map[string]any{
+		"blocked":          sum(m.blocked),
+		"warned":           sum(m.warned),
+		"blocked_by_limit": maps.Clone(m.blocked),
+		"warned_by_limit":  maps.Clone(m.warned),
+	}
+}
+
+// sum adds up the counts of a map
+func sum(counts map[string]int64) int64 {
+	var total int64
+	for _, n := range counts {
+		total += n
+	}
+
+	return total
+}
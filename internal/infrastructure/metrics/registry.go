@@ -0,0 +1,50 @@
+package metrics
+
+import (
+	"sync"
+)
+
+// Source reports a group of metrics
+type Source interface {
+	GetMetrics() map[string]any
+}
+
+// Registry collects named metric sources so they can be reported together
+type Registry struct {
+	mu      sync.RWMutex
+	sources map[string]Source
+}
+
+// NewRegistry creates a registry reporting the version metrics under "app"
+func NewRegistry() *Registry {
+	r := &Registry{sources: make(map[string]Source)}
+	r.Register("app", NewVersionMetrics())
+
+	return r
+}
+
+// Register adds a source under name, replacing any source already registered there
+func (r *Registry) Register(name string, source Source) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.sources[name] = source
+}
+
+// Snapshot returns the current metrics of every source, keyed by name
+func (r *Registry) Snapshot() map[string]any {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	snapshot := make(map[string]any, len(r.sources))
+
+	for name, source := range r.sources {
+		if updater, ok := source.(interface{ Update() }); ok {
+			updater.Update()
+		}
+
+		snapshot[name] = source.GetMetrics()
+	}
+
+	return snapshot
+}
@@ -5,24 +5,48 @@ package infrastructure
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
+	"strings"
 
 	"github.com/labstack/echo/v4"
 	"go.uber.org/fx"
+	"gorm.io/gorm"
 
 	"embed"
 
 	"github.com/goformx/goforms/internal/application/handlers/web"
+	"github.com/goformx/goforms/internal/domain/common/events"
+	"github.com/goformx/goforms/internal/domain/dataset"
+	"github.com/goformx/goforms/internal/domain/deadletter"
+	"github.com/goformx/goforms/internal/domain/emaildelivery"
+	"github.com/goformx/goforms/internal/domain/eventlog"
 	"github.com/goformx/goforms/internal/domain/form"
 	formevent "github.com/goformx/goforms/internal/domain/form/event"
+	"github.com/goformx/goforms/internal/domain/securityevent"
 	"github.com/goformx/goforms/internal/domain/user"
+	"github.com/goformx/goforms/internal/infrastructure/cache"
 	"github.com/goformx/goforms/internal/infrastructure/config"
+	"github.com/goformx/goforms/internal/infrastructure/counter"
+	"github.com/goformx/goforms/internal/infrastructure/crashreport"
 	"github.com/goformx/goforms/internal/infrastructure/database"
+	"github.com/goformx/goforms/internal/infrastructure/email"
 	"github.com/goformx/goforms/internal/infrastructure/event"
+	"github.com/goformx/goforms/internal/infrastructure/geoip"
+	"github.com/goformx/goforms/internal/infrastructure/httpclient"
+	"github.com/goformx/goforms/internal/infrastructure/idempotency"
 	"github.com/goformx/goforms/internal/infrastructure/logging"
+	"github.com/goformx/goforms/internal/infrastructure/metrics"
+	"github.com/goformx/goforms/internal/infrastructure/ops"
+	"github.com/goformx/goforms/internal/infrastructure/optionsource"
+	"github.com/goformx/goforms/internal/infrastructure/pii"
+	"github.com/goformx/goforms/internal/infrastructure/resilience"
 	"github.com/goformx/goforms/internal/infrastructure/sanitization"
+	"github.com/goformx/goforms/internal/infrastructure/scheduler"
 	"github.com/goformx/goforms/internal/infrastructure/server"
+	"github.com/goformx/goforms/internal/infrastructure/storage"
+	"github.com/goformx/goforms/internal/infrastructure/updates"
 	"github.com/goformx/goforms/internal/infrastructure/version"
 	infraweb "github.com/goformx/goforms/internal/infrastructure/web"
 )
@@ -107,6 +131,14 @@ type EventPublisherParams struct {
 	Logger logging.Logger `validate:"required"`
 }
 
+// EventBusParams contains dependencies for creating the event bus
+type EventBusParams struct {
+	fx.In
+	Logger logging.Logger
+	// Records is nil unless events.store.enabled is set
+	Records eventlog.Repository `optional:"true"`
+}
+
 // LoggerFactoryParams contains dependencies for creating a logger factory
 type LoggerFactoryParams struct {
 	fx.In
@@ -144,6 +176,17 @@ func NewEventPublisher(p EventPublisherParams) (formevent.Publisher, error) {
 	return publisher, nil
 }
 
+// ProvideEventBus creates the in-memory event bus. With the event store enabled
+// the bus stores each published event before dispatching it.
+func ProvideEventBus(p EventBusParams) events.EventBus {
+	bus := event.NewMemoryEventBus(p.Logger)
+	if p.Records == nil {
+		return bus
+	}
+
+	return eventlog.NewRecorder(bus, p.Records, p.Logger)
+}
+
 // NewLoggerFactory creates a new logger factory with proper configuration and error handling.
 func NewLoggerFactory(p LoggerFactoryParams) (*logging.Factory, error) {
 	if p.Config == nil {
@@ -177,6 +220,23 @@ func NewLoggerFactory(p LoggerFactoryParams) (*logging.Factory, error) {
 		LogLevel:         logLevel,
 		OutputPaths:      outputPaths,
 		ErrorOutputPaths: []string{"stderr"},
+		SanitizationRules: &logging.SanitizationRules{
+			Mask:           p.Config.Logging.Sanitization.Mask,
+			Hash:           p.Config.Logging.Sanitization.Hash,
+			Truncate:       p.Config.Logging.Sanitization.Truncate,
+			Drop:           p.Config.Logging.Sanitization.Drop,
+			Allow:          p.Config.Logging.Sanitization.Allow,
+			TruncateLength: p.Config.Logging.Sanitization.TruncateLength,
+		},
+	}
+
+	if p.Config.PII.RedactLogs {
+		classifier, err := pii.NewClassifier(p.Config.PII.Profiles)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create log redactor: %w", err)
+		}
+
+		factoryConfig.Redactor = classifier
 	}
 
 	factory, err := logging.NewFactory(&factoryConfig, p.Sanitizer)
@@ -290,8 +350,26 @@ func ProvideEcho() *echo.Echo {
 	return e
 }
 
+// ExternalDB is a connection owned by a program embedding GoForms. When
+// provided it is used instead of opening one, and it is not closed at shutdown.
+type ExternalDB struct {
+	DB *gorm.DB
+}
+
+// DatabaseParams groups the dependencies for providing the database.
+type DatabaseParams struct {
+	fx.In
+	Lifecycle fx.Lifecycle
+	Config    *config.Config
+	Logger    logging.Logger
+	External  *ExternalDB `optional:"true"`
+}
+
 // ProvideDatabase creates a new database connection with lifecycle management.
-func ProvideDatabase(lc fx.Lifecycle, cfg *config.Config, logger logging.Logger) (database.DB, error) {
+// With the memory and MongoDB drivers no SQL connection is opened and a nil DB is provided.
+func ProvideDatabase(p DatabaseParams) (database.DB, error) {
+	cfg, logger, lc := p.Config, p.Logger, p.Lifecycle
+
 	if cfg == nil {
 		return nil, ErrMissingConfig
 	}
@@ -300,6 +378,26 @@ func ProvideDatabase(lc fx.Lifecycle, cfg *config.Config, logger logging.Logger)
 		return nil, ErrMissingLogger
 	}
 
+	if p.External != nil && p.External.DB != nil {
+		logger.Info("using the embedding program's database connection")
+
+		return database.NewWithDB(p.External.DB, logger), nil
+	}
+
+	if cfg.Database.Driver == config.DatabaseDriverMemory {
+		logger.Warn("using in-memory repositories; data is not persisted to a database",
+			"snapshot_path", cfg.Database.SnapshotPath)
+
+		return nil, nil
+	}
+
+	if cfg.Database.Driver == config.DatabaseDriverMongoDB {
+		logger.Info("using MongoDB for forms and submissions; other data is kept in memory",
+			"database", cfg.Database.Name, "snapshot_path", cfg.Database.SnapshotPath)
+
+		return nil, nil
+	}
+
 	db, err := database.New(cfg, logger)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create database connection: %w", err)
@@ -322,6 +420,314 @@ func ProvideDatabase(lc fx.Lifecycle, cfg *config.Config, logger logging.Logger)
 	return db, nil
 }
 
+// ShardsParams groups the dependencies for providing the submission shards
+type ShardsParams struct {
+	fx.In
+	Lifecycle fx.Lifecycle
+	Config    *config.Config
+	Logger    logging.Logger
+}
+
+// ProvideShards connects to the databases submissions are sharded over, with
+// lifecycle management. Without database.sharding.enabled nil is provided and
+// submissions stay in the primary database.
+func ProvideShards(p ShardsParams) (*database.Shards, error) {
+	if !p.Config.Database.Sharding.Enabled {
+		return nil, nil
+	}
+
+	shards, err := database.OpenShards(p.Config, p.Logger)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to database shards: %w", err)
+	}
+
+	p.Lifecycle.Append(fx.Hook{
+		OnStart: func(_ context.Context) error {
+			p.Logger.Info("submissions are sharded by form ID", "shards", shards.IDs())
+
+			return nil
+		},
+		OnStop: func(_ context.Context) error {
+			p.Logger.Info("Closing database shard connections")
+
+			return shards.Close()
+		},
+	})
+
+	return shards, nil
+}
+
+// EmailSenderParams contains dependencies for creating the outbound email sender
+type EmailSenderParams struct {
+	fx.In
+	Config *config.Config `validate:"required"`
+	Logger logging.Logger `validate:"required"`
+	// Deliveries keeps the suppression list and counts sent email per form
+	Deliveries emaildelivery.Service `optional:"true"`
+	Clients    *httpclient.Factory   `optional:"true"`
+	// DeadLetters keeps messages that still fail after retries; nil unless dead_letter.enabled is set
+	DeadLetters deadletter.Service `optional:"true"`
+}
+
+// ProvideEmailSender creates the outbound email sender. Suppressions and sends
+// go through the email delivery service, so bounces and complaints reported by
+// provider webhooks stop further email to the address. Messages that fail
+// transiently after retries are dead-lettered, and retrying one sends it again.
+func ProvideEmailSender(p EmailSenderParams) email.Sender {
+	var sender email.Sender
+	if p.Deliveries == nil {
+		sender = email.NewSender(p.Config, nil, nil, p.Clients, p.Logger)
+	} else {
+		sender = email.NewSender(p.Config, p.Deliveries, p.Deliveries, p.Clients, p.Logger)
+	}
+
+	if p.DeadLetters == nil {
+		return sender
+	}
+
+	p.DeadLetters.Handle(deadletter.KindEmail, func(ctx context.Context, entry *deadletter.Entry) error {
+		var msg email.Message
+		if err := json.Unmarshal(entry.Payload, &msg); err != nil {
+			return fmt.Errorf("decode dead-lettered email: %w", err)
+		}
+
+		return sender.Send(ctx, &msg)
+	})
+
+	return email.WithDeadLetters(sender, &emailDeadLetters{letters: p.DeadLetters}, p.Logger)
+}
+
+// emailDeadLetters keeps undelivered email in the dead-letter queue
+type emailDeadLetters struct {
+	letters deadletter.Service
+}
+
+// DeadLetter implements email.DeadLetterQueue
+func (d *emailDeadLetters) DeadLetter(ctx context.Context, msg *email.Message, err error) error {
+	reference := fmt.Sprintf("%q to %s", msg.Subject, strings.Join(msg.To, ", "))
+
+	return d.letters.Record(ctx, deadletter.KindEmail, reference, msg, err)
+}
+
+// SecurityNotifierParams contains dependencies for creating the security event notifier
+type SecurityNotifierParams struct {
+	fx.In
+	Config *config.Config `validate:"required"`
+	Sender email.Sender   `validate:"required"`
+}
+
+// ProvideSecurityNotifier creates the notifier that emails security events to
+// security.events.notify_emails. It provides nil when no recipients are configured.
+func ProvideSecurityNotifier(p SecurityNotifierParams) securityevent.Notifier {
+	recipients := p.Config.Security.Events.NotifyEmails
+	if len(recipients) == 0 {
+		return nil
+	}
+
+	return email.NewSecurityNotifier(p.Sender, recipients, p.Config.App.Name)
+}
+
+// ResilienceParams contains dependencies for creating the outbound call registry
+type ResilienceParams struct {
+	fx.In
+	Config  config.ResilienceConfig
+	Logger  logging.Logger
+	Metrics *metrics.Registry `optional:"true"`
+}
+
+// ProvideResilience creates the registry guarding outbound integrations and
+// reports each target under the "outbound" metrics. It provides nil when
+// resilience.enabled is unset.
+func ProvideResilience(p ResilienceParams) *resilience.Registry {
+	registry := resilience.NewRegistry(p.Config, p.Logger)
+	if registry != nil && p.Metrics != nil {
+		p.Metrics.Register("outbound", registry)
+	}
+
+	return registry
+}
+
+// CrashReporterParams contains dependencies for creating the crash reporter
+type CrashReporterParams struct {
+	fx.In
+	Config  config.CrashReportsConfig
+	Logger  logging.Logger
+	Clients *httpclient.Factory `optional:"true"`
+	Metrics *metrics.Registry   `optional:"true"`
+}
+
+// ProvideCrashReporter creates the reporter of recovered panics and reports
+// the panic counts under the "crash_reports" metrics
+func ProvideCrashReporter(p CrashReporterParams) *crashreport.Reporter {
+	reporter := crashreport.New(p.Config, p.Clients, p.Logger)
+	if p.Metrics != nil {
+		p.Metrics.Register("crash_reports", reporter)
+	}
+
+	return reporter
+}
+
+// OpsServerParams contains dependencies for running the diagnostics listener
+type OpsServerParams struct {
+	fx.In
+	Config    *config.Config
+	Logger    logging.Logger
+	Lifecycle fx.Lifecycle
+	Metrics   *metrics.Registry `optional:"true"`
+}
+
+// RunOpsServer serves the runtime diagnostics on ops.address while the
+// application runs. Without ops.address they are served under /api/v1/admin/debug.
+func RunOpsServer(p OpsServerParams) {
+	server := ops.NewServer(p.Config, p.Metrics, p.Logger)
+	if server == nil {
+		return
+	}
+
+	p.Lifecycle.Append(fx.Hook{
+		OnStart: server.Start,
+		OnStop:  server.Stop,
+	})
+}
+
+// UpdateCheckerParams contains dependencies for creating the release update checker
+type UpdateCheckerParams struct {
+	fx.In
+	Config    config.UpdatesConfig
+	Logger    logging.Logger
+	Lifecycle fx.Lifecycle
+	Clients   *httpclient.Factory `optional:"true"`
+}
+
+// ProvideUpdateChecker creates the checker of new GoForms releases. A running
+// server checks once at startup and then every updates.interval. It provides
+// nil when updates.enabled is unset.
+func ProvideUpdateChecker(p UpdateCheckerParams) *updates.Checker {
+	checker := updates.New(p.Config, p.Clients, p.Logger)
+	if checker == nil {
+		return nil
+	}
+
+	runner := scheduler.NewRunner("update_check", p.Config.Interval, checker.Refresh, p.Logger)
+	ctx, cancel := context.WithCancel(context.Background())
+
+	p.Lifecycle.Append(fx.Hook{
+		OnStart: func(_ context.Context) error {
+			go func() {
+				_, _ = checker.Check(ctx)
+			}()
+
+			runner.Start()
+
+			return nil
+		},
+		OnStop: func(stopCtx context.Context) error {
+			cancel()
+			runner.Stop(stopCtx)
+
+			return nil
+		},
+	})
+
+	return checker
+}
+
+// GeoIPParams contains dependencies for creating the IP address locator
+type GeoIPParams struct {
+	fx.In
+	Config    config.GeoIPConfig
+	Logger    logging.Logger
+	Lifecycle fx.Lifecycle
+	Clients   *httpclient.Factory `optional:"true"`
+}
+
+// ProvideGeoIP creates the locator of submitters' countries. When
+// geoip.update.enabled is set, a running server downloads new database
+// releases at startup and then every geoip.update.interval. It provides nil
+// when geoip.enabled is unset.
+func ProvideGeoIP(p GeoIPParams) *geoip.Locator {
+	locator := geoip.New(p.Config, p.Logger)
+
+	updater := geoip.NewUpdater(p.Config, locator, p.Clients, p.Logger)
+	if updater == nil {
+		return locator
+	}
+
+	runner := scheduler.NewRunner("geoip_update", p.Config.Update.Interval, updater.Update, p.Logger)
+	ctx, cancel := context.WithCancel(context.Background())
+
+	p.Lifecycle.Append(fx.Hook{
+		OnStart: func(_ context.Context) error {
+			go func() {
+				if err := updater.Update(ctx); err != nil {
+					p.Logger.Warn("failed to update geoip database", "error", err)
+				}
+			}()
+
+			runner.Start()
+
+			return nil
+		},
+		OnStop: func(stopCtx context.Context) error {
+			cancel()
+			runner.Stop(stopCtx)
+
+			return nil
+		},
+	})
+
+	return locator
+}
+
+// ProvidePIIClassifier creates the personal data classifier used by submission
+// exports, detecting the national ID formats of pii.profiles
+func ProvidePIIClassifier(cfg config.PIIConfig) (*pii.Classifier, error) {
+	classifier, err := pii.NewClassifier(cfg.Profiles)
+	if err != nil {
+		return nil, fmt.Errorf("create pii classifier: %w", err)
+	}
+
+	return classifier, nil
+}
+
+// OptionSourcesParams contains dependencies for creating the field option resolver
+type OptionSourcesParams struct {
+	fx.In
+	Config  config.FormConfig
+	Cache   cache.Cache
+	Logger  logging.Logger
+	Clients *httpclient.Factory `optional:"true"`
+	// Datasets gives fields the options of their owner's datasets, named "dataset:<name>"
+	Datasets dataset.Service `optional:"true"`
+}
+
+// ProvideOptionSources creates the resolver of the option lists select, radio
+// and selectboxes fields name with optionsSource (form.options.*)
+func ProvideOptionSources(p OptionSourcesParams) (*optionsource.Resolver, error) {
+	resolver, err := optionsource.New(p.Config.Options, p.Cache, p.Clients, p.Logger)
+	if err != nil {
+		return nil, fmt.Errorf("create option sources: %w", err)
+	}
+
+	if p.Datasets != nil {
+		resolver.UseManagedDatasets(func(ctx context.Context, ownerID, name, labelColumn string) ([]optionsource.Option, error) {
+			choices, optionsErr := p.Datasets.Options(ctx, ownerID, name, labelColumn)
+			if optionsErr != nil {
+				return nil, fmt.Errorf("load dataset options: %w", optionsErr)
+			}
+
+			options := make([]optionsource.Option, 0, len(choices))
+			for _, choice := range choices {
+				options = append(options, optionsource.Option{Label: choice.Label, Value: choice.Value})
+			}
+
+			return options, nil
+		})
+	}
+
+	return resolver, nil
+}
+
 // ProvideSanitizationService creates a new sanitization service with proper annotations.
 func ProvideSanitizationService() sanitization.ServiceInterface {
 	return sanitization.NewService()
@@ -337,6 +743,7 @@ var Module = fx.Module("infrastructure",
 
 		// Database with lifecycle management
 		ProvideDatabase,
+		ProvideShards,
 
 		// HTTP server
 		server.New,
@@ -351,11 +758,52 @@ var Module = fx.Module("infrastructure",
 		NewLoggerFactory,
 		NewLogger,
 
+		// Personal data detection for exports (pii.*)
+		ProvidePIIClassifier,
+
 		// Event system
 		NewEventPublisher,
-		event.NewMemoryEventBus,
+		ProvideEventBus,
+
+		// Circuit breakers and retries of outbound integrations (resilience.*)
+		ProvideResilience,
+
+		// Outbound HTTP clients: proxy, CA bundle, pins and timeouts (http_client.*)
+		httpclient.New,
+
+		// New release notices (updates.*)
+		ProvideUpdateChecker,
+
+		// Submitter locations from a MaxMind database (geoip.*)
+		ProvideGeoIP,
+
+		// Crash reports of recovered panics (crash_reports.*)
+		ProvideCrashReporter,
+
+		// Outbound email
+		ProvideEmailSender,
+		ProvideSecurityNotifier,
+
+		// Idempotency-Key records
+		idempotency.NewStore,
+
+		// Usage metering counters (api.metering.store)
+		counter.New,
+
+		// Shared cache (cache.type) and metrics
+		cache.New,
+		metrics.NewRegistry,
+
+		// Field options loaded from named lists (form.options.*)
+		ProvideOptionSources,
+
+		// Object storage (storage.type)
+		storage.New,
 	),
 
+	// Runtime diagnostics on their own listener (ops.address)
+	fx.Invoke(RunOpsServer),
+
 	// Lifecycle management
 	fx.Invoke(func(lc fx.Lifecycle, logger logging.Logger, _ *config.Config) {
 		lc.Append(fx.Hook{
@@ -0,0 +1,80 @@
+// Package ops serves the runtime diagnostics: net/http/pprof profiles, expvar
+// variables and a runtime statistics summary. Handler serves them under
+// /debug; the application mounts it for admins on the main server, or Server
+// serves it on a listener of its own guarded by a bearer token (ops.address).
+package ops
+
+import (
+	"encoding/json"
+	"expvar"
+	"net/http"
+	"net/http/pprof"
+	"sync"
+	"sync/atomic"
+
+	"github.com/goformx/goforms/internal/infrastructure/metrics"
+)
+
+// Paths of the diagnostics, relative to where Handler is mounted
+const (
+	PathPprof   = "/debug/pprof/"
+	PathVars    = "/debug/vars"
+	PathRuntime = "/debug/runtime"
+)
+
+var (
+	// published is the registry the "goforms" expvar reports
+	published    atomic.Pointer[metrics.Registry]
+	publishOnce  sync.Once
+	emptyMetrics = map[string]any{}
+)
+
+// Handler returns the diagnostics handler. registry, which may be nil, is
+// reported by expvar under "goforms" next to the runtime's memstats.
+func Handler(registry *metrics.Registry) http.Handler {
+	publish(registry)
+
+	mux := http.NewServeMux()
+	mux.HandleFunc(PathPprof, pprof.Index)
+	mux.HandleFunc(PathPprof+"cmdline", pprof.Cmdline)
+	mux.HandleFunc(PathPprof+"profile", pprof.Profile)
+	mux.HandleFunc(PathPprof+"symbol", pprof.Symbol)
+	mux.HandleFunc(PathPprof+"trace", pprof.Trace)
+	mux.Handle(PathVars, expvar.Handler())
+	mux.HandleFunc(PathRuntime, serveRuntime)
+
+	return mux
+}
+
+// publish reports registry under the "goforms" expvar. expvar names are
+// process-wide, so the variable is published once and reads the latest registry.
+func publish(registry *metrics.Registry) {
+	if registry != nil {
+		published.Store(registry)
+	}
+
+	publishOnce.Do(func() {
+		expvar.Publish("goforms", expvar.Func(func() any {
+			if current := published.Load(); current != nil {
+				return current.Snapshot()
+			}
+
+			return emptyMetrics
+		}))
+	})
+}
+
+// serveRuntime writes the runtime statistics as JSON
+func serveRuntime(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet && r.Method != http.MethodHead {
+		w.Header().Set("Allow", "GET, HEAD")
+		http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-store")
+
+	_ = json.NewEncoder(w).Encode(ReadStats())
+}
@@ -0,0 +1,78 @@
+package ops_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"runtime"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/goformx/goforms/internal/infrastructure/metrics"
+	"github.com/goformx/goforms/internal/infrastructure/ops"
+)
+
+func get(t *testing.T, handler http.Handler, path, token string) *httptest.ResponseRecorder {
+	t.Helper()
+
+	req := httptest.NewRequest(http.MethodGet, path, http.NoBody)
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+
+	return rec
+}
+
+func TestHandler(t *testing.T) {
+	handler := ops.Handler(metrics.NewRegistry())
+
+	rec := get(t, handler, ops.PathRuntime, "")
+	require.Equal(t, http.StatusOK, rec.Code)
+
+	var stats map[string]any
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &stats))
+	assert.Contains(t, stats, "goroutines")
+	assert.Contains(t, stats, "heap")
+	assert.Contains(t, stats, "gc")
+
+	rec = get(t, handler, ops.PathVars, "")
+	require.Equal(t, http.StatusOK, rec.Code)
+
+	var vars map[string]any
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &vars))
+	assert.Contains(t, vars, "memstats")
+	assert.Contains(t, vars["goforms"], "app", "the metrics registry is published")
+
+	rec = get(t, handler, ops.PathPprof, "")
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.Contains(t, rec.Body.String(), "goroutine")
+
+	rec = get(t, handler, ops.PathPprof+"heap", "")
+	assert.Equal(t, http.StatusOK, rec.Code)
+}
+
+func TestRequireToken(t *testing.T) {
+	handler := ops.RequireToken("0123456789abcdef", ops.Handler(nil))
+
+	assert.Equal(t, http.StatusUnauthorized, get(t, handler, ops.PathRuntime, "").Code)
+	assert.Equal(t, http.StatusUnauthorized, get(t, handler, ops.PathRuntime, "wrong").Code)
+	assert.Equal(t, http.StatusOK, get(t, handler, ops.PathRuntime, "0123456789abcdef").Code)
+}
+
+func TestReadStats(t *testing.T) {
+	runtime.GC()
+
+	stats := ops.ReadStats()
+
+	assert.Positive(t, stats.Goroutines)
+	assert.Positive(t, stats.Heap.Sys)
+	assert.Positive(t, stats.GC.Count)
+	assert.NotEmpty(t, stats.GC.RecentPauses)
+	assert.LessOrEqual(t, len(stats.GC.RecentPauses), 10)
+	assert.False(t, stats.GC.LastAt.IsZero())
+}
@@ -0,0 +1,94 @@
+package ops
+
+import (
+	"context"
+	"crypto/subtle"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/goformx/goforms/internal/infrastructure/config"
+	"github.com/goformx/goforms/internal/infrastructure/logging"
+	"github.com/goformx/goforms/internal/infrastructure/metrics"
+)
+
+// readHeaderTimeout bounds reading request headers on the diagnostics listener
+const readHeaderTimeout = 10 * time.Second
+
+// Server serves the diagnostics on ops.address. It has no write timeout, so
+// CPU profiles and traces can run for as long as ?seconds= asks.
+type Server struct {
+	config config.OpsConfig
+	logger logging.Logger
+	server *http.Server
+}
+
+// NewServer creates the diagnostics listener. It returns nil when ops.address
+// is unset or the diagnostics are disabled.
+func NewServer(cfg *config.Config, registry *metrics.Registry, logger logging.Logger) *Server {
+	if cfg.Ops.Address == "" || !cfg.DiagnosticsEnabled() {
+		return nil
+	}
+
+	s := &Server{config: cfg.Ops, logger: logger}
+	s.server = &http.Server{
+		Addr:              cfg.Ops.Address,
+		Handler:           RequireToken(cfg.Ops.Token, Handler(registry)),
+		ReadHeaderTimeout: readHeaderTimeout,
+	}
+
+	return s
+}
+
+// Start listens on ops.address and serves in the background
+func (s *Server) Start(ctx context.Context) error {
+	if s == nil {
+		return nil
+	}
+
+	listener, err := (&net.ListenConfig{}).Listen(ctx, "tcp", s.config.Address)
+	if err != nil {
+		return fmt.Errorf("listen for diagnostics: %w", err)
+	}
+
+	go func() {
+		if serveErr := s.server.Serve(listener); serveErr != nil && !errors.Is(serveErr, http.ErrServerClosed) {
+			s.logger.Error("diagnostics server failed", "error", serveErr)
+		}
+	}()
+
+	s.logger.Info("diagnostics server started", "listen", listener.Addr().String())
+
+	return nil
+}
+
+// Stop shuts the listener down
+func (s *Server) Stop(ctx context.Context) error {
+	if s == nil {
+		return nil
+	}
+
+	if err := s.server.Shutdown(ctx); err != nil {
+		return fmt.Errorf("shut down diagnostics server: %w", err)
+	}
+
+	return nil
+}
+
+// RequireToken answers 401 to requests without "Authorization: Bearer <token>"
+func RequireToken(token string, next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		given, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+		if !ok || token == "" || subtle.ConstantTimeCompare([]byte(given), []byte(token)) != 1 {
+			w.Header().Set("WWW-Authenticate", `Bearer realm="diagnostics"`)
+			http.Error(w, http.StatusText(http.StatusUnauthorized), http.StatusUnauthorized)
+
+			return
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
@@ -0,0 +1,91 @@
+package ops
+
+import (
+	"runtime"
+	"time"
+)
+
+// recentPauses is how many of the latest GC pauses Stats reports
+const recentPauses = 10
+
+// Stats summarizes the Go runtime: goroutines, heap and garbage collection
+type Stats struct {
+	GoVersion  string    `json:"go_version"`
+	Goroutines int       `json:"goroutines"`
+	CPUs       int       `json:"cpus"`
+	GOMAXPROCS int       `json:"gomaxprocs"`
+	Heap       HeapStats `json:"heap"`
+	GC         GCStats   `json:"gc"`
+}
+
+// HeapStats are the heap sizes in bytes and the live object count
+type HeapStats struct {
+	Alloc    uint64 `json:"alloc"`
+	InUse    uint64 `json:"in_use"`
+	Idle     uint64 `json:"idle"`
+	Released uint64 `json:"released"`
+	Sys      uint64 `json:"sys"`
+	Objects  uint64 `json:"objects"`
+}
+
+// GCStats describe the garbage collections so far
+type GCStats struct {
+	Count uint32 `json:"count"`
+	// NextTarget is the heap size that triggers the next collection
+	NextTarget uint64    `json:"next_target"`
+	LastAt     time.Time `json:"last_at,omitzero"`
+	PauseTotal Duration  `json:"pause_total"`
+	// RecentPauses are the latest pauses, newest first
+	RecentPauses []Duration `json:"recent_pauses"`
+	// CPUFraction is the share of CPU time spent in GC since the process started
+	CPUFraction float64 `json:"cpu_fraction"`
+}
+
+// Duration marshals as a string such as "1.2ms"
+type Duration time.Duration
+
+// MarshalText implements encoding.TextMarshaler
+func (d Duration) MarshalText() ([]byte, error) {
+	return []byte(time.Duration(d).String()), nil
+}
+
+// ReadStats returns the current runtime statistics. Reading the memory
+// statistics briefly stops the world.
+func ReadStats() Stats {
+	var mem runtime.MemStats
+	runtime.ReadMemStats(&mem)
+
+	stats := Stats{
+		GoVersion:  runtime.Version(),
+		Goroutines: runtime.NumGoroutine(),
+		CPUs:       runtime.NumCPU(),
+		GOMAXPROCS: runtime.GOMAXPROCS(0),
+		Heap: HeapStats{
+			Alloc:    mem.HeapAlloc,
+			InUse:    mem.HeapInuse,
+			Idle:     mem.HeapIdle,
+			Released: mem.HeapReleased,
+			Sys:      mem.HeapSys,
+			Objects:  mem.HeapObjects,
+		},
+		GC: GCStats{
+			Count:        mem.NumGC,
+			NextTarget:   mem.NextGC,
+			PauseTotal:   Duration(mem.PauseTotalNs),
+			RecentPauses: []Duration{},
+			CPUFraction:  mem.GCCPUFraction,
+		},
+	}
+
+	if mem.LastGC > 0 {
+		stats.GC.LastAt = time.Unix(0, int64(mem.LastGC)).UTC()
+	}
+
+	// PauseNs is a circular buffer; the latest pause is at (NumGC+255)%256
+	for i := range min(mem.NumGC, recentPauses) {
+		index := (mem.NumGC - 1 - i) % uint32(len(mem.PauseNs))
+		stats.GC.RecentPauses = append(stats.GC.RecentPauses, Duration(mem.PauseNs[index]))
+	}
+
+	return stats
+}
@@ -0,0 +1,36 @@
+package optionsource
+
+import (
+	"context"
+
+	"github.com/goformx/goforms/internal/domain/form/model"
+)
+
+// Built-in datasets
+const (
+	// DatasetCountries lists the countries addresses can be entered for, valued by ISO code
+	DatasetCountries = "countries"
+)
+
+// datasets are the built-in option lists, available without configuration
+var datasets = map[string]Source{
+	DatasetCountries: datasetFunc(countryOptions),
+}
+
+// datasetFunc adapts a function to a Source
+type datasetFunc func() []Option
+
+// Options implements Source
+func (f datasetFunc) Options(_ context.Context) ([]Option, error) {
+	return f(), nil
+}
+
+// countryOptions lists model.Countries
+func countryOptions() []Option {
+	options := make([]Option, 0, len(model.Countries))
+	for _, country := range model.Countries {
+		options = append(options, Option{Label: country.Name, Value: country.Code})
+	}
+
+	return options
+}
@@ -0,0 +1,137 @@
+package optionsource
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/goformx/goforms/internal/infrastructure/config"
+)
+
+// maxResponseBody bounds how much of a source response is read
+const maxResponseBody = 4 << 20
+
+// HTTPSource fetches an option list with a GET request returning JSON
+type HTTPSource struct {
+	config config.OptionSourceConfig
+	client *http.Client
+}
+
+// NewHTTPSource creates a source of cfg's URL. A nil client uses http.DefaultClient.
+func NewHTTPSource(cfg config.OptionSourceConfig, client *http.Client) *HTTPSource {
+	if client == nil {
+		client = http.DefaultClient
+	}
+
+	if cfg.ValueProperty == "" {
+		cfg.ValueProperty = "value"
+	}
+
+	if cfg.LabelProperty == "" {
+		cfg.LabelProperty = "label"
+	}
+
+	return &HTTPSource{config: cfg, client: client}
+}
+
+// Options implements Source. Items without a value are skipped; an item
+// without a label is labelled by its value.
+func (s *HTTPSource) Options(ctx context.Context) ([]Option, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, s.config.URL, http.NoBody)
+	if err != nil {
+		return nil, fmt.Errorf("create option request: %w", err)
+	}
+
+	req.Header.Set("Accept", "application/json")
+
+	for name, value := range s.config.Headers {
+		req.Header.Set(name, value)
+	}
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("fetch options: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxResponseBody))
+	if err != nil {
+		return nil, fmt.Errorf("read options: %w", err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("fetch options: %s", resp.Status)
+	}
+
+	var document any
+	if err = json.Unmarshal(body, &document); err != nil {
+		return nil, fmt.Errorf("decode options: %w", err)
+	}
+
+	items, err := itemsAt(document, s.config.Path)
+	if err != nil {
+		return nil, err
+	}
+
+	options := make([]Option, 0, len(items))
+
+	for _, item := range items {
+		fields, ok := item.(map[string]any)
+		if !ok {
+			continue
+		}
+
+		value := scalarText(fields[s.config.ValueProperty])
+		if value == "" {
+			continue
+		}
+
+		label := scalarText(fields[s.config.LabelProperty])
+		if label == "" {
+			label = value
+		}
+
+		options = append(options, Option{Label: label, Value: value})
+	}
+
+	return options, nil
+}
+
+// itemsAt returns the array at a dot-separated path of a JSON document
+func itemsAt(document any, path string) ([]any, error) {
+	if path != "" {
+		for _, segment := range strings.Split(path, ".") {
+			object, ok := document.(map[string]any)
+			if !ok {
+				return nil, fmt.Errorf("options response has no %q", path)
+			}
+
+			document = object[segment]
+		}
+	}
+
+	items, ok := document.([]any)
+	if !ok {
+		return nil, fmt.Errorf("options response has no array at %q", path)
+	}
+
+	return items, nil
+}
+
+// scalarText returns a string, number or boolean as text, and "" for anything else
+func scalarText(value any) string {
+	switch v := value.(type) {
+	case string:
+		return strings.TrimSpace(v)
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	case bool:
+		return strconv.FormatBool(v)
+	default:
+		return ""
+	}
+}
@@ -0,0 +1,269 @@
+// Package optionsource loads the options of select, radio and selectboxes
+// fields from named lists, so dropdowns such as countries, products or
+// departments stay current without editing the form schema. A component names
+// its list with the "optionsSource" property; lists are either built-in
+// datasets, external sources configured under form.options.sources, fetched
+// server-side and cached for their TTL, or "dataset:<name>" for a dataset the
+// form's owner manages. When a fetch fails the last list fetched is used until
+// the source recovers.
+package optionsource
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"golang.org/x/sync/singleflight"
+
+	"github.com/goformx/goforms/internal/domain/form/model"
+	"github.com/goformx/goforms/internal/infrastructure/cache"
+	"github.com/goformx/goforms/internal/infrastructure/config"
+	"github.com/goformx/goforms/internal/infrastructure/httpclient"
+	"github.com/goformx/goforms/internal/infrastructure/logging"
+)
+
+// SchemaProperty is the component property naming a component's option list
+const SchemaProperty = "optionsSource"
+
+// ManagedDatasetPrefix marks a list name as one of the form owner's managed datasets
+const ManagedDatasetPrefix = "dataset:"
+
+// ManagedDatasetLabelProperty is the component property naming the dataset column
+// option labels are taken from
+const ManagedDatasetLabelProperty = "datasetLabel"
+
+// cacheKeyPrefix namespaces option lists in the shared cache
+const cacheKeyPrefix = "options:"
+
+// ErrUnknownSource is returned for a list name that is neither a dataset nor a configured source
+var ErrUnknownSource = errors.New("unknown option source")
+
+// Option is one choice of a field
+type Option struct {
+	Label string `json:"label"`
+	Value string `json:"value"`
+}
+
+// Source produces an option list
+type Source interface {
+	Options(ctx context.Context) ([]Option, error)
+}
+
+// ManagedDatasetFunc returns the options a form owner's dataset gives a field, labelled
+// from labelColumn
+type ManagedDatasetFunc func(ctx context.Context, ownerID, name, labelColumn string) ([]Option, error)
+
+// registration is a source and how long its lists are cached
+type registration struct {
+	source Source
+	ttl    time.Duration
+}
+
+// Resolver looks up option lists by name and fills them into form schemas
+type Resolver struct {
+	sources map[string]registration
+	cache   cache.Cache
+	logger  logging.Logger
+	group   singleflight.Group
+	// managed loads the datasets form owners manage, which the dataset service caches
+	managed ManagedDatasetFunc
+
+	mu sync.RWMutex
+	// last keeps the last list fetched from each source, used when a fetch fails
+	last map[string][]Option
+}
+
+// New creates a resolver of the built-in datasets and the sources of
+// form.options.sources. clients may be nil; c may be nil to fetch lists on
+// every use.
+func New(cfg config.OptionSourcesConfig, c cache.Cache, clients *httpclient.Factory, logger logging.Logger) (*Resolver, error) {
+	r := &Resolver{
+		sources: make(map[string]registration, len(datasets)+len(cfg.Sources)),
+		cache:   c,
+		logger:  logger.WithComponent("option_sources"),
+		last:    make(map[string][]Option),
+	}
+
+	for name, dataset := range datasets {
+		r.sources[name] = registration{source: dataset}
+	}
+
+	for _, sourceCfg := range cfg.Sources {
+		if _, taken := r.sources[sourceCfg.Name]; taken {
+			return nil, fmt.Errorf("option source %q: the name is taken", sourceCfg.Name)
+		}
+
+		ttl := sourceCfg.TTL
+		if ttl <= 0 {
+			ttl = cfg.TTL
+		}
+
+		client := clients.Client(config.ResilienceTargetOptions, sourceCfg.Name, cfg.Timeout)
+		r.sources[sourceCfg.Name] = registration{source: NewHTTPSource(sourceCfg, client), ttl: ttl}
+	}
+
+	return r, nil
+}
+
+// UseManagedDatasets lets components name a form owner's datasets with "dataset:<name>"
+func (r *Resolver) UseManagedDatasets(datasets ManagedDatasetFunc) {
+	r.managed = datasets
+}
+
+// Options returns the list called name, from the cache while it is fresh
+func (r *Resolver) Options(ctx context.Context, name string) ([]Option, error) {
+	registered, ok := r.sources[name]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrUnknownSource, name)
+	}
+
+	// Datasets are compiled in and need no caching
+	if registered.ttl <= 0 {
+		return registered.source.Options(ctx)
+	}
+
+	if options, found := r.cached(ctx, name); found {
+		return options, nil
+	}
+
+	result, err, _ := r.group.Do(name, func() (any, error) {
+		return r.fetch(ctx, name, registered)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	options, _ := result.([]Option)
+
+	return options, nil
+}
+
+// cached returns a list from the shared cache
+func (r *Resolver) cached(ctx context.Context, name string) ([]Option, bool) {
+	if r.cache == nil {
+		return nil, false
+	}
+
+	payload, found, err := r.cache.Get(ctx, cacheKeyPrefix+name)
+	if err != nil || !found {
+		return nil, false
+	}
+
+	var options []Option
+	if err = json.Unmarshal(payload, &options); err != nil {
+		return nil, false
+	}
+
+	return options, true
+}
+
+// fetch loads a list from its source and caches it. A failed fetch falls back
+// to the last list fetched, if any.
+func (r *Resolver) fetch(ctx context.Context, name string, registered registration) ([]Option, error) {
+	options, err := registered.source.Options(ctx)
+	if err != nil {
+		r.mu.RLock()
+		last, ok := r.last[name]
+		r.mu.RUnlock()
+
+		if !ok {
+			return nil, fmt.Errorf("fetch option source %s: %w", name, err)
+		}
+
+		r.logger.Warn("option source failed, using the last list fetched", "source", name, "error", err)
+
+		return last, nil
+	}
+
+	r.mu.Lock()
+	r.last[name] = options
+	r.mu.Unlock()
+
+	if r.cache != nil {
+		if payload, encodeErr := json.Marshal(options); encodeErr == nil {
+			if setErr := r.cache.Set(ctx, cacheKeyPrefix+name, payload, registered.ttl); setErr != nil {
+				r.logger.Warn("failed to cache option list", "source", name, "error", setErr)
+			}
+		}
+	}
+
+	return options, nil
+}
+
+// ResolveSchema returns a copy of schema whose components naming an option
+// list have its options: select components under data.values, radio and
+// selectboxes components under values. Dataset lists are those of ownerID. A
+// component whose list cannot be loaded keeps the options it has, and the
+// error is logged.
+func (r *Resolver) ResolveSchema(ctx context.Context, ownerID string, schema model.JSON) model.JSON {
+	if r == nil || !HasSources(schema) {
+		return schema
+	}
+
+	resolved := schema.Clone()
+
+	_ = model.WalkSchema(resolved, func(component map[string]any) error {
+		name, _ := component[SchemaProperty].(string)
+		if name == "" {
+			return nil
+		}
+
+		options, err := r.componentOptions(ctx, ownerID, name, component)
+		if err != nil {
+			r.logger.Warn("failed to load field options", "source", name, "key", component["key"], "error", err)
+
+			return nil
+		}
+
+		values := make([]any, 0, len(options))
+		for _, option := range options {
+			values = append(values, map[string]any{"label": option.Label, "value": option.Value})
+		}
+
+		if component["type"] == "select" {
+			component["dataSrc"] = "values"
+			component["data"] = map[string]any{"values": values}
+		} else {
+			component["values"] = values
+		}
+
+		return nil
+	})
+
+	return resolved
+}
+
+// componentOptions loads the list a component names
+func (r *Resolver) componentOptions(ctx context.Context, ownerID, name string, component map[string]any) ([]Option, error) {
+	dataset, isManaged := strings.CutPrefix(name, ManagedDatasetPrefix)
+	if !isManaged {
+		return r.Options(ctx, name)
+	}
+
+	if r.managed == nil || ownerID == "" {
+		return nil, fmt.Errorf("%w: %s", ErrUnknownSource, name)
+	}
+
+	labelColumn, _ := component[ManagedDatasetLabelProperty].(string)
+
+	return r.managed(ctx, ownerID, dataset, labelColumn)
+}
+
+// HasSources reports whether a component of schema names an option list
+func HasSources(schema model.JSON) bool {
+	found := false
+
+	_ = model.WalkSchema(schema, func(component map[string]any) error {
+		if name, _ := component[SchemaProperty].(string); name != "" {
+			found = true
+		}
+
+		return nil
+	})
+
+	return found
+}
@@ -0,0 +1,148 @@
+package optionsource_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/goformx/goforms/internal/domain/form/model"
+	"github.com/goformx/goforms/internal/infrastructure/cache"
+	"github.com/goformx/goforms/internal/infrastructure/config"
+	"github.com/goformx/goforms/internal/infrastructure/optionsource"
+	mocklogging "github.com/goformx/goforms/test/mocks/logging"
+)
+
+func newResolver(t *testing.T, sources ...config.OptionSourceConfig) *optionsource.Resolver {
+	t.Helper()
+
+	logger := mocklogging.NewMockLogger(gomock.NewController(t))
+	logger.EXPECT().WithComponent(gomock.Any()).Return(logger).AnyTimes()
+	logger.EXPECT().Warn(gomock.Any(), gomock.Any()).AnyTimes()
+
+	resolver, err := optionsource.New(config.OptionSourcesConfig{
+		TTL:     time.Minute,
+		Timeout: time.Second,
+		Sources: sources,
+	}, cache.NewMemoryCache(100), nil, logger)
+	require.NoError(t, err)
+
+	return resolver
+}
+
+func TestResolver_HTTPSource(t *testing.T) {
+	var calls atomic.Int32
+
+	failing := atomic.Bool{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		assert.Equal(t, "secret", r.Header.Get("X-Api-Key"))
+
+		if failing.Load() {
+			w.WriteHeader(http.StatusBadGateway)
+
+			return
+		}
+
+		_, _ = w.Write([]byte(`{"data":{"items":[{"sku":"A1","name":"Widget"},{"sku":42},{"name":"no sku"}]}}`))
+	}))
+	t.Cleanup(server.Close)
+
+	products := config.OptionSourceConfig{
+		Name: "products", URL: server.URL, Path: "data.items", ValueProperty: "sku", LabelProperty: "name",
+		Headers: map[string]string{"x-api-key": "secret"},
+	}
+	resolver := newResolver(t, products)
+
+	options, err := resolver.Options(t.Context(), "products")
+	require.NoError(t, err)
+	assert.Equal(t, []optionsource.Option{{Label: "Widget", Value: "A1"}, {Label: "42", Value: "42"}}, options)
+
+	_, err = resolver.Options(t.Context(), "products")
+	require.NoError(t, err)
+	assert.Equal(t, int32(1), calls.Load(), "lists are cached for their TTL")
+
+	// Once the cached list expires, a failing source keeps the last list fetched
+	shortLived := products
+	shortLived.TTL = time.Millisecond
+	resolver = newResolver(t, shortLived)
+	_, err = resolver.Options(t.Context(), "products")
+	require.NoError(t, err)
+
+	failing.Store(true)
+	time.Sleep(5 * time.Millisecond)
+
+	options, err = resolver.Options(t.Context(), "products")
+	require.NoError(t, err)
+	assert.Len(t, options, 2)
+	assert.Equal(t, int32(3), calls.Load(), "the expired list was fetched again")
+
+	_, err = newResolver(t, products).Options(t.Context(), "products")
+	require.Error(t, err, "a failing source without a list fetched before is an error")
+
+	_, err = resolver.Options(t.Context(), "missing")
+	require.ErrorIs(t, err, optionsource.ErrUnknownSource)
+}
+
+func TestResolver_ResolveSchema(t *testing.T) {
+	resolver := newResolver(t)
+
+	schema := model.JSON{"components": []any{
+		map[string]any{"type": "select", "key": "country", "optionsSource": optionsource.DatasetCountries, "dataSrc": "url"},
+		map[string]any{"type": "radio", "key": "origin", "optionsSource": optionsource.DatasetCountries},
+		map[string]any{"type": "select", "key": "unknown", "optionsSource": "missing", "data": map[string]any{"values": []any{}}},
+	}}
+
+	resolved := resolver.ResolveSchema(t.Context(), "user-1", schema)
+
+	components := resolved["components"].([]any)
+	country := components[0].(map[string]any)
+	assert.Equal(t, "values", country["dataSrc"])
+	assert.Len(t, country["data"].(map[string]any)["values"], len(model.Countries))
+	assert.Len(t, components[1].(map[string]any)["values"], len(model.Countries))
+	assert.Empty(t, components[2].(map[string]any)["data"].(map[string]any)["values"], "an unknown list leaves the options alone")
+
+	assert.Equal(t, "url", schema["components"].([]any)[0].(map[string]any)["dataSrc"], "the stored schema is left alone")
+
+	plain := model.JSON{"components": []any{map[string]any{"type": "textfield", "key": "name"}}}
+	assert.Equal(t, plain, resolver.ResolveSchema(t.Context(), "user-1", plain))
+}
+
+func TestResolver_ManagedDatasets(t *testing.T) {
+	resolver := newResolver(t)
+
+	schema := model.JSON{"components": []any{
+		map[string]any{"type": "select", "key": "coupon", "optionsSource": "dataset:coupons", "datasetLabel": "title"},
+	}}
+
+	resolved := resolver.ResolveSchema(t.Context(), "user-1", schema)
+	assert.NotContains(t, resolved["components"].([]any)[0], "data", "managed datasets are unknown until enabled")
+
+	resolver.UseManagedDatasets(func(_ context.Context, ownerID, name, labelColumn string) ([]optionsource.Option, error) {
+		assert.Equal(t, "user-1", ownerID)
+		assert.Equal(t, "coupons", name)
+		assert.Equal(t, "title", labelColumn)
+
+		return []optionsource.Option{{Label: "Spring sale", Value: "SPRING"}}, nil
+	})
+
+	resolved = resolver.ResolveSchema(t.Context(), "user-1", schema)
+	coupon := resolved["components"].([]any)[0].(map[string]any)
+	assert.Equal(t, []any{map[string]any{"label": "Spring sale", "value": "SPRING"}}, coupon["data"].(map[string]any)["values"])
+}
+
+func TestNew_RejectsTakenNames(t *testing.T) {
+	logger := mocklogging.NewMockLogger(gomock.NewController(t))
+	logger.EXPECT().WithComponent(gomock.Any()).Return(logger).AnyTimes()
+
+	_, err := optionsource.New(config.OptionSourcesConfig{
+		Sources: []config.OptionSourceConfig{{Name: optionsource.DatasetCountries, URL: "https://example.com"}},
+	}, nil, nil, logger)
+	require.Error(t, err)
+}
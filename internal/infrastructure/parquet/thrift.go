@@ -0,0 +1,94 @@
+package parquet
+
+import (
+	"bytes"
+	"encoding/binary"
+)
+
+// Thrift compact protocol type IDs
+const (
+	compactI32    = 5
+	compactI64    = 6
+	compactBinary = 8
+	compactList   = 9
+	compactStruct = 12
+)
+
+// compactWriter writes the Thrift compact protocol subset used by Parquet metadata
+type compactWriter struct {
+	buf bytes.Buffer
+	// lastField holds the previous field ID of each open struct
+	lastField []int16
+}
+
+// begin opens a struct
+func (w *compactWriter) begin() {
+	w.lastField = append(w.lastField, 0)
+}
+
+// end closes a struct with a stop byte
+func (w *compactWriter) end() {
+	w.buf.WriteByte(0)
+	w.lastField = w.lastField[:len(w.lastField)-1]
+}
+
+// field writes a field header, using the short delta form when it fits
+func (w *compactWriter) field(id int16, typ byte) {
+	last := &w.lastField[len(w.lastField)-1]
+
+	if delta := id - *last; delta > 0 && delta <= 15 {
+		w.buf.WriteByte(byte(delta)<<4 | typ)
+	} else {
+		w.buf.WriteByte(typ)
+		w.varint(int64(id))
+	}
+
+	*last = id
+}
+
+func (w *compactWriter) i32(id int16, v int32) {
+	w.field(id, compactI32)
+	w.varint(int64(v))
+}
+
+func (w *compactWriter) i64(id int16, v int64) {
+	w.field(id, compactI64)
+	w.varint(v)
+}
+
+func (w *compactWriter) str(id int16, v string) {
+	w.field(id, compactBinary)
+	w.binary(v)
+}
+
+// structField opens a struct-typed field; close it with end
+func (w *compactWriter) structField(id int16) {
+	w.field(id, compactStruct)
+	w.begin()
+}
+
+// listField writes a list field header; the elements follow
+func (w *compactWriter) listField(id int16, elemType byte, size int) {
+	w.field(id, compactList)
+
+	if size < 15 {
+		w.buf.WriteByte(byte(size)<<4 | elemType)
+	} else {
+		w.buf.WriteByte(0xf0 | elemType)
+		w.uvarint(uint64(size))
+	}
+}
+
+func (w *compactWriter) binary(v string) {
+	w.uvarint(uint64(len(v)))
+	w.buf.WriteString(v)
+}
+
+// varint writes a zigzag-encoded integer
+func (w *compactWriter) varint(v int64) {
+	w.buf.Write(binary.AppendVarint(nil, v))
+}
+
+func (w *compactWriter) uvarint(v uint64) {
+	w.buf.Write(binary.AppendUvarint(nil, v))
+}
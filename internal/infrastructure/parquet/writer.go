@@ -0,0 +1,402 @@
+// Package parquet provides a minimal, dependency-free Parquet writer for flat
+// tables such as submission exports. Every column is optional (nullable) and
+// holds strings, 64-bit integers, doubles, booleans or millisecond UTC
+// timestamps. Values are PLAIN encoded in one gzip-compressed data page per
+// column chunk, which DuckDB, Spark, Athena, BigQuery and pyarrow all read.
+package parquet
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math"
+	"time"
+)
+
+// Type is the type of a column's values
+type Type int
+
+const (
+	// String columns hold UTF-8 text
+	String Type = iota
+	// Int64 columns hold 64-bit signed integers
+	Int64
+	// Double columns hold 64-bit floats
+	Double
+	// Bool columns hold booleans
+	Bool
+	// Timestamp columns hold UTC instants with millisecond precision
+	Timestamp
+)
+
+// Parquet physical types, converted types, encodings and codecs used by this writer
+const (
+	physicalBoolean   = 0
+	physicalInt64     = 2
+	physicalDouble    = 5
+	physicalByteArray = 6
+
+	convertedUTF8            = 0
+	convertedTimestampMillis = 9
+
+	repetitionOptional = 1
+
+	encodingPlain = 0
+	encodingRLE   = 3
+
+	codecGzip = 2
+
+	pageTypeData = 0
+
+	formatVersion = 1
+)
+
+// DefaultRowGroupSize is the number of rows per row group when none is set
+const DefaultRowGroupSize = 10000
+
+// magic starts and ends every Parquet file
+var magic = []byte("PAR1")
+
+// ErrRowLength is returned when a row does not have one value per column
+var ErrRowLength = errors.New("row does not have one value per column")
+
+// Column describes a table column
+type Column struct {
+	Name string
+	Type Type
+}
+
+// Table is a set of rows written as a Parquet file
+type Table struct {
+	// RowGroupSize is the number of rows per row group; zero uses DefaultRowGroupSize
+	RowGroupSize int
+	// CreatedBy is recorded in the file footer
+	CreatedBy string
+
+	columns []Column
+	rows    [][]any
+}
+
+// NewTable creates an empty table with the given columns
+func NewTable(columns ...Column) *Table {
+	return &Table{columns: columns}
+}
+
+// AddRow appends a row. A nil value is a null; other values must match the
+// column type: string, int64, float64, bool or time.Time.
+func (t *Table) AddRow(values ...any) error {
+	if len(values) != len(t.columns) {
+		return ErrRowLength
+	}
+
+	for i, value := range values {
+		if value != nil && !t.columns[i].Type.accepts(value) {
+			return fmt.Errorf("column %q: unexpected value of type %T", t.columns[i].Name, value)
+		}
+	}
+
+	t.rows = append(t.rows, values)
+
+	return nil
+}
+
+// Len returns the number of rows
+func (t *Table) Len() int {
+	return len(t.rows)
+}
+
+// Bytes renders the table as a Parquet file
+func (t *Table) Bytes() ([]byte, error) {
+	size := t.RowGroupSize
+	if size <= 0 {
+		size = DefaultRowGroupSize
+	}
+
+	var out bytes.Buffer
+
+	out.Write(magic)
+
+	var groups []rowGroup
+
+	for start := 0; start < len(t.rows); start += size {
+		group, err := t.writeRowGroup(&out, t.rows[start:min(start+size, len(t.rows))])
+		if err != nil {
+			return nil, err
+		}
+
+		groups = append(groups, group)
+	}
+
+	footer := t.footer(groups)
+	out.Write(footer)
+	out.Write(binary.LittleEndian.AppendUint32(nil, uint32(len(footer))))
+	out.Write(magic)
+
+	return out.Bytes(), nil
+}
+
+// rowGroup records where a row group's column chunks were written
+type rowGroup struct {
+	rows   int
+	chunks []columnChunk
+}
+
+// columnChunk records a written column chunk
+type columnChunk struct {
+	offset           int64
+	values           int
+	uncompressedSize int64
+	compressedSize   int64
+}
+
+// writeRowGroup writes one data page per column for rows
+func (t *Table) writeRowGroup(out *bytes.Buffer, rows [][]any) (rowGroup, error) {
+	group := rowGroup{rows: len(rows), chunks: make([]columnChunk, 0, len(t.columns))}
+
+	for i, column := range t.columns {
+		page := encodePage(column.Type, rows, i)
+
+		var compressed bytes.Buffer
+
+		zw := gzip.NewWriter(&compressed)
+		if _, err := zw.Write(page); err != nil {
+			return rowGroup{}, fmt.Errorf("compress column %q: %w", column.Name, err)
+		}
+
+		if err := zw.Close(); err != nil {
+			return rowGroup{}, fmt.Errorf("compress column %q: %w", column.Name, err)
+		}
+
+		header := pageHeader(len(rows), len(page), compressed.Len())
+
+		group.chunks = append(group.chunks, columnChunk{
+			offset:           int64(out.Len()),
+			values:           len(rows),
+			uncompressedSize: int64(len(header) + len(page)),
+			compressedSize:   int64(len(header) + compressed.Len()),
+		})
+
+		out.Write(header)
+		out.Write(compressed.Bytes())
+	}
+
+	return group, nil
+}
+
+// encodePage encodes a column's definition levels and non-null values as a v1 data page
+func encodePage(typ Type, rows [][]any, column int) []byte {
+	levels := make([]bool, len(rows))
+
+	var values bytes.Buffer
+
+	var bits []bool
+
+	for r, row := range rows {
+		value := row[column]
+		if value == nil {
+			continue
+		}
+
+		levels[r] = true
+
+		switch v := value.(type) {
+		case string:
+			values.Write(binary.LittleEndian.AppendUint32(nil, uint32(len(v))))
+			values.WriteString(v)
+		case int64:
+			values.Write(binary.LittleEndian.AppendUint64(nil, uint64(v)))
+		case float64:
+			values.Write(binary.LittleEndian.AppendUint64(nil, math.Float64bits(v)))
+		case bool:
+			bits = append(bits, v)
+		case time.Time:
+			values.Write(binary.LittleEndian.AppendUint64(nil, uint64(v.UnixMilli())))
+		}
+	}
+
+	if typ == Bool {
+		values.Write(packBits(bits))
+	}
+
+	encodedLevels := encodeLevels(levels)
+
+	page := make([]byte, 0, 4+len(encodedLevels)+values.Len())
+	page = binary.LittleEndian.AppendUint32(page, uint32(len(encodedLevels)))
+	page = append(page, encodedLevels...)
+
+	return append(page, values.Bytes()...)
+}
+
+// encodeLevels encodes definition levels of bit width 1 as RLE runs of the
+// RLE/bit-packing hybrid encoding
+func encodeLevels(levels []bool) []byte {
+	var out []byte
+
+	for start := 0; start < len(levels); {
+		end := start
+		for end < len(levels) && levels[end] == levels[start] {
+			end++
+		}
+
+		out = binary.AppendUvarint(out, uint64(end-start)<<1)
+
+		if levels[start] {
+			out = append(out, 1)
+		} else {
+			out = append(out, 0)
+		}
+
+		start = end
+	}
+
+	return out
+}
+
+// packBits packs booleans one bit each, least significant bit first
+func packBits(bits []bool) []byte {
+	out := make([]byte, (len(bits)+7)/8)
+
+	for i, bit := range bits {
+		if bit {
+			out[i/8] |= 1 << (i % 8)
+		}
+	}
+
+	return out
+}
+
+// pageHeader encodes the header of a v1 data page
+func pageHeader(values, uncompressedSize, compressedSize int) []byte {
+	var w compactWriter
+
+	w.begin()
+	w.i32(1, pageTypeData)
+	w.i32(2, int32(uncompressedSize))
+	w.i32(3, int32(compressedSize))
+	w.structField(5)
+	w.i32(1, int32(values))
+	w.i32(2, encodingPlain)
+	w.i32(3, encodingRLE)
+	w.i32(4, encodingRLE)
+	w.end()
+	w.end()
+
+	return w.buf.Bytes()
+}
+
+// footer encodes the file metadata
+func (t *Table) footer(groups []rowGroup) []byte {
+	var w compactWriter
+
+	w.begin()
+	w.i32(1, formatVersion)
+
+	w.listField(2, compactStruct, len(t.columns)+1)
+	w.begin()
+	w.str(4, "schema")
+	w.i32(5, int32(len(t.columns)))
+	w.end()
+
+	for _, column := range t.columns {
+		w.begin()
+		w.i32(1, column.Type.physical())
+		w.i32(3, repetitionOptional)
+		w.str(4, column.Name)
+
+		if converted, ok := column.Type.converted(); ok {
+			w.i32(6, converted)
+		}
+
+		w.end()
+	}
+
+	w.i64(3, int64(len(t.rows)))
+
+	w.listField(4, compactStruct, len(groups))
+
+	for _, group := range groups {
+		var total int64
+
+		w.begin()
+		w.listField(1, compactStruct, len(group.chunks))
+
+		for i, chunk := range group.chunks {
+			total += chunk.uncompressedSize
+
+			w.begin()
+			w.i64(2, chunk.offset)
+			w.structField(3)
+			w.i32(1, t.columns[i].Type.physical())
+			w.listField(2, compactI32, 2)
+			w.varint(encodingPlain)
+			w.varint(encodingRLE)
+			w.listField(3, compactBinary, 1)
+			w.binary(t.columns[i].Name)
+			w.i32(4, codecGzip)
+			w.i64(5, int64(chunk.values))
+			w.i64(6, chunk.uncompressedSize)
+			w.i64(7, chunk.compressedSize)
+			w.i64(9, chunk.offset)
+			w.end()
+			w.end()
+		}
+
+		w.i64(2, total)
+		w.i64(3, int64(group.rows))
+		w.end()
+	}
+
+	if t.CreatedBy != "" {
+		w.str(6, t.CreatedBy)
+	}
+
+	w.end()
+
+	return w.buf.Bytes()
+}
+
+// accepts reports whether value can be stored in a column of this type
+func (typ Type) accepts(value any) bool {
+	switch value.(type) {
+	case string:
+		return typ == String
+	case int64:
+		return typ == Int64
+	case float64:
+		return typ == Double
+	case bool:
+		return typ == Bool
+	case time.Time:
+		return typ == Timestamp
+	default:
+		return false
+	}
+}
+
+// physical returns the Parquet physical type
+func (typ Type) physical() int32 {
+	switch typ {
+	case Int64, Timestamp:
+		return physicalInt64
+	case Double:
+		return physicalDouble
+	case Bool:
+		return physicalBoolean
+	default:
+		return physicalByteArray
+	}
+}
+
+// converted returns the Parquet converted type, if any
+func (typ Type) converted() (int32, bool) {
+	switch typ {
+	case String:
+		return convertedUTF8, true
+	case Timestamp:
+		return convertedTimestampMillis, true
+	default:
+		return 0, false
+	}
+}
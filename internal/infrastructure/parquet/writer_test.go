@@ -0,0 +1,55 @@
+package parquet_test
+
+import (
+	"bytes"
+	"encoding/binary"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/goformx/goforms/internal/infrastructure/parquet"
+)
+
+func TestTable_Bytes(t *testing.T) {
+	table := parquet.NewTable(
+		parquet.Column{Name: "submission_id", Type: parquet.String},
+		parquet.Column{Name: "submitted_at", Type: parquet.Timestamp},
+		parquet.Column{Name: "age", Type: parquet.Double},
+		parquet.Column{Name: "subscribed", Type: parquet.Bool},
+	)
+	table.RowGroupSize = 2
+	table.CreatedBy = "goforms"
+
+	require.NoError(t, table.AddRow("a", time.Now(), 36.5, true))
+	require.NoError(t, table.AddRow("b", nil, nil, nil))
+	require.NoError(t, table.AddRow("c", time.Now(), 41.0, false))
+	assert.Equal(t, 3, table.Len())
+
+	data, err := table.Bytes()
+	require.NoError(t, err)
+
+	require.True(t, bytes.HasPrefix(data, []byte("PAR1")))
+	require.True(t, bytes.HasSuffix(data, []byte("PAR1")))
+
+	footerLength := int(binary.LittleEndian.Uint32(data[len(data)-8:]))
+	require.Less(t, footerLength, len(data)-12)
+
+	footer := data[len(data)-8-footerLength : len(data)-8]
+	for _, name := range []string{"submission_id", "submitted_at", "age", "subscribed", "goforms"} {
+		assert.Contains(t, string(footer), name)
+	}
+}
+
+func TestTable_AddRowChecksValues(t *testing.T) {
+	table := parquet.NewTable(parquet.Column{Name: "count", Type: parquet.Int64})
+
+	require.ErrorIs(t, table.AddRow(int64(1), "extra"), parquet.ErrRowLength)
+	require.Error(t, table.AddRow("1"))
+	require.NoError(t, table.AddRow(nil))
+
+	data, err := table.Bytes()
+	require.NoError(t, err)
+	assert.True(t, bytes.HasPrefix(data, []byte("PAR1")))
+}
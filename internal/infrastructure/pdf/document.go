@@ -0,0 +1,298 @@
+// Package pdf provides a minimal, dependency-free PDF writer for text documents
+// such as submission records. It supports the standard Helvetica fonts,
+// automatic line wrapping, pagination and per-page header/footer lines.
+package pdf
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"strings"
+)
+
+const (
+	pageWidth    = 612.0 // US Letter, in points
+	pageHeight   = 792.0
+	marginX      = 56.0
+	marginTop    = 64.0
+	marginBottom = 64.0
+
+	// avgCharWidth approximates Helvetica glyph width as a fraction of font size
+	avgCharWidth = 0.5
+)
+
+// Style selects the font and size of a line
+type Style int
+
+const (
+	// StyleBody is regular 10pt text
+	StyleBody Style = iota
+	// StyleLabel is bold 10pt text
+	StyleLabel
+	// StyleTitle is bold 16pt text
+	StyleTitle
+	// StyleMuted is regular 8pt text used for headers and footers
+	StyleMuted
+)
+
+// PageInfo is passed to header and footer callbacks
+type PageInfo struct {
+	Page  int
+	Pages int
+}
+
+type line struct {
+	text  string
+	style Style
+	gap   float64
+}
+
+// Document accumulates lines and renders them to PDF
+type Document struct {
+	lines  []line
+	Header func(PageInfo) string
+	Footer func(PageInfo) string
+}
+
+// NewDocument creates an empty document
+func NewDocument() *Document {
+	return &Document{}
+}
+
+// AddText appends text in the given style, wrapping it to the page width.
+// Embedded newlines start new lines.
+func (d *Document) AddText(text string, style Style) {
+	size := fontSize(style)
+	maxChars := int((pageWidth - 2*marginX) / (size * avgCharWidth))
+
+	for _, paragraph := range strings.Split(text, "\n") {
+		for _, wrapped := range wrap(paragraph, maxChars) {
+			d.lines = append(d.lines, line{text: wrapped, style: style})
+		}
+	}
+}
+
+// AddSpace appends vertical whitespace in points
+func (d *Document) AddSpace(points float64) {
+	d.lines = append(d.lines, line{gap: points})
+}
+
+// Bytes renders the document and returns the encoded PDF
+func (d *Document) Bytes() []byte {
+	var buf bytes.Buffer
+
+	_ = d.Render(&buf) // writes to a bytes.Buffer cannot fail
+
+	return buf.Bytes()
+}
+
+// Render writes the encoded PDF to w
+func (d *Document) Render(w io.Writer) error {
+	pages := d.paginate()
+
+	var (
+		buf     bytes.Buffer
+		offsets []int
+	)
+
+	addObject := func(body string) {
+		offsets = append(offsets, buf.Len())
+		fmt.Fprintf(&buf, "%d 0 obj\n%s\nendobj\n", len(offsets), body)
+	}
+
+	buf.WriteString("%PDF-1.4\n%\xe2\xe3\xcf\xd3\n")
+
+	// Object layout: 1 catalog, 2 pages, 3-4 fonts, then page/content pairs
+	const firstPageObject = 5
+
+	kids := make([]string, len(pages))
+	for i := range pages {
+		kids[i] = fmt.Sprintf("%d 0 R", firstPageObject+i*2)
+	}
+
+	addObject("<< /Type /Catalog /Pages 2 0 R >>")
+	addObject(fmt.Sprintf("<< /Type /Pages /Kids [%s] /Count %d >>", strings.Join(kids, " "), len(pages)))
+	addObject("<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica /Encoding /WinAnsiEncoding >>")
+	addObject("<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica-Bold /Encoding /WinAnsiEncoding >>")
+
+	for i, content := range pages {
+		stream := d.pageStream(content, PageInfo{Page: i + 1, Pages: len(pages)})
+
+		addObject(fmt.Sprintf(
+			"<< /Type /Page /Parent 2 0 R /MediaBox [0 0 %g %g] "+
+				"/Resources << /Font << /F1 3 0 R /F2 4 0 R >> >> /Contents %d 0 R >>",
+			pageWidth, pageHeight, firstPageObject+i*2+1))
+		addObject(fmt.Sprintf("<< /Length %d >>\nstream\n%s\nendstream", len(stream), stream))
+	}
+
+	xref := buf.Len()
+	fmt.Fprintf(&buf, "xref\n0 %d\n0000000000 65535 f \n", len(offsets)+1)
+
+	for _, offset := range offsets {
+		fmt.Fprintf(&buf, "%010d 00000 n \n", offset)
+	}
+
+	fmt.Fprintf(&buf, "trailer\n<< /Size %d /Root 1 0 R >>\nstartxref\n%d\n%%%%EOF\n", len(offsets)+1, xref)
+
+	if _, err := w.Write(buf.Bytes()); err != nil {
+		return fmt.Errorf("write pdf: %w", err)
+	}
+
+	return nil
+}
+
+// paginate splits lines into pages that fit between the margins
+func (d *Document) paginate() [][]line {
+	var (
+		pages   [][]line
+		current []line
+	)
+
+	available := pageHeight - marginTop - marginBottom
+	used := 0.0
+
+	for _, l := range d.lines {
+		height := l.gap
+		if l.gap == 0 {
+			height = lineHeight(l.style)
+		}
+
+		if used+height > available && len(current) > 0 {
+			pages = append(pages, current)
+			current, used = nil, 0
+		}
+
+		current = append(current, l)
+		used += height
+	}
+
+	return append(pages, current)
+}
+
+// pageStream builds the content stream for one page
+func (d *Document) pageStream(lines []line, info PageInfo) string {
+	var b strings.Builder
+
+	if d.Header != nil {
+		writeText(&b, StyleMuted, marginX, pageHeight-marginTop/2, d.Header(info))
+	}
+
+	y := pageHeight - marginTop
+
+	for _, l := range lines {
+		if l.gap > 0 {
+			y -= l.gap
+
+			continue
+		}
+
+		y -= lineHeight(l.style)
+		writeText(&b, l.style, marginX, y, l.text)
+	}
+
+	if d.Footer != nil {
+		writeText(&b, StyleMuted, marginX, marginBottom/2, d.Footer(info))
+	}
+
+	return b.String()
+}
+
+// writeText emits a single positioned text run
+func writeText(b *strings.Builder, style Style, x, y float64, text string) {
+	if text == "" {
+		return
+	}
+
+	font := "F1"
+	if style == StyleLabel || style == StyleTitle {
+		font = "F2"
+	}
+
+	fmt.Fprintf(b, "BT /%s %g Tf %.2f %.2f Td (%s) Tj ET\n", font, fontSize(style), x, y, escape(text))
+}
+
+func fontSize(style Style) float64 {
+	switch style {
+	case StyleTitle:
+		return 16
+	case StyleMuted:
+		return 8
+	case StyleBody, StyleLabel:
+		return 10
+	}
+
+	return 10
+}
+
+func lineHeight(style Style) float64 {
+	return fontSize(style) * 1.4
+}
+
+// escape converts text to a PDF literal string body in WinAnsi encoding.
+// Characters outside Latin-1 are replaced with '?'.
+func escape(text string) string {
+	var b strings.Builder
+
+	for _, r := range text {
+		switch {
+		case r == '(' || r == ')' || r == '\\':
+			b.WriteByte('\\')
+			b.WriteRune(r)
+		case r == '\t':
+			b.WriteByte(' ')
+		case r < 0x20:
+			continue
+		case r < 0x80:
+			b.WriteRune(r)
+		case r <= 0xff:
+			fmt.Fprintf(&b, "\\%03o", r)
+		default:
+			b.WriteByte('?')
+		}
+	}
+
+	return b.String()
+}
+
+// wrap splits a paragraph into lines of at most maxChars characters,
+// breaking on spaces where possible
+func wrap(text string, maxChars int) []string {
+	if maxChars <= 0 || len([]rune(text)) <= maxChars {
+		return []string{text}
+	}
+
+	var (
+		lines   []string
+		current []rune
+	)
+
+	for _, word := range strings.Fields(text) {
+		runes := []rune(word)
+
+		for len(runes) > maxChars {
+			if len(current) > 0 {
+				lines = append(lines, string(current))
+				current = nil
+			}
+
+			lines = append(lines, string(runes[:maxChars]))
+			runes = runes[maxChars:]
+		}
+
+		switch {
+		case len(current) == 0:
+			current = runes
+		case len(current)+1+len(runes) <= maxChars:
+			current = append(append(current, ' '), runes...)
+		default:
+			lines = append(lines, string(current))
+			current = runes
+		}
+	}
+
+	if len(current) > 0 {
+		lines = append(lines, string(current))
+	}
+
+	return lines
+}
@@ -0,0 +1,39 @@
+package pdf_test
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/goformx/goforms/internal/infrastructure/pdf"
+)
+
+func TestDocument_Bytes(t *testing.T) {
+	doc := pdf.NewDocument()
+	doc.Footer = func(info pdf.PageInfo) string {
+		return "page " + string(rune('0'+info.Page))
+	}
+
+	doc.AddText("Title (draft)", pdf.StyleTitle)
+	doc.AddText(strings.Repeat("lorem ipsum ", 40), pdf.StyleBody)
+
+	out := doc.Bytes()
+
+	assert.True(t, bytes.HasPrefix(out, []byte("%PDF-1.4")))
+	assert.True(t, bytes.HasSuffix(out, []byte("%%EOF\n")))
+	assert.Contains(t, string(out), `(Title \(draft\)) Tj`)
+	assert.Contains(t, string(out), "/Count 1")
+	assert.Contains(t, string(out), "(page 1) Tj")
+}
+
+func TestDocument_Paginates(t *testing.T) {
+	doc := pdf.NewDocument()
+
+	for range 120 {
+		doc.AddText("line", pdf.StyleBody)
+	}
+
+	assert.Contains(t, string(doc.Bytes()), "/Count 3")
+}
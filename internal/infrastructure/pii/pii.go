@@ -0,0 +1,308 @@
+// Package pii detects personal data such as email addresses, phone numbers and
+// national ID numbers in free text, and masks it for logs and exports.
+package pii
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"slices"
+	"sort"
+	"strings"
+	"unicode"
+)
+
+// Kind names a category of personal data
+type Kind string
+
+const (
+	// KindEmail is an email address
+	KindEmail Kind = "email"
+	// KindPhone is a phone number written with a country code or separators
+	KindPhone Kind = "phone"
+	// KindNationalID is a national identification number from a configured profile
+	KindNationalID Kind = "national_id"
+)
+
+// ErrUnknownProfile is returned for a national ID profile that is not built in
+var ErrUnknownProfile = errors.New("unknown pii profile")
+
+// Match is one piece of personal data found in a string
+type Match struct {
+	Kind  Kind
+	Start int
+	End   int
+}
+
+// detector finds one kind of personal data. valid, when set, rejects matches
+// the pattern alone cannot rule out, such as numbers with a bad checksum.
+type detector struct {
+	kind    Kind
+	pattern *regexp.Regexp
+	valid   func(string) bool
+}
+
+var (
+	emailPattern = regexp.MustCompile(`[A-Za-z0-9._%+-]+@[A-Za-z0-9-]+(?:\.[A-Za-z0-9-]+)*\.[A-Za-z]{2,}`)
+	// Phone numbers either start with a country code or use the (555) 123-4567
+	// layout, so bare digit runs such as IDs and timestamps are not matched
+	phonePattern = regexp.MustCompile(
+		`\+\d{1,3}[\s.-]?(?:\(\d{1,4}\)[\s.-]?)?\d{1,4}(?:[\s.-]?\d{2,4}){1,4}|\(?\b\d{3}\)?[\s.-]\d{3}[\s.-]\d{4}\b`)
+)
+
+// minPhoneDigits and maxPhoneDigits bound the digits in a phone number (E.164)
+const (
+	minPhoneDigits = 8
+	maxPhoneDigits = 15
+)
+
+// profiles holds the national ID detectors by profile name
+var profiles = map[string]detector{
+	// US Social Security numbers
+	"us": {
+		kind:    KindNationalID,
+		pattern: regexp.MustCompile(`\b\d{3}-\d{2}-\d{4}\b`),
+		valid:   validSSN,
+	},
+	// UK National Insurance numbers
+	"uk": {
+		kind:    KindNationalID,
+		pattern: regexp.MustCompile(`\b[A-CEGHJ-PR-TW-Z][A-CEGHJ-NPR-TW-Z] ?\d{2} ?\d{2} ?\d{2} ?[A-D]\b`),
+		valid:   validNINO,
+	},
+	// Canadian Social Insurance numbers
+	"ca": {
+		kind:    KindNationalID,
+		pattern: regexp.MustCompile(`\b\d{3}[ -]\d{3}[ -]\d{3}\b`),
+		valid:   luhn,
+	},
+}
+
+// Profiles returns the names of the built-in national ID profiles
+func Profiles() []string {
+	names := make([]string, 0, len(profiles))
+	for name := range profiles {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	return names
+}
+
+// Classifier finds personal data in text. It always detects email addresses
+// and phone numbers, plus the national ID formats of its profiles. A
+// Classifier is safe for concurrent use.
+type Classifier struct {
+	detectors []detector
+}
+
+// NewClassifier creates a classifier detecting the national ID formats of the
+// given profiles, such as "us" or "uk"
+func NewClassifier(profileNames []string) (*Classifier, error) {
+	c := &Classifier{detectors: []detector{
+		{kind: KindEmail, pattern: emailPattern},
+		{kind: KindPhone, pattern: phonePattern, valid: validPhone},
+	}}
+
+	for _, name := range profileNames {
+		profile, ok := profiles[strings.ToLower(name)]
+		if !ok {
+			return nil, fmt.Errorf("%w: %q", ErrUnknownProfile, name)
+		}
+
+		c.detectors = append(c.detectors, profile)
+	}
+
+	return c, nil
+}
+
+// Detect returns the personal data found in value, in order. Where matches of
+// different kinds overlap, the one starting first (then the longest) wins.
+func (c *Classifier) Detect(value string) []Match {
+	var matches []Match
+
+	for _, d := range c.detectors {
+		for _, loc := range d.pattern.FindAllStringIndex(value, -1) {
+			if !bounded(value, loc[0], loc[1]) {
+				continue
+			}
+
+			if d.valid != nil && !d.valid(value[loc[0]:loc[1]]) {
+				continue
+			}
+
+			matches = append(matches, Match{Kind: d.kind, Start: loc[0], End: loc[1]})
+		}
+	}
+
+	slices.SortFunc(matches, func(a, b Match) int {
+		if a.Start != b.Start {
+			return a.Start - b.Start
+		}
+
+		return b.End - a.End
+	})
+
+	kept := matches[:0]
+
+	for _, match := range matches {
+		if len(kept) > 0 && match.Start < kept[len(kept)-1].End {
+			continue
+		}
+
+		kept = append(kept, match)
+	}
+
+	return kept
+}
+
+// Classify returns the kind of personal data value consists of, or "" when it
+// is not wholly a single email address, phone number or national ID
+func (c *Classifier) Classify(value string) Kind {
+	trimmed := strings.TrimSpace(value)
+
+	matches := c.Detect(trimmed)
+	if len(matches) == 1 && matches[0].Start == 0 && matches[0].End == len(trimmed) {
+		return matches[0].Kind
+	}
+
+	return ""
+}
+
+// Redact replaces the personal data in value with a placeholder naming its kind
+func (c *Classifier) Redact(value string) string {
+	matches := c.Detect(value)
+	if len(matches) == 0 {
+		return value
+	}
+
+	var b strings.Builder
+
+	last := 0
+
+	for _, match := range matches {
+		b.WriteString(value[last:match.Start])
+		b.WriteString(Placeholder(match.Kind))
+		last = match.End
+	}
+
+	b.WriteString(value[last:])
+
+	return b.String()
+}
+
+// Placeholder is the text Redact puts in place of personal data
+func Placeholder(kind Kind) string {
+	return "[" + string(kind) + "]"
+}
+
+// Mask hides most of value while keeping enough to recognise it: the first
+// character and domain of an email address, the last four digits of a phone
+// number or ID, or the last two characters of anything else
+func Mask(kind Kind, value string) string {
+	switch kind {
+	case KindEmail:
+		if at := strings.LastIndexByte(value, '@'); at > 0 {
+			return value[:1] + "***" + value[at:]
+		}
+	case KindPhone, KindNationalID:
+		return maskDigits(value, 4)
+	}
+
+	runes := []rune(value)
+	if len(runes) <= 2 {
+		return strings.Repeat("*", len(runes))
+	}
+
+	return strings.Repeat("*", len(runes)-2) + string(runes[len(runes)-2:])
+}
+
+// maskDigits replaces all but the last keep digits and letters in value with
+// '*', leaving separators in place
+func maskDigits(value string, keep int) string {
+	runes := []rune(value)
+
+	for i := len(runes) - 1; i >= 0; i-- {
+		if !unicode.IsDigit(runes[i]) && !unicode.IsLetter(runes[i]) {
+			continue
+		}
+
+		if keep > 0 {
+			keep--
+
+			continue
+		}
+
+		runes[i] = '*'
+	}
+
+	return string(runes)
+}
+
+// bounded reports whether value[start:end] is not part of a longer word or
+// identifier, so a number inside a UUID or a reference such as "INV-555-123-4567"
+// is not matched
+func bounded(value string, start, end int) bool {
+	return (start == 0 || !joins(value[start-1])) && (end == len(value) || !joins(value[end]))
+}
+
+// joins reports whether c continues a word or identifier
+func joins(c byte) bool {
+	return c >= '0' && c <= '9' || c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c == '-' || c == '_'
+}
+
+// validPhone checks the number of digits in a phone number
+func validPhone(value string) bool {
+	digits := 0
+
+	for _, r := range value {
+		if unicode.IsDigit(r) {
+			digits++
+		}
+	}
+
+	return digits >= minPhoneDigits && digits <= maxPhoneDigits
+}
+
+// validSSN rejects Social Security numbers that are never issued
+func validSSN(value string) bool {
+	area, group, serial := value[0:3], value[4:6], value[7:11]
+
+	return area != "000" && area != "666" && area[0] != '9' && group != "00" && serial != "0000"
+}
+
+// validNINO rejects National Insurance prefixes that are never issued
+func validNINO(value string) bool {
+	switch strings.ToUpper(value[:2]) {
+	case "BG", "GB", "NK", "KN", "TN", "NT", "ZZ":
+		return false
+	}
+
+	return true
+}
+
+// luhn checks the Luhn checksum of the digits in value
+func luhn(value string) bool {
+	sum, double := 0, false
+
+	for i := len(value) - 1; i >= 0; i-- {
+		r := value[i]
+		if r < '0' || r > '9' {
+			continue
+		}
+
+		digit := int(r - '0')
+		if double {
+			digit *= 2
+			if digit > 9 {
+				digit -= 9
+			}
+		}
+
+		sum += digit
+		double = !double
+	}
+
+	return sum%10 == 0
+}
@@ -0,0 +1,70 @@
+package pii_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/goformx/goforms/internal/infrastructure/pii"
+)
+
+func TestClassifier_Redact(t *testing.T) {
+	classifier, err := pii.NewClassifier([]string{"us", "uk"})
+	require.NoError(t, err)
+
+	tests := []struct {
+		name  string
+		input string
+		want  string
+	}{
+		{"email", "sent to ada@example.com.", "sent to [email]."},
+		{"international phone", "call +44 20 7946 0958 today", "call [phone] today"},
+		{"north american phone", "(555) 123-4567", "[phone]"},
+		{"ssn", "ssn 123-45-6789", "ssn [national_id]"},
+		{"never issued ssn", "ssn 666-45-6789", "ssn 666-45-6789"},
+		{"nino", "NI number AB 12 34 56 C", "NI number [national_id]"},
+		{"uuid", "form 550e8400-e29b-41d4-a716-446655440000", "form 550e8400-e29b-41d4-a716-446655440000"},
+		{"timestamp", "at 2025-06-01T12:00:00+02:00", "at 2025-06-01T12:00:00+02:00"},
+		{"reference", "order INV-555-123-4567", "order INV-555-123-4567"},
+		{"several", "ada@example.com or +1 555 123 4567", "[email] or [phone]"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, classifier.Redact(tt.input))
+		})
+	}
+}
+
+func TestClassifier_Profiles(t *testing.T) {
+	us, err := pii.NewClassifier([]string{"us"})
+	require.NoError(t, err)
+	assert.Equal(t, pii.Kind(""), us.Classify("046 454 286"))
+
+	ca, err := pii.NewClassifier([]string{"ca"})
+	require.NoError(t, err)
+	assert.Equal(t, pii.KindNationalID, ca.Classify("046 454 286"))
+	assert.Equal(t, pii.Kind(""), ca.Classify("046 454 287"), "fails the Luhn check")
+
+	_, err = pii.NewClassifier([]string{"atlantis"})
+	require.ErrorIs(t, err, pii.ErrUnknownProfile)
+}
+
+func TestClassifier_Classify(t *testing.T) {
+	classifier, err := pii.NewClassifier(nil)
+	require.NoError(t, err)
+
+	assert.Equal(t, pii.KindEmail, classifier.Classify(" ada@example.com "))
+	assert.Equal(t, pii.KindPhone, classifier.Classify("+1 555 123 4567"))
+	assert.Equal(t, pii.Kind(""), classifier.Classify("write to ada@example.com"))
+	assert.Equal(t, pii.Kind(""), classifier.Classify("12345678"))
+}
+
+func TestMask(t *testing.T) {
+	assert.Equal(t, "a***@example.com", pii.Mask(pii.KindEmail, "ada@example.com"))
+	assert.Equal(t, "+* *** ***-4567", pii.Mask(pii.KindPhone, "+1 555 123-4567"))
+	assert.Equal(t, "***-**-6789", pii.Mask(pii.KindNationalID, "123-45-6789"))
+	assert.Equal(t, "******ce", pii.Mask("", "Lovelace"))
+	assert.Equal(t, "**", pii.Mask("", "ab"))
+}
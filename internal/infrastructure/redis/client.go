@@ -0,0 +1,197 @@
+// Package redis is a minimal Redis client speaking RESP over pooled TCP
+// connections. It covers the string commands the stores and caches need.
+package redis
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	// dialTimeout bounds connection setup
+	dialTimeout = 5 * time.Second
+	// ioTimeout bounds a single command round trip when the context has no deadline
+	ioTimeout = 3 * time.Second
+	// maxIdle is the number of idle connections kept for reuse
+	maxIdle = 8
+)
+
+// ErrNil is returned for a nil reply, such as GET on a missing key
+var ErrNil = errors.New("redis: nil reply")
+
+// Client runs commands against one Redis server
+type Client struct {
+	addr     string
+	password string
+	db       int
+	idle     chan *redisConn
+}
+
+// NewClient creates a client for the Redis server at addr. Connections are
+// dialed on first use.
+func NewClient(addr, password string, db int) *Client {
+	return &Client{
+		addr:     addr,
+		password: password,
+		db:       db,
+		idle:     make(chan *redisConn, maxIdle),
+	}
+}
+
+// Do runs one command on a pooled connection and returns the reply. Integer and
+// status replies are returned as strings; a nil reply returns ErrNil. Connections
+// that fail are discarded.
+func (c *Client) Do(ctx context.Context, args ...string) (string, error) {
+	conn, err := c.get(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	reply, err := conn.command(ctx, args...)
+	if err != nil && !errors.Is(err, ErrNil) && !isRedisError(err) {
+		_ = conn.Close()
+
+		return "", err
+	}
+
+	c.put(conn)
+
+	return reply, err
+}
+
+// get returns an idle connection or dials a new one
+func (c *Client) get(ctx context.Context) (*redisConn, error) {
+	select {
+	case conn := <-c.idle:
+		return conn, nil
+	default:
+	}
+
+	dialer := net.Dialer{Timeout: dialTimeout}
+
+	raw, err := dialer.DialContext(ctx, "tcp", c.addr)
+	if err != nil {
+		return nil, fmt.Errorf("dial redis: %w", err)
+	}
+
+	conn := &redisConn{Conn: raw, reader: bufio.NewReader(raw)}
+
+	if c.password != "" {
+		if _, authErr := conn.command(ctx, "AUTH", c.password); authErr != nil {
+			_ = conn.Close()
+
+			return nil, fmt.Errorf("redis auth: %w", authErr)
+		}
+	}
+
+	if c.db != 0 {
+		if _, selectErr := conn.command(ctx, "SELECT", strconv.Itoa(c.db)); selectErr != nil {
+			_ = conn.Close()
+
+			return nil, fmt.Errorf("redis select: %w", selectErr)
+		}
+	}
+
+	return conn, nil
+}
+
+// put returns a connection to the idle pool, closing it when the pool is full
+func (c *Client) put(conn *redisConn) {
+	select {
+	case c.idle <- conn:
+	default:
+		_ = conn.Close()
+	}
+}
+
+// redisError is an error reply sent by the server
+type redisError string
+
+func (e redisError) Error() string {
+	return "redis: " + string(e)
+}
+
+// isRedisError reports whether err is a server error reply, which leaves the connection usable
+func isRedisError(err error) bool {
+	var replyErr redisError
+
+	return errors.As(err, &replyErr)
+}
+
+// redisConn is a single connection speaking RESP
+type redisConn struct {
+	net.Conn
+	reader *bufio.Reader
+}
+
+// command writes args as a RESP array and reads one reply. Integer and
+// status replies are returned as strings.
+func (c *redisConn) command(ctx context.Context, args ...string) (string, error) {
+	deadline, ok := ctx.Deadline()
+	if !ok {
+		deadline = time.Now().Add(ioTimeout)
+	}
+
+	if err := c.SetDeadline(deadline); err != nil {
+		return "", fmt.Errorf("set redis deadline: %w", err)
+	}
+
+	var b strings.Builder
+
+	fmt.Fprintf(&b, "*%d\r\n", len(args))
+
+	for _, arg := range args {
+		fmt.Fprintf(&b, "$%d\r\n%s\r\n", len(arg), arg)
+	}
+
+	if _, err := io.WriteString(c.Conn, b.String()); err != nil {
+		return "", fmt.Errorf("write redis command: %w", err)
+	}
+
+	return c.readReply()
+}
+
+// readReply reads a simple string, error, integer or bulk string reply
+func (c *redisConn) readReply() (string, error) {
+	line, err := c.reader.ReadString('\n')
+	if err != nil {
+		return "", fmt.Errorf("read redis reply: %w", err)
+	}
+
+	line = strings.TrimSuffix(line, "\r\n")
+	if line == "" {
+		return "", errors.New("redis: empty reply")
+	}
+
+	switch line[0] {
+	case '+', ':':
+		return line[1:], nil
+	case '-':
+		return "", redisError(line[1:])
+	case '$':
+		size, convErr := strconv.Atoi(line[1:])
+		if convErr != nil {
+			return "", fmt.Errorf("redis: invalid bulk length %q", line[1:])
+		}
+
+		if size < 0 {
+			return "", ErrNil
+		}
+
+		buf := make([]byte, size+2)
+		if _, readErr := io.ReadFull(c.reader, buf); readErr != nil {
+			return "", fmt.Errorf("read redis bulk reply: %w", readErr)
+		}
+
+		return string(buf[:size]), nil
+	default:
+		return "", fmt.Errorf("redis: unsupported reply type %q", line[0])
+	}
+}
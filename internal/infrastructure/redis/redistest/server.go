@@ -0,0 +1,192 @@
+// Package redistest serves an in-memory subset of Redis for unit tests of code
+// built on the redis client, so they run without a Redis server.
+package redistest
+
+import (
+	"bufio"
+	"io"
+	"net"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// Start serves the SET/GET/GETDEL/DEL/INCRBY/PEXPIRE subset of RESP on a local
+// port until the test ends and returns its address. Expiry options are accepted
+// and ignored.
+func Start(t testing.TB) string {
+	t.Helper()
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("listen: %v", err)
+	}
+
+	t.Cleanup(func() { _ = listener.Close() })
+
+	srv := &server{data: make(map[string]string)}
+
+	go func() {
+		for {
+			conn, acceptErr := listener.Accept()
+			if acceptErr != nil {
+				return
+			}
+
+			go srv.serve(conn)
+		}
+	}()
+
+	return listener.Addr().String()
+}
+
+// server holds the keys of one fake Redis
+type server struct {
+	mu   sync.Mutex
+	data map[string]string
+}
+
+// serve answers the commands of one connection until it is closed
+func (s *server) serve(conn net.Conn) {
+	defer conn.Close()
+
+	reader := bufio.NewReader(conn)
+
+	for {
+		args, err := readCommand(reader)
+		if err != nil {
+			return
+		}
+
+		s.mu.Lock()
+		reply := s.handle(args)
+		s.mu.Unlock()
+
+		if _, writeErr := io.WriteString(conn, reply); writeErr != nil {
+			return
+		}
+	}
+}
+
+// readCommand reads one command sent as a RESP array of bulk strings
+func readCommand(reader *bufio.Reader) ([]string, error) {
+	line, err := reader.ReadString('\n')
+	if err != nil {
+		return nil, err
+	}
+
+	count, err := strconv.Atoi(strings.TrimSpace(line[1:]))
+	if err != nil {
+		return nil, err
+	}
+
+	args := make([]string, 0, count)
+
+	for range count {
+		header, headerErr := reader.ReadString('\n')
+		if headerErr != nil {
+			return nil, headerErr
+		}
+
+		size, sizeErr := strconv.Atoi(strings.TrimSpace(header[1:]))
+		if sizeErr != nil {
+			return nil, sizeErr
+		}
+
+		buf := make([]byte, size+2)
+		if _, readErr := io.ReadFull(reader, buf); readErr != nil {
+			return nil, readErr
+		}
+
+		args = append(args, string(buf[:size]))
+	}
+
+	return args, nil
+}
+
+// handle runs one command and returns its RESP reply
+func (s *server) handle(args []string) string {
+	if len(args) < 2 {
+		return "-ERR wrong number of arguments\r\n"
+	}
+
+	key := args[1]
+
+	switch strings.ToUpper(args[0]) {
+	case "SET":
+		if len(args) < 3 {
+			return "-ERR wrong number of arguments\r\n"
+		}
+
+		nx := false
+
+		for _, arg := range args[3:] {
+			if strings.EqualFold(arg, "NX") {
+				nx = true
+			}
+		}
+
+		if _, exists := s.data[key]; exists && nx {
+			return "$-1\r\n"
+		}
+
+		s.data[key] = args[2]
+
+		return "+OK\r\n"
+	case "GET", "GETDEL":
+		value, ok := s.data[key]
+		if !ok {
+			return "$-1\r\n"
+		}
+
+		if strings.EqualFold(args[0], "GETDEL") {
+			delete(s.data, key)
+		}
+
+		return bulk(value)
+	case "DEL":
+		_, existed := s.data[key]
+		delete(s.data, key)
+
+		if existed {
+			return ":1\r\n"
+		}
+
+		return ":0\r\n"
+	case "INCRBY":
+		if len(args) < 3 {
+			return "-ERR wrong number of arguments\r\n"
+		}
+
+		by, err := strconv.ParseInt(args[2], 10, 64)
+		if err != nil {
+			return "-ERR value is not an integer or out of range\r\n"
+		}
+
+		current := int64(0)
+		if value, ok := s.data[key]; ok {
+			if current, err = strconv.ParseInt(value, 10, 64); err != nil {
+				return "-ERR value is not an integer or out of range\r\n"
+			}
+		}
+
+		current += by
+		s.data[key] = strconv.FormatInt(current, 10)
+
+		return ":" + s.data[key] + "\r\n"
+	case "PEXPIRE":
+		if _, ok := s.data[key]; ok {
+			return ":1\r\n"
+		}
+
+		return ":0\r\n"
+	default:
+		return "-ERR unknown command\r\n"
+	}
+}
+
+// bulk formats a bulk string reply
+func bulk(value string) string {
+	return "$" + strconv.Itoa(len(value)) + "\r\n" + value + "\r\n"
+}
@@ -0,0 +1,61 @@
+package repository
+
+import (
+	"cmp"
+	"context"
+	"slices"
+	"time"
+
+	"github.com/goformx/goforms/internal/domain/archive"
+	"github.com/goformx/goforms/internal/domain/form/model"
+	"github.com/goformx/goforms/internal/infrastructure/database"
+	"github.com/goformx/goforms/internal/infrastructure/logging"
+)
+
+// ShardedStore implements archive.Repository over the shards submissions are
+// spread over
+type ShardedStore struct {
+	stores []*Store
+}
+
+// NewShardedStore creates a submission archive store that reads and deletes in every shard
+func NewShardedStore(shards *database.Shards, logger logging.Logger) archive.Repository {
+	stores := make([]*Store, 0, len(shards.IDs()))
+	for _, id := range shards.IDs() {
+		stores = append(stores, &Store{db: shards.DB(id), logger: logger.With("shard", id)})
+	}
+
+	return &ShardedStore{stores: stores}
+}
+
+// Expired returns up to limit submissions submitted before cutoff, oldest first.
+// Each shard's oldest limit submissions are merged, which holds the oldest overall.
+func (s *ShardedStore) Expired(ctx context.Context, cutoff time.Time, limit int) ([]*model.FormSubmission, error) {
+	var submissions []*model.FormSubmission
+
+	for _, store := range s.stores {
+		expired, err := store.Expired(ctx, cutoff, limit)
+		if err != nil {
+			return nil, err
+		}
+
+		submissions = append(submissions, expired...)
+	}
+
+	slices.SortFunc(submissions, func(a, b *model.FormSubmission) int {
+		return cmp.Or(a.SubmittedAt.Compare(b.SubmittedAt), cmp.Compare(a.ID, b.ID))
+	})
+
+	return submissions[:min(limit, len(submissions))], nil
+}
+
+// Delete deletes the submissions with the given IDs from every shard
+func (s *ShardedStore) Delete(ctx context.Context, ids []string) error {
+	for _, store := range s.stores {
+		if err := store.Delete(ctx, ids); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,59 @@
+// Package repository provides the submission archive repository implementation
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/goformx/goforms/internal/domain/archive"
+	"github.com/goformx/goforms/internal/domain/form/model"
+	"github.com/goformx/goforms/internal/infrastructure/database"
+	"github.com/goformx/goforms/internal/infrastructure/logging"
+	"github.com/goformx/goforms/internal/infrastructure/repository/common"
+	"github.com/goformx/goforms/internal/infrastructure/repository/tables"
+)
+
+// Store implements archive.Repository
+type Store struct {
+	db     database.DB
+	logger logging.Logger
+}
+
+// NewStore creates a new submission archive store
+func NewStore(db database.DB, logger logging.Logger) archive.Repository {
+	return &Store{
+		db:     db,
+		logger: logger,
+	}
+}
+
+// Expired returns up to limit submissions submitted before cutoff, oldest first
+func (s *Store) Expired(ctx context.Context, cutoff time.Time, limit int) ([]*model.FormSubmission, error) {
+	var submissions []*model.FormSubmission
+	if err := s.db.GetDB().WithContext(ctx).
+		Where(tables.Submissions.SubmittedAt.Lt(cutoff)).
+		Order(tables.Submissions.SubmittedAt.Asc()).
+		Order(tables.Submissions.UUID.Asc()).
+		Limit(limit).
+		Find(&submissions).Error; err != nil {
+		return nil, fmt.Errorf("list expired submissions: %w",
+			common.NewDatabaseError("list", "form_submission", "", err))
+	}
+
+	return submissions, nil
+}
+
+// Delete deletes the submissions with the given IDs
+func (s *Store) Delete(ctx context.Context, ids []string) error {
+	if len(ids) == 0 {
+		return nil
+	}
+
+	if err := s.db.GetDB().WithContext(ctx).Where(tables.Submissions.UUID.In(ids)).Delete(&model.FormSubmission{}).Error; err != nil {
+		return fmt.Errorf("delete archived submissions: %w",
+			common.NewDatabaseError("delete", "form_submission", "", err))
+	}
+
+	return nil
+}
@@ -0,0 +1,76 @@
+// Package repository provides the audit log repository implementation
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"gorm.io/gorm"
+
+	"github.com/goformx/goforms/internal/domain/audit"
+	"github.com/goformx/goforms/internal/infrastructure/database"
+	"github.com/goformx/goforms/internal/infrastructure/logging"
+	"github.com/goformx/goforms/internal/infrastructure/repository/common"
+	"github.com/goformx/goforms/internal/infrastructure/repository/tables"
+)
+
+// Store implements audit.Repository
+type Store struct {
+	db     database.DB
+	logger logging.Logger
+}
+
+// NewStore creates a new audit log store
+func NewStore(db database.DB, logger logging.Logger) audit.Repository {
+	return &Store{
+		db:     db,
+		logger: logger,
+	}
+}
+
+// CreateEntry stores an audit entry
+func (s *Store) CreateEntry(ctx context.Context, entry *audit.Entry) error {
+	if err := s.db.GetDB().WithContext(ctx).Create(entry).Error; err != nil {
+		return fmt.Errorf("create audit entry: %w", common.NewDatabaseError("create", "audit_entry", "", err))
+	}
+
+	return nil
+}
+
+// ListEntries lists matching entries, newest first, with the total count
+func (s *Store) ListEntries(ctx context.Context, filter audit.Filter, offset, limit int) ([]*audit.Entry, int64, error) {
+	var total int64
+	if err := s.filtered(ctx, filter).Model(&audit.Entry{}).Count(&total).Error; err != nil {
+		return nil, 0, fmt.Errorf("count audit entries: %w", common.NewDatabaseError("list", "audit_entry", "", err))
+	}
+
+	var entries []*audit.Entry
+	if err := s.filtered(ctx, filter).
+		Order(tables.AuditLogs.CreatedAt.Desc()).
+		Offset(offset).
+		Limit(limit).
+		Find(&entries).Error; err != nil {
+		return nil, 0, fmt.Errorf("list audit entries: %w", common.NewDatabaseError("list", "audit_entry", "", err))
+	}
+
+	return entries, total, nil
+}
+
+// filtered starts a query restricted to the entries matching filter
+func (s *Store) filtered(ctx context.Context, filter audit.Filter) *gorm.DB {
+	query := s.db.GetDB().WithContext(ctx)
+
+	if filter.ActorID != "" {
+		query = query.Where(tables.AuditLogs.ActorID.Eq(filter.ActorID))
+	}
+
+	if filter.TargetID != "" {
+		query = query.Where(tables.AuditLogs.TargetID.Eq(filter.TargetID))
+	}
+
+	if filter.Action != "" {
+		query = query.Where(tables.AuditLogs.Action.Eq(filter.Action))
+	}
+
+	return query
+}
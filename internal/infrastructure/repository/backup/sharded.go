@@ -0,0 +1,137 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"github.com/goformx/goforms/internal/domain/backup"
+	"github.com/goformx/goforms/internal/domain/form/model"
+	"github.com/goformx/goforms/internal/infrastructure/database"
+	"github.com/goformx/goforms/internal/infrastructure/logging"
+	"github.com/goformx/goforms/internal/infrastructure/repository/common"
+	"github.com/goformx/goforms/internal/infrastructure/repository/tables"
+)
+
+// formIDBatchSize is the most form IDs bound to one shard query
+const formIDBatchSize = 1000
+
+// ShardedStore implements backup.Repository when submissions are sharded. Forms
+// are read from and written to the primary database and submissions to the
+// shards holding their forms.
+type ShardedStore struct {
+	db     database.DB
+	shards *database.Shards
+	logger logging.Logger
+}
+
+// NewShardedStore creates a backup data store over the primary database and the shards
+func NewShardedStore(db database.DB, shards *database.Shards, logger logging.Logger) backup.Repository {
+	return &ShardedStore{
+		db:     db,
+		shards: shards,
+		logger: logger,
+	}
+}
+
+// Dump reads every form that is not deleted and the submissions to those forms.
+// Every shard is asked for every form, so submissions a rebalance has yet to
+// move are dumped too.
+func (s *ShardedStore) Dump(ctx context.Context) (*backup.Dataset, error) {
+	var forms []*model.Form
+	if err := s.db.GetDB().WithContext(ctx).Order(tables.Forms.CreatedAt.Asc()).Find(&forms).Error; err != nil {
+		return nil, fmt.Errorf("dump forms: %w", common.NewDatabaseError("list", "form", "", err))
+	}
+
+	formIDs := make([]string, 0, len(forms))
+	for _, f := range forms {
+		formIDs = append(formIDs, f.ID)
+	}
+
+	var submissions []*model.FormSubmission
+
+	for _, id := range s.shards.IDs() {
+		for batch := range slices.Chunk(formIDs, formIDBatchSize) {
+			var shardSubmissions []*model.FormSubmission
+			if err := s.shards.DB(id).GetDB().WithContext(ctx).
+				Where(tables.Submissions.FormID.In(batch)).
+				Find(&shardSubmissions).Error; err != nil {
+				return nil, fmt.Errorf("dump form submissions of shard %s: %w", id,
+					common.NewDatabaseError("list", "form_submission", "", err))
+			}
+
+			submissions = append(submissions, shardSubmissions...)
+		}
+	}
+
+	slices.SortStableFunc(submissions, func(a, b *model.FormSubmission) int {
+		return a.CreatedAt.Compare(b.CreatedAt)
+	})
+
+	dataset := &backup.Dataset{
+		Forms:       make([]backup.Form, 0, len(forms)),
+		Submissions: make([]backup.Submission, 0, len(submissions)),
+	}
+
+	for _, f := range forms {
+		dataset.Forms = append(dataset.Forms, backup.NewForm(f))
+	}
+
+	for _, submission := range submissions {
+		dataset.Submissions = append(dataset.Submissions, backup.NewSubmission(submission))
+	}
+
+	return dataset, nil
+}
+
+// Restore writes the dataset's forms in one transaction of the primary database,
+// then each shard's submissions in one transaction of that shard. Existing rows
+// with the same IDs, including deleted forms, are overwritten. A failed shard
+// leaves the forms and the shards before it restored; restoring again is safe.
+func (s *ShardedStore) Restore(ctx context.Context, dataset *backup.Dataset) error {
+	forms := make([]*model.Form, 0, len(dataset.Forms))
+	for _, f := range dataset.Forms {
+		forms = append(forms, f.Model())
+	}
+
+	byShard := make(map[string][]*model.FormSubmission)
+
+	for _, submission := range dataset.Submissions {
+		row := submission.Model()
+		id := s.shards.Locate(row.FormID)
+		byShard[id] = append(byShard[id], row)
+	}
+
+	// Forms and submissions are both keyed by their uuid column
+	upsert := clause.OnConflict{Columns: common.Unqualified(tables.Forms.UUID), UpdateAll: true}
+
+	if len(forms) > 0 {
+		err := s.db.GetDB().WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+			return tx.Unscoped().Omit(clause.Associations).Clauses(upsert).CreateInBatches(forms, restoreBatchSize).Error
+		})
+		if err != nil {
+			return fmt.Errorf("restore backup: %w",
+				common.NewDatabaseError("restore", "backup", "", fmt.Errorf("restore forms: %w", err)))
+		}
+	}
+
+	for _, id := range s.shards.IDs() {
+		submissions := byShard[id]
+		if len(submissions) == 0 {
+			continue
+		}
+
+		err := s.shards.DB(id).GetDB().WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+			return tx.Clauses(upsert).CreateInBatches(submissions, restoreBatchSize).Error
+		})
+		if err != nil {
+			return fmt.Errorf("restore backup: %w", common.NewDatabaseError("restore", "backup", "",
+				fmt.Errorf("restore form submissions of shard %s: %w", id, err)))
+		}
+	}
+
+	return nil
+}
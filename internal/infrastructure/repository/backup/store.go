@@ -0,0 +1,107 @@
+// Package repository provides the backup data repository implementation
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"github.com/goformx/goforms/internal/domain/backup"
+	"github.com/goformx/goforms/internal/domain/form/model"
+	"github.com/goformx/goforms/internal/infrastructure/database"
+	"github.com/goformx/goforms/internal/infrastructure/logging"
+	"github.com/goformx/goforms/internal/infrastructure/repository/common"
+	"github.com/goformx/goforms/internal/infrastructure/repository/tables"
+)
+
+// restoreBatchSize is the number of rows written per insert during a restore
+const restoreBatchSize = 100
+
+// Store implements backup.Repository
+type Store struct {
+	db     database.DB
+	logger logging.Logger
+}
+
+// NewStore creates a new backup data store
+func NewStore(db database.DB, logger logging.Logger) backup.Repository {
+	return &Store{
+		db:     db,
+		logger: logger,
+	}
+}
+
+// Dump reads every form that is not deleted and the submissions to those forms
+func (s *Store) Dump(ctx context.Context) (*backup.Dataset, error) {
+	db := s.db.GetDB().WithContext(ctx)
+
+	var forms []*model.Form
+	if err := db.Order(tables.Forms.CreatedAt.Asc()).Find(&forms).Error; err != nil {
+		return nil, fmt.Errorf("dump forms: %w", common.NewDatabaseError("list", "form", "", err))
+	}
+
+	var submissions []*model.FormSubmission
+	if err := db.
+		Where(tables.Submissions.FormID.InQuery(db.Model(&model.Form{}).Clauses(common.Select(tables.Forms.UUID)))).
+		Order(tables.Submissions.CreatedAt.Asc()).
+		Find(&submissions).Error; err != nil {
+		return nil, fmt.Errorf("dump form submissions: %w",
+			common.NewDatabaseError("list", "form_submission", "", err))
+	}
+
+	dataset := &backup.Dataset{
+		Forms:       make([]backup.Form, 0, len(forms)),
+		Submissions: make([]backup.Submission, 0, len(submissions)),
+	}
+
+	for _, f := range forms {
+		dataset.Forms = append(dataset.Forms, backup.NewForm(f))
+	}
+
+	for _, submission := range submissions {
+		dataset.Submissions = append(dataset.Submissions, backup.NewSubmission(submission))
+	}
+
+	return dataset, nil
+}
+
+// Restore writes the dataset's forms and submissions in one transaction.
+// Existing rows with the same IDs, including deleted forms, are overwritten.
+func (s *Store) Restore(ctx context.Context, dataset *backup.Dataset) error {
+	forms := make([]*model.Form, 0, len(dataset.Forms))
+	for _, f := range dataset.Forms {
+		forms = append(forms, f.Model())
+	}
+
+	submissions := make([]*model.FormSubmission, 0, len(dataset.Submissions))
+	for _, submission := range dataset.Submissions {
+		submissions = append(submissions, submission.Model())
+	}
+
+	// Forms and submissions are both keyed by their uuid column
+	upsert := clause.OnConflict{Columns: common.Unqualified(tables.Forms.UUID), UpdateAll: true}
+
+	err := s.db.GetDB().WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if len(forms) > 0 {
+			if err := tx.Unscoped().Omit(clause.Associations).Clauses(upsert).
+				CreateInBatches(forms, restoreBatchSize).Error; err != nil {
+				return fmt.Errorf("restore forms: %w", err)
+			}
+		}
+
+		if len(submissions) > 0 {
+			if err := tx.Clauses(upsert).CreateInBatches(submissions, restoreBatchSize).Error; err != nil {
+				return fmt.Errorf("restore form submissions: %w", err)
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("restore backup: %w", common.NewDatabaseError("restore", "backup", "", err))
+	}
+
+	return nil
+}
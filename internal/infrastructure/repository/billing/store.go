@@ -0,0 +1,83 @@
+// Package repository provides the billing subscription repository implementation
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"github.com/goformx/goforms/internal/domain/billing"
+	"github.com/goformx/goforms/internal/infrastructure/database"
+	"github.com/goformx/goforms/internal/infrastructure/logging"
+	"github.com/goformx/goforms/internal/infrastructure/repository/common"
+	"github.com/goformx/goforms/internal/infrastructure/repository/tables"
+)
+
+// Store implements billing.Repository
+type Store struct {
+	db     database.DB
+	logger logging.Logger
+}
+
+// NewStore creates a new billing subscription store
+func NewStore(db database.DB, logger logging.Logger) billing.Repository {
+	return &Store{
+		db:     db,
+		logger: logger,
+	}
+}
+
+// GetSubscription returns a user's subscription
+func (s *Store) GetSubscription(ctx context.Context, userID string) (*billing.Subscription, error) {
+	var subscription billing.Subscription
+	if err := s.db.GetDB().WithContext(ctx).Where(tables.BillingSubscriptions.UserID.Eq(userID)).First(&subscription).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, billing.ErrSubscriptionNotFound
+		}
+
+		return nil, fmt.Errorf("get billing subscription: %w",
+			common.NewDatabaseError("get", "billing_subscription", userID, err))
+	}
+
+	return &subscription, nil
+}
+
+// GetSubscriptionByCustomer returns the most recently updated subscription of a Stripe customer
+func (s *Store) GetSubscriptionByCustomer(ctx context.Context, customerID string) (*billing.Subscription, error) {
+	var subscription billing.Subscription
+	if err := s.db.GetDB().WithContext(ctx).
+		Where(tables.BillingSubscriptions.CustomerID.Eq(customerID)).
+		Order(tables.BillingSubscriptions.UpdatedAt.Desc()).
+		First(&subscription).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, billing.ErrSubscriptionNotFound
+		}
+
+		return nil, fmt.Errorf("get billing subscription: %w",
+			common.NewDatabaseError("get", "billing_subscription", customerID, err))
+	}
+
+	return &subscription, nil
+}
+
+// SaveSubscription creates or replaces a user's subscription
+func (s *Store) SaveSubscription(ctx context.Context, subscription *billing.Subscription) error {
+	subscriptions := tables.BillingSubscriptions
+	upsert := clause.OnConflict{
+		Columns: common.Unqualified(subscriptions.UserID),
+		DoUpdates: common.Excluded(
+			subscriptions.CustomerID, subscriptions.SubscriptionID, subscriptions.PlanID, subscriptions.Status,
+			subscriptions.CurrentPeriodEnd, subscriptions.CancelAtPeriodEnd, subscriptions.EventAt, subscriptions.UpdatedAt,
+		),
+	}
+
+	if err := s.db.GetDB().WithContext(ctx).Clauses(upsert).Create(subscription).Error; err != nil {
+		return fmt.Errorf("save billing subscription: %w",
+			common.NewDatabaseError("save", "billing_subscription", subscription.UserID, err))
+	}
+
+	return nil
+}
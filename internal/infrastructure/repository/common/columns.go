@@ -0,0 +1,205 @@
+package common
+
+import (
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// Column is a column of a table whose values have type T. A nullable column
+// has the type of its values, not a pointer; IsNull matches its null rows.
+//
+// Stores build conditions, orders, selects and updates from columns instead of
+// writing SQL strings, so a misspelt column or a value of the wrong type does
+// not compile. The columns of each table are declared once, in the tables
+// package, and checked against the models' schemas there.
+type Column[T any] struct {
+	Table string
+	Name  string
+}
+
+// NewColumn declares the column name of table
+func NewColumn[T any](table, name string) Column[T] {
+	return Column[T]{Table: table, Name: name}
+}
+
+// Clause is the column qualified by its table
+func (c Column[T]) Clause() clause.Column {
+	return clause.Column{Table: c.Table, Name: c.Name}
+}
+
+// Build implements clause.Expression, writing the qualified column, so a
+// column can be selected, summed or compared like any other expression
+func (c Column[T]) Build(builder clause.Builder) {
+	builder.WriteQuoted(c.Clause())
+}
+
+// Eq matches rows whose column equals value
+func (c Column[T]) Eq(value T) clause.Expression {
+	return clause.Eq{Column: c.Clause(), Value: value}
+}
+
+// Neq matches rows whose column does not equal value
+func (c Column[T]) Neq(value T) clause.Expression {
+	return clause.Neq{Column: c.Clause(), Value: value}
+}
+
+// Lt matches rows whose column is less than value
+func (c Column[T]) Lt(value T) clause.Expression {
+	return clause.Lt{Column: c.Clause(), Value: value}
+}
+
+// Lte matches rows whose column is at most value
+func (c Column[T]) Lte(value T) clause.Expression {
+	return clause.Lte{Column: c.Clause(), Value: value}
+}
+
+// Gt matches rows whose column is greater than value
+func (c Column[T]) Gt(value T) clause.Expression {
+	return clause.Gt{Column: c.Clause(), Value: value}
+}
+
+// Gte matches rows whose column is at least value
+func (c Column[T]) Gte(value T) clause.Expression {
+	return clause.Gte{Column: c.Clause(), Value: value}
+}
+
+// In matches rows whose column is one of values; none matches no rows
+func (c Column[T]) In(values []T) clause.Expression {
+	vars := make([]any, len(values))
+	for i, value := range values {
+		vars[i] = value
+	}
+
+	return clause.IN{Column: c.Clause(), Values: vars}
+}
+
+// InQuery matches rows whose column is one of the values the subquery selects
+func (c Column[T]) InQuery(subquery *gorm.DB) clause.Expression {
+	return clause.Expr{SQL: "? IN (?)", Vars: []any{c.Clause(), subquery}}
+}
+
+// IsNull matches rows whose column is null
+func (c Column[T]) IsNull() clause.Expression {
+	return clause.Eq{Column: c.Clause(), Value: nil}
+}
+
+// IsNotNull matches rows whose column is not null
+func (c Column[T]) IsNotNull() clause.Expression {
+	return clause.Neq{Column: c.Clause(), Value: nil}
+}
+
+// EqColumn matches rows whose column equals other, as in a join condition
+func (c Column[T]) EqColumn(other Column[T]) clause.Expression {
+	return clause.Eq{Column: c.Clause(), Value: other.Clause()}
+}
+
+// Asc orders by the column, smallest first
+func (c Column[T]) Asc() clause.OrderByColumn {
+	return clause.OrderByColumn{Column: c.Clause()}
+}
+
+// Desc orders by the column, largest first
+func (c Column[T]) Desc() clause.OrderByColumn {
+	return clause.OrderByColumn{Column: c.Clause(), Desc: true}
+}
+
+// Set assigns value to the column, for Values and upserts
+func (c Column[T]) Set(value T) clause.Assignment {
+	return clause.Assignment{Column: clause.Column{Name: c.Name}, Value: value}
+}
+
+// SetExpr assigns the result of an expression to the column
+func (c Column[T]) SetExpr(expr clause.Expression) clause.Assignment {
+	return clause.Assignment{Column: clause.Column{Name: c.Name}, Value: expr}
+}
+
+// Number is a numeric column type
+type Number interface {
+	~int | ~int32 | ~int64 | ~float64
+}
+
+// Plus is the column's value plus n, as in an increment
+func Plus[T Number](c Column[T], n T) clause.Expression {
+	return clause.Expr{SQL: "? + ?", Vars: []any{c.Clause(), n}}
+}
+
+// Columned is a column of any type
+type Columned interface {
+	Clause() clause.Column
+}
+
+// Names returns the unqualified names of columns, for Select before Updates
+func Names(columns ...Columned) []string {
+	names := make([]string, len(columns))
+	for i, column := range columns {
+		names[i] = column.Clause().Name
+	}
+
+	return names
+}
+
+// Unqualified returns the columns without their table, for the conflict
+// target of upserts
+func Unqualified(columns ...Columned) []clause.Column {
+	clauses := make([]clause.Column, len(columns))
+	for i, column := range columns {
+		clauses[i] = clause.Column{Name: column.Clause().Name}
+	}
+
+	return clauses
+}
+
+// Excluded sets each column to the value the upsert tried to insert
+func Excluded(columns ...Columned) clause.Set {
+	return clause.AssignmentColumns(Names(columns...))
+}
+
+// Values maps the assigned columns to their values, for Updates and UpdateColumns
+func Values(assignments ...clause.Assignment) map[string]any {
+	values := make(map[string]any, len(assignments))
+	for _, assignment := range assignments {
+		values[assignment.Column.Name] = assignment.Value
+	}
+
+	return values
+}
+
+// Select selects the columns and expressions, as in
+// db.Clauses(common.Select(forms.UUID, common.CountAs("total")))
+func Select(columns ...clause.Expression) clause.Select {
+	return clause.Select{Expression: clause.CommaExpression{Exprs: columns}}
+}
+
+// CountAs counts the rows of each group under alias
+func CountAs(alias string) clause.Expression {
+	return clause.Expr{SQL: "COUNT(*) AS ?", Vars: []any{clause.Column{Name: alias}}}
+}
+
+// SumOrZero is the sum of an expression over the rows, and zero when there are none
+func SumOrZero(expr clause.Expression) clause.Expression {
+	return clause.Expr{SQL: "COALESCE(SUM(?), 0)", Vars: []any{expr}}
+}
+
+// GroupBy groups rows by the columns
+func GroupBy(columns ...Columned) clause.GroupBy {
+	clauses := make([]clause.Column, len(columns))
+	for i, column := range columns {
+		clauses[i] = column.Clause()
+	}
+
+	return clause.GroupBy{Columns: clauses}
+}
+
+// Join inner joins table on the conditions
+func Join(table string, on ...clause.Expression) clause.From {
+	return clause.From{Joins: []clause.Join{{
+		Type:  clause.InnerJoin,
+		Table: clause.Table{Name: table},
+		ON:    clause.Where{Exprs: on},
+	}}}
+}
+
+// Pluck queries a single column of the matching rows into dest
+func Pluck[T any](db *gorm.DB, column Column[T], dest *[]T) *gorm.DB {
+	return db.Clauses(clause.Select{Columns: []clause.Column{column.Clause()}}).Pluck(column.Name, dest)
+}
@@ -0,0 +1,168 @@
+package common_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"github.com/goformx/goforms/internal/infrastructure/repository/common"
+)
+
+//nolint:gochecknoglobals // Read-only test columns
+var (
+	formUUID      = common.NewColumn[string]("forms", "uuid")
+	formUser      = common.NewColumn[string]("forms", "user_id")
+	formDeletedAt = common.NewColumn[time.Time]("forms", "deleted_at")
+	formVersion   = common.NewColumn[int]("forms", "version")
+	submissionID  = common.NewColumn[string]("form_submissions", "uuid")
+	submissionFor = common.NewColumn[string]("form_submissions", "form_id")
+)
+
+// rendered builds a statement on every dialect. Writes skip their transaction,
+// which would need a connection.
+func rendered(t *testing.T, build func(db *gorm.DB) *gorm.DB) map[string]*gorm.Statement {
+	t.Helper()
+
+	statements := make(map[string]*gorm.Statement)
+	for name, db := range dialects(t) {
+		statements[name] = build(db.Session(&gorm.Session{SkipDefaultTransaction: true})).Statement
+	}
+
+	return statements
+}
+
+func TestColumn_Conditions(t *testing.T) {
+	since := time.Date(2026, 1, 2, 0, 0, 0, 0, time.UTC)
+
+	want := map[string]string{
+		"postgres": `SELECT * FROM "forms" WHERE ("forms"."user_id" = $1 AND ("forms"."deleted_at" IS NULL OR ` +
+			`"forms"."deleted_at" >= $2)) AND ("forms"."uuid" IN ($3,$4) AND "forms"."version" <> $5)`,
+		"mysql": "SELECT * FROM `forms` WHERE (`forms`.`user_id` = ? AND (`forms`.`deleted_at` IS NULL OR " +
+			"`forms`.`deleted_at` >= ?)) AND (`forms`.`uuid` IN (?,?) AND `forms`.`version` <> ?)",
+	}
+
+	statements := rendered(t, func(db *gorm.DB) *gorm.DB {
+		return db.Table("forms").
+			Where(formUser.Eq("user-1"), clause.Or(formDeletedAt.IsNull(), formDeletedAt.Gte(since))).
+			Where(formUUID.In([]string{"a", "b"}), formVersion.Neq(2)).
+			Find(&[]row{})
+	})
+
+	for name, stmt := range statements {
+		assert.Equal(t, want[name], stmt.SQL.String(), name)
+		assert.Equal(t, []any{"user-1", since, "a", "b", 2}, stmt.Vars, name)
+	}
+}
+
+func TestColumn_InNothing(t *testing.T) {
+	for name, stmt := range rendered(t, func(db *gorm.DB) *gorm.DB {
+		return db.Table("forms").Where(formUUID.In(nil)).Find(&[]row{})
+	}) {
+		assert.Contains(t, stmt.SQL.String(), "IN (NULL)", "%s: no values match no rows", name)
+	}
+}
+
+func TestJoinSelectGroup(t *testing.T) {
+	want := map[string]string{
+		"postgres": `SELECT "forms"."user_id", COUNT(*) AS "submissions" FROM "form_submissions" ` +
+			`INNER JOIN "forms" ON "forms"."uuid" = "form_submissions"."form_id" AND "forms"."deleted_at" IS NULL ` +
+			`GROUP BY "forms"."user_id" ORDER BY "forms"."user_id" DESC`,
+		"mysql": "SELECT `forms`.`user_id`, COUNT(*) AS `submissions` FROM `form_submissions` " +
+			"INNER JOIN `forms` ON `forms`.`uuid` = `form_submissions`.`form_id` AND `forms`.`deleted_at` IS NULL " +
+			"GROUP BY `forms`.`user_id` ORDER BY `forms`.`user_id` DESC",
+	}
+
+	statements := rendered(t, func(db *gorm.DB) *gorm.DB {
+		return db.Table("form_submissions").
+			Clauses(
+				common.Select(formUser, common.CountAs("submissions")),
+				common.Join("forms", formUUID.EqColumn(submissionFor), formDeletedAt.IsNull()),
+				common.GroupBy(formUser),
+			).
+			Order(formUser.Desc()).
+			Find(&[]row{})
+	})
+
+	for name, stmt := range statements {
+		assert.Equal(t, want[name], stmt.SQL.String(), name)
+	}
+}
+
+func TestColumn_InQuery(t *testing.T) {
+	want := map[string]string{
+		"postgres": `SELECT * FROM "form_submissions" WHERE "form_submissions"."form_id" IN ` +
+			`(SELECT "forms"."uuid" FROM "forms" WHERE "forms"."user_id" = $1)`,
+		"mysql": "SELECT * FROM `form_submissions` WHERE `form_submissions`.`form_id` IN " +
+			"(SELECT `forms`.`uuid` FROM `forms` WHERE `forms`.`user_id` = ?)",
+	}
+
+	statements := rendered(t, func(db *gorm.DB) *gorm.DB {
+		owned := db.Table("forms").Clauses(common.Select(formUUID)).Where(formUser.Eq("user-1"))
+
+		return db.Table("form_submissions").Where(submissionFor.InQuery(owned)).Find(&[]row{})
+	})
+
+	for name, stmt := range statements {
+		assert.Equal(t, want[name], stmt.SQL.String(), name)
+	}
+}
+
+func TestPluck(t *testing.T) {
+	want := map[string]string{
+		"postgres": `SELECT "form_submissions"."form_id" FROM "form_submissions" WHERE "form_submissions"."uuid" = $1 LIMIT $2`,
+		"mysql":    "SELECT `form_submissions`.`form_id` FROM `form_submissions` WHERE `form_submissions`.`uuid` = ? LIMIT ?",
+	}
+
+	statements := rendered(t, func(db *gorm.DB) *gorm.DB {
+		var formIDs []string
+
+		return common.Pluck(db.Table("form_submissions").Where(submissionID.Eq("s-1")).Limit(1), submissionFor, &formIDs)
+	})
+
+	for name, stmt := range statements {
+		assert.Equal(t, want[name], stmt.SQL.String(), name)
+	}
+}
+
+func TestValues(t *testing.T) {
+	want := map[string]string{
+		"postgres": `UPDATE "forms" SET "user_id"=$1,"version"=$2 WHERE "forms"."uuid" = $3`,
+		"mysql":    "UPDATE `forms` SET `user_id`=?,`version`=? WHERE `forms`.`uuid` = ?",
+	}
+
+	statements := rendered(t, func(db *gorm.DB) *gorm.DB {
+		return db.Table("forms").
+			Where(formUUID.Eq("f-1")).
+			UpdateColumns(common.Values(formUser.Set("user-2"), formVersion.Set(3)))
+	})
+
+	for name, stmt := range statements {
+		assert.Equal(t, want[name], stmt.SQL.String(), name)
+		assert.Equal(t, []any{"user-2", 3, "f-1"}, stmt.Vars, name)
+	}
+}
+
+func TestUpsert(t *testing.T) {
+	want := map[string]string{
+		"postgres": `INSERT INTO "forms" ("id") VALUES ($1) ON CONFLICT ("uuid") ` +
+			`DO UPDATE SET "version"="forms"."version" + $2,"user_id"="excluded"."user_id"`,
+		"mysql": "INSERT INTO `forms` (`id`) VALUES (?) " +
+			"ON DUPLICATE KEY UPDATE `version`=`forms`.`version` + ?,`user_id`=VALUES(`user_id`)",
+	}
+
+	statements := rendered(t, func(db *gorm.DB) *gorm.DB {
+		upsert := clause.OnConflict{
+			Columns:   common.Unqualified(formUUID),
+			DoUpdates: append(clause.Set{formVersion.SetExpr(common.Plus(formVersion, 1))}, common.Excluded(formUser)...),
+		}
+
+		return db.Table("forms").Clauses(upsert).Create(&row{ID: "f-1"})
+	})
+
+	for name, stmt := range statements {
+		assert.Equal(t, want[name], stmt.SQL.String(), name)
+	}
+}
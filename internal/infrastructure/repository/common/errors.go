@@ -59,7 +59,7 @@ func NewInvalidInputError(op, entity, id string, err error) error {
 		Op:      op,
 		Entity:  entity,
 		ID:      id,
-		Err:     err,
+		Err:     fmt.Errorf("%w: %w", ErrInvalidInput, err),
 		Details: fmt.Sprintf("%+v", err),
 	}
 }
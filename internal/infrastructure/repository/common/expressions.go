@@ -0,0 +1,92 @@
+package common
+
+import (
+	"strings"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// Typed query expressions for the SQL that differs between PostgreSQL and
+// MariaDB. They quote their columns and bind their values, so stores pass them
+// to Where and Select instead of building SQL strings per driver.
+//
+// The rest of a statement is built from the typed columns of columns.go.
+// Add an expression here when a store needs SQL one of the drivers does not
+// accept, rather than switching on the dialect name in the store.
+
+// likeEscaper escapes the LIKE wildcards of a search term, so they match
+// themselves; backslash is the default escape character of both databases
+//
+//nolint:gochecknoglobals // Stateless and safe for concurrent use
+var likeEscaper = strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+
+// ContainsFold matches rows where any of the columns contains the value,
+// ignoring case. Columns are compared as text, so JSON and enum columns can be
+// searched too.
+type ContainsFold struct {
+	Columns []clause.Column
+	Value   string
+}
+
+// Build implements clause.Expression
+func (c ContainsFold) Build(builder clause.Builder) {
+	pattern := "%" + likeEscaper.Replace(strings.ToLower(c.Value)) + "%"
+
+	_, _ = builder.WriteString("(")
+
+	for i, column := range c.Columns {
+		if i > 0 {
+			_, _ = builder.WriteString(" OR ")
+		}
+
+		_, _ = builder.WriteString("LOWER(")
+		writeText(builder, column)
+		_, _ = builder.WriteString(") LIKE ")
+		builder.AddVar(builder, pattern)
+	}
+
+	_, _ = builder.WriteString(")")
+}
+
+// ByteLength is the size in bytes of a column's value, as text for the JSON
+// columns PostgreSQL cannot measure directly
+type ByteLength struct {
+	Column clause.Column
+}
+
+// Build implements clause.Expression
+func (b ByteLength) Build(builder clause.Builder) {
+	if dialect(builder) == "postgres" {
+		_, _ = builder.WriteString("OCTET_LENGTH(")
+		writeText(builder, b.Column)
+		_, _ = builder.WriteString(")")
+
+		return
+	}
+
+	_, _ = builder.WriteString("LENGTH(")
+	builder.WriteQuoted(b.Column)
+	_, _ = builder.WriteString(")")
+}
+
+// writeText writes the column cast to the dialect's text type
+func writeText(builder clause.Builder, column clause.Column) {
+	textType := "CHAR"
+	if dialect(builder) == "postgres" {
+		textType = "TEXT"
+	}
+
+	_, _ = builder.WriteString("CAST(")
+	builder.WriteQuoted(column)
+	_, _ = builder.WriteString(" AS " + textType + ")")
+}
+
+// dialect names the database the statement is built for, such as postgres or mysql
+func dialect(builder clause.Builder) string {
+	if stmt, ok := builder.(*gorm.Statement); ok && stmt.Dialector != nil {
+		return stmt.Dialector.Name()
+	}
+
+	return ""
+}
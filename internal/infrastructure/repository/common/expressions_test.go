@@ -0,0 +1,72 @@
+package common_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/mysql"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"github.com/goformx/goforms/internal/infrastructure/repository/common"
+)
+
+type row struct {
+	ID string
+}
+
+// dryRun opens a dialect without connecting, for rendering statements
+func dryRun(t *testing.T, dialector gorm.Dialector) *gorm.DB {
+	t.Helper()
+
+	db, err := gorm.Open(dialector, &gorm.Config{DryRun: true, DisableAutomaticPing: true})
+	require.NoError(t, err)
+
+	return db
+}
+
+func dialects(t *testing.T) map[string]*gorm.DB {
+	t.Helper()
+
+	return map[string]*gorm.DB{
+		"postgres": dryRun(t, postgres.New(postgres.Config{DSN: "host=localhost"})),
+		"mysql":    dryRun(t, mysql.New(mysql.Config{DSN: "user@tcp(localhost:3306)/db", SkipInitializeWithVersion: true})),
+	}
+}
+
+func TestContainsFold(t *testing.T) {
+	want := map[string]string{
+		"postgres": `SELECT * FROM "rows" WHERE (LOWER(CAST("data" AS TEXT)) LIKE $1 OR LOWER(CAST("rows"."status" AS TEXT)) LIKE $2)`,
+		"mysql":    "SELECT * FROM `rows` WHERE (LOWER(CAST(`data` AS CHAR)) LIKE ? OR LOWER(CAST(`rows`.`status` AS CHAR)) LIKE ?)",
+	}
+
+	for name, db := range dialects(t) {
+		t.Run(name, func(t *testing.T) {
+			stmt := db.Where(common.ContainsFold{
+				Columns: []clause.Column{{Name: "data"}, {Table: "rows", Name: "status"}},
+				Value:   `50%_Off\`,
+			}).Find(&[]row{}).Statement
+
+			assert.Equal(t, want[name], stmt.SQL.String())
+			assert.Equal(t, []any{`%50\%\_off\\%`, `%50\%\_off\\%`}, stmt.Vars, "wildcards in the value match themselves")
+		})
+	}
+}
+
+func TestByteLength(t *testing.T) {
+	want := map[string]string{
+		"postgres": `SELECT COALESCE(SUM(OCTET_LENGTH(CAST("form_submissions"."data" AS TEXT))), 0) FROM "rows"`,
+		"mysql":    "SELECT COALESCE(SUM(LENGTH(`form_submissions`.`data`)), 0) FROM `rows`",
+	}
+
+	for name, db := range dialects(t) {
+		t.Run(name, func(t *testing.T) {
+			size := common.ByteLength{Column: clause.Column{Table: "form_submissions", Name: "data"}}
+			stmt := db.Model(&row{}).Select("COALESCE(SUM(?), 0)", size).Find(&[]row{}).Statement
+
+			assert.Equal(t, want[name], stmt.SQL.String())
+		})
+	}
+}
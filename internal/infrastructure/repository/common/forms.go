@@ -0,0 +1,31 @@
+package common
+
+import (
+	"strings"
+
+	"github.com/google/uuid"
+
+	"github.com/goformx/goforms/internal/domain/form/model"
+)
+
+// NormalizeFormID trims and lowercases a form ID. An ID that is not a UUID is
+// an invalid input error for op, so every store rejects the same IDs.
+func NormalizeFormID(op, id string) (string, error) {
+	normalizedID := strings.TrimSpace(strings.ToLower(id))
+
+	if _, err := uuid.Parse(normalizedID); err != nil {
+		return "", NewInvalidInputError(op, "form", id, err)
+	}
+
+	return normalizedID, nil
+}
+
+// PrepareForm runs the hooks GORM runs before inserting a form, for stores
+// that do not go through GORM
+func PrepareForm(formModel *model.Form) error {
+	if err := formModel.BeforeCreate(nil); err != nil {
+		return err
+	}
+
+	return formModel.BeforeSave(nil)
+}
@@ -0,0 +1,21 @@
+package common_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/goformx/goforms/internal/infrastructure/repository/common"
+)
+
+func TestNormalizeFormID(t *testing.T) {
+	id, err := common.NormalizeFormID("get", "  3F2504E0-4F89-11D3-9A0C-0305E82C3301 ")
+	require.NoError(t, err)
+	assert.Equal(t, "3f2504e0-4f89-11d3-9a0c-0305e82c3301", id)
+
+	_, err = common.NormalizeFormID("get", "not-a-uuid")
+	require.Error(t, err)
+	assert.True(t, errors.Is(err, common.ErrInvalidInput))
+}
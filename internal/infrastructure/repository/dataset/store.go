@@ -0,0 +1,127 @@
+// Package repository provides the dataset repository implementation
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"github.com/goformx/goforms/internal/domain/dataset"
+	"github.com/goformx/goforms/internal/infrastructure/database"
+	"github.com/goformx/goforms/internal/infrastructure/logging"
+	"github.com/goformx/goforms/internal/infrastructure/repository/common"
+	"github.com/goformx/goforms/internal/infrastructure/repository/tables"
+)
+
+// Store implements dataset.Repository
+type Store struct {
+	db     database.DB
+	logger logging.Logger
+}
+
+// NewStore creates a new dataset store
+func NewStore(db database.DB, logger logging.Logger) dataset.Repository {
+	return &Store{
+		db:     db,
+		logger: logger,
+	}
+}
+
+// GetDataset retrieves an owner's dataset by name
+func (s *Store) GetDataset(ctx context.Context, ownerID, name string) (*dataset.Dataset, error) {
+	var stored dataset.Dataset
+	if err := s.db.GetDB().WithContext(ctx).
+		Where(named(ownerID, name)).
+		First(&stored).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, fmt.Errorf("get dataset: %w", dataset.ErrDatasetNotFound)
+		}
+
+		return nil, fmt.Errorf("get dataset: %w", common.NewDatabaseError("get", "dataset", name, err))
+	}
+
+	return &stored, nil
+}
+
+// ListDatasets lists an owner's datasets
+func (s *Store) ListDatasets(ctx context.Context, ownerID string) ([]*dataset.Dataset, error) {
+	var datasets []*dataset.Dataset
+	if err := s.db.GetDB().WithContext(ctx).
+		Where(tables.Datasets.OwnerID.Eq(ownerID)).
+		Order(tables.Datasets.Name.Asc()).
+		Find(&datasets).Error; err != nil {
+		return nil, fmt.Errorf("list datasets: %w", common.NewDatabaseError("list", "dataset", "", err))
+	}
+
+	return datasets, nil
+}
+
+// CreateDataset stores a new dataset unless the owner already uses its name
+func (s *Store) CreateDataset(ctx context.Context, created *dataset.Dataset) error {
+	err := s.db.GetDB().WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var count int64
+		if err := tx.Model(&dataset.Dataset{}).
+			Where(named(created.OwnerID, created.Name)).
+			Count(&count).Error; err != nil {
+			return err
+		}
+
+		if count > 0 {
+			return dataset.ErrDatasetExists
+		}
+
+		return tx.Create(created).Error
+	})
+
+	switch {
+	case errors.Is(err, dataset.ErrDatasetExists):
+		return fmt.Errorf("create dataset: %w", err)
+	case err != nil:
+		return fmt.Errorf("create dataset: %w", common.NewDatabaseError("create", "dataset", created.Name, err))
+	}
+
+	return nil
+}
+
+// UpdateDataset replaces the title, kind, columns and rows of an owner's dataset
+func (s *Store) UpdateDataset(ctx context.Context, updated *dataset.Dataset) error {
+	result := s.db.GetDB().WithContext(ctx).
+		Model(&dataset.Dataset{}).
+		Where(named(updated.OwnerID, updated.Name)).
+		Select(common.Names(tables.Datasets.Title, tables.Datasets.Kind, tables.Datasets.Columns, tables.Datasets.Rows, tables.Datasets.UpdatedAt)).
+		Updates(updated)
+	if result.Error != nil {
+		return fmt.Errorf("update dataset: %w",
+			common.NewDatabaseError("update", "dataset", updated.Name, result.Error))
+	}
+
+	if result.RowsAffected == 0 {
+		return fmt.Errorf("update dataset: %w", dataset.ErrDatasetNotFound)
+	}
+
+	return nil
+}
+
+// DeleteDataset deletes an owner's dataset
+func (s *Store) DeleteDataset(ctx context.Context, ownerID, name string) error {
+	result := s.db.GetDB().WithContext(ctx).
+		Where(named(ownerID, name)).
+		Delete(&dataset.Dataset{})
+	if result.Error != nil {
+		return fmt.Errorf("delete dataset: %w", common.NewDatabaseError("delete", "dataset", name, result.Error))
+	}
+
+	if result.RowsAffected == 0 {
+		return fmt.Errorf("delete dataset: %w", dataset.ErrDatasetNotFound)
+	}
+
+	return nil
+}
+
+// named matches an owner's dataset by name
+func named(ownerID, name string) clause.Expression {
+	return clause.And(tables.Datasets.OwnerID.Eq(ownerID), tables.Datasets.Name.Eq(name))
+}
@@ -0,0 +1,129 @@
+// Package repository provides the dead-letter repository implementation
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+
+	"github.com/goformx/goforms/internal/domain/deadletter"
+	"github.com/goformx/goforms/internal/infrastructure/database"
+	"github.com/goformx/goforms/internal/infrastructure/logging"
+	"github.com/goformx/goforms/internal/infrastructure/repository/common"
+	"github.com/goformx/goforms/internal/infrastructure/repository/tables"
+)
+
+// Store implements deadletter.Repository
+type Store struct {
+	db     database.DB
+	logger logging.Logger
+}
+
+// NewStore creates a new dead-letter store
+func NewStore(db database.DB, logger logging.Logger) deadletter.Repository {
+	return &Store{
+		db:     db,
+		logger: logger,
+	}
+}
+
+// Create stores an entry
+func (s *Store) Create(ctx context.Context, entry *deadletter.Entry) error {
+	if err := s.db.GetDB().WithContext(ctx).Create(entry).Error; err != nil {
+		return fmt.Errorf("create dead letter: %w", common.NewDatabaseError("create", "dead_letter", "", err))
+	}
+
+	return nil
+}
+
+// Get returns an entry
+func (s *Store) Get(ctx context.Context, id string) (*deadletter.Entry, error) {
+	var entry deadletter.Entry
+
+	err := s.db.GetDB().WithContext(ctx).Where(tables.DeadLetters.UUID.Eq(id)).First(&entry).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, fmt.Errorf("get dead letter %s: %w", id, deadletter.ErrEntryNotFound)
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("get dead letter: %w", common.NewDatabaseError("get", "dead_letter", id, err))
+	}
+
+	return &entry, nil
+}
+
+// Update saves an entry
+func (s *Store) Update(ctx context.Context, entry *deadletter.Entry) error {
+	result := s.db.GetDB().WithContext(ctx).Save(entry)
+	if result.Error != nil {
+		return fmt.Errorf("update dead letter: %w", common.NewDatabaseError("update", "dead_letter", entry.ID, result.Error))
+	}
+
+	return nil
+}
+
+// List lists matching entries, newest first, with the total count
+func (s *Store) List(ctx context.Context, filter deadletter.Filter, offset, limit int) ([]*deadletter.Entry, int64, error) {
+	var total int64
+	if err := s.filtered(ctx, filter).Model(&deadletter.Entry{}).Count(&total).Error; err != nil {
+		return nil, 0, fmt.Errorf("count dead letters: %w", common.NewDatabaseError("list", "dead_letter", "", err))
+	}
+
+	var entries []*deadletter.Entry
+	if err := s.filtered(ctx, filter).
+		Order(tables.DeadLetters.CreatedAt.Desc()).
+		Offset(offset).
+		Limit(limit).
+		Find(&entries).Error; err != nil {
+		return nil, 0, fmt.Errorf("list dead letters: %w", common.NewDatabaseError("list", "dead_letter", "", err))
+	}
+
+	return entries, total, nil
+}
+
+// Delete deletes the matching entries
+func (s *Store) Delete(ctx context.Context, filter deadletter.Filter) (int64, error) {
+	// An empty filter purges every entry
+	query := s.filtered(ctx, filter).Session(&gorm.Session{AllowGlobalUpdate: true})
+
+	result := query.Delete(&deadletter.Entry{})
+	if result.Error != nil {
+		return 0, fmt.Errorf("delete dead letters: %w", common.NewDatabaseError("delete", "dead_letter", "", result.Error))
+	}
+
+	return result.RowsAffected, nil
+}
+
+// DeleteResolvedBefore deletes the entries resolved before the given time
+func (s *Store) DeleteResolvedBefore(ctx context.Context, before time.Time) (int64, error) {
+	result := s.db.GetDB().WithContext(ctx).
+		Where(tables.DeadLetters.Status.Eq(deadletter.StatusResolved), tables.DeadLetters.ResolvedAt.Lt(before)).
+		Delete(&deadletter.Entry{})
+	if result.Error != nil {
+		return 0, fmt.Errorf("prune dead letters: %w", common.NewDatabaseError("delete", "dead_letter", "", result.Error))
+	}
+
+	return result.RowsAffected, nil
+}
+
+// filtered starts a query restricted to the entries matching filter
+func (s *Store) filtered(ctx context.Context, filter deadletter.Filter) *gorm.DB {
+	query := s.db.GetDB().WithContext(ctx)
+
+	if filter.Kind != "" {
+		query = query.Where(tables.DeadLetters.Kind.Eq(filter.Kind))
+	}
+
+	if filter.Status != "" {
+		query = query.Where(tables.DeadLetters.Status.Eq(filter.Status))
+	}
+
+	if len(filter.IDs) > 0 {
+		query = query.Where(tables.DeadLetters.UUID.In(filter.IDs))
+	}
+
+	return query
+}
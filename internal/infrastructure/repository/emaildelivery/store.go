@@ -0,0 +1,151 @@
+// Package repository provides the email suppression and delivery event repository implementation
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"gorm.io/gorm"
+
+	"github.com/goformx/goforms/internal/domain/emaildelivery"
+	"github.com/goformx/goforms/internal/infrastructure/database"
+	"github.com/goformx/goforms/internal/infrastructure/logging"
+	"github.com/goformx/goforms/internal/infrastructure/repository/common"
+	"github.com/goformx/goforms/internal/infrastructure/repository/tables"
+)
+
+// eventBatchSize bounds the rows inserted per statement
+const eventBatchSize = 100
+
+// Store implements emaildelivery.Repository
+type Store struct {
+	db     database.DB
+	logger logging.Logger
+}
+
+// NewStore creates a new email delivery store
+func NewStore(db database.DB, logger logging.Logger) emaildelivery.Repository {
+	return &Store{
+		db:     db,
+		logger: logger,
+	}
+}
+
+// IsSuppressed reports whether an address is suppressed
+func (s *Store) IsSuppressed(ctx context.Context, address string) (bool, error) {
+	var count int64
+	if err := s.db.GetDB().WithContext(ctx).
+		Model(&emaildelivery.Suppression{}).
+		Where(tables.EmailSuppressions.Address.Eq(address)).
+		Count(&count).Error; err != nil {
+		return false, fmt.Errorf("check email suppression: %w",
+			common.NewDatabaseError("get", "email_suppression", "", err))
+	}
+
+	return count > 0, nil
+}
+
+// SaveSuppression creates or replaces the suppression of an address
+func (s *Store) SaveSuppression(ctx context.Context, suppression *emaildelivery.Suppression) error {
+	err := s.db.GetDB().WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var existing emaildelivery.Suppression
+
+		err := tx.Where(tables.EmailSuppressions.Address.Eq(suppression.Address)).First(&existing).Error
+
+		switch {
+		case errors.Is(err, gorm.ErrRecordNotFound):
+			return tx.Create(suppression).Error
+		case err != nil:
+			return err
+		}
+
+		suppression.CreatedAt = existing.CreatedAt
+
+		// Select writes empty values too, so a new reason replaces the old detail
+		return tx.Model(&existing).
+			Select(common.Names(
+				tables.EmailSuppressions.Reason, tables.EmailSuppressions.Provider,
+				tables.EmailSuppressions.Detail, tables.EmailSuppressions.FormID,
+			)).
+			Updates(suppression).Error
+	})
+	if err != nil {
+		return fmt.Errorf("save email suppression: %w",
+			common.NewDatabaseError("save", "email_suppression", "", err))
+	}
+
+	return nil
+}
+
+// DeleteSuppression removes the suppression of an address
+func (s *Store) DeleteSuppression(ctx context.Context, address string) error {
+	result := s.db.GetDB().WithContext(ctx).
+		Where(tables.EmailSuppressions.Address.Eq(address)).
+		Delete(&emaildelivery.Suppression{})
+	if result.Error != nil {
+		return fmt.Errorf("delete email suppression: %w",
+			common.NewDatabaseError("delete", "email_suppression", "", result.Error))
+	}
+
+	if result.RowsAffected == 0 {
+		return fmt.Errorf("delete email suppression: %w", emaildelivery.ErrSuppressionNotFound)
+	}
+
+	return nil
+}
+
+// ListSuppressions lists suppressions, newest first, with the total count
+func (s *Store) ListSuppressions(ctx context.Context, offset, limit int) ([]*emaildelivery.Suppression, int64, error) {
+	var total int64
+	if err := s.db.GetDB().WithContext(ctx).Model(&emaildelivery.Suppression{}).Count(&total).Error; err != nil {
+		return nil, 0, fmt.Errorf("count email suppressions: %w",
+			common.NewDatabaseError("list", "email_suppression", "", err))
+	}
+
+	var suppressions []*emaildelivery.Suppression
+	if err := s.db.GetDB().WithContext(ctx).
+		Order(tables.EmailSuppressions.UpdatedAt.Desc()).
+		Offset(offset).
+		Limit(limit).
+		Find(&suppressions).Error; err != nil {
+		return nil, 0, fmt.Errorf("list email suppressions: %w",
+			common.NewDatabaseError("list", "email_suppression", "", err))
+	}
+
+	return suppressions, total, nil
+}
+
+// CreateEvents stores delivery events
+func (s *Store) CreateEvents(ctx context.Context, events []*emaildelivery.Event) error {
+	if err := s.db.GetDB().WithContext(ctx).CreateInBatches(events, eventBatchSize).Error; err != nil {
+		return fmt.Errorf("create email delivery events: %w",
+			common.NewDatabaseError("create", "email_delivery_event", "", err))
+	}
+
+	return nil
+}
+
+// CountEvents counts a form's events by type
+func (s *Store) CountEvents(ctx context.Context, formID string) (map[emaildelivery.EventType]int64, error) {
+	var rows []struct {
+		Type  emaildelivery.EventType
+		Count int64
+	}
+
+	if err := s.db.GetDB().WithContext(ctx).
+		Model(&emaildelivery.Event{}).
+		Clauses(common.Select(tables.EmailDeliveryEvents.Type, common.CountAs("count")), common.GroupBy(tables.EmailDeliveryEvents.Type)).
+		Where(tables.EmailDeliveryEvents.FormID.Eq(formID)).
+		Scan(&rows).Error; err != nil {
+		return nil, fmt.Errorf("count email delivery events: %w",
+			common.NewDatabaseError("count", "email_delivery_event", formID, err))
+	}
+
+	counts := make(map[emaildelivery.EventType]int64, len(rows))
+	for _, row := range rows {
+		counts[row.Type] = row.Count
+	}
+
+	return counts, nil
+}
@@ -0,0 +1,107 @@
+// Package repository provides the email template override repository implementation
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"gorm.io/gorm"
+
+	"github.com/goformx/goforms/internal/domain/emailtemplate"
+	"github.com/goformx/goforms/internal/infrastructure/database"
+	"github.com/goformx/goforms/internal/infrastructure/logging"
+	"github.com/goformx/goforms/internal/infrastructure/repository/common"
+	"github.com/goformx/goforms/internal/infrastructure/repository/tables"
+)
+
+// Store implements emailtemplate.Repository
+type Store struct {
+	db     database.DB
+	logger logging.Logger
+}
+
+// NewStore creates a new email template store
+func NewStore(db database.DB, logger logging.Logger) emailtemplate.Repository {
+	return &Store{
+		db:     db,
+		logger: logger,
+	}
+}
+
+// GetTemplate retrieves an owner's override for a kind
+func (s *Store) GetTemplate(ctx context.Context, ownerID string, kind emailtemplate.Kind) (*emailtemplate.Template, error) {
+	var template emailtemplate.Template
+	if err := s.db.GetDB().WithContext(ctx).
+		Where(tables.EmailTemplates.OwnerID.Eq(ownerID), tables.EmailTemplates.Kind.Eq(kind)).
+		First(&template).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, fmt.Errorf("get email template: %w", emailtemplate.ErrTemplateNotFound)
+		}
+
+		return nil, fmt.Errorf("get email template: %w",
+			common.NewDatabaseError("get", "email_template", string(kind), err))
+	}
+
+	return &template, nil
+}
+
+// ListTemplates lists an owner's overrides
+func (s *Store) ListTemplates(ctx context.Context, ownerID string) ([]*emailtemplate.Template, error) {
+	var templates []*emailtemplate.Template
+	if err := s.db.GetDB().WithContext(ctx).
+		Where(tables.EmailTemplates.OwnerID.Eq(ownerID)).
+		Order(tables.EmailTemplates.Kind.Asc()).
+		Find(&templates).Error; err != nil {
+		return nil, fmt.Errorf("list email templates: %w",
+			common.NewDatabaseError("list", "email_template", "", err))
+	}
+
+	return templates, nil
+}
+
+// SaveTemplate creates or replaces an owner's override, keeping the ID of the one it replaces
+func (s *Store) SaveTemplate(ctx context.Context, template *emailtemplate.Template) error {
+	err := s.db.GetDB().WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var existing emailtemplate.Template
+
+		err := tx.Where(tables.EmailTemplates.OwnerID.Eq(template.OwnerID), tables.EmailTemplates.Kind.Eq(template.Kind)).First(&existing).Error
+
+		switch {
+		case errors.Is(err, gorm.ErrRecordNotFound):
+			template.ID = ""
+
+			return tx.Create(template).Error
+		case err != nil:
+			return err
+		}
+
+		template.ID = existing.ID
+		template.CreatedAt = existing.CreatedAt
+
+		return tx.Save(template).Error
+	})
+	if err != nil {
+		return fmt.Errorf("save email template: %w",
+			common.NewDatabaseError("save", "email_template", string(template.Kind), err))
+	}
+
+	return nil
+}
+
+// DeleteTemplate deletes an owner's override for a kind
+func (s *Store) DeleteTemplate(ctx context.Context, ownerID string, kind emailtemplate.Kind) error {
+	result := s.db.GetDB().WithContext(ctx).
+		Where(tables.EmailTemplates.OwnerID.Eq(ownerID), tables.EmailTemplates.Kind.Eq(kind)).
+		Delete(&emailtemplate.Template{})
+	if result.Error != nil {
+		return fmt.Errorf("delete email template: %w",
+			common.NewDatabaseError("delete", "email_template", string(kind), result.Error))
+	}
+
+	if result.RowsAffected == 0 {
+		return fmt.Errorf("delete email template: %w", emailtemplate.ErrTemplateNotFound)
+	}
+
+	return nil
+}
@@ -0,0 +1,264 @@
+package repository
+
+import (
+	"errors"
+	"fmt"
+
+	"gorm.io/gorm"
+
+	"github.com/goformx/goforms/internal/domain/encryption"
+	"github.com/goformx/goforms/internal/domain/form/model"
+	"github.com/goformx/goforms/internal/infrastructure/database"
+	"github.com/goformx/goforms/internal/infrastructure/repository/common"
+	"github.com/goformx/goforms/internal/infrastructure/repository/tables"
+)
+
+// plaintextKey is the statement setting holding the plaintext of the
+// submissions sealed for a write, so it can be put back afterwards
+const plaintextKey = "goforms:submission_plaintext"
+
+// Plugin is a GORM plugin that seals the data and metadata of submissions as
+// they are created or updated, for the accounts that turned encryption on, and
+// opens sealed submissions as they are read. It works on model.FormSubmission
+// values, so every store reading or writing submissions through GORM sees
+// plaintext; SQL over the columns themselves, such as data searches and size
+// sums, sees the sealed values.
+type Plugin struct {
+	service encryption.Service
+	// forms is the primary database when the plugin is installed on a shard,
+	// which holds submissions without their forms
+	forms database.DB
+}
+
+// NewPlugin creates the submission encryption plugin
+func NewPlugin(service encryption.Service) *Plugin {
+	return &Plugin{service: service}
+}
+
+// NewShardPlugin creates the submission encryption plugin of a submission
+// shard, which finds the owners of submissions in the primary database
+func NewShardPlugin(service encryption.Service, primary database.DB) *Plugin {
+	return &Plugin{service: service, forms: primary}
+}
+
+// Name returns the plugin name
+func (p *Plugin) Name() string {
+	return "goforms:submission_encryption"
+}
+
+// Initialize registers the plugin's callbacks
+func (p *Plugin) Initialize(db *gorm.DB) error {
+	return errors.Join(
+		db.Callback().Create().Before("gorm:create").Register("goforms:seal_created_submissions", p.seal),
+		db.Callback().Create().After("gorm:create").Register("goforms:restore_created_submissions", p.restore),
+		db.Callback().Update().Before("gorm:update").Register("goforms:seal_updated_submissions", p.seal),
+		db.Callback().Update().After("gorm:update").Register("goforms:restore_updated_submissions", p.restore),
+		db.Callback().Query().After("gorm:query").Register("goforms:open_submissions", p.open),
+	)
+}
+
+// plaintext is a sealed submission's data and metadata before sealing
+type plaintext struct {
+	submission *model.FormSubmission
+	data       model.JSON
+	metadata   model.JSON
+}
+
+// seal replaces the data and metadata of the written submissions of accounts
+// with encryption turned on by their sealed values. A submission whose owner
+// cannot be told fails the write rather than being stored in plaintext.
+func (p *Plugin) seal(db *gorm.DB) {
+	if db.Error != nil {
+		return
+	}
+
+	submissions := submissionsOf(db.Statement.Dest)
+	if len(submissions) == 0 {
+		return
+	}
+
+	var sealed []plaintext
+
+	defer func() { db.InstanceSet(plaintextKey, sealed) }()
+
+	keyring := p.service.Keyring()
+	owners := make(map[string]bool)
+
+	for _, submission := range submissions {
+		if submission.Data == nil && submission.Metadata == nil {
+			// A partial update that leaves the payload alone
+			continue
+		}
+
+		encrypt, err := p.encrypts(db, submission, owners)
+		if err != nil {
+			db.AddError(err)
+
+			return
+		}
+
+		submission.Encrypted, submission.EncryptionKeyID = false, ""
+
+		if !encrypt {
+			continue
+		}
+
+		data, err := keyring.SealJSON(submission.Data)
+		if err != nil {
+			db.AddError(fmt.Errorf("seal submission data: %w", err))
+
+			return
+		}
+
+		metadata, err := keyring.SealJSON(submission.Metadata)
+		if err != nil {
+			db.AddError(fmt.Errorf("seal submission metadata: %w", err))
+
+			return
+		}
+
+		sealed = append(sealed, plaintext{submission: submission, data: submission.Data, metadata: submission.Metadata})
+		submission.Data, submission.Metadata = data, metadata
+		submission.Encrypted, submission.EncryptionKeyID = true, keyring.ActiveKey()
+	}
+}
+
+// restore puts back the plaintext of the submissions sealed for a write, so
+// callers keep working with the values they wrote
+func (p *Plugin) restore(db *gorm.DB) {
+	value, ok := db.InstanceGet(plaintextKey)
+	if !ok {
+		return
+	}
+
+	sealed, _ := value.([]plaintext)
+	for _, original := range sealed {
+		original.submission.Data, original.submission.Metadata = original.data, original.metadata
+	}
+}
+
+// open replaces sealed data and metadata of the read submissions by their plaintext
+func (p *Plugin) open(db *gorm.DB) {
+	if db.Error != nil {
+		return
+	}
+
+	keyring := p.service.Keyring()
+
+	for _, submission := range submissionsOf(db.Statement.Dest) {
+		data, dataKey, err := keyring.OpenJSON(submission.Data)
+		if err != nil {
+			db.AddError(fmt.Errorf("open submission %s data: %w", submission.ID, err))
+
+			return
+		}
+
+		metadata, metadataKey, err := keyring.OpenJSON(submission.Metadata)
+		if err != nil {
+			db.AddError(fmt.Errorf("open submission %s metadata: %w", submission.ID, err))
+
+			return
+		}
+
+		if dataKey == "" {
+			dataKey = metadataKey
+		}
+
+		submission.Data, submission.Metadata = data, metadata
+		submission.Encrypted, submission.EncryptionKeyID = dataKey != "", dataKey
+	}
+}
+
+// encrypts reports whether a submission's owner has encryption turned on. The
+// owner is found through the submission's form, or through the stored row when
+// an update does not carry the form ID; lookups are shared within a statement.
+func (p *Plugin) encrypts(db *gorm.DB, submission *model.FormSubmission, owners map[string]bool) (bool, error) {
+	key := "form:" + submission.FormID
+	if submission.FormID == "" {
+		key = "submission:" + submission.ID
+	}
+
+	if encrypt, ok := owners[key]; ok {
+		return encrypt, nil
+	}
+
+	// A new session keeps the statement's connection, so forms created in the
+	// same transaction are found
+	query := db.Session(&gorm.Session{NewDB: true}).Table(tables.Forms.Table)
+
+	switch {
+	case p.forms != nil:
+		var err error
+		if query, err = p.primaryForm(db, submission); err != nil {
+			return false, err
+		}
+	case submission.FormID != "":
+		query = query.Where(tables.Forms.UUID.Eq(submission.FormID))
+	default:
+		query = query.Clauses(common.Join(tables.Submissions.Table, tables.Submissions.FormID.EqColumn(tables.Forms.UUID))).
+			Where(tables.Submissions.UUID.Eq(submission.ID))
+	}
+
+	var ownerIDs []string
+	if err := common.Pluck(query.Limit(1), tables.Forms.UserID, &ownerIDs).Error; err != nil {
+		return false, fmt.Errorf("find submission owner: %w", err)
+	}
+
+	encrypt := false
+
+	// An unknown form has no owner; the write then fails on its foreign key,
+	// except on a shard, which has none
+	if len(ownerIDs) > 0 {
+		var err error
+		if encrypt, err = p.service.Encrypts(db.Statement.Context, ownerIDs[0]); err != nil {
+			return false, err
+		}
+	}
+
+	owners[key] = encrypt
+
+	return encrypt, nil
+}
+
+// submissionsOf returns the submissions a statement writes or reads into
+func submissionsOf(dest any) []*model.FormSubmission {
+	switch dest := dest.(type) {
+	case *model.FormSubmission:
+		return []*model.FormSubmission{dest}
+	case []*model.FormSubmission:
+		return dest
+	case *[]*model.FormSubmission:
+		return *dest
+	case *[]model.FormSubmission:
+		submissions := make([]*model.FormSubmission, len(*dest))
+		for i := range *dest {
+			submissions[i] = &(*dest)[i]
+		}
+
+		return submissions
+	}
+
+	return nil
+}
+
+// primaryForm selects a shard submission's form from the primary database. The
+// form is read from the stored submission when an update does not carry it.
+func (p *Plugin) primaryForm(db *gorm.DB, submission *model.FormSubmission) (*gorm.DB, error) {
+	formID := submission.FormID
+	if formID == "" {
+		var formIDs []string
+		stored := db.Session(&gorm.Session{NewDB: true}).
+			Table(tables.Submissions.Table).
+			Where(tables.Submissions.UUID.Eq(submission.ID)).
+			Limit(1)
+		if err := common.Pluck(stored, tables.Submissions.FormID, &formIDs).Error; err != nil {
+			return nil, fmt.Errorf("find submission form: %w", err)
+		}
+
+		if len(formIDs) > 0 {
+			formID = formIDs[0]
+		}
+	}
+
+	return p.forms.GetDB().WithContext(db.Statement.Context).Table(tables.Forms.Table).Where(tables.Forms.UUID.Eq(formID)), nil
+}
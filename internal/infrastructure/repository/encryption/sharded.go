@@ -0,0 +1,157 @@
+package repository
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/goformx/goforms/internal/domain/encryption"
+	"github.com/goformx/goforms/internal/domain/form/model"
+	"github.com/goformx/goforms/internal/infrastructure/database"
+	"github.com/goformx/goforms/internal/infrastructure/logging"
+	"github.com/goformx/goforms/internal/infrastructure/repository/common"
+	"github.com/goformx/goforms/internal/infrastructure/repository/tables"
+)
+
+// ShardedStore implements encryption.Repository when submissions are sharded.
+// Settings stay in the primary database; stored submissions are read from and
+// rewritten in the shards, and their owners are found in the primary.
+type ShardedStore struct {
+	*Store
+
+	shards *database.Shards
+}
+
+// NewShardedStore creates an encryption store that rotates the submissions of every shard
+func NewShardedStore(db database.DB, shards *database.Shards, logger logging.Logger) encryption.Repository {
+	return &ShardedStore{
+		Store:  &Store{db: db, logger: logger},
+		shards: shards,
+	}
+}
+
+// shardPayload is a shard's submission row as it is at rest, with its form in
+// place of the owner the shard does not know
+type shardPayload struct {
+	ID       string     `gorm:"column:uuid"`
+	FormID   string     `gorm:"column:form_id"`
+	Data     model.JSON `gorm:"column:data"`
+	Metadata model.JSON `gorm:"column:metadata"`
+}
+
+// StoredPayloads lists stored submissions after afterID, in ID order, without
+// opening sealed values. Each shard's first rows are merged; rows whose form is
+// not in the primary database are skipped, as the join of Store skips them.
+func (s *ShardedStore) StoredPayloads(ctx context.Context, afterID string, limit int) ([]*encryption.Payload, error) {
+	var payloads []*encryption.Payload
+
+	for len(payloads) < limit {
+		rows, err := s.shardRows(ctx, afterID, limit)
+		if err != nil {
+			return nil, err
+		}
+
+		owners, err := s.owners(ctx, rows)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, row := range rows[:min(limit-len(payloads), len(rows))] {
+			afterID = row.ID
+
+			if ownerID, ok := owners[row.FormID]; ok {
+				payloads = append(payloads, &encryption.Payload{ID: row.ID, OwnerID: ownerID, Data: row.Data, Metadata: row.Metadata})
+			}
+		}
+
+		if len(rows) < limit {
+			break
+		}
+	}
+
+	return payloads, nil
+}
+
+// shardRows returns the first limit submission rows after afterID over all shards
+func (s *ShardedStore) shardRows(ctx context.Context, afterID string, limit int) ([]shardPayload, error) {
+	var rows []shardPayload
+
+	for _, id := range s.shards.IDs() {
+		query := s.shards.DB(id).GetDB().WithContext(ctx).
+			Table(tables.Submissions.Table).
+			Clauses(common.Select(tables.Submissions.UUID, tables.Submissions.FormID, tables.Submissions.Data, tables.Submissions.Metadata)).
+			Order(tables.Submissions.UUID.Asc()).
+			Limit(limit)
+
+		if afterID != "" {
+			query = query.Where(tables.Submissions.UUID.Gt(afterID))
+		}
+
+		var shardRows []shardPayload
+		if err := query.Scan(&shardRows).Error; err != nil {
+			return nil, fmt.Errorf("list stored submissions of shard %s: %w", id,
+				common.NewDatabaseError("list", "form_submission", afterID, err))
+		}
+
+		rows = append(rows, shardRows...)
+	}
+
+	slices.SortFunc(rows, func(a, b shardPayload) int { return cmp.Compare(a.ID, b.ID) })
+
+	return rows[:min(limit, len(rows))], nil
+}
+
+// owners maps the forms of the rows to their owners
+func (s *ShardedStore) owners(ctx context.Context, rows []shardPayload) (map[string]string, error) {
+	formIDs := make([]string, 0, len(rows))
+	for _, row := range rows {
+		formIDs = append(formIDs, row.FormID)
+	}
+
+	slices.Sort(formIDs)
+	formIDs = slices.Compact(formIDs)
+
+	var forms []struct {
+		ID     string `gorm:"column:uuid"`
+		UserID string `gorm:"column:user_id"`
+	}
+
+	if len(formIDs) > 0 {
+		if err := s.db.GetDB().WithContext(ctx).
+			Table(tables.Forms.Table).
+			Clauses(common.Select(tables.Forms.UUID, tables.Forms.UserID)).
+			Where(tables.Forms.UUID.In(formIDs)).
+			Scan(&forms).Error; err != nil {
+			return nil, fmt.Errorf("find submission owners: %w", common.NewDatabaseError("list", "form", "", err))
+		}
+	}
+
+	owners := make(map[string]string, len(forms))
+	for _, f := range forms {
+		owners[f.ID] = f.UserID
+	}
+
+	return owners, nil
+}
+
+// RewritePayload stores a submission's data and metadata exactly as given, in
+// the shard holding it
+func (s *ShardedStore) RewritePayload(ctx context.Context, payload *encryption.Payload) error {
+	for _, id := range s.shards.IDs() {
+		result := s.shards.DB(id).GetDB().WithContext(ctx).
+			Model(&model.FormSubmission{}).
+			Where(tables.Submissions.UUID.Eq(payload.ID)).
+			UpdateColumns(payloadColumns(payload))
+		if result.Error != nil {
+			return fmt.Errorf("rewrite submission payload in shard %s: %w", id,
+				common.NewDatabaseError("update", "form_submission", payload.ID, result.Error))
+		}
+
+		if result.RowsAffected > 0 {
+			return nil
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,135 @@
+// Package repository provides the submission encryption repository and the
+// GORM plugin that encrypts submissions as they are written and decrypts them
+// as they are read
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"github.com/goformx/goforms/internal/domain/encryption"
+	"github.com/goformx/goforms/internal/domain/form/model"
+	"github.com/goformx/goforms/internal/infrastructure/database"
+	"github.com/goformx/goforms/internal/infrastructure/logging"
+	"github.com/goformx/goforms/internal/infrastructure/repository/common"
+	"github.com/goformx/goforms/internal/infrastructure/repository/tables"
+)
+
+// Store implements encryption.Repository
+type Store struct {
+	db     database.DB
+	logger logging.Logger
+}
+
+// NewStore creates a new encryption store
+func NewStore(db database.DB, logger logging.Logger) encryption.Repository {
+	return &Store{
+		db:     db,
+		logger: logger,
+	}
+}
+
+// GetSetting returns an account's setting
+func (s *Store) GetSetting(ctx context.Context, userID string) (*encryption.Setting, error) {
+	var setting encryption.Setting
+	if err := s.db.GetDB().WithContext(ctx).Where(tables.AccountEncryption.UserID.Eq(userID)).First(&setting).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, encryption.ErrSettingNotFound
+		}
+
+		return nil, fmt.Errorf("get encryption setting: %w",
+			common.NewDatabaseError("get", "account_encryption", userID, err))
+	}
+
+	return &setting, nil
+}
+
+// SaveSetting creates or replaces an account's setting
+func (s *Store) SaveSetting(ctx context.Context, setting *encryption.Setting) error {
+	upsert := clause.OnConflict{
+		Columns:   common.Unqualified(tables.AccountEncryption.UserID),
+		DoUpdates: common.Excluded(tables.AccountEncryption.Enabled, tables.AccountEncryption.UpdatedAt),
+	}
+
+	if err := s.db.GetDB().WithContext(ctx).Clauses(upsert).Create(setting).Error; err != nil {
+		return fmt.Errorf("save encryption setting: %w",
+			common.NewDatabaseError("save", "account_encryption", setting.UserID, err))
+	}
+
+	return nil
+}
+
+// storedPayload is a submission row as it is at rest. It is not a
+// model.FormSubmission, so the plugin leaves its sealed values alone.
+type storedPayload struct {
+	ID       string     `gorm:"column:uuid"`
+	OwnerID  string     `gorm:"column:user_id"`
+	Data     model.JSON `gorm:"column:data"`
+	Metadata model.JSON `gorm:"column:metadata"`
+}
+
+// StoredPayloads lists stored submissions after afterID, in ID order, without opening sealed values
+func (s *Store) StoredPayloads(ctx context.Context, afterID string, limit int) ([]*encryption.Payload, error) {
+	query := s.db.GetDB().WithContext(ctx).
+		Table(tables.Submissions.Table).
+		Clauses(
+			common.Select(tables.Submissions.UUID, tables.Forms.UserID, tables.Submissions.Data, tables.Submissions.Metadata),
+			common.Join(tables.Forms.Table, tables.Forms.UUID.EqColumn(tables.Submissions.FormID)),
+		).
+		Order(tables.Submissions.UUID.Asc()).
+		Limit(limit)
+
+	if afterID != "" {
+		query = query.Where(tables.Submissions.UUID.Gt(afterID))
+	}
+
+	var rows []storedPayload
+	if err := query.Scan(&rows).Error; err != nil {
+		return nil, fmt.Errorf("list stored submissions: %w",
+			common.NewDatabaseError("list", "form_submission", afterID, err))
+	}
+
+	payloads := make([]*encryption.Payload, len(rows))
+	for i, row := range rows {
+		payloads[i] = &encryption.Payload{ID: row.ID, OwnerID: row.OwnerID, Data: row.Data, Metadata: row.Metadata}
+	}
+
+	return payloads, nil
+}
+
+// RewritePayload stores a submission's data and metadata exactly as given.
+// The columns are written from a map, which the plugin does not seal.
+func (s *Store) RewritePayload(ctx context.Context, payload *encryption.Payload) error {
+	if err := s.db.GetDB().WithContext(ctx).
+		Model(&model.FormSubmission{}).
+		Where(tables.Submissions.UUID.Eq(payload.ID)).
+		UpdateColumns(payloadColumns(payload)).Error; err != nil {
+		return fmt.Errorf("rewrite submission payload: %w",
+			common.NewDatabaseError("update", "form_submission", payload.ID, err))
+	}
+
+	return nil
+}
+
+// payloadColumns maps the data and metadata columns to a payload's values
+func payloadColumns(payload *encryption.Payload) map[string]any {
+	return map[string]any{
+		tables.Submissions.Data.Name:     columnValue(payload.Data),
+		tables.Submissions.Metadata.Name: columnValue(payload.Metadata),
+	}
+}
+
+// columnValue converts a JSON object to a column value; nil is stored as NULL
+func columnValue(value map[string]any) any {
+	if value == nil {
+		return nil
+	}
+
+	column := model.JSON(value)
+
+	return &column
+}
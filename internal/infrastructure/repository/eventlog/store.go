@@ -0,0 +1,101 @@
+// Package repository provides the stored domain event repository implementation
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+
+	"github.com/goformx/goforms/internal/domain/eventlog"
+	"github.com/goformx/goforms/internal/infrastructure/database"
+	"github.com/goformx/goforms/internal/infrastructure/logging"
+	"github.com/goformx/goforms/internal/infrastructure/repository/common"
+	"github.com/goformx/goforms/internal/infrastructure/repository/tables"
+)
+
+// Store implements eventlog.Repository
+type Store struct {
+	db     database.DB
+	logger logging.Logger
+}
+
+// NewStore creates a new stored event store
+func NewStore(db database.DB, logger logging.Logger) eventlog.Repository {
+	return &Store{
+		db:     db,
+		logger: logger,
+	}
+}
+
+// Create stores an event
+func (s *Store) Create(ctx context.Context, record *eventlog.Record) error {
+	if err := s.db.GetDB().WithContext(ctx).Create(record).Error; err != nil {
+		return fmt.Errorf("create stored event: %w", common.NewDatabaseError("create", "domain_event", "", err))
+	}
+
+	return nil
+}
+
+// Get returns a stored event
+func (s *Store) Get(ctx context.Context, id string) (*eventlog.Record, error) {
+	var record eventlog.Record
+
+	err := s.db.GetDB().WithContext(ctx).Where(tables.DomainEvents.UUID.Eq(id)).First(&record).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, fmt.Errorf("get stored event %s: %w", id, eventlog.ErrRecordNotFound)
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("get stored event: %w", common.NewDatabaseError("get", "domain_event", id, err))
+	}
+
+	return &record, nil
+}
+
+// List lists matching events, oldest first, with the total count
+func (s *Store) List(ctx context.Context, filter eventlog.Filter, offset, limit int) ([]*eventlog.Record, int64, error) {
+	var total int64
+	if err := s.filtered(ctx, filter).Model(&eventlog.Record{}).Count(&total).Error; err != nil {
+		return nil, 0, fmt.Errorf("count stored events: %w", common.NewDatabaseError("list", "domain_event", "", err))
+	}
+
+	var records []*eventlog.Record
+	if err := s.filtered(ctx, filter).
+		Order(tables.DomainEvents.OccurredAt.Asc()).
+		Order(tables.DomainEvents.CreatedAt.Asc()).
+		Offset(offset).
+		Limit(limit).
+		Find(&records).Error; err != nil {
+		return nil, 0, fmt.Errorf("list stored events: %w", common.NewDatabaseError("list", "domain_event", "", err))
+	}
+
+	return records, total, nil
+}
+
+// DeleteBefore deletes the events published before the given time
+func (s *Store) DeleteBefore(ctx context.Context, before time.Time) (int64, error) {
+	result := s.db.GetDB().WithContext(ctx).Where(tables.DomainEvents.OccurredAt.Lt(before)).Delete(&eventlog.Record{})
+	if result.Error != nil {
+		return 0, fmt.Errorf("delete stored events: %w", common.NewDatabaseError("delete", "domain_event", "", result.Error))
+	}
+
+	return result.RowsAffected, nil
+}
+
+// filtered starts a query restricted to the events matching filter
+func (s *Store) filtered(ctx context.Context, filter eventlog.Filter) *gorm.DB {
+	query := s.db.GetDB().WithContext(ctx)
+
+	if filter.Name != "" {
+		query = query.Where(tables.DomainEvents.Name.Eq(filter.Name))
+	}
+
+	if !filter.Since.IsZero() {
+		query = query.Where(tables.DomainEvents.OccurredAt.Gte(filter.Since))
+	}
+
+	return query
+}
@@ -0,0 +1,75 @@
+// Package repository provides the feature flag repository implementation
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"gorm.io/gorm/clause"
+
+	"github.com/goformx/goforms/internal/domain/flags"
+	"github.com/goformx/goforms/internal/infrastructure/database"
+	"github.com/goformx/goforms/internal/infrastructure/logging"
+	"github.com/goformx/goforms/internal/infrastructure/repository/common"
+	"github.com/goformx/goforms/internal/infrastructure/repository/tables"
+)
+
+// Store implements flags.Repository
+type Store struct {
+	db     database.DB
+	logger logging.Logger
+}
+
+// NewStore creates a new feature flag store
+func NewStore(db database.DB, logger logging.Logger) flags.Repository {
+	return &Store{
+		db:     db,
+		logger: logger,
+	}
+}
+
+// ListFlags lists the stored flags, ordered by key
+func (s *Store) ListFlags(ctx context.Context) ([]*flags.Flag, error) {
+	var list []*flags.Flag
+	if err := s.db.GetDB().WithContext(ctx).Order(tables.FeatureFlags.Key.Asc()).Find(&list).Error; err != nil {
+		return nil, fmt.Errorf("list feature flags: %w",
+			common.NewDatabaseError("list", "feature_flag", "", err))
+	}
+
+	return list, nil
+}
+
+// SaveFlag creates or replaces a flag
+func (s *Store) SaveFlag(ctx context.Context, flag *flags.Flag) error {
+	flagColumns := tables.FeatureFlags
+	upsert := clause.OnConflict{
+		Columns: common.Unqualified(flagColumns.Key),
+		DoUpdates: common.Excluded(
+			flagColumns.Description, flagColumns.Enabled, flagColumns.Percentage, flagColumns.Accounts, flagColumns.UpdatedAt,
+		),
+	}
+
+	if err := s.db.GetDB().WithContext(ctx).Clauses(upsert).Create(flag).Error; err != nil {
+		return fmt.Errorf("save feature flag: %w",
+			common.NewDatabaseError("save", "feature_flag", flag.Key, err))
+	}
+
+	return nil
+}
+
+// DeleteFlag removes a flag
+func (s *Store) DeleteFlag(ctx context.Context, key string) error {
+	result := s.db.GetDB().WithContext(ctx).
+		Where(tables.FeatureFlags.Key.Eq(key)).
+		Delete(&flags.Flag{})
+	if result.Error != nil {
+		return fmt.Errorf("delete feature flag: %w",
+			common.NewDatabaseError("delete", "feature_flag", key, result.Error))
+	}
+
+	if result.RowsAffected == 0 {
+		return fmt.Errorf("delete feature flag: %w", flags.ErrFlagNotFound)
+	}
+
+	return nil
+}
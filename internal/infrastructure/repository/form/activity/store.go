@@ -0,0 +1,50 @@
+// Package repository provides the submission activity repository implementation
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/goformx/goforms/internal/domain/form"
+	"github.com/goformx/goforms/internal/domain/form/model"
+	"github.com/goformx/goforms/internal/infrastructure/database"
+	"github.com/goformx/goforms/internal/infrastructure/logging"
+	"github.com/goformx/goforms/internal/infrastructure/repository/common"
+	"github.com/goformx/goforms/internal/infrastructure/repository/tables"
+)
+
+// Store implements form.SubmissionActivityRepository
+type Store struct {
+	db     database.DB
+	logger logging.Logger
+}
+
+// NewStore creates a new submission activity store
+func NewStore(db database.DB, logger logging.Logger) form.SubmissionActivityRepository {
+	return &Store{
+		db:     db,
+		logger: logger,
+	}
+}
+
+// CreateActivity creates a new submission activity record
+func (s *Store) CreateActivity(ctx context.Context, activity *model.SubmissionActivity) error {
+	if err := s.db.GetDB().WithContext(ctx).Create(activity).Error; err != nil {
+		return fmt.Errorf("create activity: %w", common.NewDatabaseError("create", "submission_activity", activity.ID, err))
+	}
+
+	return nil
+}
+
+// ListActivitiesBySubmission lists a submission's activity, oldest first
+func (s *Store) ListActivitiesBySubmission(ctx context.Context, submissionID string) ([]*model.SubmissionActivity, error) {
+	var activities []*model.SubmissionActivity
+	if err := s.db.GetDB().WithContext(ctx).
+		Where(tables.Activities.SubmissionID.Eq(submissionID)).
+		Order(tables.Activities.CreatedAt.Asc()).
+		Find(&activities).Error; err != nil {
+		return nil, fmt.Errorf("list activities: %w", common.NewDatabaseError("list", "submission_activity", submissionID, err))
+	}
+
+	return activities, nil
+}
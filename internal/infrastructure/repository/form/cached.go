@@ -0,0 +1,203 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/goformx/goforms/internal/domain/common/events"
+	"github.com/goformx/goforms/internal/domain/form"
+	formevents "github.com/goformx/goforms/internal/domain/form/events"
+	"github.com/goformx/goforms/internal/domain/form/model"
+	"github.com/goformx/goforms/internal/infrastructure/cache"
+	"github.com/goformx/goforms/internal/infrastructure/logging"
+	"github.com/goformx/goforms/internal/infrastructure/metrics"
+)
+
+// formCacheKeyPrefix namespaces form aggregates in the shared cache
+const formCacheKeyPrefix = "form:"
+
+// cachedFormEntry is the cache encoding of a form. Access settings are hidden from
+// API responses, so they are carried in a field of their own.
+type cachedFormEntry struct {
+	*model.Form
+
+	AccessSettings model.JSON `json:"access_settings"`
+}
+
+// CachedStore caches form aggregates read by ID. Entries are dropped when a form is
+// changed through the store or a form.updated/form.deleted event is seen, and
+// otherwise expire after the TTL. Cache failures fall back to the wrapped store.
+type CachedStore struct {
+	form.Repository
+
+	cache   cache.Cache
+	ttl     time.Duration
+	metrics *metrics.CacheMetrics
+	logger  logging.Logger
+}
+
+// NewCachedStore wraps repository with a form cache and subscribes it to form events
+func NewCachedStore(
+	repository form.Repository,
+	c cache.Cache,
+	ttl time.Duration,
+	eventBus events.EventBus,
+	cacheMetrics *metrics.CacheMetrics,
+	logger logging.Logger,
+) (*CachedStore, error) {
+	s := &CachedStore{
+		Repository: repository,
+		cache:      c,
+		ttl:        ttl,
+		metrics:    cacheMetrics,
+		logger:     logger,
+	}
+
+	if eventBus != nil {
+		ctx := context.Background()
+
+		if err := eventBus.Subscribe(ctx, string(formevents.FormUpdatedEventType), s.handleEvent); err != nil {
+			return nil, fmt.Errorf("subscribe to form updated events: %w", err)
+		}
+
+		if err := eventBus.Subscribe(ctx, string(formevents.FormDeletedEventType), s.handleEvent); err != nil {
+			return nil, fmt.Errorf("subscribe to form deleted events: %w", err)
+		}
+	}
+
+	return s, nil
+}
+
+// GetFormByID returns a cached form, loading and caching it on a miss
+func (s *CachedStore) GetFormByID(ctx context.Context, id string) (*model.Form, error) {
+	key := formCacheKey(id)
+
+	if cached, ok := s.get(ctx, key); ok {
+		s.metrics.Hit()
+
+		return cached, nil
+	}
+
+	s.metrics.Miss()
+
+	formModel, err := s.Repository.GetFormByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	s.set(ctx, key, formModel)
+
+	return formModel, nil
+}
+
+// UpdateForm updates a form and drops its cached copy
+func (s *CachedStore) UpdateForm(ctx context.Context, formModel *model.Form) error {
+	if err := s.Repository.UpdateForm(ctx, formModel); err != nil {
+		return err
+	}
+
+	s.invalidate(ctx, formModel.ID)
+
+	return nil
+}
+
+// DeleteForm deletes a form and drops its cached copy
+func (s *CachedStore) DeleteForm(ctx context.Context, id string) error {
+	if err := s.Repository.DeleteForm(ctx, id); err != nil {
+		return err
+	}
+
+	s.invalidate(ctx, id)
+
+	return nil
+}
+
+// CreateSubmissionWithUniqueValues passes unique submission values through to
+// the wrapped store
+func (s *CachedStore) CreateSubmissionWithUniqueValues(
+	ctx context.Context,
+	submission *model.FormSubmission,
+	values []form.UniqueValue,
+) error {
+	uniques, ok := s.Repository.(form.UniqueSubmissionRepository)
+	if !ok {
+		return form.ErrUniqueValuesUnsupported
+	}
+
+	return uniques.CreateSubmissionWithUniqueValues(ctx, submission, values)
+}
+
+// get decodes a cached form; undecodable entries are treated as misses
+func (s *CachedStore) get(ctx context.Context, key string) (*model.Form, bool) {
+	payload, found, err := s.cache.Get(ctx, key)
+	if err != nil {
+		s.metrics.Error()
+		s.logger.Warn("form cache read failed", "error", err)
+
+		return nil, false
+	}
+
+	if !found {
+		return nil, false
+	}
+
+	entry := cachedFormEntry{Form: &model.Form{}}
+	if decodeErr := json.Unmarshal(payload, &entry); decodeErr != nil {
+		s.metrics.Error()
+		s.logger.Warn("form cache entry could not be decoded", "error", decodeErr)
+
+		return nil, false
+	}
+
+	entry.Form.AccessSettings = entry.AccessSettings
+
+	return entry.Form, true
+}
+
+// set caches a loaded form
+func (s *CachedStore) set(ctx context.Context, key string, formModel *model.Form) {
+	payload, err := json.Marshal(cachedFormEntry{Form: formModel, AccessSettings: formModel.AccessSettings})
+	if err != nil {
+		s.metrics.Error()
+		s.logger.Warn("form could not be encoded for the cache", "form_id", formModel.ID, "error", err)
+
+		return
+	}
+
+	if setErr := s.cache.Set(ctx, key, payload, s.ttl); setErr != nil {
+		s.metrics.Error()
+		s.logger.Warn("form cache write failed", "form_id", formModel.ID, "error", setErr)
+	}
+}
+
+// invalidate drops a cached form
+func (s *CachedStore) invalidate(ctx context.Context, id string) {
+	s.metrics.Invalidation()
+
+	if err := s.cache.Delete(ctx, formCacheKey(id)); err != nil {
+		s.metrics.Error()
+		s.logger.Warn("form cache invalidation failed", "form_id", id, "error", err)
+	}
+}
+
+// handleEvent invalidates the form named by a form.updated or form.deleted event
+func (s *CachedStore) handleEvent(ctx context.Context, event events.Event) error {
+	switch payload := event.Payload().(type) {
+	case *model.Form:
+		if payload != nil {
+			s.invalidate(ctx, payload.ID)
+		}
+	case string:
+		s.invalidate(ctx, payload)
+	}
+
+	return nil
+}
+
+// formCacheKey normalizes a form ID the way GetFormByID does
+func formCacheKey(id string) string {
+	return formCacheKeyPrefix + strings.TrimSpace(strings.ToLower(id))
+}
@@ -0,0 +1,73 @@
+package repository_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	formevents "github.com/goformx/goforms/internal/domain/form/events"
+	"github.com/goformx/goforms/internal/domain/form/model"
+	"github.com/goformx/goforms/internal/infrastructure/cache"
+	"github.com/goformx/goforms/internal/infrastructure/event"
+	"github.com/goformx/goforms/internal/infrastructure/metrics"
+	repository "github.com/goformx/goforms/internal/infrastructure/repository/form"
+	mockform "github.com/goformx/goforms/test/mocks/form"
+	mocklogging "github.com/goformx/goforms/test/mocks/logging"
+)
+
+const formID = "5f0c8a52-4a8b-4d6e-9a57-3d0f0c3e2b11"
+
+func TestCachedStore(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	inner := mockform.NewMockRepository(ctrl)
+	logger := mocklogging.NewMockLogger(ctrl)
+	logger.EXPECT().Debug(gomock.Any(), gomock.Any()).AnyTimes()
+
+	bus := event.NewMemoryEventBus(logger)
+	cacheMetrics := metrics.NewCacheMetrics()
+
+	store, err := repository.NewCachedStore(inner, cache.NewMemoryCache(0), time.Minute, bus, cacheMetrics, logger)
+	require.NoError(t, err)
+
+	ctx := t.Context()
+
+	inner.EXPECT().GetFormByID(gomock.Any(), formID).Return(&model.Form{
+		ID:             formID,
+		Title:          "Cached",
+		Schema:         model.JSON{"components": []any{}},
+		AccessSettings: model.JSON{"require_auth": true},
+	}, nil).Times(1)
+
+	first, err := store.GetFormByID(ctx, formID)
+	require.NoError(t, err)
+
+	second, err := store.GetFormByID(ctx, formID)
+	require.NoError(t, err)
+	assert.Equal(t, first.Title, second.Title)
+	assert.True(t, second.GetAccess().RequireAuth, "access settings must survive the cache")
+
+	snapshot := cacheMetrics.GetMetrics()
+	assert.Equal(t, int64(1), snapshot["hits"])
+	assert.Equal(t, int64(1), snapshot["misses"])
+
+	// A form.updated event drops the cached copy
+	require.NoError(t, bus.Publish(ctx, formevents.NewFormUpdatedEvent(&model.Form{ID: formID})))
+
+	inner.EXPECT().GetFormByID(gomock.Any(), formID).Return(&model.Form{ID: formID, Title: "Updated"}, nil)
+
+	third, err := store.GetFormByID(ctx, formID)
+	require.NoError(t, err)
+	assert.Equal(t, "Updated", third.Title)
+
+	// Deleting through the store drops it as well
+	inner.EXPECT().DeleteForm(gomock.Any(), formID).Return(nil)
+	require.NoError(t, store.DeleteForm(ctx, formID))
+
+	inner.EXPECT().GetFormByID(gomock.Any(), formID).Return(nil, model.ErrFormNotFound)
+
+	_, err = store.GetFormByID(ctx, formID)
+	require.ErrorIs(t, err, model.ErrFormNotFound)
+}
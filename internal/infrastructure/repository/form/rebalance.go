@@ -0,0 +1,236 @@
+package repository
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"slices"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"github.com/goformx/goforms/internal/infrastructure/database"
+	"github.com/goformx/goforms/internal/infrastructure/logging"
+	"github.com/goformx/goforms/internal/infrastructure/repository/common"
+	"github.com/goformx/goforms/internal/infrastructure/repository/tables"
+)
+
+const (
+	// defaultRebalanceBatchSize is the number of submissions moved per transaction
+	defaultRebalanceBatchSize = 500
+	// primarySource names the primary database in rebalance reports
+	primarySource = "primary"
+)
+
+// RebalanceOptions configures a rebalance run
+type RebalanceOptions struct {
+	// DryRun reports the forms that would move without moving them
+	DryRun bool
+	// FromPrimary also moves the submissions still in the primary database, as
+	// when an install starts sharding
+	FromPrimary bool
+	// BatchSize is the number of submissions moved per transaction
+	BatchSize int
+}
+
+// RebalanceMove is a form whose submissions were found outside its shard
+type RebalanceMove struct {
+	FormID      string `json:"form_id"`
+	From        string `json:"from"`
+	To          string `json:"to"`
+	Submissions int64  `json:"submissions"`
+}
+
+// RebalanceReport is the outcome of a rebalance run
+type RebalanceReport struct {
+	DryRun      bool            `json:"dry_run"`
+	Forms       int             `json:"forms"`
+	Submissions int64           `json:"submissions"`
+	Moves       []RebalanceMove `json:"moves"`
+	StartedAt   time.Time       `json:"started_at"`
+	FinishedAt  time.Time       `json:"finished_at"`
+}
+
+// submissionRow is a submission row as it is at rest. It is not a
+// model.FormSubmission, so sealed values are copied without being opened.
+type submissionRow struct {
+	ID          string `gorm:"column:uuid;primaryKey"`
+	FormID      string
+	Data        []byte
+	Status      string
+	Metadata    []byte
+	Tags        []byte
+	Test        bool
+	SubmittedAt time.Time
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+}
+
+// TableName specifies the table name for submission rows
+func (r *submissionRow) TableName() string {
+	return "form_submissions"
+}
+
+// rebalanceSource is a database submissions are moved out of
+type rebalanceSource struct {
+	name  string
+	db    database.DB
+	shard bool
+}
+
+// Rebalancer moves submissions to the shards their forms belong to, after
+// shards were added or retired. Each batch is copied with its unique values in
+// one transaction of the target shard and then deleted from the source, so an
+// interrupted run loses nothing and can be run again.
+type Rebalancer struct {
+	db     database.DB
+	shards *database.Shards
+	logger logging.Logger
+}
+
+// NewRebalancer creates a rebalancer over the primary database and the shards
+func NewRebalancer(db database.DB, shards *database.Shards, logger logging.Logger) *Rebalancer {
+	return &Rebalancer{
+		db:     db,
+		shards: shards,
+		logger: logger,
+	}
+}
+
+// Rebalance moves the submissions of every form found outside its shard. Until
+// a form is moved, reads of its submissions only see those already in its shard.
+func (r *Rebalancer) Rebalance(ctx context.Context, opts RebalanceOptions) (*RebalanceReport, error) {
+	batchSize := cmp.Or(opts.BatchSize, defaultRebalanceBatchSize)
+	report := &RebalanceReport{DryRun: opts.DryRun, Moves: []RebalanceMove{}, StartedAt: time.Now().UTC()}
+
+	var sources []rebalanceSource
+	if opts.FromPrimary {
+		sources = append(sources, rebalanceSource{name: primarySource, db: r.db})
+	}
+
+	for _, id := range r.shards.IDs() {
+		sources = append(sources, rebalanceSource{name: id, db: r.shards.DB(id), shard: true})
+	}
+
+	for _, source := range sources {
+		counts, err := countByForm(ctx, source.db)
+		if err != nil {
+			return report, fmt.Errorf("count submissions in %s: %w", source.name, err)
+		}
+
+		for _, count := range counts {
+			target := r.shards.Locate(count.FormID)
+			if source.shard && target == source.name {
+				continue
+			}
+
+			move := RebalanceMove{FormID: count.FormID, From: source.name, To: target, Submissions: count.Submissions}
+
+			if !opts.DryRun {
+				move.Submissions, err = r.move(ctx, source.db, r.shards.DB(target), count.FormID, batchSize)
+				if err != nil {
+					report.add(move)
+
+					return report, fmt.Errorf("move form %s from %s to %s: %w", count.FormID, source.name, target, err)
+				}
+
+				r.logger.Info("moved form submissions", "form_id", count.FormID, "from", source.name, "to", target,
+					"submissions", move.Submissions)
+			}
+
+			report.add(move)
+		}
+	}
+
+	report.FinishedAt = time.Now().UTC()
+
+	return report, nil
+}
+
+// add records a moved form
+func (r *RebalanceReport) add(move RebalanceMove) {
+	r.Moves = append(r.Moves, move)
+	r.Forms++
+	r.Submissions += move.Submissions
+}
+
+// formCount is the number of submissions to a form in one database
+type formCount struct {
+	FormID      string
+	Submissions int64
+}
+
+// countByForm counts a database's submissions per form, in form ID order
+func countByForm(ctx context.Context, db database.DB) ([]formCount, error) {
+	var counts []formCount
+	if err := db.GetDB().WithContext(ctx).
+		Model(&submissionRow{}).
+		Clauses(common.Select(tables.Submissions.FormID, common.CountAs("submissions")), common.GroupBy(tables.Submissions.FormID)).
+		Scan(&counts).Error; err != nil {
+		return nil, common.NewDatabaseError("count", "form_submission", "", err)
+	}
+
+	slices.SortFunc(counts, func(a, b formCount) int { return cmp.Compare(a.FormID, b.FormID) })
+
+	return counts, nil
+}
+
+// move copies a form's submissions and unique values in batches and deletes
+// each batch from the source once copied, returning how many moved. Rows the
+// target already has are kept, as after an interrupted run.
+func (r *Rebalancer) move(ctx context.Context, from, to database.DB, formID string, batchSize int) (int64, error) {
+	var moved int64
+
+	for {
+		var rows []*submissionRow
+		if err := from.GetDB().WithContext(ctx).
+			Where(tables.Submissions.FormID.Eq(formID)).
+			Order(tables.Submissions.UUID.Asc()).
+			Limit(batchSize).
+			Find(&rows).Error; err != nil {
+			return moved, fmt.Errorf("read submissions: %w", common.NewDatabaseError("list", "form_submission", formID, err))
+		}
+
+		if len(rows) == 0 {
+			return moved, nil
+		}
+
+		ids := make([]string, 0, len(rows))
+		for _, row := range rows {
+			ids = append(ids, row.ID)
+		}
+
+		var values []*uniqueValueRow
+		if err := from.GetDB().WithContext(ctx).Where(tables.UniqueValues.SubmissionID.In(ids)).Find(&values).Error; err != nil {
+			return moved, fmt.Errorf("read unique values: %w",
+				common.NewDatabaseError("list", "form_submission_unique_value", formID, err))
+		}
+
+		if err := to.GetDB().WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+			tx = tx.Clauses(clause.OnConflict{DoNothing: true})
+
+			if err := tx.Create(&rows).Error; err != nil {
+				return fmt.Errorf("copy submissions: %w", err)
+			}
+
+			if len(values) > 0 {
+				if err := tx.Create(&values).Error; err != nil {
+					return fmt.Errorf("copy unique values: %w", err)
+				}
+			}
+
+			return nil
+		}); err != nil {
+			return moved, common.NewDatabaseError("create", "form_submission", formID, err)
+		}
+
+		// The unique values of the originals go with them by cascade
+		if err := from.GetDB().WithContext(ctx).Where(tables.Submissions.UUID.In(ids)).Delete(&submissionRow{}).Error; err != nil {
+			return moved, fmt.Errorf("delete moved submissions: %w",
+				common.NewDatabaseError("delete", "form_submission", formID, err))
+		}
+
+		moved += int64(len(rows))
+	}
+}
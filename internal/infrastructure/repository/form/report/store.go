@@ -0,0 +1,129 @@
+// Package repository provides the scheduled report repository implementation
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"github.com/goformx/goforms/internal/domain/form"
+	"github.com/goformx/goforms/internal/domain/form/model"
+	"github.com/goformx/goforms/internal/infrastructure/database"
+	"github.com/goformx/goforms/internal/infrastructure/logging"
+	"github.com/goformx/goforms/internal/infrastructure/repository/common"
+	"github.com/goformx/goforms/internal/infrastructure/repository/tables"
+)
+
+// Store implements form.ReportRepository
+type Store struct {
+	db     database.DB
+	logger logging.Logger
+}
+
+// NewStore creates a new report schedule store
+func NewStore(db database.DB, logger logging.Logger) form.ReportRepository {
+	return &Store{
+		db:     db,
+		logger: logger,
+	}
+}
+
+// CreateReport creates a new report schedule
+func (s *Store) CreateReport(ctx context.Context, report *model.ReportSchedule) error {
+	if err := s.db.GetDB().WithContext(ctx).Create(report).Error; err != nil {
+		return fmt.Errorf("create report: %w", common.NewDatabaseError("create", "report", report.ID, err))
+	}
+
+	return nil
+}
+
+// GetReportByID retrieves a report schedule by ID
+func (s *Store) GetReportByID(ctx context.Context, id string) (*model.ReportSchedule, error) {
+	var report model.ReportSchedule
+	if err := s.db.GetDB().WithContext(ctx).Where(tables.ReportSchedules.UUID.Eq(id)).First(&report).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, fmt.Errorf("get report: %w", model.ErrReportNotFound)
+		}
+
+		return nil, fmt.Errorf("get report: %w", common.NewDatabaseError("get", "report", id, err))
+	}
+
+	return &report, nil
+}
+
+// ListReportsByForm lists the report schedules for a form
+func (s *Store) ListReportsByForm(ctx context.Context, formID string) ([]*model.ReportSchedule, error) {
+	var reports []*model.ReportSchedule
+	if err := s.db.GetDB().WithContext(ctx).
+		Where(tables.ReportSchedules.FormID.Eq(formID)).
+		Order(tables.ReportSchedules.CreatedAt.Asc()).
+		Find(&reports).Error; err != nil {
+		return nil, fmt.Errorf("list reports: %w", common.NewDatabaseError("list", "report", "", err))
+	}
+
+	return reports, nil
+}
+
+// UpdateReport saves a report schedule
+func (s *Store) UpdateReport(ctx context.Context, report *model.ReportSchedule) error {
+	result := s.db.GetDB().WithContext(ctx).Save(report)
+	if result.Error != nil {
+		return fmt.Errorf("update report: %w", common.NewDatabaseError("update", "report", report.ID, result.Error))
+	}
+
+	return nil
+}
+
+// DeleteReport deletes a report schedule by ID
+func (s *Store) DeleteReport(ctx context.Context, id string) error {
+	result := s.db.GetDB().WithContext(ctx).Where(tables.ReportSchedules.UUID.Eq(id)).Delete(&model.ReportSchedule{})
+	if result.Error != nil {
+		return fmt.Errorf("delete report: %w", common.NewDatabaseError("delete", "report", id, result.Error))
+	}
+
+	if result.RowsAffected == 0 {
+		return fmt.Errorf("delete report: %w", model.ErrReportNotFound)
+	}
+
+	return nil
+}
+
+// ListDueReports returns active report schedules whose next run is due
+func (s *Store) ListDueReports(ctx context.Context, now time.Time) ([]*model.ReportSchedule, error) {
+	var reports []*model.ReportSchedule
+	if err := s.db.GetDB().WithContext(ctx).
+		Where(due(now)).
+		Order(tables.ReportSchedules.NextRunAt.Asc()).
+		Find(&reports).Error; err != nil {
+		return nil, fmt.Errorf("list due reports: %w", common.NewDatabaseError("list", "report", "", err))
+	}
+
+	return reports, nil
+}
+
+// ClaimReport moves a due report's next run to leaseUntil in a single conditional
+// update, so only one instance claims it
+func (s *Store) ClaimReport(ctx context.Context, id string, now, leaseUntil time.Time) (bool, error) {
+	result := s.db.GetDB().WithContext(ctx).
+		Model(&model.ReportSchedule{}).
+		Where(tables.ReportSchedules.UUID.Eq(id), due(now)).
+		Updates(common.Values(tables.ReportSchedules.NextRunAt.Set(leaseUntil)))
+	if result.Error != nil {
+		return false, fmt.Errorf("claim report: %w", common.NewDatabaseError("update", "report", id, result.Error))
+	}
+
+	return result.RowsAffected == 1, nil
+}
+
+// due matches the active report schedules whose next run is at or before now
+func due(now time.Time) clause.Expression {
+	return clause.And(
+		tables.ReportSchedules.Active.Eq(true),
+		tables.ReportSchedules.NextRunAt.IsNotNull(),
+		tables.ReportSchedules.NextRunAt.Lte(now),
+	)
+}
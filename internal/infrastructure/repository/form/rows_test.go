@@ -0,0 +1,39 @@
+package repository
+
+import (
+	"slices"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm/schema"
+
+	"github.com/goformx/goforms/internal/infrastructure/repository/common"
+	"github.com/goformx/goforms/internal/infrastructure/repository/tables"
+)
+
+// TestRowsMatchTables checks the columns the store queries its at-rest rows by
+// against the schemas GORM parses from those rows
+func TestRowsMatchTables(t *testing.T) {
+	cases := map[string]struct {
+		row     any
+		columns []common.Columned
+	}{
+		"submissions":   {&submissionRow{}, []common.Columned{tables.Submissions.UUID, tables.Submissions.FormID}},
+		"unique values": {&uniqueValueRow{}, []common.Columned{tables.UniqueValues.SubmissionID}},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			parsed, err := schema.Parse(tc.row, &sync.Map{}, schema.NamingStrategy{})
+			require.NoError(t, err)
+
+			for _, column := range tc.columns {
+				assert.Equal(t, parsed.Table, column.Clause().Table)
+				assert.True(t, slices.Contains(parsed.DBNames, column.Clause().Name), "%s is not a column of %s",
+					column.Clause().Name, parsed.Table)
+			}
+		})
+	}
+}
@@ -0,0 +1,107 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"github.com/goformx/goforms/internal/domain/form/model"
+	"github.com/goformx/goforms/internal/infrastructure/repository/common"
+	"github.com/goformx/goforms/internal/infrastructure/repository/tables"
+)
+
+// CreateSchemaRevision stores a schema revision
+func (s *Store) CreateSchemaRevision(ctx context.Context, revision *model.SchemaRevision) error {
+	if err := s.db.GetDB().WithContext(ctx).Create(revision).Error; err != nil {
+		return fmt.Errorf("create schema revision: %w",
+			common.NewDatabaseError("create", "schema_revision", revision.FormID, err))
+	}
+
+	return nil
+}
+
+// LatestSchemaRevision returns the revision on top of a form's stack
+func (s *Store) LatestSchemaRevision(
+	ctx context.Context,
+	formID string,
+	stack model.SchemaStack,
+) (*model.SchemaRevision, error) {
+	var revision model.SchemaRevision
+
+	err := s.db.GetDB().WithContext(ctx).
+		Where(inStack(formID, stack)).
+		Order(tables.SchemaRevisions.Seq.Desc()).
+		First(&revision).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, fmt.Errorf("get schema revision: %w", common.NewNotFoundError("get", "schema_revision", formID))
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("get schema revision: %w", common.NewDatabaseError("get", "schema_revision", formID, err))
+	}
+
+	return &revision, nil
+}
+
+// DeleteSchemaRevision deletes a revision, reporting whether this call removed it
+func (s *Store) DeleteSchemaRevision(ctx context.Context, id string) (bool, error) {
+	result := s.db.GetDB().WithContext(ctx).Where(tables.SchemaRevisions.UUID.Eq(id)).Delete(&model.SchemaRevision{})
+	if result.Error != nil {
+		return false, fmt.Errorf("delete schema revision: %w",
+			common.NewDatabaseError("delete", "schema_revision", id, result.Error))
+	}
+
+	return result.RowsAffected > 0, nil
+}
+
+// TrimSchemaRevisions deletes all but the keep newest revisions of a form's stack
+func (s *Store) TrimSchemaRevisions(ctx context.Context, formID string, stack model.SchemaStack, keep int) error {
+	db := s.db.GetDB().WithContext(ctx)
+	query := db.Where(inStack(formID, stack))
+
+	if keep > 0 {
+		// The newest revision past the limit, and all older ones, are dropped
+		var cutoff []int64
+		cutoffQuery := db.Model(&model.SchemaRevision{}).
+			Where(inStack(formID, stack)).
+			Order(tables.SchemaRevisions.Seq.Desc()).
+			Offset(keep).
+			Limit(1)
+		if err := common.Pluck(cutoffQuery, tables.SchemaRevisions.Seq, &cutoff).Error; err != nil {
+			return fmt.Errorf("trim schema revisions: %w", common.NewDatabaseError("trim", "schema_revision", formID, err))
+		}
+
+		if len(cutoff) == 0 {
+			return nil
+		}
+
+		query = query.Where(tables.SchemaRevisions.Seq.Lte(cutoff[0]))
+	}
+
+	if err := query.Delete(&model.SchemaRevision{}).Error; err != nil {
+		return fmt.Errorf("trim schema revisions: %w", common.NewDatabaseError("trim", "schema_revision", formID, err))
+	}
+
+	return nil
+}
+
+// CountSchemaRevisions counts the revisions of a form's stack
+func (s *Store) CountSchemaRevisions(ctx context.Context, formID string, stack model.SchemaStack) (int, error) {
+	var count int64
+	if err := s.db.GetDB().WithContext(ctx).
+		Model(&model.SchemaRevision{}).
+		Where(inStack(formID, stack)).
+		Count(&count).Error; err != nil {
+		return 0, fmt.Errorf("count schema revisions: %w", common.NewDatabaseError("count", "schema_revision", formID, err))
+	}
+
+	return int(count), nil
+}
+
+// inStack matches the revisions of a form's stack
+func inStack(formID string, stack model.SchemaStack) clause.Expression {
+	return clause.And(tables.SchemaRevisions.FormID.Eq(formID), tables.SchemaRevisions.Stack.Eq(stack))
+}
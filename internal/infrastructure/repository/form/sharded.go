@@ -0,0 +1,190 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/goformx/goforms/internal/domain/form"
+	"github.com/goformx/goforms/internal/domain/form/model"
+	"github.com/goformx/goforms/internal/infrastructure/database"
+	"github.com/goformx/goforms/internal/infrastructure/logging"
+	"github.com/goformx/goforms/internal/infrastructure/repository/common"
+)
+
+// ShardedStore implements form.Repository over the primary database and the
+// shards submissions are spread over. Forms and schema revisions stay in the
+// primary; a form's submissions and unique values are read and written in the
+// shard its ID belongs to. Lookups by submission ID alone ask each shard in turn.
+type ShardedStore struct {
+	*Store
+
+	shards *database.Shards
+	stores map[string]*Store
+}
+
+// NewShardedStore creates a form store that keeps submissions in the shards
+func NewShardedStore(db database.DB, shards *database.Shards, logger logging.Logger) *ShardedStore {
+	stores := make(map[string]*Store)
+	for _, id := range shards.IDs() {
+		stores[id] = &Store{db: shards.DB(id), logger: logger.With("shard", id)}
+	}
+
+	return &ShardedStore{
+		Store:  &Store{db: db, logger: logger},
+		shards: shards,
+		stores: stores,
+	}
+}
+
+// shard returns the store of the shard holding a form's submissions
+func (s *ShardedStore) shard(formID string) *Store {
+	return s.stores[s.shards.Locate(formID)]
+}
+
+// CreateSubmission creates a submission in its form's shard
+func (s *ShardedStore) CreateSubmission(ctx context.Context, submission *model.FormSubmission) error {
+	return s.shard(submission.FormID).CreateSubmission(ctx, submission)
+}
+
+// CreateSubmissions inserts submissions with one insert per shard. A failed
+// shard does not undo the inserts of the shards before it.
+func (s *ShardedStore) CreateSubmissions(ctx context.Context, submissions []*model.FormSubmission) error {
+	batches := make(map[string][]*model.FormSubmission)
+	for _, submission := range submissions {
+		id := s.shards.Locate(submission.FormID)
+		batches[id] = append(batches[id], submission)
+	}
+
+	for _, id := range s.shards.IDs() {
+		if err := s.stores[id].CreateSubmissions(ctx, batches[id]); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// CreateSubmissionWithUniqueValues stores a submission and claims its unique
+// values in one transaction of its form's shard
+func (s *ShardedStore) CreateSubmissionWithUniqueValues(
+	ctx context.Context,
+	submission *model.FormSubmission,
+	values []form.UniqueValue,
+) error {
+	return s.shard(submission.FormID).CreateSubmissionWithUniqueValues(ctx, submission, values)
+}
+
+// GetSubmissionByID looks for a submission in each shard
+func (s *ShardedStore) GetSubmissionByID(ctx context.Context, submissionID string) (*model.FormSubmission, error) {
+	for _, id := range s.shards.IDs() {
+		submission, err := s.stores[id].GetSubmissionByID(ctx, submissionID)
+		if !errors.Is(err, common.ErrNotFound) {
+			return submission, err
+		}
+	}
+
+	return nil, fmt.Errorf("get submission by ID: %w", common.NewNotFoundError("get", "form_submission", submissionID))
+}
+
+// ListSubmissions retrieves all submissions for a form from its shard
+func (s *ShardedStore) ListSubmissions(ctx context.Context, formID string) ([]*model.FormSubmission, error) {
+	return s.shard(formID).ListSubmissions(ctx, formID)
+}
+
+// ListSubmissionsBetween retrieves a form's submissions received after since and
+// at or before until from its shard, oldest first
+func (s *ShardedStore) ListSubmissionsBetween(
+	ctx context.Context,
+	formID string,
+	since, until time.Time,
+) ([]*model.FormSubmission, error) {
+	return s.shard(formID).ListSubmissionsBetween(ctx, formID, since, until)
+}
+
+// DeleteTestSubmissionsBefore deletes the test submissions received before the
+// given time from every shard
+func (s *ShardedStore) DeleteTestSubmissionsBefore(ctx context.Context, before time.Time) (int64, error) {
+	var total int64
+
+	for _, id := range s.shards.IDs() {
+		deleted, err := s.stores[id].DeleteTestSubmissionsBefore(ctx, before)
+		total += deleted
+
+		if err != nil {
+			return total, err
+		}
+	}
+
+	return total, nil
+}
+
+// UpdateSubmission updates a submission in its form's shard, or looks for it in
+// each shard when the form is not given
+func (s *ShardedStore) UpdateSubmission(ctx context.Context, submission *model.FormSubmission) error {
+	if submission.FormID != "" {
+		return s.shard(submission.FormID).UpdateSubmission(ctx, submission)
+	}
+
+	for _, id := range s.shards.IDs() {
+		if err := s.stores[id].UpdateSubmission(ctx, submission); !errors.Is(err, common.ErrNotFound) {
+			return err
+		}
+	}
+
+	return fmt.Errorf("update submission: %w", common.NewNotFoundError("update", "form_submission", submission.ID))
+}
+
+// DeleteSubmission looks for a submission in each shard and deletes it
+func (s *ShardedStore) DeleteSubmission(ctx context.Context, submissionID string) error {
+	for _, id := range s.shards.IDs() {
+		if err := s.stores[id].DeleteSubmission(ctx, submissionID); !errors.Is(err, common.ErrNotFound) {
+			return err
+		}
+	}
+
+	return fmt.Errorf("delete submission: %w", common.NewNotFoundError("delete", "form_submission", submissionID))
+}
+
+// GetByFormID retrieves all submissions for a form from its shard
+func (s *ShardedStore) GetByFormID(ctx context.Context, formID string) ([]*model.FormSubmission, error) {
+	return s.ListSubmissions(ctx, formID)
+}
+
+// GetByFormIDPaginated retrieves paginated submissions for a form from its shard
+func (s *ShardedStore) GetByFormIDPaginated(
+	ctx context.Context,
+	formID string,
+	params common.PaginationParams,
+) (*common.PaginationResult, error) {
+	return s.shard(formID).GetByFormIDPaginated(ctx, formID, params)
+}
+
+// GetByFormAndUser retrieves a submission by form ID and user ID from the form's shard
+func (s *ShardedStore) GetByFormAndUser(
+	ctx context.Context,
+	formID string,
+	userID string,
+) (*model.FormSubmission, error) {
+	return s.shard(formID).GetByFormAndUser(ctx, formID, userID)
+}
+
+// GetSubmissionsByStatus retrieves submissions by status from every shard
+func (s *ShardedStore) GetSubmissionsByStatus(
+	ctx context.Context,
+	status model.SubmissionStatus,
+) ([]*model.FormSubmission, error) {
+	var submissions []*model.FormSubmission
+
+	for _, id := range s.shards.IDs() {
+		shardSubmissions, err := s.stores[id].GetSubmissionsByStatus(ctx, status)
+		if err != nil {
+			return nil, err
+		}
+
+		submissions = append(submissions, shardSubmissions...)
+	}
+
+	return submissions, nil
+}
@@ -5,9 +5,8 @@ import (
 	"context"
 	"errors"
 	"fmt"
-	"strings"
+	"time"
 
-	"github.com/google/uuid"
 	"gorm.io/gorm"
 
 	"github.com/goformx/goforms/internal/domain/form"
@@ -15,6 +14,7 @@ import (
 	"github.com/goformx/goforms/internal/infrastructure/database"
 	"github.com/goformx/goforms/internal/infrastructure/logging"
 	"github.com/goformx/goforms/internal/infrastructure/repository/common"
+	"github.com/goformx/goforms/internal/infrastructure/repository/tables"
 )
 
 // Store implements form.Repository interface
@@ -47,22 +47,17 @@ func (s *Store) CreateForm(ctx context.Context, formModel *model.Form) error {
 
 // GetFormByID retrieves a form by ID
 func (s *Store) GetFormByID(ctx context.Context, id string) (*model.Form, error) {
-	// Normalize the UUID by trimming spaces and converting to lowercase
-	normalizedID := strings.TrimSpace(strings.ToLower(id))
-
-	// Validate UUID format
-	if _, err := uuid.Parse(normalizedID); err != nil {
+	normalizedID, err := common.NormalizeFormID("get", id)
+	if err != nil {
 		s.logger.Warn("invalid form ID format received",
 			"id_length", len(id),
 			"error_type", "invalid_uuid_format")
 
-		invalidErr := common.NewInvalidInputError("get", "form", id, err)
-
-		return nil, fmt.Errorf("get form by ID: %w", invalidErr)
+		return nil, fmt.Errorf("get form by ID: %w", err)
 	}
 
 	var formModel model.Form
-	if err := s.db.GetDB().WithContext(ctx).Where("uuid = ?", normalizedID).First(&formModel).Error; err != nil {
+	if err := s.db.GetDB().WithContext(ctx).Where(tables.Forms.UUID.Eq(normalizedID)).First(&formModel).Error; err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			s.logger.Debug("form not found in database",
 				"id_length", len(normalizedID),
@@ -92,8 +87,8 @@ func (s *Store) GetFormByID(ctx context.Context, id string) (*model.Form, error)
 func (s *Store) ListForms(ctx context.Context, userID string) ([]*model.Form, error) {
 	var forms []*model.Form
 	if err := s.db.GetDB().WithContext(ctx).
-		Where("user_id = ?", userID).
-		Order("created_at DESC").
+		Where(tables.Forms.UserID.Eq(userID)).
+		Order(tables.Forms.CreatedAt.Desc()).
 		Find(&forms).Error; err != nil {
 		s.logger.Error("failed to list forms",
 			"user_id", userID,
@@ -107,36 +102,59 @@ func (s *Store) ListForms(ctx context.Context, userID string) ([]*model.Form, er
 }
 
 // UpdateForm updates a form
+// When the form carries a version, the update only applies if the stored version still
+// matches, and the version is incremented; otherwise model.ErrFormVersionConflict is returned.
 func (s *Store) UpdateForm(ctx context.Context, formModel *model.Form) error {
-	result := s.db.GetDB().WithContext(ctx).Model(&model.Form{}).Where("uuid = ?", formModel.ID).Updates(formModel)
+	expectedVersion := formModel.Version
+
+	query := s.db.GetDB().WithContext(ctx).Model(&model.Form{}).Where(tables.Forms.UUID.Eq(formModel.ID))
+	if expectedVersion > 0 {
+		query = query.Where(tables.Forms.Version.Eq(expectedVersion))
+		formModel.Version = expectedVersion + 1
+	}
+
+	result := query.Updates(formModel)
 	if result.Error != nil {
+		formModel.Version = expectedVersion
+
 		return fmt.Errorf("update form: %w", common.NewDatabaseError("update", "form", formModel.ID, result.Error))
 	}
 
 	if result.RowsAffected == 0 {
+		formModel.Version = expectedVersion
+
+		if expectedVersion > 0 && s.formExists(ctx, formModel.ID) {
+			return fmt.Errorf("update form: %w", model.ErrFormVersionConflict)
+		}
+
 		return fmt.Errorf("update form: %w", common.NewNotFoundError("update", "form", formModel.ID))
 	}
 
 	return nil
 }
 
+// formExists reports whether a non-deleted form with the given ID exists
+func (s *Store) formExists(ctx context.Context, id string) bool {
+	var count int64
+	if err := s.db.GetDB().WithContext(ctx).Model(&model.Form{}).Where(tables.Forms.UUID.Eq(id)).Count(&count).Error; err != nil {
+		return false
+	}
+
+	return count > 0
+}
+
 // DeleteForm deletes a form
 func (s *Store) DeleteForm(ctx context.Context, id string) error {
-	// Normalize the UUID by trimming spaces and converting to lowercase
-	normalizedID := strings.TrimSpace(strings.ToLower(id))
-
-	// Validate UUID format
-	if _, err := uuid.Parse(normalizedID); err != nil {
+	normalizedID, err := common.NormalizeFormID("delete", id)
+	if err != nil {
 		s.logger.Warn("invalid form ID format received for deletion",
 			"id_length", len(id),
 			"error_type", "invalid_uuid_format")
 
-		invalidErr := common.NewInvalidInputError("delete", "form", id, err)
-
-		return fmt.Errorf("delete form: %w", invalidErr)
+		return fmt.Errorf("delete form: %w", err)
 	}
 
-	result := s.db.GetDB().WithContext(ctx).Where("uuid = ?", normalizedID).Delete(&model.Form{})
+	result := s.db.GetDB().WithContext(ctx).Where(tables.Forms.UUID.Eq(normalizedID)).Delete(&model.Form{})
 	if result.Error != nil {
 		s.logger.Error("failed to delete form",
 			"id_length", len(normalizedID),
@@ -163,7 +181,7 @@ func (s *Store) DeleteForm(ctx context.Context, id string) error {
 // GetFormsByStatus returns forms by their active status
 func (s *Store) GetFormsByStatus(ctx context.Context, status string) ([]*model.Form, error) {
 	var forms []*model.Form
-	if err := s.db.GetDB().WithContext(ctx).Where("status = ?", status).Find(&forms).Error; err != nil {
+	if err := s.db.GetDB().WithContext(ctx).Where(tables.Forms.Status.Eq(status)).Find(&forms).Error; err != nil {
 		return nil, fmt.Errorf("failed to get forms by status: %w", err)
 	}
 
@@ -185,10 +203,28 @@ func (s *Store) CreateSubmission(ctx context.Context, submission *model.FormSubm
 	return nil
 }
 
+// CreateSubmissions inserts submissions in a single multi-row insert
+func (s *Store) CreateSubmissions(ctx context.Context, submissions []*model.FormSubmission) error {
+	if len(submissions) == 0 {
+		return nil
+	}
+
+	if err := s.db.GetDB().WithContext(ctx).CreateInBatches(submissions, len(submissions)).Error; err != nil {
+		s.logger.Error("failed to create form submissions",
+			"count", len(submissions),
+			"error", err,
+		)
+
+		return fmt.Errorf("create submissions: %w", common.NewDatabaseError("create", "form_submission", "", err))
+	}
+
+	return nil
+}
+
 // GetSubmissionByID retrieves a form submission by ID
 func (s *Store) GetSubmissionByID(ctx context.Context, submissionID string) (*model.FormSubmission, error) {
 	var submission model.FormSubmission
-	if err := s.db.GetDB().WithContext(ctx).Where("uuid = ?", submissionID).First(&submission).Error; err != nil {
+	if err := s.db.GetDB().WithContext(ctx).Where(tables.Submissions.UUID.Eq(submissionID)).First(&submission).Error; err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return nil, fmt.Errorf("get submission by ID: %w",
 				common.NewNotFoundError("get", "form_submission", submissionID))
@@ -204,7 +240,7 @@ func (s *Store) GetSubmissionByID(ctx context.Context, submissionID string) (*mo
 // ListSubmissions retrieves all submissions for a form
 func (s *Store) ListSubmissions(ctx context.Context, formID string) ([]*model.FormSubmission, error) {
 	var submissions []*model.FormSubmission
-	if err := s.db.GetDB().WithContext(ctx).Where("form_id = ?", formID).Find(&submissions).Error; err != nil {
+	if err := s.db.GetDB().WithContext(ctx).Where(tables.Submissions.FormID.Eq(formID)).Find(&submissions).Error; err != nil {
 		s.logger.Error("failed to list form submissions",
 			"form_id", formID,
 			"error", err,
@@ -216,11 +252,43 @@ func (s *Store) ListSubmissions(ctx context.Context, formID string) ([]*model.Fo
 	return submissions, nil
 }
 
+// ListSubmissionsBetween retrieves a form's submissions received after since and
+// at or before until, oldest first
+func (s *Store) ListSubmissionsBetween(
+	ctx context.Context,
+	formID string,
+	since, until time.Time,
+) ([]*model.FormSubmission, error) {
+	var submissions []*model.FormSubmission
+	if err := s.db.GetDB().WithContext(ctx).
+		Where(tables.Submissions.FormID.Eq(formID), tables.Submissions.SubmittedAt.Gt(since), tables.Submissions.SubmittedAt.Lte(until)).
+		Order(tables.Submissions.SubmittedAt.Asc()).
+		Find(&submissions).Error; err != nil {
+		return nil, fmt.Errorf("list form submissions between: %w",
+			common.NewDatabaseError("list", "form_submission", formID, err))
+	}
+
+	return submissions, nil
+}
+
+// DeleteTestSubmissionsBefore deletes the test submissions received before the given time
+func (s *Store) DeleteTestSubmissionsBefore(ctx context.Context, before time.Time) (int64, error) {
+	result := s.db.GetDB().WithContext(ctx).
+		Where(tables.Submissions.Test.Eq(true), tables.Submissions.SubmittedAt.Lt(before)).
+		Delete(&model.FormSubmission{})
+	if result.Error != nil {
+		return 0, fmt.Errorf("delete test submissions: %w",
+			common.NewDatabaseError("delete", "form_submission", "", result.Error))
+	}
+
+	return result.RowsAffected, nil
+}
+
 // UpdateSubmission updates a form submission
 func (s *Store) UpdateSubmission(ctx context.Context, submission *model.FormSubmission) error {
 	result := s.db.GetDB().WithContext(ctx).
 		Model(&model.FormSubmission{}).
-		Where("uuid = ?", submission.ID).
+		Where(tables.Submissions.UUID.Eq(submission.ID)).
 		Updates(submission)
 	if result.Error != nil {
 		s.logger.Error("failed to update form submission",
@@ -241,7 +309,7 @@ func (s *Store) UpdateSubmission(ctx context.Context, submission *model.FormSubm
 
 // DeleteSubmission deletes a form submission
 func (s *Store) DeleteSubmission(ctx context.Context, submissionID string) error {
-	result := s.db.GetDB().WithContext(ctx).Where("uuid = ?", submissionID).Delete(&model.FormSubmission{})
+	result := s.db.GetDB().WithContext(ctx).Where(tables.Submissions.UUID.Eq(submissionID)).Delete(&model.FormSubmission{})
 	if result.Error != nil {
 		s.logger.Error("failed to delete form submission",
 			"submission_id", submissionID,
@@ -272,7 +340,7 @@ func (s *Store) GetByFormIDPaginated(
 ) (*common.PaginationResult, error) {
 	var total int64
 
-	query := s.db.GetDB().WithContext(ctx).Model(&model.FormSubmission{}).Where("form_id = ?", formID)
+	query := s.db.GetDB().WithContext(ctx).Model(&model.FormSubmission{}).Where(tables.Submissions.FormID.Eq(formID))
 	if err := query.Count(&total).Error; err != nil {
 		return nil, fmt.Errorf("failed to count submissions: %w", err)
 	}
@@ -294,22 +362,10 @@ func (s *Store) GetByFormIDPaginated(
 	}, nil
 }
 
-// GetByFormAndUser retrieves a submission by form ID and user ID
-func (s *Store) GetByFormAndUser(
-	ctx context.Context,
-	formID string,
-	userID string,
-) (*model.FormSubmission, error) {
-	var submission model.FormSubmission
-
-	query := s.db.GetDB().WithContext(ctx).
-		Where("form_id = ? AND user_id = ?", formID, userID).
-		First(&submission)
-	if err := query.Error; err != nil {
-		return nil, fmt.Errorf("failed to get submission: %w", err)
-	}
-
-	return &submission, nil
+// GetByFormAndUser retrieves a submission by form ID and user ID. Submissions are
+// not linked to users, so none is ever found.
+func (s *Store) GetByFormAndUser(_ context.Context, formID, _ string) (*model.FormSubmission, error) {
+	return nil, fmt.Errorf("failed to get submission: %w", common.NewNotFoundError("get", "form_submission", formID))
 }
 
 // GetSubmissionsByStatus retrieves submissions by status
@@ -319,7 +375,7 @@ func (s *Store) GetSubmissionsByStatus(
 ) ([]*model.FormSubmission, error) {
 	var submissions []*model.FormSubmission
 	if err := s.db.GetDB().WithContext(ctx).
-		Where("status = ?", status).
+		Where(tables.Submissions.Status.Eq(status)).
 		Find(&submissions).Error; err != nil {
 		return nil, fmt.Errorf("failed to get submissions: %w", err)
 	}
@@ -0,0 +1,145 @@
+package repository_test
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+
+	domainform "github.com/goformx/goforms/internal/domain/form"
+	"github.com/goformx/goforms/internal/domain/form/model"
+	"github.com/goformx/goforms/internal/infrastructure/database"
+	"github.com/goformx/goforms/internal/infrastructure/repository/common"
+	repository "github.com/goformx/goforms/internal/infrastructure/repository/form"
+	mocklogging "github.com/goformx/goforms/test/mocks/logging"
+)
+
+var errNoPrepare = errors.New("prepared statements are not scripted")
+
+// scriptedConn is a database connection that answers every statement with
+// rowsAffected and every query with count, recording what it was sent
+type scriptedConn struct {
+	rowsAffected int64
+	count        int64
+	statements   []string
+	args         [][]driver.NamedValue
+}
+
+func (c *scriptedConn) Connect(context.Context) (driver.Conn, error) { return c, nil }
+func (c *scriptedConn) Driver() driver.Driver                        { return nil }
+func (c *scriptedConn) Prepare(string) (driver.Stmt, error)          { return nil, errNoPrepare }
+func (c *scriptedConn) Close() error                                 { return nil }
+func (c *scriptedConn) Begin() (driver.Tx, error)                    { return c, nil }
+func (c *scriptedConn) Commit() error                                { return nil }
+func (c *scriptedConn) Rollback() error                              { return nil }
+
+func (c *scriptedConn) ExecContext(_ context.Context, query string, args []driver.NamedValue) (driver.Result, error) {
+	c.statements = append(c.statements, query)
+	c.args = append(c.args, args)
+
+	return driver.RowsAffected(c.rowsAffected), nil
+}
+
+func (c *scriptedConn) QueryContext(_ context.Context, query string, args []driver.NamedValue) (driver.Rows, error) {
+	c.statements = append(c.statements, query)
+	c.args = append(c.args, args)
+
+	return &countRows{count: c.count}, nil
+}
+
+// countRows is the single-row result of a COUNT query
+type countRows struct {
+	count int64
+	done  bool
+}
+
+func (r *countRows) Columns() []string { return []string{"count"} }
+func (r *countRows) Close() error      { return nil }
+
+func (r *countRows) Next(dest []driver.Value) error {
+	if r.done {
+		return io.EOF
+	}
+
+	r.done = true
+	dest[0] = r.count
+
+	return nil
+}
+
+func newScriptedStore(t *testing.T, conn *scriptedConn) domainform.Repository {
+	t.Helper()
+
+	ctrl := gomock.NewController(t)
+	logger := mocklogging.NewMockLogger(ctrl)
+	logger.EXPECT().Error(gomock.Any(), gomock.Any()).AnyTimes()
+
+	db, err := gorm.Open(postgres.New(postgres.Config{Conn: sql.OpenDB(conn)}), &gorm.Config{SkipDefaultTransaction: true})
+	require.NoError(t, err)
+
+	return repository.NewStore(database.NewWithDB(db, logger), logger)
+}
+
+func TestStore_UpdateFormVersion(t *testing.T) {
+	newForm := func(version int) *model.Form {
+		return &model.Form{ID: formID, UserID: "user-1", Title: "Survey", Version: version}
+	}
+
+	t.Run("current version", func(t *testing.T) {
+		conn := &scriptedConn{rowsAffected: 1}
+		form := newForm(3)
+
+		require.NoError(t, newScriptedStore(t, conn).UpdateForm(t.Context(), form))
+		assert.Equal(t, 4, form.Version, "the version is incremented with the update")
+
+		require.Len(t, conn.statements, 1)
+		assert.Contains(t, conn.statements[0], `"forms"."version" = $`)
+
+		var args []any
+		for _, arg := range conn.args[0] {
+			args = append(args, arg.Value)
+		}
+
+		assert.Contains(t, args, int64(3), "the update is conditional on the version read")
+		assert.Contains(t, args, int64(4), "the new version is written")
+	})
+
+	t.Run("stale version", func(t *testing.T) {
+		conn := &scriptedConn{rowsAffected: 0, count: 1}
+		form := newForm(3)
+
+		err := newScriptedStore(t, conn).UpdateForm(t.Context(), form)
+		require.ErrorIs(t, err, model.ErrFormVersionConflict)
+		assert.Equal(t, 3, form.Version, "a refused update keeps the version read")
+		require.Len(t, conn.statements, 2)
+		assert.Contains(t, strings.ToLower(conn.statements[1]), "count(")
+	})
+
+	t.Run("deleted form", func(t *testing.T) {
+		conn := &scriptedConn{rowsAffected: 0, count: 0}
+
+		err := newScriptedStore(t, conn).UpdateForm(t.Context(), newForm(3))
+		require.ErrorIs(t, err, common.ErrNotFound)
+		assert.NotErrorIs(t, err, model.ErrFormVersionConflict)
+	})
+
+	t.Run("no version", func(t *testing.T) {
+		conn := &scriptedConn{rowsAffected: 0}
+		form := newForm(0)
+
+		err := newScriptedStore(t, conn).UpdateForm(t.Context(), form)
+		require.ErrorIs(t, err, common.ErrNotFound)
+		require.Len(t, conn.statements, 1, "a form that is not found is not counted")
+		assert.NotContains(t, conn.statements[0], `"forms"."version" = $`)
+		assert.Equal(t, 0, form.Version)
+	})
+}
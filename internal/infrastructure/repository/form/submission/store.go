@@ -8,12 +8,14 @@ import (
 	"math"
 
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 
 	"github.com/goformx/goforms/internal/domain/form"
 	"github.com/goformx/goforms/internal/domain/form/model"
 	"github.com/goformx/goforms/internal/infrastructure/database"
 	"github.com/goformx/goforms/internal/infrastructure/logging"
 	"github.com/goformx/goforms/internal/infrastructure/repository/common"
+	"github.com/goformx/goforms/internal/infrastructure/repository/tables"
 )
 
 // Store implements repository.Repository for form submissions
@@ -42,7 +44,7 @@ func (s *Store) Create(ctx context.Context, submission *model.FormSubmission) er
 // GetByID retrieves a form submission by ID
 func (s *Store) GetByID(ctx context.Context, id string) (*model.FormSubmission, error) {
 	var submission model.FormSubmission
-	if err := s.db.GetDB().WithContext(ctx).Where("id = ?", id).First(&submission).Error; err != nil {
+	if err := s.db.GetDB().WithContext(ctx).Where(tables.Submissions.UUID.Eq(id)).First(&submission).Error; err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return nil, fmt.Errorf("form submission not found: %s", id)
 		}
@@ -56,7 +58,7 @@ func (s *Store) GetByID(ctx context.Context, id string) (*model.FormSubmission,
 // GetByFormID retrieves all submissions for a specific form
 func (s *Store) GetByFormID(ctx context.Context, formID string) ([]*model.FormSubmission, error) {
 	var submissions []*model.FormSubmission
-	if err := s.db.GetDB().WithContext(ctx).Where("form_id = ?", formID).Find(&submissions).Error; err != nil {
+	if err := s.db.GetDB().WithContext(ctx).Where(tables.Submissions.FormID.Eq(formID)).Find(&submissions).Error; err != nil {
 		return nil, fmt.Errorf("failed to get form submissions: %w", err)
 	}
 
@@ -74,7 +76,7 @@ func (s *Store) Update(ctx context.Context, submission *model.FormSubmission) er
 
 // Delete deletes a form submission by ID
 func (s *Store) Delete(ctx context.Context, id string) error {
-	if err := s.db.GetDB().WithContext(ctx).Where("id = ?", id).Delete(&model.FormSubmission{}).Error; err != nil {
+	if err := s.db.GetDB().WithContext(ctx).Where(tables.Submissions.UUID.Eq(id)).Delete(&model.FormSubmission{}).Error; err != nil {
 		return fmt.Errorf("failed to delete form submission: %w", err)
 	}
 
@@ -135,7 +137,7 @@ func (s *Store) GetByFormIDPaginated(
 
 	// Count total submissions for this form
 	if err := s.db.GetDB().WithContext(ctx).Model(&model.FormSubmission{}).
-		Where("form_id = ?", formID).Count(&total).Error; err != nil {
+		Where(tables.Submissions.FormID.Eq(formID)).Count(&total).Error; err != nil {
 		return nil, fmt.Errorf("failed to count submissions: %w", err)
 	}
 
@@ -145,7 +147,7 @@ func (s *Store) GetByFormIDPaginated(
 	}
 
 	// Get paginated submissions
-	if err := s.db.GetDB().WithContext(ctx).Where("form_id = ?", formID).
+	if err := s.db.GetDB().WithContext(ctx).Where(tables.Submissions.FormID.Eq(formID)).
 		Offset(params.GetOffset()).Limit(params.GetLimit()).
 		Find(&submissions).Error; err != nil {
 		return nil, fmt.Errorf("failed to get submissions: %w", err)
@@ -158,66 +160,38 @@ func (s *Store) GetByFormIDPaginated(
 func (s *Store) CountByFormID(ctx context.Context, formID string) (int64, error) {
 	var count int64
 	if err := s.db.GetDB().WithContext(ctx).Model(&model.FormSubmission{}).
-		Where("form_id = ?", formID).Count(&count).Error; err != nil {
+		Where(tables.Submissions.FormID.Eq(formID)).Count(&count).Error; err != nil {
 		return 0, fmt.Errorf("failed to count form submissions: %w", err)
 	}
 
 	return count, nil
 }
 
-// GetByFormIDAndUserID retrieves a specific submission by form ID and user ID
-func (s *Store) GetByFormIDAndUserID(
-	ctx context.Context,
-	formID string,
-	userID string,
-) (*model.FormSubmission, error) {
-	var submission model.FormSubmission
-
-	// Count submissions for this form and user
-	var count int64
-	if err := s.db.GetDB().WithContext(ctx).Model(&model.FormSubmission{}).
-		Where("form_id = ?", formID).Count(&count).Error; err != nil {
-		return nil, fmt.Errorf("failed to count submissions: %w", err)
-	}
-
-	// If no submissions found, return not found error
-	if count == 0 {
-		return nil, fmt.Errorf("no submissions found for form %s and user %s", formID, userID)
-	}
-
-	// Get the submission
-	if err := s.db.GetDB().WithContext(ctx).
-		Where("form_id = ? AND user_id = ?", formID, userID).
-		First(&submission).Error; err != nil {
-		return nil, fmt.Errorf("failed to get submission: %w", err)
-	}
-
-	return &submission, nil
+// GetByFormIDAndUserID retrieves a specific submission by form ID and user ID.
+// Submissions are not linked to users, so none is ever found.
+func (s *Store) GetByFormIDAndUserID(_ context.Context, formID, userID string) (*model.FormSubmission, error) {
+	return nil, fmt.Errorf("no submissions found for form %s and user %s", formID, userID)
 }
 
 // GetByStatus retrieves submissions by status
 func (s *Store) GetByStatus(ctx context.Context, status model.SubmissionStatus) ([]*model.FormSubmission, error) {
 	var submissions []*model.FormSubmission
-	if err := s.db.GetDB().WithContext(ctx).Where("status = ?", status).Find(&submissions).Error; err != nil {
+	if err := s.db.GetDB().WithContext(ctx).Where(tables.Submissions.Status.Eq(status)).Find(&submissions).Error; err != nil {
 		return nil, fmt.Errorf("failed to get form submissions by status: %w", err)
 	}
 
 	return submissions, nil
 }
 
-// GetActiveSubmissions retrieves active submissions (not deleted)
+// GetActiveSubmissions retrieves active submissions (not deleted). Submissions
+// are deleted outright, so every stored one is active and none is inactive.
 func (s *Store) GetActiveSubmissions(ctx context.Context, active bool) ([]*model.FormSubmission, error) {
-	var submissions []*model.FormSubmission
-
-	query := s.db.GetDB().WithContext(ctx)
-
-	if active {
-		query = query.Where("deleted_at IS NULL")
-	} else {
-		query = query.Where("deleted_at IS NOT NULL")
+	if !active {
+		return []*model.FormSubmission{}, nil
 	}
 
-	if err := query.Find(&submissions).Error; err != nil {
+	var submissions []*model.FormSubmission
+	if err := s.db.GetDB().WithContext(ctx).Find(&submissions).Error; err != nil {
 		return nil, fmt.Errorf("failed to get active form submissions: %w", err)
 	}
 
@@ -228,10 +202,8 @@ func (s *Store) GetActiveSubmissions(ctx context.Context, active bool) ([]*model
 func (s *Store) Search(ctx context.Context, query string, offset, limit int) ([]*model.FormSubmission, error) {
 	var submissions []*model.FormSubmission
 
-	searchQuery := "%" + query + "%"
-
 	if err := s.db.GetDB().WithContext(ctx).
-		Where("data::text ILIKE ? OR status::text ILIKE ?", searchQuery, searchQuery).
+		Where(common.ContainsFold{Columns: []clause.Column{tables.Submissions.Data.Clause(), tables.Submissions.Status.Clause()}, Value: query}).
 		Offset(offset).
 		Limit(limit).
 		Find(&submissions).Error; err != nil {
@@ -248,7 +220,7 @@ func (s *Store) UpdateStatus(
 	status model.SubmissionStatus,
 ) error {
 	if err := s.db.GetDB().WithContext(ctx).Model(&model.FormSubmission{}).
-		Where("id = ?", id).Update("status", status).Error; err != nil {
+		Where(tables.Submissions.UUID.Eq(id)).Updates(common.Values(tables.Submissions.Status.Set(status))).Error; err != nil {
 		return fmt.Errorf("failed to update submission status: %w", err)
 	}
 
@@ -297,7 +269,7 @@ func (s *Store) GetSubmissionsByStatus(
 
 	// Count total submissions with this status
 	if err := s.db.GetDB().WithContext(ctx).Model(&model.FormSubmission{}).
-		Where("status = ?", status).Count(&total).Error; err != nil {
+		Where(tables.Submissions.Status.Eq(status)).Count(&total).Error; err != nil {
 		return nil, fmt.Errorf("failed to count submissions: %w", err)
 	}
 
@@ -307,7 +279,7 @@ func (s *Store) GetSubmissionsByStatus(
 	}
 
 	// Get paginated submissions
-	if err := s.db.GetDB().WithContext(ctx).Where("status = ?", status).
+	if err := s.db.GetDB().WithContext(ctx).Where(tables.Submissions.Status.Eq(status)).
 		Offset(params.GetOffset()).Limit(params.GetLimit()).
 		Find(&submissions).Error; err != nil {
 		return nil, fmt.Errorf("failed to get submissions: %w", err)
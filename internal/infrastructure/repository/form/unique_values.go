@@ -0,0 +1,96 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"github.com/goformx/goforms/internal/domain/form"
+	"github.com/goformx/goforms/internal/domain/form/model"
+	"github.com/goformx/goforms/internal/infrastructure/repository/common"
+)
+
+// uniqueValueRow is a row of form_submission_unique_values. The unique index on
+// form, field and hash is what rejects repeated values, also between
+// submissions written at the same time.
+type uniqueValueRow struct {
+	FormID       string    `gorm:"primaryKey;type:uuid"`
+	FieldKey     string    `gorm:"primaryKey;size:255"`
+	ValueHash    string    `gorm:"primaryKey;size:64"`
+	SubmissionID string    `gorm:"not null;type:uuid"`
+	CreatedAt    time.Time `gorm:"not null;autoCreateTime"`
+}
+
+// TableName specifies the table name for unique submission values
+func (r *uniqueValueRow) TableName() string {
+	return "form_submission_unique_values"
+}
+
+// CreateSubmissionWithUniqueValues stores a submission and claims its unique
+// values in one transaction. A value already claimed for the form rolls the
+// transaction back and is reported in a *form.DuplicateValuesError.
+func (s *Store) CreateSubmissionWithUniqueValues(
+	ctx context.Context,
+	submission *model.FormSubmission,
+	values []form.UniqueValue,
+) error {
+	// The claims reference the submission, so its ID is needed before the insert
+	if submission.ID == "" {
+		submission.ID = uuid.New().String()
+	}
+
+	var duplicates []string
+
+	err := s.db.GetDB().WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(submission).Error; err != nil {
+			return fmt.Errorf("create submission: %w", err)
+		}
+
+		for _, value := range values {
+			row := &uniqueValueRow{
+				FormID:       submission.FormID,
+				FieldKey:     value.FieldKey,
+				ValueHash:    value.Hash,
+				SubmissionID: submission.ID,
+			}
+
+			// A claimed value inserts nothing instead of failing the transaction
+			result := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(row)
+			if result.Error != nil {
+				return fmt.Errorf("claim unique value of %s: %w", value.FieldKey, result.Error)
+			}
+
+			if result.RowsAffected == 0 {
+				duplicates = append(duplicates, value.FieldKey)
+			}
+		}
+
+		if len(duplicates) > 0 {
+			return &form.DuplicateValuesError{Fields: duplicates}
+		}
+
+		return nil
+	})
+
+	var duplicateErr *form.DuplicateValuesError
+	if errors.As(err, &duplicateErr) {
+		return duplicateErr
+	}
+
+	if err != nil {
+		s.logger.Error("failed to create form submission with unique values",
+			"submission_id", submission.ID,
+			"form_id", submission.FormID,
+			"error", err,
+		)
+
+		return fmt.Errorf("create submission: %w", common.NewDatabaseError("create", "form_submission", submission.ID, err))
+	}
+
+	return nil
+}
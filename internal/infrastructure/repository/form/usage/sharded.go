@@ -0,0 +1,107 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/goformx/goforms/internal/domain/form"
+	"github.com/goformx/goforms/internal/domain/form/model"
+	"github.com/goformx/goforms/internal/infrastructure/database"
+	"github.com/goformx/goforms/internal/infrastructure/logging"
+	"github.com/goformx/goforms/internal/infrastructure/repository/common"
+	"github.com/goformx/goforms/internal/infrastructure/repository/tables"
+)
+
+// formIDBatchSize is the most form IDs bound to one shard query
+const formIDBatchSize = 1000
+
+// ShardedStore implements form.UsageRepository when submissions are sharded. A
+// user's forms are read from the primary database and their submissions are
+// counted and measured in the shards holding them.
+type ShardedStore struct {
+	*Store
+
+	shards *database.Shards
+}
+
+// NewShardedStore creates a usage store that sums submissions over the shards
+func NewShardedStore(db database.DB, shards *database.Shards, logger logging.Logger) form.UsageRepository {
+	return &ShardedStore{
+		Store:  &Store{db: db, logger: logger},
+		shards: shards,
+	}
+}
+
+// CountSubmissionsByUserSince counts submissions to a user's forms received at or
+// after since, test submissions aside
+func (s *ShardedStore) CountSubmissionsByUserSince(ctx context.Context, userID string, since time.Time) (int64, error) {
+	var total int64
+
+	err := s.eachShard(ctx, userID, func(db database.DB, formIDs []string) error {
+		var count int64
+		if err := db.GetDB().WithContext(ctx).
+			Model(&model.FormSubmission{}).
+			Where(tables.Submissions.FormID.In(formIDs), tables.Submissions.SubmittedAt.Gte(since), tables.Submissions.Test.Eq(false)).
+			Count(&count).Error; err != nil {
+			return fmt.Errorf("count submissions: %w", common.NewDatabaseError("count", "submission", userID, err))
+		}
+
+		total += count
+
+		return nil
+	})
+
+	return total, err
+}
+
+// SubmissionStorageByUser sums the stored size of submission data for a user's forms
+func (s *ShardedStore) SubmissionStorageByUser(ctx context.Context, userID string) (int64, error) {
+	size := common.ByteLength{Column: tables.Submissions.Data.Clause()}
+
+	var total int64
+
+	err := s.eachShard(ctx, userID, func(db database.DB, formIDs []string) error {
+		var sum int64
+		if err := db.GetDB().WithContext(ctx).
+			Table(tables.Submissions.Table).
+			Clauses(common.Select(common.SumOrZero(size))).
+			Where(tables.Submissions.FormID.In(formIDs)).
+			Scan(&sum).Error; err != nil {
+			return fmt.Errorf("measure storage: %w", common.NewDatabaseError("sum", "submission", userID, err))
+		}
+
+		total += sum
+
+		return nil
+	})
+
+	return total, err
+}
+
+// eachShard calls fn with each shard holding submissions to the user's forms
+// that are not deleted, and the IDs of those forms in batches
+func (s *ShardedStore) eachShard(ctx context.Context, userID string, fn func(db database.DB, formIDs []string) error) error {
+	var formIDs []string
+	forms := s.db.GetDB().WithContext(ctx).Model(&model.Form{}).Where(tables.Forms.UserID.Eq(userID))
+	if err := common.Pluck(forms, tables.Forms.UUID, &formIDs).Error; err != nil {
+		return fmt.Errorf("list forms: %w", common.NewDatabaseError("list", "form", userID, err))
+	}
+
+	byShard := make(map[string][]string)
+	for _, formID := range formIDs {
+		id := s.shards.Locate(formID)
+		byShard[id] = append(byShard[id], formID)
+	}
+
+	for _, id := range s.shards.IDs() {
+		for batch := range slices.Chunk(byShard[id], formIDBatchSize) {
+			if err := fn(s.shards.DB(id), batch); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
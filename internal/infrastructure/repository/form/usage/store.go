@@ -0,0 +1,82 @@
+// Package repository provides the per-user usage repository implementation
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"gorm.io/gorm/clause"
+
+	"github.com/goformx/goforms/internal/domain/form"
+	"github.com/goformx/goforms/internal/domain/form/model"
+	"github.com/goformx/goforms/internal/infrastructure/database"
+	"github.com/goformx/goforms/internal/infrastructure/logging"
+	"github.com/goformx/goforms/internal/infrastructure/repository/common"
+	"github.com/goformx/goforms/internal/infrastructure/repository/tables"
+)
+
+// Store implements form.UsageRepository with aggregate queries over forms and submissions
+type Store struct {
+	db     database.DB
+	logger logging.Logger
+}
+
+// NewStore creates a new usage store
+func NewStore(db database.DB, logger logging.Logger) form.UsageRepository {
+	return &Store{
+		db:     db,
+		logger: logger,
+	}
+}
+
+// CountFormsByUser counts the forms owned by a user, excluding deleted forms
+func (s *Store) CountFormsByUser(ctx context.Context, userID string) (int64, error) {
+	var count int64
+	if err := s.db.GetDB().WithContext(ctx).
+		Model(&model.Form{}).
+		Where(tables.Forms.UserID.Eq(userID)).
+		Count(&count).Error; err != nil {
+		return 0, fmt.Errorf("count forms: %w", common.NewDatabaseError("count", "form", userID, err))
+	}
+
+	return count, nil
+}
+
+// CountSubmissionsByUserSince counts submissions to a user's forms received at or
+// after since, test submissions aside
+func (s *Store) CountSubmissionsByUserSince(ctx context.Context, userID string, since time.Time) (int64, error) {
+	var count int64
+	if err := s.db.GetDB().WithContext(ctx).
+		Table(tables.Submissions.Table).
+		Clauses(ownedBy(userID)).
+		Where(tables.Submissions.SubmittedAt.Gte(since), tables.Submissions.Test.Eq(false)).
+		Count(&count).Error; err != nil {
+		return 0, fmt.Errorf("count submissions: %w", common.NewDatabaseError("count", "submission", userID, err))
+	}
+
+	return count, nil
+}
+
+// SubmissionStorageByUser sums the stored size of submission data for a user's forms
+func (s *Store) SubmissionStorageByUser(ctx context.Context, userID string) (int64, error) {
+	size := common.ByteLength{Column: tables.Submissions.Data.Clause()}
+
+	var total int64
+	if err := s.db.GetDB().WithContext(ctx).
+		Table(tables.Submissions.Table).
+		Clauses(common.Select(common.SumOrZero(size)), ownedBy(userID)).
+		Scan(&total).Error; err != nil {
+		return 0, fmt.Errorf("measure storage: %w", common.NewDatabaseError("sum", "submission", userID, err))
+	}
+
+	return total, nil
+}
+
+// ownedBy joins submissions to their forms, keeping those to the user's forms
+// that are not deleted
+func ownedBy(userID string) clause.From {
+	forms := tables.Forms
+
+	return common.Join(forms.Table, forms.UUID.EqColumn(tables.Submissions.FormID), forms.UserID.Eq(userID), forms.DeletedAt.IsNull())
+}
@@ -0,0 +1,91 @@
+// Package repository provides the saved submission view repository implementation
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"gorm.io/gorm"
+
+	"github.com/goformx/goforms/internal/domain/form"
+	"github.com/goformx/goforms/internal/domain/form/model"
+	"github.com/goformx/goforms/internal/infrastructure/database"
+	"github.com/goformx/goforms/internal/infrastructure/logging"
+	"github.com/goformx/goforms/internal/infrastructure/repository/common"
+	"github.com/goformx/goforms/internal/infrastructure/repository/tables"
+)
+
+// Store implements form.SubmissionViewRepository
+type Store struct {
+	db     database.DB
+	logger logging.Logger
+}
+
+// NewStore creates a new submission view store
+func NewStore(db database.DB, logger logging.Logger) form.SubmissionViewRepository {
+	return &Store{
+		db:     db,
+		logger: logger,
+	}
+}
+
+// CreateView creates a new submission view
+func (s *Store) CreateView(ctx context.Context, view *model.SubmissionView) error {
+	if err := s.db.GetDB().WithContext(ctx).Create(view).Error; err != nil {
+		return fmt.Errorf("create view: %w", common.NewDatabaseError("create", "submission_view", view.ID, err))
+	}
+
+	return nil
+}
+
+// GetViewByID retrieves a submission view by ID
+func (s *Store) GetViewByID(ctx context.Context, id string) (*model.SubmissionView, error) {
+	var view model.SubmissionView
+	if err := s.db.GetDB().WithContext(ctx).Where(tables.SubmissionViews.UUID.Eq(id)).First(&view).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, fmt.Errorf("get view: %w", model.ErrViewNotFound)
+		}
+
+		return nil, fmt.Errorf("get view: %w", common.NewDatabaseError("get", "submission_view", id, err))
+	}
+
+	return &view, nil
+}
+
+// ListViewsByForm lists the submission views for a form
+func (s *Store) ListViewsByForm(ctx context.Context, formID string) ([]*model.SubmissionView, error) {
+	var views []*model.SubmissionView
+	if err := s.db.GetDB().WithContext(ctx).
+		Where(tables.SubmissionViews.FormID.Eq(formID)).
+		Order(tables.SubmissionViews.CreatedAt.Asc()).
+		Find(&views).Error; err != nil {
+		return nil, fmt.Errorf("list views: %w", common.NewDatabaseError("list", "submission_view", "", err))
+	}
+
+	return views, nil
+}
+
+// UpdateView saves a submission view
+func (s *Store) UpdateView(ctx context.Context, view *model.SubmissionView) error {
+	result := s.db.GetDB().WithContext(ctx).Save(view)
+	if result.Error != nil {
+		return fmt.Errorf("update view: %w", common.NewDatabaseError("update", "submission_view", view.ID, result.Error))
+	}
+
+	return nil
+}
+
+// DeleteView deletes a submission view by ID
+func (s *Store) DeleteView(ctx context.Context, id string) error {
+	result := s.db.GetDB().WithContext(ctx).Where(tables.SubmissionViews.UUID.Eq(id)).Delete(&model.SubmissionView{})
+	if result.Error != nil {
+		return fmt.Errorf("delete view: %w", common.NewDatabaseError("delete", "submission_view", id, result.Error))
+	}
+
+	if result.RowsAffected == 0 {
+		return fmt.Errorf("delete view: %w", model.ErrViewNotFound)
+	}
+
+	return nil
+}
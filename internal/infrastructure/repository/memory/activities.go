@@ -0,0 +1,62 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/goformx/goforms/internal/domain/form/model"
+)
+
+// activityStore implements form.SubmissionActivityRepository in memory
+type activityStore struct {
+	store *Store
+}
+
+// CreateActivity stores a submission activity record
+func (r *activityStore) CreateActivity(_ context.Context, activity *model.SubmissionActivity) error {
+	s := r.store
+
+	if activity.ID == "" {
+		activity.ID = uuid.New().String()
+	}
+
+	if activity.CreatedAt.IsZero() {
+		activity.CreatedAt = time.Now()
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.activities = append(s.activities, cloneActivity(activity))
+
+	return nil
+}
+
+// ListActivitiesBySubmission lists a submission's activity, oldest first
+func (r *activityStore) ListActivitiesBySubmission(
+	_ context.Context,
+	submissionID string,
+) ([]*model.SubmissionActivity, error) {
+	s := r.store
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	activities := make([]*model.SubmissionActivity, 0)
+	for _, activity := range s.activities {
+		if activity.SubmissionID == submissionID {
+			activities = append(activities, cloneActivity(activity))
+		}
+	}
+
+	return activities, nil
+}
+
+func cloneActivity(activity *model.SubmissionActivity) *model.SubmissionActivity {
+	clone := *activity
+	clone.Details = activity.Details.Clone()
+
+	return &clone
+}
@@ -0,0 +1,61 @@
+package repository
+
+import (
+	"context"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/goformx/goforms/internal/domain/form/model"
+)
+
+// archiveStore implements archive.Repository in memory
+type archiveStore struct {
+	store *Store
+}
+
+// Expired returns up to limit submissions submitted before cutoff, oldest first
+func (a *archiveStore) Expired(_ context.Context, cutoff time.Time, limit int) ([]*model.FormSubmission, error) {
+	s := a.store
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var expired []*model.FormSubmission
+
+	for _, submission := range s.submissions {
+		if submission.SubmittedAt.Before(cutoff) {
+			expired = append(expired, submission)
+		}
+	}
+
+	slices.SortFunc(expired, func(x, y *model.FormSubmission) int {
+		if n := x.SubmittedAt.Compare(y.SubmittedAt); n != 0 {
+			return n
+		}
+
+		return strings.Compare(x.ID, y.ID)
+	})
+
+	expired = expired[:min(limit, len(expired))]
+
+	for i, submission := range expired {
+		expired[i] = cloneSubmission(submission)
+	}
+
+	return expired, nil
+}
+
+// Delete deletes the submissions with the given IDs
+func (a *archiveStore) Delete(_ context.Context, ids []string) error {
+	s := a.store
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, id := range ids {
+		delete(s.submissions, id)
+	}
+
+	return nil
+}
@@ -0,0 +1,65 @@
+package repository
+
+import (
+	"context"
+	"maps"
+	"slices"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/goformx/goforms/internal/domain/audit"
+)
+
+// auditStore implements audit.Repository in memory
+type auditStore struct {
+	store *Store
+}
+
+// CreateEntry stores an audit entry
+func (a *auditStore) CreateEntry(_ context.Context, entry *audit.Entry) error {
+	s := a.store
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if entry.ID == "" {
+		entry.ID = uuid.New().String()
+	}
+
+	if entry.CreatedAt.IsZero() {
+		entry.CreatedAt = time.Now()
+	}
+
+	s.auditEntries = append(s.auditEntries, cloneAuditEntry(entry))
+
+	return nil
+}
+
+// ListEntries lists matching entries, newest first, with the total count
+func (a *auditStore) ListEntries(_ context.Context, filter audit.Filter, offset, limit int) ([]*audit.Entry, int64, error) {
+	s := a.store
+
+	s.mu.RLock()
+
+	var entries []*audit.Entry
+
+	for _, entry := range s.auditEntries {
+		if filter.Matches(entry) {
+			entries = append(entries, cloneAuditEntry(entry))
+		}
+	}
+
+	s.mu.RUnlock()
+
+	slices.SortStableFunc(entries, func(x, y *audit.Entry) int { return y.CreatedAt.Compare(x.CreatedAt) })
+
+	return page(entries, offset, limit), int64(len(entries)), nil
+}
+
+func cloneAuditEntry(entry *audit.Entry) *audit.Entry {
+	clone := *entry
+	clone.Details = maps.Clone(entry.Details)
+
+	return &clone
+}
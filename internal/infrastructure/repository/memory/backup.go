@@ -0,0 +1,70 @@
+package repository
+
+import (
+	"context"
+	"slices"
+
+	"github.com/goformx/goforms/internal/domain/backup"
+	"github.com/goformx/goforms/internal/domain/form/model"
+)
+
+// backupStore implements backup.Repository in memory
+type backupStore struct {
+	store *Store
+}
+
+// Dump reads every form and the submissions to those forms, oldest first
+func (b *backupStore) Dump(_ context.Context) (*backup.Dataset, error) {
+	s := b.store
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	dataset := &backup.Dataset{
+		Forms:       make([]backup.Form, 0, len(s.forms)),
+		Submissions: make([]backup.Submission, 0, len(s.submissions)),
+	}
+
+	for _, f := range s.forms {
+		dataset.Forms = append(dataset.Forms, backup.NewForm(f.Clone()))
+	}
+
+	for _, submission := range s.submissions {
+		if _, ok := s.forms[submission.FormID]; ok {
+			dataset.Submissions = append(dataset.Submissions, backup.NewSubmission(cloneSubmission(submission)))
+		}
+	}
+
+	slices.SortFunc(dataset.Forms, func(x, y backup.Form) int { return x.CreatedAt.Compare(y.CreatedAt) })
+	slices.SortFunc(dataset.Submissions, func(x, y backup.Submission) int { return x.CreatedAt.Compare(y.CreatedAt) })
+
+	return dataset, nil
+}
+
+// Restore writes the dataset's forms and submissions, replacing those with the same IDs
+func (b *backupStore) Restore(_ context.Context, dataset *backup.Dataset) error {
+	forms := make([]*model.Form, 0, len(dataset.Forms))
+	for _, f := range dataset.Forms {
+		forms = append(forms, f.Model().Clone())
+	}
+
+	submissions := make([]*model.FormSubmission, 0, len(dataset.Submissions))
+	for _, submission := range dataset.Submissions {
+		submissions = append(submissions, cloneSubmission(submission.Model()))
+	}
+
+	s := b.store
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, f := range forms {
+		s.forms[f.ID] = f
+	}
+
+	for _, submission := range submissions {
+		s.submissions[submission.ID] = submission
+	}
+
+	return nil
+}
@@ -0,0 +1,81 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/goformx/goforms/internal/domain/billing"
+)
+
+// billingStore implements billing.Repository in memory
+type billingStore struct {
+	store *Store
+}
+
+// GetSubscription returns a user's subscription
+func (b *billingStore) GetSubscription(_ context.Context, userID string) (*billing.Subscription, error) {
+	s := b.store
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	subscription, ok := s.subscriptions[userID]
+	if !ok {
+		return nil, billing.ErrSubscriptionNotFound
+	}
+
+	return cloneSubscription(subscription), nil
+}
+
+// GetSubscriptionByCustomer returns the most recently updated subscription of a Stripe customer
+func (b *billingStore) GetSubscriptionByCustomer(_ context.Context, customerID string) (*billing.Subscription, error) {
+	s := b.store
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var found *billing.Subscription
+
+	for _, subscription := range s.subscriptions {
+		if subscription.CustomerID == customerID &&
+			(found == nil || subscription.UpdatedAt.After(found.UpdatedAt)) {
+			found = subscription
+		}
+	}
+
+	if found == nil {
+		return nil, billing.ErrSubscriptionNotFound
+	}
+
+	return cloneSubscription(found), nil
+}
+
+// SaveSubscription creates or replaces a user's subscription, keeping the creation time of the one it replaces
+func (b *billingStore) SaveSubscription(_ context.Context, subscription *billing.Subscription) error {
+	s := b.store
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := time.Now()
+
+	subscription.CreatedAt = now
+	if existing, ok := s.subscriptions[subscription.UserID]; ok {
+		subscription.CreatedAt = existing.CreatedAt
+	}
+
+	subscription.UpdatedAt = now
+	s.subscriptions[subscription.UserID] = cloneSubscription(subscription)
+
+	return nil
+}
+
+func cloneSubscription(subscription *billing.Subscription) *billing.Subscription {
+	clone := *subscription
+	if subscription.CurrentPeriodEnd != nil {
+		end := *subscription.CurrentPeriodEnd
+		clone.CurrentPeriodEnd = &end
+	}
+
+	return &clone
+}
@@ -0,0 +1,123 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/goformx/goforms/internal/domain/dataset"
+)
+
+// datasetStore implements dataset.Repository in memory
+type datasetStore struct {
+	store *Store
+}
+
+// GetDataset retrieves an owner's dataset by name
+func (d *datasetStore) GetDataset(_ context.Context, ownerID, name string) (*dataset.Dataset, error) {
+	s := d.store
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	stored := d.find(ownerID, name)
+	if stored == nil {
+		return nil, fmt.Errorf("get dataset: %w", dataset.ErrDatasetNotFound)
+	}
+
+	return stored.Clone(), nil
+}
+
+// ListDatasets lists an owner's datasets, ordered by name
+func (d *datasetStore) ListDatasets(_ context.Context, ownerID string) ([]*dataset.Dataset, error) {
+	s := d.store
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	datasets := make([]*dataset.Dataset, 0)
+
+	for _, stored := range s.datasets {
+		if stored.OwnerID == ownerID {
+			datasets = append(datasets, stored.Clone())
+		}
+	}
+
+	slices.SortFunc(datasets, func(a, b *dataset.Dataset) int {
+		return strings.Compare(a.Name, b.Name)
+	})
+
+	return datasets, nil
+}
+
+// CreateDataset stores a new dataset unless the owner already uses its name
+func (d *datasetStore) CreateDataset(_ context.Context, created *dataset.Dataset) error {
+	s := d.store
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if d.find(created.OwnerID, created.Name) != nil {
+		return fmt.Errorf("create dataset: %w", dataset.ErrDatasetExists)
+	}
+
+	stamp(&created.ID, &created.CreatedAt, &created.UpdatedAt)
+	s.datasets[created.ID] = created.Clone()
+
+	return nil
+}
+
+// UpdateDataset replaces the title, kind, columns and rows of an owner's dataset
+func (d *datasetStore) UpdateDataset(_ context.Context, updated *dataset.Dataset) error {
+	s := d.store
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	stored := d.find(updated.OwnerID, updated.Name)
+	if stored == nil {
+		return fmt.Errorf("update dataset: %w", dataset.ErrDatasetNotFound)
+	}
+
+	replacement := updated.Clone()
+	replacement.ID = stored.ID
+	replacement.CreatedAt = stored.CreatedAt
+	replacement.UpdatedAt = time.Now()
+	s.datasets[stored.ID] = replacement
+
+	updated.ID = replacement.ID
+	updated.CreatedAt = replacement.CreatedAt
+	updated.UpdatedAt = replacement.UpdatedAt
+
+	return nil
+}
+
+// DeleteDataset deletes an owner's dataset
+func (d *datasetStore) DeleteDataset(_ context.Context, ownerID, name string) error {
+	s := d.store
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	stored := d.find(ownerID, name)
+	if stored == nil {
+		return fmt.Errorf("delete dataset: %w", dataset.ErrDatasetNotFound)
+	}
+
+	delete(s.datasets, stored.ID)
+
+	return nil
+}
+
+// find returns the stored dataset of an owner and name; callers hold the lock
+func (d *datasetStore) find(ownerID, name string) *dataset.Dataset {
+	for _, stored := range d.store.datasets {
+		if stored.OwnerID == ownerID && stored.Name == name {
+			return stored
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,127 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/goformx/goforms/internal/domain/deadletter"
+)
+
+// DeadLetterStore implements deadletter.Repository in memory. Like
+// EventLogStore it stands apart from Store, because the email sender that
+// dead-letters into it is built before the other stores, and its entries are
+// not written to the snapshot.
+type DeadLetterStore struct {
+	mu      sync.RWMutex
+	entries []*deadletter.Entry
+}
+
+// NewDeadLetterStore creates an empty in-memory dead-letter queue
+func NewDeadLetterStore() *DeadLetterStore {
+	return &DeadLetterStore{}
+}
+
+// cloneDeadLetter copies an entry so callers cannot change the stored one
+func cloneDeadLetter(entry *deadletter.Entry) *deadletter.Entry {
+	clone := *entry
+	clone.Payload = slices.Clone(entry.Payload)
+	clone.History = slices.Clone(entry.History)
+
+	return &clone
+}
+
+// Create stores an entry
+func (d *DeadLetterStore) Create(_ context.Context, entry *deadletter.Entry) error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if entry.ID == "" {
+		entry.ID = uuid.New().String()
+	}
+
+	now := time.Now()
+	entry.CreatedAt = now
+	entry.UpdatedAt = now
+
+	d.entries = append(d.entries, cloneDeadLetter(entry))
+
+	return nil
+}
+
+// Get returns an entry
+func (d *DeadLetterStore) Get(_ context.Context, id string) (*deadletter.Entry, error) {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+
+	for _, entry := range d.entries {
+		if entry.ID == id {
+			return cloneDeadLetter(entry), nil
+		}
+	}
+
+	return nil, fmt.Errorf("get dead letter %s: %w", id, deadletter.ErrEntryNotFound)
+}
+
+// Update saves an entry
+func (d *DeadLetterStore) Update(_ context.Context, entry *deadletter.Entry) error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	for i, stored := range d.entries {
+		if stored.ID == entry.ID {
+			entry.UpdatedAt = time.Now()
+			d.entries[i] = cloneDeadLetter(entry)
+
+			return nil
+		}
+	}
+
+	return fmt.Errorf("update dead letter %s: %w", entry.ID, deadletter.ErrEntryNotFound)
+}
+
+// List lists matching entries, newest first, with the total count
+func (d *DeadLetterStore) List(_ context.Context, filter deadletter.Filter, offset, limit int) ([]*deadletter.Entry, int64, error) {
+	d.mu.RLock()
+
+	var entries []*deadletter.Entry
+
+	for _, entry := range d.entries {
+		if filter.Matches(entry) {
+			entries = append(entries, cloneDeadLetter(entry))
+		}
+	}
+
+	d.mu.RUnlock()
+
+	slices.Reverse(entries)
+
+	return page(entries, offset, limit), int64(len(entries)), nil
+}
+
+// Delete deletes the matching entries
+func (d *DeadLetterStore) Delete(_ context.Context, filter deadletter.Filter) (int64, error) {
+	return d.deleteWhere(filter.Matches), nil
+}
+
+// DeleteResolvedBefore deletes the entries resolved before the given time
+func (d *DeadLetterStore) DeleteResolvedBefore(_ context.Context, before time.Time) (int64, error) {
+	return d.deleteWhere(func(entry *deadletter.Entry) bool {
+		return entry.Status == deadletter.StatusResolved && entry.ResolvedAt != nil && entry.ResolvedAt.Before(before)
+	}), nil
+}
+
+// deleteWhere deletes the entries matching match and returns how many there were
+func (d *DeadLetterStore) deleteWhere(match func(*deadletter.Entry) bool) int64 {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	before := len(d.entries)
+	d.entries = slices.DeleteFunc(d.entries, match)
+
+	return int64(before - len(d.entries))
+}
@@ -0,0 +1,127 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/goformx/goforms/internal/domain/emaildelivery"
+)
+
+// deliveryStore implements emaildelivery.Repository in memory
+type deliveryStore struct {
+	store *Store
+}
+
+// IsSuppressed reports whether an address is suppressed
+func (d *deliveryStore) IsSuppressed(_ context.Context, address string) (bool, error) {
+	s := d.store
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	_, ok := s.suppressions[address]
+
+	return ok, nil
+}
+
+// SaveSuppression creates or replaces the suppression of an address
+func (d *deliveryStore) SaveSuppression(_ context.Context, suppression *emaildelivery.Suppression) error {
+	s := d.store
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := time.Now()
+
+	suppression.CreatedAt = now
+	if existing, ok := s.suppressions[suppression.Address]; ok {
+		suppression.CreatedAt = existing.CreatedAt
+	}
+
+	suppression.UpdatedAt = now
+
+	clone := *suppression
+	s.suppressions[suppression.Address] = &clone
+
+	return nil
+}
+
+// DeleteSuppression removes the suppression of an address
+func (d *deliveryStore) DeleteSuppression(_ context.Context, address string) error {
+	s := d.store
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.suppressions[address]; !ok {
+		return fmt.Errorf("delete email suppression: %w", emaildelivery.ErrSuppressionNotFound)
+	}
+
+	delete(s.suppressions, address)
+
+	return nil
+}
+
+// ListSuppressions lists suppressions, newest first, with the total count
+func (d *deliveryStore) ListSuppressions(_ context.Context, offset, limit int) ([]*emaildelivery.Suppression, int64, error) {
+	s := d.store
+
+	s.mu.RLock()
+
+	suppressions := make([]*emaildelivery.Suppression, 0, len(s.suppressions))
+	for _, suppression := range s.suppressions {
+		clone := *suppression
+		suppressions = append(suppressions, &clone)
+	}
+
+	s.mu.RUnlock()
+
+	slices.SortFunc(suppressions, func(a, b *emaildelivery.Suppression) int { return b.UpdatedAt.Compare(a.UpdatedAt) })
+
+	return page(suppressions, offset, limit), int64(len(suppressions)), nil
+}
+
+// CreateEvents stores delivery events
+func (d *deliveryStore) CreateEvents(_ context.Context, events []*emaildelivery.Event) error {
+	s := d.store
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, event := range events {
+		if event.ID == "" {
+			event.ID = uuid.New().String()
+		}
+
+		if event.CreatedAt.IsZero() {
+			event.CreatedAt = time.Now()
+		}
+
+		clone := *event
+		s.deliveryEvents = append(s.deliveryEvents, &clone)
+	}
+
+	return nil
+}
+
+// CountEvents counts a form's events by type
+func (d *deliveryStore) CountEvents(_ context.Context, formID string) (map[emaildelivery.EventType]int64, error) {
+	s := d.store
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	counts := make(map[emaildelivery.EventType]int64)
+
+	for _, event := range s.deliveryEvents {
+		if event.FormID == formID {
+			counts[event.Type]++
+		}
+	}
+
+	return counts, nil
+}
@@ -0,0 +1,55 @@
+package repository
+
+import (
+	"context"
+	"sync"
+
+	"github.com/goformx/goforms/internal/domain/encryption"
+)
+
+// EncryptionStore implements encryption.Repository in memory. Submissions in
+// memory are never at rest, so it only keeps the accounts' settings and has no
+// stored payloads to rotate. Like DeadLetterStore its settings are not written
+// to the snapshot.
+type EncryptionStore struct {
+	mu       sync.RWMutex
+	settings map[string]encryption.Setting
+}
+
+// NewEncryptionStore creates an empty in-memory encryption store
+func NewEncryptionStore() *EncryptionStore {
+	return &EncryptionStore{settings: make(map[string]encryption.Setting)}
+}
+
+// GetSetting returns an account's setting
+func (e *EncryptionStore) GetSetting(_ context.Context, userID string) (*encryption.Setting, error) {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+
+	setting, ok := e.settings[userID]
+	if !ok {
+		return nil, encryption.ErrSettingNotFound
+	}
+
+	return &setting, nil
+}
+
+// SaveSetting creates or replaces an account's setting
+func (e *EncryptionStore) SaveSetting(_ context.Context, setting *encryption.Setting) error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	e.settings[setting.UserID] = *setting
+
+	return nil
+}
+
+// StoredPayloads returns no payloads; nothing is stored at rest in memory
+func (e *EncryptionStore) StoredPayloads(_ context.Context, _ string, _ int) ([]*encryption.Payload, error) {
+	return nil, nil
+}
+
+// RewritePayload does nothing; nothing is stored at rest in memory
+func (e *EncryptionStore) RewritePayload(_ context.Context, _ *encryption.Payload) error {
+	return nil
+}
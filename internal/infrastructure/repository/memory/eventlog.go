@@ -0,0 +1,100 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/goformx/goforms/internal/domain/eventlog"
+)
+
+// EventLogStore implements eventlog.Repository in memory. It stands apart from
+// Store because the event bus it records for is built before the other stores,
+// and its events are not written to the snapshot.
+type EventLogStore struct {
+	mu      sync.RWMutex
+	records []*eventlog.Record
+}
+
+// NewEventLogStore creates an empty in-memory event log
+func NewEventLogStore() *EventLogStore {
+	return &EventLogStore{}
+}
+
+// Create stores an event
+func (e *EventLogStore) Create(_ context.Context, record *eventlog.Record) error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if record.ID == "" {
+		record.ID = uuid.New().String()
+	}
+
+	if record.CreatedAt.IsZero() {
+		record.CreatedAt = time.Now()
+	}
+
+	clone := *record
+	e.records = append(e.records, &clone)
+
+	return nil
+}
+
+// Get returns a stored event
+func (e *EventLogStore) Get(_ context.Context, id string) (*eventlog.Record, error) {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+
+	for _, record := range e.records {
+		if record.ID == id {
+			clone := *record
+
+			return &clone, nil
+		}
+	}
+
+	return nil, fmt.Errorf("get stored event %s: %w", id, eventlog.ErrRecordNotFound)
+}
+
+// List lists matching events, oldest first, with the total count
+func (e *EventLogStore) List(_ context.Context, filter eventlog.Filter, offset, limit int) ([]*eventlog.Record, int64, error) {
+	e.mu.RLock()
+
+	var records []*eventlog.Record
+
+	for _, record := range e.records {
+		if filter.Matches(record) {
+			clone := *record
+			records = append(records, &clone)
+		}
+	}
+
+	e.mu.RUnlock()
+
+	slices.SortStableFunc(records, func(x, y *eventlog.Record) int { return x.OccurredAt.Compare(y.OccurredAt) })
+
+	return page(records, offset, limit), int64(len(records)), nil
+}
+
+// DeleteBefore deletes the events published before the given time
+func (e *EventLogStore) DeleteBefore(_ context.Context, before time.Time) (int64, error) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	kept := e.records[:0]
+	for _, record := range e.records {
+		if !record.OccurredAt.Before(before) {
+			kept = append(kept, record)
+		}
+	}
+
+	deleted := int64(len(e.records) - len(kept))
+	clear(e.records[len(kept):])
+	e.records = kept
+
+	return deleted, nil
+}
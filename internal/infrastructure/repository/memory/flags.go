@@ -0,0 +1,78 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/goformx/goforms/internal/domain/flags"
+)
+
+// flagStore implements flags.Repository in memory
+type flagStore struct {
+	store *Store
+}
+
+// ListFlags lists the stored flags, ordered by key
+func (f *flagStore) ListFlags(_ context.Context) ([]*flags.Flag, error) {
+	s := f.store
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	list := make([]*flags.Flag, 0, len(s.flags))
+	for _, flag := range s.flags {
+		list = append(list, cloneFlag(flag))
+	}
+
+	slices.SortFunc(list, func(a, b *flags.Flag) int {
+		return strings.Compare(a.Key, b.Key)
+	})
+
+	return list, nil
+}
+
+// SaveFlag creates or replaces a flag, keeping the creation time of the one it replaces
+func (f *flagStore) SaveFlag(_ context.Context, flag *flags.Flag) error {
+	s := f.store
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := time.Now()
+
+	flag.CreatedAt = now
+	if existing, ok := s.flags[flag.Key]; ok {
+		flag.CreatedAt = existing.CreatedAt
+	}
+
+	flag.UpdatedAt = now
+	s.flags[flag.Key] = cloneFlag(flag)
+
+	return nil
+}
+
+// DeleteFlag removes a flag
+func (f *flagStore) DeleteFlag(_ context.Context, key string) error {
+	s := f.store
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.flags[key]; !ok {
+		return fmt.Errorf("delete feature flag: %w", flags.ErrFlagNotFound)
+	}
+
+	delete(s.flags, key)
+
+	return nil
+}
+
+func cloneFlag(flag *flags.Flag) *flags.Flag {
+	clone := *flag
+	clone.Accounts = slices.Clone(flag.Accounts)
+
+	return &clone
+}
@@ -0,0 +1,309 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/goformx/goforms/internal/domain/form"
+	"github.com/goformx/goforms/internal/domain/form/model"
+	"github.com/goformx/goforms/internal/infrastructure/repository/common"
+)
+
+// formStore implements form.Repository, form.SubmissionBatchRepository,
+// form.SubmissionRangeRepository, form.UniqueSubmissionRepository and
+// form.TestSubmissionRepository in memory
+type formStore struct {
+	store *Store
+}
+
+// CreateForm creates a new form
+func (r *formStore) CreateForm(_ context.Context, formModel *model.Form) error {
+	s := r.store
+
+	if err := common.PrepareForm(formModel); err != nil {
+		return fmt.Errorf("create form: %w", common.NewDatabaseError("create", "form", formModel.ID, err))
+	}
+
+	stamp(&formModel.ID, &formModel.CreatedAt, &formModel.UpdatedAt)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.forms[formModel.ID] = formModel.Clone()
+
+	return nil
+}
+
+// GetFormByID retrieves a form by ID
+func (r *formStore) GetFormByID(_ context.Context, id string) (*model.Form, error) {
+	normalizedID, err := common.NormalizeFormID("get", id)
+	if err != nil {
+		return nil, fmt.Errorf("get form by ID: %w", err)
+	}
+
+	s := r.store
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	formModel, ok := s.forms[normalizedID]
+	if !ok {
+		return nil, fmt.Errorf("get form by ID: %w", common.NewNotFoundError("get", "form", normalizedID))
+	}
+
+	return formModel.Clone(), nil
+}
+
+// ListForms retrieves a user's forms, newest first
+func (r *formStore) ListForms(_ context.Context, userID string) ([]*model.Form, error) {
+	forms := r.store.filterForms(func(f *model.Form) bool { return f.UserID == userID })
+
+	slices.SortStableFunc(forms, func(a, b *model.Form) int { return b.CreatedAt.Compare(a.CreatedAt) })
+
+	return forms, nil
+}
+
+// UpdateForm updates the non-zero fields of a form. When the form carries a version,
+// the update only applies if the stored version still matches, and the version is
+// incremented; otherwise model.ErrFormVersionConflict is returned.
+func (r *formStore) UpdateForm(_ context.Context, formModel *model.Form) error {
+	s := r.store
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	stored, ok := s.forms[formModel.ID]
+	if !ok {
+		return fmt.Errorf("update form: %w", common.NewNotFoundError("update", "form", formModel.ID))
+	}
+
+	if formModel.Version > 0 {
+		if stored.Version != formModel.Version {
+			return fmt.Errorf("update form: %w", model.ErrFormVersionConflict)
+		}
+
+		formModel.Version++
+	}
+
+	if err := formModel.BeforeSave(nil); err != nil {
+		return fmt.Errorf("update form: %w", common.NewDatabaseError("update", "form", formModel.ID, err))
+	}
+
+	formModel.UpdatedAt = time.Now()
+
+	updated := stored.Clone()
+	mergeNonZero(updated, formModel.Clone())
+	s.forms[formModel.ID] = updated
+
+	return nil
+}
+
+// DeleteForm deletes a form. Its submissions are kept, as with the soft delete of the database store.
+func (r *formStore) DeleteForm(_ context.Context, id string) error {
+	normalizedID, err := common.NormalizeFormID("delete", id)
+	if err != nil {
+		return fmt.Errorf("delete form: %w", err)
+	}
+
+	s := r.store
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.forms[normalizedID]; !ok {
+		return fmt.Errorf("delete form: %w", common.NewNotFoundError("delete", "form", normalizedID))
+	}
+
+	delete(s.forms, normalizedID)
+
+	return nil
+}
+
+// GetFormsByStatus returns forms with the given status
+func (r *formStore) GetFormsByStatus(_ context.Context, status string) ([]*model.Form, error) {
+	return r.store.filterForms(func(f *model.Form) bool { return f.Status == status }), nil
+}
+
+// CreateSubmission creates a new form submission
+func (r *formStore) CreateSubmission(_ context.Context, submission *model.FormSubmission) error {
+	r.store.insertSubmissions(submission)
+
+	return nil
+}
+
+// CreateSubmissionWithUniqueValues stores a submission unless a stored submission
+// to the same form has one of its unique values. Stored values are read from
+// the submissions' data under the form's current schema.
+func (r *formStore) CreateSubmissionWithUniqueValues(
+	_ context.Context,
+	submission *model.FormSubmission,
+	values []form.UniqueValue,
+) error {
+	s := r.store
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var schema model.JSON
+	if stored, ok := s.forms[submission.FormID]; ok {
+		schema = stored.Schema
+	}
+
+	taken := make(map[form.UniqueValue]bool)
+
+	for _, stored := range s.submissions {
+		if stored.FormID != submission.FormID {
+			continue
+		}
+
+		for _, value := range form.UniqueSubmissionValues(schema, stored.Data) {
+			taken[value] = true
+		}
+	}
+
+	var duplicates []string
+
+	for _, value := range values {
+		if taken[value] {
+			duplicates = append(duplicates, value.FieldKey)
+		}
+	}
+
+	if len(duplicates) > 0 {
+		return &form.DuplicateValuesError{Fields: duplicates}
+	}
+
+	stamp(&submission.ID, &submission.CreatedAt, &submission.UpdatedAt)
+	s.submissions[submission.ID] = cloneSubmission(submission)
+
+	return nil
+}
+
+// CreateSubmissions stores submissions together
+func (r *formStore) CreateSubmissions(_ context.Context, submissions []*model.FormSubmission) error {
+	r.store.insertSubmissions(submissions...)
+
+	return nil
+}
+
+// GetSubmissionByID retrieves a form submission by ID
+func (r *formStore) GetSubmissionByID(_ context.Context, submissionID string) (*model.FormSubmission, error) {
+	submission, ok := r.store.getSubmission(submissionID)
+	if !ok {
+		return nil, fmt.Errorf("get submission by ID: %w",
+			common.NewNotFoundError("get", "form_submission", submissionID))
+	}
+
+	return submission, nil
+}
+
+// ListSubmissions retrieves all submissions for a form
+func (r *formStore) ListSubmissions(_ context.Context, formID string) ([]*model.FormSubmission, error) {
+	return r.store.filterSubmissions(byForm(formID)), nil
+}
+
+// ListSubmissionsBetween retrieves a form's submissions received after since and
+// at or before until, oldest first
+func (r *formStore) ListSubmissionsBetween(
+	_ context.Context,
+	formID string,
+	since, until time.Time,
+) ([]*model.FormSubmission, error) {
+	submissions := r.store.filterSubmissions(func(submission *model.FormSubmission) bool {
+		return submission.FormID == formID && submission.SubmittedAt.After(since) && !submission.SubmittedAt.After(until)
+	})
+
+	slices.SortFunc(submissions, func(a, b *model.FormSubmission) int { return a.SubmittedAt.Compare(b.SubmittedAt) })
+
+	return submissions, nil
+}
+
+// UpdateSubmission updates the non-zero fields of a form submission
+func (r *formStore) UpdateSubmission(_ context.Context, submission *model.FormSubmission) error {
+	if !r.store.updateSubmission(submission, true) {
+		return fmt.Errorf("update submission: %w", common.NewNotFoundError("update", "form_submission", submission.ID))
+	}
+
+	return nil
+}
+
+// DeleteSubmission deletes a form submission
+func (r *formStore) DeleteSubmission(_ context.Context, submissionID string) error {
+	if !r.store.deleteSubmission(submissionID) {
+		return fmt.Errorf("delete submission: %w", common.NewNotFoundError("delete", "form_submission", submissionID))
+	}
+
+	return nil
+}
+
+// DeleteTestSubmissionsBefore deletes the test submissions received before the given time
+func (r *formStore) DeleteTestSubmissionsBefore(_ context.Context, before time.Time) (int64, error) {
+	s := r.store
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var deleted int64
+
+	for id, submission := range s.submissions {
+		if submission.Test && submission.SubmittedAt.Before(before) {
+			delete(s.submissions, id)
+			deleted++
+		}
+	}
+
+	return deleted, nil
+}
+
+// GetByFormID retrieves all submissions for a form
+func (r *formStore) GetByFormID(ctx context.Context, formID string) ([]*model.FormSubmission, error) {
+	return r.ListSubmissions(ctx, formID)
+}
+
+// GetByFormIDPaginated retrieves paginated submissions for a form
+func (r *formStore) GetByFormIDPaginated(
+	_ context.Context,
+	formID string,
+	params common.PaginationParams,
+) (*common.PaginationResult, error) {
+	submissions := r.store.filterSubmissions(byForm(formID))
+	result := common.NewPaginationResult(
+		page(submissions, params.GetOffset(), params.GetLimit()), len(submissions), params.Page, params.PageSize)
+
+	return &result, nil
+}
+
+// GetByFormAndUser retrieves a submission by form ID and user ID. Submissions are
+// not linked to users, so none is ever found, as with the database store.
+func (r *formStore) GetByFormAndUser(_ context.Context, formID, _ string) (*model.FormSubmission, error) {
+	return nil, fmt.Errorf("failed to get submission: %w", common.NewNotFoundError("get", "form_submission", formID))
+}
+
+// GetSubmissionsByStatus retrieves submissions by status
+func (r *formStore) GetSubmissionsByStatus(
+	_ context.Context,
+	status model.SubmissionStatus,
+) ([]*model.FormSubmission, error) {
+	return r.store.filterSubmissions(func(submission *model.FormSubmission) bool {
+		return submission.Status == status
+	}), nil
+}
+
+// filterForms returns copies of the forms matching keep
+func (s *Store) filterForms(keep func(*model.Form) bool) []*model.Form {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	forms := make([]*model.Form, 0)
+	for _, formModel := range s.forms {
+		if keep(formModel) {
+			forms = append(forms, formModel.Clone())
+		}
+	}
+
+	slices.SortFunc(forms, func(a, b *model.Form) int { return a.CreatedAt.Compare(b.CreatedAt) })
+
+	return forms
+}
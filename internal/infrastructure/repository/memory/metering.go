@@ -0,0 +1,94 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/goformx/goforms/internal/domain/metering"
+)
+
+// meteringStore implements metering.Repository in memory
+type meteringStore struct {
+	store *Store
+}
+
+// usageCountKey keys a daily count by account, metric and day
+func usageCountKey(account, metric string, day time.Time) string {
+	return account + "|" + metric + "|" + day.UTC().Format(time.DateOnly)
+}
+
+// usageAlertKey keys an alert by account, metric, period and threshold
+func usageAlertKey(alert *metering.Alert) string {
+	return fmt.Sprintf("%s|%s|%s|%d", alert.Account, alert.Metric, alert.PeriodStart.UTC().Format(time.DateOnly), alert.Threshold)
+}
+
+// AddCounts adds counts to the stored daily totals
+func (m *meteringStore) AddCounts(_ context.Context, counts []*metering.DailyCount) error {
+	s := m.store
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := time.Now()
+
+	for _, count := range counts {
+		key := usageCountKey(count.Account, count.Metric, count.Day)
+
+		stored, ok := s.usageCounts[key]
+		if !ok {
+			clone := *count
+			stored = &clone
+			stored.Count = 0
+			s.usageCounts[key] = stored
+		}
+
+		stored.Count += count.Count
+		stored.UpdatedAt = now
+	}
+
+	return nil
+}
+
+// ListCounts returns an account's daily totals for the days from..to, ordered by day
+func (m *meteringStore) ListCounts(_ context.Context, account string, from, to time.Time) ([]*metering.DailyCount, error) {
+	s := m.store
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var list []*metering.DailyCount
+
+	for _, count := range s.usageCounts {
+		if count.Account == account && !count.Day.Before(from) && !count.Day.After(to) {
+			clone := *count
+			list = append(list, &clone)
+		}
+	}
+
+	slices.SortFunc(list, func(a, b *metering.DailyCount) int {
+		return a.Day.Compare(b.Day)
+	})
+
+	return list, nil
+}
+
+// RecordAlert stores an alert; it reports false when the alert was already recorded
+func (m *meteringStore) RecordAlert(_ context.Context, alert *metering.Alert) (bool, error) {
+	s := m.store
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	key := usageAlertKey(alert)
+	if _, ok := s.usageAlerts[key]; ok {
+		return false, nil
+	}
+
+	alert.CreatedAt = time.Now()
+	clone := *alert
+	s.usageAlerts[key] = &clone
+
+	return true, nil
+}
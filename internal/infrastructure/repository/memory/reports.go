@@ -0,0 +1,278 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/goformx/goforms/internal/domain/form/model"
+)
+
+// reportStore implements form.ReportRepository in memory
+type reportStore struct {
+	store *Store
+}
+
+// CreateReport creates a new report schedule
+func (r *reportStore) CreateReport(_ context.Context, report *model.ReportSchedule) error {
+	s := r.store
+
+	stamp(&report.ID, &report.CreatedAt, &report.UpdatedAt)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.reports[report.ID] = cloneReport(report)
+
+	return nil
+}
+
+// GetReportByID retrieves a report schedule by ID
+func (r *reportStore) GetReportByID(_ context.Context, id string) (*model.ReportSchedule, error) {
+	s := r.store
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	report, ok := s.reports[id]
+	if !ok {
+		return nil, fmt.Errorf("get report: %w", model.ErrReportNotFound)
+	}
+
+	return cloneReport(report), nil
+}
+
+// ListReportsByForm lists the report schedules for a form, oldest first
+func (r *reportStore) ListReportsByForm(_ context.Context, formID string) ([]*model.ReportSchedule, error) {
+	reports := r.filter(func(report *model.ReportSchedule) bool { return report.FormID == formID })
+
+	slices.SortFunc(reports, func(a, b *model.ReportSchedule) int { return a.CreatedAt.Compare(b.CreatedAt) })
+
+	return reports, nil
+}
+
+// UpdateReport saves a report schedule
+func (r *reportStore) UpdateReport(_ context.Context, report *model.ReportSchedule) error {
+	s := r.store
+
+	stamp(&report.ID, &report.CreatedAt, &report.UpdatedAt)
+	report.UpdatedAt = time.Now()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.reports[report.ID] = cloneReport(report)
+
+	return nil
+}
+
+// DeleteReport deletes a report schedule by ID
+func (r *reportStore) DeleteReport(_ context.Context, id string) error {
+	s := r.store
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.reports[id]; !ok {
+		return fmt.Errorf("delete report: %w", model.ErrReportNotFound)
+	}
+
+	delete(s.reports, id)
+
+	return nil
+}
+
+// ListDueReports returns active report schedules whose next run is due, soonest first
+func (r *reportStore) ListDueReports(_ context.Context, now time.Time) ([]*model.ReportSchedule, error) {
+	reports := r.filter(func(report *model.ReportSchedule) bool {
+		return report.Active && report.NextRunAt != nil && !report.NextRunAt.After(now)
+	})
+
+	slices.SortFunc(reports, func(a, b *model.ReportSchedule) int { return a.NextRunAt.Compare(*b.NextRunAt) })
+
+	return reports, nil
+}
+
+// ClaimReport moves a due report's next run to leaseUntil, reporting whether it was still due
+func (r *reportStore) ClaimReport(_ context.Context, id string, now, leaseUntil time.Time) (bool, error) {
+	s := r.store
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	report, ok := s.reports[id]
+	if !ok || !report.Active || report.NextRunAt == nil || report.NextRunAt.After(now) {
+		return false, nil
+	}
+
+	report.NextRunAt = &leaseUntil
+
+	return true, nil
+}
+
+// filter returns copies of the report schedules matching keep
+func (r *reportStore) filter(keep func(*model.ReportSchedule) bool) []*model.ReportSchedule {
+	s := r.store
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	reports := make([]*model.ReportSchedule, 0)
+	for _, report := range s.reports {
+		if keep(report) {
+			reports = append(reports, cloneReport(report))
+		}
+	}
+
+	return reports
+}
+
+// viewStore implements form.SubmissionViewRepository in memory
+type viewStore struct {
+	store *Store
+}
+
+// CreateView creates a new submission view
+func (r *viewStore) CreateView(_ context.Context, view *model.SubmissionView) error {
+	s := r.store
+
+	stamp(&view.ID, &view.CreatedAt, &view.UpdatedAt)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.views[view.ID] = cloneView(view)
+
+	return nil
+}
+
+// GetViewByID retrieves a submission view by ID
+func (r *viewStore) GetViewByID(_ context.Context, id string) (*model.SubmissionView, error) {
+	s := r.store
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	view, ok := s.views[id]
+	if !ok {
+		return nil, fmt.Errorf("get view: %w", model.ErrViewNotFound)
+	}
+
+	return cloneView(view), nil
+}
+
+// ListViewsByForm lists the submission views for a form, oldest first
+func (r *viewStore) ListViewsByForm(_ context.Context, formID string) ([]*model.SubmissionView, error) {
+	s := r.store
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	views := make([]*model.SubmissionView, 0)
+	for _, view := range s.views {
+		if view.FormID == formID {
+			views = append(views, cloneView(view))
+		}
+	}
+
+	slices.SortFunc(views, func(a, b *model.SubmissionView) int { return a.CreatedAt.Compare(b.CreatedAt) })
+
+	return views, nil
+}
+
+// UpdateView saves a submission view
+func (r *viewStore) UpdateView(_ context.Context, view *model.SubmissionView) error {
+	s := r.store
+
+	stamp(&view.ID, &view.CreatedAt, &view.UpdatedAt)
+	view.UpdatedAt = time.Now()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.views[view.ID] = cloneView(view)
+
+	return nil
+}
+
+// DeleteView deletes a submission view by ID
+func (r *viewStore) DeleteView(_ context.Context, id string) error {
+	s := r.store
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.views[id]; !ok {
+		return fmt.Errorf("delete view: %w", model.ErrViewNotFound)
+	}
+
+	delete(s.views, id)
+
+	return nil
+}
+
+// usageStore implements form.UsageRepository in memory
+type usageStore struct {
+	store *Store
+}
+
+// CountFormsByUser counts the forms owned by a user
+func (r *usageStore) CountFormsByUser(_ context.Context, userID string) (int64, error) {
+	s := r.store
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var count int64
+
+	for _, formModel := range s.forms {
+		if formModel.UserID == userID {
+			count++
+		}
+	}
+
+	return count, nil
+}
+
+// CountSubmissionsByUserSince counts submissions to a user's forms received at or
+// after since, test submissions aside
+func (r *usageStore) CountSubmissionsByUserSince(_ context.Context, userID string, since time.Time) (int64, error) {
+	var count int64
+
+	r.eachUserSubmission(userID, func(submission *model.FormSubmission) {
+		if !submission.Test && !submission.SubmittedAt.Before(since) {
+			count++
+		}
+	})
+
+	return count, nil
+}
+
+// SubmissionStorageByUser sums the encoded size of submission data for a user's forms
+func (r *usageStore) SubmissionStorageByUser(_ context.Context, userID string) (int64, error) {
+	var total int64
+
+	r.eachUserSubmission(userID, func(submission *model.FormSubmission) {
+		if data, err := json.Marshal(submission.Data); err == nil {
+			total += int64(len(data))
+		}
+	})
+
+	return total, nil
+}
+
+// eachUserSubmission calls fn for every submission to a form the user owns
+func (r *usageStore) eachUserSubmission(userID string, fn func(*model.FormSubmission)) {
+	s := r.store
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	for _, submission := range s.submissions {
+		if formModel, ok := s.forms[submission.FormID]; ok && formModel.UserID == userID {
+			fn(submission)
+		}
+	}
+}
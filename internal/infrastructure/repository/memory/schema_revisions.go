@@ -0,0 +1,129 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/goformx/goforms/internal/domain/form/model"
+	"github.com/goformx/goforms/internal/infrastructure/repository/common"
+)
+
+// CreateSchemaRevision stores a schema revision
+func (r *formStore) CreateSchemaRevision(_ context.Context, revision *model.SchemaRevision) error {
+	s := r.store
+
+	if revision.ID == "" {
+		revision.ID = uuid.New().String()
+	}
+
+	if revision.CreatedAt.IsZero() {
+		revision.CreatedAt = time.Now()
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.schemaRevisions = append(s.schemaRevisions, cloneSchemaRevision(revision))
+
+	return nil
+}
+
+// LatestSchemaRevision returns the revision on top of a form's stack
+func (r *formStore) LatestSchemaRevision(
+	_ context.Context,
+	formID string,
+	stack model.SchemaStack,
+) (*model.SchemaRevision, error) {
+	s := r.store
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var latest *model.SchemaRevision
+
+	for _, revision := range s.schemaRevisions {
+		if revision.FormID == formID && revision.Stack == stack && (latest == nil || revision.Seq > latest.Seq) {
+			latest = revision
+		}
+	}
+
+	if latest == nil {
+		return nil, fmt.Errorf("get schema revision: %w", common.NewNotFoundError("get", "schema_revision", formID))
+	}
+
+	return cloneSchemaRevision(latest), nil
+}
+
+// DeleteSchemaRevision deletes a revision, reporting whether this call removed it
+func (r *formStore) DeleteSchemaRevision(_ context.Context, id string) (bool, error) {
+	s := r.store
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	before := len(s.schemaRevisions)
+	s.schemaRevisions = slices.DeleteFunc(s.schemaRevisions, func(revision *model.SchemaRevision) bool {
+		return revision.ID == id
+	})
+
+	return len(s.schemaRevisions) < before, nil
+}
+
+// TrimSchemaRevisions deletes all but the keep newest revisions of a form's stack
+func (r *formStore) TrimSchemaRevisions(_ context.Context, formID string, stack model.SchemaStack, keep int) error {
+	s := r.store
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var seqs []int64
+
+	for _, revision := range s.schemaRevisions {
+		if revision.FormID == formID && revision.Stack == stack {
+			seqs = append(seqs, revision.Seq)
+		}
+	}
+
+	if len(seqs) <= keep {
+		return nil
+	}
+
+	// The newest revision past the limit, and all older ones, are dropped
+	slices.Sort(seqs)
+	cutoff := seqs[len(seqs)-keep-1]
+
+	s.schemaRevisions = slices.DeleteFunc(s.schemaRevisions, func(revision *model.SchemaRevision) bool {
+		return revision.FormID == formID && revision.Stack == stack && revision.Seq <= cutoff
+	})
+
+	return nil
+}
+
+// CountSchemaRevisions counts the revisions of a form's stack
+func (r *formStore) CountSchemaRevisions(_ context.Context, formID string, stack model.SchemaStack) (int, error) {
+	s := r.store
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	count := 0
+
+	for _, revision := range s.schemaRevisions {
+		if revision.FormID == formID && revision.Stack == stack {
+			count++
+		}
+	}
+
+	return count, nil
+}
+
+func cloneSchemaRevision(revision *model.SchemaRevision) *model.SchemaRevision {
+	clone := *revision
+	clone.Schema = revision.Schema.Clone()
+
+	return &clone
+}
@@ -0,0 +1,69 @@
+package repository
+
+import (
+	"context"
+	"slices"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/goformx/goforms/internal/domain/securityevent"
+)
+
+// securityEventStore implements securityevent.Repository in memory
+type securityEventStore struct {
+	store *Store
+}
+
+// CreateEvent stores a security event
+func (e *securityEventStore) CreateEvent(_ context.Context, event *securityevent.Event) error {
+	s := e.store
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if event.ID == "" {
+		event.ID = uuid.New().String()
+	}
+
+	if event.CreatedAt.IsZero() {
+		event.CreatedAt = time.Now()
+	}
+
+	s.securityEvents = append(s.securityEvents, cloneSecurityEvent(event))
+
+	return nil
+}
+
+// ListEvents lists matching events, newest first, with the total count
+func (e *securityEventStore) ListEvents(
+	_ context.Context, filter securityevent.Filter, offset, limit int,
+) ([]*securityevent.Event, int64, error) {
+	s := e.store
+
+	s.mu.RLock()
+
+	var events []*securityevent.Event
+
+	for _, event := range s.securityEvents {
+		if filter.Matches(event) {
+			events = append(events, cloneSecurityEvent(event))
+		}
+	}
+
+	s.mu.RUnlock()
+
+	slices.SortStableFunc(events, func(x, y *securityevent.Event) int { return y.CreatedAt.Compare(x.CreatedAt) })
+
+	return page(events, offset, limit), int64(len(events)), nil
+}
+
+func cloneSecurityEvent(event *securityevent.Event) *securityevent.Event {
+	clone := *event
+	if event.BlockedUntil != nil {
+		until := *event.BlockedUntil
+		clone.BlockedUntil = &until
+	}
+
+	return &clone
+}
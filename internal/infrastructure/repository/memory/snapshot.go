@@ -0,0 +1,379 @@
+package repository
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"time"
+
+	"github.com/goformx/goforms/internal/domain/audit"
+	"github.com/goformx/goforms/internal/domain/billing"
+	"github.com/goformx/goforms/internal/domain/dataset"
+	"github.com/goformx/goforms/internal/domain/emaildelivery"
+	"github.com/goformx/goforms/internal/domain/emailtemplate"
+	"github.com/goformx/goforms/internal/domain/entities"
+	"github.com/goformx/goforms/internal/domain/flags"
+	"github.com/goformx/goforms/internal/domain/form/model"
+	"github.com/goformx/goforms/internal/domain/metering"
+	"github.com/goformx/goforms/internal/domain/securityevent"
+)
+
+// snapshot is the file encoding of a store. Fields hidden from API responses are
+// carried in fields of their own so they survive a restart.
+type snapshot struct {
+	SavedAt        time.Time                    `json:"saved_at"`
+	Users          []snapshotUser               `json:"users"`
+	Forms          []snapshotForm               `json:"forms"`
+	Submissions    []snapshotSubmission         `json:"submissions"`
+	Reports        []snapshotReport             `json:"reports"`
+	Views          []snapshotView               `json:"views"`
+	Activities     []*model.SubmissionActivity  `json:"submission_activities"`
+	SchemaHistory  []*model.SchemaRevision      `json:"form_schema_revisions"`
+	Templates      []*emailtemplate.Template    `json:"email_templates"`
+	Datasets       []snapshotDataset            `json:"datasets"`
+	Suppressions   []*emaildelivery.Suppression `json:"email_suppressions"`
+	DeliveryEvents []*emaildelivery.Event       `json:"email_delivery_events"`
+	AuditEntries   []*audit.Entry               `json:"audit_logs"`
+	SecurityEvents []*securityevent.Event       `json:"security_events"`
+	Flags          []*flags.Flag                `json:"feature_flags"`
+	Subscriptions  []snapshotSubscription       `json:"billing_subscriptions"`
+	UsageCounts    []*metering.DailyCount       `json:"api_usage_daily"`
+	UsageAlerts    []*metering.Alert            `json:"api_usage_alerts"`
+}
+
+// snapshotDataset keeps the dataset owner hidden from API responses
+type snapshotDataset struct {
+	*dataset.Dataset
+
+	OwnerID string `json:"owner_id"`
+}
+
+// snapshotSubscription keeps the subscription fields hidden from API responses
+type snapshotSubscription struct {
+	*billing.Subscription
+
+	UserID         string    `json:"user_id"`
+	CustomerID     string    `json:"customer_id"`
+	SubscriptionID string    `json:"subscription_id"`
+	EventAt        time.Time `json:"event_at"`
+}
+
+type snapshotUser struct {
+	*entities.User
+
+	HashedPassword string `json:"hashed_password"`
+}
+
+type snapshotForm struct {
+	*model.Form
+
+	AccessSettings model.JSON `json:"access_settings"`
+}
+
+type snapshotSubmission struct {
+	*model.FormSubmission
+
+	Tags model.JSON `json:"tags"`
+}
+
+type snapshotReport struct {
+	*model.ReportSchedule
+
+	Recipients model.JSON `json:"recipients"`
+}
+
+type snapshotView struct {
+	*model.SubmissionView
+
+	Tags model.JSON `json:"tags"`
+}
+
+// Save writes the store to path as JSON. The file is written next to path and
+// renamed into place, so an interrupted save never leaves a truncated snapshot.
+func (s *Store) Save(path string) error {
+	snap := s.snapshot()
+
+	payload, err := json.MarshalIndent(snap, "", "  ")
+	if err != nil {
+		return fmt.Errorf("encode snapshot: %w", err)
+	}
+
+	if mkdirErr := os.MkdirAll(filepath.Dir(path), 0o750); mkdirErr != nil {
+		return fmt.Errorf("create snapshot directory: %w", mkdirErr)
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".*.tmp")
+	if err != nil {
+		return fmt.Errorf("create snapshot file: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, writeErr := tmp.Write(payload); writeErr != nil {
+		tmp.Close()
+
+		return fmt.Errorf("write snapshot: %w", writeErr)
+	}
+
+	if closeErr := tmp.Close(); closeErr != nil {
+		return fmt.Errorf("write snapshot: %w", closeErr)
+	}
+
+	if renameErr := os.Rename(tmp.Name(), path); renameErr != nil {
+		return fmt.Errorf("replace snapshot: %w", renameErr)
+	}
+
+	s.logger.Info("in-memory store saved", "path", path,
+		"users", len(snap.Users), "forms", len(snap.Forms), "submissions", len(snap.Submissions))
+
+	return nil
+}
+
+// Load replaces the contents of the store with the snapshot at path. A missing
+// file leaves the store empty, so the first run starts from scratch.
+func (s *Store) Load(path string) error {
+	payload, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		s.logger.Info("no in-memory store snapshot found, starting empty", "path", path)
+
+		return nil
+	}
+
+	if err != nil {
+		return fmt.Errorf("read snapshot: %w", err)
+	}
+
+	var snap snapshot
+	if decodeErr := json.Unmarshal(payload, &snap); decodeErr != nil {
+		return fmt.Errorf("decode snapshot: %w", decodeErr)
+	}
+
+	s.restore(&snap)
+
+	s.logger.Info("in-memory store loaded", "path", path,
+		"users", len(snap.Users), "forms", len(snap.Forms), "submissions", len(snap.Submissions))
+
+	return nil
+}
+
+// snapshot copies the store into its file encoding
+func (s *Store) snapshot() *snapshot {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	snap := &snapshot{
+		SavedAt:        time.Now().UTC(),
+		Users:          make([]snapshotUser, 0, len(s.users)),
+		Forms:          make([]snapshotForm, 0, len(s.forms)),
+		Submissions:    make([]snapshotSubmission, 0, len(s.submissions)),
+		Reports:        make([]snapshotReport, 0, len(s.reports)),
+		Views:          make([]snapshotView, 0, len(s.views)),
+		Activities:     slices.Clone(s.activities),
+		SchemaHistory:  slices.Clone(s.schemaRevisions),
+		Templates:      make([]*emailtemplate.Template, 0, len(s.templates)),
+		Datasets:       make([]snapshotDataset, 0, len(s.datasets)),
+		Suppressions:   make([]*emaildelivery.Suppression, 0, len(s.suppressions)),
+		DeliveryEvents: slices.Clone(s.deliveryEvents),
+		AuditEntries:   slices.Clone(s.auditEntries),
+		SecurityEvents: slices.Clone(s.securityEvents),
+		Flags:          make([]*flags.Flag, 0, len(s.flags)),
+		Subscriptions:  make([]snapshotSubscription, 0, len(s.subscriptions)),
+		UsageCounts:    make([]*metering.DailyCount, 0, len(s.usageCounts)),
+		UsageAlerts:    make([]*metering.Alert, 0, len(s.usageAlerts)),
+	}
+
+	for _, u := range s.users {
+		snap.Users = append(snap.Users, snapshotUser{User: u, HashedPassword: u.HashedPassword})
+	}
+
+	for _, formModel := range s.forms {
+		snap.Forms = append(snap.Forms, snapshotForm{Form: formModel, AccessSettings: formModel.AccessSettings})
+	}
+
+	for _, submission := range s.submissions {
+		snap.Submissions = append(snap.Submissions,
+			snapshotSubmission{FormSubmission: submission, Tags: submission.Tags})
+	}
+
+	for _, report := range s.reports {
+		snap.Reports = append(snap.Reports, snapshotReport{ReportSchedule: report, Recipients: report.Recipients})
+	}
+
+	for _, view := range s.views {
+		snap.Views = append(snap.Views, snapshotView{SubmissionView: view, Tags: view.Tags})
+	}
+
+	for _, template := range s.templates {
+		snap.Templates = append(snap.Templates, template)
+	}
+
+	for _, stored := range s.datasets {
+		snap.Datasets = append(snap.Datasets, snapshotDataset{Dataset: stored, OwnerID: stored.OwnerID})
+	}
+
+	for _, suppression := range s.suppressions {
+		snap.Suppressions = append(snap.Suppressions, suppression)
+	}
+
+	for _, flag := range s.flags {
+		snap.Flags = append(snap.Flags, flag)
+	}
+
+	for _, sub := range s.subscriptions {
+		snap.Subscriptions = append(snap.Subscriptions, snapshotSubscription{
+			Subscription:   sub,
+			UserID:         sub.UserID,
+			CustomerID:     sub.CustomerID,
+			SubscriptionID: sub.SubscriptionID,
+			EventAt:        sub.EventAt,
+		})
+	}
+
+	for _, count := range s.usageCounts {
+		snap.UsageCounts = append(snap.UsageCounts, count)
+	}
+
+	for _, alert := range s.usageAlerts {
+		snap.UsageAlerts = append(snap.UsageAlerts, alert)
+	}
+
+	return snap
+}
+
+// restore replaces the store contents with a decoded snapshot
+func (s *Store) restore(snap *snapshot) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.users = make(map[string]*entities.User, len(snap.Users))
+	for _, entry := range snap.Users {
+		if entry.User != nil {
+			entry.User.HashedPassword = entry.HashedPassword
+			s.users[entry.User.ID] = entry.User
+		}
+	}
+
+	s.forms = make(map[string]*model.Form, len(snap.Forms))
+	for _, entry := range snap.Forms {
+		if entry.Form != nil {
+			entry.Form.AccessSettings = entry.AccessSettings
+			s.forms[entry.Form.ID] = entry.Form
+		}
+	}
+
+	s.submissions = make(map[string]*model.FormSubmission, len(snap.Submissions))
+	for _, entry := range snap.Submissions {
+		if entry.FormSubmission != nil {
+			entry.FormSubmission.Tags = entry.Tags
+			s.submissions[entry.FormSubmission.ID] = entry.FormSubmission
+		}
+	}
+
+	s.reports = make(map[string]*model.ReportSchedule, len(snap.Reports))
+	for _, entry := range snap.Reports {
+		if entry.ReportSchedule != nil {
+			entry.ReportSchedule.Recipients = entry.Recipients
+			s.reports[entry.ReportSchedule.ID] = entry.ReportSchedule
+		}
+	}
+
+	s.views = make(map[string]*model.SubmissionView, len(snap.Views))
+	for _, entry := range snap.Views {
+		if entry.SubmissionView != nil {
+			entry.SubmissionView.Tags = entry.Tags
+			s.views[entry.SubmissionView.ID] = entry.SubmissionView
+		}
+	}
+
+	s.activities = make([]*model.SubmissionActivity, 0, len(snap.Activities))
+	for _, activity := range snap.Activities {
+		if activity != nil {
+			s.activities = append(s.activities, activity)
+		}
+	}
+
+	s.schemaRevisions = make([]*model.SchemaRevision, 0, len(snap.SchemaHistory))
+	for _, revision := range snap.SchemaHistory {
+		if revision != nil {
+			s.schemaRevisions = append(s.schemaRevisions, revision)
+		}
+	}
+
+	s.templates = make(map[string]*emailtemplate.Template, len(snap.Templates))
+	for _, template := range snap.Templates {
+		if template != nil {
+			s.templates[template.ID] = template
+		}
+	}
+
+	s.datasets = make(map[string]*dataset.Dataset, len(snap.Datasets))
+	for _, entry := range snap.Datasets {
+		if entry.Dataset != nil {
+			entry.Dataset.OwnerID = entry.OwnerID
+			s.datasets[entry.Dataset.ID] = entry.Dataset
+		}
+	}
+
+	s.suppressions = make(map[string]*emaildelivery.Suppression, len(snap.Suppressions))
+	for _, suppression := range snap.Suppressions {
+		if suppression != nil {
+			s.suppressions[suppression.Address] = suppression
+		}
+	}
+
+	s.deliveryEvents = make([]*emaildelivery.Event, 0, len(snap.DeliveryEvents))
+	for _, event := range snap.DeliveryEvents {
+		if event != nil {
+			s.deliveryEvents = append(s.deliveryEvents, event)
+		}
+	}
+
+	s.auditEntries = make([]*audit.Entry, 0, len(snap.AuditEntries))
+	for _, entry := range snap.AuditEntries {
+		if entry != nil {
+			s.auditEntries = append(s.auditEntries, entry)
+		}
+	}
+
+	s.securityEvents = make([]*securityevent.Event, 0, len(snap.SecurityEvents))
+	for _, event := range snap.SecurityEvents {
+		if event != nil {
+			s.securityEvents = append(s.securityEvents, event)
+		}
+	}
+
+	s.flags = make(map[string]*flags.Flag, len(snap.Flags))
+	for _, flag := range snap.Flags {
+		if flag != nil {
+			s.flags[flag.Key] = flag
+		}
+	}
+
+	s.subscriptions = make(map[string]*billing.Subscription, len(snap.Subscriptions))
+	for _, entry := range snap.Subscriptions {
+		if entry.Subscription != nil {
+			entry.Subscription.UserID = entry.UserID
+			entry.Subscription.CustomerID = entry.CustomerID
+			entry.Subscription.SubscriptionID = entry.SubscriptionID
+			entry.Subscription.EventAt = entry.EventAt
+			s.subscriptions[entry.UserID] = entry.Subscription
+		}
+	}
+
+	s.usageCounts = make(map[string]*metering.DailyCount, len(snap.UsageCounts))
+	for _, count := range snap.UsageCounts {
+		if count != nil {
+			s.usageCounts[usageCountKey(count.Account, count.Metric, count.Day)] = count
+		}
+	}
+
+	s.usageAlerts = make(map[string]*metering.Alert, len(snap.UsageAlerts))
+	for _, alert := range snap.UsageAlerts {
+		if alert != nil {
+			s.usageAlerts[usageAlertKey(alert)] = alert
+		}
+	}
+}
@@ -0,0 +1,273 @@
+// Package repository provides in-memory implementations of every repository,
+// selected with database.driver=memory. Data lives in process memory and is
+// optionally loaded from and written to a JSON snapshot file, so contributors can
+// run the application without provisioning MariaDB or PostgreSQL.
+//
+// The stores mirror the behaviour of the GORM stores: the same not-found errors,
+// ID normalization, version checks and partial updates. Values are copied on the
+// way in and out, so callers never share state with the store.
+package repository
+
+import (
+	"reflect"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/goformx/goforms/internal/domain/archive"
+	"github.com/goformx/goforms/internal/domain/audit"
+	"github.com/goformx/goforms/internal/domain/backup"
+	"github.com/goformx/goforms/internal/domain/billing"
+	"github.com/goformx/goforms/internal/domain/dataset"
+	"github.com/goformx/goforms/internal/domain/emaildelivery"
+	"github.com/goformx/goforms/internal/domain/emailtemplate"
+	"github.com/goformx/goforms/internal/domain/entities"
+	"github.com/goformx/goforms/internal/domain/flags"
+	"github.com/goformx/goforms/internal/domain/form"
+	"github.com/goformx/goforms/internal/domain/form/model"
+	"github.com/goformx/goforms/internal/domain/metering"
+	"github.com/goformx/goforms/internal/domain/securityevent"
+	"github.com/goformx/goforms/internal/domain/user"
+	"github.com/goformx/goforms/internal/infrastructure/logging"
+)
+
+// Store holds all application data in memory
+type Store struct {
+	logger logging.Logger
+
+	mu          sync.RWMutex
+	users       map[string]*entities.User
+	forms       map[string]*model.Form
+	submissions map[string]*model.FormSubmission
+	reports     map[string]*model.ReportSchedule
+	views       map[string]*model.SubmissionView
+	templates   map[string]*emailtemplate.Template
+	datasets    map[string]*dataset.Dataset
+	// activities are kept in the order they were recorded
+	activities []*model.SubmissionActivity
+	// schemaRevisions are the undo and redo history of form schemas
+	schemaRevisions []*model.SchemaRevision
+	// suppressions are keyed by normalized address
+	suppressions   map[string]*emaildelivery.Suppression
+	deliveryEvents []*emaildelivery.Event
+	auditEntries   []*audit.Entry
+	securityEvents []*securityevent.Event
+	flags          map[string]*flags.Flag
+	// subscriptions are keyed by user ID
+	subscriptions map[string]*billing.Subscription
+	// usageCounts are keyed by account, metric and day; usageAlerts also by threshold
+	usageCounts map[string]*metering.DailyCount
+	usageAlerts map[string]*metering.Alert
+}
+
+// NewStore creates an empty in-memory store
+func NewStore(logger logging.Logger) *Store {
+	return &Store{
+		logger:        logger,
+		users:         make(map[string]*entities.User),
+		forms:         make(map[string]*model.Form),
+		submissions:   make(map[string]*model.FormSubmission),
+		reports:       make(map[string]*model.ReportSchedule),
+		views:         make(map[string]*model.SubmissionView),
+		templates:     make(map[string]*emailtemplate.Template),
+		datasets:      make(map[string]*dataset.Dataset),
+		suppressions:  make(map[string]*emaildelivery.Suppression),
+		flags:         make(map[string]*flags.Flag),
+		subscriptions: make(map[string]*billing.Subscription),
+		usageCounts:   make(map[string]*metering.DailyCount),
+		usageAlerts:   make(map[string]*metering.Alert),
+	}
+}
+
+// Users returns the user repository; it also implements user.DirectoryRepository
+func (s *Store) Users() user.Repository {
+	return &userStore{store: s}
+}
+
+// Forms returns the form repository; it also implements form.SubmissionBatchRepository
+// and form.SchemaRevisionRepository
+func (s *Store) Forms() form.Repository {
+	return &formStore{store: s}
+}
+
+// Submissions returns the submission repository
+func (s *Store) Submissions() form.SubmissionRepository {
+	return &submissionStore{store: s}
+}
+
+// Reports returns the report schedule repository
+func (s *Store) Reports() form.ReportRepository {
+	return &reportStore{store: s}
+}
+
+// Usage returns the usage repository
+func (s *Store) Usage() form.UsageRepository {
+	return &usageStore{store: s}
+}
+
+// Views returns the submission view repository
+func (s *Store) Views() form.SubmissionViewRepository {
+	return &viewStore{store: s}
+}
+
+// Activities returns the submission activity repository
+func (s *Store) Activities() form.SubmissionActivityRepository {
+	return &activityStore{store: s}
+}
+
+// EmailTemplates returns the email template override repository
+func (s *Store) EmailTemplates() emailtemplate.Repository {
+	return &templateStore{store: s}
+}
+
+// Datasets returns the dataset repository
+func (s *Store) Datasets() dataset.Repository {
+	return &datasetStore{store: s}
+}
+
+// EmailDeliveries returns the email suppression and delivery event repository
+func (s *Store) EmailDeliveries() emaildelivery.Repository {
+	return &deliveryStore{store: s}
+}
+
+// Audit returns the audit log repository
+func (s *Store) Audit() audit.Repository {
+	return &auditStore{store: s}
+}
+
+// SecurityEvents returns the security event repository
+func (s *Store) SecurityEvents() securityevent.Repository {
+	return &securityEventStore{store: s}
+}
+
+// Backups returns the repository that reads and writes backup data
+func (s *Store) Backups() backup.Repository {
+	return &backupStore{store: s}
+}
+
+// Archive returns the repository the submission archiver reads and deletes from
+func (s *Store) Archive() archive.Repository {
+	return &archiveStore{store: s}
+}
+
+// Flags returns the feature flag repository
+func (s *Store) Flags() flags.Repository {
+	return &flagStore{store: s}
+}
+
+// Billing returns the billing subscription repository
+func (s *Store) Billing() billing.Repository {
+	return &billingStore{store: s}
+}
+
+// Metering returns the API usage metering repository
+func (s *Store) Metering() metering.Repository {
+	return &meteringStore{store: s}
+}
+
+// mergeNonZero copies the non-zero fields of src onto dst, as a GORM Updates with a struct does
+func mergeNonZero[T any](dst, src *T) {
+	target := reflect.ValueOf(dst).Elem()
+	source := reflect.ValueOf(src).Elem()
+
+	for i := range source.NumField() {
+		if field := source.Field(i); !field.IsZero() && target.Field(i).CanSet() {
+			target.Field(i).Set(field)
+		}
+	}
+}
+
+// stamp fills the ID and timestamps GORM or the database would set on insert
+func stamp(id *string, createdAt, updatedAt *time.Time) {
+	if *id == "" {
+		*id = uuid.New().String()
+	}
+
+	now := time.Now()
+
+	if createdAt.IsZero() {
+		*createdAt = now
+	}
+
+	if updatedAt.IsZero() {
+		*updatedAt = now
+	}
+}
+
+// page returns the window of items selected by offset and limit; a negative limit means all
+func page[T any](items []T, offset, limit int) []T {
+	if offset < 0 {
+		offset = 0
+	}
+
+	if offset >= len(items) {
+		return []T{}
+	}
+
+	items = items[offset:]
+	if limit >= 0 && limit < len(items) {
+		items = items[:limit]
+	}
+
+	return items
+}
+
+func cloneUser(u *entities.User) *entities.User {
+	clone := *u
+
+	if u.LastActiveAt != nil {
+		lastActiveAt := *u.LastActiveAt
+		clone.LastActiveAt = &lastActiveAt
+	}
+
+	if u.DeletionRequestedAt != nil {
+		requestedAt := *u.DeletionRequestedAt
+		clone.DeletionRequestedAt = &requestedAt
+	}
+
+	if u.DeletionScheduledAt != nil {
+		scheduledAt := *u.DeletionScheduledAt
+		clone.DeletionScheduledAt = &scheduledAt
+	}
+
+	if u.HealthEmailSentAt != nil {
+		sentAt := *u.HealthEmailSentAt
+		clone.HealthEmailSentAt = &sentAt
+	}
+
+	return &clone
+}
+
+func cloneSubmission(submission *model.FormSubmission) *model.FormSubmission {
+	clone := *submission
+	clone.Data = submission.Data.Clone()
+	clone.Metadata = submission.Metadata.Clone()
+	clone.Tags = submission.Tags.Clone()
+
+	return &clone
+}
+
+func cloneReport(report *model.ReportSchedule) *model.ReportSchedule {
+	clone := *report
+	clone.Recipients = report.Recipients.Clone()
+
+	if report.LastRunAt != nil {
+		lastRunAt := *report.LastRunAt
+		clone.LastRunAt = &lastRunAt
+	}
+
+	if report.NextRunAt != nil {
+		nextRunAt := *report.NextRunAt
+		clone.NextRunAt = &nextRunAt
+	}
+
+	return &clone
+}
+
+func cloneView(view *model.SubmissionView) *model.SubmissionView {
+	clone := *view
+	clone.Tags = view.Tags.Clone()
+
+	return &clone
+}
@@ -0,0 +1,266 @@
+package repository_test
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/goformx/goforms/internal/domain/emaildelivery"
+	"github.com/goformx/goforms/internal/domain/emailtemplate"
+	"github.com/goformx/goforms/internal/domain/entities"
+	"github.com/goformx/goforms/internal/domain/form"
+	"github.com/goformx/goforms/internal/domain/form/model"
+	"github.com/goformx/goforms/internal/infrastructure/repository/common"
+	repository "github.com/goformx/goforms/internal/infrastructure/repository/memory"
+	mocklogging "github.com/goformx/goforms/test/mocks/logging"
+)
+
+func newStore(t *testing.T) *repository.Store {
+	t.Helper()
+
+	logger := mocklogging.NewMockLogger(gomock.NewController(t))
+	logger.EXPECT().Info(gomock.Any(), gomock.Any()).AnyTimes()
+
+	return repository.NewStore(logger)
+}
+
+func TestStore_Users(t *testing.T) {
+	users := newStore(t).Users()
+	ctx := t.Context()
+
+	u := &entities.User{Email: "ada@example.com", FirstName: "Ada", LastName: "Lovelace", HashedPassword: "hash"}
+	require.NoError(t, users.Create(ctx, u))
+	assert.NotEmpty(t, u.ID)
+	assert.True(t, u.Active)
+	assert.Equal(t, "user", u.Role)
+
+	require.Error(t, users.Create(ctx, &entities.User{Email: "ADA@example.com"}), "emails are unique")
+
+	found, err := users.GetByEmail(ctx, "ada@example.com")
+	require.NoError(t, err)
+	assert.Equal(t, u.ID, found.ID)
+
+	found.FirstName = "Changed"
+	stored, err := users.GetByID(ctx, u.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "Ada", stored.FirstName, "callers must not share state with the store")
+
+	matches, err := users.Search(ctx, "lovelace", 0, 10)
+	require.NoError(t, err)
+	assert.Len(t, matches, 1)
+
+	require.NoError(t, users.Delete(ctx, u.ID))
+
+	_, err = users.GetByID(ctx, u.ID)
+	require.ErrorIs(t, err, common.ErrNotFound)
+}
+
+func TestStore_Forms(t *testing.T) {
+	forms := newStore(t).Forms()
+	ctx := t.Context()
+
+	formModel := &model.Form{UserID: "user-1", Title: "Contact", Schema: model.JSON{"components": []any{}}}
+	require.NoError(t, forms.CreateForm(ctx, formModel))
+	assert.Equal(t, "draft", formModel.Status)
+	assert.Equal(t, 1, formModel.Version)
+
+	_, err := forms.GetFormByID(ctx, "not-a-uuid")
+	require.Error(t, err)
+
+	loaded, err := forms.GetFormByID(ctx, formModel.ID)
+	require.NoError(t, err)
+
+	// Only non-zero fields are updated and the version is bumped
+	require.NoError(t, forms.UpdateForm(ctx, &model.Form{ID: formModel.ID, Title: "Renamed", Version: loaded.Version}))
+
+	updated, err := forms.GetFormByID(ctx, formModel.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "Renamed", updated.Title)
+	assert.Equal(t, "user-1", updated.UserID)
+	assert.Equal(t, 2, updated.Version)
+
+	// A stale version is rejected
+	err = forms.UpdateForm(ctx, &model.Form{ID: formModel.ID, Title: "Stale", Version: loaded.Version})
+	require.ErrorIs(t, err, model.ErrFormVersionConflict)
+
+	list, err := forms.ListForms(ctx, "user-1")
+	require.NoError(t, err)
+	assert.Len(t, list, 1)
+
+	require.NoError(t, forms.DeleteForm(ctx, formModel.ID))
+
+	_, err = forms.GetFormByID(ctx, formModel.ID)
+	require.ErrorIs(t, err, common.ErrNotFound)
+}
+
+func TestStore_Submissions(t *testing.T) {
+	store := newStore(t)
+	forms := store.Forms()
+	ctx := t.Context()
+
+	batches, ok := forms.(form.SubmissionBatchRepository)
+	require.True(t, ok, "the form store must support batch inserts")
+
+	start := time.Now()
+	submissions := make([]*model.FormSubmission, 3)
+
+	for i := range submissions {
+		submissions[i] = &model.FormSubmission{
+			FormID:      "form-1",
+			Data:        model.JSON{"n": i},
+			Status:      model.SubmissionStatusPending,
+			SubmittedAt: start.Add(time.Duration(i) * time.Second),
+		}
+	}
+
+	require.NoError(t, batches.CreateSubmissions(ctx, submissions))
+
+	result, err := store.Submissions().GetByFormIDPaginated(ctx, "form-1", common.PaginationParams{Page: 2, PageSize: 2})
+	require.NoError(t, err)
+	assert.Equal(t, 3, result.TotalItems)
+	assert.Equal(t, 2, result.TotalPages)
+	require.Len(t, result.Items, 1)
+	assert.Equal(t, submissions[2].ID, result.Items.([]any)[0].(*model.FormSubmission).ID)
+
+	require.NoError(t, forms.UpdateSubmission(ctx, &model.FormSubmission{
+		ID:     submissions[0].ID,
+		Status: model.SubmissionStatusCompleted,
+	}))
+
+	updated, err := forms.GetSubmissionByID(ctx, submissions[0].ID)
+	require.NoError(t, err)
+	assert.Equal(t, model.SubmissionStatusCompleted, updated.Status)
+	assert.Equal(t, "form-1", updated.FormID)
+
+	err = forms.UpdateSubmission(ctx, &model.FormSubmission{ID: "missing"})
+	require.ErrorIs(t, err, common.ErrNotFound)
+}
+
+func TestStore_EmailTemplates(t *testing.T) {
+	templates := newStore(t).EmailTemplates()
+	ctx := t.Context()
+
+	first := &emailtemplate.Template{OwnerID: "owner-1", Kind: emailtemplate.KindDigest, Subject: "One", Text: "body"}
+	require.NoError(t, templates.SaveTemplate(ctx, first))
+	assert.NotEmpty(t, first.ID)
+
+	replacement := &emailtemplate.Template{OwnerID: "owner-1", Kind: emailtemplate.KindDigest, Subject: "Two", Text: "body"}
+	require.NoError(t, templates.SaveTemplate(ctx, replacement))
+	assert.Equal(t, first.ID, replacement.ID, "saving again replaces the owner's override")
+
+	found, err := templates.GetTemplate(ctx, "owner-1", emailtemplate.KindDigest)
+	require.NoError(t, err)
+	assert.Equal(t, "Two", found.Subject)
+
+	listed, err := templates.ListTemplates(ctx, "owner-2")
+	require.NoError(t, err)
+	assert.Empty(t, listed)
+
+	require.NoError(t, templates.DeleteTemplate(ctx, "owner-1", emailtemplate.KindDigest))
+
+	_, err = templates.GetTemplate(ctx, "owner-1", emailtemplate.KindDigest)
+	require.ErrorIs(t, err, emailtemplate.ErrTemplateNotFound)
+}
+
+func TestStore_EmailDeliveries(t *testing.T) {
+	deliveries := newStore(t).EmailDeliveries()
+	ctx := t.Context()
+
+	for _, address := range []string{"a@example.com", "b@example.com"} {
+		require.NoError(t, deliveries.SaveSuppression(ctx,
+			&emaildelivery.Suppression{Address: address, Reason: emaildelivery.ReasonBounce}))
+	}
+
+	suppressed, err := deliveries.IsSuppressed(ctx, "a@example.com")
+	require.NoError(t, err)
+	assert.True(t, suppressed)
+
+	listed, total, err := deliveries.ListSuppressions(ctx, 0, 1)
+	require.NoError(t, err)
+	assert.Len(t, listed, 1)
+	assert.Equal(t, int64(2), total)
+
+	require.NoError(t, deliveries.DeleteSuppression(ctx, "a@example.com"))
+	require.ErrorIs(t, deliveries.DeleteSuppression(ctx, "a@example.com"), emaildelivery.ErrSuppressionNotFound)
+
+	require.NoError(t, deliveries.CreateEvents(ctx, []*emaildelivery.Event{
+		{FormID: "form-1", Type: emaildelivery.EventSent, Recipient: "a@example.com"},
+		{FormID: "form-1", Type: emaildelivery.EventSent, Recipient: "b@example.com"},
+		{FormID: "form-1", Type: emaildelivery.EventBounce, Recipient: "b@example.com"},
+		{FormID: "form-2", Type: emaildelivery.EventSent, Recipient: "c@example.com"},
+	}))
+
+	counts, err := deliveries.CountEvents(ctx, "form-1")
+	require.NoError(t, err)
+	assert.Equal(t, map[emaildelivery.EventType]int64{emaildelivery.EventSent: 2, emaildelivery.EventBounce: 1}, counts)
+}
+
+func TestStore_SnapshotRoundTrip(t *testing.T) {
+	store := newStore(t)
+	ctx := t.Context()
+	path := filepath.Join(t.TempDir(), "data", "snapshot.json")
+
+	require.NoError(t, store.Load(path), "a missing snapshot starts empty")
+
+	u := &entities.User{Email: "ada@example.com", HashedPassword: "secret-hash"}
+	require.NoError(t, store.Users().Create(ctx, u))
+
+	formModel := &model.Form{
+		UserID:         u.ID,
+		Title:          "Contact",
+		Schema:         model.JSON{"components": []any{}},
+		AccessSettings: model.JSON{"require_auth": true},
+	}
+	require.NoError(t, store.Forms().CreateForm(ctx, formModel))
+
+	submission := &model.FormSubmission{FormID: formModel.ID, Data: model.JSON{"name": "Ada"}, Tags: model.JSON{"a": true}}
+	require.NoError(t, store.Submissions().Create(ctx, submission))
+
+	template := &emailtemplate.Template{OwnerID: u.ID, Kind: emailtemplate.KindDigest, Subject: "Digest", Text: "body"}
+	require.NoError(t, store.EmailTemplates().SaveTemplate(ctx, template))
+
+	require.NoError(t, store.EmailDeliveries().SaveSuppression(ctx,
+		&emaildelivery.Suppression{Address: "gone@example.com", Reason: emaildelivery.ReasonBounce}))
+	require.NoError(t, store.EmailDeliveries().CreateEvents(ctx,
+		[]*emaildelivery.Event{{FormID: formModel.ID, Type: emaildelivery.EventSent, Recipient: "gone@example.com"}}))
+
+	require.NoError(t, store.Save(path))
+
+	restored := newStore(t)
+	require.NoError(t, restored.Load(path))
+
+	loadedUser, err := restored.Users().GetByID(ctx, u.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "secret-hash", loadedUser.HashedPassword)
+
+	loadedForm, err := restored.Forms().GetFormByID(ctx, formModel.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "Contact", loadedForm.Title)
+	assert.True(t, loadedForm.GetAccess().RequireAuth)
+
+	loadedSubmission, err := restored.Submissions().GetByID(ctx, submission.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "Ada", loadedSubmission.Data["name"])
+	assert.Equal(t, true, loadedSubmission.Tags["a"])
+
+	loadedTemplate, err := restored.EmailTemplates().GetTemplate(ctx, u.ID, emailtemplate.KindDigest)
+	require.NoError(t, err)
+	assert.Equal(t, template.ID, loadedTemplate.ID)
+	assert.Equal(t, "Digest", loadedTemplate.Subject)
+
+	suppressed, err := restored.EmailDeliveries().IsSuppressed(ctx, "gone@example.com")
+	require.NoError(t, err)
+	assert.True(t, suppressed)
+
+	counts, err := restored.EmailDeliveries().CountEvents(ctx, formModel.ID)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), counts[emaildelivery.EventSent])
+
+	count, err := restored.Usage().CountFormsByUser(ctx, u.ID)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), count)
+}
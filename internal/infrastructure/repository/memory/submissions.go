@@ -0,0 +1,233 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/goformx/goforms/internal/domain/form/model"
+	"github.com/goformx/goforms/internal/infrastructure/repository/common"
+)
+
+// submissionStore implements form.SubmissionRepository in memory
+type submissionStore struct {
+	store *Store
+}
+
+// Create creates a new form submission
+func (r *submissionStore) Create(_ context.Context, submission *model.FormSubmission) error {
+	r.store.insertSubmissions(submission)
+
+	return nil
+}
+
+// GetByID retrieves a form submission by ID
+func (r *submissionStore) GetByID(_ context.Context, id string) (*model.FormSubmission, error) {
+	submission, ok := r.store.getSubmission(id)
+	if !ok {
+		return nil, fmt.Errorf("form submission not found: %s", id)
+	}
+
+	return submission, nil
+}
+
+// GetByFormID retrieves all submissions for a form
+func (r *submissionStore) GetByFormID(_ context.Context, formID string) ([]*model.FormSubmission, error) {
+	return r.store.filterSubmissions(byForm(formID)), nil
+}
+
+// Update saves a form submission, inserting it when it does not exist
+func (r *submissionStore) Update(_ context.Context, submission *model.FormSubmission) error {
+	if !r.store.updateSubmission(submission, false) {
+		r.store.insertSubmissions(submission)
+	}
+
+	return nil
+}
+
+// Delete deletes a form submission by ID
+func (r *submissionStore) Delete(_ context.Context, id string) error {
+	r.store.deleteSubmission(id)
+
+	return nil
+}
+
+// List retrieves a window of form submissions
+func (r *submissionStore) List(_ context.Context, offset, limit int) ([]*model.FormSubmission, error) {
+	return page(r.store.filterSubmissions(nil), offset, limit), nil
+}
+
+// GetByFormIDPaginated retrieves form submissions by form ID with pagination
+func (r *submissionStore) GetByFormIDPaginated(
+	_ context.Context,
+	formID string,
+	params common.PaginationParams,
+) (*common.PaginationResult, error) {
+	return paginate(r.store.filterSubmissions(byForm(formID)), params), nil
+}
+
+// GetByFormAndUser retrieves a submission by form ID and user ID. Submissions are
+// not linked to users, so none is ever found, as with the database store.
+func (r *submissionStore) GetByFormAndUser(_ context.Context, formID, userID string) (*model.FormSubmission, error) {
+	return nil, fmt.Errorf("no submissions found for form %s and user %s", formID, userID)
+}
+
+// GetSubmissionsByStatus retrieves form submissions by status with pagination
+func (r *submissionStore) GetSubmissionsByStatus(
+	_ context.Context,
+	status model.SubmissionStatus,
+	params common.PaginationParams,
+) (*common.PaginationResult, error) {
+	return paginate(r.store.filterSubmissions(func(submission *model.FormSubmission) bool {
+		return submission.Status == status
+	}), params), nil
+}
+
+// CreateSubmission creates a new form submission
+func (r *submissionStore) CreateSubmission(ctx context.Context, submission *model.FormSubmission) error {
+	return r.Create(ctx, submission)
+}
+
+// UpdateSubmission updates an existing form submission
+func (r *submissionStore) UpdateSubmission(ctx context.Context, submission *model.FormSubmission) error {
+	return r.Update(ctx, submission)
+}
+
+// DeleteSubmission deletes a form submission
+func (r *submissionStore) DeleteSubmission(ctx context.Context, id string) error {
+	return r.Delete(ctx, id)
+}
+
+// Count returns the total number of form submissions
+func (r *submissionStore) Count(_ context.Context) (int, error) {
+	s := r.store
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return len(s.submissions), nil
+}
+
+// Search finds submissions whose data or status contains query, ignoring case
+func (r *submissionStore) Search(_ context.Context, query string, offset, limit int) ([]*model.FormSubmission, error) {
+	query = strings.ToLower(query)
+
+	matches := r.store.filterSubmissions(func(submission *model.FormSubmission) bool {
+		data, err := json.Marshal(submission.Data)
+
+		return (err == nil && strings.Contains(strings.ToLower(string(data)), query)) ||
+			strings.Contains(strings.ToLower(string(submission.Status)), query)
+	})
+
+	return page(matches, offset, limit), nil
+}
+
+// paginate builds a pagination result the way the database submission store does
+func paginate(submissions []*model.FormSubmission, params common.PaginationParams) *common.PaginationResult {
+	window := page(submissions, params.GetOffset(), params.GetLimit())
+
+	items := make([]any, len(window))
+	for i, submission := range window {
+		items[i] = submission
+	}
+
+	result := common.NewPaginationResult(items, len(submissions), params.Page, params.PageSize)
+
+	return &result
+}
+
+// byForm matches the submissions to a form
+func byForm(formID string) func(*model.FormSubmission) bool {
+	return func(submission *model.FormSubmission) bool {
+		return submission.FormID == formID
+	}
+}
+
+// insertSubmissions stores copies of submissions, assigning IDs and timestamps
+func (s *Store) insertSubmissions(submissions ...*model.FormSubmission) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, submission := range submissions {
+		stamp(&submission.ID, &submission.CreatedAt, &submission.UpdatedAt)
+		s.submissions[submission.ID] = cloneSubmission(submission)
+	}
+}
+
+// getSubmission returns a copy of a submission
+func (s *Store) getSubmission(id string) (*model.FormSubmission, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	submission, ok := s.submissions[id]
+	if !ok {
+		return nil, false
+	}
+
+	return cloneSubmission(submission), true
+}
+
+// updateSubmission replaces a stored submission, or only its non-zero fields when
+// partial is set. It reports whether the submission existed.
+func (s *Store) updateSubmission(submission *model.FormSubmission, partial bool) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	stored, ok := s.submissions[submission.ID]
+	if !ok {
+		return false
+	}
+
+	submission.UpdatedAt = time.Now()
+
+	updated := cloneSubmission(submission)
+	if partial {
+		updated = cloneSubmission(stored)
+		mergeNonZero(updated, cloneSubmission(submission))
+	}
+
+	s.submissions[submission.ID] = updated
+
+	return true
+}
+
+// deleteSubmission removes a submission and reports whether it existed
+func (s *Store) deleteSubmission(id string) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.submissions[id]; !ok {
+		return false
+	}
+
+	delete(s.submissions, id)
+
+	return true
+}
+
+// filterSubmissions returns copies of the submissions matching keep, oldest first;
+// a nil keep matches all
+func (s *Store) filterSubmissions(keep func(*model.FormSubmission) bool) []*model.FormSubmission {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	submissions := make([]*model.FormSubmission, 0)
+	for _, submission := range s.submissions {
+		if keep == nil || keep(submission) {
+			submissions = append(submissions, cloneSubmission(submission))
+		}
+	}
+
+	slices.SortFunc(submissions, func(a, b *model.FormSubmission) int {
+		if byTime := a.SubmittedAt.Compare(b.SubmittedAt); byTime != 0 {
+			return byTime
+		}
+
+		return strings.Compare(a.ID, b.ID)
+	})
+
+	return submissions
+}
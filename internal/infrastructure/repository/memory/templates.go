@@ -0,0 +1,108 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/goformx/goforms/internal/domain/emailtemplate"
+)
+
+// templateStore implements emailtemplate.Repository in memory
+type templateStore struct {
+	store *Store
+}
+
+// GetTemplate retrieves an owner's override for a kind
+func (t *templateStore) GetTemplate(_ context.Context, ownerID string, kind emailtemplate.Kind) (*emailtemplate.Template, error) {
+	s := t.store
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	template := t.find(ownerID, kind)
+	if template == nil {
+		return nil, fmt.Errorf("get email template: %w", emailtemplate.ErrTemplateNotFound)
+	}
+
+	clone := *template
+
+	return &clone, nil
+}
+
+// ListTemplates lists an owner's overrides, ordered by kind
+func (t *templateStore) ListTemplates(_ context.Context, ownerID string) ([]*emailtemplate.Template, error) {
+	s := t.store
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	templates := make([]*emailtemplate.Template, 0)
+
+	for _, template := range s.templates {
+		if template.OwnerID == ownerID {
+			clone := *template
+			templates = append(templates, &clone)
+		}
+	}
+
+	slices.SortFunc(templates, func(a, b *emailtemplate.Template) int {
+		return strings.Compare(string(a.Kind), string(b.Kind))
+	})
+
+	return templates, nil
+}
+
+// SaveTemplate creates or replaces an owner's override, keeping the ID of the one it replaces
+func (t *templateStore) SaveTemplate(_ context.Context, template *emailtemplate.Template) error {
+	s := t.store
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	template.ID = ""
+	template.CreatedAt = time.Time{}
+
+	if existing := t.find(template.OwnerID, template.Kind); existing != nil {
+		template.ID = existing.ID
+		template.CreatedAt = existing.CreatedAt
+	}
+
+	stamp(&template.ID, &template.CreatedAt, &template.UpdatedAt)
+	template.UpdatedAt = time.Now()
+
+	clone := *template
+	s.templates[template.ID] = &clone
+
+	return nil
+}
+
+// DeleteTemplate deletes an owner's override for a kind
+func (t *templateStore) DeleteTemplate(_ context.Context, ownerID string, kind emailtemplate.Kind) error {
+	s := t.store
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	template := t.find(ownerID, kind)
+	if template == nil {
+		return fmt.Errorf("delete email template: %w", emailtemplate.ErrTemplateNotFound)
+	}
+
+	delete(s.templates, template.ID)
+
+	return nil
+}
+
+// find returns the stored override for an owner and kind; callers hold the lock
+func (t *templateStore) find(ownerID string, kind emailtemplate.Kind) *emailtemplate.Template {
+	for _, template := range t.store.templates {
+		if template.OwnerID == ownerID && template.Kind == kind {
+			return template
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,259 @@
+package repository
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/goformx/goforms/internal/domain/entities"
+	"github.com/goformx/goforms/internal/domain/user"
+	"github.com/goformx/goforms/internal/infrastructure/repository/common"
+)
+
+// errDuplicateEmail mirrors the unique index on users.email
+var errDuplicateEmail = errors.New("duplicate key value violates unique constraint on email")
+
+// userStore implements user.Repository, user.DirectoryRepository and user.DeletionRepository in memory
+type userStore struct {
+	store *Store
+}
+
+// Create stores a new user
+func (r *userStore) Create(_ context.Context, u *entities.User) error {
+	s := r.store
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, existing := range s.users {
+		if strings.EqualFold(existing.Email, u.Email) {
+			return fmt.Errorf("create user: %w", common.NewDatabaseError("create", "user", u.ID, errDuplicateEmail))
+		}
+	}
+
+	if err := u.BeforeCreate(nil); err != nil {
+		return fmt.Errorf("create user: %w", common.NewDatabaseError("create", "user", u.ID, err))
+	}
+
+	stamp(&u.ID, &u.CreatedAt, &u.UpdatedAt)
+	s.users[u.ID] = cloneUser(u)
+
+	return nil
+}
+
+// GetByEmail retrieves a user by email
+func (r *userStore) GetByEmail(_ context.Context, email string) (*entities.User, error) {
+	if u := r.find(func(u *entities.User) bool { return u.Email == email }); u != nil {
+		return u, nil
+	}
+
+	return nil, fmt.Errorf("get user by email: %w", common.NewNotFoundError("get_by_email", "user", email))
+}
+
+// GetByID retrieves a user by ID
+func (r *userStore) GetByID(_ context.Context, id string) (*entities.User, error) {
+	s := r.store
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if u, ok := s.users[id]; ok {
+		return cloneUser(u), nil
+	}
+
+	return nil, fmt.Errorf("get user by ID: %w", common.NewNotFoundError("get_by_id", "user", id))
+}
+
+// GetByUsername retrieves a user by username. Users have no username column, so
+// this never finds one, as with the database store.
+func (r *userStore) GetByUsername(_ context.Context, username string) (*entities.User, error) {
+	return nil, fmt.Errorf("get user by username: %w", common.NewNotFoundError("get_by_username", "user", username))
+}
+
+// Update saves a user
+func (r *userStore) Update(_ context.Context, u *entities.User) error {
+	s := r.store
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.users[u.ID]; !ok {
+		return fmt.Errorf("update user: %w", common.NewNotFoundError("update", "user", u.ID))
+	}
+
+	u.UpdatedAt = time.Now()
+	s.users[u.ID] = cloneUser(u)
+
+	return nil
+}
+
+// Delete removes a user by ID
+func (r *userStore) Delete(_ context.Context, id string) error {
+	s := r.store
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.users[id]; !ok {
+		return fmt.Errorf("delete user: %w", common.NewNotFoundError("delete", "user", id))
+	}
+
+	delete(s.users, id)
+
+	return nil
+}
+
+// List returns users ordered by ID
+func (r *userStore) List(_ context.Context, offset, limit int) ([]*entities.User, error) {
+	return page(r.filter(nil), offset, limit), nil
+}
+
+// Count returns the total number of users
+func (r *userStore) Count(_ context.Context) (int, error) {
+	s := r.store
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return len(s.users), nil
+}
+
+// GetByRole retrieves users by role
+func (r *userStore) GetByRole(_ context.Context, role string, offset, limit int) ([]*entities.User, error) {
+	return page(r.filter(func(u *entities.User) bool { return u.Role == role }), offset, limit), nil
+}
+
+// GetActiveUsers retrieves active users
+func (r *userStore) GetActiveUsers(_ context.Context, offset, limit int) ([]*entities.User, error) {
+	return page(r.filter(func(u *entities.User) bool { return u.Active }), offset, limit), nil
+}
+
+// GetInactiveUsers retrieves inactive users
+func (r *userStore) GetInactiveUsers(_ context.Context, offset, limit int) ([]*entities.User, error) {
+	return page(r.filter(func(u *entities.User) bool { return !u.Active }), offset, limit), nil
+}
+
+// Search finds users whose name or email contains query, ignoring case
+func (r *userStore) Search(_ context.Context, query string, offset, limit int) ([]*entities.User, error) {
+	query = strings.ToLower(query)
+
+	matches := r.filter(func(u *entities.User) bool {
+		return strings.Contains(strings.ToLower(u.GetFullName()), query) ||
+			strings.Contains(strings.ToLower(u.Email), query)
+	})
+
+	return page(matches, offset, limit), nil
+}
+
+// find returns a copy of the first user matching keep
+func (r *userStore) find(keep func(*entities.User) bool) *entities.User {
+	if matches := r.filter(keep); len(matches) > 0 {
+		return matches[0]
+	}
+
+	return nil
+}
+
+// filter returns copies of the users matching keep, ordered by ID; a nil keep matches all
+func (r *userStore) filter(keep func(*entities.User) bool) []*entities.User {
+	s := r.store
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	users := make([]*entities.User, 0, len(s.users))
+	for _, u := range s.users {
+		if keep == nil || keep(u) {
+			users = append(users, cloneUser(u))
+		}
+	}
+
+	slices.SortFunc(users, func(a, b *entities.User) int { return cmp.Compare(a.ID, b.ID) })
+
+	return users
+}
+
+// SearchUsers lists users matching filter, newest first, with the total count
+func (r *userStore) SearchUsers(_ context.Context, filter user.Filter, offset, limit int) ([]*entities.User, int64, error) {
+	query := strings.ToLower(filter.Query)
+
+	matches := r.filter(func(u *entities.User) bool {
+		return (query == "" || strings.Contains(strings.ToLower(u.Email), query) ||
+			strings.Contains(strings.ToLower(u.FirstName), query) ||
+			strings.Contains(strings.ToLower(u.LastName), query)) &&
+			(filter.Role == "" || u.Role == filter.Role) &&
+			(filter.Active == nil || u.Active == *filter.Active)
+	})
+
+	// filter orders by ID, so users created at the same time keep a stable order
+	slices.SortStableFunc(matches, func(a, b *entities.User) int { return b.CreatedAt.Compare(a.CreatedAt) })
+
+	return page(matches, offset, limit), int64(len(matches)), nil
+}
+
+// TouchLastActive sets a user's last activity without changing UpdatedAt
+func (r *userStore) TouchLastActive(_ context.Context, id string, at time.Time) error {
+	s := r.store
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if u, ok := s.users[id]; ok {
+		u.LastActiveAt = &at
+	}
+
+	return nil
+}
+
+// ListDeletionsDue lists up to limit users whose deletion is scheduled at or before now, earliest first
+func (r *userStore) ListDeletionsDue(_ context.Context, now time.Time, limit int) ([]*entities.User, error) {
+	due := r.filter(func(u *entities.User) bool {
+		return u.DeletionScheduledAt != nil && !u.DeletionScheduledAt.After(now)
+	})
+
+	slices.SortStableFunc(due, func(a, b *entities.User) int { return a.DeletionScheduledAt.Compare(*b.DeletionScheduledAt) })
+
+	return page(due, 0, limit), nil
+}
+
+// ListHealthEmailsDue lists up to limit active users who opted in to the
+// health email and were last sent one at or before before, or never
+func (r *userStore) ListHealthEmailsDue(_ context.Context, before time.Time, limit int) ([]*entities.User, error) {
+	due := r.filter(func(u *entities.User) bool {
+		return u.HealthEmail && u.Active && (u.HealthEmailSentAt == nil || !u.HealthEmailSentAt.After(before))
+	})
+
+	return page(due, 0, limit), nil
+}
+
+// SetHealthEmailSent moves a user's last health email time from last to sentAt if it is still last
+func (r *userStore) SetHealthEmailSent(_ context.Context, id string, last *time.Time, sentAt time.Time) (bool, error) {
+	s := r.store
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	u, ok := s.users[id]
+	if !ok {
+		return false, nil
+	}
+
+	current := u.HealthEmailSentAt
+	if (current == nil) != (last == nil) || (current != nil && !current.Equal(*last)) {
+		return false, nil
+	}
+
+	u.HealthEmailSentAt = &sentAt
+
+	return true, nil
+}
+
+// Purge removes a user. The memory store keeps no soft-deleted users, so
+// this is the same as Delete.
+func (r *userStore) Purge(ctx context.Context, id string) error {
+	return r.Delete(ctx, id)
+}
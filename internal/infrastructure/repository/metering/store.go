@@ -0,0 +1,83 @@
+// Package repository provides the API usage metering repository implementation
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"github.com/goformx/goforms/internal/domain/metering"
+	"github.com/goformx/goforms/internal/infrastructure/database"
+	"github.com/goformx/goforms/internal/infrastructure/logging"
+	"github.com/goformx/goforms/internal/infrastructure/repository/common"
+	"github.com/goformx/goforms/internal/infrastructure/repository/tables"
+)
+
+// Store implements metering.Repository
+type Store struct {
+	db     database.DB
+	logger logging.Logger
+}
+
+// NewStore creates a new metering store
+func NewStore(db database.DB, logger logging.Logger) metering.Repository {
+	return &Store{
+		db:     db,
+		logger: logger,
+	}
+}
+
+// AddCounts adds counts to the stored daily totals in one transaction
+func (s *Store) AddCounts(ctx context.Context, counts []*metering.DailyCount) error {
+	err := s.db.GetDB().WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		for _, count := range counts {
+			increment := clause.OnConflict{
+				Columns: common.Unqualified(tables.UsageDaily.Account, tables.UsageDaily.Metric, tables.UsageDaily.Day),
+				DoUpdates: clause.Set{
+					tables.UsageDaily.Count.SetExpr(common.Plus(tables.UsageDaily.Count, count.Count)),
+					tables.UsageDaily.UpdatedAt.Set(time.Now()),
+				},
+			}
+
+			if err := tx.Clauses(increment).Create(count).Error; err != nil {
+				return fmt.Errorf("add usage count for %s: %w", count.Account, err)
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("add usage counts: %w",
+			common.NewDatabaseError("add", "api_usage_daily", "", err))
+	}
+
+	return nil
+}
+
+// ListCounts returns an account's daily totals for the days from..to, ordered by day
+func (s *Store) ListCounts(ctx context.Context, account string, from, to time.Time) ([]*metering.DailyCount, error) {
+	var counts []*metering.DailyCount
+	if err := s.db.GetDB().WithContext(ctx).
+		Where(tables.UsageDaily.Account.Eq(account), tables.UsageDaily.Day.Gte(from), tables.UsageDaily.Day.Lte(to)).
+		Order(tables.UsageDaily.Day.Asc()).
+		Find(&counts).Error; err != nil {
+		return nil, fmt.Errorf("list usage counts: %w",
+			common.NewDatabaseError("list", "api_usage_daily", account, err))
+	}
+
+	return counts, nil
+}
+
+// RecordAlert stores an alert; it reports false when another instance recorded it first
+func (s *Store) RecordAlert(ctx context.Context, alert *metering.Alert) (bool, error) {
+	result := s.db.GetDB().WithContext(ctx).Clauses(clause.OnConflict{DoNothing: true}).Create(alert)
+	if result.Error != nil {
+		return false, fmt.Errorf("record usage alert: %w",
+			common.NewDatabaseError("create", "api_usage_alert", alert.Account, result.Error))
+	}
+
+	return result.RowsAffected > 0, nil
+}
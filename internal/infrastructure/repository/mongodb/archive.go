@@ -0,0 +1,42 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"github.com/goformx/goforms/internal/domain/form/model"
+)
+
+// archiveStore implements archive.Repository in MongoDB
+type archiveStore struct {
+	store *Store
+}
+
+// Expired returns up to limit submissions submitted before cutoff, oldest first
+func (a *archiveStore) Expired(ctx context.Context, cutoff time.Time, limit int) ([]*model.FormSubmission, error) {
+	submissions, err := a.store.findSubmissions(ctx,
+		bson.D{{Key: "submitted_at", Value: bson.D{{Key: "$lt", Value: cutoff}}}},
+		options.Find().SetSort(bson.D{{Key: "submitted_at", Value: 1}, {Key: "_id", Value: 1}}).SetLimit(int64(limit)))
+	if err != nil {
+		return nil, fmt.Errorf("list expired submissions: %w", err)
+	}
+
+	return submissions, nil
+}
+
+// Delete deletes the submissions with the given IDs
+func (a *archiveStore) Delete(ctx context.Context, ids []string) error {
+	if len(ids) == 0 {
+		return nil
+	}
+
+	if _, err := a.store.submissions.DeleteMany(ctx, bson.D{{Key: "_id", Value: bson.D{{Key: "$in", Value: ids}}}}); err != nil {
+		return fmt.Errorf("delete archived submissions: %w", err)
+	}
+
+	return nil
+}
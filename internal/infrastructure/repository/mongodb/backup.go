@@ -0,0 +1,84 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"github.com/goformx/goforms/internal/domain/backup"
+)
+
+// backupStore implements backup.Repository in MongoDB
+type backupStore struct {
+	store *Store
+}
+
+// Dump reads every form and the submissions to those forms, oldest first
+func (b *backupStore) Dump(ctx context.Context) (*backup.Dataset, error) {
+	oldestFirst := options.Find().SetSort(bson.D{{Key: "created_at", Value: 1}})
+
+	forms, err := b.store.findForms(ctx, bson.D{}, oldestFirst)
+	if err != nil {
+		return nil, fmt.Errorf("dump forms: %w", err)
+	}
+
+	submissions, err := b.store.findSubmissions(ctx, bson.D{}, oldestFirst)
+	if err != nil {
+		return nil, fmt.Errorf("dump submissions: %w", err)
+	}
+
+	dataset := &backup.Dataset{
+		Forms:       make([]backup.Form, 0, len(forms)),
+		Submissions: make([]backup.Submission, 0, len(submissions)),
+	}
+
+	formIDs := make(map[string]bool, len(forms))
+
+	for _, f := range forms {
+		formIDs[f.ID] = true
+		dataset.Forms = append(dataset.Forms, backup.NewForm(f))
+	}
+
+	for _, submission := range submissions {
+		if formIDs[submission.FormID] {
+			dataset.Submissions = append(dataset.Submissions, backup.NewSubmission(submission))
+		}
+	}
+
+	return dataset, nil
+}
+
+// Restore writes the dataset's forms and submissions, replacing those with the same IDs
+func (b *backupStore) Restore(ctx context.Context, dataset *backup.Dataset) error {
+	forms := make([]mongo.WriteModel, 0, len(dataset.Forms))
+	for _, f := range dataset.Forms {
+		forms = append(forms, replaceModel(f.ID, newFormDocument(f.Model())))
+	}
+
+	submissions := make([]mongo.WriteModel, 0, len(dataset.Submissions))
+	for _, submission := range dataset.Submissions {
+		submissions = append(submissions, replaceModel(submission.ID, newSubmissionDocument(submission.Model())))
+	}
+
+	if len(forms) > 0 {
+		if _, err := b.store.forms.BulkWrite(ctx, forms); err != nil {
+			return fmt.Errorf("restore forms: %w", err)
+		}
+	}
+
+	if len(submissions) > 0 {
+		if _, err := b.store.submissions.BulkWrite(ctx, submissions); err != nil {
+			return fmt.Errorf("restore submissions: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// replaceModel replaces the document with the given ID, inserting it when missing
+func replaceModel(id string, document any) mongo.WriteModel {
+	return mongo.NewReplaceOneModel().SetFilter(bson.D{{Key: "_id", Value: id}}).SetReplacement(document).SetUpsert(true)
+}
@@ -0,0 +1,245 @@
+package repository
+
+import (
+	"reflect"
+	"strings"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	"github.com/goformx/goforms/internal/domain/form/model"
+)
+
+// formDocument is a form as stored in the forms collection. The model's fields
+// are listed here rather than tagged on the model, which carries GORM tags and
+// hides some fields from JSON.
+type formDocument struct {
+	ID                    string     `bson:"_id"`
+	UserID                string     `bson:"user_id"`
+	Title                 string     `bson:"title"`
+	Description           string     `bson:"description"`
+	Schema                model.JSON `bson:"schema"`
+	Active                bool       `bson:"active"`
+	CreatedAt             time.Time  `bson:"created_at"`
+	UpdatedAt             time.Time  `bson:"updated_at"`
+	Status                string     `bson:"status"`
+	Version               int        `bson:"version"`
+	CorsOrigins           model.JSON `bson:"cors_origins"`
+	CorsMethods           model.JSON `bson:"cors_methods"`
+	CorsHeaders           model.JSON `bson:"cors_headers"`
+	AccessSettings        model.JSON `bson:"access_settings"`
+	SubmissionWriteMode   string     `bson:"submission_write_mode"`
+	ThrottleMode          string     `bson:"throttle_mode"`
+	Timezone              string     `bson:"timezone"`
+	Extensions            model.JSON `bson:"extensions"`
+	Script                model.JSON `bson:"script"`
+	NotificationTemplates model.JSON `bson:"notification_templates"`
+	RedactionPolicy       model.JSON `bson:"redaction_policy"`
+	EmbedMethods          model.JSON `bson:"embed_methods"`
+	PublicStats           model.JSON `bson:"public_stats"`
+}
+
+func newFormDocument(f *model.Form) *formDocument {
+	return &formDocument{
+		ID:                    f.ID,
+		UserID:                f.UserID,
+		Title:                 f.Title,
+		Description:           f.Description,
+		Schema:                f.Schema,
+		Active:                f.Active,
+		CreatedAt:             f.CreatedAt,
+		UpdatedAt:             f.UpdatedAt,
+		Status:                f.Status,
+		Version:               f.Version,
+		CorsOrigins:           f.CorsOrigins,
+		CorsMethods:           f.CorsMethods,
+		CorsHeaders:           f.CorsHeaders,
+		AccessSettings:        f.AccessSettings,
+		SubmissionWriteMode:   f.SubmissionWriteMode,
+		ThrottleMode:          f.ThrottleMode,
+		Timezone:              f.Timezone,
+		Extensions:            f.Extensions,
+		Script:                f.Script,
+		NotificationTemplates: f.NotificationTemplates,
+		RedactionPolicy:       f.RedactionPolicy,
+		EmbedMethods:          f.EmbedMethods,
+		PublicStats:           f.PublicStats,
+	}
+}
+
+func (d *formDocument) model() *model.Form {
+	return &model.Form{
+		ID:                    d.ID,
+		UserID:                d.UserID,
+		Title:                 d.Title,
+		Description:           d.Description,
+		Schema:                normalizeJSON(d.Schema),
+		Active:                d.Active,
+		CreatedAt:             d.CreatedAt,
+		UpdatedAt:             d.UpdatedAt,
+		Status:                d.Status,
+		Version:               d.Version,
+		CorsOrigins:           normalizeJSON(d.CorsOrigins),
+		CorsMethods:           normalizeJSON(d.CorsMethods),
+		CorsHeaders:           normalizeJSON(d.CorsHeaders),
+		AccessSettings:        normalizeJSON(d.AccessSettings),
+		SubmissionWriteMode:   d.SubmissionWriteMode,
+		ThrottleMode:          d.ThrottleMode,
+		Timezone:              d.Timezone,
+		Extensions:            normalizeJSON(d.Extensions),
+		Script:                normalizeJSON(d.Script),
+		NotificationTemplates: normalizeJSON(d.NotificationTemplates),
+		RedactionPolicy:       normalizeJSON(d.RedactionPolicy),
+		EmbedMethods:          normalizeJSON(d.EmbedMethods),
+		PublicStats:           normalizeJSON(d.PublicStats),
+	}
+}
+
+// submissionDocument is a submission as stored in the form_submissions collection
+type submissionDocument struct {
+	ID          string                 `bson:"_id"`
+	FormID      string                 `bson:"form_id"`
+	Data        model.JSON             `bson:"data"`
+	SubmittedAt time.Time              `bson:"submitted_at"`
+	Status      model.SubmissionStatus `bson:"status"`
+	Metadata    model.JSON             `bson:"metadata"`
+	Tags        model.JSON             `bson:"tags"`
+	CreatedAt   time.Time              `bson:"created_at"`
+	UpdatedAt   time.Time              `bson:"updated_at"`
+	Test        bool                   `bson:"test"`
+}
+
+func newSubmissionDocument(submission *model.FormSubmission) *submissionDocument {
+	return &submissionDocument{
+		ID:          submission.ID,
+		FormID:      submission.FormID,
+		Data:        submission.Data,
+		SubmittedAt: submission.SubmittedAt,
+		Status:      submission.Status,
+		Metadata:    submission.Metadata,
+		Tags:        submission.Tags,
+		CreatedAt:   submission.CreatedAt,
+		UpdatedAt:   submission.UpdatedAt,
+		Test:        submission.Test,
+	}
+}
+
+func (d *submissionDocument) model() *model.FormSubmission {
+	return &model.FormSubmission{
+		ID:          d.ID,
+		FormID:      d.FormID,
+		Data:        normalizeJSON(d.Data),
+		SubmittedAt: d.SubmittedAt,
+		Status:      d.Status,
+		Metadata:    normalizeJSON(d.Metadata),
+		Tags:        normalizeJSON(d.Tags),
+		CreatedAt:   d.CreatedAt,
+		UpdatedAt:   d.UpdatedAt,
+		Test:        d.Test,
+	}
+}
+
+// revisionDocument is a schema revision as stored in the form_schema_revisions collection
+type revisionDocument struct {
+	ID        string            `bson:"_id"`
+	FormID    string            `bson:"form_id"`
+	Stack     model.SchemaStack `bson:"stack"`
+	Schema    model.JSON        `bson:"schema"`
+	Seq       int64             `bson:"seq"`
+	CreatedAt time.Time         `bson:"created_at"`
+}
+
+func newRevisionDocument(revision *model.SchemaRevision) *revisionDocument {
+	return &revisionDocument{
+		ID:        revision.ID,
+		FormID:    revision.FormID,
+		Stack:     revision.Stack,
+		Schema:    revision.Schema,
+		Seq:       revision.Seq,
+		CreatedAt: revision.CreatedAt,
+	}
+}
+
+func (d *revisionDocument) model() *model.SchemaRevision {
+	return &model.SchemaRevision{
+		ID:        d.ID,
+		FormID:    d.FormID,
+		Stack:     d.Stack,
+		Schema:    normalizeJSON(d.Schema),
+		Seq:       d.Seq,
+		CreatedAt: d.CreatedAt,
+	}
+}
+
+// normalizeJSON turns a decoded document back into the values encoding/json
+// produces, as the SQL stores return them: nested documents become maps,
+// arrays become []any and integers become float64. The driver decodes them
+// into its own types, which code type-asserting map[string]any would miss.
+func normalizeJSON(document model.JSON) model.JSON {
+	if document == nil {
+		return nil
+	}
+
+	normalized := make(model.JSON, len(document))
+	for key, value := range document {
+		normalized[key] = normalizeValue(value)
+	}
+
+	return normalized
+}
+
+// normalizeValue normalizes one decoded value; see normalizeJSON
+func normalizeValue(value any) any {
+	switch v := value.(type) {
+	case primitive.D:
+		object := make(map[string]any, len(v))
+		for _, element := range v {
+			object[element.Key] = normalizeValue(element.Value)
+		}
+
+		return object
+	case primitive.M:
+		return map[string]any(normalizeJSON(model.JSON(v)))
+	case model.JSON:
+		return map[string]any(normalizeJSON(v))
+	case map[string]any:
+		return map[string]any(normalizeJSON(v))
+	case primitive.A:
+		return normalizeValue([]any(v))
+	case []any:
+		items := make([]any, len(v))
+		for i, item := range v {
+			items[i] = normalizeValue(item)
+		}
+
+		return items
+	case int32:
+		return float64(v)
+	case int64:
+		return float64(v)
+	case primitive.DateTime:
+		return v.Time().UTC().Format(time.RFC3339Nano)
+	default:
+		return value
+	}
+}
+
+// nonZeroFields returns the non-zero fields of a document, by their BSON
+// names and without the ID, for a $set that updates only the fields given,
+// as GORM's Updates does with a struct
+func nonZeroFields(document any) bson.D {
+	value := reflect.Indirect(reflect.ValueOf(document))
+	fields := bson.D{}
+
+	for i := range value.NumField() {
+		name, _, _ := strings.Cut(value.Type().Field(i).Tag.Get("bson"), ",")
+		if name == "" || name == "_id" || value.Field(i).IsZero() {
+			continue
+		}
+
+		fields = append(fields, bson.E{Key: name, Value: value.Field(i).Interface()})
+	}
+
+	return fields
+}
@@ -0,0 +1,61 @@
+package repository
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/bson"
+
+	"github.com/goformx/goforms/internal/domain/form/model"
+)
+
+func TestSubmissionDocument_RoundTrip(t *testing.T) {
+	submitted := time.Date(2026, time.March, 2, 9, 30, 0, 0, time.UTC)
+	submission := &model.FormSubmission{
+		ID:     "submission-1",
+		FormID: "form-1",
+		Data: model.JSON{
+			"name":    "Ada",
+			"age":     36,
+			"address": map[string]any{"city": "London", "lines": []any{"1 Main St", int64(2)}},
+			"tags":    []any{map[string]any{"label": "vip"}},
+		},
+		SubmittedAt: submitted,
+		Status:      model.SubmissionStatusCompleted,
+		Test:        true,
+	}
+
+	raw, err := bson.Marshal(newSubmissionDocument(submission))
+	require.NoError(t, err)
+
+	var document submissionDocument
+	require.NoError(t, bson.Unmarshal(raw, &document))
+
+	decoded := document.model()
+	assert.Equal(t, model.JSON{
+		"name":    "Ada",
+		"age":     float64(36),
+		"address": map[string]any{"city": "London", "lines": []any{"1 Main St", float64(2)}},
+		"tags":    []any{map[string]any{"label": "vip"}},
+	}, decoded.Data, "values decode as encoding/json would")
+	assert.True(t, decoded.SubmittedAt.Equal(submitted))
+	assert.True(t, decoded.Test)
+	assert.Nil(t, decoded.Metadata)
+}
+
+func TestNonZeroFields(t *testing.T) {
+	fields := nonZeroFields(newFormDocument(&model.Form{
+		ID:          "form-1",
+		Title:       "Renamed",
+		Version:     3,
+		CorsOrigins: model.JSON{},
+	}))
+
+	assert.Equal(t, bson.D{
+		{Key: "title", Value: "Renamed"},
+		{Key: "version", Value: 3},
+		{Key: "cors_origins", Value: model.JSON{}},
+	}, fields, "the ID and zero fields are left out; empty but set JSON is kept")
+}
@@ -0,0 +1,372 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"github.com/goformx/goforms/internal/domain/form"
+	"github.com/goformx/goforms/internal/domain/form/model"
+	"github.com/goformx/goforms/internal/infrastructure/repository/common"
+)
+
+// The stores find these capabilities by type assertion, so they are checked here
+var (
+	_ form.SubmissionBatchRepository = (*formStore)(nil)
+	_ form.SubmissionRangeRepository = (*formStore)(nil)
+	_ form.TestSubmissionRepository  = (*formStore)(nil)
+	_ form.SchemaRevisionRepository  = (*formStore)(nil)
+)
+
+// formStore implements form.Repository, form.SubmissionBatchRepository,
+// form.SubmissionRangeRepository, form.TestSubmissionRepository and
+// form.SchemaRevisionRepository in MongoDB
+type formStore struct {
+	store *Store
+}
+
+// CreateForm creates a new form
+func (r *formStore) CreateForm(ctx context.Context, formModel *model.Form) error {
+	if err := common.PrepareForm(formModel); err != nil {
+		return fmt.Errorf("create form: %w", common.NewDatabaseError("create", "form", formModel.ID, err))
+	}
+
+	stamp(&formModel.CreatedAt, &formModel.UpdatedAt)
+
+	if _, err := r.store.forms.InsertOne(ctx, newFormDocument(formModel)); err != nil {
+		r.store.logger.Error("failed to create form", "form_id", formModel.ID, "error", err)
+
+		return fmt.Errorf("create form: %w", common.NewDatabaseError("create", "form", formModel.ID, err))
+	}
+
+	return nil
+}
+
+// GetFormByID retrieves a form by ID
+func (r *formStore) GetFormByID(ctx context.Context, id string) (*model.Form, error) {
+	normalizedID, err := common.NormalizeFormID("get", id)
+	if err != nil {
+		return nil, fmt.Errorf("get form by ID: %w", err)
+	}
+
+	var document formDocument
+	if err = r.store.forms.FindOne(ctx, bson.D{{Key: "_id", Value: normalizedID}}).Decode(&document); err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, fmt.Errorf("get form by ID: %w", common.NewNotFoundError("get", "form", normalizedID))
+		}
+
+		return nil, fmt.Errorf("get form by ID: %w", common.NewDatabaseError("get", "form", normalizedID, err))
+	}
+
+	return document.model(), nil
+}
+
+// ListForms retrieves a user's forms, newest first
+func (r *formStore) ListForms(ctx context.Context, userID string) ([]*model.Form, error) {
+	forms, err := r.store.findForms(ctx, bson.D{{Key: "user_id", Value: userID}},
+		options.Find().SetSort(bson.D{{Key: "created_at", Value: -1}}))
+	if err != nil {
+		r.store.logger.Error("failed to list forms", "user_id", userID, "error", err)
+
+		return nil, fmt.Errorf("list forms: %w", common.NewDatabaseError("list", "form", "", err))
+	}
+
+	return forms, nil
+}
+
+// UpdateForm updates the non-zero fields of a form. When the form carries a version,
+// the update only applies if the stored version still matches, and the version is
+// incremented; otherwise model.ErrFormVersionConflict is returned.
+func (r *formStore) UpdateForm(ctx context.Context, formModel *model.Form) error {
+	expectedVersion := formModel.Version
+
+	filter := bson.D{{Key: "_id", Value: formModel.ID}}
+	if expectedVersion > 0 {
+		filter = append(filter, bson.E{Key: "version", Value: expectedVersion})
+		formModel.Version = expectedVersion + 1
+	}
+
+	if err := formModel.BeforeSave(nil); err != nil {
+		formModel.Version = expectedVersion
+
+		return fmt.Errorf("update form: %w", common.NewDatabaseError("update", "form", formModel.ID, err))
+	}
+
+	formModel.UpdatedAt = time.Now()
+
+	result, err := r.store.forms.UpdateOne(ctx, filter, bson.D{{Key: "$set", Value: nonZeroFields(newFormDocument(formModel))}})
+	if err != nil {
+		formModel.Version = expectedVersion
+
+		return fmt.Errorf("update form: %w", common.NewDatabaseError("update", "form", formModel.ID, err))
+	}
+
+	if result.MatchedCount == 0 {
+		formModel.Version = expectedVersion
+
+		if expectedVersion > 0 && r.formExists(ctx, formModel.ID) {
+			return fmt.Errorf("update form: %w", model.ErrFormVersionConflict)
+		}
+
+		return fmt.Errorf("update form: %w", common.NewNotFoundError("update", "form", formModel.ID))
+	}
+
+	return nil
+}
+
+// formExists reports whether a form with the given ID exists
+func (r *formStore) formExists(ctx context.Context, id string) bool {
+	count, err := r.store.forms.CountDocuments(ctx, bson.D{{Key: "_id", Value: id}})
+
+	return err == nil && count > 0
+}
+
+// DeleteForm deletes a form. Its submissions are kept, as with the soft delete of the database store.
+func (r *formStore) DeleteForm(ctx context.Context, id string) error {
+	normalizedID, err := common.NormalizeFormID("delete", id)
+	if err != nil {
+		return fmt.Errorf("delete form: %w", err)
+	}
+
+	result, err := r.store.forms.DeleteOne(ctx, bson.D{{Key: "_id", Value: normalizedID}})
+	if err != nil {
+		r.store.logger.Error("failed to delete form", "form_id", normalizedID, "error", err)
+
+		return fmt.Errorf("delete form: %w", common.NewDatabaseError("delete", "form", normalizedID, err))
+	}
+
+	if result.DeletedCount == 0 {
+		return fmt.Errorf("delete form: %w", common.NewNotFoundError("delete", "form", normalizedID))
+	}
+
+	return nil
+}
+
+// GetFormsByStatus returns forms with the given status
+func (r *formStore) GetFormsByStatus(ctx context.Context, status string) ([]*model.Form, error) {
+	forms, err := r.store.findForms(ctx, bson.D{{Key: "status", Value: status}})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get forms by status: %w", err)
+	}
+
+	return forms, nil
+}
+
+// CreateSubmission creates a new form submission
+func (r *formStore) CreateSubmission(ctx context.Context, submission *model.FormSubmission) error {
+	prepareSubmission(submission)
+
+	if _, err := r.store.submissions.InsertOne(ctx, newSubmissionDocument(submission)); err != nil {
+		r.store.logger.Error("failed to create form submission",
+			"submission_id", submission.ID, "form_id", submission.FormID, "error", err)
+
+		return fmt.Errorf("create submission: %w", common.NewDatabaseError("create", "form_submission", submission.ID, err))
+	}
+
+	return nil
+}
+
+// CreateSubmissions inserts submissions in a single write
+func (r *formStore) CreateSubmissions(ctx context.Context, submissions []*model.FormSubmission) error {
+	if len(submissions) == 0 {
+		return nil
+	}
+
+	documents := make([]any, len(submissions))
+	for i, submission := range submissions {
+		prepareSubmission(submission)
+		documents[i] = newSubmissionDocument(submission)
+	}
+
+	if _, err := r.store.submissions.InsertMany(ctx, documents); err != nil {
+		r.store.logger.Error("failed to create form submissions", "count", len(submissions), "error", err)
+
+		return fmt.Errorf("create submissions: %w", common.NewDatabaseError("create", "form_submission", "", err))
+	}
+
+	return nil
+}
+
+// GetSubmissionByID retrieves a form submission by ID
+func (r *formStore) GetSubmissionByID(ctx context.Context, submissionID string) (*model.FormSubmission, error) {
+	submission, err := r.store.getSubmission(ctx, submissionID)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, fmt.Errorf("get submission by ID: %w",
+				common.NewNotFoundError("get", "form_submission", submissionID))
+		}
+
+		return nil, fmt.Errorf("get submission by ID: %w",
+			common.NewDatabaseError("get", "form_submission", submissionID, err))
+	}
+
+	return submission, nil
+}
+
+// ListSubmissions retrieves all submissions for a form
+func (r *formStore) ListSubmissions(ctx context.Context, formID string) ([]*model.FormSubmission, error) {
+	submissions, err := r.store.findSubmissions(ctx, bson.D{{Key: "form_id", Value: formID}},
+		options.Find().SetSort(bson.D{{Key: "created_at", Value: 1}}))
+	if err != nil {
+		r.store.logger.Error("failed to list form submissions", "form_id", formID, "error", err)
+
+		return nil, fmt.Errorf("list form submissions: %w", common.NewDatabaseError("list", "form_submission", formID, err))
+	}
+
+	return submissions, nil
+}
+
+// ListSubmissionsBetween retrieves a form's submissions received after since and
+// at or before until, oldest first
+func (r *formStore) ListSubmissionsBetween(
+	ctx context.Context,
+	formID string,
+	since, until time.Time,
+) ([]*model.FormSubmission, error) {
+	submissions, err := r.store.findSubmissions(ctx, bson.D{
+		{Key: "form_id", Value: formID},
+		{Key: "submitted_at", Value: bson.D{{Key: "$gt", Value: since}, {Key: "$lte", Value: until}}},
+	}, options.Find().SetSort(bson.D{{Key: "submitted_at", Value: 1}}))
+	if err != nil {
+		return nil, fmt.Errorf("list form submissions between: %w",
+			common.NewDatabaseError("list", "form_submission", formID, err))
+	}
+
+	return submissions, nil
+}
+
+// DeleteTestSubmissionsBefore deletes the test submissions received before the given time
+func (r *formStore) DeleteTestSubmissionsBefore(ctx context.Context, before time.Time) (int64, error) {
+	result, err := r.store.submissions.DeleteMany(ctx, bson.D{
+		{Key: "test", Value: true},
+		{Key: "submitted_at", Value: bson.D{{Key: "$lt", Value: before}}},
+	})
+	if err != nil {
+		return 0, fmt.Errorf("delete test submissions: %w",
+			common.NewDatabaseError("delete", "form_submission", "", err))
+	}
+
+	return result.DeletedCount, nil
+}
+
+// UpdateSubmission updates the non-zero fields of a form submission
+func (r *formStore) UpdateSubmission(ctx context.Context, submission *model.FormSubmission) error {
+	submission.UpdatedAt = time.Now()
+
+	result, err := r.store.submissions.UpdateOne(ctx, bson.D{{Key: "_id", Value: submission.ID}},
+		bson.D{{Key: "$set", Value: nonZeroFields(newSubmissionDocument(submission))}})
+	if err != nil {
+		r.store.logger.Error("failed to update form submission", "submission_id", submission.ID, "error", err)
+
+		return fmt.Errorf("update submission: %w",
+			common.NewDatabaseError("update", "form_submission", submission.ID, err))
+	}
+
+	if result.MatchedCount == 0 {
+		return fmt.Errorf("update submission: %w", common.NewNotFoundError("update", "form_submission", submission.ID))
+	}
+
+	return nil
+}
+
+// DeleteSubmission deletes a form submission
+func (r *formStore) DeleteSubmission(ctx context.Context, submissionID string) error {
+	result, err := r.store.submissions.DeleteOne(ctx, bson.D{{Key: "_id", Value: submissionID}})
+	if err != nil {
+		r.store.logger.Error("failed to delete form submission", "submission_id", submissionID, "error", err)
+
+		return fmt.Errorf("delete submission: %w",
+			common.NewDatabaseError("delete", "form_submission", submissionID, err))
+	}
+
+	if result.DeletedCount == 0 {
+		return fmt.Errorf("delete submission: %w", common.NewNotFoundError("delete", "form_submission", submissionID))
+	}
+
+	return nil
+}
+
+// GetByFormID retrieves all submissions for a form
+func (r *formStore) GetByFormID(ctx context.Context, formID string) ([]*model.FormSubmission, error) {
+	return r.ListSubmissions(ctx, formID)
+}
+
+// GetByFormIDPaginated retrieves paginated submissions for a form
+func (r *formStore) GetByFormIDPaginated(
+	ctx context.Context,
+	formID string,
+	params common.PaginationParams,
+) (*common.PaginationResult, error) {
+	filter := bson.D{{Key: "form_id", Value: formID}}
+
+	total, err := r.store.submissions.CountDocuments(ctx, filter)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count submissions: %w", err)
+	}
+
+	submissions, err := r.store.findSubmissions(ctx, filter, pageOptions(params.GetOffset(), params.GetLimit()))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get submissions: %w", err)
+	}
+
+	result := common.NewPaginationResult(submissions, int(total), params.Page, params.PageSize)
+
+	return &result, nil
+}
+
+// GetByFormAndUser retrieves a submission by form ID and user ID. Submissions are
+// not linked to users, so none is ever found, as with the database store.
+func (r *formStore) GetByFormAndUser(_ context.Context, formID, _ string) (*model.FormSubmission, error) {
+	return nil, fmt.Errorf("failed to get submission: %w", common.NewNotFoundError("get", "form_submission", formID))
+}
+
+// GetSubmissionsByStatus retrieves submissions by status
+func (r *formStore) GetSubmissionsByStatus(
+	ctx context.Context,
+	status model.SubmissionStatus,
+) ([]*model.FormSubmission, error) {
+	submissions, err := r.store.findSubmissions(ctx, bson.D{{Key: "status", Value: status}})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get submissions: %w", err)
+	}
+
+	return submissions, nil
+}
+
+// prepareSubmission fills the ID and timestamps GORM or the database would set on insert
+func prepareSubmission(submission *model.FormSubmission) {
+	if submission.ID == "" {
+		submission.ID = uuid.New().String()
+	}
+
+	stamp(&submission.CreatedAt, &submission.UpdatedAt)
+}
+
+// stamp fills unset creation and update times
+func stamp(createdAt, updatedAt *time.Time) {
+	now := time.Now()
+
+	if createdAt.IsZero() {
+		*createdAt = now
+	}
+
+	if updatedAt.IsZero() {
+		*updatedAt = now
+	}
+}
+
+// pageOptions selects the window of documents at offset; a negative limit means all
+func pageOptions(offset, limit int) *options.FindOptions {
+	opts := options.Find().SetSort(bson.D{{Key: "created_at", Value: 1}}).SetSkip(int64(max(offset, 0)))
+	if limit >= 0 {
+		opts.SetLimit(int64(limit))
+	}
+
+	return opts
+}
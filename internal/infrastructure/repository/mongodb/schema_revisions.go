@@ -0,0 +1,106 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"github.com/goformx/goforms/internal/domain/form/model"
+	"github.com/goformx/goforms/internal/infrastructure/repository/common"
+)
+
+// CreateSchemaRevision stores a schema revision
+func (r *formStore) CreateSchemaRevision(ctx context.Context, revision *model.SchemaRevision) error {
+	if err := revision.BeforeCreate(nil); err != nil {
+		return fmt.Errorf("create schema revision: %w", common.NewDatabaseError("create", "schema_revision", revision.FormID, err))
+	}
+
+	if revision.CreatedAt.IsZero() {
+		revision.CreatedAt = time.Now()
+	}
+
+	if _, err := r.store.revisions.InsertOne(ctx, newRevisionDocument(revision)); err != nil {
+		return fmt.Errorf("create schema revision: %w", common.NewDatabaseError("create", "schema_revision", revision.FormID, err))
+	}
+
+	return nil
+}
+
+// LatestSchemaRevision returns the revision on top of a form's stack
+func (r *formStore) LatestSchemaRevision(
+	ctx context.Context,
+	formID string,
+	stack model.SchemaStack,
+) (*model.SchemaRevision, error) {
+	var document revisionDocument
+
+	err := r.store.revisions.FindOne(ctx, stackFilter(formID, stack),
+		options.FindOne().SetSort(bson.D{{Key: "seq", Value: -1}})).Decode(&document)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return nil, fmt.Errorf("get schema revision: %w", common.NewNotFoundError("get", "schema_revision", formID))
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("get schema revision: %w", common.NewDatabaseError("get", "schema_revision", formID, err))
+	}
+
+	return document.model(), nil
+}
+
+// DeleteSchemaRevision deletes a revision, reporting whether this call removed it
+func (r *formStore) DeleteSchemaRevision(ctx context.Context, id string) (bool, error) {
+	result, err := r.store.revisions.DeleteOne(ctx, bson.D{{Key: "_id", Value: id}})
+	if err != nil {
+		return false, fmt.Errorf("delete schema revision: %w", common.NewDatabaseError("delete", "schema_revision", id, err))
+	}
+
+	return result.DeletedCount > 0, nil
+}
+
+// TrimSchemaRevisions deletes all but the keep newest revisions of a form's stack
+func (r *formStore) TrimSchemaRevisions(ctx context.Context, formID string, stack model.SchemaStack, keep int) error {
+	filter := stackFilter(formID, stack)
+
+	if keep > 0 {
+		// The newest revision past the limit, and all older ones, are dropped
+		var cutoff revisionDocument
+
+		err := r.store.revisions.FindOne(ctx, filter,
+			options.FindOne().SetSort(bson.D{{Key: "seq", Value: -1}}).SetSkip(int64(keep))).Decode(&cutoff)
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil
+		}
+
+		if err != nil {
+			return fmt.Errorf("trim schema revisions: %w", common.NewDatabaseError("trim", "schema_revision", formID, err))
+		}
+
+		filter = append(filter, bson.E{Key: "seq", Value: bson.D{{Key: "$lte", Value: cutoff.Seq}}})
+	}
+
+	if _, err := r.store.revisions.DeleteMany(ctx, filter); err != nil {
+		return fmt.Errorf("trim schema revisions: %w", common.NewDatabaseError("trim", "schema_revision", formID, err))
+	}
+
+	return nil
+}
+
+// CountSchemaRevisions counts the revisions of a form's stack
+func (r *formStore) CountSchemaRevisions(ctx context.Context, formID string, stack model.SchemaStack) (int, error) {
+	count, err := r.store.revisions.CountDocuments(ctx, stackFilter(formID, stack))
+	if err != nil {
+		return 0, fmt.Errorf("count schema revisions: %w", common.NewDatabaseError("count", "schema_revision", formID, err))
+	}
+
+	return int(count), nil
+}
+
+// stackFilter matches the revisions of a form's stack
+func stackFilter(formID string, stack model.SchemaStack) bson.D {
+	return bson.D{{Key: "form_id", Value: formID}, {Key: "stack", Value: stack}}
+}
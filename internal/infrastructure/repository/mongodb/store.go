@@ -0,0 +1,175 @@
+// Package repository provides the MongoDB form and submission repository implementation,
+// used when database.driver is mongodb. Forms, submissions and schema revisions are
+// stored as documents; the other repositories are kept in memory.
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"github.com/goformx/goforms/internal/domain/archive"
+	"github.com/goformx/goforms/internal/domain/backup"
+	"github.com/goformx/goforms/internal/domain/form"
+	"github.com/goformx/goforms/internal/domain/form/model"
+	"github.com/goformx/goforms/internal/infrastructure/logging"
+)
+
+// Collection names, matching the table names of the SQL stores
+const (
+	formsCollection       = "forms"
+	submissionsCollection = "form_submissions"
+	revisionsCollection   = "form_schema_revisions"
+)
+
+// Store holds the MongoDB client and collections shared by the repositories
+type Store struct {
+	client      *mongo.Client
+	forms       *mongo.Collection
+	submissions *mongo.Collection
+	revisions   *mongo.Collection
+	logger      logging.Logger
+}
+
+// NewStore creates a store on the named database. The client connects in the
+// background; Open checks the connection.
+func NewStore(uri, database string, logger logging.Logger) (*Store, error) {
+	client, err := mongo.Connect(context.Background(), options.Client().ApplyURI(uri))
+	if err != nil {
+		return nil, fmt.Errorf("connect to mongodb: %w", err)
+	}
+
+	db := client.Database(database)
+
+	return &Store{
+		client:      client,
+		forms:       db.Collection(formsCollection),
+		submissions: db.Collection(submissionsCollection),
+		revisions:   db.Collection(revisionsCollection),
+		logger:      logger,
+	}, nil
+}
+
+// Open checks the connection and creates the indexes the repositories query by.
+// Creating an index that exists is a no-op.
+func (s *Store) Open(ctx context.Context) error {
+	if err := s.client.Ping(ctx, nil); err != nil {
+		return fmt.Errorf("ping mongodb: %w", err)
+	}
+
+	indexes := map[*mongo.Collection][]mongo.IndexModel{
+		s.forms: {
+			{Keys: bson.D{{Key: "user_id", Value: 1}, {Key: "created_at", Value: -1}}},
+			{Keys: bson.D{{Key: "status", Value: 1}}},
+		},
+		s.submissions: {
+			{Keys: bson.D{{Key: "form_id", Value: 1}, {Key: "created_at", Value: 1}}},
+			{Keys: bson.D{{Key: "form_id", Value: 1}, {Key: "submitted_at", Value: 1}}},
+			{Keys: bson.D{{Key: "submitted_at", Value: 1}}},
+			{Keys: bson.D{{Key: "created_at", Value: 1}}},
+		},
+		s.revisions: {
+			{Keys: bson.D{{Key: "form_id", Value: 1}, {Key: "stack", Value: 1}, {Key: "seq", Value: -1}}},
+		},
+	}
+
+	for collection, models := range indexes {
+		if _, err := collection.Indexes().CreateMany(ctx, models); err != nil {
+			return fmt.Errorf("create %s indexes: %w", collection.Name(), err)
+		}
+	}
+
+	s.logger.Info("mongodb store ready", "database", s.forms.Database().Name())
+
+	return nil
+}
+
+// Close disconnects the client
+func (s *Store) Close(ctx context.Context) error {
+	if err := s.client.Disconnect(ctx); err != nil && !errors.Is(err, mongo.ErrClientDisconnected) {
+		return fmt.Errorf("disconnect from mongodb: %w", err)
+	}
+
+	return nil
+}
+
+// Forms returns the form repository. It also implements form.SubmissionBatchRepository,
+// form.SubmissionRangeRepository, form.TestSubmissionRepository and form.SchemaRevisionRepository,
+// but not form.UniqueSubmissionRepository.
+func (s *Store) Forms() form.Repository { return &formStore{store: s} }
+
+// Submissions returns the submission repository
+func (s *Store) Submissions() form.SubmissionRepository { return &submissionStore{store: s} }
+
+// Usage returns the per-user usage repository
+func (s *Store) Usage() form.UsageRepository { return &usageStore{store: s} }
+
+// Backups returns the backup repository
+func (s *Store) Backups() backup.Repository { return &backupStore{store: s} }
+
+// Archive returns the submission archive repository
+func (s *Store) Archive() archive.Repository { return &archiveStore{store: s} }
+
+// findSubmissions returns the submissions matching filter
+func (s *Store) findSubmissions(ctx context.Context, filter any, opts ...*options.FindOptions) ([]*model.FormSubmission, error) {
+	cursor, err := s.submissions.Find(ctx, filter, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	var documents []*submissionDocument
+	if err = cursor.All(ctx, &documents); err != nil {
+		return nil, err
+	}
+
+	submissions := make([]*model.FormSubmission, len(documents))
+	for i, document := range documents {
+		submissions[i] = document.model()
+	}
+
+	return submissions, nil
+}
+
+// findForms returns the forms matching filter
+func (s *Store) findForms(ctx context.Context, filter any, opts ...*options.FindOptions) ([]*model.Form, error) {
+	cursor, err := s.forms.Find(ctx, filter, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	var documents []*formDocument
+	if err = cursor.All(ctx, &documents); err != nil {
+		return nil, err
+	}
+
+	forms := make([]*model.Form, len(documents))
+	for i, document := range documents {
+		forms[i] = document.model()
+	}
+
+	return forms, nil
+}
+
+// userFormIDs returns the IDs of a user's forms
+func (s *Store) userFormIDs(ctx context.Context, userID string) ([]any, error) {
+	ids, err := s.forms.Distinct(ctx, "_id", bson.D{{Key: "user_id", Value: userID}})
+	if err != nil {
+		return nil, err
+	}
+
+	return ids, nil
+}
+
+// getSubmission returns a submission, or mongo.ErrNoDocuments
+func (s *Store) getSubmission(ctx context.Context, id string) (*model.FormSubmission, error) {
+	var document submissionDocument
+	if err := s.submissions.FindOne(ctx, bson.D{{Key: "_id", Value: id}}).Decode(&document); err != nil {
+		return nil, err
+	}
+
+	return document.model(), nil
+}
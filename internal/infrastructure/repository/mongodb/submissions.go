@@ -0,0 +1,201 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"github.com/goformx/goforms/internal/domain/form/model"
+	"github.com/goformx/goforms/internal/infrastructure/repository/common"
+)
+
+// submissionStore implements form.SubmissionRepository in MongoDB
+type submissionStore struct {
+	store *Store
+}
+
+// Create creates a new form submission
+func (r *submissionStore) Create(ctx context.Context, submission *model.FormSubmission) error {
+	prepareSubmission(submission)
+
+	if _, err := r.store.submissions.InsertOne(ctx, newSubmissionDocument(submission)); err != nil {
+		return fmt.Errorf("failed to create form submission: %w", err)
+	}
+
+	return nil
+}
+
+// GetByID retrieves a form submission by ID
+func (r *submissionStore) GetByID(ctx context.Context, id string) (*model.FormSubmission, error) {
+	submission, err := r.store.getSubmission(ctx, id)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return nil, fmt.Errorf("form submission not found: %s", id)
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to get form submission: %w", err)
+	}
+
+	return submission, nil
+}
+
+// GetByFormID retrieves all submissions for a form
+func (r *submissionStore) GetByFormID(ctx context.Context, formID string) ([]*model.FormSubmission, error) {
+	submissions, err := r.store.findSubmissions(ctx, bson.D{{Key: "form_id", Value: formID}},
+		options.Find().SetSort(bson.D{{Key: "created_at", Value: 1}}))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get form submissions: %w", err)
+	}
+
+	return submissions, nil
+}
+
+// Update saves a form submission, inserting it when it does not exist
+func (r *submissionStore) Update(ctx context.Context, submission *model.FormSubmission) error {
+	prepareSubmission(submission)
+	submission.UpdatedAt = time.Now()
+
+	if _, err := r.store.submissions.ReplaceOne(ctx, bson.D{{Key: "_id", Value: submission.ID}},
+		newSubmissionDocument(submission), options.Replace().SetUpsert(true)); err != nil {
+		return fmt.Errorf("failed to update form submission: %w", err)
+	}
+
+	return nil
+}
+
+// Delete deletes a form submission by ID
+func (r *submissionStore) Delete(ctx context.Context, id string) error {
+	if _, err := r.store.submissions.DeleteOne(ctx, bson.D{{Key: "_id", Value: id}}); err != nil {
+		return fmt.Errorf("failed to delete form submission: %w", err)
+	}
+
+	return nil
+}
+
+// List retrieves a window of form submissions
+func (r *submissionStore) List(ctx context.Context, offset, limit int) ([]*model.FormSubmission, error) {
+	submissions, err := r.store.findSubmissions(ctx, bson.D{}, pageOptions(offset, limit))
+	if err != nil {
+		return nil, fmt.Errorf("failed to list form submissions: %w", err)
+	}
+
+	return submissions, nil
+}
+
+// GetByFormIDPaginated retrieves form submissions by form ID with pagination
+func (r *submissionStore) GetByFormIDPaginated(
+	ctx context.Context,
+	formID string,
+	params common.PaginationParams,
+) (*common.PaginationResult, error) {
+	return r.paginate(ctx, bson.D{{Key: "form_id", Value: formID}}, params)
+}
+
+// GetByFormAndUser retrieves a submission by form ID and user ID. Submissions are
+// not linked to users, so none is ever found, as with the database store.
+func (r *submissionStore) GetByFormAndUser(_ context.Context, formID, userID string) (*model.FormSubmission, error) {
+	return nil, fmt.Errorf("no submissions found for form %s and user %s", formID, userID)
+}
+
+// GetSubmissionsByStatus retrieves form submissions by status with pagination
+func (r *submissionStore) GetSubmissionsByStatus(
+	ctx context.Context,
+	status model.SubmissionStatus,
+	params common.PaginationParams,
+) (*common.PaginationResult, error) {
+	return r.paginate(ctx, bson.D{{Key: "status", Value: status}}, params)
+}
+
+// CreateSubmission creates a new form submission
+func (r *submissionStore) CreateSubmission(ctx context.Context, submission *model.FormSubmission) error {
+	return r.Create(ctx, submission)
+}
+
+// UpdateSubmission updates an existing form submission
+func (r *submissionStore) UpdateSubmission(ctx context.Context, submission *model.FormSubmission) error {
+	return r.Update(ctx, submission)
+}
+
+// DeleteSubmission deletes a form submission
+func (r *submissionStore) DeleteSubmission(ctx context.Context, id string) error {
+	return r.Delete(ctx, id)
+}
+
+// Count returns the total number of form submissions
+func (r *submissionStore) Count(ctx context.Context) (int, error) {
+	count, err := r.store.submissions.CountDocuments(ctx, bson.D{})
+	if err != nil {
+		return 0, fmt.Errorf("failed to count form submissions: %w", err)
+	}
+
+	return int(count), nil
+}
+
+// Search finds submissions whose data or status contains query, ignoring case.
+// Documents cannot be matched as text in a query, so the submissions are
+// scanned, oldest first.
+func (r *submissionStore) Search(ctx context.Context, query string, offset, limit int) ([]*model.FormSubmission, error) {
+	query = strings.ToLower(query)
+
+	cursor, err := r.store.submissions.Find(ctx, bson.D{}, options.Find().SetSort(bson.D{{Key: "created_at", Value: 1}}))
+	if err != nil {
+		return nil, fmt.Errorf("failed to search form submissions: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	matches := make([]*model.FormSubmission, 0)
+
+	for (limit < 0 || len(matches) < offset+limit) && cursor.Next(ctx) {
+		var document submissionDocument
+		if err = cursor.Decode(&document); err != nil {
+			return nil, fmt.Errorf("failed to search form submissions: %w", err)
+		}
+
+		submission := document.model()
+		data, marshalErr := json.Marshal(submission.Data)
+
+		if (marshalErr == nil && strings.Contains(strings.ToLower(string(data)), query)) ||
+			strings.Contains(strings.ToLower(string(submission.Status)), query) {
+			matches = append(matches, submission)
+		}
+	}
+
+	if err = cursor.Err(); err != nil {
+		return nil, fmt.Errorf("failed to search form submissions: %w", err)
+	}
+
+	return matches[min(max(offset, 0), len(matches)):], nil
+}
+
+// paginate builds a pagination result the way the database submission store does
+func (r *submissionStore) paginate(
+	ctx context.Context,
+	filter bson.D,
+	params common.PaginationParams,
+) (*common.PaginationResult, error) {
+	total, err := r.store.submissions.CountDocuments(ctx, filter)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count form submissions: %w", err)
+	}
+
+	submissions, err := r.store.findSubmissions(ctx, filter, pageOptions(params.GetOffset(), params.GetLimit()))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get form submissions: %w", err)
+	}
+
+	items := make([]any, len(submissions))
+	for i, submission := range submissions {
+		items[i] = submission
+	}
+
+	result := common.NewPaginationResult(items, int(total), params.Page, params.PageSize)
+
+	return &result, nil
+}
@@ -0,0 +1,78 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+
+	"github.com/goformx/goforms/internal/infrastructure/repository/common"
+)
+
+// usageStore implements form.UsageRepository in MongoDB
+type usageStore struct {
+	store *Store
+}
+
+// CountFormsByUser counts the forms owned by a user
+func (r *usageStore) CountFormsByUser(ctx context.Context, userID string) (int64, error) {
+	count, err := r.store.forms.CountDocuments(ctx, bson.D{{Key: "user_id", Value: userID}})
+	if err != nil {
+		return 0, fmt.Errorf("count forms: %w", common.NewDatabaseError("count", "form", userID, err))
+	}
+
+	return count, nil
+}
+
+// CountSubmissionsByUserSince counts submissions to a user's forms received at or
+// after since, test submissions aside
+func (r *usageStore) CountSubmissionsByUserSince(ctx context.Context, userID string, since time.Time) (int64, error) {
+	formIDs, err := r.store.userFormIDs(ctx, userID)
+	if err != nil {
+		return 0, fmt.Errorf("count submissions: %w", common.NewDatabaseError("count", "submission", userID, err))
+	}
+
+	count, err := r.store.submissions.CountDocuments(ctx, bson.D{
+		{Key: "form_id", Value: bson.D{{Key: "$in", Value: formIDs}}},
+		{Key: "submitted_at", Value: bson.D{{Key: "$gte", Value: since}}},
+		{Key: "test", Value: false},
+	})
+	if err != nil {
+		return 0, fmt.Errorf("count submissions: %w", common.NewDatabaseError("count", "submission", userID, err))
+	}
+
+	return count, nil
+}
+
+// SubmissionStorageByUser sums the BSON size of submission data for a user's forms
+func (r *usageStore) SubmissionStorageByUser(ctx context.Context, userID string) (int64, error) {
+	formIDs, err := r.store.userFormIDs(ctx, userID)
+	if err != nil {
+		return 0, fmt.Errorf("measure storage: %w", common.NewDatabaseError("sum", "submission", userID, err))
+	}
+
+	cursor, err := r.store.submissions.Aggregate(ctx, bson.A{
+		bson.D{{Key: "$match", Value: bson.D{{Key: "form_id", Value: bson.D{{Key: "$in", Value: formIDs}}}}}},
+		bson.D{{Key: "$group", Value: bson.D{
+			{Key: "_id", Value: nil},
+			{Key: "total", Value: bson.D{{Key: "$sum", Value: bson.D{{Key: "$bsonSize", Value: "$data"}}}}},
+		}}},
+	})
+	if err != nil {
+		return 0, fmt.Errorf("measure storage: %w", common.NewDatabaseError("sum", "submission", userID, err))
+	}
+
+	var totals []struct {
+		Total int64 `bson:"total"`
+	}
+	if err = cursor.All(ctx, &totals); err != nil {
+		return 0, fmt.Errorf("measure storage: %w", common.NewDatabaseError("sum", "submission", userID, err))
+	}
+
+	if len(totals) == 0 {
+		return 0, nil
+	}
+
+	return totals[0].Total, nil
+}
@@ -0,0 +1,74 @@
+// Package repository provides the security event repository implementation
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"gorm.io/gorm"
+
+	"github.com/goformx/goforms/internal/domain/securityevent"
+	"github.com/goformx/goforms/internal/infrastructure/database"
+	"github.com/goformx/goforms/internal/infrastructure/logging"
+	"github.com/goformx/goforms/internal/infrastructure/repository/common"
+	"github.com/goformx/goforms/internal/infrastructure/repository/tables"
+)
+
+// Store implements securityevent.Repository
+type Store struct {
+	db     database.DB
+	logger logging.Logger
+}
+
+// NewStore creates a new security event store
+func NewStore(db database.DB, logger logging.Logger) securityevent.Repository {
+	return &Store{
+		db:     db,
+		logger: logger,
+	}
+}
+
+// CreateEvent stores a security event
+func (s *Store) CreateEvent(ctx context.Context, event *securityevent.Event) error {
+	if err := s.db.GetDB().WithContext(ctx).Create(event).Error; err != nil {
+		return fmt.Errorf("create security event: %w", common.NewDatabaseError("create", "security_event", "", err))
+	}
+
+	return nil
+}
+
+// ListEvents lists matching events, newest first, with the total count
+func (s *Store) ListEvents(
+	ctx context.Context, filter securityevent.Filter, offset, limit int,
+) ([]*securityevent.Event, int64, error) {
+	var total int64
+	if err := s.filtered(ctx, filter).Model(&securityevent.Event{}).Count(&total).Error; err != nil {
+		return nil, 0, fmt.Errorf("count security events: %w", common.NewDatabaseError("list", "security_event", "", err))
+	}
+
+	var events []*securityevent.Event
+	if err := s.filtered(ctx, filter).
+		Order(tables.SecurityEvents.CreatedAt.Desc()).
+		Offset(offset).
+		Limit(limit).
+		Find(&events).Error; err != nil {
+		return nil, 0, fmt.Errorf("list security events: %w", common.NewDatabaseError("list", "security_event", "", err))
+	}
+
+	return events, total, nil
+}
+
+// filtered starts a query restricted to the events matching filter
+func (s *Store) filtered(ctx context.Context, filter securityevent.Filter) *gorm.DB {
+	query := s.db.GetDB().WithContext(ctx)
+
+	if filter.Kind != "" {
+		query = query.Where(tables.SecurityEvents.Kind.Eq(filter.Kind))
+	}
+
+	if filter.IPAddress != "" {
+		query = query.Where(tables.SecurityEvents.IPAddress.Eq(filter.IPAddress))
+	}
+
+	return query
+}
@@ -0,0 +1,394 @@
+// Package tables declares the columns the repository stores query, typed by
+// the values they hold. Stores build their conditions, orders, selects and
+// updates from these columns rather than from SQL strings, so a misspelt
+// column, or a value of the wrong type for one, does not compile. The tests
+// check every column against the schema GORM derives from its model.
+//
+// A column is added here when a store first queries it, not for every field
+// of a model.
+package tables
+
+import (
+	"time"
+
+	"github.com/goformx/goforms/internal/domain/dataset"
+	"github.com/goformx/goforms/internal/domain/emaildelivery"
+	"github.com/goformx/goforms/internal/domain/emailtemplate"
+	"github.com/goformx/goforms/internal/domain/form/model"
+	"github.com/goformx/goforms/internal/infrastructure/repository/common"
+)
+
+type users struct {
+	Table               string
+	UUID                common.Column[string]
+	Email               common.Column[string]
+	FirstName           common.Column[string]
+	LastName            common.Column[string]
+	Role                common.Column[string]
+	Active              common.Column[bool]
+	LastActiveAt        common.Column[time.Time]
+	DeletionScheduledAt common.Column[time.Time]
+	HealthEmail         common.Column[bool]
+	HealthEmailSentAt   common.Column[time.Time]
+	CreatedAt           common.Column[time.Time]
+}
+
+type forms struct {
+	Table     string
+	UUID      common.Column[string]
+	UserID    common.Column[string]
+	Status    common.Column[string]
+	Version   common.Column[int]
+	CreatedAt common.Column[time.Time]
+	DeletedAt common.Column[time.Time]
+}
+
+type submissions struct {
+	Table       string
+	UUID        common.Column[string]
+	FormID      common.Column[string]
+	Data        common.Column[model.JSON]
+	Metadata    common.Column[model.JSON]
+	Status      common.Column[model.SubmissionStatus]
+	Test        common.Column[bool]
+	SubmittedAt common.Column[time.Time]
+	CreatedAt   common.Column[time.Time]
+}
+
+type uniqueValues struct {
+	Table        string
+	SubmissionID common.Column[string]
+}
+
+type activities struct {
+	Table        string
+	SubmissionID common.Column[string]
+	CreatedAt    common.Column[time.Time]
+}
+
+type reportSchedules struct {
+	Table     string
+	UUID      common.Column[string]
+	FormID    common.Column[string]
+	Active    common.Column[bool]
+	NextRunAt common.Column[time.Time]
+	CreatedAt common.Column[time.Time]
+}
+
+type schemaRevisions struct {
+	Table  string
+	UUID   common.Column[string]
+	FormID common.Column[string]
+	Stack  common.Column[model.SchemaStack]
+	Seq    common.Column[int64]
+}
+
+type submissionViews struct {
+	Table     string
+	UUID      common.Column[string]
+	FormID    common.Column[string]
+	CreatedAt common.Column[time.Time]
+}
+
+type securityEvents struct {
+	Table     string
+	Kind      common.Column[string]
+	IPAddress common.Column[string]
+	CreatedAt common.Column[time.Time]
+}
+
+type usageDaily struct {
+	Table     string
+	Account   common.Column[string]
+	Metric    common.Column[string]
+	Day       common.Column[time.Time]
+	Count     common.Column[int64]
+	UpdatedAt common.Column[time.Time]
+}
+
+type domainEvents struct {
+	Table      string
+	UUID       common.Column[string]
+	Name       common.Column[string]
+	OccurredAt common.Column[time.Time]
+	CreatedAt  common.Column[time.Time]
+}
+
+type auditLogs struct {
+	Table     string
+	ActorID   common.Column[string]
+	Action    common.Column[string]
+	TargetID  common.Column[string]
+	CreatedAt common.Column[time.Time]
+}
+
+type emailTemplates struct {
+	Table   string
+	OwnerID common.Column[string]
+	Kind    common.Column[emailtemplate.Kind]
+}
+
+type emailDeliveryEvents struct {
+	Table  string
+	FormID common.Column[string]
+	Type   common.Column[emaildelivery.EventType]
+}
+
+type emailSuppressions struct {
+	Table     string
+	Address   common.Column[string]
+	Reason    common.Column[string]
+	Provider  common.Column[string]
+	Detail    common.Column[string]
+	FormID    common.Column[string]
+	UpdatedAt common.Column[time.Time]
+}
+
+type billingSubscriptions struct {
+	Table             string
+	UserID            common.Column[string]
+	CustomerID        common.Column[string]
+	SubscriptionID    common.Column[string]
+	PlanID            common.Column[string]
+	Status            common.Column[string]
+	CurrentPeriodEnd  common.Column[time.Time]
+	CancelAtPeriodEnd common.Column[bool]
+	EventAt           common.Column[time.Time]
+	UpdatedAt         common.Column[time.Time]
+}
+
+type accountEncryption struct {
+	Table     string
+	UserID    common.Column[string]
+	Enabled   common.Column[bool]
+	UpdatedAt common.Column[time.Time]
+}
+
+type featureFlags struct {
+	Table       string
+	Key         common.Column[string]
+	Description common.Column[string]
+	Enabled     common.Column[bool]
+	Percentage  common.Column[int]
+	Accounts    common.Column[[]string]
+	UpdatedAt   common.Column[time.Time]
+}
+
+type deadLetters struct {
+	Table      string
+	UUID       common.Column[string]
+	Kind       common.Column[string]
+	Status     common.Column[string]
+	ResolvedAt common.Column[time.Time]
+	CreatedAt  common.Column[time.Time]
+}
+
+type datasets struct {
+	Table     string
+	OwnerID   common.Column[string]
+	Name      common.Column[string]
+	Title     common.Column[string]
+	Kind      common.Column[dataset.Kind]
+	Columns   common.Column[[]string]
+	Rows      common.Column[[]dataset.Row]
+	UpdatedAt common.Column[time.Time]
+}
+
+//nolint:gochecknoglobals // Read-only table declarations
+var (
+	// Users is the users table
+	Users = users{
+		Table:               "users",
+		UUID:                common.NewColumn[string]("users", "uuid"),
+		Email:               common.NewColumn[string]("users", "email"),
+		FirstName:           common.NewColumn[string]("users", "first_name"),
+		LastName:            common.NewColumn[string]("users", "last_name"),
+		Role:                common.NewColumn[string]("users", "role"),
+		Active:              common.NewColumn[bool]("users", "active"),
+		LastActiveAt:        common.NewColumn[time.Time]("users", "last_active_at"),
+		DeletionScheduledAt: common.NewColumn[time.Time]("users", "deletion_scheduled_at"),
+		HealthEmail:         common.NewColumn[bool]("users", "health_email"),
+		HealthEmailSentAt:   common.NewColumn[time.Time]("users", "health_email_sent_at"),
+		CreatedAt:           common.NewColumn[time.Time]("users", "created_at"),
+	}
+
+	// Forms is the forms table
+	Forms = forms{
+		Table:     "forms",
+		UUID:      common.NewColumn[string]("forms", "uuid"),
+		UserID:    common.NewColumn[string]("forms", "user_id"),
+		Status:    common.NewColumn[string]("forms", "status"),
+		Version:   common.NewColumn[int]("forms", "version"),
+		CreatedAt: common.NewColumn[time.Time]("forms", "created_at"),
+		DeletedAt: common.NewColumn[time.Time]("forms", "deleted_at"),
+	}
+
+	// Submissions is the form_submissions table
+	Submissions = submissions{
+		Table:       "form_submissions",
+		UUID:        common.NewColumn[string]("form_submissions", "uuid"),
+		FormID:      common.NewColumn[string]("form_submissions", "form_id"),
+		Data:        common.NewColumn[model.JSON]("form_submissions", "data"),
+		Metadata:    common.NewColumn[model.JSON]("form_submissions", "metadata"),
+		Status:      common.NewColumn[model.SubmissionStatus]("form_submissions", "status"),
+		Test:        common.NewColumn[bool]("form_submissions", "test"),
+		SubmittedAt: common.NewColumn[time.Time]("form_submissions", "submitted_at"),
+		CreatedAt:   common.NewColumn[time.Time]("form_submissions", "created_at"),
+	}
+
+	// UniqueValues is the form_submission_unique_values table
+	UniqueValues = uniqueValues{
+		Table:        "form_submission_unique_values",
+		SubmissionID: common.NewColumn[string]("form_submission_unique_values", "submission_id"),
+	}
+
+	// Activities is the form_submission_activities table
+	Activities = activities{
+		Table:        "form_submission_activities",
+		SubmissionID: common.NewColumn[string]("form_submission_activities", "submission_id"),
+		CreatedAt:    common.NewColumn[time.Time]("form_submission_activities", "created_at"),
+	}
+
+	// ReportSchedules is the form_report_schedules table
+	ReportSchedules = reportSchedules{
+		Table:     "form_report_schedules",
+		UUID:      common.NewColumn[string]("form_report_schedules", "uuid"),
+		FormID:    common.NewColumn[string]("form_report_schedules", "form_id"),
+		Active:    common.NewColumn[bool]("form_report_schedules", "active"),
+		NextRunAt: common.NewColumn[time.Time]("form_report_schedules", "next_run_at"),
+		CreatedAt: common.NewColumn[time.Time]("form_report_schedules", "created_at"),
+	}
+
+	// SchemaRevisions is the form_schema_revisions table
+	SchemaRevisions = schemaRevisions{
+		Table:  "form_schema_revisions",
+		UUID:   common.NewColumn[string]("form_schema_revisions", "uuid"),
+		FormID: common.NewColumn[string]("form_schema_revisions", "form_id"),
+		Stack:  common.NewColumn[model.SchemaStack]("form_schema_revisions", "stack"),
+		Seq:    common.NewColumn[int64]("form_schema_revisions", "seq"),
+	}
+
+	// SubmissionViews is the form_submission_views table
+	SubmissionViews = submissionViews{
+		Table:     "form_submission_views",
+		UUID:      common.NewColumn[string]("form_submission_views", "uuid"),
+		FormID:    common.NewColumn[string]("form_submission_views", "form_id"),
+		CreatedAt: common.NewColumn[time.Time]("form_submission_views", "created_at"),
+	}
+
+	// SecurityEvents is the security_events table
+	SecurityEvents = securityEvents{
+		Table:     "security_events",
+		Kind:      common.NewColumn[string]("security_events", "kind"),
+		IPAddress: common.NewColumn[string]("security_events", "ip_address"),
+		CreatedAt: common.NewColumn[time.Time]("security_events", "created_at"),
+	}
+
+	// UsageDaily is the api_usage_daily table
+	UsageDaily = usageDaily{
+		Table:     "api_usage_daily",
+		Account:   common.NewColumn[string]("api_usage_daily", "account"),
+		Metric:    common.NewColumn[string]("api_usage_daily", "metric"),
+		Day:       common.NewColumn[time.Time]("api_usage_daily", "day"),
+		Count:     common.NewColumn[int64]("api_usage_daily", "count"),
+		UpdatedAt: common.NewColumn[time.Time]("api_usage_daily", "updated_at"),
+	}
+
+	// DomainEvents is the domain_events table
+	DomainEvents = domainEvents{
+		Table:      "domain_events",
+		UUID:       common.NewColumn[string]("domain_events", "uuid"),
+		Name:       common.NewColumn[string]("domain_events", "name"),
+		OccurredAt: common.NewColumn[time.Time]("domain_events", "occurred_at"),
+		CreatedAt:  common.NewColumn[time.Time]("domain_events", "created_at"),
+	}
+
+	// AuditLogs is the audit_logs table
+	AuditLogs = auditLogs{
+		Table:     "audit_logs",
+		ActorID:   common.NewColumn[string]("audit_logs", "actor_id"),
+		Action:    common.NewColumn[string]("audit_logs", "action"),
+		TargetID:  common.NewColumn[string]("audit_logs", "target_id"),
+		CreatedAt: common.NewColumn[time.Time]("audit_logs", "created_at"),
+	}
+
+	// EmailTemplates is the email_templates table
+	EmailTemplates = emailTemplates{
+		Table:   "email_templates",
+		OwnerID: common.NewColumn[string]("email_templates", "owner_id"),
+		Kind:    common.NewColumn[emailtemplate.Kind]("email_templates", "kind"),
+	}
+
+	// EmailDeliveryEvents is the email_delivery_events table
+	EmailDeliveryEvents = emailDeliveryEvents{
+		Table:  "email_delivery_events",
+		FormID: common.NewColumn[string]("email_delivery_events", "form_id"),
+		Type:   common.NewColumn[emaildelivery.EventType]("email_delivery_events", "type"),
+	}
+
+	// EmailSuppressions is the email_suppressions table
+	EmailSuppressions = emailSuppressions{
+		Table:     "email_suppressions",
+		Address:   common.NewColumn[string]("email_suppressions", "address"),
+		Reason:    common.NewColumn[string]("email_suppressions", "reason"),
+		Provider:  common.NewColumn[string]("email_suppressions", "provider"),
+		Detail:    common.NewColumn[string]("email_suppressions", "detail"),
+		FormID:    common.NewColumn[string]("email_suppressions", "form_id"),
+		UpdatedAt: common.NewColumn[time.Time]("email_suppressions", "updated_at"),
+	}
+
+	// BillingSubscriptions is the billing_subscriptions table
+	BillingSubscriptions = billingSubscriptions{
+		Table:             "billing_subscriptions",
+		UserID:            common.NewColumn[string]("billing_subscriptions", "user_id"),
+		CustomerID:        common.NewColumn[string]("billing_subscriptions", "customer_id"),
+		SubscriptionID:    common.NewColumn[string]("billing_subscriptions", "subscription_id"),
+		PlanID:            common.NewColumn[string]("billing_subscriptions", "plan_id"),
+		Status:            common.NewColumn[string]("billing_subscriptions", "status"),
+		CurrentPeriodEnd:  common.NewColumn[time.Time]("billing_subscriptions", "current_period_end"),
+		CancelAtPeriodEnd: common.NewColumn[bool]("billing_subscriptions", "cancel_at_period_end"),
+		EventAt:           common.NewColumn[time.Time]("billing_subscriptions", "event_at"),
+		UpdatedAt:         common.NewColumn[time.Time]("billing_subscriptions", "updated_at"),
+	}
+
+	// AccountEncryption is the account_encryption table
+	AccountEncryption = accountEncryption{
+		Table:     "account_encryption",
+		UserID:    common.NewColumn[string]("account_encryption", "user_id"),
+		Enabled:   common.NewColumn[bool]("account_encryption", "enabled"),
+		UpdatedAt: common.NewColumn[time.Time]("account_encryption", "updated_at"),
+	}
+
+	// FeatureFlags is the feature_flags table
+	FeatureFlags = featureFlags{
+		Table:       "feature_flags",
+		Key:         common.NewColumn[string]("feature_flags", "flag_key"),
+		Description: common.NewColumn[string]("feature_flags", "description"),
+		Enabled:     common.NewColumn[bool]("feature_flags", "enabled"),
+		Percentage:  common.NewColumn[int]("feature_flags", "percentage"),
+		Accounts:    common.NewColumn[[]string]("feature_flags", "accounts"),
+		UpdatedAt:   common.NewColumn[time.Time]("feature_flags", "updated_at"),
+	}
+
+	// DeadLetters is the dead_letters table
+	DeadLetters = deadLetters{
+		Table:      "dead_letters",
+		UUID:       common.NewColumn[string]("dead_letters", "uuid"),
+		Kind:       common.NewColumn[string]("dead_letters", "kind"),
+		Status:     common.NewColumn[string]("dead_letters", "status"),
+		ResolvedAt: common.NewColumn[time.Time]("dead_letters", "resolved_at"),
+		CreatedAt:  common.NewColumn[time.Time]("dead_letters", "created_at"),
+	}
+
+	// Datasets is the datasets table
+	Datasets = datasets{
+		Table:     "datasets",
+		OwnerID:   common.NewColumn[string]("datasets", "owner_id"),
+		Name:      common.NewColumn[string]("datasets", "name"),
+		Title:     common.NewColumn[string]("datasets", "title"),
+		Kind:      common.NewColumn[dataset.Kind]("datasets", "kind"),
+		Columns:   common.NewColumn[[]string]("datasets", "column_names"),
+		Rows:      common.NewColumn[[]dataset.Row]("datasets", "row_data"),
+		UpdatedAt: common.NewColumn[time.Time]("datasets", "updated_at"),
+	}
+)
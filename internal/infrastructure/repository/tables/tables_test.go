@@ -0,0 +1,99 @@
+package tables_test
+
+import (
+	"reflect"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+	"gorm.io/gorm/schema"
+
+	"github.com/goformx/goforms/internal/domain/audit"
+	"github.com/goformx/goforms/internal/domain/billing"
+	"github.com/goformx/goforms/internal/domain/dataset"
+	"github.com/goformx/goforms/internal/domain/deadletter"
+	"github.com/goformx/goforms/internal/domain/emaildelivery"
+	"github.com/goformx/goforms/internal/domain/emailtemplate"
+	"github.com/goformx/goforms/internal/domain/encryption"
+	"github.com/goformx/goforms/internal/domain/entities"
+	"github.com/goformx/goforms/internal/domain/eventlog"
+	"github.com/goformx/goforms/internal/domain/flags"
+	"github.com/goformx/goforms/internal/domain/form/model"
+	"github.com/goformx/goforms/internal/domain/metering"
+	"github.com/goformx/goforms/internal/domain/securityevent"
+	"github.com/goformx/goforms/internal/infrastructure/repository/common"
+	"github.com/goformx/goforms/internal/infrastructure/repository/tables"
+)
+
+// TestTablesMatchModels checks every declared column, and the type of its
+// values, against the schema GORM parses from the table's model. The rows the
+// form store keeps at rest are checked in that package.
+func TestTablesMatchModels(t *testing.T) {
+	cases := map[string]struct {
+		table any
+		model any
+	}{
+		"users":                 {tables.Users, &entities.User{}},
+		"forms":                 {tables.Forms, &model.Form{}},
+		"form_submissions":      {tables.Submissions, &model.FormSubmission{}},
+		"activities":            {tables.Activities, &model.SubmissionActivity{}},
+		"report_schedules":      {tables.ReportSchedules, &model.ReportSchedule{}},
+		"schema_revisions":      {tables.SchemaRevisions, &model.SchemaRevision{}},
+		"submission_views":      {tables.SubmissionViews, &model.SubmissionView{}},
+		"security_events":       {tables.SecurityEvents, &securityevent.Event{}},
+		"usage_daily":           {tables.UsageDaily, &metering.DailyCount{}},
+		"domain_events":         {tables.DomainEvents, &eventlog.Record{}},
+		"audit_logs":            {tables.AuditLogs, &audit.Entry{}},
+		"email_templates":       {tables.EmailTemplates, &emailtemplate.Template{}},
+		"email_delivery_events": {tables.EmailDeliveryEvents, &emaildelivery.Event{}},
+		"email_suppressions":    {tables.EmailSuppressions, &emaildelivery.Suppression{}},
+		"billing_subscriptions": {tables.BillingSubscriptions, &billing.Subscription{}},
+		"account_encryption":    {tables.AccountEncryption, &encryption.Setting{}},
+		"feature_flags":         {tables.FeatureFlags, &flags.Flag{}},
+		"dead_letters":          {tables.DeadLetters, &deadletter.Entry{}},
+		"datasets":              {tables.Datasets, &dataset.Dataset{}},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			parsed, err := schema.Parse(tc.model, &sync.Map{}, schema.NamingStrategy{})
+			require.NoError(t, err)
+
+			table := reflect.ValueOf(tc.table)
+			assert.Equal(t, parsed.Table, table.FieldByName("Table").String())
+
+			for i := range table.NumField() {
+				column, ok := table.Field(i).Interface().(common.Columned)
+				if !ok {
+					continue
+				}
+
+				columnName := column.Clause().Name
+				assert.Equal(t, parsed.Table, column.Clause().Table, "table of %s", columnName)
+				require.True(t, slices.Contains(parsed.DBNames, columnName), "%s is not a column of %s", columnName, parsed.Table)
+
+				// The value type is the parameter of Set; a nullable field holds a pointer to it
+				valueType := table.Field(i).MethodByName("Set").Type().In(0)
+				assert.Equal(t, valueType, storedType(parsed.LookUpField(columnName).FieldType), "type of %s", columnName)
+			}
+		})
+	}
+}
+
+// storedType is the type of the values a model field holds, without the
+// pointer or wrapper that makes it nullable
+func storedType(fieldType reflect.Type) reflect.Type {
+	if fieldType.Kind() == reflect.Pointer {
+		return fieldType.Elem()
+	}
+
+	if fieldType == reflect.TypeFor[gorm.DeletedAt]() {
+		return reflect.TypeFor[time.Time]()
+	}
+
+	return fieldType
+}
@@ -6,22 +6,30 @@ import (
 	"errors"
 	"fmt"
 	"strconv"
+	"time"
 
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 
 	"github.com/goformx/goforms/internal/domain/entities"
 	"github.com/goformx/goforms/internal/domain/user"
 	"github.com/goformx/goforms/internal/infrastructure/database"
 	"github.com/goformx/goforms/internal/infrastructure/logging"
 	"github.com/goformx/goforms/internal/infrastructure/repository/common"
+	"github.com/goformx/goforms/internal/infrastructure/repository/tables"
 )
 
-// Store implements user.Repository interface
+// Store implements user.Repository, user.DirectoryRepository and user.DeletionRepository
 type Store struct {
 	db     database.DB
 	logger logging.Logger
 }
 
+// searchColumns are the columns a user search looks in
+//
+//nolint:gochecknoglobals // Read-only column list
+var searchColumns = []clause.Column{tables.Users.Email.Clause(), tables.Users.FirstName.Clause(), tables.Users.LastName.Clause()}
+
 // NewStore creates a new user store
 func NewStore(db database.DB, logger logging.Logger) user.Repository {
 	return &Store{
@@ -46,7 +54,7 @@ func (s *Store) Create(ctx context.Context, u *entities.User) error {
 func (s *Store) GetByEmail(ctx context.Context, email string) (*entities.User, error) {
 	var u entities.User
 
-	result := s.db.GetDB().WithContext(ctx).Where("email = ?", email).First(&u)
+	result := s.db.GetDB().WithContext(ctx).Where(tables.Users.Email.Eq(email)).First(&u)
 	if result.Error != nil {
 		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
 			notFoundErr := common.NewNotFoundError("get_by_email", "user", email)
@@ -66,7 +74,7 @@ func (s *Store) GetByEmail(ctx context.Context, email string) (*entities.User, e
 func (s *Store) GetByID(ctx context.Context, id string) (*entities.User, error) {
 	var u entities.User
 
-	result := s.db.GetDB().WithContext(ctx).First(&u, "uuid = ?", id)
+	result := s.db.GetDB().WithContext(ctx).Where(tables.Users.UUID.Eq(id)).First(&u)
 	if result.Error != nil {
 		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
 			notFoundErr := common.NewNotFoundError("get_by_id", "user", id)
@@ -114,7 +122,7 @@ func (s *Store) Update(ctx context.Context, userModel *entities.User) error {
 
 // Delete removes a user by ID
 func (s *Store) Delete(ctx context.Context, id string) error {
-	result := s.db.GetDB().WithContext(ctx).Delete(&entities.User{}, "uuid = ?", id)
+	result := s.db.GetDB().WithContext(ctx).Where(tables.Users.UUID.Eq(id)).Delete(&entities.User{})
 	if result.Error != nil {
 		return fmt.Errorf("delete user: %w", common.NewDatabaseError("delete", "user", id, result.Error))
 	}
@@ -130,7 +138,7 @@ func (s *Store) Delete(ctx context.Context, id string) error {
 func (s *Store) List(ctx context.Context, offset, limit int) ([]*entities.User, error) {
 	var users []*entities.User
 
-	result := s.db.GetDB().WithContext(ctx).Order("uuid").Offset(offset).Limit(limit).Find(&users)
+	result := s.db.GetDB().WithContext(ctx).Order(tables.Users.UUID.Asc()).Offset(offset).Limit(limit).Find(&users)
 	if result.Error != nil {
 		return nil, fmt.Errorf("list users: %w", common.NewDatabaseError("list", "user", "", result.Error))
 	}
@@ -157,7 +165,7 @@ func (s *Store) ListPaginated(ctx context.Context, params common.PaginationParam
 
 	// Get paginated results
 	result := s.db.GetDB().WithContext(ctx).
-		Order("uuid").
+		Order(tables.Users.UUID.Asc()).
 		Offset(params.GetOffset()).
 		Limit(params.GetLimit()).
 		Find(&users)
@@ -187,22 +195,10 @@ func (s *Store) Count(ctx context.Context) (int, error) {
 	return int(count), nil
 }
 
-// GetByUsername retrieves a user by username
-func (s *Store) GetByUsername(ctx context.Context, username string) (*entities.User, error) {
-	var u entities.User
-
-	result := s.db.GetDB().WithContext(ctx).Where("username = ?", username).First(&u)
-	if result.Error != nil {
-		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
-			return nil, fmt.Errorf("get user by username: %w",
-				common.NewNotFoundError("get_by_username", "user", username))
-		}
-
-		return nil, fmt.Errorf("get user by username: %w",
-			common.NewDatabaseError("get_by_username", "user", username, result.Error))
-	}
-
-	return &u, nil
+// GetByUsername retrieves a user by username. Users have no username column, so
+// this never finds one.
+func (s *Store) GetByUsername(_ context.Context, username string) (*entities.User, error) {
+	return nil, fmt.Errorf("get user by username: %w", common.NewNotFoundError("get_by_username", "user", username))
 }
 
 // GetByRole retrieves users by role
@@ -210,8 +206,8 @@ func (s *Store) GetByRole(ctx context.Context, role string, offset, limit int) (
 	var users []*entities.User
 
 	result := s.db.GetDB().WithContext(ctx).
-		Where("role = ?", role).
-		Order("uuid").
+		Where(tables.Users.Role.Eq(role)).
+		Order(tables.Users.UUID.Asc()).
 		Offset(offset).
 		Limit(limit).
 		Find(&users)
@@ -227,8 +223,8 @@ func (s *Store) GetActiveUsers(ctx context.Context, offset, limit int) ([]*entit
 	var users []*entities.User
 
 	result := s.db.GetDB().WithContext(ctx).
-		Where("active = ?", true).
-		Order("uuid").
+		Where(tables.Users.Active.Eq(true)).
+		Order(tables.Users.UUID.Asc()).
 		Offset(offset).
 		Limit(limit).
 		Find(&users)
@@ -244,8 +240,8 @@ func (s *Store) GetInactiveUsers(ctx context.Context, offset, limit int) ([]*ent
 	var users []*entities.User
 
 	result := s.db.GetDB().WithContext(ctx).
-		Where("active = ?", false).
-		Order("uuid").
+		Where(tables.Users.Active.Eq(false)).
+		Order(tables.Users.UUID.Asc()).
 		Offset(offset).
 		Limit(limit).
 		Find(&users)
@@ -262,8 +258,8 @@ func (s *Store) Search(ctx context.Context, query string, offset, limit int) ([]
 	var users []*entities.User
 
 	result := s.db.GetDB().WithContext(ctx).
-		Where("name LIKE ? OR email LIKE ?", "%"+query+"%", "%"+query+"%").
-		Order("uuid").
+		Where(common.ContainsFold{Columns: searchColumns, Value: query}).
+		Order(tables.Users.UUID.Asc()).
 		Offset(offset).
 		Limit(limit).
 		Find(&users)
@@ -273,3 +269,121 @@ func (s *Store) Search(ctx context.Context, query string, offset, limit int) ([]
 
 	return users, nil
 }
+
+// SearchUsers lists users matching filter, newest first, with the total count
+func (s *Store) SearchUsers(ctx context.Context, filter user.Filter, offset, limit int) ([]*entities.User, int64, error) {
+	var total int64
+	if err := s.filtered(ctx, filter).Model(&entities.User{}).Count(&total).Error; err != nil {
+		return nil, 0, fmt.Errorf("count users: %w", common.NewDatabaseError("search", "user", filter.Query, err))
+	}
+
+	var users []*entities.User
+	if err := s.filtered(ctx, filter).
+		Order(tables.Users.CreatedAt.Desc()).
+		Order(tables.Users.UUID.Asc()).
+		Offset(offset).
+		Limit(limit).
+		Find(&users).Error; err != nil {
+		return nil, 0, fmt.Errorf("search users: %w", common.NewDatabaseError("search", "user", filter.Query, err))
+	}
+
+	return users, total, nil
+}
+
+// TouchLastActive sets a user's last activity without running hooks or changing updated_at
+func (s *Store) TouchLastActive(ctx context.Context, id string, at time.Time) error {
+	result := s.db.GetDB().WithContext(ctx).
+		Model(&entities.User{}).
+		Where(tables.Users.UUID.Eq(id)).
+		UpdateColumns(common.Values(tables.Users.LastActiveAt.Set(at)))
+	if result.Error != nil {
+		return fmt.Errorf("touch user activity: %w", common.NewDatabaseError("touch_last_active", "user", id, result.Error))
+	}
+
+	return nil
+}
+
+// ListDeletionsDue lists up to limit users whose deletion is scheduled at or before now, earliest first
+func (s *Store) ListDeletionsDue(ctx context.Context, now time.Time, limit int) ([]*entities.User, error) {
+	var users []*entities.User
+
+	if err := s.db.GetDB().WithContext(ctx).
+		Where(tables.Users.DeletionScheduledAt.Lte(now)).
+		Order(tables.Users.DeletionScheduledAt.Asc()).
+		Limit(limit).
+		Find(&users).Error; err != nil {
+		return nil, fmt.Errorf("list deletions due: %w", common.NewDatabaseError("list_deletions_due", "user", "", err))
+	}
+
+	return users, nil
+}
+
+// Purge removes a user's row for good, including a soft-deleted one. The
+// user's forms and their submissions are removed with it by the foreign keys.
+func (s *Store) Purge(ctx context.Context, id string) error {
+	result := s.db.GetDB().WithContext(ctx).Unscoped().Where(tables.Users.UUID.Eq(id)).Delete(&entities.User{})
+	if result.Error != nil {
+		return fmt.Errorf("purge user: %w", common.NewDatabaseError("purge", "user", id, result.Error))
+	}
+
+	if result.RowsAffected == 0 {
+		return fmt.Errorf("purge user: %w", common.NewNotFoundError("purge", "user", id))
+	}
+
+	return nil
+}
+
+// ListHealthEmailsDue lists up to limit active users who opted in to the
+// health email and were last sent one at or before before, or never
+func (s *Store) ListHealthEmailsDue(ctx context.Context, before time.Time, limit int) ([]*entities.User, error) {
+	var users []*entities.User
+
+	if err := s.db.GetDB().WithContext(ctx).
+		Where(tables.Users.HealthEmail.Eq(true), tables.Users.Active.Eq(true)).
+		Where(clause.Or(tables.Users.HealthEmailSentAt.IsNull(), tables.Users.HealthEmailSentAt.Lte(before))).
+		Order(tables.Users.UUID.Asc()).
+		Limit(limit).
+		Find(&users).Error; err != nil {
+		return nil, fmt.Errorf("list health emails due: %w", common.NewDatabaseError("list_health_emails_due", "user", "", err))
+	}
+
+	return users, nil
+}
+
+// SetHealthEmailSent moves a user's last health email time from last to sentAt
+// if it is still last, without changing UpdatedAt
+func (s *Store) SetHealthEmailSent(ctx context.Context, id string, last *time.Time, sentAt time.Time) (bool, error) {
+	query := s.db.GetDB().WithContext(ctx).Model(&entities.User{}).Where(tables.Users.UUID.Eq(id))
+	if last == nil {
+		query = query.Where(tables.Users.HealthEmailSentAt.IsNull())
+	} else {
+		query = query.Where(tables.Users.HealthEmailSentAt.Eq(*last))
+	}
+
+	result := query.UpdateColumns(common.Values(tables.Users.HealthEmailSentAt.Set(sentAt)))
+	if result.Error != nil {
+		return false, fmt.Errorf("set health email sent: %w",
+			common.NewDatabaseError("set_health_email_sent", "user", id, result.Error))
+	}
+
+	return result.RowsAffected == 1, nil
+}
+
+// filtered starts a query restricted to the users matching filter
+func (s *Store) filtered(ctx context.Context, filter user.Filter) *gorm.DB {
+	query := s.db.GetDB().WithContext(ctx)
+
+	if filter.Query != "" {
+		query = query.Where(common.ContainsFold{Columns: searchColumns, Value: filter.Query})
+	}
+
+	if filter.Role != "" {
+		query = query.Where(tables.Users.Role.Eq(filter.Role))
+	}
+
+	if filter.Active != nil {
+		query = query.Where(tables.Users.Active.Eq(*filter.Active))
+	}
+
+	return query
+}
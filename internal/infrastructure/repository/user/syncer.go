@@ -36,12 +36,17 @@ func NewUserEnsurer(repo user.Repository) user.UserEnsurer {
 }
 
 // EnsureUser checks via Repository.GetByID; on ErrNotFound, creates a shadow user with retry on race.
+// Returns user.ErrAccountDisabled for a disabled user and records the user's activity when stale.
 func (s *Syncer) EnsureUser(ctx context.Context, userID string) error {
 	if userID == "" {
 		return errors.New("ensure user: user ID must not be empty")
 	}
-	_, err := s.repo.GetByID(ctx, userID)
+	existing, err := s.repo.GetByID(ctx, userID)
 	if err == nil {
+		if signInErr := user.CheckCanSignIn(existing); signInErr != nil {
+			return signInErr
+		}
+		s.touch(ctx, existing)
 		return nil
 	}
 	if !errors.Is(err, common.ErrNotFound) {
@@ -58,6 +63,20 @@ func (s *Syncer) EnsureUser(ctx context.Context, userID string) error {
 	return nil
 }
 
+// touch records the user's activity when the last recorded activity is stale.
+// Activity tracking is best effort; a failed write does not fail the request.
+func (s *Syncer) touch(ctx context.Context, u *entities.User) {
+	directory, ok := s.repo.(user.DirectoryRepository)
+	if !ok {
+		return
+	}
+	now := time.Now()
+	if !user.ActivityStale(u, now) {
+		return
+	}
+	_ = directory.TouchLastActive(ctx, u.ID, now)
+}
+
 // newShadowUser returns a minimal user so forms.user_id FK is satisfied.
 // The user is not intended for login via Go (placeholder email and invalid password hash).
 func newShadowUser(id string) *entities.User {
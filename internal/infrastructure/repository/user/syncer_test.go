@@ -8,8 +8,11 @@ import (
 	"testing"
 
 	"github.com/goformx/goforms/internal/domain/entities"
+	"github.com/goformx/goforms/internal/domain/user"
 	"github.com/goformx/goforms/internal/infrastructure/repository/common"
+	memorystore "github.com/goformx/goforms/internal/infrastructure/repository/memory"
 	repository "github.com/goformx/goforms/internal/infrastructure/repository/user"
+	mocklogging "github.com/goformx/goforms/test/mocks/logging"
 	mockuser "github.com/goformx/goforms/test/mocks/user"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -38,7 +41,7 @@ func TestSyncer_EnsureUser_userExists(t *testing.T) {
 
 	ctx := context.Background()
 	userID := "42"
-	existing := &entities.User{ID: userID, Email: "existing@example.com"}
+	existing := &entities.User{ID: userID, Email: "existing@example.com", Active: true}
 
 	repo.EXPECT().
 		GetByID(ctx, userID).
@@ -165,6 +168,44 @@ func TestSyncer_shadowUser_emailTruncatedForLongID(t *testing.T) {
 	require.NoError(t, err)
 }
 
+func TestSyncer_EnsureUser_disabledUser_returnsErrAccountDisabled(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mockuser.NewMockRepository(ctrl)
+	syncer := repository.NewUserEnsurer(repo)
+
+	ctx := context.Background()
+	repo.EXPECT().GetByID(ctx, "42").Return(&entities.User{ID: "42", Active: false}, nil)
+
+	err := syncer.EnsureUser(ctx, "42")
+	require.ErrorIs(t, err, user.ErrAccountDisabled)
+}
+
+func TestSyncer_EnsureUser_recordsStaleActivity(t *testing.T) {
+	logger := mocklogging.NewMockLogger(gomock.NewController(t))
+	users := memorystore.NewStore(logger).Users()
+	syncer := repository.NewUserEnsurer(users)
+
+	ctx := context.Background()
+	require.NoError(t, syncer.EnsureUser(ctx, "42"))
+
+	// The shadow user is created without activity; the next request records it
+	require.NoError(t, syncer.EnsureUser(ctx, "42"))
+
+	u, err := users.GetByID(ctx, "42")
+	require.NoError(t, err)
+	require.NotNil(t, u.LastActiveAt)
+
+	// Activity within the resolution is not written again
+	first := *u.LastActiveAt
+	require.NoError(t, syncer.EnsureUser(ctx, "42"))
+
+	u, err = users.GetByID(ctx, "42")
+	require.NoError(t, err)
+	assert.Equal(t, first, *u.LastActiveAt)
+}
+
 func TestSyncer_shadowUser_passwordCanNeverMatchBcrypt(t *testing.T) {
 	// The shadow password "!shadow-no-login" is not a valid bcrypt hash,
 	// so bcrypt.CompareHashAndPassword will always reject it.
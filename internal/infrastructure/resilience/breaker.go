@@ -0,0 +1,129 @@
+package resilience
+
+import (
+	"errors"
+	"sync"
+	"time"
+)
+
+// State is the state of a circuit
+type State string
+
+// Circuit states
+const (
+	// StateClosed lets calls through
+	StateClosed State = "closed"
+	// StateOpen rejects calls until the cooldown has passed
+	StateOpen State = "open"
+	// StateHalfOpen lets one trial call through; its outcome closes or reopens the circuit
+	StateHalfOpen State = "half_open"
+)
+
+// ErrCircuitOpen is returned for calls rejected by an open circuit
+var ErrCircuitOpen = errors.New("circuit open")
+
+// Breaker is a circuit breaker. It opens after a run of consecutive failures
+// and, once the cooldown has passed, lets one trial call decide whether to close.
+type Breaker struct {
+	threshold int
+	cooldown  time.Duration
+	now       func() time.Time
+
+	mu       sync.Mutex
+	state    State
+	failures int
+	openedAt time.Time
+	// probing is set while the half-open trial call is in flight
+	probing bool
+	opens   int64
+}
+
+// NewBreaker creates a closed breaker. A threshold below one opens on the first failure.
+func NewBreaker(threshold int, cooldown time.Duration) *Breaker {
+	return &Breaker{threshold: max(threshold, 1), cooldown: cooldown, now: time.Now, state: StateClosed}
+}
+
+// Allow reports whether a call may go ahead, returning ErrCircuitOpen when it may not.
+// Every allowed call must be followed by Success, Failure or Cancel.
+func (b *Breaker) Allow() error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	switch b.state {
+	case StateOpen:
+		if b.now().Sub(b.openedAt) < b.cooldown {
+			return ErrCircuitOpen
+		}
+
+		b.state = StateHalfOpen
+		b.probing = true
+
+		return nil
+	case StateHalfOpen:
+		if b.probing {
+			return ErrCircuitOpen
+		}
+
+		b.probing = true
+
+		return nil
+	default:
+		return nil
+	}
+}
+
+// Success records a call that succeeded and closes the circuit
+func (b *Breaker) Success() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.state = StateClosed
+	b.failures = 0
+	b.probing = false
+}
+
+// Failure records a call that failed. It reopens a half-open circuit and opens
+// a closed one at the threshold.
+func (b *Breaker) Failure() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.failures++
+	b.probing = false
+
+	if b.state == StateHalfOpen || (b.state == StateClosed && b.failures >= b.threshold) {
+		b.state = StateOpen
+		b.openedAt = b.now()
+		b.opens++
+	}
+}
+
+// Cancel releases an allowed call whose outcome says nothing about the target,
+// such as one the caller gave up on
+func (b *Breaker) Cancel() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.probing = false
+}
+
+// State returns the circuit's state. An open circuit past its cooldown reports
+// half-open, as the next call is a trial.
+func (b *Breaker) State() State {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.state == StateOpen && b.now().Sub(b.openedAt) >= b.cooldown {
+		return StateHalfOpen
+	}
+
+	return b.state
+}
+
+// Opens returns how many times the circuit has opened
+func (b *Breaker) Opens() int64 {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	return b.opens
+}
@@ -0,0 +1,442 @@
+// Package resilience guards outbound calls to external integrations with a
+// circuit breaker, bounded retries and a timeout budget. Each target, such as
+// one email provider or one extension hook, has its own circuit; the policy
+// comes from the integration it belongs to, tuned by resilience.targets.
+package resilience
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/goformx/goforms/internal/infrastructure/config"
+	"github.com/goformx/goforms/internal/infrastructure/logging"
+)
+
+// Policy controls how calls to a target are retried and when its circuit opens
+type Policy struct {
+	// MaxAttempts bounds the attempts of one call, the first included
+	MaxAttempts int
+	// Backoff is the delay before the first retry; it doubles up to MaxBackoff
+	Backoff    time.Duration
+	MaxBackoff time.Duration
+	// Timeout is the budget of one call across all its attempts; zero leaves
+	// the caller's deadline
+	Timeout time.Duration
+	// FailureThreshold is how many consecutive failures open the circuit
+	FailureThreshold int
+	// Cooldown is how long an open circuit rejects calls before a trial call
+	Cooldown time.Duration
+}
+
+// defaultPolicy applies to integrations without a policy of their own
+var defaultPolicy = Policy{
+	MaxAttempts:      2,
+	Backoff:          200 * time.Millisecond,
+	MaxBackoff:       2 * time.Second,
+	FailureThreshold: 5,
+	Cooldown:         30 * time.Second,
+}
+
+// defaultPolicies reflect each integration: hooks run inside the extension
+// timeout, and storage requests are small and idempotent. The email sender
+// passes each provider's policy to TargetWithPolicy.
+var defaultPolicies = map[string]Policy{
+	config.ResilienceTargetHooks: {
+		MaxAttempts: 2, Backoff: 100 * time.Millisecond, MaxBackoff: time.Second, FailureThreshold: 5, Cooldown: 30 * time.Second,
+	},
+	config.ResilienceTargetStripe: {
+		MaxAttempts: 2, Backoff: 500 * time.Millisecond, MaxBackoff: 2 * time.Second, FailureThreshold: 5, Cooldown: 30 * time.Second,
+	},
+	config.ResilienceTargetStorage: {
+		MaxAttempts: 3, Backoff: 200 * time.Millisecond, MaxBackoff: 2 * time.Second, FailureThreshold: 10, Cooldown: 15 * time.Second,
+	},
+}
+
+// policyFor returns an integration's default policy with configured overrides applied
+func policyFor(integration string, cfg config.ResilienceConfig) Policy {
+	policy, ok := defaultPolicies[integration]
+	if !ok {
+		policy = defaultPolicy
+	}
+
+	return withOverrides(policy, cfg.Targets[integration])
+}
+
+// withOverrides returns policy with the set fields of override applied
+func withOverrides(policy Policy, override config.ResiliencePolicyConfig) Policy {
+
+	if override.MaxAttempts > 0 {
+		policy.MaxAttempts = override.MaxAttempts
+	}
+
+	if override.Backoff > 0 {
+		policy.Backoff = override.Backoff
+		policy.MaxBackoff = max(policy.MaxBackoff, override.Backoff)
+	}
+
+	if override.MaxBackoff > 0 {
+		policy.MaxBackoff = override.MaxBackoff
+	}
+
+	if override.Timeout > 0 {
+		policy.Timeout = override.Timeout
+	}
+
+	if override.FailureThreshold > 0 {
+		policy.FailureThreshold = override.FailureThreshold
+	}
+
+	if override.Cooldown > 0 {
+		policy.Cooldown = override.Cooldown
+	}
+
+	policy.MaxAttempts = max(policy.MaxAttempts, 1)
+
+	return policy
+}
+
+// delay returns how long to wait before retry number attempt, starting at zero,
+// preferring the delay the failed attempt asked for
+func (p Policy) delay(attempt int, err error) time.Duration {
+	var delayer retryDelayer
+	if errors.As(err, &delayer) && delayer.RetryDelay() > 0 {
+		return min(delayer.RetryDelay(), p.MaxBackoff)
+	}
+
+	delay := p.Backoff << attempt
+	if delay <= 0 || delay > p.MaxBackoff {
+		return p.MaxBackoff
+	}
+
+	return delay
+}
+
+// retryDelayer is implemented by errors that carry the delay the target asked
+// for before the next attempt, such as a Retry-After header
+type retryDelayer interface {
+	RetryDelay() time.Duration
+}
+
+// permanentError marks an error the target returned about the request itself
+type permanentError struct {
+	err error
+}
+
+func (e *permanentError) Error() string {
+	return e.err.Error()
+}
+
+func (e *permanentError) Unwrap() error {
+	return e.err
+}
+
+// Permanent marks an error that retrying cannot fix, such as a rejected request.
+// It is returned at once, unwrapped, and counts as a response from a healthy target.
+func Permanent(err error) error {
+	if err == nil {
+		return nil
+	}
+
+	return &permanentError{err: err}
+}
+
+// Target is one outbound destination with its own circuit
+type Target struct {
+	name   string
+	policy Policy
+	// breaker is nil for a target without a circuit
+	breaker *Breaker
+	logger  logging.Logger
+	sleep   func(ctx context.Context, d time.Duration) error
+
+	calls    atomic.Int64
+	failures atomic.Int64
+	rejected atomic.Int64
+	retries  atomic.Int64
+}
+
+// NewTarget creates a target outside a registry, for calls that are retried
+// whether or not outbound calls are guarded. A policy without a
+// FailureThreshold gives the target no circuit.
+func NewTarget(name string, policy Policy, logger logging.Logger) *Target {
+	policy.MaxAttempts = max(policy.MaxAttempts, 1)
+
+	target := &Target{name: name, policy: policy, logger: logger, sleep: sleepContext}
+	if policy.FailureThreshold > 0 {
+		target.breaker = NewBreaker(policy.FailureThreshold, policy.Cooldown)
+	}
+
+	return target
+}
+
+// Name returns the target's name
+func (t *Target) Name() string {
+	return t.name
+}
+
+// State returns the state of the target's circuit; a target without one is
+// always closed
+func (t *Target) State() State {
+	if t.breaker == nil {
+		return StateClosed
+	}
+
+	return t.breaker.State()
+}
+
+// Do calls fn within the target's timeout budget, retrying failures the policy
+// allows. It returns ErrCircuitOpen without calling fn while the circuit is open.
+func (t *Target) Do(ctx context.Context, fn func(ctx context.Context) error) error {
+	ctx, cancel := t.budget(ctx)
+	defer cancel()
+
+	return t.run(ctx, t.policy.MaxAttempts, fn)
+}
+
+// budget bounds ctx by the policy timeout
+func (t *Target) budget(ctx context.Context) (context.Context, context.CancelFunc) {
+	if t.policy.Timeout <= 0 {
+		return context.WithCancel(ctx)
+	}
+
+	return context.WithTimeout(ctx, t.policy.Timeout)
+}
+
+// run makes up to attempts calls of fn, recording each outcome on the circuit
+func (t *Target) run(ctx context.Context, attempts int, fn func(ctx context.Context) error) error {
+	var err error
+
+	for attempt := 0; ; attempt++ {
+		if allowErr := t.allow(); allowErr != nil {
+			t.rejected.Add(1)
+
+			if err != nil {
+				// A failed attempt opened the circuit; report that failure
+				return err
+			}
+
+			return fmt.Errorf("%s: %w", t.name, allowErr)
+		}
+
+		t.calls.Add(1)
+
+		err = fn(ctx)
+
+		var permanent *permanentError
+
+		switch {
+		case err == nil:
+			t.record((*Breaker).Success)
+
+			return nil
+		case errors.As(err, &permanent):
+			t.record((*Breaker).Success)
+
+			return permanent.err
+		case errors.Is(ctx.Err(), context.Canceled):
+			// The caller gave up; the target is not to blame
+			t.record((*Breaker).Cancel)
+
+			return err
+		}
+
+		t.failures.Add(1)
+		t.record((*Breaker).Failure)
+
+		if attempt+1 >= attempts || ctx.Err() != nil {
+			return err
+		}
+
+		delay := t.policy.delay(attempt, err)
+		if deadline, ok := ctx.Deadline(); ok && time.Until(deadline) < delay {
+			return err
+		}
+
+		t.retries.Add(1)
+		t.logger.Warn("outbound call failed, retrying",
+			"target", t.name, "attempt", attempt+1, "retry_in", delay.String(), "error", err)
+
+		if sleepErr := t.sleep(ctx, delay); sleepErr != nil {
+			return err
+		}
+	}
+}
+
+// allow asks the target's circuit, if it has one, to let a call through
+func (t *Target) allow() error {
+	if t.breaker == nil {
+		return nil
+	}
+
+	return t.breaker.Allow()
+}
+
+// record reports the outcome of a call to the target's circuit, if it has one
+func (t *Target) record(outcome func(*Breaker)) {
+	if t.breaker != nil {
+		outcome(t.breaker)
+	}
+}
+
+// metrics returns the target's counters and circuit state
+func (t *Target) metrics() map[string]any {
+	var opens int64
+	if t.breaker != nil {
+		opens = t.breaker.Opens()
+	}
+
+	return map[string]any{
+		"state":    string(t.State()),
+		"calls":    t.calls.Load(),
+		"failures": t.failures.Load(),
+		"rejected": t.rejected.Load(),
+		"retries":  t.retries.Load(),
+		"opens":    opens,
+	}
+}
+
+// Registry holds the targets of outbound calls. A nil registry guards nothing:
+// its targets call through, and its clients are plain.
+type Registry struct {
+	config config.ResilienceConfig
+	logger logging.Logger
+
+	mu      sync.Mutex
+	targets map[string]*Target
+}
+
+// NewRegistry creates the registry of outbound targets. It returns nil when
+// resilience.enabled is unset.
+func NewRegistry(cfg config.ResilienceConfig, logger logging.Logger) *Registry {
+	if !cfg.Enabled {
+		return nil
+	}
+
+	return &Registry{config: cfg, logger: logger, targets: make(map[string]*Target)}
+}
+
+// Target returns an integration's target called name, creating it on first use.
+// Targets are named "<integration>:<name>".
+func (r *Registry) Target(integration, name string) *Target {
+	if r == nil {
+		return nil
+	}
+
+	return r.target(integration, name, policyFor(integration, r.config))
+}
+
+// TargetWithPolicy returns an integration's target called name like Target,
+// with base in place of the integration's default policy. Overrides from
+// resilience.targets still apply.
+func (r *Registry) TargetWithPolicy(integration, name string, base Policy) *Target {
+	if r == nil {
+		return nil
+	}
+
+	return r.target(integration, name, withOverrides(base, r.config.Targets[integration]))
+}
+
+// target returns the target called name of an integration, creating it with
+// policy on first use
+func (r *Registry) target(integration, name string, policy Policy) *Target {
+	key := integration + ":" + name
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if target, ok := r.targets[key]; ok {
+		return target
+	}
+
+	policy.FailureThreshold = max(policy.FailureThreshold, 1)
+
+	target := NewTarget(key, policy, r.logger)
+	r.targets[key] = target
+
+	return target
+}
+
+// Do calls fn through the target name of an integration
+func (r *Registry) Do(ctx context.Context, integration, name string, fn func(ctx context.Context) error) error {
+	if r == nil {
+		err := fn(ctx)
+
+		var permanent *permanentError
+		if errors.As(err, &permanent) {
+			return permanent.err
+		}
+
+		return err
+	}
+
+	return r.Target(integration, name).Do(ctx, fn)
+}
+
+// States returns the circuit state of every target, keyed by target name
+func (r *Registry) States() map[string]State {
+	states := make(map[string]State)
+	if r == nil {
+		return states
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for key, target := range r.targets {
+		states[key] = target.State()
+	}
+
+	return states
+}
+
+// Open returns the names of the targets whose circuit is open, sorted
+func (r *Registry) Open() []string {
+	var open []string
+
+	for name, state := range r.States() {
+		if state == StateOpen {
+			open = append(open, name)
+		}
+	}
+
+	slices.Sort(open)
+
+	return open
+}
+
+// GetMetrics returns each target's counters and circuit state; it implements metrics.Source
+func (r *Registry) GetMetrics() map[string]any {
+	snapshot := make(map[string]any)
+	if r == nil {
+		return snapshot
+	}
+
+	r.mu.Lock()
+	targets := maps.Clone(r.targets)
+	r.mu.Unlock()
+
+	for key, target := range targets {
+		snapshot[key] = target.metrics()
+	}
+
+	return snapshot
+}
+
+// sleepContext waits for d or until ctx is done
+func sleepContext(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
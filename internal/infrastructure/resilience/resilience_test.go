@@ -0,0 +1,228 @@
+package resilience_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/goformx/goforms/internal/infrastructure/config"
+	"github.com/goformx/goforms/internal/infrastructure/resilience"
+	mocklogging "github.com/goformx/goforms/test/mocks/logging"
+)
+
+func newRegistry(t *testing.T, policy config.ResiliencePolicyConfig) *resilience.Registry {
+	t.Helper()
+
+	logger := mocklogging.NewMockLogger(gomock.NewController(t))
+	logger.EXPECT().Warn(gomock.Any(), gomock.Any()).AnyTimes()
+
+	return resilience.NewRegistry(config.ResilienceConfig{
+		Enabled: true,
+		Targets: map[string]config.ResiliencePolicyConfig{config.ResilienceTargetHooks: policy},
+	}, logger)
+}
+
+func TestBreaker(t *testing.T) {
+	breaker := resilience.NewBreaker(2, 20*time.Millisecond)
+
+	require.NoError(t, breaker.Allow())
+	breaker.Failure()
+	assert.Equal(t, resilience.StateClosed, breaker.State())
+
+	require.NoError(t, breaker.Allow())
+	breaker.Failure()
+	assert.Equal(t, resilience.StateOpen, breaker.State())
+	require.ErrorIs(t, breaker.Allow(), resilience.ErrCircuitOpen)
+
+	time.Sleep(25 * time.Millisecond)
+	assert.Equal(t, resilience.StateHalfOpen, breaker.State())
+
+	// One trial call at a time
+	require.NoError(t, breaker.Allow())
+	require.ErrorIs(t, breaker.Allow(), resilience.ErrCircuitOpen)
+
+	breaker.Failure()
+	assert.Equal(t, resilience.StateOpen, breaker.State())
+
+	time.Sleep(25 * time.Millisecond)
+	require.NoError(t, breaker.Allow())
+	breaker.Success()
+	assert.Equal(t, resilience.StateClosed, breaker.State())
+	assert.Equal(t, int64(2), breaker.Opens())
+}
+
+func TestTransportRetriesAndReplaysBody(t *testing.T) {
+	var calls atomic.Int32
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		assert.Equal(t, `{"n":1}`, string(body))
+
+		if calls.Add(1) == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+
+			return
+		}
+
+		_, _ = w.Write([]byte("ok"))
+	}))
+	defer server.Close()
+
+	registry := newRegistry(t, config.ResiliencePolicyConfig{MaxAttempts: 3, Backoff: time.Millisecond})
+	client := registry.Client(config.ResilienceTargetHooks, "crm", 0)
+
+	resp, err := client.Post(server.URL, "application/json", strings.NewReader(`{"n":1}`))
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	assert.Equal(t, "ok", string(body))
+	assert.Equal(t, int32(2), calls.Load())
+
+	target := registry.GetMetrics()["hooks:crm"].(map[string]any)
+	assert.Equal(t, "closed", target["state"])
+	assert.Equal(t, int64(2), target["calls"])
+	assert.Equal(t, int64(1), target["retries"])
+}
+
+func TestTransportReturnsLastFailedResponse(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		http.Error(w, "down", http.StatusBadGateway)
+	}))
+	defer server.Close()
+
+	registry := newRegistry(t, config.ResiliencePolicyConfig{MaxAttempts: 2, Backoff: time.Millisecond})
+
+	resp, err := registry.Client(config.ResilienceTargetHooks, "crm", 0).Get(server.URL)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	assert.Equal(t, http.StatusBadGateway, resp.StatusCode)
+}
+
+func TestCircuitOpensAndRejects(t *testing.T) {
+	var calls atomic.Int32
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		calls.Add(1)
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	registry := newRegistry(t, config.ResiliencePolicyConfig{MaxAttempts: 1, FailureThreshold: 2, Cooldown: time.Hour})
+	client := registry.Client(config.ResilienceTargetHooks, "crm", 0)
+
+	for range 2 {
+		resp, err := client.Get(server.URL)
+		require.NoError(t, err)
+		resp.Body.Close()
+	}
+
+	_, err := client.Get(server.URL)
+	require.ErrorIs(t, err, resilience.ErrCircuitOpen)
+	assert.Equal(t, int32(2), calls.Load())
+	assert.Equal(t, []string{"hooks:crm"}, registry.Open())
+	assert.Equal(t, resilience.StateOpen, registry.States()["hooks:crm"])
+
+	// Other targets of the integration keep their own circuit
+	assert.Equal(t, resilience.StateClosed, registry.Target(config.ResilienceTargetHooks, "billing").State())
+}
+
+func TestDoPermanentErrors(t *testing.T) {
+	registry := newRegistry(t, config.ResiliencePolicyConfig{MaxAttempts: 3, FailureThreshold: 1, Backoff: time.Millisecond})
+	rejected := errors.New("rejected")
+
+	calls := 0
+	err := registry.Do(t.Context(), config.ResilienceTargetHooks, "crm", func(_ context.Context) error {
+		calls++
+
+		return resilience.Permanent(rejected)
+	})
+
+	require.ErrorIs(t, err, rejected)
+	assert.Equal(t, 1, calls)
+	assert.Empty(t, registry.Open())
+}
+
+func TestNilRegistryCallsThrough(t *testing.T) {
+	var registry *resilience.Registry
+
+	assert.Equal(t, http.DefaultTransport, registry.Transport(config.ResilienceTargetStripe, "api", nil))
+	assert.Empty(t, registry.GetMetrics())
+	assert.Nil(t, resilience.NewRegistry(config.ResilienceConfig{}, nil))
+
+	rejected := errors.New("rejected")
+	err := registry.Do(t.Context(), config.ResilienceTargetEmail, "smtp", func(_ context.Context) error {
+		return resilience.Permanent(rejected)
+	})
+	require.Equal(t, rejected, err)
+}
+
+// throttledError asks for a delay before the next attempt
+type throttledError struct {
+	after time.Duration
+}
+
+func (e *throttledError) Error() string {
+	return "throttled"
+}
+
+func (e *throttledError) RetryDelay() time.Duration {
+	return e.after
+}
+
+func TestTargetWithoutCircuit(t *testing.T) {
+	logger := mocklogging.NewMockLogger(gomock.NewController(t))
+	logger.EXPECT().Warn(gomock.Any(), gomock.Any()).AnyTimes()
+
+	policy := resilience.Policy{MaxAttempts: 3, Backoff: time.Millisecond, MaxBackoff: time.Second}
+	target := resilience.NewTarget("email:smtp", policy, logger)
+
+	// Failures are retried but never open a circuit
+	for range 3 {
+		require.Error(t, target.Do(t.Context(), func(_ context.Context) error { return errors.New("timeout") }))
+	}
+
+	assert.Equal(t, resilience.StateClosed, target.State())
+
+	// The delay the failed attempt asked for replaces the backoff
+	calls := 0
+	started := time.Now()
+	err := target.Do(t.Context(), func(_ context.Context) error {
+		calls++
+		if calls == 1 {
+			return &throttledError{after: 30 * time.Millisecond}
+		}
+
+		return nil
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, 2, calls)
+	assert.GreaterOrEqual(t, time.Since(started), 30*time.Millisecond)
+}
+
+func TestTargetWithPolicy(t *testing.T) {
+	registry := newRegistry(t, config.ResiliencePolicyConfig{})
+	base := resilience.Policy{MaxAttempts: 1, FailureThreshold: 1, Cooldown: time.Hour}
+
+	target := registry.TargetWithPolicy(config.ResilienceTargetHooks, "crm", base)
+	require.Error(t, target.Do(t.Context(), func(_ context.Context) error { return errors.New("timeout") }))
+
+	assert.Equal(t, resilience.StateOpen, target.State())
+	assert.Same(t, target, registry.Target(config.ResilienceTargetHooks, "crm"))
+
+	var disabled *resilience.Registry
+	assert.Nil(t, disabled.TargetWithPolicy(config.ResilienceTargetEmail, "smtp", base))
+}
@@ -0,0 +1,153 @@
+package resilience
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+)
+
+// maxDrainBody bounds how much of a discarded response is read so its connection can be reused
+const maxDrainBody = 4096
+
+// statusError reports a response whose status means the target is failing
+type statusError struct {
+	status int
+}
+
+func (e *statusError) Error() string {
+	return fmt.Sprintf("responded %d", e.status)
+}
+
+// failedStatus reports whether a response status means the target is failing:
+// it is throttling or has a server error. Other responses are answers.
+func failedStatus(status int) bool {
+	return status == http.StatusTooManyRequests ||
+		(status >= http.StatusInternalServerError && status != http.StatusNotImplemented)
+}
+
+// transport guards a RoundTripper with a target
+type transport struct {
+	target *Target
+	base   http.RoundTripper
+}
+
+// Transport wraps base, or http.DefaultTransport when nil, with the target
+// called name of an integration. Requests are retried only when their body
+// can be replayed. The last response is returned even when its status counts
+// as a failure, so callers still see what the target said.
+func (r *Registry) Transport(integration, name string, base http.RoundTripper) http.RoundTripper {
+	if base == nil {
+		base = http.DefaultTransport
+	}
+
+	if r == nil {
+		return base
+	}
+
+	return &transport{target: r.Target(integration, name), base: base}
+}
+
+// Client returns an HTTP client whose calls go through the target called name
+// of an integration. A zero timeout leaves calls bounded by their context.
+func (r *Registry) Client(integration, name string, timeout time.Duration) *http.Client {
+	return &http.Client{Timeout: timeout, Transport: r.Transport(integration, name, nil)}
+}
+
+// RoundTrip implements http.RoundTripper
+func (t *transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	attempts := t.target.policy.MaxAttempts
+	if req.Body != nil && req.Body != http.NoBody && req.GetBody == nil {
+		attempts = 1
+	}
+
+	ctx, cancel := t.target.budget(req.Context())
+
+	var (
+		resp  *http.Response
+		tries int
+	)
+
+	err := t.target.run(ctx, attempts, func(ctx context.Context) error {
+		if resp != nil {
+			discard(resp)
+			resp = nil
+		}
+
+		attempt := req.WithContext(ctx)
+
+		if tries++; tries > 1 {
+			replayed, err := rewind(attempt)
+			if err != nil {
+				return Permanent(err)
+			}
+
+			attempt = replayed
+		}
+
+		var err error
+
+		resp, err = t.base.RoundTrip(attempt)
+		if err != nil {
+			return err
+		}
+
+		if failedStatus(resp.StatusCode) {
+			return &statusError{status: resp.StatusCode}
+		}
+
+		return nil
+	})
+
+	if resp == nil {
+		cancel()
+
+		if err == nil {
+			err = fmt.Errorf("%s: no response", t.target.name)
+		}
+
+		return nil, err
+	}
+
+	// The budget must outlive RoundTrip until the body has been read
+	resp.Body = &cancelBody{ReadCloser: resp.Body, cancel: cancel}
+
+	return resp, nil
+}
+
+// rewind gives req a fresh copy of its body, for a repeated attempt
+func rewind(req *http.Request) (*http.Request, error) {
+	if req.GetBody == nil || req.Body == nil || req.Body == http.NoBody {
+		return req, nil
+	}
+
+	body, err := req.GetBody()
+	if err != nil {
+		return nil, fmt.Errorf("replay request body: %w", err)
+	}
+
+	req.Body = body
+
+	return req, nil
+}
+
+// discard drains and closes a response that will not be returned
+func discard(resp *http.Response) {
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, maxDrainBody))
+	_ = resp.Body.Close()
+}
+
+// cancelBody releases a call's timeout budget when its response body is closed
+type cancelBody struct {
+	io.ReadCloser
+	cancel context.CancelFunc
+}
+
+// Close closes the body and releases the budget
+func (b *cancelBody) Close() error {
+	err := b.ReadCloser.Close()
+	b.cancel()
+
+	return err
+}
@@ -0,0 +1,208 @@
+// Package scheduler provides cron expression parsing and a lightweight
+// in-process job runner for periodic background work.
+package scheduler
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// maxSearchYears bounds the search for the next matching time so impossible
+// expressions such as "0 0 30 2 *" terminate
+const maxSearchYears = 5
+
+// ErrInvalidCron is returned when a cron expression cannot be parsed
+var ErrInvalidCron = errors.New("invalid cron expression")
+
+// cronMacros maps the supported shorthand expressions to their five-field form
+var cronMacros = map[string]string{
+	"@hourly":   "0 * * * *",
+	"@daily":    "0 0 * * *",
+	"@midnight": "0 0 * * *",
+	"@weekly":   "0 0 * * 0",
+	"@monthly":  "0 0 1 * *",
+	"@yearly":   "0 0 1 1 *",
+	"@annually": "0 0 1 1 *",
+}
+
+// Schedule is a parsed five-field cron expression (minute hour day-of-month month day-of-week)
+type Schedule struct {
+	minute, hour, dom, month, dow uint64
+	domStar, dowStar              bool
+}
+
+type cronField struct {
+	min, max int
+}
+
+var cronFields = [5]cronField{
+	{0, 59}, // minute
+	{0, 23}, // hour
+	{1, 31}, // day of month
+	{1, 12}, // month
+	{0, 6},  // day of week (7 is accepted as Sunday)
+}
+
+// ParseCron parses a standard five-field cron expression or one of the
+// @hourly, @daily, @weekly, @monthly and @yearly macros
+func ParseCron(expr string) (*Schedule, error) {
+	expr = strings.TrimSpace(expr)
+	if macro, ok := cronMacros[strings.ToLower(expr)]; ok {
+		expr = macro
+	}
+
+	parts := strings.Fields(expr)
+	if len(parts) != len(cronFields) {
+		return nil, fmt.Errorf("%w: expected 5 fields, got %d", ErrInvalidCron, len(parts))
+	}
+
+	var bits [5]uint64
+
+	for i, part := range parts {
+		field := cronFields[i]
+		if i == 4 {
+			field.max = 7
+		}
+
+		parsed, err := parseCronField(part, field)
+		if err != nil {
+			return nil, err
+		}
+
+		bits[i] = parsed
+	}
+
+	// Fold day-of-week 7 into 0 (both mean Sunday)
+	if bits[4]&(1<<7) != 0 {
+		bits[4] = bits[4]&^(1<<7) | 1
+	}
+
+	return &Schedule{
+		minute:  bits[0],
+		hour:    bits[1],
+		dom:     bits[2],
+		month:   bits[3],
+		dow:     bits[4],
+		domStar: parts[2] == "*" || parts[2] == "?",
+		dowStar: parts[4] == "*" || parts[4] == "?",
+	}, nil
+}
+
+// parseCronField parses a comma-separated list of values, ranges and steps
+func parseCronField(part string, field cronField) (uint64, error) {
+	var bits uint64
+
+	for _, item := range strings.Split(part, ",") {
+		rangePart, step := item, 1
+
+		if idx := strings.Index(item, "/"); idx >= 0 {
+			parsedStep, err := strconv.Atoi(item[idx+1:])
+			if err != nil || parsedStep <= 0 {
+				return 0, fmt.Errorf("%w: invalid step %q", ErrInvalidCron, item)
+			}
+
+			rangePart, step = item[:idx], parsedStep
+		}
+
+		low, high := field.min, field.max
+
+		switch {
+		case rangePart == "*" || rangePart == "?":
+		case strings.Contains(rangePart, "-"):
+			bounds := strings.SplitN(rangePart, "-", 2)
+
+			var err error
+			if low, err = parseCronValue(bounds[0], field); err != nil {
+				return 0, err
+			}
+
+			if high, err = parseCronValue(bounds[1], field); err != nil {
+				return 0, err
+			}
+
+			if low > high {
+				return 0, fmt.Errorf("%w: invalid range %q", ErrInvalidCron, rangePart)
+			}
+		default:
+			value, err := parseCronValue(rangePart, field)
+			if err != nil {
+				return 0, err
+			}
+
+			low = value
+			if strings.Contains(item, "/") {
+				high = field.max
+			} else {
+				high = value
+			}
+		}
+
+		for v := low; v <= high; v += step {
+			bits |= 1 << uint(v)
+		}
+	}
+
+	return bits, nil
+}
+
+func parseCronValue(s string, field cronField) (int, error) {
+	value, err := strconv.Atoi(s)
+	if err != nil || value < field.min || value > field.max {
+		return 0, fmt.Errorf("%w: value %q out of range %d-%d", ErrInvalidCron, s, field.min, field.max)
+	}
+
+	return value, nil
+}
+
+// Next returns the first activation time strictly after the given time, in
+// the location of after. The zero time is returned if none exists.
+func (s *Schedule) Next(after time.Time) time.Time {
+	t := after.Truncate(time.Minute).Add(time.Minute)
+	limit := t.AddDate(maxSearchYears, 0, 0)
+
+	for t.Before(limit) {
+		if s.month&(1<<uint(t.Month())) == 0 {
+			t = time.Date(t.Year(), t.Month()+1, 1, 0, 0, 0, 0, t.Location())
+
+			continue
+		}
+
+		if !s.dayMatches(t) {
+			t = time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, t.Location())
+
+			continue
+		}
+
+		if s.hour&(1<<uint(t.Hour())) == 0 {
+			t = time.Date(t.Year(), t.Month(), t.Day(), t.Hour()+1, 0, 0, 0, t.Location())
+
+			continue
+		}
+
+		if s.minute&(1<<uint(t.Minute())) == 0 {
+			t = t.Add(time.Minute)
+
+			continue
+		}
+
+		return t
+	}
+
+	return time.Time{}
+}
+
+// dayMatches applies the cron rule that when both day fields are restricted,
+// a day matching either one is accepted
+func (s *Schedule) dayMatches(t time.Time) bool {
+	domMatch := s.dom&(1<<uint(t.Day())) != 0
+	dowMatch := s.dow&(1<<uint(t.Weekday())) != 0
+
+	if s.domStar || s.dowStar {
+		return domMatch && dowMatch
+	}
+
+	return domMatch || dowMatch
+}
@@ -0,0 +1,52 @@
+package scheduler_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/goformx/goforms/internal/infrastructure/scheduler"
+)
+
+func TestSchedule_Next(t *testing.T) {
+	// Wednesday 2026-10-14 10:30 UTC
+	from := time.Date(2026, 10, 14, 10, 30, 0, 0, time.UTC)
+
+	tests := []struct {
+		expr string
+		want time.Time
+	}{
+		{"@daily", time.Date(2026, 10, 15, 0, 0, 0, 0, time.UTC)},
+		{"@weekly", time.Date(2026, 10, 18, 0, 0, 0, 0, time.UTC)},
+		{"*/15 * * * *", time.Date(2026, 10, 14, 10, 45, 0, 0, time.UTC)},
+		{"0 9 * * 1-5", time.Date(2026, 10, 15, 9, 0, 0, 0, time.UTC)},
+		{"30 8 1,15 * *", time.Date(2026, 10, 15, 8, 30, 0, 0, time.UTC)},
+		{"0 0 1 1 *", time.Date(2027, 1, 1, 0, 0, 0, 0, time.UTC)},
+		{"0 12 * * 7", time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.expr, func(t *testing.T) {
+			schedule, err := scheduler.ParseCron(tt.expr)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, schedule.Next(from))
+		})
+	}
+}
+
+func TestParseCron_Invalid(t *testing.T) {
+	for _, expr := range []string{"", "* * * *", "60 * * * *", "* * * * 8", "5-1 * * * *", "*/0 * * * *", "a * * * *"} {
+		t.Run(expr, func(t *testing.T) {
+			_, err := scheduler.ParseCron(expr)
+			require.ErrorIs(t, err, scheduler.ErrInvalidCron)
+		})
+	}
+}
+
+func TestSchedule_NextImpossible(t *testing.T) {
+	schedule, err := scheduler.ParseCron("0 0 30 2 *")
+	require.NoError(t, err)
+	assert.True(t, schedule.Next(time.Now()).IsZero())
+}
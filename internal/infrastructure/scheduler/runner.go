@@ -0,0 +1,89 @@
+package scheduler
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/goformx/goforms/internal/infrastructure/logging"
+)
+
+// Job is a unit of periodic background work
+type Job func(ctx context.Context) error
+
+// Runner executes a job on a fixed interval until stopped.
+// Runs never overlap: a tick that fires while the job is still running is skipped.
+type Runner struct {
+	name     string
+	interval time.Duration
+	job      Job
+	logger   logging.Logger
+
+	mu     sync.Mutex
+	cancel context.CancelFunc
+	done   chan struct{}
+}
+
+// NewRunner creates a runner for the given job
+func NewRunner(name string, interval time.Duration, job Job, logger logging.Logger) *Runner {
+	return &Runner{
+		name:     name,
+		interval: interval,
+		job:      job,
+		logger:   logger,
+	}
+}
+
+// Start begins executing the job in the background. Calling Start on a
+// running runner is a no-op.
+func (r *Runner) Start() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.cancel != nil {
+		return
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	r.cancel = cancel
+	r.done = make(chan struct{})
+
+	go r.loop(ctx, r.done)
+}
+
+// Stop cancels the running job and waits for it to return or for ctx to expire
+func (r *Runner) Stop(ctx context.Context) {
+	r.mu.Lock()
+	cancel, done := r.cancel, r.done
+	r.cancel, r.done = nil, nil
+	r.mu.Unlock()
+
+	if cancel == nil {
+		return
+	}
+
+	cancel()
+
+	select {
+	case <-done:
+	case <-ctx.Done():
+	}
+}
+
+func (r *Runner) loop(ctx context.Context, done chan struct{}) {
+	defer close(done)
+
+	ticker := time.NewTicker(r.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := r.job(ctx); err != nil && ctx.Err() == nil {
+				r.logger.Error("scheduled job failed", "job", r.name, "error", err)
+			}
+		}
+	}
+}
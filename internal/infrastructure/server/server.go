@@ -15,6 +15,8 @@ import (
 	"github.com/goformx/goforms/internal/application/response"
 	"github.com/goformx/goforms/internal/infrastructure/config"
 	"github.com/goformx/goforms/internal/infrastructure/logging"
+	"github.com/goformx/goforms/internal/infrastructure/metrics"
+	"github.com/goformx/goforms/internal/infrastructure/resilience"
 	"github.com/goformx/goforms/internal/infrastructure/version"
 )
 
@@ -104,6 +106,9 @@ type Deps struct {
 	Logger    logging.Logger
 	Config    *config.Config
 	Echo      *echo.Echo
+	Metrics   *metrics.Registry `optional:"true"`
+	// Outbound reports the circuits of outbound integrations in the health check
+	Outbound *resilience.Registry `optional:"true"`
 }
 
 // New creates a new server instance with the provided dependencies
@@ -120,16 +125,35 @@ func New(deps Deps) *Server {
 		"environment", deps.Config.App.Environment,
 		"server_type", "echo")
 
-	// Add health check endpoint (supports both GET and HEAD for health check tools)
+	// Add health check endpoint (supports both GET and HEAD for health check tools).
+	// Open circuits report the service as degraded; it still serves requests.
 	healthHandler := func(c echo.Context) error {
-		return response.Success(c, map[string]string{
+		health := map[string]any{
 			"status": "ok",
 			"time":   time.Now().Format(time.RFC3339),
-		})
+		}
+
+		if deps.Outbound != nil {
+			health["outbound"] = deps.Outbound.States()
+
+			if open := deps.Outbound.Open(); len(open) > 0 {
+				health["status"] = "degraded"
+				health["open_circuits"] = open
+			}
+		}
+
+		return response.Success(c, health)
 	}
 	deps.Echo.GET("/health", healthHandler)
 	deps.Echo.HEAD("/health", healthHandler)
 
+	// Metrics snapshot (version, uptime, cache hit/miss counters)
+	if deps.Metrics != nil {
+		deps.Echo.GET("/metrics", func(c echo.Context) error {
+			return response.Success(c, deps.Metrics.Snapshot())
+		})
+	}
+
 	// Register lifecycle hooks
 	deps.Lifecycle.Append(fx.Hook{
 		OnStart: func(_ context.Context) error {
@@ -0,0 +1,345 @@
+package storage
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/goformx/goforms/internal/infrastructure/config"
+)
+
+const (
+	// azureAPIVersion is the Blob service version requests and SAS tokens use
+	azureAPIVersion = "2021-08-06"
+	// azureRequestTTL bounds the SAS tokens the driver signs for its own requests
+	azureRequestTTL = 15 * time.Minute
+
+	// Azurite's well-known development account, selected by UseDevelopmentStorage=true
+	azureDevelopmentAccount  = "devstoreaccount1"
+	azureDevelopmentKey      = "Eby8vdM02xNOcqFlqUwJPLlmEtlCDXJ1OUzFT50uSRZ6IFsuFq2UVErCz4I6tq/K1SZFPTOtr/KBHBeksoGMGw=="
+	azureDevelopmentEndpoint = "http://127.0.0.1:10000/devstoreaccount1"
+)
+
+// Azure stores objects as block blobs in an Azure Blob Storage container
+// (storage.type=azure). Requests and download URLs are authorized with
+// service SAS tokens signed by the account key.
+type Azure struct {
+	account   string
+	key       []byte
+	container string
+	endpoint  string
+	scope     string
+	client    *http.Client
+	now       func() time.Time
+}
+
+// NewAzure creates an Azure Blob Storage driver. A connection string, when
+// set, replaces the account name, key and endpoint. A nil client uses
+// http.DefaultClient.
+func NewAzure(cfg config.AzureStorageConfig, client *http.Client) (*Azure, error) {
+	if cfg.Container == "" {
+		return nil, errors.New("azure container is required")
+	}
+
+	if client == nil {
+		client = http.DefaultClient
+	}
+
+	account, accountKey, endpoint := cfg.AccountName, cfg.AccountKey, cfg.Endpoint
+
+	if cfg.ConnectionString != "" {
+		var err error
+
+		account, accountKey, endpoint, err = parseAzureConnectionString(cfg.ConnectionString)
+		if err != nil {
+			return nil, err
+		}
+
+		if cfg.Endpoint != "" {
+			endpoint = cfg.Endpoint
+		}
+	}
+
+	if account == "" || accountKey == "" {
+		return nil, errors.New("azure account name and key are required")
+	}
+
+	key, err := base64.StdEncoding.DecodeString(accountKey)
+	if err != nil {
+		return nil, fmt.Errorf("decode azure account key: %w", err)
+	}
+
+	if endpoint == "" {
+		endpoint = "https://" + account + ".blob.core.windows.net"
+	}
+
+	return &Azure{
+		account:   account,
+		key:       key,
+		container: cfg.Container,
+		endpoint:  strings.TrimRight(endpoint, "/"),
+		scope:     cfg.EncryptionScope,
+		client:    client,
+		now:       time.Now,
+	}, nil
+}
+
+// parseAzureConnectionString reads the account, key and blob endpoint of a
+// storage connection string
+func parseAzureConnectionString(connectionString string) (account, key, endpoint string, err error) {
+	settings := make(map[string]string)
+
+	for part := range strings.SplitSeq(connectionString, ";") {
+		if name, value, ok := strings.Cut(strings.TrimSpace(part), "="); ok {
+			settings[strings.ToLower(name)] = value
+		}
+	}
+
+	if strings.EqualFold(settings["usedevelopmentstorage"], "true") {
+		return azureDevelopmentAccount, azureDevelopmentKey, azureDevelopmentEndpoint, nil
+	}
+
+	account, key, endpoint = settings["accountname"], settings["accountkey"], settings["blobendpoint"]
+	if account == "" || key == "" {
+		return "", "", "", errors.New("azure connection string must set AccountName and AccountKey")
+	}
+
+	if endpoint == "" {
+		protocol, suffix := settings["defaultendpointsprotocol"], settings["endpointsuffix"]
+		if protocol == "" {
+			protocol = "https"
+		}
+
+		if suffix == "" {
+			suffix = "core.windows.net"
+		}
+
+		endpoint = protocol + "://" + account + ".blob." + suffix
+	}
+
+	return account, key, endpoint, nil
+}
+
+// Put uploads data to key as a block blob, in the configured encryption scope if any
+func (a *Azure) Put(ctx context.Context, key string, data []byte) error {
+	name, err := cleanKey(key)
+	if err != nil {
+		return err
+	}
+
+	headers := map[string]string{
+		"x-ms-blob-type": "BlockBlob",
+		"Content-Type":   "application/octet-stream",
+	}
+	if a.scope != "" {
+		headers["x-ms-encryption-scope"] = a.scope
+	}
+
+	resp, err := a.do(ctx, http.MethodPut, a.blobURL(name, a.sas("w", name, a.now().Add(azureRequestTTL))), headers, data)
+	if err != nil {
+		return fmt.Errorf("write object %s: %w", key, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusCreated {
+		return responseError("write object", key, resp)
+	}
+
+	return nil
+}
+
+// Get downloads key
+func (a *Azure) Get(ctx context.Context, key string) ([]byte, error) {
+	name, err := cleanKey(key)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := a.do(ctx, http.MethodGet, a.blobURL(name, a.sas("r", name, a.now().Add(azureRequestTTL))), nil, nil)
+	if err != nil {
+		return nil, fmt.Errorf("read object %s: %w", key, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, responseError("read object", key, resp)
+	}
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("read object %s: %w", key, err)
+	}
+
+	return data, nil
+}
+
+// azureBlobList is one page of a blob listing
+type azureBlobList struct {
+	Blobs []struct {
+		Name string `xml:"Name"`
+	} `xml:"Blobs>Blob"`
+	NextMarker string `xml:"NextMarker"`
+}
+
+// List lists the keys under prefix, following result pages
+func (a *Azure) List(ctx context.Context, prefix string) ([]string, error) {
+	dir, err := cleanKey(prefix)
+	if err != nil {
+		return nil, err
+	}
+
+	var keys []string
+
+	marker := ""
+
+	for {
+		query := a.sas("l", "", a.now().Add(azureRequestTTL))
+		query.Set("restype", "container")
+		query.Set("comp", "list")
+		query.Set("prefix", dir+"/")
+
+		if marker != "" {
+			query.Set("marker", marker)
+		}
+
+		page, pageErr := a.listPage(ctx, a.endpoint+"/"+url.PathEscape(a.container)+"?"+query.Encode())
+		if pageErr != nil {
+			return nil, fmt.Errorf("list objects under %s: %w", prefix, pageErr)
+		}
+
+		for _, blob := range page.Blobs {
+			keys = append(keys, blob.Name)
+		}
+
+		if page.NextMarker == "" {
+			break
+		}
+
+		marker = page.NextMarker
+	}
+
+	sort.Strings(keys)
+
+	return keys, nil
+}
+
+// listPage fetches one page of a blob listing
+func (a *Azure) listPage(ctx context.Context, target string) (*azureBlobList, error) {
+	resp, err := a.do(ctx, http.MethodGet, target, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, responseError("list", a.container, resp)
+	}
+
+	var page azureBlobList
+	if decodeErr := xml.NewDecoder(resp.Body).Decode(&page); decodeErr != nil {
+		return nil, fmt.Errorf("decode blob list: %w", decodeErr)
+	}
+
+	return &page, nil
+}
+
+// Delete removes key
+func (a *Azure) Delete(ctx context.Context, key string) error {
+	name, err := cleanKey(key)
+	if err != nil {
+		return err
+	}
+
+	resp, err := a.do(ctx, http.MethodDelete, a.blobURL(name, a.sas("d", name, a.now().Add(azureRequestTTL))), nil, nil)
+	if err != nil {
+		return fmt.Errorf("delete object %s: %w", key, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusAccepted && resp.StatusCode != http.StatusNotFound {
+		return responseError("delete object", key, resp)
+	}
+
+	return nil
+}
+
+// SignedURL returns a read-only SAS URL for key
+func (a *Azure) SignedURL(_ context.Context, key string, ttl time.Duration) (string, error) {
+	name, err := cleanKey(key)
+	if err != nil {
+		return "", err
+	}
+
+	return a.blobURL(name, a.sas("r", name, a.now().Add(ttl))), nil
+}
+
+// blobURL is the URL of a blob with a SAS query
+func (a *Azure) blobURL(name string, sas url.Values) string {
+	return a.endpoint + "/" + url.PathEscape(a.container) + "/" + escapePath(name) + "?" + sas.Encode()
+}
+
+// sas signs a service SAS granting permissions on one blob, or on the
+// container when blob is empty, until expiry
+func (a *Azure) sas(permissions, blob string, expiry time.Time) url.Values {
+	resource, canonical := "c", "/blob/"+a.account+"/"+a.container
+	if blob != "" {
+		resource, canonical = "b", canonical+"/"+blob
+	}
+
+	signedExpiry := expiry.UTC().Format("2006-01-02T15:04:05Z")
+
+	// Fields for signed start, identifier, IP, protocol, snapshot time,
+	// encryption scope and response header overrides are left empty
+	stringToSign := strings.Join([]string{
+		permissions, "", signedExpiry, canonical, "", "", "", azureAPIVersion, resource,
+		"", "", "", "", "", "", "",
+	}, "\n")
+
+	mac := hmac.New(sha256.New, a.key)
+	mac.Write([]byte(stringToSign))
+
+	return url.Values{
+		"sv":  {azureAPIVersion},
+		"sp":  {permissions},
+		"se":  {signedExpiry},
+		"sr":  {resource},
+		"sig": {base64.StdEncoding.EncodeToString(mac.Sum(nil))},
+	}
+}
+
+// do sends a request to the Blob service
+func (a *Azure) do(ctx context.Context, method, target string, headers map[string]string, body []byte) (*http.Response, error) {
+	var reader io.Reader
+	if body != nil {
+		reader = bytes.NewReader(body)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, target, reader)
+	if err != nil {
+		return nil, fmt.Errorf("create request: %w", err)
+	}
+
+	req.Header.Set("x-ms-version", azureAPIVersion)
+
+	for name, value := range headers {
+		req.Header.Set(name, value)
+	}
+
+	resp, err := a.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("send request: %w", err)
+	}
+
+	return resp, nil
+}
@@ -0,0 +1,182 @@
+package storage_test
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/xml"
+	"io"
+	"io/fs"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/goformx/goforms/internal/infrastructure/config"
+	"github.com/goformx/goforms/internal/infrastructure/storage"
+)
+
+const (
+	azureAccount = "goforms"
+	azureKey     = "c2VjcmV0LWFjY291bnQta2V5" // "secret-account-key"
+)
+
+// fakeAzure serves the parts of the Blob service the driver uses and checks
+// each request's SAS signature
+type fakeAzure struct {
+	mu     sync.Mutex
+	blobs  map[string][]byte
+	scopes map[string]string
+}
+
+func (f *fakeAzure) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	name := strings.TrimPrefix(strings.TrimPrefix(r.URL.Path, "/uploads"), "/")
+	if !validSAS(r.URL.Query(), name) {
+		w.WriteHeader(http.StatusForbidden)
+
+		return
+	}
+
+	switch {
+	case r.URL.Query().Get("comp") == "list":
+		type blobItem struct {
+			Name string `xml:"Name"`
+		}
+
+		var list struct {
+			XMLName xml.Name   `xml:"EnumerationResults"`
+			Blobs   []blobItem `xml:"Blobs>Blob"`
+		}
+
+		for blob := range f.blobs {
+			if strings.HasPrefix(blob, r.URL.Query().Get("prefix")) {
+				list.Blobs = append(list.Blobs, blobItem{Name: blob})
+			}
+		}
+
+		_ = xml.NewEncoder(w).Encode(list)
+	case r.Method == http.MethodPut && r.Header.Get("x-ms-blob-type") == "BlockBlob":
+		data, _ := io.ReadAll(r.Body)
+		f.blobs[name] = data
+		f.scopes[name] = r.Header.Get("x-ms-encryption-scope")
+		w.WriteHeader(http.StatusCreated)
+	case r.Method == http.MethodGet:
+		data, ok := f.blobs[name]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+
+			return
+		}
+
+		_, _ = w.Write(data)
+	case r.Method == http.MethodDelete:
+		if _, ok := f.blobs[name]; !ok {
+			w.WriteHeader(http.StatusNotFound)
+
+			return
+		}
+
+		delete(f.blobs, name)
+		w.WriteHeader(http.StatusAccepted)
+	default:
+		w.WriteHeader(http.StatusBadRequest)
+	}
+}
+
+// validSAS recomputes a service SAS signature with the test account key
+func validSAS(query url.Values, blob string) bool {
+	canonical := "/blob/" + azureAccount + "/uploads"
+	if query.Get("sr") == "b" {
+		canonical += "/" + blob
+	}
+
+	stringToSign := strings.Join([]string{
+		query.Get("sp"), "", query.Get("se"), canonical, "", "", "", query.Get("sv"), query.Get("sr"),
+		"", "", "", "", "", "", "",
+	}, "\n")
+
+	key, _ := base64.StdEncoding.DecodeString(azureKey)
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(stringToSign))
+
+	return query.Get("sig") == base64.StdEncoding.EncodeToString(mac.Sum(nil))
+}
+
+func TestAzure(t *testing.T) {
+	fake := &fakeAzure{blobs: make(map[string][]byte), scopes: make(map[string]string)}
+	server := httptest.NewServer(fake)
+	t.Cleanup(server.Close)
+
+	azure, err := storage.NewAzure(config.AzureStorageConfig{
+		AccountName:     azureAccount,
+		AccountKey:      azureKey,
+		Container:       "uploads",
+		EncryptionScope: "scope-1",
+		Endpoint:        server.URL,
+	}, server.Client())
+	require.NoError(t, err)
+
+	ctx := t.Context()
+
+	require.NoError(t, azure.Put(ctx, "archive/b.gz", []byte("b")))
+	require.NoError(t, azure.Put(ctx, "archive/a.gz", []byte("a")))
+	require.NoError(t, azure.Put(ctx, "archived/c.gz", []byte("c")))
+	assert.Equal(t, "scope-1", fake.scopes["archive/a.gz"])
+
+	data, err := azure.Get(ctx, "archive/a.gz")
+	require.NoError(t, err)
+	assert.Equal(t, "a", string(data))
+
+	keys, err := azure.List(ctx, "archive")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"archive/a.gz", "archive/b.gz"}, keys)
+
+	require.NoError(t, azure.Delete(ctx, "archive/a.gz"))
+	require.NoError(t, azure.Delete(ctx, "archive/a.gz"), "deleting a missing key is not an error")
+
+	_, err = azure.Get(ctx, "archive/a.gz")
+	require.ErrorIs(t, err, fs.ErrNotExist)
+
+	// Signed URLs download without further credentials and are read-only
+	signed, err := azure.SignedURL(ctx, "archive/b.gz", time.Minute)
+	require.NoError(t, err)
+
+	parsed, err := url.Parse(signed)
+	require.NoError(t, err)
+	assert.Equal(t, "r", parsed.Query().Get("sp"))
+	assert.Equal(t, "b", parsed.Query().Get("sr"))
+
+	resp, err := server.Client().Get(signed)
+	require.NoError(t, err)
+
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	require.NoError(t, resp.Body.Close())
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, "b", string(body))
+}
+
+func TestAzure_ConnectionString(t *testing.T) {
+	azure, err := storage.NewAzure(config.AzureStorageConfig{
+		ConnectionString: "DefaultEndpointsProtocol=https;AccountName=" + azureAccount + ";AccountKey=" + azureKey +
+			";EndpointSuffix=core.windows.net",
+		Container: "uploads",
+	}, nil)
+	require.NoError(t, err)
+
+	signed, err := azure.SignedURL(t.Context(), "files/a.pdf", time.Minute)
+	require.NoError(t, err)
+	assert.True(t, strings.HasPrefix(signed, "https://goforms.blob.core.windows.net/uploads/files/a.pdf?"))
+
+	_, err = storage.NewAzure(config.AzureStorageConfig{ConnectionString: "AccountName=goforms", Container: "uploads"}, nil)
+	require.Error(t, err)
+}
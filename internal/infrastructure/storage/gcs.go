@@ -0,0 +1,481 @@
+package storage
+
+import (
+	"bytes"
+	"context"
+	"crypto"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/goformx/goforms/internal/infrastructure/config"
+)
+
+const (
+	gcsDefaultEndpoint = "https://storage.googleapis.com"
+	gcsDefaultTokenURI = "https://oauth2.googleapis.com/token"
+	// gcsMetadataTokenURL issues access tokens for the instance's service account
+	gcsMetadataTokenURL = "http://metadata.google.internal/computeMetadata/v1/instance/service-accounts/default/token"
+	gcsScope            = "https://www.googleapis.com/auth/devstorage.read_write"
+	gcsSigningAlgorithm = "GOOG4-RSA-SHA256"
+	// gcsTokenLifetime is the lifetime requested for service account access tokens
+	gcsTokenLifetime = time.Hour
+	// gcsTokenRefreshMargin renews access tokens this long before they expire
+	gcsTokenRefreshMargin = time.Minute
+)
+
+// gcsServiceAccount is the part of a service account key the driver uses
+type gcsServiceAccount struct {
+	ClientEmail string `json:"client_email"`
+	PrivateKey  string `json:"private_key"`
+	TokenURI    string `json:"token_uri"`
+}
+
+// GCS stores objects in a Google Cloud Storage bucket (storage.type=gcs)
+type GCS struct {
+	bucket   string
+	endpoint *url.URL
+	kmsKey   string
+	client   *http.Client
+	now      func() time.Time
+
+	// email, key and tokenURI come from the service account key, if any
+	email    string
+	key      *rsa.PrivateKey
+	tokenURI string
+	// anonymous skips authorization, for emulators
+	anonymous bool
+
+	mu          sync.Mutex
+	token       string
+	tokenExpiry time.Time
+}
+
+// NewGCS creates a GCS driver. With a service account key, requests are
+// authorized with the key and download URLs are signed with it. Without one,
+// requests use the metadata server's credentials and URLs cannot be signed;
+// requests to a custom endpoint, such as an emulator, are sent unauthorized.
+// A nil client uses http.DefaultClient.
+func NewGCS(cfg config.GCSStorageConfig, client *http.Client) (*GCS, error) {
+	if cfg.Bucket == "" {
+		return nil, errors.New("gcs bucket is required")
+	}
+
+	if client == nil {
+		client = http.DefaultClient
+	}
+
+	endpoint := cfg.Endpoint
+	if endpoint == "" {
+		endpoint = gcsDefaultEndpoint
+	}
+
+	endpointURL, err := url.Parse(strings.TrimRight(endpoint, "/"))
+	if err != nil || endpointURL.Host == "" {
+		return nil, fmt.Errorf("invalid gcs endpoint %q", endpoint)
+	}
+
+	g := &GCS{
+		bucket:   cfg.Bucket,
+		endpoint: endpointURL,
+		kmsKey:   cfg.KMSKeyName,
+		client:   client,
+		now:      time.Now,
+	}
+
+	credentials := []byte(cfg.CredentialsJSON)
+
+	if cfg.CredentialsFile != "" {
+		credentials, err = os.ReadFile(cfg.CredentialsFile)
+		if err != nil {
+			return nil, fmt.Errorf("read gcs credentials: %w", err)
+		}
+	}
+
+	if len(credentials) == 0 {
+		g.anonymous = cfg.Endpoint != ""
+
+		return g, nil
+	}
+
+	if loadErr := g.loadServiceAccount(credentials); loadErr != nil {
+		return nil, loadErr
+	}
+
+	return g, nil
+}
+
+// loadServiceAccount reads the client email and private key of a service account key
+func (g *GCS) loadServiceAccount(credentials []byte) error {
+	var account gcsServiceAccount
+	if err := json.Unmarshal(credentials, &account); err != nil {
+		return fmt.Errorf("parse gcs credentials: %w", err)
+	}
+
+	block, _ := pem.Decode([]byte(account.PrivateKey))
+	if account.ClientEmail == "" || block == nil {
+		return errors.New("gcs credentials must be a service account key with client_email and private_key")
+	}
+
+	parsed, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		parsed, err = x509.ParsePKCS1PrivateKey(block.Bytes)
+	}
+
+	key, ok := parsed.(*rsa.PrivateKey)
+	if err != nil || !ok {
+		return errors.New("gcs credentials private key must be an RSA key")
+	}
+
+	g.email = account.ClientEmail
+	g.key = key
+	g.tokenURI = account.TokenURI
+
+	if g.tokenURI == "" {
+		g.tokenURI = gcsDefaultTokenURI
+	}
+
+	return nil
+}
+
+// Put uploads data to key, encrypted with the configured KMS key if any
+func (g *GCS) Put(ctx context.Context, key string, data []byte) error {
+	name, err := cleanKey(key)
+	if err != nil {
+		return err
+	}
+
+	query := url.Values{"uploadType": {"media"}, "name": {name}}
+	if g.kmsKey != "" {
+		query.Set("kmsKeyName", g.kmsKey)
+	}
+
+	resp, err := g.do(ctx, http.MethodPost, "/upload/storage/v1/b/"+url.PathEscape(g.bucket)+"/o", query, data)
+	if err != nil {
+		return fmt.Errorf("write object %s: %w", key, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return responseError("write object", key, resp)
+	}
+
+	return nil
+}
+
+// Get downloads key
+func (g *GCS) Get(ctx context.Context, key string) ([]byte, error) {
+	name, err := cleanKey(key)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := g.do(ctx, http.MethodGet, g.objectPath(name), url.Values{"alt": {"media"}}, nil)
+	if err != nil {
+		return nil, fmt.Errorf("read object %s: %w", key, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, responseError("read object", key, resp)
+	}
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("read object %s: %w", key, err)
+	}
+
+	return data, nil
+}
+
+// List lists the keys under prefix, following result pages
+func (g *GCS) List(ctx context.Context, prefix string) ([]string, error) {
+	dir, err := cleanKey(prefix)
+	if err != nil {
+		return nil, err
+	}
+
+	var keys []string
+
+	query := url.Values{"prefix": {dir + "/"}, "fields": {"items(name),nextPageToken"}}
+
+	for {
+		page, pageErr := g.listPage(ctx, query)
+		if pageErr != nil {
+			return nil, fmt.Errorf("list objects under %s: %w", prefix, pageErr)
+		}
+
+		for _, item := range page.Items {
+			keys = append(keys, item.Name)
+		}
+
+		if page.NextPageToken == "" {
+			break
+		}
+
+		query.Set("pageToken", page.NextPageToken)
+	}
+
+	sort.Strings(keys)
+
+	return keys, nil
+}
+
+// gcsObjectList is one page of an object listing
+type gcsObjectList struct {
+	Items []struct {
+		Name string `json:"name"`
+	} `json:"items"`
+	NextPageToken string `json:"nextPageToken"`
+}
+
+// listPage fetches one page of an object listing
+func (g *GCS) listPage(ctx context.Context, query url.Values) (*gcsObjectList, error) {
+	resp, err := g.do(ctx, http.MethodGet, "/storage/v1/b/"+url.PathEscape(g.bucket)+"/o", query, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, responseError("list", g.bucket, resp)
+	}
+
+	var page gcsObjectList
+	if decodeErr := json.NewDecoder(resp.Body).Decode(&page); decodeErr != nil {
+		return nil, fmt.Errorf("decode object list: %w", decodeErr)
+	}
+
+	return &page, nil
+}
+
+// Delete removes key
+func (g *GCS) Delete(ctx context.Context, key string) error {
+	name, err := cleanKey(key)
+	if err != nil {
+		return err
+	}
+
+	resp, err := g.do(ctx, http.MethodDelete, g.objectPath(name), nil, nil)
+	if err != nil {
+		return fmt.Errorf("delete object %s: %w", key, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusNoContent && resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusNotFound {
+		return responseError("delete object", key, resp)
+	}
+
+	return nil
+}
+
+// SignedURL returns a V4 signed download URL for key, signed with the
+// service account key
+func (g *GCS) SignedURL(_ context.Context, key string, ttl time.Duration) (string, error) {
+	if g.key == nil {
+		return "", ErrSignedURLUnsupported
+	}
+
+	name, err := cleanKey(key)
+	if err != nil {
+		return "", err
+	}
+
+	now := g.now().UTC()
+	timestamp := now.Format("20060102T150405Z")
+	scope := now.Format("20060102") + "/auto/storage/goog4_request"
+	resource := "/" + url.PathEscape(g.bucket) + "/" + escapePath(name)
+
+	query := url.Values{
+		"X-Goog-Algorithm":     {gcsSigningAlgorithm},
+		"X-Goog-Credential":    {g.email + "/" + scope},
+		"X-Goog-Date":          {timestamp},
+		"X-Goog-Expires":       {strconv.Itoa(int(ttl.Seconds()))},
+		"X-Goog-SignedHeaders": {"host"},
+	}
+	canonicalQuery := strings.ReplaceAll(query.Encode(), "+", "%20")
+
+	canonicalRequest := strings.Join([]string{
+		http.MethodGet,
+		resource,
+		canonicalQuery,
+		"host:" + g.endpoint.Host + "\n",
+		"host",
+		"UNSIGNED-PAYLOAD",
+	}, "\n")
+	requestHash := sha256.Sum256([]byte(canonicalRequest))
+
+	stringToSign := strings.Join([]string{gcsSigningAlgorithm, timestamp, scope, hex.EncodeToString(requestHash[:])}, "\n")
+	digest := sha256.Sum256([]byte(stringToSign))
+
+	signature, err := rsa.SignPKCS1v15(nil, g.key, crypto.SHA256, digest[:])
+	if err != nil {
+		return "", fmt.Errorf("sign url for %s: %w", key, err)
+	}
+
+	return fmt.Sprintf("%s://%s%s?%s&X-Goog-Signature=%s",
+		g.endpoint.Scheme, g.endpoint.Host, resource, canonicalQuery, hex.EncodeToString(signature)), nil
+}
+
+// objectPath is the JSON API path of an object
+func (g *GCS) objectPath(name string) string {
+	return "/storage/v1/b/" + url.PathEscape(g.bucket) + "/o/" + url.PathEscape(name)
+}
+
+// do sends an authorized request to the JSON API
+func (g *GCS) do(ctx context.Context, method, apiPath string, query url.Values, body []byte) (*http.Response, error) {
+	target := *g.endpoint
+	target.RawPath = g.endpoint.Path + apiPath
+	target.Path, _ = url.PathUnescape(target.RawPath)
+	target.RawQuery = query.Encode()
+
+	var reader io.Reader
+	if body != nil {
+		reader = bytes.NewReader(body)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, target.String(), reader)
+	if err != nil {
+		return nil, fmt.Errorf("create request: %w", err)
+	}
+
+	if body != nil {
+		req.Header.Set("Content-Type", "application/octet-stream")
+	}
+
+	if !g.anonymous {
+		token, tokenErr := g.accessToken(ctx)
+		if tokenErr != nil {
+			return nil, tokenErr
+		}
+
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+
+	resp, err := g.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("send request: %w", err)
+	}
+
+	return resp, nil
+}
+
+// accessToken returns a cached access token, fetching a new one when it is
+// about to expire. The fetch happens outside the lock; concurrent refreshes
+// are harmless.
+func (g *GCS) accessToken(ctx context.Context) (string, error) {
+	g.mu.Lock()
+	token, expiry := g.token, g.tokenExpiry
+	g.mu.Unlock()
+
+	if token != "" && g.now().Before(expiry.Add(-gcsTokenRefreshMargin)) {
+		return token, nil
+	}
+
+	token, lifetime, err := g.fetchToken(ctx)
+	if err != nil {
+		return "", fmt.Errorf("get gcs access token: %w", err)
+	}
+
+	g.mu.Lock()
+	g.token, g.tokenExpiry = token, g.now().Add(lifetime)
+	g.mu.Unlock()
+
+	return token, nil
+}
+
+// fetchToken exchanges a signed JWT for an access token, or asks the metadata
+// server for one without a service account key
+func (g *GCS) fetchToken(ctx context.Context) (string, time.Duration, error) {
+	var req *http.Request
+
+	if g.key != nil {
+		assertion, err := g.jwtAssertion()
+		if err != nil {
+			return "", 0, err
+		}
+
+		form := url.Values{
+			"grant_type": {"urn:ietf:params:oauth:grant-type:jwt-bearer"},
+			"assertion":  {assertion},
+		}
+
+		req, err = http.NewRequestWithContext(ctx, http.MethodPost, g.tokenURI, strings.NewReader(form.Encode()))
+		if err != nil {
+			return "", 0, fmt.Errorf("create token request: %w", err)
+		}
+
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	} else {
+		var err error
+
+		req, err = http.NewRequestWithContext(ctx, http.MethodGet, gcsMetadataTokenURL, http.NoBody)
+		if err != nil {
+			return "", 0, fmt.Errorf("create token request: %w", err)
+		}
+
+		req.Header.Set("Metadata-Flavor", "Google")
+	}
+
+	resp, err := g.client.Do(req)
+	if err != nil {
+		return "", 0, fmt.Errorf("send token request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return "", 0, responseError("token request", req.URL.Host, resp)
+	}
+
+	var body struct {
+		AccessToken string `json:"access_token"`
+		ExpiresIn   int    `json:"expires_in"`
+	}
+	if decodeErr := json.NewDecoder(resp.Body).Decode(&body); decodeErr != nil || body.AccessToken == "" {
+		return "", 0, errors.New("token response has no access token")
+	}
+
+	return body.AccessToken, time.Duration(body.ExpiresIn) * time.Second, nil
+}
+
+// jwtAssertion builds the RS256 JWT a service account exchanges for an access token
+func (g *GCS) jwtAssertion() (string, error) {
+	now := g.now()
+
+	claims, err := json.Marshal(map[string]any{
+		"iss":   g.email,
+		"scope": gcsScope,
+		"aud":   g.tokenURI,
+		"iat":   now.Unix(),
+		"exp":   now.Add(gcsTokenLifetime).Unix(),
+	})
+	if err != nil {
+		return "", fmt.Errorf("encode jwt claims: %w", err)
+	}
+
+	encoding := base64.RawURLEncoding
+	signingInput := encoding.EncodeToString([]byte(`{"alg":"RS256","typ":"JWT"}`)) + "." + encoding.EncodeToString(claims)
+	digest := sha256.Sum256([]byte(signingInput))
+
+	signature, err := rsa.SignPKCS1v15(nil, g.key, crypto.SHA256, digest[:])
+	if err != nil {
+		return "", fmt.Errorf("sign jwt: %w", err)
+	}
+
+	return signingInput + "." + encoding.EncodeToString(signature), nil
+}
@@ -0,0 +1,206 @@
+package storage_test
+
+import (
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/hex"
+	"encoding/json"
+	"encoding/pem"
+	"io"
+	"io/fs"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/goformx/goforms/internal/infrastructure/config"
+	"github.com/goformx/goforms/internal/infrastructure/storage"
+)
+
+// fakeGCS serves the parts of the GCS JSON API and token endpoint the driver uses
+type fakeGCS struct {
+	mu      sync.Mutex
+	objects map[string][]byte
+	kmsKeys map[string]string
+	tokens  int
+}
+
+func (f *fakeGCS) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if r.URL.Path == "/token" {
+		f.tokens++
+		_ = json.NewEncoder(w).Encode(map[string]any{"access_token": "token-1", "expires_in": 3600})
+
+		return
+	}
+
+	if r.Header.Get("Authorization") != "Bearer token-1" {
+		w.WriteHeader(http.StatusUnauthorized)
+
+		return
+	}
+
+	const objects = "/storage/v1/b/uploads/o"
+
+	switch {
+	case r.Method == http.MethodPost && r.URL.Path == "/upload"+objects:
+		data, _ := io.ReadAll(r.Body)
+		name := r.URL.Query().Get("name")
+		f.objects[name] = data
+		f.kmsKeys[name] = r.URL.Query().Get("kmsKeyName")
+		_ = json.NewEncoder(w).Encode(map[string]string{"name": name})
+	case r.Method == http.MethodGet && r.URL.Path == objects:
+		var items []map[string]string
+
+		for name := range f.objects {
+			if strings.HasPrefix(name, r.URL.Query().Get("prefix")) {
+				items = append(items, map[string]string{"name": name})
+			}
+		}
+
+		_ = json.NewEncoder(w).Encode(map[string]any{"items": items})
+	case strings.HasPrefix(r.URL.Path, objects+"/"):
+		name := strings.TrimPrefix(r.URL.Path, objects+"/")
+
+		data, ok := f.objects[name]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+
+			return
+		}
+
+		if r.Method == http.MethodDelete {
+			delete(f.objects, name)
+			w.WriteHeader(http.StatusNoContent)
+
+			return
+		}
+
+		_, _ = w.Write(data)
+	default:
+		w.WriteHeader(http.StatusBadRequest)
+	}
+}
+
+func TestGCS(t *testing.T) {
+	fake := &fakeGCS{objects: make(map[string][]byte), kmsKeys: make(map[string]string)}
+	server := httptest.NewServer(fake)
+	t.Cleanup(server.Close)
+
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	gcs, err := storage.NewGCS(config.GCSStorageConfig{
+		Bucket:          "uploads",
+		CredentialsFile: writeServiceAccount(t, key, server.URL+"/token"),
+		KMSKeyName:      "projects/p/locations/global/keyRings/r/cryptoKeys/k",
+		Endpoint:        server.URL,
+	}, server.Client())
+	require.NoError(t, err)
+
+	ctx := t.Context()
+
+	require.NoError(t, gcs.Put(ctx, "backups/b.json", []byte("b")))
+	require.NoError(t, gcs.Put(ctx, "backups/a.json", []byte("a")))
+	require.NoError(t, gcs.Put(ctx, "backups-old/c.json", []byte("c")))
+	assert.Equal(t, "projects/p/locations/global/keyRings/r/cryptoKeys/k", fake.kmsKeys["backups/a.json"])
+
+	data, err := gcs.Get(ctx, "backups/a.json")
+	require.NoError(t, err)
+	assert.Equal(t, "a", string(data))
+
+	keys, err := gcs.List(ctx, "backups")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"backups/a.json", "backups/b.json"}, keys)
+
+	require.NoError(t, gcs.Delete(ctx, "backups/a.json"))
+	require.NoError(t, gcs.Delete(ctx, "backups/a.json"), "deleting a missing key is not an error")
+
+	_, err = gcs.Get(ctx, "backups/a.json")
+	require.ErrorIs(t, err, fs.ErrNotExist)
+
+	assert.Equal(t, 1, fake.tokens, "access tokens are reused until they expire")
+
+	_, err = gcs.Get(ctx, "../secret")
+	require.ErrorIs(t, err, storage.ErrInvalidKey)
+}
+
+func TestGCS_SignedURL(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	gcs, err := storage.NewGCS(config.GCSStorageConfig{
+		Bucket:          "uploads",
+		CredentialsFile: writeServiceAccount(t, key, ""),
+	}, nil)
+	require.NoError(t, err)
+
+	signed, err := gcs.SignedURL(t.Context(), "files/report 1.pdf", 15*time.Minute)
+	require.NoError(t, err)
+
+	parsed, err := url.Parse(signed)
+	require.NoError(t, err)
+	assert.Equal(t, "storage.googleapis.com", parsed.Host)
+	assert.Equal(t, "/uploads/files/report%201.pdf", parsed.EscapedPath())
+
+	query := parsed.Query()
+	assert.Equal(t, "GOOG4-RSA-SHA256", query.Get("X-Goog-Algorithm"))
+	assert.Equal(t, "900", query.Get("X-Goog-Expires"))
+	assert.True(t, strings.HasPrefix(query.Get("X-Goog-Credential"), "uploader@example.iam.gserviceaccount.com/"))
+
+	// The signature verifies against the V4 string to sign
+	signature, err := hex.DecodeString(query.Get("X-Goog-Signature"))
+	require.NoError(t, err)
+
+	canonicalQuery := strings.SplitN(parsed.RawQuery, "&X-Goog-Signature=", 2)[0]
+	canonicalRequest := "GET\n/uploads/files/report%201.pdf\n" + canonicalQuery +
+		"\nhost:storage.googleapis.com\n\nhost\nUNSIGNED-PAYLOAD"
+	requestHash := sha256.Sum256([]byte(canonicalRequest))
+	credential := strings.SplitN(query.Get("X-Goog-Credential"), "/", 2)[1]
+	stringToSign := "GOOG4-RSA-SHA256\n" + query.Get("X-Goog-Date") + "\n" + credential + "\n" + hex.EncodeToString(requestHash[:])
+	digest := sha256.Sum256([]byte(stringToSign))
+
+	require.NoError(t, rsa.VerifyPKCS1v15(&key.PublicKey, crypto.SHA256, digest[:], signature))
+}
+
+func TestGCS_SignedURLNeedsKey(t *testing.T) {
+	gcs, err := storage.NewGCS(config.GCSStorageConfig{Bucket: "uploads", Endpoint: "http://localhost:4443"}, nil)
+	require.NoError(t, err)
+
+	_, err = gcs.SignedURL(t.Context(), "files/a.pdf", time.Minute)
+	require.ErrorIs(t, err, storage.ErrSignedURLUnsupported)
+}
+
+// writeServiceAccount writes a service account key file for key
+func writeServiceAccount(t *testing.T, key *rsa.PrivateKey, tokenURI string) string {
+	t.Helper()
+
+	der, err := x509.MarshalPKCS8PrivateKey(key)
+	require.NoError(t, err)
+
+	data, err := json.Marshal(map[string]string{
+		"type":         "service_account",
+		"client_email": "uploader@example.iam.gserviceaccount.com",
+		"private_key":  string(pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der})),
+		"token_uri":    tokenURI,
+	})
+	require.NoError(t, err)
+
+	name := filepath.Join(t.TempDir(), "key.json")
+	require.NoError(t, os.WriteFile(name, data, 0o600))
+
+	return name
+}
@@ -0,0 +1,126 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// Local stores objects as files under a root directory (storage.type=local)
+type Local struct {
+	root string
+}
+
+// NewLocal creates a local storage driver rooted at dir
+func NewLocal(dir string) *Local {
+	return &Local{root: dir}
+}
+
+// Put writes data to key. The file is written next to its destination and
+// renamed into place, so readers never see a partial object.
+func (l *Local) Put(_ context.Context, key string, data []byte) error {
+	name, err := l.path(key)
+	if err != nil {
+		return err
+	}
+
+	if mkdirErr := os.MkdirAll(filepath.Dir(name), 0o750); mkdirErr != nil {
+		return fmt.Errorf("create storage directory: %w", mkdirErr)
+	}
+
+	tmp := name + ".tmp"
+	if writeErr := os.WriteFile(tmp, data, 0o600); writeErr != nil {
+		return fmt.Errorf("write object %s: %w", key, writeErr)
+	}
+
+	if renameErr := os.Rename(tmp, name); renameErr != nil {
+		_ = os.Remove(tmp)
+
+		return fmt.Errorf("write object %s: %w", key, renameErr)
+	}
+
+	return nil
+}
+
+// Get reads key; a missing key returns an error wrapping fs.ErrNotExist
+func (l *Local) Get(_ context.Context, key string) ([]byte, error) {
+	name, err := l.path(key)
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := os.ReadFile(name)
+	if err != nil {
+		return nil, fmt.Errorf("read object %s: %w", key, err)
+	}
+
+	return data, nil
+}
+
+// List lists the keys under prefix, in lexical order
+func (l *Local) List(_ context.Context, prefix string) ([]string, error) {
+	dir, err := l.path(prefix)
+	if err != nil {
+		return nil, err
+	}
+
+	var keys []string
+
+	walkErr := filepath.WalkDir(dir, func(name string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+
+		if entry.IsDir() || strings.HasSuffix(name, ".tmp") {
+			return nil
+		}
+
+		rel, relErr := filepath.Rel(l.root, name)
+		if relErr != nil {
+			return relErr
+		}
+
+		keys = append(keys, filepath.ToSlash(rel))
+
+		return nil
+	})
+	if walkErr != nil && !errors.Is(walkErr, fs.ErrNotExist) {
+		return nil, fmt.Errorf("list objects under %s: %w", prefix, walkErr)
+	}
+
+	return keys, nil
+}
+
+// Delete removes key; deleting a missing key is not an error
+func (l *Local) Delete(_ context.Context, key string) error {
+	name, err := l.path(key)
+	if err != nil {
+		return err
+	}
+
+	if removeErr := os.Remove(name); removeErr != nil && !errors.Is(removeErr, fs.ErrNotExist) {
+		return fmt.Errorf("delete object %s: %w", key, removeErr)
+	}
+
+	return nil
+}
+
+// SignedURL is not supported; local files are served through the application
+func (l *Local) SignedURL(_ context.Context, _ string, _ time.Duration) (string, error) {
+	return "", ErrSignedURLUnsupported
+}
+
+// path maps a key to a file under the root, refusing keys that escape it
+func (l *Local) path(key string) (string, error) {
+	name, err := cleanKey(key)
+	if err != nil {
+		return "", err
+	}
+
+	return filepath.Join(l.root, filepath.FromSlash(name)), nil
+}
@@ -0,0 +1,47 @@
+package storage_test
+
+import (
+	"context"
+	"io/fs"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/goformx/goforms/internal/infrastructure/storage"
+)
+
+func TestLocal(t *testing.T) {
+	ctx := context.Background()
+	local := storage.NewLocal(t.TempDir())
+
+	require.NoError(t, local.Put(ctx, "backups/b.json", []byte("b")))
+	require.NoError(t, local.Put(ctx, "backups/a.json", []byte("a")))
+
+	data, err := local.Get(ctx, "backups/a.json")
+	require.NoError(t, err)
+	assert.Equal(t, "a", string(data))
+
+	keys, err := local.List(ctx, "backups")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"backups/a.json", "backups/b.json"}, keys)
+
+	require.NoError(t, local.Delete(ctx, "backups/a.json"))
+	require.NoError(t, local.Delete(ctx, "backups/a.json"), "deleting a missing key is not an error")
+
+	_, err = local.Get(ctx, "backups/a.json")
+	require.ErrorIs(t, err, fs.ErrNotExist)
+
+	keys, err = local.List(ctx, "missing")
+	require.NoError(t, err)
+	assert.Empty(t, keys)
+}
+
+func TestLocal_RejectsKeysOutsideRoot(t *testing.T) {
+	local := storage.NewLocal(t.TempDir())
+
+	for _, key := range []string{"", "/", "../secret", "backups/../../secret"} {
+		_, err := local.Get(context.Background(), key)
+		require.ErrorIs(t, err, storage.ErrInvalidKey, key)
+	}
+}
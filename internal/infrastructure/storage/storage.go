@@ -0,0 +1,97 @@
+// Package storage provides storage backend drivers. Objects are addressed by
+// slash-separated keys relative to the backend's root.
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"net/http"
+	"net/url"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/goformx/goforms/internal/infrastructure/config"
+	"github.com/goformx/goforms/internal/infrastructure/httpclient"
+)
+
+// errorBodyLimit bounds how much of an error response is kept for the error message
+const errorBodyLimit = 512
+
+var (
+	// ErrInvalidKey is returned for a key that is empty or escapes the storage root
+	ErrInvalidKey = errors.New("invalid storage key")
+	// ErrSignedURLUnsupported is returned by backends that cannot sign download URLs
+	ErrSignedURLUnsupported = errors.New("signed URLs are not supported by this storage backend")
+)
+
+// Storage is an object storage backend
+type Storage interface {
+	// Put writes data to key, replacing any existing object
+	Put(ctx context.Context, key string, data []byte) error
+	// Get reads key; a missing key returns an error wrapping fs.ErrNotExist
+	Get(ctx context.Context, key string) ([]byte, error)
+	// List lists the keys under the directory prefix, in lexical order
+	List(ctx context.Context, prefix string) ([]string, error)
+	// Delete removes key; deleting a missing key is not an error
+	Delete(ctx context.Context, key string) error
+	// SignedURL returns a URL that downloads key without credentials until ttl passes
+	SignedURL(ctx context.Context, key string, ttl time.Duration) (string, error)
+}
+
+var (
+	_ Storage = (*Local)(nil)
+	_ Storage = (*GCS)(nil)
+	_ Storage = (*Azure)(nil)
+)
+
+// New creates the driver selected by storage.type. Cloud drivers call their
+// service with a client from clients.
+func New(cfg config.StorageConfig, clients *httpclient.Factory) (Storage, error) {
+	switch strings.ToLower(cfg.Type) {
+	case config.StorageTypeLocal:
+		return NewLocal(cfg.Local.Path), nil
+	case config.StorageTypeGCS:
+		return NewGCS(cfg.GCS, clients.Client(config.ResilienceTargetStorage, config.StorageTypeGCS, 0))
+	case config.StorageTypeAzure:
+		return NewAzure(cfg.Azure, clients.Client(config.ResilienceTargetStorage, config.StorageTypeAzure, 0))
+	default:
+		return nil, fmt.Errorf("unsupported storage type %q", cfg.Type)
+	}
+}
+
+// cleanKey returns key without surrounding slashes, refusing keys that are
+// empty or escape the storage root
+func cleanKey(key string) (string, error) {
+	clean := path.Clean("/" + key)
+	if clean == "/" || strings.Trim(key, "/") != strings.TrimPrefix(clean, "/") {
+		return "", fmt.Errorf("%w: %q", ErrInvalidKey, key)
+	}
+
+	return strings.TrimPrefix(clean, "/"), nil
+}
+
+// escapePath escapes each segment of an object name for use in a URL path
+func escapePath(name string) string {
+	segments := strings.Split(name, "/")
+	for i, segment := range segments {
+		segments[i] = url.PathEscape(segment)
+	}
+
+	return strings.Join(segments, "/")
+}
+
+// responseError describes an unexpected backend response. Not found responses
+// wrap fs.ErrNotExist.
+func responseError(op, key string, resp *http.Response) error {
+	if resp.StatusCode == http.StatusNotFound {
+		return fmt.Errorf("%s %s: %w", op, key, fs.ErrNotExist)
+	}
+
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, errorBodyLimit))
+
+	return fmt.Errorf("%s %s: unexpected status %d: %s", op, key, resp.StatusCode, strings.TrimSpace(string(body)))
+}
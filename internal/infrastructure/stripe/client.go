@@ -0,0 +1,142 @@
+// Package stripe talks to the Stripe API for billing: it creates Checkout and
+// Customer Portal sessions and authenticates and decodes webhook events. It
+// uses the REST API directly, with form-encoded requests.
+package stripe
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/goformx/goforms/internal/domain/billing"
+)
+
+const (
+	// defaultEndpoint is the Stripe API base URL
+	defaultEndpoint = "https://api.stripe.com"
+	// defaultTimeout bounds each API call
+	defaultTimeout = 10 * time.Second
+	// maxResponseBody bounds how much of a response is read
+	maxResponseBody = 1 << 20
+)
+
+// Client creates Stripe Checkout and Customer Portal sessions; it implements billing.Gateway
+type Client struct {
+	secretKey string
+	endpoint  string
+	client    *http.Client
+}
+
+// NewClient creates a Stripe API client. An empty endpoint uses api.stripe.com,
+// and a nil transport http.DefaultTransport.
+func NewClient(secretKey, endpoint string, timeout time.Duration, transport http.RoundTripper) *Client {
+	if endpoint == "" {
+		endpoint = defaultEndpoint
+	}
+
+	if timeout <= 0 {
+		timeout = defaultTimeout
+	}
+
+	return &Client{
+		secretKey: secretKey,
+		endpoint:  strings.TrimSuffix(endpoint, "/"),
+		client:    &http.Client{Timeout: timeout, Transport: transport},
+	}
+}
+
+// session is the part of a Checkout or Portal session response that is used
+type session struct {
+	URL string `json:"url"`
+}
+
+// apiError is Stripe's error response
+type apiError struct {
+	Error struct {
+		Type    string `json:"type"`
+		Message string `json:"message"`
+	} `json:"error"`
+}
+
+// CreateCheckoutSession creates a subscription-mode Checkout session. The user
+// ID is set as the session's client reference and as user_id metadata on the
+// subscription, where webhooks read it.
+func (c *Client) CreateCheckoutSession(ctx context.Context, checkout *billing.Checkout) (string, error) {
+	form := url.Values{
+		"mode":                                 {"subscription"},
+		"line_items[0][price]":                 {checkout.PriceID},
+		"line_items[0][quantity]":              {"1"},
+		"success_url":                          {checkout.SuccessURL},
+		"cancel_url":                           {checkout.CancelURL},
+		"client_reference_id":                  {checkout.UserID},
+		"subscription_data[metadata][user_id]": {checkout.UserID},
+	}
+
+	if checkout.CustomerID != "" {
+		form.Set("customer", checkout.CustomerID)
+	}
+
+	var created session
+	if err := c.post(ctx, "/v1/checkout/sessions", form, &created); err != nil {
+		return "", fmt.Errorf("create stripe checkout session: %w", err)
+	}
+
+	return created.URL, nil
+}
+
+// CreatePortalSession creates a Customer Portal session returning to returnURL
+func (c *Client) CreatePortalSession(ctx context.Context, customerID, returnURL string) (string, error) {
+	form := url.Values{"customer": {customerID}}
+	if returnURL != "" {
+		form.Set("return_url", returnURL)
+	}
+
+	var created session
+	if err := c.post(ctx, "/v1/billing_portal/sessions", form, &created); err != nil {
+		return "", fmt.Errorf("create stripe portal session: %w", err)
+	}
+
+	return created.URL, nil
+}
+
+// post sends a form-encoded API request and decodes the response into out
+func (c *Client) post(ctx context.Context, path string, form url.Values, out any) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.endpoint+path, strings.NewReader(form.Encode()))
+	if err != nil {
+		return fmt.Errorf("build request: %w", err)
+	}
+
+	req.Header.Set("Authorization", "Bearer "+c.secretKey)
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("send request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxResponseBody))
+	if err != nil {
+		return fmt.Errorf("read response: %w", err)
+	}
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		var failure apiError
+		if jsonErr := json.Unmarshal(body, &failure); jsonErr == nil && failure.Error.Message != "" {
+			return fmt.Errorf("stripe returned %d: %s", resp.StatusCode, failure.Error.Message)
+		}
+
+		return fmt.Errorf("stripe returned %d", resp.StatusCode)
+	}
+
+	if err := json.Unmarshal(body, out); err != nil {
+		return fmt.Errorf("decode response: %w", err)
+	}
+
+	return nil
+}
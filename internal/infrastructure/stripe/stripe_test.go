@@ -0,0 +1,110 @@
+package stripe_test
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/goformx/goforms/internal/domain/billing"
+	"github.com/goformx/goforms/internal/infrastructure/stripe"
+)
+
+func sign(secret string, timestamp int64, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	fmt.Fprintf(mac, "%d.", timestamp)
+	mac.Write(body)
+
+	return fmt.Sprintf("t=%d,v1=%s", timestamp, hex.EncodeToString(mac.Sum(nil)))
+}
+
+func TestWebhookVerifier(t *testing.T) {
+	verifier := stripe.NewWebhookVerifier("whsec_test", 0)
+	body := []byte(`{"id":"evt_1"}`)
+	now := time.Now().Unix()
+
+	require.NoError(t, verifier.Verify(sign("whsec_test", now, body), body))
+	rolled := sign("whsec_old", now, body) + "," + strings.SplitN(sign("whsec_test", now, body), ",", 2)[1]
+	require.NoError(t, verifier.Verify(rolled, body), "any v1 signature may match")
+	require.ErrorIs(t, verifier.Verify(sign("whsec_other", now, body), body), stripe.ErrWebhookSignature)
+	require.ErrorIs(t, verifier.Verify(sign("whsec_test", now, []byte(`{}`)), body), stripe.ErrWebhookSignature)
+	require.ErrorIs(t, verifier.Verify(sign("whsec_test", now-3600, body), body), stripe.ErrWebhookExpired)
+	require.ErrorIs(t, verifier.Verify("", body), stripe.ErrWebhookSignature)
+}
+
+func TestParseEvent(t *testing.T) {
+	body := []byte(`{
+		"id": "evt_1",
+		"type": "customer.subscription.updated",
+		"created": 1767225600,
+		"data": {"object": {
+			"id": "sub_1",
+			"customer": "cus_1",
+			"status": "active",
+			"cancel_at_period_end": true,
+			"metadata": {"user_id": "user-1"},
+			"items": {"data": [{"current_period_end": 1769904000, "price": {"id": "price_pro"}}]}
+		}}
+	}`)
+
+	event, err := stripe.ParseEvent(body)
+	require.NoError(t, err)
+	assert.Equal(t, billing.EventSubscriptionUpdated, event.Type)
+	assert.Equal(t, "user-1", event.UserID)
+	assert.Equal(t, "cus_1", event.CustomerID)
+	assert.Equal(t, "sub_1", event.SubscriptionID)
+	assert.Equal(t, "price_pro", event.PriceID)
+	assert.True(t, event.CancelAtPeriodEnd)
+	require.NotNil(t, event.CurrentPeriodEnd)
+	assert.Equal(t, int64(1769904000), event.CurrentPeriodEnd.Unix())
+	assert.Equal(t, int64(1767225600), event.CreatedAt.Unix())
+
+	other, err := stripe.ParseEvent([]byte(`{"id":"evt_2","type":"invoice.paid","data":{"object":{}}}`))
+	require.NoError(t, err)
+	assert.Equal(t, "invoice.paid", other.Type)
+	assert.Empty(t, other.CustomerID)
+}
+
+func TestClientCreateCheckoutSession(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/v1/checkout/sessions", r.URL.Path)
+		assert.Equal(t, "Bearer sk_test", r.Header.Get("Authorization"))
+		require.NoError(t, r.ParseForm())
+		assert.Equal(t, "subscription", r.PostForm.Get("mode"))
+		assert.Equal(t, "price_pro", r.PostForm.Get("line_items[0][price]"))
+		assert.Equal(t, "user-1", r.PostForm.Get("subscription_data[metadata][user_id]"))
+		assert.Equal(t, "cus_1", r.PostForm.Get("customer"))
+
+		_, _ = w.Write([]byte(`{"id":"cs_1","url":"https://checkout.stripe.com/c/cs_1"}`))
+	}))
+	defer server.Close()
+
+	client := stripe.NewClient("sk_test", server.URL, 0, nil)
+
+	url, err := client.CreateCheckoutSession(t.Context(), &billing.Checkout{
+		UserID: "user-1", CustomerID: "cus_1", PriceID: "price_pro",
+		SuccessURL: "https://app.example.com/billing?done=1", CancelURL: "https://app.example.com/billing",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "https://checkout.stripe.com/c/cs_1", url)
+}
+
+func TestClientReportsAPIErrors(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte(`{"error":{"type":"invalid_request_error","message":"No such customer: 'cus_x'"}}`))
+	}))
+	defer server.Close()
+
+	_, err := stripe.NewClient("sk_test", server.URL, 0, nil).CreatePortalSession(t.Context(), "cus_x", "")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "No such customer")
+}
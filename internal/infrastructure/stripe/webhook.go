@@ -0,0 +1,172 @@
+package stripe
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/goformx/goforms/internal/domain/billing"
+)
+
+// SignatureHeader carries the signature of a webhook delivery
+const SignatureHeader = "Stripe-Signature"
+
+// DefaultTolerance is how old a signed delivery may be, as in Stripe's libraries
+const DefaultTolerance = 5 * time.Minute
+
+var (
+	// ErrWebhookSignature is returned when a delivery's signature does not verify
+	ErrWebhookSignature = errors.New("stripe webhook signature invalid")
+
+	// ErrWebhookExpired is returned when a delivery is older than the tolerance
+	ErrWebhookExpired = errors.New("stripe webhook timestamp outside tolerance")
+)
+
+// WebhookVerifier authenticates deliveries of a Stripe webhook endpoint
+type WebhookVerifier struct {
+	secret    []byte
+	tolerance time.Duration
+	now       func() time.Time
+}
+
+// NewWebhookVerifier creates a verifier for an endpoint's signing secret (whsec_...).
+// A zero tolerance uses DefaultTolerance.
+func NewWebhookVerifier(secret string, tolerance time.Duration) *WebhookVerifier {
+	if tolerance <= 0 {
+		tolerance = DefaultTolerance
+	}
+
+	return &WebhookVerifier{secret: []byte(secret), tolerance: tolerance, now: time.Now}
+}
+
+// Verify checks the Stripe-Signature header against the raw body. The header
+// is "t=<unix seconds>,v1=<hex HMAC-SHA256 of t.body>", with one v1 entry per
+// active signing secret.
+func (v *WebhookVerifier) Verify(header string, body []byte) error {
+	var (
+		timestamp  string
+		signatures [][]byte
+	)
+
+	for part := range strings.SplitSeq(header, ",") {
+		key, value, ok := strings.Cut(strings.TrimSpace(part), "=")
+		if !ok {
+			continue
+		}
+
+		switch key {
+		case "t":
+			timestamp = value
+		case "v1":
+			if decoded, err := hex.DecodeString(value); err == nil {
+				signatures = append(signatures, decoded)
+			}
+		}
+	}
+
+	seconds, err := strconv.ParseInt(timestamp, 10, 64)
+	if err != nil || len(signatures) == 0 {
+		return ErrWebhookSignature
+	}
+
+	age := v.now().Sub(time.Unix(seconds, 0))
+	if age > v.tolerance || age < -v.tolerance {
+		return ErrWebhookExpired
+	}
+
+	mac := hmac.New(sha256.New, v.secret)
+	mac.Write([]byte(timestamp))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	expected := mac.Sum(nil)
+
+	for _, signature := range signatures {
+		if hmac.Equal(signature, expected) {
+			return nil
+		}
+	}
+
+	return ErrWebhookSignature
+}
+
+// event is the envelope of a webhook delivery
+type event struct {
+	ID      string `json:"id"`
+	Type    string `json:"type"`
+	Created int64  `json:"created"`
+	Data    struct {
+		Object json.RawMessage `json:"object"`
+	} `json:"data"`
+}
+
+// subscription is the part of a subscription object that is used. Newer API
+// versions report the billing period on the subscription items.
+type subscription struct {
+	ID                string            `json:"id"`
+	Customer          string            `json:"customer"`
+	Status            string            `json:"status"`
+	CurrentPeriodEnd  int64             `json:"current_period_end"`
+	CancelAtPeriodEnd bool              `json:"cancel_at_period_end"`
+	Metadata          map[string]string `json:"metadata"`
+	Items             struct {
+		Data []struct {
+			CurrentPeriodEnd int64 `json:"current_period_end"`
+			Price            struct {
+				ID string `json:"id"`
+			} `json:"price"`
+		} `json:"data"`
+	} `json:"items"`
+}
+
+// ParseEvent decodes a verified delivery. Subscription events carry the
+// subscription's state; other events carry only their ID and type.
+func ParseEvent(body []byte) (*billing.Event, error) {
+	var envelope event
+	if err := json.Unmarshal(body, &envelope); err != nil {
+		return nil, fmt.Errorf("decode stripe event: %w", err)
+	}
+
+	parsed := &billing.Event{
+		ID:        envelope.ID,
+		Type:      envelope.Type,
+		CreatedAt: time.Unix(envelope.Created, 0).UTC(),
+	}
+
+	if !strings.HasPrefix(envelope.Type, "customer.subscription.") {
+		return parsed, nil
+	}
+
+	var sub subscription
+	if err := json.Unmarshal(envelope.Data.Object, &sub); err != nil {
+		return nil, fmt.Errorf("decode stripe subscription: %w", err)
+	}
+
+	parsed.UserID = sub.Metadata["user_id"]
+	parsed.CustomerID = sub.Customer
+	parsed.SubscriptionID = sub.ID
+	parsed.Status = sub.Status
+	parsed.CancelAtPeriodEnd = sub.CancelAtPeriodEnd
+
+	periodEnd := sub.CurrentPeriodEnd
+
+	if len(sub.Items.Data) > 0 {
+		parsed.PriceID = sub.Items.Data[0].Price.ID
+
+		if periodEnd == 0 {
+			periodEnd = sub.Items.Data[0].CurrentPeriodEnd
+		}
+	}
+
+	if periodEnd > 0 {
+		end := time.Unix(periodEnd, 0).UTC()
+		parsed.CurrentPeriodEnd = &end
+	}
+
+	return parsed, nil
+}
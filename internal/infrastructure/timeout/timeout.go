@@ -0,0 +1,91 @@
+// Package timeout splits the time of a request among the layers serving it.
+// The context middleware attaches the configured Budgets to each request's
+// context, which already carries the request deadline; the database and the
+// outbound HTTP clients then derive each statement's and call's context with
+// Database and Outbound. A layer's context ends after the layer budget or
+// Reserve before the request deadline, whichever comes first, so slow work is
+// cancelled while there is still time to answer. Contexts without budgets,
+// such as those of background jobs, are left alone.
+package timeout
+
+import (
+	"context"
+	"time"
+
+	"github.com/goformx/goforms/internal/infrastructure/config"
+)
+
+// Budgets are the time budgets of the layers serving one request
+type Budgets struct {
+	// Database bounds each database statement; zero for no layer limit
+	Database time.Duration
+	// Outbound bounds each outbound HTTP call; zero for no layer limit
+	Outbound time.Duration
+	// Reserve is kept back from the request deadline to write the response
+	Reserve time.Duration
+}
+
+// FromConfig returns the budgets of timeouts.*
+func FromConfig(cfg config.TimeoutsConfig) Budgets {
+	return Budgets{Database: cfg.Database, Outbound: cfg.Outbound, Reserve: cfg.Reserve}
+}
+
+// budgetsKey is the context key of the request's budgets
+type budgetsKey struct{}
+
+// WithBudgets returns a copy of ctx carrying the budgets of a request
+func WithBudgets(ctx context.Context, budgets Budgets) context.Context {
+	return context.WithValue(ctx, budgetsKey{}, budgets)
+}
+
+// FromContext returns the budgets ctx carries
+func FromContext(ctx context.Context) (Budgets, bool) {
+	budgets, ok := ctx.Value(budgetsKey{}).(Budgets)
+
+	return budgets, ok
+}
+
+// Database returns the context of one database statement made with ctx. The
+// cancel function must be called when the statement is done.
+func Database(ctx context.Context) (context.Context, context.CancelFunc) {
+	budgets, ok := FromContext(ctx)
+	if !ok {
+		return ctx, func() {}
+	}
+
+	return within(ctx, budgets.Database, budgets.Reserve)
+}
+
+// Outbound returns the context of one outbound call made with ctx. The
+// cancel function must be called once the response body is closed.
+func Outbound(ctx context.Context) (context.Context, context.CancelFunc) {
+	budgets, ok := FromContext(ctx)
+	if !ok {
+		return ctx, func() {}
+	}
+
+	return within(ctx, budgets.Outbound, budgets.Reserve)
+}
+
+// within returns a context ending after budget, or reserve before the
+// deadline of ctx, whichever is earlier. A context whose remaining time is
+// already inside the reserve ends at once.
+func within(ctx context.Context, budget, reserve time.Duration) (context.Context, context.CancelFunc) {
+	var deadline time.Time
+
+	if budget > 0 {
+		deadline = time.Now().Add(budget)
+	}
+
+	if parent, ok := ctx.Deadline(); ok {
+		if cutoff := parent.Add(-reserve); deadline.IsZero() || cutoff.Before(deadline) {
+			deadline = cutoff
+		}
+	}
+
+	if deadline.IsZero() {
+		return ctx, func() {}
+	}
+
+	return context.WithDeadline(ctx, deadline)
+}
@@ -0,0 +1,66 @@
+package timeout_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/goformx/goforms/internal/infrastructure/timeout"
+)
+
+func TestContextsWithoutBudgetsAreUnchanged(t *testing.T) {
+	ctx := context.Background()
+
+	dbCtx, cancel := timeout.Database(ctx)
+	defer cancel()
+
+	assert.Equal(t, ctx, dbCtx)
+
+	_, ok := dbCtx.Deadline()
+	assert.False(t, ok)
+}
+
+func TestBudgetCapsTheLayer(t *testing.T) {
+	parent, cancelParent := context.WithTimeout(context.Background(), time.Minute)
+	defer cancelParent()
+
+	ctx := timeout.WithBudgets(parent, timeout.Budgets{Database: time.Second, Reserve: time.Second})
+
+	dbCtx, cancel := timeout.Database(ctx)
+	defer cancel()
+
+	deadline, ok := dbCtx.Deadline()
+	require.True(t, ok)
+	assert.WithinDuration(t, time.Now().Add(time.Second), deadline, 100*time.Millisecond)
+}
+
+func TestReserveEndsTheLayerBeforeTheRequest(t *testing.T) {
+	parent, cancelParent := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancelParent()
+
+	parentDeadline, _ := parent.Deadline()
+	ctx := timeout.WithBudgets(parent, timeout.Budgets{Outbound: time.Minute, Reserve: time.Second})
+
+	callCtx, cancel := timeout.Outbound(ctx)
+	defer cancel()
+
+	deadline, ok := callCtx.Deadline()
+	require.True(t, ok)
+	assert.Equal(t, parentDeadline.Add(-time.Second), deadline)
+}
+
+func TestLayerInsideTheReserveEndsAtOnce(t *testing.T) {
+	parent, cancelParent := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancelParent()
+
+	ctx := timeout.WithBudgets(parent, timeout.Budgets{Reserve: time.Second})
+
+	dbCtx, cancel := timeout.Database(ctx)
+	defer cancel()
+
+	require.ErrorIs(t, dbCtx.Err(), context.DeadlineExceeded)
+	assert.NoError(t, parent.Err())
+}
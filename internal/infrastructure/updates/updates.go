@@ -0,0 +1,304 @@
+// Package updates checks GitHub releases for a newer GoForms version. The
+// latest release is fetched from the releases API and compared with the
+// running version; a development build never reports an update. Checks are
+// opt-out through updates.enabled.
+package updates
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/goformx/goforms/internal/infrastructure/config"
+	"github.com/goformx/goforms/internal/infrastructure/httpclient"
+	"github.com/goformx/goforms/internal/infrastructure/logging"
+	"github.com/goformx/goforms/internal/infrastructure/version"
+)
+
+const (
+	// defaultTimeout bounds each releases API call
+	defaultTimeout = 10 * time.Second
+	// maxResponseBody bounds how much of a response is read
+	maxResponseBody = 1 << 20
+	// maxHighlights is how many changelog lines a release keeps
+	maxHighlights = 5
+)
+
+// Release is a published GoForms release
+type Release struct {
+	Version     string    `json:"version"`
+	Name        string    `json:"name"`
+	URL         string    `json:"url"`
+	PublishedAt time.Time `json:"published_at"`
+	// Highlights are the first bullet points of the release notes
+	Highlights []string `json:"highlights,omitempty"`
+}
+
+// Status is the outcome of the last check
+type Status struct {
+	Enabled         bool      `json:"enabled"`
+	Current         string    `json:"current"`
+	Latest          *Release  `json:"latest,omitempty"`
+	UpdateAvailable bool      `json:"update_available"`
+	CheckedAt       time.Time `json:"checked_at,omitzero"`
+	Error           string    `json:"error,omitempty"`
+}
+
+// Checker fetches the latest release and keeps the last result
+type Checker struct {
+	config  config.UpdatesConfig
+	client  *http.Client
+	current string
+	logger  logging.Logger
+
+	mu     sync.RWMutex
+	status Status
+}
+
+// New creates the update checker. It returns nil when updates.enabled is unset.
+// clients and logger may be nil.
+func New(cfg config.UpdatesConfig, clients *httpclient.Factory, logger logging.Logger) *Checker {
+	if !cfg.Enabled {
+		return nil
+	}
+
+	current := version.GetInfo().Version
+
+	return &Checker{
+		config:  cfg,
+		client:  clients.Client(config.ResilienceTargetUpdates, cfg.Repository, defaultTimeout),
+		current: current,
+		logger:  logger,
+		status:  Status{Enabled: true, Current: current},
+	}
+}
+
+// Status returns the result of the last check. A nil checker reports checks as disabled.
+func (c *Checker) Status() Status {
+	if c == nil {
+		return Status{Current: version.GetInfo().Version}
+	}
+
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	return c.status
+}
+
+// Check fetches the latest release, records the result and returns it. A
+// failed check keeps the last known release.
+func (c *Checker) Check(ctx context.Context) (Status, error) {
+	if c == nil {
+		return c.Status(), nil
+	}
+
+	release, err := c.latest(ctx)
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.status.CheckedAt = time.Now().UTC()
+	c.status.Error = ""
+
+	if err != nil {
+		c.status.Error = err.Error()
+
+		if c.logger != nil {
+			c.logger.Warn("update check failed", "repository", c.config.Repository, "error", err)
+		}
+
+		return c.status, err
+	}
+
+	c.status.Latest = release
+	c.status.UpdateAvailable = Newer(release.Version, c.current)
+
+	if c.status.UpdateAvailable && c.logger != nil {
+		c.logger.Info("new version available", "current", c.current, "latest", release.Version, "url", release.URL)
+	}
+
+	return c.status, nil
+}
+
+// Refresh checks for a new release; it is the scheduler job of a running server
+func (c *Checker) Refresh(ctx context.Context) error {
+	_, err := c.Check(ctx)
+
+	return err
+}
+
+// githubRelease is the part of a GitHub release used
+type githubRelease struct {
+	TagName     string    `json:"tag_name"`
+	Name        string    `json:"name"`
+	HTMLURL     string    `json:"html_url"`
+	Body        string    `json:"body"`
+	PublishedAt time.Time `json:"published_at"`
+}
+
+// latest fetches the repository's latest release
+func (c *Checker) latest(ctx context.Context) (*Release, error) {
+	endpoint := strings.TrimSuffix(c.config.APIURL, "/") + "/repos/" + c.config.Repository + "/releases/latest"
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, http.NoBody)
+	if err != nil {
+		return nil, fmt.Errorf("create release request: %w", err)
+	}
+
+	req.Header.Set("Accept", "application/vnd.github+json")
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("fetch latest release: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxResponseBody))
+	if err != nil {
+		return nil, fmt.Errorf("read latest release: %w", err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("fetch latest release: %s", resp.Status)
+	}
+
+	var release githubRelease
+	if err = json.Unmarshal(body, &release); err != nil {
+		return nil, fmt.Errorf("decode latest release: %w", err)
+	}
+
+	if release.TagName == "" {
+		return nil, fmt.Errorf("latest release of %s has no tag", c.config.Repository)
+	}
+
+	return &Release{
+		Version:     release.TagName,
+		Name:        release.Name,
+		URL:         release.HTMLURL,
+		PublishedAt: release.PublishedAt,
+		Highlights:  Highlights(release.Body),
+	}, nil
+}
+
+// Highlights returns the first bullet points of release notes in Markdown
+func Highlights(notes string) []string {
+	var highlights []string
+
+	for line := range strings.Lines(notes) {
+		line = strings.TrimSpace(line)
+
+		for _, bullet := range []string{"- ", "* ", "+ "} {
+			if item, ok := strings.CutPrefix(line, bullet); ok && strings.TrimSpace(item) != "" {
+				highlights = append(highlights, strings.TrimSpace(item))
+
+				break
+			}
+		}
+
+		if len(highlights) == maxHighlights {
+			break
+		}
+	}
+
+	return highlights
+}
+
+// Newer reports whether latest is a higher semantic version than current.
+// Versions may carry a "v" prefix; a pre-release sorts before its release.
+// It is false when either version does not parse, so development builds
+// never report an update.
+func Newer(latest, current string) bool {
+	l, ok := parse(latest)
+	if !ok {
+		return false
+	}
+
+	c, ok := parse(current)
+	if !ok {
+		return false
+	}
+
+	for i := range l.core {
+		if l.core[i] != c.core[i] {
+			return l.core[i] > c.core[i]
+		}
+	}
+
+	switch {
+	case l.pre == c.pre:
+		return false
+	case l.pre == "":
+		return true
+	case c.pre == "":
+		return false
+	default:
+		return comparePreRelease(l.pre, c.pre) > 0
+	}
+}
+
+// semver is a parsed major.minor.patch[-pre] version
+type semver struct {
+	core [3]int
+	pre  string
+}
+
+// parse parses a version, ignoring build metadata
+func parse(v string) (semver, bool) {
+	v = strings.TrimPrefix(strings.TrimSpace(v), "v")
+	v, _, _ = strings.Cut(v, "+")
+	core, pre, _ := strings.Cut(v, "-")
+
+	parts := strings.Split(core, ".")
+	if len(parts) == 0 || len(parts) > 3 {
+		return semver{}, false
+	}
+
+	var parsed semver
+
+	for i, part := range parts {
+		n, err := strconv.Atoi(part)
+		if err != nil || n < 0 {
+			return semver{}, false
+		}
+
+		parsed.core[i] = n
+	}
+
+	parsed.pre = pre
+
+	return parsed, true
+}
+
+// comparePreRelease compares dot-separated pre-release identifiers: numeric
+// identifiers compare numerically and sort before alphanumeric ones
+func comparePreRelease(a, b string) int {
+	as, bs := strings.Split(a, "."), strings.Split(b, ".")
+
+	for i := 0; i < len(as) && i < len(bs); i++ {
+		an, aErr := strconv.Atoi(as[i])
+		bn, bErr := strconv.Atoi(bs[i])
+
+		switch {
+		case aErr == nil && bErr == nil:
+			if an != bn {
+				return an - bn
+			}
+		case aErr == nil:
+			return -1
+		case bErr == nil:
+			return 1
+		default:
+			if cmp := strings.Compare(as[i], bs[i]); cmp != 0 {
+				return cmp
+			}
+		}
+	}
+
+	return len(as) - len(bs)
+}
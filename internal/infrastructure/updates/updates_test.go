@@ -0,0 +1,123 @@
+package updates_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/goformx/goforms/internal/infrastructure/config"
+	"github.com/goformx/goforms/internal/infrastructure/updates"
+	"github.com/goformx/goforms/internal/infrastructure/version"
+)
+
+func TestNewer(t *testing.T) {
+	tests := []struct {
+		latest, current string
+		want            bool
+	}{
+		{"v1.2.0", "v1.1.9", true},
+		{"v1.10.0", "v1.9.0", true},
+		{"1.2.0", "v1.2.0", false},
+		{"v1.2.0", "v1.3.0", false},
+		{"v1.2.0", "v1.2.0-rc.1", true},
+		{"v1.2.0-rc.2", "v1.2.0-rc.1", true},
+		{"v1.2.0-rc.1", "v1.2.0-beta", true},
+		{"v1.2.0-beta.2", "v1.2.0-beta.10", false},
+		{"v1.2.0-rc.1", "v1.2.0", false},
+		{"v2.0.0", "dev", false},
+		{"v2.0.0", version.UnknownVersion, false},
+		{"nightly", "v1.0.0", false},
+	}
+
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, updates.Newer(tt.latest, tt.current), "%s over %s", tt.latest, tt.current)
+	}
+}
+
+func TestHighlights(t *testing.T) {
+	notes := "## What's new\r\n\r\n- Form versioning\r\n* Faster exports\n\nSome prose.\n+ Webhook retries\n-\n- Four\n- Five\n- Six\n"
+
+	assert.Equal(t, []string{
+		"Form versioning", "Faster exports", "Webhook retries", "Four", "Five",
+	}, updates.Highlights(notes))
+}
+
+func TestCheck(t *testing.T) {
+	previous := version.Version
+	version.Version = "v1.0.0"
+
+	t.Cleanup(func() { version.Version = previous })
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/repos/goformx/goforms/releases/latest", r.URL.Path)
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{
+			"tag_name": "v1.1.0",
+			"name": "GoForms 1.1",
+			"html_url": "https://github.com/goformx/goforms/releases/tag/v1.1.0",
+			"published_at": "2026-10-01T12:00:00Z",
+			"body": "- Form versioning\n- Update notices"
+		}`))
+	}))
+	defer server.Close()
+
+	checker := updates.New(config.UpdatesConfig{
+		Enabled:    true,
+		Repository: "goformx/goforms",
+		Interval:   time.Hour,
+		APIURL:     server.URL,
+	}, nil, nil)
+
+	status, err := checker.Check(context.Background())
+	require.NoError(t, err)
+
+	assert.True(t, status.UpdateAvailable)
+	assert.Equal(t, "v1.0.0", status.Current)
+	require.NotNil(t, status.Latest)
+	assert.Equal(t, "v1.1.0", status.Latest.Version)
+	assert.Equal(t, []string{"Form versioning", "Update notices"}, status.Latest.Highlights)
+	assert.Equal(t, status, checker.Status())
+}
+
+func TestCheckFailureKeepsLastRelease(t *testing.T) {
+	fail := false
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		if fail {
+			w.WriteHeader(http.StatusForbidden)
+
+			return
+		}
+
+		_, _ = w.Write([]byte(`{"tag_name": "v9.0.0"}`))
+	}))
+	defer server.Close()
+
+	checker := updates.New(config.UpdatesConfig{Enabled: true, Repository: "goformx/goforms", APIURL: server.URL}, nil, nil)
+
+	_, err := checker.Check(context.Background())
+	require.NoError(t, err)
+
+	fail = true
+
+	status, err := checker.Check(context.Background())
+	require.Error(t, err)
+	assert.Contains(t, status.Error, "403")
+	require.NotNil(t, status.Latest)
+	assert.Equal(t, "v9.0.0", status.Latest.Version)
+}
+
+func TestDisabled(t *testing.T) {
+	checker := updates.New(config.UpdatesConfig{Enabled: false}, nil, nil)
+	assert.Nil(t, checker)
+
+	status, err := checker.Check(context.Background())
+	require.NoError(t, err)
+	assert.False(t, status.Enabled)
+	assert.False(t, status.UpdateAvailable)
+}
@@ -19,6 +19,8 @@ import (
 type AssetManager struct {
 	resolver  AssetResolver
 	pathCache map[string]string
+	// pageCache holds resolved page assets keyed by their entry points
+	pageCache map[string]*PageAssets
 	mu        sync.RWMutex
 	logger    logging.Logger
 	config    *config.Config
@@ -36,6 +38,7 @@ func NewAssetManager(cfg *config.Config, logger logging.Logger, distFS embed.FS)
 
 	manager := &AssetManager{
 		pathCache: make(map[string]string),
+		pageCache: make(map[string]*PageAssets),
 		config:    cfg,
 		logger:    logger,
 	}
@@ -45,12 +48,12 @@ func NewAssetManager(cfg *config.Config, logger logging.Logger, distFS embed.FS)
 		manager.resolver = NewDevelopmentAssetResolver(cfg, logger)
 	} else {
 		// Load manifest for production
-		manifest, err := loadManifestFromFS(distFS, logger)
+		resolver, err := newProductionResolverFromFS(distFS, logger)
 		if err != nil {
 			return nil, fmt.Errorf("failed to load manifest: %w", err)
 		}
 
-		manager.resolver = NewProductionAssetResolver(manifest, logger)
+		manager.resolver = resolver
 	}
 
 	return manager, nil
@@ -108,6 +111,36 @@ func (m *AssetManager) ResolveAssetPath(ctx context.Context, path string) (strin
 	return resolvedPath, nil
 }
 
+// PageAssets resolves the files a page loads from its entry points. Results are
+// cached with the asset paths and dropped by ClearCache.
+func (m *AssetManager) PageAssets(ctx context.Context, entries ...string) (*PageAssets, error) {
+	pages, ok := m.resolver.(PageAssetResolver)
+	if !ok {
+		return nil, fmt.Errorf("%w: resolver cannot resolve page assets", ErrResolverNotFound)
+	}
+
+	key := strings.Join(entries, "\x00")
+
+	m.mu.RLock()
+	cached, found := m.pageCache[key]
+	m.mu.RUnlock()
+
+	if found {
+		return cached, nil
+	}
+
+	assets, err := pages.ResolvePageAssets(ctx, entries)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve page assets: %w", err)
+	}
+
+	m.mu.Lock()
+	m.pageCache[key] = assets
+	m.mu.Unlock()
+
+	return assets, nil
+}
+
 // GetAssetType returns the type of asset based on its path
 func (m *AssetManager) GetAssetType(path string) AssetType {
 	return GetAssetTypeFromPath(path)
@@ -120,6 +153,7 @@ func (m *AssetManager) ClearCache() {
 
 	oldSize := len(m.pathCache)
 	m.pathCache = make(map[string]string)
+	m.pageCache = make(map[string]*PageAssets)
 
 	m.logger.Debug("asset cache cleared", "previous_size", oldSize)
 }
@@ -215,12 +249,12 @@ func NewModule(cfg *config.Config, logger logging.Logger, distFS embed.FS) (*Mod
 		resolver = NewDevelopmentAssetResolver(cfg, logger)
 	} else {
 		// Load manifest for production resolver
-		manifest, manifestErr := loadManifestFromFS(distFS, logger)
+		production, manifestErr := newProductionResolverFromFS(distFS, logger)
 		if manifestErr != nil {
 			return nil, fmt.Errorf("failed to load manifest for resolver: %w", manifestErr)
 		}
 
-		resolver = NewProductionAssetResolver(manifest, logger)
+		resolver = production
 	}
 
 	return &Module{
@@ -254,6 +288,7 @@ func (f *AssetManagerFactory) CreateManagerWithResolver(resolver AssetResolver)
 	return &AssetManager{
 		resolver:  resolver,
 		pathCache: make(map[string]string),
+		pageCache: make(map[string]*PageAssets),
 		config:    f.config,
 		logger:    f.logger,
 	}
@@ -0,0 +1,213 @@
+package web
+
+import (
+	"context"
+	"crypto/sha512"
+	"encoding/base64"
+	"fmt"
+	"html"
+	"html/template"
+	"io/fs"
+	"path"
+	"strings"
+)
+
+// PageAsset is a file a page loads
+type PageAsset struct {
+	URL string
+	// Integrity is the SRI hash browsers check the file against; it is empty
+	// for files served by the Vite dev server
+	Integrity string
+}
+
+// PageAssets are the files one page loads: the scripts of its entry points, the
+// stylesheets they need and the chunks they import
+type PageAssets struct {
+	Scripts []PageAsset
+	Styles  []PageAsset
+	// Preloads are the chunks the scripts import statically. Hinting them lets
+	// the browser fetch them alongside the scripts instead of one after another.
+	Preloads []PageAsset
+}
+
+// HTML renders the tags loading the assets: modulepreload hints, stylesheets
+// and module scripts, in that order. Tags of files with an integrity hash carry
+// it, with crossorigin set so the browser can check it. A non-empty nonce is
+// set on every tag, for a CSP allowing scripts and styles by nonce.
+func (p *PageAssets) HTML(nonce string) template.HTML {
+	var b strings.Builder
+
+	for _, asset := range p.Preloads {
+		b.WriteString(`<link rel="modulepreload" href="` + html.EscapeString(asset.URL) + `"`)
+		writeAssetAttributes(&b, asset, nonce)
+		b.WriteString(">\n")
+	}
+
+	for _, asset := range p.Styles {
+		b.WriteString(`<link rel="stylesheet" href="` + html.EscapeString(asset.URL) + `"`)
+		writeAssetAttributes(&b, asset, nonce)
+		b.WriteString(">\n")
+	}
+
+	for _, asset := range p.Scripts {
+		b.WriteString(`<script type="module" src="` + html.EscapeString(asset.URL) + `"`)
+		writeAssetAttributes(&b, asset, nonce)
+		b.WriteString("></script>\n")
+	}
+
+	return template.HTML(b.String()) //nolint:gosec // attribute values are escaped
+}
+
+// LinkHeader returns a Link header value hinting the page's stylesheets and
+// chunks, so they can be fetched before the HTML is parsed; or an empty string
+func (p *PageAssets) LinkHeader() string {
+	links := make([]string, 0, len(p.Styles)+len(p.Preloads))
+
+	for _, asset := range p.Styles {
+		links = append(links, "<"+asset.URL+">; rel=preload; as=style")
+	}
+
+	for _, asset := range p.Preloads {
+		links = append(links, "<"+asset.URL+">; rel=modulepreload")
+	}
+
+	return strings.Join(links, ", ")
+}
+
+// writeAssetAttributes writes the integrity, crossorigin and nonce attributes of an asset tag
+func writeAssetAttributes(b *strings.Builder, asset PageAsset, nonce string) {
+	if asset.Integrity != "" {
+		b.WriteString(` integrity="` + html.EscapeString(asset.Integrity) + `" crossorigin="anonymous"`)
+	}
+
+	if nonce != "" {
+		b.WriteString(` nonce="` + html.EscapeString(nonce) + `"`)
+	}
+}
+
+// IntegrityManifest holds the SRI hash of each built file, keyed by its path in
+// the Vite manifest
+type IntegrityManifest map[string]string
+
+// BuildIntegrityManifest hashes the scripts and stylesheets the manifest names,
+// reading them from dir in fsys. A hash the manifest already carries is kept.
+func BuildIntegrityManifest(fsys fs.FS, dir string, manifest Manifest) (IntegrityManifest, error) {
+	integrity := make(IntegrityManifest)
+
+	add := func(file string) error {
+		if _, done := integrity[file]; done {
+			return nil
+		}
+
+		data, err := fs.ReadFile(fsys, path.Join(dir, file))
+		if err != nil {
+			return fmt.Errorf("%w: read %s: %w", ErrAssetNotFound, file, err)
+		}
+
+		sum := sha512.Sum384(data)
+		integrity[file] = "sha384-" + base64.StdEncoding.EncodeToString(sum[:])
+
+		return nil
+	}
+
+	for _, entry := range manifest {
+		if entry.Integrity != "" {
+			integrity[entry.File] = entry.Integrity
+		} else if t := GetAssetTypeFromPath(entry.File); t == AssetTypeJS || t == AssetTypeCSS {
+			if err := add(entry.File); err != nil {
+				return nil, err
+			}
+		}
+
+		for _, css := range entry.CSS {
+			if err := add(css); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	return integrity, nil
+}
+
+// SetIntegrity sets the SRI hashes given to resolved page assets
+func (r *ProductionAssetResolver) SetIntegrity(integrity IntegrityManifest) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.integrity = integrity
+}
+
+// ResolvePageAssets walks the manifest from each entry point through its static
+// imports. Dynamic imports are not followed.
+func (r *ProductionAssetResolver) ResolvePageAssets(_ context.Context, entries []string) (*PageAssets, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	assets := &PageAssets{}
+	seen := make(map[string]bool)
+
+	for _, key := range entries {
+		entry, found := r.manifest[key]
+		if !found {
+			return nil, fmt.Errorf("%w: %s not found in manifest", ErrAssetNotFound, key)
+		}
+
+		if !entry.IsEntry {
+			return nil, fmt.Errorf("%w: %s is not an entry point", ErrInvalidPath, key)
+		}
+
+		if !seen[entry.File] {
+			seen[entry.File] = true
+			assets.Scripts = append(assets.Scripts, r.pageAsset(entry.File))
+		}
+
+		r.collectImports(key, assets, seen)
+	}
+
+	return assets, nil
+}
+
+// collectImports adds the stylesheets of a manifest entry and, recursively, the
+// chunks it imports statically
+func (r *ProductionAssetResolver) collectImports(key string, assets *PageAssets, seen map[string]bool) {
+	entry := r.manifest[key]
+
+	for _, css := range entry.CSS {
+		if !seen[css] {
+			seen[css] = true
+			assets.Styles = append(assets.Styles, r.pageAsset(css))
+		}
+	}
+
+	for _, imported := range entry.Imports {
+		chunk, found := r.manifest[imported]
+		if !found || seen[chunk.File] {
+			continue
+		}
+
+		seen[chunk.File] = true
+		assets.Preloads = append(assets.Preloads, r.pageAsset(chunk.File))
+		r.collectImports(imported, assets, seen)
+	}
+}
+
+// pageAsset returns a built file with its SRI hash
+func (r *ProductionAssetResolver) pageAsset(file string) PageAsset {
+	return PageAsset{URL: normalizeAssetPath(file), Integrity: r.integrity[file]}
+}
+
+// ResolvePageAssets returns the Vite client and the entry points, served by the
+// dev server. Vite injects stylesheets and imports itself.
+func (r *DevelopmentAssetResolver) ResolvePageAssets(_ context.Context, entries []string) (*PageAssets, error) {
+	assets := &PageAssets{Scripts: []PageAsset{{URL: r.applyPathRules("@vite/client")}}}
+
+	for _, entry := range entries {
+		if err := validateAssetPath(entry); err != nil {
+			return nil, fmt.Errorf("development asset resolution failed: %w", err)
+		}
+
+		assets.Scripts = append(assets.Scripts, PageAsset{URL: r.applyPathRules(entry)})
+	}
+
+	return assets, nil
+}
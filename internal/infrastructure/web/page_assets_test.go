@@ -0,0 +1,150 @@
+package web_test
+
+import (
+	"crypto/sha512"
+	"encoding/base64"
+	"testing"
+	"testing/fstest"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/goformx/goforms/internal/infrastructure/config"
+	"github.com/goformx/goforms/internal/infrastructure/web"
+	mocklogging "github.com/goformx/goforms/test/mocks/logging"
+)
+
+// builderManifest is the manifest of a build with two pages sharing a vendor chunk
+func builderManifest() web.Manifest {
+	return web.Manifest{
+		"src/js/pages/form-builder.ts": {
+			File:           "assets/form-builder.111.js",
+			IsEntry:        true,
+			CSS:            []string{"assets/form-builder.222.css"},
+			Imports:        []string{"_vendor.333.js"},
+			DynamicImports: []string{"src/js/pages/preview.ts"},
+		},
+		"src/js/pages/dashboard.ts": {
+			File:    "assets/dashboard.444.js",
+			IsEntry: true,
+			Imports: []string{"_vendor.333.js"},
+		},
+		"_vendor.333.js": {
+			File: "assets/vendor.333.js",
+			CSS:  []string{"assets/vendor.555.css"},
+		},
+		"src/js/pages/preview.ts": {
+			File:           "assets/preview.666.js",
+			IsDynamicEntry: true,
+		},
+	}
+}
+
+// sri returns the SRI hash of data
+func sri(data string) string {
+	sum := sha512.Sum384([]byte(data))
+
+	return "sha384-" + base64.StdEncoding.EncodeToString(sum[:])
+}
+
+func TestBuildIntegrityManifest(t *testing.T) {
+	manifest := builderManifest()
+	manifest["src/js/pages/dashboard.ts"] = web.ManifestEntry{
+		File:      "assets/dashboard.444.js",
+		IsEntry:   true,
+		Integrity: "sha384-fromvite",
+	}
+
+	fsys := fstest.MapFS{
+		"dist/assets/form-builder.111.js":  {Data: []byte("builder")},
+		"dist/assets/form-builder.222.css": {Data: []byte("builder css")},
+		"dist/assets/vendor.333.js":        {Data: []byte("vendor")},
+		"dist/assets/vendor.555.css":       {Data: []byte("vendor css")},
+		"dist/assets/preview.666.js":       {Data: []byte("preview")},
+	}
+
+	integrity, err := web.BuildIntegrityManifest(fsys, "dist", manifest)
+	require.NoError(t, err)
+
+	assert.Equal(t, sri("builder"), integrity["assets/form-builder.111.js"])
+	assert.Equal(t, sri("builder css"), integrity["assets/form-builder.222.css"])
+	assert.Equal(t, sri("vendor css"), integrity["assets/vendor.555.css"])
+	assert.Equal(t, "sha384-fromvite", integrity["assets/dashboard.444.js"], "a hash from the manifest is kept")
+
+	delete(fsys, "dist/assets/vendor.333.js")
+
+	_, err = web.BuildIntegrityManifest(fsys, "dist", manifest)
+	require.ErrorIs(t, err, web.ErrAssetNotFound)
+}
+
+func TestProductionAssetResolver_ResolvePageAssets(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	resolver := web.NewProductionAssetResolver(builderManifest(), mocklogging.NewMockLogger(ctrl))
+	resolver.SetIntegrity(web.IntegrityManifest{"assets/form-builder.111.js": "sha384-builder"})
+
+	assets, err := resolver.ResolvePageAssets(t.Context(), []string{"src/js/pages/form-builder.ts"})
+	require.NoError(t, err)
+
+	assert.Equal(t, []web.PageAsset{{URL: "/assets/form-builder.111.js", Integrity: "sha384-builder"}}, assets.Scripts)
+	assert.Equal(t, []web.PageAsset{{URL: "/assets/form-builder.222.css"}, {URL: "/assets/vendor.555.css"}}, assets.Styles)
+	assert.Equal(t, []web.PageAsset{{URL: "/assets/vendor.333.js"}}, assets.Preloads, "dynamic imports are not preloaded")
+
+	// The chunk two pages share is preloaded once
+	assets, err = resolver.ResolvePageAssets(t.Context(), []string{"src/js/pages/form-builder.ts", "src/js/pages/dashboard.ts"})
+	require.NoError(t, err)
+	assert.Len(t, assets.Scripts, 2)
+	assert.Len(t, assets.Preloads, 1)
+
+	_, err = resolver.ResolvePageAssets(t.Context(), []string{"src/js/pages/missing.ts"})
+	require.ErrorIs(t, err, web.ErrAssetNotFound)
+
+	_, err = resolver.ResolvePageAssets(t.Context(), []string{"_vendor.333.js"})
+	require.ErrorIs(t, err, web.ErrInvalidPath)
+}
+
+func TestDevelopmentAssetResolver_ResolvePageAssets(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	cfg := &config.Config{
+		App: config.AppConfig{
+			Scheme:      "http",
+			ViteDevHost: "localhost",
+			ViteDevPort: "5173",
+		},
+	}
+
+	resolver := web.NewDevelopmentAssetResolver(cfg, mocklogging.NewMockLogger(ctrl))
+
+	assets, err := resolver.ResolvePageAssets(t.Context(), []string{"src/js/pages/form-builder.ts"})
+	require.NoError(t, err)
+
+	assert.Equal(t, []web.PageAsset{
+		{URL: "http://localhost:5173/@vite/client"},
+		{URL: "http://localhost:5173/src/js/pages/form-builder.ts"},
+	}, assets.Scripts)
+	assert.Empty(t, assets.Styles)
+	assert.Empty(t, assets.Preloads)
+}
+
+func TestPageAssets_HTML(t *testing.T) {
+	assets := &web.PageAssets{
+		Scripts:  []web.PageAsset{{URL: "/assets/app.js", Integrity: "sha384-app"}},
+		Styles:   []web.PageAsset{{URL: "/assets/app.css"}},
+		Preloads: []web.PageAsset{{URL: "/assets/vendor.js", Integrity: "sha384-vendor"}},
+	}
+
+	assert.Equal(t,
+		`<link rel="modulepreload" href="/assets/vendor.js" integrity="sha384-vendor" crossorigin="anonymous" nonce="n&#34;1">`+"\n"+
+			`<link rel="stylesheet" href="/assets/app.css" nonce="n&#34;1">`+"\n"+
+			`<script type="module" src="/assets/app.js" integrity="sha384-app" crossorigin="anonymous" nonce="n&#34;1"></script>`+"\n",
+		string(assets.HTML(`n"1`)))
+
+	assert.Equal(t,
+		"</assets/app.css>; rel=preload; as=style, </assets/vendor.js>; rel=modulepreload",
+		assets.LinkHeader())
+	assert.Empty(t, (&web.PageAssets{}).LinkHeader())
+}
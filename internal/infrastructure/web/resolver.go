@@ -22,9 +22,10 @@ type AssetPathRule struct {
 
 // ProductionAssetResolver handles production asset resolution
 type ProductionAssetResolver struct {
-	manifest Manifest
-	logger   logging.Logger
-	mu       sync.RWMutex
+	manifest  Manifest
+	integrity IntegrityManifest
+	logger    logging.Logger
+	mu        sync.RWMutex
 }
 
 // NewProductionAssetResolver creates a new production asset resolver
@@ -229,9 +230,31 @@ func normalizeAssetPath(path string) string {
 	return path
 }
 
+// distDir is the directory of the embedded filesystem holding the Vite build
+const distDir = "dist"
+
+// newProductionResolverFromFS creates a production resolver from the manifest of
+// the embedded build, with the SRI hashes of the built files
+func newProductionResolverFromFS(distFS fs.FS, logger logging.Logger) (*ProductionAssetResolver, error) {
+	manifest, err := loadManifestFromFS(distFS, logger)
+	if err != nil {
+		return nil, err
+	}
+
+	integrity, err := BuildIntegrityManifest(distFS, distDir, manifest)
+	if err != nil {
+		return nil, fmt.Errorf("hash built assets: %w", err)
+	}
+
+	resolver := NewProductionAssetResolver(manifest, logger)
+	resolver.SetIntegrity(integrity)
+
+	return resolver, nil
+}
+
 // loadManifestFromFS loads the manifest from the embedded filesystem
-func loadManifestFromFS(distFS embed.FS, logger logging.Logger) (Manifest, error) {
-	const manifestPath = "dist/.vite/manifest.json"
+func loadManifestFromFS(distFS fs.FS, logger logging.Logger) (Manifest, error) {
+	const manifestPath = distDir + "/.vite/manifest.json"
 
 	data, readErr := fs.ReadFile(distFS, manifestPath)
 	if readErr != nil {
@@ -278,10 +301,10 @@ func (f *AssetResolverFactory) CreateResolver(distFS embed.FS) (AssetResolver, e
 
 	f.logger.Info("creating production asset resolver")
 
-	manifest, err := loadManifestFromFS(distFS, f.logger)
+	resolver, err := newProductionResolverFromFS(distFS, f.logger)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create production resolver: %w", err)
 	}
 
-	return NewProductionAssetResolver(manifest, f.logger), nil
+	return resolver, nil
 }
@@ -86,6 +86,15 @@ func (s *DevelopmentAssetServer) RegisterRoutes(e *echo.Echo) error {
 		return fmt.Errorf("public directory validation failed: %w", err)
 	}
 
+	// Proxy Vite requests (including the HMR websocket) while the dev server is running;
+	// otherwise requests fall through to the static routes below.
+	viteProxy, err := NewViteProxy(s.config, s.logger)
+	if err != nil {
+		return fmt.Errorf("failed to create vite proxy: %w", err)
+	}
+
+	e.Pre(viteProxy.Middleware())
+
 	// Register security headers middleware
 	e.Use(s.createSecurityHeadersMiddleware())
 
@@ -96,6 +105,7 @@ func (s *DevelopmentAssetServer) RegisterRoutes(e *echo.Echo) error {
 
 	s.logger.Info("development asset server configured",
 		"public_dir", s.serverConfig.PublicDir,
+		"vite_target", viteProxy.Target(),
 		"max_age", s.serverConfig.MaxAge,
 		"security_headers", len(s.serverConfig.SecurityHeaders))
 
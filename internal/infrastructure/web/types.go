@@ -56,11 +56,14 @@ type ManifestEntry struct {
 	File           string   `json:"file"`
 	Name           string   `json:"name,omitempty"`
 	Src            string   `json:"src,omitempty"`
-	IsEntry        bool     `json:"is_entry"`
+	IsEntry        bool     `json:"isEntry,omitempty"`
+	IsDynamicEntry bool     `json:"isDynamicEntry,omitempty"`
 	CSS            []string `json:"css,omitempty"`
 	Assets         []string `json:"assets,omitempty"`
 	Imports        []string `json:"imports,omitempty"`
-	DynamicImports []string `json:"dynamic_imports,omitempty"`
+	DynamicImports []string `json:"dynamicImports,omitempty"`
+	// Integrity is the SRI hash of File, when the build adds one to the manifest
+	Integrity string `json:"integrity,omitempty"`
 }
 
 // Manifest represents the Vite manifest file
@@ -97,6 +100,13 @@ type AssetResolver interface {
 	ResolveAssetPath(ctx context.Context, path string) (string, error)
 }
 
+// PageAssetResolver resolves the files a page loads from its entry points
+type PageAssetResolver interface {
+	// ResolvePageAssets returns the scripts, stylesheets and preload hints of
+	// the given manifest entry points
+	ResolvePageAssets(ctx context.Context, entries []string) (*PageAssets, error)
+}
+
 // AssetServer defines the interface for serving assets
 type AssetServer interface {
 	// RegisterRoutes registers the necessary routes for serving assets
@@ -127,6 +137,11 @@ type AssetManagerInterface interface {
 
 	// GetBaseURL returns the base URL for assets (useful for CSP headers)
 	GetBaseURL() string
+
+	// PageAssets returns the files a page with the given entry points loads.
+	// Chunks the entries only import dynamically are left out, so heavy code
+	// split from a page is fetched when it runs rather than with the page.
+	PageAssets(ctx context.Context, entries ...string) (*PageAssets, error)
 }
 
 // AssetPathValidator defines validation rules for asset paths
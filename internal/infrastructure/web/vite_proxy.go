@@ -0,0 +1,175 @@
+// Package web provides utilities for handling web assets in the application.
+package web
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/http/httputil"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/labstack/echo/v4"
+
+	"github.com/goformx/goforms/internal/infrastructure/config"
+	"github.com/goformx/goforms/internal/infrastructure/logging"
+)
+
+const (
+	// viteDialTimeout bounds the reachability probe against the Vite dev server
+	viteDialTimeout = 250 * time.Millisecond
+	// viteProbeInterval controls how long a reachability result is reused
+	viteProbeInterval = 2 * time.Second
+)
+
+// defaultVitePrefixes are the request paths served by the Vite dev server
+var defaultVitePrefixes = []string{
+	"/@vite/",
+	"/@id/",
+	"/@fs/",
+	"/@react-refresh",
+	"/src/",
+	"/node_modules/",
+	"/assets/",
+}
+
+// ViteProxy forwards asset and HMR requests to the Vite dev server in development.
+// When the dev server is unreachable, requests fall through to the next handler so
+// that embedded or on-disk assets are served instead.
+type ViteProxy struct {
+	target   *url.URL
+	proxy    *httputil.ReverseProxy
+	logger   logging.Logger
+	prefixes []string
+
+	mu        sync.Mutex
+	available bool
+	checkedAt time.Time
+	// probing is set while a reachability probe is running
+	probing bool
+}
+
+// NewViteProxy creates a proxy targeting the Vite dev server configured in AppConfig
+func NewViteProxy(cfg *config.Config, logger logging.Logger) (*ViteProxy, error) {
+	if cfg == nil {
+		return nil, errors.New("config is required")
+	}
+
+	host := cfg.App.ViteDevHost
+	if host == "" || host == "0.0.0.0" {
+		host = "localhost"
+	}
+
+	target, err := url.Parse("http://" + net.JoinHostPort(host, cfg.App.ViteDevPort))
+	if err != nil {
+		return nil, fmt.Errorf("invalid vite dev server address: %w", err)
+	}
+
+	p := &ViteProxy{
+		target:   target,
+		logger:   logger,
+		prefixes: defaultVitePrefixes,
+	}
+
+	// httputil.ReverseProxy handles "Upgrade: websocket" natively, which covers HMR.
+	p.proxy = httputil.NewSingleHostReverseProxy(target)
+	p.proxy.ErrorHandler = p.handleProxyError
+
+	return p, nil
+}
+
+// Target returns the Vite dev server URL the proxy forwards to
+func (p *ViteProxy) Target() string {
+	return p.target.String()
+}
+
+// Middleware returns an Echo middleware that proxies Vite requests when the dev server is up
+func (p *ViteProxy) Middleware() echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			req := c.Request()
+			if !p.matches(req) || !p.isAvailable() {
+				return next(c)
+			}
+
+			p.proxy.ServeHTTP(c.Response(), req)
+
+			return nil
+		}
+	}
+}
+
+// matches reports whether the request should be handled by the Vite dev server
+func (p *ViteProxy) matches(req *http.Request) bool {
+	path := req.URL.Path
+
+	// The HMR client connects to the root with a "vite-hmr" websocket subprotocol
+	if strings.EqualFold(req.Header.Get("Upgrade"), "websocket") &&
+		strings.Contains(req.Header.Get("Sec-WebSocket-Protocol"), "vite-hmr") {
+		return true
+	}
+
+	for _, prefix := range p.prefixes {
+		if strings.HasPrefix(path, prefix) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// isAvailable probes the dev server, caching the result for viteProbeInterval.
+// The probe runs outside the lock; requests arriving while it runs use the
+// previous result instead of waiting.
+func (p *ViteProxy) isAvailable() bool {
+	p.mu.Lock()
+
+	if p.probing || (!p.checkedAt.IsZero() && time.Since(p.checkedAt) < viteProbeInterval) {
+		available := p.available
+		p.mu.Unlock()
+
+		return available
+	}
+
+	p.probing = true
+	p.mu.Unlock()
+
+	conn, err := net.DialTimeout("tcp", p.target.Host, viteDialTimeout)
+	if conn != nil {
+		_ = conn.Close()
+	}
+
+	p.mu.Lock()
+	wasAvailable := p.available
+	p.available = err == nil
+	p.checkedAt = time.Now()
+	p.probing = false
+	available := p.available
+	p.mu.Unlock()
+
+	if wasAvailable != available {
+		p.logger.Debug("vite dev server availability changed",
+			"target", p.target.String(),
+			"available", available)
+	}
+
+	return available
+}
+
+// handleProxyError marks the dev server unavailable so following requests fall back
+func (p *ViteProxy) handleProxyError(w http.ResponseWriter, r *http.Request, err error) {
+	p.mu.Lock()
+	p.available = false
+	p.checkedAt = time.Now()
+	p.mu.Unlock()
+
+	p.logger.Warn("vite dev server proxy failed",
+		"target", p.target.String(),
+		"path", r.URL.Path,
+		"error", err)
+
+	w.WriteHeader(http.StatusBadGateway)
+}
@@ -0,0 +1,83 @@
+package web_test
+
+import (
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/goformx/goforms/internal/infrastructure/config"
+	"github.com/goformx/goforms/internal/infrastructure/web"
+	mocklogging "github.com/goformx/goforms/test/mocks/logging"
+)
+
+func newViteProxyForAddr(t *testing.T, addr string) *web.ViteProxy {
+	t.Helper()
+
+	ctrl := gomock.NewController(t)
+	mockLogger := mocklogging.NewMockLogger(ctrl)
+	mockLogger.EXPECT().Debug(gomock.Any(), gomock.Any()).AnyTimes()
+	mockLogger.EXPECT().Warn(gomock.Any(), gomock.Any()).AnyTimes()
+
+	host, port, err := net.SplitHostPort(addr)
+	require.NoError(t, err)
+
+	cfg := &config.Config{App: config.AppConfig{ViteDevHost: host, ViteDevPort: port}}
+
+	proxy, err := web.NewViteProxy(cfg, mockLogger)
+	require.NoError(t, err)
+
+	return proxy
+}
+
+func serve(proxy *web.ViteProxy, path string) *httptest.ResponseRecorder {
+	e := echo.New()
+	e.Pre(proxy.Middleware())
+	e.GET("/*", func(c echo.Context) error {
+		return c.String(http.StatusOK, "fallback")
+	})
+
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, path, http.NoBody))
+
+	return rec
+}
+
+func TestViteProxy_ForwardsWhenDevServerRunning(t *testing.T) {
+	vite := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte("vite:" + r.URL.Path))
+	}))
+	defer vite.Close()
+
+	target, err := url.Parse(vite.URL)
+	require.NoError(t, err)
+
+	proxy := newViteProxyForAddr(t, target.Host)
+
+	rec := serve(proxy, "/src/js/main.ts")
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "vite:/src/js/main.ts", rec.Body.String())
+
+	rec = serve(proxy, "/api/forms")
+	assert.Equal(t, "fallback", rec.Body.String())
+}
+
+func TestViteProxy_FallsBackWhenDevServerDown(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	addr := listener.Addr().String()
+	require.NoError(t, listener.Close())
+
+	proxy := newViteProxyForAddr(t, addr)
+
+	rec := serve(proxy, "/assets/main.js")
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "fallback", rec.Body.String())
+}
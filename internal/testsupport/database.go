@@ -0,0 +1,229 @@
+package testsupport
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"go.uber.org/zap/zaptest"
+
+	"github.com/goformx/goforms/internal/infrastructure/config"
+	"github.com/goformx/goforms/internal/infrastructure/database"
+	"github.com/goformx/goforms/internal/infrastructure/logging"
+	"github.com/goformx/goforms/internal/infrastructure/sanitization"
+)
+
+const (
+	postgresImage = "postgres:16-alpine"
+	mariadbImage  = "mariadb:11"
+
+	databaseName     = "goforms"
+	databaseUser     = "goforms"
+	databasePassword = "goforms"
+)
+
+// Database is a migrated database running in a container
+type Database struct {
+	*database.GormDB
+
+	// Config points at the container and can be passed to code that opens its own connection
+	Config *config.Config
+	Logger logging.Logger
+}
+
+// StartPostgres starts PostgreSQL, applies migrations/postgresql and returns a connection
+func StartPostgres(t testing.TB) *Database {
+	t.Helper()
+
+	return startDatabase(t, "postgres", ContainerRequest{
+		Image: postgresImage,
+		Port:  "5432/tcp",
+		Env: map[string]string{
+			"POSTGRES_DB":       databaseName,
+			"POSTGRES_USER":     databaseUser,
+			"POSTGRES_PASSWORD": databasePassword,
+		},
+	})
+}
+
+// StartMariaDB starts MariaDB, applies migrations/mariadb and returns a connection
+func StartMariaDB(t testing.TB) *Database {
+	t.Helper()
+
+	return startDatabase(t, "mariadb", ContainerRequest{
+		Image: mariadbImage,
+		Port:  "3306/tcp",
+		Env: map[string]string{
+			"MARIADB_DATABASE":      databaseName,
+			"MARIADB_USER":          databaseUser,
+			"MARIADB_PASSWORD":      databasePassword,
+			"MARIADB_ROOT_PASSWORD": databasePassword,
+		},
+	})
+}
+
+// NewLogger returns a logger that writes to the test log
+func NewLogger(t testing.TB) logging.Logger {
+	t.Helper()
+
+	factory, err := logging.NewFactory(&logging.FactoryConfig{
+		AppName:     "goforms-test",
+		Environment: "test",
+		LogLevel:    "debug",
+	}, sanitization.NewService())
+	if err != nil {
+		t.Fatalf("create logger factory: %v", err)
+	}
+
+	logger, err := factory.WithTestCore(zaptest.NewLogger(t).Core()).CreateLogger()
+	if err != nil {
+		t.Fatalf("create logger: %v", err)
+	}
+
+	return logger
+}
+
+func startDatabase(t testing.TB, driver string, req ContainerRequest) *Database {
+	t.Helper()
+
+	container := StartContainer(t, req)
+	logger := NewLogger(t)
+
+	cfg := &config.Config{
+		App: config.AppConfig{Name: "goforms-test", Environment: "test"},
+		Database: config.DatabaseConfig{
+			Driver:          driver,
+			Host:            container.Host,
+			Port:            container.Port,
+			Name:            databaseName,
+			Username:        databaseUser,
+			Password:        databasePassword,
+			SSLMode:         "disable",
+			MaxOpenConns:    10,
+			MaxIdleConns:    2,
+			ConnMaxLifetime: 5 * time.Minute,
+			Logging: config.DatabaseLoggingConfig{
+				LogLevel:       "warn",
+				SlowThreshold:  time.Second,
+				IgnoreNotFound: true,
+			},
+		},
+	}
+
+	var db *database.GormDB
+
+	// The server accepts connections before it finishes initializing, so retry
+	WaitFor(t, driver, func(_ context.Context) error {
+		var err error
+
+		db, err = database.New(cfg, logger)
+
+		return err
+	})
+
+	t.Cleanup(func() {
+		if err := db.Close(); err != nil {
+			t.Logf("close %s connection: %v", driver, err)
+		}
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+
+	if err := Migrate(ctx, db, driver); err != nil {
+		t.Fatalf("migrate %s: %v", driver, err)
+	}
+
+	return &Database{GormDB: db, Config: cfg, Logger: logger}
+}
+
+// Migrate applies every up migration for driver in version order
+func Migrate(ctx context.Context, db database.DB, driver string) error {
+	dir, err := migrationsDir(driver)
+	if err != nil {
+		return err
+	}
+
+	files, err := filepath.Glob(filepath.Join(dir, "*.up.sql"))
+	if err != nil {
+		return fmt.Errorf("list migrations: %w", err)
+	}
+
+	slices.Sort(files)
+
+	sqlDB, err := db.GetDB().DB()
+	if err != nil {
+		return fmt.Errorf("get database instance: %w", err)
+	}
+
+	for _, file := range files {
+		script, readErr := os.ReadFile(file)
+		if readErr != nil {
+			return fmt.Errorf("read migration: %w", readErr)
+		}
+
+		// PostgreSQL runs a whole script in one simple-protocol call; the MariaDB
+		// connection does not allow multiple statements, so they are sent one by one
+		statements := []string{string(script)}
+		if driver == "mariadb" {
+			statements = splitStatements(string(script))
+		}
+
+		for _, statement := range statements {
+			if _, execErr := sqlDB.ExecContext(ctx, statement); execErr != nil {
+				return fmt.Errorf("apply %s: %w", filepath.Base(file), execErr)
+			}
+		}
+	}
+
+	return nil
+}
+
+// migrationsDir locates the migrations for driver relative to this source file
+func migrationsDir(driver string) (string, error) {
+	_, file, _, ok := runtime.Caller(0)
+	if !ok {
+		return "", fmt.Errorf("locate migrations: no caller information")
+	}
+
+	dialect := map[string]string{"postgres": "postgresql", "mariadb": "mariadb"}[driver]
+	if dialect == "" {
+		return "", fmt.Errorf("no migrations for driver %q", driver)
+	}
+
+	return filepath.Join(filepath.Dir(file), "..", "..", "migrations", dialect), nil
+}
+
+// splitStatements splits a script into statements ending with a semicolon at the end of a line
+func splitStatements(script string) []string {
+	var statements []string
+
+	var current strings.Builder
+
+	for line := range strings.SplitSeq(script, "\n") {
+		trimmed := strings.TrimSpace(line)
+		if trimmed == "" || strings.HasPrefix(trimmed, "--") {
+			continue
+		}
+
+		current.WriteString(line)
+		current.WriteByte('\n')
+
+		if strings.HasSuffix(trimmed, ";") {
+			statements = append(statements, current.String())
+			current.Reset()
+		}
+	}
+
+	if rest := strings.TrimSpace(current.String()); rest != "" {
+		statements = append(statements, rest)
+	}
+
+	return statements
+}
@@ -0,0 +1,47 @@
+package testsupport
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSplitStatements(t *testing.T) {
+	script := `-- Create table
+CREATE TABLE t (
+    id INT
+);
+
+-- Create index
+CREATE INDEX idx ON t (id);
+`
+
+	statements := splitStatements(script)
+	require.Len(t, statements, 2)
+	assert.True(t, strings.HasPrefix(statements[0], "CREATE TABLE t ("))
+	assert.Equal(t, "CREATE INDEX idx ON t (id);\n", statements[1])
+}
+
+func TestMigrationsDir(t *testing.T) {
+	for _, driver := range []string{"postgres", "mariadb"} {
+		dir, err := migrationsDir(driver)
+		require.NoError(t, err)
+
+		files, err := filepath.Glob(filepath.Join(dir, "*.up.sql"))
+		require.NoError(t, err)
+		assert.NotEmpty(t, files, driver)
+
+		for _, file := range files {
+			script, readErr := os.ReadFile(file)
+			require.NoError(t, readErr)
+			assert.NotEmpty(t, splitStatements(string(script)), file)
+		}
+	}
+
+	_, err := migrationsDir("sqlite")
+	require.Error(t, err)
+}
@@ -0,0 +1,175 @@
+// Package testsupport starts throwaway Postgres, MariaDB and Redis servers for
+// integration tests, applies the SQL migrations and builds fixtures.
+//
+// Containers are driven through the docker CLI, so the only requirement is a
+// reachable Docker daemon. Tests that need one are skipped when none is available,
+// and every container is removed when the test that started it finishes. Tests
+// using this package carry the integration build tag; run them with
+// `task test:integration`.
+package testsupport
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net"
+	"os/exec"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+const (
+	// dockerTimeout bounds a single docker CLI call; image pulls can be slow
+	dockerTimeout = 5 * time.Minute
+	// readyTimeout bounds how long a container may take to accept connections
+	readyTimeout = 90 * time.Second
+	// readyInterval is the pause between readiness probes
+	readyInterval = 500 * time.Millisecond
+)
+
+var (
+	dockerOnce sync.Once
+	dockerErr  error
+)
+
+// ContainerRequest describes a container to start
+type ContainerRequest struct {
+	// Image is the image reference, e.g. postgres:16-alpine
+	Image string
+	// Port is the container port to publish, e.g. 5432/tcp
+	Port string
+	// Env is passed to the container as environment variables
+	Env map[string]string
+	// Cmd overrides the image command
+	Cmd []string
+}
+
+// Container is a running container with its published port
+type Container struct {
+	ID   string
+	Host string
+	Port int
+}
+
+// Addr returns the host:port the container is reachable on
+func (c *Container) Addr() string {
+	return net.JoinHostPort(c.Host, strconv.Itoa(c.Port))
+}
+
+// RequireDocker skips the test when no Docker daemon is reachable
+func RequireDocker(t testing.TB) {
+	t.Helper()
+
+	dockerOnce.Do(func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+
+		_, dockerErr = docker(ctx, "info", "--format", "{{.ServerVersion}}")
+	})
+
+	if dockerErr != nil {
+		t.Skipf("docker is not available: %v", dockerErr)
+	}
+}
+
+// StartContainer starts a container and removes it when the test finishes. The
+// port is published on a random loopback port.
+func StartContainer(t testing.TB, req ContainerRequest) *Container {
+	t.Helper()
+	RequireDocker(t)
+
+	ctx, cancel := context.WithTimeout(context.Background(), dockerTimeout)
+	defer cancel()
+
+	args := []string{"run", "--detach", "--rm", "--publish", "127.0.0.1::" + req.Port}
+
+	keys := make([]string, 0, len(req.Env))
+	for key := range req.Env {
+		keys = append(keys, key)
+	}
+
+	slices.Sort(keys)
+
+	for _, key := range keys {
+		args = append(args, "--env", key+"="+req.Env[key])
+	}
+
+	args = append(args, req.Image)
+	args = append(args, req.Cmd...)
+
+	out, err := docker(ctx, args...)
+	if err != nil {
+		t.Fatalf("start %s container: %v", req.Image, err)
+	}
+
+	id := strings.TrimSpace(out)
+
+	t.Cleanup(func() {
+		cleanupCtx, cleanupCancel := context.WithTimeout(context.Background(), time.Minute)
+		defer cleanupCancel()
+
+		if _, rmErr := docker(cleanupCtx, "rm", "--force", "--volumes", id); rmErr != nil {
+			t.Logf("remove %s container %s: %v", req.Image, id, rmErr)
+		}
+	})
+
+	mapping, err := docker(ctx, "port", id, req.Port)
+	if err != nil {
+		t.Fatalf("look up published port of %s: %v", req.Image, err)
+	}
+
+	// docker port prints one line per address family; the first is enough
+	first, _, _ := strings.Cut(strings.TrimSpace(mapping), "\n")
+
+	host, portText, err := net.SplitHostPort(strings.TrimSpace(first))
+	if err != nil {
+		t.Fatalf("parse published port %q of %s: %v", first, req.Image, err)
+	}
+
+	port, err := strconv.Atoi(portText)
+	if err != nil {
+		t.Fatalf("parse published port %q of %s: %v", first, req.Image, err)
+	}
+
+	return &Container{ID: id, Host: host, Port: port}
+}
+
+// WaitFor calls probe until it succeeds, failing the test once readyTimeout passes
+func WaitFor(t testing.TB, what string, probe func(ctx context.Context) error) {
+	t.Helper()
+
+	ctx, cancel := context.WithTimeout(context.Background(), readyTimeout)
+	defer cancel()
+
+	for {
+		err := probe(ctx)
+		if err == nil {
+			return
+		}
+
+		select {
+		case <-ctx.Done():
+			t.Fatalf("%s not ready after %s: %v", what, readyTimeout, err)
+		case <-time.After(readyInterval):
+		}
+	}
+}
+
+// docker runs a docker CLI command and returns its standard output
+func docker(ctx context.Context, args ...string) (string, error) {
+	var stdout, stderr bytes.Buffer
+
+	cmd := exec.CommandContext(ctx, "docker", args...)
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	if err := cmd.Run(); err != nil {
+		return "", fmt.Errorf("docker %s: %w: %s", args[0], err, strings.TrimSpace(stderr.String()))
+	}
+
+	return stdout.String(), nil
+}
@@ -0,0 +1,117 @@
+package testsupport
+
+import (
+	"context"
+	"fmt"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/goformx/goforms/internal/domain/entities"
+	"github.com/goformx/goforms/internal/domain/form"
+	"github.com/goformx/goforms/internal/domain/form/model"
+	"github.com/goformx/goforms/internal/domain/user"
+	"github.com/goformx/goforms/internal/infrastructure/database"
+	formstore "github.com/goformx/goforms/internal/infrastructure/repository/form"
+	userstore "github.com/goformx/goforms/internal/infrastructure/repository/user"
+)
+
+// FixturePassword is the password of every fixture user
+const FixturePassword = "password123"
+
+// fixtureSeq keeps fixture emails and titles unique across a test binary
+var fixtureSeq atomic.Int64
+
+// Fixtures creates users, forms and submissions through the application stores
+type Fixtures struct {
+	t     testing.TB
+	Users user.Repository
+	Forms form.Repository
+}
+
+// NewFixtures creates fixture builders writing to db
+func NewFixtures(t testing.TB, db database.DB) *Fixtures {
+	t.Helper()
+
+	logger := NewLogger(t)
+
+	return &Fixtures{
+		t:     t,
+		Users: userstore.NewStore(db, logger),
+		Forms: formstore.NewStore(db, logger),
+	}
+}
+
+// User creates a user with a unique email and FixturePassword; modify adjusts it before it is stored
+func (f *Fixtures) User(modify ...func(*entities.User)) *entities.User {
+	f.t.Helper()
+
+	n := fixtureSeq.Add(1)
+
+	u, err := entities.NewUser(fmt.Sprintf("user%d@example.com", n), FixturePassword, "Test", fmt.Sprintf("User %d", n))
+	if err != nil {
+		f.t.Fatalf("build fixture user: %v", err)
+	}
+
+	for _, fn := range modify {
+		fn(u)
+	}
+
+	if createErr := f.Users.Create(context.Background(), u); createErr != nil {
+		f.t.Fatalf("create fixture user: %v", createErr)
+	}
+
+	return u
+}
+
+// Form creates a published form owned by owner with a name and email field
+func (f *Fixtures) Form(owner *entities.User, modify ...func(*model.Form)) *model.Form {
+	f.t.Helper()
+
+	formModel := model.NewForm(owner.ID, fmt.Sprintf("Fixture form %d", fixtureSeq.Add(1)), "Created by a test", model.JSON{
+		"display": "form",
+		"components": []any{
+			map[string]any{"type": "textfield", "key": "name", "label": "Name", "input": true,
+				"validate": map[string]any{"required": true}},
+			map[string]any{"type": "email", "key": "email", "label": "Email", "input": true},
+		},
+	})
+	formModel.Status = "published"
+
+	for _, fn := range modify {
+		fn(formModel)
+	}
+
+	if err := f.Forms.CreateForm(context.Background(), formModel); err != nil {
+		f.t.Fatalf("create fixture form: %v", err)
+	}
+
+	return formModel
+}
+
+// Submission creates a completed submission to formModel
+func (f *Fixtures) Submission(formModel *model.Form, modify ...func(*model.FormSubmission)) *model.FormSubmission {
+	f.t.Helper()
+
+	n := fixtureSeq.Add(1)
+	submission := &model.FormSubmission{
+		ID:          uuid.New().String(),
+		FormID:      formModel.ID,
+		Data:        model.JSON{"name": fmt.Sprintf("Submitter %d", n), "email": fmt.Sprintf("submitter%d@example.com", n)},
+		Status:      model.SubmissionStatusCompleted,
+		SubmittedAt: time.Now().UTC().Truncate(time.Second),
+		Metadata:    model.JSON{"source": "fixture"},
+	}
+
+	for _, fn := range modify {
+		fn(submission)
+	}
+
+	if err := f.Forms.CreateSubmission(context.Background(), submission); err != nil {
+		f.t.Fatalf("create fixture submission: %v", err)
+	}
+
+	return submission
+}
@@ -0,0 +1,46 @@
+package testsupport
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	mongostore "github.com/goformx/goforms/internal/infrastructure/repository/mongodb"
+)
+
+const mongoImage = "mongo:7"
+
+// MongoDB is a MongoDB server running in a container
+type MongoDB struct {
+	*mongostore.Store
+
+	// URI is the connection string to pass to code that opens its own store
+	URI string
+}
+
+// StartMongoDB starts MongoDB and returns a store once it answers a ping and
+// its indexes are created
+func StartMongoDB(t testing.TB) *MongoDB {
+	t.Helper()
+
+	container := StartContainer(t, ContainerRequest{Image: mongoImage, Port: "27017/tcp"})
+	uri := "mongodb://" + container.Addr()
+
+	store, err := mongostore.NewStore(uri, databaseName, NewLogger(t))
+	if err != nil {
+		t.Fatalf("create mongodb store: %v", err)
+	}
+
+	WaitFor(t, "mongodb", store.Open)
+
+	t.Cleanup(func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+
+		if closeErr := store.Close(ctx); closeErr != nil {
+			t.Logf("close mongodb store: %v", closeErr)
+		}
+	})
+
+	return &MongoDB{Store: store, URI: uri}
+}
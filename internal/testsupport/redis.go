@@ -0,0 +1,34 @@
+package testsupport
+
+import (
+	"context"
+	"testing"
+
+	"github.com/goformx/goforms/internal/infrastructure/redis"
+)
+
+const redisImage = "redis:7-alpine"
+
+// Redis is a Redis server running in a container
+type Redis struct {
+	*redis.Client
+
+	// Addr is the host:port to pass to code that dials its own client
+	Addr string
+}
+
+// StartRedis starts Redis and returns a client once it answers PING
+func StartRedis(t testing.TB) *Redis {
+	t.Helper()
+
+	container := StartContainer(t, ContainerRequest{Image: redisImage, Port: "6379/tcp"})
+	client := redis.NewClient(container.Addr(), "", 0)
+
+	WaitFor(t, "redis", func(ctx context.Context) error {
+		_, err := client.Do(ctx, "PING")
+
+		return err
+	})
+
+	return &Redis{Client: client, Addr: container.Addr()}
+}
@@ -0,0 +1,3 @@
+-- Add optimistic concurrency version to forms table
+ALTER TABLE forms
+ADD COLUMN version INTEGER NOT NULL DEFAULT 1;
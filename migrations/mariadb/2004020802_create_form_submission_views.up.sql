@@ -0,0 +1,16 @@
+-- Create form_submission_views table for saved submission filters
+CREATE TABLE IF NOT EXISTS form_submission_views (
+    uuid VARCHAR(36) PRIMARY KEY,
+    form_id VARCHAR(36) NOT NULL,
+    user_id VARCHAR(36) NOT NULL,
+    name VARCHAR(100) NOT NULL,
+    tags JSON,
+    status VARCHAR(20) NOT NULL DEFAULT '',
+    search VARCHAR(200) NOT NULL DEFAULT '',
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    FOREIGN KEY (form_id) REFERENCES forms (uuid) ON DELETE CASCADE
+);
+
+-- Create index for per-form listing
+CREATE INDEX IF NOT EXISTS idx_form_submission_views_form_id ON form_submission_views (form_id);
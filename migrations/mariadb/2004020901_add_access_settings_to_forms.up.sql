@@ -0,0 +1,3 @@
+-- Add access controls (passphrase, login requirement, email domains) to forms table
+ALTER TABLE forms
+ADD COLUMN access_settings JSON;
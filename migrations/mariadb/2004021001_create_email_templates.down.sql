@@ -0,0 +1,2 @@
+-- Drop email_templates table
+DROP TABLE IF EXISTS email_templates;
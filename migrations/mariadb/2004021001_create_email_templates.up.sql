@@ -0,0 +1,14 @@
+-- Create email_templates table for per-owner transactional email overrides
+CREATE TABLE IF NOT EXISTS email_templates (
+    uuid VARCHAR(36) PRIMARY KEY,
+    owner_id VARCHAR(36) NOT NULL,
+    kind VARCHAR(50) NOT NULL,
+    subject VARCHAR(255) NOT NULL,
+    html_body TEXT,
+    text_body TEXT,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP
+);
+
+-- One override per owner and kind
+CREATE UNIQUE INDEX IF NOT EXISTS idx_email_templates_owner_kind ON email_templates (owner_id, kind);
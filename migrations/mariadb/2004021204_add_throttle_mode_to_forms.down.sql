@@ -0,0 +1,3 @@
+-- Remove per-form adaptive throttling mode from forms table
+ALTER TABLE forms
+DROP COLUMN throttle_mode;
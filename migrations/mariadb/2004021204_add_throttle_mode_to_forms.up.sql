@@ -0,0 +1,3 @@
+-- Add per-form adaptive throttling mode ('' and 'adaptive' throttle spikes, 'off' never throttles)
+ALTER TABLE forms
+ADD COLUMN throttle_mode VARCHAR(20) NOT NULL DEFAULT '';
@@ -0,0 +1,3 @@
+-- Remove the submission script from forms table
+ALTER TABLE forms
+DROP COLUMN script;
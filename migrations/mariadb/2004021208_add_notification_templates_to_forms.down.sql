@@ -0,0 +1,3 @@
+-- Remove per-form notification templates from forms table
+ALTER TABLE forms
+DROP COLUMN notification_templates;
@@ -0,0 +1,2 @@
+-- Drop form_submission_activities table
+DROP TABLE IF EXISTS form_submission_activities;
@@ -0,0 +1,14 @@
+-- Create form_submission_activities table for submission status history, notes and webhook deliveries
+CREATE TABLE IF NOT EXISTS form_submission_activities (
+    uuid VARCHAR(36) PRIMARY KEY,
+    form_id VARCHAR(36) NOT NULL,
+    submission_id VARCHAR(36) NOT NULL,
+    kind VARCHAR(30) NOT NULL,
+    actor_id VARCHAR(36) NOT NULL DEFAULT '',
+    details JSON,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (form_id) REFERENCES forms (uuid) ON DELETE CASCADE
+);
+
+-- Create index for per-submission listing
+CREATE INDEX IF NOT EXISTS idx_form_submission_activities_submission_id ON form_submission_activities (submission_id, created_at);
@@ -0,0 +1,3 @@
+-- Remove the failed run count from form_report_schedules table
+ALTER TABLE form_report_schedules
+DROP COLUMN failure_count;
@@ -0,0 +1,3 @@
+-- Add the count of failed runs since the last successful one to form_report_schedules table
+ALTER TABLE form_report_schedules
+ADD COLUMN failure_count INT NOT NULL DEFAULT 0;
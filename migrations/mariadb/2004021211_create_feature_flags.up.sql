@@ -0,0 +1,10 @@
+-- Create feature_flags table for flags toggled at runtime through the admin API
+CREATE TABLE IF NOT EXISTS feature_flags (
+    flag_key VARCHAR(100) PRIMARY KEY,
+    description VARCHAR(255),
+    enabled BOOLEAN NOT NULL DEFAULT FALSE,
+    percentage INT NOT NULL DEFAULT 100,
+    accounts JSON,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
@@ -0,0 +1,2 @@
+-- Drop billing_subscriptions table
+DROP TABLE IF EXISTS billing_subscriptions;
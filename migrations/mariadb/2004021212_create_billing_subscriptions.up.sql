@@ -0,0 +1,17 @@
+-- Create billing_subscriptions table for each user's Stripe subscription
+CREATE TABLE IF NOT EXISTS billing_subscriptions (
+    user_id VARCHAR(36) PRIMARY KEY,
+    customer_id VARCHAR(255) NOT NULL,
+    subscription_id VARCHAR(255) NOT NULL,
+    plan_id VARCHAR(100),
+    status VARCHAR(50) NOT NULL,
+    current_period_end TIMESTAMP NULL,
+    cancel_at_period_end BOOLEAN NOT NULL DEFAULT false,
+    event_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (user_id) REFERENCES users (uuid) ON DELETE CASCADE
+);
+
+-- Create index for finding the user of a Stripe customer
+CREATE INDEX IF NOT EXISTS idx_billing_subscriptions_customer_id ON billing_subscriptions (customer_id);
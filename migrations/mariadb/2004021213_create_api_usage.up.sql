@@ -0,0 +1,21 @@
+-- Create api_usage_daily table for per-account API call and submission counts
+CREATE TABLE IF NOT EXISTS api_usage_daily (
+    account VARCHAR(100) NOT NULL,
+    metric VARCHAR(50) NOT NULL,
+    day DATE NOT NULL,
+    count BIGINT NOT NULL DEFAULT 0,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (account, metric, day)
+);
+
+-- Create api_usage_alerts table so each usage threshold is announced once per month
+CREATE TABLE IF NOT EXISTS api_usage_alerts (
+    account VARCHAR(100) NOT NULL,
+    metric VARCHAR(50) NOT NULL,
+    period_start TIMESTAMP NOT NULL,
+    threshold INT NOT NULL,
+    used BIGINT NOT NULL,
+    usage_limit BIGINT NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (account, metric, period_start, threshold)
+);
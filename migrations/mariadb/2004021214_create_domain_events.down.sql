@@ -0,0 +1,2 @@
+-- Drop domain_events table
+DROP TABLE IF EXISTS domain_events;
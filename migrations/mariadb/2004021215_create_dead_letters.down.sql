@@ -0,0 +1,2 @@
+-- Drop dead_letters table
+DROP TABLE IF EXISTS dead_letters;
@@ -0,0 +1,18 @@
+-- Create dead_letters table for failed background work kept for inspection and retry
+CREATE TABLE IF NOT EXISTS dead_letters (
+    uuid VARCHAR(36) PRIMARY KEY,
+    kind VARCHAR(50) NOT NULL,
+    reference VARCHAR(255),
+    payload LONGTEXT NOT NULL,
+    status VARCHAR(20) NOT NULL,
+    attempts INTEGER NOT NULL DEFAULT 0,
+    last_error TEXT,
+    history TEXT,
+    resolved_at TIMESTAMP NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Entries are listed and retried per kind and status, newest first
+CREATE INDEX IF NOT EXISTS idx_dead_letters_kind ON dead_letters (kind, created_at);
+CREATE INDEX IF NOT EXISTS idx_dead_letters_status ON dead_letters (status, created_at);
@@ -0,0 +1,3 @@
+-- Remove per-form export redaction policies from forms table
+ALTER TABLE forms
+DROP COLUMN redaction_policy;
@@ -0,0 +1,3 @@
+-- Add per-form export redaction policies ({"columns": {key: action}, "detect": action}) to forms table
+ALTER TABLE forms
+ADD COLUMN redaction_policy JSON;
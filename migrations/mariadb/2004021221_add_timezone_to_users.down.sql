@@ -0,0 +1,3 @@
+-- Remove the account time zone from users table
+ALTER TABLE users
+DROP COLUMN timezone;
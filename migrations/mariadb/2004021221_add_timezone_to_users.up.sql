@@ -0,0 +1,3 @@
+-- Add the account time zone (IANA name; '' is UTC) to users table
+ALTER TABLE users
+ADD COLUMN timezone VARCHAR(64) NOT NULL DEFAULT '';
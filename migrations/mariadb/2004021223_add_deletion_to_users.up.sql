@@ -0,0 +1,6 @@
+-- Add the requested account deletion and the time it is carried out to users table
+ALTER TABLE users
+ADD COLUMN deletion_requested_at TIMESTAMP NULL,
+ADD COLUMN deletion_scheduled_at TIMESTAMP NULL;
+
+CREATE INDEX IF NOT EXISTS idx_users_deletion_scheduled_at ON users (deletion_scheduled_at);
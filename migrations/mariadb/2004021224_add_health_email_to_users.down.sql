@@ -0,0 +1,4 @@
+-- Remove the weekly account health email from users table
+ALTER TABLE users
+DROP COLUMN health_email_sent_at,
+DROP COLUMN health_email;
@@ -0,0 +1,4 @@
+-- Add the weekly account health email opt-in and the time it was last sent to users table
+ALTER TABLE users
+ADD COLUMN health_email BOOLEAN NOT NULL DEFAULT FALSE,
+ADD COLUMN health_email_sent_at TIMESTAMP NULL;
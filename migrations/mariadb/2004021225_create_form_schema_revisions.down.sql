@@ -0,0 +1,2 @@
+-- Drop form_schema_revisions table
+DROP TABLE IF EXISTS form_schema_revisions;
@@ -0,0 +1,13 @@
+-- Create form_schema_revisions table for the undo and redo history of form schema edits
+CREATE TABLE IF NOT EXISTS form_schema_revisions (
+    uuid VARCHAR(36) PRIMARY KEY,
+    form_id VARCHAR(36) NOT NULL,
+    stack VARCHAR(10) NOT NULL,
+    schema JSON NOT NULL,
+    seq BIGINT NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (form_id) REFERENCES forms (uuid) ON DELETE CASCADE
+);
+
+-- Create index for reading the top of a form's stack
+CREATE INDEX IF NOT EXISTS idx_form_schema_revisions_form_stack ON form_schema_revisions (form_id, stack, seq);
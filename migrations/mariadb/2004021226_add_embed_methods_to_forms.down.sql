@@ -0,0 +1,3 @@
+-- Remove per-form allowed embed methods from forms table
+ALTER TABLE forms
+DROP COLUMN embed_methods;
@@ -0,0 +1,3 @@
+-- Add per-form allowed embed methods ({"allowed": [methods]}) to forms table
+ALTER TABLE forms
+ADD COLUMN embed_methods JSON;
@@ -0,0 +1,3 @@
+-- Remove public stats settings from forms table
+ALTER TABLE forms
+DROP COLUMN public_stats;
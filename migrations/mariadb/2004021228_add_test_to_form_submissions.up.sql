@@ -0,0 +1,6 @@
+-- Add the test flag of submissions made from the builder's preview to form_submissions table
+ALTER TABLE form_submissions
+ADD COLUMN test BOOLEAN NOT NULL DEFAULT FALSE;
+
+-- Create index for purging test submissions past their retention
+CREATE INDEX IF NOT EXISTS idx_form_submissions_test_submitted_at ON form_submissions (test, submitted_at);
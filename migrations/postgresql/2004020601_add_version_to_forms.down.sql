@@ -0,0 +1,3 @@
+-- Remove optimistic concurrency version from forms table
+ALTER TABLE forms
+DROP COLUMN version;
@@ -0,0 +1,2 @@
+-- Drop form_report_schedules table
+DROP TABLE IF EXISTS form_report_schedules;
@@ -0,0 +1,21 @@
+-- Create form_report_schedules table for scheduled submission digests
+CREATE TABLE IF NOT EXISTS form_report_schedules (
+    uuid VARCHAR(36) PRIMARY KEY,
+    form_id VARCHAR(36) NOT NULL,
+    user_id VARCHAR(36) NOT NULL,
+    name VARCHAR(100) NOT NULL,
+    cron VARCHAR(100) NOT NULL,
+    timezone VARCHAR(64) NOT NULL DEFAULT 'UTC',
+    recipients JSON NOT NULL,
+    format VARCHAR(20) NOT NULL DEFAULT 'csv',
+    active BOOLEAN NOT NULL DEFAULT true,
+    last_run_at TIMESTAMP NULL,
+    next_run_at TIMESTAMP NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (form_id) REFERENCES forms (uuid) ON DELETE CASCADE
+);
+
+-- Create indexes for per-form listing and due-report polling
+CREATE INDEX IF NOT EXISTS idx_form_report_schedules_form_id ON form_report_schedules (form_id);
+CREATE INDEX IF NOT EXISTS idx_form_report_schedules_next_run_at ON form_report_schedules (active, next_run_at);
@@ -0,0 +1 @@
+DROP TRIGGER IF EXISTS update_form_report_schedules_updated_at ON form_report_schedules;
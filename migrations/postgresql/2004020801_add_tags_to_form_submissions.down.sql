@@ -0,0 +1,3 @@
+-- Remove triage tags from form_submissions table
+ALTER TABLE form_submissions
+DROP COLUMN tags;
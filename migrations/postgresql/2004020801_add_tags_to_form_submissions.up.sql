@@ -0,0 +1,3 @@
+-- Add triage tags to form_submissions table
+ALTER TABLE form_submissions
+ADD COLUMN tags JSON;
@@ -0,0 +1 @@
+DROP TRIGGER IF EXISTS update_form_submission_views_updated_at ON form_submission_views;
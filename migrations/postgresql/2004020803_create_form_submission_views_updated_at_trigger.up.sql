@@ -0,0 +1,5 @@
+-- Create trigger to automatically update updated_at
+CREATE TRIGGER update_form_submission_views_updated_at
+    BEFORE UPDATE ON form_submission_views
+    FOR EACH ROW
+    EXECUTE FUNCTION update_updated_at_column();
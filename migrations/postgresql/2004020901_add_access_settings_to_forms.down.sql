@@ -0,0 +1,3 @@
+-- Remove access controls from forms table
+ALTER TABLE forms
+DROP COLUMN access_settings;
@@ -0,0 +1,3 @@
+-- Remove per-form submission write mode from forms table
+ALTER TABLE forms
+DROP COLUMN submission_write_mode;
@@ -0,0 +1,3 @@
+-- Add per-form submission write mode ('' follows form.write_behind, 'sync' always writes synchronously)
+ALTER TABLE forms
+ADD COLUMN submission_write_mode VARCHAR(20) NOT NULL DEFAULT '';
@@ -0,0 +1 @@
+DROP TRIGGER IF EXISTS update_email_templates_updated_at ON email_templates;
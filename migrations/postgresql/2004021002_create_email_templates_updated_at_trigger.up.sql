@@ -0,0 +1,5 @@
+-- Create trigger to automatically update updated_at
+CREATE TRIGGER update_email_templates_updated_at
+    BEFORE UPDATE ON email_templates
+    FOR EACH ROW
+    EXECUTE FUNCTION update_updated_at_column();
@@ -0,0 +1,2 @@
+-- Drop email_suppressions table
+DROP TABLE IF EXISTS email_suppressions;
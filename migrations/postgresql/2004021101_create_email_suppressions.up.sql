@@ -0,0 +1,13 @@
+-- Create email_suppressions table for addresses that must not receive email
+CREATE TABLE IF NOT EXISTS email_suppressions (
+    address VARCHAR(320) PRIMARY KEY,
+    reason VARCHAR(20) NOT NULL,
+    provider VARCHAR(20),
+    detail VARCHAR(500),
+    form_id VARCHAR(36),
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Suppressions are listed newest first
+CREATE INDEX IF NOT EXISTS idx_email_suppressions_updated_at ON email_suppressions (updated_at);
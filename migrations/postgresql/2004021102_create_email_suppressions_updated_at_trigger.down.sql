@@ -0,0 +1 @@
+DROP TRIGGER IF EXISTS update_email_suppressions_updated_at ON email_suppressions;
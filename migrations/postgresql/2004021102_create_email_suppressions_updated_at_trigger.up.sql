@@ -0,0 +1,5 @@
+-- Create trigger to automatically update updated_at
+CREATE TRIGGER update_email_suppressions_updated_at
+    BEFORE UPDATE ON email_suppressions
+    FOR EACH ROW
+    EXECUTE FUNCTION update_updated_at_column();
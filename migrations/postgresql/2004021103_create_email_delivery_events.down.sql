@@ -0,0 +1,2 @@
+-- Drop email_delivery_events table
+DROP TABLE IF EXISTS email_delivery_events;
@@ -0,0 +1,15 @@
+-- Create email_delivery_events table for per-form send, bounce and complaint counts
+CREATE TABLE IF NOT EXISTS email_delivery_events (
+    uuid VARCHAR(36) PRIMARY KEY,
+    form_id VARCHAR(36),
+    type VARCHAR(20) NOT NULL,
+    recipient VARCHAR(320) NOT NULL,
+    provider VARCHAR(20) NOT NULL,
+    permanent BOOLEAN NOT NULL DEFAULT FALSE,
+    detail VARCHAR(500),
+    occurred_at TIMESTAMP NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Delivery stats count a form's events by type
+CREATE INDEX IF NOT EXISTS idx_email_delivery_events_form_type ON email_delivery_events (form_id, type);
@@ -0,0 +1,4 @@
+-- Remove last activity and forced password reset from users table
+ALTER TABLE users
+DROP COLUMN password_reset_required,
+DROP COLUMN last_active_at;
@@ -0,0 +1,4 @@
+-- Add last activity and forced password reset to users table
+ALTER TABLE users
+ADD COLUMN last_active_at TIMESTAMP NULL,
+ADD COLUMN password_reset_required BOOLEAN NOT NULL DEFAULT FALSE;
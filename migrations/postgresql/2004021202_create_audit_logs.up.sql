@@ -0,0 +1,16 @@
+-- Create audit_logs table for administrative actions
+CREATE TABLE IF NOT EXISTS audit_logs (
+    uuid VARCHAR(36) PRIMARY KEY,
+    actor_id VARCHAR(36) NOT NULL,
+    action VARCHAR(100) NOT NULL,
+    target_type VARCHAR(50),
+    target_id VARCHAR(255),
+    details JSON,
+    ip_address VARCHAR(45),
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+-- The log is read newest first, per actor or per target
+CREATE INDEX IF NOT EXISTS idx_audit_logs_created_at ON audit_logs (created_at);
+CREATE INDEX IF NOT EXISTS idx_audit_logs_actor ON audit_logs (actor_id, created_at);
+CREATE INDEX IF NOT EXISTS idx_audit_logs_target ON audit_logs (target_type, target_id);
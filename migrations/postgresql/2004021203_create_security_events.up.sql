@@ -0,0 +1,16 @@
+-- Create security_events table for detected brute-force and anomalous traffic
+CREATE TABLE IF NOT EXISTS security_events (
+    uuid VARCHAR(36) PRIMARY KEY,
+    kind VARCHAR(50) NOT NULL,
+    ip_address VARCHAR(45) NOT NULL,
+    subject VARCHAR(255),
+    count INT NOT NULL,
+    window_seconds INT NOT NULL,
+    blocked_until TIMESTAMP NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Events are read newest first, per kind or per IP
+CREATE INDEX IF NOT EXISTS idx_security_events_created_at ON security_events (created_at);
+CREATE INDEX IF NOT EXISTS idx_security_events_kind ON security_events (kind, created_at);
+CREATE INDEX IF NOT EXISTS idx_security_events_ip ON security_events (ip_address, created_at);
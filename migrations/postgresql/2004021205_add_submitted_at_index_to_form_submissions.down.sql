@@ -0,0 +1,2 @@
+-- Drop the submission time index
+DROP INDEX IF EXISTS idx_form_submissions_submitted_at;
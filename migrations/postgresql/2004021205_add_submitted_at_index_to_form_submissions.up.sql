@@ -0,0 +1,2 @@
+-- Index submission time for the submission archiver
+CREATE INDEX IF NOT EXISTS idx_form_submissions_submitted_at ON form_submissions (submitted_at);
@@ -0,0 +1,3 @@
+-- Remove per-form enabled extensions from forms table
+ALTER TABLE forms
+DROP COLUMN extensions;
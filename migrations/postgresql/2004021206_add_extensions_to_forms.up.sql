@@ -0,0 +1,3 @@
+-- Add per-form enabled extensions ({"enabled": [names]}) to forms table
+ALTER TABLE forms
+ADD COLUMN extensions JSON;
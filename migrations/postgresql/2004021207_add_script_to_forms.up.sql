@@ -0,0 +1,3 @@
+-- Add the submission script ({"source": "..."}) to forms table
+ALTER TABLE forms
+ADD COLUMN script JSON;
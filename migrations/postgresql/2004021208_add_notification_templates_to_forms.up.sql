@@ -0,0 +1,3 @@
+-- Add per-form notification templates ({"webhook"|"slack"|"email": source}) to forms table
+ALTER TABLE forms
+ADD COLUMN notification_templates JSON;
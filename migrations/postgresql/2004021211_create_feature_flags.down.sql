@@ -0,0 +1,2 @@
+-- Drop feature_flags table
+DROP TABLE IF EXISTS feature_flags;
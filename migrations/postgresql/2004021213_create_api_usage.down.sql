@@ -0,0 +1,3 @@
+-- Drop API usage tables
+DROP TABLE IF EXISTS api_usage_alerts;
+DROP TABLE IF EXISTS api_usage_daily;
@@ -0,0 +1,13 @@
+-- Create domain_events table for published domain events kept for inspection and replay
+CREATE TABLE IF NOT EXISTS domain_events (
+    uuid VARCHAR(36) PRIMARY KEY,
+    name VARCHAR(100) NOT NULL,
+    payload TEXT NOT NULL,
+    metadata TEXT,
+    occurred_at TIMESTAMP NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Events are replayed oldest first, per name, and pruned by age
+CREATE INDEX IF NOT EXISTS idx_domain_events_occurred_at ON domain_events (occurred_at);
+CREATE INDEX IF NOT EXISTS idx_domain_events_name ON domain_events (name, occurred_at);
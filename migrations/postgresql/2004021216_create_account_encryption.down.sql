@@ -0,0 +1,2 @@
+-- Drop account_encryption table
+DROP TABLE IF EXISTS account_encryption;
@@ -0,0 +1,7 @@
+-- Create account_encryption table for each account's submission encryption-at-rest setting
+CREATE TABLE IF NOT EXISTS account_encryption (
+    user_id VARCHAR(36) PRIMARY KEY,
+    enabled BOOLEAN NOT NULL DEFAULT false,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (user_id) REFERENCES users (uuid) ON DELETE CASCADE
+);
@@ -0,0 +1,3 @@
+-- Remove the dashboard theme preference from users table
+ALTER TABLE users
+DROP COLUMN theme;
@@ -0,0 +1,3 @@
+-- Add the dashboard theme preference (system, light or dark) to users table
+ALTER TABLE users
+ADD COLUMN theme VARCHAR(20) NOT NULL DEFAULT 'system';
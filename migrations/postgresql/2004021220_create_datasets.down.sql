@@ -0,0 +1,2 @@
+-- Drop datasets table
+DROP TABLE IF EXISTS datasets;
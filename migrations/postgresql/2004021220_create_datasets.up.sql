@@ -0,0 +1,15 @@
+-- Create datasets table for the named key/value lists and tables account owners keep for their forms
+CREATE TABLE IF NOT EXISTS datasets (
+    uuid VARCHAR(36) PRIMARY KEY,
+    owner_id VARCHAR(36) NOT NULL,
+    name VARCHAR(63) NOT NULL,
+    title VARCHAR(255),
+    kind VARCHAR(20) NOT NULL,
+    column_names TEXT NOT NULL,
+    row_data TEXT NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Datasets are named uniquely per owner
+CREATE UNIQUE INDEX IF NOT EXISTS idx_datasets_owner_name ON datasets (owner_id, name);
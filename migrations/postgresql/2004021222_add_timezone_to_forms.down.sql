@@ -0,0 +1,3 @@
+-- Remove the form time zone from forms table
+ALTER TABLE forms
+DROP COLUMN timezone;
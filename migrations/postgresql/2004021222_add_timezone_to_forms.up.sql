@@ -0,0 +1,3 @@
+-- Add the form time zone (IANA name; '' uses the owner's) to forms table
+ALTER TABLE forms
+ADD COLUMN timezone VARCHAR(64) NOT NULL DEFAULT '';
@@ -0,0 +1,4 @@
+-- Remove the requested account deletion from users table
+ALTER TABLE users
+DROP COLUMN deletion_scheduled_at,
+DROP COLUMN deletion_requested_at;
@@ -0,0 +1,3 @@
+-- Add opt-in public stats settings ({"enabled", "response_count", "rating_field"}) to forms table
+ALTER TABLE forms
+ADD COLUMN public_stats JSON;
@@ -0,0 +1,5 @@
+-- Remove the test flag from form_submissions table
+DROP INDEX IF EXISTS idx_form_submissions_test_submitted_at;
+
+ALTER TABLE form_submissions
+DROP COLUMN test;
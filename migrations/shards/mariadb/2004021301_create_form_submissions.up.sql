@@ -0,0 +1,20 @@
+-- Create form_submissions table of a submission shard. The forms are in the
+-- primary database, so form_id references no table here.
+CREATE TABLE IF NOT EXISTS form_submissions (
+    uuid VARCHAR(36) PRIMARY KEY,
+    form_id VARCHAR(36) NOT NULL,
+    data JSON NOT NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'pending',
+    metadata JSON,
+    tags JSON,
+    test BOOLEAN NOT NULL DEFAULT FALSE,
+    submitted_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    deleted_at TIMESTAMP NULL
+);
+
+-- Create indexes on form_id, on submission time for the archiver and for purging test submissions
+CREATE INDEX IF NOT EXISTS idx_form_submissions_form_id ON form_submissions (form_id);
+CREATE INDEX IF NOT EXISTS idx_form_submissions_submitted_at ON form_submissions (submitted_at);
+CREATE INDEX IF NOT EXISTS idx_form_submissions_test_submitted_at ON form_submissions (test, submitted_at);
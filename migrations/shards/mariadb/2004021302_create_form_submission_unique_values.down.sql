@@ -0,0 +1,2 @@
+-- Drop form_submission_unique_values table
+DROP TABLE IF EXISTS form_submission_unique_values;
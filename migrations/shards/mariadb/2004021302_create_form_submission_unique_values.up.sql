@@ -0,0 +1,13 @@
+-- Create form_submission_unique_values table claiming the hashed values of unique form fields
+CREATE TABLE IF NOT EXISTS form_submission_unique_values (
+    form_id VARCHAR(36) NOT NULL,
+    field_key VARCHAR(255) NOT NULL,
+    value_hash VARCHAR(64) NOT NULL,
+    submission_id VARCHAR(36) NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (form_id, field_key, value_hash),
+    FOREIGN KEY (submission_id) REFERENCES form_submissions (uuid) ON DELETE CASCADE
+);
+
+-- Create index for releasing the values of a deleted submission
+CREATE INDEX IF NOT EXISTS idx_form_submission_unique_values_submission_id ON form_submission_unique_values (submission_id);
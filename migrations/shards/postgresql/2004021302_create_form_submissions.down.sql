@@ -0,0 +1,2 @@
+-- Drop table and its indexes
+DROP TABLE IF EXISTS form_submissions;
@@ -0,0 +1 @@
+DROP TRIGGER IF EXISTS update_form_submissions_updated_at ON form_submissions;
@@ -0,0 +1,5 @@
+-- Create trigger to automatically update updated_at
+CREATE TRIGGER update_form_submissions_updated_at
+    BEFORE UPDATE ON form_submissions
+    FOR EACH ROW
+    EXECUTE FUNCTION update_updated_at_column();
@@ -0,0 +1,211 @@
+// Package goforms runs GoForms inside another Go program. Module wires the
+// same Fx graph the goforms server runs, without listening on a port: the
+// embedding program mounts Handler under its own router.
+//
+//	app := fx.New(
+//		goforms.Module(
+//			goforms.WithLogger(zapLogger),
+//			goforms.WithDB(gormDB),
+//			goforms.WithRoutePrefix("/forms"),
+//		),
+//		fx.Invoke(func(h *goforms.Handler) {
+//			mux.Handle(h.Prefix()+"/", h)
+//		}),
+//	)
+//
+// Configuration is loaded as the server loads it, from config files and
+// environment variables, unless WithConfig supplies it. GoForms' routes are
+// registered at the root of its own router and the prefix is stripped before
+// they are matched; redirects and links in the server-rendered pages still
+// point at the root.
+package goforms
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+	"go.uber.org/fx"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+
+	"github.com/goformx/goforms/internal/application"
+	"github.com/goformx/goforms/internal/application/handlers/web"
+	appmiddleware "github.com/goformx/goforms/internal/application/middleware"
+	"github.com/goformx/goforms/internal/application/middleware/access"
+	"github.com/goformx/goforms/internal/domain"
+	"github.com/goformx/goforms/internal/infrastructure"
+	"github.com/goformx/goforms/internal/infrastructure/config"
+	"github.com/goformx/goforms/internal/infrastructure/logging"
+	"github.com/goformx/goforms/internal/infrastructure/sanitization"
+	"github.com/goformx/goforms/internal/infrastructure/server"
+)
+
+// Config is the GoForms configuration
+type Config = config.Config
+
+// Logger is the GoForms logger interface
+type Logger = logging.Logger
+
+// The Fx modules Module combines, for programs that assemble the graph themselves
+//
+//nolint:gochecknoglobals // Fx modules are package-level values
+var (
+	// ConfigModule loads the configuration and provides its sections
+	ConfigModule = config.Module
+	// InfrastructureModule provides the database, logger, cache, storage, email and HTTP plumbing
+	InfrastructureModule = infrastructure.Module
+	// DomainModule provides the domain services
+	DomainModule = domain.Module
+	// ApplicationModule provides the middleware and the HTTP handlers
+	ApplicationModule = fx.Options(application.Module, appmiddleware.Module, web.Module)
+)
+
+// options collects the Option values given to Module
+type options struct {
+	config      *Config
+	logger      *zap.Logger
+	db          *gorm.DB
+	routePrefix string
+}
+
+// Option customizes Module
+type Option func(*options)
+
+// WithConfig uses cfg as given instead of loading the configuration
+func WithConfig(cfg *Config) Option {
+	return func(o *options) {
+		o.config = cfg
+	}
+}
+
+// WithLogger writes GoForms' logs to the embedding program's zap logger
+func WithLogger(logger *zap.Logger) Option {
+	return func(o *options) {
+		o.logger = logger
+	}
+}
+
+// WithDB uses the embedding program's database connection instead of opening
+// one; database.driver must still name its dialect. The connection is not
+// closed when the application stops.
+func WithDB(db *gorm.DB) Option {
+	return func(o *options) {
+		o.db = db
+	}
+}
+
+// WithRoutePrefix sets the path Handler is mounted under, such as "/forms"
+func WithRoutePrefix(prefix string) Option {
+	return func(o *options) {
+		o.routePrefix = "/" + strings.Trim(prefix, "/")
+		if o.routePrefix == "/" {
+			o.routePrefix = ""
+		}
+	}
+}
+
+// LoadConfig loads and validates the configuration as the server does, reading
+// config files from dir, or searching the standard paths when dir is empty
+func LoadConfig(dir string) (*Config, error) {
+	return config.NewViperConfigIn(dir).Load()
+}
+
+// Module wires GoForms and provides its *Handler
+func Module(opts ...Option) fx.Option {
+	var o options
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	configOption := ConfigModule
+	if o.config != nil {
+		configOption = fx.Options(fx.Supply(o.config, config.Sources{}), config.Sections)
+	}
+
+	moduleOptions := []fx.Option{
+		configOption,
+		InfrastructureModule,
+		DomainModule,
+		ApplicationModule,
+		fx.Supply(prefix(o.routePrefix)),
+		fx.Provide(newHandler),
+		fx.Invoke(setupApplication),
+	}
+
+	if o.logger != nil {
+		zapLogger := o.logger
+		moduleOptions = append(moduleOptions, fx.Decorate(func(sanitizer sanitization.ServiceInterface) logging.Logger {
+			return logging.NewFromZap(zapLogger, sanitizer)
+		}))
+	}
+
+	if o.db != nil {
+		moduleOptions = append(moduleOptions, fx.Supply(&infrastructure.ExternalDB{DB: o.db}))
+	}
+
+	return fx.Module("goforms", moduleOptions...)
+}
+
+// prefix is the route prefix given to Module
+type prefix string
+
+// Handler serves GoForms' routes under the route prefix
+type Handler struct {
+	prefix  string
+	handler http.Handler
+}
+
+// newHandler wraps the router the handlers were registered on. It depends on
+// the server for the health and metrics routes it registers.
+func newHandler(srv *server.Server, routePrefix prefix) *Handler {
+	var handler http.Handler = srv.Echo()
+	if routePrefix != "" {
+		handler = http.StripPrefix(string(routePrefix), handler)
+	}
+
+	return &Handler{prefix: string(routePrefix), handler: handler}
+}
+
+// Prefix returns the path the handler expects to be mounted under, empty for the root
+func (h *Handler) Prefix() string {
+	return h.prefix
+}
+
+// ServeHTTP implements http.Handler
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	h.handler.ServeHTTP(w, r)
+}
+
+// applicationParams collects the dependencies for registering middleware and handlers
+type applicationParams struct {
+	fx.In
+
+	Echo              *echo.Echo
+	Logger            logging.Logger
+	Handlers          []web.Handler `group:"handlers"`
+	MiddlewareManager *appmiddleware.Manager
+	AccessManager     *access.Manager
+	MigrationAdapter  *appmiddleware.MigrationAdapter
+}
+
+// setupApplication configures middleware and registers handlers.
+func setupApplication(p applicationParams) error {
+	if err := p.MigrationAdapter.SetupWithFallback(p.Echo, p.MiddlewareManager); err != nil {
+		return fmt.Errorf("middleware setup failed: %w", err)
+	}
+
+	for i, h := range p.Handlers {
+		if h == nil {
+			return fmt.Errorf("nil handler encountered at index %d", i)
+		}
+	}
+
+	web.RegisterHandlers(p.Echo, p.Handlers, p.AccessManager, p.Logger)
+
+	// Route rate limits are declared with the handlers' route metadata
+	p.MiddlewareManager.SetRouteRateLimits(web.RouteRateLimits(p.Handlers))
+
+	return nil
+}
@@ -0,0 +1,53 @@
+package goforms_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/fx"
+	"go.uber.org/fx/fxtest"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zaptest/observer"
+
+	"github.com/goformx/goforms/pkg/goforms"
+)
+
+func TestModule_Embedded(t *testing.T) {
+	t.Setenv("DATABASE_DRIVER", "memory")
+	t.Setenv("SESSION_SECRET", "0123456789abcdef0123456789abcdef")
+	t.Setenv("SECURITY_CSRF_SECRET", "0123456789abcdef0123456789abcdef")
+	t.Setenv("SECURITY_CORS_ALLOWED_ORIGINS", "http://localhost:3000")
+	t.Setenv("UPDATES_ENABLED", "false")
+
+	cfg, err := goforms.LoadConfig(t.TempDir())
+	require.NoError(t, err)
+
+	core, logs := observer.New(zap.InfoLevel)
+
+	var handler *goforms.Handler
+
+	app := fxtest.New(t,
+		goforms.Module(
+			goforms.WithConfig(cfg),
+			goforms.WithLogger(zap.New(core)),
+			goforms.WithRoutePrefix("/forms/"),
+		),
+		fx.Populate(&handler),
+	)
+	app.RequireStart()
+	t.Cleanup(app.RequireStop)
+
+	assert.Equal(t, "/forms", handler.Prefix())
+
+	mux := http.NewServeMux()
+	mux.Handle(handler.Prefix()+"/", handler)
+
+	rec := httptest.NewRecorder()
+	mux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/forms/health", http.NoBody))
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	assert.NotZero(t, logs.Len(), "GoForms logs to the embedding program's logger")
+}
@@ -0,0 +1,198 @@
+#!/bin/bash
+
+# GoFormX Zero-Downtime Deployment Script
+#
+# Replaces the running goforms containers of docker-compose.prod.yml with the
+# image IMAGE_TAG points at, without dropping traffic:
+#
+#   rolling     start one new container, wait for /health, move it into the
+#               Nginx upstream in place of one old container, stop that old
+#               container, and repeat for every replica
+#   blue-green  start every new container, wait for all of them, switch the
+#               whole upstream at once, then stop the old containers
+#
+# Traffic is switched by port swap: each container publishes 8090 on a free
+# host port from GOFORMS_HOST_PORTS, and the script rewrites the upstream
+# file the Nginx site includes and reloads Nginx. If a new container does not
+# become healthy, or HEALTH_URL fails after a switch, the new containers are
+# removed and the upstream is restored to the old ones.
+#
+# Usage: scripts/deploy-zero-downtime.sh [rolling|blue-green]
+
+set -euo pipefail
+
+# Configuration
+STRATEGY="${1:-${STRATEGY:-rolling}}"
+COMPOSE_FILE="${COMPOSE_FILE:-docker-compose.prod.yml}"
+SERVICE="${SERVICE:-goforms}"
+UPSTREAM_NAME="${UPSTREAM_NAME:-goforms}"
+UPSTREAM_FILE="${UPSTREAM_FILE:-/etc/nginx/goforms-upstream.conf}"
+NGINX_RELOAD="${NGINX_RELOAD:-sudo nginx -s reload}"
+HEALTH_PATH="${HEALTH_PATH:-/health}"
+HEALTH_TIMEOUT="${HEALTH_TIMEOUT:-120}"   # seconds a new container has to become healthy
+HEALTH_URL="${HEALTH_URL:-}"              # optional URL checked through Nginx after each switch
+DRAIN_SECONDS="${DRAIN_SECONDS:-10}"      # time old containers keep serving in-flight requests
+export GOFORMS_HOST_PORTS="${GOFORMS_HOST_PORTS:-8090-8099}"
+
+# Colors for output
+RED='\033[0;31m'
+GREEN='\033[0;32m'
+YELLOW='\033[1;33m'
+NC='\033[0m' # No Color
+
+# Logging function
+log() {
+    echo -e "${GREEN}[$(date +'%Y-%m-%d %H:%M:%S')]${NC} $1"
+}
+
+warn() {
+    echo -e "${YELLOW}[$(date +'%Y-%m-%d %H:%M:%S')] WARNING:${NC} $1"
+}
+
+error() {
+    echo -e "${RED}[$(date +'%Y-%m-%d %H:%M:%S')] ERROR:${NC} $1"
+    exit 1
+}
+
+compose() {
+    docker compose -f "$COMPOSE_FILE" "$@"
+}
+
+# Lists the IDs of the service's running containers
+containers() {
+    compose ps -q "$SERVICE" | sort
+}
+
+# Prints the host port a container publishes 8090 on
+host_port() {
+    docker port "$1" 8090/tcp | head -n 1 | sed 's/.*://'
+}
+
+# Waits until a container answers the health endpoint with 200
+wait_healthy() {
+    local id=$1 port deadline
+    port=$(host_port "$id")
+    deadline=$((SECONDS + HEALTH_TIMEOUT))
+
+    while [ $SECONDS -lt $deadline ]; do
+        if [ "$(docker inspect -f '{{.State.Running}}' "$id")" != "true" ]; then
+            warn "Container ${id:0:12} exited"
+            return 1
+        fi
+        if curl -fsS -o /dev/null "http://127.0.0.1:${port}${HEALTH_PATH}"; then
+            return 0
+        fi
+        sleep 2
+    done
+
+    warn "Container ${id:0:12} was not healthy within ${HEALTH_TIMEOUT}s"
+    return 1
+}
+
+# Points the Nginx upstream at the given containers and reloads Nginx
+switch_upstream() {
+    local id
+    {
+        echo "# Written by scripts/deploy-zero-downtime.sh on $(date -u +'%Y-%m-%dT%H:%M:%SZ')"
+        echo "upstream ${UPSTREAM_NAME} {"
+        for id in "$@"; do
+            echo "    server 127.0.0.1:$(host_port "$id");"
+        done
+        echo "}"
+    } | sudo tee "$UPSTREAM_FILE" > /dev/null
+
+    $NGINX_RELOAD
+}
+
+# Checks the public health URL after a switch, when one is configured
+verify_switch() {
+    [ -z "$HEALTH_URL" ] && return 0
+    sleep 2
+    curl -fsS -o /dev/null --max-time 10 "$HEALTH_URL"
+}
+
+# Removes containers
+remove() {
+    [ $# -eq 0 ] && return 0
+    docker stop "$@" > /dev/null
+    docker rm "$@" > /dev/null
+}
+
+# Starts count more containers of the new image and prints their IDs
+scale_up() {
+    local count=$1 before after
+    before=$(containers)
+    compose up -d --no-deps --no-recreate --scale "$SERVICE=$(($(echo "$before" | grep -c .) + count))" "$SERVICE" >&2
+    after=$(containers)
+    comm -13 <(echo "$before") <(echo "$after")
+}
+
+# Restores the old containers after a failed step and exits
+rollback() {
+    local reason=$1
+    warn "$reason; rolling back"
+    # shellcheck disable=SC2086
+    remove $NEW
+    # shellcheck disable=SC2086
+    switch_upstream $OLD
+    if [ "$(echo "$OLD" | wc -w)" -lt "$REPLICAS" ]; then
+        warn "Containers already replaced are gone; run 'task docker:prod-deploy' again to restore ${REPLICAS} replicas"
+    fi
+    error "Deployment failed, ${SERVICE} is back on the previous containers"
+}
+
+case "$STRATEGY" in
+    rolling|blue-green) ;;
+    *) error "Unknown strategy '$STRATEGY'; use rolling or blue-green" ;;
+esac
+
+log "Starting ${STRATEGY} deployment of ${SERVICE}..."
+
+OLD=$(containers | xargs)
+[ -z "$OLD" ] && error "No running ${SERVICE} containers; start the stack with 'task docker:prod-up' first"
+REPLICAS=$(echo "$OLD" | wc -w)
+NEW=""
+
+log "Pulling the new image..."
+compose pull "$SERVICE"
+
+if [ "$STRATEGY" = "blue-green" ]; then
+    log "Starting ${REPLICAS} new container(s)..."
+    NEW=$(scale_up "$REPLICAS" | xargs)
+
+    for id in $NEW; do
+        wait_healthy "$id" || rollback "New container ${id:0:12} is not healthy"
+    done
+
+    log "Switching traffic to the new containers..."
+    # shellcheck disable=SC2086
+    switch_upstream $NEW
+    verify_switch || rollback "${HEALTH_URL} failed after the switch"
+
+    sleep "$DRAIN_SECONDS"
+    # shellcheck disable=SC2086
+    remove $OLD
+else
+    SERVING="$OLD"
+
+    for old in $OLD; do
+        log "Replacing container ${old:0:12}..."
+        id=$(scale_up 1)
+        NEW="$NEW $id"
+
+        wait_healthy "$id" || rollback "New container ${id:0:12} is not healthy"
+
+        SERVING="$(echo "$SERVING" | tr ' ' '\n' | grep -v "^${old}$" | xargs || true) $id"
+        # shellcheck disable=SC2086
+        switch_upstream $SERVING
+        verify_switch || rollback "${HEALTH_URL} failed after the switch"
+
+        sleep "$DRAIN_SECONDS"
+        remove "$old"
+        # The replaced container is gone and cannot be rolled back to
+        OLD=$(echo "$OLD" | tr ' ' '\n' | grep -v "^${old}$" | xargs || true)
+    done
+fi
+
+log "✅ ${SERVICE} deployed with the ${STRATEGY} strategy"
+compose ps "$SERVICE"
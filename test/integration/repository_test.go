@@ -0,0 +1,205 @@
+//go:build integration
+
+package integration_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	domainform "github.com/goformx/goforms/internal/domain/form"
+	"github.com/goformx/goforms/internal/domain/form/model"
+	"github.com/goformx/goforms/internal/domain/metering"
+	"github.com/goformx/goforms/internal/infrastructure/cache"
+	"github.com/goformx/goforms/internal/infrastructure/repository/common"
+	usagestore "github.com/goformx/goforms/internal/infrastructure/repository/form/usage"
+	meteringstore "github.com/goformx/goforms/internal/infrastructure/repository/metering"
+	"github.com/goformx/goforms/internal/testsupport"
+)
+
+func TestRepositories(t *testing.T) {
+	databases := map[string]func(testing.TB) *testsupport.Database{
+		"postgres": testsupport.StartPostgres,
+		"mariadb":  testsupport.StartMariaDB,
+	}
+
+	for name, start := range databases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			db := start(t)
+			fixtures := testsupport.NewFixtures(t, db)
+			ctx := t.Context()
+
+			owner := fixtures.User()
+
+			loadedUser, err := fixtures.Users.GetByEmail(ctx, owner.Email)
+			require.NoError(t, err)
+			assert.True(t, loadedUser.CheckPassword(testsupport.FixturePassword))
+
+			formModel := fixtures.Form(owner)
+
+			loaded, err := fixtures.Forms.GetFormByID(ctx, formModel.ID)
+			require.NoError(t, err)
+			assert.Equal(t, formModel.Title, loaded.Title)
+			assert.Equal(t, "published", loaded.Status)
+
+			// A stale version is rejected after a successful update
+			loaded.Title = "Renamed"
+			require.NoError(t, fixtures.Forms.UpdateForm(ctx, loaded))
+
+			stale := formModel.Clone()
+			stale.Title = "Stale"
+			require.ErrorIs(t, fixtures.Forms.UpdateForm(ctx, stale), model.ErrFormVersionConflict)
+
+			for range 3 {
+				fixtures.Submission(formModel)
+			}
+
+			page, err := fixtures.Forms.GetByFormIDPaginated(ctx, formModel.ID, common.PaginationParams{Page: 1, PageSize: 2})
+			require.NoError(t, err)
+			assert.Equal(t, 3, page.TotalItems)
+			assert.Equal(t, 2, page.TotalPages)
+
+			usage := usagestore.NewStore(db, db.Logger)
+
+			count, err := usage.CountSubmissionsByUserSince(ctx, owner.ID, time.Now().Add(-time.Hour))
+			require.NoError(t, err)
+			assert.Equal(t, int64(3), count)
+
+			// Flushed usage counts add up per account, metric and day
+			metered := meteringstore.NewStore(db, db.Logger)
+			day := time.Date(2026, time.March, 2, 0, 0, 0, 0, time.UTC)
+			account := metering.UserAccount(owner.ID)
+
+			for range 2 {
+				require.NoError(t, metered.AddCounts(ctx, []*metering.DailyCount{
+					{Account: account, Metric: metering.MetricAPICalls, Day: day, Count: 5},
+				}))
+			}
+
+			counts, err := metered.ListCounts(ctx, account, day, day)
+			require.NoError(t, err)
+			require.Len(t, counts, 1)
+			assert.Equal(t, int64(10), counts[0].Count)
+
+			alert := &metering.Alert{Account: account, Metric: metering.MetricAPICalls, PeriodStart: day, Threshold: 80, Used: 10, Limit: 12}
+
+			created, err := metered.RecordAlert(ctx, alert)
+			require.NoError(t, err)
+			assert.True(t, created)
+
+			created, err = metered.RecordAlert(ctx, alert)
+			require.NoError(t, err)
+			assert.False(t, created)
+
+			require.NoError(t, fixtures.Forms.DeleteForm(ctx, formModel.ID))
+
+			_, err = fixtures.Forms.GetFormByID(ctx, formModel.ID)
+			require.ErrorIs(t, err, common.ErrNotFound)
+		})
+	}
+}
+
+func TestMongoDBRepositories(t *testing.T) {
+	server := testsupport.StartMongoDB(t)
+	ctx := t.Context()
+
+	forms := server.Forms()
+
+	formModel := model.NewForm("user-1", "Contact", "", model.JSON{
+		"components": []any{map[string]any{"key": "email", "type": "email", "validate": map[string]any{"required": true}}},
+	})
+	require.NoError(t, forms.CreateForm(ctx, formModel))
+
+	loaded, err := forms.GetFormByID(ctx, formModel.ID)
+	require.NoError(t, err)
+	assert.Equal(t, formModel.Schema, loaded.Schema, "nested documents decode as JSON values")
+
+	// A stale version is rejected after a successful update
+	loaded.Title = "Renamed"
+	require.NoError(t, forms.UpdateForm(ctx, loaded))
+
+	stale := formModel.Clone()
+	stale.Title = "Stale"
+	require.ErrorIs(t, forms.UpdateForm(ctx, stale), model.ErrFormVersionConflict)
+
+	listed, err := forms.ListForms(ctx, "user-1")
+	require.NoError(t, err)
+	require.Len(t, listed, 1)
+	assert.Equal(t, "Renamed", listed[0].Title)
+	assert.Empty(t, listed[0].Description, "zero fields are left out of updates")
+
+	since := time.Now().Add(-time.Hour)
+
+	for i := range 3 {
+		require.NoError(t, forms.CreateSubmission(ctx, &model.FormSubmission{
+			FormID:      formModel.ID,
+			Data:        model.JSON{"email": "ada@example.com", "n": float64(i)},
+			SubmittedAt: time.Now(),
+			Status:      model.SubmissionStatusPending,
+			Test:        i == 2,
+		}))
+	}
+
+	page, err := forms.GetByFormIDPaginated(ctx, formModel.ID, common.PaginationParams{Page: 1, PageSize: 2})
+	require.NoError(t, err)
+	assert.Equal(t, 3, page.TotalItems)
+	assert.Equal(t, 2, page.TotalPages)
+
+	between, err := forms.(domainform.SubmissionRangeRepository).ListSubmissionsBetween(ctx, formModel.ID, since, time.Now())
+	require.NoError(t, err)
+	require.Len(t, between, 3)
+	assert.Equal(t, float64(0), between[0].Data["n"])
+
+	count, err := server.Usage().CountSubmissionsByUserSince(ctx, "user-1", since)
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), count, "test submissions are not counted")
+
+	purged, err := forms.(domainform.TestSubmissionRepository).DeleteTestSubmissionsBefore(ctx, time.Now())
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), purged)
+
+	revisions := forms.(domainform.SchemaRevisionRepository)
+	for seq := range int64(3) {
+		require.NoError(t, revisions.CreateSchemaRevision(ctx, &model.SchemaRevision{
+			FormID: formModel.ID, Stack: model.SchemaStackUndo, Schema: model.JSON{"seq": float64(seq)}, Seq: seq,
+		}))
+	}
+
+	require.NoError(t, revisions.TrimSchemaRevisions(ctx, formModel.ID, model.SchemaStackUndo, 2))
+
+	latest, err := revisions.LatestSchemaRevision(ctx, formModel.ID, model.SchemaStackUndo)
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), latest.Seq)
+
+	remaining, err := revisions.CountSchemaRevisions(ctx, formModel.ID, model.SchemaStackUndo)
+	require.NoError(t, err)
+	assert.Equal(t, 2, remaining)
+
+	dataset, err := server.Backups().Dump(ctx)
+	require.NoError(t, err)
+	assert.Len(t, dataset.Forms, 1)
+	assert.Len(t, dataset.Submissions, 2)
+
+	require.NoError(t, forms.DeleteForm(ctx, formModel.ID))
+
+	_, err = forms.GetFormByID(ctx, formModel.ID)
+	require.ErrorIs(t, err, common.ErrNotFound)
+}
+
+func TestRedisCache(t *testing.T) {
+	server := testsupport.StartRedis(t)
+	ctx := t.Context()
+
+	c := cache.NewRedisCache(server.Client)
+
+	require.NoError(t, c.Set(ctx, "greeting", []byte("hello"), time.Minute))
+
+	value, found, err := c.Get(ctx, "greeting")
+	require.NoError(t, err)
+	assert.True(t, found)
+	assert.Equal(t, []byte("hello"), value)
+}